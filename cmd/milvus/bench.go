@@ -0,0 +1,40 @@
+package milvus
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/milvus-io/milvus/internal/bench"
+)
+
+// runBench runs the workload generator until the configured duration elapses or it's interrupted.
+func runBench(args []string, flags *flag.FlagSet) {
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, benchLine)
+		flags.PrintDefaults()
+	}
+	config := bench.DefaultConfig()
+	config.BindFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		os.Exit(-1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	runner, err := bench.NewRunner(ctx, config, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start bench: %v\n", err)
+		os.Exit(-1)
+	}
+	defer runner.Close()
+
+	if err := runner.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "bench failed: %v\n", err)
+		os.Exit(-1)
+	}
+}
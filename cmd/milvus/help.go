@@ -9,7 +9,8 @@ import (
 
 var (
 	usageLine = fmt.Sprintf("Usage:\n"+
-		"%s\n%s\n%s\n%s\n", runLine, stopLine, mckLine, serverTypeLine)
+		"%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n", runLine, benchLine, storageBenchLine, storageGatewayLine, stopLine, mckLine, migrateStorageLine,
+		rotateKeysLine, mountLine, segmentLine, serverTypeLine)
 
 	serverTypeLine = `
 [server type]
@@ -31,6 +32,50 @@ milvus run [server type] [flags]
 		Start the datacoord server.
 	-alias ''
 		Set alias
+`
+	benchLine = `
+milvus run bench [flags]
+	Generate insert/search workloads against a Milvus cluster for capacity testing.
+	Tips: Run 'milvus run bench -h' to see all flags.
+[flags]
+	-address 'localhost:19530'
+		Address of the proxy.
+	-distribution 'uniform'
+		Distribution of the vectors: uniform, normal or clustered.
+	-insertQPS '0'
+		Insert requests per second during the run.
+	-searchQPSStart '10' -searchQPS '100' -ramp '1m'
+		Ramp the search requests per second up in the duration.
+	-selectivity '1'
+		Fraction of the rows matching the search filter.
+	-duration '5m'
+		Duration of the run.
+	-csv ''
+		File to export the latency statistics in csv.
+`
+	storageBenchLine = `
+milvus run storage-bench [flags]
+	Benchmark the object storage configured in milvus.yaml to validate its sizing.
+	Tips: Run 'milvus run storage-bench -h' to see all flags.
+[flags]
+	-sizes '1MB'
+		Comma separated sizes of the objects, e.g. 64KB,1MB,16MB.
+	-objects '100'
+		Number of objects written before the run to be read.
+	-parallelism '16'
+		Number of requests in flight.
+	-readRatio '0.8'
+		Fraction of the requests which are reads, the others are writes.
+	-duration '1m'
+		Duration of the run.
+	-csv ''
+		File to export the latency statistics in csv.
+`
+	storageGatewayLine = `
+milvus run storage-gateway
+	Serve the storage configured in milvus.yaml to the nodes with the remote storage type,
+	which can't access the storage directly. The gateway listens on storageGateway.listenAddress and
+	storageGateway.port, authenticates the nodes by storageGateway.token, and serves TLS by common.security.tlsMode.
 `
 	stopLine = `
 milvus stop [server type] [flags]
@@ -62,5 +107,80 @@ milvus mck run [flags]
 milvus mck cleanTrash [flags]
 	Clean the back inconsistent data
 	Tips: The flags is the same as its of the 'milvus mck [flags]'
+`
+	migrateStorageLine = `
+milvus migrate-storage [flags]
+	Copy all objects from one storage to another for cluster relocation.
+	Tips: Run 'milvus migrate-storage -h' to see all flags.
+[flags]
+	-srcStorage 'minio' -dstStorage 'minio'
+		Storage type of the source and the target: local or minio.
+	-srcAddress -srcBucketName -srcRootPath ...
+		Connection of the source, the target is configured by the same flags prefixed with 'dst'.
+	-workers '8'
+		Number of objects migrated concurrently.
+	-verify 'true'
+		Verify the migrated objects by checksum.
+	-dryRun 'false'
+		Only count the objects to migrate.
+	-state 'migrate-storage.state'
+		File to save the progress, rerun with the same file to resume.
+`
+	rotateKeysLine = `
+milvus rotate-keys [flags]
+	Move the encrypted objects in the storage configured in milvus.yaml to a new master key in place,
+	the objects not encrypted are skipped.
+	Tips: Run 'milvus rotate-keys -h' to see all flags.
+[flags]
+	-keyFile ''
+		JSON file mapping the master key ids to the base64-encoded keys, both the old and the new ones.
+	-newKeyID ''
+		Id of the master key to rotate to.
+	-reencrypt 'false'
+		Encrypt the contents again with new data keys instead of only wrapping the data keys again.
+	-prefix ''
+		Directory to rotate, the root path by default.
+	-workers '8'
+		Number of objects rotated concurrently.
+	-state 'rotate-keys.state'
+		File to save the progress, rerun with the same file to resume.
+`
+	mountLine = `
+milvus mount [flags] <mount point>
+	Mount the storage configured in milvus.yaml read-only by FUSE, to inspect the binlogs by the standard tools
+	or read the exported data in place. Interrupt it to unmount.
+[flags]
+	-prefix ''
+		Directory to mount, the root path by default.
+	-allowOther 'false'
+		Allow the other users to access the mount, which needs user_allow_other in /etc/fuse.conf.
+	-cacheTTL '10s'
+		How long the kernel caches the directories and attributes.
+`
+	segmentLine = `
+milvus segment [mark-bad | unmark | list-bad | reload | re-replicate | check] [flags]
+	Operate on segments through the querycoord, e.g. to stop serving a corrupted segment.
+	mark-bad: release the segment from all replicas and keep it from being loaded, until unmarked.
+	reload: release the segment from the node and load it again.
+	re-replicate: move the segment from the node to another node of the same replica.
+	check: report the binlogs missing or with a wrong size in the storage and the orphan objects as json,
+		checked by the datacoord.
+[flags]
+	-address 'localhost:9091'
+		Address of the querycoord management http server.
+	-collection -segment
+		Collection and segment to operate on.
+	-node
+		Querynode the segment is loaded on, for reload and re-replicate.
+	-target
+		Querynode to move the segment to, chosen by the balancer by default.
+	-reason ''
+		Why the segment is marked bad.
+	-datacoord 'localhost:9091'
+		Address of the datacoord management http server, for check.
+	-limit 1000
+		Max number of the inconsistent files and orphans listed by check.
+	-quarantine 'false'
+		Mark the inconsistent segments bad after check.
 `
 )
@@ -0,0 +1,125 @@
+package milvus
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+const (
+	MigrateStorageCmd = "migrate-storage"
+)
+
+// storageFlags are the flags to connect one side of a storage migration.
+type storageFlags struct {
+	storageType   string
+	rootPath      string
+	address       string
+	accessKeyID   string
+	secretKey     string
+	useSSL        bool
+	bucketName    string
+	useIAM        bool
+	cloudProvider string
+	iamEndpoint   string
+	prefix        string
+}
+
+func (f *storageFlags) bindFlags(flags *flag.FlagSet, side string) {
+	flags.StringVar(&f.storageType, side+"Storage", "minio", "storage type of the "+side+": local or minio")
+	flags.StringVar(&f.rootPath, side+"RootPath", "files", "root path of the "+side)
+	flags.StringVar(&f.address, side+"Address", "localhost:9000", "address of the "+side+" object storage")
+	flags.StringVar(&f.accessKeyID, side+"AccessKeyID", "", "access key of the "+side+" object storage")
+	flags.StringVar(&f.secretKey, side+"SecretAccessKey", "", "secret key of the "+side+" object storage")
+	flags.BoolVar(&f.useSSL, side+"UseSSL", false, "whether to use ssl to connect the "+side+" object storage")
+	flags.StringVar(&f.bucketName, side+"BucketName", "a-bucket", "bucket of the "+side+" object storage")
+	flags.BoolVar(&f.useIAM, side+"UseIAM", false, "whether to use IAM to connect the "+side+" object storage")
+	flags.StringVar(&f.cloudProvider, side+"CloudProvider", "aws", "cloud provider of the "+side+" object storage")
+	flags.StringVar(&f.iamEndpoint, side+"IAMEndpoint", "", "IAM endpoint of the "+side+" object storage")
+	flags.StringVar(&f.prefix, side+"Prefix", "", "directory to migrate of the "+side+", the whole root path by default")
+}
+
+func (f *storageFlags) newChunkManager(ctx context.Context) (storage.ChunkManager, error) {
+	return storage.NewChunkManagerFactory(f.storageType,
+		storage.RootPath(f.rootPath),
+		storage.Address(f.address),
+		storage.AccessKeyID(f.accessKeyID),
+		storage.SecretAccessKeyID(f.secretKey),
+		storage.UseSSL(f.useSSL),
+		storage.BucketName(f.bucketName),
+		storage.UseIAM(f.useIAM),
+		storage.CloudProvider(f.cloudProvider),
+		storage.IAMEndpoint(f.iamEndpoint),
+		storage.CreateBucket(true)).NewPersistentStorageChunkManager(ctx)
+}
+
+// getPrefix returns the prefix to migrate, object storage keys include the root path while local keys don't.
+func (f *storageFlags) getPrefix() string {
+	if f.prefix != "" || f.storageType == "local" {
+		return f.prefix
+	}
+	return f.rootPath
+}
+
+type migrateStorage struct {
+	src       storageFlags
+	dst       storageFlags
+	workers   int
+	verify    bool
+	dryRun    bool
+	statePath string
+}
+
+func (c *migrateStorage) execute(args []string, flags *flag.FlagSet) {
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, migrateStorageLine)
+		flags.PrintDefaults()
+	}
+	c.src.bindFlags(flags, "src")
+	c.dst.bindFlags(flags, "dst")
+	flags.IntVar(&c.workers, "workers", storage.DefaultMigrateWorkers, "number of objects migrated concurrently")
+	flags.BoolVar(&c.verify, "verify", true, "whether to verify the migrated objects by checksum")
+	flags.BoolVar(&c.dryRun, "dryRun", false, "only count the objects to migrate")
+	flags.StringVar(&c.statePath, "state", "migrate-storage.state", "file to save the progress, used to resume the migration")
+	if err := flags.Parse(args[2:]); err != nil {
+		os.Exit(-1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	src, err := c.src.newChunkManager(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect the source storage: %v\n", err)
+		os.Exit(-1)
+	}
+	dst, err := c.dst.newChunkManager(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect the target storage: %v\n", err)
+		os.Exit(-1)
+	}
+
+	migrator := storage.NewMigrator(src, dst,
+		storage.WithMigrateWorkers(c.workers),
+		storage.WithMigrateVerify(c.verify),
+		storage.WithMigrateDryRun(c.dryRun),
+		storage.WithMigrateStateFile(c.statePath))
+	state, err := migrator.Migrate(ctx, c.src.getPrefix(), c.dst.getPrefix())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate storage failed: %v\n", err)
+		if state != nil && !c.dryRun {
+			fmt.Fprintf(os.Stderr, "progress is saved in %s at %s, rerun the command to resume\n", c.statePath, state.Checkpoint)
+		}
+		os.Exit(-1)
+	}
+	if c.dryRun {
+		fmt.Fprintf(os.Stdout, "%d objects (%d bytes) to migrate\n", state.Objects, state.Bytes)
+		return
+	}
+	fmt.Fprintf(os.Stdout, "%d objects (%d bytes) migrated\n", state.Objects, state.Bytes)
+}
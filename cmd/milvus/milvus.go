@@ -53,6 +53,14 @@ func RunMilvus(args []string) {
 		c = &dryRun{}
 	case MckCmd:
 		c = &mck{}
+	case MigrateStorageCmd:
+		c = &migrateStorage{}
+	case RotateKeysCmd:
+		c = &rotateKeys{}
+	case SegmentCmd:
+		c = &segment{}
+	case MountCmd:
+		c = &mount{}
 	default:
 		c = &defaultCommand{}
 	}
@@ -0,0 +1,80 @@
+package milvus
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/storage/chunkfs"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+const (
+	MountCmd = "mount"
+)
+
+type mount struct {
+	prefix     string
+	allowOther bool
+	cacheTTL   time.Duration
+	debug      bool
+}
+
+// execute mounts the storage configured in milvus.yaml read-only until it's interrupted.
+func (c *mount) execute(args []string, flags *flag.FlagSet) {
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, mountLine)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&c.prefix, "prefix", "", "directory to mount, the root path by default")
+	flags.BoolVar(&c.allowOther, "allowOther", false, "allow the other users to access the mount, which needs user_allow_other in /etc/fuse.conf")
+	flags.DurationVar(&c.cacheTTL, "cacheTTL", 10*time.Second, "how long the kernel caches the directories and attributes")
+	flags.BoolVar(&c.debug, "debug", false, "log the FUSE requests")
+	if err := flags.Parse(args[2:]); err != nil {
+		os.Exit(-1)
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(-1)
+	}
+	mountPoint := flags.Arg(0)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	paramtable.Init()
+	params := paramtable.Get()
+	cm, err := storage.NewChunkManagerFactoryWithParam(params).NewPersistentStorageChunkManager(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect the storage: %v\n", err)
+		os.Exit(-1)
+	}
+	// object storage keys include the root path while local keys don't
+	prefix := c.prefix
+	if storageType := params.CommonCfg.StorageType; prefix == "" && storageType != "local" && storageType != "rocksdb" {
+		prefix = cm.RootPath()
+	}
+
+	server, err := chunkfs.Mount(chunkfs.New(cm, prefix), mountPoint, chunkfs.MountOptions{
+		AllowOther: c.allowOther,
+		CacheTTL:   c.cacheTTL,
+		Debug:      c.debug,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to mount %s: %v\n", mountPoint, err)
+		os.Exit(-1)
+	}
+	fmt.Fprintf(os.Stdout, "storage mounted on %s read-only, interrupt to unmount\n", mountPoint)
+	go func() {
+		<-ctx.Done()
+		if err := server.Unmount(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to unmount %s: %v, unmount it by fusermount -u\n", mountPoint, err)
+		}
+	}()
+	server.Wait()
+}
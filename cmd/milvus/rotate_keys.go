@@ -0,0 +1,93 @@
+package milvus
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+const (
+	RotateKeysCmd = "rotate-keys"
+)
+
+type rotateKeys struct {
+	prefix    string
+	keyFile   string
+	newKeyID  string
+	reencrypt bool
+	workers   int
+	rate      float64
+	bandwidth int64
+	statePath string
+}
+
+// execute moves the objects encrypted by storage.EncryptObject in the storage configured in milvus.yaml
+// to a new master key in place. The chunk managers don't encrypt the objects they write, so the objects
+// not encrypted are left as they are.
+func (c *rotateKeys) execute(args []string, flags *flag.FlagSet) {
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, rotateKeysLine)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&c.prefix, "prefix", "", "directory to rotate, the root path by default")
+	flags.StringVar(&c.keyFile, "keyFile", "", "JSON file mapping the master key ids to the base64-encoded keys, both the old and the new ones")
+	flags.StringVar(&c.newKeyID, "newKeyID", "", "id of the master key to rotate to")
+	flags.BoolVar(&c.reencrypt, "reencrypt", false, "encrypt the contents again with new data keys instead of only wrapping the data keys again")
+	flags.IntVar(&c.workers, "workers", storage.DefaultMigrateWorkers, "number of objects rotated concurrently")
+	flags.Float64Var(&c.rate, "rate", 0, "max objects rotated per second, 0 means unlimited")
+	flags.Int64Var(&c.bandwidth, "bandwidth", 0, "max bytes read per second, 0 means unlimited")
+	flags.StringVar(&c.statePath, "state", "rotate-keys.state", "file to save the progress, used to resume the rotation")
+	if err := flags.Parse(args[2:]); err != nil {
+		os.Exit(-1)
+	}
+	if c.keyFile == "" || c.newKeyID == "" {
+		flags.Usage()
+		os.Exit(-1)
+	}
+
+	keys, err := storage.NewStaticKeyProviderFromFile(c.keyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load the master keys: %v\n", err)
+		os.Exit(-1)
+	}
+	if !keys.HasKey(c.newKeyID) {
+		fmt.Fprintf(os.Stderr, "master key %s is not in %s\n", c.newKeyID, c.keyFile)
+		os.Exit(-1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	paramtable.Init()
+	params := paramtable.Get()
+	cm, err := storage.NewChunkManagerFactoryWithParam(params).NewPersistentStorageChunkManager(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect the storage: %v\n", err)
+		os.Exit(-1)
+	}
+	// object storage keys include the root path while local keys don't
+	prefix := c.prefix
+	if storageType := params.CommonCfg.StorageType; prefix == "" && storageType != "local" && storageType != "rocksdb" {
+		prefix = cm.RootPath()
+	}
+
+	state, err := storage.RotateKeys(ctx, cm, prefix, keys, c.newKeyID, c.reencrypt,
+		storage.WithMigrateWorkers(c.workers),
+		storage.WithMigrateRate(c.rate),
+		storage.WithMigrateBandwidth(c.bandwidth),
+		storage.WithMigrateStateFile(c.statePath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rotate keys failed: %v\n", err)
+		if state != nil {
+			fmt.Fprintf(os.Stderr, "progress is saved in %s at %s, rerun the command to resume\n", c.statePath, state.Checkpoint)
+		}
+		os.Exit(-1)
+	}
+	fmt.Fprintf(os.Stdout, "%d objects rotated to %s, %d objects skipped\n", state.Objects-state.Skipped, c.newKeyID, state.Skipped)
+}
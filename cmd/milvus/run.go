@@ -48,6 +48,18 @@ func (c *run) execute(args []string, flags *flag.FlagSet) {
 		fmt.Fprintln(os.Stderr, c.getHelp())
 	}
 	c.serverType = args[2]
+	if c.serverType == typeutil.BenchRole {
+		runBench(args[3:], flags)
+		return
+	}
+	if c.serverType == typeutil.StorageBenchRole {
+		runStorageBench(args[3:], flags)
+		return
+	}
+	if c.serverType == typeutil.StorageGatewayRole {
+		runStorageGateway(args[3:], flags)
+		return
+	}
 	c.formatFlags(args, flags)
 
 	var local = false
@@ -0,0 +1,174 @@
+package milvus
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/datacoord"
+	"github.com/milvus-io/milvus/internal/management"
+	"github.com/milvus-io/milvus/internal/querycoordv2"
+)
+
+const (
+	SegmentCmd = "segment"
+
+	segmentMarkBadCmd     = "mark-bad"
+	segmentUnmarkCmd      = "unmark"
+	segmentListBadCmd     = "list-bad"
+	segmentReloadCmd      = "reload"
+	segmentReplicateCmd   = "re-replicate"
+	segmentCheckCmd       = "check"
+	segmentRequestTimeout = 10 * time.Minute
+)
+
+// segment operates on segments through the management http server of the querycoord,
+// and checks segments through the one of the datacoord.
+type segment struct {
+	address    string
+	datacoord  string
+	collection int64
+	segment    int64
+	node       int64
+	target     int64
+	reason     string
+	limit      int
+	quarantine bool
+}
+
+func (c *segment) execute(args []string, flags *flag.FlagSet) {
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, segmentLine)
+		flags.PrintDefaults()
+	}
+	if len(args) < 3 {
+		flags.Usage()
+		os.Exit(-1)
+	}
+	flags.StringVar(&c.address, "address", "localhost:"+management.DefaultListenPort, "address of the querycoord management http server")
+	flags.Int64Var(&c.collection, "collection", -1, "collection of the segment")
+	flags.Int64Var(&c.segment, "segment", -1, "segment to operate on")
+	flags.Int64Var(&c.node, "node", -1, "querynode the segment is loaded on")
+	flags.Int64Var(&c.target, "target", -1, "querynode to move the segment to, chosen by the balancer by default")
+	flags.StringVar(&c.reason, "reason", "", "why the segment is marked bad")
+	flags.StringVar(&c.datacoord, "datacoord", "localhost:"+management.DefaultListenPort, "address of the datacoord management http server")
+	flags.IntVar(&c.limit, "limit", 1000, "max number of the inconsistent files and orphans listed by check")
+	flags.BoolVar(&c.quarantine, "quarantine", false, "mark the inconsistent segments bad after check")
+	if err := flags.Parse(args[3:]); err != nil {
+		os.Exit(-1)
+	}
+
+	var (
+		method string
+		path   string
+		query  = url.Values{}
+	)
+	switch args[2] {
+	case segmentListBadCmd:
+		method, path = http.MethodGet, querycoordv2.BadSegmentRouterPath
+	case segmentMarkBadCmd:
+		method, path = http.MethodPost, querycoordv2.BadSegmentRouterPath
+		query.Set("collectionID", strconv.FormatInt(c.collection, 10))
+		query.Set("segmentID", strconv.FormatInt(c.segment, 10))
+		query.Set("reason", c.reason)
+	case segmentUnmarkCmd:
+		method, path = http.MethodDelete, querycoordv2.BadSegmentRouterPath
+		query.Set("segmentID", strconv.FormatInt(c.segment, 10))
+	case segmentReloadCmd:
+		method, path = http.MethodPost, querycoordv2.ReloadSegmentRouterPath
+		query.Set("collectionID", strconv.FormatInt(c.collection, 10))
+		query.Set("segmentID", strconv.FormatInt(c.segment, 10))
+		query.Set("nodeID", strconv.FormatInt(c.node, 10))
+	case segmentReplicateCmd:
+		method, path = http.MethodPost, querycoordv2.ReplicateSegmentRouterPath
+		query.Set("collectionID", strconv.FormatInt(c.collection, 10))
+		query.Set("segmentID", strconv.FormatInt(c.segment, 10))
+		query.Set("sourceNodeID", strconv.FormatInt(c.node, 10))
+		if c.target >= 0 {
+			query.Set("targetNodeID", strconv.FormatInt(c.target, 10))
+		}
+	case segmentCheckCmd:
+		c.check()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown segment command : %s\n", args[2])
+		flags.Usage()
+		os.Exit(-1)
+	}
+
+	body, err := managementRequest(c.address, method, path, query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s failed: %v\n", SegmentCmd, args[2], err)
+		os.Exit(-1)
+	}
+	if len(body) > 0 {
+		fmt.Fprintln(os.Stdout, string(body))
+		return
+	}
+	fmt.Fprintf(os.Stdout, "%s %s done\n", SegmentCmd, args[2])
+}
+
+// check prints the consistency report of the segment meta and the storage,
+// and marks the inconsistent segments bad if quarantine is set.
+func (c *segment) check() {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(c.limit))
+	body, err := managementRequest(c.datacoord, http.MethodGet, datacoord.ConsistencyCheckRouterPath, query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s failed: %v\n", SegmentCmd, segmentCheckCmd, err)
+		os.Exit(-1)
+	}
+	fmt.Fprintln(os.Stdout, string(body))
+	if !c.quarantine {
+		return
+	}
+
+	report := &datacoord.ConsistencyReport{}
+	if err := json.Unmarshal(body, report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to parse consistency report: %v\n", err)
+		os.Exit(-1)
+	}
+	failed := 0
+	for _, segment := range report.Segments {
+		query := url.Values{}
+		query.Set("collectionID", strconv.FormatInt(segment.CollectionID, 10))
+		query.Set("segmentID", strconv.FormatInt(segment.SegmentID, 10))
+		query.Set("reason", fmt.Sprintf("inconsistent with storage: %d missing, %d size mismatched",
+			segment.MissingNum, segment.SizeMismatchNum))
+		// the segments of the collections not loaded fail to be marked, they are not served anyway
+		if _, err := managementRequest(c.address, http.MethodPost, querycoordv2.BadSegmentRouterPath, query); err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "failed to quarantine segment %d: %v\n", segment.SegmentID, err)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "segment %d quarantined\n", segment.SegmentID)
+	}
+	if failed > 0 {
+		os.Exit(-1)
+	}
+}
+
+// managementRequest sends the request to the management http server at address, and returns the response body.
+func managementRequest(address string, method string, path string, query url.Values) ([]byte, error) {
+	u := url.URL{Scheme: "http", Host: address, Path: path, RawQuery: query.Encode()}
+	req, err := http.NewRequest(method, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := (&http.Client{Timeout: segmentRequestTimeout}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s", resp.Status, body)
+	}
+	return body, nil
+}
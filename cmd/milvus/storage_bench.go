@@ -0,0 +1,54 @@
+package milvus
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
+
+	"github.com/milvus-io/milvus/internal/bench"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// runStorageBench benchmarks the storage configured in milvus.yaml until the configured duration elapses or it's interrupted.
+func runStorageBench(args []string, flags *flag.FlagSet) {
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, storageBenchLine)
+		flags.PrintDefaults()
+	}
+	config := bench.DefaultStorageConfig()
+	config.BindFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		os.Exit(-1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	paramtable.Init()
+	params := paramtable.Get()
+	cm, err := storage.NewChunkManagerFactoryWithParam(params).NewPersistentStorageChunkManager(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect the storage: %v\n", err)
+		os.Exit(-1)
+	}
+	// object storage keys include the root path while local keys don't
+	prefix := config.Prefix
+	if storageType := params.CommonCfg.StorageType; storageType != "local" && storageType != "rocksdb" {
+		prefix = path.Join(cm.RootPath(), prefix)
+	}
+
+	runner, err := bench.NewStorageRunner(config, cm, prefix, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start storage bench: %v\n", err)
+		os.Exit(-1)
+	}
+	if err := runner.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "storage bench failed: %v\n", err)
+		os.Exit(-1)
+	}
+}
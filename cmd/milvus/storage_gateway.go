@@ -0,0 +1,97 @@
+package milvus
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// runStorageGateway serves the storage configured in milvus.yaml to the nodes with the remote storage type
+// until it's interrupted.
+func runStorageGateway(args []string, flags *flag.FlagSet) {
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, storageGatewayLine)
+	}
+	if err := flags.Parse(args); err != nil {
+		os.Exit(-1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	paramtable.Init()
+	paramtable.SetRole(typeutil.StorageGatewayRole)
+	params := paramtable.Get()
+	if params.CommonCfg.StorageType == "remote" {
+		fmt.Fprintln(os.Stderr, "the storage gateway can't serve the remote storage, set common.storageType to the storage to serve")
+		os.Exit(-1)
+	}
+	cm, err := storage.NewChunkManagerFactoryWithParam(params).NewPersistentStorageChunkManager(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect the storage: %v\n", err)
+		os.Exit(-1)
+	}
+
+	cfg := &params.StorageGatewayCfg
+	address := net.JoinHostPort(cfg.ListenAddress.GetValue(), strconv.Itoa(cfg.Port.GetAsInt()))
+	token := cfg.Token.GetValue()
+	if token == "" && !isLoopbackHost(cfg.ListenAddress.GetValue()) {
+		fmt.Fprintf(os.Stderr, "the storage gateway serves the whole storage, set storageGateway.token to listen on %s\n", address)
+		os.Exit(-1)
+	}
+	tlsConfig, err := storage.GatewayTLSConfig(cfg.TLSMode.GetAsInt(), cfg.ServerPemPath.GetValue(), cfg.ServerKeyPath.GetValue(),
+		cfg.CaPemPath.GetValue(), true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load tls config: %v\n", err)
+		os.Exit(-1)
+	}
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to listen on %s: %v\n", address, err)
+		os.Exit(-1)
+	}
+	maxMessageSize := cfg.MaxMessageSize.GetAsInt() * 1024 * 1024
+	server := storage.NewGatewayGrpcServer(cm, token, tlsConfig,
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             5 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.MaxRecvMsgSize(maxMessageSize),
+		grpc.MaxSendMsgSize(maxMessageSize))
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+	log.Info("storage gateway started", zap.String("address", address), zap.String("storageType", params.CommonCfg.StorageType),
+		zap.Bool("tls", tlsConfig != nil), zap.Bool("token", token != ""))
+	if err := server.Serve(lis); err != nil {
+		fmt.Fprintf(os.Stderr, "storage gateway failed: %v\n", err)
+		os.Exit(-1)
+	}
+	log.Info("storage gateway stopped")
+}
+
+// isLoopbackHost returns whether @host only accepts the connections from the local host.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -22,7 +22,6 @@ import (
 	"os"
 	"os/signal"
 	"strings"
-	"sync"
 	"syscall"
 
 	"github.com/milvus-io/milvus/internal/management"
@@ -56,6 +55,9 @@ func init() {
 	Registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 	Registry.MustRegister(prometheus.NewGoCollector())
 	metrics.RegisterEtcdMetrics(Registry)
+	metrics.RegisterStorageMetrics(Registry)
+	metrics.RegisterLockMetrics(Registry)
+	metrics.RegisterGrpcMetrics(Registry)
 }
 
 func stopRocksmq() {
@@ -69,35 +71,31 @@ type component interface {
 }
 
 func runComponent[T component](ctx context.Context,
+	name string,
 	localMsg bool,
 	extraInit func(),
 	creator func(context.Context, dependency.Factory) (T, error),
-	metricRegister func(*prometheus.Registry)) T {
-	var role T
-	var wg sync.WaitGroup
-
-	wg.Add(1)
-	go func() {
+	metricRegister func(*prometheus.Registry)) *supervisedRole {
+	role := newSupervisedRole(name, supervisorConfigFromParam(paramtable.Get()), func() (component, error) {
 		if extraInit != nil {
 			extraInit()
 		}
 		factory := dependency.NewFactory(localMsg)
-		var err error
-		role, err = creator(ctx, factory)
+		c, err := creator(ctx, factory)
 		if localMsg {
 			paramtable.SetRole(typeutil.StandaloneRole)
 		} else {
-			paramtable.SetRole(role.GetName())
+			paramtable.SetRole(name)
 		}
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-		wg.Done()
-		_ = role.Run()
-	}()
-	wg.Wait()
+		return c, nil
+	})
+	role.run(ctx)
 
 	healthz.Register(role)
+	defaultSupervisor.add(role)
 	metricRegister(Registry)
 	return role
 }
@@ -129,12 +127,12 @@ func (mr *MilvusRoles) printLDPreLoad() {
 	}
 }
 
-func (mr *MilvusRoles) runRootCoord(ctx context.Context, localMsg bool) *components.RootCoord {
-	return runComponent(ctx, localMsg, nil, components.NewRootCoord, metrics.RegisterRootCoord)
+func (mr *MilvusRoles) runRootCoord(ctx context.Context, localMsg bool) *supervisedRole {
+	return runComponent(ctx, typeutil.RootCoordRole, localMsg, nil, components.NewRootCoord, metrics.RegisterRootCoord)
 }
 
-func (mr *MilvusRoles) runProxy(ctx context.Context, localMsg bool, alias string) *components.Proxy {
-	return runComponent(ctx, localMsg,
+func (mr *MilvusRoles) runProxy(ctx context.Context, localMsg bool, alias string) *supervisedRole {
+	return runComponent(ctx, typeutil.ProxyRole, localMsg,
 		func() {
 			proxy.Params.ProxyCfg.InitAlias(alias)
 		},
@@ -142,12 +140,12 @@ func (mr *MilvusRoles) runProxy(ctx context.Context, localMsg bool, alias string
 		metrics.RegisterProxy)
 }
 
-func (mr *MilvusRoles) runQueryCoord(ctx context.Context, localMsg bool) *components.QueryCoord {
-	return runComponent(ctx, localMsg, nil, components.NewQueryCoord, metrics.RegisterQueryCoord)
+func (mr *MilvusRoles) runQueryCoord(ctx context.Context, localMsg bool) *supervisedRole {
+	return runComponent(ctx, typeutil.QueryCoordRole, localMsg, nil, components.NewQueryCoord, metrics.RegisterQueryCoord)
 }
 
-func (mr *MilvusRoles) runQueryNode(ctx context.Context, localMsg bool, alias string) *components.QueryNode {
-	return runComponent(ctx, localMsg,
+func (mr *MilvusRoles) runQueryNode(ctx context.Context, localMsg bool, alias string) *supervisedRole {
+	return runComponent(ctx, typeutil.QueryNodeRole, localMsg,
 		func() {
 			querynode.Params.QueryNodeCfg.InitAlias(alias)
 		},
@@ -155,12 +153,12 @@ func (mr *MilvusRoles) runQueryNode(ctx context.Context, localMsg bool, alias st
 		metrics.RegisterQueryNode)
 }
 
-func (mr *MilvusRoles) runDataCoord(ctx context.Context, localMsg bool) *components.DataCoord {
-	return runComponent(ctx, localMsg, nil, components.NewDataCoord, metrics.RegisterDataCoord)
+func (mr *MilvusRoles) runDataCoord(ctx context.Context, localMsg bool) *supervisedRole {
+	return runComponent(ctx, typeutil.DataCoordRole, localMsg, nil, components.NewDataCoord, metrics.RegisterDataCoord)
 }
 
-func (mr *MilvusRoles) runDataNode(ctx context.Context, localMsg bool, alias string) *components.DataNode {
-	return runComponent(ctx, localMsg,
+func (mr *MilvusRoles) runDataNode(ctx context.Context, localMsg bool, alias string) *supervisedRole {
+	return runComponent(ctx, typeutil.DataNodeRole, localMsg,
 		func() {
 			datanode.Params.DataNodeCfg.InitAlias(alias)
 		},
@@ -168,12 +166,12 @@ func (mr *MilvusRoles) runDataNode(ctx context.Context, localMsg bool, alias str
 		metrics.RegisterDataNode)
 }
 
-func (mr *MilvusRoles) runIndexCoord(ctx context.Context, localMsg bool) *components.IndexCoord {
-	return runComponent(ctx, localMsg, nil, components.NewIndexCoord, metrics.RegisterIndexCoord)
+func (mr *MilvusRoles) runIndexCoord(ctx context.Context, localMsg bool) *supervisedRole {
+	return runComponent(ctx, typeutil.IndexCoordRole, localMsg, nil, components.NewIndexCoord, metrics.RegisterIndexCoord)
 }
 
-func (mr *MilvusRoles) runIndexNode(ctx context.Context, localMsg bool, alias string) *components.IndexNode {
-	return runComponent(ctx, localMsg,
+func (mr *MilvusRoles) runIndexNode(ctx context.Context, localMsg bool, alias string) *supervisedRole {
+	return runComponent(ctx, typeutil.IndexNodeRole, localMsg,
 		func() {
 			indexnode.Params.IndexNodeCfg.InitAlias(alias)
 		},
@@ -240,74 +238,78 @@ func (mr *MilvusRoles) Run(local bool, alias string) {
 		}
 	}
 
-	var rc *components.RootCoord
+	var rc *supervisedRole
 	if mr.EnableRootCoord {
 		rc = mr.runRootCoord(ctx, local)
 		if rc != nil {
-			defer rc.Stop()
+			defer rc.stop()
 		}
 	}
 
-	var pn *components.Proxy
+	var pn *supervisedRole
 	if mr.EnableProxy {
 		pctx := log.WithModule(ctx, "Proxy")
 		pn = mr.runProxy(pctx, local, alias)
 		if pn != nil {
-			defer pn.Stop()
+			defer pn.stop()
 		}
 	}
 
-	var qs *components.QueryCoord
+	var qs *supervisedRole
 	if mr.EnableQueryCoord {
 		qs = mr.runQueryCoord(ctx, local)
 		if qs != nil {
-			defer qs.Stop()
+			defer qs.stop()
 		}
 	}
 
-	var qn *components.QueryNode
+	var qn *supervisedRole
 	if mr.EnableQueryNode {
 		qn = mr.runQueryNode(ctx, local, alias)
 		if qn != nil {
-			defer qn.Stop()
+			defer qn.stop()
 		}
 	}
 
-	var ds *components.DataCoord
+	var ds *supervisedRole
 	if mr.EnableDataCoord {
 		ds = mr.runDataCoord(ctx, local)
 		if ds != nil {
-			defer ds.Stop()
+			defer ds.stop()
 		}
 	}
 
-	var dn *components.DataNode
+	var dn *supervisedRole
 	if mr.EnableDataNode {
 		dn = mr.runDataNode(ctx, local, alias)
 		if dn != nil {
-			defer dn.Stop()
+			defer dn.stop()
 		}
 	}
 
-	var is *components.IndexCoord
+	var is *supervisedRole
 	if mr.EnableIndexCoord {
 		is = mr.runIndexCoord(ctx, local)
 		if is != nil {
-			defer is.Stop()
+			defer is.stop()
 		}
 	}
 
-	var in *components.IndexNode
+	var in *supervisedRole
 	if mr.EnableIndexNode {
 		in = mr.runIndexNode(ctx, local, alias)
 		if in != nil {
-			defer in.Stop()
+			defer in.stop()
 		}
 	}
 
 	mr.setupLogger()
 
 	metrics.Register(Registry)
+	management.Register(&management.HTTPHandler{
+		Path:        RolesRouterPath,
+		HandlerFunc: defaultSupervisor.handleRoles,
+	})
 	management.ServeHTTP()
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc,
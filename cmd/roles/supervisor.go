@@ -0,0 +1,394 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// RolesRouterPath lists the roles running in the process with their uptime and restart counts.
+const RolesRouterPath = "/roles"
+
+// Restart policies of the roles running in one process.
+const (
+	// RestartNever lets a role failing to start kill the process, a role whose Run returns an error is left failed.
+	RestartNever = "never"
+	// RestartOnFailure restarts a role failing to start or unhealthy, the process exits once it fails
+	// more than the max restarts in a row.
+	RestartOnFailure = "onFailure"
+)
+
+// maxRestartBackoff caps the backoff doubled per consecutive restart.
+const maxRestartBackoff = time.Minute
+
+// Role states reported by RolesRouterPath.
+const (
+	roleStateStarting   = "starting"
+	roleStateRunning    = "running"
+	roleStateRestarting = "restarting"
+	roleStateFailed     = "failed"
+	roleStateStopped    = "stopped"
+)
+
+type supervisorConfig struct {
+	policy              string
+	maxRestarts         int
+	backoff             time.Duration
+	healthCheckInterval time.Duration
+	unhealthyThreshold  int
+}
+
+func supervisorConfigFromParam(params *paramtable.ComponentParam) supervisorConfig {
+	return supervisorConfig{
+		policy:              params.CommonCfg.RoleRestartPolicy,
+		maxRestarts:         params.CommonCfg.RoleMaxRestarts,
+		backoff:             params.CommonCfg.RoleRestartBackoff,
+		healthCheckInterval: params.CommonCfg.RoleHealthCheckInterval,
+		unhealthyThreshold:  params.CommonCfg.RoleUnhealthyThreshold,
+	}
+}
+
+// RoleStatus is the status of a role reported by RolesRouterPath.
+type RoleStatus struct {
+	Role      string    `json:"role"`
+	State     string    `json:"state"`
+	StartTime time.Time `json:"start_time,omitempty"`
+	// Uptime is the seconds since the role started last time
+	Uptime    int64  `json:"uptime"`
+	Restarts  int    `json:"restarts"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// supervisedRole runs a role in the process, and restarts it by its restart policy.
+// Only the panics of creating and starting the role are recovered, as Go couldn't recover the panics of other
+// goroutines, a role broken by them at runtime is restarted once it's unhealthy for the unhealthy threshold.
+type supervisedRole struct {
+	name   string
+	create func() (component, error)
+	cfg    supervisorConfig
+
+	mu        sync.RWMutex
+	current   component
+	state     string
+	startTime time.Time
+	restarts  int
+	lastError string
+
+	cancel context.CancelFunc
+}
+
+func newSupervisedRole(name string, cfg supervisorConfig, create func() (component, error)) *supervisedRole {
+	return &supervisedRole{
+		name:   name,
+		create: create,
+		cfg:    cfg,
+		state:  roleStateStarting,
+	}
+}
+
+// run creates the role and runs it in the background, as the roles wait for each other while starting.
+// It panics if the role couldn't be created by the policy.
+func (r *supervisedRole) run(ctx context.Context) {
+	if r.cfg.policy != RestartOnFailure {
+		role, err := r.create()
+		if err != nil {
+			panic(err)
+		}
+		r.setCurrent(role)
+		go func() {
+			if err := role.Run(); err != nil {
+				log.Error("role failed to start", zap.String("role", r.name), zap.Error(err))
+				r.setFailed(err)
+				return
+			}
+			r.setRunning(role)
+		}()
+		return
+	}
+
+	ctx, r.cancel = context.WithCancel(ctx)
+	role, err := r.safeCreate()
+	if err == nil {
+		r.setCurrent(role)
+	}
+	go r.supervise(ctx, role, err)
+}
+
+// supervise starts the created @role, or restarts it if it failed to be created with @err,
+// then restarts it whenever it's unhealthy. The process exits if the role couldn't recover.
+func (r *supervisedRole) supervise(ctx context.Context, role component, err error) {
+	for {
+		if err := r.startWithRetry(ctx, role, err); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			panic(err)
+		}
+		if !r.waitUnhealthy(ctx) {
+			return
+		}
+
+		log.Warn("role is unhealthy, restart it", zap.String("role", r.name), zap.Int("checks", r.cfg.unhealthyThreshold))
+		r.mu.Lock()
+		unhealthy := r.current
+		r.current = nil
+		r.state = roleStateRestarting
+		r.restarts++
+		r.lastError = "unhealthy"
+		r.mu.Unlock()
+		if unhealthy != nil {
+			r.safeStop(unhealthy)
+		}
+		role, err = r.safeCreate()
+		if err == nil {
+			r.setCurrent(role)
+		}
+	}
+}
+
+// startWithRetry runs @role created with @err, and creates and runs it again until it succeeds,
+// the backoff is doubled per failure. It fails once the role fails more than the max restarts in a row,
+// or @ctx is done.
+func (r *supervisedRole) startWithRetry(ctx context.Context, role component, err error) error {
+	backoff := r.cfg.backoff
+	for failures := 0; ; failures++ {
+		if err == nil {
+			if err = runRole(role); err == nil {
+				if !r.setRunning(role) {
+					r.safeStop(role)
+					return errors.New("role is stopped")
+				}
+				return nil
+			}
+			// release the resources of the role started partially before restarting it
+			r.safeStop(role)
+		}
+		r.mu.Lock()
+		r.current = nil
+		r.lastError = err.Error()
+		r.mu.Unlock()
+		if failures >= r.cfg.maxRestarts {
+			return fmt.Errorf("role %s failed %d times in a row: %w", r.name, failures+1, err)
+		}
+		log.Warn("role failed to start, restart it", zap.String("role", r.name),
+			zap.Duration("backoff", backoff), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("role %s failed to start before exiting: %w", r.name, err)
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxRestartBackoff {
+			backoff = maxRestartBackoff
+		}
+		r.mu.Lock()
+		r.restarts++
+		r.state = roleStateRestarting
+		r.mu.Unlock()
+		if role, err = r.safeCreate(); err == nil {
+			r.setCurrent(role)
+		}
+	}
+}
+
+// waitUnhealthy returns true once the role is abnormal for the unhealthy threshold in a row,
+// or false if @ctx is done.
+func (r *supervisedRole) waitUnhealthy(ctx context.Context) bool {
+	ticker := time.NewTicker(r.cfg.healthCheckInterval)
+	defer ticker.Stop()
+	unhealthy := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+		if r.Health(ctx) != commonpb.StateCode_Abnormal {
+			unhealthy = 0
+			continue
+		}
+		if unhealthy++; unhealthy >= r.cfg.unhealthyThreshold {
+			return true
+		}
+	}
+}
+
+// safeCreate creates the role, the panics are returned as errors.
+func (r *supervisedRole) safeCreate() (role component, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Error("role panicked while creating", zap.String("role", r.name),
+				zap.Any("panic", p), zap.ByteString("stack", debug.Stack()))
+			role, err = nil, fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return r.create()
+}
+
+// runRole runs @role, the panics are returned as errors, as the components panic if they fail to start.
+func runRole(role component) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Error("role panicked while starting", zap.String("role", role.GetName()),
+				zap.Any("panic", p), zap.ByteString("stack", debug.Stack()))
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return role.Run()
+}
+
+// safeStop stops the role, the panics are logged.
+func (r *supervisedRole) safeStop(role component) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Error("role panicked while stopping", zap.String("role", r.name), zap.Any("panic", p))
+		}
+	}()
+	if err := role.Stop(); err != nil {
+		log.Warn("failed to stop role", zap.String("role", r.name), zap.Error(err))
+	}
+}
+
+func (r *supervisedRole) setCurrent(role component) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = role
+}
+
+// setRunning returns false if the role is stopped meanwhile.
+func (r *supervisedRole) setRunning(role component) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.state == roleStateStopped {
+		return false
+	}
+	r.current = role
+	r.state = roleStateRunning
+	r.startTime = time.Now()
+	return true
+}
+
+// setFailed records the error of the role failing to start, unless the role is stopped meanwhile.
+func (r *supervisedRole) setFailed(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.state == roleStateStopped {
+		return
+	}
+	r.state = roleStateFailed
+	r.lastError = err.Error()
+}
+
+// stop stops the supervision and the role, the role being restarted is stopped once it's started.
+func (r *supervisedRole) stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.mu.Lock()
+	role := r.current
+	r.current = nil
+	r.state = roleStateStopped
+	r.mu.Unlock()
+	if role != nil {
+		r.safeStop(role)
+	}
+}
+
+// GetName implements healthz.Indicator.
+func (r *supervisedRole) GetName() string {
+	return r.name
+}
+
+// Health implements healthz.Indicator, the role is abnormal while restarting.
+func (r *supervisedRole) Health(ctx context.Context) (code commonpb.StateCode) {
+	r.mu.RLock()
+	role := r.current
+	r.mu.RUnlock()
+	if role == nil {
+		return commonpb.StateCode_Abnormal
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			code = commonpb.StateCode_Abnormal
+		}
+	}()
+	return role.Health(ctx)
+}
+
+func (r *supervisedRole) status() *RoleStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	status := &RoleStatus{
+		Role:      r.name,
+		State:     r.state,
+		Restarts:  r.restarts,
+		LastError: r.lastError,
+	}
+	if r.state == roleStateRunning {
+		status.StartTime = r.startTime
+		status.Uptime = int64(time.Since(r.startTime).Seconds())
+	}
+	return status
+}
+
+// supervisor holds the roles running in the process.
+type supervisor struct {
+	mu    sync.RWMutex
+	roles []*supervisedRole
+}
+
+var defaultSupervisor = &supervisor{}
+
+func (s *supervisor) add(role *supervisedRole) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles = append(s.roles, role)
+}
+
+func (s *supervisor) statuses() []*RoleStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	statuses := make([]*RoleStatus, 0, len(s.roles))
+	for _, role := range s.roles {
+		statuses = append(statuses, role.status())
+	}
+	return statuses
+}
+
+// handleRoles lists the statuses of the roles on GET.
+func (s *supervisor) handleRoles(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.statuses()); err != nil {
+		log.Warn("failed to write http response", zap.Error(err))
+	}
+}
@@ -0,0 +1,178 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockComponent struct {
+	runErr error
+	// failErr is returned by Run instead of panicking
+	failErr error
+	runs    *int32
+	stopped int32
+	healthy int32
+}
+
+func (c *mockComponent) GetName() string { return "mock" }
+
+func (c *mockComponent) Health(ctx context.Context) commonpb.StateCode {
+	if atomic.LoadInt32(&c.healthy) == 0 {
+		return commonpb.StateCode_Abnormal
+	}
+	return commonpb.StateCode_Healthy
+}
+
+func (c *mockComponent) Run() error {
+	atomic.AddInt32(c.runs, 1)
+	if c.runErr != nil {
+		panic(c.runErr)
+	}
+	if c.failErr != nil {
+		return c.failErr
+	}
+	atomic.StoreInt32(&c.healthy, 1)
+	return nil
+}
+
+func (c *mockComponent) Stop() error {
+	atomic.StoreInt32(&c.stopped, 1)
+	return nil
+}
+
+func TestSupervisedRole(t *testing.T) {
+	cfg := supervisorConfig{
+		policy:              RestartOnFailure,
+		maxRestarts:         3,
+		backoff:             time.Millisecond,
+		healthCheckInterval: 10 * time.Millisecond,
+		unhealthyThreshold:  2,
+	}
+
+	t.Run("restart on start failure", func(t *testing.T) {
+		var runs, creates int32
+		var components []*mockComponent
+		role := newSupervisedRole("mock", cfg, func() (component, error) {
+			n := atomic.AddInt32(&creates, 1)
+			if n == 1 {
+				panic("create failed")
+			}
+			c := &mockComponent{runs: &runs}
+			if n == 2 {
+				c.runErr = errors.New("run failed")
+			}
+			components = append(components, c)
+			return c, nil
+		})
+		role.run(context.Background())
+		defer role.stop()
+
+		assert.Eventually(t, func() bool { return role.status().State == roleStateRunning }, time.Second, time.Millisecond)
+		status := role.status()
+		assert.Equal(t, 2, status.Restarts)
+		assert.Equal(t, "panic: run failed", status.LastError)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+		// the role failed to run is stopped
+		assert.Equal(t, int32(1), atomic.LoadInt32(&components[0].stopped))
+		assert.Equal(t, commonpb.StateCode_Healthy, role.Health(context.Background()))
+
+		// restarted once unhealthy
+		atomic.StoreInt32(&components[1].healthy, 0)
+		assert.Eventually(t, func() bool { return role.status().Restarts == 3 && role.status().State == roleStateRunning },
+			time.Second, time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&components[1].stopped))
+		assert.Equal(t, "unhealthy", role.status().LastError)
+
+		role.stop()
+		assert.Equal(t, roleStateStopped, role.status().State)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&components[2].stopped))
+		assert.Equal(t, commonpb.StateCode_Abnormal, role.Health(context.Background()))
+	})
+
+	t.Run("never restart", func(t *testing.T) {
+		never := cfg
+		never.policy = RestartNever
+		role := newSupervisedRole("mock", never, func() (component, error) {
+			return nil, errors.New("create failed")
+		})
+		assert.Panics(t, func() { role.run(context.Background()) })
+
+		var runs int32
+		role = newSupervisedRole("mock", never, func() (component, error) {
+			return &mockComponent{runs: &runs}, nil
+		})
+		role.run(context.Background())
+		assert.Eventually(t, func() bool { return role.status().State == roleStateRunning }, time.Second, time.Millisecond)
+		role.stop()
+
+		role = newSupervisedRole("mock", never, func() (component, error) {
+			return &mockComponent{runs: &runs, failErr: errors.New("run failed")}, nil
+		})
+		role.run(context.Background())
+		assert.Eventually(t, func() bool { return role.status().State == roleStateFailed }, time.Second, time.Millisecond)
+		assert.Equal(t, "run failed", role.status().LastError)
+		assert.Zero(t, role.status().Uptime)
+		role.stop()
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		role := newSupervisedRole("mock", cfg, func() (component, error) {
+			return nil, errors.New("create failed")
+		})
+		err := role.startWithRetry(context.Background(), nil, errors.New("create failed"))
+		assert.Error(t, err)
+		assert.Equal(t, cfg.maxRestarts, role.status().Restarts)
+	})
+}
+
+func TestSupervisorHandleRoles(t *testing.T) {
+	var runs int32
+	s := &supervisor{}
+	role := newSupervisedRole("mock", supervisorConfig{policy: RestartNever}, func() (component, error) {
+		return &mockComponent{runs: &runs}, nil
+	})
+	role.run(context.Background())
+	defer role.stop()
+	s.add(role)
+	require.Eventually(t, func() bool { return role.status().State == roleStateRunning }, time.Second, time.Millisecond)
+
+	w := httptest.NewRecorder()
+	s.handleRoles(w, httptest.NewRequest(http.MethodGet, RolesRouterPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var statuses []*RoleStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &statuses))
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "mock", statuses[0].Role)
+	assert.Equal(t, roleStateRunning, statuses[0].State)
+	assert.Equal(t, 0, statuses[0].Restarts)
+
+	w = httptest.NewRecorder()
+	s.handleRoles(w, httptest.NewRequest(http.MethodPost, RolesRouterPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,56 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func main() {
+	checkZero := flag.Bool("zero", false, "report all-zero vectors as invalid")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Println("usage: vecscan [-zero] path1 path2 ...")
+		fmt.Println("each path can be a binlog file or a directory holding the insert logs of segments")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	cm := storage.NewLocalChunkManager(storage.RootPath(""))
+	total := 0
+	for _, prefix := range flag.Args() {
+		reports, err := storage.ScanInvalidVectorsWithPrefix(ctx, cm, prefix, *checkZero)
+		if err != nil {
+			fmt.Printf("error: %s\n", err.Error())
+			os.Exit(1)
+		}
+		for _, report := range reports {
+			fmt.Printf("segment %d field %d: %d invalid vectors in %s\n",
+				report.SegmentID, report.FieldID, len(report.Offsets), report.Key)
+			for i, offset := range report.Offsets {
+				fmt.Printf("\trow %d: %s\n", offset, report.Reasons[i].String())
+			}
+			total += len(report.Offsets)
+		}
+	}
+	fmt.Printf("scan complete, %d invalid vectors found.\n", total)
+}
@@ -0,0 +1,167 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+)
+
+const (
+	// DistributionUniform generates vectors with components uniformly distributed in [0, 1).
+	DistributionUniform = "uniform"
+	// DistributionNormal generates vectors with components in standard normal distribution.
+	DistributionNormal = "normal"
+	// DistributionClustered generates vectors around a few random centers.
+	DistributionClustered = "clustered"
+)
+
+// Config is the configuration of a benchmark run.
+type Config struct {
+	Address  string
+	User     string
+	Password string
+
+	CollectionName string
+	// Reuse uses the existing collection instead of recreating it
+	Reuse     bool
+	Drop      bool
+	ShardsNum int
+	Dim       int
+
+	// Distribution is the distribution of the generated vectors, see Distribution*
+	Distribution string
+	Clusters     int
+
+	IndexType    string
+	MetricType   string
+	IndexParams  string
+	SearchParams string
+
+	// InitRows are inserted before the collection is loaded
+	InitRows  int
+	BatchSize int
+
+	// InsertQPS is the rate of insert requests during the run, 0 means no insert
+	InsertQPS float64
+	// the search rate ramps from SearchQPSStart to SearchQPS in RampDuration
+	SearchQPSStart float64
+	SearchQPS      float64
+	RampDuration   time.Duration
+	NQ             int
+	TopK           int
+	// FilterSelectivity is the fraction of the rows matching the search filter, 1 means no filter
+	FilterSelectivity float64
+
+	Concurrency    int
+	Duration       time.Duration
+	ReportInterval time.Duration
+	// CSVPath is the file to export the latency statistics, empty means no export
+	CSVPath string
+}
+
+// DefaultConfig returns the default benchmark configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		Address:           "localhost:19530",
+		CollectionName:    "milvus_bench",
+		ShardsNum:         2,
+		Dim:               128,
+		Distribution:      DistributionUniform,
+		Clusters:          16,
+		IndexType:         "IVF_FLAT",
+		MetricType:        "L2",
+		IndexParams:       `{"nlist": 128}`,
+		SearchParams:      `{"nprobe": 16}`,
+		InitRows:          100000,
+		BatchSize:         1000,
+		InsertQPS:         0,
+		SearchQPSStart:    10,
+		SearchQPS:         100,
+		RampDuration:      time.Minute,
+		NQ:                1,
+		TopK:              10,
+		FilterSelectivity: 1,
+		Concurrency:       16,
+		Duration:          5 * time.Minute,
+		ReportInterval:    10 * time.Second,
+	}
+}
+
+// BindFlags binds the configuration to the flags.
+func (c *Config) BindFlags(flags *flag.FlagSet) {
+	flags.StringVar(&c.Address, "address", c.Address, "address of the proxy")
+	flags.StringVar(&c.User, "user", c.User, "user name if authorization is enabled")
+	flags.StringVar(&c.Password, "password", c.Password, "password if authorization is enabled")
+	flags.StringVar(&c.CollectionName, "collection", c.CollectionName, "name of the collection to benchmark")
+	flags.BoolVar(&c.Reuse, "reuse", c.Reuse, "reuse the existing collection and skip inserting the initial rows")
+	flags.BoolVar(&c.Drop, "drop", c.Drop, "drop the collection after the benchmark")
+	flags.IntVar(&c.ShardsNum, "shards", c.ShardsNum, "shard number of the collection")
+	flags.IntVar(&c.Dim, "dim", c.Dim, "dimension of the vectors")
+	flags.StringVar(&c.Distribution, "distribution", c.Distribution, "distribution of the vectors: uniform, normal or clustered")
+	flags.IntVar(&c.Clusters, "clusters", c.Clusters, "number of the clusters for clustered distribution")
+	flags.StringVar(&c.IndexType, "indexType", c.IndexType, "index type of the vector field")
+	flags.StringVar(&c.MetricType, "metricType", c.MetricType, "metric type of the vector field")
+	flags.StringVar(&c.IndexParams, "indexParams", c.IndexParams, "index params in json")
+	flags.StringVar(&c.SearchParams, "searchParams", c.SearchParams, "search params in json")
+	flags.IntVar(&c.InitRows, "initRows", c.InitRows, "rows inserted before loading the collection")
+	flags.IntVar(&c.BatchSize, "batchSize", c.BatchSize, "rows of each insert request")
+	flags.Float64Var(&c.InsertQPS, "insertQPS", c.InsertQPS, "insert requests per second during the run")
+	flags.Float64Var(&c.SearchQPSStart, "searchQPSStart", c.SearchQPSStart, "search requests per second at the beginning of the ramp")
+	flags.Float64Var(&c.SearchQPS, "searchQPS", c.SearchQPS, "search requests per second after the ramp")
+	flags.DurationVar(&c.RampDuration, "ramp", c.RampDuration, "duration to ramp the search rate up")
+	flags.IntVar(&c.NQ, "nq", c.NQ, "number of query vectors of each search request")
+	flags.IntVar(&c.TopK, "topk", c.TopK, "topk of each search request")
+	flags.Float64Var(&c.FilterSelectivity, "selectivity", c.FilterSelectivity, "fraction of the rows matching the search filter, 1 means no filter")
+	flags.IntVar(&c.Concurrency, "concurrency", c.Concurrency, "max concurrent requests of each workload")
+	flags.DurationVar(&c.Duration, "duration", c.Duration, "duration of the run")
+	flags.DurationVar(&c.ReportInterval, "reportInterval", c.ReportInterval, "interval to report the statistics")
+	flags.StringVar(&c.CSVPath, "csv", c.CSVPath, "file to export the statistics in csv")
+}
+
+// Validate checks whether the configuration is valid.
+func (c *Config) Validate() error {
+	switch {
+	case c.Address == "":
+		return errors.New("address is empty")
+	case c.CollectionName == "":
+		return errors.New("collection name is empty")
+	case c.Dim <= 0:
+		return fmt.Errorf("invalid dim %d", c.Dim)
+	case c.Distribution != DistributionUniform && c.Distribution != DistributionNormal && c.Distribution != DistributionClustered:
+		return fmt.Errorf("unknown distribution %s", c.Distribution)
+	case c.Distribution == DistributionClustered && c.Clusters <= 0:
+		return fmt.Errorf("invalid clusters %d", c.Clusters)
+	case c.InitRows < 0:
+		return fmt.Errorf("invalid initRows %d", c.InitRows)
+	case c.BatchSize <= 0:
+		return fmt.Errorf("invalid batchSize %d", c.BatchSize)
+	case c.InsertQPS < 0 || c.SearchQPSStart < 0 || c.SearchQPS < 0:
+		return errors.New("qps must not be negative")
+	case c.NQ <= 0 || c.TopK <= 0:
+		return fmt.Errorf("invalid nq %d or topk %d", c.NQ, c.TopK)
+	case c.FilterSelectivity <= 0 || c.FilterSelectivity > 1:
+		return fmt.Errorf("selectivity %v must be in (0, 1]", c.FilterSelectivity)
+	case c.Concurrency <= 0:
+		return fmt.Errorf("invalid concurrency %d", c.Concurrency)
+	case c.Duration <= 0 || c.ReportInterval <= 0:
+		return errors.New("duration and report interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+)
+
+const (
+	// tagRange is the range of the scalar tag field, the tags are uniformly distributed in [0, tagRange).
+	tagRange = 10000
+	// all generators share the same cluster centers, so that the query vectors fall in the same clusters as the data
+	centerSeed = 0
+)
+
+// vectorGenerator generates vectors in the configured distribution, it's not safe for concurrent use.
+type vectorGenerator struct {
+	dim          int
+	distribution string
+	centers      [][]float32
+	rand         *rand.Rand
+}
+
+func newVectorGenerator(dim int, distribution string, clusters int, seed int64) *vectorGenerator {
+	gen := &vectorGenerator{
+		dim:          dim,
+		distribution: distribution,
+		rand:         rand.New(rand.NewSource(seed)),
+	}
+	if distribution == DistributionClustered {
+		centerRand := rand.New(rand.NewSource(centerSeed))
+		gen.centers = make([][]float32, clusters)
+		for i := range gen.centers {
+			gen.centers[i] = make([]float32, dim)
+			for j := range gen.centers[i] {
+				gen.centers[i][j] = centerRand.Float32()
+			}
+		}
+	}
+	return gen
+}
+
+// Generate appends n vectors into a flattened slice.
+func (gen *vectorGenerator) Generate(n int) []float32 {
+	data := make([]float32, 0, n*gen.dim)
+	for i := 0; i < n; i++ {
+		switch gen.distribution {
+		case DistributionNormal:
+			for j := 0; j < gen.dim; j++ {
+				data = append(data, float32(gen.rand.NormFloat64()))
+			}
+		case DistributionClustered:
+			center := gen.centers[gen.rand.Intn(len(gen.centers))]
+			for j := 0; j < gen.dim; j++ {
+				data = append(data, center[j]+float32(gen.rand.NormFloat64()*0.05))
+			}
+		default:
+			for j := 0; j < gen.dim; j++ {
+				data = append(data, gen.rand.Float32())
+			}
+		}
+	}
+	return data
+}
+
+// GenerateTags generates n tags uniformly distributed in [0, tagRange).
+func (gen *vectorGenerator) GenerateTags(n int) []int64 {
+	tags := make([]int64, n)
+	for i := range tags {
+		tags[i] = gen.rand.Int63n(tagRange)
+	}
+	return tags
+}
+
+// filterExpr returns the expression matching about `selectivity` of the rows.
+func filterExpr(selectivity float64) string {
+	if selectivity >= 1 {
+		return ""
+	}
+	return fmt.Sprintf("%s < %d", tagField, int64(math.Ceil(selectivity*tagRange)))
+}
+
+// placeholderGroup encodes the flattened query vectors into a placeholder group.
+func placeholderGroup(vectors []float32, dim int) *commonpb.PlaceholderGroup {
+	values := make([][]byte, 0, len(vectors)/dim)
+	for i := 0; i+dim <= len(vectors); i += dim {
+		value := make([]byte, dim*4)
+		for j, v := range vectors[i : i+dim] {
+			binary.LittleEndian.PutUint32(value[j*4:], math.Float32bits(v))
+		}
+		values = append(values, value)
+	}
+	return &commonpb.PlaceholderGroup{
+		Placeholders: []*commonpb.PlaceholderValue{{
+			Tag:    "$0",
+			Type:   commonpb.PlaceholderType_FloatVector,
+			Values: values,
+		}},
+	}
+}
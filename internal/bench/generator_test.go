@@ -0,0 +1,71 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorGenerator(t *testing.T) {
+	for _, distribution := range []string{DistributionUniform, DistributionNormal, DistributionClustered} {
+		gen := newVectorGenerator(8, distribution, 4, 1)
+		vectors := gen.Generate(10)
+		assert.Equal(t, 80, len(vectors))
+
+		tags := gen.GenerateTags(100)
+		assert.Equal(t, 100, len(tags))
+		for _, tag := range tags {
+			assert.True(t, tag >= 0 && tag < tagRange)
+		}
+	}
+
+	// generators share the same cluster centers
+	gen1 := newVectorGenerator(8, DistributionClustered, 4, 1)
+	gen2 := newVectorGenerator(8, DistributionClustered, 4, 2)
+	assert.Equal(t, gen1.centers, gen2.centers)
+}
+
+func TestFilterExpr(t *testing.T) {
+	assert.Equal(t, "", filterExpr(1))
+	assert.Equal(t, "tag < 100", filterExpr(0.01))
+	assert.Equal(t, "tag < 5000", filterExpr(0.5))
+}
+
+func TestPlaceholderGroup(t *testing.T) {
+	group := placeholderGroup(make([]float32, 12), 4)
+	assert.Equal(t, 1, len(group.GetPlaceholders()))
+	assert.Equal(t, 3, len(group.GetPlaceholders()[0].GetValues()))
+	assert.Equal(t, 16, len(group.GetPlaceholders()[0].GetValues()[0]))
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := DefaultConfig()
+	assert.NoError(t, config.Validate())
+
+	config.Distribution = "unknown"
+	assert.Error(t, config.Validate())
+
+	config = DefaultConfig()
+	config.FilterSelectivity = 0
+	assert.Error(t, config.Validate())
+
+	config = DefaultConfig()
+	config.Concurrency = 0
+	assert.Error(t, config.Validate())
+}
@@ -0,0 +1,202 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// the upper bound of the i-th bucket is minLatency * bucketGrowth^i,
+	// the last bucket holds the latencies exceeding all bounds
+	minLatency   = 50 * time.Microsecond
+	bucketGrowth = 1.2
+	bucketNum    = 80
+)
+
+var bucketBounds = func() []time.Duration {
+	bounds := make([]time.Duration, bucketNum)
+	for i := range bounds {
+		bounds[i] = time.Duration(float64(minLatency) * math.Pow(bucketGrowth, float64(i)))
+	}
+	return bounds
+}()
+
+// Histogram records latencies in exponential buckets, it's safe for concurrent use.
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []int64
+	count   int64
+	errors  int64
+	sum     time.Duration
+	max     time.Duration
+}
+
+// NewHistogram creates an empty histogram.
+func NewHistogram() *Histogram {
+	return &Histogram{
+		buckets: make([]int64, bucketNum+1),
+	}
+}
+
+// Record records the latency of a successful request.
+func (h *Histogram) Record(latency time.Duration) {
+	idx := bucketNum
+	for i, bound := range bucketBounds {
+		if latency <= bound {
+			idx = i
+			break
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buckets[idx]++
+	h.count++
+	h.sum += latency
+	if latency > h.max {
+		h.max = latency
+	}
+}
+
+// RecordError records a failed request.
+func (h *Histogram) RecordError() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.errors++
+}
+
+// Merge adds the records of other into h.
+func (h *Histogram) Merge(other *Histogram) {
+	other.mu.Lock()
+	snapshot := other.clone()
+	other.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := range h.buckets {
+		h.buckets[i] += snapshot.buckets[i]
+	}
+	h.count += snapshot.count
+	h.errors += snapshot.errors
+	h.sum += snapshot.sum
+	if snapshot.max > h.max {
+		h.max = snapshot.max
+	}
+}
+
+// Swap returns the records so far and resets the histogram.
+func (h *Histogram) Swap() *Histogram {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	snapshot := h.clone()
+	h.buckets = make([]int64, bucketNum+1)
+	h.count, h.errors, h.sum, h.max = 0, 0, 0, 0
+	return snapshot
+}
+
+func (h *Histogram) clone() *Histogram {
+	buckets := make([]int64, len(h.buckets))
+	copy(buckets, h.buckets)
+	return &Histogram{
+		buckets: buckets,
+		count:   h.count,
+		errors:  h.errors,
+		sum:     h.sum,
+		max:     h.max,
+	}
+}
+
+// Count returns the number of successful requests.
+func (h *Histogram) Count() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Errors returns the number of failed requests.
+func (h *Histogram) Errors() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.errors
+}
+
+// Mean returns the average latency.
+func (h *Histogram) Mean() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Max returns the max latency.
+func (h *Histogram) Max() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.max
+}
+
+// Percentile returns the upper bound of the bucket containing the p-th percentile latency,
+// p is in [0, 100].
+func (h *Histogram) Percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.count == 0 {
+		return 0
+	}
+
+	rank := int64(math.Ceil(float64(h.count) * p / 100))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int64
+	for i, n := range h.buckets {
+		seen += n
+		if seen >= rank {
+			if i == bucketNum || bucketBounds[i] > h.max {
+				return h.max
+			}
+			return bucketBounds[i]
+		}
+	}
+	return h.max
+}
+
+// Buckets returns the upper bounds and counts of the non-empty buckets,
+// the bound of the overflow bucket is the max latency.
+func (h *Histogram) Buckets() ([]time.Duration, []int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var bounds []time.Duration
+	var counts []int64
+	for i, n := range h.buckets {
+		if n == 0 {
+			continue
+		}
+		if i == bucketNum {
+			bounds = append(bounds, h.max)
+		} else {
+			bounds = append(bounds, bucketBounds[i])
+		}
+		counts = append(counts, n)
+	}
+	return bounds, counts
+}
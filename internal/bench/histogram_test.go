@@ -0,0 +1,96 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram()
+	assert.Equal(t, time.Duration(0), h.Percentile(99))
+	assert.Equal(t, time.Duration(0), h.Mean())
+
+	for i := 1; i <= 100; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+	h.RecordError()
+	assert.EqualValues(t, 100, h.Count())
+	assert.EqualValues(t, 1, h.Errors())
+	assert.Equal(t, 50500*time.Microsecond, h.Mean())
+	assert.Equal(t, 100*time.Millisecond, h.Max())
+	// the percentiles are rounded up to the bucket bounds
+	assert.InDelta(t, float64(50*time.Millisecond), float64(h.Percentile(50)), float64(10*time.Millisecond))
+	assert.Equal(t, 100*time.Millisecond, h.Percentile(100))
+
+	bounds, counts := h.Buckets()
+	assert.Equal(t, len(bounds), len(counts))
+	var sum int64
+	for _, n := range counts {
+		sum += n
+	}
+	assert.EqualValues(t, 100, sum)
+
+	snapshot := h.Swap()
+	assert.EqualValues(t, 0, h.Count())
+	assert.EqualValues(t, 100, snapshot.Count())
+
+	total := NewHistogram()
+	total.Merge(snapshot)
+	total.Merge(snapshot)
+	assert.EqualValues(t, 200, total.Count())
+	assert.EqualValues(t, 2, total.Errors())
+
+	// latency exceeding all buckets
+	h.Record(time.Hour)
+	assert.Equal(t, time.Hour, h.Percentile(99))
+}
+
+func TestReporter(t *testing.T) {
+	h := NewHistogram()
+	h.Record(time.Millisecond)
+	h.Record(2 * time.Millisecond)
+	stats := &Stats{
+		Op:        opSearch,
+		Elapsed:   10 * time.Second,
+		Period:    10 * time.Second,
+		Missed:    3,
+		Histogram: h,
+	}
+	assert.Equal(t, 0.2, stats.QPS())
+
+	out := &bytes.Buffer{}
+	csvOut := &bytes.Buffer{}
+	reporter, err := newReporter(out, csvOut)
+	require.NoError(t, err)
+	err = reporter.Report(stats)
+	assert.NoError(t, err)
+	reporter.ReportHistogram(stats)
+	assert.Contains(t, out.String(), "count=2")
+	assert.Contains(t, out.String(), "latency histogram of search")
+
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	require.Equal(t, 2, len(lines))
+	assert.Equal(t, strings.Join(csvHeader, ","), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "10,search,2,0,3,0.2,"))
+}
@@ -0,0 +1,112 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"elapsed_s", "op", "count", "errors", "missed", "qps", "mean_ms", "p50_ms", "p90_ms", "p99_ms", "max_ms"}
+
+// Stats is the statistics of a workload in a period.
+type Stats struct {
+	Op      string
+	Elapsed time.Duration
+	Period  time.Duration
+	// Missed is the number of requests not sent since all workers were busy
+	Missed int64
+	*Histogram
+}
+
+// QPS returns the successful requests per second in the period.
+func (s *Stats) QPS() float64 {
+	if s.Period <= 0 {
+		return 0
+	}
+	return float64(s.Count()) / s.Period.Seconds()
+}
+
+func (s *Stats) String() string {
+	return fmt.Sprintf("[%6.0fs] %-6s count=%d errors=%d missed=%d qps=%.1f mean=%v p50=%v p90=%v p99=%v max=%v",
+		s.Elapsed.Seconds(), s.Op, s.Count(), s.Errors(), s.Missed, s.QPS(),
+		s.Mean(), s.Percentile(50), s.Percentile(90), s.Percentile(99), s.Max())
+}
+
+func (s *Stats) csvRecord() []string {
+	ms := func(d time.Duration) string {
+		return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+	}
+	return []string{
+		strconv.FormatFloat(s.Elapsed.Seconds(), 'f', 0, 64),
+		s.Op,
+		strconv.FormatInt(s.Count(), 10),
+		strconv.FormatInt(s.Errors(), 10),
+		strconv.FormatInt(s.Missed, 10),
+		strconv.FormatFloat(s.QPS(), 'f', 1, 64),
+		ms(s.Mean()),
+		ms(s.Percentile(50)),
+		ms(s.Percentile(90)),
+		ms(s.Percentile(99)),
+		ms(s.Max()),
+	}
+}
+
+// reporter prints the statistics and exports them in csv.
+type reporter struct {
+	out io.Writer
+	csv *csv.Writer
+}
+
+func newReporter(out io.Writer, csvOut io.Writer) (*reporter, error) {
+	r := &reporter{out: out}
+	if csvOut != nil {
+		r.csv = csv.NewWriter(csvOut)
+		if err := r.csv.Write(csvHeader); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *reporter) Report(stats ...*Stats) error {
+	for _, s := range stats {
+		fmt.Fprintln(r.out, s.String())
+		if r.csv != nil {
+			if err := r.csv.Write(s.csvRecord()); err != nil {
+				return err
+			}
+		}
+	}
+	if r.csv != nil {
+		r.csv.Flush()
+		return r.csv.Error()
+	}
+	return nil
+}
+
+// ReportHistogram prints the latency distribution of the whole run.
+func (r *reporter) ReportHistogram(stats *Stats) {
+	bounds, counts := stats.Buckets()
+	fmt.Fprintf(r.out, "latency histogram of %s:\n", stats.Op)
+	for i := range bounds {
+		fmt.Fprintf(r.out, "  <= %-12v %d\n", bounds[i], counts[i])
+	}
+}
@@ -0,0 +1,474 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/util"
+	"github.com/milvus-io/milvus/internal/util/crypto"
+)
+
+const (
+	opInsert = "insert"
+	opSearch = "search"
+
+	pkField     = "id"
+	tagField    = "tag"
+	vectorField = "vector"
+
+	// the interval of the pacer to emit tokens
+	paceInterval = 10 * time.Millisecond
+)
+
+// workload sends requests at the given rate with bounded concurrency.
+type workload struct {
+	op       string
+	qps      func(elapsed time.Duration) float64
+	do       func(ctx context.Context, gen *vectorGenerator) error
+	interval *Histogram
+	total    *Histogram
+	missed   atomic.Int64
+}
+
+// Runner runs the insert and search workloads against a Milvus cluster.
+type Runner struct {
+	config *Config
+	conn   *grpc.ClientConn
+	client milvuspb.MilvusServiceClient
+	out    io.Writer
+
+	searchExpr   string
+	searchParams []*commonpb.KeyValuePair
+}
+
+// NewRunner connects to the proxy of the cluster.
+func NewRunner(ctx context.Context, config *Config, out io.Writer) (*Runner, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	conn, err := grpc.DialContext(ctx, config.Address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", config.Address, err)
+	}
+	return &Runner{
+		config: config,
+		conn:   conn,
+		client: milvuspb.NewMilvusServiceClient(conn),
+		out:    out,
+
+		searchExpr: filterExpr(config.FilterSelectivity),
+		searchParams: []*commonpb.KeyValuePair{
+			{Key: "anns_field", Value: vectorField},
+			{Key: "topk", Value: strconv.Itoa(config.TopK)},
+			{Key: common.MetricTypeKey, Value: config.MetricType},
+			{Key: "params", Value: config.SearchParams},
+			{Key: "round_decimal", Value: "-1"},
+		},
+	}, nil
+}
+
+// Close closes the connection.
+func (r *Runner) Close() error {
+	return r.conn.Close()
+}
+
+// Run prepares the collection, then runs the workloads until the duration elapses or ctx is done.
+func (r *Runner) Run(ctx context.Context) error {
+	ctx = r.withAuth(ctx)
+	if err := r.setup(ctx); err != nil {
+		return err
+	}
+	if r.config.Drop {
+		defer func() {
+			// the run context may be canceled already
+			_, err := r.client.DropCollection(r.withAuth(context.Background()), &milvuspb.DropCollectionRequest{CollectionName: r.config.CollectionName})
+			if err != nil {
+				fmt.Fprintf(r.out, "failed to drop collection %s: %v\n", r.config.CollectionName, err)
+			}
+		}()
+	}
+
+	var csvOut io.Writer
+	if r.config.CSVPath != "" {
+		file, err := os.Create(r.config.CSVPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		csvOut = file
+	}
+	reporter, err := newReporter(r.out, csvOut)
+	if err != nil {
+		return err
+	}
+
+	var workloads []*workload
+	if r.config.InsertQPS > 0 {
+		workloads = append(workloads, r.newWorkload(opInsert, func(time.Duration) float64 {
+			return r.config.InsertQPS
+		}, r.insert))
+	}
+	if r.config.SearchQPS > 0 || r.config.SearchQPSStart > 0 {
+		workloads = append(workloads, r.newWorkload(opSearch, func(elapsed time.Duration) float64 {
+			return rampQPS(r.config.SearchQPSStart, r.config.SearchQPS, r.config.RampDuration, elapsed)
+		}, r.search))
+	}
+	if len(workloads) == 0 {
+		return errors.New("no workload to run, set insertQPS or searchQPS")
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, r.config.Duration)
+	defer cancel()
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	for _, w := range workloads {
+		r.startWorkload(runCtx, wg, start, w)
+	}
+
+	ticker := time.NewTicker(r.config.ReportInterval)
+	defer ticker.Stop()
+	lastReport := start
+	report := func(now time.Time) error {
+		stats := make([]*Stats, 0, len(workloads))
+		for _, w := range workloads {
+			interval := w.interval.Swap()
+			w.total.Merge(interval)
+			stats = append(stats, &Stats{
+				Op:        w.op,
+				Elapsed:   now.Sub(start),
+				Period:    now.Sub(lastReport),
+				Missed:    w.missed.Swap(0),
+				Histogram: interval,
+			})
+		}
+		lastReport = now
+		return reporter.Report(stats...)
+	}
+
+loop:
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := report(now); err != nil {
+				return err
+			}
+		case <-runCtx.Done():
+			break loop
+		}
+	}
+	wg.Wait()
+	if err := report(time.Now()); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(r.out, "summary:")
+	elapsed := time.Since(start)
+	for _, w := range workloads {
+		stats := &Stats{
+			Op:        w.op,
+			Elapsed:   elapsed,
+			Period:    elapsed,
+			Histogram: w.total,
+		}
+		if err := reporter.Report(stats); err != nil {
+			return err
+		}
+		reporter.ReportHistogram(stats)
+	}
+	return nil
+}
+
+func (r *Runner) withAuth(ctx context.Context) context.Context {
+	if r.config.User == "" {
+		return ctx
+	}
+	token := crypto.Base64Encode(r.config.User + util.CredentialSeperator + r.config.Password)
+	return metadata.AppendToOutgoingContext(ctx, util.HeaderAuthorize, token)
+}
+
+// setup creates the collection with index and initial rows, and loads it.
+func (r *Runner) setup(ctx context.Context) error {
+	name := r.config.CollectionName
+	resp, err := r.client.HasCollection(ctx, &milvuspb.HasCollectionRequest{CollectionName: name})
+	if err = checkStatus(resp.GetStatus(), err); err != nil {
+		return fmt.Errorf("failed to check collection %s: %w", name, err)
+	}
+
+	if !resp.GetValue() || !r.config.Reuse {
+		if resp.GetValue() {
+			fmt.Fprintf(r.out, "dropping existing collection %s\n", name)
+			status, err := r.client.DropCollection(ctx, &milvuspb.DropCollectionRequest{CollectionName: name})
+			if err = checkStatus(status, err); err != nil {
+				return fmt.Errorf("failed to drop collection %s: %w", name, err)
+			}
+		}
+		if err := r.createCollection(ctx); err != nil {
+			return err
+		}
+		if err := r.insertInitRows(ctx); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(r.out, "loading collection %s\n", name)
+	status, err := r.client.LoadCollection(ctx, &milvuspb.LoadCollectionRequest{CollectionName: name})
+	if err = checkStatus(status, err); err != nil {
+		return fmt.Errorf("failed to load collection %s: %w", name, err)
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		progress, err := r.client.GetLoadingProgress(ctx, &milvuspb.GetLoadingProgressRequest{CollectionName: name})
+		if err = checkStatus(progress.GetStatus(), err); err != nil {
+			return fmt.Errorf("failed to get loading progress of collection %s: %w", name, err)
+		}
+		if progress.GetProgress() >= 100 {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *Runner) createCollection(ctx context.Context) error {
+	name := r.config.CollectionName
+	schema := &schemapb.CollectionSchema{
+		Name: name,
+		Fields: []*schemapb.FieldSchema{
+			{Name: pkField, IsPrimaryKey: true, AutoID: true, DataType: schemapb.DataType_Int64},
+			{Name: tagField, DataType: schemapb.DataType_Int64},
+			{
+				Name:       vectorField,
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: strconv.Itoa(r.config.Dim)}},
+			},
+		},
+	}
+	bs, err := proto.Marshal(schema)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.out, "creating collection %s\n", name)
+	status, err := r.client.CreateCollection(ctx, &milvuspb.CreateCollectionRequest{
+		CollectionName: name,
+		Schema:         bs,
+		ShardsNum:      int32(r.config.ShardsNum),
+	})
+	if err = checkStatus(status, err); err != nil {
+		return fmt.Errorf("failed to create collection %s: %w", name, err)
+	}
+
+	status, err = r.client.CreateIndex(ctx, &milvuspb.CreateIndexRequest{
+		CollectionName: name,
+		FieldName:      vectorField,
+		ExtraParams: []*commonpb.KeyValuePair{
+			{Key: common.IndexTypeKey, Value: r.config.IndexType},
+			{Key: common.MetricTypeKey, Value: r.config.MetricType},
+			{Key: common.IndexParamsKey, Value: r.config.IndexParams},
+		},
+	})
+	if err = checkStatus(status, err); err != nil {
+		return fmt.Errorf("failed to create index on collection %s: %w", name, err)
+	}
+	return nil
+}
+
+func (r *Runner) insertInitRows(ctx context.Context) error {
+	if r.config.InitRows == 0 {
+		return nil
+	}
+	fmt.Fprintf(r.out, "inserting %d rows\n", r.config.InitRows)
+	gen := newVectorGenerator(r.config.Dim, r.config.Distribution, r.config.Clusters, time.Now().UnixNano())
+	for inserted := 0; inserted < r.config.InitRows; inserted += r.config.BatchSize {
+		rows := r.config.BatchSize
+		if inserted+rows > r.config.InitRows {
+			rows = r.config.InitRows - inserted
+		}
+		if err := r.insertRows(ctx, gen, rows); err != nil {
+			return err
+		}
+	}
+
+	resp, err := r.client.Flush(ctx, &milvuspb.FlushRequest{CollectionNames: []string{r.config.CollectionName}})
+	if err = checkStatus(resp.GetStatus(), err); err != nil {
+		return fmt.Errorf("failed to flush collection %s: %w", r.config.CollectionName, err)
+	}
+	return nil
+}
+
+func (r *Runner) insert(ctx context.Context, gen *vectorGenerator) error {
+	return r.insertRows(ctx, gen, r.config.BatchSize)
+}
+
+func (r *Runner) insertRows(ctx context.Context, gen *vectorGenerator, rows int) error {
+	resp, err := r.client.Insert(ctx, &milvuspb.InsertRequest{
+		CollectionName: r.config.CollectionName,
+		NumRows:        uint32(rows),
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type:      schemapb.DataType_Int64,
+				FieldName: tagField,
+				Field: &schemapb.FieldData_Scalars{
+					Scalars: &schemapb.ScalarField{
+						Data: &schemapb.ScalarField_LongData{
+							LongData: &schemapb.LongArray{Data: gen.GenerateTags(rows)},
+						},
+					},
+				},
+			},
+			{
+				Type:      schemapb.DataType_FloatVector,
+				FieldName: vectorField,
+				Field: &schemapb.FieldData_Vectors{
+					Vectors: &schemapb.VectorField{
+						Dim: int64(r.config.Dim),
+						Data: &schemapb.VectorField_FloatVector{
+							FloatVector: &schemapb.FloatArray{Data: gen.Generate(rows)},
+						},
+					},
+				},
+			},
+		},
+	})
+	return checkStatus(resp.GetStatus(), err)
+}
+
+func (r *Runner) search(ctx context.Context, gen *vectorGenerator) error {
+	bs, err := proto.Marshal(placeholderGroup(gen.Generate(r.config.NQ), r.config.Dim))
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Search(ctx, &milvuspb.SearchRequest{
+		CollectionName:   r.config.CollectionName,
+		Dsl:              r.searchExpr,
+		DslType:          commonpb.DslType_BoolExprV1,
+		PlaceholderGroup: bs,
+		SearchParams:     r.searchParams,
+		Nq:               int64(r.config.NQ),
+	})
+	return checkStatus(resp.GetStatus(), err)
+}
+
+func (r *Runner) newWorkload(op string, qps func(time.Duration) float64, do func(context.Context, *vectorGenerator) error) *workload {
+	return &workload{
+		op:       op,
+		qps:      qps,
+		do:       do,
+		interval: NewHistogram(),
+		total:    NewHistogram(),
+	}
+}
+
+// startWorkload starts the pacer and the workers of the workload.
+func (r *Runner) startWorkload(ctx context.Context, wg *sync.WaitGroup, start time.Time, w *workload) {
+	tokens := make(chan struct{}, r.config.Concurrency)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		pace(ctx, start, w.qps, tokens, &w.missed)
+	}()
+
+	for i := 0; i < r.config.Concurrency; i++ {
+		gen := newVectorGenerator(r.config.Dim, r.config.Distribution, r.config.Clusters, start.UnixNano()+int64(i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-tokens:
+				}
+				begin := time.Now()
+				err := w.do(ctx, gen)
+				if ctx.Err() != nil {
+					// the run is over, the request may be canceled
+					return
+				}
+				if err != nil {
+					w.interval.RecordError()
+					continue
+				}
+				w.interval.Record(time.Since(begin))
+			}
+		}()
+	}
+}
+
+// pace emits tokens at the rate of qps(elapsed) until ctx is done,
+// the tokens are dropped and counted as missed if all workers are busy.
+func pace(ctx context.Context, start time.Time, qps func(time.Duration) float64, tokens chan<- struct{}, missed *atomic.Int64) {
+	ticker := time.NewTicker(paceInterval)
+	defer ticker.Stop()
+	last := time.Now()
+	var due float64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			due += qps(now.Sub(start)) * now.Sub(last).Seconds()
+			last = now
+			for ; due >= 1; due-- {
+				select {
+				case tokens <- struct{}{}:
+				default:
+					missed.Inc()
+				}
+			}
+		}
+	}
+}
+
+// rampQPS returns the rate ramping linearly from start to target in ramp.
+func rampQPS(start, target float64, ramp time.Duration, elapsed time.Duration) float64 {
+	if ramp <= 0 || elapsed >= ramp {
+		return target
+	}
+	return start + (target-start)*float64(elapsed)/float64(ramp)
+}
+
+func checkStatus(status *commonpb.Status, err error) error {
+	if err != nil {
+		return err
+	}
+	if status.GetErrorCode() != commonpb.ErrorCode_Success {
+		return errors.New(status.GetReason())
+	}
+	return nil
+}
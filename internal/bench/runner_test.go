@@ -0,0 +1,52 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func TestRampQPS(t *testing.T) {
+	assert.Equal(t, 10.0, rampQPS(10, 100, time.Minute, 0))
+	assert.Equal(t, 55.0, rampQPS(10, 100, time.Minute, 30*time.Second))
+	assert.Equal(t, 100.0, rampQPS(10, 100, time.Minute, 2*time.Minute))
+	assert.Equal(t, 100.0, rampQPS(10, 100, 0, 0))
+}
+
+func TestPace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	tokens := make(chan struct{}, 1000)
+	missed := atomic.NewInt64(0)
+	pace(ctx, time.Now(), func(time.Duration) float64 { return 500 }, tokens, missed)
+	assert.InDelta(t, 100, len(tokens), 50)
+	assert.EqualValues(t, 0, missed.Load())
+
+	// all workers are busy
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	tokens = make(chan struct{}, 1)
+	pace(ctx, time.Now(), func(time.Duration) float64 { return 500 }, tokens, missed)
+	assert.Equal(t, 1, len(tokens))
+	assert.Greater(t, missed.Load(), int64(0))
+}
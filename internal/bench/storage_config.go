@@ -0,0 +1,159 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StorageConfig is the configuration of a storage benchmark run.
+type StorageConfig struct {
+	// ObjectSizes are the sizes of the written objects, every write picks one of them randomly
+	ObjectSizes []int64
+	// Objects is the number of objects written before the run, which are read during the run
+	Objects int
+	// Parallelism is the number of requests in flight
+	Parallelism int
+	// ReadRatio is the fraction of the requests which are reads, the others are writes
+	ReadRatio float64
+	// Prefix is the directory of the objects under the root path, which is removed after the run
+	Prefix string
+
+	Duration       time.Duration
+	ReportInterval time.Duration
+	// CSVPath is the file to export the latency statistics, empty means no export
+	CSVPath string
+}
+
+// DefaultStorageConfig returns the default storage benchmark configuration.
+func DefaultStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		ObjectSizes:    []int64{1 << 20},
+		Objects:        100,
+		Parallelism:    16,
+		ReadRatio:      0.8,
+		Prefix:         "storage-bench",
+		Duration:       time.Minute,
+		ReportInterval: 10 * time.Second,
+	}
+}
+
+// BindFlags binds the configuration to the flags.
+func (c *StorageConfig) BindFlags(flags *flag.FlagSet) {
+	flags.Var((*sizeList)(&c.ObjectSizes), "sizes", "comma separated sizes of the objects, e.g. 64KB,1MB,16MB")
+	flags.IntVar(&c.Objects, "objects", c.Objects, "number of objects written before the run to be read")
+	flags.IntVar(&c.Parallelism, "parallelism", c.Parallelism, "number of requests in flight")
+	flags.Float64Var(&c.ReadRatio, "readRatio", c.ReadRatio, "fraction of the requests which are reads, the others are writes")
+	flags.StringVar(&c.Prefix, "prefix", c.Prefix, "directory of the objects under the root path, removed after the run")
+	flags.DurationVar(&c.Duration, "duration", c.Duration, "duration of the run")
+	flags.DurationVar(&c.ReportInterval, "reportInterval", c.ReportInterval, "interval to report the statistics")
+	flags.StringVar(&c.CSVPath, "csv", c.CSVPath, "file to export the statistics in csv")
+}
+
+// Validate checks whether the configuration is valid.
+func (c *StorageConfig) Validate() error {
+	if len(c.ObjectSizes) == 0 {
+		return errors.New("no object size")
+	}
+	for _, size := range c.ObjectSizes {
+		if size <= 0 {
+			return fmt.Errorf("invalid object size %d", size)
+		}
+	}
+	switch {
+	case c.ReadRatio < 0 || c.ReadRatio > 1:
+		return fmt.Errorf("readRatio %v must be in [0, 1]", c.ReadRatio)
+	case c.ReadRatio > 0 && c.Objects <= 0:
+		return fmt.Errorf("invalid objects %d, the reads need objects", c.Objects)
+	case c.Parallelism <= 0:
+		return fmt.Errorf("invalid parallelism %d", c.Parallelism)
+	case strings.Trim(c.Prefix, "/") == "":
+		return errors.New("prefix is empty")
+	case c.Duration <= 0 || c.ReportInterval <= 0:
+		return errors.New("duration and report interval must be positive")
+	}
+	return nil
+}
+
+// sizeList is a flag of comma separated sizes with the optional units B, KB, MB and GB.
+type sizeList []int64
+
+func (l *sizeList) String() string {
+	if l == nil {
+		return ""
+	}
+	sizes := make([]string, 0, len(*l))
+	for _, size := range *l {
+		sizes = append(sizes, formatSize(size))
+	}
+	return strings.Join(sizes, ",")
+}
+
+func (l *sizeList) Set(value string) error {
+	var sizes []int64
+	for _, s := range strings.Split(value, ",") {
+		size, err := parseSize(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		sizes = append(sizes, size)
+	}
+	*l = sizes
+	return nil
+}
+
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+func parseSize(s string) (int64, error) {
+	upper := strings.ToUpper(s)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(upper, unit.suffix) {
+			n, err := strconv.ParseInt(strings.TrimSpace(upper[:len(upper)-len(unit.suffix)]), 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q", s)
+			}
+			return n * unit.bytes, nil
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n, nil
+}
+
+func formatSize(size int64) string {
+	for _, unit := range sizeUnits {
+		if size >= unit.bytes && size%unit.bytes == 0 {
+			return strconv.FormatInt(size/unit.bytes, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(size, 10) + "B"
+}
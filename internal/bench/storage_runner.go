@@ -0,0 +1,268 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+const (
+	opRead  = "read"
+	opWrite = "write"
+
+	// the writes of a worker overwrite this many objects in turn, so that the run doesn't fill the storage
+	storageWriteSlots = 16
+)
+
+// storageOp is the statistics of the reads or writes of one object size.
+type storageOp struct {
+	name     string
+	interval *Histogram
+	total    *Histogram
+	bytes    atomic.Int64
+}
+
+// StorageRunner runs the read and write workloads against a ChunkManager.
+type StorageRunner struct {
+	config *StorageConfig
+	cm     storage.ChunkManager
+	// prefix is the directory of the objects, including the root path of the object storages
+	prefix string
+	out    io.Writer
+
+	// payloads are the contents of the sizes, shared by all writes
+	payloads map[int64][]byte
+	ops      map[string]*storageOp
+}
+
+// NewStorageRunner returns a StorageRunner writing and reading the objects under @prefix of @cm.
+func NewStorageRunner(config *StorageConfig, cm storage.ChunkManager, prefix string, out io.Writer) (*StorageRunner, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	r := &StorageRunner{
+		config:   config,
+		cm:       cm,
+		prefix:   prefix,
+		out:      out,
+		payloads: make(map[int64][]byte),
+		ops:      make(map[string]*storageOp),
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for _, size := range config.ObjectSizes {
+		if _, ok := r.payloads[size]; ok {
+			continue
+		}
+		payload := make([]byte, size)
+		// random contents, so that the storages compressing or deduplicating the objects don't skew the results
+		rng.Read(payload)
+		r.payloads[size] = payload
+		for _, op := range []string{opRead, opWrite} {
+			name := op + "/" + formatSize(size)
+			r.ops[name] = &storageOp{name: name, interval: NewHistogram(), total: NewHistogram()}
+		}
+	}
+	return r, nil
+}
+
+func (r *StorageRunner) op(op string, size int64) *storageOp {
+	return r.ops[op+"/"+formatSize(size)]
+}
+
+// objectSize returns the size of the i-th object read during the run.
+func (r *StorageRunner) objectSize(i int) int64 {
+	return r.config.ObjectSizes[i%len(r.config.ObjectSizes)]
+}
+
+func (r *StorageRunner) objectPath(i int) string {
+	return path.Join(r.prefix, "objects", strconv.Itoa(i))
+}
+
+// Run writes the objects to read, then runs the workloads until the duration elapses or ctx is done.
+// The objects are removed after the run.
+func (r *StorageRunner) Run(ctx context.Context) error {
+	defer func() {
+		// the run context may be canceled already
+		if err := r.cm.RemoveWithPrefix(context.Background(), r.prefix); err != nil {
+			fmt.Fprintf(r.out, "failed to remove the objects under %s: %v\n", r.prefix, err)
+		}
+	}()
+
+	var csvOut io.Writer
+	if r.config.CSVPath != "" {
+		file, err := os.Create(r.config.CSVPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		csvOut = file
+	}
+	reporter, err := newReporter(r.out, csvOut)
+	if err != nil {
+		return err
+	}
+
+	if r.config.ReadRatio > 0 {
+		if err := r.prepare(ctx); err != nil {
+			return err
+		}
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, r.config.Duration)
+	defer cancel()
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	for i := 0; i < r.config.Parallelism; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			r.work(runCtx, worker, rand.New(rand.NewSource(start.UnixNano()+int64(worker))))
+		}(i)
+	}
+
+	names := make([]string, 0, len(r.ops))
+	for name := range r.ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ticker := time.NewTicker(r.config.ReportInterval)
+	defer ticker.Stop()
+	lastReport := start
+	report := func(now time.Time) error {
+		var stats []*Stats
+		for _, name := range names {
+			op := r.ops[name]
+			interval := op.interval.Swap()
+			op.total.Merge(interval)
+			if interval.Count() == 0 && interval.Errors() == 0 {
+				continue
+			}
+			stats = append(stats, &Stats{
+				Op:        name,
+				Elapsed:   now.Sub(start),
+				Period:    now.Sub(lastReport),
+				Histogram: interval,
+			})
+		}
+		lastReport = now
+		return reporter.Report(stats...)
+	}
+
+loop:
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := report(now); err != nil {
+				return err
+			}
+		case <-runCtx.Done():
+			break loop
+		}
+	}
+	wg.Wait()
+	if err := report(time.Now()); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(r.out, "summary:")
+	elapsed := time.Since(start)
+	for _, name := range names {
+		op := r.ops[name]
+		if op.total.Count() == 0 && op.total.Errors() == 0 {
+			continue
+		}
+		stats := &Stats{
+			Op:        name,
+			Elapsed:   elapsed,
+			Period:    elapsed,
+			Histogram: op.total,
+		}
+		if err := reporter.Report(stats); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out, "throughput of %s: %.1f MB/s\n", name, float64(op.bytes.Load())/elapsed.Seconds()/(1<<20))
+		reporter.ReportHistogram(stats)
+	}
+	return nil
+}
+
+// prepare writes the objects read during the run.
+func (r *StorageRunner) prepare(ctx context.Context) error {
+	fmt.Fprintf(r.out, "writing %d objects to read under %s\n", r.config.Objects, r.prefix)
+	group, ctx := errgroup.WithContext(ctx)
+	next := atomic.NewInt64(-1)
+	for i := 0; i < r.config.Parallelism; i++ {
+		group.Go(func() error {
+			for {
+				i := int(next.Inc())
+				if i >= r.config.Objects {
+					return nil
+				}
+				if err := r.cm.Write(ctx, r.objectPath(i), r.payloads[r.objectSize(i)]); err != nil {
+					return fmt.Errorf("failed to write object %s: %w", r.objectPath(i), err)
+				}
+			}
+		})
+	}
+	return group.Wait()
+}
+
+// work sends the requests one by one until ctx is done.
+func (r *StorageRunner) work(ctx context.Context, worker int, rng *rand.Rand) {
+	for n := 0; ctx.Err() == nil; n++ {
+		var op *storageOp
+		var size int64
+		var err error
+		begin := time.Now()
+		if rng.Float64() < r.config.ReadRatio {
+			i := rng.Intn(r.config.Objects)
+			size = r.objectSize(i)
+			op = r.op(opRead, size)
+			_, err = r.cm.Read(ctx, r.objectPath(i))
+		} else {
+			size = r.config.ObjectSizes[rng.Intn(len(r.config.ObjectSizes))]
+			op = r.op(opWrite, size)
+			filePath := path.Join(r.prefix, "writes", strconv.Itoa(worker), strconv.Itoa(n%storageWriteSlots))
+			err = r.cm.Write(ctx, filePath, r.payloads[size])
+		}
+		if ctx.Err() != nil {
+			// the run is over, the request may be canceled
+			return
+		}
+		if err != nil {
+			op.interval.RecordError()
+			continue
+		}
+		op.interval.Record(time.Since(begin))
+		op.bytes.Add(size)
+	}
+}
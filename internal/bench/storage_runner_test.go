@@ -0,0 +1,72 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func TestStorageConfig(t *testing.T) {
+	config := DefaultStorageConfig()
+	flags := flag.NewFlagSet("storage-bench", flag.ContinueOnError)
+	config.BindFlags(flags)
+	require.NoError(t, flags.Parse([]string{"-sizes", "64KB, 1mb,512", "-readRatio", "0.5"}))
+	assert.Equal(t, []int64{64 << 10, 1 << 20, 512}, config.ObjectSizes)
+	assert.Equal(t, 0.5, config.ReadRatio)
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, "64KB,1MB,512B", (*sizeList)(&config.ObjectSizes).String())
+
+	assert.Error(t, flags.Parse([]string{"-sizes", "1TB"}))
+	config.ReadRatio = 1.5
+	assert.Error(t, config.Validate())
+}
+
+func TestStorageRunner(t *testing.T) {
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	config := DefaultStorageConfig()
+	config.ObjectSizes = []int64{1 << 10, 4 << 10}
+	config.Objects = 8
+	config.Parallelism = 4
+	config.ReadRatio = 0.5
+	config.Duration = 200 * time.Millisecond
+	config.ReportInterval = 100 * time.Millisecond
+
+	out := &bytes.Buffer{}
+	runner, err := NewStorageRunner(config, cm, config.Prefix, out)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run(context.Background()))
+
+	for _, name := range []string{"read/1KB", "read/4KB", "write/1KB", "write/4KB"} {
+		op := runner.ops[name]
+		assert.Greater(t, op.total.Count(), int64(0), name)
+		assert.Zero(t, op.total.Errors(), name)
+	}
+	assert.Contains(t, out.String(), "summary:")
+	// the objects are removed after the run
+	exist, err := cm.Exist(context.Background(), runner.objectPath(0))
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
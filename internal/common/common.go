@@ -35,6 +35,10 @@ const (
 	// TimeStampField is the ID of the Timestamp field reserved by the system
 	TimeStampField = 1
 
+	// DeletionVectorFieldID is the field ID of the deltalog holding the deletion vector of a sealed segment,
+	// it's reserved by the system to distinguish from the plain deltalogs
+	DeletionVectorFieldID = 2
+
 	// RowIDFieldName defines the name of the RowID field
 	RowIDFieldName = "RowID"
 
@@ -86,4 +90,23 @@ const (
 
 const (
 	CollectionTTLConfigKey = "collection.ttl.seconds"
+	// CollectionShardRoutingKey decides how primary keys are routed to the shards of a collection
+	CollectionShardRoutingKey = "collection.shard.routing"
+	// CollectionPayloadCompressionKey decides the codec of the scalar payloads of the insert binlogs of a collection
+	CollectionPayloadCompressionKey = "collection.payload.compression"
+)
+
+// ReadOnlyReplicaKey is the search and query param targeting the read-only replicas, which serve the sealed segments
+// as of a snapshot. It's also the grpc metadata of GetShardLeaders returning the leaders of the read-only replicas,
+// which are excluded otherwise.
+const ReadOnlyReplicaKey = "read_only_replica"
+
+// Shard routing of collection
+
+const (
+	// ShardRoutingModulo routes a primary key to `hash(pk) % shardNum`, it's the default routing
+	ShardRoutingModulo = "modulo"
+	// ShardRoutingJumpHash routes a primary key with jump consistent hash,
+	// so adding a shard only remaps 1/shardNum of the primary keys
+	ShardRoutingJumpHash = "jump_hash"
 )
@@ -38,3 +38,18 @@ struct LoadDeletedRecordInfo {
     const milvus::IdArray* primary_keys = nullptr;
     int64_t row_count = -1;
 };
+
+// dictionary-encoded string field data, the dictionary is sorted and the codes are the positions in it
+struct LoadDictionaryFieldDataInfo {
+    int64_t field_id;
+    const milvus::proto::schema::StringArray* dictionary = nullptr;
+    const int32_t* codes = nullptr;
+    int64_t row_count = -1;
+};
+
+// offsets and timestamps of the rows deleted by the deletion vector
+struct LoadDeletionVectorInfo {
+    const int64_t* offsets = nullptr;
+    const uint64_t* timestamps = nullptr;
+    int64_t row_count = -1;
+};
@@ -76,6 +76,14 @@ typedef struct CLoadFieldDataInfo {
     int64_t row_count;
 } CLoadFieldDataInfo;
 
+typedef struct CLoadDictionaryFieldDataInfo {
+    int64_t field_id;
+    const uint8_t* dictionary;
+    uint64_t dictionary_size;
+    const int32_t* codes;
+    int64_t row_count;
+} CLoadDictionaryFieldDataInfo;
+
 typedef struct CLoadDeletedRecordInfo {
     void* timestamps;
     const uint8_t* primary_keys;
@@ -83,6 +91,12 @@ typedef struct CLoadDeletedRecordInfo {
     int64_t row_count;
 } CLoadDeletedRecordInfo;
 
+typedef struct CLoadDeletionVectorInfo {
+    const int64_t* offsets;
+    const uint64_t* timestamps;
+    int64_t row_count;
+} CLoadDeletionVectorInfo;
+
 typedef struct CStorageConfig {
     const char* address;
     const char* bucket_name;
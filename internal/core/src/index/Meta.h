@@ -30,6 +30,8 @@ constexpr const char* PREFIX_VALUE = "prefix_value";
 // below configurations will be persistent, do not edit them.
 constexpr const char* MARISA_TRIE_INDEX = "marisa_trie_index";
 constexpr const char* MARISA_STR_IDS = "marisa_trie_str_ids";
+constexpr const char* DICTIONARY_VALUES = "dictionary_values";
+constexpr const char* DICTIONARY_CODES = "dictionary_codes";
 
 constexpr const char* INDEX_TYPE = "index_type";
 constexpr const char* INDEX_MODE = "index_mode";
@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#include <algorithm>
+#include <cstring>
+
+#include <pb/schema.pb.h>
+
+#include "index/StringDictionaryIndex.h"
+#include "index/Meta.h"
+#include "common/Slice.h"
+#include "common/Utils.h"
+#include "exceptions/EasyAssert.h"
+
+namespace milvus::index {
+
+void
+StringDictionaryIndex::Build(size_t n, const std::string* values) {
+    if (built_) {
+        throw std::runtime_error("index has been built");
+    }
+
+    std::vector<std::string> dictionary(values, values + n);
+    std::sort(dictionary.begin(), dictionary.end());
+    dictionary.erase(std::unique(dictionary.begin(), dictionary.end()), dictionary.end());
+    dictionary_ = std::move(dictionary);
+
+    codes_.resize(n);
+    for (size_t i = 0; i < n; ++i) {
+        codes_[i] = lookup(values[i]);
+    }
+    built_ = true;
+}
+
+void
+StringDictionaryIndex::BuildEncoded(std::vector<std::string>&& dictionary, const int32_t* codes, size_t n) {
+    if (built_) {
+        throw std::runtime_error("index has been built");
+    }
+
+    for (size_t i = 1; i < dictionary.size(); ++i) {
+        AssertInfo(dictionary[i - 1] < dictionary[i], "dictionary is not sorted or has duplicated values");
+    }
+    auto cardinality = int32_t(dictionary.size());
+    for (size_t i = 0; i < n; ++i) {
+        AssertInfo(codes[i] >= 0 && codes[i] < cardinality, "dictionary code out of range");
+    }
+    dictionary_ = std::move(dictionary);
+    codes_.assign(codes, codes + n);
+    built_ = true;
+}
+
+BinarySet
+StringDictionaryIndex::Serialize(const Config& config) {
+    AssertInfo(built_, "index has not been built");
+
+    proto::schema::StringArray arr;
+    for (const auto& value : dictionary_) {
+        arr.add_data(value);
+    }
+    auto values_size = arr.ByteSizeLong();
+    std::shared_ptr<uint8_t[]> values(new uint8_t[values_size]);
+    arr.SerializeToArray(values.get(), values_size);
+
+    auto codes_size = codes_.size() * sizeof(int32_t);
+    std::shared_ptr<uint8_t[]> codes(new uint8_t[codes_size]);
+    memcpy(codes.get(), codes_.data(), codes_size);
+
+    BinarySet res_set;
+    res_set.Append(DICTIONARY_VALUES, values, values_size);
+    res_set.Append(DICTIONARY_CODES, codes, codes_size);
+
+    milvus::Disassemble(res_set);
+
+    return res_set;
+}
+
+void
+StringDictionaryIndex::Load(const BinarySet& set, const Config& config) {
+    milvus::Assemble(const_cast<BinarySet&>(set));
+
+    auto values = set.GetByName(DICTIONARY_VALUES);
+    proto::schema::StringArray arr;
+    arr.ParseFromArray(values->data.get(), values->size);
+    std::vector<std::string> dictionary(arr.data().begin(), arr.data().end());
+
+    auto codes = set.GetByName(DICTIONARY_CODES);
+    auto n = codes->size / sizeof(int32_t);
+    BuildEncoded(std::move(dictionary), reinterpret_cast<const int32_t*>(codes->data.get()), n);
+}
+
+int32_t
+StringDictionaryIndex::lookup(const std::string& value) const {
+    auto it = std::lower_bound(dictionary_.begin(), dictionary_.end(), value);
+    if (it == dictionary_.end() || *it != value) {
+        return -1;
+    }
+    return int32_t(it - dictionary_.begin());
+}
+
+TargetBitmapPtr
+StringDictionaryIndex::match_code_range(size_t begin, size_t end) const {
+    TargetBitmapPtr bitset = std::make_unique<TargetBitmap>(codes_.size());
+    if (begin >= end) {
+        return bitset;
+    }
+    for (size_t offset = 0; offset < codes_.size(); ++offset) {
+        auto code = size_t(codes_[offset]);
+        if (code >= begin && code < end) {
+            bitset->set(offset);
+        }
+    }
+    return bitset;
+}
+
+TargetBitmapPtr
+StringDictionaryIndex::match_codes(const std::vector<bool>& matched) const {
+    TargetBitmapPtr bitset = std::make_unique<TargetBitmap>(codes_.size());
+    for (size_t offset = 0; offset < codes_.size(); ++offset) {
+        if (matched[codes_[offset]]) {
+            bitset->set(offset);
+        }
+    }
+    return bitset;
+}
+
+const TargetBitmapPtr
+StringDictionaryIndex::In(size_t n, const std::string* values) {
+    AssertInfo(built_, "index has not been built");
+    std::vector<bool> matched(dictionary_.size(), false);
+    for (size_t i = 0; i < n; ++i) {
+        auto code = lookup(values[i]);
+        if (code >= 0) {
+            matched[code] = true;
+        }
+    }
+    return match_codes(matched);
+}
+
+const TargetBitmapPtr
+StringDictionaryIndex::NotIn(size_t n, const std::string* values) {
+    AssertInfo(built_, "index has not been built");
+    std::vector<bool> matched(dictionary_.size(), true);
+    for (size_t i = 0; i < n; ++i) {
+        auto code = lookup(values[i]);
+        if (code >= 0) {
+            matched[code] = false;
+        }
+    }
+    return match_codes(matched);
+}
+
+const TargetBitmapPtr
+StringDictionaryIndex::Range(std::string value, OpType op) {
+    AssertInfo(built_, "index has not been built");
+    auto lb = size_t(std::lower_bound(dictionary_.begin(), dictionary_.end(), value) - dictionary_.begin());
+    auto ub = size_t(std::upper_bound(dictionary_.begin(), dictionary_.end(), value) - dictionary_.begin());
+    switch (op) {
+        case OpType::LessThan:
+            return match_code_range(0, lb);
+        case OpType::LessEqual:
+            return match_code_range(0, ub);
+        case OpType::GreaterThan:
+            return match_code_range(ub, dictionary_.size());
+        case OpType::GreaterEqual:
+            return match_code_range(lb, dictionary_.size());
+        default:
+            throw std::invalid_argument(std::string("Invalid OperatorType: ") + std::to_string((int)op) + "!");
+    }
+}
+
+const TargetBitmapPtr
+StringDictionaryIndex::Range(std::string lower_bound_value,
+                             bool lb_inclusive,
+                             std::string upper_bound_value,
+                             bool ub_inclusive) {
+    AssertInfo(built_, "index has not been built");
+    if (lower_bound_value.compare(upper_bound_value) > 0 ||
+        (lower_bound_value.compare(upper_bound_value) == 0 && !(lb_inclusive && ub_inclusive))) {
+        return std::make_unique<TargetBitmap>(codes_.size());
+    }
+    auto begin = lb_inclusive ? std::lower_bound(dictionary_.begin(), dictionary_.end(), lower_bound_value)
+                              : std::upper_bound(dictionary_.begin(), dictionary_.end(), lower_bound_value);
+    auto end = ub_inclusive ? std::upper_bound(dictionary_.begin(), dictionary_.end(), upper_bound_value)
+                            : std::lower_bound(dictionary_.begin(), dictionary_.end(), upper_bound_value);
+    return match_code_range(begin - dictionary_.begin(), end - dictionary_.begin());
+}
+
+const TargetBitmapPtr
+StringDictionaryIndex::PrefixMatch(std::string prefix) {
+    AssertInfo(built_, "index has not been built");
+    // the values with the prefix are adjacent in the sorted dictionary
+    auto begin = std::lower_bound(dictionary_.begin(), dictionary_.end(), prefix);
+    auto end = begin;
+    while (end != dictionary_.end() && milvus::PrefixMatch(*end, prefix)) {
+        ++end;
+    }
+    return match_code_range(begin - dictionary_.begin(), end - dictionary_.begin());
+}
+
+std::string
+StringDictionaryIndex::Reverse_Lookup(size_t offset) const {
+    AssertInfo(offset < codes_.size(), "out of range of total count");
+    return dictionary_[codes_[offset]];
+}
+
+}  // namespace milvus::index
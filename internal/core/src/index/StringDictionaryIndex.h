@@ -0,0 +1,108 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+#pragma once
+
+#include <memory>
+#include <string>
+#include <vector>
+
+#include "index/StringIndex.h"
+
+namespace milvus::index {
+
+// StringDictionaryIndex keeps a dictionary-encoded string column: the distinct values in ascending order
+// and the code of every row, which is the position of its value in the dictionary.
+// Since the dictionary is sorted, the predicates are evaluated on the dictionary once,
+// and the rows are matched by their codes without touching the strings.
+class StringDictionaryIndex : public StringIndex {
+ public:
+    StringDictionaryIndex() = default;
+
+    int64_t
+    Size() override {
+        return codes_.size();
+    }
+
+    BinarySet
+    Serialize(const Config& config) override;
+
+    void
+    Load(const BinarySet& set, const Config& config = {}) override;
+
+    int64_t
+    Count() override {
+        return codes_.size();
+    }
+
+    void
+    Build(size_t n, const std::string* values) override;
+
+    // BuildEncoded takes the dictionary-encoded column as it is,
+    // the dictionary must be sorted without duplications and the codes must be in its range.
+    void
+    BuildEncoded(std::vector<std::string>&& dictionary, const int32_t* codes, size_t n);
+
+    const TargetBitmapPtr
+    In(size_t n, const std::string* values) override;
+
+    const TargetBitmapPtr
+    NotIn(size_t n, const std::string* values) override;
+
+    const TargetBitmapPtr
+    Range(std::string value, OpType op) override;
+
+    const TargetBitmapPtr
+    Range(std::string lower_bound_value, bool lb_inclusive, std::string upper_bound_value, bool ub_inclusive) override;
+
+    const TargetBitmapPtr
+    PrefixMatch(std::string prefix) override;
+
+    std::string
+    Reverse_Lookup(size_t offset) const override;
+
+    size_t
+    Cardinality() const {
+        return dictionary_.size();
+    }
+
+ private:
+    // code of @value, -1 if it's not in the dictionary
+    int32_t
+    lookup(const std::string& value) const;
+
+    // rows whose codes are in [begin, end)
+    TargetBitmapPtr
+    match_code_range(size_t begin, size_t end) const;
+
+    // rows whose codes are set in @matched
+    TargetBitmapPtr
+    match_codes(const std::vector<bool>& matched) const;
+
+ private:
+    std::vector<std::string> dictionary_;
+    std::vector<int32_t> codes_;
+    bool built_ = false;
+};
+
+using StringDictionaryIndexPtr = std::unique_ptr<StringDictionaryIndex>;
+
+inline StringDictionaryIndexPtr
+CreateStringDictionaryIndex() {
+    return std::make_unique<StringDictionaryIndex>();
+}
+
+}  // namespace milvus::index
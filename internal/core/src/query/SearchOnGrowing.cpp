@@ -9,7 +9,9 @@
 // is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
+#include <algorithm>
 #include <cstddef>
+#include <vector>
 #include "common/BitsetView.h"
 #include "common/QueryInfo.h"
 #include "SearchOnGrowing.h"
@@ -18,6 +20,34 @@
 
 namespace milvus::query {
 
+// rows of a spilled chunk read back from disk at once by brute force search
+constexpr int64_t SPILL_READ_BLOCK_ROWS = 4096;
+
+// BruteForceSearchSpilled searches the spilled chunk block by block, so that it's never loaded into memory entirely
+SubSearchResult
+BruteForceSearchSpilled(const dataset::SearchDataset& dataset,
+                        const segcore::VectorBase& vec,
+                        int64_t element_size,
+                        int64_t element_begin,
+                        int64_t element_count,
+                        const BitsetView& bitset) {
+    SubSearchResult result(dataset.num_queries, dataset.topk, dataset.metric_type, dataset.round_decimal);
+    std::vector<uint8_t> buffer(std::min(element_count, SPILL_READ_BLOCK_ROWS) * element_size);
+    for (int64_t offset = 0; offset < element_count; offset += SPILL_READ_BLOCK_ROWS) {
+        auto block_rows = std::min(SPILL_READ_BLOCK_ROWS, element_count - offset);
+        vec.read_spilled(element_begin + offset, block_rows, buffer.data());
+        auto sub_view = bitset.subview(element_begin + offset, block_rows);
+        auto sub_qr = BruteForceSearch(dataset, buffer.data(), block_rows, sub_view);
+        for (auto& x : sub_qr.mutable_seg_offsets()) {
+            if (x != -1) {
+                x += offset;
+            }
+        }
+        result.merge(sub_qr);
+    }
+    return result;
+}
+
 // TODO: small index is disabled, however 3 unittests still call this API, consider to remove this API
 //   - Query::ExecWithPredicateLoader
 //   - Query::ExecWithPredicate
@@ -114,16 +144,19 @@ SearchOnGrowing(const segcore::SegmentGrowingImpl& segment,
     auto vec_ptr = record.get_field_data_base(vecfield_id);
     auto vec_size_per_chunk = vec_ptr->get_size_per_chunk();
     auto max_chunk = upper_div(active_count, vec_size_per_chunk);
+    int64_t element_size = data_type == DataType::VECTOR_FLOAT ? dim * sizeof(float) : dim / 8;
+    auto guard = vec_ptr->lock_chunks();
 
     for (int chunk_id = current_chunk_id; chunk_id < max_chunk; ++chunk_id) {
-        auto chunk_data = vec_ptr->get_chunk_data(chunk_id);
-
         auto element_begin = chunk_id * vec_size_per_chunk;
         auto element_end = std::min(active_count, (chunk_id + 1) * vec_size_per_chunk);
         auto size_per_chunk = element_end - element_begin;
 
-        auto sub_view = bitset.subview(element_begin, size_per_chunk);
-        auto sub_qr = BruteForceSearch(search_dataset, chunk_data, size_per_chunk, sub_view);
+        auto sub_qr = vec_ptr->is_spilled(chunk_id)
+                          ? BruteForceSearchSpilled(search_dataset, *vec_ptr, element_size, element_begin,
+                                                    size_per_chunk, bitset)
+                          : BruteForceSearch(search_dataset, vec_ptr->get_chunk_data(chunk_id), size_per_chunk,
+                                             bitset.subview(element_begin, size_per_chunk));
 
         // convert chunk uid to segment uid
         for (auto& x : sub_qr.mutable_seg_offsets()) {
@@ -14,6 +14,7 @@
 #include <atomic>
 #include <cassert>
 #include <deque>
+#include <memory>
 #include <mutex>
 #include <string>
 #include <unordered_map>
@@ -28,6 +29,7 @@
 #include "common/Types.h"
 #include "common/Utils.h"
 #include "exceptions/EasyAssert.h"
+#include "segcore/SpillFile.h"
 
 namespace milvus::segcore {
 
@@ -116,8 +118,34 @@ class VectorBase {
     virtual bool
     empty() = 0;
 
+    // spill the chunk to the file of @path and release its memory, returns the bytes released,
+    // 0 if the chunk is spilled already. Only the vector fields could be spilled
+    virtual int64_t
+    spill_chunk(ssize_t chunk_id, const std::string& path) {
+        PanicInfo("spill is only supported by vector fields");
+    }
+
+    // the spilled chunks must be checked with the guard of lock_chunks held
+    virtual bool
+    is_spilled(ssize_t chunk_id) const {
+        return false;
+    }
+
+    // read the elements of a spilled chunk into @dst, with the guard of lock_chunks held
+    virtual void
+    read_spilled(ssize_t element_offset, ssize_t element_count, void* dst) const {
+        PanicInfo("spill is only supported by vector fields");
+    }
+
+    // keeps the chunks from being spilled while the guard is held
+    std::shared_lock<std::shared_mutex>
+    lock_chunks() const {
+        return std::shared_lock<std::shared_mutex>(spill_mutex_);
+    }
+
  protected:
     const int64_t size_per_chunk_;
+    mutable std::shared_mutex spill_mutex_;
 };
 
 template <typename Type, bool is_scalar = false>
@@ -150,6 +178,10 @@ class ConcurrentVectorImpl : public VectorBase {
 
     Span<TraitType>
     get_span(int64_t chunk_id) const {
+        if constexpr (!is_scalar) {
+            auto guard = lock_chunks();
+            AssertInfo(!is_spilled(chunk_id), "span of spilled chunk is not available");
+        }
         auto& chunk = get_chunk(chunk_id);
         if constexpr (is_scalar) {
             return Span<TraitType>(chunk.data(), chunk.size());
@@ -263,9 +295,43 @@ class ConcurrentVectorImpl : public VectorBase {
 
     void
     clear() {
+        std::unique_lock lck(spill_mutex_);
+        spilled_.clear();
         chunks_.clear();
     }
 
+    int64_t
+    spill_chunk(ssize_t chunk_id, const std::string& path) override {
+        if constexpr (is_scalar) {
+            PanicInfo("spill is only supported by vector fields");
+        } else {
+            std::unique_lock lck(spill_mutex_);
+            if (spilled_.count(chunk_id)) {
+                return 0;
+            }
+            Chunk& chunk = chunks_[chunk_id];
+            auto bytes = int64_t(chunk.size() * sizeof(Type));
+            spilled_.emplace(chunk_id, std::make_unique<SpillFile>(path, chunk.data(), bytes));
+            Chunk().swap(chunk);
+            return bytes;
+        }
+    }
+
+    bool
+    is_spilled(ssize_t chunk_id) const override {
+        return spilled_.count(chunk_id) > 0;
+    }
+
+    void
+    read_spilled(ssize_t element_offset, ssize_t element_count, void* dst) const override {
+        auto chunk_id = element_offset / size_per_chunk_;
+        auto chunk_offset = element_offset % size_per_chunk_;
+        AssertInfo(chunk_offset + element_count <= size_per_chunk_, "read spilled elements across chunks");
+        auto iter = spilled_.find(chunk_id);
+        AssertInfo(iter != spilled_.end(), "chunk is not spilled");
+        iter->second->Read(chunk_offset * Dim * sizeof(Type), element_count * Dim * sizeof(Type), dst);
+    }
+
  private:
     void
     fill_chunk(
@@ -284,6 +350,8 @@ class ConcurrentVectorImpl : public VectorBase {
 
  private:
     ThreadSafeVector<Chunk> chunks_;
+    // chunk id -> spilled chunk, guarded by spill_mutex_
+    std::unordered_map<ssize_t, std::unique_ptr<SpillFile>> spilled_;
 };
 
 template <typename Type>
@@ -12,6 +12,7 @@
 #pragma once
 
 #include <memory>
+#include <string>
 #include <vector>
 
 #include "common/LoadInfo.h"
@@ -38,6 +39,11 @@ class SegmentGrowing : public SegmentInternalInterface {
            const Timestamp* timestamps,
            const InsertData* insert_data) = 0;
 
+    // spill the cold chunks of vector fields to files under @dir until @target_bytes are released,
+    // returns the bytes released
+    virtual int64_t
+    Spill(const std::string& dir, int64_t target_bytes) = 0;
+
     // virtual int64_t
     // PreDelete(int64_t size) = 0;
 
@@ -136,9 +136,35 @@ SegmentGrowingImpl::GetMemoryUsageInBytes() const {
     total_bytes += ins_n * (schema_->get_total_sizeof() + 16 + 1);
     int64_t del_n = upper_align(deleted_record_.reserved, chunk_rows);
     total_bytes += del_n * (16 * 2);
+    total_bytes -= spilled_bytes_;
     return total_bytes;
 }
 
+int64_t
+SegmentGrowingImpl::Spill(const std::string& dir, int64_t target_bytes) {
+    std::lock_guard lck(spill_mutex_);
+    // only the full chunks are cold, and the small indexes must be built before the raw data is spilled
+    auto chunk_rows = segcore_config_.get_chunk_rows();
+    auto full_chunks = insert_record_.ack_responder_.GetAck() / chunk_rows;
+    int64_t spilled = 0;
+    for (int64_t chunk_id = 0; chunk_id < full_chunks && spilled < target_bytes; ++chunk_id) {
+        for (auto& [field_id, field_meta] : schema_->get_fields()) {
+            if (!field_meta.is_vector()) {
+                continue;
+            }
+            if (indexing_record_.is_in(field_id) && enable_small_index_ &&
+                chunk_id >= indexing_record_.get_finished_ack()) {
+                continue;
+            }
+            auto path = dir + "/" + std::to_string(id_) + "_" + std::to_string(field_id.get()) + "_" +
+                        std::to_string(chunk_id);
+            spilled += insert_record_.get_field_data_base(field_id)->spill_chunk(chunk_id, path);
+        }
+    }
+    spilled_bytes_ += spilled;
+    return spilled;
+}
+
 void
 SegmentGrowingImpl::LoadDeletedRecord(const LoadDeletedRecordInfo& info) {
     AssertInfo(info.row_count > 0, "The row count of deleted record is 0");
@@ -266,9 +292,14 @@ SegmentGrowingImpl::bulk_subscript_impl(int64_t element_sizeof,
     auto& vec = *vec_ptr;
     std::vector<uint8_t> empty(element_sizeof, 0);
     auto output_base = reinterpret_cast<char*>(output_raw);
+    auto guard = vec.lock_chunks();
     for (int i = 0; i < count; ++i) {
         auto dst = output_base + i * element_sizeof;
         auto offset = seg_offsets[i];
+        if (offset != INVALID_SEG_OFFSET && vec.is_spilled(offset / vec.get_size_per_chunk())) {
+            vec.read_spilled(offset, 1, dst);
+            continue;
+        }
         const uint8_t* src = (offset == INVALID_SEG_OFFSET ? empty.data() : (const uint8_t*)vec.get_element(offset));
         memcpy(dst, src, element_sizeof);
     }
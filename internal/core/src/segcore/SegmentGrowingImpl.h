@@ -11,8 +11,10 @@
 
 #pragma once
 
+#include <atomic>
 #include <deque>
 #include <memory>
+#include <mutex>
 #include <shared_mutex>
 #include <string>
 #include <tbb/concurrent_priority_queue.h>
@@ -58,6 +60,9 @@ class SegmentGrowingImpl : public SegmentGrowing {
     int64_t
     GetMemoryUsageInBytes() const override;
 
+    int64_t
+    Spill(const std::string& dir, int64_t target_bytes) override;
+
     void
     LoadDeletedRecord(const LoadDeletedRecordInfo& info) override;
 
@@ -232,6 +237,10 @@ class SegmentGrowingImpl : public SegmentGrowing {
 
     int64_t id_;
 
+    // bytes of the chunks spilled to disk, spill_mutex_ serializes the spills
+    std::atomic<int64_t> spilled_bytes_ = 0;
+    std::mutex spill_mutex_;
+
  private:
     bool enable_small_index_ = true;
 };
@@ -30,6 +30,13 @@ class SegmentSealed : public SegmentInternalInterface {
     virtual void
     LoadFieldData(const LoadFieldDataInfo& info) = 0;
     virtual void
+    LoadDictionaryFieldData(const LoadDictionaryFieldDataInfo& info) = 0;
+    // slice lengths of the persisted timestamp index, used instead of rebuilding them when the timestamps are loaded
+    virtual void
+    LoadTimestampIndex(std::vector<int64_t> slice_lengths) = 0;
+    virtual void
+    LoadDeletionVector(const LoadDeletionVectorInfo& info) = 0;
+    virtual void
     DropIndex(const FieldId field_id) = 0;
     virtual void
     DropFieldData(const FieldId field_id) = 0;
@@ -10,11 +10,16 @@
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
 #include "SegmentSealedImpl.h"
+
+#include <numeric>
+
 #include "common/Consts.h"
 #include "query/SearchBruteForce.h"
 #include "query/SearchOnSealed.h"
 #include "query/ScalarIndex.h"
+#include "index/StringDictionaryIndex.h"
 #include "Utils.h"
+#include "log/Log.h"
 
 namespace milvus::segcore {
 
@@ -159,10 +164,28 @@ SegmentSealedImpl::LoadFieldData(const LoadFieldDataInfo& info) {
             auto timestamps = reinterpret_cast<const Timestamp*>(info.field_data->scalars().long_data().data().data());
 
             TimestampIndex index;
-            auto min_slice_length = size < 4096 ? 1 : 4096;
-            auto meta = GenerateFakeSlices(timestamps, size, min_slice_length);
-            index.set_length_meta(std::move(meta));
-            index.build_with(timestamps, size);
+            std::vector<int64_t> persisted;
+            {
+                std::shared_lock lck(mutex_);
+                persisted = timestamp_slice_lengths_;
+            }
+            bool built = false;
+            if (!persisted.empty() && std::accumulate(persisted.begin(), persisted.end(), int64_t(0)) == size) {
+                // the persisted slices must still be sorted on the loaded timestamps, otherwise they are rebuilt
+                try {
+                    index.set_length_meta(std::move(persisted));
+                    index.build_with(timestamps, size);
+                    built = true;
+                } catch (std::exception& e) {
+                    LOG_SEGCORE_WARNING_C << "invalid persisted timestamp index of segment " << id_ << ": " << e.what();
+                }
+            }
+            if (!built) {
+                auto min_slice_length = size < 4096 ? 1 : 4096;
+                auto meta = GenerateFakeSlices(timestamps, size, min_slice_length);
+                index.set_length_meta(std::move(meta));
+                index.build_with(timestamps, size);
+            }
 
             // use special index
             std::unique_lock lck(mutex_);
@@ -216,6 +239,50 @@ SegmentSealedImpl::LoadFieldData(const LoadFieldDataInfo& info) {
     update_row_count(info.row_count);
 }
 
+void
+SegmentSealedImpl::LoadDictionaryFieldData(const LoadDictionaryFieldDataInfo& info) {
+    AssertInfo(info.row_count > 0, "The row count of field data is 0");
+    AssertInfo(info.dictionary != nullptr, "Dictionary is null");
+    AssertInfo(info.codes != nullptr, "Dictionary codes is null");
+    auto field_id = FieldId(info.field_id);
+    auto& field_meta = schema_->operator[](field_id);
+    AssertInfo(field_meta.get_data_type() == DataType::VARCHAR, "only varchar field could be dictionary-encoded");
+    AssertInfo(schema_->get_primary_field_id() != field_id, "primary key field could not be dictionary-encoded");
+
+    // the encoded column is kept as a scalar index, so that the expressions are evaluated on the codes
+    auto index = index::CreateStringDictionaryIndex();
+    std::vector<std::string> dictionary(info.dictionary->data().begin(), info.dictionary->data().end());
+    index->BuildEncoded(std::move(dictionary), info.codes, info.row_count);
+
+    std::unique_lock lck(mutex_);
+    AssertInfo(!get_bit(field_data_ready_bitset_, field_id),
+               "dictionary-encoded data can't be loaded when raw data exists at field " +
+                   std::to_string(field_id.get()));
+    AssertInfo(!get_bit(index_ready_bitset_, field_id),
+               "scalar index has been exist at " + std::to_string(field_id.get()));
+    if (row_count_opt_.has_value()) {
+        AssertInfo(row_count_opt_.value() == info.row_count,
+                   "field (" + std::to_string(field_id.get()) + ") data has different row count (" +
+                       std::to_string(info.row_count) + ") than other column's row count (" +
+                       std::to_string(row_count_opt_.value()) + ")");
+    }
+
+    scalar_indexings_[field_id] = std::move(index);
+    set_bit(index_ready_bitset_, field_id, true);
+    update_row_count(info.row_count);
+}
+
+void
+SegmentSealedImpl::LoadTimestampIndex(std::vector<int64_t> slice_lengths) {
+    AssertInfo(!slice_lengths.empty(), "The timestamp index has no slice");
+    for (auto length : slice_lengths) {
+        AssertInfo(length > 0, "The timestamp index has empty slice");
+    }
+    std::unique_lock lck(mutex_);
+    AssertInfo(insert_record_.timestamps_.empty(), "timestamp index must be loaded before timestamps");
+    timestamp_slice_lengths_ = std::move(slice_lengths);
+}
+
 void
 SegmentSealedImpl::LoadDeletedRecord(const LoadDeletedRecordInfo& info) {
     AssertInfo(info.row_count > 0, "The row count of deleted record is 0");
@@ -237,6 +304,28 @@ SegmentSealedImpl::LoadDeletedRecord(const LoadDeletedRecordInfo& info) {
     deleted_record_.ack_responder_.AddSegment(reserved_begin, reserved_begin + size);
 }
 
+void
+SegmentSealedImpl::LoadDeletionVector(const LoadDeletionVectorInfo& info) {
+    AssertInfo(info.row_count > 0, "The row count of deletion vector is 0");
+    AssertInfo(info.offsets, "Deleted offsets is null");
+    AssertInfo(info.timestamps, "Deleted timestamps is null");
+
+    std::unique_lock lck(mutex_);
+    AssertInfo(row_count_opt_.has_value(), "Deletion vector must be loaded after field data");
+    auto num_rows = row_count_opt_.value();
+    auto deletion_vector = std::make_unique<DeletionVector>();
+    deletion_vector->offsets.assign(info.offsets, info.offsets + info.row_count);
+    deletion_vector->timestamps.assign(info.timestamps, info.timestamps + info.row_count);
+    deletion_vector->bitmap.resize(num_rows, false);
+    for (int64_t i = 0; i < info.row_count; ++i) {
+        auto offset = deletion_vector->offsets[i];
+        AssertInfo(offset >= 0 && offset < num_rows, "Deleted offset out of range");
+        deletion_vector->bitmap.set(offset);
+        deletion_vector->max_timestamp = std::max(deletion_vector->max_timestamp, deletion_vector->timestamps[i]);
+    }
+    deletion_vector_ = std::move(deletion_vector);
+}
+
 // internal API: support scalar index only
 int64_t
 SegmentSealedImpl::num_chunk_index(FieldId field_id) const {
@@ -310,8 +399,28 @@ SegmentSealedImpl::get_schema() const {
     return *schema_;
 }
 
+void
+SegmentSealedImpl::mask_with_deletion_vector(BitsetType& bitset, Timestamp timestamp) const {
+    if (deletion_vector_ == nullptr) {
+        return;
+    }
+    auto& deletion_vector = *deletion_vector_;
+    if (timestamp >= deletion_vector.max_timestamp && deletion_vector.bitmap.size() == bitset.size()) {
+        bitset |= deletion_vector.bitmap;
+        return;
+    }
+    // only the deletions before the query timestamp take effect
+    for (size_t i = 0; i < deletion_vector.offsets.size(); ++i) {
+        auto offset = deletion_vector.offsets[i];
+        if (deletion_vector.timestamps[i] <= timestamp && offset < int64_t(bitset.size())) {
+            bitset.set(offset);
+        }
+    }
+}
+
 void
 SegmentSealedImpl::mask_with_delete(BitsetType& bitset, int64_t ins_barrier, Timestamp timestamp) const {
+    mask_with_deletion_vector(bitset, timestamp);
     auto del_barrier = get_barrier(get_deleted_record(), timestamp);
     if (del_barrier == 0) {
         return;
@@ -41,6 +41,12 @@ class SegmentSealedImpl : public SegmentSealed {
     void
     LoadDeletedRecord(const LoadDeletedRecordInfo& info) override;
     void
+    LoadDictionaryFieldData(const LoadDictionaryFieldDataInfo& info) override;
+    void
+    LoadTimestampIndex(std::vector<int64_t> slice_lengths) override;
+    void
+    LoadDeletionVector(const LoadDeletionVectorInfo& info) override;
+    void
     LoadSegmentMeta(const milvus::proto::segcore::LoadSegmentMeta& segment_meta) override;
     void
     DropIndex(const FieldId field_id) override;
@@ -157,6 +163,9 @@ class SegmentSealedImpl : public SegmentSealed {
         return system_ready_count_ == 2;
     }
 
+    void
+    mask_with_deletion_vector(BitsetType& bitset, Timestamp timestamp) const;
+
     const DeletedRecord&
     get_deleted_record() const {
         return deleted_record_;
@@ -187,6 +196,9 @@ class SegmentSealedImpl : public SegmentSealed {
     // TODO: generate index for scalar
     std::optional<int64_t> row_count_opt_;
 
+    // slice lengths of the persisted timestamp index, empty if the index is built from the timestamps
+    std::vector<int64_t> timestamp_slice_lengths_;
+
     // scalar field index
     std::unordered_map<FieldId, index::IndexBasePtr> scalar_indexings_;
     // vector field index
@@ -198,6 +210,15 @@ class SegmentSealedImpl : public SegmentSealed {
     // deleted pks
     mutable DeletedRecord deleted_record_;
 
+    // deleted rows merged into the deletion vector
+    struct DeletionVector {
+        std::vector<int64_t> offsets;
+        std::vector<Timestamp> timestamps;
+        Timestamp max_timestamp = 0;
+        BitsetType bitmap;
+    };
+    std::unique_ptr<DeletionVector> deletion_vector_;
+
     SchemaPtr schema_;
     int64_t id_;
 };
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+
+#include "segcore/SpillFile.h"
+
+#include <fcntl.h>
+#include <unistd.h>
+
+#include <cerrno>
+#include <cstring>
+
+#include "exceptions/EasyAssert.h"
+
+namespace milvus::segcore {
+
+SpillFile::SpillFile(const std::string& path, const void* data, size_t size) : path_(path), size_(size) {
+    fd_ = open(path_.c_str(), O_CREAT | O_TRUNC | O_RDWR, S_IRUSR | S_IWUSR);
+    AssertInfo(fd_ != -1, "failed to create spill file " + path_ + ": " + strerror(errno));
+    auto src = static_cast<const char*>(data);
+    size_t written = 0;
+    while (written < size_) {
+        auto n = pwrite(fd_, src + written, size_ - written, written);
+        if (n == -1 && errno == EINTR) {
+            continue;
+        }
+        if (n <= 0) {
+            auto err = std::string(strerror(errno));
+            close(fd_);
+            unlink(path_.c_str());
+            PanicInfo("failed to write spill file " + path_ + ": " + err);
+        }
+        written += n;
+    }
+}
+
+SpillFile::~SpillFile() {
+    if (fd_ != -1) {
+        close(fd_);
+        unlink(path_.c_str());
+    }
+}
+
+void
+SpillFile::Read(size_t offset, size_t size, void* dst) const {
+    AssertInfo(offset + size <= size_, "read out of the range of spill file " + path_);
+    auto out = static_cast<char*>(dst);
+    size_t read = 0;
+    while (read < size) {
+        auto n = pread(fd_, out + read, size - read, offset + read);
+        if (n == -1 && errno == EINTR) {
+            continue;
+        }
+        AssertInfo(n > 0, "failed to read spill file " + path_ + ": " + strerror(errno));
+        read += n;
+    }
+}
+
+}  // namespace milvus::segcore
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2020 Zilliz. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under the License
+
+
+#pragma once
+
+#include <cstddef>
+#include <string>
+
+namespace milvus::segcore {
+
+// SpillFile keeps a chunk spilled to local disk, the file is removed once the SpillFile is destroyed.
+class SpillFile {
+ public:
+    SpillFile(const std::string& path, const void* data, size_t size);
+
+    ~SpillFile();
+
+    SpillFile(const SpillFile&) = delete;
+    SpillFile&
+    operator=(const SpillFile&) = delete;
+
+    // read @size bytes at @offset of the spilled chunk into @dst
+    void
+    Read(size_t offset, size_t size, void* dst) const;
+
+    size_t
+    Size() const {
+        return size_;
+    }
+
+ private:
+    std::string path_;
+    int fd_ = -1;
+    size_t size_;
+};
+
+}  // namespace milvus::segcore
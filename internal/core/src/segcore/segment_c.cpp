@@ -170,6 +170,17 @@ PreInsert(CSegmentInterface c_segment, int64_t size, int64_t* offset) {
     }
 }
 
+CStatus
+SpillGrowingSegment(CSegmentInterface c_segment, const char* dir, int64_t target_bytes, int64_t* spilled_bytes) {
+    try {
+        auto segment = (milvus::segcore::SegmentGrowing*)c_segment;
+        *spilled_bytes = segment->Spill(std::string(dir), target_bytes);
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
 CStatus
 Delete(CSegmentInterface c_segment,
        int64_t reserved_offset,
@@ -215,6 +226,37 @@ LoadFieldData(CSegmentInterface c_segment, CLoadFieldDataInfo load_field_data_in
     }
 }
 
+CStatus
+LoadDictionaryFieldData(CSegmentInterface c_segment, CLoadDictionaryFieldDataInfo load_info) {
+    try {
+        auto segment_interface = reinterpret_cast<milvus::segcore::SegmentInterface*>(c_segment);
+        auto segment = dynamic_cast<milvus::segcore::SegmentSealed*>(segment_interface);
+        AssertInfo(segment != nullptr, "segment conversion failed");
+        auto dictionary = std::make_unique<milvus::proto::schema::StringArray>();
+        auto suc = dictionary->ParseFromArray(load_info.dictionary, load_info.dictionary_size);
+        AssertInfo(suc, "unmarshal dictionary failed");
+        auto info =
+            LoadDictionaryFieldDataInfo{load_info.field_id, dictionary.get(), load_info.codes, load_info.row_count};
+        segment->LoadDictionaryFieldData(info);
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
+CStatus
+LoadTimestampIndex(CSegmentInterface c_segment, const int64_t* slice_lengths, int64_t num_slices) {
+    try {
+        auto segment_interface = reinterpret_cast<milvus::segcore::SegmentInterface*>(c_segment);
+        auto segment = dynamic_cast<milvus::segcore::SegmentSealed*>(segment_interface);
+        AssertInfo(segment != nullptr, "segment conversion failed");
+        segment->LoadTimestampIndex(std::vector<int64_t>(slice_lengths, slice_lengths + num_slices));
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
 CStatus
 LoadDeletedRecord(CSegmentInterface c_segment, CLoadDeletedRecordInfo deleted_record_info) {
     try {
@@ -232,6 +274,21 @@ LoadDeletedRecord(CSegmentInterface c_segment, CLoadDeletedRecordInfo deleted_re
     }
 }
 
+CStatus
+LoadDeletionVector(CSegmentInterface c_segment, CLoadDeletionVectorInfo deletion_vector_info) {
+    try {
+        auto segment_interface = reinterpret_cast<milvus::segcore::SegmentInterface*>(c_segment);
+        auto segment = dynamic_cast<milvus::segcore::SegmentSealed*>(segment_interface);
+        AssertInfo(segment != nullptr, "segment conversion failed");
+        auto load_info = LoadDeletionVectorInfo{deletion_vector_info.offsets, deletion_vector_info.timestamps,
+                                                deletion_vector_info.row_count};
+        segment->LoadDeletionVector(load_info);
+        return milvus::SuccessCStatus();
+    } catch (std::exception& e) {
+        return milvus::FailureCStatus(UnexpectedError, e.what());
+    }
+}
+
 CStatus
 UpdateSealedSegmentIndex(CSegmentInterface c_segment, CLoadIndexInfo c_load_index_info) {
     try {
@@ -75,13 +75,25 @@ Insert(CSegmentInterface c_segment,
 CStatus
 PreInsert(CSegmentInterface c_segment, int64_t size, int64_t* offset);
 
+CStatus
+SpillGrowingSegment(CSegmentInterface c_segment, const char* dir, int64_t target_bytes, int64_t* spilled_bytes);
+
 //////////////////////////////    interfaces for sealed segment    //////////////////////////////
 CStatus
 LoadFieldData(CSegmentInterface c_segment, CLoadFieldDataInfo load_field_data_info);
 
+CStatus
+LoadDictionaryFieldData(CSegmentInterface c_segment, CLoadDictionaryFieldDataInfo load_info);
+
+CStatus
+LoadTimestampIndex(CSegmentInterface c_segment, const int64_t* slice_lengths, int64_t num_slices);
+
 CStatus
 LoadDeletedRecord(CSegmentInterface c_segment, CLoadDeletedRecordInfo deleted_record_info);
 
+CStatus
+LoadDeletionVector(CSegmentInterface c_segment, CLoadDeletionVectorInfo deletion_vector_info);
+
 CStatus
 UpdateSealedSegmentIndex(CSegmentInterface c_segment, CLoadIndexInfo c_load_index_info);
 
@@ -10,7 +10,9 @@
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
 #include <gtest/gtest.h>
+#include <boost/filesystem.hpp>
 
+#include "query/PlanImpl.h"
 #include "segcore/SegmentGrowing.h"
 #include "segcore/SegmentGrowingImpl.h"
 #include "pb/schema.pb.h"
@@ -83,3 +85,69 @@ TEST(Growing, RealCount) {
     ASSERT_TRUE(status.ok());
     ASSERT_EQ(0, segment->get_real_count());
 }
+
+TEST(Growing, Spill) {
+    auto schema = std::make_shared<Schema>();
+    auto vec = schema->AddDebugField("fakevec", DataType::VECTOR_FLOAT, 16, knowhere::metric::L2);
+    auto pk = schema->AddDebugField("pk", DataType::INT64);
+    schema->set_primary_field_id(pk);
+    auto conf = SegcoreConfig::default_config();
+    conf.set_chunk_rows(1024);
+    auto segment = CreateGrowingSegment(schema, 1, conf);
+    segment->disable_small_index();
+
+    int64_t N = 4000;
+    auto dataset = DataGen(schema, N);
+    segment->PreInsert(N);
+    segment->Insert(0, N, dataset.row_ids_.data(), dataset.timestamps_.data(), dataset.raw_);
+
+    std::string dsl = R"({
+        "bool": {
+            "must": [
+            {
+                "vector": {
+                    "fakevec": {
+                        "metric_type": "L2",
+                        "params": {
+                            "nprobe": 10
+                        },
+                        "query": "$0",
+                        "topk": 5,
+                        "round_decimal": -1
+                    }
+                }
+            }
+            ]
+        }
+    })";
+    auto plan = query::CreatePlan(*schema, dsl);
+    auto ph_group_raw = CreatePlaceholderGroup(5, 16, 1024);
+    auto ph_group = query::ParsePlaceholderGroup(plan.get(), ph_group_raw.SerializeAsString());
+    std::vector<int64_t> offsets = {0, 1023, 1024, 3000, N - 1};
+
+    auto expected = segment->Search(plan.get(), ph_group.get(), MAX_TIMESTAMP);
+    auto expected_vectors = segment->bulk_subscript(vec, offsets.data(), offsets.size());
+    auto memory = segment->GetMemoryUsageInBytes();
+
+    auto dir = boost::filesystem::temp_directory_path() / boost::filesystem::unique_path();
+    boost::filesystem::create_directories(dir);
+    // only the 3 full chunks are spilled
+    auto spilled = segment->Spill(dir.string(), std::numeric_limits<int64_t>::max());
+    ASSERT_EQ(spilled, int64_t(3 * 1024 * 16 * sizeof(float)));
+    ASSERT_EQ(segment->GetMemoryUsageInBytes(), memory - spilled);
+    ASSERT_EQ(segment->Spill(dir.string(), std::numeric_limits<int64_t>::max()), 0);
+
+    auto sr = segment->Search(plan.get(), ph_group.get(), MAX_TIMESTAMP);
+    ASSERT_EQ(sr->seg_offsets_, expected->seg_offsets_);
+    ASSERT_EQ(sr->distances_, expected->distances_);
+    auto vectors = segment->bulk_subscript(vec, offsets.data(), offsets.size());
+    ASSERT_EQ(vectors->vectors().float_vector().data_size(), expected_vectors->vectors().float_vector().data_size());
+    for (int i = 0; i < vectors->vectors().float_vector().data_size(); ++i) {
+        ASSERT_EQ(vectors->vectors().float_vector().data(i), expected_vectors->vectors().float_vector().data(i));
+    }
+
+    // the spill files are removed with the segment
+    segment.reset();
+    ASSERT_TRUE(boost::filesystem::is_empty(dir));
+    boost::filesystem::remove_all(dir);
+}
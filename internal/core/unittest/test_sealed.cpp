@@ -649,6 +649,122 @@ TEST(Sealed, Delete) {
                     reinterpret_cast<const Timestamp*>(new_timestamps.data()));
 }
 
+TEST(Sealed, DeletionVector) {
+    auto dim = 16;
+    auto N = 10;
+    auto metric_type = knowhere::metric::L2;
+    auto schema = std::make_shared<Schema>();
+    schema->AddDebugField("fakevec", DataType::VECTOR_FLOAT, dim, metric_type);
+    auto counter_id = schema->AddDebugField("counter", DataType::INT64);
+    schema->set_primary_field_id(counter_id);
+
+    auto dataset = DataGen(schema, N);
+    auto segment = CreateSealedSegment(schema);
+
+    std::vector<int64_t> offsets{1, 3, 5};
+    std::vector<Timestamp> timestamps{10, 20, 30};
+    LoadDeletionVectorInfo info = {offsets.data(), timestamps.data(), int64_t(offsets.size())};
+    // field data must be loaded before the deletion vector
+    ASSERT_ANY_THROW(segment->LoadDeletionVector(info));
+
+    SealedLoadFieldData(dataset, *segment);
+    segment->LoadDeletionVector(info);
+
+    BitsetType bitset(N, false);
+    segment->mask_with_delete(bitset, N, 30);
+    ASSERT_EQ(bitset.count(), offsets.size());
+    for (auto offset : offsets) {
+        ASSERT_TRUE(bitset[offset]);
+    }
+
+    // time travel, only the deletions before the query timestamp take effect
+    BitsetType travel_bitset(N, false);
+    segment->mask_with_delete(travel_bitset, N, 20);
+    ASSERT_EQ(travel_bitset.count(), 2);
+    ASSERT_FALSE(travel_bitset[5]);
+
+    std::vector<int64_t> invalid_offsets{int64_t(N)};
+    LoadDeletionVectorInfo invalid_info = {invalid_offsets.data(), timestamps.data(), 1};
+    ASSERT_ANY_THROW(segment->LoadDeletionVector(invalid_info));
+}
+
+TEST(Sealed, DictionaryFieldData) {
+    auto dim = 16;
+    auto N = 10;
+    auto metric_type = knowhere::metric::L2;
+    auto schema = std::make_shared<Schema>();
+    schema->AddDebugField("fakevec", DataType::VECTOR_FLOAT, dim, metric_type);
+    auto counter_id = schema->AddDebugField("counter", DataType::INT64);
+    auto str_id = schema->AddDebugField("str", DataType::VARCHAR);
+    schema->set_primary_field_id(counter_id);
+
+    auto dataset = DataGen(schema, N);
+    auto segment = CreateSealedSegment(schema);
+    SealedLoadFieldData(dataset, *segment, {str_id.get()});
+
+    auto strs = dataset.get_col<std::string>(str_id);
+    std::vector<std::string> values(strs.begin(), strs.end());
+    std::sort(values.begin(), values.end());
+    values.erase(std::unique(values.begin(), values.end()), values.end());
+    proto::schema::StringArray dictionary;
+    *dictionary.mutable_data() = {values.begin(), values.end()};
+    std::vector<int32_t> codes(N);
+    for (int i = 0; i < N; ++i) {
+        codes[i] = std::lower_bound(values.begin(), values.end(), strs[i]) - values.begin();
+    }
+
+    LoadDictionaryFieldDataInfo info{str_id.get(), &dictionary, codes.data(), N};
+    segment->LoadDictionaryFieldData(info);
+    ASSERT_TRUE(segment->HasIndex(str_id));
+    auto& index = segment->chunk_scalar_index<std::string>(str_id, 0);
+    for (int i = 0; i < N; ++i) {
+        ASSERT_EQ(index.Reverse_Lookup(i), strs[i]);
+    }
+
+    // loaded only once
+    ASSERT_ANY_THROW(segment->LoadDictionaryFieldData(info));
+    // the primary key is never dictionary-encoded
+    LoadDictionaryFieldDataInfo pk_info{counter_id.get(), &dictionary, codes.data(), N};
+    ASSERT_ANY_THROW(segment->LoadDictionaryFieldData(pk_info));
+}
+
+TEST(Sealed, PersistedTimestampIndex) {
+    auto dim = 16;
+    auto N = 10;
+    auto metric_type = knowhere::metric::L2;
+    auto schema = std::make_shared<Schema>();
+    schema->AddDebugField("fakevec", DataType::VECTOR_FLOAT, dim, metric_type);
+    auto counter_id = schema->AddDebugField("counter", DataType::INT64);
+    schema->set_primary_field_id(counter_id);
+    auto dataset = DataGen(schema, N);
+
+    auto check = [&](const SegmentSealed& segment) {
+        // the timestamps of DataGen are 0, 1, ..., N - 1
+        for (Timestamp ts = 0; ts < N + 1; ++ts) {
+            BitsetType bitset(N, false);
+            segment.mask_with_timestamps(bitset, ts);
+            for (int i = 0; i < N; ++i) {
+                ASSERT_EQ(bitset[i], i > ts);
+            }
+        }
+    };
+
+    auto segment = CreateSealedSegment(schema);
+    ASSERT_ANY_THROW(segment->LoadTimestampIndex({}));
+    ASSERT_ANY_THROW(segment->LoadTimestampIndex({N, 0}));
+    segment->LoadTimestampIndex({N / 2, N - N / 2});
+    SealedLoadFieldData(dataset, *segment);
+    check(*segment);
+    // the timestamps are loaded already
+    ASSERT_ANY_THROW(segment->LoadTimestampIndex({N}));
+
+    // the slices not matching the timestamps are rebuilt
+    segment = CreateSealedSegment(schema);
+    segment->LoadTimestampIndex({N + 1});
+    SealedLoadFieldData(dataset, *segment);
+    check(*segment);
+}
+
 auto
 GenMaxFloatVecs(int N, int dim) {
     std::vector<float> vecs;
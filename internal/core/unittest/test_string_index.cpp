@@ -19,6 +19,7 @@
 
 #define private public
 #include "index/StringIndexMarisa.h"
+#include "index/StringDictionaryIndex.h"
 
 #include "index/IndexFactory.h"
 #include "test_utils/indexbuilder_test_utils.h"
@@ -333,3 +334,72 @@ TEST_F(StringIndexMarisaTest, BaseIndexCodec) {
         }
     }
 }
+
+class StringDictionaryIndexTest : public StringIndexBaseTest {};
+
+TEST_F(StringDictionaryIndexTest, Build) {
+    std::vector<std::string> strings{"b", "a", "c", "a", "b", "a"};
+    auto index = milvus::index::CreateStringDictionaryIndex();
+    index->Build(strings.size(), strings.data());
+    ASSERT_EQ(index->Count(), strings.size());
+    ASSERT_EQ(index->Cardinality(), 3);
+    for (size_t i = 0; i < strings.size(); i++) {
+        ASSERT_EQ(index->Reverse_Lookup(i), strings[i]);
+    }
+    ASSERT_ANY_THROW(index->Build(strings.size(), strings.data()));
+}
+
+TEST_F(StringDictionaryIndexTest, BuildEncoded) {
+    std::vector<int32_t> codes{1, 0, 2, 0};
+    auto index = milvus::index::CreateStringDictionaryIndex();
+    index->BuildEncoded({"a", "b", "c"}, codes.data(), codes.size());
+    ASSERT_EQ(index->Reverse_Lookup(0), "b");
+    ASSERT_EQ(index->Reverse_Lookup(2), "c");
+
+    // the dictionary must be sorted
+    auto unsorted = milvus::index::CreateStringDictionaryIndex();
+    ASSERT_ANY_THROW(unsorted->BuildEncoded({"b", "a"}, codes.data(), 2));
+    // the codes must be in the dictionary
+    auto out_of_range = milvus::index::CreateStringDictionaryIndex();
+    ASSERT_ANY_THROW(out_of_range->BuildEncoded({"a", "b"}, codes.data(), codes.size()));
+}
+
+TEST_F(StringDictionaryIndexTest, Expr) {
+    std::vector<std::string> strings{"apple", "banana", "cherry", "apple", "apricot", "banana"};
+    auto index = milvus::index::CreateStringDictionaryIndex();
+    index->Build(strings.size(), strings.data());
+
+    std::vector<std::string> terms{"apple", "cherry", "durian"};
+    auto in = index->In(terms.size(), terms.data());
+    ASSERT_EQ(in->count(), 3);
+    ASSERT_TRUE(in->test(0) && in->test(2) && in->test(3));
+
+    auto not_in = index->NotIn(terms.size(), terms.data());
+    ASSERT_EQ(not_in->count(), 3);
+
+    ASSERT_EQ(index->Range("banana", milvus::OpType::LessThan)->count(), 3);
+    ASSERT_EQ(index->Range("banana", milvus::OpType::LessEqual)->count(), 5);
+    ASSERT_EQ(index->Range("banana", milvus::OpType::GreaterThan)->count(), 1);
+    ASSERT_EQ(index->Range("banana", milvus::OpType::GreaterEqual)->count(), 3);
+    ASSERT_EQ(index->Range("apricot", true, "banana", false)->count(), 1);
+    ASSERT_EQ(index->Range("apricot", false, "cherry", true)->count(), 3);
+    ASSERT_EQ(index->Range("cherry", true, "apple", true)->count(), 0);
+
+    auto prefix = index->PrefixMatch("ap");
+    ASSERT_EQ(prefix->count(), 3);
+    ASSERT_TRUE(prefix->test(4));
+    ASSERT_EQ(index->PrefixMatch("z")->count(), 0);
+}
+
+TEST_F(StringDictionaryIndexTest, Codec) {
+    auto index = milvus::index::CreateStringDictionaryIndex();
+    index->Build(nb, strs.data());
+    auto binary_set = index->Serialize(nullptr);
+
+    auto copy_index = milvus::index::CreateStringDictionaryIndex();
+    copy_index->Load(binary_set);
+    ASSERT_EQ(copy_index->Count(), nb);
+    for (size_t i = 0; i < nb; i++) {
+        ASSERT_EQ(copy_index->Reverse_Lookup(i), strs[i]);
+    }
+}
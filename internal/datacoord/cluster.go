@@ -19,6 +19,7 @@ package datacoord
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus/internal/log"
@@ -125,6 +126,44 @@ func (c *Cluster) ReCollectSegmentStats(ctx context.Context, nodeID int64) {
 	c.sessionManager.ReCollectSegmentStats(ctx, nodeID)
 }
 
+// SyncSegmentStats asks the DataNode to resend its segment stats and waits for the call to return.
+func (c *Cluster) SyncSegmentStats(ctx context.Context, nodeID int64) ([]int64, error) {
+	return c.sessionManager.SyncSegmentStats(ctx, nodeID)
+}
+
+// FreshSearch searches the insert buffers of the DataNodes watching the channels of the collection by @req,
+// and returns the responses of the DataNodes.
+func (c *Cluster) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) ([]*datapb.FreshSearchResponse, error) {
+	collectionID := req.GetCollectionID()
+	var nodeIDs []int64
+	for _, info := range c.channelManager.GetChannels() {
+		for _, ch := range info.Channels {
+			if ch.CollectionID == collectionID {
+				nodeIDs = append(nodeIDs, info.NodeID)
+				break
+			}
+		}
+	}
+
+	results := make([]*datapb.FreshSearchResponse, len(nodeIDs))
+	errs := make([]error, len(nodeIDs))
+	wg := sync.WaitGroup{}
+	for i, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(i int, nodeID int64) {
+			defer wg.Done()
+			results[i], errs[i] = c.sessionManager.FreshSearch(ctx, nodeID, req)
+		}(i, nodeID)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("fresh search on datanode %d failed: %w", nodeIDs[i], err)
+		}
+	}
+	return results, nil
+}
+
 // GetSessions returns all sessions
 func (c *Cluster) GetSessions() []*Session {
 	return c.sessionManager.GetSessions()
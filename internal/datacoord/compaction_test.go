@@ -293,7 +293,8 @@ func TestCompactionPlanHandler_handleMergeCompactionResult(t *testing.T) {
 	plans := map[int64]*compactionTask{1: task}
 
 	errMeta := &meta{
-		catalog: &datacoord.Catalog{Txn: &saveFailKV{TxnKV: memkv.NewMemoryKV()}},
+		keyLocks: newMetaLockManager(),
+		catalog:  &datacoord.Catalog{Txn: &saveFailKV{TxnKV: memkv.NewMemoryKV()}},
 		segments: &SegmentsInfo{
 			map[int64]*SegmentInfo{
 				seg1.ID: {SegmentInfo: seg1},
@@ -303,7 +304,8 @@ func TestCompactionPlanHandler_handleMergeCompactionResult(t *testing.T) {
 	}
 
 	meta := &meta{
-		catalog: &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
+		keyLocks: newMetaLockManager(),
+		catalog:  &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
 		segments: &SegmentsInfo{
 			map[int64]*SegmentInfo{
 				seg1.ID: {SegmentInfo: seg1},
@@ -451,7 +453,8 @@ func TestCompactionPlanHandler_completeCompaction(t *testing.T) {
 		plans := map[int64]*compactionTask{1: task}
 
 		meta := &meta{
-			catalog: &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
+			keyLocks: newMetaLockManager(),
+			catalog:  &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
 			segments: &SegmentsInfo{
 				map[int64]*SegmentInfo{
 					seg1.ID: {SegmentInfo: seg1},
@@ -546,7 +549,8 @@ func TestCompactionPlanHandler_completeCompaction(t *testing.T) {
 		plans := map[int64]*compactionTask{1: task}
 
 		meta := &meta{
-			catalog: &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
+			keyLocks: newMetaLockManager(),
+			catalog:  &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
 			segments: &SegmentsInfo{
 				map[int64]*SegmentInfo{
 					seg1.ID: {SegmentInfo: seg1},
@@ -706,6 +710,7 @@ func Test_compactionPlanHandler_updateCompaction(t *testing.T) {
 					},
 				},
 				meta: &meta{
+					keyLocks: newMetaLockManager(),
 					segments: &SegmentsInfo{
 						map[int64]*SegmentInfo{
 							1: {SegmentInfo: &datapb.SegmentInfo{ID: 1}},
@@ -786,7 +791,7 @@ func Test_newCompactionPlanHandler(t *testing.T) {
 			args{
 				&SessionManager{},
 				&ChannelManager{},
-				&meta{},
+				&meta{keyLocks: newMetaLockManager()},
 				newMockAllocator(),
 				nil,
 				&SegmentReferenceManager{segmentsLock: map[UniqueID]map[UniqueID]*datapb.SegmentReferenceLock{}},
@@ -795,7 +800,7 @@ func Test_newCompactionPlanHandler(t *testing.T) {
 				plans:      map[int64]*compactionTask{},
 				sessions:   &SessionManager{},
 				chManager:  &ChannelManager{},
-				meta:       &meta{},
+				meta:       &meta{keyLocks: newMetaLockManager()},
 				allocator:  newMockAllocator(),
 				flushCh:    nil,
 				segRefer:   &SegmentReferenceManager{segmentsLock: map[UniqueID]map[UniqueID]*datapb.SegmentReferenceLock{}},
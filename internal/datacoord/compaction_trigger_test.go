@@ -103,6 +103,7 @@ func Test_compactionTrigger_force(t *testing.T) {
 			"test force compaction",
 			fields{
 				&meta{
+					keyLocks: newMetaLockManager(),
 					segments: &SegmentsInfo{
 						map[int64]*SegmentInfo{
 							1: {
@@ -699,6 +700,7 @@ func Test_compactionTrigger_force_maxSegmentLimit(t *testing.T) {
 			"test many segments",
 			fields{
 				&meta{
+					keyLocks: newMetaLockManager(),
 					segments: segmentInfos,
 					collections: map[int64]*collectionInfo{
 						2: {
@@ -834,6 +836,7 @@ func Test_compactionTrigger_noplan(t *testing.T) {
 			"test no plan",
 			fields{
 				&meta{
+					keyLocks: newMetaLockManager(),
 					// 4 segment
 					segments: &SegmentsInfo{
 						map[int64]*SegmentInfo{
@@ -1029,6 +1032,7 @@ func Test_compactionTrigger_smallfiles(t *testing.T) {
 			"test small segment",
 			fields{
 				&meta{
+					keyLocks: newMetaLockManager(),
 					// 4 small segments
 					segments: &SegmentsInfo{
 						map[int64]*SegmentInfo{
@@ -1264,6 +1268,7 @@ func Test_compactionTrigger_noplan_random_size(t *testing.T) {
 			"test rand size segment",
 			fields{
 				&meta{
+					keyLocks: newMetaLockManager(),
 					segments: segmentInfos,
 					collections: map[int64]*collectionInfo{
 						2: {
@@ -1348,7 +1353,7 @@ func Test_compactionTrigger_shouldDoSingleCompaction(t *testing.T) {
 	Params.Init()
 
 	indexCoord := newMockIndexCoord()
-	trigger := newCompactionTrigger(&meta{}, &compactionPlanHandler{}, newMockAllocator(),
+	trigger := newCompactionTrigger(&meta{keyLocks: newMetaLockManager()}, &compactionPlanHandler{}, newMockAllocator(),
 		&SegmentReferenceManager{segmentsLock: map[UniqueID]map[UniqueID]*datapb.SegmentReferenceLock{}}, indexCoord, newMockHandler())
 
 	// Test too many files.
@@ -1473,7 +1478,7 @@ func Test_newCompactionTrigger(t *testing.T) {
 		{
 			"test new trigger",
 			args{
-				&meta{},
+				&meta{keyLocks: newMetaLockManager()},
 				&compactionPlanHandler{},
 				newMockAllocator(),
 			},
@@ -1494,7 +1499,7 @@ func Test_newCompactionTrigger(t *testing.T) {
 func Test_handleSignal(t *testing.T) {
 
 	indexCoord := newMockIndexCoord()
-	got := newCompactionTrigger(&meta{segments: NewSegmentsInfo()}, &compactionPlanHandler{}, newMockAllocator(),
+	got := newCompactionTrigger(&meta{keyLocks: newMetaLockManager(), segments: NewSegmentsInfo()}, &compactionPlanHandler{}, newMockAllocator(),
 		&SegmentReferenceManager{segmentsLock: map[UniqueID]map[UniqueID]*datapb.SegmentReferenceLock{}}, indexCoord, newMockHandler())
 	signal := &compactionSignal{
 		segmentID: 1,
@@ -1505,13 +1510,13 @@ func Test_handleSignal(t *testing.T) {
 }
 
 func Test_allocTs(t *testing.T) {
-	got := newCompactionTrigger(&meta{segments: NewSegmentsInfo()}, &compactionPlanHandler{}, newMockAllocator(),
+	got := newCompactionTrigger(&meta{keyLocks: newMetaLockManager(), segments: NewSegmentsInfo()}, &compactionPlanHandler{}, newMockAllocator(),
 		&SegmentReferenceManager{segmentsLock: map[UniqueID]map[UniqueID]*datapb.SegmentReferenceLock{}}, nil, newMockHandler())
 	ts, err := got.allocTs()
 	assert.NoError(t, err)
 	assert.True(t, ts > 0)
 
-	got = newCompactionTrigger(&meta{segments: NewSegmentsInfo()}, &compactionPlanHandler{}, &FailsAllocator{},
+	got = newCompactionTrigger(&meta{keyLocks: newMetaLockManager(), segments: NewSegmentsInfo()}, &compactionPlanHandler{}, &FailsAllocator{},
 		&SegmentReferenceManager{segmentsLock: map[UniqueID]map[UniqueID]*datapb.SegmentReferenceLock{}}, nil, newMockHandler())
 	ts, err = got.allocTs()
 	assert.Error(t, err)
@@ -1538,7 +1543,7 @@ func Test_getCompactTime(t *testing.T) {
 		},
 	}
 
-	m := &meta{segments: NewSegmentsInfo(), collections: collections}
+	m := &meta{keyLocks: newMetaLockManager(), segments: NewSegmentsInfo(), collections: collections}
 	got := newCompactionTrigger(m, &compactionPlanHandler{}, newMockAllocator(),
 		&SegmentReferenceManager{segmentsLock: map[UniqueID]map[UniqueID]*datapb.SegmentReferenceLock{}}, nil, &ServerHandler{
 			&Server{
@@ -0,0 +1,275 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/management"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+const (
+	ConsistencyCheckRouterPath = "/datacoord/consistency"
+
+	// defaultConsistencyCheckLimit is the max number of files and orphans listed in a report by default
+	defaultConsistencyCheckLimit = 1000
+)
+
+// InconsistencyType is the type of an inconsistency between the segment meta and the storage.
+type InconsistencyType string
+
+const (
+	// InconsistencyMissing is a binlog in the segment meta but not in the storage
+	InconsistencyMissing InconsistencyType = "missing"
+	// InconsistencySizeMismatch is a binlog whose size in the storage differs from the one in the segment meta
+	InconsistencySizeMismatch InconsistencyType = "size_mismatch"
+)
+
+// InconsistentFile is a binlog of a segment inconsistent with the storage.
+type InconsistentFile struct {
+	Key          string            `json:"key"`
+	Type         InconsistencyType `json:"type"`
+	CollectionID UniqueID          `json:"collection_id"`
+	SegmentID    UniqueID          `json:"segment_id"`
+	// ExpectedSize is the size in the segment meta, ActualSize is the size in the storage,
+	// both are only set for the size mismatches
+	ExpectedSize int64 `json:"expected_size,omitempty"`
+	ActualSize   int64 `json:"actual_size,omitempty"`
+}
+
+// InconsistentSegment is a segment with any binlog inconsistent with the storage,
+// which could not be loaded or returns wrong results.
+type InconsistentSegment struct {
+	CollectionID    UniqueID `json:"collection_id"`
+	PartitionID     UniqueID `json:"partition_id"`
+	SegmentID       UniqueID `json:"segment_id"`
+	State           string   `json:"state"`
+	MissingNum      int      `json:"missing_num"`
+	SizeMismatchNum int      `json:"size_mismatch_num"`
+}
+
+// ConsistencyReport is the result of a consistency check, it lists at most Limit files and orphans,
+// while the counts cover all of them. All inconsistent segments are listed, to be quarantined.
+type ConsistencyReport struct {
+	CreatedAt        time.Time              `json:"created_at"`
+	MissingTolerance time.Duration          `json:"missing_tolerance"`
+	SegmentNum       int                    `json:"segment_num"`
+	FileNum          int                    `json:"file_num"`
+	ObjectNum        int                    `json:"object_num"`
+	MissingNum       int                    `json:"missing_num"`
+	SizeMismatchNum  int                    `json:"size_mismatch_num"`
+	Unparsable       int                    `json:"unparsable"`
+	OrphanNum        int                    `json:"orphan_num"`
+	OrphanSize       int64                  `json:"orphan_size"`
+	Limit            int                    `json:"limit"`
+	Segments         []*InconsistentSegment `json:"segments"`
+	Files            []*InconsistentFile    `json:"files"`
+	Orphans          []*OrphanObject        `json:"orphans"`
+}
+
+// consistencyTarget is a binlog of a segment to check, its size is only checked if checkSize is true.
+type consistencyTarget struct {
+	binlog    *datapb.Binlog
+	checkSize bool
+}
+
+// listConsistencyTargets lists the binlogs of the segment. The size of insert logs is the memory size of the rows,
+// so only the sizes of stats logs and delta logs are checked.
+func listConsistencyTargets(segment *SegmentInfo) []consistencyTarget {
+	var targets []consistencyTarget
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			targets = append(targets, consistencyTarget{binlog: binlog})
+		}
+	}
+	for _, fieldBinlogs := range [][]*datapb.FieldBinlog{segment.GetStatslogs(), segment.GetDeltalogs()} {
+		for _, fieldBinlog := range fieldBinlogs {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				targets = append(targets, consistencyTarget{binlog: binlog, checkSize: binlog.GetLogSize() > 0})
+			}
+		}
+	}
+	return targets
+}
+
+// checkConsistency cross-references the binlogs of the segments in meta against the objects in the storage,
+// it reports the binlogs missing or with a wrong size, and the objects not referenced by any segment.
+// The dropped segments are not checked, their binlogs are being removed by the garbage collector.
+func (gc *garbageCollector) checkConsistency(ctx context.Context, limit int) (*ConsistencyReport, error) {
+	report := &ConsistencyReport{
+		CreatedAt:        time.Now(),
+		MissingTolerance: gc.option.missingTolerance,
+		Limit:            limit,
+		Segments:         make([]*InconsistentSegment, 0),
+		Files:            make([]*InconsistentFile, 0),
+		Orphans:          make([]*OrphanObject, 0),
+	}
+
+	// list the objects first, the binlogs saved after listing are found by stat
+	refs := gc.getReferences()
+	objects := make(map[string]int64)
+	for _, prefix := range gc.binlogPrefixes() {
+		err := gc.option.cli.WalkWithPrefix(ctx, prefix, true, func(chunkInfo storage.ChunkObjectInfo) bool {
+			report.ObjectNum++
+			objects[chunkInfo.FilePath] = chunkInfo.Size
+			referenced, err := gc.isReferenced(refs, prefix, chunkInfo.FilePath)
+			if err != nil {
+				report.Unparsable++
+				return true
+			}
+			if referenced {
+				return true
+			}
+
+			age := report.CreatedAt.Sub(chunkInfo.ModifyTime)
+			report.OrphanNum++
+			report.OrphanSize += chunkInfo.Size
+			if len(report.Orphans) < limit {
+				report.Orphans = append(report.Orphans, &OrphanObject{
+					Key:        chunkInfo.FilePath,
+					Size:       chunkInfo.Size,
+					ModifyTime: chunkInfo.ModifyTime,
+					Age:        age,
+					Removable:  age > gc.option.missingTolerance,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	segments := gc.meta.SelectSegments(isSegmentHealthy)
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].GetID() < segments[j].GetID()
+	})
+	for _, segment := range segments {
+		report.SegmentNum++
+		inconsistent := &InconsistentSegment{
+			CollectionID: segment.GetCollectionID(),
+			PartitionID:  segment.GetPartitionID(),
+			SegmentID:    segment.GetID(),
+			State:        segment.GetState().String(),
+		}
+		for _, target := range listConsistencyTargets(segment) {
+			report.FileNum++
+			key := target.binlog.GetLogPath()
+			size, ok := objects[key]
+			if !ok {
+				// the binlogs out of the prefixes, e.g. with the legacy root paths, are checked by stat
+				info, err := gc.option.cli.Stat(ctx, key)
+				if err != nil && !errors.Is(err, storage.ErrNoSuchKey) {
+					return nil, err
+				}
+				ok = err == nil
+				size = info.Size
+			}
+
+			file := &InconsistentFile{
+				Key:          key,
+				CollectionID: segment.GetCollectionID(),
+				SegmentID:    segment.GetID(),
+			}
+			switch {
+			case !ok:
+				file.Type = InconsistencyMissing
+				inconsistent.MissingNum++
+				report.MissingNum++
+			case target.checkSize && size != target.binlog.GetLogSize():
+				file.Type = InconsistencySizeMismatch
+				file.ExpectedSize = target.binlog.GetLogSize()
+				file.ActualSize = size
+				inconsistent.SizeMismatchNum++
+				report.SizeMismatchNum++
+			default:
+				continue
+			}
+			if len(report.Files) < limit {
+				report.Files = append(report.Files, file)
+			}
+		}
+		if inconsistent.MissingNum > 0 || inconsistent.SizeMismatchNum > 0 {
+			report.Segments = append(report.Segments, inconsistent)
+		}
+	}
+
+	log.Info("check consistency of segment meta and storage",
+		zap.Int("segmentNum", report.SegmentNum),
+		zap.Int("fileNum", report.FileNum),
+		zap.Int("objectNum", report.ObjectNum),
+		zap.Int("inconsistentSegmentNum", len(report.Segments)),
+		zap.Int("missingNum", report.MissingNum),
+		zap.Int("sizeMismatchNum", report.SizeMismatchNum),
+		zap.Int("orphanNum", report.OrphanNum),
+		zap.Int64("orphanSize", report.OrphanSize))
+	return report, nil
+}
+
+// registerConsistencyCheckHandlerOnce avoid register http handler multiple times
+var registerConsistencyCheckHandlerOnce sync.Once
+
+func (s *Server) registerConsistencyCheckHandler() {
+	management.Register(&management.HTTPHandler{
+		Path:        ConsistencyCheckRouterPath,
+		HandlerFunc: s.handleConsistencyCheck,
+	})
+}
+
+// handleConsistencyCheck checks the consistency of the segment meta and the storage on GET, the number of listed
+// files and orphans is limited by the "limit" query parameter.
+func (s *Server) handleConsistencyCheck(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.garbageCollector.option.cli == nil {
+		http.Error(w, "chunk manager is not provided", http.StatusServiceUnavailable)
+		return
+	}
+	limit := defaultConsistencyCheckLimit
+	if v := req.URL.Query().Get("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := s.garbageCollector.checkConsistency(req.Context(), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Warn("failed to write consistency report", zap.Error(err))
+	}
+}
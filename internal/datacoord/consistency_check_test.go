@@ -0,0 +1,120 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func Test_garbageCollector_checkConsistency(t *testing.T) {
+	ctx := context.Background()
+	cli := storage.NewMemoryChunkManager(storage.RootPath("files"))
+	require.NoError(t, cli.MultiWrite(ctx, map[string][]byte{
+		"files/insert_log/1/2/100/0/1":  []byte("insert"),
+		"files/stats_log/1/2/100/0/2":   []byte("stats"),
+		"files/delta_log/1/2/100/3":     []byte("delta"),
+		"legacy/insert_log/1/2/103/0/6": []byte("legacy"),
+		// orphans of a segment not in meta
+		"files/insert_log/1/2/200/0/5": []byte("orphan"),
+		"files/insert_log/bad":         []byte("bad"),
+	}))
+
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	addSegment := func(id UniqueID, state commonpb.SegmentState, binlogs, statslogs, deltalogs []*datapb.Binlog) {
+		segment := buildSegment(1, 2, id, "ch", false)
+		segment.State = state
+		segment.Binlogs = []*datapb.FieldBinlog{{FieldID: 0, Binlogs: binlogs}}
+		segment.Statslogs = []*datapb.FieldBinlog{{FieldID: 0, Binlogs: statslogs}}
+		segment.Deltalogs = []*datapb.FieldBinlog{{Binlogs: deltalogs}}
+		require.NoError(t, meta.AddSegment(segment))
+	}
+	// the size of insert logs is the memory size, which is not checked
+	addSegment(100, commonpb.SegmentState_Flushed,
+		[]*datapb.Binlog{{LogPath: "files/insert_log/1/2/100/0/1", LogSize: 1024}},
+		[]*datapb.Binlog{{LogPath: "files/stats_log/1/2/100/0/2", LogSize: 5}},
+		[]*datapb.Binlog{{LogPath: "files/delta_log/1/2/100/3", LogSize: 10}})
+	addSegment(101, commonpb.SegmentState_Flushed,
+		[]*datapb.Binlog{{LogPath: "files/insert_log/1/2/101/0/4"}}, nil, nil)
+	addSegment(102, commonpb.SegmentState_Dropped,
+		[]*datapb.Binlog{{LogPath: "files/insert_log/1/2/102/0/7"}}, nil, nil)
+	addSegment(103, commonpb.SegmentState_Flushed,
+		[]*datapb.Binlog{{LogPath: "legacy/insert_log/1/2/103/0/6"}}, nil, nil)
+
+	gc := newGarbageCollector(meta, newMockHandler(), &SegmentReferenceManager{
+		segmentsLock:    map[UniqueID]map[UniqueID]*datapb.SegmentReferenceLock{},
+		segmentReferCnt: map[UniqueID]int{},
+	}, nil, GcOption{
+		cli:              cli,
+		missingTolerance: time.Hour,
+	})
+
+	report, err := gc.checkConsistency(ctx, defaultConsistencyCheckLimit)
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.SegmentNum)
+	assert.Equal(t, 5, report.FileNum)
+	assert.Equal(t, 5, report.ObjectNum)
+	assert.Equal(t, 1, report.MissingNum)
+	assert.Equal(t, 1, report.SizeMismatchNum)
+	assert.Equal(t, 1, report.Unparsable)
+	assert.Equal(t, 1, report.OrphanNum)
+	assert.EqualValues(t, 6, report.OrphanSize)
+
+	require.Len(t, report.Segments, 2)
+	assert.Equal(t, &InconsistentSegment{
+		CollectionID:    1,
+		PartitionID:     2,
+		SegmentID:       100,
+		State:           commonpb.SegmentState_Flushed.String(),
+		SizeMismatchNum: 1,
+	}, report.Segments[0])
+	assert.EqualValues(t, 101, report.Segments[1].SegmentID)
+	assert.Equal(t, 1, report.Segments[1].MissingNum)
+
+	require.Len(t, report.Files, 2)
+	assert.Equal(t, &InconsistentFile{
+		Key:          "files/delta_log/1/2/100/3",
+		Type:         InconsistencySizeMismatch,
+		CollectionID: 1,
+		SegmentID:    100,
+		ExpectedSize: 10,
+		ActualSize:   5,
+	}, report.Files[0])
+	assert.Equal(t, InconsistencyMissing, report.Files[1].Type)
+	assert.Equal(t, "files/insert_log/1/2/101/0/4", report.Files[1].Key)
+
+	require.Len(t, report.Orphans, 1)
+	assert.Equal(t, "files/insert_log/1/2/200/0/5", report.Orphans[0].Key)
+	assert.False(t, report.Orphans[0].Removable)
+
+	// the lists are limited, while the counts are not
+	report, err = gc.checkConsistency(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.MissingNum)
+	assert.Equal(t, 1, report.SizeMismatchNum)
+	assert.Len(t, report.Files, 1)
+	assert.Len(t, report.Segments, 2)
+}
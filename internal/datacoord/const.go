@@ -22,6 +22,12 @@ const (
 	segmentReferPrefix = "segmentRefer"
 )
 
+// snapshot pin
+const (
+	// snapshotPinPrefix is the prefix of the snapshot pin path
+	snapshotPinPrefix = "snapshotPin"
+)
+
 const (
 	moduleName = "DataCoord"
 )
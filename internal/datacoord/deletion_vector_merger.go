@@ -0,0 +1,291 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/metautil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+	"go.uber.org/zap"
+)
+
+// deletionVectorMerger merges the deltalogs of flushed segments into deletion vectors periodically.
+// A deletion vector marks the deleted rows by their offsets in the segment, it's stored as a deltalog
+// with field id common.DeletionVectorFieldID and replaces the deltalogs merged into it.
+type deletionVectorMerger struct {
+	cli       storage.ChunkManager
+	meta      *meta
+	handler   Handler
+	allocator allocator
+
+	checkInterval  time.Duration
+	minDeltalogNum int
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+	closeCh   chan struct{}
+}
+
+func newDeletionVectorMerger(meta *meta, handler Handler, allocator allocator, cli storage.ChunkManager) *deletionVectorMerger {
+	return &deletionVectorMerger{
+		cli:            cli,
+		meta:           meta,
+		handler:        handler,
+		allocator:      allocator,
+		checkInterval:  Params.DataCoordCfg.DeletionVectorMergeInterval,
+		minDeltalogNum: Params.DataCoordCfg.DeletionVectorMinDeltalogNum,
+		closeCh:        make(chan struct{}),
+	}
+}
+
+func (m *deletionVectorMerger) start() {
+	if !Params.DataCoordCfg.EnableDeletionVector {
+		return
+	}
+	m.startOnce.Do(func() {
+		m.wg.Add(1)
+		go m.work()
+	})
+}
+
+func (m *deletionVectorMerger) work() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.mergeAll()
+		case <-m.closeCh:
+			log.Info("deletion vector merger quit")
+			return
+		}
+	}
+}
+
+func (m *deletionVectorMerger) close() {
+	m.stopOnce.Do(func() {
+		close(m.closeCh)
+		m.wg.Wait()
+	})
+}
+
+func (m *deletionVectorMerger) mergeAll() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	segments := m.meta.SelectSegments(func(segment *SegmentInfo) bool {
+		return segment.GetState() == commonpb.SegmentState_Flushed &&
+			!segment.isCompacting &&
+			getPlainDeltalogNum(segment) >= m.minDeltalogNum
+	})
+	for _, segment := range segments {
+		select {
+		case <-m.closeCh:
+			return
+		default:
+		}
+		if err := m.merge(ctx, segment.GetID()); err != nil {
+			log.Warn("failed to merge deletion vector", zap.Int64("segmentID", segment.GetID()), zap.Error(err))
+		}
+	}
+}
+
+// merge folds the plain deltalogs of the segment into its deletion vector,
+// the segment is marked as compacting during the merge to keep compaction away.
+func (m *deletionVectorMerger) merge(ctx context.Context, segmentID UniqueID) error {
+	if !m.meta.TrySetSegmentCompacting(segmentID) {
+		return nil
+	}
+	defer m.meta.SetSegmentCompacting(segmentID, false)
+
+	segment := m.meta.GetSegment(segmentID)
+	if segment == nil || segment.GetState() != commonpb.SegmentState_Flushed {
+		return nil
+	}
+	collection, err := m.handler.GetCollection(ctx, segment.GetCollectionID())
+	if err != nil {
+		return err
+	}
+	if collection == nil {
+		return fmt.Errorf("collection %d not found", segment.GetCollectionID())
+	}
+
+	pks, tss, err := m.readPkAndTs(ctx, collection, segment)
+	if err != nil {
+		return err
+	}
+
+	dv := storage.NewDeletionVector(int64(len(pks)))
+	var deltaPaths []string
+	for _, fieldBinlog := range segment.GetDeltalogs() {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if fieldBinlog.GetFieldID() != common.DeletionVectorFieldID {
+				deltaPaths = append(deltaPaths, binlog.GetLogPath())
+				continue
+			}
+			value, err := m.cli.Read(ctx, binlog.GetLogPath())
+			if err != nil {
+				return err
+			}
+			dv, err = storage.NewDeletionVectorCodec().Deserialize(&storage.Blob{Key: binlog.GetLogPath(), Value: value})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(deltaPaths) == 0 {
+		return nil
+	}
+
+	values, err := m.cli.MultiRead(ctx, deltaPaths)
+	if err != nil {
+		return err
+	}
+	blobs := make([]*storage.Blob, 0, len(values))
+	for i, value := range values {
+		blobs = append(blobs, &storage.Blob{Key: deltaPaths[i], Value: value})
+	}
+	_, _, deletes, err := storage.NewDeleteCodec().Deserialize(blobs)
+	if err != nil {
+		return err
+	}
+	count, err := dv.Merge(pks, tss, deletes)
+	if err != nil {
+		return err
+	}
+
+	blob, err := storage.NewDeletionVectorCodec().Serialize(segment.GetCollectionID(), segment.GetPartitionID(), segmentID, dv)
+	if err != nil {
+		return err
+	}
+	logID, err := m.allocator.allocID(ctx)
+	if err != nil {
+		return err
+	}
+	logPath := metautil.BuildDeltaLogPath(m.cli.RootPath(), segment.GetCollectionID(), segment.GetPartitionID(), segmentID, logID)
+	if err := m.cli.Write(ctx, logPath, blob.GetValue()); err != nil {
+		return err
+	}
+
+	dvLog := &datapb.Binlog{
+		EntriesNum: dv.Count(),
+		LogPath:    logPath,
+		LogSize:    int64(len(blob.GetValue())),
+	}
+	dvLog.TimestampFrom, dvLog.TimestampTo = getTimestampRange(dv.DeleteData().Tss)
+	if err := m.meta.UpdateDeletionVector(segmentID, deltaPaths, dvLog); err != nil {
+		return err
+	}
+	log.Info("deletion vector merged", zap.Int64("segmentID", segmentID), zap.Int("deltalogs", len(deltaPaths)),
+		zap.Int64("newDeleted", count), zap.Int64("deleted", dv.Count()), zap.String("path", logPath))
+	return nil
+}
+
+// readPkAndTs reads the primary keys and timestamps of the segment rows in offset order.
+func (m *deletionVectorMerger) readPkAndTs(ctx context.Context, collection *collectionInfo, segment *SegmentInfo) ([]storage.PrimaryKey, []Timestamp, error) {
+	pkField, err := typeutil.GetPrimaryFieldSchema(collection.Schema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var blobs []*storage.Blob
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		if fieldBinlog.GetFieldID() != pkField.GetFieldID() && fieldBinlog.GetFieldID() != common.TimeStampField {
+			continue
+		}
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			value, err := m.cli.Read(ctx, binlog.GetLogPath())
+			if err != nil {
+				return nil, nil, err
+			}
+			blobs = append(blobs, &storage.Blob{Key: binlog.GetLogPath(), Value: value})
+		}
+	}
+	if len(blobs) == 0 {
+		return nil, nil, nil
+	}
+	codec := storage.NewInsertCodec(&etcdpb.CollectionMeta{ID: collection.ID, Schema: collection.Schema})
+	_, _, data, err := codec.Deserialize(blobs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkData, err := storage.GetPkFromInsertData(collection.Schema, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	tsData, err := storage.GetTimestampFromInsertData(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pkData.RowNum() != tsData.RowNum() {
+		return nil, nil, fmt.Errorf("row number of primary keys %d and timestamps %d mismatch", pkData.RowNum(), tsData.RowNum())
+	}
+
+	pks := make([]storage.PrimaryKey, 0, pkData.RowNum())
+	tss := make([]Timestamp, 0, tsData.RowNum())
+	for i := 0; i < pkData.RowNum(); i++ {
+		switch pkData := pkData.(type) {
+		case *storage.Int64FieldData:
+			pks = append(pks, storage.NewInt64PrimaryKey(pkData.Data[i]))
+		case *storage.StringFieldData:
+			pks = append(pks, storage.NewVarCharPrimaryKey(pkData.Data[i]))
+		}
+		tss = append(tss, Timestamp(tsData.Data[i]))
+	}
+	return pks, tss, nil
+}
+
+// getPlainDeltalogNum returns the number of deltalogs not merged into the deletion vector.
+func getPlainDeltalogNum(segment *SegmentInfo) int {
+	num := 0
+	for _, fieldBinlog := range segment.GetDeltalogs() {
+		if fieldBinlog.GetFieldID() != common.DeletionVectorFieldID {
+			num += len(fieldBinlog.GetBinlogs())
+		}
+	}
+	return num
+}
+
+func getTimestampRange(tss []Timestamp) (Timestamp, Timestamp) {
+	if len(tss) == 0 {
+		return 0, 0
+	}
+	from, to := Timestamp(math.MaxUint64), Timestamp(0)
+	for _, ts := range tss {
+		if ts < from {
+			from = ts
+		}
+		if ts > to {
+			to = ts
+		}
+	}
+	return from, to
+}
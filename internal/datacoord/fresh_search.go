@@ -0,0 +1,54 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+)
+
+// FreshSearch forwards the fresh search to the DataNodes watching the channels of the collection,
+// the results of the DataNodes are returned as they are and reduced by the proxy.
+func (s *Server) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	resp := &datapb.FreshSearchResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		},
+	}
+	if s.isClosed() {
+		resp.Status.Reason = serverNotServingErrMsg
+		return resp, nil
+	}
+
+	results, err := s.cluster.FreshSearch(ctx, req)
+	if err != nil {
+		log.Warn("DataCoord fresh search failed", zap.Int64("collectionID", req.GetCollectionID()), zap.Error(err))
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+	for _, result := range results {
+		resp.Results = append(resp.Results, result.GetResults()...)
+		resp.Rows += result.GetRows()
+	}
+	resp.Status.ErrorCode = commonpb.ErrorCode_Success
+	return resp, nil
+}
@@ -18,13 +18,17 @@ package datacoord
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
@@ -41,6 +45,15 @@ const (
 	deltaLogPrefix  = `delta_log`
 )
 
+const (
+	// gcRemoveModeDefault removes files with a plain remove
+	gcRemoveModeDefault = "default"
+	// gcRemoveModeDeleteMarker only leaves delete markers, which requires a versioned bucket
+	gcRemoveModeDeleteMarker = "deleteMarker"
+	// gcRemoveModePermanent removes all versions of files, which requires a versioned bucket
+	gcRemoveModePermanent = "permanent"
+)
+
 // GcOption garbage collection options
 type GcOption struct {
 	cli              storage.ChunkManager // client
@@ -48,6 +61,10 @@ type GcOption struct {
 	checkInterval    time.Duration        // each interval
 	missingTolerance time.Duration        // key missing in meta tolerance time
 	dropTolerance    time.Duration        // dropped segment related key tolerance time
+	removeMode       string               // how files are removed, see gcRemoveMode*
+	pins             *snapshotPinManager  // snapshot pins keeping segments and files
+	reportUsage      bool                 // report the storage usage of collections after each gc
+	rekeyLegacy      bool                 // move the objects of the legacy root paths to the root path
 }
 
 // garbageCollector handles garbage files in object storage
@@ -58,6 +75,8 @@ type garbageCollector struct {
 	handler    Handler
 	segRefer   *SegmentReferenceManager
 	indexCoord types.IndexCoord
+	// reportedCollections are the collections whose storage usage was reported
+	reportedCollections typeutil.UniqueSet
 
 	startOnce sync.Once
 	stopOnce  sync.Once
@@ -68,7 +87,8 @@ type garbageCollector struct {
 // newGarbageCollector create garbage collector with meta and option
 func newGarbageCollector(meta *meta, handler Handler, segRefer *SegmentReferenceManager, indexCoord types.IndexCoord, opt GcOption) *garbageCollector {
 	log.Info("GC with option", zap.Bool("enabled", opt.enabled), zap.Duration("interval", opt.checkInterval),
-		zap.Duration("missingTolerance", opt.missingTolerance), zap.Duration("dropTolerance", opt.dropTolerance))
+		zap.Duration("missingTolerance", opt.missingTolerance), zap.Duration("dropTolerance", opt.dropTolerance),
+		zap.String("removeMode", opt.removeMode))
 	return &garbageCollector{
 		meta:       meta,
 		handler:    handler,
@@ -86,6 +106,10 @@ func (gc *garbageCollector) start() {
 			log.Warn("DataCoord gc enabled, but SSO client is not provided")
 			return
 		}
+		if err := gc.checkRemoveMode(); err != nil {
+			log.Error("DataCoord gc enabled, but remove mode is not supported", zap.String("removeMode", gc.option.removeMode), zap.Error(err))
+			return
+		}
 		gc.startOnce.Do(func() {
 			gc.wg.Add(1)
 			go gc.work()
@@ -93,6 +117,41 @@ func (gc *garbageCollector) start() {
 	}
 }
 
+// checkRemoveMode checks the storage supports the configured remove mode
+func (gc *garbageCollector) checkRemoveMode() error {
+	switch gc.option.removeMode {
+	case "", gcRemoveModeDefault:
+		return nil
+	case gcRemoveModeDeleteMarker, gcRemoveModePermanent:
+		vcm, ok := storage.AsChunkManager[storage.VersionedChunkManager](gc.option.cli)
+		if !ok {
+			return fmt.Errorf("chunk manager %T does not support versioning", gc.option.cli)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		enabled, err := vcm.IsVersioningEnabled(ctx)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			return errors.New("versioning is not enabled on the bucket")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown gc remove mode %s", gc.option.removeMode)
+	}
+}
+
+// removeObject removes the file in the configured remove mode
+func (gc *garbageCollector) removeObject(ctx context.Context, filePath string) error {
+	if gc.option.removeMode == gcRemoveModePermanent {
+		vcm, _ := storage.AsChunkManager[storage.VersionedChunkManager](gc.option.cli)
+		return vcm.RemoveAllVersions(ctx, filePath)
+	}
+	// a plain remove leaves a delete marker on a versioned bucket
+	return gc.option.cli.Remove(ctx, filePath)
+}
+
 // work contains actual looping check logic
 func (gc *garbageCollector) work() {
 	defer gc.wg.Done()
@@ -100,8 +159,17 @@ func (gc *garbageCollector) work() {
 	for {
 		select {
 		case <-ticker:
+			if gc.option.pins != nil {
+				gc.option.pins.removeExpired()
+			}
 			gc.clearEtcd()
 			gc.scan()
+			gc.collectDedup()
+			gc.transitionStorageClass()
+			gc.rekeyLegacyRootPaths()
+			if gc.option.reportUsage {
+				gc.reportStorageUsage()
+			}
 		case <-gc.closeCh:
 			log.Warn("garbage collector quit")
 			return
@@ -116,76 +184,151 @@ func (gc *garbageCollector) close() {
 	})
 }
 
-// scan load meta file info and compares OSS keys
-// if missing found, performs gc cleanup
-func (gc *garbageCollector) scan() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	var (
-		total   = 0
-		valid   = 0
-		missing = 0
+// gcReferences are the binlogs and segments referenced by the segment meta, and the ones pinned by snapshots
+type gcReferences struct {
+	segments typeutil.UniqueSet
+	files    typeutil.Set[string]
+	pinned   *snapshotPins
+}
 
-		segmentMap = typeutil.NewUniqueSet()
-		filesMap   = typeutil.NewSet[string]()
-	)
+func (gc *garbageCollector) getReferences() *gcReferences {
+	refs := &gcReferences{
+		segments: typeutil.NewUniqueSet(),
+		files:    typeutil.NewSet[string](),
+		pinned:   gc.option.pins.pinned(),
+	}
 	segments := gc.meta.GetAllSegmentsUnsafe()
 	for _, segment := range segments {
-		segmentMap.Insert(segment.GetID())
+		refs.segments.Insert(segment.GetID())
 		for _, log := range getLogs(segment) {
-			filesMap.Insert(log.GetLogPath())
+			refs.files.Insert(log.GetLogPath())
+		}
+	}
+	return refs
+}
+
+// reportStorageUsage reports the bytes and objects of the binlogs on the storage of every collection with segments in meta,
+// the metrics of the collections without any segment are removed.
+func (gc *garbageCollector) reportStorageUsage() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	collections := typeutil.NewUniqueSet()
+	for _, segment := range gc.meta.GetAllSegmentsUnsafe() {
+		collections.Insert(segment.GetCollectionID())
+	}
+	for collectionID := range collections {
+		var bytes, objects int64
+		var err error
+		for _, prefix := range gc.binlogPrefixes() {
+			var prefixBytes, prefixObjects int64
+			prefixBytes, prefixObjects, err = gc.option.cli.PrefixSize(ctx, path.Join(prefix, strconv.FormatInt(collectionID, 10))+"/")
+			if err != nil {
+				break
+			}
+			bytes += prefixBytes
+			objects += prefixObjects
+		}
+		if err != nil {
+			log.Warn("failed to get the storage usage of collection", zap.Int64("collectionID", collectionID), zap.Error(err))
+			continue
+		}
+		metrics.DataCoordCollectionStorageUsage.WithLabelValues(strconv.FormatInt(collectionID, 10)).Set(float64(bytes))
+		metrics.DataCoordCollectionStorageObjects.WithLabelValues(strconv.FormatInt(collectionID, 10)).Set(float64(objects))
+	}
+	for collectionID := range gc.reportedCollections {
+		if !collections.Contain(collectionID) {
+			metrics.DataCoordCollectionStorageUsage.DeleteLabelValues(strconv.FormatInt(collectionID, 10))
+			metrics.DataCoordCollectionStorageObjects.DeleteLabelValues(strconv.FormatInt(collectionID, 10))
 		}
 	}
+	gc.reportedCollections = collections
+}
 
+// binlogPrefixes returns the prefixes of the binlogs managed by the segment meta
+func (gc *garbageCollector) binlogPrefixes() []string {
 	// walk only data cluster related prefixes
 	prefixes := make([]string, 0, 3)
 	prefixes = append(prefixes, path.Join(gc.option.cli.RootPath(), insertLogPrefix))
 	prefixes = append(prefixes, path.Join(gc.option.cli.RootPath(), statsLogPrefix))
 	prefixes = append(prefixes, path.Join(gc.option.cli.RootPath(), deltaLogPrefix))
-	var removedKeys []string
+	return prefixes
+}
 
-	for _, prefix := range prefixes {
-		infoKeys, modTimes, err := gc.option.cli.ListWithPrefix(ctx, prefix, true)
-		if err != nil {
-			log.Error("failed to list files with prefix",
-				zap.String("prefix", prefix),
-				zap.String("error", err.Error()),
-			)
-		}
-		for i, infoKey := range infoKeys {
-			total++
-			_, has := filesMap[infoKey]
-			if has {
-				valid++
-				continue
+// isReferenced checks whether the binlog under the prefix is still in use,
+// returns error if the segment id can't be parsed from the key
+func (gc *garbageCollector) isReferenced(refs *gcReferences, prefix string, key string) (bool, error) {
+	if refs.files.Contain(key) || refs.pinned.files.Contain(key) {
+		return true, nil
+	}
+	// the meta may keep the keys with the legacy root paths
+	if acm, ok := storage.AsChunkManager[*storage.AliasChunkManager](gc.option.cli); ok {
+		for _, alias := range acm.Aliases(key) {
+			if refs.files.Contain(alias) || refs.pinned.files.Contain(alias) {
+				return true, nil
 			}
+		}
+	}
+
+	segmentID, err := storage.ParseSegmentIDByBinlog(gc.option.cli.RootPath(), key)
+	if err != nil {
+		return false, err
+	}
+
+	if gc.segRefer.HasSegmentLock(segmentID) || refs.pinned.segments.Contain(segmentID) {
+		return true, nil
+	}
+
+	if strings.Contains(prefix, statsLogPrefix) &&
+		refs.segments.Contain(segmentID) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// scan load meta file info and compares OSS keys
+// if missing found, performs gc cleanup
+func (gc *garbageCollector) scan() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		total   = 0
+		valid   = 0
+		missing = 0
+		locked  = 0
+	)
+	refs := gc.getReferences()
+	var removedKeys []string
 
-			segmentID, err := storage.ParseSegmentIDByBinlog(gc.option.cli.RootPath(), infoKey)
+	for _, prefix := range gc.binlogPrefixes() {
+		err := gc.option.cli.WalkWithPrefix(ctx, prefix, true, func(chunkInfo storage.ChunkObjectInfo) bool {
+			infoKey := chunkInfo.FilePath
+			total++
+			referenced, err := gc.isReferenced(refs, prefix, infoKey)
 			if err != nil {
 				missing++
 				log.Warn("parse segment id error",
 					zap.String("infoKey", infoKey),
 					zap.Error(err))
-				continue
+				return true
 			}
-
-			if gc.segRefer.HasSegmentLock(segmentID) {
+			if referenced {
 				valid++
-				continue
-			}
-
-			if strings.Contains(prefix, statsLogPrefix) &&
-				segmentMap.Contain(segmentID) {
-				valid++
-				continue
+				return true
 			}
 
 			// not found in meta, check last modified time exceeds tolerance duration
-			if time.Since(modTimes[i]) > gc.option.missingTolerance {
+			if time.Since(chunkInfo.ModifyTime) > gc.option.missingTolerance {
 				// ignore error since it could be cleaned up next time
+				err = gc.removeObject(ctx, infoKey)
+				// the object under retention or legal hold is removed after the lock expires
+				if errors.Is(err, storage.ErrObjectLocked) {
+					locked++
+					log.Debug("object is locked, skip removing it", zap.String("infoKey", infoKey))
+					return true
+				}
 				removedKeys = append(removedKeys, infoKey)
-				err = gc.option.cli.Remove(ctx, infoKey)
 				if err != nil {
 					missing++
 					log.Error("failed to remove object",
@@ -193,21 +336,90 @@ func (gc *garbageCollector) scan() {
 						zap.Error(err))
 				}
 			}
+			return true
+		})
+		if err != nil {
+			log.Error("failed to walk files with prefix",
+				zap.String("prefix", prefix),
+				zap.String("error", err.Error()),
+			)
 		}
 	}
 	log.Info("scan file to do garbage collection",
 		zap.Int("total", total),
 		zap.Int("valid", valid),
 		zap.Int("missing", missing),
+		zap.Int("locked", locked),
 		zap.Strings("removedKeys", removedKeys))
 }
 
+// collectDedup removes the deduplicated contents without references if the storage deduplicates objects,
+// the references are removed along with the binlogs by scan and clearEtcd
+func (gc *garbageCollector) collectDedup() {
+	dcm, ok := storage.AsChunkManager[*storage.DedupChunkManager](gc.option.cli)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stats, err := dcm.CollectGarbage(ctx, gc.option.missingTolerance)
+	if err != nil {
+		log.Warn("failed to collect deduplicated objects", zap.Error(err))
+		return
+	}
+	log.Info("collect deduplicated objects",
+		zap.Int("blobs", stats.Blobs),
+		zap.Int("references", stats.References),
+		zap.Int("removedReferences", stats.RemovedRefs),
+		zap.Int("removedBlobs", stats.RemovedBlobs))
+}
+
+// transitionStorageClass moves the aged objects to the storage classes of the storage class policy,
+// if the storage supports it
+func (gc *garbageCollector) transitionStorageClass() {
+	transitioner, ok := storage.AsChunkManager[storage.StorageClassTransitioner](gc.option.cli)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	transitioned, err := transitioner.TransitionStorageClass(ctx, gc.option.cli.RootPath())
+	if err != nil {
+		log.Warn("failed to transition storage class", zap.Int("transitioned", transitioned), zap.Error(err))
+		return
+	}
+	if transitioned > 0 {
+		log.Info("transition storage class", zap.Int("transitioned", transitioned))
+	}
+}
+
+// rekeyLegacyRootPaths moves the objects of the legacy root paths to the root path if enabled,
+// the keys kept in the meta with the legacy root paths are read from the root path by their aliases.
+func (gc *garbageCollector) rekeyLegacyRootPaths() {
+	if !gc.option.rekeyLegacy {
+		return
+	}
+	acm, ok := storage.AsChunkManager[*storage.AliasChunkManager](gc.option.cli)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	moved, err := acm.Rekey(ctx)
+	if err != nil {
+		log.Warn("failed to rekey objects of legacy root paths", zap.Int("moved", moved), zap.Error(err))
+	}
+}
+
 func (gc *garbageCollector) clearEtcd() {
 	all := gc.meta.SelectSegments(func(si *SegmentInfo) bool { return true })
 	drops := make(map[int64]*SegmentInfo, 0)
 	compactTo := make(map[int64]*SegmentInfo)
+	// the segments dropped by compaction are kept until the snapshots pinning them are done
+	pinned := gc.option.pins.pinned()
 	for _, segment := range all {
-		if segment.GetState() == commonpb.SegmentState_Dropped && !gc.segRefer.HasSegmentLock(segment.ID) {
+		if segment.GetState() == commonpb.SegmentState_Dropped && !gc.segRefer.HasSegmentLock(segment.ID) &&
+			!pinned.segments.Contain(segment.ID) {
 			drops[segment.GetID()] = segment
 			continue
 		}
@@ -272,7 +484,13 @@ func (gc *garbageCollector) removeLogs(logs []*datapb.Binlog) bool {
 	defer cancel()
 	delFlag := true
 	for _, l := range logs {
-		err := gc.option.cli.Remove(ctx, l.GetLogPath())
+		err := gc.removeObject(ctx, l.GetLogPath())
+		if errors.Is(err, storage.ErrObjectLocked) {
+			// keep the segment meta so that the locked logs are removed after the lock expires
+			log.Info("binlog is locked, skip removing it", zap.String("path", l.GetLogPath()))
+			delFlag = false
+			continue
+		}
 		if err != nil {
 			switch err.(type) {
 			case minio.ErrorResponse:
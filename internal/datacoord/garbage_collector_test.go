@@ -27,6 +27,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/storage"
@@ -34,6 +35,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -90,6 +92,77 @@ func Test_garbageCollector_basic(t *testing.T) {
 		})
 	})
 
+	t.Run("check remove mode", func(t *testing.T) {
+		gc := newGarbageCollector(meta, newMockHandler(), segRefer, indexCoord, GcOption{
+			cli:              cli,
+			enabled:          true,
+			checkInterval:    time.Millisecond * 10,
+			missingTolerance: time.Hour * 24,
+			dropTolerance:    time.Hour * 24,
+		})
+		assert.NoError(t, gc.checkRemoveMode())
+
+		// versioning is not enabled on the ut bucket
+		gc.option.removeMode = gcRemoveModeDeleteMarker
+		assert.Error(t, gc.checkRemoveMode())
+		gc.option.removeMode = gcRemoveModePermanent
+		assert.Error(t, gc.checkRemoveMode())
+
+		gc.option.removeMode = "unknown"
+		assert.Error(t, gc.checkRemoveMode())
+		assert.NotPanics(t, func() {
+			gc.start()
+			gc.close()
+		})
+	})
+
+	t.Run("collect dedup", func(t *testing.T) {
+		ctx := context.Background()
+		dcm := storage.NewDedupChunkManager(storage.NewLocalChunkManager(storage.RootPath(t.TempDir())), storage.Dedup(true, 1))
+		require.NoError(t, dcm.Write(ctx, "insert_log/1", []byte("content")))
+		require.NoError(t, dcm.Remove(ctx, "insert_log/1"))
+
+		gc := newGarbageCollector(meta, newMockHandler(), segRefer, indexCoord, GcOption{
+			cli:              dcm,
+			enabled:          true,
+			checkInterval:    time.Millisecond * 10,
+			missingTolerance: 0,
+			dropTolerance:    time.Hour * 24,
+		})
+		// the blob is removed in the second round since it stays unreferenced
+		gc.collectDedup()
+		stats, err := dcm.CollectGarbage(ctx, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, stats.RemovedBlobs)
+	})
+
+	t.Run("report storage usage", func(t *testing.T) {
+		ctx := context.Background()
+		lcm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+		require.NoError(t, lcm.Write(ctx, path.Join(lcm.RootPath(), insertLogPrefix, "100/1/2/3"), []byte("content")))
+		require.NoError(t, lcm.Write(ctx, path.Join(lcm.RootPath(), deltaLogPrefix, "100/1/2/4"), []byte("delta")))
+		require.NoError(t, lcm.Write(ctx, path.Join(lcm.RootPath(), insertLogPrefix, "1000/1/2/3"), []byte("other")))
+		require.NoError(t, meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{ID: 1, CollectionID: 100})))
+
+		gc := newGarbageCollector(meta, newMockHandler(), segRefer, indexCoord, GcOption{
+			cli:              lcm,
+			enabled:          true,
+			checkInterval:    time.Millisecond * 10,
+			missingTolerance: time.Hour * 24,
+			dropTolerance:    time.Hour * 24,
+			reportUsage:      true,
+		})
+		gc.reportStorageUsage()
+		assert.Equal(t, float64(12), testutil.ToFloat64(metrics.DataCoordCollectionStorageUsage.WithLabelValues("100")))
+		assert.Equal(t, float64(2), testutil.ToFloat64(metrics.DataCoordCollectionStorageObjects.WithLabelValues("100")))
+		assert.True(t, gc.reportedCollections.Contain(100))
+
+		// the metrics of the collections without segments are removed
+		require.NoError(t, meta.DropSegment(1))
+		gc.reportStorageUsage()
+		assert.Equal(t, 0, testutil.CollectAndCount(metrics.DataCoordCollectionStorageUsage))
+		assert.Empty(t, gc.reportedCollections)
+	})
 }
 
 func validateMinioPrefixElements(t *testing.T, cli *minio.Client, bucketName string, prefix string, elements []string) {
@@ -173,6 +246,44 @@ func Test_garbageCollector_scan(t *testing.T) {
 
 		gc.close()
 	})
+	t.Run("audit orphans", func(t *testing.T) {
+		indexCoord := mocks.NewMockIndexCoord(t)
+		gc := newGarbageCollector(meta, newMockHandler(), segRefer, indexCoord, GcOption{
+			cli:              cli,
+			enabled:          true,
+			checkInterval:    time.Minute * 30,
+			missingTolerance: time.Hour * 24,
+			dropTolerance:    time.Hour * 24,
+		})
+		report, err := gc.auditOrphans(context.TODO(), defaultOrphanAuditLimit)
+		require.NoError(t, err)
+		total := len(inserts) + len(stats) + len(delta)
+		assert.Equal(t, total, report.Total)
+		// the objects with bad path are neither orphans
+		assert.Equal(t, 3, report.Unparsable)
+		assert.Equal(t, total-3, report.OrphanNum)
+		assert.Equal(t, 0, report.RemovableNum)
+		assert.Len(t, report.Orphans, report.OrphanNum)
+		var size int64
+		for _, orphan := range report.Orphans {
+			assert.False(t, orphan.Removable)
+			size += orphan.Size
+		}
+		assert.Equal(t, report.OrphanSize, size)
+
+		gc.option.missingTolerance = 0
+		report, err = gc.auditOrphans(context.TODO(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, total-3, report.OrphanNum)
+		assert.Equal(t, total-3, report.RemovableNum)
+		assert.Len(t, report.Orphans, 1)
+
+		// nothing is removed
+		validateMinioPrefixElements(t, cli.Client, bucketName, path.Join(rootPath, insertLogPrefix), inserts)
+		validateMinioPrefixElements(t, cli.Client, bucketName, path.Join(rootPath, statsLogPrefix), stats)
+		validateMinioPrefixElements(t, cli.Client, bucketName, path.Join(rootPath, deltaLogPrefix), delta)
+		validateMinioPrefixElements(t, cli.Client, bucketName, path.Join(rootPath, `indexes`), others)
+	})
 	t.Run("hit, no gc", func(t *testing.T) {
 		segment := buildSegment(1, 10, 100, "ch", false)
 		segment.State = commonpb.SegmentState_Flushed
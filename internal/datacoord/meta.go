@@ -38,6 +38,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/lock"
 	"github.com/milvus-io/milvus/internal/util/metautil"
 	"github.com/milvus-io/milvus/internal/util/timerecord"
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
@@ -45,8 +46,15 @@ import (
 	"go.uber.org/zap"
 )
 
+// metaLockKey is locked exclusively by the updates across collections, and shared by the others.
+const metaLockKey = "meta"
+
+// meta guards its in-memory state with the embedded RWMutex, which is only held briefly.
+// The updates persisting segments hold the keys of keyLocks instead during the catalog operations,
+// so that the updates on different segments or collections don't wait for each other.
 type meta struct {
 	sync.RWMutex
+	keyLocks     *lock.LockManager
 	ctx          context.Context
 	catalog      metastore.DataCoordCatalog
 	collections  map[UniqueID]*collectionInfo       // collection id to collection info
@@ -66,8 +74,9 @@ type collectionInfo struct {
 // NewMeta creates meta from provided `kv.TxnKV`
 func newMeta(ctx context.Context, kv kv.TxnKV, chunkManagerRootPath string, chunkManager storage.ChunkManager) (*meta, error) {
 	mt := &meta{
+		keyLocks:     newMetaLockManager(),
 		ctx:          ctx,
-		catalog:      &datacoord.Catalog{Txn: kv, ChunkManagerRootPath: chunkManagerRootPath},
+		catalog:      &datacoord.Catalog{Txn: kv, ChunkManagerRootPath: chunkManagerRootPath, CompactPaths: Params.CommonCfg.MetaCompactPaths},
 		collections:  make(map[UniqueID]*collectionInfo),
 		segments:     NewSegmentsInfo(),
 		channelCPs:   make(map[string]*internalpb.MsgPosition),
@@ -80,6 +89,48 @@ func newMeta(ctx context.Context, kv kv.TxnKV, chunkManagerRootPath string, chun
 	return mt, nil
 }
 
+func newMetaLockManager() *lock.LockManager {
+	return lock.NewLockManager(typeutil.DataCoordRole + "_meta")
+}
+
+func collectionLockKey(collectionID UniqueID) string {
+	return fmt.Sprintf("collection-%d", collectionID)
+}
+
+func segmentLockKey(segmentID UniqueID) string {
+	return fmt.Sprintf("segment-%d", segmentID)
+}
+
+// lockSegment locks the segment exclusively for the updates persisting only this segment.
+func (m *meta) lockSegment(collectionID, segmentID UniqueID) (*lock.Guard, error) {
+	return m.keyLocks.Acquire(m.ctx,
+		lock.SharedKey(metaLockKey),
+		lock.SharedKey(collectionLockKey(collectionID)),
+		lock.ExclusiveKey(segmentLockKey(segmentID)))
+}
+
+// lockCollection locks the collection exclusively for the updates persisting many segments of it.
+func (m *meta) lockCollection(collectionID UniqueID) (*lock.Guard, error) {
+	return m.keyLocks.Acquire(m.ctx,
+		lock.SharedKey(metaLockKey),
+		lock.ExclusiveKey(collectionLockKey(collectionID)))
+}
+
+// lockMeta locks the whole meta exclusively for the updates across collections.
+func (m *meta) lockMeta() (*lock.Guard, error) {
+	return m.keyLocks.Acquire(m.ctx, lock.ExclusiveKey(metaLockKey))
+}
+
+// getCollectionIDOfSegment returns the collection id of the segment, or 0 if the segment is not found.
+func (m *meta) getCollectionIDOfSegment(segmentID UniqueID) UniqueID {
+	m.RLock()
+	defer m.RUnlock()
+	if segment := m.segments.GetSegment(segmentID); segment != nil {
+		return segment.GetCollectionID()
+	}
+	return 0
+}
+
 // reloadFromKV loads meta from KV storage
 func (m *meta) reloadFromKV() error {
 	record := timerecord.NewTimeRecorder("datacoord")
@@ -237,15 +288,20 @@ func (m *meta) GetTotalBinlogSize() int64 {
 func (m *meta) AddSegment(segment *SegmentInfo) error {
 	log.Info("meta update: adding segment",
 		zap.Int64("segment ID", segment.GetID()))
-	m.Lock()
-	defer m.Unlock()
+	guard, err := m.lockSegment(segment.GetCollectionID(), segment.GetID())
+	if err != nil {
+		return err
+	}
+	defer guard.Release()
 	if err := m.catalog.AddSegment(m.ctx, segment.SegmentInfo); err != nil {
 		log.Error("meta update: adding segment failed",
 			zap.Int64("segment ID", segment.GetID()),
 			zap.Error(err))
 		return err
 	}
+	m.Lock()
 	m.segments.SetSegment(segment.GetID(), segment)
+	m.Unlock()
 	metrics.DataCoordNumSegments.WithLabelValues(segment.GetState().String()).Inc()
 	log.Info("meta update: adding segment - complete",
 		zap.Int64("segment ID", segment.GetID()))
@@ -256,9 +312,12 @@ func (m *meta) AddSegment(segment *SegmentInfo) error {
 func (m *meta) DropSegment(segmentID UniqueID) error {
 	log.Info("meta update: dropping segment",
 		zap.Int64("segment ID", segmentID))
-	m.Lock()
-	defer m.Unlock()
-	segment := m.segments.GetSegment(segmentID)
+	guard, err := m.lockSegment(m.getCollectionIDOfSegment(segmentID), segmentID)
+	if err != nil {
+		return err
+	}
+	defer guard.Release()
+	segment := m.GetSegmentUnsafe(segmentID)
 	if segment == nil {
 		log.Warn("meta update: dropping segment failed - segment not found",
 			zap.Int64("segment ID", segmentID))
@@ -271,7 +330,9 @@ func (m *meta) DropSegment(segmentID UniqueID) error {
 		return err
 	}
 	metrics.DataCoordNumSegments.WithLabelValues(metrics.DropedSegmentLabel).Inc()
+	m.Lock()
 	m.segments.DropSegment(segmentID)
+	m.Unlock()
 	log.Info("meta update: dropping segment - complete",
 		zap.Int64("segment ID", segmentID))
 	return nil
@@ -310,9 +371,12 @@ func (m *meta) SetState(segmentID UniqueID, targetState commonpb.SegmentState) e
 	log.Info("meta update: setting segment state",
 		zap.Int64("segment ID", segmentID),
 		zap.Any("target state", targetState))
-	m.Lock()
-	defer m.Unlock()
-	curSegInfo := m.segments.GetSegment(segmentID)
+	guard, err := m.lockSegment(m.getCollectionIDOfSegment(segmentID), segmentID)
+	if err != nil {
+		return err
+	}
+	defer guard.Release()
+	curSegInfo := m.GetSegmentUnsafe(segmentID)
 	if curSegInfo == nil {
 		log.Warn("meta update: setting segment state - segment not found",
 			zap.Int64("segment ID", segmentID),
@@ -342,7 +406,9 @@ func (m *meta) SetState(segmentID UniqueID, targetState commonpb.SegmentState) e
 		}
 	}
 	// Update in-memory meta.
+	m.Lock()
 	m.segments.SetState(segmentID, targetState)
+	m.Unlock()
 	log.Info("meta update: setting segment state - complete",
 		zap.Int64("segment ID", segmentID),
 		zap.String("target state", targetState.String()))
@@ -353,9 +419,12 @@ func (m *meta) SetState(segmentID UniqueID, targetState commonpb.SegmentState) e
 func (m *meta) UnsetIsImporting(segmentID UniqueID) error {
 	log.Info("meta update: unsetting isImport state of segment",
 		zap.Int64("segment ID", segmentID))
-	m.Lock()
-	defer m.Unlock()
-	curSegInfo := m.segments.GetSegment(segmentID)
+	guard, err := m.lockSegment(m.getCollectionIDOfSegment(segmentID), segmentID)
+	if err != nil {
+		return err
+	}
+	defer guard.Release()
+	curSegInfo := m.GetSegmentUnsafe(segmentID)
 	if curSegInfo == nil {
 		return fmt.Errorf("segment not found %d", segmentID)
 	}
@@ -371,7 +440,9 @@ func (m *meta) UnsetIsImporting(segmentID UniqueID) error {
 		}
 	}
 	// Update in-memory meta.
+	m.Lock()
 	m.segments.SetIsImporting(segmentID, false)
+	m.Unlock()
 	log.Info("meta update: unsetting isImport state of segment - complete",
 		zap.Int64("segment ID", segmentID))
 	return nil
@@ -399,6 +470,13 @@ func (m *meta) UpdateFlushSegmentsInfo(
 		zap.Any("check points", checkpoints),
 		zap.Any("start position", startPositions),
 		zap.Bool("importing", importing))
+	// the checkpoints and start positions are of the segments of the same channel,
+	// so the whole collection is locked
+	guard, err := m.lockCollection(m.getCollectionIDOfSegment(segmentID))
+	if err != nil {
+		return err
+	}
+	defer guard.Release()
 	m.Lock()
 	defer m.Unlock()
 
@@ -538,6 +616,11 @@ func (m *meta) UpdateFlushSegmentsInfo(
 func (m *meta) UpdateDropChannelSegmentInfo(channel string, segments []*SegmentInfo) error {
 	log.Info("meta update: update drop channel segment info",
 		zap.String("channel", channel))
+	guard, err := m.lockMeta()
+	if err != nil {
+		return err
+	}
+	defer guard.Release()
 	m.Lock()
 	defer m.Unlock()
 	modSegments := make(map[UniqueID]*SegmentInfo)
@@ -564,7 +647,7 @@ func (m *meta) UpdateDropChannelSegmentInfo(channel string, segments []*SegmentI
 			originSegments[seg.GetID()] = seg
 		}
 	}
-	err := m.batchSaveDropSegments(channel, modSegments)
+	err = m.batchSaveDropSegments(channel, modSegments)
 	if err == nil {
 		for _, seg := range originSegments {
 			state := seg.GetState()
@@ -787,6 +870,54 @@ func (m *meta) GetNumRowsOfPartition(collectionID UniqueID, partitionID UniqueID
 	return ret
 }
 
+// RowCountStats holds the row counts of a collection or partition grouped by segment state
+type RowCountStats struct {
+	// rows of Flushing & Flushed segments
+	Flushed int64
+	// rows reported by DataNodes of Growing & Sealed segments which are not flushed yet
+	Growing int64
+	// rows deleted by the delta logs of Flushing & Flushed segments
+	Deleted int64
+}
+
+// GetRowCountStats returns the row counts of healthy segments belongs to provided collection & partition,
+// all partitions are counted if partitionID is allPartitionID
+func (m *meta) GetRowCountStats(collectionID UniqueID, partitionID UniqueID) *RowCountStats {
+	m.RLock()
+	defer m.RUnlock()
+	stats := &RowCountStats{}
+	segments := m.segments.GetSegments()
+	for _, segment := range segments {
+		if !isSegmentHealthy(segment) || segment.GetCollectionID() != collectionID ||
+			(partitionID > allPartitionID && segment.GetPartitionID() != partitionID) {
+			continue
+		}
+		switch segment.GetState() {
+		case commonpb.SegmentState_Growing, commonpb.SegmentState_Sealed:
+			stats.Growing += segment.currRows
+		case commonpb.SegmentState_Flushing, commonpb.SegmentState_Flushed:
+			stats.Flushed += segment.GetNumOfRows()
+			for _, fieldBinlog := range segment.GetDeltalogs() {
+				for _, binlog := range fieldBinlog.GetBinlogs() {
+					stats.Deleted += binlog.GetEntriesNum()
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// GetStaleStatsSegments returns the Growing & Sealed segments belongs to provided collection & partition,
+// whose stats are not reported by DataNodes since `before`
+func (m *meta) GetStaleStatsSegments(collectionID UniqueID, partitionID UniqueID, before time.Time) []*SegmentInfo {
+	return m.SelectSegments(func(segment *SegmentInfo) bool {
+		return segment.GetCollectionID() == collectionID &&
+			(partitionID <= allPartitionID || segment.GetPartitionID() == partitionID) &&
+			(segment.GetState() == commonpb.SegmentState_Growing || segment.GetState() == commonpb.SegmentState_Sealed) &&
+			segment.lastStatsTime.Before(before)
+	})
+}
+
 // GetUnFlushedSegments get all segments which state is not `Flushing` nor `Flushed`
 func (m *meta) GetUnFlushedSegments() []*SegmentInfo {
 	m.RLock()
@@ -834,9 +965,12 @@ func (m *meta) AddAllocation(segmentID UniqueID, allocation *Allocation) error {
 	log.Info("meta update: add allocation",
 		zap.Int64("segmentID", segmentID),
 		zap.Any("allocation", allocation))
-	m.Lock()
-	defer m.Unlock()
-	curSegInfo := m.segments.GetSegment(segmentID)
+	guard, err := m.lockSegment(m.getCollectionIDOfSegment(segmentID), segmentID)
+	if err != nil {
+		return err
+	}
+	defer guard.Release()
+	curSegInfo := m.GetSegmentUnsafe(segmentID)
 	if curSegInfo == nil {
 		// TODO: Error handling.
 		log.Warn("meta update: add allocation failed - segment not found",
@@ -854,7 +988,9 @@ func (m *meta) AddAllocation(segmentID UniqueID, allocation *Allocation) error {
 		}
 	}
 	// Update in-memory meta.
+	m.Lock()
 	m.segments.AddAllocation(segmentID, allocation)
+	m.Unlock()
 	log.Info("meta update: add allocation - complete",
 		zap.Int64("segmentID", segmentID))
 	return nil
@@ -892,6 +1028,73 @@ func (m *meta) SetSegmentCompacting(segmentID UniqueID, compacting bool) {
 	m.segments.SetIsCompacting(segmentID, compacting)
 }
 
+// TrySetSegmentCompacting marks the segment as compacting if it's healthy and not compacting,
+// it returns false if the segment can't be marked.
+func (m *meta) TrySetSegmentCompacting(segmentID UniqueID) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	segment := m.segments.GetSegment(segmentID)
+	if !isSegmentHealthy(segment) || segment.isCompacting {
+		return false
+	}
+	m.segments.SetIsCompacting(segmentID, true)
+	return true
+}
+
+// UpdateDeletionVector replaces the deltalogs at @mergedPaths and the previous deletion vector of the segment
+// with the deletion vector @dvLog. The deltalogs added since the merge started are kept.
+func (m *meta) UpdateDeletionVector(segmentID UniqueID, mergedPaths []string, dvLog *datapb.Binlog) error {
+	guard, err := m.lockSegment(m.getCollectionIDOfSegment(segmentID), segmentID)
+	if err != nil {
+		return err
+	}
+	defer guard.Release()
+	m.Lock()
+	defer m.Unlock()
+
+	segment := m.segments.GetSegment(segmentID)
+	if !isSegmentHealthy(segment) {
+		return fmt.Errorf("segment %d not found or unhealthy", segmentID)
+	}
+
+	merged := typeutil.NewSet(mergedPaths...)
+	clonedSegment := segment.Clone()
+	deltalogs := make([]*datapb.FieldBinlog, 0, len(clonedSegment.GetDeltalogs())+1)
+	hasDeletionVector := false
+	for _, fieldBinlog := range clonedSegment.GetDeltalogs() {
+		if fieldBinlog.GetFieldID() == common.DeletionVectorFieldID {
+			hasDeletionVector = true
+			fieldBinlog.Binlogs = []*datapb.Binlog{dvLog}
+			deltalogs = append(deltalogs, fieldBinlog)
+			continue
+		}
+		binlogs := make([]*datapb.Binlog, 0, len(fieldBinlog.GetBinlogs()))
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if !merged.Contain(binlog.GetLogPath()) {
+				binlogs = append(binlogs, binlog)
+			}
+		}
+		// keep the field binlog even if it's empty, so that the persisted one is overwritten
+		fieldBinlog.Binlogs = binlogs
+		deltalogs = append(deltalogs, fieldBinlog)
+	}
+	if !hasDeletionVector {
+		deltalogs = append(deltalogs, &datapb.FieldBinlog{
+			FieldID: common.DeletionVectorFieldID,
+			Binlogs: []*datapb.Binlog{dvLog},
+		})
+	}
+	clonedSegment.Deltalogs = deltalogs
+
+	if err := m.catalog.AlterSegments(m.ctx, []*datapb.SegmentInfo{clonedSegment.SegmentInfo}); err != nil {
+		log.Warn("meta update: failed to update deletion vector", zap.Int64("segmentID", segmentID), zap.Error(err))
+		return err
+	}
+	m.segments.SetSegment(segmentID, clonedSegment)
+	return nil
+}
+
 // PrepareCompleteCompactionMutation returns
 // - the segment info of compactedFrom segments before compaction to revert
 // - the segment info of compactedFrom segments after compaction to alter
@@ -899,6 +1102,11 @@ func (m *meta) SetSegmentCompacting(segmentID UniqueID, compacting bool) {
 // The compactedTo segment could contain 0 numRows
 func (m *meta) PrepareCompleteCompactionMutation(compactionLogs []*datapb.CompactionSegmentBinlogs, result *datapb.CompactionResult) ([]*datapb.SegmentInfo, []*SegmentInfo, *SegmentInfo, error) {
 	log.Info("meta update: prepare for complete compaction mutation")
+	guard, err := m.lockMeta()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer guard.Release()
 	m.Lock()
 	defer m.Unlock()
 
@@ -943,6 +1151,12 @@ func (m *meta) PrepareCompleteCompactionMutation(compactionLogs []*datapb.Compac
 	}
 
 	newAddedDeltalogs := m.updateDeltalogs(originDeltalogs, deletedDeltalogs, nil)
+	for _, fieldBinlog := range newAddedDeltalogs {
+		if fieldBinlog.GetFieldID() == common.DeletionVectorFieldID {
+			// the offsets in deletion vector don't apply to the compacted segment
+			return nil, nil, nil, fmt.Errorf("deletion vector of compacted segments changed during compaction")
+		}
+	}
 	copiedDeltalogs, err := m.copyDeltaFiles(newAddedDeltalogs, modSegments[0].CollectionID, modSegments[0].PartitionID, result.GetSegmentID())
 	if err != nil {
 		return nil, nil, nil, err
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/common"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/util"
+	"github.com/milvus-io/milvus/internal/util/metautil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -679,7 +681,8 @@ func TestMeta_alterMetaStore(t *testing.T) {
 	}
 
 	m := &meta{
-		catalog: &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
+		keyLocks: newMetaLockManager(),
+		catalog:  &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
 		segments: &SegmentsInfo{map[int64]*SegmentInfo{
 			1: {SegmentInfo: &datapb.SegmentInfo{
 				ID:        1,
@@ -699,6 +702,7 @@ func TestMeta_alterMetaStore(t *testing.T) {
 
 func TestMeta_alterInMemoryMetaAfterCompaction(t *testing.T) {
 	m := &meta{
+		keyLocks: newMetaLockManager(),
 		catalog:  &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
 		segments: &SegmentsInfo{make(map[UniqueID]*SegmentInfo)},
 	}
@@ -759,6 +763,7 @@ func TestMeta_PrepareCompleteCompactionMutation(t *testing.T) {
 	}
 
 	m := &meta{
+		keyLocks: newMetaLockManager(),
 		catalog:  &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
 		segments: prepareSegments,
 	}
@@ -854,6 +859,7 @@ func Test_meta_SetSegmentCompacting(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &meta{
+				keyLocks: newMetaLockManager(),
 				catalog:  &datacoord.Catalog{Txn: tt.fields.client},
 				segments: tt.fields.segments,
 			}
@@ -864,6 +870,73 @@ func Test_meta_SetSegmentCompacting(t *testing.T) {
 	}
 }
 
+func Test_meta_TrySetSegmentCompacting(t *testing.T) {
+	m := &meta{
+		keyLocks: newMetaLockManager(),
+		catalog:  &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
+		segments: &SegmentsInfo{
+			map[int64]*SegmentInfo{
+				1: {
+					SegmentInfo: &datapb.SegmentInfo{
+						ID:    1,
+						State: commonpb.SegmentState_Flushed,
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, m.TrySetSegmentCompacting(1))
+	assert.False(t, m.TrySetSegmentCompacting(1))
+	assert.False(t, m.TrySetSegmentCompacting(2))
+	m.SetSegmentCompacting(1, false)
+	assert.True(t, m.TrySetSegmentCompacting(1))
+}
+
+func TestMeta_UpdateDeletionVector(t *testing.T) {
+	deltalogPath := func(logID UniqueID) string {
+		return metautil.BuildDeltaLogPath("files", 10, 100, 1, logID)
+	}
+	m := &meta{
+		keyLocks: newMetaLockManager(),
+		ctx:      context.TODO(),
+		catalog:  &datacoord.Catalog{Txn: memkv.NewMemoryKV()},
+		segments: &SegmentsInfo{
+			map[int64]*SegmentInfo{
+				1: {
+					SegmentInfo: &datapb.SegmentInfo{
+						ID:           1,
+						CollectionID: 10,
+						PartitionID:  100,
+						State:        commonpb.SegmentState_Flushed,
+						Deltalogs:    []*datapb.FieldBinlog{getFieldBinlogPaths(0, deltalogPath(1), deltalogPath(2), deltalogPath(3))},
+					},
+				},
+			},
+		},
+	}
+
+	err := m.UpdateDeletionVector(1, []string{deltalogPath(1), deltalogPath(2)}, &datapb.Binlog{LogPath: deltalogPath(4), EntriesNum: 10})
+	assert.NoError(t, err)
+	deltalogs := m.GetSegment(1).GetDeltalogs()
+	assert.Equal(t, 2, len(deltalogs))
+	assert.Equal(t, 1, len(deltalogs[0].GetBinlogs()))
+	assert.Equal(t, deltalogPath(3), deltalogs[0].GetBinlogs()[0].GetLogPath())
+	assert.EqualValues(t, common.DeletionVectorFieldID, deltalogs[1].GetFieldID())
+	assert.Equal(t, deltalogPath(4), deltalogs[1].GetBinlogs()[0].GetLogPath())
+
+	// the deletion vector is replaced rather than appended
+	err = m.UpdateDeletionVector(1, []string{deltalogPath(3)}, &datapb.Binlog{LogPath: deltalogPath(5), EntriesNum: 12})
+	assert.NoError(t, err)
+	deltalogs = m.GetSegment(1).GetDeltalogs()
+	assert.Equal(t, 2, len(deltalogs))
+	assert.Equal(t, 0, len(deltalogs[0].GetBinlogs()))
+	assert.Equal(t, 1, len(deltalogs[1].GetBinlogs()))
+	assert.Equal(t, deltalogPath(5), deltalogs[1].GetBinlogs()[0].GetLogPath())
+
+	err = m.UpdateDeletionVector(2, nil, &datapb.Binlog{LogPath: deltalogPath(6)})
+	assert.Error(t, err)
+}
+
 func Test_meta_SetSegmentImporting(t *testing.T) {
 	type fields struct {
 		client   kv.TxnKV
@@ -903,6 +976,7 @@ func Test_meta_SetSegmentImporting(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &meta{
+				keyLocks: newMetaLockManager(),
 				catalog:  &datacoord.Catalog{Txn: tt.fields.client},
 				segments: tt.fields.segments,
 			}
@@ -979,6 +1053,7 @@ func Test_meta_GetSegmentsOfCollection(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &meta{
+				keyLocks: newMetaLockManager(),
 				segments: tt.fields.segments,
 			}
 			got := m.GetSegmentsOfCollection(tt.args.collectionID)
@@ -989,6 +1064,7 @@ func Test_meta_GetSegmentsOfCollection(t *testing.T) {
 
 func TestMeta_HasSegments(t *testing.T) {
 	m := &meta{
+		keyLocks: newMetaLockManager(),
 		segments: &SegmentsInfo{
 			segments: map[UniqueID]*SegmentInfo{
 				1: {
@@ -1012,6 +1088,7 @@ func TestMeta_HasSegments(t *testing.T) {
 
 func TestMeta_GetAllSegments(t *testing.T) {
 	m := &meta{
+		keyLocks: newMetaLockManager(),
 		segments: &SegmentsInfo{
 			segments: map[UniqueID]*SegmentInfo{
 				1: {
@@ -1040,6 +1117,132 @@ func TestMeta_GetAllSegments(t *testing.T) {
 	assert.NotNil(t, seg2All)
 }
 
+func TestMeta_GetRowCountStats(t *testing.T) {
+	now := time.Now()
+	m := &meta{
+		keyLocks: newMetaLockManager(),
+		segments: &SegmentsInfo{
+			segments: map[UniqueID]*SegmentInfo{
+				1: {
+					SegmentInfo: &datapb.SegmentInfo{
+						ID:           1,
+						CollectionID: 1,
+						PartitionID:  10,
+						State:        commonpb.SegmentState_Growing,
+					},
+					currRows:      100,
+					lastStatsTime: now,
+				},
+				2: {
+					SegmentInfo: &datapb.SegmentInfo{
+						ID:           2,
+						CollectionID: 1,
+						PartitionID:  11,
+						State:        commonpb.SegmentState_Sealed,
+					},
+					currRows: 50,
+				},
+				3: {
+					SegmentInfo: &datapb.SegmentInfo{
+						ID:           3,
+						CollectionID: 1,
+						PartitionID:  10,
+						State:        commonpb.SegmentState_Flushed,
+						NumOfRows:    1000,
+						Deltalogs: []*datapb.FieldBinlog{
+							{Binlogs: []*datapb.Binlog{{EntriesNum: 5}, {EntriesNum: 7}}},
+						},
+					},
+				},
+				4: {
+					SegmentInfo: &datapb.SegmentInfo{
+						ID:           4,
+						CollectionID: 1,
+						PartitionID:  10,
+						State:        commonpb.SegmentState_Dropped,
+						NumOfRows:    2000,
+					},
+				},
+				5: {
+					SegmentInfo: &datapb.SegmentInfo{
+						ID:           5,
+						CollectionID: 2,
+						PartitionID:  20,
+						State:        commonpb.SegmentState_Flushed,
+						NumOfRows:    3000,
+					},
+				},
+			},
+		},
+	}
+
+	stats := m.GetRowCountStats(1, allPartitionID)
+	assert.Equal(t, &RowCountStats{Flushed: 1000, Growing: 150, Deleted: 12}, stats)
+	stats = m.GetRowCountStats(1, 10)
+	assert.Equal(t, &RowCountStats{Flushed: 1000, Growing: 100, Deleted: 12}, stats)
+	stats = m.GetRowCountStats(3, allPartitionID)
+	assert.Equal(t, &RowCountStats{}, stats)
+
+	stale := m.GetStaleStatsSegments(1, allPartitionID, now.Add(-time.Second))
+	assert.Equal(t, 1, len(stale))
+	assert.EqualValues(t, 2, stale[0].GetID())
+	stale = m.GetStaleStatsSegments(1, allPartitionID, now.Add(time.Second))
+	assert.Equal(t, 2, len(stale))
+	stale = m.GetStaleStatsSegments(1, 10, now.Add(-time.Second))
+	assert.Equal(t, 0, len(stale))
+
+	m.SetCurrentRows(2, 60)
+	stale = m.GetStaleStatsSegments(1, allPartitionID, now)
+	assert.Equal(t, 0, len(stale))
+	assert.Equal(t, int64(160), m.GetRowCountStats(1, allPartitionID).Growing)
+}
+
+func TestMeta_KeyLocks(t *testing.T) {
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	for _, id := range []UniqueID{1, 2} {
+		err = meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{ID: id, CollectionID: 100, State: commonpb.SegmentState_Growing}))
+		require.NoError(t, err)
+	}
+
+	guard, err := meta.lockSegment(100, 1)
+	require.NoError(t, err)
+
+	// the other segments of the collection are not blocked
+	err = meta.SetState(2, commonpb.SegmentState_Sealed)
+	assert.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- meta.SetState(1, commonpb.SegmentState_Sealed)
+	}()
+	select {
+	case <-done:
+		assert.FailNow(t, "the segment update should wait for the segment key")
+	case <-time.After(20 * time.Millisecond):
+	}
+	assert.Equal(t, commonpb.SegmentState_Growing, meta.GetSegment(1).GetState())
+
+	guard.Release()
+	assert.NoError(t, <-done)
+	assert.Equal(t, commonpb.SegmentState_Sealed, meta.GetSegment(1).GetState())
+
+	// the collection updates wait for the segment updates
+	guard, err = meta.lockSegment(100, 2)
+	require.NoError(t, err)
+	go func() {
+		done <- meta.UpdateFlushSegmentsInfo(1, true, false, false, nil, nil, nil, nil, nil)
+	}()
+	select {
+	case <-done:
+		assert.FailNow(t, "the collection update should wait for the segment key")
+	case <-time.After(20 * time.Millisecond):
+	}
+	guard.Release()
+	assert.NoError(t, <-done)
+	assert.Equal(t, commonpb.SegmentState_Flushing, meta.GetSegment(1).GetState())
+}
+
 func TestMeta_isSegmentHealthy_issue17823_panic(t *testing.T) {
 	var seg *SegmentInfo
 
@@ -0,0 +1,166 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/management"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+const (
+	OrphanAuditRouterPath = "/datacoord/gc/orphans"
+
+	// defaultOrphanAuditLimit is the max number of orphans listed in a report by default
+	defaultOrphanAuditLimit = 1000
+)
+
+// OrphanObject is an object not referenced by any segment meta.
+type OrphanObject struct {
+	Key        string        `json:"key"`
+	Size       int64         `json:"size"`
+	ModifyTime time.Time     `json:"modify_time"`
+	Age        time.Duration `json:"age"`
+	// Removable is true if the garbage collector would remove it in the next round,
+	// the object is kept until it exceeds the missing tolerance.
+	Removable bool `json:"removable"`
+}
+
+// OrphanReport is the result of an orphan audit, it lists at most Limit orphans,
+// while the counts and sizes cover all of them.
+type OrphanReport struct {
+	CreatedAt        time.Time       `json:"created_at"`
+	MissingTolerance time.Duration   `json:"missing_tolerance"`
+	Total            int             `json:"total"`
+	Unparsable       int             `json:"unparsable"`
+	OrphanNum        int             `json:"orphan_num"`
+	OrphanSize       int64           `json:"orphan_size"`
+	RemovableNum     int             `json:"removable_num"`
+	RemovableSize    int64           `json:"removable_size"`
+	Limit            int             `json:"limit"`
+	Orphans          []*OrphanObject `json:"orphans"`
+}
+
+// auditOrphans lists the binlogs not referenced by any segment meta without removing them,
+// which is a dry run of scan to validate the garbage collection before enabling it.
+func (gc *garbageCollector) auditOrphans(ctx context.Context, limit int) (*OrphanReport, error) {
+	report := &OrphanReport{
+		CreatedAt:        time.Now(),
+		MissingTolerance: gc.option.missingTolerance,
+		Limit:            limit,
+		Orphans:          make([]*OrphanObject, 0),
+	}
+	refs := gc.getReferences()
+	for _, prefix := range gc.binlogPrefixes() {
+		err := gc.option.cli.WalkWithPrefix(ctx, prefix, true, func(chunkInfo storage.ChunkObjectInfo) bool {
+			report.Total++
+			referenced, err := gc.isReferenced(refs, prefix, chunkInfo.FilePath)
+			if err != nil {
+				// scan never removes the objects without segment id either
+				report.Unparsable++
+				return true
+			}
+			if referenced {
+				return true
+			}
+
+			size, err := gc.option.cli.Size(ctx, chunkInfo.FilePath)
+			if err != nil {
+				log.Warn("failed to get size of orphan object", zap.String("key", chunkInfo.FilePath), zap.Error(err))
+			}
+			age := report.CreatedAt.Sub(chunkInfo.ModifyTime)
+			removable := age > gc.option.missingTolerance
+			report.OrphanNum++
+			report.OrphanSize += size
+			if removable {
+				report.RemovableNum++
+				report.RemovableSize += size
+			}
+			if len(report.Orphans) < limit {
+				report.Orphans = append(report.Orphans, &OrphanObject{
+					Key:        chunkInfo.FilePath,
+					Size:       size,
+					ModifyTime: chunkInfo.ModifyTime,
+					Age:        age,
+					Removable:  removable,
+				})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	log.Info("audit orphan objects",
+		zap.Int("total", report.Total),
+		zap.Int("orphanNum", report.OrphanNum),
+		zap.Int64("orphanSize", report.OrphanSize),
+		zap.Int("removableNum", report.RemovableNum),
+		zap.Int64("removableSize", report.RemovableSize))
+	return report, nil
+}
+
+// registerOrphanAuditHandlerOnce avoid register http handler multiple times
+var registerOrphanAuditHandlerOnce sync.Once
+
+func (s *Server) registerOrphanAuditHandler() {
+	management.Register(&management.HTTPHandler{
+		Path:        OrphanAuditRouterPath,
+		HandlerFunc: s.handleOrphanAudit,
+	})
+}
+
+// handleOrphanAudit reports the orphan objects on GET, the number of listed orphans
+// is limited by the "limit" query parameter.
+func (s *Server) handleOrphanAudit(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.garbageCollector.option.cli == nil {
+		http.Error(w, "chunk manager is not provided", http.StatusServiceUnavailable)
+		return
+	}
+	limit := defaultOrphanAuditLimit
+	if v := req.URL.Query().Get("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := s.garbageCollector.auditOrphans(req.Context(), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Warn("failed to write orphan audit report", zap.Error(err))
+	}
+}
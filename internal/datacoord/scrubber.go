@@ -0,0 +1,92 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"path"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// newScrubber returns a scrubber verifying the binlogs of the flushed segments, nil if it's disabled.
+func newScrubber(meta *meta, cli storage.ChunkManager) *storage.Scrubber {
+	if !Params.DataCoordCfg.EnableScrubber {
+		return nil
+	}
+	prefixes := []string{
+		path.Join(cli.RootPath(), insertLogPrefix),
+		path.Join(cli.RootPath(), statsLogPrefix),
+		path.Join(cli.RootPath(), deltaLogPrefix),
+	}
+	return storage.NewScrubber(cli, prefixes, func(ctx context.Context) ([]storage.ScrubTarget, error) {
+		return listScrubTargets(meta), nil
+	}, Params.DataCoordCfg.ScrubInterval,
+		storage.WithScrubRate(Params.DataCoordCfg.ScrubRate),
+		storage.WithScrubBandwidth(Params.DataCoordCfg.ScrubBandwidth),
+		storage.WithScrubContent(Params.DataCoordCfg.ScrubVerifyContent),
+		storage.WithScrubIssueHandler(func(issue storage.ScrubIssue) {
+			metrics.DataCoordScrubIssues.WithLabelValues(string(issue.Type)).Inc()
+		}))
+}
+
+// listScrubTargets lists the logs of the flushed segments, the growing segments are still being written.
+// The size of insert logs is the memory size of the rows, so their rows are verified instead.
+func listScrubTargets(meta *meta) []storage.ScrubTarget {
+	segments := meta.SelectSegments(func(segment *SegmentInfo) bool {
+		return segment.GetState() == commonpb.SegmentState_Flushed
+	})
+	var targets []storage.ScrubTarget
+	for _, segment := range segments {
+		for _, fieldBinlog := range segment.GetBinlogs() {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				targets = append(targets, storage.ScrubTarget{
+					FilePath: binlog.GetLogPath(),
+					IsBinlog: true,
+					Rows:     binlog.GetEntriesNum(),
+				})
+			}
+		}
+		for _, fieldBinlog := range segment.GetStatslogs() {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				targets = append(targets, storage.ScrubTarget{
+					FilePath: binlog.GetLogPath(),
+					Size:     binlog.GetLogSize(),
+				})
+			}
+		}
+		for _, fieldBinlog := range segment.GetDeltalogs() {
+			// the deletion vector is not a binlog of deleted rows
+			isDeltaBinlog := fieldBinlog.GetFieldID() != common.DeletionVectorFieldID
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				target := storage.ScrubTarget{
+					FilePath: binlog.GetLogPath(),
+					Size:     binlog.GetLogSize(),
+					IsBinlog: isDeltaBinlog,
+				}
+				if isDeltaBinlog {
+					target.Rows = binlog.GetEntriesNum()
+				}
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets
+}
@@ -0,0 +1,71 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/metautil"
+)
+
+func TestListScrubTargets(t *testing.T) {
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	insertLogPath := metautil.BuildInsertLogPath("files", 0, 0, 1, 100, 1)
+	statsLogPath := metautil.BuildStatsLogPath("files", 0, 0, 1, 100, 2)
+	deltaLogPath := metautil.BuildDeltaLogPath("files", 0, 0, 1, 3)
+	deletionVectorPath := metautil.BuildDeltaLogPath("files", 0, 0, 1, 4)
+	require.NoError(t, meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{
+		ID:    1,
+		State: commonpb.SegmentState_Flushed,
+		Binlogs: []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []*datapb.Binlog{
+			{LogPath: insertLogPath, LogSize: 1000, EntriesNum: 10},
+		}}},
+		Statslogs: []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []*datapb.Binlog{
+			{LogPath: statsLogPath, LogSize: 20},
+		}}},
+		Deltalogs: []*datapb.FieldBinlog{
+			{Binlogs: []*datapb.Binlog{{LogPath: deltaLogPath, LogSize: 30, EntriesNum: 3}}},
+			{FieldID: common.DeletionVectorFieldID, Binlogs: []*datapb.Binlog{{LogPath: deletionVectorPath, LogSize: 40, EntriesNum: 4}}},
+		},
+	})))
+	require.NoError(t, meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{
+		ID:    2,
+		State: commonpb.SegmentState_Growing,
+		Binlogs: []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []*datapb.Binlog{
+			{LogPath: metautil.BuildInsertLogPath("files", 0, 0, 2, 100, 5), EntriesNum: 10},
+		}}},
+	})))
+
+	targets := listScrubTargets(meta)
+	assert.ElementsMatch(t, []storage.ScrubTarget{
+		{FilePath: insertLogPath, IsBinlog: true, Rows: 10},
+		{FilePath: statsLogPath, Size: 20},
+		{FilePath: deltaLogPath, Size: 30, IsBinlog: true, Rows: 3},
+		{FilePath: deletionVectorPath, Size: 40},
+	}, targets)
+
+	Params.DataCoordCfg.EnableScrubber = false
+	assert.Nil(t, newScrubber(meta, storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))))
+}
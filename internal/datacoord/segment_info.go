@@ -40,6 +40,8 @@ type SegmentInfo struct {
 	// a cache to avoid calculate twice
 	size            int64
 	lastWrittenTime time.Time
+	// the last time the segment stats were reported by DataNode
+	lastStatsTime time.Time
 }
 
 // NewSegmentInfo create `SegmentInfo` wrapper from `datapb.SegmentInfo`
@@ -204,6 +206,7 @@ func (s *SegmentInfo) Clone(opts ...SegmentInfoOption) *SegmentInfo {
 		isCompacting:  s.isCompacting,
 		//cannot copy size, since binlog may be changed
 		lastWrittenTime: s.lastWrittenTime,
+		lastStatsTime:   s.lastStatsTime,
 	}
 	for _, opt := range opts {
 		opt(cloned)
@@ -221,6 +224,7 @@ func (s *SegmentInfo) ShadowClone(opts ...SegmentInfoOption) *SegmentInfo {
 		isCompacting:    s.isCompacting,
 		size:            s.size,
 		lastWrittenTime: s.lastWrittenTime,
+		lastStatsTime:   s.lastStatsTime,
 	}
 
 	for _, opt := range opts {
@@ -294,6 +298,7 @@ func SetCurrentRows(rows int64) SegmentInfoOption {
 	return func(segment *SegmentInfo) {
 		segment.currRows = rows
 		segment.lastWrittenTime = time.Now()
+		segment.lastStatsTime = segment.lastWrittenTime
 	}
 }
 
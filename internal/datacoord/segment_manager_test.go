@@ -603,6 +603,7 @@ func TestSegmentManager_DropSegmentsOfChannel(t *testing.T) {
 			"test drop segments",
 			fields{
 				meta: &meta{
+					keyLocks: newMetaLockManager(),
 					segments: &SegmentsInfo{
 						segments: map[int64]*SegmentInfo{
 							1: {
@@ -633,6 +634,7 @@ func TestSegmentManager_DropSegmentsOfChannel(t *testing.T) {
 			"test drop segments with dropped segment",
 			fields{
 				meta: &meta{
+					keyLocks: newMetaLockManager(),
 					segments: &SegmentsInfo{
 						segments: map[int64]*SegmentInfo{
 							1: {
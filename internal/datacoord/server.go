@@ -68,6 +68,8 @@ var (
 	ttMaxInterval             = 2 * time.Minute
 	ttCheckerWarnMsg          = fmt.Sprintf("Datacoord haven't received tt for %f minutes", ttMaxInterval.Minutes())
 	segmentTimedFlushDuration = 10.0
+
+	segmentStatsSyncCheckInterval = 50 * time.Millisecond
 )
 
 type (
@@ -107,6 +109,8 @@ type Server struct {
 	channelManager   *ChannelManager
 	rootCoordClient  types.RootCoord
 	garbageCollector *garbageCollector
+	dvMerger         *deletionVectorMerger
+	scrubber         *storage.Scrubber
 	gcOpt            GcOption
 	handler          Handler
 
@@ -131,6 +135,7 @@ type Server struct {
 	indexCoord             types.IndexCoord
 
 	segReferManager *SegmentReferenceManager
+	snapshotPins    *snapshotPinManager
 }
 
 // ServerHelper datacoord server injection helper
@@ -288,7 +293,13 @@ func (s *Server) Init() error {
 	}
 	s.initSegmentManager()
 
+	if s.snapshotPins, err = newSnapshotPinManager(s.kvClient); err != nil {
+		return err
+	}
 	s.initGarbageCollection(storageCli)
+	s.applyStorageLifecycle(storageCli)
+	s.dvMerger = newDeletionVectorMerger(s.meta, s.handler, s.allocator, storageCli)
+	s.scrubber = newScrubber(s.meta, storageCli)
 
 	return nil
 }
@@ -322,6 +333,10 @@ func (s *Server) Start() error {
 		logutil.Logger(s.ctx).Info("DataCoord startup successfully")
 	}
 
+	registerOrphanAuditHandlerOnce.Do(s.registerOrphanAuditHandler)
+	registerConsistencyCheckHandlerOnce.Do(s.registerConsistencyCheckHandler)
+	registerSnapshotPinHandlerOnce.Do(s.registerSnapshotPinHandler)
+
 	Params.DataCoordCfg.CreatedTime = time.Now()
 	Params.DataCoordCfg.UpdatedTime = time.Now()
 
@@ -396,9 +411,32 @@ func (s *Server) initGarbageCollection(cli storage.ChunkManager) {
 		checkInterval:    Params.DataCoordCfg.GCInterval,
 		missingTolerance: Params.DataCoordCfg.GCMissingTolerance,
 		dropTolerance:    Params.DataCoordCfg.GCDropTolerance,
+		removeMode:       Params.DataCoordCfg.GCRemoveMode,
+		pins:             s.snapshotPins,
+		reportUsage:      Params.DataCoordCfg.GCReportStorageUsage,
+		rekeyLegacy:      Params.MinioCfg.LegacyRekey.GetAsBool(),
 	})
 }
 
+// applyStorageLifecycle installs the bucket lifecycle rules derived from the configs if enabled,
+// the failure doesn't fail the data coord, the bucket lifecycle is kept as it is.
+func (s *Server) applyStorageLifecycle(cli storage.ChunkManager) {
+	if !Params.MinioCfg.LifecycleEnabled.GetAsBool() {
+		return
+	}
+	manager, ok := storage.AsChunkManager[storage.LifecycleManager](cli)
+	if !ok {
+		log.Warn("storage doesn't support bucket lifecycle", zap.String("storage", Params.CommonCfg.StorageType))
+		return
+	}
+	changed, err := manager.ApplyLifecycle(s.ctx, Params.DataCoordCfg.GCMissingTolerance)
+	if err != nil {
+		log.Warn("failed to apply bucket lifecycle", zap.Error(err))
+		return
+	}
+	log.Info("bucket lifecycle applied", zap.Bool("changed", changed))
+}
+
 func (s *Server) initServiceDiscovery() error {
 	r := semver.MustParseRange(">=2.1.2")
 	sessions, rev, err := s.session.GetSessionsWithVersionRange(typeutil.DataNodeRole, r)
@@ -476,6 +514,10 @@ func (s *Server) startServerLoop() {
 	s.startWatchService(s.serverLoopCtx)
 	s.startFlushLoop(s.serverLoopCtx)
 	s.garbageCollector.start()
+	s.dvMerger.start()
+	if s.scrubber != nil {
+		s.scrubber.Start()
+	}
 }
 
 // startDataNodeTtLoop start a goroutine to recv data node tt msg from msgstream
@@ -825,6 +867,10 @@ func (s *Server) Stop() error {
 	logutil.Logger(s.ctx).Info("server shutdown")
 	s.cluster.Close()
 	s.garbageCollector.close()
+	s.dvMerger.close()
+	if s.scrubber != nil {
+		s.scrubber.Close()
+	}
 	s.stopServerLoop()
 	s.session.Revoke(time.Second)
 
@@ -919,3 +965,82 @@ func (s *Server) reCollectSegmentStats(ctx context.Context) {
 		s.cluster.ReCollectSegmentStats(ctx, node)
 	}
 }
+
+// statisticsFreshness returns the freshness bound of a statistics request, `dataCoord.statistics.freshness` if unset.
+func statisticsFreshness(freshnessMs int64) time.Duration {
+	if freshnessMs > 0 {
+		return time.Duration(freshnessMs) * time.Millisecond
+	}
+	return Params.DataCoordCfg.StatisticsFreshness
+}
+
+// syncSegmentStats makes the row counts of the unflushed segments of the collection no staler than
+// @freshness: the DataNodes watching the collection are asked to resend their segment stats
+// if any stats is stale, then it waits until the resent stats arrive or the sync times out.
+func (s *Server) syncSegmentStats(ctx context.Context, collectionID UniqueID, freshness time.Duration) {
+	if freshness <= 0 || s.channelManager == nil {
+		return
+	}
+	syncStart := time.Now()
+	if len(s.meta.GetStaleStatsSegments(collectionID, allPartitionID, syncStart.Add(-freshness))) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Params.DataCoordCfg.StatisticsSyncTimeout)
+	defer cancel()
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		segResent = make(map[UniqueID]struct{})
+	)
+	for _, nodeChannels := range s.channelManager.GetChannels() {
+		watched := false
+		for _, ch := range nodeChannels.Channels {
+			if ch.CollectionID == collectionID {
+				watched = true
+				break
+			}
+		}
+		if !watched {
+			continue
+		}
+		wg.Add(1)
+		go func(nodeID int64) {
+			defer wg.Done()
+			segmentIDs, err := s.cluster.SyncSegmentStats(ctx, nodeID)
+			if err != nil {
+				log.Warn("failed to sync segment stats", zap.Int64("DataNode ID", nodeID), zap.Error(err))
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, segmentID := range segmentIDs {
+				segResent[segmentID] = struct{}{}
+			}
+		}(nodeChannels.NodeID)
+	}
+	wg.Wait()
+
+	// segments not resent hold no new rows on DataNodes, only wait for the resent ones
+	ticker := time.NewTicker(segmentStatsSyncCheckInterval)
+	defer ticker.Stop()
+	for {
+		pending := 0
+		for _, segment := range s.meta.GetStaleStatsSegments(collectionID, allPartitionID, syncStart) {
+			if _, ok := segResent[segment.GetID()]; ok {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			log.Warn("sync segment stats timeout, the row counts may be stale",
+				zap.Int64("collectionID", collectionID),
+				zap.Int("pending segments", pending))
+			return
+		case <-ticker.C:
+		}
+	}
+}
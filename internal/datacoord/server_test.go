@@ -26,6 +26,7 @@ import (
 	"path"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -56,6 +57,7 @@ import (
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/etcd"
+	"github.com/milvus-io/milvus/internal/util/metautil"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 )
@@ -462,6 +464,108 @@ func TestGetCollectionStatistics(t *testing.T) {
 		assert.EqualValues(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
 
 	})
+	t.Run("with request freshness bound", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+		var resent int32
+		svr.sessionManager.sessionCreator = func(ctx context.Context, addr string) (types.DataNode, error) {
+			cli, err := newMockDataNodeClient(0, nil)
+			return &resendCountingDataNodeClient{mockDataNodeClient: cli, resent: &resent}, err
+		}
+		svr.sessionManager.AddSession(&NodeInfo{
+			NodeID:  0,
+			Address: "localhost:8080",
+		})
+		err := svr.channelManager.AddNode(0)
+		assert.Nil(t, err)
+		err = svr.channelManager.Watch(&channel{Name: "ch1", CollectionID: 0})
+		assert.Nil(t, err)
+		// no stats reported yet
+		err = svr.meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{
+			ID:            1,
+			CollectionID:  0,
+			PartitionID:   10,
+			InsertChannel: "ch1",
+			State:         commonpb.SegmentState_Sealed,
+			NumOfRows:     20,
+		}))
+		assert.Nil(t, err)
+
+		// no bound by the config nor the request
+		resp, err := svr.GetCollectionStatistics(svr.ctx, &datapb.GetCollectionStatisticsRequest{CollectionID: 0})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.EqualValues(t, 0, atomic.LoadInt32(&resent))
+
+		resp, err = svr.GetCollectionStatistics(svr.ctx, &datapb.GetCollectionStatisticsRequest{CollectionID: 0, FreshnessMs: 1000})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		assert.EqualValues(t, 1, atomic.LoadInt32(&resent))
+
+		partResp, err := svr.GetPartitionStatistics(svr.ctx, &datapb.GetPartitionStatisticsRequest{
+			CollectionID: 0,
+			PartitionIDs: []int64{10},
+			FreshnessMs:  1000,
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, partResp.Status.ErrorCode)
+		assert.EqualValues(t, 2, atomic.LoadInt32(&resent))
+	})
+	t.Run("with freshness bound", func(t *testing.T) {
+		svr := newTestServer(t, nil)
+		defer closeTestServer(t, svr)
+		Params.DataCoordCfg.StatisticsFreshness = time.Second
+		defer func() {
+			Params.DataCoordCfg.StatisticsFreshness = 0
+		}()
+
+		svr.sessionManager.AddSession(&NodeInfo{
+			NodeID:  0,
+			Address: "localhost:8080",
+		})
+		err := svr.channelManager.AddNode(0)
+		assert.Nil(t, err)
+		err = svr.channelManager.Watch(&channel{Name: "ch1", CollectionID: 0})
+		assert.Nil(t, err)
+
+		err = svr.meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{
+			ID:            1,
+			CollectionID:  0,
+			InsertChannel: "ch1",
+			State:         commonpb.SegmentState_Growing,
+		}))
+		assert.Nil(t, err)
+		svr.meta.SetCurrentRows(1, 10)
+		err = svr.meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{
+			ID:            2,
+			CollectionID:  0,
+			InsertChannel: "ch1",
+			State:         commonpb.SegmentState_Flushed,
+			NumOfRows:     100,
+			Deltalogs: []*datapb.FieldBinlog{
+				{Binlogs: []*datapb.Binlog{{EntriesNum: 3, LogID: 1, LogPath: metautil.BuildDeltaLogPath("files", 0, 0, 2, 1)}}},
+			},
+		}))
+		assert.Nil(t, err)
+		err = svr.meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{
+			ID:            3,
+			CollectionID:  0,
+			InsertChannel: "ch1",
+			State:         commonpb.SegmentState_Sealed,
+			NumOfRows:     20,
+		}))
+		assert.Nil(t, err)
+
+		resp, err := svr.GetCollectionStatistics(svr.ctx, &datapb.GetCollectionStatisticsRequest{
+			CollectionID: 0,
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		stats := funcutil.KeyValuePair2Map(resp.GetStats())
+		assert.Equal(t, "100", stats["flushed_row_count"])
+		assert.Equal(t, "30", stats["growing_row_count"])
+		assert.Equal(t, "3", stats["deleted_row_count"])
+	})
 	t.Run("with closed server", func(t *testing.T) {
 		svr := newTestServer(t, nil)
 		closeTestServer(t, svr)
@@ -2865,6 +2969,7 @@ func TestGetFlushState(t *testing.T) {
 	t.Run("get flush state with all flushed segments", func(t *testing.T) {
 		svr := &Server{
 			meta: &meta{
+				keyLocks: newMetaLockManager(),
 				segments: &SegmentsInfo{
 					segments: map[int64]*SegmentInfo{
 						1: {
@@ -2895,6 +3000,7 @@ func TestGetFlushState(t *testing.T) {
 	t.Run("get flush state with unflushed segments", func(t *testing.T) {
 		svr := &Server{
 			meta: &meta{
+				keyLocks: newMetaLockManager(),
 				segments: &SegmentsInfo{
 					segments: map[int64]*SegmentInfo{
 						1: {
@@ -2926,6 +3032,7 @@ func TestGetFlushState(t *testing.T) {
 	t.Run("get flush state with compacted segments", func(t *testing.T) {
 		svr := &Server{
 			meta: &meta{
+				keyLocks: newMetaLockManager(),
 				segments: &SegmentsInfo{
 					segments: map[int64]*SegmentInfo{
 						1: {
@@ -3355,6 +3462,17 @@ func (ms *MockClosePanicMsgstream) Chan() <-chan *msgstream.MsgPack {
 	return args.Get(0).(chan *msgstream.MsgPack)
 }
 
+// resendCountingDataNodeClient counts the ResendSegmentStats calls.
+type resendCountingDataNodeClient struct {
+	*mockDataNodeClient
+	resent *int32
+}
+
+func (c *resendCountingDataNodeClient) ResendSegmentStats(ctx context.Context, req *datapb.ResendSegmentStatsRequest) (*datapb.ResendSegmentStatsResponse, error) {
+	atomic.AddInt32(c.resent, 1)
+	return c.mockDataNodeClient.ResendSegmentStats(ctx, req)
+}
+
 func newTestServer(t *testing.T, receiveCh chan any, opts ...Option) *Server {
 	var err error
 	Params.Init()
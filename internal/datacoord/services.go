@@ -290,16 +290,27 @@ func (s *Server) GetCollectionStatistics(ctx context.Context, req *datapb.GetCol
 		resp.Status.Reason = serverNotServingErrMsg
 		return resp, nil
 	}
+	s.syncSegmentStats(ctx, req.CollectionID, statisticsFreshness(req.GetFreshnessMs()))
 	nums := s.meta.GetNumRowsOfCollection(req.CollectionID)
 	resp.Status.ErrorCode = commonpb.ErrorCode_Success
 	resp.Stats = append(resp.Stats, &commonpb.KeyValuePair{Key: "row_count", Value: strconv.FormatInt(nums, 10)})
+	resp.Stats = append(resp.Stats, rowCountStatsToKeyValuePairs(s.meta.GetRowCountStats(req.CollectionID, allPartitionID))...)
 	logutil.Logger(ctx).Info("success to get collection statistics", zap.Any("response", resp))
 	return resp, nil
 }
 
+// rowCountStatsToKeyValuePairs converts the row counts grouped by segment state to statistics key-value pairs
+func rowCountStatsToKeyValuePairs(stats *RowCountStats) []*commonpb.KeyValuePair {
+	return []*commonpb.KeyValuePair{
+		{Key: "flushed_row_count", Value: strconv.FormatInt(stats.Flushed, 10)},
+		{Key: "growing_row_count", Value: strconv.FormatInt(stats.Growing, 10)},
+		{Key: "deleted_row_count", Value: strconv.FormatInt(stats.Deleted, 10)},
+	}
+}
+
 // GetPartitionStatistics returns statistics for partition
 // if partID is empty, return statistics for all partitions of the collection
-// for now only row count is returned
+// for now only row counts are returned
 func (s *Server) GetPartitionStatistics(ctx context.Context, req *datapb.GetPartitionStatisticsRequest) (*datapb.GetPartitionStatisticsResponse, error) {
 	resp := &datapb.GetPartitionStatisticsResponse{
 		Status: &commonpb.Status{
@@ -310,16 +321,24 @@ func (s *Server) GetPartitionStatistics(ctx context.Context, req *datapb.GetPart
 		resp.Status.Reason = serverNotServingErrMsg
 		return resp, nil
 	}
+	s.syncSegmentStats(ctx, req.CollectionID, statisticsFreshness(req.GetFreshnessMs()))
 	nums := int64(0)
+	rowCountStats := &RowCountStats{}
 	if len(req.GetPartitionIDs()) == 0 {
 		nums = s.meta.GetNumRowsOfCollection(req.CollectionID)
+		rowCountStats = s.meta.GetRowCountStats(req.CollectionID, allPartitionID)
 	}
 	for _, partID := range req.GetPartitionIDs() {
 		num := s.meta.GetNumRowsOfPartition(req.CollectionID, partID)
 		nums += num
+		partStats := s.meta.GetRowCountStats(req.CollectionID, partID)
+		rowCountStats.Flushed += partStats.Flushed
+		rowCountStats.Growing += partStats.Growing
+		rowCountStats.Deleted += partStats.Deleted
 	}
 	resp.Status.ErrorCode = commonpb.ErrorCode_Success
 	resp.Stats = append(resp.Stats, &commonpb.KeyValuePair{Key: "row_count", Value: strconv.FormatInt(nums, 10)})
+	resp.Stats = append(resp.Stats, rowCountStatsToKeyValuePairs(rowCountStats)...)
 	logutil.Logger(ctx).Info("success to get partition statistics", zap.Any("response", resp))
 	return resp, nil
 }
@@ -212,10 +212,23 @@ func (c *SessionManager) ReCollectSegmentStats(ctx context.Context, nodeID int64
 }
 
 func (c *SessionManager) execReCollectSegmentStats(ctx context.Context, nodeID int64) {
+	segResent, err := c.SyncSegmentStats(ctx, nodeID)
+	if err != nil {
+		log.Error("re-collect segment stats call failed",
+			zap.Int64("DataNode ID", nodeID), zap.Error(err))
+	} else {
+		log.Info("re-collect segment stats call succeeded",
+			zap.Int64("DataNode ID", nodeID),
+			zap.Int64s("segment stat collected", segResent))
+	}
+}
+
+// SyncSegmentStats asks the DataNode to resend its segment stats synchronously,
+// and returns the IDs of the segments whose stats will be resent.
+func (c *SessionManager) SyncSegmentStats(ctx context.Context, nodeID int64) ([]int64, error) {
 	cli, err := c.getClient(ctx, nodeID)
 	if err != nil {
-		log.Warn("failed to get dataNode client", zap.Int64("DataNode ID", nodeID), zap.Error(err))
-		return
+		return nil, err
 	}
 	ctx, cancel := context.WithTimeout(ctx, reCollectTimeout)
 	defer cancel()
@@ -226,13 +239,22 @@ func (c *SessionManager) execReCollectSegmentStats(ctx context.Context, nodeID i
 		),
 	})
 	if err := VerifyResponse(resp, err); err != nil {
-		log.Error("re-collect segment stats call failed",
-			zap.Int64("DataNode ID", nodeID), zap.Error(err))
-	} else {
-		log.Info("re-collect segment stats call succeeded",
-			zap.Int64("DataNode ID", nodeID),
-			zap.Int64s("segment stat collected", resp.GetSegResent()))
+		return nil, err
+	}
+	return resp.GetSegResent(), nil
+}
+
+// FreshSearch searches the insert buffers of the DataNode by @req.
+func (c *SessionManager) FreshSearch(ctx context.Context, nodeID int64, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	cli, err := c.getClient(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.FreshSearch(ctx, req)
+	if err := VerifyResponse(resp, err); err != nil {
+		return nil, err
 	}
+	return resp, nil
 }
 
 func (c *SessionManager) GetCompactionState() map[int64]*datapb.CompactionStateResult {
@@ -0,0 +1,332 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/management"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	SnapshotPinRouterPath = "/datacoord/snapshot/pins"
+)
+
+var errSnapshotPinNotFound = errors.New("snapshot pin not found")
+
+// SnapshotPin keeps the objects of a snapshot, e.g. the segments in a backup manifest, from being removed by gc
+// until it's released or expired.
+type SnapshotPin struct {
+	ID int64 `json:"id"`
+	// Epoch is the timestamp when the pin is registered, the snapshot is taken at or after it
+	Epoch      Timestamp `json:"epoch"`
+	Owner      string    `json:"owner"`
+	SegmentIDs []int64   `json:"segment_ids"`
+	// Paths are the objects pinned besides the binlogs of the segments
+	Paths     []string  `json:"paths,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpireAt  time.Time `json:"expire_at"`
+}
+
+func (p *SnapshotPin) expired(now time.Time) bool {
+	return now.After(p.ExpireAt)
+}
+
+// snapshotPins are the segments and objects of all the live pins
+type snapshotPins struct {
+	segments typeutil.UniqueSet
+	files    typeutil.Set[string]
+}
+
+// snapshotPinManager persists the snapshot pins in etcd, so that they survive datacoord restarts.
+type snapshotPinManager struct {
+	kv   kv.BaseKV
+	mu   sync.RWMutex
+	pins map[int64]*SnapshotPin
+}
+
+func newSnapshotPinManager(kv kv.BaseKV) (*snapshotPinManager, error) {
+	m := &snapshotPinManager{
+		kv:   kv,
+		pins: make(map[int64]*SnapshotPin),
+	}
+	_, values, err := kv.LoadWithPrefix(snapshotPinPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		pin := &SnapshotPin{}
+		if err := json.Unmarshal([]byte(value), pin); err != nil {
+			log.Error("failed to unmarshal snapshot pin", zap.Error(err))
+			return nil, err
+		}
+		m.pins[pin.ID] = pin
+	}
+	log.Info("load snapshot pins", zap.Int("num", len(m.pins)))
+	return m, nil
+}
+
+func snapshotPinKey(id int64) string {
+	return path.Join(snapshotPinPrefix, strconv.FormatInt(id, 10))
+}
+
+func (m *snapshotPinManager) save(pin *SnapshotPin) error {
+	value, err := json.Marshal(pin)
+	if err != nil {
+		return err
+	}
+	return m.kv.Save(snapshotPinKey(pin.ID), string(value))
+}
+
+// Pin registers the pin.
+func (m *snapshotPinManager) Pin(pin *SnapshotPin) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.save(pin); err != nil {
+		return err
+	}
+	m.pins[pin.ID] = pin
+	log.Info("pin snapshot", zap.Int64("pinID", pin.ID), zap.String("owner", pin.Owner),
+		zap.Uint64("epoch", pin.Epoch), zap.Int("segments", len(pin.SegmentIDs)),
+		zap.Int("paths", len(pin.Paths)), zap.Time("expireAt", pin.ExpireAt))
+	return nil
+}
+
+// Renew extends the pin to expire after ttl from now, an expired pin can't be renewed.
+func (m *snapshotPinManager) Renew(id int64, ttl time.Duration) (*SnapshotPin, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pin, ok := m.pins[id]
+	now := time.Now()
+	if !ok || pin.expired(now) {
+		return nil, fmt.Errorf("%w(pinID=%d)", errSnapshotPinNotFound, id)
+	}
+	renewed := *pin
+	renewed.ExpireAt = now.Add(ttl)
+	if err := m.save(&renewed); err != nil {
+		return nil, err
+	}
+	m.pins[id] = &renewed
+	return &renewed, nil
+}
+
+// Unpin releases the pin once the snapshot is completed.
+func (m *snapshotPinManager) Unpin(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.pins[id]; !ok {
+		return fmt.Errorf("%w(pinID=%d)", errSnapshotPinNotFound, id)
+	}
+	if err := m.kv.Remove(snapshotPinKey(id)); err != nil {
+		return err
+	}
+	delete(m.pins, id)
+	log.Info("unpin snapshot", zap.Int64("pinID", id))
+	return nil
+}
+
+// List returns the pins, including the expired ones not removed yet.
+func (m *snapshotPinManager) List() []*SnapshotPin {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	pins := make([]*SnapshotPin, 0, len(m.pins))
+	for _, pin := range m.pins {
+		pins = append(pins, pin)
+	}
+	return pins
+}
+
+// removeExpired removes the expired pins, the ones failed to remove are retried next time.
+func (m *snapshotPinManager) removeExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for id, pin := range m.pins {
+		if !pin.expired(now) {
+			continue
+		}
+		if err := m.kv.Remove(snapshotPinKey(id)); err != nil {
+			log.Warn("failed to remove expired snapshot pin", zap.Int64("pinID", id), zap.Error(err))
+			continue
+		}
+		delete(m.pins, id)
+		log.Info("snapshot pin expired", zap.Int64("pinID", id), zap.String("owner", pin.Owner), zap.Time("expireAt", pin.ExpireAt))
+	}
+}
+
+// pinned returns the segments and objects of the live pins.
+func (m *snapshotPinManager) pinned() *snapshotPins {
+	pinned := &snapshotPins{
+		segments: typeutil.NewUniqueSet(),
+		files:    typeutil.NewSet[string](),
+	}
+	if m == nil {
+		return pinned
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := time.Now()
+	for _, pin := range m.pins {
+		if pin.expired(now) {
+			continue
+		}
+		pinned.segments.Insert(pin.SegmentIDs...)
+		pinned.files.Insert(pin.Paths...)
+	}
+	return pinned
+}
+
+// snapshotPinRequest is the body to register a pin, all the healthy segments of the collection,
+// or of all collections if collection id is 0, are pinned if no segment is given.
+type snapshotPinRequest struct {
+	Owner        string   `json:"owner"`
+	CollectionID int64    `json:"collection_id"`
+	SegmentIDs   []int64  `json:"segment_ids"`
+	Paths        []string `json:"paths"`
+	TTLSeconds   int64    `json:"ttl_seconds"`
+}
+
+// snapshotPinTTL returns the ttl in seconds capped by the configured one, which is also the default.
+func snapshotPinTTL(seconds int64) time.Duration {
+	ttl := Params.DataCoordCfg.GCSnapshotPinTTL
+	if seconds > 0 && time.Duration(seconds)*time.Second < ttl {
+		ttl = time.Duration(seconds) * time.Second
+	}
+	return ttl
+}
+
+// pinSnapshot registers a pin at a new epoch.
+func (s *Server) pinSnapshot(ctx context.Context, req *snapshotPinRequest) (*SnapshotPin, error) {
+	id, err := s.allocator.allocID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	epoch, err := s.allocator.allocTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	segmentIDs := req.SegmentIDs
+	if len(segmentIDs) == 0 && len(req.Paths) == 0 {
+		segments := s.meta.SelectSegments(func(segment *SegmentInfo) bool {
+			return isSegmentHealthy(segment) &&
+				(req.CollectionID == 0 || segment.GetCollectionID() == req.CollectionID)
+		})
+		for _, segment := range segments {
+			segmentIDs = append(segmentIDs, segment.GetID())
+		}
+	}
+
+	now := time.Now()
+	pin := &SnapshotPin{
+		ID:         id,
+		Epoch:      epoch,
+		Owner:      req.Owner,
+		SegmentIDs: segmentIDs,
+		Paths:      req.Paths,
+		CreatedAt:  now,
+		ExpireAt:   now.Add(snapshotPinTTL(req.TTLSeconds)),
+	}
+	if err := s.snapshotPins.Pin(pin); err != nil {
+		return nil, err
+	}
+	return pin, nil
+}
+
+// registerSnapshotPinHandlerOnce avoid register http handler multiple times
+var registerSnapshotPinHandlerOnce sync.Once
+
+func (s *Server) registerSnapshotPinHandler() {
+	management.Register(&management.HTTPHandler{
+		Path:        SnapshotPinRouterPath,
+		HandlerFunc: s.handleSnapshotPin,
+	})
+}
+
+// handleSnapshotPin lists the pins on GET, registers a pin on POST, renews the pin by "id"
+// for "ttl_seconds" on PUT and releases the pin by "id" on DELETE.
+func (s *Server) handleSnapshotPin(w http.ResponseWriter, req *http.Request) {
+	if s.snapshotPins == nil {
+		http.Error(w, "datacoord is not ready", http.StatusServiceUnavailable)
+		return
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	switch req.Method {
+	case http.MethodGet:
+		result = s.snapshotPins.List()
+	case http.MethodPost:
+		pinReq := &snapshotPinRequest{}
+		if err := json.NewDecoder(req.Body).Decode(pinReq); err != nil {
+			http.Error(w, "invalid pin request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err = s.pinSnapshot(req.Context(), pinReq)
+	case http.MethodPut, http.MethodDelete:
+		id, parseErr := strconv.ParseInt(req.URL.Query().Get("id"), 10, 64)
+		if parseErr != nil {
+			http.Error(w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		if req.Method == http.MethodDelete {
+			err = s.snapshotPins.Unpin(id)
+			result = map[string]int64{"id": id}
+			break
+		}
+		var seconds int64
+		if v := req.URL.Query().Get("ttl_seconds"); v != "" {
+			seconds, parseErr = strconv.ParseInt(v, 10, 64)
+			if parseErr != nil || seconds <= 0 {
+				http.Error(w, "invalid ttl_seconds", http.StatusBadRequest)
+				return
+			}
+		}
+		result, err = s.snapshotPins.Renew(id, snapshotPinTTL(seconds))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if errors.Is(err, errSnapshotPinNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Warn("failed to write snapshot pin response", zap.Error(err))
+	}
+}
@@ -0,0 +1,155 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+)
+
+func TestSnapshotPinManager(t *testing.T) {
+	kv := memkv.NewMemoryKV()
+	m, err := newSnapshotPinManager(kv)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, m.Pin(&SnapshotPin{ID: 1, SegmentIDs: []int64{10, 11}, CreatedAt: now, ExpireAt: now.Add(time.Hour)}))
+	require.NoError(t, m.Pin(&SnapshotPin{ID: 2, Paths: []string{"a/b"}, CreatedAt: now, ExpireAt: now.Add(-time.Second)}))
+	assert.Len(t, m.List(), 2)
+
+	// the expired pin pins nothing
+	pinned := m.pinned()
+	assert.True(t, pinned.segments.Contain(10, 11))
+	assert.False(t, pinned.files.Contain("a/b"))
+
+	_, err = m.Renew(2, time.Hour)
+	assert.ErrorIs(t, err, errSnapshotPinNotFound)
+	renewed, err := m.Renew(1, 2*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, renewed.ExpireAt.After(now.Add(time.Hour)))
+
+	// pins are reloaded from kv
+	reloaded, err := newSnapshotPinManager(kv)
+	require.NoError(t, err)
+	assert.Len(t, reloaded.List(), 2)
+
+	m.removeExpired()
+	assert.Len(t, m.List(), 1)
+	_, values, err := kv.LoadWithPrefix(snapshotPinPrefix)
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+
+	assert.ErrorIs(t, m.Unpin(2), errSnapshotPinNotFound)
+	require.NoError(t, m.Unpin(1))
+	assert.Empty(t, m.List())
+	assert.Empty(t, m.pinned().segments)
+
+	var nilManager *snapshotPinManager
+	assert.Empty(t, nilManager.pinned().segments)
+}
+
+func TestServer_handleSnapshotPin(t *testing.T) {
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	for id, state := range map[int64]commonpb.SegmentState{
+		1: commonpb.SegmentState_Flushed,
+		2: commonpb.SegmentState_Growing,
+		3: commonpb.SegmentState_Dropped,
+	} {
+		require.NoError(t, meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{ID: id, CollectionID: 100, State: state})))
+	}
+	require.NoError(t, meta.AddSegment(NewSegmentInfo(&datapb.SegmentInfo{ID: 4, CollectionID: 200, State: commonpb.SegmentState_Flushed})))
+
+	pins, err := newSnapshotPinManager(memkv.NewMemoryKV())
+	require.NoError(t, err)
+	s := &Server{meta: meta, allocator: newMockAllocator(), snapshotPins: pins}
+
+	do := func(method, url string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		w := httptest.NewRecorder()
+		s.handleSnapshotPin(w, httptest.NewRequest(method, url, &buf))
+		return w
+	}
+
+	w := do(http.MethodPost, SnapshotPinRouterPath, &snapshotPinRequest{Owner: "backup", CollectionID: 100, TTLSeconds: 60})
+	require.Equal(t, http.StatusOK, w.Code)
+	pin := &SnapshotPin{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), pin))
+	assert.ElementsMatch(t, []int64{1, 2}, pin.SegmentIDs)
+	assert.NotZero(t, pin.Epoch)
+	assert.True(t, pin.ExpireAt.Before(time.Now().Add(time.Minute+time.Second)))
+
+	w = do(http.MethodGet, SnapshotPinRouterPath, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listed []*SnapshotPin
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 1)
+
+	w = do(http.MethodPut, fmt.Sprintf("%s?id=%d&ttl_seconds=3600", SnapshotPinRouterPath, pin.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = do(http.MethodPut, fmt.Sprintf("%s?id=%d&ttl_seconds=-1", SnapshotPinRouterPath, pin.ID), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodDelete, fmt.Sprintf("%s?id=%d", SnapshotPinRouterPath, pin.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = do(http.MethodDelete, fmt.Sprintf("%s?id=%d", SnapshotPinRouterPath, pin.ID), nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do(http.MethodDelete, SnapshotPinRouterPath+"?id=x", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodPatch, SnapshotPinRouterPath, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func Test_garbageCollector_pinned(t *testing.T) {
+	meta, err := newMemoryMeta()
+	require.NoError(t, err)
+	pins, err := newSnapshotPinManager(memkv.NewMemoryKV())
+	require.NoError(t, err)
+	now := time.Now()
+	require.NoError(t, pins.Pin(&SnapshotPin{ID: 1, SegmentIDs: []int64{1}, CreatedAt: now, ExpireAt: now.Add(time.Hour)}))
+
+	dropped := func(id int64) *SegmentInfo {
+		return NewSegmentInfo(&datapb.SegmentInfo{ID: id, CollectionID: 100, State: commonpb.SegmentState_Dropped})
+	}
+	require.NoError(t, meta.AddSegment(dropped(1)))
+	require.NoError(t, meta.AddSegment(dropped(2)))
+
+	gc := newGarbageCollector(meta, newMockHandler(), &SegmentReferenceManager{segmentReferCnt: map[UniqueID]int{}}, nil, GcOption{
+		dropTolerance: 0,
+		pins:          pins,
+	})
+	gc.clearEtcd()
+	// the pinned segment is kept, while the other one is removed
+	assert.NotNil(t, meta.GetSegmentUnsafe(1))
+	assert.Nil(t, meta.GetSegmentUnsafe(2))
+}
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
@@ -45,6 +46,12 @@ type downloader interface {
 	// errDownloadFromBlobStorage is returned if ctx is canceled from outside while a downloading is inprogress.
 	// Beware of the ctx here, if no timeout or cancel is applied to this ctx, this downloading may retry forever.
 	download(ctx context.Context, paths []string) ([]*Blob, error)
+	// prefetch hints that the binlogs of @paths are going to be downloaded in order, so that the next ones could be
+	// downloaded while the current one is processed.
+	prefetch(ctx context.Context, paths []string)
+	// selectObject evaluates the S3 Select @expression on the parquet binlog @path, see storage.SelectObject,
+	// the binlog is downloaded and its records are selected by @fallback if the storage can't evaluate it.
+	selectObject(ctx context.Context, path string, expression string, fallback func(content []byte) ([][]byte, error)) ([][]byte, error)
 }
 
 type uploader interface {
@@ -53,8 +60,14 @@ type uploader interface {
 	// errUploadToBlobStorage is returned if ctx is canceled from outside while a uploading is inprogress.
 	// Beware of the ctx here, if no timeout or cancel is applied to this ctx, this uploading may retry forever.
 	upload(ctx context.Context, segID, partID UniqueID, iData []*InsertData, dData *DeleteData, meta *etcdpb.CollectionMeta) (*segPaths, error)
-	uploadInsertLog(ctx context.Context, segID, partID UniqueID, iData *InsertData, meta *etcdpb.CollectionMeta) (map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error)
+	// uploadInsertLog saves InsertData into blob storage with the scalar payloads compressed by @compression,
+	// common.binlog.payloadCompression if it's empty.
+	uploadInsertLog(ctx context.Context, segID, partID UniqueID, iData *InsertData, meta *etcdpb.CollectionMeta, compression storage.PayloadCompression) (map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error)
 	uploadDeltaLog(ctx context.Context, segID, partID UniqueID, dData *DeleteData, meta *etcdpb.CollectionMeta) ([]*datapb.FieldBinlog, error)
+	// uploadDictionaryLog saves the low cardinality VarChar fields of InsertData dictionary-encoded into blob storage.
+	uploadDictionaryLog(ctx context.Context, segID, partID UniqueID, iData *InsertData, meta *etcdpb.CollectionMeta, maxCardinality int) (map[UniqueID]*datapb.FieldBinlog, error)
+	// uploadTimestampIndexLog saves the timestamp index of InsertData into blob storage.
+	uploadTimestampIndexLog(ctx context.Context, segID, partID UniqueID, iData *InsertData, meta *etcdpb.CollectionMeta) (map[UniqueID]*datapb.FieldBinlog, error)
 }
 
 type binlogIO struct {
@@ -103,6 +116,14 @@ func (b *binlogIO) download(ctx context.Context, paths []string) ([]*Blob, error
 	return rst, nil
 }
 
+func (b *binlogIO) prefetch(ctx context.Context, paths []string) {
+	storage.Prefetch(ctx, b.ChunkManager, paths)
+}
+
+func (b *binlogIO) selectObject(ctx context.Context, path string, expression string, fallback func(content []byte) ([][]byte, error)) ([][]byte, error) {
+	return storage.SelectObject(ctx, b.ChunkManager, path, expression, fallback)
+}
+
 func (b *binlogIO) uploadSegmentFiles(
 	ctx context.Context,
 	CollectionID UniqueID,
@@ -124,7 +145,7 @@ func (b *binlogIO) uploadSegmentFiles(
 					zap.Int64("segmentID", segID))
 				<-time.After(50 * time.Millisecond)
 			}
-			err = b.MultiWrite(ctx, kvs)
+			err = storage.MultiWriteBinlogs(ctx, b.ChunkManager, CollectionID, segID, kvs, getOrCreateIOPool().Cap())
 		}
 	}
 	return nil
@@ -161,7 +182,7 @@ func (b *binlogIO) upload(
 			continue
 		}
 
-		blobs, inpaths, statspaths, err := b.genInsertBlobs(iData, partID, segID, meta)
+		blobs, inpaths, statspaths, err := b.genInsertBlobs(iData, partID, segID, meta, "")
 		if err != nil {
 			log.Warn("generate insert blobs wrong",
 				zap.Int64("collectionID", meta.GetID()),
@@ -252,8 +273,9 @@ func (b *binlogIO) genDeltaBlobs(data *DeleteData, collID, partID, segID UniqueI
 }
 
 // genInsertBlobs returns kvs, insert-paths, stats-paths
-func (b *binlogIO) genInsertBlobs(data *InsertData, partID, segID UniqueID, meta *etcdpb.CollectionMeta) (map[string][]byte, map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error) {
+func (b *binlogIO) genInsertBlobs(data *InsertData, partID, segID UniqueID, meta *etcdpb.CollectionMeta, compression storage.PayloadCompression) (map[string][]byte, map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error) {
 	inCodec := storage.NewInsertCodec(meta)
+	inCodec.PayloadCompression = compression
 	inlogs, statslogs, err := inCodec.Serialize(partID, segID, data)
 	if err != nil {
 		return nil, nil, nil, err
@@ -309,6 +331,103 @@ func (b *binlogIO) genInsertBlobs(data *InsertData, partID, segID UniqueID, meta
 	return kvs, inpaths, statspaths, nil
 }
 
+// genDictionaryBlobs returns kvs and dictionary-paths of the low cardinality VarChar fields in @data,
+// a field is dictionary-encoded only if it has no more than @maxCardinality distinct values and the
+// dictionary is at most half as long as the field.
+// The dictionary logs are saved as stats logs of the field, one for each insert binlog of the field.
+func (b *binlogIO) genDictionaryBlobs(data *InsertData, partID, segID UniqueID, meta *etcdpb.CollectionMeta, maxCardinality int) (map[string][]byte, map[UniqueID]*datapb.FieldBinlog, error) {
+	var (
+		codec   = storage.NewDictionaryCodec()
+		kvs     = make(map[string][]byte)
+		dcpaths = make(map[UniqueID]*datapb.FieldBinlog)
+		blobs   = make(map[UniqueID]*Blob)
+	)
+
+	for _, field := range meta.GetSchema().GetFields() {
+		if field.GetDataType() != schemapb.DataType_VarChar || field.GetIsPrimaryKey() {
+			continue
+		}
+		fData, ok := data.Data[field.GetFieldID()].(*storage.StringFieldData)
+		if !ok || len(fData.Data) == 0 {
+			continue
+		}
+		limit := maxCardinality
+		if half := len(fData.Data) / 2; half < limit {
+			limit = half
+		}
+		encoded := storage.EncodeDictionary(fData.Data, limit)
+		if encoded == nil {
+			continue
+		}
+		blob, err := codec.Serialize(encoded)
+		if err != nil {
+			return nil, nil, err
+		}
+		blobs[field.GetFieldID()] = blob
+	}
+
+	if len(blobs) == 0 {
+		return kvs, dcpaths, nil
+	}
+
+	notifyGenIdx := make(chan struct{})
+	defer close(notifyGenIdx)
+
+	generator, err := b.idxGenerator(len(blobs), notifyGenIdx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for fID, blob := range blobs {
+		k := metautil.JoinIDPath(meta.GetID(), partID, segID, fID, <-generator)
+		key := path.Join(b.ChunkManager.RootPath(), common.SegmentStatslogPath, k)
+
+		value := blob.GetValue()
+		kvs[key] = value
+		dcpaths[fID] = &datapb.FieldBinlog{
+			FieldID: fID,
+			Binlogs: []*datapb.Binlog{{LogSize: int64(len(value)), LogPath: key}},
+		}
+	}
+
+	return kvs, dcpaths, nil
+}
+
+// serializeTimestampIndex returns the serialized timestamp index of @data, nil if @data has no timestamp.
+func serializeTimestampIndex(data *InsertData) (*Blob, error) {
+	fData, ok := data.Data[common.TimeStampField].(*storage.Int64FieldData)
+	if !ok || len(fData.Data) == 0 {
+		return nil, nil
+	}
+	return storage.NewTimestampIndexCodec().Serialize(storage.BuildTimestampIndex(fData.Data))
+}
+
+// genTimestampIndexBlobs returns kvs and timestamp-index-paths of @data,
+// the timestamp index is saved as a stats log of the timestamp field, one for each insert binlog.
+func (b *binlogIO) genTimestampIndexBlobs(data *InsertData, partID, segID UniqueID, meta *etcdpb.CollectionMeta) (map[string][]byte, map[UniqueID]*datapb.FieldBinlog, error) {
+	kvs := make(map[string][]byte)
+	tipaths := make(map[UniqueID]*datapb.FieldBinlog)
+	blob, err := serializeTimestampIndex(data)
+	if err != nil || blob == nil {
+		return kvs, tipaths, err
+	}
+
+	logID, err := b.allocID()
+	if err != nil {
+		return nil, nil, err
+	}
+	k := metautil.JoinIDPath(meta.GetID(), partID, segID, common.TimeStampField, logID)
+	key := path.Join(b.ChunkManager.RootPath(), common.SegmentStatslogPath, k)
+
+	value := blob.GetValue()
+	kvs[key] = value
+	tipaths[common.TimeStampField] = &datapb.FieldBinlog{
+		FieldID: common.TimeStampField,
+		Binlogs: []*datapb.Binlog{{LogSize: int64(len(value)), LogPath: key}},
+	}
+	return kvs, tipaths, nil
+}
+
 func (b *binlogIO) idxGenerator(n int, done <-chan struct{}) (<-chan UniqueID, error) {
 
 	idStart, _, err := b.allocIDBatch(uint32(n))
@@ -337,7 +456,8 @@ func (b *binlogIO) uploadInsertLog(
 	segID UniqueID,
 	partID UniqueID,
 	iData *InsertData,
-	meta *etcdpb.CollectionMeta) (map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error) {
+	meta *etcdpb.CollectionMeta,
+	compression storage.PayloadCompression) (map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error) {
 	var (
 		insertField2Path = make(map[UniqueID]*datapb.FieldBinlog)
 		statsField2Path  = make(map[UniqueID]*datapb.FieldBinlog)
@@ -352,7 +472,7 @@ func (b *binlogIO) uploadInsertLog(
 		return nil, nil, nil
 	}
 
-	kvs, inpaths, statspaths, err := b.genInsertBlobs(iData, partID, segID, meta)
+	kvs, inpaths, statspaths, err := b.genInsertBlobs(iData, partID, segID, meta, compression)
 	if err != nil {
 		log.Warn("generate insert blobs wrong",
 			zap.Int64("collectionID", meta.GetID()),
@@ -388,6 +508,57 @@ func (b *binlogIO) uploadInsertLog(
 	return insertField2Path, statsField2Path, nil
 }
 
+func (b *binlogIO) uploadDictionaryLog(
+	ctx context.Context,
+	segID UniqueID,
+	partID UniqueID,
+	iData *InsertData,
+	meta *etcdpb.CollectionMeta,
+	maxCardinality int) (map[UniqueID]*datapb.FieldBinlog, error) {
+	kvs, dcpaths, err := b.genDictionaryBlobs(iData, partID, segID, meta, maxCardinality)
+	if err != nil {
+		log.Warn("generate dictionary blobs wrong",
+			zap.Int64("collectionID", meta.GetID()),
+			zap.Int64("segmentID", segID),
+			zap.Error(err))
+		return nil, err
+	}
+	if len(kvs) == 0 {
+		return nil, nil
+	}
+
+	err = b.uploadSegmentFiles(ctx, meta.GetID(), segID, kvs)
+	if err != nil {
+		return nil, err
+	}
+	return dcpaths, nil
+}
+
+func (b *binlogIO) uploadTimestampIndexLog(
+	ctx context.Context,
+	segID UniqueID,
+	partID UniqueID,
+	iData *InsertData,
+	meta *etcdpb.CollectionMeta) (map[UniqueID]*datapb.FieldBinlog, error) {
+	kvs, tipaths, err := b.genTimestampIndexBlobs(iData, partID, segID, meta)
+	if err != nil {
+		log.Warn("generate timestamp index blobs wrong",
+			zap.Int64("collectionID", meta.GetID()),
+			zap.Int64("segmentID", segID),
+			zap.Error(err))
+		return nil, err
+	}
+	if len(kvs) == 0 {
+		return nil, nil
+	}
+
+	err = b.uploadSegmentFiles(ctx, meta.GetID(), segID, kvs)
+	if err != nil {
+		return nil, err
+	}
+	return tipaths, nil
+}
+
 func (b *binlogIO) uploadDeltaLog(
 	ctx context.Context,
 	segID UniqueID,
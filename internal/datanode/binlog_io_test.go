@@ -74,7 +74,7 @@ func TestBinlogIOInterfaceMethods(t *testing.T) {
 
 		ctx, cancel := context.WithCancel(context.Background())
 
-		in, stats, err := b.uploadInsertLog(ctx, 1, 10, iData, meta)
+		in, stats, err := b.uploadInsertLog(ctx, 1, 10, iData, meta, "")
 		assert.NoError(t, err)
 		assert.Equal(t, 12, len(in))
 		assert.Equal(t, 1, len(in[0].GetBinlogs()))
@@ -91,7 +91,7 @@ func TestBinlogIOInterfaceMethods(t *testing.T) {
 		assert.EqualError(t, err, errUploadToBlobStorage.Error())
 		assert.Nil(t, p)
 
-		in, _, err = b.uploadInsertLog(ctx, 1, 10, iData, meta)
+		in, _, err = b.uploadInsertLog(ctx, 1, 10, iData, meta, "")
 		assert.EqualError(t, err, errUploadToBlobStorage.Error())
 		assert.Nil(t, in)
 
@@ -147,7 +147,7 @@ func TestBinlogIOInterfaceMethods(t *testing.T) {
 		assert.Error(t, err)
 		assert.Empty(t, p)
 
-		in, _, err := b.uploadInsertLog(ctx, 1, 10, iData, meta)
+		in, _, err := b.uploadInsertLog(ctx, 1, 10, iData, meta, "")
 		assert.Error(t, err)
 		assert.Empty(t, in)
 
@@ -160,7 +160,7 @@ func TestBinlogIOInterfaceMethods(t *testing.T) {
 		assert.Error(t, err)
 		assert.Empty(t, p)
 
-		in, _, err = b.uploadInsertLog(ctx, 1, 10, iData, meta)
+		in, _, err = b.uploadInsertLog(ctx, 1, 10, iData, meta, "")
 		assert.Error(t, err)
 		assert.Empty(t, in)
 
@@ -176,7 +176,7 @@ func TestBinlogIOInterfaceMethods(t *testing.T) {
 		assert.Error(t, err)
 		assert.Empty(t, p)
 
-		in, _, err = b.uploadInsertLog(ctx, 1, 10, iData, meta)
+		in, _, err = b.uploadInsertLog(ctx, 1, 10, iData, meta, "")
 		assert.Error(t, err)
 		assert.Empty(t, in)
 
@@ -229,6 +229,29 @@ func TestBinlogIOInterfaceMethods(t *testing.T) {
 		}
 	})
 
+	t.Run("Test upload and download with faults", func(t *testing.T) {
+		fm := storage.NewFaultInjectionChunkManager(cm, 1)
+		fm.SetFault(storage.FaultOpWrite, &storage.Fault{ErrorRate: 0.5, PartialWriteRate: 0.5})
+		fm.SetFault(storage.FaultOpRead, &storage.Fault{ErrorRate: 0.5})
+		b := &binlogIO{fm, alloc}
+
+		kvs := map[string][]byte{
+			path.Join("test_faults", "a"): {1, 2, 3, 4, 5, 6, 7, 8},
+			path.Join("test_faults", "b"): {9, 10, 11, 12, 13, 14, 15, 16},
+		}
+		ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+		defer cancel()
+		// the partially written binlogs are overwritten by retries
+		require.NoError(t, b.uploadSegmentFiles(ctx, 1, 1, kvs))
+		assert.Greater(t, fm.Injected(storage.FaultOpWrite), 0)
+
+		for key, value := range kvs {
+			loaded, err := b.download(ctx, []string{key})
+			require.NoError(t, err)
+			assert.Equal(t, value, loaded[0].GetValue())
+		}
+	})
+
 	t.Run("Test download twice", func(t *testing.T) {
 		mkc := &mockCm{errMultiLoad: true}
 		b := &binlogIO{mkc, alloc}
@@ -336,7 +359,7 @@ func TestBinlogIOInnerMethods(t *testing.T) {
 				assert.NoError(t, err)
 				primaryKeyFieldID := primaryKeyFieldSchema.GetFieldID()
 
-				kvs, pin, pstats, err := b.genInsertBlobs(genInsertData(), 10, 1, meta)
+				kvs, pin, pstats, err := b.genInsertBlobs(genInsertData(), 10, 1, meta, "")
 
 				assert.NoError(t, err)
 				assert.Equal(t, 1, len(pstats))
@@ -352,7 +375,7 @@ func TestBinlogIOInnerMethods(t *testing.T) {
 	})
 
 	t.Run("Test genInsertBlobs error", func(t *testing.T) {
-		kvs, pin, pstats, err := b.genInsertBlobs(&InsertData{}, 1, 1, nil)
+		kvs, pin, pstats, err := b.genInsertBlobs(&InsertData{}, 1, 1, nil, "")
 		assert.Error(t, err)
 		assert.Empty(t, kvs)
 		assert.Empty(t, pin)
@@ -361,7 +384,7 @@ func TestBinlogIOInnerMethods(t *testing.T) {
 		f := &MetaFactory{}
 		meta := f.GetCollectionMeta(UniqueID(10001), "test_gen_blobs", schemapb.DataType_Int64)
 
-		kvs, pin, pstats, err = b.genInsertBlobs(genEmptyInsertData(), 10, 1, meta)
+		kvs, pin, pstats, err = b.genInsertBlobs(genEmptyInsertData(), 10, 1, meta, "")
 		assert.Error(t, err)
 		assert.Empty(t, kvs)
 		assert.Empty(t, pin)
@@ -370,7 +393,7 @@ func TestBinlogIOInnerMethods(t *testing.T) {
 		errAlloc := NewAllocatorFactory()
 		errAlloc.errAllocBatch = true
 		bin := &binlogIO{cm, errAlloc}
-		kvs, pin, pstats, err = bin.genInsertBlobs(genInsertData(), 10, 1, meta)
+		kvs, pin, pstats, err = bin.genInsertBlobs(genInsertData(), 10, 1, meta, "")
 
 		assert.Error(t, err)
 		assert.Empty(t, kvs)
@@ -378,6 +401,72 @@ func TestBinlogIOInnerMethods(t *testing.T) {
 		assert.Empty(t, pstats)
 	})
 
+	t.Run("Test genDictionaryBlobs", func(t *testing.T) {
+		f := &MetaFactory{}
+		meta := f.GetCollectionMeta(UniqueID(10001), "test_gen_blobs", schemapb.DataType_Int64)
+
+		// two distinct values in two rows, the dictionary is not shorter than the field
+		kvs, pdict, err := b.genDictionaryBlobs(genInsertData(), 10, 1, meta, 100)
+		assert.NoError(t, err)
+		assert.Empty(t, kvs)
+		assert.Empty(t, pdict)
+
+		iData := &InsertData{Data: map[int64]storage.FieldData{
+			109: &storage.StringFieldData{
+				NumRows: []int64{4},
+				Data:    []string{"b", "a", "b", "b"},
+			},
+		}}
+		kvs, pdict, err = b.genDictionaryBlobs(iData, 10, 1, meta, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(kvs))
+		require.Contains(t, pdict, int64(109))
+		key := pdict[109].GetBinlogs()[0].GetLogPath()
+		assert.Contains(t, key, common.SegmentStatslogPath)
+
+		d, err := storage.NewDictionaryCodec().Deserialize(&Blob{Value: kvs[key]})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "a", "b", "b"}, d.Decode())
+
+		kvs, pdict, err = b.genDictionaryBlobs(iData, 10, 1, meta, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, kvs)
+		assert.Empty(t, pdict)
+
+		errAlloc := NewAllocatorFactory()
+		errAlloc.errAllocBatch = true
+		bin := &binlogIO{cm, errAlloc}
+		_, _, err = bin.genDictionaryBlobs(iData, 10, 1, meta, 100)
+		assert.Error(t, err)
+	})
+
+	t.Run("Test genTimestampIndexBlobs", func(t *testing.T) {
+		f := &MetaFactory{}
+		meta := f.GetCollectionMeta(UniqueID(10001), "test_gen_blobs", schemapb.DataType_Int64)
+
+		kvs, pindex, err := b.genTimestampIndexBlobs(genInsertData(), 10, 1, meta)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(kvs))
+		require.Contains(t, pindex, int64(common.TimeStampField))
+		key := pindex[common.TimeStampField].GetBinlogs()[0].GetLogPath()
+		assert.Contains(t, key, common.SegmentStatslogPath)
+
+		index, err := storage.NewTimestampIndexCodec().Deserialize(&Blob{Value: kvs[key]})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), index.RowNum())
+
+		kvs, pindex, err = b.genTimestampIndexBlobs(genEmptyInsertData(), 10, 1, meta)
+		assert.NoError(t, err)
+		assert.Empty(t, kvs)
+		assert.Empty(t, pindex)
+
+		errAlloc := NewAllocatorFactory()
+		errAlloc.isvalid = false
+		bin := &binlogIO{cm, errAlloc}
+		_, _, err = bin.genTimestampIndexBlobs(genInsertData(), 10, 1, meta)
+		assert.Error(t, err)
+	})
+
 	t.Run("Test idxGenerator", func(t *testing.T) {
 		tests := []struct {
 			isvalid  bool
@@ -452,6 +541,13 @@ func (mk *mockCm) MultiWrite(ctx context.Context, contents map[string][]byte) er
 	return nil
 }
 
+func (mk *mockCm) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...storage.WriteOption) error {
+	if mk.errMultiSave {
+		return errors.New("mockKv multisave error")
+	}
+	return nil
+}
+
 func (mk *mockCm) Read(ctx context.Context, filePath string) ([]byte, error) {
 	return nil, nil
 }
@@ -77,9 +77,11 @@ func (bm *DelBufferManager) StoreNewDeletes(segID UniqueID, pks []primaryKey,
 	delData := delDataBuf.delData
 	rowCount := len(pks)
 	var bufSize int64
+	bm.channel.updateBuffers(func() {
+		delData.Pks = append(delData.Pks, pks...)
+		delData.Tss = append(delData.Tss, tss...)
+	})
 	for i := 0; i < rowCount; i++ {
-		delData.Pks = append(delData.Pks, pks[i])
-		delData.Tss = append(delData.Tss, tss[i])
 		switch pks[i].Type() {
 		case schemapb.DataType_Int64:
 			bufSize += 8
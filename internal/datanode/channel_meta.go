@@ -52,6 +52,7 @@ var (
 type Channel interface {
 	getCollectionID() UniqueID
 	getCollectionSchema(collectionID UniqueID, ts Timestamp) (*schemapb.CollectionSchema, error)
+	getPayloadCompression(collectionID UniqueID) storage.PayloadCompression
 	getCollectionAndPartitionID(segID UniqueID) (collID, partitionID UniqueID, err error)
 	getChannelName(segID UniqueID) string
 
@@ -87,6 +88,9 @@ type Channel interface {
 	setCurDeleteBuffer(segmentID UniqueID, buf *DelDataBuf)
 	rollDeleteBuffer(segmentID UniqueID)
 	evictHistoryDeleteBuffer(segmentID UniqueID, endPos *internalpb.MsgPosition)
+
+	updateBuffers(fn func())
+	searchBuffers(req *datapb.FreshSearchRequest) (*freshSearchResult, error)
 }
 
 // ChannelMeta contains channel meta and the latest segments infos of the channel.
@@ -94,15 +98,21 @@ type ChannelMeta struct {
 	collectionID UniqueID
 	channelName  string
 	collSchema   *schemapb.CollectionSchema
-	schemaMut    sync.RWMutex
+	// the payload compression of the collection, empty until the collection properties are fetched
+	payloadCompression storage.PayloadCompression
+	schemaMut          sync.RWMutex
 
 	segMu    sync.RWMutex
 	segments map[UniqueID]*Segment
+	// guards the data in the buffers, which is read by the fresh searches out of the flow graph
+	bufferMu sync.RWMutex
 
 	syncPolicies []segmentSyncPolicy
 
 	metaService  *metaService
 	chunkManager storage.ChunkManager
+	// loads the pk stats of the flushed segments on demand, nil if they are loaded with the segments
+	pkStatsCache *storage.PkStatsCache
 }
 
 var _ Channel = &ChannelMeta{}
@@ -217,6 +227,14 @@ func (c *ChannelMeta) addSegment(req addSegmentReq) error {
 			zap.Error(err))
 		return err
 	}
+	if !seg.hasPkStats() && len(req.binLogs) > 0 {
+		if err := c.initBinlogPkRanges(context.TODO(), seg, req.binLogs, req.recoverTs); err != nil {
+			log.Error("failed to init pk ranges from binlogs",
+				zap.Int64("segment ID", req.segID),
+				zap.Error(err))
+			return err
+		}
+	}
 
 	c.segMu.Lock()
 	c.segments[req.segID] = seg
@@ -321,33 +339,70 @@ func (c *ChannelMeta) InitPKstats(ctx context.Context, s *Segment, statsBinlogs
 	}
 
 	// read historical PK filter
-	values, err := c.chunkManager.MultiRead(ctx, bloomFilterFiles)
+	stats, err := storage.LoadPkStatistics(ctx, c.chunkManager, bloomFilterFiles)
 	if err != nil {
 		log.Warn("failed to load bloom filter files", zap.Error(err))
 		return err
 	}
-	blobs := make([]*Blob, 0)
-	for i := 0; i < len(values); i++ {
-		blobs = append(blobs, &Blob{Value: values[i]})
+	if c.pkStatsCache != nil && !s.notFlushed() {
+		s.setLazyPkStats(c.pkStatsCache, bloomFilterFiles, stats)
+		log.Info("pk stats will be loaded lazily", zap.Int("stats logs", len(bloomFilterFiles)))
+		return nil
 	}
+	var size uint
+	for _, stat := range stats {
+		size += stat.PkFilter.Cap()
+	}
+	s.historyStats = append(s.historyStats, stats...)
+	log.Info("Successfully load pk stats", zap.Any("time", time.Since(startTs)), zap.Uint("size", size))
+
+	return nil
+}
 
-	stats, err := storage.DeserializeStats(blobs)
+// initBinlogPkRanges reads the pk ranges of a segment without pk stats logs from the footer stats of its pk binlogs,
+// so that the deletes of the pks in them are still applied to the segment. Only int64 pks have the ranges.
+func (c *ChannelMeta) initBinlogPkRanges(ctx context.Context, s *Segment, binlogs []*datapb.FieldBinlog, ts Timestamp) error {
+	log := log.With(zap.Int64("segmentID", s.segmentID))
+	schema, err := c.getCollectionSchema(s.collectionID, ts)
 	if err != nil {
-		log.Warn("failed to deserialize bloom filter files", zap.Error(err))
 		return err
 	}
-	var size uint
-	for _, stat := range stats {
-		pkStat := &storage.PkStatistics{
-			PkFilter: stat.BF,
-			MinPK:    stat.MinPk,
-			MaxPK:    stat.MaxPk,
-		}
-		size += stat.BF.Cap()
-		s.historyStats = append(s.historyStats, pkStat)
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return err
+	}
+	if pkField.GetDataType() != schemapb.DataType_Int64 {
+		log.Warn("no pk stats of the segment, and its pk ranges are unknown", zap.String("pk type", pkField.GetDataType().String()))
+		return nil
 	}
-	log.Info("Successfully load pk stats", zap.Any("time", time.Since(startTs)), zap.Uint("size", size))
 
+	var pkRanges []*storage.PkStatistics
+	for _, binlog := range binlogs {
+		if binlog.GetFieldID() != pkField.GetFieldID() {
+			continue
+		}
+		for _, l := range binlog.GetBinlogs() {
+			content, err := c.chunkManager.Read(ctx, l.GetLogPath())
+			if err != nil {
+				return err
+			}
+			min, max, ok, err := storage.Int64BinlogRange(content)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				// the range of the binlog is unknown, so is the segment
+				log.Warn("no footer stats in the pk binlog", zap.String("path", l.GetLogPath()))
+				return nil
+			}
+			pkRanges = append(pkRanges, &storage.PkStatistics{
+				MinPK: newInt64PrimaryKey(min),
+				MaxPK: newInt64PrimaryKey(max),
+			})
+		}
+	}
+	s.setBinlogPkRanges(pkRanges)
+	log.Info("init pk ranges from the binlog footers", zap.Int("binlogs", len(pkRanges)))
 	return nil
 }
 
@@ -434,6 +489,7 @@ func (c *ChannelMeta) removeSegments(segIDs ...UniqueID) {
 			seg.curDeleteBuf = nil
 			seg.historyInsertBuf = nil
 			seg.historyDeleteBuf = nil
+			seg.releasePkStats()
 		}
 
 		delete(c.segments, segID)
@@ -519,6 +575,35 @@ func (c *ChannelMeta) getCollectionSchema(collID UniqueID, ts Timestamp) (*schem
 	return c.collSchema, nil
 }
 
+// getPayloadCompression gets the payload compression in the collection properties from rootcoord.
+// Empty is returned if the properties are not available, so that common.binlog.payloadCompression is used.
+func (c *ChannelMeta) getPayloadCompression(collID UniqueID) storage.PayloadCompression {
+	if !c.validCollection(collID) {
+		return ""
+	}
+	c.schemaMut.RLock()
+	compression := c.payloadCompression
+	c.schemaMut.RUnlock()
+	if compression != "" {
+		return compression
+	}
+
+	info, err := c.metaService.getCollectionInfo(context.Background(), collID, 0)
+	if err == nil {
+		compression, err = storage.GetPayloadCompression(info.GetProperties())
+	}
+	if err != nil {
+		// the binlogs are readable with any compression, so fall back to the default instead of failing the flush
+		log.Warn("failed to get payload compression of collection, use the default",
+			zap.Int64("collectionID", collID), zap.Error(err))
+		return ""
+	}
+	c.schemaMut.Lock()
+	c.payloadCompression = compression
+	c.schemaMut.Unlock()
+	return compression
+}
+
 func (c *ChannelMeta) validCollection(collID UniqueID) bool {
 	return collID == c.collectionID
 }
@@ -561,8 +646,7 @@ func (c *ChannelMeta) mergeFlushedSegments(seg *Segment, planID UniqueID, compac
 		s.compactedTo = seg.segmentID
 		s.setType(datapb.SegmentType_Compacted)
 		// release bloom filter
-		s.currentStat = nil
-		s.historyStats = nil
+		s.releasePkStats()
 	}
 
 	// only store segments with numRows > 0
@@ -729,6 +813,13 @@ func (c *ChannelMeta) evictHistoryInsertBuffer(segmentID UniqueID, endPos *inter
 	log.Warn("cannot find segment when evictHistoryInsertBuffer", zap.Int64("segmentID", segmentID))
 }
 
+// updateBuffers updates the data in the insert and delete buffers by @fn.
+func (c *ChannelMeta) updateBuffers(fn func()) {
+	c.bufferMu.Lock()
+	defer c.bufferMu.Unlock()
+	fn()
+}
+
 func (c *ChannelMeta) getCurDeleteBuffer(segmentID UniqueID) (*DelDataBuf, bool) {
 	c.segMu.RLock()
 	defer c.segMu.RUnlock()
@@ -33,6 +33,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/storage"
 )
@@ -462,6 +463,21 @@ func TestChannelMeta_InterfaceMethod(t *testing.T) {
 		rc.setCollectionID(1)
 	})
 
+	t.Run("Test_getPayloadCompression", func(t *testing.T) {
+		channel := newChannel("a", 1, nil, rc, cm)
+		// empty if the collection properties are not available
+		assert.Equal(t, storage.PayloadCompression(""), channel.getPayloadCompression(2))
+		rc.setCollectionID(-1)
+		assert.Equal(t, storage.PayloadCompression(""), channel.getPayloadCompression(1))
+
+		rc.setCollectionID(1)
+		assert.Equal(t, storage.PayloadCompressionZstd, channel.getPayloadCompression(1))
+		// the compression is cached
+		rc.setCollectionID(-1)
+		assert.Equal(t, storage.PayloadCompressionZstd, channel.getPayloadCompression(1))
+		rc.setCollectionID(1)
+	})
+
 	t.Run("Test listAllSegmentIDs", func(t *testing.T) {
 		s1 := Segment{segmentID: 1}
 		s2 := Segment{segmentID: 2}
@@ -751,6 +767,66 @@ func TestChannelMeta_UpdatePKRange(t *testing.T) {
 
 }
 
+func TestChannelMeta_InitBinlogPkRanges(t *testing.T) {
+	ctx := context.Background()
+	rc := &RootCoordFactory{
+		pkType: schemapb.DataType_Int64,
+	}
+	cm := storage.NewMemoryChunkManager()
+	channel := newChannel("insert-03", 1, nil, rc, cm)
+
+	// the pk binlog of a segment without stats logs
+	meta := &etcdpb.CollectionMeta{
+		ID: 1,
+		Schema: &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: common.RowIDField, DataType: schemapb.DataType_Int64},
+				{FieldID: common.TimeStampField, DataType: schemapb.DataType_Int64},
+				{FieldID: 106, DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			},
+		},
+	}
+	blobs, _, err := storage.NewInsertCodec(meta).Serialize(2, 10, &InsertData{
+		Data: map[int64]storage.FieldData{
+			common.RowIDField:     &storage.Int64FieldData{Data: []int64{1, 2, 3}},
+			common.TimeStampField: &storage.Int64FieldData{Data: []int64{1, 2, 3}},
+			106:                   &storage.Int64FieldData{Data: []int64{20, 10, 30}},
+		},
+	})
+	require.NoError(t, err)
+	for _, blob := range blobs {
+		require.NoError(t, cm.Write(ctx, "insert_log/10/"+blob.Key, blob.Value))
+	}
+	binLogs := []*datapb.FieldBinlog{{FieldID: 106, Binlogs: []*datapb.Binlog{{LogPath: "insert_log/10/106"}}}}
+
+	err = channel.addSegment(addSegmentReq{
+		segType:     datapb.SegmentType_Flushed,
+		segID:       10,
+		collID:      1,
+		partitionID: 2,
+		numOfRows:   3,
+		binLogs:     binLogs,
+	})
+	require.NoError(t, err)
+	seg := channel.segments[10]
+	assert.False(t, seg.isPKExist(newInt64PrimaryKey(9)))
+	assert.True(t, seg.isPKExist(newInt64PrimaryKey(10)))
+	assert.True(t, seg.isPKExist(newInt64PrimaryKey(25)))
+	assert.True(t, seg.isPKExist(newInt64PrimaryKey(30)))
+	assert.False(t, seg.isPKExist(newInt64PrimaryKey(31)))
+
+	// the pk binlog is not found
+	err = channel.addSegment(addSegmentReq{
+		segType:     datapb.SegmentType_Flushed,
+		segID:       11,
+		collID:      1,
+		partitionID: 2,
+		numOfRows:   3,
+		binLogs:     []*datapb.FieldBinlog{{FieldID: 106, Binlogs: []*datapb.Binlog{{LogPath: "insert_log/11/106"}}}},
+	})
+	assert.Error(t, err)
+}
+
 func TestChannelMeta_ChannelCP(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
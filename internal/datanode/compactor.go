@@ -17,7 +17,9 @@
 package datanode
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -27,6 +29,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/apache/arrow/go/v8/arrow"
+
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
@@ -48,11 +52,12 @@ import (
 var (
 	errCompactionTypeUndifined = errors.New("compaction type undefined")
 	errIllegalCompactionPlan   = errors.New("compaction plan illegal")
-	errTransferType            = errors.New("transfer intferface to type wrong")
-	errUnknownDataType         = errors.New("unknown shema DataType")
 	errContext                 = errors.New("context done or timeout")
 )
 
+// maxTimestampExpression selects the max timestamp of a parquet timestamp binlog with S3 Select.
+var maxTimestampExpression = fmt.Sprintf(`SELECT MAX(s."%s") FROM S3Object s`, common.TimeStampFieldName)
+
 type iterator = storage.Iterator
 
 type compactor interface {
@@ -191,6 +196,30 @@ func (t *compactionTask) mergeDeltalogs(dBlobs map[UniqueID][]*Blob, timetravelT
 	return pk2ts, dbuff, nil
 }
 
+// deletionVector2Deltalogs converts the deletion vector blobs into deltalog blobs,
+// the deletions are applied by primary keys during compaction like the plain deltalogs.
+func deletionVector2Deltalogs(collectionID, partitionID, segmentID UniqueID, blobs []*Blob) ([]*Blob, error) {
+	dCodec := storage.NewDeleteCodec()
+	dvCodec := storage.NewDeletionVectorCodec()
+	deltalogs := make([]*Blob, 0, len(blobs))
+	for _, blob := range blobs {
+		dv, err := dvCodec.Deserialize(blob)
+		if err != nil {
+			return nil, err
+		}
+		if dv.Count() == 0 {
+			continue
+		}
+		deltalog, err := dCodec.Serialize(collectionID, partitionID, segmentID, dv.DeleteData())
+		if err != nil {
+			return nil, err
+		}
+		deltalog.Key = blob.Key
+		deltalogs = append(deltalogs, deltalog)
+	}
+	return deltalogs, nil
+}
+
 // nano2Milli transfers nanoseconds to milliseconds in unit
 func nano2Milli(nano time.Duration) float64 {
 	return float64(nano) / float64(time.Millisecond)
@@ -201,32 +230,185 @@ func (t *compactionTask) uploadSingleInsertLog(
 	targetSegID UniqueID,
 	partID UniqueID,
 	meta *etcdpb.CollectionMeta,
-	fID2Content map[UniqueID][]interface{},
-	fID2Type map[UniqueID]schemapb.DataType) (map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error) {
-	iData := &InsertData{
-		Data: make(map[storage.FieldID]storage.FieldData)}
+	record arrow.Record) (map[UniqueID]*datapb.FieldBinlog, map[UniqueID]*datapb.FieldBinlog, error) {
+	iData, err := storage.RecordToInsertData(record)
+	if err != nil {
+		log.Warn("transfer record to insert data wrong", zap.Error(err))
+		return nil, nil, err
+	}
 
-	for fID, content := range fID2Content {
-		tp, ok := fID2Type[fID]
-		if !ok {
-			log.Warn("no field ID in this schema", zap.Int64("fieldID", fID))
-			return nil, nil, errors.New("Unexpected error")
+	inPaths, statPaths, err := t.uploadInsertLog(ctxTimeout, targetSegID, partID, iData, meta, t.getPayloadCompression(meta.GetID()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if maxCardinality := Params.DataNodeCfg.CompactionDictionaryMaxCardinality; maxCardinality > 0 {
+		dictPaths, err := t.uploadDictionaryLog(ctxTimeout, targetSegID, partID, iData, meta, maxCardinality)
+		if err != nil {
+			return nil, nil, err
 		}
+		if statPaths == nil {
+			statPaths = make(map[UniqueID]*datapb.FieldBinlog)
+		}
+		// dictionary logs are stats logs of the VarChar fields, pk stats readers only look at the pk field
+		for fID, path := range dictPaths {
+			if tmpBinlog, ok := statPaths[fID]; ok {
+				tmpBinlog.Binlogs = append(tmpBinlog.Binlogs, path.GetBinlogs()...)
+			} else {
+				statPaths[fID] = path
+			}
+		}
+	}
 
-		fData, err := interface2FieldData(tp, content, int64(len(content)))
+	if Params.DataNodeCfg.TimestampIndexEnabled {
+		tsIndexPaths, err := t.uploadTimestampIndexLog(ctxTimeout, targetSegID, partID, iData, meta)
 		if err != nil {
-			log.Warn("transfer interface to FieldData wrong", zap.Error(err))
 			return nil, nil, err
 		}
-		iData.Data[fID] = fData
+		if statPaths == nil {
+			statPaths = make(map[UniqueID]*datapb.FieldBinlog)
+		}
+		// timestamp index logs are the only stats logs of the timestamp field
+		for fID, path := range tsIndexPaths {
+			statPaths[fID] = path
+		}
 	}
 
-	inPaths, statPaths, err := t.uploadInsertLog(ctxTimeout, targetSegID, partID, iData, meta)
+	return inPaths, statPaths, nil
+}
+
+// expiredInsertBatches returns the insert binlog batches of @segment whose rows are all expired by the collection TTL,
+// which are found by the timestamp index logs without downloading the binlogs. If the segment has no timestamp index
+// log for every batch, they're found by the max timestamps of the timestamp binlogs, see expiredInsertBatchesBySelect.
+func (t *compactionTask) expiredInsertBatches(ctx context.Context, segment *datapb.CompactionSegmentBinlogs, binlogNum int) map[int]struct{} {
+	if t.plan.GetCollectionTtl() <= 0 {
+		return nil
+	}
+	var tsIndexLogs []string
+	for _, fieldBinlog := range segment.GetField2StatslogPaths() {
+		if fieldBinlog.GetFieldID() != common.TimeStampField {
+			continue
+		}
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			tsIndexLogs = append(tsIndexLogs, binlog.GetLogPath())
+		}
+	}
+	if len(tsIndexLogs) != binlogNum {
+		return t.expiredInsertBatchesBySelect(ctx, segment, binlogNum)
+	}
+
+	blobs, err := t.download(ctx, tsIndexLogs)
 	if err != nil {
-		return nil, nil, err
+		log.Warn("failed to download timestamp index logs, check expiration by rows",
+			zap.Int64("planID", t.getPlanID()), zap.Int64("segmentID", segment.GetSegmentID()), zap.Error(err))
+		return nil
+	}
+	codec := storage.NewTimestampIndexCodec()
+	currentTs := t.GetCurrentTime()
+	expired := make(map[int]struct{})
+	expiredRows := int64(0)
+	for idx, blob := range blobs {
+		index, err := codec.Deserialize(blob)
+		if err != nil {
+			log.Warn("failed to deserialize timestamp index log, check expiration by rows",
+				zap.Int64("planID", t.getPlanID()), zap.Int64("segmentID", segment.GetSegmentID()), zap.Error(err))
+			return nil
+		}
+		if index.RowNum() > 0 && t.isExpiredEntity(index.MaxTimestamp(), currentTs) {
+			expired[idx] = struct{}{}
+			expiredRows += index.RowNum()
+		}
 	}
+	if len(expired) > 0 {
+		log.Info("skip the expired insert binlogs by timestamp index", zap.Int64("planID", t.getPlanID()),
+			zap.Int64("segmentID", segment.GetSegmentID()), zap.Int("batches", len(expired)), zap.Int64("rows", expiredRows))
+	}
+	return expired
+}
 
-	return inPaths, statPaths, nil
+// expiredInsertBatchesBySelect returns the insert binlog batches of @segment whose max timestamps are expired by
+// the collection TTL. The max timestamps are selected from the parquet timestamp binlogs by S3 Select, so that only
+// the aggregates are transferred, and the timestamp binlogs are downloaded only if the storage can't select them.
+// It returns nil if any of them fails, then the expiration is checked by rows with the full read of the binlogs.
+func (t *compactionTask) expiredInsertBatchesBySelect(ctx context.Context, segment *datapb.CompactionSegmentBinlogs, binlogNum int) map[int]struct{} {
+	var tsBinlogs []*datapb.Binlog
+	for _, fieldBinlog := range segment.GetFieldBinlogs() {
+		if fieldBinlog.GetFieldID() == common.TimeStampField {
+			tsBinlogs = fieldBinlog.GetBinlogs()
+		}
+	}
+	if len(tsBinlogs) != binlogNum {
+		return nil
+	}
+
+	currentTs := t.GetCurrentTime()
+	expired := make(map[int]struct{})
+	for idx, binlog := range tsBinlogs {
+		records, err := t.selectObject(ctx, binlog.GetLogPath(), maxTimestampExpression, selectMaxTimestamp)
+		if err != nil {
+			log.Warn("failed to select max timestamp of binlog, check expiration by rows", zap.Int64("planID", t.getPlanID()),
+				zap.Int64("segmentID", segment.GetSegmentID()), zap.String("path", binlog.GetLogPath()), zap.Error(err))
+			return nil
+		}
+		maxTs, err := parseMaxTimestampRecord(records)
+		if err != nil {
+			log.Warn("invalid max timestamp of binlog, check expiration by rows", zap.Int64("planID", t.getPlanID()),
+				zap.Int64("segmentID", segment.GetSegmentID()), zap.String("path", binlog.GetLogPath()), zap.Error(err))
+			return nil
+		}
+		if t.isExpiredEntity(maxTs, currentTs) {
+			expired[idx] = struct{}{}
+		}
+	}
+	if len(expired) > 0 {
+		log.Info("skip the expired insert binlogs by max timestamp", zap.Int64("planID", t.getPlanID()),
+			zap.Int64("segmentID", segment.GetSegmentID()), zap.Int("batches", len(expired)))
+	}
+	return expired
+}
+
+// selectMaxTimestamp selects the max timestamp of the timestamp binlog @content locally, in the same record
+// as the one returned by S3 Select for maxTimestampExpression.
+func selectMaxTimestamp(content []byte) ([][]byte, error) {
+	_, _, data, err := storage.NewInsertCodec(nil).Deserialize([]*Blob{{Value: content}})
+	if err != nil {
+		return nil, err
+	}
+	tss, ok := data.Data[common.TimeStampField].(*storage.Int64FieldData)
+	if !ok || len(tss.Data) == 0 {
+		return nil, errors.New("no timestamp in the binlog")
+	}
+	maxTs := tss.Data[0]
+	for _, ts := range tss.Data[1:] {
+		if ts > maxTs {
+			maxTs = ts
+		}
+	}
+	return [][]byte{[]byte(fmt.Sprintf(`{"_1":%d}`, maxTs))}, nil
+}
+
+// parseMaxTimestampRecord parses the record selected by maxTimestampExpression, e.g. {"_1":123}.
+func parseMaxTimestampRecord(records [][]byte) (Timestamp, error) {
+	if len(records) != 1 {
+		return 0, fmt.Errorf("%d records of the max timestamp, expected 1", len(records))
+	}
+	var record map[string]json.Number
+	decoder := json.NewDecoder(bytes.NewReader(records[0]))
+	decoder.UseNumber()
+	if err := decoder.Decode(&record); err != nil {
+		return 0, err
+	}
+	if len(record) != 1 {
+		return 0, fmt.Errorf("invalid record of the max timestamp: %s", records[0])
+	}
+	for _, value := range record {
+		maxTs, err := strconv.ParseUint(value.String(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid max timestamp %s: %w", value, err)
+		}
+		return maxTs, nil
+	}
+	return 0, nil
 }
 
 func (t *compactionTask) merge(
@@ -251,9 +433,6 @@ func (t *compactionTask) merge(
 		pkID   UniqueID
 		pkType schemapb.DataType
 
-		fID2Type    = make(map[UniqueID]schemapb.DataType)
-		fID2Content = make(map[UniqueID][]interface{})
-
 		insertField2Path = make(map[UniqueID]*datapb.FieldBinlog)
 		insertPaths      = make([]*datapb.FieldBinlog, 0)
 
@@ -295,7 +474,6 @@ func (t *compactionTask) merge(
 
 	// get pkID, pkType, dim
 	for _, fs := range meta.GetSchema().GetFields() {
-		fID2Type[fs.GetFieldID()] = fs.GetDataType()
 		if fs.GetIsPrimaryKey() && fs.GetFieldID() >= 100 && typeutil.IsPrimaryFieldType(fs.GetDataType()) {
 			pkID = fs.GetFieldID()
 			pkType = fs.GetDataType()
@@ -319,10 +497,40 @@ func (t *compactionTask) merge(
 	numBinlogs = 0
 	currentTs := t.GetCurrentTime()
 	maxRowsPerBinlog = int(Params.DataNodeCfg.FlushInsertBufferSize / (int64(dim) * 4))
-	currentRows := 0
 	downloadTimeCost := time.Duration(0)
 	uploadInsertTimeCost := time.Duration(0)
 
+	// the rows are kept in the column buffers of the record builder until uploaded
+	builder, err := storage.NewInsertRecordBuilder(meta.GetSchema())
+	if err != nil {
+		log.Warn("new insert record builder wrong", zap.Error(err))
+		return nil, nil, 0, err
+	}
+	defer builder.Release()
+	uploadRecord := func() error {
+		uploadInsertStart := time.Now()
+		record := builder.NewRecord()
+		defer record.Release()
+		inPaths, statsPaths, err := t.uploadSingleInsertLog(ctxTimeout, targetSegID, partID, meta, record)
+		if err != nil {
+			return err
+		}
+		uploadInsertTimeCost += time.Since(uploadInsertStart)
+		addInsertFieldPath(inPaths)
+		addStatFieldPath(statsPaths)
+
+		numRows += record.NumRows()
+		numBinlogs++
+		return nil
+	}
+
+	// the insert binlogs are merged one group after another, the next groups are downloaded while merging
+	var insertlogPaths []string
+	for _, paths := range unMergedInsertlogs {
+		insertlogPaths = append(insertlogPaths, paths...)
+	}
+	t.prefetch(ctxTimeout, insertlogPaths)
+
 	for _, path := range unMergedInsertlogs {
 		downloadStart := time.Now()
 		data, err := t.download(ctxTimeout, path)
@@ -362,45 +570,22 @@ func (t *compactionTask) merge(
 				return nil, nil, 0, errors.New("unexpected error")
 			}
 
-			for fID, vInter := range row {
-				if _, ok := fID2Content[fID]; !ok {
-					fID2Content[fID] = make([]interface{}, 0)
-				}
-				fID2Content[fID] = append(fID2Content[fID], vInter)
+			if err := builder.AppendRow(row); err != nil {
+				log.Warn("append row to insert record wrong", zap.Error(err))
+				return nil, nil, 0, err
 			}
 
-			currentRows++
-
-			if currentRows == maxRowsPerBinlog {
-				uploadInsertStart := time.Now()
-				inPaths, statsPaths, err := t.uploadSingleInsertLog(ctxTimeout, targetSegID, partID, meta, fID2Content, fID2Type)
-				if err != nil {
+			if builder.Len() == maxRowsPerBinlog {
+				if err := uploadRecord(); err != nil {
 					return nil, nil, 0, err
 				}
-				uploadInsertTimeCost += time.Since(uploadInsertStart)
-				addInsertFieldPath(inPaths)
-				addStatFieldPath(statsPaths)
-
-				fID2Content = make(map[int64][]interface{})
-				currentRows = 0
-				numRows += int64(maxRowsPerBinlog)
-				numBinlogs++
 			}
 		}
 	}
-	if currentRows != 0 {
-		uploadInsertStart := time.Now()
-		inPaths, statsPaths, err := t.uploadSingleInsertLog(ctxTimeout, targetSegID, partID, meta, fID2Content, fID2Type)
-		if err != nil {
+	if builder.Len() != 0 {
+		if err := uploadRecord(); err != nil {
 			return nil, nil, 0, err
 		}
-		uploadInsertTimeCost += time.Since(uploadInsertStart)
-
-		addInsertFieldPath(inPaths)
-		addStatFieldPath(statsPaths)
-
-		numRows += int64(currentRows)
-		numBinlogs++
 	}
 
 	for _, path := range insertField2Path {
@@ -591,7 +776,11 @@ func (t *compactionTask) compact() (*datapb.CompactionResult, error) {
 			return nil, errIllegalCompactionPlan
 		}
 
+		expiredBatches := t.expiredInsertBatches(ctxTimeout, s, binlogNum)
 		for idx := 0; idx < binlogNum; idx++ {
+			if _, ok := expiredBatches[idx]; ok {
+				continue
+			}
 			var ps []string
 			for _, f := range s.GetFieldBinlogs() {
 				ps = append(ps, f.GetBinlogs()[idx].GetLogPath())
@@ -601,6 +790,7 @@ func (t *compactionTask) compact() (*datapb.CompactionResult, error) {
 
 		segID := s.GetSegmentID()
 		for _, d := range s.GetDeltalogs() {
+			isDeletionVector := d.GetFieldID() == common.DeletionVectorFieldID
 			for _, l := range d.GetBinlogs() {
 				path := l.GetLogPath()
 				g.Go(func() error {
@@ -609,6 +799,16 @@ func (t *compactionTask) compact() (*datapb.CompactionResult, error) {
 						log.Warn("download deltalogs wrong")
 						return err
 					}
+					if isDeletionVector {
+						bs, err = deletionVector2Deltalogs(meta.GetID(), partID, segID, bs)
+						if err != nil {
+							log.Warn("convert deletion vector wrong", zap.String("path", path), zap.Error(err))
+							return err
+						}
+						if len(bs) == 0 {
+							return nil
+						}
+					}
 
 					dmu.Lock()
 					dblobs[segID] = append(dblobs[segID], bs...)
@@ -691,172 +891,6 @@ func (t *compactionTask) compact() (*datapb.CompactionResult, error) {
 	return pack, nil
 }
 
-// TODO copy maybe expensive, but this seems to be the only convinent way.
-func interface2FieldData(schemaDataType schemapb.DataType, content []interface{}, numRows int64) (storage.FieldData, error) {
-	var rst storage.FieldData
-	numOfRows := []int64{numRows}
-	switch schemaDataType {
-	case schemapb.DataType_Bool:
-		var data = &storage.BoolFieldData{
-			NumRows: numOfRows,
-			Data:    make([]bool, 0, len(content)),
-		}
-
-		for _, c := range content {
-			r, ok := c.(bool)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r)
-		}
-		rst = data
-
-	case schemapb.DataType_Int8:
-		var data = &storage.Int8FieldData{
-			NumRows: numOfRows,
-			Data:    make([]int8, 0, len(content)),
-		}
-
-		for _, c := range content {
-			r, ok := c.(int8)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r)
-		}
-		rst = data
-
-	case schemapb.DataType_Int16:
-		var data = &storage.Int16FieldData{
-			NumRows: numOfRows,
-			Data:    make([]int16, 0, len(content)),
-		}
-
-		for _, c := range content {
-			r, ok := c.(int16)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r)
-		}
-		rst = data
-
-	case schemapb.DataType_Int32:
-		var data = &storage.Int32FieldData{
-			NumRows: numOfRows,
-			Data:    make([]int32, 0, len(content)),
-		}
-
-		for _, c := range content {
-			r, ok := c.(int32)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r)
-		}
-		rst = data
-
-	case schemapb.DataType_Int64:
-		var data = &storage.Int64FieldData{
-			NumRows: numOfRows,
-			Data:    make([]int64, 0, len(content)),
-		}
-
-		for _, c := range content {
-			r, ok := c.(int64)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r)
-		}
-		rst = data
-
-	case schemapb.DataType_Float:
-		var data = &storage.FloatFieldData{
-			NumRows: numOfRows,
-			Data:    make([]float32, 0, len(content)),
-		}
-
-		for _, c := range content {
-			r, ok := c.(float32)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r)
-		}
-		rst = data
-
-	case schemapb.DataType_Double:
-		var data = &storage.DoubleFieldData{
-			NumRows: numOfRows,
-			Data:    make([]float64, 0, len(content)),
-		}
-
-		for _, c := range content {
-			r, ok := c.(float64)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r)
-		}
-		rst = data
-
-	case schemapb.DataType_String, schemapb.DataType_VarChar:
-		var data = &storage.StringFieldData{
-			NumRows: numOfRows,
-			Data:    make([]string, 0, len(content)),
-		}
-
-		for _, c := range content {
-			r, ok := c.(string)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r)
-		}
-		rst = data
-
-	case schemapb.DataType_FloatVector:
-		var data = &storage.FloatVectorFieldData{
-			NumRows: numOfRows,
-			Data:    []float32{},
-		}
-
-		for _, c := range content {
-			r, ok := c.([]float32)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r...)
-		}
-
-		data.Dim = len(data.Data) / int(numRows)
-		rst = data
-
-	case schemapb.DataType_BinaryVector:
-		var data = &storage.BinaryVectorFieldData{
-			NumRows: numOfRows,
-			Data:    []byte{},
-		}
-
-		for _, c := range content {
-			r, ok := c.([]byte)
-			if !ok {
-				return nil, errTransferType
-			}
-			data.Data = append(data.Data, r...)
-		}
-
-		data.Dim = len(data.Data) * 8 / int(numRows)
-		rst = data
-
-	default:
-		return nil, errUnknownDataType
-	}
-
-	return rst, nil
-}
-
 func (t *compactionTask) getSegmentMeta(segID UniqueID) (UniqueID, UniqueID, *etcdpb.CollectionMeta, error) {
 	collID, partID, err := t.getCollectionAndPartitionID(segID)
 	if err != nil {
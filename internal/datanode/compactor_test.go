@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 
 	//	"math"
 	"testing"
@@ -28,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
 	memkv "github.com/milvus-io/milvus/internal/kv/mem"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
@@ -82,54 +84,6 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 		assert.Error(t, err)
 	})
 
-	t.Run("Test.interface2FieldData", func(t *testing.T) {
-		tests := []struct {
-			isvalid bool
-
-			tp      schemapb.DataType
-			content []interface{}
-
-			description string
-		}{
-			{true, schemapb.DataType_Bool, []interface{}{true, false}, "valid bool"},
-			{true, schemapb.DataType_Int8, []interface{}{int8(1), int8(2)}, "valid int8"},
-			{true, schemapb.DataType_Int16, []interface{}{int16(1), int16(2)}, "valid int16"},
-			{true, schemapb.DataType_Int32, []interface{}{int32(1), int32(2)}, "valid int32"},
-			{true, schemapb.DataType_Int64, []interface{}{int64(1), int64(2)}, "valid int64"},
-			{true, schemapb.DataType_Float, []interface{}{float32(1), float32(2)}, "valid float32"},
-			{true, schemapb.DataType_Double, []interface{}{float64(1), float64(2)}, "valid float64"},
-			{true, schemapb.DataType_VarChar, []interface{}{"test1", "test2"}, "valid varChar"},
-			{true, schemapb.DataType_FloatVector, []interface{}{[]float32{1.0, 2.0}}, "valid floatvector"},
-			{true, schemapb.DataType_BinaryVector, []interface{}{[]byte{255}}, "valid binaryvector"},
-			{false, schemapb.DataType_Bool, []interface{}{1, 2}, "invalid bool"},
-			{false, schemapb.DataType_Int8, []interface{}{nil, nil}, "invalid int8"},
-			{false, schemapb.DataType_Int16, []interface{}{nil, nil}, "invalid int16"},
-			{false, schemapb.DataType_Int32, []interface{}{nil, nil}, "invalid int32"},
-			{false, schemapb.DataType_Int64, []interface{}{nil, nil}, "invalid int64"},
-			{false, schemapb.DataType_Float, []interface{}{nil, nil}, "invalid float32"},
-			{false, schemapb.DataType_Double, []interface{}{nil, nil}, "invalid float64"},
-			{false, schemapb.DataType_VarChar, []interface{}{nil, nil}, "invalid varChar"},
-			{false, schemapb.DataType_FloatVector, []interface{}{nil, nil}, "invalid floatvector"},
-			{false, schemapb.DataType_BinaryVector, []interface{}{nil, nil}, "invalid binaryvector"},
-			{false, schemapb.DataType_None, nil, "invalid data type"},
-		}
-
-		for _, test := range tests {
-			t.Run(test.description, func(t *testing.T) {
-				if test.isvalid {
-					fd, err := interface2FieldData(test.tp, test.content, 2)
-					assert.NoError(t, err)
-					assert.Equal(t, 2, fd.RowNum())
-				} else {
-					fd, err := interface2FieldData(test.tp, test.content, 2)
-					assert.Error(t, err)
-					assert.Nil(t, fd)
-				}
-			})
-		}
-
-	})
-
 	t.Run("Test mergeDeltalogs", func(t *testing.T) {
 		t.Run("One segment with timetravel", func(t *testing.T) {
 			invalidBlobs := map[UniqueID][]*Blob{
@@ -270,6 +224,10 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 				Schema: meta.GetSchema(),
 			}, nil)
 		channel := newChannel("a", collectionID, meta.GetSchema(), rc, nil)
+		timestampIndexEnabled := Params.DataNodeCfg.TimestampIndexEnabled
+		defer func() { Params.DataNodeCfg.TimestampIndexEnabled = timestampIndexEnabled }()
+		Params.DataNodeCfg.TimestampIndexEnabled = false
+
 		t.Run("Merge without expiration", func(t *testing.T) {
 			alloc := NewAllocatorFactory(1)
 			mockbIO := &binlogIO{cm, alloc}
@@ -277,7 +235,7 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			iData := genInsertDataWithExpiredTS()
 
 			var allPaths [][]string
-			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta)
+			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta, "")
 			assert.NoError(t, err)
 			assert.Equal(t, 12, len(inpath))
 			binlogNum := len(inpath[0].GetBinlogs())
@@ -315,7 +273,7 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			meta := NewMetaFactory().GetCollectionMeta(1, "test", schemapb.DataType_Int64)
 
 			var allPaths [][]string
-			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta)
+			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta, "")
 			assert.NoError(t, err)
 			assert.Equal(t, 12, len(inpath))
 			binlogNum := len(inpath[0].GetBinlogs())
@@ -340,6 +298,41 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			assert.Equal(t, 2, len(statsPaths[0].GetBinlogs()))
 		})
 
+		t.Run("Merge with timestamp index", func(t *testing.T) {
+			alloc := NewAllocatorFactory(1)
+			mockbIO := &binlogIO{cm, alloc}
+			Params.CommonCfg.EntityExpirationTTL = 0
+			Params.DataNodeCfg.TimestampIndexEnabled = true
+			defer func() { Params.DataNodeCfg.TimestampIndexEnabled = false }()
+			iData := genInsertDataWithExpiredTS()
+
+			var allPaths [][]string
+			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta, "")
+			assert.NoError(t, err)
+			var ps []string
+			for _, path := range inpath {
+				ps = append(ps, path.GetBinlogs()[0].GetLogPath())
+			}
+			allPaths = append(allPaths, ps)
+
+			ct := &compactionTask{Channel: channel, downloader: mockbIO, uploader: mockbIO}
+			_, statsPaths, numOfRow, err := ct.merge(context.Background(), allPaths, 2, 0, meta, map[interface{}]Timestamp{})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), numOfRow)
+			assert.Equal(t, 2, len(statsPaths))
+			var tsIndexPath string
+			for _, fieldBinlog := range statsPaths {
+				if fieldBinlog.GetFieldID() == common.TimeStampField {
+					tsIndexPath = fieldBinlog.GetBinlogs()[0].GetLogPath()
+				}
+			}
+			value, err := cm.Read(context.Background(), tsIndexPath)
+			require.NoError(t, err)
+			index, err := storage.NewTimestampIndexCodec().Deserialize(&storage.Blob{Value: value})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), index.RowNum())
+		})
+
 		t.Run("Merge with expiration", func(t *testing.T) {
 			alloc := NewAllocatorFactory(1)
 			mockbIO := &binlogIO{cm, alloc}
@@ -348,7 +341,7 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			meta := NewMetaFactory().GetCollectionMeta(1, "test", schemapb.DataType_Int64)
 
 			var allPaths [][]string
-			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta)
+			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta, "")
 			assert.NoError(t, err)
 			assert.Equal(t, 12, len(inpath))
 			binlogNum := len(inpath[0].GetBinlogs())
@@ -382,6 +375,43 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			assert.Equal(t, 0, len(statsPaths))
 		})
 
+		t.Run("Expired batches by max timestamp", func(t *testing.T) {
+			// S3 Select evaluates the parquet binlogs only
+			binlogFormatVersion := Params.CommonCfg.BinlogFormatVersion
+			defer func() { Params.CommonCfg.BinlogFormatVersion = binlogFormatVersion }()
+			Params.CommonCfg.BinlogFormatVersion = storage.BinlogFormatV2
+			alloc := NewAllocatorFactory(1)
+			mockbIO := &binlogIO{cm, alloc}
+			iData := genInsertDataWithExpiredTS()
+			meta := NewMetaFactory().GetCollectionMeta(1, "test", schemapb.DataType_Int64)
+			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta, "")
+			require.NoError(t, err)
+			segment := &datapb.CompactionSegmentBinlogs{SegmentID: 1}
+			for _, fieldBinlog := range inpath {
+				segment.FieldBinlogs = append(segment.FieldBinlogs, fieldBinlog)
+			}
+
+			// the timestamp binlog is read entirely by the local storage
+			ct := &compactionTask{
+				Channel:    channel,
+				downloader: mockbIO,
+				uploader:   mockbIO,
+				plan:       &datapb.CompactionPlan{CollectionTtl: 864000},
+			}
+			assert.Equal(t, map[int]struct{}{0: {}}, ct.expiredInsertBatches(context.Background(), segment, 1))
+
+			// the max timestamp is selected by the storage
+			selector := &fakeObjectSelector{ChunkManager: cm, records: [][]byte{[]byte(`{"_1":` + strconv.FormatUint(ct.GetCurrentTime(), 10) + `}`)}}
+			ct.downloader = &binlogIO{selector, alloc}
+			assert.Empty(t, ct.expiredInsertBatches(context.Background(), segment, 1))
+			assert.Equal(t, maxTimestampExpression, selector.expression)
+
+			selector.records = [][]byte{[]byte(`{}`)}
+			assert.Nil(t, ct.expiredInsertBatches(context.Background(), segment, 1))
+			// the batches are not matched
+			assert.Nil(t, ct.expiredInsertBatches(context.Background(), segment, 2))
+		})
+
 		t.Run("Merge with meta error", func(t *testing.T) {
 			alloc := NewAllocatorFactory(1)
 			mockbIO := &binlogIO{cm, alloc}
@@ -390,7 +420,7 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			meta := NewMetaFactory().GetCollectionMeta(1, "test", schemapb.DataType_Int64)
 
 			var allPaths [][]string
-			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta)
+			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta, "")
 			assert.NoError(t, err)
 			assert.Equal(t, 12, len(inpath))
 			binlogNum := len(inpath[0].GetBinlogs())
@@ -427,7 +457,7 @@ func TestCompactionTaskInnerMethods(t *testing.T) {
 			meta := NewMetaFactory().GetCollectionMeta(1, "test", schemapb.DataType_Int64)
 
 			var allPaths [][]string
-			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta)
+			inpath, _, err := mockbIO.uploadInsertLog(context.Background(), 1, 0, iData, meta, "")
 			assert.NoError(t, err)
 			assert.Equal(t, 12, len(inpath))
 			binlogNum := len(inpath[0].GetBinlogs())
@@ -550,6 +580,33 @@ func getInsertBlobs(segID UniqueID, iData *InsertData, meta *etcdpb.CollectionMe
 	return iblobs, err
 }
 
+// fakeObjectSelector selects the records of every object.
+type fakeObjectSelector struct {
+	storage.ChunkManager
+	records    [][]byte
+	expression string
+}
+
+func (s *fakeObjectSelector) SelectObject(ctx context.Context, filePath string, expression string) ([][]byte, error) {
+	s.expression = expression
+	return s.records, nil
+}
+
+func TestParseMaxTimestampRecord(t *testing.T) {
+	maxTs, err := parseMaxTimestampRecord([][]byte{[]byte(`{"_1":435874409325461505}`)})
+	require.NoError(t, err)
+	assert.Equal(t, Timestamp(435874409325461505), maxTs)
+
+	_, err = parseMaxTimestampRecord(nil)
+	assert.Error(t, err)
+	_, err = parseMaxTimestampRecord([][]byte{[]byte(`{"_1":null}`)})
+	assert.Error(t, err)
+	_, err = parseMaxTimestampRecord([][]byte{[]byte(`{"_1":1,"_2":2}`)})
+	assert.Error(t, err)
+	_, err = parseMaxTimestampRecord([][]byte{[]byte(`[`)})
+	assert.Error(t, err)
+}
+
 func TestCompactorInterfaceMethods(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
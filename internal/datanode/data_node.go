@@ -129,6 +129,7 @@ type DataNode struct {
 	session        *sessionutil.Session
 	watchKv        kv.MetaKv
 	chunkManager   storage.ChunkManager
+	pkStatsCache   *storage.PkStatsCache
 	rowIDAllocator *allocator2.IDAllocator
 
 	closer io.Closer
@@ -510,6 +511,14 @@ func (node *DataNode) Start() error {
 
 	node.chunkManager = chunkManager
 
+	if Params.CommonCfg.BloomFilterLazyLoad {
+		// the stats logs checked by the datanodes are written by themselves, so they're never validated
+		node.pkStatsCache, err = storage.NewPkStatsCache(chunkManager, Params.CommonCfg.BloomFilterCacheSize, 0)
+		if err != nil {
+			return err
+		}
+	}
+
 	go node.BackGroundGC(node.clearSignal)
 
 	go node.compactionExecutor.start(node.ctx)
@@ -517,6 +526,10 @@ func (node *DataNode) Start() error {
 	// Start node watch node
 	go node.StartWatchChannels(node.ctx)
 
+	if Params.DataNodeCfg.TimeTickWatchdogEnabled {
+		go newTtWatchdog(node).start(node.ctx)
+	}
+
 	Params.DataNodeCfg.CreatedTime = time.Now()
 	Params.DataNodeCfg.UpdatedTime = time.Now()
 
@@ -701,6 +714,10 @@ func (node *DataNode) Stop() error {
 	node.cancel()
 	node.flowgraphManager.dropAll()
 
+	if node.pkStatsCache != nil {
+		node.pkStatsCache.Close()
+	}
+
 	if node.rowIDAllocator != nil {
 		log.Info("close id allocator", zap.String("role", typeutil.DataNodeRole))
 		node.rowIDAllocator.Close()
@@ -1082,12 +1099,36 @@ func (node *DataNode) Import(ctx context.Context, req *datapb.ImportTaskRequest)
 		}, nil
 	}
 
+	// imported rows must be routed to the same shards as inserted rows, and written with the same payload compression
+	shardRouting, err := typeutil.GetShardRouting(colInfo.GetProperties())
+	var payloadCompression storage.PayloadCompression
+	if err == nil {
+		payloadCompression, err = storage.GetPayloadCompression(colInfo.GetProperties())
+	}
+	if err != nil {
+		log.Warn("invalid properties of collection",
+			zap.Int64("task ID", req.GetImportTask().GetTaskId()),
+			zap.Int64("collection ID", req.GetImportTask().GetCollectionId()),
+			zap.Error(err))
+		importResult.State = commonpb.ImportState_ImportFailed
+		importResult.Infos = append(importResult.Infos, &commonpb.KeyValuePair{Key: "failed_reason", Value: err.Error()})
+		reportErr := reportFunc(importResult)
+		if reportErr != nil {
+			log.Warn("fail to report import state to RootCoord", zap.Error(err))
+		}
+		return &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+			Reason:    err.Error(),
+		}, nil
+	}
+
 	// parse files and generate segments
 	segmentSize := int64(Params.DataCoordCfg.SegmentMaxSize) * 1024 * 1024
 	importWrapper := importutil.NewImportWrapper(newCtx, colInfo.GetSchema(), colInfo.GetShardsNum(), segmentSize, node.rowIDAllocator,
 		node.chunkManager, importResult, reportFunc)
+	importWrapper.SetShardRouting(shardRouting)
 	importWrapper.SetCallbackFunctions(assignSegmentFunc(node, req),
-		createBinLogsFunc(node, req, colInfo.GetSchema(), ts),
+		createBinLogsFunc(node, req, colInfo.GetSchema(), payloadCompression, ts),
 		saveSegmentFunc(node, req, importResult, ts))
 	// todo: pass tsStart and tsStart after import_wrapper support
 	tsStart, tsEnd, err := importutil.ParseTSFromOptions(req.GetImportTask().GetInfos())
@@ -1239,7 +1280,8 @@ func assignSegmentFunc(node *DataNode, req *datapb.ImportTaskRequest) importutil
 	}
 }
 
-func createBinLogsFunc(node *DataNode, req *datapb.ImportTaskRequest, schema *schemapb.CollectionSchema, ts Timestamp) importutil.CreateBinlogsFunc {
+func createBinLogsFunc(node *DataNode, req *datapb.ImportTaskRequest, schema *schemapb.CollectionSchema,
+	payloadCompression storage.PayloadCompression, ts Timestamp) importutil.CreateBinlogsFunc {
 	return func(fields map[storage.FieldID]storage.FieldData, segmentID int64) ([]*datapb.FieldBinlog, []*datapb.FieldBinlog, error) {
 		var rowNum int
 		for _, field := range fields {
@@ -1261,7 +1303,7 @@ func createBinLogsFunc(node *DataNode, req *datapb.ImportTaskRequest, schema *sc
 		colID := req.GetImportTask().GetCollectionId()
 		partID := req.GetImportTask().GetPartitionId()
 
-		fieldInsert, fieldStats, err := createBinLogs(rowNum, schema, ts, fields, node, segmentID, colID, partID)
+		fieldInsert, fieldStats, err := createBinLogs(rowNum, schema, payloadCompression, ts, fields, node, segmentID, colID, partID)
 		if err != nil {
 			log.Error("failed to create binlogs",
 				zap.Int64("task ID", importTaskID),
@@ -1377,7 +1419,7 @@ func composeAssignSegmentIDRequest(rowNum int, shardID int, chNames []string,
 	return segmentIDReq
 }
 
-func createBinLogs(rowNum int, schema *schemapb.CollectionSchema, ts Timestamp,
+func createBinLogs(rowNum int, schema *schemapb.CollectionSchema, payloadCompression storage.PayloadCompression, ts Timestamp,
 	fields map[storage.FieldID]storage.FieldData, node *DataNode, segmentID, colID, partID UniqueID) ([]*datapb.FieldBinlog, []*datapb.FieldBinlog, error) {
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1409,7 +1451,9 @@ func createBinLogs(rowNum int, schema *schemapb.CollectionSchema, ts Timestamp,
 		ID:     colID,
 		Schema: schema,
 	}
-	binLogs, statsBinLogs, err := storage.NewInsertCodec(meta).Serialize(partID, segmentID, data.buffer)
+	inCodec := storage.NewInsertCodec(meta)
+	inCodec.PayloadCompression = payloadCompression
+	binLogs, statsBinLogs, err := inCodec.Serialize(partID, segmentID, data.buffer)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1472,7 +1516,7 @@ func createBinLogs(rowNum int, schema *schemapb.CollectionSchema, ts Timestamp,
 		}
 	}
 
-	err = node.chunkManager.MultiWrite(ctx, kvs)
+	err = storage.MultiWriteBinlogs(ctx, node.chunkManager, colID, segmentID, kvs, getOrCreateIOPool().Cap())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
@@ -29,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/mq/msgstream/mqwrapper"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/commonpbutil"
@@ -58,6 +60,9 @@ type dataSyncService struct {
 	flushManager     flushManager // flush manager handles flush process
 	chunkManager     storage.ChunkManager
 	compactor        *compactionExecutor // reference to compaction executor
+
+	vchanInfo  *datapb.VchannelInfo // vchannel info the flowgraph started from
+	ttProgress *ttProgress          // time tick consumed by the flowgraph
 }
 
 func newDataSyncService(ctx context.Context,
@@ -96,6 +101,8 @@ func newDataSyncService(ctx context.Context,
 		flushingSegCache: flushingSegCache,
 		chunkManager:     chunkManager,
 		compactor:        compactor,
+		vchanInfo:        vchan,
+		ttProgress:       newTtProgress(),
 	}
 
 	if err := service.initNodes(vchan); err != nil {
@@ -204,6 +211,7 @@ func (dsService *dataSyncService) initNodes(vchanInfo *datapb.VchannelInfo) erro
 				partitionID:  segment.PartitionID,
 				numOfRows:    segment.GetNumOfRows(),
 				statsBinLogs: segment.Statslogs,
+				binLogs:      segment.Binlogs,
 				endPos:       segment.GetDmlPosition(),
 				recoverTs:    vchanInfo.GetSeekPosition().GetTimestamp()}); err != nil {
 				return nil, err
@@ -239,6 +247,7 @@ func (dsService *dataSyncService) initNodes(vchanInfo *datapb.VchannelInfo) erro
 				partitionID:  segment.PartitionID,
 				numOfRows:    segment.GetNumOfRows(),
 				statsBinLogs: segment.Statslogs,
+				binLogs:      segment.Binlogs,
 				recoverTs:    vchanInfo.GetSeekPosition().GetTimestamp(),
 			}); err != nil {
 				return nil, err
@@ -304,7 +313,7 @@ func (dsService *dataSyncService) initNodes(vchanInfo *datapb.VchannelInfo) erro
 	}
 
 	var ttNode Node
-	ttNode, err = newTTNode(c, dsService.dataCoord)
+	ttNode, err = newTTNode(c, dsService.dataCoord, dsService.ttProgress)
 	if err != nil {
 		return err
 	}
@@ -362,6 +371,44 @@ func (dsService *dataSyncService) initNodes(vchanInfo *datapb.VchannelInfo) erro
 	return nil
 }
 
+// recoveryVChannelInfo builds the vchannel info to restart the flowgraph from the current channel checkpoint,
+// the buffered but not yet flushed data will be consumed again.
+func (dsService *dataSyncService) recoveryVChannelInfo() *datapb.VchannelInfo {
+	seekPos := dsService.vchanInfo.GetSeekPosition()
+	if _, ttPos, _ := dsService.ttProgress.get(); ttPos != nil {
+		seekPos = dsService.channel.getChannelCheckpoint(ttPos)
+	}
+	if seekPos != nil {
+		// input node overwrites the channel name of seek position
+		seekPos = proto.Clone(seekPos).(*internalpb.MsgPosition)
+	}
+
+	unflushed := dsService.channel.listNotFlushedSegmentIDs()
+	unflushedSet := make(map[UniqueID]struct{}, len(unflushed))
+	for _, segID := range unflushed {
+		unflushedSet[segID] = struct{}{}
+	}
+	var flushed []UniqueID
+	for _, segID := range dsService.channel.listAllSegmentIDs() {
+		if _, ok := unflushedSet[segID]; !ok {
+			flushed = append(flushed, segID)
+		}
+	}
+	dropped := append([]UniqueID{}, dsService.vchanInfo.GetDroppedSegmentIds()...)
+	for _, compactedFrom := range dsService.channel.listCompactedSegmentIDs() {
+		dropped = append(dropped, compactedFrom...)
+	}
+
+	return &datapb.VchannelInfo{
+		CollectionID:        dsService.collectionID,
+		ChannelName:         dsService.vchannelName,
+		SeekPosition:        seekPos,
+		UnflushedSegmentIds: unflushed,
+		FlushedSegmentIds:   flushed,
+		DroppedSegmentIds:   dropped,
+	}
+}
+
 // getSegmentInfos return the SegmentInfo details according to the given ids through RPC to datacoord
 func (dsService *dataSyncService) getSegmentInfos(segmentIDs []int64) ([]*datapb.SegmentInfo, error) {
 	infoResp, err := dsService.dataCoord.GetSegmentInfo(dsService.ctx, &datapb.GetSegmentInfoRequest{
@@ -226,10 +226,11 @@ func (dn *deleteNode) filterSegmentByPK(partID UniqueID, pks []primaryKey, tss [
 	segID2Pks := make(map[UniqueID][]primaryKey)
 	segID2Tss := make(map[UniqueID][]uint64)
 	segments := dn.channel.filterSegments(partID)
-	for index, pk := range pks {
-		for _, segment := range segments {
-			segmentID := segment.segmentID
-			if segment.isPKExist(pk) {
+	for _, segment := range segments {
+		segmentID := segment.segmentID
+		exist := segment.pksExist(pks)
+		for index, pk := range pks {
+			if exist[index] {
 				segID2Pks[segmentID] = append(segID2Pks[segmentID], pk)
 				segID2Tss[segmentID] = append(segID2Tss[segmentID], tss[index])
 			}
@@ -540,7 +540,10 @@ func (ibNode *insertBufferNode) bufferInsertMsg(msg *msgstream.InsertMsg, startP
 	}
 
 	// Maybe there are large write zoom if frequent insert requests are met.
-	buffer.buffer = storage.MergeInsertData(buffer.buffer, addedBuffer)
+	merged := storage.MergeInsertData(buffer.buffer, addedBuffer)
+	ibNode.channel.updateBuffers(func() {
+		buffer.buffer = merged
+	})
 
 	tsData, err := storage.GetTimestampFromInsertData(addedBuffer)
 	if err != nil {
@@ -44,6 +44,7 @@ func (fm *flowgraphManager) addAndStart(dn *DataNode, vchan *datapb.VchannelInfo
 	}
 
 	channel := newChannel(vchan.GetChannelName(), vchan.GetCollectionID(), schema, dn.rootCoord, dn.chunkManager)
+	channel.pkStatsCache = dn.pkStatsCache
 
 	var alloc allocatorInterface = newAllocator(dn.rootCoord)
 
@@ -68,6 +69,30 @@ func (fm *flowgraphManager) release(vchanName string) {
 	rateCol.removeFlowGraphChannel(vchanName)
 }
 
+// restart closes the flowgraph of the vchannel and starts a new one from the current channel checkpoint,
+// which reconnects the consumer of the vchannel.
+func (fm *flowgraphManager) restart(dn *DataNode, vchanName string) error {
+	fg, ok := fm.getFlowgraphService(vchanName)
+	if !ok {
+		return fmt.Errorf("flowgraph of vchannel %s not found", vchanName)
+	}
+	schema, err := fg.channel.getCollectionSchema(fg.collectionID, 0)
+	if err != nil {
+		return err
+	}
+	vchan := fg.recoveryVChannelInfo()
+
+	log.Info("restart flowgraph", zap.String("vChannelName", vchanName),
+		zap.Uint64("seekTs", vchan.GetSeekPosition().GetTimestamp()),
+		zap.Int64s("unflushed segments", vchan.GetUnflushedSegmentIds()),
+		zap.Int64s("flushed segments", vchan.GetFlushedSegmentIds()))
+	if cur, ok := fm.getFlowgraphService(vchanName); !ok || cur != fg {
+		return fmt.Errorf("flowgraph of vchannel %s has been released or replaced", vchanName)
+	}
+	fm.release(vchanName)
+	return fm.addAndStart(dn, vchan, schema)
+}
+
 func (fm *flowgraphManager) getFlushCh(segID UniqueID) (chan<- flushMsg, error) {
 	var flushCh chan flushMsg
 
@@ -49,6 +49,7 @@ type ttNode struct {
 	channel        Channel
 	lastUpdateTime time.Time
 	dataCoord      types.DataCoord
+	progress       *ttProgress
 }
 
 // Name returns node name, implementing flowgraph.Node
@@ -74,6 +75,8 @@ func (ttn *ttNode) Operate(in []Msg) []Msg {
 		return []Msg{}
 	}
 
+	ttn.progress.update(fgMsg.timeRange.timestampMax, fgMsg.endPositions[0])
+
 	curTs, _ := tsoutil.ParseTS(fgMsg.timeRange.timestampMax)
 	if curTs.Sub(ttn.lastUpdateTime) >= updateChanCPInterval {
 		ttn.updateChannelCP(fgMsg.endPositions[0])
@@ -109,7 +112,7 @@ func (ttn *ttNode) updateChannelCP(ttPos *internalpb.MsgPosition) {
 	log.Info("UpdateChannelCheckpoint success", zap.String("channel", ttn.vChannelName), zap.Time("channelCPTs", channelCPTs))
 }
 
-func newTTNode(config *nodeConfig, dc types.DataCoord) (*ttNode, error) {
+func newTTNode(config *nodeConfig, dc types.DataCoord, progress *ttProgress) (*ttNode, error) {
 	baseNode := BaseNode{}
 	baseNode.SetMaxQueueLength(Params.DataNodeCfg.FlowGraphMaxQueueLength)
 	baseNode.SetMaxParallelism(Params.DataNodeCfg.FlowGraphMaxParallelism)
@@ -120,6 +123,7 @@ func newTTNode(config *nodeConfig, dc types.DataCoord) (*ttNode, error) {
 		channel:        config.channel,
 		lastUpdateTime: time.Time{}, // set to Zero to update channel checkpoint immediately after fg started
 		dataCoord:      dc,
+		progress:       progress,
 	}
 
 	return tt, nil
@@ -363,14 +363,23 @@ func (m *rendezvousFlushManager) flushBufferData(data *BufferData, segmentID Uni
 
 	// encode data and convert output data
 	inCodec := storage.NewInsertCodec(meta)
+	inCodec.PayloadCompression = m.getPayloadCompression(collID)
 
 	binLogs, statsBinlogs, err := inCodec.Serialize(partID, segmentID, data.buffer)
 	if err != nil {
 		return nil, err
 	}
 
+	var tsIndexBlob *Blob
+	if Params.DataNodeCfg.TimestampIndexEnabled {
+		tsIndexBlob, err = serializeTimestampIndex(data.buffer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// binlogs
-	start, _, err := m.allocIDBatch(uint32(len(binLogs) + len(statsBinlogs)))
+	start, _, err := m.allocIDBatch(uint32(len(binLogs) + len(statsBinlogs) + 1))
 	if err != nil {
 		return nil, err
 	}
@@ -426,8 +435,25 @@ func (m *rendezvousFlushManager) flushBufferData(data *BufferData, segmentID Uni
 		}
 	}
 
+	// the timestamp index log is the only stats log of the timestamp field
+	if tsIndexBlob != nil {
+		logidx := start + UniqueID(len(binLogs)+len(statsBinlogs))
+		k := metautil.JoinIDPath(collID, partID, segmentID, common.TimeStampField, logidx)
+		key := path.Join(m.ChunkManager.RootPath(), common.SegmentStatslogPath, k)
+		kvs[key] = tsIndexBlob.Value
+		field2Stats[common.TimeStampField] = &datapb.Binlog{
+			EntriesNum:    data.size,
+			TimestampFrom: data.tsFrom,
+			TimestampTo:   data.tsTo,
+			LogPath:       key,
+			LogSize:       int64(len(tsIndexBlob.Value)),
+		}
+	}
+
 	m.handleInsertTask(segmentID, &flushBufferInsertTask{
 		ChunkManager: m.ChunkManager,
+		collectionID: collID,
+		segmentID:    segmentID,
 		data:         kvs,
 	}, field2Insert, field2Stats, flushed, dropped, pos)
 
@@ -471,6 +497,8 @@ func (m *rendezvousFlushManager) flushDelData(data *DelDataBuf, segmentID Unique
 	log.Info("delete blob path", zap.String("path", blobPath))
 	m.handleDeleteTask(segmentID, &flushBufferDeleteTask{
 		ChunkManager: m.ChunkManager,
+		collectionID: collID,
+		segmentID:    segmentID,
 		data:         kvs,
 	}, data, pos)
 	return nil
@@ -566,7 +594,9 @@ func (m *rendezvousFlushManager) close() {
 
 type flushBufferInsertTask struct {
 	storage.ChunkManager
-	data map[string][]byte
+	collectionID UniqueID
+	segmentID    UniqueID
+	data         map[string][]byte
 }
 
 // flushInsertData implements flushInsertTask
@@ -575,7 +605,7 @@ func (t *flushBufferInsertTask) flushInsertData() error {
 	defer cancel()
 	if t.ChunkManager != nil && len(t.data) > 0 {
 		tr := timerecord.NewTimeRecorder("insertData")
-		err := t.MultiWrite(ctx, t.data)
+		err := storage.MultiWriteBinlogs(ctx, t.ChunkManager, t.collectionID, t.segmentID, t.data, getOrCreateIOPool().Cap())
 		metrics.DataNodeSave2StorageLatency.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.InsertLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
 		if err == nil {
 			for _, d := range t.data {
@@ -589,7 +619,9 @@ func (t *flushBufferInsertTask) flushInsertData() error {
 
 type flushBufferDeleteTask struct {
 	storage.ChunkManager
-	data map[string][]byte
+	collectionID UniqueID
+	segmentID    UniqueID
+	data         map[string][]byte
 }
 
 // flushDeleteData implements flushDeleteTask
@@ -598,7 +630,7 @@ func (t *flushBufferDeleteTask) flushDeleteData() error {
 	defer cancel()
 	if len(t.data) > 0 && t.ChunkManager != nil {
 		tr := timerecord.NewTimeRecorder("deleteData")
-		err := t.MultiWrite(ctx, t.data)
+		err := storage.MultiWriteBinlogs(ctx, t.ChunkManager, t.collectionID, t.segmentID, t.data, getOrCreateIOPool().Cap())
 		metrics.DataNodeSave2StorageLatency.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.DeleteLabel).Observe(float64(tr.ElapseSpan().Milliseconds()))
 		if err == nil {
 			for _, d := range t.data {
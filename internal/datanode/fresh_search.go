@@ -0,0 +1,257 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/distance"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// freshHit is a hit of the fresh search, the score is the negative distance for the metrics
+// that smaller distances are more similar, so that higher scores are always better.
+type freshHit struct {
+	pk    interface{}
+	score float32
+}
+
+// freshSearchResult is the top k hits of every query in descending order of score,
+// and the number of the buffered rows searched.
+type freshSearchResult struct {
+	hits [][]freshHit
+	rows int64
+}
+
+// searchBuffers searches the rows in the current insert buffers of the segments by brute force, the rows deleted
+// by the current, history and flushed delete buffers and the ones invisible at the travel timestamp are excluded.
+// The flushed delete buffers are kept by the segments until their current insert buffers roll, since the deltas
+// flushed before the insert buffers are not applied by anyone else to the buffered rows.
+func (c *ChannelMeta) searchBuffers(req *datapb.FreshSearchRequest) (*freshSearchResult, error) {
+	c.segMu.RLock()
+	defer c.segMu.RUnlock()
+	c.bufferMu.RLock()
+	defer c.bufferMu.RUnlock()
+
+	partitions := typeutil.NewUniqueSet(req.GetPartitionIDs()...)
+	// the deletes apply to the rows of all partitions
+	deleted := make(map[interface{}]Timestamp)
+	applyDeletes := func(buf *DelDataBuf) {
+		if buf == nil || buf.delData == nil {
+			return
+		}
+		for i, pk := range buf.delData.Pks {
+			if ts := buf.delData.Tss[i]; ts <= req.GetTravelTimestamp() && ts > deleted[pk.GetValue()] {
+				deleted[pk.GetValue()] = ts
+			}
+		}
+	}
+	var buffers []*InsertData
+	for _, seg := range c.segments {
+		if seg.collectionID != req.GetCollectionID() {
+			continue
+		}
+		// the history delete buffers are being flushed, the rows they delete may still be in the insert buffers
+		applyDeletes(seg.curDeleteBuf)
+		for _, buf := range seg.historyDeleteBuf {
+			applyDeletes(buf)
+		}
+		for _, buf := range seg.flushedDeleteBuf {
+			applyDeletes(buf)
+		}
+		if seg.curInsertBuf == nil || seg.curInsertBuf.buffer == nil {
+			continue
+		}
+		if partitions.Len() > 0 && !partitions.Contain(seg.partitionID) {
+			continue
+		}
+		buffers = append(buffers, seg.curInsertBuf.buffer)
+	}
+
+	dim := req.GetDim()
+	result := &freshSearchResult{hits: make([][]freshHit, req.GetNq())}
+	for _, data := range buffers {
+		vectors, ok := data.Data[req.GetVectorFieldID()].(*storage.FloatVectorFieldData)
+		if !ok {
+			return nil, fmt.Errorf("field %d of collection %d is not a float vector field", req.GetVectorFieldID(), req.GetCollectionID())
+		}
+		if int64(vectors.Dim) != dim {
+			return nil, fmt.Errorf("dimension of field %d is %d, but %d is requested", req.GetVectorFieldID(), vectors.Dim, dim)
+		}
+		tss, ok := data.Data[common.TimeStampField].(*storage.Int64FieldData)
+		if !ok {
+			return nil, fmt.Errorf("no timestamp field in the insert buffer of collection %d", req.GetCollectionID())
+		}
+		pks, ok := data.Data[req.GetPkFieldID()]
+		if !ok {
+			return nil, fmt.Errorf("no primary field %d in the insert buffer of collection %d", req.GetPkFieldID(), req.GetCollectionID())
+		}
+		for row := 0; row < vectors.RowNum(); row++ {
+			ts := Timestamp(tss.Data[row])
+			if ts > req.GetTravelTimestamp() {
+				continue
+			}
+			pk := pks.GetRow(row)
+			if deleteTs, ok := deleted[pk]; ok && ts < deleteTs {
+				continue
+			}
+			result.rows++
+			for i := range result.hits {
+				query := req.GetVectors()[int64(i)*dim : int64(i+1)*dim]
+				hit := freshHit{pk: pk, score: freshSearchScore(req.GetMetricType(), dim, query, vectors.Data, int64(row))}
+				result.hits[i] = appendTopK(result.hits[i], hit, req.GetTopk())
+			}
+		}
+	}
+	return result, nil
+}
+
+// freshSearchScore returns the score of the @row-th vector of @vectors for @query, higher is better.
+func freshSearchScore(metric string, dim int64, query []float32, vectors []float32, row int64) float32 {
+	if distance.PositivelyRelated(metric) {
+		return distance.CalcIP(dim, query, 0, vectors, row)
+	}
+	return -distance.CalcL2(dim, query, 0, vectors, row)
+}
+
+// appendTopK inserts @hit into @hits in descending order of score, keeping at most @topk hits.
+func appendTopK(hits []freshHit, hit freshHit, topk int64) []freshHit {
+	if int64(len(hits)) >= topk && hits[len(hits)-1].score >= hit.score {
+		return hits
+	}
+	pos := sort.Search(len(hits), func(i int) bool { return hits[i].score < hit.score })
+	if int64(len(hits)) < topk {
+		hits = append(hits, freshHit{})
+	}
+	copy(hits[pos+1:], hits[pos:])
+	hits[pos] = hit
+	return hits
+}
+
+// validateFreshSearchRequest checks the request could be answered by the brute-force search.
+func validateFreshSearchRequest(req *datapb.FreshSearchRequest) error {
+	metric := strings.ToUpper(req.GetMetricType())
+	if metric != distance.L2 && metric != distance.IP {
+		return fmt.Errorf("metric type %s is not supported by fresh search", req.GetMetricType())
+	}
+	req.MetricType = metric
+	if req.GetTopk() <= 0 || req.GetDim() <= 0 || req.GetNq() <= 0 {
+		return fmt.Errorf("invalid nq %d, topk %d or dimension %d of fresh search", req.GetNq(), req.GetTopk(), req.GetDim())
+	}
+	if int64(len(req.GetVectors())) != req.GetNq()*req.GetDim() {
+		return fmt.Errorf("%d values of the query vectors, but %d queries of dimension %d are requested",
+			len(req.GetVectors()), req.GetNq(), req.GetDim())
+	}
+	return nil
+}
+
+// freshSearchResultData converts the hits of the fresh search to the search result data of @nq queries,
+// so that it's reduced by the proxy along with the results of the QueryNodes.
+func freshSearchResultData(hits [][]freshHit, nq, topk int64) *schemapb.SearchResultData {
+	data := &schemapb.SearchResultData{
+		NumQueries: nq,
+		TopK:       topk,
+		Scores:     []float32{},
+		Ids:        &schemapb.IDs{},
+		Topks:      make([]int64, nq),
+	}
+	var intIDs []int64
+	var strIDs []string
+	for i := int64(0); i < nq && i < int64(len(hits)); i++ {
+		for _, hit := range hits[i] {
+			switch pk := hit.pk.(type) {
+			case int64:
+				intIDs = append(intIDs, pk)
+			case string:
+				strIDs = append(strIDs, pk)
+			}
+			data.Scores = append(data.Scores, hit.score)
+		}
+		data.Topks[i] = int64(len(hits[i]))
+	}
+	if len(strIDs) > 0 {
+		data.Ids.IdField = &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: strIDs}}
+	} else if len(intIDs) > 0 {
+		data.Ids.IdField = &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: intIDs}}
+	}
+	return data
+}
+
+// FreshSearch searches the insert buffers of the channels of the collection by brute force,
+// the hits of all the channels are merged into one result.
+func (node *DataNode) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	resp := &datapb.FreshSearchResponse{
+		Status: &commonpb.Status{
+			ErrorCode: commonpb.ErrorCode_UnexpectedError,
+		},
+	}
+	if !node.isHealthy() {
+		resp.Status.Reason = msgDataNodeIsUnhealthy(paramtable.GetNodeID())
+		return resp, nil
+	}
+	if !Params.DataNodeCfg.FreshSearchEnabled {
+		resp.Status.Reason = "fresh search is not enabled on the datanode"
+		return resp, nil
+	}
+	if err := validateFreshSearchRequest(req); err != nil {
+		resp.Status.Reason = err.Error()
+		return resp, nil
+	}
+
+	merged := &freshSearchResult{hits: make([][]freshHit, req.GetNq())}
+	var searchErr error
+	node.flowgraphManager.flowgraphs.Range(func(key, value interface{}) bool {
+		ds := value.(*dataSyncService)
+		if ds.collectionID != req.GetCollectionID() {
+			return true
+		}
+		result, err := ds.channel.searchBuffers(req)
+		if err != nil {
+			searchErr = err
+			return false
+		}
+		merged.rows += result.rows
+		for i, hits := range result.hits {
+			for _, hit := range hits {
+				merged.hits[i] = appendTopK(merged.hits[i], hit, req.GetTopk())
+			}
+		}
+		return true
+	})
+	if searchErr != nil {
+		log.Warn("failed to search insert buffers", zap.Int64("collectionID", req.GetCollectionID()), zap.Error(searchErr))
+		resp.Status.Reason = searchErr.Error()
+		return resp, nil
+	}
+
+	resp.Status.ErrorCode = commonpb.ErrorCode_Success
+	resp.Results = []*schemapb.SearchResultData{freshSearchResultData(merged.hits, req.GetNq(), req.GetTopk())}
+	resp.Rows = merged.rows
+	return resp, nil
+}
@@ -0,0 +1,133 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func TestChannelMeta_searchBuffers(t *testing.T) {
+	newBuffer := func(pks []int64, tss []int64, vectors []float32) *BufferData {
+		return &BufferData{buffer: &InsertData{Data: map[storage.FieldID]storage.FieldData{
+			common.TimeStampField: &storage.Int64FieldData{NumRows: []int64{int64(len(tss))}, Data: tss},
+			100:                   &storage.Int64FieldData{NumRows: []int64{int64(len(pks))}, Data: pks},
+			101:                   &storage.FloatVectorFieldData{NumRows: []int64{int64(len(pks))}, Data: vectors, Dim: 2},
+		}}}
+	}
+	channel := &ChannelMeta{segments: map[UniqueID]*Segment{
+		1: {
+			collectionID: 1,
+			partitionID:  10,
+			curInsertBuf: newBuffer([]int64{1, 2, 3}, []int64{100, 100, 300}, []float32{0, 0, 1, 1, 2, 2}),
+			curDeleteBuf: &DelDataBuf{delData: &DeleteData{
+				Pks: []primaryKey{storage.NewInt64PrimaryKey(2)},
+				Tss: []Timestamp{150},
+			}},
+			// the deletes being flushed still apply
+			historyDeleteBuf: []*DelDataBuf{{delData: &DeleteData{
+				Pks: []primaryKey{storage.NewInt64PrimaryKey(4)},
+				Tss: []Timestamp{150},
+			}}},
+		},
+		2: {
+			collectionID: 1,
+			partitionID:  11,
+			curInsertBuf: newBuffer([]int64{4, 5, 7}, []int64{100, 200, 100}, []float32{3, 3, 0.5, 0.5, 1, 0}),
+			// the deletes flushed as deltas before the insert buffer still apply
+			historyDeleteBuf: []*DelDataBuf{{
+				delData: &DeleteData{Pks: []primaryKey{storage.NewInt64PrimaryKey(7)}, Tss: []Timestamp{120}},
+				endPos:  &internalpb.MsgPosition{Timestamp: 120},
+			}},
+		},
+		3: {
+			collectionID: 2,
+			partitionID:  20,
+			curInsertBuf: newBuffer([]int64{6}, []int64{100}, []float32{0, 0}),
+		},
+	}}
+
+	req := &datapb.FreshSearchRequest{
+		CollectionID:    1,
+		PkFieldID:       100,
+		VectorFieldID:   101,
+		MetricType:      "L2",
+		Nq:              2,
+		Topk:            2,
+		Dim:             2,
+		Vectors:         []float32{0, 0, 3, 3},
+		TravelTimestamp: 250,
+	}
+	require.NoError(t, validateFreshSearchRequest(req))
+	channel.segments[2].evictHistoryDeleteBuffer(&internalpb.MsgPosition{Timestamp: 120})
+	assert.Empty(t, channel.segments[2].historyDeleteBuf)
+	// pk 2, 4 and 7 are deleted, pk 3 is invisible at the travel timestamp
+	result, err := channel.searchBuffers(req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.rows)
+	assert.Equal(t, []freshHit{{pk: int64(1), score: 0}, {pk: int64(5), score: -0.5}}, result.hits[0])
+	assert.Equal(t, []freshHit{{pk: int64(5), score: -12.5}, {pk: int64(1), score: -18}}, result.hits[1])
+
+	data := freshSearchResultData(result.hits, 2, 2)
+	assert.Equal(t, []int64{2, 2}, data.GetTopks())
+	assert.Equal(t, []int64{1, 5, 5, 1}, data.GetIds().GetIntId().GetData())
+	assert.Equal(t, []float32{0, -0.5, -12.5, -18}, data.GetScores())
+
+	req.PartitionIDs = []int64{11}
+	req.MetricType = "IP"
+	result, err = channel.searchBuffers(req)
+	require.NoError(t, err)
+	assert.Equal(t, []freshHit{{pk: int64(5), score: 3}}, result.hits[1])
+
+	req.VectorFieldID = 100
+	_, err = channel.searchBuffers(req)
+	assert.Error(t, err)
+
+	// the flushed deletes are dropped along with the rows they delete once the insert buffer rolls
+	channel.segments[2].rollInsertBuffer()
+	assert.Empty(t, channel.segments[2].flushedDeleteBuf)
+
+	assert.Error(t, validateFreshSearchRequest(&datapb.FreshSearchRequest{MetricType: "HAMMING", Nq: 1, Topk: 1, Dim: 2}))
+	assert.Error(t, validateFreshSearchRequest(&datapb.FreshSearchRequest{MetricType: "L2", Nq: 1, Topk: 0, Dim: 2}))
+	assert.Error(t, validateFreshSearchRequest(&datapb.FreshSearchRequest{MetricType: "L2", Nq: 1, Topk: 1, Dim: 2,
+		Vectors: []float32{1}}))
+}
+
+func TestFreshSearchResultData(t *testing.T) {
+	data := freshSearchResultData([][]freshHit{{{pk: "a", score: 1}}}, 2, 2)
+	assert.Equal(t, []int64{1, 0}, data.GetTopks())
+	assert.Equal(t, []string{"a"}, data.GetIds().GetStrId().GetData())
+
+	data = freshSearchResultData(nil, 1, 2)
+	assert.Equal(t, []int64{0}, data.GetTopks())
+	assert.Empty(t, data.GetScores())
+}
+
+func TestAppendTopK(t *testing.T) {
+	var hits []freshHit
+	for i, score := range []float32{1, 3, 2, 0, 5} {
+		hits = appendTopK(hits, freshHit{pk: int64(i), score: score}, 3)
+	}
+	assert.Equal(t, []freshHit{{pk: int64(4), score: 5}, {pk: int64(1), score: 3}, {pk: int64(2), score: 2}}, hits)
+}
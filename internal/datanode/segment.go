@@ -17,10 +17,11 @@
 package datanode
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
-	"github.com/bits-and-blooms/bloom/v3"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
@@ -30,6 +31,9 @@ import (
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
 )
 
+// pkStatsLoadTimeout bounds the lazy loading of the history stats of a segment.
+const pkStatsLoadTimeout = 30 * time.Second
+
 // Segment contains the latest segment infos from channel.
 type Segment struct {
 	collectionID UniqueID
@@ -45,10 +49,21 @@ type Segment struct {
 	curDeleteBuf     *DelDataBuf
 	historyInsertBuf []*BufferData
 	historyDeleteBuf []*DelDataBuf
+	// the flushed delete buffers since curInsertBuf was created, the rows they delete may still be in curInsertBuf,
+	// so the fresh searches keep applying them until curInsertBuf is rolled
+	flushedDeleteBuf []*DelDataBuf
 
 	statLock     sync.RWMutex
 	currentStat  *storage.PkStatistics
 	historyStats []*storage.PkStatistics
+	// set if the history stats are loaded lazily from statsLogPaths by pkStatsCache,
+	// their pk ranges are kept in pkRanges to check the pks before loading them
+	statsLogPaths []string
+	pkStatsCache  *storage.PkStatsCache
+	pkRanges      []*storage.PkStatistics
+	// set if the segment has no pk stats logs, the pk ranges read from the footers of its pk binlogs,
+	// the pks in them may exist
+	binlogPkRanges []*storage.PkStatistics
 
 	lastSyncTs Timestamp
 	startPos   *internalpb.MsgPosition // TODO readonly
@@ -60,6 +75,7 @@ type addSegmentReq struct {
 	numOfRows                  int64
 	startPos, endPos           *internalpb.MsgPosition
 	statsBinLogs               []*datapb.FieldBinlog
+	binLogs                    []*datapb.FieldBinlog
 	recoverTs                  Timestamp
 	importing                  bool
 }
@@ -93,25 +109,113 @@ func (s *Segment) updatePKRange(ids storage.FieldData) {
 func (s *Segment) InitCurrentStat() {
 	if s.currentStat == nil {
 		s.currentStat = &storage.PkStatistics{
-			PkFilter: bloom.NewWithEstimates(storage.BloomFilterSize, storage.MaxBloomFalsePositive),
+			PkFilter: storage.NewBloomFilterWithRows(storage.BloomFilterSize),
 		}
 	}
 }
 
-// check if PK exists is current
-func (s *Segment) isPKExist(pk primaryKey) bool {
+// setLazyPkStats makes the history stats loaded from the stats logs on demand, only the pk ranges of @stats are kept.
+func (s *Segment) setLazyPkStats(pkStatsCache *storage.PkStatsCache, statsLogPaths []string, stats []*storage.PkStatistics) {
+	s.statLock.Lock()
+	defer s.statLock.Unlock()
+	s.pkStatsCache = pkStatsCache
+	s.statsLogPaths = statsLogPaths
+	s.pkRanges = storage.PkRanges(stats)
+}
+
+// setBinlogPkRanges makes the pks in @pkRanges treated as existing, for the segments without pk stats logs.
+func (s *Segment) setBinlogPkRanges(pkRanges []*storage.PkStatistics) {
 	s.statLock.Lock()
 	defer s.statLock.Unlock()
-	if s.currentStat != nil && s.currentStat.PkExist(pk) {
-		return true
+	s.binlogPkRanges = pkRanges
+}
+
+// hasPkStats returns whether the segment has any pk stats to check the pks.
+func (s *Segment) hasPkStats() bool {
+	s.statLock.RLock()
+	defer s.statLock.RUnlock()
+	return s.currentStat != nil || len(s.historyStats) > 0 || len(s.pkRanges) > 0 || len(s.binlogPkRanges) > 0
+}
+
+// releasePkStats frees the memory of the pk stats.
+func (s *Segment) releasePkStats() {
+	s.statLock.Lock()
+	defer s.statLock.Unlock()
+	s.currentStat = nil
+	s.historyStats = nil
+	s.binlogPkRanges = nil
+	if s.pkStatsCache != nil {
+		s.pkStatsCache.Remove(s.segmentID)
+		s.pkStatsCache = nil
+		s.statsLogPaths = nil
+		s.pkRanges = nil
 	}
+}
 
-	for _, historyStats := range s.historyStats {
-		if historyStats.PkExist(pk) {
-			return true
+// check if PK exists is current
+func (s *Segment) isPKExist(pk primaryKey) bool {
+	return s.pksExist([]primaryKey{pk})[0]
+}
+
+// pksExist checks if every pk of @pks may exist in the segment. The history stats loaded lazily are loaded
+// outside statLock at most once for all the pks, and only if some pk is in their pk ranges.
+func (s *Segment) pksExist(pks []primaryKey) []bool {
+	exist := make([]bool, len(pks))
+	var inLazyRanges []int
+	s.statLock.RLock()
+	for i, pk := range pks {
+		if s.currentStat != nil && s.currentStat.PkExist(pk) {
+			exist[i] = true
+			continue
+		}
+		for _, historyStat := range s.historyStats {
+			if historyStat.PkExist(pk) {
+				exist[i] = true
+				break
+			}
+		}
+		for _, pkRange := range s.binlogPkRanges {
+			if pkRange.PkInRange(pk) {
+				exist[i] = true
+				break
+			}
+		}
+		if exist[i] {
+			continue
+		}
+		for _, pkRange := range s.pkRanges {
+			if pkRange.PkInRange(pk) {
+				inLazyRanges = append(inLazyRanges, i)
+				break
+			}
+		}
+	}
+	pkStatsCache, statsLogPaths := s.pkStatsCache, s.statsLogPaths
+	s.statLock.RUnlock()
+	if len(inLazyRanges) == 0 || pkStatsCache == nil {
+		return exist
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pkStatsLoadTimeout)
+	defer cancel()
+	stats, err := pkStatsCache.Get(ctx, s.segmentID, statsLogPaths)
+	if err != nil {
+		// unable to filter by the stats, the pks may exist
+		log.Warn("failed to load pk stats lazily", zap.Int64("segmentID", s.segmentID), zap.Error(err))
+		for _, i := range inLazyRanges {
+			exist[i] = true
+		}
+		return exist
+	}
+	for _, i := range inLazyRanges {
+		for _, stat := range stats {
+			if stat.PkExist(pks[i]) {
+				exist[i] = true
+				break
+			}
 		}
 	}
-	return false
+	return exist
 }
 
 // rollInsertBuffer moves curInsertBuf to historyInsertBuf, and then sets curInsertBuf to nil.
@@ -122,6 +226,7 @@ func (s *Segment) rollInsertBuffer() {
 	s.curInsertBuf.buffer = nil // free buffer memory, only keep meta infos in historyInsertBuf
 	s.historyInsertBuf = append(s.historyInsertBuf, s.curInsertBuf)
 	s.curInsertBuf = nil
+	s.flushedDeleteBuf = nil
 }
 
 // evictHistoryInsertBuffer removes flushed buffer from historyInsertBuf after saveBinlogPath.
@@ -138,21 +243,24 @@ func (s *Segment) evictHistoryInsertBuffer(endPos *internalpb.MsgPosition) {
 }
 
 // rollDeleteBuffer moves curDeleteBuf to historyDeleteBuf, and then sets curDeleteBuf to nil.
+// The deletes are kept until the buffer is evicted, since the fresh searches still apply them while they're flushed.
 func (s *Segment) rollDeleteBuffer() {
 	if s.curDeleteBuf == nil {
 		return
 	}
-	s.curDeleteBuf.delData = nil // free buffer memory, only keep meta infos in historyDeleteBuf
 	s.historyDeleteBuf = append(s.historyDeleteBuf, s.curDeleteBuf)
 	s.curDeleteBuf = nil
 }
 
-// evictHistoryDeleteBuffer removes flushed buffer from historyDeleteBuf after saveBinlogPath.
+// evictHistoryDeleteBuffer removes flushed buffer from historyDeleteBuf after saveBinlogPath,
+// they're moved to flushedDeleteBuf if the rows they delete may still be in curInsertBuf.
 func (s *Segment) evictHistoryDeleteBuffer(endPos *internalpb.MsgPosition) {
 	tmpBuffers := make([]*DelDataBuf, 0)
 	for _, buf := range s.historyDeleteBuf {
 		if buf.endPos.Timestamp > endPos.Timestamp {
 			tmpBuffers = append(tmpBuffers, buf)
+		} else if s.curInsertBuf != nil {
+			s.flushedDeleteBuf = append(s.flushedDeleteBuf, buf)
 		}
 	}
 	s.historyDeleteBuf = tmpBuffers
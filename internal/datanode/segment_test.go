@@ -56,3 +56,26 @@ func TestEmptySegment(t *testing.T) {
 	pk := newInt64PrimaryKey(1000)
 	assert.False(t, seg.isPKExist(pk))
 }
+
+func TestSegment_LazyPkStats(t *testing.T) {
+	cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	pkStatsCache, err := storage.NewPkStatsCache(cm, 1, 0)
+	assert.NoError(t, err)
+	defer pkStatsCache.Close()
+
+	seg := &Segment{segmentID: 1}
+	seg.setLazyPkStats(pkStatsCache, []string{"not-exist"}, []*storage.PkStatistics{{
+		PkFilter: storage.NewBloomFilterWithRows(storage.BloomFilterSize),
+		MinPK:    newInt64PrimaryKey(10),
+		MaxPK:    newInt64PrimaryKey(20),
+	}})
+
+	// out of the pk range, the stats are not loaded
+	assert.Equal(t, []bool{false, false}, seg.pksExist([]primaryKey{newInt64PrimaryKey(5), newInt64PrimaryKey(30)}))
+	// the stats logs fail to load, the pks in the range may exist
+	assert.Equal(t, []bool{false, true}, seg.pksExist([]primaryKey{newInt64PrimaryKey(5), newInt64PrimaryKey(15)}))
+	assert.Equal(t, 0, pkStatsCache.Len())
+
+	seg.releasePkStats()
+	assert.False(t, seg.isPKExist(newInt64PrimaryKey(15)))
+}
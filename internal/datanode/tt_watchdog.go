@@ -0,0 +1,223 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+const (
+	ttStallReconnectLabel = "reconnect"
+	ttStallEscalateLabel  = "escalate"
+	ttStallRecoveredLabel = "recovered"
+)
+
+// ttProgress records the latest time tick consumed by a flowgraph and when it advanced.
+type ttProgress struct {
+	mu         sync.RWMutex
+	ts         Timestamp
+	position   *internalpb.MsgPosition
+	updateTime time.Time
+}
+
+func newTtProgress() *ttProgress {
+	return &ttProgress{updateTime: time.Now()}
+}
+
+func (p *ttProgress) update(ts Timestamp, position *internalpb.MsgPosition) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ts <= p.ts {
+		return
+	}
+	p.ts = ts
+	p.position = position
+	p.updateTime = time.Now()
+}
+
+// get returns the latest consumed time tick, its position and when it advanced.
+func (p *ttProgress) get() (Timestamp, *internalpb.MsgPosition, time.Time) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.ts, p.position, p.updateTime
+}
+
+// channelStall is a vchannel whose time tick stops advancing.
+type channelStall struct {
+	ts        Timestamp // time tick consumed when the stall was detected
+	attempts  int       // times of reconnecting
+	escalated bool      // datacoord has been asked to reassign the vchannel
+}
+
+// ttWatchdog detects vchannels whose time tick stops advancing, which makes the inserted data unqueryable
+// under strong consistency. A stalled vchannel is reconnected from its channel checkpoint first, if it
+// is still stalled after several reconnections, datacoord is asked to reassign it to another datanode.
+type ttWatchdog struct {
+	fm             *flowgraphManager
+	checkInterval  time.Duration
+	stallThreshold time.Duration
+	maxReconnect   int
+
+	reconnect func(vChanName string) error
+	escalate  func(vChanName string) error
+
+	stalls map[string]*channelStall
+}
+
+func newTtWatchdog(node *DataNode) *ttWatchdog {
+	return &ttWatchdog{
+		fm:             node.flowgraphManager,
+		checkInterval:  Params.DataNodeCfg.TimeTickWatchdogCheckInterval,
+		stallThreshold: Params.DataNodeCfg.TimeTickStallThreshold,
+		maxReconnect:   Params.DataNodeCfg.TimeTickStallMaxReconnectTimes,
+		reconnect: func(vChanName string) error {
+			return node.flowgraphManager.restart(node, vChanName)
+		},
+		escalate: node.markChannelWatchFailure,
+		stalls:   make(map[string]*channelStall),
+	}
+}
+
+func (w *ttWatchdog) start(ctx context.Context) {
+	log.Info("start time tick watchdog", zap.Duration("checkInterval", w.checkInterval),
+		zap.Duration("stallThreshold", w.stallThreshold), zap.Int("maxReconnect", w.maxReconnect))
+	ticker := time.NewTicker(w.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("time tick watchdog quit")
+			return
+		case <-ticker.C:
+			w.check(time.Now())
+		}
+	}
+}
+
+func (w *ttWatchdog) check(now time.Time) {
+	progresses := make(map[string]*ttProgress)
+	w.fm.flowgraphs.Range(func(key, value interface{}) bool {
+		progresses[key.(string)] = value.(*dataSyncService).ttProgress
+		return true
+	})
+	for vChanName := range w.stalls {
+		if _, ok := progresses[vChanName]; !ok {
+			delete(w.stalls, vChanName)
+		}
+	}
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	for vChanName, progress := range progresses {
+		ts, _, updateTime := progress.get()
+		stall, stalled := w.stalls[vChanName]
+		if stalled && ts > stall.ts {
+			log.Info("time tick of vchannel recovered", zap.String("vChannelName", vChanName),
+				zap.Int("reconnect times", stall.attempts), zap.Bool("escalated", stall.escalated))
+			metrics.DataNodeTimeTickStallCount.WithLabelValues(nodeID, vChanName, ttStallRecoveredLabel).Inc()
+			delete(w.stalls, vChanName)
+			stalled = false
+		}
+		if now.Sub(updateTime) < w.stallThreshold {
+			continue
+		}
+		if !stalled {
+			stall = &channelStall{ts: ts}
+			w.stalls[vChanName] = stall
+		}
+		if stall.escalated {
+			continue
+		}
+
+		ttTime, _ := tsoutil.ParseTS(stall.ts)
+		if stall.attempts < w.maxReconnect {
+			stall.attempts++
+			log.Warn("time tick of vchannel stalled, reconnect the vchannel",
+				zap.String("vChannelName", vChanName),
+				zap.Time("timeTick", ttTime),
+				zap.Duration("stalled", now.Sub(updateTime)),
+				zap.Int("attempt", stall.attempts))
+			metrics.DataNodeTimeTickStallCount.WithLabelValues(nodeID, vChanName, ttStallReconnectLabel).Inc()
+			if err := w.reconnect(vChanName); err != nil {
+				log.Warn("failed to reconnect stalled vchannel", zap.String("vChannelName", vChanName), zap.Error(err))
+			}
+			continue
+		}
+
+		log.Error("time tick of vchannel is still stalled after reconnecting, ask datacoord to reassign the vchannel",
+			zap.String("vChannelName", vChanName),
+			zap.Time("timeTick", ttTime),
+			zap.Duration("stalled", now.Sub(updateTime)),
+			zap.Int("reconnect times", stall.attempts))
+		metrics.DataNodeTimeTickStallCount.WithLabelValues(nodeID, vChanName, ttStallEscalateLabel).Inc()
+		if err := w.escalate(vChanName); err != nil {
+			log.Warn("failed to ask datacoord to reassign stalled vchannel", zap.String("vChannelName", vChanName), zap.Error(err))
+			continue
+		}
+		stall.escalated = true
+	}
+}
+
+// markChannelWatchFailure sets the watch state of the vchannel to WatchFailure,
+// then datacoord releases the vchannel from this datanode and reassigns it.
+func (node *DataNode) markChannelWatchFailure(vChanName string) error {
+	key := path.Join(Params.DataNodeCfg.ChannelWatchSubPath, fmt.Sprintf("%d", paramtable.GetNodeID()), vChanName)
+	_, values, versions, err := node.watchKv.LoadWithPrefix2(key)
+	if err != nil {
+		return err
+	}
+	for i, value := range values {
+		watchInfo := &datapb.ChannelWatchInfo{}
+		// keys of other vchannels may share the prefix
+		if err := proto.Unmarshal([]byte(value), watchInfo); err != nil || watchInfo.GetVchan().GetChannelName() != vChanName {
+			continue
+		}
+		if watchInfo.GetState() != datapb.ChannelWatchState_WatchSuccess &&
+			watchInfo.GetState() != datapb.ChannelWatchState_Complete {
+			return fmt.Errorf("unexpected watch state %s of vchannel %s", watchInfo.GetState().String(), vChanName)
+		}
+
+		watchInfo.State = datapb.ChannelWatchState_WatchFailure
+		v, err := proto.Marshal(watchInfo)
+		if err != nil {
+			return err
+		}
+		success, err := node.watchKv.CompareVersionAndSwap(key, versions[i], string(v))
+		if err != nil {
+			return err
+		}
+		if !success {
+			return fmt.Errorf("watch info of vchannel %s changed", vChanName)
+		}
+		log.Info("mark vchannel watch failure", zap.String("key", key), zap.String("vChannelName", vChanName))
+		return nil
+	}
+	return fmt.Errorf("watch info of vchannel %s not found", vChanName)
+}
@@ -0,0 +1,162 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datanode
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/etcd"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+func TestTtProgress(t *testing.T) {
+	p := newTtProgress()
+	ts, pos, updateTime := p.get()
+	assert.Equal(t, Timestamp(0), ts)
+	assert.Nil(t, pos)
+
+	p.update(100, &internalpb.MsgPosition{Timestamp: 100})
+	ts, pos, updateTime2 := p.get()
+	assert.Equal(t, Timestamp(100), ts)
+	assert.Equal(t, Timestamp(100), pos.GetTimestamp())
+	assert.False(t, updateTime2.Before(updateTime))
+
+	// time tick doesn't advance
+	p.update(100, &internalpb.MsgPosition{Timestamp: 100})
+	p.update(50, &internalpb.MsgPosition{Timestamp: 50})
+	ts, _, updateTime3 := p.get()
+	assert.Equal(t, Timestamp(100), ts)
+	assert.Equal(t, updateTime2, updateTime3)
+}
+
+func TestTtWatchdog(t *testing.T) {
+	ch := "by-dev-rootcoord-dml-channel_0_1v0"
+	var reconnected, escalated []string
+	var escalateErr error
+	fm := newFlowgraphManager()
+	w := &ttWatchdog{
+		fm:             fm,
+		checkInterval:  time.Second,
+		stallThreshold: time.Minute,
+		maxReconnect:   2,
+		reconnect: func(vChanName string) error {
+			reconnected = append(reconnected, vChanName)
+			return nil
+		},
+		escalate: func(vChanName string) error {
+			escalated = append(escalated, vChanName)
+			return escalateErr
+		},
+		stalls: make(map[string]*channelStall),
+	}
+	progress := newTtProgress()
+	progress.update(100, nil)
+	fm.flowgraphs.Store(ch, &dataSyncService{ttProgress: progress})
+	now := time.Now()
+
+	// not stalled
+	w.check(now)
+	assert.Empty(t, reconnected)
+	assert.Empty(t, w.stalls)
+
+	// stalled, reconnect twice
+	w.check(now.Add(2 * time.Minute))
+	w.check(now.Add(4 * time.Minute))
+	assert.Equal(t, []string{ch, ch}, reconnected)
+	assert.Empty(t, escalated)
+
+	// escalate fails, retry next time
+	escalateErr = errors.New("mock")
+	w.check(now.Add(6 * time.Minute))
+	assert.Equal(t, []string{ch}, escalated)
+	assert.False(t, w.stalls[ch].escalated)
+
+	escalateErr = nil
+	w.check(now.Add(8 * time.Minute))
+	w.check(now.Add(10 * time.Minute))
+	assert.Equal(t, []string{ch, ch}, escalated)
+	assert.True(t, w.stalls[ch].escalated)
+	assert.Len(t, reconnected, 2)
+
+	// recovered
+	progress.update(200, nil)
+	w.check(time.Now())
+	assert.Empty(t, w.stalls)
+
+	// released
+	w.check(time.Now().Add(2 * time.Minute))
+	assert.Len(t, w.stalls, 1)
+	fm.flowgraphs.Delete(ch)
+	w.check(time.Now())
+	assert.Empty(t, w.stalls)
+}
+
+func TestMarkChannelWatchFailure(t *testing.T) {
+	etcdCli, err := etcd.GetEtcdClient(&Params.EtcdCfg)
+	require.NoError(t, err)
+	defer etcdCli.Close()
+	kv := etcdkv.NewEtcdKV(etcdCli, Params.EtcdCfg.MetaRootPath.GetValue())
+	node := &DataNode{watchKv: kv}
+
+	ch := fmt.Sprintf("datanode-etcd-test-by-dev-rootcoord-dml-channel_%d", rand.Int31())
+	prefix := fmt.Sprintf("%s/%d", Params.DataNodeCfg.ChannelWatchSubPath, paramtable.GetNodeID())
+	defer kv.RemoveWithPrefix(prefix)
+
+	err = node.markChannelWatchFailure(ch)
+	assert.Error(t, err)
+
+	save := func(vChanName string, state datapb.ChannelWatchState) {
+		info := &datapb.ChannelWatchInfo{
+			State: state,
+			Vchan: &datapb.VchannelInfo{CollectionID: 1, ChannelName: vChanName},
+		}
+		val, err := proto.Marshal(info)
+		require.NoError(t, err)
+		err = kv.Save(fmt.Sprintf("%s/%s", prefix, vChanName), string(val))
+		require.NoError(t, err)
+	}
+	// another channel shares the prefix
+	save(ch+"0", datapb.ChannelWatchState_WatchSuccess)
+	save(ch, datapb.ChannelWatchState_ToRelease)
+	err = node.markChannelWatchFailure(ch)
+	assert.Error(t, err)
+
+	save(ch, datapb.ChannelWatchState_WatchSuccess)
+	err = node.markChannelWatchFailure(ch)
+	assert.NoError(t, err)
+
+	load := func(vChanName string) datapb.ChannelWatchState {
+		val, err := kv.Load(fmt.Sprintf("%s/%s", prefix, vChanName))
+		require.NoError(t, err)
+		info := &datapb.ChannelWatchInfo{}
+		require.NoError(t, proto.Unmarshal([]byte(val), info))
+		return info.GetState()
+	}
+	assert.Equal(t, datapb.ChannelWatchState_WatchFailure, load(ch))
+	assert.Equal(t, datapb.ChannelWatchState_WatchSuccess, load(ch+"0"))
+}
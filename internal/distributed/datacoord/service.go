@@ -407,3 +407,8 @@ func (s *Server) BroadcastAlteredCollection(ctx context.Context, request *milvus
 func (s *Server) CheckHealth(ctx context.Context, req *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error) {
 	return s.dataCoord.CheckHealth(ctx, req)
 }
+
+// FreshSearch is the distributed caller of FreshSearch.
+func (s *Server) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	return s.dataCoord.FreshSearch(ctx, req)
+}
@@ -70,6 +70,7 @@ type MockDataCoord struct {
 	unsetIsImportingStateResp *commonpb.Status
 	markSegmentsDroppedResp   *commonpb.Status
 	broadCastResp             *commonpb.Status
+	freshSearchResp           *datapb.FreshSearchResponse
 }
 
 func (m *MockDataCoord) Init() error {
@@ -239,6 +240,10 @@ func (m *MockDataCoord) CheckHealth(ctx context.Context, req *milvuspb.CheckHeal
 	}, nil
 }
 
+func (m *MockDataCoord) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	return m.freshSearchResp, m.err
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 func Test_NewServer(t *testing.T) {
 	paramtable.Init()
@@ -567,6 +572,15 @@ func Test_NewServer(t *testing.T) {
 		assert.Equal(t, true, ret.IsHealthy)
 	})
 
+	t.Run("fresh search", func(t *testing.T) {
+		server.dataCoord = &MockDataCoord{
+			freshSearchResp: &datapb.FreshSearchResponse{},
+		}
+		resp, err := server.FreshSearch(ctx, nil)
+		assert.Nil(t, err)
+		assert.NotNil(t, resp)
+	})
+
 	err := server.Stop()
 	assert.Nil(t, err)
 }
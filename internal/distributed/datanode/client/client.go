@@ -61,6 +61,7 @@ func NewClient(ctx context.Context, addr string) (*Client, error) {
 			InitialBackoff:         ClientParams.InitialBackoff,
 			MaxBackoff:             ClientParams.MaxBackoff,
 			BackoffMultiplier:      ClientParams.BackoffMultiplier,
+			CompressionType:        ClientParams.Compression,
 		},
 	}
 	client.grpcClient.SetRole(typeutil.DataNodeRole)
@@ -311,3 +312,21 @@ func (c *Client) SyncSegments(ctx context.Context, req *datapb.SyncSegmentsReque
 	}
 	return ret.(*commonpb.Status), err
 }
+
+// FreshSearch is the DataNode client side code for FreshSearch call.
+func (c *Client) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	req = typeutil.Clone(req)
+	commonpbutil.UpdateMsgBase(
+		req.GetBase(),
+		commonpbutil.FillMsgBaseFromClient(paramtable.GetNodeID()))
+	ret, err := c.grpcClient.ReCall(ctx, func(client datapb.DataNodeClient) (any, error) {
+		if !funcutil.CheckCtxValid(ctx) {
+			return nil, ctx.Err()
+		}
+		return client.FreshSearch(ctx, req)
+	})
+	if err != nil || ret == nil {
+		return nil, err
+	}
+	return ret.(*datapb.FreshSearchResponse), err
+}
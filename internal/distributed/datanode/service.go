@@ -381,3 +381,7 @@ func (s *Server) AddImportSegment(ctx context.Context, request *datapb.AddImport
 func (s *Server) SyncSegments(ctx context.Context, request *datapb.SyncSegmentsRequest) (*commonpb.Status, error) {
 	return s.datanode.SyncSegments(ctx, request)
 }
+
+func (s *Server) FreshSearch(ctx context.Context, request *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	return s.datanode.FreshSearch(ctx, request)
+}
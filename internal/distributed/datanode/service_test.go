@@ -51,6 +51,7 @@ type MockDataNode struct {
 	resendResp           *datapb.ResendSegmentStatsResponse
 	addImportSegmentResp *datapb.AddImportSegmentResponse
 	compactionResp       *datapb.CompactionStateResponse
+	freshSearchResp      *datapb.FreshSearchResponse
 }
 
 func (m *MockDataNode) Init() error {
@@ -143,6 +144,10 @@ func (m *MockDataNode) SyncSegments(ctx context.Context, req *datapb.SyncSegment
 	return m.status, m.err
 }
 
+func (m *MockDataNode) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	return m.freshSearchResp, m.err
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 type mockDataCoord struct {
 	types.DataCoord
@@ -320,6 +325,15 @@ func Test_NewServer(t *testing.T) {
 		assert.NotNil(t, resp)
 	})
 
+	t.Run("fresh search", func(t *testing.T) {
+		server.datanode = &MockDataNode{
+			freshSearchResp: &datapb.FreshSearchResponse{},
+		}
+		resp, err := server.FreshSearch(ctx, nil)
+		assert.Nil(t, err)
+		assert.NotNil(t, resp)
+	})
+
 	err = server.Stop()
 	assert.Nil(t, err)
 }
@@ -62,6 +62,7 @@ func NewClient(ctx context.Context, addr string, encryption bool) (*Client, erro
 			InitialBackoff:         ClientParams.InitialBackoff,
 			MaxBackoff:             ClientParams.MaxBackoff,
 			BackoffMultiplier:      ClientParams.BackoffMultiplier,
+			CompressionType:        ClientParams.Compression,
 		},
 	}
 	client.grpcClient.SetRole(typeutil.IndexNodeRole)
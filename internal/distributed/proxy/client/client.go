@@ -61,6 +61,7 @@ func NewClient(ctx context.Context, addr string) (*Client, error) {
 			InitialBackoff:         ClientParams.InitialBackoff,
 			MaxBackoff:             ClientParams.MaxBackoff,
 			BackoffMultiplier:      ClientParams.BackoffMultiplier,
+			CompressionType:        ClientParams.Compression,
 		},
 	}
 	client.grpcClient.SetRole(typeutil.ProxyRole)
@@ -22,8 +22,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/milvus-io/milvus/internal/util/metautil"
-
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
@@ -218,68 +216,73 @@ func (gc *garbageCollector) recycleUnusedIndexFiles() {
 			return
 		case <-ticker.C:
 			prefix := path.Join(gc.chunkManager.RootPath(), common.SegmentIndexPath) + "/"
-			// list dir first
-			keys, _, err := gc.chunkManager.ListWithPrefix(gc.ctx, prefix, false)
+			// walk dir first
+			err := gc.chunkManager.WalkWithPrefix(gc.ctx, prefix, false, func(chunkInfo storage.ChunkObjectInfo) bool {
+				gc.recycleIndexFilesOfKey(chunkInfo.FilePath)
+				return true
+			})
 			if err != nil {
-				log.Ctx(gc.ctx).Error("IndexCoord garbageCollector recycleUnusedIndexFiles list keys from chunk manager failed", zap.Error(err))
-				continue
+				log.Ctx(gc.ctx).Error("IndexCoord garbageCollector recycleUnusedIndexFiles walk keys from chunk manager failed", zap.Error(err))
 			}
-			for _, key := range keys {
-				log.Ctx(gc.ctx).Debug("indexFiles keys", zap.String("key", key))
-				buildID, err := parseBuildIDFromFilePath(key)
-				if err != nil {
-					log.Ctx(gc.ctx).Error("IndexCoord garbageCollector recycleUnusedIndexFiles parseIndexFileKey", zap.String("key", key), zap.Error(err))
-					continue
-				}
-				log.Ctx(gc.ctx).Info("IndexCoord garbageCollector will recycle index files", zap.Int64("buildID", buildID))
-				if !gc.metaTable.HasBuildID(buildID) {
-					// buildID no longer exists in meta, remove all index files
-					log.Ctx(gc.ctx).Info("IndexCoord garbageCollector recycleUnusedIndexFiles find meta has not exist, remove index files",
-						zap.Int64("buildID", buildID))
-					err = gc.chunkManager.RemoveWithPrefix(gc.ctx, key)
-					if err != nil {
-						log.Ctx(gc.ctx).Warn("IndexCoord garbageCollector recycleUnusedIndexFiles remove index files failed",
-							zap.Int64("buildID", buildID), zap.String("prefix", key), zap.Error(err))
-						continue
-					}
-					continue
-				}
-				log.Ctx(gc.ctx).Info("index meta can be recycled, recycle index files", zap.Int64("buildID", buildID))
-				canRecycle, segIdx := gc.metaTable.GetSegmentIndexByBuildID(buildID)
-				if !canRecycle {
-					// Even if the index is marked as deleted, the index file will not be recycled, wait for the next gc,
-					// and delete all index files about the buildID at one time.
-					log.Ctx(gc.ctx).Warn("IndexCoord garbageCollector can not recycle index files", zap.Int64("buildID", buildID))
-					continue
-				}
-				filesMap := make(map[string]struct{})
-				for _, fileID := range segIdx.IndexFileKeys {
-					filepath := metautil.BuildSegmentIndexFilePath(gc.chunkManager.RootPath(), segIdx.BuildID, segIdx.IndexVersion,
-						segIdx.PartitionID, segIdx.SegmentID, fileID)
-					filesMap[filepath] = struct{}{}
-				}
-				files, _, err := gc.chunkManager.ListWithPrefix(gc.ctx, key, true)
-				if err != nil {
-					log.Ctx(gc.ctx).Warn("IndexCoord garbageCollector recycleUnusedIndexFiles list files failed",
-						zap.Int64("buildID", buildID), zap.String("prefix", key), zap.Error(err))
-					continue
-				}
-				log.Ctx(gc.ctx).Info("recycle index files", zap.Int64("buildID", buildID), zap.Int("meta files num", len(filesMap)),
-					zap.Int("chunkManager files num", len(files)))
-				deletedFilesNum := 0
-				for _, file := range files {
-					if _, ok := filesMap[file]; !ok {
-						if err = gc.chunkManager.Remove(gc.ctx, file); err != nil {
-							log.Ctx(gc.ctx).Warn("IndexCoord garbageCollector recycleUnusedIndexFiles remove file failed",
-								zap.Int64("buildID", buildID), zap.String("file", file), zap.Error(err))
-							continue
-						}
-						deletedFilesNum++
-					}
-				}
-				log.Ctx(gc.ctx).Info("index files recycle success", zap.Int64("buildID", buildID),
-					zap.Int("delete index files num", deletedFilesNum))
+		}
+	}
+}
+
+// recycleIndexFilesOfKey recycles the index files under the dir @key of a buildID which are not recorded in meta
+func (gc *garbageCollector) recycleIndexFilesOfKey(key string) {
+	log.Ctx(gc.ctx).Debug("indexFiles keys", zap.String("key", key))
+	buildID, err := parseBuildIDFromFilePath(key)
+	if err != nil {
+		log.Ctx(gc.ctx).Error("IndexCoord garbageCollector recycleUnusedIndexFiles parseIndexFileKey", zap.String("key", key), zap.Error(err))
+		return
+	}
+	log.Ctx(gc.ctx).Info("IndexCoord garbageCollector will recycle index files", zap.Int64("buildID", buildID))
+	if !gc.metaTable.HasBuildID(buildID) {
+		// buildID no longer exists in meta, remove all index files
+		log.Ctx(gc.ctx).Info("IndexCoord garbageCollector recycleUnusedIndexFiles find meta has not exist, remove index files",
+			zap.Int64("buildID", buildID))
+		err = gc.chunkManager.RemoveWithPrefix(gc.ctx, key)
+		if err != nil {
+			log.Ctx(gc.ctx).Warn("IndexCoord garbageCollector recycleUnusedIndexFiles remove index files failed",
+				zap.Int64("buildID", buildID), zap.String("prefix", key), zap.Error(err))
+		}
+		return
+	}
+	log.Ctx(gc.ctx).Info("index meta can be recycled, recycle index files", zap.Int64("buildID", buildID))
+	canRecycle, segIdx := gc.metaTable.GetSegmentIndexByBuildID(buildID)
+	if !canRecycle {
+		// Even if the index is marked as deleted, the index file will not be recycled, wait for the next gc,
+		// and delete all index files about the buildID at one time.
+		log.Ctx(gc.ctx).Warn("IndexCoord garbageCollector can not recycle index files", zap.Int64("buildID", buildID))
+		return
+	}
+	filesMap := make(map[string]struct{})
+	for _, filepath := range storage.BuildIndexFilePaths(gc.chunkManager.RootPath(), segIdx.BuildID, segIdx.IndexVersion,
+		segIdx.PartitionID, segIdx.SegmentID, segIdx.IndexFileKeys) {
+		filesMap[filepath] = struct{}{}
+	}
+	filesNum := 0
+	deletedFilesNum := 0
+	err = gc.chunkManager.WalkWithPrefix(gc.ctx, key, true, func(chunkInfo storage.ChunkObjectInfo) bool {
+		filesNum++
+		file := chunkInfo.FilePath
+		if _, ok := filesMap[file]; !ok {
+			if err := gc.chunkManager.Remove(gc.ctx, file); err != nil {
+				log.Ctx(gc.ctx).Warn("IndexCoord garbageCollector recycleUnusedIndexFiles remove file failed",
+					zap.Int64("buildID", buildID), zap.String("file", file), zap.Error(err))
+				return true
 			}
+			deletedFilesNum++
 		}
+		return true
+	})
+	if err != nil {
+		log.Ctx(gc.ctx).Warn("IndexCoord garbageCollector recycleUnusedIndexFiles walk files failed",
+			zap.Int64("buildID", buildID), zap.String("prefix", key), zap.Error(err))
+		return
 	}
+	log.Ctx(gc.ctx).Info("recycle index files", zap.Int64("buildID", buildID), zap.Int("meta files num", len(filesMap)),
+		zap.Int("chunkManager files num", filesNum))
+	log.Ctx(gc.ctx).Info("index files recycle success", zap.Int64("buildID", buildID),
+		zap.Int("delete index files num", deletedFilesNum))
 }
@@ -30,8 +30,6 @@ import (
 
 	"github.com/golang/protobuf/proto"
 
-	"github.com/milvus-io/milvus/internal/util/metautil"
-
 	"github.com/milvus-io/milvus/internal/util/errorutil"
 
 	"golang.org/x/sync/errgroup"
@@ -802,7 +800,7 @@ func (i *IndexCoord) GetIndexInfos(ctx context.Context, req *indexpb.GetIndexInf
 		if len(segIdxes) != 0 {
 			ret.SegmentInfo[segID].EnableIndex = true
 			for _, segIdx := range segIdxes {
-				indexFilePaths := metautil.BuildSegmentIndexFilePaths(i.chunkManager.RootPath(), segIdx.BuildID, segIdx.IndexVersion,
+				indexFilePaths := storage.BuildIndexFilePaths(i.chunkManager.RootPath(), segIdx.BuildID, segIdx.IndexVersion,
 					segIdx.PartitionID, segIdx.SegmentID, segIdx.IndexFileKeys)
 
 				if segIdx.IndexState == commonpb.IndexState_Finished {
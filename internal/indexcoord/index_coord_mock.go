@@ -653,6 +653,23 @@ func (cmm *chunkManagerMock) ListWithPrefix(ctx context.Context, prefix string,
 	return cmm.listWithPrefix(prefix, recursive)
 }
 
+func (cmm *chunkManagerMock) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) error {
+	keys, modTimes, err := cmm.listWithPrefix(prefix, recursive)
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		chunkInfo := storage.ChunkObjectInfo{FilePath: key}
+		if i < len(modTimes) {
+			chunkInfo.ModifyTime = modTimes[i]
+		}
+		if !walkFunc(chunkInfo) {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (cmm *chunkManagerMock) Remove(ctx context.Context, key string) error {
 	return cmm.remove(key)
 }
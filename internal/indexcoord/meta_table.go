@@ -62,7 +62,8 @@ type metaTable struct {
 func NewMetaTable(kv kv.MetaKv) (*metaTable, error) {
 	mt := &metaTable{
 		catalog: &indexcoord.Catalog{
-			Txn: kv,
+			Txn:          kv,
+			CompactPaths: Params.CommonCfg.MetaCompactPaths,
 		},
 		indexLock:        sync.RWMutex{},
 		segmentIndexLock: sync.RWMutex{},
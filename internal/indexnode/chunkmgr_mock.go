@@ -105,11 +105,52 @@ func (c *mockChunkmgr) Size(ctx context.Context, filePath string) (int64, error)
 	return 0, errNotImplErr
 }
 
+func (c *mockChunkmgr) Stat(ctx context.Context, filePath string) (storage.ObjectInfo, error) {
+	// TODO
+	return storage.ObjectInfo{}, errNotImplErr
+}
+
+func (c *mockChunkmgr) MultiStat(ctx context.Context, filePaths []string) ([]*storage.ObjectInfo, error) {
+	// TODO
+	return nil, errNotImplErr
+}
+
 func (c *mockChunkmgr) Write(ctx context.Context, filePath string, content []byte) error {
 	c.indexedData.Store(filePath, content)
 	return nil
 }
 
+func (c *mockChunkmgr) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...storage.WriteOption) error {
+	return c.Write(ctx, filePath, content)
+}
+
+func (c *mockChunkmgr) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	if _, loaded := c.indexedData.LoadOrStore(filePath, content); loaded {
+		return storage.WrapErrObjectExists(filePath)
+	}
+	return nil
+}
+
+func (c *mockChunkmgr) Append(ctx context.Context, filePath string, content []byte) error {
+	// TODO
+	return errNotImplErr
+}
+
+func (c *mockChunkmgr) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	// TODO
+	return errNotImplErr
+}
+
+func (c *mockChunkmgr) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	// TODO
+	return errNotImplErr
+}
+
+func (c *mockChunkmgr) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	// TODO
+	return "", errNotImplErr
+}
+
 func (c *mockChunkmgr) MultiWrite(ctx context.Context, contents map[string][]byte) error {
 	// TODO
 	return errNotImplErr
@@ -148,6 +189,16 @@ func (c *mockChunkmgr) ListWithPrefix(ctx context.Context, prefix string, recurs
 	return nil, nil, errNotImplErr
 }
 
+func (c *mockChunkmgr) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) error {
+	// TODO
+	return errNotImplErr
+}
+
+func (c *mockChunkmgr) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	// TODO
+	return 0, 0, errNotImplErr
+}
+
 func (c *mockChunkmgr) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
 	// TODO
 	return nil, errNotImplErr
@@ -158,6 +209,11 @@ func (c *mockChunkmgr) ReadAt(ctx context.Context, filePath string, off int64, l
 	return nil, errNotImplErr
 }
 
+func (c *mockChunkmgr) MultiReadAt(ctx context.Context, filePath string, ranges []storage.Range) ([][]byte, error) {
+	// TODO
+	return nil, errNotImplErr
+}
+
 func (c *mockChunkmgr) Remove(ctx context.Context, filePath string) error {
 	// TODO
 	return errNotImplErr
@@ -0,0 +1,192 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexnode
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/metautil"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/retry"
+)
+
+// streamUploadRounds is the max number of rounds to upload the index files, every round resumes the last one
+// with the index files not uploaded yet.
+const streamUploadRounds = 3
+
+// indexFileStreamer encodes the index files one by one and uploads each of them once encoded, so that the encoded
+// index files in memory are bounded by the buffer size instead of being as large as the index. The raw index files
+// are released once uploaded, and the uploaded ones are skipped if the upload is resumed after failures.
+type indexFileStreamer struct {
+	cm         storage.ChunkManager
+	encode     func(blob *Blob) (*Blob, error)
+	pathOf     func(key string) string
+	buffer     *semaphore.Weighted
+	bufferSize int64
+	parallel   int
+
+	mu       sync.Mutex
+	uploaded map[string]string // key -> path of the uploaded index files
+}
+
+func newIndexFileStreamer(cm storage.ChunkManager, encode func(blob *Blob) (*Blob, error), pathOf func(key string) string,
+	bufferSize int64, parallel int) *indexFileStreamer {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	return &indexFileStreamer{
+		cm:         cm,
+		encode:     encode,
+		pathOf:     pathOf,
+		buffer:     semaphore.NewWeighted(bufferSize),
+		bufferSize: bufferSize,
+		parallel:   parallel,
+		uploaded:   make(map[string]string),
+	}
+}
+
+// upload encodes and uploads @blobs, blobs[i] is set nil once uploaded to release it.
+// A file larger than the buffer takes the whole buffer, so that it's uploaded alone.
+func (s *indexFileStreamer) upload(ctx context.Context, blobs []*Blob) error {
+	uploadFn := func(idx int) error {
+		blob := blobs[idx]
+		if blob == nil {
+			return nil
+		}
+		if _, ok := s.path(blob.Key); ok {
+			blobs[idx] = nil
+			return nil
+		}
+		weight := int64(len(blob.Value))
+		if weight > s.bufferSize {
+			weight = s.bufferSize
+		}
+		if err := s.buffer.Acquire(ctx, weight); err != nil {
+			return err
+		}
+		defer s.buffer.Release(weight)
+
+		encoded, err := s.encode(blob)
+		if err != nil {
+			return err
+		}
+		if err := s.write(ctx, blob.Key, encoded.Value); err != nil {
+			return err
+		}
+		blobs[idx] = nil
+		return nil
+	}
+	return funcutil.ProcessFuncParallel(len(blobs), s.parallel, uploadFn, "streamIndexFile")
+}
+
+// write uploads the encoded index file @key, which is skipped if it's uploaded already.
+func (s *indexFileStreamer) write(ctx context.Context, key string, value []byte) error {
+	if _, ok := s.path(key); ok {
+		return nil
+	}
+	savePath := s.pathOf(key)
+	saveFn := func() error {
+		return s.cm.Write(ctx, savePath, value)
+	}
+	if err := retry.Do(ctx, saveFn, retry.Attempts(5)); err != nil {
+		log.Ctx(ctx).Warn("index node save index file failed", zap.Error(err), zap.String("savePath", savePath))
+		return err
+	}
+	s.mu.Lock()
+	s.uploaded[key] = savePath
+	s.mu.Unlock()
+	return nil
+}
+
+// path returns the path of the index file @key if it's uploaded.
+func (s *indexFileStreamer) path(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	savePath, ok := s.uploaded[key]
+	return savePath, ok
+}
+
+// streamSaveIndexFiles encodes and uploads the index files serialized by BuildIndex one by one.
+func (it *indexBuildTask) streamSaveIndexFiles(ctx context.Context) error {
+	codec := storage.NewIndexFileBinlogCodec()
+	indexParamBlob, err := codec.SerializeIndexParams(it.req.BuildID, it.req.IndexVersion, it.collectionID, it.partitionID,
+		it.segmentID, it.fieldID, it.newIndexParams, it.req.IndexName, it.req.IndexID)
+	if err != nil {
+		return err
+	}
+	// querycoord parses the index params from the first index file
+	fileKeys := make([]string, 0, len(it.rawIndexBlobs)+1)
+	fileKeys = append(fileKeys, indexParamBlob.Key)
+	for _, blob := range it.rawIndexBlobs {
+		fileKeys = append(fileKeys, blob.Key)
+	}
+
+	ts := storage.Timestamp(time.Now().UnixNano())
+	encode := func(blob *Blob) (*Blob, error) {
+		return codec.SerializeIndexFile(it.req.BuildID, it.req.IndexVersion, it.collectionID, it.partitionID,
+			it.segmentID, it.fieldID, it.req.IndexName, it.req.IndexID, blob, ts)
+	}
+	pathOf := func(key string) string {
+		return metautil.BuildSegmentIndexFilePath(it.cm.RootPath(), it.req.BuildID,
+			it.req.IndexVersion, it.partitionID, it.segmentID, key)
+	}
+	streamer := newIndexFileStreamer(it.cm, encode, pathOf, Params.IndexNodeCfg.StreamUploadMaxBufferSize, runtime.NumCPU())
+
+	for round := 1; ; round++ {
+		err = streamer.write(ctx, indexParamBlob.Key, indexParamBlob.Value)
+		if err == nil {
+			err = streamer.upload(ctx, it.rawIndexBlobs)
+		}
+		if err == nil {
+			break
+		}
+		// If an error occurs, return the error that the task state will be set to retry.
+		if round >= streamUploadRounds || ctx.Err() != nil {
+			log.Ctx(ctx).Error("stream index files fail", zap.Int("round", round), zap.Error(err))
+			return err
+		}
+		log.Ctx(ctx).Warn("stream index files fail, resume the upload", zap.Int("round", round), zap.Error(err))
+	}
+	it.rawIndexBlobs = nil
+
+	savePaths := make([]string, 0, len(fileKeys))
+	for _, key := range fileKeys {
+		savePath, _ := streamer.path(key)
+		savePaths = append(savePaths, savePath)
+	}
+	it.savePaths = savePaths
+	it.statistic.EndTime = time.Now().UnixMicro()
+	it.node.storeIndexFilesAndStatistic(it.ClusterID, it.BuildID, fileKeys, it.serializedSize, &it.statistic)
+	log.Ctx(ctx).Info("save index files done", zap.Strings("IndexFiles", savePaths))
+	saveIndexFileDur := it.tr.Record("index file save done")
+	metrics.IndexNodeSaveIndexFileLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10)).Observe(float64(saveIndexFileDur.Milliseconds()))
+	it.tr.Elapse("index building all done")
+	log.Ctx(ctx).Info("Successfully stream index files", zap.Int64("buildID", it.BuildID), zap.Int64("Collection", it.collectionID),
+		zap.Int64("partition", it.partitionID), zap.Int64("SegmentId", it.segmentID))
+	return nil
+}
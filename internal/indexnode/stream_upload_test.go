@@ -0,0 +1,101 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexnode
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// failingChunkManager fails the writes of failKey until failures runs out
+type failingChunkManager struct {
+	storage.ChunkManager
+	failKey  string
+	failures int32
+	writes   int32
+}
+
+func (cm *failingChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	atomic.AddInt32(&cm.writes, 1)
+	if path.Base(filePath) == cm.failKey && atomic.AddInt32(&cm.failures, -1) >= 0 {
+		return errors.New("mock write failure")
+	}
+	return cm.ChunkManager.Write(ctx, filePath, content)
+}
+
+func TestIndexFileStreamer(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir() + "/"
+	cm := &failingChunkManager{ChunkManager: storage.NewLocalChunkManager(storage.RootPath(root)), failKey: "b", failures: 5}
+
+	encoded := int32(0)
+	encode := func(blob *Blob) (*Blob, error) {
+		atomic.AddInt32(&encoded, 1)
+		return &Blob{Key: blob.Key, Value: append([]byte("encoded-"), blob.Value...)}, nil
+	}
+	pathOf := func(key string) string {
+		return path.Join(root, "index", key)
+	}
+	streamer := newIndexFileStreamer(cm, encode, pathOf, 4, 2)
+	blobs := []*Blob{
+		{Key: "a", Value: []byte("aa")},
+		{Key: "b", Value: []byte("bbbbbbbb")},
+		{Key: "c", Value: []byte("c")},
+	}
+
+	// the write of b fails in all the retries
+	err := streamer.upload(ctx, blobs)
+	assert.Error(t, err)
+	assert.Nil(t, blobs[0])
+	assert.NotNil(t, blobs[1])
+	assert.Nil(t, blobs[2])
+	_, ok := streamer.path("b")
+	assert.False(t, ok)
+
+	// the uploaded ones are skipped by the resumed upload
+	encoded = 0
+	cm.writes = 0
+	cm.failures = 0
+	err = streamer.upload(ctx, blobs)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), encoded)
+	assert.Equal(t, int32(1), cm.writes)
+	assert.Nil(t, blobs[1])
+
+	for key, value := range map[string]string{"a": "encoded-aa", "b": "encoded-bbbbbbbb", "c": "encoded-c"} {
+		savePath, ok := streamer.path(key)
+		require.True(t, ok)
+		assert.Equal(t, pathOf(key), savePath)
+		content, err := cm.Read(ctx, savePath)
+		require.NoError(t, err)
+		assert.Equal(t, value, string(content))
+	}
+
+	// uploaded already
+	require.NoError(t, streamer.write(ctx, "a", []byte("other")))
+	content, err := cm.Read(ctx, pathOf("a"))
+	require.NoError(t, err)
+	assert.Equal(t, "encoded-aa", string(content))
+}
@@ -95,6 +95,7 @@ type indexBuildTask struct {
 	fieldID        UniqueID
 	fieldData      storage.FieldData
 	indexBlobs     []*storage.Blob
+	rawIndexBlobs  []*storage.Blob
 	newTypeParams  map[string]string
 	newIndexParams map[string]string
 	serializedSize uint64
@@ -114,6 +115,7 @@ func (it *indexBuildTask) Reset() {
 	it.req = nil
 	it.fieldData = nil
 	it.indexBlobs = nil
+	it.rawIndexBlobs = nil
 	it.newTypeParams = nil
 	it.newIndexParams = nil
 	it.tr = nil
@@ -288,6 +290,14 @@ func (it *indexBuildTask) BuildIndex(ctx context.Context) error {
 		log.Ctx(ctx).Error("IndexNode indexBuildTask Execute CIndexDelete failed", zap.Error(err))
 	}
 
+	// the index files are encoded along with uploading by SaveIndexFiles
+	if Params.IndexNodeCfg.StreamUploadEnabled {
+		it.rawIndexBlobs = indexBlobs
+		log.Ctx(ctx).Info("Successfully build index", zap.Int64("buildID", it.BuildID),
+			zap.Int64("Collection", it.collectionID), zap.Int64("SegmentID", it.segmentID))
+		return nil
+	}
+
 	var serializedIndexBlobs []*storage.Blob
 	codec := storage.NewIndexFileBinlogCodec()
 	serializedIndexBlobs, err = codec.Serialize(
@@ -425,6 +435,9 @@ func (it *indexBuildTask) SaveIndexFiles(ctx context.Context) error {
 	if indexType == indexparamcheck.IndexDISKANN {
 		return it.SaveDiskAnnIndexFiles(ctx)
 	}
+	if it.rawIndexBlobs != nil {
+		return it.streamSaveIndexFiles(ctx)
+	}
 
 	blobCnt := len(it.indexBlobs)
 	savePaths := make([]string, blobCnt)
@@ -24,6 +24,7 @@ const (
 	SegmentStatslogPathPrefix = MetaPrefix + "/statslog"
 	ChannelRemovePrefix       = MetaPrefix + "/channel-removal"
 	ChannelCheckpointPrefix   = MetaPrefix + "/channel-cp"
+	LogPathPrefixDictPrefix   = MetaPrefix + "/log-path-prefix"
 
 	RemoveFlagTomestone = "removed"
 )
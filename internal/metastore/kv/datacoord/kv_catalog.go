@@ -18,11 +18,14 @@ package datacoord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
 
@@ -43,6 +46,19 @@ const maxEtcdTxnNum = 64
 type Catalog struct {
 	Txn                  kv.TxnKV
 	ChunkManagerRootPath string
+	// CompactPaths keeps the log paths under the root paths other than ChunkManagerRootPath as references to
+	// the log path prefixes, which the versions before can't read. The references are always read.
+	CompactPaths bool
+
+	prefixesMu sync.Mutex
+	// logPathPrefixes is loaded from meta on the first use, only the catalogs meeting binlogs
+	// under a root path other than ChunkManagerRootPath use it.
+	logPathPrefixes *storage.LogPathPrefixes
+}
+
+// versionSwapper is implemented by the meta kvs creating keys atomically, e.g. etcd.
+type versionSwapper interface {
+	CompareVersionAndSwap(key string, version int64, target string, opts ...clientv3.OpOption) (bool, error)
 }
 
 func (kc *Catalog) ListSegments(ctx context.Context) ([]*datapb.SegmentInfo, error) {
@@ -93,7 +109,7 @@ func (kc *Catalog) ListSegments(ctx context.Context) ([]*datapb.SegmentInfo, err
 }
 
 func (kc *Catalog) AddSegment(ctx context.Context, segment *datapb.SegmentInfo) error {
-	kvs, err := buildSegmentAndBinlogsKvs(segment)
+	kvs, err := kc.buildSegmentAndBinlogsKvs(segment)
 	if err != nil {
 		return err
 	}
@@ -106,7 +122,7 @@ func (kc *Catalog) AlterSegments(ctx context.Context, newSegments []*datapb.Segm
 	}
 	kvsBySeg := make(map[int64]map[string]string)
 	for _, segment := range newSegments {
-		segmentKvs, err := buildSegmentAndBinlogsKvs(segment)
+		segmentKvs, err := kc.buildSegmentAndBinlogsKvs(segment)
 		if err != nil {
 			return err
 		}
@@ -143,7 +159,7 @@ func (kc *Catalog) AlterSegments(ctx context.Context, newSegments []*datapb.Segm
 
 func (kc *Catalog) AlterSegment(ctx context.Context, newSegment *datapb.SegmentInfo, oldSegment *datapb.SegmentInfo) error {
 	kvs := make(map[string]string)
-	segmentKvs, err := buildSegmentAndBinlogsKvs(newSegment)
+	segmentKvs, err := kc.buildSegmentAndBinlogsKvs(newSegment)
 	if err != nil {
 		return err
 	}
@@ -201,7 +217,7 @@ func (kc *Catalog) AlterSegmentsAndAddNewSegment(ctx context.Context, segments [
 			// convert to new format that include segment key and three binlog keys,
 			// or GC can not find data path on the storage.
 			if !hasBinlogkeys {
-				binlogsKvs, err := buildBinlogKvsWithLogID(noBinlogsSegment.CollectionID, noBinlogsSegment.PartitionID, noBinlogsSegment.ID, binlogs, deltalogs, statslogs)
+				binlogsKvs, err := kc.buildBinlogKvsWithLogID(noBinlogsSegment.CollectionID, noBinlogsSegment.PartitionID, noBinlogsSegment.ID, binlogs, deltalogs, statslogs)
 				if err != nil {
 					return err
 				}
@@ -218,7 +234,7 @@ func (kc *Catalog) AlterSegmentsAndAddNewSegment(ctx context.Context, segments [
 
 	if newSegment != nil {
 		if newSegment.GetNumOfRows() > 0 {
-			segmentKvs, err := buildSegmentAndBinlogsKvs(newSegment)
+			segmentKvs, err := kc.buildSegmentAndBinlogsKvs(newSegment)
 			if err != nil {
 				return err
 			}
@@ -246,7 +262,7 @@ func (kc *Catalog) RevertAlterSegmentsAndAddNewSegment(ctx context.Context, oldS
 	)
 
 	for _, s := range oldSegments {
-		segmentKvs, err := buildSegmentAndBinlogsKvs(s)
+		segmentKvs, err := kc.buildSegmentAndBinlogsKvs(s)
 		if err != nil {
 			return err
 		}
@@ -403,68 +419,14 @@ func (kc *Catalog) unmarshalBinlog(binlogType storage.BinlogType, collectionID,
 			return nil, fmt.Errorf("failed to unmarshal datapb.FieldBinlog: %d, err:%w", fieldBinlog.FieldID, err)
 		}
 
-		fillLogPathByLogID(kc.ChunkManagerRootPath, binlogType, collectionID, partitionID, segmentID, fieldBinlog)
+		if err := kc.decompressLogPaths(binlogType, collectionID, partitionID, segmentID, fieldBinlog); err != nil {
+			return nil, err
+		}
 		result[i] = fieldBinlog
 	}
 	return result, nil
 }
 
-func fillLogPathByLogID(chunkManagerRootPath string, binlogType storage.BinlogType, collectionID, partitionID,
-	segmentID typeutil.UniqueID, fieldBinlog *datapb.FieldBinlog) error {
-	for _, binlog := range fieldBinlog.Binlogs {
-		path, err := buildLogPath(chunkManagerRootPath, binlogType, collectionID, partitionID,
-			segmentID, fieldBinlog.GetFieldID(), binlog.GetLogID())
-		if err != nil {
-			return err
-		}
-
-		binlog.LogPath = path
-	}
-
-	return nil
-}
-
-func fillLogIDByLogPath(multiFieldBinlogs ...[]*datapb.FieldBinlog) error {
-	for _, fieldBinlogs := range multiFieldBinlogs {
-		for _, fieldBinlog := range fieldBinlogs {
-			for _, binlog := range fieldBinlog.Binlogs {
-				logPath := binlog.LogPath
-				idx := strings.LastIndex(logPath, "/")
-				if idx == -1 {
-					return fmt.Errorf("invailed binlog path: %s", logPath)
-				}
-				logPathStr := logPath[(idx + 1):]
-				logID, err := strconv.ParseInt(logPathStr, 10, 64)
-				if err != nil {
-					return err
-				}
-
-				// set log path to empty and only store log id
-				binlog.LogPath = ""
-				binlog.LogID = logID
-			}
-		}
-	}
-	return nil
-}
-
-// build a binlog path on the storage by metadata
-func buildLogPath(chunkManagerRootPath string, binlogType storage.BinlogType, collectionID, partitionID, segmentID, filedID, logID typeutil.UniqueID) (string, error) {
-	switch binlogType {
-	case storage.InsertBinlog:
-		path := metautil.BuildInsertLogPath(chunkManagerRootPath, collectionID, partitionID, segmentID, filedID, logID)
-		return path, nil
-	case storage.DeleteBinlog:
-		path := metautil.BuildDeltaLogPath(chunkManagerRootPath, collectionID, partitionID, segmentID, logID)
-		return path, nil
-	case storage.StatsBinlog:
-		path := metautil.BuildStatsLogPath(chunkManagerRootPath, collectionID, partitionID, segmentID, filedID, logID)
-		return path, nil
-	default:
-		return "", fmt.Errorf("invalid binlog type: %d", binlogType)
-	}
-}
-
 func checkBinlogs(binlogType storage.BinlogType, segmentID typeutil.UniqueID, logs []*datapb.FieldBinlog) {
 	check := func(getSegmentID func(logPath string) typeutil.UniqueID) {
 		for _, fieldBinlog := range logs {
@@ -487,27 +449,168 @@ func checkBinlogs(binlogType storage.BinlogType, segmentID typeutil.UniqueID, lo
 	}
 }
 
-func buildBinlogKvsWithLogID(collectionID, partitionID, segmentID typeutil.UniqueID,
+// getLogPathPrefixes returns the log path prefixes, loading them from meta on the first call.
+// The new prefixes are only added if the meta kv creates keys atomically, see allocLogPathPrefixID.
+// The caller must hold prefixesMu.
+func (kc *Catalog) getLogPathPrefixes() (*storage.LogPathPrefixes, error) {
+	if kc.logPathPrefixes != nil {
+		return kc.logPathPrefixes, nil
+	}
+	ids, values, err := kc.loadLogPathPrefixes()
+	if err != nil {
+		return nil, err
+	}
+	var alloc storage.LogPathPrefixAllocator
+	if _, ok := kc.Txn.(versionSwapper); ok {
+		alloc = kc.allocLogPathPrefixID
+	}
+	prefixes := storage.NewLogPathPrefixes(alloc)
+	for i, id := range ids {
+		prefixes.Set(id, values[i])
+	}
+	kc.logPathPrefixes = prefixes
+	return prefixes, nil
+}
+
+func (kc *Catalog) loadLogPathPrefixes() ([]typeutil.UniqueID, []string, error) {
+	keys, values, err := kc.Txn.LoadWithPrefix(LogPathPrefixDictPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]typeutil.UniqueID, 0, len(keys))
+	for _, key := range keys {
+		id, err := strconv.ParseInt(key[strings.LastIndex(key, "/")+1:], 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid log path prefix key: %s", key)
+		}
+		ids = append(ids, id)
+	}
+	return ids, values, nil
+}
+
+// allocLogPathPrefixID persists @prefix with the next ID absent from meta. The key of the ID is created by
+// CompareVersionAndSwap, so the datacoords sharing the meta never allocate an ID to different prefixes,
+// and the ID of @prefix is reused if it's persisted by another one meanwhile.
+func (kc *Catalog) allocLogPathPrefixID(prefix string) (typeutil.UniqueID, error) {
+	ids, values, err := kc.loadLogPathPrefixes()
+	if err != nil {
+		return 0, err
+	}
+	var maxID typeutil.UniqueID
+	for i, id := range ids {
+		if values[i] == prefix {
+			return id, nil
+		}
+		if id > maxID {
+			maxID = id
+		}
+	}
+	swapper := kc.Txn.(versionSwapper)
+	for id := maxID + 1; ; id++ {
+		key := buildLogPathPrefixKey(id)
+		ok, err := swapper.CompareVersionAndSwap(key, 0, prefix)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			log.Info("log path prefix allocated", zap.Int64("id", id), zap.String("prefix", prefix))
+			return id, nil
+		}
+		// created by another datacoord meanwhile
+		value, err := kc.Txn.Load(key)
+		if err != nil {
+			return 0, err
+		}
+		if value == prefix {
+			return id, nil
+		}
+	}
+}
+
+// compressLogPaths compresses the log paths of @logs, see storage.CompressLogPaths, and returns the kvs of the
+// log path prefixes referred by @logs, which must be saved along with them.
+func (kc *Catalog) compressLogPaths(binlogType storage.BinlogType, collectionID, partitionID, segmentID typeutil.UniqueID,
+	logs []*datapb.FieldBinlog) (map[string]string, error) {
+	kc.prefixesMu.Lock()
+	defer kc.prefixesMu.Unlock()
+	var getPrefixes storage.LogPathPrefixesGetter
+	if kc.CompactPaths {
+		getPrefixes = kc.getLogPathPrefixes
+	}
+	if err := storage.CompressLogPaths(kc.ChunkManagerRootPath, getPrefixes, binlogType, collectionID, partitionID, segmentID, logs...); err != nil {
+		return nil, err
+	}
+
+	kvs := make(map[string]string)
+	for _, fieldBinlog := range logs {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			id, ok := storage.LogPathPrefixRef(binlog.GetLogPath())
+			if !ok {
+				continue
+			}
+			prefixes, err := kc.getLogPathPrefixes()
+			if err != nil {
+				return nil, err
+			}
+			prefix, ok := prefixes.Prefix(id)
+			if !ok {
+				return nil, fmt.Errorf("unknown log path prefix %d of segment %d", id, segmentID)
+			}
+			kvs[buildLogPathPrefixKey(id)] = prefix
+		}
+	}
+	return kvs, nil
+}
+
+// decompressLogPaths rebuilds the log paths compressed by compressLogPaths. The log path prefixes are loaded
+// from meta again if a reference to an unknown one is met, which may be allocated by another datacoord.
+func (kc *Catalog) decompressLogPaths(binlogType storage.BinlogType, collectionID, partitionID, segmentID typeutil.UniqueID,
+	fieldBinlog *datapb.FieldBinlog) error {
+	kc.prefixesMu.Lock()
+	defer kc.prefixesMu.Unlock()
+	err := storage.DecompressLogPaths(kc.ChunkManagerRootPath, kc.getLogPathPrefixes, binlogType, collectionID, partitionID, segmentID, fieldBinlog)
+	if !errors.Is(err, storage.ErrUnknownLogPathPrefix) || kc.logPathPrefixes == nil {
+		return err
+	}
+	kc.logPathPrefixes = nil
+	return storage.DecompressLogPaths(kc.ChunkManagerRootPath, kc.getLogPathPrefixes, binlogType, collectionID, partitionID, segmentID, fieldBinlog)
+}
+
+// buildBinlogKvsWithLogID builds the binlog kvs with the log paths dictionary-encoded,
+// only the log IDs and the prefix references of them are persisted.
+func (kc *Catalog) buildBinlogKvsWithLogID(collectionID, partitionID, segmentID typeutil.UniqueID,
 	binlogs, deltalogs, statslogs []*datapb.FieldBinlog) (map[string]string, error) {
 
 	checkBinlogs(storage.InsertBinlog, segmentID, binlogs)
 	checkBinlogs(storage.DeleteBinlog, segmentID, deltalogs)
 	checkBinlogs(storage.StatsBinlog, segmentID, statslogs)
 
-	fillLogIDByLogPath(binlogs, deltalogs, statslogs)
+	prefixKvs := make(map[string]string)
+	for binlogType, logs := range map[storage.BinlogType][]*datapb.FieldBinlog{
+		storage.InsertBinlog: binlogs,
+		storage.DeleteBinlog: deltalogs,
+		storage.StatsBinlog:  statslogs,
+	} {
+		kvs, err := kc.compressLogPaths(binlogType, collectionID, partitionID, segmentID, logs)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(prefixKvs, kvs)
+	}
 	kvs, err := buildBinlogKvs(collectionID, partitionID, segmentID, binlogs, deltalogs, statslogs)
 	if err != nil {
 		return nil, err
 	}
+	maps.Copy(kvs, prefixKvs)
 
 	return kvs, nil
 }
 
-func buildSegmentAndBinlogsKvs(segment *datapb.SegmentInfo) (map[string]string, error) {
+func (kc *Catalog) buildSegmentAndBinlogsKvs(segment *datapb.SegmentInfo) (map[string]string, error) {
 	noBinlogsSegment, binlogs, deltalogs, statslogs := CloneSegmentWithExcludeBinlogs(segment)
 
 	// save binlogs separately
-	kvs, err := buildBinlogKvsWithLogID(noBinlogsSegment.CollectionID, noBinlogsSegment.PartitionID, noBinlogsSegment.ID, binlogs, deltalogs, statslogs)
+	kvs, err := kc.buildBinlogKvsWithLogID(noBinlogsSegment.CollectionID, noBinlogsSegment.PartitionID, noBinlogsSegment.ID, binlogs, deltalogs, statslogs)
 	if err != nil {
 		return nil, err
 	}
@@ -644,6 +747,11 @@ func buildFieldStatslogPathPrefix(collectionID typeutil.UniqueID, partitionID ty
 	return fmt.Sprintf("%s/%d/%d/%d", SegmentStatslogPathPrefix, collectionID, partitionID, segmentID)
 }
 
+// buildLogPathPrefixKey builds the key of the log path prefix of @id in the dictionary
+func buildLogPathPrefixKey(id typeutil.UniqueID) string {
+	return fmt.Sprintf("%s/%d", LogPathPrefixDictPrefix, id)
+}
+
 // buildChannelRemovePath builds vchannel remove flag path
 func buildChannelRemovePath(channel string) string {
 	return fmt.Sprintf("%s/%s", ChannelRemovePrefix, channel)
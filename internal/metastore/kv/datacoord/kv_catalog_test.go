@@ -9,10 +9,12 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"golang.org/x/exp/maps"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus/internal/kv"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
 	"github.com/milvus-io/milvus/internal/kv/mocks"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
@@ -178,7 +180,7 @@ func Test_ListSegments(t *testing.T) {
 			return nil, nil, errors.New("error")
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		ret, err := catalog.ListSegments(context.TODO())
 		assert.Nil(t, ret)
 		assert.Error(t, err)
@@ -219,7 +221,7 @@ func Test_ListSegments(t *testing.T) {
 			return []string{k5}, []string{string(segBytes)}, nil
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		ret, err := catalog.ListSegments(context.TODO())
 		assert.NotNil(t, ret)
 		assert.NoError(t, err)
@@ -235,7 +237,7 @@ func Test_ListSegments(t *testing.T) {
 			return nil
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AddSegment(context.TODO(), segment1)
 		assert.Nil(t, err)
 
@@ -274,7 +276,7 @@ func Test_AddSegments(t *testing.T) {
 			return errors.New("error")
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		assert.Panics(t, func() {
 			catalog.AddSegment(context.TODO(), invalidSegment)
 		})
@@ -286,7 +288,7 @@ func Test_AddSegments(t *testing.T) {
 			return errors.New("error")
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AddSegment(context.TODO(), segment1)
 		assert.Error(t, err)
 	})
@@ -299,7 +301,7 @@ func Test_AddSegments(t *testing.T) {
 			return nil
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AddSegment(context.TODO(), segment1)
 		assert.Nil(t, err)
 
@@ -317,7 +319,7 @@ func Test_AlterSegments(t *testing.T) {
 			return errors.New("error")
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		assert.Panics(t, func() {
 			catalog.AlterSegments(context.TODO(), []*datapb.SegmentInfo{invalidSegment})
 		})
@@ -329,7 +331,7 @@ func Test_AlterSegments(t *testing.T) {
 			return errors.New("error")
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AlterSegments(context.TODO(), []*datapb.SegmentInfo{segment1})
 
 		assert.Error(t, err)
@@ -343,7 +345,7 @@ func Test_AlterSegments(t *testing.T) {
 			return nil
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AlterSegments(context.TODO(), []*datapb.SegmentInfo{})
 		assert.Nil(t, err)
 
@@ -370,7 +372,7 @@ func Test_AlterSegments(t *testing.T) {
 			return nil
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AlterSegments(context.TODO(), []*datapb.SegmentInfo{})
 		assert.Nil(t, err)
 
@@ -412,7 +414,7 @@ func Test_AlterSegmentsAndAddNewSegment(t *testing.T) {
 			return errors.New("error")
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AlterSegmentsAndAddNewSegment(context.TODO(), []*datapb.SegmentInfo{}, segment1)
 		assert.Error(t, err)
 	})
@@ -423,7 +425,7 @@ func Test_AlterSegmentsAndAddNewSegment(t *testing.T) {
 			return nil, nil, errors.New("error")
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AlterSegmentsAndAddNewSegment(context.TODO(), []*datapb.SegmentInfo{droppedSegment}, nil)
 		assert.Error(t, err)
 	})
@@ -441,7 +443,7 @@ func Test_AlterSegmentsAndAddNewSegment(t *testing.T) {
 		}
 
 		// TODO fubang
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.AlterSegmentsAndAddNewSegment(context.TODO(), []*datapb.SegmentInfo{droppedSegment}, segment1)
 		assert.NoError(t, err)
 
@@ -458,7 +460,7 @@ func Test_DropSegment(t *testing.T) {
 			return errors.New("error")
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.DropSegment(context.TODO(), segment1)
 		assert.Error(t, err)
 	})
@@ -473,7 +475,7 @@ func Test_DropSegment(t *testing.T) {
 			return nil
 		}
 
-		catalog := &Catalog{txn, "a"}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.DropSegment(context.TODO(), segment1)
 		assert.NoError(t, err)
 
@@ -485,11 +487,201 @@ func Test_DropSegment(t *testing.T) {
 	})
 }
 
+// casMemoryKV creates the keys by CompareVersionAndSwap like etcd, the log path prefixes listed are hidden
+// if hidePrefixes is set, as if they're created by another datacoord after listed.
+type casMemoryKV struct {
+	*memkv.MemoryKV
+	hidePrefixes bool
+}
+
+func newCasMemoryKV() *casMemoryKV {
+	return &casMemoryKV{MemoryKV: memkv.NewMemoryKV()}
+}
+
+func (kv *casMemoryKV) LoadWithPrefix(key string) ([]string, []string, error) {
+	if kv.hidePrefixes && strings.HasPrefix(key, LogPathPrefixDictPrefix) {
+		return nil, nil, nil
+	}
+	return kv.MemoryKV.LoadWithPrefix(key)
+}
+
+func (kv *casMemoryKV) CompareVersionAndSwap(key string, version int64, target string, opts ...clientv3.OpOption) (bool, error) {
+	if version != 0 {
+		return false, errors.New("only the creation is supported")
+	}
+	if _, err := kv.Load(key); err == nil {
+		return false, nil
+	}
+	return true, kv.Save(key, target)
+}
+
+func Test_LogPathPrefixes(t *testing.T) {
+	txn := newCasMemoryKV()
+	catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "files", CompactPaths: true}
+	binlogPaths := []string{
+		metautil.BuildInsertLogPath("files", 1, 10, 100, 101, 1000),
+		metautil.BuildInsertLogPath("/mnt/migrated", 1, 10, 100, 101, 1001),
+		metautil.BuildInsertLogPath("/mnt/migrated", 1, 10, 100, 101, 1002),
+	}
+	segment := &datapb.SegmentInfo{
+		ID:           100,
+		CollectionID: 1,
+		PartitionID:  10,
+		NumOfRows:    100,
+		State:        commonpb.SegmentState_Flushed,
+		Binlogs: []*datapb.FieldBinlog{{
+			FieldID: 101,
+			Binlogs: []*datapb.Binlog{{LogPath: binlogPaths[0]}, {LogPath: binlogPaths[1]}, {LogPath: binlogPaths[2]}},
+		}},
+	}
+	err := catalog.AddSegment(context.TODO(), segment)
+	assert.NoError(t, err)
+
+	// only the IDs and the prefix references are kept in meta
+	value, err := txn.Load(buildFieldBinlogPath(1, 10, 100, 101))
+	assert.NoError(t, err)
+	fieldBinlog := &datapb.FieldBinlog{}
+	assert.NoError(t, proto.Unmarshal([]byte(value), fieldBinlog))
+	assert.Equal(t, "", fieldBinlog.Binlogs[0].GetLogPath())
+	assert.Equal(t, "$1", fieldBinlog.Binlogs[1].GetLogPath())
+	assert.Equal(t, "$1", fieldBinlog.Binlogs[2].GetLogPath())
+	prefix, err := txn.Load(buildLogPathPrefixKey(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "/mnt/migrated", prefix)
+	// the segment in memory is not touched
+	assert.Equal(t, binlogPaths[1], segment.Binlogs[0].Binlogs[1].GetLogPath())
+
+	// the deltalogs persisted with full paths by older versions
+	legacyDeltalog := &datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogID: 2000, LogPath: metautil.BuildDeltaLogPath("legacy", 1, 10, 100, 2000)}}}
+	legacyValue, err := proto.Marshal(legacyDeltalog)
+	assert.NoError(t, err)
+	assert.NoError(t, txn.Save(buildFieldDeltalogPath(1, 10, 100, 0), string(legacyValue)))
+
+	// the dictionary is loaded from meta by a new catalog, whether it compacts the paths or not
+	catalog = &Catalog{Txn: txn, ChunkManagerRootPath: "files"}
+	segments, err := catalog.ListSegments(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(segments))
+	var paths []string
+	for _, binlog := range segments[0].GetBinlogs()[0].GetBinlogs() {
+		paths = append(paths, binlog.GetLogPath())
+	}
+	assert.Equal(t, binlogPaths, paths)
+	assert.Equal(t, legacyDeltalog.Binlogs[0].GetLogPath(), segments[0].GetDeltalogs()[0].GetBinlogs()[0].GetLogPath())
+
+	// a reference to an unknown prefix
+	assert.NoError(t, txn.Remove(buildLogPathPrefixKey(1)))
+	catalog = &Catalog{Txn: txn, ChunkManagerRootPath: "files"}
+	_, err = catalog.ListSegments(context.TODO())
+	assert.Error(t, err)
+}
+
+func Test_LogPathPrefixesDisabled(t *testing.T) {
+	binlogPaths := []string{
+		metautil.BuildInsertLogPath("files", 1, 10, 100, 101, 1000),
+		metautil.BuildInsertLogPath("/mnt/migrated", 1, 10, 100, 101, 1001),
+	}
+	newSegment := func() *datapb.SegmentInfo {
+		return &datapb.SegmentInfo{
+			ID:           100,
+			CollectionID: 1,
+			PartitionID:  10,
+			NumOfRows:    100,
+			State:        commonpb.SegmentState_Flushed,
+			Binlogs: []*datapb.FieldBinlog{{
+				FieldID: 101,
+				Binlogs: []*datapb.Binlog{{LogPath: binlogPaths[0]}, {LogPath: binlogPaths[1]}},
+			}},
+		}
+	}
+	loadLogPaths := func(txn kv.TxnKV) []string {
+		value, err := txn.Load(buildFieldBinlogPath(1, 10, 100, 101))
+		assert.NoError(t, err)
+		fieldBinlog := &datapb.FieldBinlog{}
+		assert.NoError(t, proto.Unmarshal([]byte(value), fieldBinlog))
+		return []string{fieldBinlog.Binlogs[0].GetLogPath(), fieldBinlog.Binlogs[1].GetLogPath()}
+	}
+
+	// the paths under other root paths are kept unless compacting paths is enabled
+	txn := newCasMemoryKV()
+	catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "files"}
+	assert.NoError(t, catalog.AddSegment(context.TODO(), newSegment()))
+	assert.Equal(t, []string{"", binlogPaths[1]}, loadLogPaths(txn))
+	keys, _, err := txn.LoadWithPrefix(LogPathPrefixDictPrefix)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// and if the meta kv can't create the keys atomically
+	memTxn := memkv.NewMemoryKV()
+	catalog = &Catalog{Txn: memTxn, ChunkManagerRootPath: "files", CompactPaths: true}
+	assert.NoError(t, catalog.AddSegment(context.TODO(), newSegment()))
+	assert.Equal(t, []string{"", binlogPaths[1]}, loadLogPaths(memTxn))
+
+	segments, err := catalog.ListSegments(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(segments))
+	assert.Equal(t, binlogPaths[1], segments[0].GetBinlogs()[0].GetBinlogs()[1].GetLogPath())
+}
+
+func Test_LogPathPrefixesSharedMeta(t *testing.T) {
+	txn := newCasMemoryKV()
+	newSegment := func(segmentID int64, prefix string) *datapb.SegmentInfo {
+		return &datapb.SegmentInfo{
+			ID:           segmentID,
+			CollectionID: 1,
+			PartitionID:  10,
+			NumOfRows:    100,
+			State:        commonpb.SegmentState_Flushed,
+			Binlogs: []*datapb.FieldBinlog{{
+				FieldID: 101,
+				Binlogs: []*datapb.Binlog{{LogPath: metautil.BuildInsertLogPath(prefix, 1, 10, segmentID, 101, 1000)}},
+			}},
+		}
+	}
+	loadLogPath := func(segmentID int64) string {
+		value, err := txn.Load(buildFieldBinlogPath(1, 10, segmentID, 101))
+		assert.NoError(t, err)
+		fieldBinlog := &datapb.FieldBinlog{}
+		assert.NoError(t, proto.Unmarshal([]byte(value), fieldBinlog))
+		return fieldBinlog.Binlogs[0].GetLogPath()
+	}
+
+	// both catalogs load the dictionary before the other allocates
+	catalog1 := &Catalog{Txn: txn, ChunkManagerRootPath: "files", CompactPaths: true}
+	catalog2 := &Catalog{Txn: txn, ChunkManagerRootPath: "files", CompactPaths: true}
+	assert.NoError(t, catalog1.AddSegment(context.TODO(), newSegment(100, "/mnt/a")))
+	assert.Equal(t, "$1", loadLogPath(100))
+	assert.NoError(t, catalog2.AddSegment(context.TODO(), newSegment(101, "/mnt/b")))
+	assert.Equal(t, "$2", loadLogPath(101))
+	// the ID allocated by the other is reused
+	assert.NoError(t, catalog2.AddSegment(context.TODO(), newSegment(102, "/mnt/a")))
+	assert.Equal(t, "$1", loadLogPath(102))
+
+	// the key of the next ID is created by the other after listed
+	txn.hidePrefixes = true
+	catalog3 := &Catalog{Txn: txn, ChunkManagerRootPath: "files", CompactPaths: true}
+	assert.NoError(t, catalog3.AddSegment(context.TODO(), newSegment(103, "/mnt/b")))
+	assert.Equal(t, "$2", loadLogPath(103))
+	assert.NoError(t, catalog3.AddSegment(context.TODO(), newSegment(104, "/mnt/c")))
+	assert.Equal(t, "$3", loadLogPath(104))
+	txn.hidePrefixes = false
+
+	// the dictionary loaded before is reloaded for the prefixes allocated by the other
+	segments, err := catalog1.ListSegments(context.TODO())
+	assert.NoError(t, err)
+	paths := make(map[int64]string)
+	for _, segment := range segments {
+		paths[segment.GetID()] = segment.GetBinlogs()[0].GetBinlogs()[0].GetLogPath()
+	}
+	assert.Equal(t, metautil.BuildInsertLogPath("/mnt/b", 1, 10, 101, 101, 1000), paths[101])
+	assert.Equal(t, metautil.BuildInsertLogPath("/mnt/c", 1, 10, 104, 101, 1000), paths[104])
+}
+
 func Test_SaveDroppedSegmentsInBatch_SaveError(t *testing.T) {
 	txn := &mocks.TxnKV{}
 	txn.EXPECT().MultiSave(mock.Anything).Return(errors.New("mock error"))
 
-	catalog := &Catalog{txn, ""}
+	catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 	segments := []*datapb.SegmentInfo{
 		{
 			ID:           1,
@@ -514,7 +706,7 @@ func Test_SaveDroppedSegmentsInBatch_MultiSave(t *testing.T) {
 		}).
 		Return(nil)
 
-	catalog := &Catalog{txn, ""}
+	catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 
 	// no segments
 	{
@@ -566,7 +758,7 @@ func TestCatalog_RevertAlterSegmentsAndAddNewSegment(t *testing.T) {
 		txn := &mocks.TxnKV{}
 		txn.EXPECT().MultiSaveAndRemove(mock.Anything, mock.Anything).Return(errors.New("mock error"))
 
-		catalog := &Catalog{txn, ""}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.RevertAlterSegmentsAndAddNewSegment(context.TODO(), []*datapb.SegmentInfo{segment1}, droppedSegment)
 		assert.Error(t, err)
 	})
@@ -574,7 +766,7 @@ func TestCatalog_RevertAlterSegmentsAndAddNewSegment(t *testing.T) {
 	t.Run("revert successfully", func(t *testing.T) {
 		txn := &mocks.TxnKV{}
 		txn.EXPECT().MultiSaveAndRemove(mock.Anything, mock.Anything).Return(nil)
-		catalog := &Catalog{txn, ""}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: "a"}
 		err := catalog.RevertAlterSegmentsAndAddNewSegment(context.TODO(), []*datapb.SegmentInfo{segment1}, droppedSegment)
 		assert.NoError(t, err)
 	})
@@ -596,7 +788,7 @@ func TestChannelCP(t *testing.T) {
 	t.Run("ListChannelCheckpoint", func(t *testing.T) {
 		txn := &mocks.TxnKV{}
 		txn.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
-		catalog := &Catalog{txn, ""}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 		err := catalog.SaveChannelCheckpoint(context.TODO(), mockVChannel, pos)
 		assert.NoError(t, err)
 
@@ -608,7 +800,7 @@ func TestChannelCP(t *testing.T) {
 
 	t.Run("ListChannelCheckpoint failed", func(t *testing.T) {
 		txn := &mocks.TxnKV{}
-		catalog := &Catalog{txn, ""}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 		txn.EXPECT().LoadWithPrefix(mock.Anything).Return(nil, nil, errors.New("mock error"))
 		_, err = catalog.ListChannelCheckpoint(context.TODO())
 		assert.Error(t, err)
@@ -617,14 +809,14 @@ func TestChannelCP(t *testing.T) {
 	t.Run("SaveChannelCheckpoint", func(t *testing.T) {
 		txn := &mocks.TxnKV{}
 		txn.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
-		catalog := &Catalog{txn, ""}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 		err := catalog.SaveChannelCheckpoint(context.TODO(), mockVChannel, pos)
 		assert.NoError(t, err)
 	})
 
 	t.Run("SaveChannelCheckpoint failed", func(t *testing.T) {
 		txn := &mocks.TxnKV{}
-		catalog := &Catalog{txn, ""}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 		txn.EXPECT().Save(mock.Anything, mock.Anything).Return(errors.New("mock error"))
 		err = catalog.SaveChannelCheckpoint(context.TODO(), mockVChannel, &internalpb.MsgPosition{})
 		assert.Error(t, err)
@@ -633,7 +825,7 @@ func TestChannelCP(t *testing.T) {
 	t.Run("DropChannelCheckpoint", func(t *testing.T) {
 		txn := &mocks.TxnKV{}
 		txn.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
-		catalog := &Catalog{txn, ""}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 		err := catalog.SaveChannelCheckpoint(context.TODO(), mockVChannel, pos)
 		assert.NoError(t, err)
 
@@ -648,7 +840,7 @@ func TestChannelCP(t *testing.T) {
 
 	t.Run("DropChannelCheckpoint failed", func(t *testing.T) {
 		txn := &mocks.TxnKV{}
-		catalog := &Catalog{txn, ""}
+		catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 		txn.EXPECT().Remove(mock.Anything).Return(errors.New("mock error"))
 		err = catalog.DropChannelCheckpoint(context.TODO(), mockVChannel)
 		assert.Error(t, err)
@@ -661,7 +853,7 @@ func Test_MarkChannelDeleted_SaveError(t *testing.T) {
 		Save(mock.Anything, mock.Anything).
 		Return(errors.New("mock error"))
 
-	catalog := &Catalog{txn, ""}
+	catalog := &Catalog{Txn: txn, ChunkManagerRootPath: ""}
 	err := catalog.MarkChannelDeleted(context.TODO(), "test_channel_1")
 	assert.Error(t, err)
 }
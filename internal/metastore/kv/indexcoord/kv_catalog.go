@@ -14,11 +14,15 @@ import (
 	"github.com/milvus-io/milvus/internal/kv"
 	"github.com/milvus-io/milvus/internal/metastore/model"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 type Catalog struct {
 	Txn kv.TxnKV
+	// CompactPaths keeps the numbered index file keys as ID ranges, which the versions before can't read.
+	// The ranges are always read.
+	CompactPaths bool
 }
 
 func BuildIndexKey(collectionID, indexID int64) string {
@@ -101,11 +105,11 @@ func (kc *Catalog) DropIndex(ctx context.Context, collID typeutil.UniqueID, drop
 func (kc *Catalog) CreateSegmentIndex(ctx context.Context, segIdx *model.SegmentIndex) error {
 	key := BuildSegmentIndexKey(segIdx.CollectionID, segIdx.PartitionID, segIdx.SegmentID, segIdx.BuildID)
 
-	value, err := proto.Marshal(model.MarshalSegmentIndexModel(segIdx))
+	value, err := kc.marshalSegmentIndex(segIdx)
 	if err != nil {
 		return err
 	}
-	err = kc.Txn.Save(key, string(value))
+	err = kc.Txn.Save(key, value)
 	if err != nil {
 		log.Error("failed to save segment index meta in etcd", zap.Int64("buildID", segIdx.BuildID),
 			zap.Int64("segmentID", segIdx.SegmentID), zap.Error(err))
@@ -130,6 +134,7 @@ func (kc *Catalog) ListSegmentIndexes(ctx context.Context) ([]*model.SegmentInde
 			continue
 		}
 
+		segmentIndexInfo.IndexFileKeys = storage.DecompressIndexFileKeys(segmentIndexInfo.IndexFileKeys)
 		segIndexes = append(segIndexes, model.UnmarshalSegmentIndexModel(segmentIndexInfo))
 	}
 
@@ -144,15 +149,29 @@ func (kc *Catalog) AlterSegmentIndexes(ctx context.Context, segIdxes []*model.Se
 	kvs := make(map[string]string)
 	for _, segIdx := range segIdxes {
 		key := BuildSegmentIndexKey(segIdx.CollectionID, segIdx.PartitionID, segIdx.SegmentID, segIdx.BuildID)
-		value, err := proto.Marshal(model.MarshalSegmentIndexModel(segIdx))
+		value, err := kc.marshalSegmentIndex(segIdx)
 		if err != nil {
 			return err
 		}
-		kvs[key] = string(value)
+		kvs[key] = value
 	}
 	return kc.Txn.MultiSave(kvs)
 }
 
+// marshalSegmentIndex marshals @segIdx, with its index file keys compressed by storage.CompressIndexFileKeys
+// if CompactPaths is set.
+func (kc *Catalog) marshalSegmentIndex(segIdx *model.SegmentIndex) (string, error) {
+	segmentIndexInfo := model.MarshalSegmentIndexModel(segIdx)
+	if kc.CompactPaths {
+		segmentIndexInfo.IndexFileKeys = storage.CompressIndexFileKeys(segmentIndexInfo.IndexFileKeys)
+	}
+	value, err := proto.Marshal(segmentIndexInfo)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
 func (kc *Catalog) DropSegmentIndex(ctx context.Context, collID, partID, segID, buildID typeutil.UniqueID) error {
 	key := BuildSegmentIndexKey(collID, partID, segID, buildID)
 
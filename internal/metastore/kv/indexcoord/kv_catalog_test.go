@@ -318,6 +318,63 @@ func TestCatalog_ListSegmentIndexes(t *testing.T) {
 	})
 }
 
+func TestCatalog_SegmentIndexFileKeys(t *testing.T) {
+	saved := make(map[string]string)
+	txn := &MockedTxnKV{
+		save: func(key, value string) error {
+			saved[key] = value
+			return nil
+		},
+		loadWithPrefix: func(key string) ([]string, []string, error) {
+			keys := make([]string, 0, len(saved))
+			values := make([]string, 0, len(saved))
+			for k, v := range saved {
+				keys = append(keys, k)
+				values = append(values, v)
+			}
+			return keys, values, nil
+		},
+	}
+	catalog := &Catalog{
+		Txn: txn,
+	}
+
+	// the keys are kept as they are unless compacting paths is enabled
+	fileKeys := []string{"index_params", "SLICE_META", "IVF_0", "IVF_1", "IVF_2"}
+	err := catalog.CreateSegmentIndex(context.Background(), &model.SegmentIndex{SegmentID: 1, BuildID: 1, IndexFileKeys: fileKeys})
+	assert.NoError(t, err)
+	segIdx := &indexpb.SegmentIndex{}
+	assert.NoError(t, proto.Unmarshal([]byte(saved[BuildSegmentIndexKey(0, 0, 1, 1)]), segIdx))
+	assert.Equal(t, fileKeys, segIdx.GetIndexFileKeys())
+
+	catalog.CompactPaths = true
+	err = catalog.CreateSegmentIndex(context.Background(), &model.SegmentIndex{SegmentID: 1, BuildID: 1, IndexFileKeys: fileKeys})
+	assert.NoError(t, err)
+	segIdx = &indexpb.SegmentIndex{}
+	assert.NoError(t, proto.Unmarshal([]byte(saved[BuildSegmentIndexKey(0, 0, 1, 1)]), segIdx))
+	assert.Equal(t, []string{"index_params", "SLICE_META", "IVF_{0..2}"}, segIdx.GetIndexFileKeys())
+
+	// the ranges written before are still read after disabling
+	catalog.CompactPaths = false
+
+	// the full paths persisted by older versions
+	legacyPaths := []string{"files/index_files/2/1/0/2/IVF_0", "files/index_files/2/1/0/2/IVF_1"}
+	v, err := proto.Marshal(&indexpb.SegmentIndex{SegmentID: 2, BuildID: 2, IndexFileKeys: legacyPaths})
+	assert.NoError(t, err)
+	saved[BuildSegmentIndexKey(0, 0, 2, 2)] = string(v)
+
+	segIdxes, err := catalog.ListSegmentIndexes(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(segIdxes))
+	for _, segIdx := range segIdxes {
+		if segIdx.SegmentID == 1 {
+			assert.Equal(t, fileKeys, segIdx.IndexFileKeys)
+		} else {
+			assert.Equal(t, legacyPaths, segIdx.IndexFileKeys)
+		}
+	}
+}
+
 func TestCatalog_AlterSegmentIndex(t *testing.T) {
 	segIdx := &model.SegmentIndex{
 		SegmentID:     0,
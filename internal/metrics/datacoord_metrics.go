@@ -95,6 +95,30 @@ var (
 			Help:      "binlog size of segments",
 		}, []string{segmentStateLabelName})
 
+	DataCoordScrubIssues = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "scrub_issues_count",
+			Help:      "count of corrupted or missing binlogs found by the storage scrubber",
+		}, []string{issueTypeLabelName})
+
+	DataCoordCollectionStorageUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "collection_storage_usage",
+			Help:      "bytes of the binlogs of the collection on the storage",
+		}, []string{collectionIDLabelName})
+
+	DataCoordCollectionStorageObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataCoordRole,
+			Name:      "collection_storage_objects",
+			Help:      "number of the binlogs of the collection on the storage",
+		}, []string{collectionIDLabelName})
+
 	/* hard to implement, commented now
 	DataCoordSegmentSizeRatio = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -151,4 +175,7 @@ func RegisterDataCoord(registry *prometheus.Registry) {
 	registry.MustRegister(DataCoordNumStoredRowsCounter)
 	registry.MustRegister(DataCoordConsumeDataNodeTimeTickLag)
 	registry.MustRegister(DataCoordStoredBinlogSize)
+	registry.MustRegister(DataCoordScrubIssues)
+	registry.MustRegister(DataCoordCollectionStorageUsage)
+	registry.MustRegister(DataCoordCollectionStorageObjects)
 }
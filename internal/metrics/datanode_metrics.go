@@ -100,6 +100,18 @@ var (
 			channelNameLabelName,
 		})
 
+	DataNodeTimeTickStallCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.DataNodeRole,
+			Name:      "tt_stall_count",
+			Help:      "count of time tick stalls detected per virtual channel, and how they were handled",
+		}, []string{
+			nodeIDLabelName,
+			channelNameLabelName,
+			statusLabelName,
+		})
+
 	DataNodeConsumeMsgCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: milvusNamespace,
@@ -228,6 +240,7 @@ func RegisterDataNode(registry *prometheus.Registry) {
 	registry.MustRegister(DataNodeProduceTimeTickLag)
 	registry.MustRegister(DataNodeConsumeBytesCount)
 	registry.MustRegister(DataNodeForwardDeleteMsgTimeTaken)
+	registry.MustRegister(DataNodeTimeTickStallCount)
 }
 
 func CleanupDataNodeCollectionMetrics(nodeID int64, collectionID int64, channel string) {
@@ -0,0 +1,36 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	GrpcCompressionBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "grpc",
+			Name:      "compression_bytes",
+			Help:      "raw and compressed bytes of the grpc messages passing the compressors",
+		}, []string{compressorLabelName, directionLabelName, bytesTypeLabelName})
+)
+
+// RegisterGrpcMetrics registers grpc metrics
+func RegisterGrpcMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(GrpcCompressionBytes)
+}
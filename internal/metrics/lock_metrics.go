@@ -0,0 +1,58 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	lockNameLabelName = "lock_name"
+	lockModeLabelName = "lock_mode"
+)
+
+var (
+	// LockWaitLatency records the time waited to acquire the keys of the lock managers.
+	LockWaitLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "lock",
+			Name:      "wait_latency",
+			Help:      "latency of waiting to acquire a key of the lock manager in milliseconds",
+			Buckets:   buckets,
+		}, []string{
+			lockNameLabelName,
+			lockModeLabelName,
+		})
+
+	// LockDeadlockCount counts the acquisitions of the lock managers aborted for deadlocks.
+	LockDeadlockCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "lock",
+			Name:      "deadlock_count",
+			Help:      "count of the acquisitions aborted for deadlocks",
+		}, []string{
+			lockNameLabelName,
+		})
+)
+
+// RegisterLockMetrics registers lock manager metrics
+func RegisterLockMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(LockWaitLatency)
+	registry.MustRegister(LockDeadlockCount)
+}
@@ -43,6 +43,11 @@ const (
 	TimetickLabel  = "timetick"
 	AllLabel       = "all"
 
+	CompressLabel   = "compress"
+	DecompressLabel = "decompress"
+	RawLabel        = "raw"
+	CompressedLabel = "compressed"
+
 	UnissuedIndexTaskLabel   = "unissued"
 	InProgressIndexTaskLabel = "in-progress"
 	FinishedIndexTaskLabel   = "finished"
@@ -73,6 +78,12 @@ const (
 	cacheNameLabelName       = "cache_name"
 	cacheStateLabelName      = "cache_state"
 	requestScope             = "scope"
+	invalidReasonLabelName   = "invalid_reason"
+	rootPathLabelName        = "root_path"
+	compressorLabelName      = "compressor"
+	directionLabelName       = "direction"
+	bytesTypeLabelName       = "bytes_type"
+	issueTypeLabelName       = "issue_type"
 )
 
 var (
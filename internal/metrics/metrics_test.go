@@ -34,5 +34,6 @@ func TestRegisterMetrics(t *testing.T) {
 	RegisterQueryNode(r)
 	RegisterQueryCoord(r)
 	RegisterEtcdMetrics(r)
+	RegisterLockMetrics(r)
 	Register(r)
 }
@@ -44,6 +44,15 @@ var (
 			Help:      "counter of vectors successfully inserted",
 		}, []string{nodeIDLabelName})
 
+	// ProxyInvalidVectors record the number of invalid vectors (NaN/Inf/zero) found on insert.
+	ProxyInvalidVectors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "invalid_vectors_count",
+			Help:      "counter of invalid vectors found on insert",
+		}, []string{nodeIDLabelName, invalidReasonLabelName})
+
 	// ProxySQLatency record the latency of search successfully.
 	ProxySQLatency = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -230,6 +239,7 @@ var (
 func RegisterProxy(registry *prometheus.Registry) {
 	registry.MustRegister(ProxySearchVectors)
 	registry.MustRegister(ProxyInsertVectors)
+	registry.MustRegister(ProxyInvalidVectors)
 
 	registry.MustRegister(ProxySQLatency)
 	registry.MustRegister(ProxyCollectionSQLatency)
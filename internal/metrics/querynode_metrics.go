@@ -46,6 +46,29 @@ var (
 			collectionIDLabelName,
 		})
 
+	QueryNodeServiceableLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "serviceable_lag_ms",
+			Help:      "now time minus serviceable time per virtual channel, the latency before inserted data becomes queryable",
+		}, []string{
+			nodeIDLabelName,
+			collectionIDLabelName,
+			channelNameLabelName,
+		})
+
+	QueryNodeServiceableLagSLOViolated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "serviceable_lag_slo_violated",
+			Help:      "1 if the serviceable lag of any channel of the collection exceeds the SLO threshold, otherwise 0",
+		}, []string{
+			nodeIDLabelName,
+			collectionIDLabelName,
+		})
+
 	QueryNodeConsumerMsgCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: milvusNamespace,
@@ -288,6 +311,17 @@ var (
 			nodeIDLabelName,
 		})
 
+	QueryNodeSearchResultCacheCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "search_result_cache_count",
+			Help:      "count of search result cache lookups of sealed segments",
+		}, []string{
+			nodeIDLabelName,
+			cacheStateLabelName,
+		})
+
 	QueryNodeEvictedReadReqCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: milvusNamespace,
@@ -335,6 +369,18 @@ var (
 			Name:      "execute_bytes_counter",
 			Help:      "",
 		}, []string{nodeIDLabelName, msgTypeLabelName})
+
+	// QueryNodePrunedPartitionCount counts the partitions skipped by search / query with partition statistics.
+	QueryNodePrunedPartitionCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "pruned_partition_count",
+			Help:      "count of partitions skipped by search / query with partition statistics",
+		}, []string{
+			nodeIDLabelName,
+			queryTypeLabelName,
+		})
 )
 
 //RegisterQueryNode registers QueryNode metrics
@@ -359,6 +405,7 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeSearchGroupNQ)
 	registry.MustRegister(QueryNodeSearchNQ)
 	registry.MustRegister(QueryNodeSearchGroupSize)
+	registry.MustRegister(QueryNodeSearchResultCacheCount)
 	registry.MustRegister(QueryNodeEvictedReadReqCount)
 	registry.MustRegister(QueryNodeSearchGroupTopK)
 	registry.MustRegister(QueryNodeSearchTopK)
@@ -368,6 +415,9 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeExecuteCounter)
 	registry.MustRegister(QueryNodeConsumerMsgCount)
 	registry.MustRegister(QueryNodeConsumeTimeTickLag)
+	registry.MustRegister(QueryNodeServiceableLag)
+	registry.MustRegister(QueryNodeServiceableLagSLOViolated)
+	registry.MustRegister(QueryNodePrunedPartitionCount)
 }
 
 func CleanupQueryNodeCollectionMetrics(nodeID int64, collectionID int64) {
@@ -0,0 +1,81 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	LocalStorageDiskUsageRatio = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "local_storage",
+			Name:      "disk_usage_ratio",
+			Help:      "disk usage ratio of the file system where the local storage root path is",
+		}, []string{rootPathLabelName})
+
+	LocalStorageDiskFreeBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "local_storage",
+			Name:      "disk_free_bytes",
+			Help:      "free bytes of the file system where the local storage root path is",
+		}, []string{rootPathLabelName})
+
+	StorageReplicationPendingCount = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "storage",
+			Name:      "replication_pending_count",
+			Help:      "number of the object changes waiting to be replicated to the secondary storage",
+		})
+
+	StorageReplicationFailCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "storage",
+			Name:      "replication_fail_count",
+			Help:      "number of the failed attempts to replicate an object change to the secondary storage",
+		})
+
+	StorageThrottleLimit = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "storage",
+			Name:      "throttle_limit",
+			Help:      "max in-flight object storage requests allowed by the adaptive throttling",
+		})
+
+	StorageThrottledCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: "storage",
+			Name:      "throttled_count",
+			Help:      "number of the object storage requests throttled by the storage, i.e. 429 or 503 SlowDown",
+		})
+)
+
+// RegisterStorageMetrics registers storage metrics
+func RegisterStorageMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(LocalStorageDiskUsageRatio)
+	registry.MustRegister(LocalStorageDiskFreeBytes)
+	registry.MustRegister(StorageReplicationPendingCount)
+	registry.MustRegister(StorageReplicationFailCount)
+	registry.MustRegister(StorageThrottleLimit)
+	registry.MustRegister(StorageThrottledCount)
+}
@@ -27,6 +27,84 @@ func (_m *ChunkManager) EXPECT() *ChunkManager_Expecter {
 	return &ChunkManager_Expecter{mock: &_m.Mock}
 }
 
+// Append provides a mock function with given fields: ctx, filePath, content
+func (_m *ChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	ret := _m.Called(ctx, filePath, content)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, filePath, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChunkManager_Append_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Append'
+type ChunkManager_Append_Call struct {
+	*mock.Call
+}
+
+// Append is a helper method to define mock.On call
+//  - ctx context.Context
+//  - filePath string
+//  - content []byte
+func (_e *ChunkManager_Expecter) Append(ctx interface{}, filePath interface{}, content interface{}) *ChunkManager_Append_Call {
+	return &ChunkManager_Append_Call{Call: _e.mock.On("Append", ctx, filePath, content)}
+}
+
+func (_c *ChunkManager_Append_Call) Run(run func(ctx context.Context, filePath string, content []byte)) *ChunkManager_Append_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_Append_Call) Return(_a0 error) *ChunkManager_Append_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// Copy provides a mock function with given fields: ctx, srcFilePath, dstFilePath
+func (_m *ChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	ret := _m.Called(ctx, srcFilePath, dstFilePath)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, srcFilePath, dstFilePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChunkManager_Copy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Copy'
+type ChunkManager_Copy_Call struct {
+	*mock.Call
+}
+
+// Copy is a helper method to define mock.On call
+//  - ctx context.Context
+//  - srcFilePath string
+//  - dstFilePath string
+func (_e *ChunkManager_Expecter) Copy(ctx interface{}, srcFilePath interface{}, dstFilePath interface{}) *ChunkManager_Copy_Call {
+	return &ChunkManager_Copy_Call{Call: _e.mock.On("Copy", ctx, srcFilePath, dstFilePath)}
+}
+
+func (_c *ChunkManager_Copy_Call) Run(run func(ctx context.Context, srcFilePath string, dstFilePath string)) *ChunkManager_Copy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_Copy_Call) Return(_a0 error) *ChunkManager_Copy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 // Exist provides a mock function with given fields: ctx, filePath
 func (_m *ChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
 	ret := _m.Called(ctx, filePath)
@@ -176,6 +254,45 @@ func (_c *ChunkManager_Mmap_Call) Return(_a0 *mmap.ReaderAt, _a1 error) *ChunkMa
 	return _c
 }
 
+// Move provides a mock function with given fields: ctx, srcFilePath, dstFilePath
+func (_m *ChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	ret := _m.Called(ctx, srcFilePath, dstFilePath)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, srcFilePath, dstFilePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChunkManager_Move_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Move'
+type ChunkManager_Move_Call struct {
+	*mock.Call
+}
+
+// Move is a helper method to define mock.On call
+//  - ctx context.Context
+//  - srcFilePath string
+//  - dstFilePath string
+func (_e *ChunkManager_Expecter) Move(ctx interface{}, srcFilePath interface{}, dstFilePath interface{}) *ChunkManager_Move_Call {
+	return &ChunkManager_Move_Call{Call: _e.mock.On("Move", ctx, srcFilePath, dstFilePath)}
+}
+
+func (_c *ChunkManager_Move_Call) Run(run func(ctx context.Context, srcFilePath string, dstFilePath string)) *ChunkManager_Move_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_Move_Call) Return(_a0 error) *ChunkManager_Move_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 // MultiRead provides a mock function with given fields: ctx, filePaths
 func (_m *ChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
 	ret := _m.Called(ctx, filePaths)
@@ -223,6 +340,54 @@ func (_c *ChunkManager_MultiRead_Call) Return(_a0 [][]byte, _a1 error) *ChunkMan
 	return _c
 }
 
+// MultiReadAt provides a mock function with given fields: ctx, filePath, ranges
+func (_m *ChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []storage.Range) ([][]byte, error) {
+	ret := _m.Called(ctx, filePath, ranges)
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(context.Context, string, []storage.Range) [][]byte); ok {
+		r0 = rf(ctx, filePath, ranges)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []storage.Range) error); ok {
+		r1 = rf(ctx, filePath, ranges)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChunkManager_MultiReadAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MultiReadAt'
+type ChunkManager_MultiReadAt_Call struct {
+	*mock.Call
+}
+
+// MultiReadAt is a helper method to define mock.On call
+//  - ctx context.Context
+//  - filePath string
+//  - ranges []storage.Range
+func (_e *ChunkManager_Expecter) MultiReadAt(ctx interface{}, filePath interface{}, ranges interface{}) *ChunkManager_MultiReadAt_Call {
+	return &ChunkManager_MultiReadAt_Call{Call: _e.mock.On("MultiReadAt", ctx, filePath, ranges)}
+}
+
+func (_c *ChunkManager_MultiReadAt_Call) Run(run func(ctx context.Context, filePath string, ranges []storage.Range)) *ChunkManager_MultiReadAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]storage.Range))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_MultiReadAt_Call) Return(_a0 [][]byte, _a1 error) *ChunkManager_MultiReadAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // MultiRemove provides a mock function with given fields: ctx, filePaths
 func (_m *ChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
 	ret := _m.Called(ctx, filePaths)
@@ -261,6 +426,53 @@ func (_c *ChunkManager_MultiRemove_Call) Return(_a0 error) *ChunkManager_MultiRe
 	return _c
 }
 
+// MultiStat provides a mock function with given fields: ctx, filePaths
+func (_m *ChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*storage.ObjectInfo, error) {
+	ret := _m.Called(ctx, filePaths)
+
+	var r0 []*storage.ObjectInfo
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*storage.ObjectInfo); ok {
+		r0 = rf(ctx, filePaths)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.ObjectInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, filePaths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChunkManager_MultiStat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MultiStat'
+type ChunkManager_MultiStat_Call struct {
+	*mock.Call
+}
+
+// MultiStat is a helper method to define mock.On call
+//  - ctx context.Context
+//  - filePaths []string
+func (_e *ChunkManager_Expecter) MultiStat(ctx interface{}, filePaths interface{}) *ChunkManager_MultiStat_Call {
+	return &ChunkManager_MultiStat_Call{Call: _e.mock.On("MultiStat", ctx, filePaths)}
+}
+
+func (_c *ChunkManager_MultiStat_Call) Run(run func(ctx context.Context, filePaths []string)) *ChunkManager_MultiStat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_MultiStat_Call) Return(_a0 []*storage.ObjectInfo, _a1 error) *ChunkManager_MultiStat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // MultiWrite provides a mock function with given fields: ctx, contents
 func (_m *ChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
 	ret := _m.Called(ctx, contents)
@@ -344,6 +556,105 @@ func (_c *ChunkManager_Path_Call) Return(_a0 string, _a1 error) *ChunkManager_Pa
 	return _c
 }
 
+// PrefixSize provides a mock function with given fields: ctx, prefix
+func (_m *ChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	ret := _m.Called(ctx, prefix)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string) int64); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, prefix)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ChunkManager_PrefixSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrefixSize'
+type ChunkManager_PrefixSize_Call struct {
+	*mock.Call
+}
+
+// PrefixSize is a helper method to define mock.On call
+//  - ctx context.Context
+//  - prefix string
+func (_e *ChunkManager_Expecter) PrefixSize(ctx interface{}, prefix interface{}) *ChunkManager_PrefixSize_Call {
+	return &ChunkManager_PrefixSize_Call{Call: _e.mock.On("PrefixSize", ctx, prefix)}
+}
+
+func (_c *ChunkManager_PrefixSize_Call) Run(run func(ctx context.Context, prefix string)) *ChunkManager_PrefixSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_PrefixSize_Call) Return(bytes int64, objects int64, err error) *ChunkManager_PrefixSize_Call {
+	_c.Call.Return(bytes, objects, err)
+	return _c
+}
+
+// PresignURL provides a mock function with given fields: ctx, filePath, method, expiry
+func (_m *ChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	ret := _m.Called(ctx, filePath, method, expiry)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) string); ok {
+		r0 = rf(ctx, filePath, method, expiry)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, filePath, method, expiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChunkManager_PresignURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PresignURL'
+type ChunkManager_PresignURL_Call struct {
+	*mock.Call
+}
+
+// PresignURL is a helper method to define mock.On call
+//  - ctx context.Context
+//  - filePath string
+//  - method string
+//  - expiry time.Duration
+func (_e *ChunkManager_Expecter) PresignURL(ctx interface{}, filePath interface{}, method interface{}, expiry interface{}) *ChunkManager_PresignURL_Call {
+	return &ChunkManager_PresignURL_Call{Call: _e.mock.On("PresignURL", ctx, filePath, method, expiry)}
+}
+
+func (_c *ChunkManager_PresignURL_Call) Run(run func(ctx context.Context, filePath string, method string, expiry time.Duration)) *ChunkManager_PresignURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_PresignURL_Call) Return(_a0 string, _a1 error) *ChunkManager_PresignURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // Read provides a mock function with given fields: ctx, filePath
 func (_m *ChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
 	ret := _m.Called(ctx, filePath)
@@ -700,6 +1011,91 @@ func (_c *ChunkManager_Size_Call) Return(_a0 int64, _a1 error) *ChunkManager_Siz
 	return _c
 }
 
+// Stat provides a mock function with given fields: ctx, filePath
+func (_m *ChunkManager) Stat(ctx context.Context, filePath string) (storage.ObjectInfo, error) {
+	ret := _m.Called(ctx, filePath)
+
+	var r0 storage.ObjectInfo
+	if rf, ok := ret.Get(0).(func(context.Context, string) storage.ObjectInfo); ok {
+		r0 = rf(ctx, filePath)
+	} else {
+		r0 = ret.Get(0).(storage.ObjectInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChunkManager_Stat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stat'
+type ChunkManager_Stat_Call struct {
+	*mock.Call
+}
+
+// Stat is a helper method to define mock.On call
+//  - ctx context.Context
+//  - filePath string
+func (_e *ChunkManager_Expecter) Stat(ctx interface{}, filePath interface{}) *ChunkManager_Stat_Call {
+	return &ChunkManager_Stat_Call{Call: _e.mock.On("Stat", ctx, filePath)}
+}
+
+func (_c *ChunkManager_Stat_Call) Run(run func(ctx context.Context, filePath string)) *ChunkManager_Stat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_Stat_Call) Return(_a0 storage.ObjectInfo, _a1 error) *ChunkManager_Stat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// WalkWithPrefix provides a mock function with given fields: ctx, prefix, recursive, walkFunc
+func (_m *ChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) error {
+	ret := _m.Called(ctx, prefix, recursive, walkFunc)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, storage.ChunkObjectWalkFunc) error); ok {
+		r0 = rf(ctx, prefix, recursive, walkFunc)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChunkManager_WalkWithPrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WalkWithPrefix'
+type ChunkManager_WalkWithPrefix_Call struct {
+	*mock.Call
+}
+
+// WalkWithPrefix is a helper method to define mock.On call
+//  - ctx context.Context
+//  - prefix string
+//  - recursive bool
+//  - walkFunc storage.ChunkObjectWalkFunc
+func (_e *ChunkManager_Expecter) WalkWithPrefix(ctx interface{}, prefix interface{}, recursive interface{}, walkFunc interface{}) *ChunkManager_WalkWithPrefix_Call {
+	return &ChunkManager_WalkWithPrefix_Call{Call: _e.mock.On("WalkWithPrefix", ctx, prefix, recursive, walkFunc)}
+}
+
+func (_c *ChunkManager_WalkWithPrefix_Call) Run(run func(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc)) *ChunkManager_WalkWithPrefix_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(storage.ChunkObjectWalkFunc))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_WalkWithPrefix_Call) Return(_a0 error) *ChunkManager_WalkWithPrefix_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 // Write provides a mock function with given fields: ctx, filePath, content
 func (_m *ChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
 	ret := _m.Called(ctx, filePath, content)
@@ -739,6 +1135,99 @@ func (_c *ChunkManager_Write_Call) Return(_a0 error) *ChunkManager_Write_Call {
 	return _c
 }
 
+// WriteIfNotExist provides a mock function with given fields: ctx, filePath, content
+func (_m *ChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	ret := _m.Called(ctx, filePath, content)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, filePath, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChunkManager_WriteIfNotExist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteIfNotExist'
+type ChunkManager_WriteIfNotExist_Call struct {
+	*mock.Call
+}
+
+// WriteIfNotExist is a helper method to define mock.On call
+//  - ctx context.Context
+//  - filePath string
+//  - content []byte
+func (_e *ChunkManager_Expecter) WriteIfNotExist(ctx interface{}, filePath interface{}, content interface{}) *ChunkManager_WriteIfNotExist_Call {
+	return &ChunkManager_WriteIfNotExist_Call{Call: _e.mock.On("WriteIfNotExist", ctx, filePath, content)}
+}
+
+func (_c *ChunkManager_WriteIfNotExist_Call) Run(run func(ctx context.Context, filePath string, content []byte)) *ChunkManager_WriteIfNotExist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *ChunkManager_WriteIfNotExist_Call) Return(_a0 error) *ChunkManager_WriteIfNotExist_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// WriteWithOptions provides a mock function with given fields: ctx, filePath, content, opts
+func (_m *ChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...storage.WriteOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, filePath, content)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, ...storage.WriteOption) error); ok {
+		r0 = rf(ctx, filePath, content, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChunkManager_WriteWithOptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteWithOptions'
+type ChunkManager_WriteWithOptions_Call struct {
+	*mock.Call
+}
+
+// WriteWithOptions is a helper method to define mock.On call
+//  - ctx context.Context
+//  - filePath string
+//  - content []byte
+//  - opts ...storage.WriteOption
+func (_e *ChunkManager_Expecter) WriteWithOptions(ctx interface{}, filePath interface{}, content interface{}, opts ...interface{}) *ChunkManager_WriteWithOptions_Call {
+	return &ChunkManager_WriteWithOptions_Call{Call: _e.mock.On("WriteWithOptions",
+		append([]interface{}{ctx, filePath, content}, opts...)...)}
+}
+
+func (_c *ChunkManager_WriteWithOptions_Call) Run(run func(ctx context.Context, filePath string, content []byte, opts ...storage.WriteOption)) *ChunkManager_WriteWithOptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]storage.WriteOption, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(storage.WriteOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChunkManager_WriteWithOptions_Call) Return(_a0 error) *ChunkManager_WriteWithOptions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 type mockConstructorTestingTNewChunkManager interface {
 	mock.TestingT
 	Cleanup(func())
@@ -311,6 +311,53 @@ func (_c *DataCoord_Flush_Call) Return(_a0 *datapb.FlushResponse, _a1 error) *Da
 	return _c
 }
 
+// FreshSearch provides a mock function with given fields: ctx, req
+func (_m *DataCoord) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *datapb.FreshSearchResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.FreshSearchRequest) *datapb.FreshSearchResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*datapb.FreshSearchResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *datapb.FreshSearchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataCoord_FreshSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FreshSearch'
+type DataCoord_FreshSearch_Call struct {
+	*mock.Call
+}
+
+// FreshSearch is a helper method to define mock.On call
+//  - ctx context.Context
+//  - req *datapb.FreshSearchRequest
+func (_e *DataCoord_Expecter) FreshSearch(ctx interface{}, req interface{}) *DataCoord_FreshSearch_Call {
+	return &DataCoord_FreshSearch_Call{Call: _e.mock.On("FreshSearch", ctx, req)}
+}
+
+func (_c *DataCoord_FreshSearch_Call) Run(run func(ctx context.Context, req *datapb.FreshSearchRequest)) *DataCoord_FreshSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.FreshSearchRequest))
+	})
+	return _c
+}
+
+func (_c *DataCoord_FreshSearch_Call) Return(_a0 *datapb.FreshSearchResponse, _a1 error) *DataCoord_FreshSearch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetCollectionStatistics provides a mock function with given fields: ctx, req
 func (_m *DataCoord) GetCollectionStatistics(ctx context.Context, req *datapb.GetCollectionStatisticsRequest) (*datapb.GetCollectionStatisticsResponse, error) {
 	ret := _m.Called(ctx, req)
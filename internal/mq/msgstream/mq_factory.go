@@ -47,10 +47,13 @@ type PmsFactory struct {
 	PulsarAuthParams string
 	PulsarTenant     string
 	PulsarNameSpace  string
+	// PulsarChannelGroups puts the channels into the tenant/namespace of their groups
+	PulsarChannelGroups *pulsarmqwrapper.ChannelGroups
 }
 
-func NewPmsFactory(config *paramtable.PulsarConfig) *PmsFactory {
-	return &PmsFactory{
+// NewPmsFactory creates a PmsFactory, an error is returned if the channel groups are misconfigured.
+func NewPmsFactory(config *paramtable.PulsarConfig) (*PmsFactory, error) {
+	f := &PmsFactory{
 		PulsarBufSize:    1024,
 		ReceiveBufSize:   1024,
 		PulsarAddress:    config.Address.GetValue(),
@@ -60,6 +63,14 @@ func NewPmsFactory(config *paramtable.PulsarConfig) *PmsFactory {
 		PulsarTenant:     config.Tenant.GetValue(),
 		PulsarNameSpace:  config.Namespace.GetValue(),
 	}
+	groups, err := pulsarmqwrapper.ParseChannelGroups(config.ChannelGroups.GetValue(), f.PulsarTenant, f.PulsarNameSpace)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > 0 {
+		f.PulsarChannelGroups = pulsarmqwrapper.NewChannelGroups(groups, f.PulsarWebAddress, f.PulsarAuthPlugin, f.PulsarAuthParams)
+	}
+	return f, nil
 }
 
 // NewMsgStream is used to generate a new Msgstream object
@@ -73,7 +84,7 @@ func (f *PmsFactory) NewMsgStream(ctx context.Context) (MsgStream, error) {
 		Authentication: auth,
 	}
 
-	pulsarClient, err := pulsarmqwrapper.NewClient(f.PulsarTenant, f.PulsarNameSpace, clientOpts)
+	pulsarClient, err := pulsarmqwrapper.NewClientWithChannelGroups(f.PulsarTenant, f.PulsarNameSpace, clientOpts, f.PulsarChannelGroups)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +102,7 @@ func (f *PmsFactory) NewTtMsgStream(ctx context.Context) (MsgStream, error) {
 		Authentication: auth,
 	}
 
-	pulsarClient, err := pulsarmqwrapper.NewClient(f.PulsarTenant, f.PulsarNameSpace, clientOpts)
+	pulsarClient, err := pulsarmqwrapper.NewClientWithChannelGroups(f.PulsarTenant, f.PulsarNameSpace, clientOpts, f.PulsarChannelGroups)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +134,7 @@ func (f *PmsFactory) NewMsgStreamDisposer(ctx context.Context) func([]string, st
 			return err
 		}
 		for _, channel := range channels {
-			fullTopicName, err := pulsarmqwrapper.GetFullTopicName(f.PulsarTenant, f.PulsarNameSpace, channel)
+			fullTopicName, err := f.PulsarChannelGroups.FullTopicName(f.PulsarTenant, f.PulsarNameSpace, channel)
 			if err != nil {
 				return err
 			}
@@ -25,10 +25,11 @@ import (
 )
 
 func TestPmsFactory(t *testing.T) {
-	pmsFactory := NewPmsFactory(&Params.PulsarCfg)
+	pmsFactory, err := NewPmsFactory(&Params.PulsarCfg)
+	assert.NoError(t, err)
 
 	ctx := context.Background()
-	_, err := pmsFactory.NewMsgStream(ctx)
+	_, err = pmsFactory.NewMsgStream(ctx)
 	assert.Nil(t, err)
 
 	_, err = pmsFactory.NewTtMsgStream(ctx)
@@ -49,10 +50,11 @@ func TestPmsFactoryWithAuth(t *testing.T) {
 		Params.Save(Params.PulsarCfg.AuthPlugin.Key, "")
 		Params.Save(Params.PulsarCfg.AuthParams.Key, "")
 	}()
-	pmsFactory := NewPmsFactory(config)
+	pmsFactory, err := NewPmsFactory(config)
+	assert.NoError(t, err)
 
 	ctx := context.Background()
-	_, err := pmsFactory.NewMsgStream(ctx)
+	_, err = pmsFactory.NewMsgStream(ctx)
 	assert.Nil(t, err)
 
 	_, err = pmsFactory.NewTtMsgStream(ctx)
@@ -62,7 +64,8 @@ func TestPmsFactoryWithAuth(t *testing.T) {
 	assert.Nil(t, err)
 
 	Params.Save(Params.PulsarCfg.AuthParams.Key, "")
-	pmsFactory = NewPmsFactory(config)
+	pmsFactory, err = NewPmsFactory(config)
+	assert.NoError(t, err)
 
 	ctx = context.Background()
 	_, err = pmsFactory.NewMsgStream(ctx)
@@ -0,0 +1,257 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	pulsarctl "github.com/streamnative/pulsarctl/pkg/pulsar"
+	"github.com/streamnative/pulsarctl/pkg/pulsar/utils"
+	"go.uber.org/zap"
+)
+
+// DefaultBacklogQuotaPolicy holds the producers when the backlog quota is exceeded,
+// the other policies either fail the producers or drop messages not consumed yet.
+const DefaultBacklogQuotaPolicy = string(utils.ProducerRequestHold)
+
+// ChannelGroup is a group of channels which share the same pulsar tenant, namespace and namespace policies.
+type ChannelGroup struct {
+	Name string
+	// ChannelPrefix is the prefix of the channels in the group, e.g. by-dev-rootcoord-dml
+	ChannelPrefix string
+	Tenant        string
+	Namespace     string
+	// MessageTTL is in seconds, 0 means keeping the pulsar setting
+	MessageTTL int
+	// BacklogQuota is in bytes, negative means keeping the pulsar setting
+	BacklogQuota       int64
+	BacklogQuotaPolicy string
+	// RetentionTime is in minutes and RetentionSize is in MB, the retention policy is kept if both of them are 0
+	RetentionTime int
+	RetentionSize int
+}
+
+func (g *ChannelGroup) namespace() string {
+	return g.Tenant + "/" + g.Namespace
+}
+
+// ParseChannelGroups parses the channel groups from the configs like "dml.channelprefix: by-dev-rootcoord-dml",
+// the tenant and namespace of a group are @defaultTenant and @defaultNamespace if not set.
+// The groups can't share a namespace, since the policies applied at the namespace level would overwrite each other.
+func ParseChannelGroups(configs map[string]string, defaultTenant string, defaultNamespace string) ([]ChannelGroup, error) {
+	groups := make(map[string]*ChannelGroup)
+	for key, value := range configs {
+		idx := strings.Index(key, ".")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid pulsar channel group config %s", key)
+		}
+		name, field := key[:idx], strings.ToLower(key[idx+1:])
+		group, ok := groups[name]
+		if !ok {
+			group = &ChannelGroup{
+				Name:               name,
+				Tenant:             defaultTenant,
+				Namespace:          defaultNamespace,
+				BacklogQuota:       -1,
+				BacklogQuotaPolicy: DefaultBacklogQuotaPolicy,
+			}
+			groups[name] = group
+		}
+		var err error
+		switch field {
+		case "channelprefix":
+			group.ChannelPrefix = value
+		case "tenant":
+			group.Tenant = value
+		case "namespace":
+			group.Namespace = value
+		case "messagettl":
+			group.MessageTTL, err = strconv.Atoi(value)
+		case "backlogquota":
+			group.BacklogQuota, err = strconv.ParseInt(value, 10, 64)
+		case "backlogquotapolicy":
+			group.BacklogQuotaPolicy = value
+		case "retentiontime":
+			group.RetentionTime, err = strconv.Atoi(value)
+		case "retentionsize":
+			group.RetentionSize, err = strconv.Atoi(value)
+		default:
+			return nil, fmt.Errorf("unknown pulsar channel group config %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid pulsar channel group config %s: %w", key, err)
+		}
+	}
+
+	ret := make([]ChannelGroup, 0, len(groups))
+	for _, group := range groups {
+		if group.ChannelPrefix == "" {
+			return nil, fmt.Errorf("channelPrefix of pulsar channel group %s is not set", group.Name)
+		}
+		if group.Tenant == "" || group.Namespace == "" {
+			return nil, fmt.Errorf("tenant or namespace of pulsar channel group %s is empty", group.Name)
+		}
+		if !funcutil.SliceContain([]string{string(utils.ProducerRequestHold), string(utils.ProducerException),
+			string(utils.ConsumerBacklogEviction)}, group.BacklogQuotaPolicy) {
+			return nil, fmt.Errorf("unknown backlogQuotaPolicy %s of pulsar channel group %s", group.BacklogQuotaPolicy, group.Name)
+		}
+		ret = append(ret, *group)
+	}
+	// match the longest prefix first
+	sort.Slice(ret, func(i, j int) bool {
+		if len(ret[i].ChannelPrefix) != len(ret[j].ChannelPrefix) {
+			return len(ret[i].ChannelPrefix) > len(ret[j].ChannelPrefix)
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	namespaces := make(map[string]string, len(ret))
+	for _, group := range ret {
+		if other, ok := namespaces[group.namespace()]; ok {
+			return nil, fmt.Errorf("pulsar channel groups %s and %s share the namespace %s, each group must have its own namespace",
+				other, group.Name, group.namespace())
+		}
+		namespaces[group.namespace()] = group.Name
+	}
+	return ret, nil
+}
+
+// ChannelGroups puts the channels into the tenant and namespace of their groups,
+// and applies the namespace policies of a group before its first channel is used.
+type ChannelGroups struct {
+	groups []ChannelGroup
+
+	webAddress string
+	authPlugin string
+	authParams string
+
+	mu       sync.Mutex
+	prepared map[string]bool
+}
+
+// NewChannelGroups creates a ChannelGroups, the admin client built from @webAddress is used to apply the policies.
+func NewChannelGroups(groups []ChannelGroup, webAddress, authPlugin, authParams string) *ChannelGroups {
+	return &ChannelGroups{
+		groups:     groups,
+		webAddress: webAddress,
+		authPlugin: authPlugin,
+		authParams: authParams,
+		prepared:   make(map[string]bool),
+	}
+}
+
+// Match returns the group @channel belongs to, or nil if there is none.
+func (cg *ChannelGroups) Match(channel string) *ChannelGroup {
+	if cg == nil {
+		return nil
+	}
+	for i := range cg.groups {
+		if strings.HasPrefix(channel, cg.groups[i].ChannelPrefix) {
+			return &cg.groups[i]
+		}
+	}
+	return nil
+}
+
+// FullTopicName returns the full topic name of @channel, which is in @tenant/@namespace if it belongs to no group.
+func (cg *ChannelGroups) FullTopicName(tenant string, namespace string, channel string) (string, error) {
+	if group := cg.Match(channel); group != nil {
+		return GetFullTopicName(group.Tenant, group.Namespace, channel)
+	}
+	return GetFullTopicName(tenant, namespace, channel)
+}
+
+// prepare creates the tenant and namespace of @group if they don't exist, and applies the namespace policies.
+func (cg *ChannelGroups) prepare(group *ChannelGroup) error {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	if cg.prepared[group.Name] {
+		return nil
+	}
+
+	admin, err := NewAdminClient(cg.webAddress, cg.authPlugin, cg.authParams)
+	if err != nil {
+		return err
+	}
+	if err := ensureNamespace(admin, group.Tenant, group.namespace()); err != nil {
+		return err
+	}
+	if err := applyNamespacePolicies(admin, group); err != nil {
+		return err
+	}
+	log.Info("pulsar channel group prepared", zap.String("group", group.Name),
+		zap.String("namespace", group.namespace()), zap.Int("messageTTL", group.MessageTTL),
+		zap.Int64("backlogQuota", group.BacklogQuota), zap.Int("retentionTime", group.RetentionTime),
+		zap.Int("retentionSize", group.RetentionSize))
+	cg.prepared[group.Name] = true
+	return nil
+}
+
+func ensureNamespace(admin pulsarctl.Client, tenant string, namespace string) error {
+	tenants, err := admin.Tenants().List()
+	if err != nil {
+		return fmt.Errorf("failed to list pulsar tenants: %w", err)
+	}
+	if !funcutil.SliceContain(tenants, tenant) {
+		clusters, err := admin.Clusters().List()
+		if err != nil {
+			return fmt.Errorf("failed to list pulsar clusters: %w", err)
+		}
+		err = admin.Tenants().Create(utils.TenantData{Name: tenant, AllowedClusters: clusters})
+		if err != nil {
+			return fmt.Errorf("failed to create pulsar tenant %s: %w", tenant, err)
+		}
+	}
+
+	namespaces, err := admin.Namespaces().GetNamespaces(tenant)
+	if err != nil {
+		return fmt.Errorf("failed to list pulsar namespaces of %s: %w", tenant, err)
+	}
+	if !funcutil.SliceContain(namespaces, namespace) {
+		if err := admin.Namespaces().CreateNamespace(namespace); err != nil {
+			return fmt.Errorf("failed to create pulsar namespace %s: %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+func applyNamespacePolicies(admin pulsarctl.Client, group *ChannelGroup) error {
+	namespace := group.namespace()
+	if group.MessageTTL > 0 {
+		if err := admin.Namespaces().SetNamespaceMessageTTL(namespace, group.MessageTTL); err != nil {
+			return fmt.Errorf("failed to set message ttl of %s: %w", namespace, err)
+		}
+	}
+	if group.BacklogQuota >= 0 {
+		quota := utils.NewBacklogQuota(group.BacklogQuota, -1, utils.RetentionPolicy(group.BacklogQuotaPolicy))
+		if err := admin.Namespaces().SetBacklogQuota(namespace, quota, utils.DestinationStorage); err != nil {
+			return fmt.Errorf("failed to set backlog quota of %s: %w", namespace, err)
+		}
+	}
+	if group.RetentionTime != 0 || group.RetentionSize != 0 {
+		retention := utils.NewRetentionPolicies(group.RetentionTime, group.RetentionSize)
+		if err := admin.Namespaces().SetRetention(namespace, retention); err != nil {
+			return fmt.Errorf("failed to set retention of %s: %w", namespace, err)
+		}
+	}
+	return nil
+}
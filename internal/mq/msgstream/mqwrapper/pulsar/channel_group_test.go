@@ -0,0 +1,110 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulsar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChannelGroups(t *testing.T) {
+	groups, err := ParseChannelGroups(nil, "public", "default")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(groups))
+
+	groups, err = ParseChannelGroups(map[string]string{
+		"dml.channelprefix":       "by-dev-rootcoord-dml",
+		"dml.namespace":           "milvus-dml",
+		"dml.messagettl":          "3600",
+		"dml.backlogquota":        "1024",
+		"dml.retentiontime":       "60",
+		"dml.retentionsize":       "100",
+		"rootcoord.channelprefix": "by-dev-rootcoord",
+		"rootcoord.tenant":        "milvus",
+	}, "public", "default")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(groups))
+	// the longest prefix comes first
+	assert.Equal(t, ChannelGroup{
+		Name:               "dml",
+		ChannelPrefix:      "by-dev-rootcoord-dml",
+		Tenant:             "public",
+		Namespace:          "milvus-dml",
+		MessageTTL:         3600,
+		BacklogQuota:       1024,
+		BacklogQuotaPolicy: DefaultBacklogQuotaPolicy,
+		RetentionTime:      60,
+		RetentionSize:      100,
+	}, groups[0])
+	assert.Equal(t, ChannelGroup{
+		Name:               "rootcoord",
+		ChannelPrefix:      "by-dev-rootcoord",
+		Tenant:             "milvus",
+		Namespace:          "default",
+		BacklogQuota:       -1,
+		BacklogQuotaPolicy: DefaultBacklogQuotaPolicy,
+	}, groups[1])
+
+	invalidConfigs := []map[string]string{
+		{"channelprefix": "by-dev"},
+		{"dml.unknown": "1"},
+		{"dml.channelprefix": "by-dev", "dml.messagettl": "abc"},
+		{"dml.namespace": "milvus-dml"},
+		{"dml.channelprefix": "by-dev", "dml.backlogquotapolicy": "unknown"},
+		// the groups sharing a namespace would overwrite the policies of each other
+		{"dml.channelprefix": "by-dev-dml", "dml.messagettl": "60", "dc.channelprefix": "by-dev-dc", "dc.messagettl": "3600"},
+		{"dml.channelprefix": "by-dev-dml", "dml.namespace": "milvus", "dc.channelprefix": "by-dev-dc", "dc.namespace": "milvus"},
+	}
+	for _, configs := range invalidConfigs {
+		_, err = ParseChannelGroups(configs, "public", "default")
+		assert.Error(t, err, configs)
+	}
+}
+
+func TestChannelGroups_FullTopicName(t *testing.T) {
+	groups, err := ParseChannelGroups(map[string]string{
+		"dml.channelprefix":       "by-dev-rootcoord-dml",
+		"dml.namespace":           "milvus-dml",
+		"rootcoord.channelprefix": "by-dev-rootcoord",
+		"rootcoord.tenant":        "milvus",
+	}, "public", "default")
+	require.NoError(t, err)
+	cg := NewChannelGroups(groups, "", "", "")
+
+	assert.Equal(t, "dml", cg.Match("by-dev-rootcoord-dml_0").Name)
+	assert.Equal(t, "rootcoord", cg.Match("by-dev-rootcoord-timetick").Name)
+	assert.Nil(t, cg.Match("by-dev-datacoord-timetick-channel"))
+
+	name, err := cg.FullTopicName("public", "default", "by-dev-rootcoord-dml_0")
+	assert.NoError(t, err)
+	assert.Equal(t, "public/milvus-dml/by-dev-rootcoord-dml_0", name)
+	name, err = cg.FullTopicName("public", "default", "by-dev-rootcoord-timetick")
+	assert.NoError(t, err)
+	assert.Equal(t, "milvus/default/by-dev-rootcoord-timetick", name)
+	name, err = cg.FullTopicName("public", "default", "by-dev-datacoord-timetick-channel")
+	assert.NoError(t, err)
+	assert.Equal(t, "public/default/by-dev-datacoord-timetick-channel", name)
+
+	// nil ChannelGroups keeps every channel in the default tenant/namespace
+	var nilGroups *ChannelGroups
+	assert.Nil(t, nilGroups.Match("by-dev-rootcoord-dml_0"))
+	name, err = nilGroups.FullTopicName("public", "default", "by-dev-rootcoord-dml_0")
+	assert.NoError(t, err)
+	assert.Equal(t, "public/default/by-dev-rootcoord-dml_0", name)
+}
@@ -36,6 +36,8 @@ type pulsarClient struct {
 	tenant    string
 	namespace string
 	client    pulsar.Client
+	// channelGroups overrides the tenant and namespace of the channels in the groups
+	channelGroups *ChannelGroups
 }
 
 var sc *pulsarClient
@@ -44,6 +46,12 @@ var once sync.Once
 // NewClient creates a pulsarClient object
 // according to the parameter opts of type pulsar.ClientOptions
 func NewClient(tenant string, namespace string, opts pulsar.ClientOptions) (*pulsarClient, error) {
+	return NewClientWithChannelGroups(tenant, namespace, opts, nil)
+}
+
+// NewClientWithChannelGroups creates a pulsarClient object which puts the channels of @channelGroups
+// into the tenant and namespace of their groups.
+func NewClientWithChannelGroups(tenant string, namespace string, opts pulsar.ClientOptions, channelGroups *ChannelGroups) (*pulsarClient, error) {
 	once.Do(func() {
 		c, err := pulsar.NewClient(opts)
 		if err != nil {
@@ -51,9 +59,10 @@ func NewClient(tenant string, namespace string, opts pulsar.ClientOptions) (*pul
 			return
 		}
 		cli := &pulsarClient{
-			client:    c,
-			tenant:    tenant,
-			namespace: namespace,
+			client:        c,
+			tenant:        tenant,
+			namespace:     namespace,
+			channelGroups: channelGroups,
 		}
 		sc = cli
 	})
@@ -62,7 +71,7 @@ func NewClient(tenant string, namespace string, opts pulsar.ClientOptions) (*pul
 
 // CreateProducer create a pulsar producer from options
 func (pc *pulsarClient) CreateProducer(options mqwrapper.ProducerOptions) (mqwrapper.Producer, error) {
-	fullTopicName, err := GetFullTopicName(pc.tenant, pc.namespace, options.Topic)
+	fullTopicName, err := pc.fullTopicName(options.Topic)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +99,7 @@ func (pc *pulsarClient) CreateProducer(options mqwrapper.ProducerOptions) (mqwra
 // Subscribe creates a pulsar consumer instance and subscribe a topic
 func (pc *pulsarClient) Subscribe(options mqwrapper.ConsumerOptions) (mqwrapper.Consumer, error) {
 	receiveChannel := make(chan pulsar.ConsumerMessage, options.BufSize)
-	fullTopicName, err := GetFullTopicName(pc.tenant, pc.namespace, options.Topic)
+	fullTopicName, err := pc.fullTopicName(options.Topic)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +126,21 @@ func (pc *pulsarClient) Subscribe(options mqwrapper.ConsumerOptions) (mqwrapper.
 	return pConsumer, nil
 }
 
+// fullTopicName returns the full topic name of @topic, the namespace policies of its channel group
+// are applied before the topic is used for the first time.
+func (pc *pulsarClient) fullTopicName(topic string) (string, error) {
+	group := pc.channelGroups.Match(topic)
+	if group == nil {
+		return GetFullTopicName(pc.tenant, pc.namespace, topic)
+	}
+	if err := pc.channelGroups.prepare(group); err != nil {
+		log.Warn("failed to prepare pulsar channel group", zap.String("group", group.Name),
+			zap.String("topic", topic), zap.Error(err))
+		return "", err
+	}
+	return GetFullTopicName(group.Tenant, group.Namespace, topic)
+}
+
 func GetFullTopicName(tenant string, namespace string, topic string) (string, error) {
 	if len(tenant) == 0 || len(namespace) == 0 || len(topic) == 0 {
 		log.Error("build full topic name failed",
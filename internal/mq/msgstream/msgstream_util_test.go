@@ -24,7 +24,8 @@ import (
 )
 
 func TestPulsarMsgUtil(t *testing.T) {
-	pmsFactory := NewPmsFactory(&Params.PulsarCfg)
+	pmsFactory, err := NewPmsFactory(&Params.PulsarCfg)
+	assert.NoError(t, err)
 
 	ctx := context.Background()
 	msgStream, err := pmsFactory.NewMsgStream(ctx)
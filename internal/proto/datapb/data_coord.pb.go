@@ -942,12 +942,14 @@ func (m *GetInsertBinlogPathsResponse) GetStatus() *commonpb.Status {
 }
 
 type GetCollectionStatisticsRequest struct {
-	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	DbID                 int64             `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	CollectionID         int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Base         *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	DbID         int64             `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionID int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	// the row counts are no staler than freshness_ms, 0 to use dataCoord.statistics.freshness
+	FreshnessMs          int64    `protobuf:"varint,4,opt,name=freshness_ms,json=freshnessMs,proto3" json:"freshness_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetCollectionStatisticsRequest) Reset()         { *m = GetCollectionStatisticsRequest{} }
@@ -996,6 +998,13 @@ func (m *GetCollectionStatisticsRequest) GetCollectionID() int64 {
 	return 0
 }
 
+func (m *GetCollectionStatisticsRequest) GetFreshnessMs() int64 {
+	if m != nil {
+		return m.FreshnessMs
+	}
+	return 0
+}
+
 type GetCollectionStatisticsResponse struct {
 	Stats                []*commonpb.KeyValuePair `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
 	Status               *commonpb.Status         `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
@@ -1044,13 +1053,15 @@ func (m *GetCollectionStatisticsResponse) GetStatus() *commonpb.Status {
 }
 
 type GetPartitionStatisticsRequest struct {
-	Base                 *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	DbID                 int64             `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
-	CollectionID         int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
-	PartitionIDs         []int64           `protobuf:"varint,4,rep,packed,name=partitionIDs,proto3" json:"partitionIDs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Base         *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	DbID         int64             `protobuf:"varint,2,opt,name=dbID,proto3" json:"dbID,omitempty"`
+	CollectionID int64             `protobuf:"varint,3,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	PartitionIDs []int64           `protobuf:"varint,4,rep,packed,name=partitionIDs,proto3" json:"partitionIDs,omitempty"`
+	// the row counts are no staler than freshness_ms, 0 to use dataCoord.statistics.freshness
+	FreshnessMs          int64    `protobuf:"varint,5,opt,name=freshness_ms,json=freshnessMs,proto3" json:"freshness_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetPartitionStatisticsRequest) Reset()         { *m = GetPartitionStatisticsRequest{} }
@@ -1106,6 +1117,13 @@ func (m *GetPartitionStatisticsRequest) GetPartitionIDs() []int64 {
 	return nil
 }
 
+func (m *GetPartitionStatisticsRequest) GetFreshnessMs() int64 {
+	if m != nil {
+		return m.FreshnessMs
+	}
+	return 0
+}
+
 type GetPartitionStatisticsResponse struct {
 	Stats                []*commonpb.KeyValuePair `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
 	Status               *commonpb.Status         `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
@@ -4803,6 +4821,183 @@ func (m *SegmentReferenceLock) GetSegmentIDs() []int64 {
 	return nil
 }
 
+type FreshSearchRequest struct {
+	Base          *commonpb.MsgBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	CollectionID  int64             `protobuf:"varint,2,opt,name=collectionID,proto3" json:"collectionID,omitempty"`
+	PartitionIDs  []int64           `protobuf:"varint,3,rep,packed,name=partitionIDs,proto3" json:"partitionIDs,omitempty"`
+	PkFieldID     int64             `protobuf:"varint,4,opt,name=pk_fieldID,json=pkFieldID,proto3" json:"pk_fieldID,omitempty"`
+	VectorFieldID int64             `protobuf:"varint,5,opt,name=vector_fieldID,json=vectorFieldID,proto3" json:"vector_fieldID,omitempty"`
+	MetricType    string            `protobuf:"bytes,6,opt,name=metric_type,json=metricType,proto3" json:"metric_type,omitempty"`
+	Nq            int64             `protobuf:"varint,7,opt,name=nq,proto3" json:"nq,omitempty"`
+	Topk          int64             `protobuf:"varint,8,opt,name=topk,proto3" json:"topk,omitempty"`
+	Dim           int64             `protobuf:"varint,9,opt,name=dim,proto3" json:"dim,omitempty"`
+	// the float vectors of the nq queries, nq * dim values
+	Vectors              []float32 `protobuf:"fixed32,10,rep,packed,name=vectors,proto3" json:"vectors,omitempty"`
+	TravelTimestamp      uint64    `protobuf:"varint,11,opt,name=travel_timestamp,json=travelTimestamp,proto3" json:"travel_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *FreshSearchRequest) Reset()         { *m = FreshSearchRequest{} }
+func (m *FreshSearchRequest) String() string { return proto.CompactTextString(m) }
+func (*FreshSearchRequest) ProtoMessage()    {}
+func (*FreshSearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82cd95f524594f49, []int{75}
+}
+
+func (m *FreshSearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FreshSearchRequest.Unmarshal(m, b)
+}
+func (m *FreshSearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FreshSearchRequest.Marshal(b, m, deterministic)
+}
+func (m *FreshSearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreshSearchRequest.Merge(m, src)
+}
+func (m *FreshSearchRequest) XXX_Size() int {
+	return xxx_messageInfo_FreshSearchRequest.Size(m)
+}
+func (m *FreshSearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreshSearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreshSearchRequest proto.InternalMessageInfo
+
+func (m *FreshSearchRequest) GetBase() *commonpb.MsgBase {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *FreshSearchRequest) GetCollectionID() int64 {
+	if m != nil {
+		return m.CollectionID
+	}
+	return 0
+}
+
+func (m *FreshSearchRequest) GetPartitionIDs() []int64 {
+	if m != nil {
+		return m.PartitionIDs
+	}
+	return nil
+}
+
+func (m *FreshSearchRequest) GetPkFieldID() int64 {
+	if m != nil {
+		return m.PkFieldID
+	}
+	return 0
+}
+
+func (m *FreshSearchRequest) GetVectorFieldID() int64 {
+	if m != nil {
+		return m.VectorFieldID
+	}
+	return 0
+}
+
+func (m *FreshSearchRequest) GetMetricType() string {
+	if m != nil {
+		return m.MetricType
+	}
+	return ""
+}
+
+func (m *FreshSearchRequest) GetNq() int64 {
+	if m != nil {
+		return m.Nq
+	}
+	return 0
+}
+
+func (m *FreshSearchRequest) GetTopk() int64 {
+	if m != nil {
+		return m.Topk
+	}
+	return 0
+}
+
+func (m *FreshSearchRequest) GetDim() int64 {
+	if m != nil {
+		return m.Dim
+	}
+	return 0
+}
+
+func (m *FreshSearchRequest) GetVectors() []float32 {
+	if m != nil {
+		return m.Vectors
+	}
+	return nil
+}
+
+func (m *FreshSearchRequest) GetTravelTimestamp() uint64 {
+	if m != nil {
+		return m.TravelTimestamp
+	}
+	return 0
+}
+
+type FreshSearchResponse struct {
+	Status *commonpb.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// the top k hits of the buffered rows, one per DataNode
+	Results []*schemapb.SearchResultData `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	// the number of the buffered rows searched
+	Rows                 int64    `protobuf:"varint,3,opt,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FreshSearchResponse) Reset()         { *m = FreshSearchResponse{} }
+func (m *FreshSearchResponse) String() string { return proto.CompactTextString(m) }
+func (*FreshSearchResponse) ProtoMessage()    {}
+func (*FreshSearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82cd95f524594f49, []int{76}
+}
+
+func (m *FreshSearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FreshSearchResponse.Unmarshal(m, b)
+}
+func (m *FreshSearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FreshSearchResponse.Marshal(b, m, deterministic)
+}
+func (m *FreshSearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreshSearchResponse.Merge(m, src)
+}
+func (m *FreshSearchResponse) XXX_Size() int {
+	return xxx_messageInfo_FreshSearchResponse.Size(m)
+}
+func (m *FreshSearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreshSearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreshSearchResponse proto.InternalMessageInfo
+
+func (m *FreshSearchResponse) GetStatus() *commonpb.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *FreshSearchResponse) GetResults() []*schemapb.SearchResultData {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *FreshSearchResponse) GetRows() int64 {
+	if m != nil {
+		return m.Rows
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("milvus.proto.data.SegmentType", SegmentType_name, SegmentType_value)
 	proto.RegisterEnum("milvus.proto.data.ChannelWatchState", ChannelWatchState_name, ChannelWatchState_value)
@@ -4882,285 +5077,298 @@ func init() {
 	proto.RegisterType((*UnsetIsImportingStateRequest)(nil), "milvus.proto.data.UnsetIsImportingStateRequest")
 	proto.RegisterType((*MarkSegmentsDroppedRequest)(nil), "milvus.proto.data.MarkSegmentsDroppedRequest")
 	proto.RegisterType((*SegmentReferenceLock)(nil), "milvus.proto.data.SegmentReferenceLock")
+	proto.RegisterType((*FreshSearchRequest)(nil), "milvus.proto.data.FreshSearchRequest")
+	proto.RegisterType((*FreshSearchResponse)(nil), "milvus.proto.data.FreshSearchResponse")
 }
 
 func init() { proto.RegisterFile("data_coord.proto", fileDescriptor_82cd95f524594f49) }
 
 var fileDescriptor_82cd95f524594f49 = []byte{
-	// 4364 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x3c, 0x4b, 0x6f, 0x24, 0x49,
-	0x5a, 0x9d, 0xf5, 0x72, 0xd5, 0x57, 0x0f, 0x97, 0xa3, 0x7b, 0xec, 0xea, 0xea, 0xe7, 0x64, 0x4f,
-	0xcf, 0xf4, 0xf4, 0xf4, 0x74, 0xcf, 0x78, 0x18, 0xed, 0x40, 0xef, 0xcc, 0xaa, 0x6d, 0x8f, 0xbb,
-	0x0b, 0x6c, 0xaf, 0x37, 0xed, 0x9e, 0x96, 0x76, 0x91, 0x4a, 0xe9, 0xca, 0x70, 0x39, 0xd7, 0xf9,
-	0xa8, 0xce, 0xcc, 0xb2, 0xdb, 0xcb, 0x61, 0x47, 0xac, 0x84, 0x34, 0x08, 0xb1, 0x08, 0x09, 0x01,
-	0x07, 0x24, 0xc4, 0x69, 0x59, 0x04, 0x42, 0x5a, 0x71, 0xe1, 0xc2, 0x75, 0x04, 0x87, 0x15, 0x42,
-	0xe2, 0x07, 0x70, 0x00, 0xee, 0x5c, 0x39, 0xa0, 0x78, 0x64, 0xe4, 0xbb, 0x2a, 0x5d, 0xd5, 0x3d,
-	0x8d, 0xe0, 0x56, 0x11, 0xf9, 0x45, 0x7c, 0x11, 0x5f, 0x7c, 0xef, 0xf8, 0xa2, 0xa0, 0xad, 0xa9,
-	0x9e, 0xda, 0x1f, 0xd8, 0xb6, 0xa3, 0xdd, 0x1f, 0x39, 0xb6, 0x67, 0xa3, 0x25, 0x53, 0x37, 0x4e,
-	0xc6, 0x2e, 0x6b, 0xdd, 0x27, 0x9f, 0xbb, 0x8d, 0x81, 0x6d, 0x9a, 0xb6, 0xc5, 0xba, 0xba, 0x2d,
-	0xdd, 0xf2, 0xb0, 0x63, 0xa9, 0x06, 0x6f, 0x37, 0xc2, 0x03, 0xba, 0x0d, 0x77, 0x70, 0x84, 0x4d,
-	0x95, 0xb5, 0xe4, 0x05, 0x28, 0x7f, 0x6e, 0x8e, 0xbc, 0x33, 0xf9, 0x4f, 0x24, 0x68, 0x6c, 0x1a,
-	0x63, 0xf7, 0x48, 0xc1, 0xcf, 0xc7, 0xd8, 0xf5, 0xd0, 0x07, 0x50, 0x3a, 0x50, 0x5d, 0xdc, 0x91,
-	0x6e, 0x4a, 0x77, 0xea, 0xab, 0x57, 0xef, 0x47, 0xb0, 0x72, 0x7c, 0xdb, 0xee, 0x70, 0x4d, 0x75,
-	0xb1, 0x42, 0x21, 0x11, 0x82, 0x92, 0x76, 0xd0, 0xdb, 0xe8, 0x14, 0x6e, 0x4a, 0x77, 0x8a, 0x0a,
-	0xfd, 0x8d, 0xae, 0x03, 0xb8, 0x78, 0x68, 0x62, 0xcb, 0xeb, 0x6d, 0xb8, 0x9d, 0xe2, 0xcd, 0xe2,
-	0x9d, 0xa2, 0x12, 0xea, 0x41, 0x32, 0x34, 0x06, 0xb6, 0x61, 0xe0, 0x81, 0xa7, 0xdb, 0x56, 0x6f,
-	0xa3, 0x53, 0xa2, 0x63, 0x23, 0x7d, 0xf2, 0xbf, 0x4b, 0xd0, 0xe4, 0x4b, 0x73, 0x47, 0xb6, 0xe5,
-	0x62, 0xf4, 0x11, 0x54, 0x5c, 0x4f, 0xf5, 0xc6, 0x2e, 0x5f, 0xdd, 0x95, 0xd4, 0xd5, 0xed, 0x51,
-	0x10, 0x85, 0x83, 0xa6, 0x2e, 0x2f, 0x8e, 0xbe, 0x98, 0x44, 0x1f, 0xdb, 0x42, 0x29, 0xb1, 0x85,
-	0x3b, 0xb0, 0x78, 0x48, 0x56, 0xb7, 0x17, 0x00, 0x95, 0x29, 0x50, 0xbc, 0x9b, 0xcc, 0xe4, 0xe9,
-	0x26, 0xfe, 0xee, 0xe1, 0x1e, 0x56, 0x8d, 0x4e, 0x85, 0xe2, 0x0a, 0xf5, 0xc8, 0xff, 0x2c, 0x41,
-	0x5b, 0x80, 0xfb, 0xe7, 0x70, 0x09, 0xca, 0x03, 0x7b, 0x6c, 0x79, 0x74, 0xab, 0x4d, 0x85, 0x35,
-	0xd0, 0x9b, 0xd0, 0x18, 0x1c, 0xa9, 0x96, 0x85, 0x8d, 0xbe, 0xa5, 0x9a, 0x98, 0x6e, 0xaa, 0xa6,
-	0xd4, 0x79, 0xdf, 0x8e, 0x6a, 0xe2, 0x5c, 0x7b, 0xbb, 0x09, 0xf5, 0x91, 0xea, 0x78, 0x7a, 0x84,
-	0xfa, 0xe1, 0x2e, 0xd4, 0x85, 0xaa, 0xee, 0xf6, 0xcc, 0x91, 0xed, 0x78, 0x9d, 0xf2, 0x4d, 0xe9,
-	0x4e, 0x55, 0x11, 0x6d, 0x82, 0x41, 0xa7, 0xbf, 0xf6, 0x55, 0xf7, 0xb8, 0xb7, 0xc1, 0x77, 0x14,
-	0xe9, 0x93, 0xff, 0x5c, 0x82, 0xe5, 0x47, 0xae, 0xab, 0x0f, 0xad, 0xc4, 0xce, 0x96, 0xa1, 0x62,
-	0xd9, 0x1a, 0xee, 0x6d, 0xd0, 0xad, 0x15, 0x15, 0xde, 0x42, 0x57, 0xa0, 0x36, 0xc2, 0xd8, 0xe9,
-	0x3b, 0xb6, 0xe1, 0x6f, 0xac, 0x4a, 0x3a, 0x14, 0xdb, 0xc0, 0xe8, 0x7b, 0xb0, 0xe4, 0xc6, 0x26,
-	0x62, 0x7c, 0x55, 0x5f, 0xbd, 0x75, 0x3f, 0x21, 0x19, 0xf7, 0xe3, 0x48, 0x95, 0xe4, 0x68, 0xf9,
-	0xcb, 0x02, 0x5c, 0x14, 0x70, 0x6c, 0xad, 0xe4, 0x37, 0xa1, 0xbc, 0x8b, 0x87, 0x62, 0x79, 0xac,
-	0x91, 0x87, 0xf2, 0xe2, 0xc8, 0x8a, 0xe1, 0x23, 0xcb, 0xc1, 0xea, 0xf1, 0xf3, 0x28, 0x27, 0xcf,
-	0xe3, 0x06, 0xd4, 0xf1, 0x8b, 0x91, 0xee, 0xe0, 0x3e, 0x61, 0x1c, 0x4a, 0xf2, 0x92, 0x02, 0xac,
-	0x6b, 0x5f, 0x37, 0xc3, 0xb2, 0xb1, 0x90, 0x5b, 0x36, 0xe4, 0xbf, 0x90, 0x60, 0x25, 0x71, 0x4a,
-	0x5c, 0xd8, 0x14, 0x68, 0xd3, 0x9d, 0x07, 0x94, 0x21, 0x62, 0x47, 0x08, 0xfe, 0xf6, 0x24, 0x82,
-	0x07, 0xe0, 0x4a, 0x62, 0x7c, 0x68, 0x91, 0x85, 0xfc, 0x8b, 0x3c, 0x86, 0x95, 0xc7, 0xd8, 0xe3,
-	0x08, 0xc8, 0x37, 0xec, 0xce, 0xae, 0xac, 0xa2, 0x52, 0x5d, 0x88, 0x4b, 0xb5, 0xfc, 0xb7, 0x05,
-	0x21, 0x8b, 0x14, 0x55, 0xcf, 0x3a, 0xb4, 0xd1, 0x55, 0xa8, 0x09, 0x10, 0xce, 0x15, 0x41, 0x07,
-	0xfa, 0x16, 0x94, 0xc9, 0x4a, 0x19, 0x4b, 0xb4, 0x56, 0xdf, 0x4c, 0xdf, 0x53, 0x68, 0x4e, 0x85,
-	0xc1, 0xa3, 0x1e, 0xb4, 0x5c, 0x4f, 0x75, 0xbc, 0xfe, 0xc8, 0x76, 0xe9, 0x39, 0x53, 0xc6, 0xa9,
-	0xaf, 0xca, 0xd1, 0x19, 0x84, 0x5a, 0xdf, 0x76, 0x87, 0xbb, 0x1c, 0x52, 0x69, 0xd2, 0x91, 0x7e,
-	0x13, 0x7d, 0x0e, 0x0d, 0x6c, 0x69, 0xc1, 0x44, 0xa5, 0xdc, 0x13, 0xd5, 0xb1, 0xa5, 0x89, 0x69,
-	0x82, 0xf3, 0x29, 0xe7, 0x3f, 0x9f, 0xdf, 0x93, 0xa0, 0x93, 0x3c, 0xa0, 0x79, 0x54, 0xf6, 0x43,
-	0x36, 0x08, 0xb3, 0x03, 0x9a, 0x28, 0xe1, 0xe2, 0x90, 0x14, 0x3e, 0x44, 0xfe, 0x23, 0x09, 0xde,
-	0x08, 0x96, 0x43, 0x3f, 0xbd, 0x2a, 0x6e, 0x41, 0x77, 0xa1, 0xad, 0x5b, 0x03, 0x63, 0xac, 0xe1,
-	0xa7, 0xd6, 0x13, 0xac, 0x1a, 0xde, 0xd1, 0x19, 0x3d, 0xc3, 0xaa, 0x92, 0xe8, 0x97, 0x7f, 0x22,
-	0xc1, 0x72, 0x7c, 0x5d, 0xf3, 0x10, 0xe9, 0x57, 0xa0, 0xac, 0x5b, 0x87, 0xb6, 0x4f, 0xa3, 0xeb,
-	0x13, 0x84, 0x92, 0xe0, 0x62, 0xc0, 0xb2, 0x09, 0x57, 0x1e, 0x63, 0xaf, 0x67, 0xb9, 0xd8, 0xf1,
-	0xd6, 0x74, 0xcb, 0xb0, 0x87, 0xbb, 0xaa, 0x77, 0x34, 0x87, 0x40, 0x45, 0x64, 0xa3, 0x10, 0x93,
-	0x0d, 0xf9, 0x67, 0x12, 0x5c, 0x4d, 0xc7, 0xc7, 0xb7, 0xde, 0x85, 0xea, 0xa1, 0x8e, 0x0d, 0x8d,
-	0xd0, 0x57, 0xa2, 0xf4, 0x15, 0x6d, 0x22, 0x58, 0x23, 0x02, 0xcc, 0x77, 0xf8, 0x66, 0x06, 0x37,
-	0xef, 0x79, 0x8e, 0x6e, 0x0d, 0xb7, 0x74, 0xd7, 0x53, 0x18, 0x7c, 0x88, 0x9e, 0xc5, 0xfc, 0x6c,
-	0xfc, 0xbb, 0x12, 0x5c, 0x7f, 0x8c, 0xbd, 0x75, 0xa1, 0x97, 0xc9, 0x77, 0xdd, 0xf5, 0xf4, 0x81,
-	0xfb, 0x72, 0x7d, 0xa3, 0x1c, 0x06, 0x5a, 0xfe, 0xa9, 0x04, 0x37, 0x32, 0x17, 0xc3, 0x49, 0xc7,
-	0xf5, 0x8e, 0xaf, 0x95, 0xd3, 0xf5, 0xce, 0x6f, 0xe0, 0xb3, 0x2f, 0x54, 0x63, 0x8c, 0x77, 0x55,
-	0xdd, 0x61, 0x7a, 0x67, 0x46, 0x2d, 0xfc, 0xd7, 0x12, 0x5c, 0x7b, 0x8c, 0xbd, 0x5d, 0xdf, 0x26,
-	0xbd, 0x46, 0xea, 0x10, 0x98, 0x90, 0x6d, 0xf4, 0x9d, 0xb3, 0x48, 0x9f, 0xfc, 0xfb, 0xec, 0x38,
-	0x53, 0xd7, 0xfb, 0x5a, 0x08, 0x78, 0x9d, 0x4a, 0x42, 0x48, 0x24, 0xd7, 0x99, 0xeb, 0xc0, 0xc9,
-	0x27, 0xff, 0x99, 0x04, 0x97, 0x1f, 0x0d, 0x9e, 0x8f, 0x75, 0x07, 0x73, 0xa0, 0x2d, 0x7b, 0x70,
-	0x3c, 0x3b, 0x71, 0x03, 0x37, 0xab, 0x10, 0x71, 0xb3, 0xa6, 0xb9, 0xe6, 0xcb, 0x50, 0xf1, 0x98,
-	0x5f, 0xc7, 0x3c, 0x15, 0xde, 0xa2, 0xeb, 0x53, 0xb0, 0x81, 0x55, 0xf7, 0x7f, 0xe7, 0xfa, 0x7e,
-	0x5a, 0x82, 0xc6, 0x17, 0xdc, 0x1d, 0xa3, 0x56, 0x3b, 0xce, 0x49, 0x52, 0xba, 0xe3, 0x15, 0xf2,
-	0xe0, 0xd2, 0x9c, 0xba, 0xc7, 0xd0, 0x74, 0x31, 0x3e, 0x9e, 0xc5, 0x46, 0x37, 0xc8, 0x40, 0x61,
-	0x5b, 0xb7, 0x60, 0x69, 0x6c, 0xd1, 0xd0, 0x00, 0x6b, 0x9c, 0x80, 0x8c, 0x73, 0xa7, 0xeb, 0xee,
-	0xe4, 0x40, 0xf4, 0x84, 0x47, 0x1f, 0xa1, 0xb9, 0xca, 0xb9, 0xe6, 0x8a, 0x0f, 0x43, 0x3d, 0x68,
-	0x6b, 0x8e, 0x3d, 0x1a, 0x61, 0xad, 0xef, 0xfa, 0x53, 0x55, 0xf2, 0x4d, 0xc5, 0xc7, 0x89, 0xa9,
-	0x3e, 0x80, 0x8b, 0xf1, 0x95, 0xf6, 0x34, 0xe2, 0x90, 0x92, 0x33, 0x4c, 0xfb, 0x84, 0xee, 0xc1,
-	0x52, 0x12, 0xbe, 0x4a, 0xe1, 0x93, 0x1f, 0xd0, 0xfb, 0x80, 0x62, 0x4b, 0x25, 0xe0, 0x35, 0x06,
-	0x1e, 0x5d, 0x4c, 0x4f, 0x73, 0xe5, 0xaf, 0x24, 0x58, 0x7e, 0xa6, 0x7a, 0x83, 0xa3, 0x0d, 0x93,
-	0xcb, 0xda, 0x1c, 0xba, 0xea, 0x53, 0xa8, 0x9d, 0x70, 0xbe, 0xf0, 0x0d, 0xd2, 0x8d, 0x14, 0xfa,
-	0x84, 0x39, 0x50, 0x09, 0x46, 0x90, 0x78, 0xe8, 0xd2, 0x66, 0x28, 0x2e, 0x7c, 0x0d, 0x5a, 0x73,
-	0x4a, 0x40, 0x2b, 0xbf, 0x00, 0xe0, 0x8b, 0xdb, 0x76, 0x87, 0x33, 0xac, 0xeb, 0x13, 0x58, 0xe0,
-	0xb3, 0x71, 0xb5, 0x38, 0x8d, 0x7f, 0x7c, 0x70, 0xf9, 0xe7, 0x15, 0xa8, 0x87, 0x3e, 0xa0, 0x16,
-	0x14, 0x84, 0xbc, 0x16, 0x52, 0x76, 0x57, 0x98, 0x1e, 0x42, 0x15, 0x93, 0x21, 0xd4, 0x6d, 0x68,
-	0xe9, 0xd4, 0x0f, 0xe9, 0xf3, 0x53, 0xa1, 0x0a, 0xa4, 0xa6, 0x34, 0x59, 0x2f, 0x67, 0x11, 0x74,
-	0x1d, 0xea, 0xd6, 0xd8, 0xec, 0xdb, 0x87, 0x7d, 0xc7, 0x3e, 0x75, 0x79, 0x2c, 0x56, 0xb3, 0xc6,
-	0xe6, 0x77, 0x0f, 0x15, 0xfb, 0xd4, 0x0d, 0xdc, 0xfd, 0xca, 0x39, 0xdd, 0xfd, 0xeb, 0x50, 0x37,
-	0xd5, 0x17, 0x64, 0xd6, 0xbe, 0x35, 0x36, 0x69, 0x98, 0x56, 0x54, 0x6a, 0xa6, 0xfa, 0x42, 0xb1,
-	0x4f, 0x77, 0xc6, 0x26, 0xba, 0x03, 0x6d, 0x43, 0x75, 0xbd, 0x7e, 0x38, 0xce, 0xab, 0xd2, 0x38,
-	0xaf, 0x45, 0xfa, 0x3f, 0x0f, 0x62, 0xbd, 0x64, 0xe0, 0x50, 0x9b, 0x23, 0x70, 0xd0, 0x4c, 0x23,
-	0x98, 0x08, 0xf2, 0x07, 0x0e, 0x9a, 0x69, 0x88, 0x69, 0x3e, 0x81, 0x85, 0x03, 0xea, 0xdd, 0xb9,
-	0x9d, 0x7a, 0xa6, 0xee, 0xd8, 0x24, 0x8e, 0x1d, 0x73, 0x02, 0x15, 0x1f, 0x1c, 0x7d, 0x1b, 0x6a,
-	0xd4, 0xa8, 0xd2, 0xb1, 0x8d, 0x5c, 0x63, 0x83, 0x01, 0x64, 0xb4, 0x86, 0x0d, 0x4f, 0xa5, 0xa3,
-	0x9b, 0xf9, 0x46, 0x8b, 0x01, 0x44, 0x5f, 0x0d, 0x1c, 0xac, 0x7a, 0x58, 0x5b, 0x3b, 0x5b, 0xb7,
-	0xcd, 0x91, 0x4a, 0x99, 0xa9, 0xd3, 0xa2, 0x1e, 0x7c, 0xda, 0x27, 0xf4, 0x36, 0xb4, 0x06, 0xa2,
-	0xb5, 0xe9, 0xd8, 0x66, 0x67, 0x91, 0xca, 0x51, 0xac, 0x17, 0x5d, 0x03, 0xf0, 0x35, 0x95, 0xea,
-	0x75, 0xda, 0xf4, 0x14, 0x6b, 0xbc, 0xe7, 0x11, 0x4d, 0xe3, 0xe8, 0x6e, 0x9f, 0x25, 0x4c, 0x74,
-	0x6b, 0xd8, 0x59, 0xa2, 0x18, 0xeb, 0x7e, 0x86, 0x45, 0xb7, 0x86, 0x68, 0x05, 0x16, 0x74, 0xb7,
-	0x7f, 0xa8, 0x1e, 0xe3, 0x0e, 0xa2, 0x5f, 0x2b, 0xba, 0xbb, 0xa9, 0x1e, 0x63, 0xf9, 0xc7, 0x70,
-	0x29, 0xe0, 0xae, 0xd0, 0x49, 0x26, 0x99, 0x42, 0x9a, 0x95, 0x29, 0x26, 0xfb, 0xf4, 0xbf, 0x2c,
-	0xc1, 0xf2, 0x9e, 0x7a, 0x82, 0x5f, 0x7d, 0xf8, 0x90, 0x4b, 0xad, 0x6d, 0xc1, 0x12, 0x8d, 0x18,
-	0x56, 0x43, 0xeb, 0x99, 0x60, 0x57, 0xc3, 0xac, 0x90, 0x1c, 0x88, 0xbe, 0x43, 0x1c, 0x02, 0x3c,
-	0x38, 0xde, 0xb5, 0xf5, 0xc0, 0xa6, 0x5e, 0x4b, 0x99, 0x67, 0x5d, 0x40, 0x29, 0xe1, 0x11, 0x68,
-	0x17, 0x16, 0xa3, 0xc7, 0xe0, 0x5b, 0xd3, 0x77, 0x26, 0x06, 0xb1, 0x01, 0xf5, 0x95, 0x56, 0xe4,
-	0x30, 0x5c, 0xd4, 0x81, 0x05, 0x6e, 0x0a, 0xa9, 0xce, 0xa8, 0x2a, 0x7e, 0x13, 0xed, 0xc2, 0x45,
-	0xb6, 0x83, 0x3d, 0x2e, 0x10, 0x6c, 0xf3, 0xd5, 0x5c, 0x9b, 0x4f, 0x1b, 0x1a, 0x95, 0xa7, 0xda,
-	0x79, 0xe5, 0xa9, 0x03, 0x0b, 0x9c, 0xc7, 0xa9, 0x1e, 0xa9, 0x2a, 0x7e, 0x93, 0x1c, 0x73, 0xc0,
-	0xed, 0x75, 0xfa, 0x2d, 0xe8, 0x20, 0xa1, 0x17, 0x04, 0xf4, 0x9c, 0x92, 0x6e, 0xf9, 0x0c, 0xaa,
-	0x82, 0xc3, 0x0b, 0xb9, 0x39, 0x5c, 0x8c, 0x89, 0xeb, 0xf7, 0x62, 0x4c, 0xbf, 0xcb, 0xff, 0x24,
-	0x41, 0x63, 0x83, 0x6c, 0x69, 0xcb, 0x1e, 0x52, 0x6b, 0x74, 0x1b, 0x5a, 0x0e, 0x1e, 0xd8, 0x8e,
-	0xd6, 0xc7, 0x96, 0xe7, 0xe8, 0x98, 0x45, 0xe9, 0x25, 0xa5, 0xc9, 0x7a, 0x3f, 0x67, 0x9d, 0x04,
-	0x8c, 0xa8, 0x6c, 0xd7, 0x53, 0xcd, 0x51, 0xff, 0x90, 0xa8, 0x86, 0x02, 0x03, 0x13, 0xbd, 0x54,
-	0x33, 0xbc, 0x09, 0x8d, 0x00, 0xcc, 0xb3, 0x29, 0xfe, 0x92, 0x52, 0x17, 0x7d, 0xfb, 0x36, 0x7a,
-	0x0b, 0x5a, 0x94, 0xa6, 0x7d, 0xc3, 0x1e, 0xf6, 0x49, 0x44, 0xcb, 0x0d, 0x55, 0x43, 0xe3, 0xcb,
-	0x22, 0x67, 0x15, 0x85, 0x72, 0xf5, 0x1f, 0x61, 0x6e, 0xaa, 0x04, 0xd4, 0x9e, 0xfe, 0x23, 0x2c,
-	0xff, 0xa3, 0x04, 0xcd, 0x0d, 0xd5, 0x53, 0x77, 0x6c, 0x0d, 0xef, 0xcf, 0x68, 0xd8, 0x73, 0xa4,
-	0x3e, 0xaf, 0x42, 0x4d, 0xec, 0x80, 0x6f, 0x29, 0xe8, 0x40, 0x9b, 0xd0, 0xf2, 0x5d, 0xcb, 0x3e,
-	0x8b, 0xb8, 0x4a, 0x99, 0x0e, 0x54, 0xc8, 0x72, 0xba, 0x4a, 0xd3, 0x1f, 0x46, 0x9b, 0xf2, 0x26,
-	0x34, 0xc2, 0x9f, 0x09, 0xd6, 0xbd, 0x38, 0xa3, 0x88, 0x0e, 0xc2, 0x8d, 0x3b, 0x63, 0x93, 0x9c,
-	0x29, 0x57, 0x2c, 0x7e, 0x53, 0xfe, 0x89, 0x04, 0x4d, 0x6e, 0xee, 0xf7, 0xc4, 0x25, 0x01, 0xdd,
-	0x9a, 0x44, 0xb7, 0x46, 0x7f, 0xa3, 0x5f, 0x8b, 0xe6, 0xf5, 0xde, 0x4a, 0x55, 0x02, 0x74, 0x12,
-	0xea, 0x64, 0x46, 0x6c, 0x7d, 0x9e, 0x18, 0xff, 0x4b, 0xc2, 0x68, 0xfc, 0x68, 0x28, 0xa3, 0x75,
-	0x60, 0x41, 0xd5, 0x34, 0x07, 0xbb, 0x2e, 0x5f, 0x87, 0xdf, 0x24, 0x5f, 0x4e, 0xb0, 0xe3, 0xfa,
-	0x2c, 0x5f, 0x54, 0xfc, 0x26, 0xfa, 0x36, 0x54, 0x85, 0x57, 0xca, 0xd2, 0xe1, 0x37, 0xb3, 0xd7,
-	0xc9, 0x23, 0x52, 0x31, 0x42, 0xfe, 0xbb, 0x02, 0xb4, 0x38, 0xc1, 0xd6, 0xb8, 0x3d, 0x9e, 0x2c,
-	0x7c, 0x6b, 0xd0, 0x38, 0x0c, 0x64, 0x7f, 0x52, 0xee, 0x29, 0xac, 0x22, 0x22, 0x63, 0xa6, 0x09,
-	0x60, 0xd4, 0x23, 0x28, 0xcd, 0xe5, 0x11, 0x94, 0xcf, 0xab, 0xc1, 0x92, 0x3e, 0x62, 0x25, 0xc5,
-	0x47, 0x94, 0x7f, 0x13, 0xea, 0xa1, 0x09, 0xa8, 0x86, 0x66, 0x49, 0x2b, 0x4e, 0x31, 0xbf, 0x89,
-	0x3e, 0x0a, 0xfc, 0x22, 0x46, 0xaa, 0xcb, 0x29, 0x6b, 0x89, 0xb9, 0x44, 0xf2, 0x3f, 0x48, 0x50,
-	0xe1, 0x33, 0xdf, 0x80, 0x3a, 0x57, 0x3a, 0xd4, 0x67, 0x64, 0xb3, 0x03, 0xef, 0x22, 0x4e, 0xe3,
-	0xcb, 0xd3, 0x3a, 0x97, 0xa1, 0x1a, 0xd3, 0x37, 0x0b, 0xdc, 0x2c, 0xf8, 0x9f, 0x42, 0x4a, 0x86,
-	0x7c, 0x22, 0xfa, 0x05, 0x5d, 0x82, 0xb2, 0x61, 0x0f, 0xc5, 0x25, 0x10, 0x6b, 0xc8, 0x5f, 0x4b,
-	0x34, 0x67, 0xaf, 0xe0, 0x81, 0x7d, 0x82, 0x9d, 0xb3, 0xf9, 0x93, 0x9d, 0x0f, 0x43, 0x6c, 0x9e,
-	0x33, 0xf8, 0x12, 0x03, 0xd0, 0xc3, 0xe0, 0x10, 0x8a, 0x69, 0x99, 0x9e, 0xb0, 0xde, 0xe1, 0x4c,
-	0x1a, 0x1c, 0xc6, 0x1f, 0xb0, 0xb4, 0x6d, 0x74, 0x2b, 0xb3, 0x7a, 0x3b, 0x2f, 0x25, 0x90, 0x91,
-	0x7f, 0x29, 0x41, 0x37, 0x48, 0x25, 0xb9, 0x6b, 0x67, 0xf3, 0x5e, 0x8a, 0xbc, 0x9c, 0xf8, 0xea,
-	0x57, 0x45, 0xd6, 0x9e, 0x08, 0x6d, 0xae, 0xc8, 0xc8, 0xcf, 0xd9, 0x5b, 0x34, 0x2b, 0x9d, 0xdc,
-	0xd0, 0x3c, 0x2c, 0xd3, 0x85, 0xaa, 0xc8, 0x67, 0xb0, 0xcc, 0xbd, 0x68, 0x13, 0x09, 0xbb, 0xfc,
-	0x18, 0x7b, 0x9b, 0xd1, 0x54, 0xc8, 0xeb, 0x26, 0x60, 0xf8, 0x36, 0xe1, 0x88, 0xdf, 0x26, 0x94,
-	0x62, 0xb7, 0x09, 0xbc, 0x5f, 0x36, 0x29, 0x0b, 0x24, 0x36, 0xf0, 0xaa, 0x08, 0xf6, 0x3b, 0x12,
-	0x74, 0x38, 0x16, 0x8a, 0x93, 0x84, 0x44, 0x06, 0xf6, 0xb0, 0xf6, 0x4d, 0xa7, 0x0a, 0xfe, 0x5b,
-	0x82, 0x76, 0xd8, 0xea, 0x52, 0xc3, 0xf9, 0x31, 0x94, 0x69, 0xa6, 0x85, 0xaf, 0x60, 0xaa, 0x6a,
-	0x60, 0xd0, 0x44, 0x6d, 0x53, 0x57, 0x7b, 0x5f, 0x38, 0x08, 0xbc, 0x19, 0x98, 0xfe, 0xe2, 0xf9,
-	0x4d, 0x3f, 0x77, 0x85, 0xec, 0x31, 0x99, 0x97, 0xa5, 0x28, 0x83, 0x0e, 0xf4, 0x29, 0x54, 0x58,
-	0x21, 0x06, 0xbf, 0x61, 0xbb, 0x1d, 0x9d, 0x9a, 0x17, 0x69, 0x84, 0xf2, 0xfe, 0xb4, 0x43, 0xe1,
-	0x83, 0xe4, 0x5f, 0x87, 0xe5, 0x20, 0x1a, 0x65, 0x68, 0x67, 0x65, 0x5a, 0xf9, 0x5f, 0x25, 0xb8,
-	0xb8, 0x77, 0x66, 0x0d, 0xe2, 0xec, 0xbf, 0x0c, 0x95, 0x91, 0xa1, 0x06, 0x19, 0x53, 0xde, 0xa2,
-	0x6e, 0x20, 0xc3, 0x8d, 0x35, 0x62, 0x43, 0x18, 0xcd, 0xea, 0xa2, 0x6f, 0xdf, 0x9e, 0x6a, 0xda,
-	0x6f, 0x8b, 0xf0, 0x19, 0x6b, 0xcc, 0x5a, 0xb1, 0x34, 0x54, 0x53, 0xf4, 0x52, 0x6b, 0xf5, 0x29,
-	0x00, 0x35, 0xe8, 0xfd, 0xf3, 0x18, 0x71, 0x3a, 0x62, 0x8b, 0xa8, 0xec, 0x5f, 0x14, 0xa0, 0x13,
-	0xa2, 0xd2, 0x37, 0xed, 0xdf, 0x64, 0x44, 0x65, 0xc5, 0x97, 0x14, 0x95, 0x95, 0xe6, 0xf7, 0x69,
-	0xca, 0x69, 0x3e, 0xcd, 0xbf, 0x15, 0xa0, 0x15, 0x50, 0x6d, 0xd7, 0x50, 0xad, 0x4c, 0x4e, 0xd8,
-	0x13, 0xfe, 0x7c, 0x94, 0x4e, 0xef, 0xa5, 0xc9, 0x49, 0xc6, 0x41, 0x28, 0xb1, 0x29, 0xd0, 0x35,
-	0x7a, 0xe8, 0x8e, 0xc7, 0x12, 0x5f, 0x3c, 0x86, 0x60, 0x02, 0xa9, 0x9b, 0x18, 0xdd, 0x03, 0xc4,
-	0xa5, 0xa8, 0xaf, 0x5b, 0x7d, 0x17, 0x0f, 0x6c, 0x4b, 0x63, 0xf2, 0x55, 0x56, 0xda, 0xfc, 0x4b,
-	0xcf, 0xda, 0x63, 0xfd, 0xe8, 0x63, 0x28, 0x79, 0x67, 0x23, 0xe6, 0xad, 0xb4, 0x52, 0xed, 0x7d,
-	0xb0, 0xae, 0xfd, 0xb3, 0x11, 0x56, 0x28, 0xb8, 0x5f, 0xa9, 0xe3, 0x39, 0xea, 0x09, 0x77, 0xfd,
-	0x4a, 0x4a, 0xa8, 0x87, 0x68, 0x0c, 0x9f, 0x86, 0x0b, 0xcc, 0x45, 0xe2, 0x4d, 0xc6, 0xd9, 0xbe,
-	0xd0, 0xf6, 0x3d, 0xcf, 0xa0, 0xa9, 0x3b, 0xca, 0xd9, 0x7e, 0xef, 0xbe, 0x67, 0xc8, 0xff, 0x52,
-	0x80, 0x76, 0x80, 0x59, 0xc1, 0xee, 0xd8, 0xc8, 0x16, 0xb8, 0xc9, 0xb9, 0x91, 0x69, 0xb2, 0xf6,
-	0x1d, 0xa8, 0xf3, 0x63, 0x3f, 0x07, 0xdb, 0x00, 0x1b, 0xb2, 0x35, 0x81, 0x8f, 0xcb, 0x2f, 0x89,
-	0x8f, 0x2b, 0x33, 0x64, 0x17, 0xd2, 0x89, 0x2f, 0xff, 0x4c, 0x82, 0x37, 0x12, 0x6a, 0x71, 0x22,
-	0x69, 0x27, 0xc7, 0x76, 0x5c, 0x5d, 0xc6, 0xa7, 0xe4, 0x0a, 0xfe, 0x21, 0x54, 0x1c, 0x3a, 0x3b,
-	0xbf, 0x0a, 0xba, 0x35, 0x91, 0xbb, 0xd8, 0x42, 0x14, 0x3e, 0x44, 0xfe, 0x43, 0x09, 0x56, 0x92,
-	0x4b, 0x9d, 0xc3, 0x6a, 0xaf, 0xc1, 0x02, 0x9b, 0xda, 0x17, 0xc2, 0x3b, 0x93, 0x85, 0x30, 0x20,
-	0x8e, 0xe2, 0x0f, 0x94, 0xf7, 0x60, 0xd9, 0x37, 0xee, 0x01, 0xe9, 0xb7, 0xb1, 0xa7, 0x4e, 0x88,
-	0x6c, 0x6e, 0x40, 0x9d, 0xb9, 0xc8, 0x2c, 0x62, 0x60, 0x39, 0x01, 0x38, 0x10, 0xa9, 0x34, 0xf9,
-	0x3f, 0x25, 0xb8, 0x44, 0xad, 0x63, 0xfc, 0xee, 0x25, 0xcf, 0xbd, 0x9c, 0x2c, 0x52, 0x0e, 0x3b,
-	0xaa, 0xc9, 0xeb, 0x40, 0x6a, 0x4a, 0xa4, 0x0f, 0xf5, 0x92, 0x99, 0xb6, 0xd4, 0x08, 0x38, 0xb8,
-	0xc8, 0x25, 0xd1, 0x36, 0xbd, 0xc7, 0x8d, 0xa7, 0xd8, 0x02, 0xab, 0x5c, 0x9a, 0xc5, 0x2a, 0x6f,
-	0xc1, 0x1b, 0xb1, 0x9d, 0xce, 0x71, 0xa2, 0xf2, 0x5f, 0x4a, 0xe4, 0x38, 0x22, 0xf5, 0x34, 0xb3,
-	0x7b, 0xa6, 0xd7, 0xc4, 0xa5, 0x4f, 0x5f, 0xd7, 0xe2, 0x4a, 0x44, 0x43, 0x9f, 0x41, 0xcd, 0xc2,
-	0xa7, 0xfd, 0xb0, 0xb3, 0x93, 0xc3, 0x6d, 0xaf, 0x5a, 0xf8, 0x94, 0xfe, 0x92, 0x77, 0x60, 0x25,
-	0xb1, 0xd4, 0x79, 0xf6, 0xfe, 0xf7, 0x12, 0x5c, 0xde, 0x70, 0xec, 0xd1, 0x17, 0xba, 0xe3, 0x8d,
-	0x55, 0x23, 0x7a, 0x45, 0xfe, 0x6a, 0x52, 0x57, 0x4f, 0x42, 0x6e, 0x2f, 0xe3, 0x9f, 0x7b, 0x29,
-	0x12, 0x94, 0x5c, 0x14, 0xdf, 0x74, 0xc8, 0x49, 0xfe, 0x8f, 0x62, 0xda, 0xe2, 0x39, 0xdc, 0x14,
-	0xc7, 0x23, 0x4f, 0x04, 0x91, 0x9a, 0xe9, 0x2e, 0xce, 0x9a, 0xe9, 0xce, 0x50, 0xef, 0xa5, 0x97,
-	0xa4, 0xde, 0xcf, 0x9d, 0x7a, 0x79, 0x02, 0xd1, 0x5b, 0x08, 0x6a, 0x7e, 0x67, 0xba, 0xbe, 0x58,
-	0x03, 0x08, 0x32, 0xf2, 0xbc, 0x1c, 0x32, 0xcf, 0x34, 0xa1, 0x51, 0xe4, 0xb4, 0x84, 0x29, 0xe5,
-	0xa6, 0x3c, 0x94, 0x23, 0xfe, 0x1e, 0x74, 0xd3, 0xb8, 0x74, 0x1e, 0xce, 0xff, 0x45, 0x01, 0xa0,
-	0x27, 0x2a, 0x68, 0x67, 0xb3, 0x05, 0xb7, 0x20, 0xe4, 0x6e, 0x04, 0xf2, 0x1e, 0xe6, 0x22, 0x8d,
-	0x88, 0x84, 0x08, 0x3a, 0x09, 0x4c, 0x22, 0x10, 0xd5, 0xe8, 0x3c, 0x21, 0xa9, 0x61, 0x4c, 0x11,
-	0x57, 0xbf, 0x57, 0xa0, 0xe6, 0xd8, 0xa7, 0x7d, 0x22, 0x66, 0x9a, 0x5f, 0x22, 0xec, 0xd8, 0xa7,
-	0x44, 0xf8, 0x34, 0xb4, 0x02, 0x0b, 0x9e, 0xea, 0x1e, 0x93, 0xf9, 0x2b, 0xa1, 0x2a, 0x0d, 0x0d,
-	0x5d, 0x82, 0xf2, 0xa1, 0x6e, 0x60, 0x56, 0x14, 0x50, 0x53, 0x58, 0x03, 0x7d, 0xcb, 0xaf, 0x65,
-	0xab, 0xe6, 0xae, 0xc4, 0x61, 0xe5, 0x6c, 0x5f, 0x4b, 0xb0, 0x18, 0x50, 0x8d, 0x2a, 0x20, 0xa2,
-	0xd3, 0xa8, 0x3e, 0x5b, 0xb7, 0x35, 0xa6, 0x2a, 0x5a, 0x19, 0x16, 0x81, 0x0d, 0x64, 0x5a, 0x2b,
-	0x18, 0x32, 0x29, 0x0e, 0x26, 0xfb, 0x22, 0x9b, 0xd6, 0x35, 0xbf, 0x32, 0xa5, 0xe2, 0xd8, 0xa7,
-	0x3d, 0x4d, 0x50, 0x83, 0xd5, 0xff, 0xb2, 0xa8, 0x8f, 0x50, 0x63, 0x9d, 0x96, 0x00, 0xdf, 0x82,
-	0x26, 0x76, 0x1c, 0xdb, 0xe9, 0x9b, 0xd8, 0x75, 0xd5, 0x21, 0xe6, 0x0e, 0x78, 0x83, 0x76, 0x6e,
-	0xb3, 0x3e, 0xf9, 0x8f, 0x4b, 0xd0, 0x0a, 0xb6, 0xe2, 0xdf, 0x83, 0xeb, 0x9a, 0x7f, 0x0f, 0xae,
-	0x93, 0xa3, 0x03, 0x87, 0xa9, 0x42, 0x71, 0xb8, 0x6b, 0x85, 0x8e, 0xa4, 0xd4, 0x78, 0x6f, 0x4f,
-	0x23, 0x66, 0x99, 0x08, 0x99, 0x65, 0x6b, 0x38, 0x38, 0x5c, 0xf0, 0xbb, 0xf8, 0xd9, 0x46, 0x78,
-	0xa4, 0x94, 0x83, 0x47, 0xca, 0x39, 0x78, 0xa4, 0x92, 0xc2, 0x23, 0xcb, 0x50, 0x39, 0x18, 0x0f,
-	0x8e, 0xb1, 0xc7, 0x3d, 0x36, 0xde, 0x8a, 0xf2, 0x4e, 0x35, 0xc6, 0x3b, 0x82, 0x45, 0x6a, 0x61,
-	0x16, 0xb9, 0x02, 0x35, 0x76, 0x21, 0xdb, 0xf7, 0x5c, 0x7a, 0xbb, 0x54, 0x54, 0xaa, 0xac, 0x63,
-	0xdf, 0x45, 0x9f, 0xf8, 0xee, 0x5c, 0x3d, 0x4d, 0xd8, 0xa9, 0xd6, 0x89, 0x71, 0x89, 0xef, 0xcc,
-	0xbd, 0x03, 0x8b, 0x21, 0x72, 0x50, 0x1b, 0xd1, 0xa0, 0x4b, 0x0d, 0xb9, 0xf3, 0xd4, 0x4c, 0xdc,
-	0x86, 0x56, 0x40, 0x12, 0x0a, 0xd7, 0x64, 0x51, 0x94, 0xe8, 0xa5, 0x60, 0x82, 0x93, 0x5b, 0xe7,
-	0xe3, 0x64, 0x74, 0x19, 0xaa, 0x3c, 0xfc, 0x71, 0x3b, 0x8b, 0x91, 0x6c, 0x84, 0xfc, 0x43, 0x40,
-	0xc1, 0xea, 0xe7, 0xf3, 0x16, 0x63, 0xec, 0x51, 0x88, 0xb3, 0x87, 0xfc, 0x73, 0x09, 0x96, 0xc2,
-	0xc8, 0x66, 0x35, 0xbc, 0x9f, 0x41, 0x9d, 0xdd, 0xef, 0xf5, 0x89, 0xe0, 0xf3, 0x2c, 0xcf, 0xb5,
-	0x89, 0xe7, 0xa2, 0x40, 0xf0, 0x82, 0x80, 0xb0, 0xd7, 0xa9, 0xed, 0x1c, 0xeb, 0xd6, 0xb0, 0x4f,
-	0x56, 0xe6, 0x8b, 0x5b, 0x83, 0x77, 0xee, 0x90, 0x3e, 0xf9, 0x2b, 0x09, 0xae, 0x3f, 0x1d, 0x69,
-	0xaa, 0x87, 0x43, 0x1e, 0xc8, 0xbc, 0x45, 0x89, 0x1f, 0xfb, 0x55, 0x81, 0x85, 0x7c, 0x77, 0x54,
-	0x0c, 0x5a, 0xfe, 0x1b, 0xb1, 0x16, 0x6e, 0x0e, 0xe8, 0x85, 0xe6, 0x88, 0x5e, 0x10, 0xcf, 0xbc,
-	0x96, 0x2e, 0x54, 0x4f, 0xf8, 0x74, 0xfe, 0x8b, 0x08, 0xbf, 0x1d, 0xb9, 0x07, 0x2d, 0x9e, 0xff,
-	0x1e, 0x54, 0xde, 0x86, 0xcb, 0x0a, 0x76, 0xb1, 0xa5, 0x45, 0x76, 0x33, 0x73, 0x36, 0x69, 0x04,
-	0xdd, 0xb4, 0xe9, 0xe6, 0x61, 0x56, 0xe6, 0xbb, 0xf6, 0x1d, 0x32, 0xad, 0xc7, 0x55, 0x31, 0x71,
-	0x99, 0x28, 0x1e, 0x4f, 0xfe, 0xab, 0x02, 0xac, 0x3c, 0xd2, 0x34, 0xae, 0xc5, 0xb9, 0x37, 0xf6,
-	0xaa, 0x1c, 0xe5, 0xb8, 0x23, 0x59, 0x4c, 0x3a, 0x92, 0x2f, 0x4b, 0xb3, 0x72, 0x1b, 0x63, 0x8d,
-	0x4d, 0xdf, 0x76, 0x3a, 0xac, 0x40, 0xe8, 0x21, 0xbf, 0x18, 0x23, 0x01, 0x3d, 0xb5, 0x9f, 0xd3,
-	0xfd, 0xab, 0xaa, 0x9f, 0x15, 0x93, 0x47, 0xd0, 0x49, 0x12, 0x6b, 0x4e, 0x55, 0xe2, 0x53, 0x64,
-	0x64, 0xb3, 0x0c, 0x6a, 0x83, 0xb8, 0x50, 0xb4, 0x6b, 0xd7, 0x76, 0xe5, 0xff, 0x2a, 0x40, 0x67,
-	0x4f, 0x3d, 0xc1, 0xff, 0x7f, 0x0e, 0xe8, 0xfb, 0x70, 0xc9, 0x55, 0x4f, 0x70, 0x3f, 0x14, 0x18,
-	0xf7, 0x1d, 0xfc, 0x9c, 0xbb, 0xa0, 0xef, 0xa6, 0x69, 0x92, 0xd4, 0x3a, 0x1a, 0x65, 0xc9, 0x8d,
-	0xf4, 0x2b, 0xf8, 0x39, 0x7a, 0x1b, 0x16, 0xc3, 0x85, 0x5a, 0x64, 0x69, 0x55, 0x4a, 0xf2, 0x66,
-	0xa8, 0x0e, 0xab, 0xa7, 0xc9, 0xcf, 0xe1, 0xea, 0x53, 0xcb, 0xc5, 0x5e, 0x2f, 0xa8, 0x25, 0x9a,
-	0x33, 0x84, 0xbc, 0x01, 0xf5, 0x80, 0xf0, 0x89, 0x57, 0x10, 0x9a, 0x2b, 0xdb, 0xd0, 0xdd, 0x56,
-	0x9d, 0x63, 0x3f, 0x8f, 0xbc, 0xc1, 0x6a, 0x3e, 0x5e, 0x21, 0xc2, 0x43, 0x51, 0x02, 0xa5, 0xe0,
-	0x43, 0xec, 0x60, 0x6b, 0x80, 0xb7, 0xec, 0xc1, 0x71, 0xa8, 0x34, 0x58, 0x0a, 0x97, 0x06, 0xcf,
-	0x5a, 0x6a, 0x7c, 0xf7, 0x33, 0x51, 0x96, 0xb8, 0x7f, 0x36, 0xc2, 0x68, 0x01, 0x8a, 0x3b, 0xf8,
-	0xb4, 0x7d, 0x01, 0x01, 0x54, 0x76, 0x6c, 0xc7, 0x54, 0x8d, 0xb6, 0x84, 0xea, 0xb0, 0xc0, 0x6f,
-	0x61, 0xda, 0x05, 0xd4, 0x84, 0xda, 0xba, 0x9f, 0xc9, 0x6e, 0x17, 0xef, 0xfe, 0xa9, 0x04, 0x4b,
-	0x89, 0x7b, 0x02, 0xd4, 0x02, 0x78, 0x6a, 0x0d, 0xf8, 0x05, 0x4a, 0xfb, 0x02, 0x6a, 0x40, 0xd5,
-	0xbf, 0x4e, 0x61, 0xf3, 0xed, 0xdb, 0x14, 0xba, 0x5d, 0x40, 0x6d, 0x68, 0xb0, 0x81, 0xe3, 0xc1,
-	0x00, 0xbb, 0x6e, 0xbb, 0x28, 0x7a, 0x36, 0x55, 0xdd, 0x18, 0x3b, 0xb8, 0x5d, 0x22, 0x38, 0xf7,
-	0x6d, 0x5e, 0x98, 0xdd, 0x2e, 0x23, 0x04, 0x2d, 0xbf, 0x4a, 0x9b, 0x0f, 0xaa, 0x84, 0xfa, 0xfc,
-	0x61, 0x0b, 0x77, 0x9f, 0x85, 0xb3, 0xbd, 0x74, 0x7b, 0x2b, 0x70, 0xf1, 0xa9, 0xa5, 0xe1, 0x43,
-	0xdd, 0xc2, 0x5a, 0xf0, 0xa9, 0x7d, 0x01, 0x5d, 0x84, 0xc5, 0x6d, 0xec, 0x0c, 0x71, 0xa8, 0xb3,
-	0x80, 0x96, 0xa0, 0xb9, 0xad, 0xbf, 0x08, 0x75, 0x15, 0xe5, 0x52, 0x55, 0x6a, 0x4b, 0xab, 0x5f,
-	0x5d, 0x83, 0xda, 0x86, 0xea, 0xa9, 0xeb, 0xb6, 0xed, 0x68, 0xc8, 0x00, 0x44, 0xdf, 0x31, 0x98,
-	0x23, 0xdb, 0x12, 0xaf, 0x83, 0xd0, 0xfd, 0x28, 0x17, 0xf0, 0x46, 0x12, 0x90, 0xf3, 0x50, 0xf7,
-	0xad, 0x54, 0xf8, 0x18, 0xb0, 0x7c, 0x01, 0x99, 0x14, 0xdb, 0xbe, 0x6e, 0xe2, 0x7d, 0x7d, 0x70,
-	0xec, 0x5b, 0xca, 0x0f, 0x32, 0xec, 0x62, 0x12, 0xd4, 0xc7, 0x77, 0x2b, 0x15, 0x1f, 0x7b, 0x68,
-	0xe2, 0x6b, 0x4d, 0xf9, 0x02, 0x7a, 0x0e, 0x97, 0x1e, 0xe3, 0x90, 0xd3, 0xe1, 0x23, 0x5c, 0xcd,
-	0x46, 0x98, 0x00, 0x3e, 0x27, 0xca, 0x2d, 0x28, 0x53, 0x76, 0x43, 0x69, 0x7e, 0x49, 0xf8, 0x21,
-	0x6f, 0xf7, 0x66, 0x36, 0x80, 0x98, 0xed, 0x87, 0xb0, 0x18, 0x7b, 0xfe, 0x87, 0xd2, 0xb4, 0x54,
-	0xfa, 0x43, 0xce, 0xee, 0xdd, 0x3c, 0xa0, 0x02, 0xd7, 0x10, 0x5a, 0xd1, 0xf7, 0x0f, 0x28, 0x2d,
-	0x53, 0x99, 0xfa, 0x72, 0xab, 0xfb, 0x6e, 0x0e, 0x48, 0x81, 0xc8, 0x84, 0x76, 0xfc, 0x39, 0x1a,
-	0xba, 0x3b, 0x71, 0x82, 0x28, 0xb3, 0xbd, 0x97, 0x0b, 0x56, 0xa0, 0x3b, 0xa3, 0x4c, 0x90, 0x78,
-	0xe1, 0x14, 0xe7, 0x71, 0x7f, 0x9a, 0xac, 0xa7, 0x57, 0xdd, 0x07, 0xb9, 0xe1, 0x05, 0xea, 0xdf,
-	0x66, 0x65, 0x16, 0x69, 0xaf, 0x84, 0xd0, 0x87, 0xe9, 0xd3, 0x4d, 0x78, 0xde, 0xd4, 0x5d, 0x3d,
-	0xcf, 0x10, 0xb1, 0x88, 0x1f, 0xd3, 0xfa, 0x88, 0x94, 0x77, 0x36, 0x71, 0xb9, 0xf3, 0xe7, 0xcb,
-	0x7e, 0x42, 0xd4, 0xfd, 0xf0, 0x1c, 0x23, 0xc4, 0x02, 0xec, 0xf8, 0x7b, 0x3f, 0x5f, 0x0c, 0x1f,
-	0x4c, 0xe5, 0x9a, 0xd9, 0x64, 0xf0, 0x07, 0xb0, 0x18, 0xb3, 0xdb, 0x28, 0xbf, 0x6d, 0xef, 0x4e,
-	0x72, 0xae, 0x98, 0x48, 0xc6, 0xca, 0x4d, 0x50, 0x06, 0xf7, 0xa7, 0x94, 0xa4, 0x74, 0xef, 0xe6,
-	0x01, 0x15, 0x1b, 0x71, 0xa9, 0xba, 0x8c, 0x15, 0x11, 0xa0, 0x7b, 0xe9, 0x73, 0xa4, 0x17, 0x4b,
-	0x74, 0xdf, 0xcf, 0x09, 0x2d, 0x90, 0x9e, 0xc0, 0xc5, 0x94, 0x5a, 0x0f, 0xf4, 0xfe, 0xc4, 0xc3,
-	0x8a, 0x17, 0xb9, 0x74, 0xef, 0xe7, 0x05, 0x17, 0x78, 0x7f, 0x0b, 0xd0, 0xde, 0x91, 0x7d, 0xba,
-	0x6e, 0x5b, 0x87, 0xfa, 0x70, 0xec, 0xa8, 0x2c, 0xf3, 0x9f, 0x65, 0x1b, 0x92, 0xa0, 0x19, 0x3c,
-	0x3a, 0x71, 0x84, 0x40, 0xde, 0x07, 0x78, 0x8c, 0xbd, 0x6d, 0xec, 0x39, 0x44, 0x30, 0xde, 0xce,
-	0x32, 0x7f, 0x1c, 0xc0, 0x47, 0xf5, 0xce, 0x54, 0xb8, 0x90, 0x29, 0x6a, 0x6f, 0xab, 0xd6, 0x58,
-	0x35, 0x42, 0xc5, 0xea, 0xf7, 0x52, 0x87, 0xc7, 0xc1, 0x32, 0x0e, 0x32, 0x13, 0x5a, 0xa0, 0x3c,
-	0x15, 0xa6, 0x3d, 0x74, 0xb5, 0x34, 0xd9, 0xb4, 0x27, 0xeb, 0x16, 0xe2, 0x6a, 0x6f, 0x02, 0xbc,
-	0x40, 0xfc, 0xa5, 0x44, 0xcb, 0x85, 0x62, 0x00, 0xcf, 0x74, 0xef, 0x68, 0xd7, 0x50, 0x2d, 0x37,
-	0xcf, 0x12, 0x28, 0xe0, 0x39, 0x96, 0xc0, 0xe1, 0xc5, 0x12, 0x34, 0x68, 0x46, 0x6e, 0x7c, 0x50,
-	0x5a, 0x75, 0x77, 0xda, 0xed, 0x57, 0xf7, 0xce, 0x74, 0x40, 0x81, 0xe5, 0x08, 0x9a, 0xbe, 0x28,
-	0x31, 0xe2, 0xbe, 0x9b, 0xb5, 0xd2, 0x00, 0x26, 0x43, 0x13, 0xa4, 0x83, 0x86, 0x35, 0x41, 0x32,
-	0xa1, 0x8d, 0xf2, 0x5d, 0x84, 0x4c, 0xd2, 0x04, 0xd9, 0x59, 0x72, 0xa6, 0xea, 0x62, 0x97, 0x47,
-	0xe9, 0x7a, 0x34, 0xf5, 0x2e, 0x2c, 0x55, 0xd5, 0x65, 0xdc, 0x45, 0xc9, 0x17, 0xd0, 0x33, 0xa8,
-	0xf0, 0x7f, 0xaf, 0x78, 0x6b, 0x72, 0x12, 0x8a, 0xcf, 0x7e, 0x7b, 0x0a, 0x94, 0x98, 0xf8, 0x18,
-	0x56, 0x32, 0x52, 0x50, 0xa9, 0x26, 0x78, 0x72, 0xba, 0x6a, 0x9a, 0x71, 0x10, 0xc8, 0x12, 0x39,
-	0xa6, 0x09, 0xc8, 0xb2, 0xf2, 0x51, 0xd3, 0x90, 0xa9, 0x80, 0x92, 0xef, 0x51, 0x53, 0x79, 0x22,
-	0xf3, 0xd9, 0x6a, 0x0e, 0x14, 0xc9, 0x27, 0xa5, 0xa9, 0x28, 0x32, 0x5f, 0x9e, 0x4e, 0x43, 0xd1,
-	0x87, 0xa5, 0x44, 0x12, 0x02, 0xbd, 0x97, 0x61, 0xae, 0xd3, 0x52, 0x15, 0xd3, 0x10, 0x0c, 0xe1,
-	0x8d, 0xd4, 0x80, 0x3b, 0xd5, 0xfd, 0x98, 0x14, 0x9a, 0x4f, 0x43, 0x34, 0x80, 0x8b, 0x29, 0x61,
-	0x76, 0xaa, 0xe1, 0xcc, 0x0e, 0xc7, 0xa7, 0x21, 0x39, 0x82, 0xee, 0x9a, 0x63, 0xab, 0xda, 0x40,
-	0x75, 0xbd, 0x47, 0x86, 0x87, 0x1d, 0x12, 0x0b, 0xfa, 0xfe, 0x5f, 0x9c, 0x6e, 0xbc, 0x41, 0xe1,
-	0x02, 0xa8, 0x9c, 0x98, 0x0e, 0xa0, 0x4e, 0x59, 0x92, 0xfd, 0x3f, 0x02, 0x4a, 0xb7, 0x75, 0x21,
-	0x88, 0x0c, 0x05, 0x9a, 0x06, 0xe8, 0x0b, 0xe7, 0xea, 0xd7, 0x35, 0xa8, 0xfa, 0x25, 0xf6, 0xdf,
-	0x70, 0x28, 0xfa, 0x1a, 0x62, 0xc3, 0x1f, 0xc0, 0x62, 0xec, 0xb9, 0x6b, 0xaa, 0x3e, 0x4d, 0x7f,
-	0x12, 0x3b, 0xed, 0xb8, 0x9e, 0xf1, 0x3f, 0x63, 0x12, 0x6e, 0xe2, 0x3b, 0x59, 0xf1, 0x65, 0xdc,
-	0x43, 0x9c, 0x32, 0xf1, 0xff, 0x6d, 0xbf, 0x6c, 0x07, 0x20, 0xe4, 0x91, 0x4d, 0x2e, 0x44, 0x23,
-	0x4e, 0xc6, 0x34, 0x6a, 0x99, 0xa9, 0x4e, 0xd7, 0xbb, 0x79, 0x6a, 0x7e, 0xb2, 0xcd, 0x66, 0xb6,
-	0xab, 0xf5, 0x14, 0x1a, 0xe1, 0x12, 0x51, 0x94, 0xfa, 0xd7, 0x3f, 0xc9, 0x1a, 0xd2, 0x69, 0xbb,
-	0xd8, 0x3e, 0xa7, 0x35, 0x9e, 0x32, 0x9d, 0x4b, 0xcc, 0x48, 0xfc, 0xee, 0x21, 0xc3, 0x8c, 0x64,
-	0xdc, 0x78, 0xa4, 0x7a, 0x2f, 0xd9, 0x17, 0x1a, 0x2c, 0xcd, 0x10, 0x4f, 0xa8, 0xa7, 0xa6, 0x19,
-	0x32, 0xae, 0x28, 0x52, 0xd3, 0x0c, 0x59, 0x19, 0x7a, 0xf9, 0xc2, 0xda, 0x47, 0xdf, 0xff, 0x70,
-	0xa8, 0x7b, 0x47, 0xe3, 0x03, 0xb2, 0xfb, 0x07, 0x6c, 0xe8, 0xfb, 0xba, 0xcd, 0x7f, 0x3d, 0xf0,
-	0xd9, 0xfd, 0x01, 0x9d, 0xed, 0x01, 0x99, 0x6d, 0x74, 0x70, 0x50, 0xa1, 0xad, 0x8f, 0xfe, 0x27,
-	0x00, 0x00, 0xff, 0xff, 0x36, 0x4c, 0xc5, 0x32, 0x4e, 0x4e, 0x00, 0x00,
+	// 4534 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x3c, 0x4b, 0x8f, 0x1c, 0x49,
+	0x5a, 0xce, 0x7a, 0x75, 0xd5, 0x57, 0x8f, 0xae, 0x0e, 0x7b, 0xda, 0xe5, 0xf2, 0x3b, 0x67, 0xec,
+	0xb1, 0x3d, 0x7e, 0xcc, 0xf4, 0x30, 0x62, 0xc0, 0x3b, 0x33, 0x72, 0xbb, 0xa7, 0x3d, 0x05, 0x6e,
+	0xaf, 0x37, 0xbb, 0x3d, 0x96, 0x76, 0x11, 0xa9, 0xec, 0xca, 0xe8, 0xea, 0xdc, 0xae, 0xca, 0x2c,
+	0x67, 0x64, 0x75, 0xbb, 0x97, 0xc3, 0x8e, 0x40, 0x42, 0x02, 0x21, 0x16, 0x21, 0x21, 0xe0, 0x80,
+	0x40, 0x9c, 0x96, 0x45, 0x20, 0xa4, 0x15, 0x17, 0x2e, 0x5c, 0x11, 0x1c, 0x16, 0x84, 0xc4, 0x0f,
+	0xe0, 0xc0, 0xe3, 0x86, 0xc4, 0x95, 0x03, 0x8a, 0x47, 0x46, 0xbe, 0xab, 0xb2, 0xab, 0xec, 0x31,
+	0x62, 0x6f, 0x15, 0x5f, 0x7e, 0x11, 0x5f, 0x3c, 0xbe, 0xf7, 0x17, 0x51, 0xd0, 0x36, 0x0d, 0xcf,
+	0xd0, 0xfb, 0x8e, 0xe3, 0x9a, 0x77, 0xc7, 0xae, 0xe3, 0x39, 0x68, 0x65, 0x64, 0x0d, 0x0f, 0x27,
+	0x84, 0xb7, 0xee, 0xd2, 0xcf, 0xdd, 0x46, 0xdf, 0x19, 0x8d, 0x1c, 0x9b, 0x83, 0xba, 0x2d, 0xcb,
+	0xf6, 0xb0, 0x6b, 0x1b, 0x43, 0xd1, 0x6e, 0x84, 0x3b, 0x74, 0x1b, 0xa4, 0xbf, 0x8f, 0x47, 0x06,
+	0x6f, 0xa9, 0x4b, 0x50, 0xfe, 0x7c, 0x34, 0xf6, 0x8e, 0xd5, 0x3f, 0x50, 0xa0, 0xb1, 0x39, 0x9c,
+	0x90, 0x7d, 0x0d, 0xbf, 0x98, 0x60, 0xe2, 0xa1, 0xf7, 0xa1, 0xb4, 0x6b, 0x10, 0xdc, 0x51, 0xae,
+	0x28, 0x37, 0xea, 0x6b, 0x17, 0xee, 0x46, 0xa8, 0x0a, 0x7a, 0x5b, 0x64, 0xb0, 0x6e, 0x10, 0xac,
+	0x31, 0x4c, 0x84, 0xa0, 0x64, 0xee, 0xf6, 0x36, 0x3a, 0x85, 0x2b, 0xca, 0x8d, 0xa2, 0xc6, 0x7e,
+	0xa3, 0x4b, 0x00, 0x04, 0x0f, 0x46, 0xd8, 0xf6, 0x7a, 0x1b, 0xa4, 0x53, 0xbc, 0x52, 0xbc, 0x51,
+	0xd4, 0x42, 0x10, 0xa4, 0x42, 0xa3, 0xef, 0x0c, 0x87, 0xb8, 0xef, 0x59, 0x8e, 0xdd, 0xdb, 0xe8,
+	0x94, 0x58, 0xdf, 0x08, 0x4c, 0xfd, 0x37, 0x05, 0x9a, 0x62, 0x6a, 0x64, 0xec, 0xd8, 0x04, 0xa3,
+	0x0f, 0xa1, 0x42, 0x3c, 0xc3, 0x9b, 0x10, 0x31, 0xbb, 0xf3, 0xa9, 0xb3, 0xdb, 0x66, 0x28, 0x9a,
+	0x40, 0x4d, 0x9d, 0x5e, 0x9c, 0x7c, 0x31, 0x49, 0x3e, 0xb6, 0x84, 0x52, 0x62, 0x09, 0x37, 0x60,
+	0x79, 0x8f, 0xce, 0x6e, 0x3b, 0x40, 0x2a, 0x33, 0xa4, 0x38, 0x98, 0x8e, 0xe4, 0x59, 0x23, 0xfc,
+	0xcd, 0xbd, 0x6d, 0x6c, 0x0c, 0x3b, 0x15, 0x46, 0x2b, 0x04, 0x51, 0xff, 0x49, 0x81, 0xb6, 0x44,
+	0xf7, 0xcf, 0xe1, 0x0c, 0x94, 0xfb, 0xce, 0xc4, 0xf6, 0xd8, 0x52, 0x9b, 0x1a, 0x6f, 0xa0, 0xab,
+	0xd0, 0xe8, 0xef, 0x1b, 0xb6, 0x8d, 0x87, 0xba, 0x6d, 0x8c, 0x30, 0x5b, 0x54, 0x4d, 0xab, 0x0b,
+	0xd8, 0x13, 0x63, 0x84, 0x73, 0xad, 0xed, 0x0a, 0xd4, 0xc7, 0x86, 0xeb, 0x59, 0x91, 0xdd, 0x0f,
+	0x83, 0x50, 0x17, 0xaa, 0x16, 0xe9, 0x8d, 0xc6, 0x8e, 0xeb, 0x75, 0xca, 0x57, 0x94, 0x1b, 0x55,
+	0x4d, 0xb6, 0x29, 0x05, 0x8b, 0xfd, 0xda, 0x31, 0xc8, 0x41, 0x6f, 0x43, 0xac, 0x28, 0x02, 0x53,
+	0xff, 0x44, 0x81, 0xd5, 0x07, 0x84, 0x58, 0x03, 0x3b, 0xb1, 0xb2, 0x55, 0xa8, 0xd8, 0x8e, 0x89,
+	0x7b, 0x1b, 0x6c, 0x69, 0x45, 0x4d, 0xb4, 0xd0, 0x79, 0xa8, 0x8d, 0x31, 0x76, 0x75, 0xd7, 0x19,
+	0xfa, 0x0b, 0xab, 0x52, 0x80, 0xe6, 0x0c, 0x31, 0xfa, 0x16, 0xac, 0x90, 0xd8, 0x40, 0x9c, 0xaf,
+	0xea, 0x6b, 0x6f, 0xdf, 0x4d, 0x48, 0xc6, 0xdd, 0x38, 0x51, 0x2d, 0xd9, 0x5b, 0xfd, 0xaa, 0x00,
+	0xa7, 0x25, 0x1e, 0x9f, 0x2b, 0xfd, 0x4d, 0x77, 0x9e, 0xe0, 0x81, 0x9c, 0x1e, 0x6f, 0xe4, 0xd9,
+	0x79, 0x79, 0x64, 0xc5, 0xf0, 0x91, 0xe5, 0x60, 0xf5, 0xf8, 0x79, 0x94, 0x93, 0xe7, 0x71, 0x19,
+	0xea, 0xf8, 0xe5, 0xd8, 0x72, 0xb1, 0x4e, 0x19, 0x87, 0x6d, 0x79, 0x49, 0x03, 0x0e, 0xda, 0xb1,
+	0x46, 0x61, 0xd9, 0x58, 0xca, 0x2d, 0x1b, 0xea, 0x9f, 0x2a, 0x70, 0x36, 0x71, 0x4a, 0x42, 0xd8,
+	0x34, 0x68, 0xb3, 0x95, 0x07, 0x3b, 0x43, 0xc5, 0x8e, 0x6e, 0xf8, 0xf5, 0x69, 0x1b, 0x1e, 0xa0,
+	0x6b, 0x89, 0xfe, 0xa1, 0x49, 0x16, 0xf2, 0x4f, 0xf2, 0x00, 0xce, 0x3e, 0xc2, 0x9e, 0x20, 0x40,
+	0xbf, 0x61, 0x32, 0xbf, 0xb2, 0x8a, 0x4a, 0x75, 0x21, 0x2e, 0xd5, 0xea, 0x5f, 0x15, 0xa0, 0x1d,
+	0x26, 0xd5, 0xb3, 0xf7, 0x1c, 0x74, 0x01, 0x6a, 0x12, 0x45, 0x70, 0x45, 0x00, 0x40, 0x3f, 0x0b,
+	0x65, 0x3a, 0x53, 0xce, 0x12, 0xad, 0xb5, 0xab, 0xe9, 0x6b, 0x0a, 0x8d, 0xa9, 0x71, 0x7c, 0xd4,
+	0x83, 0x16, 0xf1, 0x0c, 0xd7, 0xd3, 0xc7, 0x0e, 0x61, 0xe7, 0xcc, 0x18, 0xa7, 0xbe, 0xa6, 0x46,
+	0x47, 0x90, 0x6a, 0x7d, 0x8b, 0x0c, 0x9e, 0x0a, 0x4c, 0xad, 0xc9, 0x7a, 0xfa, 0x4d, 0xf4, 0x39,
+	0x34, 0xb0, 0x6d, 0x06, 0x03, 0x95, 0x72, 0x0f, 0x54, 0xc7, 0xb6, 0x29, 0x87, 0x09, 0xce, 0xa7,
+	0x9c, 0xff, 0x7c, 0x7e, 0x4b, 0x81, 0x4e, 0xf2, 0x80, 0x16, 0x51, 0xd9, 0xf7, 0x79, 0x27, 0xcc,
+	0x0f, 0x68, 0xaa, 0x84, 0xcb, 0x43, 0xd2, 0x44, 0x17, 0xf5, 0xf7, 0x14, 0x78, 0x2b, 0x98, 0x0e,
+	0xfb, 0xf4, 0xba, 0xb8, 0x05, 0xdd, 0x82, 0xb6, 0x65, 0xf7, 0x87, 0x13, 0x13, 0x3f, 0xb3, 0xbf,
+	0xc0, 0xc6, 0xd0, 0xdb, 0x3f, 0x66, 0x67, 0x58, 0xd5, 0x12, 0x70, 0xf5, 0xd7, 0x14, 0x58, 0x8d,
+	0xcf, 0x6b, 0x91, 0x4d, 0xfa, 0x19, 0x28, 0x5b, 0xf6, 0x9e, 0xe3, 0xef, 0xd1, 0xa5, 0x29, 0x42,
+	0x49, 0x69, 0x71, 0x64, 0x75, 0x04, 0xe7, 0x1f, 0x61, 0xaf, 0x67, 0x13, 0xec, 0x7a, 0xeb, 0x96,
+	0x3d, 0x74, 0x06, 0x4f, 0x0d, 0x6f, 0x7f, 0x01, 0x81, 0x8a, 0xc8, 0x46, 0x21, 0x26, 0x1b, 0xea,
+	0x0f, 0x15, 0xb8, 0x90, 0x4e, 0x4f, 0x2c, 0xbd, 0x0b, 0xd5, 0x3d, 0x0b, 0x0f, 0xcd, 0xde, 0x06,
+	0xd7, 0x2e, 0x45, 0x4d, 0xb6, 0xa9, 0x60, 0x8d, 0x29, 0xb2, 0x58, 0xe1, 0xd5, 0x0c, 0x6e, 0xde,
+	0xf6, 0x5c, 0xcb, 0x1e, 0x3c, 0xb6, 0x88, 0xa7, 0x71, 0xfc, 0xd0, 0x7e, 0x16, 0xf3, 0xb3, 0xf1,
+	0x5f, 0x28, 0x70, 0xe9, 0x11, 0xf6, 0x1e, 0x4a, 0xbd, 0x4c, 0xbf, 0x5b, 0xc4, 0xb3, 0xfa, 0xe4,
+	0xd5, 0xfa, 0x46, 0x79, 0x0c, 0xf4, 0x55, 0x68, 0xec, 0xb9, 0x98, 0xec, 0xdb, 0x98, 0x10, 0x7d,
+	0x44, 0x7c, 0x0b, 0x2d, 0x61, 0x5b, 0x44, 0xfd, 0x81, 0x02, 0x97, 0x33, 0xe7, 0x2b, 0x76, 0x57,
+	0xa8, 0x26, 0x5f, 0x71, 0xa7, 0xab, 0xa6, 0x5f, 0xc4, 0xc7, 0x5f, 0x1a, 0xc3, 0x09, 0x7e, 0x6a,
+	0x58, 0x2e, 0x57, 0x4d, 0x73, 0x2a, 0xea, 0x7f, 0x54, 0xe0, 0xe2, 0x23, 0xec, 0x3d, 0xf5, 0xcd,
+	0xd6, 0x9b, 0xdc, 0x40, 0x15, 0x1a, 0x21, 0xf3, 0xe9, 0xfb, 0x6f, 0x11, 0x58, 0x62, 0x93, 0xcb,
+	0xc9, 0x4d, 0xfe, 0x6d, 0xce, 0x14, 0xa9, 0x4b, 0x7a, 0x23, 0x7b, 0x7c, 0x89, 0xc9, 0x53, 0x48,
+	0xb0, 0x1f, 0x72, 0x07, 0x44, 0xec, 0xb0, 0xfa, 0x47, 0x0a, 0x9c, 0x7b, 0xd0, 0x7f, 0x31, 0xb1,
+	0x5c, 0x2c, 0x90, 0x1e, 0x3b, 0xfd, 0x83, 0xf9, 0xf7, 0x3f, 0x70, 0xd6, 0x0a, 0x11, 0x67, 0x6d,
+	0x96, 0x83, 0xbf, 0x0a, 0x15, 0x8f, 0x7b, 0x87, 0x9c, 0x75, 0x45, 0x8b, 0xcd, 0x4f, 0xc3, 0x43,
+	0x6c, 0x90, 0xff, 0x9b, 0xf3, 0xfb, 0x41, 0x09, 0x1a, 0x5f, 0x0a, 0xa7, 0x8e, 0xd9, 0xfe, 0x38,
+	0xb3, 0x29, 0xe9, 0xee, 0x5b, 0xc8, 0x0f, 0x4c, 0x73, 0x0d, 0x1f, 0x41, 0x93, 0x60, 0x7c, 0x30,
+	0x8f, 0xa5, 0x6f, 0xd0, 0x8e, 0x7e, 0x0b, 0x3d, 0x86, 0x95, 0x89, 0xcd, 0x02, 0x0c, 0x6c, 0x8a,
+	0x0d, 0xe4, 0xcc, 0x3d, 0xdb, 0x02, 0x24, 0x3b, 0xa2, 0x2f, 0x60, 0x39, 0x06, 0xea, 0x94, 0x73,
+	0x8d, 0x15, 0xef, 0x86, 0x7a, 0xd0, 0x36, 0x5d, 0x67, 0x3c, 0xc6, 0xa6, 0x4e, 0xfc, 0xa1, 0x2a,
+	0xf9, 0x86, 0x12, 0xfd, 0xe4, 0x50, 0xef, 0xc3, 0xe9, 0xf8, 0x4c, 0x7b, 0x26, 0x75, 0x6b, 0xe9,
+	0x19, 0xa6, 0x7d, 0x42, 0xb7, 0x61, 0x25, 0x89, 0x5f, 0x65, 0xf8, 0xc9, 0x0f, 0xe8, 0x0e, 0xa0,
+	0xd8, 0x54, 0x29, 0x7a, 0x8d, 0xa3, 0x47, 0x27, 0xd3, 0x33, 0x89, 0xfa, 0x1b, 0x0a, 0xac, 0x3e,
+	0x37, 0xbc, 0xfe, 0xfe, 0xc6, 0x48, 0xc8, 0xda, 0x02, 0xea, 0xec, 0x13, 0xa8, 0x1d, 0x0a, 0xbe,
+	0xf0, 0xcd, 0xda, 0xe5, 0x94, 0xfd, 0x09, 0x73, 0xa0, 0x16, 0xf4, 0xa0, 0x51, 0xd5, 0x99, 0xcd,
+	0x50, 0x74, 0xf9, 0x06, 0x14, 0xeb, 0x8c, 0xb0, 0x58, 0x7d, 0x09, 0x20, 0x26, 0xb7, 0x45, 0x06,
+	0x73, 0xcc, 0xeb, 0x63, 0x58, 0x12, 0xa3, 0x09, 0xb5, 0x38, 0x8b, 0x7f, 0x7c, 0x74, 0xf5, 0x47,
+	0x15, 0xa8, 0x87, 0x3e, 0xa0, 0x16, 0x14, 0xa4, 0xbc, 0x16, 0x52, 0x56, 0x57, 0x98, 0x1d, 0x88,
+	0x15, 0x93, 0x81, 0xd8, 0x35, 0x68, 0x59, 0xcc, 0x9b, 0xd1, 0xc5, 0xa9, 0x30, 0x05, 0x52, 0xd3,
+	0x9a, 0x1c, 0x2a, 0x58, 0x04, 0x5d, 0x82, 0xba, 0x3d, 0x19, 0xe9, 0xce, 0x9e, 0xee, 0x3a, 0x47,
+	0xbe, 0x69, 0xa9, 0xd9, 0x93, 0xd1, 0x37, 0xf7, 0x34, 0xe7, 0x88, 0x04, 0x41, 0x43, 0xe5, 0x84,
+	0x41, 0xc3, 0x25, 0xa8, 0x8f, 0x8c, 0x97, 0x74, 0x54, 0xdd, 0x9e, 0x8c, 0x58, 0xb0, 0x57, 0xd4,
+	0x6a, 0x23, 0xe3, 0xa5, 0xe6, 0x1c, 0x3d, 0x99, 0x8c, 0xd0, 0x0d, 0x68, 0x0f, 0x0d, 0xe2, 0xe9,
+	0xe1, 0x68, 0xb1, 0xca, 0xa2, 0xc5, 0x16, 0x85, 0x7f, 0x1e, 0x44, 0x8c, 0xc9, 0xf0, 0xa3, 0xb6,
+	0x40, 0xf8, 0x61, 0x8e, 0x86, 0xc1, 0x40, 0x90, 0x3f, 0xfc, 0x30, 0x47, 0x43, 0x39, 0xcc, 0xc7,
+	0xb0, 0xb4, 0xcb, 0x7c, 0x44, 0xd2, 0xa9, 0x67, 0xea, 0x8e, 0x4d, 0xea, 0x1e, 0x72, 0x57, 0x52,
+	0xf3, 0xd1, 0xd1, 0x37, 0xa0, 0xc6, 0x8c, 0x2a, 0xeb, 0xdb, 0xc8, 0xd5, 0x37, 0xe8, 0x40, 0x7b,
+	0x9b, 0x78, 0xe8, 0x19, 0xac, 0x77, 0x33, 0x5f, 0x6f, 0xd9, 0x81, 0xea, 0xab, 0xbe, 0x8b, 0x0d,
+	0x0f, 0x9b, 0xeb, 0xc7, 0x0f, 0x9d, 0xd1, 0xd8, 0x60, 0xcc, 0xd4, 0x69, 0xb1, 0x38, 0x20, 0xed,
+	0x13, 0xba, 0x0e, 0xad, 0xbe, 0x6c, 0x6d, 0xba, 0xce, 0xa8, 0xb3, 0xcc, 0xe4, 0x28, 0x06, 0x45,
+	0x17, 0x01, 0x7c, 0x4d, 0x65, 0x78, 0x9d, 0x36, 0x3b, 0xc5, 0x9a, 0x80, 0x3c, 0x60, 0xc9, 0x20,
+	0x8b, 0xe8, 0x3c, 0xed, 0x62, 0xd9, 0x83, 0xce, 0x0a, 0xa3, 0x58, 0xf7, 0xf3, 0x34, 0x96, 0x3d,
+	0x40, 0x67, 0x61, 0xc9, 0x22, 0xfa, 0x9e, 0x71, 0x80, 0x3b, 0x88, 0x7d, 0xad, 0x58, 0x64, 0xd3,
+	0x38, 0xc0, 0xea, 0xf7, 0xe1, 0x4c, 0xc0, 0x5d, 0xa1, 0x93, 0x4c, 0x32, 0x85, 0x32, 0x2f, 0x53,
+	0x4c, 0x8f, 0x0c, 0x7e, 0x52, 0x82, 0xd5, 0x6d, 0xe3, 0x10, 0xbf, 0xfe, 0x20, 0x24, 0x97, 0x5a,
+	0x7b, 0x0c, 0x2b, 0x2c, 0xee, 0x58, 0x0b, 0xcd, 0xa7, 0x53, 0xca, 0xc5, 0x0a, 0xc9, 0x8e, 0xe8,
+	0x33, 0xea, 0x10, 0xe0, 0xfe, 0xc1, 0x53, 0xc7, 0x0a, 0x6c, 0xea, 0xc5, 0x94, 0x71, 0x1e, 0x4a,
+	0x2c, 0x2d, 0xdc, 0x03, 0x3d, 0x85, 0xe5, 0xe8, 0x31, 0xf8, 0xd6, 0xf4, 0xdd, 0xa9, 0xa1, 0x70,
+	0xb0, 0xfb, 0x5a, 0x2b, 0x72, 0x18, 0x04, 0x75, 0x60, 0x49, 0x98, 0x42, 0xa6, 0x33, 0xaa, 0x9a,
+	0xdf, 0x44, 0x4f, 0xe1, 0x34, 0x5f, 0xc1, 0xb6, 0x10, 0x08, 0xbe, 0xf8, 0x6a, 0xae, 0xc5, 0xa7,
+	0x75, 0x8d, 0xca, 0x53, 0xed, 0xa4, 0xf2, 0xd4, 0x81, 0x25, 0xc1, 0xe3, 0x4c, 0x8f, 0x54, 0x35,
+	0xbf, 0x49, 0x8f, 0x39, 0xe0, 0xf6, 0x3a, 0xfb, 0x16, 0x00, 0xd4, 0xdf, 0x54, 0x00, 0x82, 0xfd,
+	0x9c, 0x91, 0xb4, 0xf9, 0x14, 0xaa, 0x92, 0xc3, 0x0b, 0xb9, 0x39, 0x5c, 0xf6, 0x89, 0xeb, 0xf7,
+	0x62, 0x4c, 0xbf, 0xab, 0xff, 0xa0, 0x40, 0x63, 0x83, 0x2e, 0xe9, 0xb1, 0x33, 0x60, 0xd6, 0xe8,
+	0x1a, 0xb4, 0x5c, 0xdc, 0x77, 0x5c, 0x53, 0xc7, 0xb6, 0xe7, 0x5a, 0x98, 0xc7, 0xfa, 0x25, 0xad,
+	0xc9, 0xa1, 0x9f, 0x73, 0x20, 0x45, 0xa3, 0x2a, 0x9b, 0x78, 0xc6, 0x68, 0xac, 0xef, 0x51, 0xd5,
+	0x50, 0xe0, 0x68, 0x12, 0xca, 0x34, 0xc3, 0x55, 0x68, 0x04, 0x68, 0x9e, 0xc3, 0xe8, 0x97, 0xb4,
+	0xba, 0x84, 0xed, 0x38, 0xe8, 0x1d, 0x68, 0xb1, 0x3d, 0xd5, 0x87, 0xce, 0x40, 0xa7, 0x71, 0xb1,
+	0x30, 0x54, 0x0d, 0x53, 0x4c, 0x8b, 0x9e, 0x55, 0x14, 0x8b, 0x58, 0xdf, 0xc3, 0xc2, 0x54, 0x49,
+	0xac, 0x6d, 0xeb, 0x7b, 0x58, 0xfd, 0x7b, 0x05, 0x9a, 0x1b, 0x86, 0x67, 0x3c, 0x71, 0x4c, 0xbc,
+	0x33, 0xa7, 0x61, 0xcf, 0x91, 0x40, 0xbd, 0x00, 0x35, 0xb9, 0x02, 0xb1, 0xa4, 0x00, 0x80, 0x36,
+	0xa1, 0xe5, 0xbb, 0x96, 0x3a, 0x8f, 0xb8, 0x4a, 0x99, 0x0e, 0x54, 0xc8, 0x72, 0x12, 0xad, 0xe9,
+	0x77, 0x63, 0x4d, 0x75, 0x13, 0x1a, 0xe1, 0xcf, 0x94, 0xea, 0x76, 0x9c, 0x51, 0x24, 0x80, 0x72,
+	0xe3, 0x93, 0xc9, 0x88, 0x9e, 0xa9, 0x50, 0x2c, 0x7e, 0x93, 0x26, 0x74, 0x9a, 0xc2, 0xdc, 0x6f,
+	0xcb, 0x52, 0x03, 0x5b, 0x9a, 0xc2, 0x96, 0xc6, 0x7e, 0xa3, 0x9f, 0x8f, 0x66, 0x07, 0xdf, 0x49,
+	0x55, 0x02, 0x6c, 0x10, 0xe6, 0x64, 0x46, 0x6c, 0x7d, 0x0e, 0xc5, 0xa5, 0x7e, 0x45, 0x19, 0x4d,
+	0x1c, 0x0d, 0x63, 0xb4, 0x0e, 0x2c, 0x19, 0xa6, 0xe9, 0x62, 0x42, 0xc4, 0x3c, 0xfc, 0x26, 0xfd,
+	0x72, 0x88, 0x5d, 0xe2, 0xb3, 0x7c, 0x51, 0xf3, 0x9b, 0xe8, 0x1b, 0x50, 0x95, 0x5e, 0x29, 0x4f,
+	0xaa, 0x5f, 0xc9, 0x9e, 0xa7, 0x88, 0x48, 0x65, 0x0f, 0xf5, 0xaf, 0x0b, 0xd0, 0x12, 0x1b, 0xb6,
+	0x2e, 0xec, 0xf1, 0x74, 0xe1, 0x5b, 0x87, 0xc6, 0x5e, 0x20, 0xfb, 0xd3, 0x32, 0x58, 0x61, 0x15,
+	0x11, 0xe9, 0x33, 0x4b, 0x00, 0xa3, 0x1e, 0x41, 0x69, 0x21, 0x8f, 0xa0, 0x7c, 0x52, 0x0d, 0x96,
+	0xf4, 0x11, 0x2b, 0x29, 0x3e, 0xa2, 0xfa, 0x4b, 0x50, 0x0f, 0x0d, 0xc0, 0x34, 0x34, 0x4f, 0x7d,
+	0x89, 0x1d, 0xf3, 0x9b, 0xe8, 0xc3, 0xc0, 0x2f, 0xe2, 0x5b, 0x75, 0x2e, 0x65, 0x2e, 0x31, 0x97,
+	0x48, 0xfd, 0x5b, 0x05, 0x2a, 0x62, 0x64, 0x5a, 0x3c, 0xe0, 0xfa, 0x85, 0xf9, 0x8c, 0x7c, 0x74,
+	0x10, 0x20, 0xea, 0x34, 0xbe, 0x3a, 0xad, 0x73, 0x0e, 0xaa, 0x31, 0x7d, 0xb3, 0x24, 0xcc, 0x82,
+	0xff, 0x29, 0xa4, 0x64, 0x96, 0x86, 0x5c, 0xbf, 0xd0, 0xca, 0xc9, 0xd0, 0x19, 0xc8, 0x52, 0x12,
+	0x6f, 0xa8, 0x7f, 0xa7, 0xb0, 0xcc, 0xbf, 0x86, 0xfb, 0xce, 0x21, 0x76, 0x8f, 0x17, 0x4f, 0x99,
+	0xde, 0x0f, 0xb1, 0x79, 0xce, 0xe0, 0x4b, 0x76, 0x40, 0xf7, 0x83, 0x43, 0x28, 0xa6, 0x65, 0x7a,
+	0xc2, 0x7a, 0x47, 0x30, 0x69, 0x70, 0x18, 0xbf, 0xc3, 0x93, 0xbf, 0xd1, 0xa5, 0xcc, 0xeb, 0xed,
+	0xbc, 0x92, 0x40, 0x46, 0xfd, 0x89, 0x02, 0xdd, 0x20, 0x95, 0x44, 0xd6, 0x8f, 0x17, 0x2d, 0xad,
+	0xbc, 0x9a, 0xf8, 0xea, 0xe7, 0x64, 0xee, 0x9f, 0x0a, 0x6d, 0xae, 0xc8, 0x48, 0x74, 0x50, 0x6d,
+	0x96, 0xdb, 0x4e, 0x2e, 0x68, 0x11, 0x96, 0xe9, 0x42, 0x55, 0xe6, 0x33, 0x78, 0xfe, 0x5f, 0xb6,
+	0xa9, 0x84, 0x9d, 0x7b, 0x84, 0xbd, 0xcd, 0x68, 0x2a, 0xe4, 0x4d, 0x6f, 0x60, 0xb8, 0x26, 0xb1,
+	0x2f, 0x6a, 0x12, 0xa5, 0x58, 0x4d, 0x42, 0xc0, 0xd5, 0x11, 0x74, 0xd3, 0x16, 0xf0, 0xba, 0x36,
+	0xec, 0xd7, 0x15, 0xe8, 0x08, 0x2a, 0x8c, 0x26, 0x0d, 0x89, 0x86, 0xd8, 0xc3, 0xe6, 0xd7, 0x9d,
+	0x2a, 0xf8, 0x1f, 0x05, 0xda, 0x61, 0xab, 0x4b, 0xbf, 0xa2, 0x8f, 0xa0, 0xcc, 0x32, 0x2d, 0x62,
+	0x06, 0x33, 0x55, 0x03, 0xc7, 0xa6, 0x6a, 0x9b, 0xb9, 0xda, 0x3b, 0xd2, 0x41, 0x10, 0xcd, 0xc0,
+	0xf4, 0x17, 0x4f, 0x6e, 0xfa, 0x85, 0x2b, 0xe4, 0x4c, 0xe8, 0xb8, 0x3c, 0x45, 0x19, 0x00, 0xd0,
+	0x27, 0x50, 0xe1, 0xd7, 0x39, 0x44, 0x9d, 0xee, 0x5a, 0x74, 0x68, 0xfe, 0xed, 0x6e, 0xa8, 0x34,
+	0xc0, 0x00, 0x9a, 0xe8, 0xa4, 0xfe, 0x02, 0xac, 0x06, 0xd1, 0x28, 0x27, 0x3b, 0x2f, 0xd3, 0xaa,
+	0xff, 0xa2, 0xc0, 0xe9, 0xed, 0x63, 0xbb, 0x1f, 0x67, 0xff, 0x55, 0xa8, 0x8c, 0x87, 0x46, 0x90,
+	0x31, 0x15, 0x2d, 0xe6, 0x06, 0x72, 0xda, 0xd8, 0xa4, 0x36, 0x84, 0xef, 0x59, 0x5d, 0xc2, 0x76,
+	0x9c, 0x99, 0xa6, 0xfd, 0x9a, 0x0c, 0x9f, 0xb1, 0xc9, 0xad, 0x15, 0x4f, 0x43, 0x35, 0x25, 0x94,
+	0x59, 0xab, 0x4f, 0x00, 0x98, 0x41, 0xd7, 0x4f, 0x62, 0xc4, 0x59, 0x8f, 0xc7, 0x54, 0x65, 0xff,
+	0xb8, 0x00, 0x9d, 0xd0, 0x2e, 0x7d, 0xdd, 0xfe, 0x4d, 0x46, 0x54, 0x56, 0x7c, 0x45, 0x51, 0x59,
+	0x69, 0x71, 0x9f, 0xa6, 0x9c, 0xe6, 0xd3, 0xfc, 0x6b, 0x01, 0x5a, 0xc1, 0xae, 0x3d, 0x1d, 0x1a,
+	0x76, 0x26, 0x27, 0x6c, 0x4b, 0x7f, 0x3e, 0xba, 0x4f, 0xef, 0xa5, 0xc9, 0x49, 0xc6, 0x41, 0x68,
+	0xb1, 0x21, 0x68, 0xca, 0x84, 0x07, 0xce, 0x2c, 0xf1, 0x25, 0x62, 0x08, 0x2e, 0x90, 0x34, 0xe7,
+	0x75, 0x1b, 0x90, 0x90, 0x22, 0xdd, 0xb2, 0x75, 0x82, 0xfb, 0x8e, 0x6d, 0x72, 0xf9, 0x2a, 0x6b,
+	0x6d, 0xf1, 0xa5, 0x67, 0x6f, 0x73, 0x38, 0xfa, 0x08, 0x4a, 0xde, 0xf1, 0x98, 0x7b, 0x2b, 0xad,
+	0xb5, 0xab, 0x53, 0xe7, 0xb5, 0x73, 0x3c, 0xc6, 0x1a, 0x43, 0xf7, 0xef, 0xfb, 0x78, 0xae, 0x71,
+	0x28, 0x5c, 0xbf, 0x92, 0x16, 0x82, 0x50, 0x8d, 0xe1, 0xef, 0xe1, 0x12, 0x77, 0x91, 0x44, 0x93,
+	0x73, 0xb6, 0x2f, 0xb4, 0xba, 0xe7, 0x0d, 0x59, 0xea, 0x8e, 0x71, 0xb6, 0x0f, 0xdd, 0xf1, 0x86,
+	0xea, 0x3f, 0x17, 0xa0, 0x1d, 0x50, 0xd6, 0x30, 0x99, 0x0c, 0xb3, 0x05, 0x6e, 0x7a, 0x6e, 0x64,
+	0x96, 0xac, 0x7d, 0x06, 0x75, 0x71, 0xec, 0x27, 0x60, 0x1b, 0xe0, 0x5d, 0x1e, 0x4f, 0xe1, 0xe3,
+	0xf2, 0x2b, 0xe2, 0xe3, 0xca, 0x1c, 0xd9, 0x85, 0xf4, 0xcd, 0xa7, 0xb5, 0xea, 0xb7, 0x12, 0x6a,
+	0x71, 0xea, 0xd6, 0x4e, 0x8f, 0xed, 0x84, 0xba, 0x8c, 0x0f, 0x29, 0x14, 0xfc, 0x7d, 0xa8, 0xb8,
+	0x6c, 0x74, 0x51, 0x0a, 0x7a, 0x7b, 0x2a, 0x77, 0xf1, 0x89, 0x68, 0xa2, 0x8b, 0xfa, 0xbb, 0x0a,
+	0x9c, 0x4d, 0x4e, 0x75, 0x01, 0xab, 0xbd, 0x0e, 0x4b, 0x7c, 0x68, 0x5f, 0x08, 0x6f, 0x4c, 0x17,
+	0xc2, 0x60, 0x73, 0x34, 0xbf, 0xa3, 0xba, 0x0d, 0xab, 0xbe, 0x71, 0x0f, 0xb6, 0x7e, 0x0b, 0x7b,
+	0xc6, 0x94, 0xc8, 0xe6, 0x32, 0xd4, 0xb9, 0x8b, 0xcc, 0x23, 0x06, 0x9e, 0x13, 0x80, 0x5d, 0x99,
+	0x4a, 0x53, 0xff, 0x43, 0x81, 0x33, 0xcc, 0x3a, 0xc6, 0x6b, 0x2f, 0x79, 0xea, 0x72, 0x2a, 0x34,
+	0x42, 0xe9, 0x05, 0xbe, 0xb4, 0x9a, 0x16, 0x81, 0xa1, 0x5e, 0x32, 0xd3, 0x96, 0x1a, 0x01, 0x07,
+	0x85, 0x5c, 0x1a, 0x6d, 0xb3, 0x3a, 0x6e, 0x3c, 0xc5, 0x16, 0x58, 0xe5, 0xd2, 0x3c, 0x56, 0xf9,
+	0x31, 0xbc, 0x15, 0x5b, 0xe9, 0x02, 0x27, 0xaa, 0xfe, 0x99, 0x42, 0x8f, 0x23, 0x72, 0x2b, 0x67,
+	0x7e, 0xcf, 0xf4, 0xa2, 0x2c, 0xfa, 0xe8, 0x96, 0x19, 0x57, 0x22, 0x26, 0xfa, 0x14, 0x6a, 0x36,
+	0x3e, 0xd2, 0xc3, 0xce, 0x4e, 0x0e, 0xb7, 0xbd, 0x6a, 0xe3, 0x23, 0xf6, 0x4b, 0x7d, 0x02, 0x67,
+	0x13, 0x53, 0x5d, 0x64, 0xed, 0x7f, 0xa3, 0xc0, 0xb9, 0x0d, 0xd7, 0x19, 0x7f, 0x69, 0xb9, 0xde,
+	0xc4, 0x18, 0x46, 0x4b, 0xe4, 0xaf, 0x27, 0x75, 0xf5, 0x45, 0xc8, 0xed, 0xe5, 0xfc, 0x73, 0x3b,
+	0x45, 0x82, 0x92, 0x93, 0x12, 0x8b, 0x0e, 0x39, 0xc9, 0xff, 0x5e, 0x84, 0x73, 0x99, 0x78, 0x33,
+	0x1c, 0x8f, 0x3c, 0x11, 0x44, 0x6a, 0xa6, 0xbb, 0x38, 0x6f, 0xa6, 0x3b, 0x43, 0xbd, 0x97, 0x5e,
+	0x91, 0x7a, 0x3f, 0x71, 0xea, 0xe5, 0x0b, 0x88, 0x56, 0x21, 0x3a, 0x95, 0xdc, 0xc9, 0xdd, 0x68,
+	0x47, 0xb4, 0x0e, 0x10, 0x64, 0xe4, 0x3b, 0x4b, 0xb9, 0x87, 0x09, 0xf5, 0xa2, 0xa7, 0x25, 0x4d,
+	0xa9, 0x30, 0xe5, 0x01, 0x40, 0xfd, 0x16, 0x74, 0xd3, 0xb8, 0x74, 0x11, 0xce, 0xff, 0x71, 0x01,
+	0xa0, 0x27, 0xef, 0xe1, 0xce, 0x67, 0x0b, 0xde, 0x86, 0x90, 0xbb, 0x11, 0xc8, 0x7b, 0x98, 0x8b,
+	0x4c, 0x2a, 0x12, 0x32, 0xe8, 0xa4, 0x38, 0x89, 0x40, 0xd4, 0x64, 0xe3, 0x84, 0xa4, 0x86, 0x33,
+	0x45, 0x5c, 0xfd, 0x9e, 0x87, 0x1a, 0x2d, 0x65, 0x52, 0x31, 0x33, 0xfd, 0x8b, 0xc6, 0xae, 0x73,
+	0x44, 0x85, 0xcf, 0xa4, 0xd5, 0x2b, 0x7a, 0x2d, 0x83, 0x8e, 0x5f, 0x09, 0xdd, 0xd2, 0x30, 0x69,
+	0xbe, 0x68, 0xcf, 0x1a, 0x62, 0x7e, 0x29, 0xa0, 0xa6, 0xf1, 0x06, 0xad, 0xa9, 0xf2, 0x1b, 0x71,
+	0xd5, 0xdc, 0x37, 0x71, 0x18, 0x3e, 0x4d, 0x34, 0x2d, 0x07, 0xbb, 0xc6, 0x14, 0x10, 0xd5, 0x69,
+	0x4c, 0x9f, 0x3d, 0x74, 0x4c, 0xae, 0x2a, 0x5a, 0x19, 0x16, 0x81, 0x77, 0xe4, 0x5a, 0x2b, 0xe8,
+	0x32, 0x2d, 0x0e, 0xa6, 0xeb, 0xa2, 0x8b, 0xb6, 0x4c, 0xff, 0x66, 0x4a, 0xc5, 0x75, 0x8e, 0x7a,
+	0xa6, 0xdc, 0x0d, 0x7e, 0x8b, 0x98, 0x47, 0x7d, 0x74, 0x37, 0x1e, 0xd2, 0x36, 0xdd, 0x4f, 0xec,
+	0xba, 0x8e, 0xab, 0x8f, 0x30, 0x21, 0xc6, 0x00, 0x0b, 0x07, 0xbc, 0xc1, 0x80, 0x5b, 0x1c, 0xa6,
+	0xfe, 0x7e, 0x09, 0x5a, 0xc1, 0x52, 0xfc, 0x3a, 0xb8, 0x65, 0xfa, 0x75, 0x70, 0x8b, 0x1e, 0x1d,
+	0xb8, 0x5c, 0x15, 0xca, 0xc3, 0x5d, 0x2f, 0x74, 0x14, 0xad, 0x26, 0xa0, 0x3d, 0x93, 0x9a, 0x65,
+	0x2a, 0x64, 0xb6, 0x63, 0xe2, 0xe0, 0x70, 0xc1, 0x07, 0x89, 0xb3, 0x8d, 0xf0, 0x48, 0x29, 0x07,
+	0x8f, 0x94, 0x73, 0xf0, 0x48, 0x25, 0x85, 0x47, 0x56, 0xa1, 0xb2, 0x3b, 0xe9, 0x1f, 0x60, 0x4f,
+	0x78, 0x6c, 0xa2, 0x15, 0xe5, 0x9d, 0x6a, 0x8c, 0x77, 0x24, 0x8b, 0xd4, 0xc2, 0x2c, 0x72, 0x1e,
+	0x6a, 0xbc, 0x20, 0xab, 0x7b, 0x84, 0x55, 0x97, 0x8a, 0x5a, 0x95, 0x03, 0x76, 0x08, 0xfa, 0xd8,
+	0x77, 0xe7, 0xea, 0x69, 0xc2, 0xce, 0xb4, 0x4e, 0x8c, 0x4b, 0x7c, 0x67, 0xee, 0x5d, 0x58, 0x0e,
+	0x6d, 0x07, 0xb3, 0x11, 0x0d, 0x36, 0xd5, 0x90, 0x3b, 0xcf, 0xcc, 0xc4, 0x35, 0x68, 0x05, 0x5b,
+	0xc2, 0xf0, 0x9a, 0x3c, 0x8a, 0x92, 0x50, 0x86, 0x26, 0x39, 0xb9, 0x75, 0x32, 0x4e, 0xa6, 0x39,
+	0x56, 0x11, 0xfe, 0x90, 0xce, 0x72, 0x24, 0x1b, 0xa1, 0x7e, 0x17, 0x50, 0x30, 0xfb, 0xc5, 0xbc,
+	0xc5, 0x18, 0x7b, 0x14, 0xe2, 0xec, 0xa1, 0xfe, 0x48, 0x81, 0x95, 0x30, 0xb1, 0x79, 0x0d, 0xef,
+	0xa7, 0x50, 0xe7, 0xf5, 0x3d, 0x9d, 0x0a, 0xbe, 0xc8, 0xf2, 0x5c, 0x9c, 0x7a, 0x2e, 0x1a, 0x04,
+	0xef, 0x10, 0x28, 0x7b, 0x1d, 0x39, 0xee, 0x81, 0x65, 0x0f, 0x74, 0x3a, 0x33, 0x5f, 0xdc, 0x1a,
+	0x02, 0x48, 0x6b, 0x26, 0xec, 0x82, 0xcf, 0xa5, 0x67, 0x63, 0xd3, 0xf0, 0x70, 0xc8, 0x03, 0x59,
+	0xf4, 0xde, 0xe2, 0x47, 0xfe, 0xad, 0xc0, 0x42, 0xbe, 0x1a, 0x15, 0xc7, 0x56, 0xff, 0x52, 0xce,
+	0x45, 0x98, 0x03, 0x56, 0xd0, 0x1c, 0xb3, 0x02, 0xf1, 0xdc, 0x73, 0xe9, 0x42, 0xf5, 0x50, 0x0c,
+	0xe7, 0xbf, 0xab, 0xf0, 0xdb, 0x91, 0x3a, 0x68, 0xf1, 0xe4, 0x75, 0x50, 0x75, 0x8b, 0x5e, 0xe7,
+	0x23, 0xd8, 0x36, 0x23, 0xab, 0x99, 0x3b, 0x9b, 0x34, 0x86, 0x6e, 0xda, 0x70, 0x8b, 0x30, 0x2b,
+	0xf7, 0x5d, 0x75, 0x17, 0x13, 0x9e, 0x28, 0x2c, 0x0a, 0x97, 0x89, 0xd1, 0xf1, 0xd4, 0x3f, 0x2f,
+	0xc0, 0xd9, 0x07, 0xa6, 0x29, 0xb4, 0xb8, 0xf0, 0xc6, 0x5e, 0x97, 0xa3, 0x1c, 0x77, 0x24, 0x8b,
+	0x49, 0x47, 0xf2, 0x55, 0x69, 0x56, 0x61, 0x63, 0x68, 0xbd, 0x47, 0xd8, 0x4e, 0x97, 0x5f, 0x10,
+	0xba, 0x2f, 0x0a, 0x63, 0x34, 0xa0, 0xef, 0x2c, 0xe5, 0xf2, 0xaf, 0xaa, 0x7e, 0x56, 0x4c, 0x1d,
+	0x43, 0x27, 0xb9, 0x59, 0x0b, 0xaa, 0x12, 0x7f, 0x47, 0xc6, 0x0e, 0xcf, 0xa0, 0x36, 0x34, 0x10,
+	0xa0, 0xa7, 0x0e, 0x51, 0xff, 0xbb, 0x00, 0x1d, 0x7a, 0x4f, 0xe4, 0xa7, 0xe7, 0x80, 0xbe, 0x0d,
+	0x67, 0x88, 0x71, 0x88, 0xf5, 0x50, 0x60, 0xac, 0xbb, 0xf8, 0x85, 0x70, 0x41, 0x6f, 0xa6, 0x69,
+	0x92, 0xd4, 0x7b, 0x34, 0xda, 0x0a, 0x89, 0xc0, 0x35, 0xfc, 0x02, 0x5d, 0x87, 0xe5, 0xf0, 0x45,
+	0x2d, 0xdd, 0xe2, 0x86, 0xb3, 0xa1, 0x35, 0x43, 0xf7, 0xb0, 0x7a, 0xa6, 0xfa, 0x02, 0x2e, 0x3c,
+	0xb3, 0x09, 0xf6, 0x7a, 0xc1, 0x5d, 0xa2, 0x05, 0x43, 0xc8, 0xcb, 0x50, 0x0f, 0x36, 0x3e, 0xf1,
+	0x96, 0xc2, 0x24, 0xaa, 0x03, 0xdd, 0x2d, 0xc3, 0x3d, 0x10, 0x27, 0x4c, 0x36, 0xf8, 0x9d, 0x8f,
+	0xd7, 0x48, 0x70, 0x4f, 0x5e, 0x81, 0xd2, 0xf0, 0x1e, 0x76, 0xb1, 0xdd, 0xc7, 0xf4, 0x2e, 0x72,
+	0xe8, 0x6a, 0xb0, 0x12, 0xbe, 0x1a, 0x3c, 0xef, 0x55, 0x63, 0xf5, 0xbf, 0x0a, 0x80, 0x36, 0xe9,
+	0x9d, 0xf2, 0x6d, 0x6c, 0xb8, 0xfd, 0xfd, 0xd7, 0x5b, 0x1f, 0x8a, 0xdf, 0x7b, 0x2f, 0xa6, 0xdc,
+	0x7b, 0xbf, 0x08, 0x30, 0x3e, 0xd0, 0xfd, 0x8c, 0x8c, 0x28, 0x2e, 0x8c, 0x0f, 0x36, 0x39, 0x80,
+	0xfa, 0x28, 0x87, 0xb8, 0xef, 0x39, 0xae, 0x44, 0xe1, 0xdc, 0xdb, 0xe4, 0xd0, 0xcd, 0x20, 0x75,
+	0x33, 0xc2, 0x9e, 0x6b, 0xf5, 0x75, 0x96, 0x23, 0xe5, 0x15, 0x6e, 0xe0, 0x20, 0x9a, 0x0c, 0xa5,
+	0x7e, 0xa7, 0xfd, 0x42, 0x5c, 0x50, 0x2c, 0xd8, 0x2f, 0xe8, 0xed, 0x08, 0xcf, 0x19, 0x1f, 0x88,
+	0x38, 0x88, 0xfd, 0x46, 0x6d, 0x28, 0x9a, 0xd6, 0x88, 0x5d, 0x3c, 0x2c, 0x6a, 0xf4, 0x27, 0xbf,
+	0xa4, 0x40, 0xe9, 0x50, 0xff, 0xac, 0x78, 0xa3, 0xa0, 0xf9, 0x4d, 0x74, 0x13, 0xda, 0x3c, 0x81,
+	0xaa, 0x07, 0x97, 0x44, 0xea, 0x2c, 0xb9, 0xba, 0xcc, 0xe1, 0x3b, 0x3e, 0x58, 0xfd, 0x63, 0x05,
+	0x4e, 0x47, 0xb6, 0x7c, 0x11, 0x15, 0xf5, 0x59, 0x3c, 0x37, 0x96, 0x9e, 0xd7, 0x91, 0xa4, 0x26,
+	0x43, 0x8f, 0xa6, 0x88, 0x64, 0x62, 0x8c, 0x2e, 0x3c, 0x94, 0x5c, 0x65, 0xbf, 0x6f, 0x7d, 0x2a,
+	0xef, 0xaa, 0xb2, 0xbd, 0x5a, 0x82, 0xe2, 0x13, 0x7c, 0xd4, 0x3e, 0x85, 0x00, 0x2a, 0x4f, 0x1c,
+	0x77, 0x64, 0x0c, 0xdb, 0x0a, 0xaa, 0xc3, 0x92, 0x28, 0xcd, 0xb5, 0x0b, 0xa8, 0x09, 0xb5, 0x87,
+	0x7e, 0x79, 0xa3, 0x5d, 0xbc, 0xf5, 0x87, 0x0a, 0xac, 0x24, 0x8a, 0x47, 0xa8, 0x05, 0xf0, 0xcc,
+	0xee, 0x8b, 0xaa, 0x5a, 0xfb, 0x14, 0x6a, 0x40, 0xd5, 0xaf, 0xb1, 0xf1, 0xf1, 0x76, 0x1c, 0x86,
+	0xdd, 0x2e, 0xa0, 0x36, 0x34, 0x78, 0xc7, 0x49, 0xbf, 0x8f, 0x09, 0x69, 0x17, 0x25, 0x64, 0xd3,
+	0xb0, 0x86, 0x13, 0x17, 0xb7, 0x4b, 0x94, 0xe6, 0x8e, 0x23, 0x6e, 0xeb, 0xb7, 0xcb, 0x08, 0x41,
+	0x4b, 0x34, 0xfc, 0x4e, 0x95, 0x10, 0xcc, 0xef, 0xb6, 0x74, 0xeb, 0x79, 0xb8, 0x04, 0xc0, 0x96,
+	0x77, 0x16, 0x4e, 0x3f, 0xb3, 0x4d, 0xbc, 0x67, 0xd9, 0xd8, 0x0c, 0x3e, 0xb5, 0x4f, 0xa1, 0xd3,
+	0xb0, 0xbc, 0x85, 0xdd, 0x01, 0x0e, 0x01, 0x0b, 0x68, 0x05, 0x9a, 0x5b, 0xd6, 0xcb, 0x10, 0xa8,
+	0xa8, 0x96, 0xaa, 0x4a, 0x5b, 0x59, 0xfb, 0xcf, 0x8b, 0x50, 0xa3, 0x5b, 0xfb, 0xd0, 0x71, 0x5c,
+	0x13, 0x0d, 0x01, 0xb1, 0xf7, 0x2f, 0xa3, 0xb1, 0x63, 0xcb, 0x87, 0x67, 0xe8, 0x6e, 0xf4, 0x70,
+	0x44, 0x23, 0x89, 0x28, 0xc4, 0xb0, 0xfb, 0x4e, 0x2a, 0x7e, 0x0c, 0x59, 0x3d, 0x85, 0x46, 0x8c,
+	0x1a, 0x65, 0xb1, 0x1d, 0xab, 0x7f, 0xe0, 0xbb, 0x4f, 0xef, 0x67, 0x38, 0x4b, 0x49, 0x54, 0x9f,
+	0xde, 0xdb, 0xa9, 0xf4, 0xf8, 0x1b, 0x26, 0x9f, 0x4f, 0xd5, 0x53, 0xe8, 0x05, 0x9c, 0x79, 0x84,
+	0x43, 0x9e, 0xa8, 0x4f, 0x70, 0x2d, 0x9b, 0x60, 0x02, 0xf9, 0x84, 0x24, 0x1f, 0x43, 0x99, 0xb1,
+	0x1b, 0x4a, 0x73, 0x56, 0xc3, 0x6f, 0xc4, 0xbb, 0x57, 0xb2, 0x11, 0xe4, 0x68, 0xdf, 0x85, 0xe5,
+	0xd8, 0xcb, 0x52, 0x94, 0x66, 0xba, 0xd2, 0xdf, 0x08, 0x77, 0x6f, 0xe5, 0x41, 0x95, 0xb4, 0x06,
+	0xd0, 0x8a, 0x3e, 0x8a, 0x41, 0x69, 0xe9, 0xeb, 0xd4, 0x47, 0x81, 0xdd, 0x9b, 0x39, 0x30, 0x25,
+	0xa1, 0x11, 0xb4, 0xe3, 0x2f, 0x1d, 0xd1, 0xad, 0xa9, 0x03, 0x44, 0x99, 0xed, 0xbd, 0x5c, 0xb8,
+	0x92, 0xdc, 0x31, 0x9c, 0x49, 0x7b, 0x3c, 0x87, 0xee, 0xa6, 0x0f, 0x93, 0xf5, 0xaa, 0xaf, 0x7b,
+	0x2f, 0x37, 0xbe, 0x24, 0xfd, 0xab, 0xfc, 0xee, 0x4d, 0xda, 0xeb, 0x32, 0xf4, 0x41, 0xfa, 0x70,
+	0x53, 0x5e, 0xce, 0x75, 0xd7, 0x4e, 0xd2, 0x45, 0x4e, 0xe2, 0xfb, 0xb0, 0x9a, 0xfe, 0xf8, 0x0a,
+	0xbd, 0x9f, 0x3e, 0x5e, 0xf6, 0xd3, 0xb3, 0xee, 0x07, 0x27, 0xe8, 0x21, 0x27, 0xe0, 0xc4, 0x9f,
+	0x92, 0xfa, 0x62, 0x78, 0x6f, 0x26, 0xd7, 0xcc, 0x27, 0x83, 0xdf, 0x81, 0xe5, 0x98, 0x33, 0x87,
+	0xf2, 0x3b, 0x7c, 0xdd, 0x69, 0xe6, 0x8c, 0x8b, 0x64, 0xec, 0x0e, 0x12, 0xca, 0xe0, 0xfe, 0x94,
+	0x7b, 0x4a, 0xdd, 0x5b, 0x79, 0x50, 0xe5, 0x42, 0x08, 0x53, 0x97, 0xb1, 0x9b, 0x25, 0xe8, 0x76,
+	0xfa, 0x18, 0xe9, 0x37, 0x68, 0xba, 0x77, 0x72, 0x62, 0x4b, 0xa2, 0x87, 0x70, 0x3a, 0xe5, 0x02,
+	0x10, 0xba, 0x33, 0xf5, 0xb0, 0xe2, 0x37, 0x9f, 0xba, 0x77, 0xf3, 0xa2, 0x4b, 0xba, 0xbf, 0x02,
+	0x68, 0x7b, 0x9f, 0xa6, 0xe9, 0xec, 0x3d, 0x6b, 0x30, 0x71, 0x0d, 0x5e, 0x0e, 0xca, 0xb2, 0x0d,
+	0x49, 0xd4, 0x0c, 0x1e, 0x9d, 0xda, 0x43, 0x12, 0xd7, 0x01, 0x1e, 0x61, 0x6f, 0x8b, 0xf9, 0x5d,
+	0x04, 0x5d, 0xcf, 0x32, 0x7f, 0x02, 0xc1, 0x27, 0xf5, 0xee, 0x4c, 0xbc, 0x90, 0x29, 0x6a, 0x6f,
+	0x19, 0x36, 0xcd, 0x50, 0x07, 0x2f, 0x18, 0x6e, 0xa7, 0x76, 0x8f, 0xa3, 0x65, 0x1c, 0x64, 0x26,
+	0xb6, 0x24, 0x79, 0x24, 0x4d, 0x7b, 0xa8, 0xde, 0x38, 0xdd, 0xb4, 0x27, 0x2f, 0xb3, 0x74, 0xef,
+	0xe5, 0xc6, 0x97, 0x84, 0xbf, 0x52, 0xe0, 0x7c, 0x12, 0xe1, 0xb9, 0xe5, 0xed, 0xd3, 0xab, 0x0c,
+	0x24, 0xcf, 0x14, 0x18, 0xe2, 0x09, 0xa6, 0x20, 0xf0, 0xe5, 0x14, 0x4c, 0x68, 0x46, 0xca, 0x80,
+	0x28, 0xed, 0xca, 0x7f, 0x5a, 0x49, 0xb4, 0x7b, 0x63, 0x36, 0xa2, 0xa4, 0xb2, 0x0f, 0x4d, 0x5f,
+	0x94, 0xf8, 0xe6, 0xde, 0xcc, 0x9a, 0x69, 0x80, 0x93, 0xa1, 0x09, 0xd2, 0x51, 0xc3, 0x9a, 0x20,
+	0x59, 0xe5, 0x40, 0xf9, 0xaa, 0x63, 0xd3, 0x34, 0x41, 0x76, 0xe9, 0x84, 0xab, 0xba, 0x58, 0x45,
+	0x31, 0x5d, 0x8f, 0xa6, 0x16, 0x48, 0xbb, 0xb7, 0xf2, 0xa0, 0x4a, 0x5a, 0xcf, 0xa1, 0x22, 0xfe,
+	0x18, 0xe5, 0x9d, 0xe9, 0x99, 0x49, 0x31, 0xfa, 0xb5, 0x19, 0x58, 0x72, 0xe0, 0x03, 0x38, 0x9b,
+	0x91, 0x97, 0x4c, 0x35, 0xc1, 0xd3, 0x73, 0x98, 0xb3, 0x8c, 0x83, 0x24, 0x96, 0x48, 0x3c, 0x4e,
+	0x21, 0x96, 0x95, 0xa4, 0x9c, 0x45, 0xcc, 0x00, 0x94, 0x7c, 0xa4, 0x9c, 0xca, 0x13, 0x99, 0x6f,
+	0x99, 0x73, 0x90, 0x48, 0xbe, 0x33, 0x4e, 0x25, 0x91, 0xf9, 0x1c, 0x79, 0x16, 0x09, 0x1d, 0x56,
+	0x12, 0x99, 0x29, 0xf4, 0x5e, 0x86, 0xb9, 0x4e, 0xcb, 0x5f, 0xcd, 0x22, 0x30, 0x80, 0xb7, 0x52,
+	0xb3, 0x30, 0xa9, 0xee, 0xc7, 0xb4, 0x7c, 0xcd, 0x2c, 0x42, 0x7d, 0x38, 0x9d, 0x92, 0x7b, 0x49,
+	0x35, 0x9c, 0xd9, 0x39, 0x9a, 0x59, 0x44, 0xf6, 0xa1, 0xbb, 0xee, 0x3a, 0x86, 0xd9, 0x37, 0x88,
+	0xf7, 0x60, 0xe8, 0x61, 0x17, 0x9b, 0x81, 0xff, 0x17, 0xdf, 0x37, 0xd1, 0x60, 0x78, 0x01, 0x56,
+	0x4e, 0x4a, 0xbb, 0x50, 0x67, 0x2c, 0xc9, 0xff, 0x7a, 0x03, 0xa5, 0xdb, 0xba, 0x10, 0x46, 0x86,
+	0x02, 0x4d, 0x43, 0x94, 0xc2, 0xf9, 0xcb, 0x50, 0x0f, 0x65, 0x18, 0x50, 0x9a, 0x50, 0x27, 0x93,
+	0x3e, 0xdd, 0xeb, 0xb3, 0xd0, 0xfc, 0xf1, 0xd7, 0x7e, 0x08, 0x50, 0xf5, 0xdf, 0x75, 0x7c, 0xcd,
+	0xa1, 0xee, 0x1b, 0x88, 0x3d, 0xbf, 0x03, 0xcb, 0xb1, 0x37, 0xd6, 0xa9, 0xfa, 0x3a, 0xfd, 0x1d,
+	0xf6, 0x2c, 0x76, 0x78, 0x2e, 0xfe, 0x47, 0x4c, 0xba, 0xa1, 0xef, 0x66, 0xc5, 0xaf, 0x71, 0x0f,
+	0x74, 0xc6, 0xc0, 0xff, 0xbf, 0xfd, 0xbe, 0x27, 0x00, 0x21, 0x8f, 0x6f, 0xfa, 0xed, 0x47, 0xea,
+	0xc4, 0xcc, 0xda, 0xad, 0x51, 0xaa, 0x53, 0x77, 0x33, 0xcf, 0x45, 0xb3, 0x6c, 0xb3, 0x9c, 0xed,
+	0xca, 0x3d, 0x83, 0x46, 0xf8, 0x5e, 0x32, 0x4a, 0xfd, 0xd7, 0xaa, 0xe4, 0xc5, 0xe5, 0x59, 0xab,
+	0xd8, 0x3a, 0xa1, 0xb5, 0x9f, 0x31, 0x1c, 0x01, 0x94, 0x2c, 0x78, 0x65, 0x98, 0xa9, 0x8c, 0x32,
+	0x5b, 0xf7, 0x4e, 0x4e, 0xec, 0x70, 0x1a, 0x23, 0x5e, 0xc5, 0x49, 0x4d, 0x63, 0x64, 0xd4, 0xc5,
+	0xba, 0xef, 0xe5, 0xc2, 0xfd, 0xba, 0x54, 0xe5, 0xfa, 0x87, 0xdf, 0xfe, 0x60, 0x60, 0x79, 0xfb,
+	0x93, 0x5d, 0xba, 0xbb, 0xf7, 0x78, 0xaf, 0x3b, 0x96, 0x23, 0x7e, 0xdd, 0xf3, 0xc5, 0xe9, 0x1e,
+	0x1b, 0xe8, 0x1e, 0x1d, 0x68, 0xbc, 0xbb, 0x5b, 0x61, 0xad, 0x0f, 0xff, 0x77, 0x00, 0xe0, 0x1a,
+	0xa7, 0x9f, 0x69, 0x51, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -5211,6 +5419,7 @@ type DataCoordClient interface {
 	MarkSegmentsDropped(ctx context.Context, in *MarkSegmentsDroppedRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	BroadcastAlteredCollection(ctx context.Context, in *milvuspb.AlterCollectionRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	CheckHealth(ctx context.Context, in *milvuspb.CheckHealthRequest, opts ...grpc.CallOption) (*milvuspb.CheckHealthResponse, error)
+	FreshSearch(ctx context.Context, in *FreshSearchRequest, opts ...grpc.CallOption) (*FreshSearchResponse, error)
 }
 
 type dataCoordClient struct {
@@ -5527,6 +5736,15 @@ func (c *dataCoordClient) CheckHealth(ctx context.Context, in *milvuspb.CheckHea
 	return out, nil
 }
 
+func (c *dataCoordClient) FreshSearch(ctx context.Context, in *FreshSearchRequest, opts ...grpc.CallOption) (*FreshSearchResponse, error) {
+	out := new(FreshSearchResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.data.DataCoord/FreshSearch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataCoordServer is the server API for DataCoord service.
 type DataCoordServer interface {
 	GetComponentStates(context.Context, *milvuspb.GetComponentStatesRequest) (*milvuspb.ComponentStates, error)
@@ -5565,6 +5783,7 @@ type DataCoordServer interface {
 	MarkSegmentsDropped(context.Context, *MarkSegmentsDroppedRequest) (*commonpb.Status, error)
 	BroadcastAlteredCollection(context.Context, *milvuspb.AlterCollectionRequest) (*commonpb.Status, error)
 	CheckHealth(context.Context, *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error)
+	FreshSearch(context.Context, *FreshSearchRequest) (*FreshSearchResponse, error)
 }
 
 // UnimplementedDataCoordServer can be embedded to have forward compatible implementations.
@@ -5673,6 +5892,9 @@ func (*UnimplementedDataCoordServer) BroadcastAlteredCollection(ctx context.Cont
 func (*UnimplementedDataCoordServer) CheckHealth(ctx context.Context, req *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckHealth not implemented")
 }
+func (*UnimplementedDataCoordServer) FreshSearch(ctx context.Context, req *FreshSearchRequest) (*FreshSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreshSearch not implemented")
+}
 
 func RegisterDataCoordServer(s *grpc.Server, srv DataCoordServer) {
 	s.RegisterService(&_DataCoord_serviceDesc, srv)
@@ -6290,6 +6512,24 @@ func _DataCoord_CheckHealth_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataCoord_FreshSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreshSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataCoordServer).FreshSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.data.DataCoord/FreshSearch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataCoordServer).FreshSearch(ctx, req.(*FreshSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DataCoord_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "milvus.proto.data.DataCoord",
 	HandlerType: (*DataCoordServer)(nil),
@@ -6430,6 +6670,10 @@ var _DataCoord_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckHealth",
 			Handler:    _DataCoord_CheckHealth_Handler,
 		},
+		{
+			MethodName: "FreshSearch",
+			Handler:    _DataCoord_FreshSearch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "data_coord.proto",
@@ -6453,6 +6697,7 @@ type DataNodeClient interface {
 	Import(ctx context.Context, in *ImportTaskRequest, opts ...grpc.CallOption) (*commonpb.Status, error)
 	ResendSegmentStats(ctx context.Context, in *ResendSegmentStatsRequest, opts ...grpc.CallOption) (*ResendSegmentStatsResponse, error)
 	AddImportSegment(ctx context.Context, in *AddImportSegmentRequest, opts ...grpc.CallOption) (*AddImportSegmentResponse, error)
+	FreshSearch(ctx context.Context, in *FreshSearchRequest, opts ...grpc.CallOption) (*FreshSearchResponse, error)
 }
 
 type dataNodeClient struct {
@@ -6571,6 +6816,15 @@ func (c *dataNodeClient) AddImportSegment(ctx context.Context, in *AddImportSegm
 	return out, nil
 }
 
+func (c *dataNodeClient) FreshSearch(ctx context.Context, in *FreshSearchRequest, opts ...grpc.CallOption) (*FreshSearchResponse, error) {
+	out := new(FreshSearchResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.data.DataNode/FreshSearch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataNodeServer is the server API for DataNode service.
 type DataNodeServer interface {
 	GetComponentStates(context.Context, *milvuspb.GetComponentStatesRequest) (*milvuspb.ComponentStates, error)
@@ -6587,6 +6841,7 @@ type DataNodeServer interface {
 	Import(context.Context, *ImportTaskRequest) (*commonpb.Status, error)
 	ResendSegmentStats(context.Context, *ResendSegmentStatsRequest) (*ResendSegmentStatsResponse, error)
 	AddImportSegment(context.Context, *AddImportSegmentRequest) (*AddImportSegmentResponse, error)
+	FreshSearch(context.Context, *FreshSearchRequest) (*FreshSearchResponse, error)
 }
 
 // UnimplementedDataNodeServer can be embedded to have forward compatible implementations.
@@ -6629,6 +6884,9 @@ func (*UnimplementedDataNodeServer) ResendSegmentStats(ctx context.Context, req
 func (*UnimplementedDataNodeServer) AddImportSegment(ctx context.Context, req *AddImportSegmentRequest) (*AddImportSegmentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddImportSegment not implemented")
 }
+func (*UnimplementedDataNodeServer) FreshSearch(ctx context.Context, req *FreshSearchRequest) (*FreshSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreshSearch not implemented")
+}
 
 func RegisterDataNodeServer(s *grpc.Server, srv DataNodeServer) {
 	s.RegisterService(&_DataNode_serviceDesc, srv)
@@ -6850,6 +7108,24 @@ func _DataNode_AddImportSegment_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataNode_FreshSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreshSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataNodeServer).FreshSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.data.DataNode/FreshSearch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataNodeServer).FreshSearch(ctx, req.(*FreshSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DataNode_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "milvus.proto.data.DataNode",
 	HandlerType: (*DataNodeServer)(nil),
@@ -6902,6 +7178,10 @@ var _DataNode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AddImportSegment",
 			Handler:    _DataNode_AddImportSegment_Handler,
 		},
+		{
+			MethodName: "FreshSearch",
+			Handler:    _DataNode_FreshSearch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "data_coord.proto",
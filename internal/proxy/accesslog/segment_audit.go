@@ -0,0 +1,75 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/milvus-io/milvus/internal/util/trace"
+	"go.uber.org/zap"
+)
+
+// SegmentAccess describes the data read by one search or query request.
+type SegmentAccess struct {
+	User           string
+	Method         string
+	DbName         string
+	CollectionName string
+	CollectionID   int64
+	PartitionIDs   []int64
+	// sealed segments read by the request
+	SegmentIDs []int64
+	// dml channels whose growing data are read by the request
+	Channels []string
+}
+
+// SampleSegmentAccess returns true if the segment access of a request should be recorded,
+// at most `sampleRate` of the requests are sampled.
+func SampleSegmentAccess(enable bool, sampleRate float64) bool {
+	if !enable || sampleRate <= 0 || _globalL.Load() == nil {
+		return false
+	}
+	return sampleRate >= 1 || rand.Float64() < sampleRate
+}
+
+// PrintSegmentAccess records the segment access into the access log.
+func PrintSegmentAccess(ctx context.Context, access *SegmentAccess) bool {
+	if _globalL.Load() == nil {
+		return false
+	}
+
+	traceID, _, ok := trace.InfoFromContext(ctx)
+	if !ok {
+		traceID = "Unknown"
+	}
+	user := access.User
+	if user == "" {
+		user = "Unknown"
+	}
+	A().Info(fmt.Sprintf("SegmentAccess: %s-%s", getAccessAddr(ctx), access.Method),
+		zap.String("traceId", traceID),
+		zap.String("user", user),
+		zap.String("dbName", access.DbName),
+		zap.String("collectionName", access.CollectionName),
+		zap.Int64("collectionID", access.CollectionID),
+		zap.Int64s("partitionIDs", access.PartitionIDs),
+		zap.Int64s("segmentIDs", access.SegmentIDs),
+		zap.Strings("channels", access.Channels))
+	return true
+}
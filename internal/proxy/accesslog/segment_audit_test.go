@@ -0,0 +1,69 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/trace"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+)
+
+func TestSegmentAccess(t *testing.T) {
+	var Params paramtable.ComponentParam
+	closer := trace.InitTracing("test-trace")
+	defer closer.Close()
+
+	Params.Init()
+	Params.ProxyCfg.AccessLog.Filename = ""
+	InitAccessLogger(&Params.ProxyCfg.AccessLog, &Params.MinioCfg)
+
+	assert.False(t, SampleSegmentAccess(false, 1))
+	assert.False(t, SampleSegmentAccess(true, 0))
+	assert.True(t, SampleSegmentAccess(true, 1))
+
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		if SampleSegmentAccess(true, 0.1) {
+			sampled++
+		}
+	}
+	assert.Less(t, sampled, 1000)
+
+	ctx := peer.NewContext(
+		context.Background(),
+		&peer.Peer{
+			Addr: &net.IPAddr{
+				IP:   net.IPv4(0, 0, 0, 0),
+				Zone: "test",
+			},
+		})
+	ok := PrintSegmentAccess(ctx, &SegmentAccess{
+		User:           "root",
+		Method:         "Search",
+		CollectionName: "test",
+		CollectionID:   1,
+		PartitionIDs:   []int64{10},
+		SegmentIDs:     []int64{100, 101},
+		Channels:       []string{"dml_0_1v0"},
+	})
+	assert.True(t, ok)
+}
@@ -0,0 +1,186 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	// MaxStalenessKey is the search/query param to opt in adaptive consistency with a staleness budget in milliseconds.
+	MaxStalenessKey = "max_staleness"
+	// EffectiveStalenessHeader is the response header carrying how far the guarantee timestamp lags behind the request, in milliseconds.
+	EffectiveStalenessHeader = "effective-staleness-ms"
+)
+
+// lagCache is global serviceableLagCache in Proxy.
+var lagCache = newServiceableLagCache()
+
+// serviceableLagCache caches the serviceable lags of collections reported by QueryNodes.
+type serviceableLagCache struct {
+	mu   sync.RWMutex
+	lags map[UniqueID]time.Duration
+	// requested marks a request opted in adaptive consistency since the last sync,
+	// so lags are synced even if adaptive consistency is disabled globally
+	requested atomic.Bool
+}
+
+func newServiceableLagCache() *serviceableLagCache {
+	return &serviceableLagCache{
+		lags: make(map[UniqueID]time.Duration),
+	}
+}
+
+func (c *serviceableLagCache) get(collectionID UniqueID) time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lags[collectionID]
+}
+
+func (c *serviceableLagCache) update(lags map[UniqueID]time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lags = lags
+}
+
+// sync fetches the serviceable lags of QueryNodes through QueryCoord,
+// the lag of a collection is the max one among the QueryNodes serving it.
+func (c *serviceableLagCache) sync(ctx context.Context, qc types.QueryCoord) error {
+	req, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.SystemInfoMetrics)
+	if err != nil {
+		return err
+	}
+	rsp, err := qc.GetMetrics(ctx, req)
+	if err != nil {
+		return err
+	}
+	if rsp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return fmt.Errorf("failed to get query cluster metrics, err = %s", rsp.GetStatus().GetReason())
+	}
+	topology := &metricsinfo.QueryCoordTopology{}
+	if err := metricsinfo.UnmarshalTopology(rsp.GetResponse(), topology); err != nil {
+		return err
+	}
+	lags := make(map[UniqueID]time.Duration)
+	for _, node := range topology.Cluster.ConnectedNodes {
+		if node.QuotaMetrics == nil {
+			continue
+		}
+		for collectionID, lag := range node.QuotaMetrics.ServiceableLags {
+			if lag > lags[collectionID] {
+				lags[collectionID] = lag
+			}
+		}
+	}
+	c.update(lags)
+	return nil
+}
+
+// syncServiceableLagLoop keeps the lag cache fresh while adaptive consistency is in use.
+func (node *Proxy) syncServiceableLagLoop() {
+	defer node.wg.Done()
+	ticker := time.NewTicker(Params.ProxyCfg.AdaptiveConsistencyLagRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-node.ctx.Done():
+			return
+		case <-ticker.C:
+			if !Params.ProxyCfg.AdaptiveConsistencyEnabled && !lagCache.requested.Swap(false) {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(node.ctx, Params.ProxyCfg.AdaptiveConsistencyLagRefreshInterval)
+			if err := lagCache.sync(ctx, node.queryCoord); err != nil {
+				log.RatedWarn(60, "failed to sync serviceable lags", zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// getMaxStaleness returns the staleness budget of the request, and whether adaptive consistency applies to it.
+func getMaxStaleness(params []*commonpb.KeyValuePair) (time.Duration, bool, error) {
+	value, err := funcutil.GetAttrByKeyFromRepeatedKV(MaxStalenessKey, params)
+	if err != nil {
+		return Params.ProxyCfg.AdaptiveConsistencyMaxStaleness, Params.ProxyCfg.AdaptiveConsistencyEnabled, nil
+	}
+	ms, err := strconv.ParseInt(value, 0, 64)
+	if err != nil || ms < 0 {
+		return 0, false, fmt.Errorf("%s [%s] is invalid, should be a non-negative integer in milliseconds", MaxStalenessKey, value)
+	}
+	lagCache.requested.Store(true)
+	return time.Duration(ms) * time.Millisecond, true, nil
+}
+
+// parseAdaptiveGuaranteeTs parses the guarantee timestamp like parseGuaranteeTs. When adaptive consistency applies,
+// strong and bounded reads are relaxed to the serviceable time of the collection, within the staleness budget,
+// so they don't stall while time tick lags. It returns the guarantee timestamp and its staleness against tMax,
+// the staleness is negative for eventually consistent reads which don't guarantee any timestamp.
+func parseAdaptiveGuaranteeTs(collectionID UniqueID, ts, tMax typeutil.Timestamp, params []*commonpb.KeyValuePair) (typeutil.Timestamp, time.Duration, error) {
+	guaranteeTs := parseGuaranteeTs(ts, tMax)
+	if ts == strongTS || ts == boundedTS {
+		maxStaleness, enabled, err := getMaxStaleness(params)
+		if err != nil {
+			return 0, 0, err
+		}
+		if enabled {
+			relax := lagCache.get(collectionID)
+			if relax > maxStaleness {
+				relax = maxStaleness
+			}
+			relaxed := tsoutil.AddPhysicalDurationOnTs(tMax, -relax)
+			if relaxed < guaranteeTs {
+				guaranteeTs = relaxed
+			}
+		}
+	}
+	switch {
+	case guaranteeTs >= tMax:
+		return guaranteeTs, 0, nil
+	case guaranteeTs <= boundedTS:
+		return guaranteeTs, -1, nil
+	default:
+		return guaranteeTs, time.Duration(tsoutil.CalculateDuration(tMax, guaranteeTs)) * time.Millisecond, nil
+	}
+}
+
+// setStalenessHeader reports the effective staleness of a read in the grpc response header.
+func setStalenessHeader(ctx context.Context, staleness time.Duration) {
+	if staleness < 0 {
+		return
+	}
+	md := metadata.Pairs(EffectiveStalenessHeader, strconv.FormatInt(staleness.Milliseconds(), 10))
+	// fails if the request doesn't come from grpc, which is fine
+	_ = grpc.SetHeader(ctx, md)
+}
@@ -0,0 +1,131 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+func TestParseAdaptiveGuaranteeTs(t *testing.T) {
+	enabled := Params.ProxyCfg.AdaptiveConsistencyEnabled
+	maxStaleness := Params.ProxyCfg.AdaptiveConsistencyMaxStaleness
+	defer func() {
+		Params.ProxyCfg.AdaptiveConsistencyEnabled = enabled
+		Params.ProxyCfg.AdaptiveConsistencyMaxStaleness = maxStaleness
+		lagCache.update(make(map[UniqueID]time.Duration))
+	}()
+	Params.ProxyCfg.AdaptiveConsistencyEnabled = false
+	Params.ProxyCfg.AdaptiveConsistencyMaxStaleness = 3 * time.Second
+	graceful := time.Duration(Params.CommonCfg.GracefulTime) * time.Millisecond
+
+	lagCache.update(map[UniqueID]time.Duration{1: 2 * time.Second, 2: time.Hour})
+	tMax := tsoutil.ComposeTSByTime(time.Now(), 0)
+	budget := func(ms string) []*commonpb.KeyValuePair {
+		return []*commonpb.KeyValuePair{{Key: MaxStalenessKey, Value: ms}}
+	}
+
+	// disabled
+	ts, staleness, err := parseAdaptiveGuaranteeTs(1, strongTS, tMax, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, tMax, ts)
+	assert.Equal(t, time.Duration(0), staleness)
+
+	// opt in by request
+	ts, staleness, err = parseAdaptiveGuaranteeTs(1, strongTS, tMax, budget("1000"))
+	assert.NoError(t, err)
+	assert.Equal(t, tsoutil.AddPhysicalDurationOnTs(tMax, -time.Second), ts)
+	assert.Equal(t, time.Second, staleness)
+	assert.True(t, lagCache.requested.Load())
+
+	ts, staleness, err = parseAdaptiveGuaranteeTs(1, strongTS, tMax, budget("5000"))
+	assert.NoError(t, err)
+	assert.Equal(t, tsoutil.AddPhysicalDurationOnTs(tMax, -2*time.Second), ts)
+	assert.Equal(t, 2*time.Second, staleness)
+
+	_, _, err = parseAdaptiveGuaranteeTs(1, strongTS, tMax, budget("-1"))
+	assert.Error(t, err)
+	_, _, err = parseAdaptiveGuaranteeTs(1, strongTS, tMax, budget("abc"))
+	assert.Error(t, err)
+
+	// enabled globally, capped by the max staleness
+	Params.ProxyCfg.AdaptiveConsistencyEnabled = true
+	_, staleness, err = parseAdaptiveGuaranteeTs(2, strongTS, tMax, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Second, staleness)
+
+	// no lag, no relaxing
+	_, staleness, err = parseAdaptiveGuaranteeTs(3, strongTS, tMax, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), staleness)
+
+	// bounded is never stricter than the graceful time
+	_, staleness, err = parseAdaptiveGuaranteeTs(1, boundedTS, tMax, budget("0"))
+	assert.NoError(t, err)
+	assert.Equal(t, graceful, staleness)
+
+	// session and eventually are kept as is
+	sessionTs := tsoutil.AddPhysicalDurationOnTs(tMax, -100*time.Millisecond)
+	ts, staleness, err = parseAdaptiveGuaranteeTs(2, sessionTs, tMax, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sessionTs, ts)
+	assert.Equal(t, 100*time.Millisecond, staleness)
+
+	ts, staleness, err = parseAdaptiveGuaranteeTs(2, 1, tMax, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), ts)
+	assert.Less(t, staleness, time.Duration(0))
+}
+
+func TestServiceableLagCache_Sync(t *testing.T) {
+	cache := newServiceableLagCache()
+	qc := NewQueryCoordMock()
+	qc.updateState(commonpb.StateCode_Healthy)
+
+	topology := &metricsinfo.QueryCoordTopology{
+		Cluster: metricsinfo.QueryClusterTopology{
+			ConnectedNodes: []metricsinfo.QueryNodeInfos{
+				{QuotaMetrics: &metricsinfo.QueryNodeQuotaMetrics{ServiceableLags: map[int64]time.Duration{1: time.Second, 2: time.Second}}},
+				{QuotaMetrics: &metricsinfo.QueryNodeQuotaMetrics{ServiceableLags: map[int64]time.Duration{1: 3 * time.Second}}},
+				{},
+			},
+		},
+	}
+	qc.getMetricsFunc = func(ctx context.Context, request *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		resp, err := metricsinfo.MarshalTopology(topology)
+		return &milvuspb.GetMetricsResponse{
+			Status:   &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			Response: resp,
+		}, err
+	}
+	assert.NoError(t, cache.sync(context.Background(), qc))
+	assert.Equal(t, 3*time.Second, cache.get(1))
+	assert.Equal(t, time.Second, cache.get(2))
+	assert.Equal(t, time.Duration(0), cache.get(3))
+
+	qc.updateState(commonpb.StateCode_Abnormal)
+	assert.Error(t, cache.sync(context.Background(), qc))
+	assert.Equal(t, 3*time.Second, cache.get(1))
+}
@@ -0,0 +1,108 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/util/analyzer"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// validateFieldAnalyzer checks the analyzer type params of @field could create an analyzer.
+func validateFieldAnalyzer(field *schemapb.FieldSchema) error {
+	_, err := analyzer.FromField(field)
+	return err
+}
+
+// getFieldAnalyzers returns the analyzers of the VarChar fields with analyzer in @schema, keyed by field name.
+func getFieldAnalyzers(schema *schemapb.CollectionSchema) (map[string]analyzer.Analyzer, error) {
+	analyzers := make(map[string]analyzer.Analyzer)
+	for _, field := range schema.GetFields() {
+		a, err := analyzer.FromField(field)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			analyzers[field.GetName()] = a
+		}
+	}
+	return analyzers, nil
+}
+
+// checkAnalyzedFieldData applies the analyzers to the inserted text of the VarChar fields with analyzer,
+// the rows with more terms than proxy.analyzer.maxTermsPerRow are rejected.
+func (it *insertTask) checkAnalyzedFieldData() error {
+	analyzers, err := getFieldAnalyzers(it.schema)
+	if err != nil {
+		return err
+	}
+	if len(analyzers) == 0 {
+		return nil
+	}
+	maxTerms := Params.ProxyCfg.AnalyzerMaxTermsPerRow
+	for _, fieldData := range it.GetFieldsData() {
+		a, ok := analyzers[fieldData.GetFieldName()]
+		if !ok {
+			continue
+		}
+		for i, text := range fieldData.GetScalars().GetStringData().GetData() {
+			terms := a.Analyze(text)
+			if maxTerms > 0 && int64(len(terms)) > maxTerms {
+				return fmt.Errorf("the text of field %s at row %d is analyzed into %d terms, exceeds the limit %d",
+					fieldData.GetFieldName(), i, len(terms), maxTerms)
+			}
+		}
+	}
+	return nil
+}
+
+// Analyze splits @texts into terms with the analyzer of the VarChar field @fieldName,
+// which is the same analyzer applied on insertion, so the query terms are consistent with the field data.
+func (node *Proxy) Analyze(ctx context.Context, collectionName string, fieldName string, texts []string) ([][]string, error) {
+	if !node.checkHealthy() {
+		return nil, errors.New("proxy is not healthy")
+	}
+	schema, err := globalMetaCache.GetCollectionSchema(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	if err != nil {
+		return nil, err
+	}
+	field, err := helper.GetFieldFromName(fieldName)
+	if err != nil {
+		return nil, err
+	}
+	a, err := analyzer.FromField(field)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, fmt.Errorf("no analyzer is specified for field %s of collection %s", fieldName, collectionName)
+	}
+
+	terms := make([][]string, 0, len(texts))
+	for _, text := range texts {
+		terms = append(terms, a.Analyze(text))
+	}
+	return terms, nil
+}
@@ -0,0 +1,111 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util/analyzer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAnalyzedTextField(name string, analyzerName string, params string) *schemapb.FieldSchema {
+	return &schemapb.FieldSchema{
+		Name:     name,
+		DataType: schemapb.DataType_VarChar,
+		TypeParams: []*commonpb.KeyValuePair{
+			{Key: maxVarCharLengthKey, Value: "1024"},
+			{Key: analyzer.TypeParamKey, Value: analyzerName},
+			{Key: analyzer.ParamsTypeParamKey, Value: params},
+		},
+	}
+}
+
+func TestValidateFieldAnalyzer(t *testing.T) {
+	field := newAnalyzedTextField("text", analyzer.Standard, `{"stop_words": ["_english_"]}`)
+	assert.NoError(t, validateMaxLengthPerRow("coll", field))
+	assert.NoError(t, validateFieldAnalyzer(field))
+
+	field = newAnalyzedTextField("text", "unknown", "")
+	assert.NoError(t, validateMaxLengthPerRow("coll", field))
+	assert.Error(t, validateFieldAnalyzer(field))
+
+	field = newAnalyzedTextField("text", analyzer.Standard, `{"stop_words": "a"}`)
+	assert.Error(t, validateFieldAnalyzer(field))
+
+	field = newAnalyzedTextField("text", analyzer.Standard, "")
+	field.DataType = schemapb.DataType_Int64
+	assert.Error(t, validateFieldAnalyzer(field))
+}
+
+func TestInsertTask_checkAnalyzedFieldData(t *testing.T) {
+	maxTerms := Params.ProxyCfg.AnalyzerMaxTermsPerRow
+	defer func() { Params.ProxyCfg.AnalyzerMaxTermsPerRow = maxTerms }()
+
+	it := &insertTask{
+		schema: &schemapb.CollectionSchema{
+			Name: "TestInsertTask_checkAnalyzedFieldData",
+			Fields: []*schemapb.FieldSchema{
+				{Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+				newAnalyzedTextField("text", analyzer.Whitespace, ""),
+			},
+		},
+		BaseInsertTask: BaseInsertTask{
+			InsertRequest: internalpb.InsertRequest{
+				FieldsData: []*schemapb.FieldData{
+					{
+						FieldName: "text",
+						Type:      schemapb.DataType_VarChar,
+						Field: &schemapb.FieldData_Scalars{
+							Scalars: &schemapb.ScalarField{
+								Data: &schemapb.ScalarField_StringData{
+									StringData: &schemapb.StringArray{Data: []string{"a b", "a b c"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	Params.ProxyCfg.AnalyzerMaxTermsPerRow = 0
+	assert.NoError(t, it.checkAnalyzedFieldData())
+
+	Params.ProxyCfg.AnalyzerMaxTermsPerRow = 3
+	assert.NoError(t, it.checkAnalyzedFieldData())
+
+	Params.ProxyCfg.AnalyzerMaxTermsPerRow = 2
+	assert.Error(t, it.checkAnalyzedFieldData())
+
+	it.schema.Fields[1] = newAnalyzedTextField("text", "unknown", "")
+	assert.Error(t, it.checkAnalyzedFieldData())
+}
+
+func Test_parseDummyAnalyzeRequest(t *testing.T) {
+	_, err := parseDummyAnalyzeRequest("not in json format string")
+	assert.Error(t, err)
+
+	req, err := parseDummyAnalyzeRequest(`{"request_type": "analyze", "collection_name": "coll", "field_name": "text", "texts": ["a b"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "coll", req.CollectionName)
+	assert.Equal(t, "text", req.FieldName)
+	assert.Equal(t, []string{"a b"}, req.Texts)
+}
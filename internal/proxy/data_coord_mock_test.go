@@ -40,6 +40,7 @@ type DataCoordMock struct {
 	statisticsChannel      string
 	timeTickChannel        string
 	checkHealthFunc        func(ctx context.Context, req *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error)
+	freshSearchFunc        func(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error)
 }
 
 func (coord *DataCoordMock) updateState(state commonpb.StateCode) {
@@ -138,6 +139,13 @@ func (coord *DataCoordMock) CheckHealth(ctx context.Context, req *milvuspb.Check
 	return &milvuspb.CheckHealthResponse{IsHealthy: true}, nil
 }
 
+func (coord *DataCoordMock) FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+	if coord.freshSearchFunc != nil {
+		return coord.freshSearchFunc(ctx, req)
+	}
+	return &datapb.FreshSearchResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}}, nil
+}
+
 func (coord *DataCoordMock) AssignSegmentID(ctx context.Context, req *datapb.AssignSegmentIDRequest) (*datapb.AssignSegmentIDResponse, error) {
 	panic("implement me")
 }
@@ -49,3 +49,36 @@ func parseDummyQueryRequest(str string) (*dummyQueryRequest, error) {
 	}
 	return dr, nil
 }
+
+type dummyPresignURLRequest struct {
+	RequestType string `json:"request_type"`
+	Path        string `json:"path"`
+	Method      string `json:"method"`
+	// Expiry is in seconds
+	Expiry int64 `json:"expiry"`
+}
+
+func parseDummyPresignURLRequest(str string) (*dummyPresignURLRequest, error) {
+	dr := &dummyPresignURLRequest{}
+
+	if err := json.Unmarshal([]byte(str), &dr); err != nil {
+		return nil, err
+	}
+	return dr, nil
+}
+
+type dummyAnalyzeRequest struct {
+	RequestType    string   `json:"request_type"`
+	CollectionName string   `json:"collection_name"`
+	FieldName      string   `json:"field_name"`
+	Texts          []string `json:"texts"`
+}
+
+func parseDummyAnalyzeRequest(str string) (*dummyAnalyzeRequest, error) {
+	dr := &dummyAnalyzeRequest{}
+
+	if err := json.Unmarshal([]byte(str), &dr); err != nil {
+		return nil, err
+	}
+	return dr, nil
+}
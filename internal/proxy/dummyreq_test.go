@@ -218,3 +218,23 @@ func Test_parseDummyQueryRequest(t *testing.T) {
 // 	assert.Equal(t, len(drr2.PartitionNames), 0)
 // 	assert.Equal(t, drr2.OutputFields, []string{"_id", "age"})
 // }
+
+func Test_parseDummyPresignURLRequest(t *testing.T) {
+	_, err := parseDummyPresignURLRequest("not in json format string")
+	assert.NotNil(t, err)
+
+	m := map[string]interface{}{
+		"request_type": "presign_url",
+		"path":         "insert_log/1/2/3",
+		"method":       "GET",
+		"expiry":       60,
+	}
+	bs, err := json.Marshal(m)
+	assert.Nil(t, err)
+	ret, err := parseDummyPresignURLRequest(string(bs))
+	assert.Nil(t, err)
+	assert.Equal(t, "presign_url", ret.RequestType)
+	assert.Equal(t, "insert_log/1/2/3", ret.Path)
+	assert.Equal(t, "GET", ret.Method)
+	assert.Equal(t, int64(60), ret.Expiry)
+}
@@ -0,0 +1,196 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/internal/util/commonpbutil"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// FreshSearchKey is the search param to merge the search over the insert buffers of the DataNodes into the search,
+// it overrides ProxyCfg.FreshSearchEnabled.
+const FreshSearchKey = "fresh_search"
+
+// isFreshSearchEnabled returns whether the search asks for fresh search.
+func isFreshSearchEnabled(params []*commonpb.KeyValuePair) (bool, error) {
+	value, err := funcutil.GetAttrByKeyFromRepeatedKV(FreshSearchKey, params)
+	if err != nil {
+		return Params.ProxyCfg.FreshSearchEnabled, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s [%s] is invalid, should be a bool", FreshSearchKey, value)
+	}
+	return enabled, nil
+}
+
+// withFreshSearchStaleness relaxes the guarantee timestamp of the fresh search up to ProxyCfg.FreshSearchMaxStaleness,
+// since the newest data is served by the DataNodes. An explicit max_staleness of the search wins.
+func withFreshSearchStaleness(params []*commonpb.KeyValuePair) []*commonpb.KeyValuePair {
+	if _, err := funcutil.GetAttrByKeyFromRepeatedKV(MaxStalenessKey, params); err == nil {
+		return params
+	}
+	relaxed := make([]*commonpb.KeyValuePair, 0, len(params)+1)
+	relaxed = append(relaxed, params...)
+	return append(relaxed, &commonpb.KeyValuePair{
+		Key:   MaxStalenessKey,
+		Value: strconv.FormatInt(Params.ProxyCfg.FreshSearchMaxStaleness.Milliseconds(), 10),
+	})
+}
+
+// newFreshSearchRequest constructs the fresh search of the search, it returns nil if the search is not supported
+// by fresh search, which is a plain float vector search without any expression or output field.
+func newFreshSearchRequest(schema *schemapb.CollectionSchema, annsField string, req *searchTask) (*datapb.FreshSearchRequest, error) {
+	if req.request.GetDsl() != "" || len(req.request.GetOutputFields()) > 0 {
+		return nil, nil
+	}
+	var annsSchema, pkSchema *schemapb.FieldSchema
+	for _, field := range schema.GetFields() {
+		if field.GetName() == annsField {
+			annsSchema = field
+		}
+		if field.GetIsPrimaryKey() {
+			pkSchema = field
+		}
+	}
+	if annsSchema == nil || pkSchema == nil || annsSchema.GetDataType() != schemapb.DataType_FloatVector {
+		return nil, nil
+	}
+	dimStr, err := funcutil.GetAttrByKeyFromRepeatedKV(common.DimKey, annsSchema.GetTypeParams())
+	if err != nil {
+		return nil, err
+	}
+	dim, err := strconv.ParseInt(dimStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	vectors, nq, err := decodeFloatVectors(req.request.GetPlaceholderGroup(), dim)
+	if err != nil {
+		return nil, err
+	}
+	return &datapb.FreshSearchRequest{
+		Base: commonpbutil.NewMsgBase(
+			commonpbutil.WithMsgType(commonpb.MsgType_Search),
+			commonpbutil.WithSourceID(paramtable.GetNodeID()),
+		),
+		CollectionID:    req.SearchRequest.GetCollectionID(),
+		PartitionIDs:    req.SearchRequest.GetPartitionIDs(),
+		PkFieldID:       pkSchema.GetFieldID(),
+		VectorFieldID:   annsSchema.GetFieldID(),
+		MetricType:      req.SearchRequest.GetMetricType(),
+		Nq:              nq,
+		Topk:            req.SearchRequest.GetTopk(),
+		Dim:             dim,
+		Vectors:         vectors,
+		TravelTimestamp: req.SearchRequest.GetTravelTimestamp(),
+	}, nil
+}
+
+// decodeFloatVectors decodes the float vectors of @dim dimensions from the placeholder group,
+// it returns the vectors one after another and the number of them.
+func decodeFloatVectors(placeholderGroup []byte, dim int64) ([]float32, int64, error) {
+	group := &commonpb.PlaceholderGroup{}
+	if err := proto.Unmarshal(placeholderGroup, group); err != nil {
+		return nil, 0, err
+	}
+	var vectors []float32
+	var nq int64
+	for _, holder := range group.GetPlaceholders() {
+		if holder.GetType() != commonpb.PlaceholderType_FloatVector {
+			return nil, 0, fmt.Errorf("fresh search doesn't support placeholder type %s", holder.GetType().String())
+		}
+		for _, value := range holder.GetValues() {
+			if int64(len(value)) != dim*4 {
+				return nil, 0, fmt.Errorf("invalid float vector size %d of dim %d", len(value), dim)
+			}
+			for i := int64(0); i < dim; i++ {
+				vectors = append(vectors, math.Float32frombits(binary.LittleEndian.Uint32(value[i*4:])))
+			}
+			nq++
+		}
+	}
+	return vectors, nq, nil
+}
+
+// freshSearch searches the insert buffers of the DataNodes through DataCoord,
+// the results of the DataNodes which don't match the nq and topk of the search are dropped.
+func freshSearch(ctx context.Context, dc types.DataCoord, req *datapb.FreshSearchRequest) ([]*schemapb.SearchResultData, error) {
+	resp, err := dc.FreshSearch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, errors.New(resp.GetStatus().GetReason())
+	}
+	results := make([]*schemapb.SearchResultData, 0, len(resp.GetResults()))
+	for _, data := range resp.GetResults() {
+		if err := checkSearchResultData(data, req.GetNq(), req.GetTopk()); err != nil {
+			log.Ctx(ctx).Warn("invalid fresh search result", zap.Error(err))
+			continue
+		}
+		results = append(results, data)
+	}
+	return results, nil
+}
+
+// startFreshSearch runs the fresh search of the task in the background, the result is nil if it fails,
+// since the fresh search is best-effort.
+func (t *searchTask) startFreshSearch(ctx context.Context) {
+	if t.freshReq == nil || t.dc == nil {
+		return
+	}
+	t.freshResult = make(chan []*schemapb.SearchResultData, 1)
+	go func() {
+		results, err := freshSearch(ctx, t.dc, t.freshReq)
+		if err != nil {
+			log.Ctx(ctx).Warn("fresh search failed, ignore the buffered data", zap.Error(err))
+			t.freshResult <- nil
+			return
+		}
+		t.freshResult <- results
+	}()
+}
+
+// waitFreshSearch waits for the fresh search started by startFreshSearch.
+func (t *searchTask) waitFreshSearch(ctx context.Context) []*schemapb.SearchResultData {
+	if t.freshResult == nil {
+		return nil
+	}
+	select {
+	case result := <-t.freshResult:
+		return result
+	case <-ctx.Done():
+		return nil
+	}
+}
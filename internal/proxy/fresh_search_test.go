@@ -0,0 +1,128 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+func TestFreshSearchParams(t *testing.T) {
+	enabled := Params.ProxyCfg.FreshSearchEnabled
+	maxStaleness := Params.ProxyCfg.FreshSearchMaxStaleness
+	defer func() {
+		Params.ProxyCfg.FreshSearchEnabled = enabled
+		Params.ProxyCfg.FreshSearchMaxStaleness = maxStaleness
+	}()
+	Params.ProxyCfg.FreshSearchEnabled = true
+	Params.ProxyCfg.FreshSearchMaxStaleness = 2 * time.Second
+
+	fresh, err := isFreshSearchEnabled(nil)
+	assert.NoError(t, err)
+	assert.True(t, fresh)
+	fresh, err = isFreshSearchEnabled([]*commonpb.KeyValuePair{{Key: FreshSearchKey, Value: "false"}})
+	assert.NoError(t, err)
+	assert.False(t, fresh)
+	_, err = isFreshSearchEnabled([]*commonpb.KeyValuePair{{Key: FreshSearchKey, Value: "invalid"}})
+	assert.Error(t, err)
+
+	params := withFreshSearchStaleness(nil)
+	value, err := funcutil.GetAttrByKeyFromRepeatedKV(MaxStalenessKey, params)
+	assert.NoError(t, err)
+	assert.Equal(t, "2000", value)
+
+	params = withFreshSearchStaleness([]*commonpb.KeyValuePair{{Key: MaxStalenessKey, Value: "10"}})
+	assert.Len(t, params, 1)
+	value, err = funcutil.GetAttrByKeyFromRepeatedKV(MaxStalenessKey, params)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", value)
+}
+
+func TestDecodeFloatVectors(t *testing.T) {
+	encode := func(vector ...float32) []byte {
+		bs := make([]byte, len(vector)*4)
+		for i, v := range vector {
+			binary.LittleEndian.PutUint32(bs[i*4:], math.Float32bits(v))
+		}
+		return bs
+	}
+	group, err := proto.Marshal(&commonpb.PlaceholderGroup{
+		Placeholders: []*commonpb.PlaceholderValue{{
+			Tag:    "$0",
+			Type:   commonpb.PlaceholderType_FloatVector,
+			Values: [][]byte{encode(1, 2), encode(3, 4)},
+		}},
+	})
+	assert.NoError(t, err)
+
+	vectors, nq, err := decodeFloatVectors(group, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), nq)
+	assert.Equal(t, []float32{1, 2, 3, 4}, vectors)
+
+	_, _, err = decodeFloatVectors(group, 3)
+	assert.Error(t, err)
+}
+
+func TestFreshSearch(t *testing.T) {
+	ctx := context.Background()
+	req := &datapb.FreshSearchRequest{Nq: 2, Topk: 2}
+	valid := &schemapb.SearchResultData{
+		NumQueries: 2,
+		TopK:       2,
+		Scores:     []float32{3, 2},
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2}}}},
+		Topks:      []int64{2, 0},
+	}
+	dc := NewDataCoordMock()
+	dc.freshSearchFunc = func(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+		return &datapb.FreshSearchResponse{
+			Status:  &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			Results: []*schemapb.SearchResultData{valid, {NumQueries: 1, TopK: 2}},
+		}, nil
+	}
+	// the result of the mismatched nq is dropped
+	results, err := freshSearch(ctx, dc, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []*schemapb.SearchResultData{valid}, results)
+
+	dc.freshSearchFunc = func(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+		return &datapb.FreshSearchResponse{
+			Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: "mock"},
+		}, nil
+	}
+	_, err = freshSearch(ctx, dc, req)
+	assert.Error(t, err)
+
+	dc.freshSearchFunc = func(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error) {
+		return nil, errors.New("mock")
+	}
+	_, err = freshSearch(ctx, dc, req)
+	assert.Error(t, err)
+}
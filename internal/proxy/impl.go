@@ -18,11 +18,13 @@ package proxy
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -2211,6 +2213,7 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 		},
 		request:  request,
 		qc:       node.queryCoord,
+		dc:       node.dataCoord,
 		tr:       timerecord.NewTimeRecorder("search"),
 		shardMgr: node.shardMgr,
 	}
@@ -2275,6 +2278,7 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 		metrics.SearchLabel).Observe(float64(span.Milliseconds()))
 	tr.CtxRecord(ctx, "wait search result")
 	log.Debug(rpcDone(method))
+	setStalenessHeader(ctx, qt.staleness)
 
 	metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
 		metrics.SuccessLabel).Inc()
@@ -2417,6 +2421,18 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 		zap.Uint64("travel_timestamp", request.TravelTimestamp),
 		zap.Uint64("guarantee_timestamp", request.GuaranteeTimestamp))
 
+	spillAccepted, err := isSpillResultAccepted(ctx, request.GetQueryParams())
+	if err != nil {
+		metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
+			metrics.AbandonLabel).Inc()
+		return &milvuspb.QueryResults{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_IllegalArgument,
+				Reason:    err.Error(),
+			},
+		}, nil
+	}
+
 	if err := node.sched.dqQueue.Enqueue(qt); err != nil {
 		log.Warn(
 			rpcFailedToEnqueue(method),
@@ -2456,6 +2472,7 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 		metrics.QueryLabel).Observe(float64(span.Milliseconds()))
 
 	log.Debug(rpcDone(method))
+	setStalenessHeader(ctx, qt.staleness)
 
 	metrics.ProxyFunctionCall.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), method,
 		metrics.SuccessLabel).Inc()
@@ -2472,6 +2489,33 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 	sentSize := proto.Size(qt.result)
 	rateCol.Add(metricsinfo.ReadResultThroughput, float64(sentSize))
 	metrics.ProxyReadReqSendBytes.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10)).Add(float64(sentSize))
+	if spillAccepted && int64(sentSize) > Params.ProxyCfg.QueryResultSpillThreshold {
+		ref, err := node.spillQueryResult(ctx, request.GetCollectionName(), qt.ID(), ret)
+		if err != nil {
+			log.Warn("failed to spill oversized query result", zap.Int("size", sentSize), zap.Error(err))
+			return &milvuspb.QueryResults{
+				Status: &commonpb.Status{
+					ErrorCode: commonpb.ErrorCode_UnexpectedError,
+					Reason:    fmt.Sprintf("failed to spill query result of %d bytes: %s", sentSize, err.Error()),
+				},
+			}, nil
+		}
+		if err := setQueryResultHeader(ctx, ref); err != nil {
+			log.Warn("failed to respond reference of spilled query result", zap.String("path", ref.path), zap.Error(err))
+			node.removeSpilledQueryResult(ref)
+			return &milvuspb.QueryResults{
+				Status: &commonpb.Status{
+					ErrorCode: commonpb.ErrorCode_UnexpectedError,
+					Reason:    fmt.Sprintf("failed to respond reference of query result of %d bytes: %s", sentSize, err.Error()),
+				},
+			}, nil
+		}
+		log.Debug("spilled oversized query result", zap.Int("size", sentSize), zap.String("path", ref.path))
+		return &milvuspb.QueryResults{
+			Status:         ret.Status,
+			CollectionName: request.GetCollectionName(),
+		}, nil
+	}
 	return ret, nil
 }
 
@@ -2996,6 +3040,58 @@ func (node *Proxy) Dummy(ctx context.Context, req *milvuspb.DummyRequest) (*milv
 		}, nil
 	}
 
+	if drt.RequestType == "presign_url" {
+		dpr, err := parseDummyPresignURLRequest(req.RequestType)
+		if err != nil {
+			log.Warn("Failed to parse dummy presign url request",
+				zap.Error(err))
+			return failedResponse, nil
+		}
+
+		url, err := node.PresignURL(ctx, dpr.Path, dpr.Method, time.Duration(dpr.Expiry)*time.Second)
+		if err != nil {
+			log.Warn("Failed to presign url",
+				zap.String("path", dpr.Path),
+				zap.String("method", dpr.Method),
+				zap.Error(err))
+			return failedResponse, nil
+		}
+
+		bs, err := json.Marshal(map[string]string{"status": "success", "url": url})
+		if err != nil {
+			return failedResponse, nil
+		}
+		return &milvuspb.DummyResponse{
+			Response: string(bs),
+		}, nil
+	}
+
+	if drt.RequestType == "analyze" {
+		dar, err := parseDummyAnalyzeRequest(req.RequestType)
+		if err != nil {
+			log.Warn("Failed to parse dummy analyze request",
+				zap.Error(err))
+			return failedResponse, nil
+		}
+
+		terms, err := node.Analyze(ctx, dar.CollectionName, dar.FieldName, dar.Texts)
+		if err != nil {
+			log.Warn("Failed to analyze texts",
+				zap.String("collection", dar.CollectionName),
+				zap.String("field", dar.FieldName),
+				zap.Error(err))
+			return failedResponse, nil
+		}
+
+		bs, err := json.Marshal(map[string]interface{}{"status": "success", "terms": terms})
+		if err != nil {
+			return failedResponse, nil
+		}
+		return &milvuspb.DummyResponse{
+			Response: string(bs),
+		}, nil
+	}
+
 	log.Debug("cannot find specify dummy request type")
 	return failedResponse, nil
 }
@@ -30,6 +30,7 @@ import (
 
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
@@ -62,6 +63,8 @@ type Cache interface {
 	// GetCollectionSchema get collection's schema.
 	GetCollectionSchema(ctx context.Context, collectionName string) (*schemapb.CollectionSchema, error)
 	GetShards(ctx context.Context, withCache bool, collectionName string) (map[string][]nodeInfo, error)
+	// GetReadOnlyShards is GetShards of the read-only replicas, which serve the sealed segments as of a snapshot.
+	GetReadOnlyShards(ctx context.Context, withCache bool, collectionName string) (map[string][]nodeInfo, error)
 	ClearShards(collectionName string)
 	RemoveCollection(ctx context.Context, collectionName string)
 	RemoveCollectionsByID(ctx context.Context, collectionID UniqueID) []string
@@ -87,15 +90,27 @@ type collectionInfo struct {
 	createdTimestamp    uint64
 	createdUtcTimestamp uint64
 	isLoaded            bool
+	shardRouting        string
+
+	// readOnlyShardLeaders are the leaders of the read-only replicas, which are excluded from shardLeaders
+	readOnlyShardLeaders *shardLeaders
 }
 
 // shardLeaders wraps shard leader mapping for iteration.
 type shardLeaders struct {
-	idx *atomic.Int64
+	idx       *atomic.Int64
+	updatedAt time.Time
 
 	shardLeaders map[string][]nodeInfo
 }
 
+// expired returns whether the shard leaders should be fetched from QueryCoord again,
+// so that the replicas added or removed online are picked up.
+func (sl *shardLeaders) expired() bool {
+	expiration := Params.ProxyCfg.ShardLeaderCacheExpiration
+	return expiration > 0 && time.Since(sl.updatedAt) > expiration
+}
+
 type shardLeadersReader struct {
 	leaders *shardLeaders
 	idx     int64
@@ -309,6 +324,13 @@ func (m *MetaCache) updateCollection(coll *milvuspb.DescribeCollectionResponse,
 	m.collInfo[collectionName].collID = coll.CollectionID
 	m.collInfo[collectionName].createdTimestamp = coll.CreatedTimestamp
 	m.collInfo[collectionName].createdUtcTimestamp = coll.CreatedUtcTimestamp
+	shardRouting, err := typeutil.GetShardRouting(coll.Properties)
+	if err != nil {
+		log.Warn("invalid shard routing of collection, use modulo routing",
+			zap.String("collectionName", collectionName), zap.Error(err))
+		shardRouting = common.ShardRoutingModulo
+	}
+	m.collInfo[collectionName].shardRouting = shardRouting
 }
 
 func (m *MetaCache) GetPartitionID(ctx context.Context, collectionName string, partitionName string) (typeutil.UniqueID, error) {
@@ -594,6 +616,23 @@ func (m *MetaCache) UpdateCredential(credInfo *internalpb.CredentialInfo) {
 
 // GetShards update cache if withCache == false
 func (m *MetaCache) GetShards(ctx context.Context, withCache bool, collectionName string) (map[string][]nodeInfo, error) {
+	return m.getShards(ctx, withCache, collectionName, false)
+}
+
+// GetReadOnlyShards update cache if withCache == false
+func (m *MetaCache) GetReadOnlyShards(ctx context.Context, withCache bool, collectionName string) (map[string][]nodeInfo, error) {
+	return m.getShards(ctx, withCache, collectionName, true)
+}
+
+// cachedShardLeaders returns the cached leaders of the read-only replicas if @readOnly, otherwise of the others.
+func (info *collectionInfo) cachedShardLeaders(readOnly bool) **shardLeaders {
+	if readOnly {
+		return &info.readOnlyShardLeaders
+	}
+	return &info.shardLeaders
+}
+
+func (m *MetaCache) getShards(ctx context.Context, withCache bool, collectionName string, readOnly bool) (map[string][]nodeInfo, error) {
 	info, err := m.GetCollectionInfo(ctx, collectionName)
 	if err != nil {
 		return nil, err
@@ -602,11 +641,11 @@ func (m *MetaCache) GetShards(ctx context.Context, withCache bool, collectionNam
 	if withCache {
 		var shardLeaders *shardLeaders
 		info.leaderMutex.RLock()
-		shardLeaders = info.shardLeaders
+		shardLeaders = *info.cachedShardLeaders(readOnly)
 		info.leaderMutex.RUnlock()
 
-		if shardLeaders != nil {
-			iterator := info.shardLeaders.GetReader()
+		if shardLeaders != nil && !shardLeaders.expired() {
+			iterator := shardLeaders.GetReader()
 			return iterator.Shuffle(), nil
 		}
 
@@ -621,6 +660,11 @@ func (m *MetaCache) GetShards(ctx context.Context, withCache bool, collectionNam
 		CollectionID: info.collID,
 	}
 
+	if readOnly {
+		// the read-only replicas are only returned to the requests targeting them
+		ctx = metadata.AppendToOutgoingContext(ctx, common.ReadOnlyReplicaKey, "true")
+	}
+
 	// retry until service available or context timeout
 	var resp *querypb.GetShardLeadersResponse
 	childCtx, cancel := context.WithTimeout(ctx, time.Second*10)
@@ -654,12 +698,14 @@ func (m *MetaCache) GetShards(ctx context.Context, withCache bool, collectionNam
 	}
 	// lock leader
 	info.leaderMutex.Lock()
-	oldShards := info.shardLeaders
-	info.shardLeaders = &shardLeaders{
+	cached := info.cachedShardLeaders(readOnly)
+	oldShards := *cached
+	*cached = &shardLeaders{
 		shardLeaders: shards,
 		idx:          atomic.NewInt64(0),
+		updatedAt:    time.Now(),
 	}
-	iterator := info.shardLeaders.GetReader()
+	iterator := (*cached).GetReader()
 	info.leaderMutex.Unlock()
 
 	ret := iterator.Shuffle()
@@ -695,13 +741,18 @@ func (m *MetaCache) ClearShards(collectionName string) {
 	log.Info("clearing shard cache for collection", zap.String("collectionName", collectionName))
 	m.mu.Lock()
 	info, ok := m.collInfo[collectionName]
+	var leaders []*shardLeaders
 	if ok {
+		leaders = []*shardLeaders{info.shardLeaders, info.readOnlyShardLeaders}
 		m.collInfo[collectionName].shardLeaders = nil
+		m.collInfo[collectionName].readOnlyShardLeaders = nil
 	}
 	m.mu.Unlock()
 	// delete refcnt in shardClientMgr
-	if ok && info.shardLeaders != nil {
-		_ = m.shardMgr.UpdateShardLeaders(info.shardLeaders.shardLeaders, nil)
+	for _, shardLeaders := range leaders {
+		if shardLeaders != nil {
+			_ = m.shardMgr.UpdateShardLeaders(shardLeaders.shardLeaders, nil)
+		}
 	}
 }
 
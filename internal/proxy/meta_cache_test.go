@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 
@@ -405,6 +406,52 @@ func TestMetaCache_GetShards(t *testing.T) {
 		assert.Equal(t, 1, len(shards))
 		assert.Equal(t, 3, len(shards["channel-1"]))
 	})
+
+	t.Run("shardLeaders in collection info expired", func(t *testing.T) {
+		originExpiration := Params.ProxyCfg.ShardLeaderCacheExpiration
+		Params.ProxyCfg.ShardLeaderCacheExpiration = time.Millisecond
+		defer func() { Params.ProxyCfg.ShardLeaderCacheExpiration = originExpiration }()
+
+		time.Sleep(10 * time.Millisecond)
+		qc.validShardLeaders = false
+		shards, err := globalMetaCache.GetShards(ctx, true, collectionName)
+		assert.Error(t, err)
+		assert.Empty(t, shards)
+	})
+}
+
+func TestMetaCache_GetReadOnlyShards(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		collectionName = "collection1"
+	)
+
+	rootCoord := &MockRootCoordClientInterface{}
+	qc := NewQueryCoordMock()
+	shardMgr := newShardClientMgr()
+	err := InitMetaCache(ctx, rootCoord, qc, shardMgr)
+	require.Nil(t, err)
+
+	qc.Init()
+	qc.Start()
+	defer qc.Stop()
+
+	qc.validShardLeaders = true
+	shards, err := globalMetaCache.GetReadOnlyShards(ctx, true, collectionName)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(shards["channel-1"]))
+	assert.Equal(t, int64(4), shards["channel-1"][0].nodeID)
+
+	// the leaders of the read-only replicas are cached apart
+	shards, err = globalMetaCache.GetShards(ctx, true, collectionName)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(shards["channel-1"]))
+
+	globalMetaCache.ClearShards(collectionName)
+	qc.validShardLeaders = false
+	shards, err = globalMetaCache.GetReadOnlyShards(ctx, true, collectionName)
+	assert.Error(t, err)
+	assert.Empty(t, shards)
 }
 
 func TestMetaCache_ClearShards(t *testing.T) {
@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+)
+
+// PresignURL returns a url which grants temporary direct access to an object in the persistent storage,
+// only root and admin users are allowed to request it when authorization is enabled.
+func (node *Proxy) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	if !node.checkHealthy() {
+		return "", errors.New("proxy is not healthy")
+	}
+	if !Params.ProxyCfg.PresignURLEnabled {
+		return "", errors.New("presigned url is disabled, set proxy.presignURL.enabled to enable it")
+	}
+	if err := checkPresignPrivilege(ctx); err != nil {
+		return "", err
+	}
+
+	method = strings.ToUpper(method)
+	if !funcutil.SliceContain([]string{"GET", "HEAD", "PUT"}, method) {
+		return "", fmt.Errorf("unsupported presign method: %s", method)
+	}
+	if expiry <= 0 || expiry > Params.ProxyCfg.PresignURLMaxExpiry {
+		expiry = Params.ProxyCfg.PresignURLMaxExpiry
+	}
+
+	cm, err := node.getChunkManager(ctx)
+	if err != nil {
+		return "", err
+	}
+	// keep the object inside the root path, "../" can't be used to escape it
+	objectPath := path.Join(cm.RootPath(), path.Clean("/"+filePath))
+	return cm.PresignURL(ctx, objectPath, method, expiry)
+}
+
+func checkPresignPrivilege(ctx context.Context) error {
+	if !Params.CommonCfg.AuthorizationEnabled {
+		return nil
+	}
+	username, err := GetCurUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if username == util.UserRoot {
+		return nil
+	}
+	roles, err := GetRole(username)
+	if err != nil {
+		return err
+	}
+	if funcutil.SliceContain(roles, util.RoleAdmin) {
+		return nil
+	}
+	return fmt.Errorf("user %s is not allowed to get presigned url", username)
+}
+
+func (node *Proxy) getChunkManager(ctx context.Context) (storage.ChunkManager, error) {
+	node.chunkManagerMu.Lock()
+	defer node.chunkManagerMu.Unlock()
+	if node.chunkManager != nil {
+		return node.chunkManager, nil
+	}
+	cm, err := node.factory.NewPersistentStorageChunkManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	node.chunkManager = cm
+	return cm, nil
+}
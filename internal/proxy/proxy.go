@@ -37,6 +37,7 @@ import (
 	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proxy/accesslog"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/commonpbutil"
 	"github.com/milvus-io/milvus/internal/util/dependency"
@@ -104,6 +105,13 @@ type Proxy struct {
 
 	factory dependency.Factory
 
+	chunkManagerMu sync.Mutex
+	chunkManager   storage.ChunkManager
+
+	// lastQueryResultCleanup is when the expired spilled query results were removed last time
+	queryResultCleanupMu   sync.Mutex
+	lastQueryResultCleanup time.Time
+
 	searchResultCh chan *internalpb.SearchResults
 
 	// Add callback functions at different stages
@@ -360,6 +368,9 @@ func (node *Proxy) Start() error {
 
 	node.sendChannelsTimeTickLoop()
 
+	node.wg.Add(1)
+	go node.syncServiceableLagLoop()
+
 	// Start callbacks
 	for _, cb := range node.startCallbacks {
 		cb()
@@ -22,6 +22,9 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/uniquegenerator"
 
@@ -400,6 +403,21 @@ func (coord *QueryCoordMock) GetShardLeaders(ctx context.Context, req *querypb.G
 		}, nil
 	}
 
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && coord.validShardLeaders && len(md.Get(common.ReadOnlyReplicaKey)) > 0 {
+		return &querypb.GetShardLeadersResponse{
+			Status: &commonpb.Status{
+				ErrorCode: commonpb.ErrorCode_Success,
+			},
+			Shards: []*querypb.ShardLeadersList{
+				{
+					ChannelName: "channel-1",
+					NodeIds:     []int64{4},
+					NodeAddrs:   []string{"localhost:9003"},
+				},
+			},
+		}, nil
+	}
+
 	if coord.validShardLeaders {
 		return &querypb.GetShardLeadersResponse{
 			Status: &commonpb.Status{
@@ -0,0 +1,167 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+const (
+	// SpillResultKey is the query param to accept the reference of the result spilled to the object storage,
+	// instead of failing the query if its result exceeds ProxyCfg.QueryResultSpillThreshold.
+	SpillResultKey = "spill_result"
+
+	// The response headers of the spilled result, which is the marshaled milvuspb.QueryResults.
+	// QueryResultURLHeader is the presigned url to download the result before QueryResultExpireAtHeader,
+	// QueryResultPathHeader is its path in the object storage, and QueryResultSHA256Header is its hex sha256.
+	QueryResultURLHeader      = "query-result-url"
+	QueryResultPathHeader     = "query-result-path"
+	QueryResultExpireAtHeader = "query-result-expire-at"
+	QueryResultSHA256Header   = "query-result-sha256"
+
+	// queryResultSpillDir is the dir of the spilled results under the root path of the object storage
+	queryResultSpillDir = "query_results"
+)
+
+// queryResultReference is the reference of a query result spilled to the object storage.
+type queryResultReference struct {
+	path     string
+	url      string
+	expireAt time.Time
+	checksum string
+}
+
+// isSpillResultAccepted returns whether the query accepts its result to be spilled.
+// The reference of the spilled result is responded in the grpc headers, so the query not from grpc, e.g. from
+// the http server, is rejected if it accepts the spilled result, instead of responding an empty result.
+func isSpillResultAccepted(ctx context.Context, params []*commonpb.KeyValuePair) (bool, error) {
+	if !Params.ProxyCfg.QueryResultSpillEnabled {
+		return false, nil
+	}
+	value, err := funcutil.GetAttrByKeyFromRepeatedKV(SpillResultKey, params)
+	if err != nil {
+		return false, nil
+	}
+	accepted, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s [%s] is invalid, should be a bool", SpillResultKey, value)
+	}
+	if accepted && grpc.ServerTransportStreamFromContext(ctx) == nil {
+		return false, fmt.Errorf("%s is only supported by grpc requests", SpillResultKey)
+	}
+	return accepted, nil
+}
+
+// spillQueryResult writes @result of the query task @taskID to the object storage,
+// and returns the reference to download it.
+func (node *Proxy) spillQueryResult(ctx context.Context, collection string, taskID UniqueID, result *milvuspb.QueryResults) (*queryResultReference, error) {
+	cm, err := node.getChunkManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	content, err := proto.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+	ref := &queryResultReference{
+		path:     path.Join(cm.RootPath(), queryResultSpillDir, collection, fmt.Sprintf("%d-%d", paramtable.GetNodeID(), taskID)),
+		expireAt: time.Now().Add(Params.ProxyCfg.QueryResultSpillExpiry),
+		checksum: hex.EncodeToString(sum[:]),
+	}
+	if err := cm.Write(ctx, ref.path, content); err != nil {
+		return nil, err
+	}
+	ref.url, err = cm.PresignURL(ctx, ref.path, http.MethodGet, Params.ProxyCfg.QueryResultSpillExpiry)
+	if err != nil {
+		return nil, err
+	}
+	node.removeExpiredQueryResults(cm)
+	return ref, nil
+}
+
+// removeSpilledQueryResult removes the spilled result whose reference can't be responded.
+func (node *Proxy) removeSpilledQueryResult(ref *queryResultReference) {
+	cm, err := node.getChunkManager(node.ctx)
+	if err == nil {
+		err = cm.Remove(node.ctx, ref.path)
+	}
+	if err != nil {
+		log.Warn("failed to remove spilled query result", zap.String("path", ref.path), zap.Error(err))
+	}
+}
+
+// removeExpiredQueryResults removes the spilled results whose urls expired in the background,
+// at most once per half of the expiry.
+func (node *Proxy) removeExpiredQueryResults(cm storage.ChunkManager) {
+	expiry := Params.ProxyCfg.QueryResultSpillExpiry
+	node.queryResultCleanupMu.Lock()
+	if time.Since(node.lastQueryResultCleanup) < expiry/2 {
+		node.queryResultCleanupMu.Unlock()
+		return
+	}
+	node.lastQueryResultCleanup = time.Now()
+	node.queryResultCleanupMu.Unlock()
+
+	go func() {
+		ctx := node.ctx
+		var expired []string
+		err := cm.WalkWithPrefix(ctx, path.Join(cm.RootPath(), queryResultSpillDir)+"/", true, func(info storage.ChunkObjectInfo) bool {
+			if time.Since(info.ModifyTime) > expiry {
+				expired = append(expired, info.FilePath)
+			}
+			return true
+		})
+		if err == nil && len(expired) > 0 {
+			err = cm.MultiRemove(ctx, expired)
+		}
+		if err != nil {
+			log.Warn("failed to remove expired query results", zap.Error(err))
+			return
+		}
+		log.Debug("removed expired query results", zap.Int("count", len(expired)))
+	}()
+}
+
+// setQueryResultHeader sets the response headers of the spilled result.
+func setQueryResultHeader(ctx context.Context, ref *queryResultReference) error {
+	md := metadata.Pairs(
+		QueryResultURLHeader, ref.url,
+		QueryResultPathHeader, ref.path,
+		QueryResultExpireAtHeader, ref.expireAt.UTC().Format(time.RFC3339),
+		QueryResultSHA256Header, ref.checksum)
+	return grpc.SetHeader(ctx, md)
+}
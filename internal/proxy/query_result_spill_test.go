@@ -0,0 +1,159 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// fakeServerTransportStream records the headers set by the grpc handler.
+type fakeServerTransportStream struct {
+	header metadata.MD
+}
+
+func (s *fakeServerTransportStream) Method() string {
+	return "/milvus.proto.milvus.MilvusService/Query"
+}
+
+func (s *fakeServerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *fakeServerTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *fakeServerTransportStream) SetTrailer(md metadata.MD) error {
+	return nil
+}
+
+func TestIsSpillResultAccepted(t *testing.T) {
+	paramtable.Init()
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &fakeServerTransportStream{})
+	params := []*commonpb.KeyValuePair{{Key: SpillResultKey, Value: "true"}}
+
+	Params.ProxyCfg.QueryResultSpillEnabled = false
+	accepted, err := isSpillResultAccepted(ctx, params)
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+
+	Params.ProxyCfg.QueryResultSpillEnabled = true
+	defer func() { Params.ProxyCfg.QueryResultSpillEnabled = false }()
+	accepted, err = isSpillResultAccepted(ctx, params)
+	assert.NoError(t, err)
+	assert.True(t, accepted)
+
+	accepted, err = isSpillResultAccepted(ctx, nil)
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+
+	_, err = isSpillResultAccepted(ctx, []*commonpb.KeyValuePair{{Key: SpillResultKey, Value: "yes"}})
+	assert.Error(t, err)
+
+	// the reference can't be responded without grpc headers, e.g. to the http requests
+	_, err = isSpillResultAccepted(context.Background(), params)
+	assert.Error(t, err)
+	accepted, err = isSpillResultAccepted(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+}
+
+func TestSetQueryResultHeader(t *testing.T) {
+	ref := &queryResultReference{path: "files/query_results/c/1-2", url: "http://url", expireAt: time.Now(), checksum: "sum"}
+	assert.Error(t, setQueryResultHeader(context.Background(), ref))
+
+	stream := &fakeServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	assert.NoError(t, setQueryResultHeader(ctx, ref))
+	assert.Equal(t, []string{ref.path}, stream.header.Get(QueryResultPathHeader))
+	assert.Equal(t, []string{ref.url}, stream.header.Get(QueryResultURLHeader))
+}
+
+func TestProxy_SpillQueryResult(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	expiry := Params.ProxyCfg.QueryResultSpillExpiry
+	result := &milvuspb.QueryResults{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		FieldsData: []*schemapb.FieldData{{
+			Type:      schemapb.DataType_Int64,
+			FieldName: "pk",
+			Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 2, 3}}},
+			}},
+		}},
+	}
+	content, err := proto.Marshal(result)
+	require.NoError(t, err)
+	sum := sha256.Sum256(content)
+	resultPath := fmt.Sprintf("files/query_results/col/%d-3", paramtable.GetNodeID())
+
+	cm := mocks.NewChunkManager(t)
+	cm.EXPECT().RootPath().Return("files")
+	cm.EXPECT().Write(mock.Anything, resultPath, content).Return(nil).Once()
+	cm.EXPECT().PresignURL(mock.Anything, resultPath, "GET", expiry).Return("http://minio/result", nil).Once()
+	cm.EXPECT().WalkWithPrefix(mock.Anything, "files/query_results/", true, mock.Anything).Run(
+		func(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) {
+			walkFunc(storage.ChunkObjectInfo{FilePath: "files/query_results/col/1-1", ModifyTime: time.Now().Add(-2 * expiry)})
+			walkFunc(storage.ChunkObjectInfo{FilePath: "files/query_results/col/1-2", ModifyTime: time.Now()})
+		}).Return(nil).Once()
+	removed := make(chan []string, 1)
+	cm.EXPECT().MultiRemove(mock.Anything, mock.Anything).Run(func(ctx context.Context, filePaths []string) {
+		removed <- filePaths
+	}).Return(nil).Once()
+
+	node := &Proxy{ctx: ctx, chunkManager: cm}
+	ref, err := node.spillQueryResult(ctx, "col", 3, result)
+	require.NoError(t, err)
+	assert.Equal(t, resultPath, ref.path)
+	assert.Equal(t, "http://minio/result", ref.url)
+	assert.Equal(t, hex.EncodeToString(sum[:]), ref.checksum)
+	assert.WithinDuration(t, time.Now().Add(expiry), ref.expireAt, time.Minute)
+	assert.Equal(t, []string{"files/query_results/col/1-1"}, <-removed)
+
+	// the expired results are not removed again within half of the expiry
+	node.removeExpiredQueryResults(cm)
+
+	// the result is removed if its reference can't be responded
+	cm.EXPECT().Remove(mock.Anything, resultPath).Return(nil).Once()
+	node.removeSpilledQueryResult(ref)
+
+	cm.EXPECT().Write(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mock error")).Once()
+	_, err = node.spillQueryResult(ctx, "col", 4, result)
+	assert.Error(t, err)
+}
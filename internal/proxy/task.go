@@ -34,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/indexpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/commonpbutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
@@ -167,6 +168,16 @@ func (cct *createCollectionTask) PreExecute(ctx context.Context) error {
 		return fmt.Errorf("maximum field's number should be limited to %d", Params.ProxyCfg.MaxFieldNum)
 	}
 
+	// validate shard routing
+	if _, err := typeutil.GetShardRouting(cct.GetProperties()); err != nil {
+		return err
+	}
+
+	// validate payload compression
+	if _, err := storage.GetPayloadCompression(cct.GetProperties()); err != nil {
+		return err
+	}
+
 	// validate collection name
 	if err := validateCollectionName(cct.schema.Name); err != nil {
 		return err
@@ -212,6 +223,10 @@ func (cct *createCollectionTask) PreExecute(ctx context.Context) error {
 				return err
 			}
 		}
+		// validate the analyzer applied to the text of varChar field
+		if err := validateFieldAnalyzer(field); err != nil {
+			return err
+		}
 	}
 
 	if err := validateMultipleVectorFields(cct.schema); err != nil {
@@ -708,6 +723,9 @@ func (act *alterCollectionTask) PreExecute(ctx context.Context) error {
 	act.Base.MsgType = commonpb.MsgType_AlterCollection
 	act.Base.SourceID = paramtable.GetNodeID()
 
+	if _, err := storage.GetPayloadCompression(act.GetProperties()); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1377,6 +1395,8 @@ func (lct *loadCollectionTask) PostExecute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// the replicas may be changed, refresh the shard leaders
+	globalMetaCache.ClearShards(lct.CollectionName)
 	return nil
 }
 
@@ -247,7 +247,13 @@ func (dt *deleteTask) Execute(ctx context.Context) (err error) {
 		dt.result.Status.Reason = err.Error()
 		return err
 	}
-	dt.HashValues = typeutil.HashPK2Channels(dt.result.IDs, channelNames)
+	shardRouting, err := getShardRouting(ctx, dt.CollectionName)
+	if err != nil {
+		dt.result.Status.ErrorCode = commonpb.ErrorCode_UnexpectedError
+		dt.result.Status.Reason = err.Error()
+		return err
+	}
+	dt.HashValues = typeutil.HashPK2ChannelsWithRouting(dt.result.IDs, channelNames, shardRouting)
 
 	log.Debug("send delete request to virtual channels",
 		zap.String("collection", dt.GetCollectionName()),
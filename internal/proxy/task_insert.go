@@ -13,7 +13,9 @@ import (
 	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/mq/msgstream"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util"
 	"github.com/milvus-io/milvus/internal/util/commonpbutil"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/milvus-io/milvus/internal/util/timerecord"
@@ -36,6 +38,9 @@ type insertTask struct {
 	vChannels     []vChan
 	pChannels     []pChan
 	schema        *schemapb.CollectionSchema
+
+	// offsets of the rows dropped by the invalid vector policy, in the numbering of the request
+	skippedRows []uint32
 }
 
 // TraceCtx returns insertTask context
@@ -169,6 +174,101 @@ func (it *insertTask) checkPrimaryFieldData() error {
 	return nil
 }
 
+// checkFieldsDataAligned checks that the passed fields have the same number of rows as NumRows,
+// it must run before the field data is read row by row, e.g. by checkVectorFieldData.
+func (it *insertTask) checkFieldsDataAligned() error {
+	rowNums := it.NRows()
+	if rowNums <= 0 {
+		return errNumRowsLessThanOrEqualToZero(uint32(rowNums))
+	}
+	for _, fieldData := range it.GetFieldsData() {
+		fieldNumRows, err := funcutil.GetNumRowOfFieldData(fieldData)
+		if err != nil {
+			return err
+		}
+		if fieldNumRows != rowNums {
+			return fmt.Errorf("the num_rows(%d) of %sth field is not equal to passed NumRows(%d)", fieldNumRows, fieldData.GetFieldName(), rowNums)
+		}
+	}
+	return nil
+}
+
+// checkVectorFieldData checks float vectors for NaN/Inf (and all-zero vectors if configured),
+// then applies the configured invalid vector policy on the rows holding them. Zero-fill only repairs NaN/Inf,
+// the rows holding all-zero vectors can't be repaired by it, so they're skipped and reported in ErrIndex instead.
+func (it *insertTask) checkVectorFieldData() error {
+	policy := Params.ProxyCfg.InvalidVectorPolicy
+	checkZero := Params.ProxyCfg.RejectZeroVector
+	nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
+
+	invalidRows := make(map[int]struct{})
+	zeroFilled := 0
+	for _, fieldData := range it.GetFieldsData() {
+		floatVector := fieldData.GetVectors().GetFloatVector()
+		if floatVector == nil {
+			continue
+		}
+		dim := int(fieldData.GetVectors().GetDim())
+		offsets, reasons := typeutil.FindInvalidFloatVectors(floatVector.GetData(), dim, checkZero)
+		for _, reason := range reasons {
+			metrics.ProxyInvalidVectors.WithLabelValues(nodeID, reason.String()).Inc()
+		}
+		if len(offsets) == 0 {
+			continue
+		}
+
+		switch policy {
+		case util.InvalidVectorPolicyZeroFill:
+			for i, offset := range offsets {
+				if reasons[i] == typeutil.ZeroVector {
+					invalidRows[offset] = struct{}{}
+					continue
+				}
+				vector := floatVector.Data[offset*dim : (offset+1)*dim]
+				for j := range vector {
+					vector[j] = 0
+				}
+				zeroFilled++
+			}
+		case util.InvalidVectorPolicySkip:
+			for _, offset := range offsets {
+				invalidRows[offset] = struct{}{}
+			}
+		default:
+			return fmt.Errorf("invalid vector of field %s at row %d: %s, %d invalid vectors found",
+				fieldData.GetFieldName(), offsets[0], reasons[0].String(), len(offsets))
+		}
+	}
+
+	if zeroFilled > 0 {
+		log.Warn("invalid vectors are zero-filled",
+			zap.String("collectionName", it.CollectionName),
+			zap.Int("count", zeroFilled))
+	}
+	if len(invalidRows) == 0 {
+		return nil
+	}
+
+	rowNum := int(it.NRows())
+	if len(invalidRows) == rowNum {
+		return fmt.Errorf("all %d rows contain invalid vectors", rowNum)
+	}
+	fieldsData := make([]*schemapb.FieldData, len(it.GetFieldsData()))
+	for i := 0; i < rowNum; i++ {
+		if _, ok := invalidRows[i]; ok {
+			it.skippedRows = append(it.skippedRows, uint32(i))
+			continue
+		}
+		typeutil.AppendFieldData(fieldsData, it.GetFieldsData(), int64(i))
+	}
+	it.FieldsData = fieldsData
+	it.NumRows = uint64(rowNum - len(it.skippedRows))
+	log.Warn("rows with invalid vectors are skipped",
+		zap.String("collectionName", it.CollectionName),
+		zap.Int("count", len(it.skippedRows)))
+	return nil
+}
+
 func (it *insertTask) PreExecute(ctx context.Context) error {
 	sp, ctx := trace.StartSpanFromContextWithOperationName(it.ctx, "Proxy-Insert-PreExecute")
 	defer sp.Finish()
@@ -202,6 +302,17 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 	}
 	it.schema = collSchema
 
+	// check the alignment before the vectors are checked, the skipped rows are cut from every field by row offset
+	if err := it.checkFieldsDataAligned(); err != nil {
+		log.Error("field data is not aligned", zap.String("collectionName", collectionName), zap.Error(err))
+		return err
+	}
+
+	if err := it.checkVectorFieldData(); err != nil {
+		log.Error("check vector field data failed", zap.String("collectionName", collectionName), zap.Error(err))
+		return err
+	}
+
 	rowNums := uint32(it.NRows())
 	// set insertTask.rowIDs
 	var rowIDBegin UniqueID
@@ -222,12 +333,18 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 		it.Timestamps[index] = it.BeginTimestamp
 	}
 
-	// set result.SuccIndex
-	sliceIndex := make([]uint32, rowNums)
-	for i := uint32(0); i < rowNums; i++ {
-		sliceIndex[i] = i
+	// set result.SuccIndex, the skipped rows are reported in result.ErrIndex
+	sliceIndex := make([]uint32, 0, rowNums)
+	skipped := it.skippedRows
+	for i := uint32(0); uint32(len(sliceIndex)) < rowNums; i++ {
+		if len(skipped) > 0 && skipped[0] == i {
+			skipped = skipped[1:]
+			continue
+		}
+		sliceIndex = append(sliceIndex, i)
 	}
 	it.result.SuccIndex = sliceIndex
+	it.result.ErrIndex = it.skippedRows
 
 	// check primaryFieldData whether autoID is true or not
 	// set rowIDs as primary data if autoID == true
@@ -254,12 +371,18 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if err = it.checkAnalyzedFieldData(); err != nil {
+		log.Error("check analyzed field data failed",
+			zap.Error(err))
+		return err
+	}
+
 	log.Debug("Proxy Insert PreExecute done")
 
 	return nil
 }
 
-func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack, error) {
+func (it *insertTask) assignSegmentID(channelNames []string, shardRouting string) (*msgstream.MsgPack, error) {
 	threshold := Params.PulsarCfg.MaxMessageSize.GetAsInt()
 	log.Debug("assign segmentid", zap.Int("threshold", threshold))
 
@@ -272,7 +395,7 @@ func (it *insertTask) assignSegmentID(channelNames []string) (*msgstream.MsgPack
 	if len(it.HashValues) != 0 {
 		log.Warn("the hashvalues passed through client is not supported now, and will be overwritten")
 	}
-	it.HashValues = typeutil.HashPK2Channels(it.result.IDs, channelNames)
+	it.HashValues = typeutil.HashPK2ChannelsWithRouting(it.result.IDs, channelNames, shardRouting)
 	// groupedHashKeys represents the dmChannel index
 	channel2RowOffsets := make(map[string][]int)  //   channelName to count
 	channelMaxTSMap := make(map[string]Timestamp) //  channelName to max Timestamp
@@ -439,6 +562,10 @@ func (it *insertTask) Execute(ctx context.Context) error {
 		}
 	}
 	it.PartitionID = partitionID
+	shardRouting, err := getShardRouting(ctx, collectionName)
+	if err != nil {
+		return err
+	}
 	tr.Record("get collection id & partition id from cache")
 
 	stream, err := it.chMgr.getOrCreateDmlStream(collID)
@@ -466,7 +593,7 @@ func (it *insertTask) Execute(ctx context.Context) error {
 		zap.Int64("task_id", it.ID()))
 
 	// assign segmentID for insert data and repack data by segmentID
-	msgPack, err := it.assignSegmentID(channelNames)
+	msgPack, err := it.assignSegmentID(channelNames, shardRouting)
 	if err != nil {
 		log.Error("assign segmentID and repack insert data failed",
 			zap.Int64("collectionID", collID),
@@ -1,11 +1,14 @@
 package proxy
 
 import (
+	"math"
 	"testing"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -346,3 +349,104 @@ func TestInsertTask_CheckAligned(t *testing.T) {
 	err = case2.CheckAligned()
 	assert.NoError(t, err)
 }
+
+func TestInsertTask_checkVectorFieldData(t *testing.T) {
+	oldPolicy := Params.ProxyCfg.InvalidVectorPolicy
+	defer func() {
+		Params.ProxyCfg.InvalidVectorPolicy = oldPolicy
+	}()
+
+	nan := float32(math.NaN())
+	newTask := func() *insertTask {
+		return &insertTask{
+			result: &milvuspb.MutationResult{
+				Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+			},
+			BaseInsertTask: BaseInsertTask{
+				InsertRequest: internalpb.InsertRequest{
+					CollectionName: "TestInsertTask_checkVectorFieldData",
+					NumRows:        3,
+					Version:        internalpb.InsertDataVersion_ColumnBased,
+					FieldsData: []*schemapb.FieldData{
+						{
+							Type:      schemapb.DataType_Int64,
+							FieldName: "pk",
+							Field: &schemapb.FieldData_Scalars{
+								Scalars: &schemapb.ScalarField{
+									Data: &schemapb.ScalarField_LongData{
+										LongData: &schemapb.LongArray{Data: []int64{1, 2, 3}},
+									},
+								},
+							},
+						},
+						{
+							Type:      schemapb.DataType_FloatVector,
+							FieldName: "vec",
+							Field: &schemapb.FieldData_Vectors{
+								Vectors: &schemapb.VectorField{
+									Dim: 2,
+									Data: &schemapb.VectorField_FloatVector{
+										FloatVector: &schemapb.FloatArray{Data: []float32{1, 1, nan, 1, 2, 2}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		Params.ProxyCfg.InvalidVectorPolicy = util.InvalidVectorPolicyReject
+		it := newTask()
+		assert.Error(t, it.checkVectorFieldData())
+	})
+
+	t.Run("zero fill", func(t *testing.T) {
+		Params.ProxyCfg.InvalidVectorPolicy = util.InvalidVectorPolicyZeroFill
+		it := newTask()
+		assert.NoError(t, it.checkVectorFieldData())
+		assert.Equal(t, []float32{1, 1, 0, 0, 2, 2}, it.FieldsData[1].GetVectors().GetFloatVector().GetData())
+		assert.Equal(t, uint64(3), it.NRows())
+		assert.Empty(t, it.skippedRows)
+		assert.Empty(t, it.result.GetStatus().GetReason())
+
+		// all-zero vectors can't be repaired, their rows are skipped
+		Params.ProxyCfg.RejectZeroVector = true
+		defer func() { Params.ProxyCfg.RejectZeroVector = false }()
+		it = newTask()
+		it.FieldsData[1].GetVectors().GetFloatVector().Data = []float32{1, 1, nan, 1, 0, 0}
+		assert.NoError(t, it.checkVectorFieldData())
+		assert.Equal(t, []uint32{2}, it.skippedRows)
+		assert.Equal(t, uint64(2), it.NRows())
+		assert.Equal(t, []float32{1, 1, 0, 0}, it.FieldsData[1].GetVectors().GetFloatVector().GetData())
+		assert.Empty(t, it.result.GetStatus().GetReason())
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		Params.ProxyCfg.InvalidVectorPolicy = util.InvalidVectorPolicySkip
+		it := newTask()
+		assert.NoError(t, it.checkVectorFieldData())
+		assert.Equal(t, []uint32{1}, it.skippedRows)
+		assert.Equal(t, uint64(2), it.NRows())
+		assert.Equal(t, []int64{1, 3}, it.FieldsData[0].GetScalars().GetLongData().GetData())
+		assert.Equal(t, []float32{1, 1, 2, 2}, it.FieldsData[1].GetVectors().GetFloatVector().GetData())
+		assert.NoError(t, it.CheckAligned())
+	})
+	t.Run("misaligned", func(t *testing.T) {
+		Params.ProxyCfg.InvalidVectorPolicy = util.InvalidVectorPolicySkip
+		it := newTask()
+		assert.NoError(t, it.checkFieldsDataAligned())
+
+		it.NumRows = 4
+		assert.Error(t, it.checkFieldsDataAligned())
+
+		it = newTask()
+		it.FieldsData[0].GetScalars().GetLongData().Data = []int64{1, 2}
+		assert.Error(t, it.checkFieldsDataAligned())
+
+		it.NumRows = 0
+		assert.Error(t, it.checkFieldsDataAligned())
+	})
+}
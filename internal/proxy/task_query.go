@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/parser/planparserv2"
@@ -28,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog"
 )
 
 const (
@@ -57,6 +59,11 @@ type queryTask struct {
 
 	queryShardPolicy pickShardPolicy
 	shardMgr         *shardClientMgr
+
+	// staleness is how far the guarantee timestamp lags behind the request
+	staleness time.Duration
+	// readOnlyReplica routes the query to the read-only replicas
+	readOnlyReplica bool
 }
 
 type queryParams struct {
@@ -223,6 +230,11 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	t.queryParams = queryParams
 	t.RetrieveRequest.Limit = queryParams.limit + queryParams.offset
 
+	t.readOnlyReplica, err = isReadOnlyReplicaTargeted(t.request.GetQueryParams())
+	if err != nil {
+		return err
+	}
+
 	loaded, err := checkIfLoaded(ctx, t.qc, collectionName, t.RetrieveRequest.GetPartitionIDs())
 	if err != nil {
 		return fmt.Errorf("checkIfLoaded failed when query, collection:%v, partitions:%v, err = %s", collectionName, t.request.GetPartitionNames(), err)
@@ -286,7 +298,10 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	}
 
 	guaranteeTs := t.request.GetGuaranteeTimestamp()
-	t.GuaranteeTimestamp = parseGuaranteeTs(guaranteeTs, t.BeginTs())
+	t.GuaranteeTimestamp, t.staleness, err = parseAdaptiveGuaranteeTs(collID, guaranteeTs, t.BeginTs(), t.request.GetQueryParams())
+	if err != nil {
+		return err
+	}
 
 	deadline, ok := t.TraceCtx().Deadline()
 	if ok {
@@ -306,8 +321,12 @@ func (t *queryTask) Execute(ctx context.Context) error {
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("proxy execute query %d", t.ID()))
 	defer tr.CtxElapse(ctx, "done")
 
+	getShards := globalMetaCache.GetShards
+	if t.readOnlyReplica {
+		getShards = globalMetaCache.GetReadOnlyShards
+	}
 	executeQuery := func(withCache bool) error {
-		shards, err := globalMetaCache.GetShards(ctx, withCache, t.collectionName)
+		shards, err := getShards(ctx, withCache, t.collectionName)
 		if err != nil {
 			return err
 		}
@@ -335,6 +354,26 @@ func (t *queryTask) Execute(ctx context.Context) error {
 	return nil
 }
 
+// auditSegmentAccess records the segments and channels read by the query into the access log if sampled
+func (t *queryTask) auditSegmentAccess(ctx context.Context) {
+	if !sampleSegmentAccess() {
+		return
+	}
+	access := &accesslog.SegmentAccess{
+		Method:         "Query",
+		DbName:         t.request.GetDbName(),
+		CollectionName: t.collectionName,
+		CollectionID:   t.GetCollectionID(),
+		PartitionIDs:   t.GetPartitionIDs(),
+	}
+	access.User, _ = GetCurUserFromContext(ctx)
+	for _, r := range t.toReduceResults {
+		access.SegmentIDs = append(access.SegmentIDs, r.GetSealedSegmentIDsRetrieved()...)
+		access.Channels = append(access.Channels, r.GetChannelIDsRetrieved()...)
+	}
+	accesslog.PrintSegmentAccess(ctx, access)
+}
+
 func (t *queryTask) PostExecute(ctx context.Context) error {
 	tr := timerecord.NewTimeRecorder("queryTask PostExecute")
 	defer func() {
@@ -356,6 +395,8 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 		}
 	}
 
+	t.auditSegmentAccess(ctx)
+
 	metrics.ProxyDecodeResultLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.QueryLabel).Observe(0.0)
 	tr.CtxRecord(ctx, "reduceResultStart")
 	t.result, err = reduceRetrieveResults(ctx, t.toReduceResults, t.queryParams)
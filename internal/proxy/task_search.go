@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/parser/planparserv2"
@@ -31,9 +32,11 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog"
 )
 
 const (
@@ -59,6 +62,16 @@ type searchTask struct {
 
 	searchShardPolicy pickShardPolicy
 	shardMgr          *shardClientMgr
+
+	// staleness is how far the guarantee timestamp lags behind the request
+	staleness time.Duration
+	// readOnlyReplica routes the search to the read-only replicas
+	readOnlyReplica bool
+
+	// dc serves the fresh search over the insert buffers of the DataNodes
+	dc          types.DataCoord
+	freshReq    *datapb.FreshSearchRequest
+	freshResult chan []*schemapb.SearchResultData
 }
 
 func getPartitionIDs(ctx context.Context, collectionName string, partitionNames []string) (partitionIDs []UniqueID, err error) {
@@ -279,6 +292,11 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	t.SearchRequest.CollectionID = collID
 	t.schema, _ = globalMetaCache.GetCollectionSchema(ctx, collectionName)
 
+	t.readOnlyReplica, err = isReadOnlyReplicaTargeted(t.request.GetSearchParams())
+	if err != nil {
+		return err
+	}
+
 	// translate partition name to partition ids. Use regex-pattern to match partition name.
 	t.SearchRequest.PartitionIDs, err = getPartitionIDs(ctx, collectionName, t.request.GetPartitionNames())
 	if err != nil {
@@ -343,6 +361,18 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		log.Ctx(ctx).Debug("Proxy::searchTask::PreExecute",
 			zap.Int64s("plan.OutputFieldIds", plan.GetOutputFieldIds()),
 			zap.String("plan", plan.String())) // may be very large if large term passed.
+
+		fresh, err := isFreshSearchEnabled(t.request.GetSearchParams())
+		if err != nil {
+			return err
+		}
+		if fresh {
+			t.freshReq, err = newFreshSearchRequest(t.schema, annsField, t)
+			if err != nil {
+				log.Ctx(ctx).Warn("skip fresh search", zap.Error(err))
+				t.freshReq = nil
+			}
+		}
 	}
 
 	travelTimestamp := t.request.TravelTimestamp
@@ -355,8 +385,16 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	}
 	t.SearchRequest.TravelTimestamp = travelTimestamp
 
+	searchParams := t.request.GetSearchParams()
+	if t.freshReq != nil {
+		t.freshReq.TravelTimestamp = travelTimestamp
+		searchParams = withFreshSearchStaleness(searchParams)
+	}
 	guaranteeTs := t.request.GetGuaranteeTimestamp()
-	guaranteeTs = parseGuaranteeTs(guaranteeTs, t.BeginTs())
+	guaranteeTs, t.staleness, err = parseAdaptiveGuaranteeTs(collID, guaranteeTs, t.BeginTs(), searchParams)
+	if err != nil {
+		return err
+	}
 	t.SearchRequest.GuaranteeTimestamp = guaranteeTs
 
 	if deadline, ok := t.TraceCtx().Deadline(); ok {
@@ -376,6 +414,9 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		return fmt.Errorf("%s [%d] is invalid, %w", NQKey, nq, err)
 	}
 	t.SearchRequest.Nq = nq
+	if t.freshReq != nil && t.freshReq.GetNq() != nq {
+		t.freshReq = nil
+	}
 
 	log.Ctx(ctx).Debug("search PreExecute done.",
 		zap.Uint64("travel_ts", travelTimestamp), zap.Uint64("guarantee_ts", guaranteeTs),
@@ -391,8 +432,14 @@ func (t *searchTask) Execute(ctx context.Context) error {
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("proxy execute search %d", t.ID()))
 	defer tr.CtxElapse(ctx, "done")
 
+	t.startFreshSearch(ctx)
+
+	getShards := globalMetaCache.GetShards
+	if t.readOnlyReplica {
+		getShards = globalMetaCache.GetReadOnlyShards
+	}
 	executeSearch := func(withCache bool) error {
-		shard2Leaders, err := globalMetaCache.GetShards(ctx, withCache, t.collectionName)
+		shard2Leaders, err := getShards(ctx, withCache, t.collectionName)
 		if err != nil {
 			return err
 		}
@@ -437,6 +484,7 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 	if err := t.collectSearchResults(ctx); err != nil {
 		return err
 	}
+	t.auditSegmentAccess(ctx)
 
 	// Decode all search results
 	tr.CtxRecord(ctx, "decodeResultStart")
@@ -446,6 +494,7 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 	}
 	metrics.ProxyDecodeResultLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10),
 		metrics.SearchLabel).Observe(float64(tr.RecordSpan().Milliseconds()))
+	validSearchResults = append(validSearchResults, t.waitFreshSearch(ctx)...)
 
 	if len(validSearchResults) <= 0 {
 		log.Ctx(ctx).Warn("search result is empty")
@@ -477,6 +526,26 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 	return nil
 }
 
+// auditSegmentAccess records the segments and channels read by the search into the access log if sampled
+func (t *searchTask) auditSegmentAccess(ctx context.Context) {
+	if !sampleSegmentAccess() {
+		return
+	}
+	access := &accesslog.SegmentAccess{
+		Method:         "Search",
+		DbName:         t.request.GetDbName(),
+		CollectionName: t.collectionName,
+		CollectionID:   t.GetCollectionID(),
+		PartitionIDs:   t.GetPartitionIDs(),
+	}
+	access.User, _ = GetCurUserFromContext(ctx)
+	for _, r := range t.toReduceResults {
+		access.SegmentIDs = append(access.SegmentIDs, r.GetSealedSegmentIDsSearched()...)
+		access.Channels = append(access.Channels, r.GetChannelIDsSearched()...)
+	}
+	accesslog.PrintSegmentAccess(ctx, access)
+}
+
 func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.QueryNode, channelIDs []string) error {
 	req := &querypb.SearchRequest{
 		Req:         t.SearchRequest,
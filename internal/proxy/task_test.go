@@ -575,6 +575,20 @@ func TestCreateCollectionTask(t *testing.T) {
 		assert.Error(t, err)
 		task.ShardsNum = shardsNum
 
+		task.Properties = []*commonpb.KeyValuePair{{Key: common.CollectionShardRoutingKey, Value: "ring"}}
+		err = task.PreExecute(ctx)
+		assert.Error(t, err)
+		task.Properties = []*commonpb.KeyValuePair{{Key: common.CollectionShardRoutingKey, Value: common.ShardRoutingJumpHash}}
+		err = task.PreExecute(ctx)
+		assert.NoError(t, err)
+		task.Properties = []*commonpb.KeyValuePair{{Key: common.CollectionPayloadCompressionKey, Value: "snappy"}}
+		err = task.PreExecute(ctx)
+		assert.Error(t, err)
+		task.Properties = []*commonpb.KeyValuePair{{Key: common.CollectionPayloadCompressionKey, Value: "lz4"}}
+		err = task.PreExecute(ctx)
+		assert.NoError(t, err)
+		task.Properties = nil
+
 		reqBackup := proto.Clone(task.CreateCollectionRequest).(*milvuspb.CreateCollectionRequest)
 		schemaBackup := proto.Clone(schema).(*schemapb.CollectionSchema)
 
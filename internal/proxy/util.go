@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog"
 	"github.com/milvus-io/milvus/internal/types"
 
 	"go.uber.org/zap"
@@ -33,9 +34,12 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/util"
+	"github.com/milvus-io/milvus/internal/util/analyzer"
 	"github.com/milvus-io/milvus/internal/util/crypto"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/tsoutil"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
@@ -226,6 +230,9 @@ func validateDimension(field *schemapb.FieldSchema) error {
 func validateMaxLengthPerRow(collectionName string, field *schemapb.FieldSchema) error {
 	exist := false
 	for _, param := range field.TypeParams {
+		if analyzer.IsAnalyzerTypeParam(param.Key) {
+			continue
+		}
 		if param.Key != maxVarCharLengthKey {
 			return fmt.Errorf("type param key(max_length) should be specified for varChar field, not %s", param.Key)
 		}
@@ -624,6 +631,20 @@ func parseGuaranteeTs(ts, tMax typeutil.Timestamp) typeutil.Timestamp {
 	return ts
 }
 
+// isReadOnlyReplicaTargeted returns whether the search or query params target the read-only replicas,
+// which serve the sealed segments as of a snapshot, so they are never used unless targeted.
+func isReadOnlyReplicaTargeted(params []*commonpb.KeyValuePair) (bool, error) {
+	value, err := funcutil.GetAttrByKeyFromRepeatedKV(common.ReadOnlyReplicaKey, params)
+	if err != nil {
+		return false, nil
+	}
+	targeted, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s [%s] is invalid, should be a bool", common.ReadOnlyReplicaKey, value)
+	}
+	return targeted, nil
+}
+
 func validateName(entity string, nameType string) error {
 	entity = strings.TrimSpace(entity)
 
@@ -836,6 +857,18 @@ func validateIndexName(indexName string) error {
 	return nil
 }
 
+// getShardRouting returns how primary keys are routed to the shards of the collection
+func getShardRouting(ctx context.Context, collectionName string) (string, error) {
+	info, err := globalMetaCache.GetCollectionInfo(ctx, collectionName)
+	if err != nil {
+		return "", err
+	}
+	if info == nil || info.shardRouting == "" {
+		return common.ShardRoutingModulo, nil
+	}
+	return info.shardRouting, nil
+}
+
 func isCollectionLoaded(ctx context.Context, qc types.QueryCoord, collID int64) (bool, error) {
 	// get all loading collections
 	resp, err := qc.ShowCollections(ctx, &querypb.ShowCollectionsRequest{
@@ -878,3 +911,9 @@ func isPartitionLoaded(ctx context.Context, qc types.QueryCoord, collID int64, p
 	}
 	return false, nil
 }
+
+// sampleSegmentAccess returns true if the segments read by the current request should be audited
+func sampleSegmentAccess() bool {
+	return accesslog.SampleSegmentAccess(Params.ProxyCfg.AccessLog.SegmentAuditEnable,
+		Params.ProxyCfg.AccessLog.SegmentAuditSampleRate)
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/proto/rootcoordpb"
@@ -731,6 +732,32 @@ func TestGetRole(t *testing.T) {
 	assert.Equal(t, 1, len(roles))
 }
 
+func TestGetShardRouting(t *testing.T) {
+	ctx := context.Background()
+	cache := newMockCache()
+	globalMetaCache = cache
+
+	cache.setGetInfoFunc(func(ctx context.Context, collectionName string) (*collectionInfo, error) {
+		return nil, errors.New("mock")
+	})
+	_, err := getShardRouting(ctx, "test")
+	assert.Error(t, err)
+
+	cache.setGetInfoFunc(func(ctx context.Context, collectionName string) (*collectionInfo, error) {
+		return &collectionInfo{}, nil
+	})
+	routing, err := getShardRouting(ctx, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, common.ShardRoutingModulo, routing)
+
+	cache.setGetInfoFunc(func(ctx context.Context, collectionName string) (*collectionInfo, error) {
+		return &collectionInfo{shardRouting: common.ShardRoutingJumpHash}, nil
+	})
+	routing, err = getShardRouting(ctx, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, common.ShardRoutingJumpHash, routing)
+}
+
 func TestPasswordVerify(t *testing.T) {
 	username := "user-test00"
 	password := "PasswordVerify"
@@ -922,3 +949,16 @@ func Test_isPartitionIsLoaded(t *testing.T) {
 		assert.False(t, loaded)
 	})
 }
+
+func Test_isReadOnlyReplicaTargeted(t *testing.T) {
+	targeted, err := isReadOnlyReplicaTargeted(nil)
+	assert.NoError(t, err)
+	assert.False(t, targeted)
+
+	targeted, err = isReadOnlyReplicaTargeted([]*commonpb.KeyValuePair{{Key: common.ReadOnlyReplicaKey, Value: "true"}})
+	assert.NoError(t, err)
+	assert.True(t, targeted)
+
+	_, err = isReadOnlyReplicaTargeted([]*commonpb.KeyValuePair{{Key: common.ReadOnlyReplicaKey, Value: "yes"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"go.uber.org/zap"
+)
+
+// RestoreBalancer wraps a Balance, and while a distribution snapshot is set as
+// restore target, it moves segments and channels back to the nodes recorded in
+// the snapshot instead of balancing. The target is cleared once the
+// distribution matches the snapshot as closely as the alive nodes allow.
+type RestoreBalancer struct {
+	balancer    Balance
+	nodeManager *session.NodeManager
+	dist        *meta.DistributionManager
+	meta        *meta.Meta
+
+	mu     sync.RWMutex
+	target *meta.DistributionSnapshot
+}
+
+func (b *RestoreBalancer) SetRestoreTarget(snapshot *meta.DistributionSnapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.target = snapshot
+}
+
+// RestoreTarget returns the snapshot being restored, nil if there is none.
+func (b *RestoreBalancer) RestoreTarget() *meta.DistributionSnapshot {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.target
+}
+
+func (b *RestoreBalancer) AssignSegment(segments []*meta.Segment, nodes []int64) []SegmentAssignPlan {
+	return b.balancer.AssignSegment(segments, nodes)
+}
+
+func (b *RestoreBalancer) AssignChannel(channels []*meta.DmChannel, nodes []int64) []ChannelAssignPlan {
+	return b.balancer.AssignChannel(channels, nodes)
+}
+
+func (b *RestoreBalancer) Balance() ([]SegmentAssignPlan, []ChannelAssignPlan) {
+	target := b.RestoreTarget()
+	if target == nil {
+		return b.balancer.Balance()
+	}
+
+	segmentPlans, channelPlans := make([]SegmentAssignPlan, 0), make([]ChannelAssignPlan, 0)
+	for _, layout := range target.Replicas {
+		if b.meta.GetStatus(layout.CollectionID) != querypb.LoadStatus_Loaded {
+			continue
+		}
+		replica := b.meta.ReplicaManager.Get(layout.ReplicaID)
+		if replica == nil {
+			continue
+		}
+		splans, cplans := b.restoreReplica(replica, layout)
+		segmentPlans = append(segmentPlans, splans...)
+		channelPlans = append(channelPlans, cplans...)
+	}
+
+	if len(segmentPlans) == 0 && len(channelPlans) == 0 {
+		b.mu.Lock()
+		if b.target == target {
+			b.target = nil
+		}
+		b.mu.Unlock()
+		log.Info("distribution snapshot restored", zap.String("snapshot", target.Name))
+	}
+	return segmentPlans, channelPlans
+}
+
+func (b *RestoreBalancer) restoreReplica(replica *meta.Replica, layout *meta.ReplicaLayout) ([]SegmentAssignPlan, []ChannelAssignPlan) {
+	// nodeID -> IDs of the segments the node serves
+	served := make(map[int64]map[int64]struct{})
+	segments := make([]*meta.Segment, 0)
+	channels := make([]*meta.DmChannel, 0)
+	for _, node := range replica.Nodes.Collect() {
+		served[node] = make(map[int64]struct{})
+		for _, s := range b.dist.SegmentDistManager.GetByCollectionAndNode(replica.GetCollectionID(), node) {
+			served[node][s.GetID()] = struct{}{}
+			segments = append(segments, s)
+		}
+		channels = append(channels, b.dist.ChannelDistManager.GetByCollectionAndNode(replica.GetCollectionID(), node)...)
+	}
+
+	segmentPlans := make([]SegmentAssignPlan, 0)
+	for _, s := range segments {
+		to, ok := layout.Segments[s.GetID()]
+		if !ok || to == s.Node || !b.available(replica, to) {
+			continue
+		}
+		if _, ok := served[to][s.GetID()]; ok {
+			continue
+		}
+		segmentPlans = append(segmentPlans, SegmentAssignPlan{
+			Segment:   s,
+			ReplicaID: replica.GetID(),
+			From:      s.Node,
+			To:        to,
+		})
+	}
+
+	channelPlans := make([]ChannelAssignPlan, 0)
+	for _, ch := range channels {
+		to, ok := layout.Channels[ch.GetChannelName()]
+		if !ok || to == ch.Node || !b.available(replica, to) {
+			continue
+		}
+		channelPlans = append(channelPlans, ChannelAssignPlan{
+			Channel:   ch,
+			ReplicaID: replica.GetID(),
+			From:      ch.Node,
+			To:        to,
+		})
+	}
+	return segmentPlans, channelPlans
+}
+
+// available checks whether the node is alive and still belongs to the replica.
+func (b *RestoreBalancer) available(replica *meta.Replica, node int64) bool {
+	return replica.Nodes.Contain(node) && b.nodeManager.Get(node) != nil
+}
+
+func NewRestoreBalancer(
+	balancer Balance,
+	nodeManager *session.NodeManager,
+	dist *meta.DistributionManager,
+	meta *meta.Meta,
+) *RestoreBalancer {
+	return &RestoreBalancer{
+		balancer:    balancer,
+		nodeManager: nodeManager,
+		dist:        dist,
+		meta:        meta,
+	}
+}
@@ -0,0 +1,157 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"testing"
+
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/internal/util/etcd"
+	"github.com/stretchr/testify/suite"
+)
+
+type RestoreBalancerTestSuite struct {
+	suite.Suite
+	balancer *RestoreBalancer
+	inner    *MockBalancer
+	store    *meta.DistributionSnapshotStore
+	kv       *etcdkv.EtcdKV
+}
+
+func (suite *RestoreBalancerTestSuite) SetupSuite() {
+	Params.Init()
+}
+
+func (suite *RestoreBalancerTestSuite) SetupTest() {
+	config := GenerateEtcdConfig()
+	cli, err := etcd.GetEtcdClient(config)
+	suite.Require().NoError(err)
+	suite.kv = etcdkv.NewEtcdKV(cli, config.MetaRootPath.GetValue())
+	suite.store = meta.NewDistributionSnapshotStore(suite.kv)
+
+	store := meta.NewMetaStore(suite.kv)
+	testMeta := meta.NewMeta(RandomIncrementIDAllocator(), store)
+	nodeManager := session.NewNodeManager()
+	for _, node := range []int64{1, 2, 3} {
+		nodeManager.Add(session.NewNodeInfo(node, "localhost"))
+	}
+
+	collection := utils.CreateTestCollection(1, 1)
+	collection.LoadPercentage = 100
+	collection.Status = querypb.LoadStatus_Loaded
+	testMeta.CollectionManager.PutCollection(collection)
+	testMeta.ReplicaManager.Put(utils.CreateTestReplica(1, 1, []int64{1, 2, 3}))
+
+	suite.inner = NewMockBalancer(suite.T())
+	suite.balancer = NewRestoreBalancer(suite.inner, nodeManager, meta.NewDistributionManager(), testMeta)
+}
+
+func (suite *RestoreBalancerTestSuite) TearDownTest() {
+	suite.kv.RemoveWithPrefix(meta.DistributionSnapshotPrefix)
+	suite.kv.Close()
+}
+
+func (suite *RestoreBalancerTestSuite) TestSnapshotStore() {
+	dist := suite.balancer.dist
+	dist.SegmentDistManager.Update(1, &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 1, CollectionID: 1}, Node: 1})
+	dist.ChannelDistManager.Update(2, utils.CreateTestChannel(1, 2, 1, "channel-1"))
+
+	snapshot := meta.TakeDistributionSnapshot("before-upgrade", suite.balancer.meta, dist)
+	suite.Len(snapshot.Replicas, 1)
+	suite.Equal(map[int64]int64{1: 1}, snapshot.GetReplica(1).Segments)
+	suite.Equal(map[string]int64{"channel-1": 2}, snapshot.GetReplica(1).Channels)
+	suite.Nil(snapshot.GetReplica(2))
+
+	suite.NoError(suite.store.Save(snapshot))
+	loaded, err := suite.store.Get("before-upgrade")
+	suite.NoError(err)
+	suite.Equal(snapshot.Replicas, loaded.Replicas)
+
+	_, err = suite.store.Get("before")
+	suite.ErrorIs(err, meta.ErrDistributionSnapshotNotFound)
+	suite.ErrorIs(suite.store.Save(&meta.DistributionSnapshot{}), meta.ErrInvalidSnapshotName)
+
+	snapshots, err := suite.store.List()
+	suite.NoError(err)
+	suite.Len(snapshots, 1)
+
+	suite.NoError(suite.store.Remove("before-upgrade"))
+	snapshots, err = suite.store.List()
+	suite.NoError(err)
+	suite.Empty(snapshots)
+}
+
+func (suite *RestoreBalancerTestSuite) TestBalanceWithoutTarget() {
+	suite.inner.EXPECT().Balance().Return(nil, nil).Once()
+	segmentPlans, channelPlans := suite.balancer.Balance()
+	suite.Empty(segmentPlans)
+	suite.Empty(channelPlans)
+}
+
+func (suite *RestoreBalancerTestSuite) TestRestore() {
+	dist := suite.balancer.dist
+	snapshot := &meta.DistributionSnapshot{
+		Name: "snapshot",
+		Replicas: []*meta.ReplicaLayout{{
+			CollectionID: 1,
+			ReplicaID:    1,
+			Nodes:        []int64{1, 2, 3},
+			// segment 3 stays in place, segment 4 is served by an offline node
+			Segments: map[int64]int64{1: 1, 2: 2, 3: 3, 4: 4},
+			Channels: map[string]int64{"channel-1": 1},
+		}},
+	}
+	seg1 := &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 1, CollectionID: 1}, Node: 3}
+	seg2 := &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 2, CollectionID: 1}, Node: 3}
+	seg3 := &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 3, CollectionID: 1}, Node: 3}
+	seg4 := &meta.Segment{SegmentInfo: &datapb.SegmentInfo{ID: 4, CollectionID: 1}, Node: 3}
+	ch := utils.CreateTestChannel(1, 2, 1, "channel-1")
+	dist.SegmentDistManager.Update(3, seg1, seg2, seg3, seg4)
+	dist.ChannelDistManager.Update(2, ch)
+
+	suite.balancer.SetRestoreTarget(snapshot)
+	segmentPlans, channelPlans := suite.balancer.Balance()
+	suite.ElementsMatch([]SegmentAssignPlan{
+		{Segment: seg1, ReplicaID: 1, From: 3, To: 1},
+		{Segment: seg2, ReplicaID: 1, From: 3, To: 2},
+	}, segmentPlans)
+	suite.ElementsMatch([]ChannelAssignPlan{
+		{Channel: ch, ReplicaID: 1, From: 2, To: 1},
+	}, channelPlans)
+	suite.Equal(snapshot, suite.balancer.RestoreTarget())
+
+	// segment 2 is being moved, it's served by both nodes
+	dist.SegmentDistManager.Update(1, seg1)
+	dist.SegmentDistManager.Update(2, &meta.Segment{SegmentInfo: seg2.SegmentInfo, Node: 2})
+	dist.SegmentDistManager.Update(3, seg2, seg3, seg4)
+	dist.ChannelDistManager.Update(2)
+	dist.ChannelDistManager.Update(1, ch)
+	segmentPlans, channelPlans = suite.balancer.Balance()
+	suite.Empty(segmentPlans)
+	suite.Empty(channelPlans)
+	suite.Nil(suite.balancer.RestoreTarget())
+}
+
+func TestRestoreBalancerSuite(t *testing.T) {
+	suite.Run(t, new(RestoreBalancerTestSuite))
+}
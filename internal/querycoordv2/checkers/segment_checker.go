@@ -18,6 +18,7 @@ package checkers
 
 import (
 	"context"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
@@ -25,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/balance"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/task"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
@@ -37,6 +39,10 @@ type SegmentChecker struct {
 	dist      *meta.DistributionManager
 	targetMgr *meta.TargetManager
 	balancer  balance.Balance
+	nodeMgr   *session.NodeManager
+
+	// replicaID -> the last time the read-only replica was in sync with the target
+	readOnlySynced map[int64]time.Time
 }
 
 func NewSegmentChecker(
@@ -44,12 +50,15 @@ func NewSegmentChecker(
 	dist *meta.DistributionManager,
 	targetMgr *meta.TargetManager,
 	balancer balance.Balance,
+	nodeMgr *session.NodeManager,
 ) *SegmentChecker {
 	return &SegmentChecker{
-		meta:      meta,
-		dist:      dist,
-		targetMgr: targetMgr,
-		balancer:  balancer,
+		meta:           meta,
+		dist:           dist,
+		targetMgr:      targetMgr,
+		balancer:       balancer,
+		nodeMgr:        nodeMgr,
+		readOnlySynced: make(map[int64]time.Time),
 	}
 }
 
@@ -60,12 +69,19 @@ func (c *SegmentChecker) Description() string {
 func (c *SegmentChecker) Check(ctx context.Context) []task.Task {
 	collectionIDs := c.meta.CollectionManager.GetAll()
 	tasks := make([]task.Task, 0)
+	replicaIDs := typeutil.NewUniqueSet()
 	for _, cid := range collectionIDs {
 		replicas := c.meta.ReplicaManager.GetByCollection(cid)
 		for _, r := range replicas {
+			replicaIDs.Insert(r.GetID())
 			tasks = append(tasks, c.checkReplica(ctx, r)...)
 		}
 	}
+	for replicaID := range c.readOnlySynced {
+		if !replicaIDs.Contain(replicaID) {
+			delete(c.readOnlySynced, replicaID)
+		}
+	}
 
 	// find already released segments which are not contained in target
 	segments := c.dist.SegmentDistManager.GetAll()
@@ -78,18 +94,25 @@ func (c *SegmentChecker) Check(ctx context.Context) []task.Task {
 func (c *SegmentChecker) checkReplica(ctx context.Context, replica *meta.Replica) []task.Task {
 	ret := make([]task.Task, 0)
 
-	// compare with targets to find the lack and redundancy of segments
-	lacks, redundancies := c.getHistoricalSegmentDiff(c.targetMgr, c.dist, c.meta, replica.GetCollectionID(), replica.GetID())
-	tasks := c.createSegmentLoadTasks(ctx, lacks, replica)
-	ret = append(ret, tasks...)
+	// compare with targets to find the lack and redundancy of segments,
+	// read-only replicas serve a snapshot and follow the targets only once per snapshot interval
+	if c.shouldSyncWithTarget(replica) {
+		lacks, redundancies := c.getHistoricalSegmentDiff(c.targetMgr, c.dist, c.meta, replica.GetCollectionID(), replica.GetID())
+		tasks := c.createSegmentLoadTasks(ctx, lacks, replica)
+		ret = append(ret, tasks...)
 
-	tasks = c.createSegmentReduceTasks(ctx, redundancies, replica.GetID(), querypb.DataScope_All)
-	ret = append(ret, tasks...)
+		tasks = c.createSegmentReduceTasks(ctx, redundancies, replica.GetID(), querypb.DataScope_All)
+		ret = append(ret, tasks...)
+
+		if len(lacks) == 0 && len(redundancies) == 0 && utils.IsReadOnlyReplica(c.nodeMgr, replica) {
+			c.readOnlySynced[replica.GetID()] = time.Now()
+		}
+	}
 
 	// compare inner dists to find repeated loaded segments
-	redundancies = c.findRepeatedHistoricalSegments(c.dist, c.meta, replica.GetID())
+	redundancies := c.findRepeatedHistoricalSegments(c.dist, c.meta, replica.GetID())
 	redundancies = c.filterExistedOnLeader(replica, redundancies)
-	tasks = c.createSegmentReduceTasks(ctx, redundancies, replica.GetID(), querypb.DataScope_All)
+	tasks := c.createSegmentReduceTasks(ctx, redundancies, replica.GetID(), querypb.DataScope_All)
 	ret = append(ret, tasks...)
 
 	// compare with target to find the lack and redundancy of segments
@@ -100,6 +123,16 @@ func (c *SegmentChecker) checkReplica(ctx context.Context, replica *meta.Replica
 	return ret
 }
 
+// shouldSyncWithTarget returns false if the replica is a read-only replica
+// which has been in sync with the target within the snapshot interval
+func (c *SegmentChecker) shouldSyncWithTarget(replica *meta.Replica) bool {
+	if !utils.IsReadOnlyReplica(c.nodeMgr, replica) {
+		return true
+	}
+	synced, ok := c.readOnlySynced[replica.GetID()]
+	return !ok || time.Since(synced) >= Params.QueryCoordCfg.ReadOnlyReplicaSnapshotInterval
+}
+
 // GetStreamingSegmentDiff get streaming segment diff between leader view and target
 func (c *SegmentChecker) getStreamingSegmentDiff(targetMgr *meta.TargetManager,
 	distMgr *meta.DistributionManager,
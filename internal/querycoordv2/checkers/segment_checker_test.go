@@ -20,6 +20,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -30,6 +31,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/balance"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/task"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/internal/util/etcd"
@@ -41,6 +43,7 @@ type SegmentCheckerTestSuite struct {
 	checker *SegmentChecker
 	meta    *meta.Meta
 	broker  *meta.MockBroker
+	nodeMgr *session.NodeManager
 }
 
 func (suite *SegmentCheckerTestSuite) SetupSuite() {
@@ -63,7 +66,8 @@ func (suite *SegmentCheckerTestSuite) SetupTest() {
 	targetManager := meta.NewTargetManager(suite.broker, suite.meta)
 
 	balancer := suite.createMockBalancer()
-	suite.checker = NewSegmentChecker(suite.meta, distManager, targetManager, balancer)
+	suite.nodeMgr = session.NewNodeManager()
+	suite.checker = NewSegmentChecker(suite.meta, distManager, targetManager, balancer, suite.nodeMgr)
 }
 
 func (suite *SegmentCheckerTestSuite) TearDownTest() {
@@ -121,6 +125,52 @@ func (suite *SegmentCheckerTestSuite) TestLoadSegments() {
 
 }
 
+func (suite *SegmentCheckerTestSuite) TestReadOnlyReplicaSnapshot() {
+	checker := suite.checker
+	for _, nodeID := range []int64{1, 2} {
+		node := session.NewNodeInfo(nodeID, "localhost")
+		node.SetReadOnly(true)
+		suite.nodeMgr.Add(node)
+	}
+	// set meta
+	checker.meta.CollectionManager.PutCollection(utils.CreateTestCollection(1, 1))
+	checker.meta.ReplicaManager.Put(utils.CreateTestReplica(1, 1, []int64{1, 2}))
+
+	// set target
+	segments := []*datapb.SegmentBinlogs{
+		{
+			SegmentID:     1,
+			InsertChannel: "test-insert-channel",
+		},
+	}
+	suite.broker.EXPECT().GetRecoveryInfo(mock.Anything, int64(1), int64(1)).Return(
+		nil, segments, nil)
+	checker.targetMgr.UpdateCollectionNextTargetWithPartitions(int64(1), int64(1))
+
+	// set dist
+	checker.dist.ChannelDistManager.Update(2, utils.CreateTestChannel(1, 2, 1, "test-insert-channel"))
+	checker.dist.LeaderViewManager.Update(2, utils.CreateTestLeaderView(2, 1, "test-insert-channel", map[int64]int64{}, map[int64]*meta.Segment{}))
+
+	// the replica has been synced within the snapshot interval
+	checker.readOnlySynced[1] = time.Now()
+	tasks := checker.Check(context.TODO())
+	suite.Len(tasks, 0)
+
+	// the snapshot expires
+	checker.readOnlySynced[1] = time.Now().Add(-Params.QueryCoordCfg.ReadOnlyReplicaSnapshotInterval)
+	tasks = checker.Check(context.TODO())
+	suite.Len(tasks, 1)
+	action, ok := tasks[0].Actions()[0].(*task.SegmentAction)
+	suite.True(ok)
+	suite.Equal(task.ActionTypeGrow, action.Type())
+	suite.EqualValues(1, action.SegmentID())
+
+	// the synced time of removed replicas is cleared
+	checker.meta.ReplicaManager.RemoveCollection(1)
+	checker.Check(context.TODO())
+	suite.NotContains(checker.readOnlySynced, int64(1))
+}
+
 func (suite *SegmentCheckerTestSuite) TestReleaseSegments() {
 	checker := suite.checker
 	// set meta
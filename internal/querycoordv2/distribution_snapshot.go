@@ -0,0 +1,157 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/management"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"go.uber.org/zap"
+)
+
+const (
+	DistributionSnapshotRouterPath = "/querycoord/distribution/snapshots"
+	DistributionRestoreRouterPath  = "/querycoord/distribution/restore"
+)
+
+// registerSnapshotHandlerOnce avoid register http handler multiple times
+var registerSnapshotHandlerOnce sync.Once
+
+// SnapshotDistribution captures the current serving distribution and persists it with the given name,
+// an existing snapshot with the same name is overwritten.
+func (s *Server) SnapshotDistribution(name string) (*meta.DistributionSnapshot, error) {
+	if s.status.Load() != commonpb.StateCode_Healthy {
+		return nil, ErrNotHealthy
+	}
+	snapshot := meta.TakeDistributionSnapshot(name, s.meta, s.dist)
+	if err := s.snapshotStore.Save(snapshot); err != nil {
+		return nil, err
+	}
+	log.Info("distribution snapshot saved",
+		zap.String("snapshot", name),
+		zap.Int("replicaNum", len(snapshot.Replicas)))
+	return snapshot, nil
+}
+
+// RestoreDistribution sets the named snapshot as the balancer target,
+// the balancer moves segments and channels back until the layout matches it.
+func (s *Server) RestoreDistribution(name string) error {
+	if s.status.Load() != commonpb.StateCode_Healthy {
+		return ErrNotHealthy
+	}
+	snapshot, err := s.snapshotStore.Get(name)
+	if err != nil {
+		return err
+	}
+	s.restoreBalancer.SetRestoreTarget(snapshot)
+	log.Info("start restoring distribution snapshot", zap.String("snapshot", name))
+	return nil
+}
+
+// CancelRestoreDistribution clears the restore target, the balancer goes back to normal balancing.
+func (s *Server) CancelRestoreDistribution() {
+	if target := s.restoreBalancer.RestoreTarget(); target != nil {
+		log.Info("cancel restoring distribution snapshot", zap.String("snapshot", target.Name))
+	}
+	s.restoreBalancer.SetRestoreTarget(nil)
+}
+
+func (s *Server) registerSnapshotHandlers() {
+	management.Register(&management.HTTPHandler{
+		Path:        DistributionSnapshotRouterPath,
+		HandlerFunc: s.handleDistributionSnapshots,
+	})
+	management.Register(&management.HTTPHandler{
+		Path:        DistributionRestoreRouterPath,
+		HandlerFunc: s.handleDistributionRestore,
+	})
+}
+
+// handleDistributionSnapshots lists snapshots on GET, takes one on POST and removes one on DELETE,
+// the snapshot is given by the "name" query parameter.
+func (s *Server) handleDistributionSnapshots(w http.ResponseWriter, req *http.Request) {
+	name := req.URL.Query().Get("name")
+	switch req.Method {
+	case http.MethodGet:
+		snapshots, err := s.snapshotStore.List()
+		if err != nil {
+			writeHTTPError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeHTTPResponse(w, snapshots)
+	case http.MethodPost:
+		if name == "" {
+			writeHTTPError(w, http.StatusBadRequest, meta.ErrInvalidSnapshotName)
+			return
+		}
+		snapshot, err := s.SnapshotDistribution(name)
+		if err != nil {
+			writeHTTPError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeHTTPResponse(w, snapshot)
+	case http.MethodDelete:
+		if err := s.snapshotStore.Remove(name); err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleDistributionRestore shows the restoring snapshot on GET, starts restoring the snapshot
+// given by the "name" query parameter on POST, and cancels the restoring on DELETE.
+func (s *Server) handleDistributionRestore(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		name := ""
+		if target := s.restoreBalancer.RestoreTarget(); target != nil {
+			name = target.Name
+		}
+		writeHTTPResponse(w, map[string]string{"restoring": name})
+	case http.MethodPost:
+		err := s.RestoreDistribution(req.URL.Query().Get("name"))
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		s.CancelRestoreDistribution()
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeHTTPResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn("failed to write http response", zap.Error(err))
+	}
+}
+
+func writeHTTPError(w http.ResponseWriter, code int, err error) {
+	http.Error(w, err.Error(), code)
+}
@@ -20,4 +20,11 @@ import "errors"
 
 var (
 	ErrNotHealthy = errors.New("NotHealthy")
+
+	ErrInvalidCollectionID = errors.New("invalid collection id")
+	ErrInvalidLoadPriority = errors.New("invalid load priority")
+	ErrInvalidSegmentID    = errors.New("invalid segment id")
+	ErrInvalidNodeID       = errors.New("invalid node id")
+
+	ErrInvalidHistogramBuckets = errors.New("invalid histogram buckets")
 )
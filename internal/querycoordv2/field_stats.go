@@ -0,0 +1,150 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/management"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+const (
+	FieldStatsRouterPath = "/querycoord/collection/field_stats"
+
+	// defaultFieldStatsHistogramBuckets is the number of the histogram buckets returned if not specified
+	defaultFieldStatsHistogramBuckets = 16
+)
+
+// registerFieldStatsHandlerOnce avoid register http handler multiple times
+var registerFieldStatsHandlerOnce sync.Once
+
+func (s *Server) registerFieldStatsHandlers() {
+	management.Register(&management.HTTPHandler{
+		Path:        FieldStatsRouterPath,
+		HandlerFunc: s.handleFieldStats,
+	})
+}
+
+// FieldStatsSummary is the summary of the live statistics of a scalar field for the filter cost estimation.
+type FieldStatsSummary struct {
+	RowCount int64 `json:"row_count"`
+	NDV      int64 `json:"ndv"`
+	// Histogram is the bounds of an equi-depth histogram, empty for non-numeric fields
+	Histogram []float64 `json:"histogram,omitempty"`
+}
+
+// handleFieldStats returns the field statistics of the collection given by the "collectionID" query parameter
+// on GET, fieldID -> FieldStatsSummary, with the histograms of the "buckets" query parameter buckets.
+func (s *Server) handleFieldStats(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	collection, err := strconv.ParseInt(req.URL.Query().Get("collectionID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidCollectionID)
+		return
+	}
+	buckets := defaultFieldStatsHistogramBuckets
+	if value := req.URL.Query().Get("buckets"); value != "" {
+		buckets, err = strconv.Atoi(value)
+		if err != nil || buckets <= 0 {
+			writeHTTPError(w, http.StatusBadRequest, ErrInvalidHistogramBuckets)
+			return
+		}
+	}
+
+	stats, err := s.GetFieldStatistics(req.Context(), collection)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+	summaries := make(map[int64]*FieldStatsSummary, len(stats))
+	for fieldID, fieldStats := range stats {
+		summaries[fieldID] = &FieldStatsSummary{
+			RowCount:  fieldStats.RowCount,
+			NDV:       fieldStats.NDV(),
+			Histogram: fieldStats.Histogram(buckets),
+		}
+	}
+	writeHTTPResponse(w, summaries)
+}
+
+// GetFieldStatistics merges the live field statistics of the collection reported by the QueryNodes,
+// fieldID -> stats. The statistics are merged within a replica, as every replica serves all the segments,
+// and the replica covering the most rows is returned.
+func (s *Server) GetFieldStatistics(ctx context.Context, collection int64) (map[int64]*metricsinfo.FieldStatistics, error) {
+	req, err := metricsinfo.ConstructRequestByMetricType(metricsinfo.SystemInfoMetrics)
+	if err != nil {
+		return nil, err
+	}
+	nodeStats := make(map[int64]map[int64]*metricsinfo.FieldStatistics)
+	for _, metric := range s.tryGetNodesMetrics(ctx, req, s.nodeMgr.GetAll()...) {
+		if metric.err != nil || metric.resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+			continue
+		}
+		infos := metricsinfo.QueryNodeInfos{}
+		if err := metricsinfo.UnmarshalComponentInfos(metric.resp.GetResponse(), &infos); err != nil {
+			log.Warn("invalid metrics of query node was found", zap.Error(err))
+			continue
+		}
+		if stats, ok := infos.FieldStats[collection]; ok {
+			nodeStats[infos.ID] = stats
+		}
+	}
+
+	replicas := s.meta.ReplicaManager.GetByCollection(collection)
+	replicaNodes := make([][]int64, 0, len(replicas))
+	for _, replica := range replicas {
+		replicaNodes = append(replicaNodes, replica.GetNodes())
+	}
+	return mergeFieldStatsByReplica(replicaNodes, nodeStats), nil
+}
+
+// mergeFieldStatsByReplica merges the field statistics of the nodes of every replica,
+// and returns the one covering the most rows.
+func mergeFieldStatsByReplica(replicaNodes [][]int64, nodeStats map[int64]map[int64]*metricsinfo.FieldStatistics) map[int64]*metricsinfo.FieldStatistics {
+	var best map[int64]*metricsinfo.FieldStatistics
+	bestRows := int64(-1)
+	for _, nodes := range replicaNodes {
+		merged := make(map[int64]*metricsinfo.FieldStatistics)
+		rows := int64(0)
+		for _, node := range nodes {
+			for fieldID, fieldStats := range nodeStats[node] {
+				merged[fieldID] = metricsinfo.MergeFieldStatistics(merged[fieldID], fieldStats)
+				if merged[fieldID].RowCount > rows {
+					rows = merged[fieldID].RowCount
+				}
+			}
+		}
+		if rows > bestRows {
+			best, bestRows = merged, rows
+		}
+	}
+	if best == nil {
+		return make(map[int64]*metricsinfo.FieldStatistics)
+	}
+	return best
+}
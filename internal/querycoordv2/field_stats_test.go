@@ -0,0 +1,49 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+func TestMergeFieldStatsByReplica(t *testing.T) {
+	newStats := func(values ...float64) *metricsinfo.FieldStatistics {
+		stats := &metricsinfo.FieldStatistics{}
+		for _, v := range values {
+			stats.ObserveNumber(uint64(v)*0x9E3779B97F4A7C15, v)
+		}
+		return stats
+	}
+	nodeStats := map[int64]map[int64]*metricsinfo.FieldStatistics{
+		1: {100: newStats(1, 2)},
+		2: {100: newStats(3)},
+		// node 3 of the other replica hasn't loaded all segments yet
+		3: {100: newStats(1)},
+	}
+
+	merged := mergeFieldStatsByReplica([][]int64{{3}, {1, 2}}, nodeStats)
+	assert.Len(t, merged, 1)
+	assert.Equal(t, int64(3), merged[100].RowCount)
+	assert.Equal(t, int64(3), merged[100].NDV())
+	assert.Equal(t, []float64{1, 3}, merged[100].Histogram(1))
+
+	assert.Empty(t, mergeFieldStatsByReplica(nil, nodeStats))
+}
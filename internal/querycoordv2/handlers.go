@@ -24,10 +24,13 @@ import (
 
 	"github.com/samber/lo"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/job"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
@@ -340,3 +343,26 @@ func errCode(err error) commonpb.ErrorCode {
 	}
 	return commonpb.ErrorCode_UnexpectedError
 }
+
+// isLeaderReady checks whether the leader view contains all the sealed segments of its channel in the given target
+func isLeaderReady(leader *meta.LeaderView, segments map[int64]*datapb.SegmentInfo) bool {
+	for id, segment := range segments {
+		if segment.GetInsertChannel() != leader.Channel {
+			continue
+		}
+		if _, ok := leader.Segments[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isReadOnlyReplicaTargeted checks whether the request targets the read-only replicas by the grpc metadata
+func isReadOnlyReplicaTargeted(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(common.ReadOnlyReplicaKey)
+	return len(values) > 0 && values[0] == "true"
+}
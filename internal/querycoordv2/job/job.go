@@ -251,6 +251,134 @@ func (job *LoadCollectionJob) PostExecute() {
 	}
 }
 
+// AlterReplicaNumberJob changes the replica number of a loaded collection online,
+// it spawns new replicas or drains redundant replicas by moving nodes between replicas,
+// then the checkers load and release segments/channels to make the replicas consistent with the targets,
+// with the concurrency bounded by the task scheduler
+type AlterReplicaNumberJob struct {
+	*BaseJob
+	req *querypb.LoadCollectionRequest
+
+	dist    *meta.DistributionManager
+	meta    *meta.Meta
+	nodeMgr *session.NodeManager
+}
+
+func NewAlterReplicaNumberJob(
+	ctx context.Context,
+	req *querypb.LoadCollectionRequest,
+	dist *meta.DistributionManager,
+	meta *meta.Meta,
+	nodeMgr *session.NodeManager,
+) *AlterReplicaNumberJob {
+	return &AlterReplicaNumberJob{
+		BaseJob: NewBaseJob(ctx, req.Base.GetMsgID(), req.GetCollectionID()),
+		req:     req,
+		dist:    dist,
+		meta:    meta,
+		nodeMgr: nodeMgr,
+	}
+}
+
+func (job *AlterReplicaNumberJob) PreExecute() error {
+	req := job.req
+	log := log.Ctx(job.ctx).With(
+		zap.Int64("collectionID", req.GetCollectionID()),
+		zap.Int32("replicaNumber", req.GetReplicaNumber()),
+	)
+
+	if req.GetReplicaNumber() <= 0 {
+		msg := "replica number must be positive"
+		log.Warn(msg)
+		return utils.WrapError(msg, ErrInvalidRequest)
+	}
+
+	collection := job.meta.GetCollection(req.GetCollectionID())
+	if collection == nil {
+		msg := "only the replica number of loaded collection could be altered"
+		log.Warn(msg)
+		return utils.WrapError(msg, ErrLoadParameterMismatched)
+	} else if !typeutil.MapEqual(collection.GetFieldIndexID(), req.GetFieldIndexID()) {
+		msg := fmt.Sprintf("collection with different index %v existed, release this collection first before changing its index",
+			collection.GetFieldIndexID())
+		log.Warn(msg)
+		return utils.WrapError(msg, ErrLoadParameterMismatched)
+	} else if collection.GetReplicaNumber() == req.GetReplicaNumber() {
+		return ErrCollectionLoaded
+	}
+
+	if len(job.nodeMgr.GetAll()) < int(req.GetReplicaNumber()) {
+		msg := "no enough nodes to create replicas"
+		log.Warn(msg)
+		return utils.WrapError(msg, ErrNoEnoughNode)
+	}
+
+	return nil
+}
+
+func (job *AlterReplicaNumberJob) Execute() error {
+	req := job.req
+	log := log.Ctx(job.ctx).With(
+		zap.Int64("collectionID", req.GetCollectionID()),
+		zap.Int32("replicaNumber", req.GetReplicaNumber()),
+	)
+
+	replicaNum := len(job.meta.ReplicaManager.GetByCollection(req.GetCollectionID()))
+	if replicaNum < int(req.GetReplicaNumber()) {
+		replicas, err := utils.GrowReplicas(job.meta.ReplicaManager,
+			job.dist,
+			req.GetCollectionID(),
+			req.GetReplicaNumber())
+		if err != nil {
+			msg := "failed to spawn replicas for collection"
+			log.Warn(msg, zap.Error(err))
+			return utils.WrapError(msg, err)
+		}
+		err = job.meta.ReplicaManager.Put(replicas...)
+		if err != nil {
+			msg := "failed to store replicas"
+			log.Warn(msg, zap.Error(err))
+			return utils.WrapError(msg, err)
+		}
+		for _, replica := range replicas {
+			log.Info("replica updated",
+				zap.Int64("replicaID", replica.GetID()),
+				zap.Int64s("nodes", replica.GetNodes()))
+		}
+	} else if replicaNum > int(req.GetReplicaNumber()) {
+		remaining, removed := utils.ShrinkReplicas(job.meta.ReplicaManager,
+			job.dist,
+			req.GetCollectionID(),
+			req.GetReplicaNumber())
+		removedIDs := lo.Map(removed, func(replica *meta.Replica, _ int) int64 {
+			return replica.GetID()
+		})
+		err := job.meta.ReplicaManager.RemoveReplicas(req.GetCollectionID(), removedIDs...)
+		if err != nil {
+			msg := "failed to remove replicas"
+			log.Warn(msg, zap.Error(err))
+			return utils.WrapError(msg, err)
+		}
+		err = job.meta.ReplicaManager.Put(remaining...)
+		if err != nil {
+			msg := "failed to store replicas"
+			log.Warn(msg, zap.Error(err))
+			return utils.WrapError(msg, err)
+		}
+		log.Info("replicas drained", zap.Int64s("replicaIDs", removedIDs))
+	}
+
+	collection := job.meta.GetCollection(req.GetCollectionID()).Clone()
+	collection.ReplicaNumber = req.GetReplicaNumber()
+	err := job.meta.CollectionManager.UpdateCollection(collection)
+	if err != nil {
+		msg := "failed to update collection"
+		log.Warn(msg, zap.Error(err))
+		return utils.WrapError(msg, err)
+	}
+	return nil
+}
+
 type ReleaseCollectionJob struct {
 	*BaseJob
 	req       *querypb.ReleaseCollectionRequest
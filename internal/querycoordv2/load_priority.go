@@ -0,0 +1,92 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/management"
+)
+
+const (
+	LoadPriorityRouterPath = "/querycoord/collection/load_priority"
+)
+
+// registerLoadPriorityHandlerOnce avoid register http handler multiple times
+var registerLoadPriorityHandlerOnce sync.Once
+
+func (s *Server) registerLoadPriorityHandlers() {
+	management.Register(&management.HTTPHandler{
+		Path:        LoadPriorityRouterPath,
+		HandlerFunc: s.handleLoadPriority,
+	})
+}
+
+// removeLoadPriority removes the load priority of the released collection, a dropped collection is released too,
+// the priority is set again before the collection is loaded next time.
+func (s *Server) removeLoadPriority(collection int64) {
+	if err := s.loadPriorities.Remove(collection); err != nil {
+		log.Warn("failed to remove load priority of released collection",
+			zap.Int64("collectionID", collection),
+			zap.Error(err))
+	}
+}
+
+// handleLoadPriority lists the load priorities on GET, sets the one of the collection given by the "collectionID"
+// query parameter to the "priority" query parameter on POST, and resets it to default on DELETE.
+func (s *Server) handleLoadPriority(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodGet {
+		writeHTTPResponse(w, s.loadPriorities.GetAll())
+		return
+	}
+
+	collection, err := strconv.ParseInt(req.URL.Query().Get("collectionID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidCollectionID)
+		return
+	}
+	switch req.Method {
+	case http.MethodPost:
+		priority, err := strconv.ParseInt(req.URL.Query().Get("priority"), 10, 64)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, ErrInvalidLoadPriority)
+			return
+		}
+		if err := s.loadPriorities.Set(collection, priority); err != nil {
+			writeHTTPError(w, http.StatusInternalServerError, err)
+			return
+		}
+		log.Info("collection load priority set",
+			zap.Int64("collectionID", collection),
+			zap.Int64("priority", priority))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		if err := s.loadPriorities.Remove(collection); err != nil {
+			writeHTTPError(w, http.StatusInternalServerError, err)
+			return
+		}
+		log.Info("collection load priority reset", zap.Int64("collectionID", collection))
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,126 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	. "github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	BadSegmentPrefix = "querycoord-bad-segment"
+)
+
+// BadSegment is a segment excluded from serving by operators, e.g. for corruption.
+type BadSegment struct {
+	SegmentID    UniqueID  `json:"segment_id"`
+	CollectionID UniqueID  `json:"collection_id"`
+	Reason       string    `json:"reason"`
+	MarkedAt     time.Time `json:"marked_at"`
+}
+
+// BadSegmentManager keeps the segments marked bad, they are excluded from the targets,
+// so that the segments are released from all replicas and not loaded again until unmarked.
+// The marks are kept across restarts and releasing, until removed explicitly.
+type BadSegmentManager struct {
+	rwmutex sync.RWMutex
+
+	segments map[UniqueID]*BadSegment
+	cli      kv.MetaKv
+}
+
+func NewBadSegmentManager(cli kv.MetaKv) *BadSegmentManager {
+	return &BadSegmentManager{
+		segments: make(map[UniqueID]*BadSegment),
+		cli:      cli,
+	}
+}
+
+// Recover loads the bad segments from kv store
+func (m *BadSegmentManager) Recover() error {
+	_, values, err := m.cli.LoadWithPrefix(BadSegmentPrefix)
+	if err != nil {
+		return err
+	}
+
+	m.rwmutex.Lock()
+	defer m.rwmutex.Unlock()
+	for _, value := range values {
+		segment := &BadSegment{}
+		if err := json.Unmarshal([]byte(value), segment); err != nil {
+			return err
+		}
+		m.segments[segment.SegmentID] = segment
+	}
+	return nil
+}
+
+// Get returns the bad segment, nil if the segment is not marked bad
+func (m *BadSegmentManager) Get(segmentID UniqueID) *BadSegment {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	return m.segments[segmentID]
+}
+
+func (m *BadSegmentManager) GetAll() []*BadSegment {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	ret := make([]*BadSegment, 0, len(m.segments))
+	for _, segment := range m.segments {
+		ret = append(ret, segment)
+	}
+	return ret
+}
+
+func (m *BadSegmentManager) Mark(segment *BadSegment) error {
+	m.rwmutex.Lock()
+	defer m.rwmutex.Unlock()
+
+	value, err := json.Marshal(segment)
+	if err != nil {
+		return err
+	}
+	err = m.cli.Save(encodeBadSegmentKey(segment.SegmentID), string(value))
+	if err != nil {
+		return err
+	}
+	m.segments[segment.SegmentID] = segment
+	return nil
+}
+
+func (m *BadSegmentManager) Unmark(segmentID UniqueID) error {
+	m.rwmutex.Lock()
+	defer m.rwmutex.Unlock()
+
+	err := m.cli.Remove(encodeBadSegmentKey(segmentID))
+	if err != nil {
+		return err
+	}
+	delete(m.segments, segmentID)
+	return nil
+}
+
+func encodeBadSegmentKey(segmentID UniqueID) string {
+	return fmt.Sprintf("%s/%d", BadSegmentPrefix, segmentID)
+}
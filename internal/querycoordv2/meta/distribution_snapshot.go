@@ -0,0 +1,166 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/kv"
+)
+
+const (
+	DistributionSnapshotPrefix = "querycoord-distribution-snapshot"
+)
+
+var (
+	ErrDistributionSnapshotNotFound = errors.New("distribution snapshot not found")
+	ErrInvalidSnapshotName          = errors.New("invalid distribution snapshot name")
+)
+
+// ReplicaLayout records where the segments and channels of one replica are served.
+type ReplicaLayout struct {
+	CollectionID int64            `json:"collection_id"`
+	ReplicaID    int64            `json:"replica_id"`
+	Nodes        []int64          `json:"nodes"`
+	Segments     map[int64]int64  `json:"segments"` // segmentID -> nodeID
+	Channels     map[string]int64 `json:"channels"` // channel name -> nodeID
+}
+
+// DistributionSnapshot is a point-in-time copy of the serving distribution,
+// used as a restore target for the balancer.
+type DistributionSnapshot struct {
+	Name      string           `json:"name"`
+	CreatedAt time.Time        `json:"created_at"`
+	Replicas  []*ReplicaLayout `json:"replicas"`
+}
+
+// GetReplica returns the layout of the given replica, nil if it is not in the snapshot.
+func (snapshot *DistributionSnapshot) GetReplica(replicaID int64) *ReplicaLayout {
+	for _, layout := range snapshot.Replicas {
+		if layout.ReplicaID == replicaID {
+			return layout
+		}
+	}
+	return nil
+}
+
+// TakeDistributionSnapshot captures the current segment and channel distribution of all replicas.
+func TakeDistributionSnapshot(name string, m *Meta, dist *DistributionManager) *DistributionSnapshot {
+	snapshot := &DistributionSnapshot{
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	for _, collection := range m.CollectionManager.GetAll() {
+		for _, replica := range m.ReplicaManager.GetByCollection(collection) {
+			nodes := replica.Nodes.Collect()
+			sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+			layout := &ReplicaLayout{
+				CollectionID: collection,
+				ReplicaID:    replica.GetID(),
+				Nodes:        nodes,
+				Segments:     make(map[int64]int64),
+				Channels:     make(map[string]int64),
+			}
+			for _, node := range nodes {
+				for _, segment := range dist.SegmentDistManager.GetByCollectionAndNode(collection, node) {
+					layout.Segments[segment.GetID()] = node
+				}
+				for _, channel := range dist.ChannelDistManager.GetByCollectionAndNode(collection, node) {
+					layout.Channels[channel.GetChannelName()] = node
+				}
+			}
+			snapshot.Replicas = append(snapshot.Replicas, layout)
+		}
+	}
+	return snapshot
+}
+
+// DistributionSnapshotStore persists distribution snapshots in the meta kv.
+type DistributionSnapshotStore struct {
+	cli kv.MetaKv
+}
+
+func NewDistributionSnapshotStore(cli kv.MetaKv) *DistributionSnapshotStore {
+	return &DistributionSnapshotStore{
+		cli: cli,
+	}
+}
+
+func (s *DistributionSnapshotStore) Save(snapshot *DistributionSnapshot) error {
+	if snapshot.Name == "" {
+		return ErrInvalidSnapshotName
+	}
+	v, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return s.cli.Save(encodeDistributionSnapshotKey(snapshot.Name), string(v))
+}
+
+func (s *DistributionSnapshotStore) Get(name string) (*DistributionSnapshot, error) {
+	if name == "" {
+		return nil, ErrInvalidSnapshotName
+	}
+	_, values, err := s.cli.LoadWithPrefix(encodeDistributionSnapshotKey(name))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		snapshot := &DistributionSnapshot{}
+		if err := json.Unmarshal([]byte(v), snapshot); err != nil {
+			return nil, err
+		}
+		if snapshot.Name == name {
+			return snapshot, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrDistributionSnapshotNotFound, name)
+}
+
+func (s *DistributionSnapshotStore) List() ([]*DistributionSnapshot, error) {
+	_, values, err := s.cli.LoadWithPrefix(DistributionSnapshotPrefix)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*DistributionSnapshot, 0, len(values))
+	for _, v := range values {
+		snapshot := &DistributionSnapshot{}
+		if err := json.Unmarshal([]byte(v), snapshot); err != nil {
+			return nil, err
+		}
+		ret = append(ret, snapshot)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.Before(ret[j].CreatedAt)
+	})
+	return ret, nil
+}
+
+func (s *DistributionSnapshotStore) Remove(name string) error {
+	if name == "" {
+		return ErrInvalidSnapshotName
+	}
+	return s.cli.Remove(encodeDistributionSnapshotKey(name))
+}
+
+func encodeDistributionSnapshotKey(name string) string {
+	return fmt.Sprintf("%s/%s", DistributionSnapshotPrefix, name)
+}
@@ -0,0 +1,126 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/kv"
+	. "github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	LoadPriorityPrefix = "querycoord-load-priority"
+
+	// DefaultLoadPriority is the load priority of collections without one set
+	DefaultLoadPriority int64 = 0
+)
+
+// LoadPriorityManager keeps the load priorities of collections,
+// segments and channels of collections with higher priority are loaded first,
+// which matters on cold start when all collections are loading at the same time.
+// The priorities are removed once the collections are released or dropped.
+type LoadPriorityManager struct {
+	rwmutex sync.RWMutex
+
+	priorities map[UniqueID]int64
+	cli        kv.MetaKv
+}
+
+func NewLoadPriorityManager(cli kv.MetaKv) *LoadPriorityManager {
+	return &LoadPriorityManager{
+		priorities: make(map[UniqueID]int64),
+		cli:        cli,
+	}
+}
+
+// Recover loads the priorities from kv store
+func (m *LoadPriorityManager) Recover() error {
+	keys, values, err := m.cli.LoadWithPrefix(LoadPriorityPrefix)
+	if err != nil {
+		return err
+	}
+
+	m.rwmutex.Lock()
+	defer m.rwmutex.Unlock()
+	for i := range keys {
+		collection, err := strconv.ParseInt(path.Base(keys[i]), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidKey, keys[i])
+		}
+		priority, err := strconv.ParseInt(values[i], 10, 64)
+		if err != nil {
+			return err
+		}
+		m.priorities[collection] = priority
+	}
+	return nil
+}
+
+// Get returns the load priority of the given collection, DefaultLoadPriority if not set
+func (m *LoadPriorityManager) Get(collection UniqueID) int64 {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	priority, ok := m.priorities[collection]
+	if !ok {
+		return DefaultLoadPriority
+	}
+	return priority
+}
+
+func (m *LoadPriorityManager) GetAll() map[UniqueID]int64 {
+	m.rwmutex.RLock()
+	defer m.rwmutex.RUnlock()
+
+	ret := make(map[UniqueID]int64, len(m.priorities))
+	for collection, priority := range m.priorities {
+		ret[collection] = priority
+	}
+	return ret
+}
+
+func (m *LoadPriorityManager) Set(collection UniqueID, priority int64) error {
+	m.rwmutex.Lock()
+	defer m.rwmutex.Unlock()
+
+	err := m.cli.Save(encodeLoadPriorityKey(collection), strconv.FormatInt(priority, 10))
+	if err != nil {
+		return err
+	}
+	m.priorities[collection] = priority
+	return nil
+}
+
+func (m *LoadPriorityManager) Remove(collection UniqueID) error {
+	m.rwmutex.Lock()
+	defer m.rwmutex.Unlock()
+
+	err := m.cli.Remove(encodeLoadPriorityKey(collection))
+	if err != nil {
+		return err
+	}
+	delete(m.priorities, collection)
+	return nil
+}
+
+func encodeLoadPriorityKey(collection UniqueID) string {
+	return fmt.Sprintf("%s/%d", LoadPriorityPrefix, collection)
+}
@@ -173,6 +173,22 @@ func (m *ReplicaManager) RemoveCollection(collectionID UniqueID) error {
 	return nil
 }
 
+// RemoveReplicas removes the given replicas of the collection,
+// returns error if failed to remove replica from KV
+func (m *ReplicaManager) RemoveReplicas(collectionID UniqueID, replicas ...UniqueID) error {
+	m.rwmutex.Lock()
+	defer m.rwmutex.Unlock()
+
+	for _, id := range replicas {
+		err := m.store.ReleaseReplica(collectionID, id)
+		if err != nil {
+			return err
+		}
+		delete(m.replicas, id)
+	}
+	return nil
+}
+
 func (m *ReplicaManager) GetByCollection(collectionID UniqueID) []*Replica {
 	m.rwmutex.RLock()
 	defer m.rwmutex.RUnlock()
@@ -155,6 +155,25 @@ func (suite *ReplicaManagerSuite) TestRemove() {
 	}
 }
 
+func (suite *ReplicaManagerSuite) TestRemoveReplicas() {
+	mgr := suite.mgr
+
+	for _, collection := range suite.collections {
+		replicas := mgr.GetByCollection(collection)
+		err := mgr.RemoveReplicas(collection, replicas[0].GetID())
+		suite.NoError(err)
+		suite.Len(mgr.GetByCollection(collection), len(replicas)-1)
+		suite.Nil(mgr.Get(replicas[0].GetID()))
+	}
+
+	// Check whether the replicas are also removed from meta store
+	mgr.Recover(suite.collections)
+	for i, collection := range suite.collections {
+		replicas := mgr.GetByCollection(collection)
+		suite.Len(replicas, int(suite.replicaNumbers[i])-1)
+	}
+}
+
 func (suite *ReplicaManagerSuite) TestNodeManipulate() {
 	mgr := suite.mgr
 
@@ -45,14 +45,18 @@ type TargetManager struct {
 	// all remove segment/channel operation happens on Both current and next -> delete status should be consistent
 	current *target
 	next    *target
+
+	// segments excluded from both targets, e.g. the bad ones, they are not loaded until included again
+	excluded typeutil.UniqueSet
 }
 
 func NewTargetManager(broker Broker, meta *Meta) *TargetManager {
 	return &TargetManager{
-		broker:  broker,
-		meta:    meta,
-		current: newTarget(),
-		next:    newTarget(),
+		broker:   broker,
+		meta:     meta,
+		current:  newTarget(),
+		next:     newTarget(),
+		excluded: typeutil.NewUniqueSet(),
 	}
 }
 
@@ -128,6 +132,9 @@ func (mgr *TargetManager) updateCollectionNextTarget(collectionID int64, partiti
 			zap.Error(err))
 		return err
 	}
+	if mgr.excluded.Len() > 0 {
+		newTarget = mgr.removeSegmentsFromCollectionTarget(newTarget, mgr.excluded)
+	}
 
 	mgr.next.updateCollectionTarget(collectionID, newTarget)
 
@@ -254,6 +261,45 @@ func (mgr *TargetManager) RemovePartition(collectionID int64, partitionIDs ...in
 	}
 }
 
+// ExcludeSegments removes the segments from both targets, and keeps them out of the next targets pulled later,
+// so that the segments are released and not served, until included again.
+func (mgr *TargetManager) ExcludeSegments(collectionID int64, segmentIDs ...int64) {
+	mgr.rwMutex.Lock()
+	defer mgr.rwMutex.Unlock()
+
+	log.Info("exclude segments from targets",
+		zap.Int64("collectionID", collectionID),
+		zap.Int64s("segmentIDs", segmentIDs))
+
+	segmentSet := typeutil.NewUniqueSet(segmentIDs...)
+	mgr.excluded.Insert(segmentIDs...)
+	for _, t := range []*target{mgr.current, mgr.next} {
+		oldTarget := t.getCollectionTarget(collectionID)
+		if oldTarget != nil {
+			t.updateCollectionTarget(collectionID, mgr.removeSegmentsFromCollectionTarget(oldTarget, segmentSet))
+		}
+	}
+}
+
+// IncludeSegments stops excluding the segments, they are back to the targets with the next target pulled.
+func (mgr *TargetManager) IncludeSegments(segmentIDs ...int64) {
+	mgr.rwMutex.Lock()
+	defer mgr.rwMutex.Unlock()
+
+	log.Info("include segments in targets", zap.Int64s("segmentIDs", segmentIDs))
+	mgr.excluded.Remove(segmentIDs...)
+}
+
+func (mgr *TargetManager) removeSegmentsFromCollectionTarget(oldTarget *CollectionTarget, segmentSet typeutil.UniqueSet) *CollectionTarget {
+	segments := make(map[int64]*datapb.SegmentInfo)
+	for _, segment := range oldTarget.GetAllSegments() {
+		if !segmentSet.Contain(segment.GetID()) {
+			segments[segment.GetID()] = segment
+		}
+	}
+	return NewCollectionTarget(segments, oldTarget.GetAllDmChannels())
+}
+
 func (mgr *TargetManager) removePartitionFromCollectionTarget(oldTarget *CollectionTarget, partitionSet typeutil.UniqueSet) *CollectionTarget {
 	segments := make(map[int64]*datapb.SegmentInfo)
 	for _, segment := range oldTarget.GetAllSegments() {
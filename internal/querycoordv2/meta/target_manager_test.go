@@ -234,6 +234,27 @@ func (suite *TargetManagerSuite) TestRemoveCollection() {
 	suite.assertChannels([]string{}, suite.mgr.GetDmChannelsByCollection(collectionID, CurrentTarget))
 }
 
+func (suite *TargetManagerSuite) TestExcludeSegments() {
+	collectionID := int64(1000)
+	suite.mgr.UpdateCollectionCurrentTarget(collectionID)
+	suite.mgr.UpdateCollectionNextTargetWithPartitions(collectionID, suite.partitions[collectionID]...)
+
+	suite.mgr.ExcludeSegments(collectionID, 1, 3)
+	suite.assertSegments([]int64{2, 4}, suite.mgr.GetHistoricalSegmentsByCollection(collectionID, CurrentTarget))
+	suite.assertSegments([]int64{2, 4}, suite.mgr.GetHistoricalSegmentsByCollection(collectionID, NextTarget))
+	suite.assertChannels(suite.channels[collectionID], suite.mgr.GetDmChannelsByCollection(collectionID, CurrentTarget))
+
+	// excluded segments are kept out of the next target pulled later
+	suite.mgr.UpdateCollectionNextTargetWithPartitions(collectionID, suite.partitions[collectionID]...)
+	suite.assertSegments([]int64{2, 4}, suite.mgr.GetHistoricalSegmentsByCollection(collectionID, NextTarget))
+
+	suite.mgr.IncludeSegments(1, 3)
+	suite.mgr.UpdateCollectionNextTargetWithPartitions(collectionID, suite.partitions[collectionID]...)
+	suite.assertSegments(suite.getAllSegment(collectionID, suite.partitions[collectionID]),
+		suite.mgr.GetHistoricalSegmentsByCollection(collectionID, NextTarget))
+	suite.assertSegments([]int64{2, 4}, suite.mgr.GetHistoricalSegmentsByCollection(collectionID, CurrentTarget))
+}
+
 func (suite *TargetManagerSuite) getAllSegment(collectionID int64, partitionIDs []int64) []int64 {
 	allSegments := make([]int64, 0)
 	for collection, partitions := range suite.segments {
@@ -21,11 +21,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
@@ -37,18 +39,20 @@ type TargetObserver struct {
 	targetMgr *meta.TargetManager
 	distMgr   *meta.DistributionManager
 	broker    meta.Broker
+	nodeMgr   *session.NodeManager
 
 	nextTargetLastUpdate map[int64]time.Time
 	stopOnce             sync.Once
 }
 
-func NewTargetObserver(meta *meta.Meta, targetMgr *meta.TargetManager, distMgr *meta.DistributionManager, broker meta.Broker) *TargetObserver {
+func NewTargetObserver(meta *meta.Meta, targetMgr *meta.TargetManager, distMgr *meta.DistributionManager, broker meta.Broker, nodeMgr *session.NodeManager) *TargetObserver {
 	return &TargetObserver{
 		c:                    make(chan struct{}),
 		meta:                 meta,
 		targetMgr:            targetMgr,
 		distMgr:              distMgr,
 		broker:               broker,
+		nodeMgr:              nodeMgr,
 		nextTargetLastUpdate: make(map[int64]time.Time),
 	}
 }
@@ -133,7 +137,24 @@ func (ob *TargetObserver) updateNextTargetTimestamp(collectionID int64) {
 }
 
 func (ob *TargetObserver) shouldUpdateCurrentTarget(collectionID int64) bool {
-	replicaNum := len(ob.meta.ReplicaManager.GetByCollection(collectionID))
+	// read-only replicas follow the target once per snapshot interval,
+	// don't wait for them unless all replicas are read-only
+	replicas := ob.meta.ReplicaManager.GetByCollection(collectionID)
+	servingReplicas := lo.Filter(replicas, func(replica *meta.Replica, _ int) bool {
+		return !utils.IsReadOnlyReplica(ob.nodeMgr, replica)
+	})
+	if len(servingReplicas) == 0 {
+		servingReplicas = replicas
+	}
+	replicaNum := len(servingReplicas)
+	servingReplicaIDs := typeutil.NewUniqueSet(lo.Map(servingReplicas, func(replica *meta.Replica, _ int) int64 {
+		return replica.GetID()
+	})...)
+	countServingReplicas := func(group map[int64][]int64) int {
+		return len(lo.Filter(lo.Keys(group), func(replicaID int64, _ int) bool {
+			return servingReplicaIDs.Contain(replicaID)
+		}))
+	}
 
 	// check channel first
 	channelNames := ob.targetMgr.GetDmChannelsByCollection(collectionID, meta.NextTarget)
@@ -146,7 +167,7 @@ func (ob *TargetObserver) shouldUpdateCurrentTarget(collectionID int64) bool {
 		group := utils.GroupNodesByReplica(ob.meta.ReplicaManager,
 			collectionID,
 			ob.distMgr.LeaderViewManager.GetChannelDist(channel.GetChannelName()))
-		if len(group) < replicaNum {
+		if countServingReplicas(group) < replicaNum {
 			return false
 		}
 	}
@@ -157,7 +178,7 @@ func (ob *TargetObserver) shouldUpdateCurrentTarget(collectionID int64) bool {
 		group := utils.GroupNodesByReplica(ob.meta.ReplicaManager,
 			collectionID,
 			ob.distMgr.LeaderViewManager.GetSealedSegmentDist(segment.GetID()))
-		if len(group) < replicaNum {
+		if countServingReplicas(group) < replicaNum {
 			return false
 		}
 	}
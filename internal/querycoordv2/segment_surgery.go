@@ -0,0 +1,334 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycoordv2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/management"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+)
+
+const (
+	BadSegmentRouterPath       = "/querycoord/segment/bad"
+	ReloadSegmentRouterPath    = "/querycoord/segment/reload"
+	ReplicateSegmentRouterPath = "/querycoord/segment/replicate"
+)
+
+// registerSegmentSurgeryHandlerOnce avoid register http handler multiple times
+var registerSegmentSurgeryHandlerOnce sync.Once
+
+func (s *Server) registerSegmentSurgeryHandlers() {
+	management.Register(&management.HTTPHandler{
+		Path:        BadSegmentRouterPath,
+		HandlerFunc: s.handleBadSegment,
+	})
+	management.Register(&management.HTTPHandler{
+		Path:        ReloadSegmentRouterPath,
+		HandlerFunc: s.handleReloadSegment,
+	})
+	management.Register(&management.HTTPHandler{
+		Path:        ReplicateSegmentRouterPath,
+		HandlerFunc: s.handleReplicateSegment,
+	})
+}
+
+// handleBadSegment lists the bad segments on GET, marks the segment given by the "segmentID" query parameter
+// of the collection given by the "collectionID" query parameter bad on POST, with an optional "reason",
+// and unmarks it on DELETE.
+func (s *Server) handleBadSegment(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodGet {
+		writeHTTPResponse(w, s.badSegments.GetAll())
+		return
+	}
+
+	segment, err := strconv.ParseInt(req.URL.Query().Get("segmentID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidSegmentID)
+		return
+	}
+	switch req.Method {
+	case http.MethodPost:
+		collection, err := strconv.ParseInt(req.URL.Query().Get("collectionID"), 10, 64)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, ErrInvalidCollectionID)
+			return
+		}
+		if err := s.markBadSegment(collection, segment, req.URL.Query().Get("reason")); err != nil {
+			writeHTTPError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		if err := s.unmarkBadSegment(segment); err != nil {
+			writeHTTPError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleReloadSegment releases the segment given by the "segmentID" query parameter from the node given by
+// the "nodeID" query parameter, and loads it again on the same node, on POST.
+func (s *Server) handleReloadSegment(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := req.URL.Query()
+	collection, err := strconv.ParseInt(query.Get("collectionID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidCollectionID)
+		return
+	}
+	segment, err := strconv.ParseInt(query.Get("segmentID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidSegmentID)
+		return
+	}
+	node, err := strconv.ParseInt(query.Get("nodeID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidNodeID)
+		return
+	}
+
+	if err := s.reloadSegment(req.Context(), collection, segment, node); err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleReplicateSegment moves the segment given by the "segmentID" query parameter from the node given by
+// the "sourceNodeID" query parameter to another node of the same replica on POST, the node given by the optional
+// "targetNodeID" query parameter, or the one chosen by the balancer.
+func (s *Server) handleReplicateSegment(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := req.URL.Query()
+	collection, err := strconv.ParseInt(query.Get("collectionID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidCollectionID)
+		return
+	}
+	segment, err := strconv.ParseInt(query.Get("segmentID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidSegmentID)
+		return
+	}
+	source, err := strconv.ParseInt(query.Get("sourceNodeID"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, ErrInvalidNodeID)
+		return
+	}
+	target := int64(-1)
+	if query.Get("targetNodeID") != "" {
+		target, err = strconv.ParseInt(query.Get("targetNodeID"), 10, 64)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, ErrInvalidNodeID)
+			return
+		}
+	}
+
+	target, err = s.replicateSegment(req.Context(), collection, segment, source, target)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeHTTPResponse(w, map[string]int64{"targetNodeID": target})
+}
+
+// markBadSegment excludes the segment from the targets,
+// so that it's released from all replicas by the segment checker, and not loaded again until unmarked
+func (s *Server) markBadSegment(collectionID, segmentID int64, reason string) error {
+	if !s.meta.CollectionManager.Exist(collectionID) {
+		return fmt.Errorf("collection %d not loaded", collectionID)
+	}
+
+	err := s.badSegments.Mark(&meta.BadSegment{
+		SegmentID:    segmentID,
+		CollectionID: collectionID,
+		Reason:       reason,
+		MarkedAt:     time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	s.targetMgr.ExcludeSegments(collectionID, segmentID)
+
+	log.Warn("segment marked bad",
+		zap.Int64("collectionID", collectionID),
+		zap.Int64("segmentID", segmentID),
+		zap.String("reason", reason))
+	return nil
+}
+
+// unmarkBadSegment includes the segment in the targets again,
+// it's loaded again once the next target is pulled
+func (s *Server) unmarkBadSegment(segmentID int64) error {
+	segment := s.badSegments.Get(segmentID)
+	if segment == nil {
+		return fmt.Errorf("segment %d not marked bad", segmentID)
+	}
+
+	err := s.badSegments.Unmark(segmentID)
+	if err != nil {
+		return err
+	}
+	s.targetMgr.IncludeSegments(segmentID)
+	if s.meta.CollectionManager.Exist(segment.CollectionID) {
+		err = s.targetMgr.UpdateCollectionNextTarget(segment.CollectionID)
+		if err != nil {
+			log.Warn("failed to update next target after unmarking bad segment, it will be updated later",
+				zap.Int64("collectionID", segment.CollectionID),
+				zap.Int64("segmentID", segmentID),
+				zap.Error(err))
+		}
+	}
+
+	log.Info("segment unmarked bad",
+		zap.Int64("collectionID", segment.CollectionID),
+		zap.Int64("segmentID", segmentID))
+	return nil
+}
+
+// reloadSegment releases the segment from the node, then loads it again on the node.
+// The release and load are 2 tasks, as a release action must be the last one of a task
+func (s *Server) reloadSegment(ctx context.Context, collectionID, segmentID, nodeID int64) error {
+	replica, segment, err := s.getSegmentOnNode(collectionID, segmentID, nodeID)
+	if err != nil {
+		return err
+	}
+
+	log := log.With(
+		zap.Int64("collectionID", collectionID),
+		zap.Int64("segmentID", segmentID),
+		zap.Int64("nodeID", nodeID),
+	)
+	log.Info("manually reload segment...")
+
+	actions := []task.Action{
+		task.NewSegmentAction(nodeID, task.ActionTypeReduce, segment.GetInsertChannel(), segmentID),
+		task.NewSegmentAction(nodeID, task.ActionTypeGrow, segment.GetInsertChannel(), segmentID),
+	}
+	for _, action := range actions {
+		t, err := task.NewSegmentTask(ctx,
+			Params.QueryCoordCfg.SegmentTaskTimeout,
+			0,
+			collectionID,
+			replica.GetID(),
+			action,
+		)
+		if err != nil {
+			return err
+		}
+		err = s.taskScheduler.Add(t)
+		if err == task.ErrConflictTaskExisted && action.Type() == task.ActionTypeGrow {
+			// the segment checker is loading the released segment already
+			log.Info("segment is being loaded by checker")
+			return nil
+		}
+		if err != nil {
+			t.Cancel()
+			return err
+		}
+		err = task.Wait(ctx, Params.QueryCoordCfg.SegmentTaskTimeout, t)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replicateSegment loads the segment on the target node of the same replica, then releases it from the source node,
+// the target node is chosen by the balancer if it's negative. Returns the target node.
+func (s *Server) replicateSegment(ctx context.Context, collectionID, segmentID, sourceID, targetID int64) (int64, error) {
+	replica, segment, err := s.getSegmentOnNode(collectionID, segmentID, sourceID)
+	if err != nil {
+		return -1, err
+	}
+
+	if targetID < 0 {
+		nodes := lo.Filter(replica.GetNodes(), func(node int64, _ int) bool {
+			return node != sourceID && s.nodeMgr.Get(node) != nil
+		})
+		plans := s.balancer.AssignSegment([]*meta.Segment{segment}, nodes)
+		if len(plans) == 0 {
+			return -1, fmt.Errorf("no available node to replicate segment %d in replica %d", segmentID, replica.GetID())
+		}
+		targetID = plans[0].To
+	} else if targetID == sourceID || !replica.Nodes.Contain(targetID) {
+		return -1, fmt.Errorf("target node %d is not another node of replica %d", targetID, replica.GetID())
+	}
+
+	log.Info("manually replicate segment...",
+		zap.Int64("collectionID", collectionID),
+		zap.Int64("segmentID", segmentID),
+		zap.Int64("sourceNodeID", sourceID),
+		zap.Int64("targetNodeID", targetID),
+	)
+	t, err := task.NewSegmentTask(ctx,
+		Params.QueryCoordCfg.SegmentTaskTimeout,
+		0,
+		collectionID,
+		replica.GetID(),
+		task.NewSegmentAction(targetID, task.ActionTypeGrow, segment.GetInsertChannel(), segmentID),
+		task.NewSegmentAction(sourceID, task.ActionTypeReduce, segment.GetInsertChannel(), segmentID),
+	)
+	if err != nil {
+		return -1, err
+	}
+	err = s.taskScheduler.Add(t)
+	if err != nil {
+		t.Cancel()
+		return -1, err
+	}
+	return targetID, task.Wait(ctx, Params.QueryCoordCfg.SegmentTaskTimeout, t)
+}
+
+func (s *Server) getSegmentOnNode(collectionID, segmentID, nodeID int64) (*meta.Replica, *meta.Segment, error) {
+	if s.badSegments.Get(segmentID) != nil {
+		return nil, nil, fmt.Errorf("segment %d is marked bad", segmentID)
+	}
+	replica := s.meta.ReplicaManager.GetByCollectionAndNode(collectionID, nodeID)
+	if replica == nil {
+		return nil, nil, fmt.Errorf("node %d not found in any replica of collection %d", nodeID, collectionID)
+	}
+	segment, ok := lo.Find(s.dist.SegmentDistManager.GetByCollectionAndNode(collectionID, nodeID), func(segment *meta.Segment) bool {
+		return segment.GetID() == segmentID
+	})
+	if !ok {
+		return nil, nil, fmt.Errorf("segment %d not found in node %d", segmentID, nodeID)
+	}
+	return replica, segment, nil
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
@@ -87,6 +88,9 @@ type Server struct {
 	targetMgr *meta.TargetManager
 	broker    meta.Broker
 
+	loadPriorities *meta.LoadPriorityManager
+	badSegments    *meta.BadSegmentManager
+
 	// Session
 	cluster session.Cluster
 	nodeMgr *session.NodeManager
@@ -106,7 +110,9 @@ type Server struct {
 	leaderObserver     *observers.LeaderObserver
 	targetObserver     *observers.TargetObserver
 
-	balancer balance.Balance
+	balancer        balance.Balance
+	restoreBalancer *balance.RestoreBalancer
+	snapshotStore   *meta.DistributionSnapshotStore
 
 	// Active-standby
 	enableActiveStandBy bool
@@ -200,6 +206,7 @@ func (s *Server) Init() error {
 		s.broker,
 		s.cluster,
 		s.nodeMgr,
+		s.loadPriorities,
 	)
 
 	// Init heartbeat
@@ -214,12 +221,19 @@ func (s *Server) Init() error {
 
 	// Init balancer
 	log.Info("init balancer")
-	s.balancer = balance.NewRowCountBasedBalancer(
-		s.taskScheduler,
+	s.restoreBalancer = balance.NewRestoreBalancer(
+		balance.NewRowCountBasedBalancer(
+			s.taskScheduler,
+			s.nodeMgr,
+			s.dist,
+			s.meta,
+		),
 		s.nodeMgr,
 		s.dist,
 		s.meta,
 	)
+	s.balancer = s.restoreBalancer
+	s.snapshotStore = meta.NewDistributionSnapshotStore(s.kv)
 
 	// Init checker controller
 	log.Info("init checker controller")
@@ -229,6 +243,7 @@ func (s *Server) Init() error {
 		s.targetMgr,
 		s.balancer,
 		s.taskScheduler,
+		s.nodeMgr,
 	)
 
 	// Init observers
@@ -259,6 +274,13 @@ func (s *Server) initMeta() error {
 		return err
 	}
 
+	s.loadPriorities = meta.NewLoadPriorityManager(s.kv)
+	err = s.loadPriorities.Recover()
+	if err != nil {
+		log.Error("failed to recover load priorities")
+		return err
+	}
+
 	s.dist = &meta.DistributionManager{
 		SegmentDistManager: meta.NewSegmentDistManager(),
 		ChannelDistManager: meta.NewChannelDistManager(),
@@ -271,6 +293,16 @@ func (s *Server) initMeta() error {
 	)
 	s.targetMgr = meta.NewTargetManager(s.broker, s.meta)
 
+	s.badSegments = meta.NewBadSegmentManager(s.kv)
+	err = s.badSegments.Recover()
+	if err != nil {
+		log.Error("failed to recover bad segments")
+		return err
+	}
+	for _, segment := range s.badSegments.GetAll() {
+		s.targetMgr.ExcludeSegments(segment.CollectionID, segment.SegmentID)
+	}
+
 	record.Record("Server initMeta")
 	return nil
 }
@@ -293,6 +325,7 @@ func (s *Server) initObserver() {
 		s.targetMgr,
 		s.dist,
 		s.broker,
+		s.nodeMgr,
 	)
 }
 
@@ -309,7 +342,7 @@ func (s *Server) Start() error {
 		return err
 	}
 	for _, node := range sessions {
-		s.nodeMgr.Add(session.NewNodeInfo(node.ServerID, node.Address))
+		s.nodeMgr.Add(newNodeInfo(node))
 	}
 	s.checkReplicas()
 	for _, node := range sessions {
@@ -341,6 +374,11 @@ func (s *Server) Start() error {
 	s.leaderObserver.Start(s.ctx)
 	s.targetObserver.Start(s.ctx)
 
+	registerSnapshotHandlerOnce.Do(s.registerSnapshotHandlers)
+	registerLoadPriorityHandlerOnce.Do(s.registerLoadPriorityHandlers)
+	registerSegmentSurgeryHandlerOnce.Do(s.registerSegmentSurgeryHandlers)
+	registerFieldStatsHandlerOnce.Do(s.registerFieldStatsHandlers)
+
 	if s.enableActiveStandBy {
 		s.activateFunc = func() {
 			// todo to complete
@@ -552,8 +590,9 @@ func (s *Server) watchNodes(revision int64) {
 				log.Info("add node to NodeManager",
 					zap.Int64("nodeID", nodeID),
 					zap.String("nodeAddr", addr),
+					zap.Bool("readOnly", event.Session.ReadOnly),
 				)
-				s.nodeMgr.Add(session.NewNodeInfo(nodeID, addr))
+				s.nodeMgr.Add(newNodeInfo(event.Session))
 				s.handleNodeUp(nodeID)
 				s.metricsCacheManager.InvalidateSystemInfoMetrics()
 
@@ -568,6 +607,12 @@ func (s *Server) watchNodes(revision int64) {
 	}
 }
 
+func newNodeInfo(nodeSession *sessionutil.Session) *session.NodeInfo {
+	info := session.NewNodeInfo(nodeSession.ServerID, nodeSession.Address)
+	info.SetReadOnly(nodeSession.ReadOnly)
+	return info
+}
+
 func (s *Server) handleNodeUp(node int64) {
 	log := log.With(zap.Int64("nodeID", node))
 	s.distController.StartDistInstance(s.ctx, node)
@@ -576,7 +621,12 @@ func (s *Server) handleNodeUp(node int64) {
 		log := log.With(zap.Int64("collectionID", collection))
 		replica := s.meta.ReplicaManager.GetByCollectionAndNode(collection, node)
 		if replica == nil {
-			replicas := s.meta.ReplicaManager.GetByCollection(collection)
+			// read-only nodes and the others never share a replica
+			replicas := utils.FilterReplicasByNodeKind(s.nodeMgr, s.meta.ReplicaManager.GetByCollection(collection), node)
+			if len(replicas) == 0 {
+				log.Info("no replica of the same kind for node, skip assigning it")
+				continue
+			}
 			sort.Slice(replicas, func(i, j int) bool {
 				return replicas[i].Nodes.Len() < replicas[j].Nodes.Len()
 			})
@@ -375,6 +375,7 @@ func (suite *ServerSuite) hackServer() {
 		suite.broker,
 		suite.server.cluster,
 		suite.server.nodeMgr,
+		suite.server.loadPriorities,
 	)
 	suite.server.distController = dist.NewDistController(
 		suite.server.cluster,
@@ -389,6 +390,7 @@ func (suite *ServerSuite) hackServer() {
 		suite.server.targetMgr,
 		suite.server.balancer,
 		suite.server.taskScheduler,
+		suite.server.nodeMgr,
 	)
 
 	suite.broker.EXPECT().GetCollectionSchema(mock.Anything, mock.Anything).Return(&schemapb.CollectionSchema{}, nil).Maybe()
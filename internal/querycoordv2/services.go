@@ -195,14 +195,28 @@ func (s *Server) LoadCollection(ctx context.Context, req *querypb.LoadCollection
 		return utils.WrapStatus(commonpb.ErrorCode_UnexpectedError, msg, ErrNotHealthy), nil
 	}
 
-	loadJob := job.NewLoadCollectionJob(ctx,
-		req,
-		s.dist,
-		s.meta,
-		s.targetMgr,
-		s.broker,
-		s.nodeMgr,
-	)
+	var loadJob job.Job
+	collection := s.meta.CollectionManager.GetCollection(req.GetCollectionID())
+	if collection != nil && req.GetReplicaNumber() > 0 && collection.GetReplicaNumber() != req.GetReplicaNumber() {
+		// Change the replica number of the loaded collection online
+		log.Info("alter replica number of loaded collection",
+			zap.Int32("oldReplicaNumber", collection.GetReplicaNumber()))
+		loadJob = job.NewAlterReplicaNumberJob(ctx,
+			req,
+			s.dist,
+			s.meta,
+			s.nodeMgr,
+		)
+	} else {
+		loadJob = job.NewLoadCollectionJob(ctx,
+			req,
+			s.dist,
+			s.meta,
+			s.targetMgr,
+			s.broker,
+			s.nodeMgr,
+		)
+	}
 	s.jobScheduler.Add(loadJob)
 	err := loadJob.Wait()
 	if err != nil && !errors.Is(err, job.ErrCollectionLoaded) {
@@ -246,6 +260,7 @@ func (s *Server) ReleaseCollection(ctx context.Context, req *querypb.ReleaseColl
 		metrics.QueryCoordReleaseCount.WithLabelValues(metrics.FailLabel).Inc()
 		return utils.WrapStatus(commonpb.ErrorCode_UnexpectedError, msg, err), nil
 	}
+	s.removeLoadPriority(req.GetCollectionID())
 
 	log.Info("collection released")
 	metrics.QueryCoordReleaseCount.WithLabelValues(metrics.SuccessLabel).Inc()
@@ -329,6 +344,10 @@ func (s *Server) ReleasePartitions(ctx context.Context, req *querypb.ReleasePart
 		metrics.QueryCoordReleaseCount.WithLabelValues(metrics.FailLabel).Inc()
 		return utils.WrapStatus(commonpb.ErrorCode_UnexpectedError, msg, err), nil
 	}
+	// the collection is removed once all its partitions are released
+	if !s.meta.CollectionManager.Exist(req.GetCollectionID()) {
+		s.removeLoadPriority(req.GetCollectionID())
+	}
 
 	metrics.QueryCoordReleaseCount.WithLabelValues(metrics.SuccessLabel).Inc()
 	metrics.QueryCoordReleaseLatency.WithLabelValues().Observe(float64(tr.ElapseSpan().Milliseconds()))
@@ -649,10 +668,23 @@ func (s *Server) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeade
 		return resp, nil
 	}
 
+	segments := s.targetMgr.GetHistoricalSegmentsByCollection(req.GetCollectionID(), meta.CurrentTarget)
+	readOnly := isReadOnlyReplicaTargeted(ctx)
 	for _, channel := range channels {
 		log := log.With(zap.String("channel", channel.GetChannelName()))
 
-		leaders := s.dist.LeaderViewManager.GetLeadersByShard(channel.GetChannelName())
+		// the read-only replicas serve stale snapshots, they are only returned to the requests targeting them
+		leaders := lo.PickBy(s.dist.LeaderViewManager.GetLeadersByShard(channel.GetChannelName()), func(nodeID int64, _ *meta.LeaderView) bool {
+			return utils.IsReadOnlyNode(s.nodeMgr, nodeID) == readOnly
+		})
+		// prefer the leaders with all segments loaded,
+		// the leaders of the replicas just spawned are not serviceable until then
+		readyLeaders := lo.PickBy(leaders, func(_ int64, leader *meta.LeaderView) bool {
+			return isLeaderReady(leader, segments)
+		})
+		if len(readyLeaders) > 0 {
+			leaders = readyLeaders
+		}
 		ids := make([]int64, 0, len(leaders))
 		addrs := make([]string, 0, len(leaders))
 		for _, leader := range leaders {
@@ -676,6 +708,9 @@ func (s *Server) GetShardLeaders(ctx context.Context, req *querypb.GetShardLeade
 
 		if len(ids) == 0 {
 			msg := fmt.Sprintf("channel %s is not available in any replica", channel.GetChannelName())
+			if readOnly {
+				msg = fmt.Sprintf("channel %s is not available in any read-only replica", channel.GetChannelName())
+			}
 			log.Warn(msg)
 			resp.Status = utils.WrapStatus(commonpb.ErrorCode_NoReplicaAvailable, msg)
 			resp.Shards = nil
@@ -23,6 +23,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/kv"
 	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
 	"github.com/milvus-io/milvus/internal/proto/datapb"
@@ -39,8 +40,10 @@ import (
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/metadata"
 )
 
 type ServiceSuite struct {
@@ -146,6 +149,7 @@ func (suite *ServiceSuite) SetupTest() {
 		jobScheduler:        suite.jobScheduler,
 		taskScheduler:       suite.taskScheduler,
 		balancer:            suite.balancer,
+		loadPriorities:      meta.NewLoadPriorityManager(suite.kv),
 	}
 	suite.server.UpdateStateCode(commonpb.StateCode_Healthy)
 }
@@ -272,6 +276,10 @@ func (suite *ServiceSuite) TestLoadCollectionFailed() {
 
 	// Test load with different replica number
 	for _, collection := range suite.collections {
+		if suite.loadTypes[collection] != querypb.LoadType_LoadPartition {
+			continue
+		}
+
 		req := &querypb.LoadCollectionRequest{
 			CollectionID:  collection,
 			ReplicaNumber: suite.replicaNumber[collection] + 1,
@@ -298,6 +306,49 @@ func (suite *ServiceSuite) TestLoadCollectionFailed() {
 	}
 }
 
+func (suite *ServiceSuite) TestAlterReplicaNumber() {
+	suite.loadAll()
+	ctx := context.Background()
+	server := suite.server
+
+	for _, collection := range suite.collections {
+		if suite.loadTypes[collection] != querypb.LoadType_LoadCollection {
+			continue
+		}
+
+		for _, replicaNumber := range []int32{3, 2, 5} {
+			req := &querypb.LoadCollectionRequest{
+				CollectionID:  collection,
+				ReplicaNumber: replicaNumber,
+			}
+			resp, err := server.LoadCollection(ctx, req)
+			suite.NoError(err)
+			suite.Equal(commonpb.ErrorCode_Success, resp.ErrorCode)
+			suite.EqualValues(replicaNumber, suite.meta.GetReplicaNumber(collection))
+
+			// All nodes are kept by the replicas
+			replicas := suite.meta.ReplicaManager.GetByCollection(collection)
+			suite.Len(replicas, int(replicaNumber))
+			nodes := typeutil.NewUniqueSet()
+			for _, replica := range replicas {
+				suite.NotEmpty(replica.GetNodes())
+				nodes.Insert(replica.GetNodes()...)
+			}
+			suite.Equal(len(suite.nodes), nodes.Len())
+		}
+
+		// Test alter with no enough nodes
+		req := &querypb.LoadCollectionRequest{
+			CollectionID:  collection,
+			ReplicaNumber: int32(len(suite.nodes)) + 1,
+		}
+		resp, err := server.LoadCollection(ctx, req)
+		suite.NoError(err)
+		suite.Contains(resp.Reason, job.ErrNoEnoughNode.Error())
+		suite.EqualValues(5, suite.meta.GetReplicaNumber(collection))
+	}
+}
+
 func (suite *ServiceSuite) TestLoadPartition() {
 	ctx := context.Background()
 	server := suite.server
@@ -394,6 +445,7 @@ func (suite *ServiceSuite) TestReleaseCollection() {
 
 	// Test release all collections
 	for _, collection := range suite.collections {
+		suite.NoError(server.loadPriorities.Set(collection, 10))
 		req := &querypb.ReleaseCollectionRequest{
 			CollectionID: collection,
 		}
@@ -401,6 +453,8 @@ func (suite *ServiceSuite) TestReleaseCollection() {
 		suite.NoError(err)
 		suite.Equal(commonpb.ErrorCode_Success, resp.ErrorCode)
 		suite.assertReleased(collection)
+		// the load priority of the released collection is removed
+		suite.Equal(meta.DefaultLoadPriority, server.loadPriorities.Get(collection))
 	}
 
 	// Test release again
@@ -832,6 +886,33 @@ func (suite *ServiceSuite) TestGetShardLeaders() {
 		}
 	}
 
+	// Test only the leaders with all segments loaded are returned
+	for _, collection := range suite.collections {
+		if suite.replicaNumber[collection] < 2 {
+			continue
+		}
+		readyLeaders := make(map[string]int64)
+		for _, channel := range suite.channels[collection] {
+			leader := suite.dist.LeaderViewManager.GetLeadersByShard(channel)
+			view := lo.Values(leader)[0].Clone()
+			view.Segments = make(map[int64]*querypb.SegmentDist)
+			for _, segment := range suite.getAllSegments(collection) {
+				view.Segments[segment] = &querypb.SegmentDist{NodeID: view.ID}
+			}
+			suite.dist.LeaderViewManager.Update(view.ID, view)
+			readyLeaders[channel] = view.ID
+		}
+		req := &querypb.GetShardLeadersRequest{
+			CollectionID: collection,
+		}
+		resp, err := server.GetShardLeaders(ctx, req)
+		suite.NoError(err)
+		suite.Equal(commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		for _, shard := range resp.Shards {
+			suite.Equal([]int64{readyLeaders[shard.GetChannelName()]}, shard.NodeIds)
+		}
+	}
+
 	// Test when server is not healthy
 	server.UpdateStateCode(commonpb.StateCode_Initializing)
 	req := &querypb.GetShardLeadersRequest{
@@ -842,6 +923,49 @@ func (suite *ServiceSuite) TestGetShardLeaders() {
 	suite.Contains(resp.Status.Reason, ErrNotHealthy.Error())
 }
 
+func (suite *ServiceSuite) TestGetShardLeadersReadOnlyReplica() {
+	suite.loadAll()
+	ctx := context.Background()
+	server := suite.server
+
+	for _, collection := range suite.collections {
+		if suite.replicaNumber[collection] < 2 {
+			continue
+		}
+		suite.updateCollectionStatus(collection, querypb.LoadStatus_Loaded)
+		suite.updateChannelDist(collection)
+		readOnlyNodes := suite.meta.ReplicaManager.GetByCollection(collection)[0].GetNodes()
+		for _, node := range readOnlyNodes {
+			suite.nodeMgr.Get(node).SetReadOnly(true)
+		}
+
+		req := &querypb.GetShardLeadersRequest{
+			CollectionID: collection,
+		}
+		resp, err := server.GetShardLeaders(ctx, req)
+		suite.NoError(err)
+		suite.Equal(commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		for _, shard := range resp.Shards {
+			suite.Len(shard.NodeIds, int(suite.replicaNumber[collection])-1)
+			suite.Empty(lo.Intersect(shard.NodeIds, readOnlyNodes))
+		}
+
+		readOnlyCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(common.ReadOnlyReplicaKey, "true"))
+		resp, err = server.GetShardLeaders(readOnlyCtx, req)
+		suite.NoError(err)
+		suite.Equal(commonpb.ErrorCode_Success, resp.Status.ErrorCode)
+		suite.Len(resp.Shards, len(suite.channels[collection]))
+		for _, shard := range resp.Shards {
+			suite.Len(shard.NodeIds, 1)
+			suite.Contains(readOnlyNodes, shard.NodeIds[0])
+		}
+
+		for _, node := range readOnlyNodes {
+			suite.nodeMgr.Get(node).SetReadOnly(false)
+		}
+	}
+}
+
 func (suite *ServiceSuite) loadAll() {
 	ctx := context.Background()
 	for _, collection := range suite.collections {
@@ -72,9 +72,10 @@ func NewNodeManager() *NodeManager {
 
 type NodeInfo struct {
 	stats
-	mu   sync.RWMutex
-	id   int64
-	addr string
+	mu       sync.RWMutex
+	id       int64
+	addr     string
+	readOnly bool
 }
 
 func (n *NodeInfo) ID() int64 {
@@ -85,6 +86,17 @@ func (n *NodeInfo) Addr() string {
 	return n.addr
 }
 
+// IsReadOnly returns true if the node never consumes the message stream,
+// such nodes only serve the sealed segments
+func (n *NodeInfo) IsReadOnly() bool {
+	return n.readOnly
+}
+
+// SetReadOnly marks whether the node is a read-only node, it must be called before adding the node to NodeManager
+func (n *NodeInfo) SetReadOnly(readOnly bool) {
+	n.readOnly = readOnly
+}
+
 func (n *NodeInfo) SegmentCnt() int {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
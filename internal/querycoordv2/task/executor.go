@@ -332,6 +332,55 @@ func (ex *Executor) releaseSegment(task *SegmentTask, step int) {
 	log.Info("release segment done", zap.Int64("taskID", task.ID()), zap.Duration("time taken", elapsed))
 }
 
+// releasePreempted releases the segment of the preempted loading task on its node,
+// the querynode may have loaded the segment, or be still loading it, though the task is canceled.
+// The release is sent once the loading request returns, otherwise it may be overtaken by the loading.
+func (ex *Executor) releasePreempted(task *SegmentTask) {
+	step := task.Step()
+	if step >= len(task.Actions()) {
+		return
+	}
+	action := task.Actions()[step].(*SegmentAction)
+	log := log.With(
+		zap.Int64("taskID", task.ID()),
+		zap.Int64("collectionID", task.CollectionID()),
+		zap.Int64("segmentID", task.SegmentID()),
+		zap.Int64("node", action.Node()),
+	)
+
+	go func() {
+		ticker := time.NewTicker(preemptedReleaseInterval)
+		defer ticker.Stop()
+		for ex.Exist(task.ID()) {
+			select {
+			case <-ticker.C:
+			case <-ex.doneCh:
+				return
+			}
+		}
+
+		dstNode := action.Node()
+		req := packReleaseSegmentRequest(task, action)
+		if leader, ok := getShardLeader(ex.meta.ReplicaManager, ex.dist, task.CollectionID(), action.Node(), task.Shard()); ok {
+			dstNode = leader
+			req.NeedTransfer = true
+		}
+		// the context of the task is canceled already
+		ctx, cancel := context.WithTimeout(context.Background(), preemptedReleaseTimeout)
+		defer cancel()
+		status, err := ex.cluster.ReleaseSegments(ctx, dstNode, req)
+		if err != nil {
+			log.Warn("failed to release preempted segment, it may be a false failure", zap.Error(err))
+			return
+		}
+		if status.ErrorCode != commonpb.ErrorCode_Success {
+			log.Warn("failed to release preempted segment", zap.String("reason", status.GetReason()))
+			return
+		}
+		log.Info("preempted segment released")
+	}()
+}
+
 func (ex *Executor) executeDmChannelAction(task *ChannelTask, step int) {
 	switch task.Actions()[step].Type() {
 	case ActionTypeGrow:
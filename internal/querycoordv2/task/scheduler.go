@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	. "github.com/milvus-io/milvus/internal/util/typeutil"
@@ -40,6 +43,11 @@ const (
 	TaskTypeMove
 
 	taskPoolSize = 256
+
+	// the interval to check whether the loading of a preempted task returns,
+	// and the timeout to release its segment after that
+	preemptedReleaseInterval = 100 * time.Millisecond
+	preemptedReleaseTimeout  = 10 * time.Second
 )
 
 var (
@@ -165,6 +173,8 @@ type taskScheduler struct {
 	broker    meta.Broker
 	nodeMgr   *session.NodeManager
 
+	loadPriorities *meta.LoadPriorityManager
+
 	tasks        UniqueSet
 	segmentTasks map[replicaSegmentIndex]Task
 	channelTasks map[replicaChannelIndex]Task
@@ -178,7 +188,8 @@ func NewScheduler(ctx context.Context,
 	targetMgr *meta.TargetManager,
 	broker meta.Broker,
 	cluster session.Cluster,
-	nodeMgr *session.NodeManager) *taskScheduler {
+	nodeMgr *session.NodeManager,
+	loadPriorities *meta.LoadPriorityManager) *taskScheduler {
 	id := int64(0)
 	return &taskScheduler{
 		ctx:      ctx,
@@ -194,6 +205,8 @@ func NewScheduler(ctx context.Context,
 		broker:    broker,
 		nodeMgr:   nodeMgr,
 
+		loadPriorities: loadPriorities,
+
 		tasks:        make(UniqueSet),
 		segmentTasks: make(map[replicaSegmentIndex]Task),
 		channelTasks: make(map[replicaChannelIndex]Task),
@@ -329,8 +342,12 @@ func (scheduler *taskScheduler) tryPromoteAll() {
 	// Promote waiting tasks
 	toPromote := make([]Task, 0, scheduler.processQueue.Cap()-scheduler.processQueue.Len())
 	toRemove := make([]Task, 0)
-	scheduler.waitQueue.Range(func(task Task) bool {
+	priorities := scheduler.loadPriorities.GetAll()
+	for _, task := range scheduler.waitingTasks(priorities) {
 		err := scheduler.promote(task)
+		if errors.Is(err, ErrTaskQueueFull) && scheduler.preempt(task, priorities) {
+			err = scheduler.promote(task)
+		}
 		if errors.Is(err, ErrTaskStale) { // Task canceled or stale
 			task.SetStatus(TaskStatusStale)
 			task.SetErr(err)
@@ -343,8 +360,10 @@ func (scheduler *taskScheduler) tryPromoteAll() {
 			toPromote = append(toPromote, task)
 		}
 
-		return !errors.Is(err, ErrTaskQueueFull)
-	})
+		if errors.Is(err, ErrTaskQueueFull) {
+			break
+		}
+	}
 
 	for _, task := range toPromote {
 		scheduler.waitQueue.Remove(task)
@@ -360,6 +379,65 @@ func (scheduler *taskScheduler) tryPromoteAll() {
 	}
 }
 
+// waitingTasks returns the waiting tasks ordered by priority from high to low,
+// the tasks with the same priority are ordered by the load priority of their collections
+func (scheduler *taskScheduler) waitingTasks(priorities map[int64]int64) []Task {
+	tasks := make([]Task, 0, scheduler.waitQueue.Len())
+	scheduler.waitQueue.Range(func(task Task) bool {
+		tasks = append(tasks, task)
+		return true
+	})
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority() != tasks[j].Priority() {
+			return tasks[i].Priority() > tasks[j].Priority()
+		}
+		return priorities[tasks[i].CollectionID()] > priorities[tasks[j].CollectionID()]
+	})
+	return tasks
+}
+
+// preempt cancels a processing segment loading task to make room for the given task,
+// the canceled one has no higher priority and belongs to the collection with the lowest load priority,
+// which must be lower than the given task's.
+// The segment of the canceled task is released on the querynode, which may have loaded it or be still loading it,
+// and it will be loaded again by the segment checker later.
+// Returns true if some task is canceled
+func (scheduler *taskScheduler) preempt(task Task, priorities map[int64]int64) bool {
+	if !Params.QueryCoordCfg.LoadPriorityPreemptionEnabled || GetTaskType(task) != TaskTypeGrow {
+		return false
+	}
+
+	var victim Task
+	scheduler.processQueue.Range(func(processing Task) bool {
+		if _, ok := processing.(*SegmentTask); !ok ||
+			GetTaskType(processing) != TaskTypeGrow ||
+			processing.Priority() > task.Priority() {
+			return true
+		}
+		loadPriority := priorities[processing.CollectionID()]
+		if loadPriority < priorities[task.CollectionID()] &&
+			(victim == nil || loadPriority < priorities[victim.CollectionID()]) {
+			victim = processing
+		}
+		return true
+	})
+	if victim == nil {
+		return false
+	}
+
+	log.Info("preempt task of collection with lower load priority",
+		zap.Int64("taskID", victim.ID()),
+		zap.Int64("collectionID", victim.CollectionID()),
+		zap.Int64("preemptedByTaskID", task.ID()),
+		zap.Int64("preemptedByCollectionID", task.CollectionID()),
+	)
+	victim.SetStatus(TaskStatusCanceled)
+	victim.SetErr(utils.WrapError("preempted by the task of collection with higher load priority", ErrTaskCanceled))
+	scheduler.remove(victim)
+	scheduler.executor.releasePreempted(victim.(*SegmentTask))
+	return true
+}
+
 func (scheduler *taskScheduler) prePromote(task Task) error {
 	if scheduler.checkCanceled(task) {
 		return ErrTaskCanceled
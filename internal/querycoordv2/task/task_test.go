@@ -1129,6 +1129,66 @@ func (suite *TaskSuite) TestSegmentTaskReplace() {
 	suite.AssertTaskNum(0, segmentNum, 0, segmentNum)
 }
 
+func (suite *TaskSuite) TestLoadPriorityPreemption() {
+	ctx := context.Background()
+	timeout := 10 * time.Second
+	targetNode := int64(3)
+	lowCollection, highCollection := int64(1000), int64(1001)
+
+	scheduler := suite.newScheduler()
+	suite.NoError(scheduler.loadPriorities.Set(highCollection, 10))
+	defer scheduler.loadPriorities.Remove(highCollection)
+
+	newLoadTask := func(collection int64, segment int64) Task {
+		task, err := NewSegmentTask(ctx, timeout, 0, collection, suite.replica,
+			NewSegmentAction(targetNode, ActionTypeGrow, "", segment))
+		suite.NoError(err)
+		return task
+	}
+	low := newLoadTask(lowCollection, 1)
+	high := newLoadTask(highCollection, 2)
+	suite.NoError(scheduler.Add(low))
+	suite.NoError(scheduler.Add(high))
+
+	// Waiting tasks of collections with higher load priority go first
+	priorities := scheduler.loadPriorities.GetAll()
+	suite.Equal([]Task{high, low}, scheduler.waitingTasks(priorities))
+
+	// The processing task of collection with lower load priority is preempted,
+	// and its segment is released on the querynode
+	released := make(chan *querypb.ReleaseSegmentsRequest, 1)
+	suite.cluster.EXPECT().ReleaseSegments(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ int64, req *querypb.ReleaseSegmentsRequest) {
+			released <- req
+		}).
+		Return(utils.WrapStatus(commonpb.ErrorCode_Success, ""), nil).Once()
+	scheduler.waitQueue.Remove(low)
+	suite.True(scheduler.processQueue.Add(low))
+	suite.True(scheduler.preempt(high, priorities))
+	suite.Equal(TaskStatusCanceled, low.Status())
+	suite.ErrorIs(low.Err(), ErrTaskCanceled)
+	suite.Equal(0, scheduler.processQueue.Len())
+	select {
+	case req := <-released:
+		suite.Equal([]int64{1}, req.GetSegmentIDs())
+		suite.Equal(lowCollection, req.GetCollectionID())
+	case <-time.After(timeout):
+		suite.Fail("the segment of the preempted task is not released")
+	}
+
+	// The processing task of collection with higher load priority is never preempted
+	scheduler.waitQueue.Remove(high)
+	suite.True(scheduler.processQueue.Add(high))
+	suite.False(scheduler.preempt(newLoadTask(lowCollection, 3), priorities))
+
+	// No preemption if disabled
+	Params.QueryCoordCfg.LoadPriorityPreemptionEnabled = false
+	defer func() { Params.QueryCoordCfg.LoadPriorityPreemptionEnabled = true }()
+	suite.NoError(scheduler.loadPriorities.Set(lowCollection, 20))
+	defer scheduler.loadPriorities.Remove(lowCollection)
+	suite.False(scheduler.preempt(newLoadTask(lowCollection, 3), scheduler.loadPriorities.GetAll()))
+}
+
 func (suite *TaskSuite) AssertTaskNum(process, wait, channel, segment int) {
 	scheduler := suite.scheduler
 
@@ -1170,6 +1230,7 @@ func (suite *TaskSuite) newScheduler() *taskScheduler {
 		suite.broker,
 		suite.cluster,
 		suite.nodeMgr,
+		meta.NewLoadPriorityManager(suite.kv),
 	)
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sort"
 
 	"github.com/samber/lo"
 
@@ -99,9 +100,37 @@ func GroupSegmentsByReplica(replicaMgr *meta.ReplicaManager, collectionID int64,
 	return ret
 }
 
+// IsReadOnlyNode returns true if the given node is online and never consumes the message stream
+func IsReadOnlyNode(nodeMgr *session.NodeManager, nodeID int64) bool {
+	node := nodeMgr.Get(nodeID)
+	return node != nil && node.IsReadOnly()
+}
+
+// IsReadOnlyReplica returns true if the replica consists of read-only nodes,
+// read-only nodes and the others never share a replica
+func IsReadOnlyReplica(nodeMgr *session.NodeManager, replica *meta.Replica) bool {
+	for node := range replica.Nodes {
+		if IsReadOnlyNode(nodeMgr, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterReplicasByNodeKind returns the replicas which the given node could join,
+// that is the empty replicas and the ones whose nodes are the same kind as the given node
+func FilterReplicasByNodeKind(nodeMgr *session.NodeManager, replicas []*meta.Replica, nodeID int64) []*meta.Replica {
+	readOnly := IsReadOnlyNode(nodeMgr, nodeID)
+	return lo.Filter(replicas, func(replica *meta.Replica, _ int) bool {
+		return replica.Nodes.Len() == 0 || IsReadOnlyReplica(nodeMgr, replica) == readOnly
+	})
+}
+
 // AssignNodesToReplicas assigns nodes to the given replicas,
 // all given replicas must be the same collection,
-// the given replicas have to be not in ReplicaManager
+// the given replicas have to be not in ReplicaManager.
+// If there are both read-only nodes and the others, the read-only nodes are all assigned to the last replica,
+// which serves as the read-only replica, and they are left unassigned if there is only one replica
 func AssignNodesToReplicas(nodeMgr *session.NodeManager, replicas ...*meta.Replica) {
 	replicaNumber := len(replicas)
 	nodes := nodeMgr.GetAll()
@@ -109,8 +138,34 @@ func AssignNodesToReplicas(nodeMgr *session.NodeManager, replicas ...*meta.Repli
 		nodes[i], nodes[j] = nodes[j], nodes[i]
 	})
 
-	for i, node := range nodes {
-		replicas[i%replicaNumber].AddNode(node.ID())
+	var regularNodes, readOnlyNodes []*session.NodeInfo
+	for _, node := range nodes {
+		if node.IsReadOnly() {
+			readOnlyNodes = append(readOnlyNodes, node)
+		} else {
+			regularNodes = append(regularNodes, node)
+		}
+	}
+
+	readOnlyReplicaNumber := 0
+	if len(readOnlyNodes) > 0 {
+		if len(regularNodes) == 0 {
+			readOnlyReplicaNumber = replicaNumber
+		} else if replicaNumber > 1 {
+			readOnlyReplicaNumber = 1
+		}
+	}
+
+	regularReplicas := replicas[:replicaNumber-readOnlyReplicaNumber]
+	for i, node := range regularNodes {
+		regularReplicas[i%len(regularReplicas)].AddNode(node.ID())
+	}
+	readOnlyReplicas := replicas[replicaNumber-readOnlyReplicaNumber:]
+	for i, node := range readOnlyNodes {
+		if len(readOnlyReplicas) == 0 {
+			break
+		}
+		readOnlyReplicas[i%len(readOnlyReplicas)].AddNode(node.ID())
 	}
 }
 
@@ -123,3 +178,77 @@ func SpawnReplicas(replicaMgr *meta.ReplicaManager, nodeMgr *session.NodeManager
 	AssignNodesToReplicas(nodeMgr, replicas...)
 	return replicas, replicaMgr.Put(replicas...)
 }
+
+// GrowReplicas spawns new replicas for given collection until it has replicaNumber replicas,
+// nodes are moved from the replicas with the most nodes to the new ones,
+// the nodes with fewer segments of the collection are moved first to reduce the data movement.
+// It returns all the changed and spawned replicas, they are not saved yet
+func GrowReplicas(replicaMgr *meta.ReplicaManager, dist *meta.DistributionManager, collection int64, replicaNumber int32) ([]*meta.Replica, error) {
+	replicas := lo.Map(replicaMgr.GetByCollection(collection), func(replica *meta.Replica, _ int) *meta.Replica {
+		return replica.Clone()
+	})
+	spawned, err := replicaMgr.Spawn(collection, replicaNumber-int32(len(replicas)))
+	if err != nil {
+		return nil, err
+	}
+
+	nodeNum := 0
+	for _, replica := range replicas {
+		nodeNum += replica.Nodes.Len()
+	}
+	nodesPerReplica := nodeNum / int(replicaNumber)
+	if nodesPerReplica == 0 {
+		nodesPerReplica = 1
+	}
+
+	all := append(replicas, spawned...)
+	for _, replica := range spawned {
+		for replica.Nodes.Len() < nodesPerReplica {
+			donor := lo.MaxBy(all, func(a, b *meta.Replica) bool {
+				return a.Nodes.Len() > b.Nodes.Len()
+			})
+			if donor.Nodes.Len() <= 1 {
+				return nil, fmt.Errorf("no enough nodes to spawn %d replicas for collection %d", replicaNumber, collection)
+			}
+
+			node := lo.MinBy(donor.GetNodes(), func(a, b int64) bool {
+				return len(dist.SegmentDistManager.GetByCollectionAndNode(collection, a)) <
+					len(dist.SegmentDistManager.GetByCollectionAndNode(collection, b))
+			})
+			donor.RemoveNode(node)
+			replica.AddNode(node)
+		}
+	}
+	return all, nil
+}
+
+// ShrinkReplicas picks the replicas to remove until given collection has replicaNumber replicas,
+// the replicas with the fewest loaded segments are removed first,
+// their nodes are moved to the remaining replicas with the fewest nodes.
+// It returns the remaining replicas and the removed replicas, they are not saved yet
+func ShrinkReplicas(replicaMgr *meta.ReplicaManager, dist *meta.DistributionManager, collection int64, replicaNumber int32) ([]*meta.Replica, []*meta.Replica) {
+	replicas := lo.Map(replicaMgr.GetByCollection(collection), func(replica *meta.Replica, _ int) *meta.Replica {
+		return replica.Clone()
+	})
+	segmentNum := make(map[int64]int, len(replicas))
+	for _, replica := range replicas {
+		for _, node := range replica.GetNodes() {
+			segmentNum[replica.GetID()] += len(dist.SegmentDistManager.GetByCollectionAndNode(collection, node))
+		}
+	}
+	sort.SliceStable(replicas, func(i, j int) bool {
+		return segmentNum[replicas[i].GetID()] < segmentNum[replicas[j].GetID()]
+	})
+
+	removed := replicas[:len(replicas)-int(replicaNumber)]
+	remaining := replicas[len(removed):]
+	for _, replica := range removed {
+		for _, node := range replica.GetNodes() {
+			receiver := lo.MinBy(remaining, func(a, b *meta.Replica) bool {
+				return a.Nodes.Len() < b.Nodes.Len()
+			})
+			receiver.AddNode(node)
+		}
+	}
+	return remaining, removed
+}
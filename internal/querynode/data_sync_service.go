@@ -211,6 +211,7 @@ func (dsService *dataSyncService) removeFlowGraphsByDMLChannels(channels []Chann
 		}
 		delete(dsService.dmlChannel2FlowGraph, channel)
 		rateCol.removeTSafeChannel(channel)
+		lagMonitor.remove(channel)
 	}
 }
 
@@ -227,6 +228,7 @@ func (dsService *dataSyncService) removeFlowGraphsByDeltaChannels(channels []Cha
 		}
 		delete(dsService.deltaChannel2FlowGraph, channel)
 		rateCol.removeTSafeChannel(channel)
+		lagMonitor.remove(channel)
 	}
 }
 
@@ -274,6 +276,7 @@ func (dsService *dataSyncService) removeEmptyFlowGraphByChannel(collectionID int
 	// try best to remove, it's ok if all info is gone before this call
 	dsService.metaReplica.removeCollectionVDeltaChannel(collectionID, dc)
 	rateCol.removeTSafeChannel(dc)
+	lagMonitor.remove(dc)
 }
 
 // newDataSyncService returns a new dataSyncService
@@ -0,0 +1,139 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/spaolacci/murmur3"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+// observeFieldData adds the values of a scalar field data to its statistics in @stats, other types are skipped.
+func observeFieldData(stats map[FieldID]*metricsinfo.FieldStatistics, data *schemapb.FieldData) {
+	fieldStats, ok := stats[data.GetFieldId()]
+	if !ok {
+		fieldStats = &metricsinfo.FieldStatistics{}
+	}
+
+	buf := make([]byte, 8)
+	observeNumber := func(bits uint64, v float64) {
+		binary.LittleEndian.PutUint64(buf, bits)
+		fieldStats.ObserveNumber(murmur3.Sum64(buf), v)
+	}
+	scalars := data.GetScalars()
+	switch data.GetType() {
+	case schemapb.DataType_Bool:
+		for _, v := range scalars.GetBoolData().GetData() {
+			if v {
+				observeNumber(1, 1)
+			} else {
+				observeNumber(0, 0)
+			}
+		}
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		for _, v := range scalars.GetIntData().GetData() {
+			observeNumber(uint64(v), float64(v))
+		}
+	case schemapb.DataType_Int64:
+		for _, v := range scalars.GetLongData().GetData() {
+			observeNumber(uint64(v), float64(v))
+		}
+	case schemapb.DataType_Float:
+		for _, v := range scalars.GetFloatData().GetData() {
+			observeNumber(math.Float64bits(float64(v)), float64(v))
+		}
+	case schemapb.DataType_Double:
+		for _, v := range scalars.GetDoubleData().GetData() {
+			observeNumber(math.Float64bits(v), v)
+		}
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		for _, v := range scalars.GetStringData().GetData() {
+			fieldStats.Observe(murmur3.Sum64([]byte(v)))
+		}
+	default:
+		return
+	}
+	stats[data.GetFieldId()] = fieldStats
+}
+
+// fieldStatsCollector merges the live field statistics of the segments of every collection periodically,
+// so that the merged statistics are served to queryCoord without merging on every request.
+// The statistics of a collection only cover the segments served by this node.
+type fieldStatsCollector struct {
+	replica ReplicaInterface
+
+	mu    sync.RWMutex
+	stats map[UniqueID]map[FieldID]*metricsinfo.FieldStatistics
+}
+
+func newFieldStatsCollector(replica ReplicaInterface) *fieldStatsCollector {
+	return &fieldStatsCollector{
+		replica: replica,
+		stats:   make(map[UniqueID]map[FieldID]*metricsinfo.FieldStatistics),
+	}
+}
+
+// run refreshes the merged statistics periodically until ctx is done.
+func (c *fieldStatsCollector) run(ctx context.Context) {
+	if !Params.QueryNodeCfg.FieldStatsEnabled {
+		return
+	}
+	ticker := time.NewTicker(Params.QueryNodeCfg.FieldStatsRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh()
+		}
+	}
+}
+
+// refresh merges the statistics of all the sealed and growing segments by collection.
+func (c *fieldStatsCollector) refresh() {
+	stats := make(map[UniqueID]map[FieldID]*metricsinfo.FieldStatistics)
+	segments := append(c.replica.getSealedSegments(), c.replica.getGrowingSegments()...)
+	for _, segment := range segments {
+		collStats, ok := stats[segment.collectionID]
+		if !ok {
+			collStats = make(map[FieldID]*metricsinfo.FieldStatistics)
+			stats[segment.collectionID] = collStats
+		}
+		for fieldID, fieldStats := range segment.getFieldStatistics() {
+			collStats[fieldID] = metricsinfo.MergeFieldStatistics(collStats[fieldID], fieldStats)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = stats
+}
+
+// collectionStats returns the merged statistics as of the last refresh, collectionID -> fieldID -> stats.
+func (c *fieldStatsCollector) collectionStats() map[int64]map[int64]*metricsinfo.FieldStatistics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stats
+}
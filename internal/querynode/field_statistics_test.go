@@ -0,0 +1,64 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
+)
+
+func TestObserveFieldData(t *testing.T) {
+	stats := make(map[FieldID]*metricsinfo.FieldStatistics)
+	observeFieldData(stats, &schemapb.FieldData{
+		Type:    schemapb.DataType_VarChar,
+		FieldId: 101,
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+			Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", "b", "a"}}},
+		}},
+	})
+	observeFieldData(stats, &schemapb.FieldData{
+		Type:    schemapb.DataType_FloatVector,
+		FieldId: 102,
+	})
+	assert.Len(t, stats, 1)
+	assert.Equal(t, int64(3), stats[101].RowCount)
+	assert.Equal(t, int64(2), stats[101].NDV())
+	assert.Empty(t, stats[101].Sample)
+}
+
+func TestFieldStatsCollector(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replica, err := genSimpleReplicaWithSealSegment(ctx)
+	assert.NoError(t, err)
+
+	collector := newFieldStatsCollector(replica)
+	assert.Empty(t, collector.collectionStats())
+	collector.refresh()
+	stats := collector.collectionStats()[defaultCollectionID][simpleInt64Field.id]
+	assert.NotNil(t, stats)
+	assert.Equal(t, int64(defaultMsgLength), stats.RowCount)
+	assert.Equal(t, int64(defaultMsgLength), stats.NDV())
+	bounds := stats.Histogram(2)
+	assert.Equal(t, []float64{0, defaultMsgLength/2 - 1, defaultMsgLength - 1}, bounds)
+}
@@ -364,8 +364,9 @@ func filterSegmentsByPKs(pks []primaryKey, timestamps []Timestamp, segment *Segm
 
 	retPks := make([]primaryKey, 0)
 	retTss := make([]Timestamp, 0)
+	exist := segment.pksExist(pks)
 	for index, pk := range pks {
-		if segment.isPKExist(pk) {
+		if exist[index] {
 			retPks = append(retPks, pk)
 			retTss = append(retTss, timestamps[index])
 		}
@@ -66,6 +66,7 @@ func (stNode *serviceTimeNode) Operate(in []flowgraph.Msg) []flowgraph.Msg {
 		panic(fmt.Errorf("serviceTimeNode setTSafe timeout, collectionID = %d, err = %s", stNode.collectionID, err))
 	}
 	rateCol.updateTSafe(stNode.vChannel, serviceTimeMsg.timeRange.timestampMax)
+	lagMonitor.update(stNode.collectionID, stNode.vChannel, serviceTimeMsg.timeRange.timestampMax)
 	p, _ := tsoutil.ParseTS(serviceTimeMsg.timeRange.timestampMax)
 	log.RatedDebug(10.0, "update tSafe:",
 		zap.Int64("collectionID", stNode.collectionID),
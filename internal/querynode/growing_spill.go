@@ -0,0 +1,121 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/hardware"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// growingSpillPrefix is the prefix of the growing segment chunks spilled to the local storage
+const growingSpillPrefix = "growing_spill"
+
+// growingSpiller spills the cold chunks of growing segments to the local storage once the memory usage ratio
+// of the node exceeds queryNode.growingSpill.memoryWatermark, so that heavy ingest with slow flush doesn't
+// blow the memory. The largest growing segments are spilled first, searches read the spilled chunks back
+// block by block, and the spilled files are removed with the segments.
+type growingSpiller struct {
+	replica ReplicaInterface
+	dir     string
+
+	usedMemory  func() uint64
+	totalMemory func() uint64
+}
+
+func newGrowingSpiller(replica ReplicaInterface) *growingSpiller {
+	return &growingSpiller{
+		replica:     replica,
+		dir:         path.Join(Params.LocalStorageCfg.Path.GetValue(), growingSpillPrefix, strconv.FormatInt(paramtable.GetNodeID(), 10)),
+		usedMemory:  hardware.GetUsedMemoryCount,
+		totalMemory: hardware.GetMemoryCount,
+	}
+}
+
+// run checks the memory usage periodically until ctx is done,
+// the files spilled by the previous run of the node are removed first.
+func (s *growingSpiller) run(ctx context.Context) {
+	if Params.QueryNodeCfg.GrowingSpillMemoryWatermark <= 0 {
+		return
+	}
+	if err := os.RemoveAll(s.dir); err != nil {
+		log.Warn("failed to clean up growing spill dir", zap.String("dir", s.dir), zap.Error(err))
+	}
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		log.Warn("failed to create growing spill dir, growing segments are never spilled", zap.String("dir", s.dir), zap.Error(err))
+		return
+	}
+
+	ticker := time.NewTicker(Params.QueryNodeCfg.GrowingSpillCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.check()
+		}
+	}
+}
+
+// check spills the growing segments until the memory usage drops below the watermark, returns the bytes spilled.
+func (s *growingSpiller) check() int64 {
+	watermark := Params.QueryNodeCfg.GrowingSpillMemoryWatermark
+	used, total := s.usedMemory(), s.totalMemory()
+	limit := uint64(float64(total) * watermark)
+	if watermark <= 0 || used <= limit {
+		return 0
+	}
+
+	target := int64(used - limit)
+	segments := s.replica.getGrowingSegments()
+	sizes := make(map[UniqueID]int64, len(segments))
+	for _, segment := range segments {
+		sizes[segment.segmentID] = segment.getMemSize()
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return sizes[segments[i].segmentID] > sizes[segments[j].segmentID]
+	})
+
+	spilled := int64(0)
+	for _, segment := range segments {
+		if spilled >= target {
+			break
+		}
+		n, err := segment.spill(s.dir, target-spilled)
+		if err != nil {
+			log.Warn("failed to spill growing segment", zap.Int64("segmentID", segment.segmentID), zap.Error(err))
+			continue
+		}
+		spilled += n
+	}
+	log.Info("memory usage exceeds the growing spill watermark",
+		zap.Uint64("usedMemory", used),
+		zap.Uint64("totalMemory", total),
+		zap.Float64("watermark", watermark),
+		zap.Int64("spilledBytes", spilled))
+	return spilled
+}
@@ -0,0 +1,49 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrowingSpiller(t *testing.T) {
+	replica, err := genSimpleReplicaWithGrowingSegment()
+	require.NoError(t, err)
+
+	watermark := Params.QueryNodeCfg.GrowingSpillMemoryWatermark
+	defer func() { Params.QueryNodeCfg.GrowingSpillMemoryWatermark = watermark }()
+
+	s := newGrowingSpiller(replica)
+	s.dir = t.TempDir()
+	s.usedMemory = func() uint64 { return 90 }
+	s.totalMemory = func() uint64 { return 100 }
+
+	// 0 disables the spill
+	Params.QueryNodeCfg.GrowingSpillMemoryWatermark = 0
+	assert.Equal(t, int64(0), s.check())
+
+	// below the watermark
+	Params.QueryNodeCfg.GrowingSpillMemoryWatermark = 0.95
+	assert.Equal(t, int64(0), s.check())
+
+	// the chunks of the simple growing segment are not full, nothing to spill
+	Params.QueryNodeCfg.GrowingSpillMemoryWatermark = 0.5
+	assert.Equal(t, int64(0), s.check())
+}
@@ -27,6 +27,7 @@ import (
 	queryPb "github.com/milvus-io/milvus/internal/proto/querypb"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/samber/lo"
 )
 
@@ -102,6 +103,13 @@ func (l *loadSegmentsTask) Execute(ctx context.Context) error {
 		return err
 	}
 
+	if Params.QueryNodeCfg.ReadOnly {
+		// read-only nodes serve the deletions in the delta logs only
+		log.Info("LoadSegmentTask Execute done on read-only node", zap.Int64("collectionID", l.req.CollectionID),
+			zap.Int64("replicaID", l.req.ReplicaID))
+		return nil
+	}
+
 	runningGroup, groupCtx := errgroup.WithContext(l.ctx)
 	for _, deltaPosition := range l.req.DeltaPositions {
 		pos := deltaPosition
@@ -171,42 +179,53 @@ func (l *loadSegmentsTask) watchDeltaChannel(vchanName []string) error {
 		return nil
 	}
 
-	channel2FlowGraph, err := l.node.dataSyncService.addFlowGraphsForDeltaChannels(collectionID, vDeltaChannels)
-	if err != nil {
-		log.Warn("watchDeltaChannel, add flowGraph for deltaChannel failed", zap.Int64("collectionID", collectionID), zap.Strings("vDeltaChannels", vDeltaChannels), zap.Error(err))
-		return err
-	}
-	consumeSubName := funcutil.GenChannelSubName(Params.CommonCfg.QueryNodeSubName, collectionID, paramtable.GetNodeID())
-
-	// channels as consumer
-	for channel, fg := range channel2FlowGraph {
-		pchannel := VPDeltaChannels[channel]
-		// use pChannel to consume
-		err = fg.consumeFlowGraphFromLatest(pchannel, consumeSubName)
+	var channel2FlowGraph map[string]*queryNodeFlowGraph
+	// read-only nodes never consume the delta channels
+	if !Params.QueryNodeCfg.ReadOnly {
+		channel2FlowGraph, err = l.node.dataSyncService.addFlowGraphsForDeltaChannels(collectionID, vDeltaChannels)
 		if err != nil {
-			log.Error("msgStream as consumer failed for deltaChannels", zap.Int64("collectionID", collectionID), zap.Strings("vDeltaChannels", vDeltaChannels))
-			break
+			log.Warn("watchDeltaChannel, add flowGraph for deltaChannel failed", zap.Int64("collectionID", collectionID), zap.Strings("vDeltaChannels", vDeltaChannels), zap.Error(err))
+			return err
 		}
-	}
-
-	if err != nil {
-		log.Warn("watchDeltaChannel, add flowGraph for deltaChannel failed", zap.Int64("collectionID", collectionID), zap.Strings("vDeltaChannels", vDeltaChannels), zap.Error(err))
-		for _, fg := range channel2FlowGraph {
-			fg.flowGraph.Close()
+		consumeSubName := funcutil.GenChannelSubName(Params.CommonCfg.QueryNodeSubName, collectionID, paramtable.GetNodeID())
+
+		// channels as consumer
+		for channel, fg := range channel2FlowGraph {
+			pchannel := VPDeltaChannels[channel]
+			// use pChannel to consume
+			err = fg.consumeFlowGraphFromLatest(pchannel, consumeSubName)
+			if err != nil {
+				log.Error("msgStream as consumer failed for deltaChannels", zap.Int64("collectionID", collectionID), zap.Strings("vDeltaChannels", vDeltaChannels))
+				break
+			}
 		}
-		gcChannels := make([]Channel, 0)
-		for channel := range channel2FlowGraph {
-			gcChannels = append(gcChannels, channel)
+
+		if err != nil {
+			log.Warn("watchDeltaChannel, add flowGraph for deltaChannel failed", zap.Int64("collectionID", collectionID), zap.Strings("vDeltaChannels", vDeltaChannels), zap.Error(err))
+			for _, fg := range channel2FlowGraph {
+				fg.flowGraph.Close()
+			}
+			gcChannels := make([]Channel, 0)
+			for channel := range channel2FlowGraph {
+				gcChannels = append(gcChannels, channel)
+			}
+			l.node.dataSyncService.removeFlowGraphsByDeltaChannels(gcChannels)
+			return err
 		}
-		l.node.dataSyncService.removeFlowGraphsByDeltaChannels(gcChannels)
-		return err
-	}
 
-	log.Info("watchDeltaChannel, add flowGraph for deltaChannel success", zap.Int64("collectionID", collectionID), zap.Strings("vDeltaChannels", vDeltaChannels))
+		log.Info("watchDeltaChannel, add flowGraph for deltaChannel success", zap.Int64("collectionID", collectionID), zap.Strings("vDeltaChannels", vDeltaChannels))
+	}
 
 	// create tSafe
 	for _, channel := range vDeltaChannels {
 		l.node.tSafeReplica.addTSafe(channel)
+		if Params.QueryNodeCfg.ReadOnly {
+			// requests never wait for the stream on read-only nodes
+			err = l.node.tSafeReplica.setTSafe(channel, typeutil.MaxTimestamp)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	// add tsafe watch in query shard if exists, we find no way to handle it if query shard not exist
@@ -83,8 +83,10 @@ func getQuotaMetrics(node *QueryNode) (*metricsinfo.QueryNodeQuotaMetrics, error
 			MinFlowGraphTt:      minFGTt,
 			NumFlowGraph:        node.dataSyncService.getFlowGraphNum(),
 		},
-		SearchQueue: rateCol.rtCounter.getSearchNQInQueue(),
-		QueryQueue:  rateCol.rtCounter.getQueryTasksInQueue(),
+		SearchQueue:               rateCol.rtCounter.getSearchNQInQueue(),
+		QueryQueue:                rateCol.rtCounter.getQueryTasksInQueue(),
+		LagSLOViolatedCollections: lagMonitor.violatedCollections(),
+		ServiceableLags:           lagMonitor.collectionLags(),
 	}, nil
 }
 
@@ -129,6 +131,9 @@ func getSystemInfoMetrics(ctx context.Context, req *milvuspb.GetMetricsRequest,
 		},
 		QuotaMetrics: quotaMetrics,
 	}
+	if node.fieldStatsCollector != nil {
+		nodeInfos.FieldStats = node.fieldStatsCollector.collectionStats()
+	}
 	metricsinfo.FillDeployMetricsWithEnv(&nodeInfos.SystemInfo)
 
 	resp, err := metricsinfo.MarshalComponentInfos(nodeInfos)
@@ -0,0 +1,273 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// number keeps a value of numeric field, integers are not converted into float64 to keep the precision of int64.
+type number struct {
+	isFloat bool
+	i       int64
+	f       float64
+}
+
+func (n number) float() float64 {
+	if n.isFloat {
+		return n.f
+	}
+	return float64(n.i)
+}
+
+// compareNumber returns -1, 0 or 1 if a is less than, equal to or greater than b.
+func compareNumber(a, b number) int {
+	if !a.isFloat && !b.isFloat {
+		switch {
+		case a.i < b.i:
+			return -1
+		case a.i > b.i:
+			return 1
+		}
+		return 0
+	}
+	af, bf := a.float(), b.float()
+	switch {
+	case af < bf:
+		return -1
+	case af > bf:
+		return 1
+	}
+	return 0
+}
+
+// numberOf returns the number of a generic value, false if the value is not numeric.
+func numberOf(v *planpb.GenericValue) (number, bool) {
+	switch val := v.GetVal().(type) {
+	case *planpb.GenericValue_Int64Val:
+		return number{i: val.Int64Val}, true
+	case *planpb.GenericValue_FloatVal:
+		return number{isFloat: true, f: val.FloatVal}, true
+	}
+	return number{}, false
+}
+
+// fieldRange is the min and max value of a numeric field in segments.
+type fieldRange struct {
+	min number
+	max number
+}
+
+func (r *fieldRange) merge(other *fieldRange) *fieldRange {
+	merged := &fieldRange{min: r.min, max: r.max}
+	if compareNumber(other.min, merged.min) < 0 {
+		merged.min = other.min
+	}
+	if compareNumber(other.max, merged.max) > 0 {
+		merged.max = other.max
+	}
+	return merged
+}
+
+// mayContain returns false if no value in the range satisfies `value op v`.
+func (r *fieldRange) mayContain(op planpb.OpType, v *planpb.GenericValue) bool {
+	n, ok := numberOf(v)
+	if !ok {
+		return true
+	}
+	switch op {
+	case planpb.OpType_Equal:
+		return compareNumber(r.min, n) <= 0 && compareNumber(r.max, n) >= 0
+	case planpb.OpType_NotEqual:
+		return compareNumber(r.min, n) != 0 || compareNumber(r.max, n) != 0
+	case planpb.OpType_GreaterThan:
+		return compareNumber(r.max, n) > 0
+	case planpb.OpType_GreaterEqual:
+		return compareNumber(r.max, n) >= 0
+	case planpb.OpType_LessThan:
+		return compareNumber(r.min, n) < 0
+	case planpb.OpType_LessEqual:
+		return compareNumber(r.min, n) <= 0
+	}
+	return true
+}
+
+// rangeOfFieldData returns the min and max value of a numeric scalar field data, nil for other types.
+func rangeOfFieldData(data *schemapb.FieldData) *fieldRange {
+	var r *fieldRange
+	update := func(n number) {
+		if r == nil {
+			r = &fieldRange{min: n, max: n}
+			return
+		}
+		if compareNumber(n, r.min) < 0 {
+			r.min = n
+		}
+		if compareNumber(n, r.max) > 0 {
+			r.max = n
+		}
+	}
+
+	scalars := data.GetScalars()
+	switch data.GetType() {
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		for _, v := range scalars.GetIntData().GetData() {
+			update(number{i: int64(v)})
+		}
+	case schemapb.DataType_Int64:
+		for _, v := range scalars.GetLongData().GetData() {
+			update(number{i: v})
+		}
+	case schemapb.DataType_Float:
+		for _, v := range scalars.GetFloatData().GetData() {
+			update(number{isFloat: true, f: float64(v)})
+		}
+		// the constants in filter may be compared in float32 precision,
+		// widen the range by one ulp so that the values rounded to min/max are kept
+		if r != nil {
+			r.min.f = float64(math.Nextafter32(float32(r.min.f), float32(math.Inf(-1))))
+			r.max.f = float64(math.Nextafter32(float32(r.max.f), float32(math.Inf(1))))
+		}
+	case schemapb.DataType_Double:
+		for _, v := range scalars.GetDoubleData().GetData() {
+			update(number{isFloat: true, f: v})
+		}
+	}
+	return r
+}
+
+// mergeFieldStats merges the field ranges of two segments of a partition,
+// fields missing in either segment are dropped since their values are unknown.
+func mergeFieldStats(a, b map[FieldID]*fieldRange) map[FieldID]*fieldRange {
+	merged := make(map[FieldID]*fieldRange, len(a))
+	for fieldID, r := range a {
+		if other, ok := b[fieldID]; ok {
+			merged[fieldID] = r.merge(other)
+		}
+	}
+	return merged
+}
+
+// exprMayMatch returns false only if it's sure that no entity with the field ranges matches the expr.
+func exprMayMatch(expr *planpb.Expr, stats map[FieldID]*fieldRange) bool {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		switch e.BinaryExpr.GetOp() {
+		case planpb.BinaryExpr_LogicalAnd:
+			return exprMayMatch(e.BinaryExpr.GetLeft(), stats) && exprMayMatch(e.BinaryExpr.GetRight(), stats)
+		case planpb.BinaryExpr_LogicalOr:
+			return exprMayMatch(e.BinaryExpr.GetLeft(), stats) || exprMayMatch(e.BinaryExpr.GetRight(), stats)
+		}
+	case *planpb.Expr_UnaryRangeExpr:
+		r, ok := stats[e.UnaryRangeExpr.GetColumnInfo().GetFieldId()]
+		if ok {
+			return r.mayContain(e.UnaryRangeExpr.GetOp(), e.UnaryRangeExpr.GetValue())
+		}
+	case *planpb.Expr_BinaryRangeExpr:
+		r, ok := stats[e.BinaryRangeExpr.GetColumnInfo().GetFieldId()]
+		if ok {
+			lowerOp, upperOp := planpb.OpType_GreaterThan, planpb.OpType_LessThan
+			if e.BinaryRangeExpr.GetLowerInclusive() {
+				lowerOp = planpb.OpType_GreaterEqual
+			}
+			if e.BinaryRangeExpr.GetUpperInclusive() {
+				upperOp = planpb.OpType_LessEqual
+			}
+			return r.mayContain(lowerOp, e.BinaryRangeExpr.GetLowerValue()) &&
+				r.mayContain(upperOp, e.BinaryRangeExpr.GetUpperValue())
+		}
+	case *planpb.Expr_TermExpr:
+		r, ok := stats[e.TermExpr.GetColumnInfo().GetFieldId()]
+		if ok {
+			for _, v := range e.TermExpr.GetValues() {
+				if r.mayContain(planpb.OpType_Equal, v) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// parsePredicates returns the predicates of a serialized retrieve plan, nil if there is no predicate.
+func parsePredicates(serializedPlan []byte) *planpb.Expr {
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return nil
+	}
+	if plan.GetVectorAnns() != nil {
+		return plan.GetVectorAnns().GetPredicates()
+	}
+	return plan.GetPredicates()
+}
+
+// prunePartitions removes the segments of partitions which can't match the predicates.
+// The field ranges of a partition are merged from the segments to read, so the partitions whose values
+// are out of the filter, like the partitions of other dates, are skipped even if no partition is specified.
+func prunePartitions(replica ReplicaInterface, segType segmentType, predicates *planpb.Expr, segIDs []UniqueID, queryType string) []UniqueID {
+	if predicates == nil || len(segIDs) == 0 || !Params.QueryNodeCfg.PartitionPruningEnabled {
+		return segIDs
+	}
+
+	segments := make(map[UniqueID]*Segment, len(segIDs))
+	partStats := make(map[UniqueID]map[FieldID]*fieldRange)
+	for _, segID := range segIDs {
+		segment, err := replica.getSegmentByID(segID, segType)
+		if err != nil {
+			// leave it to the search / query of segments
+			continue
+		}
+		segments[segID] = segment
+		stats, ok := partStats[segment.partitionID]
+		if !ok {
+			partStats[segment.partitionID] = segment.getFieldStats()
+			continue
+		}
+		partStats[segment.partitionID] = mergeFieldStats(stats, segment.getFieldStats())
+	}
+
+	pruned := make(map[UniqueID]struct{})
+	for partitionID, stats := range partStats {
+		if !exprMayMatch(predicates, stats) {
+			pruned[partitionID] = struct{}{}
+		}
+	}
+	if len(pruned) == 0 {
+		return segIDs
+	}
+	metrics.QueryNodePrunedPartitionCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), queryType).Add(float64(len(pruned)))
+
+	result := make([]UniqueID, 0, len(segIDs))
+	for _, segID := range segIDs {
+		if segment, ok := segments[segID]; ok {
+			if _, ok := pruned[segment.partitionID]; ok {
+				continue
+			}
+		}
+		result = append(result, segID)
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
+)
+
+func genInt64Value(v int64) *planpb.GenericValue {
+	return &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: v}}
+}
+
+func genFloatValue(v float64) *planpb.GenericValue {
+	return &planpb.GenericValue{Val: &planpb.GenericValue_FloatVal{FloatVal: v}}
+}
+
+func genUnaryRangeExpr(fieldID FieldID, op planpb.OpType, value *planpb.GenericValue) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_UnaryRangeExpr{
+			UnaryRangeExpr: &planpb.UnaryRangeExpr{
+				ColumnInfo: &planpb.ColumnInfo{FieldId: fieldID},
+				Op:         op,
+				Value:      value,
+			},
+		},
+	}
+}
+
+func genBinaryExpr(op planpb.BinaryExpr_BinaryOp, left, right *planpb.Expr) *planpb.Expr {
+	return &planpb.Expr{
+		Expr: &planpb.Expr_BinaryExpr{
+			BinaryExpr: &planpb.BinaryExpr{Op: op, Left: left, Right: right},
+		},
+	}
+}
+
+func TestRangeOfFieldData(t *testing.T) {
+	r := rangeOfFieldData(&schemapb.FieldData{
+		Type: schemapb.DataType_Int64,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: []int64{5, -3, 10}}},
+			},
+		},
+	})
+	assert.Equal(t, number{i: -3}, r.min)
+	assert.Equal(t, number{i: 10}, r.max)
+
+	r = rangeOfFieldData(&schemapb.FieldData{
+		Type: schemapb.DataType_Float,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: []float32{0.1}}},
+			},
+		},
+	})
+	// float32 0.1 is kept by `== 0.1` in both float32 and float64 precision
+	assert.True(t, r.mayContain(planpb.OpType_Equal, genFloatValue(0.1)))
+	assert.True(t, r.mayContain(planpb.OpType_Equal, genFloatValue(float64(float32(0.1)))))
+
+	assert.Nil(t, rangeOfFieldData(&schemapb.FieldData{Type: schemapb.DataType_Int64}))
+	assert.Nil(t, rangeOfFieldData(&schemapb.FieldData{Type: schemapb.DataType_VarChar}))
+}
+
+func TestExprMayMatch(t *testing.T) {
+	stats := map[FieldID]*fieldRange{
+		100: {min: number{i: 10}, max: number{i: 20}},
+		101: {min: number{isFloat: true, f: 1.5}, max: number{isFloat: true, f: 2.5}},
+	}
+
+	assert.True(t, exprMayMatch(nil, stats))
+	assert.True(t, exprMayMatch(genUnaryRangeExpr(100, planpb.OpType_Equal, genInt64Value(15)), stats))
+	assert.False(t, exprMayMatch(genUnaryRangeExpr(100, planpb.OpType_Equal, genInt64Value(21)), stats))
+	assert.False(t, exprMayMatch(genUnaryRangeExpr(100, planpb.OpType_GreaterThan, genInt64Value(20)), stats))
+	assert.True(t, exprMayMatch(genUnaryRangeExpr(100, planpb.OpType_GreaterEqual, genInt64Value(20)), stats))
+	assert.False(t, exprMayMatch(genUnaryRangeExpr(100, planpb.OpType_LessThan, genInt64Value(10)), stats))
+	assert.True(t, exprMayMatch(genUnaryRangeExpr(100, planpb.OpType_LessEqual, genInt64Value(10)), stats))
+	assert.True(t, exprMayMatch(genUnaryRangeExpr(100, planpb.OpType_NotEqual, genInt64Value(10)), stats))
+	assert.False(t, exprMayMatch(genUnaryRangeExpr(101, planpb.OpType_GreaterThan, genFloatValue(2.5)), stats))
+	assert.True(t, exprMayMatch(genUnaryRangeExpr(101, planpb.OpType_LessThan, genInt64Value(2)), stats))
+	// no statistics of the field
+	assert.True(t, exprMayMatch(genUnaryRangeExpr(102, planpb.OpType_Equal, genInt64Value(0)), stats))
+
+	outOfRange := genUnaryRangeExpr(100, planpb.OpType_GreaterThan, genInt64Value(100))
+	inRange := genUnaryRangeExpr(101, planpb.OpType_GreaterThan, genFloatValue(2))
+	assert.False(t, exprMayMatch(genBinaryExpr(planpb.BinaryExpr_LogicalAnd, outOfRange, inRange), stats))
+	assert.True(t, exprMayMatch(genBinaryExpr(planpb.BinaryExpr_LogicalOr, outOfRange, inRange), stats))
+	// not is never pruned
+	assert.True(t, exprMayMatch(&planpb.Expr{
+		Expr: &planpb.Expr_UnaryExpr{UnaryExpr: &planpb.UnaryExpr{Op: planpb.UnaryExpr_Not, Child: inRange}},
+	}, stats))
+
+	binaryRange := &planpb.Expr{
+		Expr: &planpb.Expr_BinaryRangeExpr{
+			BinaryRangeExpr: &planpb.BinaryRangeExpr{
+				ColumnInfo:     &planpb.ColumnInfo{FieldId: 100},
+				LowerInclusive: false,
+				UpperInclusive: true,
+				LowerValue:     genInt64Value(20),
+				UpperValue:     genInt64Value(30),
+			},
+		},
+	}
+	assert.False(t, exprMayMatch(binaryRange, stats))
+	binaryRange.GetBinaryRangeExpr().LowerInclusive = true
+	assert.True(t, exprMayMatch(binaryRange, stats))
+
+	term := &planpb.Expr{
+		Expr: &planpb.Expr_TermExpr{
+			TermExpr: &planpb.TermExpr{
+				ColumnInfo: &planpb.ColumnInfo{FieldId: 100},
+				Values:     []*planpb.GenericValue{genInt64Value(1), genInt64Value(30)},
+			},
+		},
+	}
+	assert.False(t, exprMayMatch(term, stats))
+	term.GetTermExpr().Values = append(term.GetTermExpr().Values, genInt64Value(12))
+	assert.True(t, exprMayMatch(term, stats))
+}
+
+func TestMergeFieldStats(t *testing.T) {
+	a := map[FieldID]*fieldRange{
+		100: {min: number{i: 10}, max: number{i: 20}},
+		101: {min: number{i: 0}, max: number{i: 1}},
+	}
+	b := map[FieldID]*fieldRange{
+		100: {min: number{i: 0}, max: number{i: 15}},
+	}
+	merged := mergeFieldStats(a, b)
+	assert.Len(t, merged, 1)
+	assert.Equal(t, number{i: 0}, merged[100].min)
+	assert.Equal(t, number{i: 20}, merged[100].max)
+	// inputs are not modified
+	assert.Equal(t, number{i: 10}, a[100].min)
+}
+
+func TestPrunePartitions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replica, err := genSimpleReplicaWithSealSegment(ctx)
+	assert.NoError(t, err)
+
+	segment, err := replica.getSegmentByID(defaultSegmentID, segmentTypeSealed)
+	assert.NoError(t, err)
+	stats := segment.getFieldStats()
+	assert.Equal(t, number{i: 0}, stats[simpleInt64Field.id].min)
+	assert.Equal(t, number{i: defaultMsgLength - 1}, stats[simpleInt64Field.id].max)
+
+	segIDs := []UniqueID{defaultSegmentID, defaultSegmentID + 1}
+	outOfRange := genUnaryRangeExpr(simpleInt64Field.id, planpb.OpType_GreaterEqual, genInt64Value(defaultMsgLength))
+	inRange := genUnaryRangeExpr(simpleInt64Field.id, planpb.OpType_LessThan, genInt64Value(1))
+
+	assert.Equal(t, segIDs, prunePartitions(replica, segmentTypeSealed, nil, segIDs, metrics.SearchLabel))
+	assert.Equal(t, segIDs, prunePartitions(replica, segmentTypeSealed, inRange, segIDs, metrics.SearchLabel))
+	// the segment not found is left to search
+	assert.Equal(t, []UniqueID{defaultSegmentID + 1}, prunePartitions(replica, segmentTypeSealed, outOfRange, segIDs, metrics.SearchLabel))
+
+	Params.QueryNodeCfg.PartitionPruningEnabled = false
+	defer func() { Params.QueryNodeCfg.PartitionPruningEnabled = true }()
+	assert.Equal(t, segIDs, prunePartitions(replica, segmentTypeSealed, outOfRange, segIDs, metrics.SearchLabel))
+}
+
+func TestParsePredicates(t *testing.T) {
+	assert.Nil(t, parsePredicates([]byte{0x1, 0x2}))
+
+	predicates := genUnaryRangeExpr(100, planpb.OpType_Equal, genInt64Value(1))
+	plan, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_VectorAnns{VectorAnns: &planpb.VectorANNS{Predicates: predicates}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(predicates, parsePredicates(plan)))
+
+	plan, err = proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_Predicates{Predicates: predicates},
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(predicates, parsePredicates(plan)))
+}
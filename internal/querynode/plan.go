@@ -30,6 +30,7 @@ import (
 	"unsafe"
 
 	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/planpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
 )
 
@@ -96,6 +97,8 @@ type searchRequest struct {
 	timestamp         Timestamp
 	msgID             UniqueID
 	searchFieldID     UniqueID
+	// predicates of the search, used to prune partitions
+	predicates *planpb.Expr
 }
 
 func newSearchRequest(collection *Collection, req *querypb.SearchRequest, placeholderGrp []byte) (*searchRequest, error) {
@@ -144,6 +147,9 @@ func newSearchRequest(collection *Collection, req *querypb.SearchRequest, placeh
 		msgID:             req.GetReq().GetBase().GetMsgID(),
 		searchFieldID:     int64(fieldID),
 	}
+	if req.Req.GetDslType() == commonpb.DslType_BoolExprV1 {
+		ret.predicates = parsePredicates(req.Req.GetSerializedExprPlan())
+	}
 
 	return ret, nil
 }
@@ -182,6 +188,8 @@ type RetrievePlan struct {
 	cRetrievePlan C.CRetrievePlan
 	Timestamp     Timestamp
 	msgID         UniqueID // only used to debug.
+	// predicates of the retrieve, used to prune partitions
+	predicates *planpb.Expr
 }
 
 func createRetrievePlanByExpr(col *Collection, expr []byte, timestamp Timestamp, msgID UniqueID) (*RetrievePlan, error) {
@@ -200,6 +208,7 @@ func createRetrievePlanByExpr(col *Collection, expr []byte, timestamp Timestamp,
 		cRetrievePlan: cPlan,
 		Timestamp:     timestamp,
 		msgID:         msgID,
+		predicates:    parsePredicates(expr),
 	}
 	return newPlan, nil
 }
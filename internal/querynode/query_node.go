@@ -97,6 +97,9 @@ type QueryNode struct {
 	// tSafeReplica
 	tSafeReplica TSafeReplicaInterface
 
+	// merged live statistics of scalar fields, reported in the metrics
+	fieldStatsCollector *fieldStatsCollector
+
 	// dataSyncService
 	dataSyncService *dataSyncService
 
@@ -149,6 +152,7 @@ func (node *QueryNode) initSession() error {
 		return fmt.Errorf("session is nil, the etcd client connection may have failed")
 	}
 	node.session.Init(typeutil.QueryNodeRole, node.address, false, true)
+	node.session.ReadOnly = Params.QueryNodeCfg.ReadOnly
 	return nil
 }
 
@@ -334,6 +338,11 @@ func (node *QueryNode) Start() error {
 	}
 	node.queryShardService = queryShardService
 
+	go lagMonitor.run(node.queryNodeLoopCtx)
+	go newGrowingSpiller(node.metaReplica).run(node.queryNodeLoopCtx)
+	node.fieldStatsCollector = newFieldStatsCollector(node.metaReplica)
+	go node.fieldStatsCollector.run(node.queryNodeLoopCtx)
+
 	Params.QueryNodeCfg.CreatedTime = time.Now()
 	Params.QueryNodeCfg.UpdatedTime = time.Now()
 
@@ -41,8 +41,12 @@ type queryShard struct {
 	metaReplica  ReplicaInterface
 
 	vectorChunkManager *storage.VectorChunkManager
+	localChunkManager  storage.ChunkManager
 	localCacheEnabled  bool
 	localCacheSize     int64
+
+	// nil if search result cache is disabled
+	searchResultCache *searchResultCache
 }
 
 func newQueryShard(
@@ -72,7 +76,8 @@ func newQueryShard(
 		&etcdpb.CollectionMeta{
 			ID:     collectionID,
 			Schema: collection.schema,
-		}, Params.QueryNodeCfg.CacheMemoryLimit, localCacheEnabled)
+		}, Params.QueryNodeCfg.CacheMemoryLimit, localCacheEnabled,
+		storage.CacheValidateInterval(Params.QueryNodeCfg.CacheValidateInterval))
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +90,7 @@ func newQueryShard(
 		clusterService:     clusterService,
 		metaReplica:        metaReplica,
 		vectorChunkManager: vectorChunkManager,
+		localChunkManager:  localChunkManager,
 		tSafeReplica:       tSafeReplica,
 	}
 	deltaChannel, err := funcutil.ConvertChannelName(channel, Params.CommonCfg.RootCoordDml, Params.CommonCfg.RootCoordDelta)
@@ -92,6 +98,9 @@ func newQueryShard(
 		log.Warn("failed to convert dm channel to delta", zap.String("channel", channel), zap.Error(err))
 	}
 	qs.deltaChannel = deltaChannel
+	if Params.QueryNodeCfg.SearchResultCacheEnabled {
+		qs.searchResultCache = newSearchResultCache(Params.QueryNodeCfg.SearchResultCacheTTL, Params.QueryNodeCfg.SearchResultCacheMaxSize)
+	}
 
 	return qs, nil
 }
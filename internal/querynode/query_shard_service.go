@@ -46,6 +46,8 @@ type queryShardService struct {
 	remoteChunkManager  storage.ChunkManager
 	localCacheEnabled   bool
 	scheduler           *taskScheduler
+	// diskWatcher evicts the vector cache of the query shards when the local storage is short of space
+	diskWatcher *storage.DiskWatcher
 }
 
 func newQueryShardService(ctx context.Context, metaReplica ReplicaInterface, tSafeReplica TSafeReplicaInterface, clusterService *ShardClusterService, factory dependency.Factory, scheduler *taskScheduler) (*queryShardService, error) {
@@ -69,7 +71,10 @@ func newQueryShardService(ctx context.Context, metaReplica ReplicaInterface, tSa
 		localCacheEnabled:   Params.QueryNodeCfg.CacheEnabled,
 		factory:             factory,
 		scheduler:           scheduler,
+		diskWatcher:         storage.NewDiskWatcher(Params.QueryNodeCfg.DiskWatcherInterval),
 	}
+	qss.diskWatcher.Watch(localChunkManager.RootPath(), Params.QueryNodeCfg.DiskWatcherMinFreeRatio)
+	qss.diskWatcher.Start()
 	return qss, nil
 }
 
@@ -95,6 +100,9 @@ func (q *queryShardService) addQueryShard(collectionID UniqueID, channel Channel
 		return err
 	}
 	q.queryShards[channel] = qs
+	if q.localCacheEnabled {
+		q.diskWatcher.RegisterEvictor(q.localChunkManager.RootPath(), vectorCacheEvictorName(channel), qs.vectorChunkManager.EvictCache)
+	}
 	log.Info("Successfully add query shard", zap.Int64("collection", collectionID), zap.Int64("replica", replicaID), zap.String("channel", channel))
 	return nil
 }
@@ -106,6 +114,7 @@ func (q *queryShardService) removeQueryShard(channel Channel) error {
 		return errors.New(fmt.Sprintln("query shard(channel) ", channel, " does not exist"))
 	}
 	delete(q.queryShards, channel)
+	q.diskWatcher.UnregisterEvictor(q.localChunkManager.RootPath(), vectorCacheEvictorName(channel))
 	log.Info("Successfully remove query shard", zap.String("channel", channel))
 	return nil
 }
@@ -129,6 +138,7 @@ func (q *queryShardService) getQueryShard(channel Channel) (*queryShard, error)
 func (q *queryShardService) close() {
 	log.Warn("Close query shard service")
 	q.cancel()
+	q.diskWatcher.Stop()
 	q.queryShardsMu.Lock()
 	defer q.queryShardsMu.Unlock()
 
@@ -143,8 +153,14 @@ func (q *queryShardService) releaseCollection(collectionID int64) {
 		if queryShard.collectionID == collectionID {
 			queryShard.Close()
 			delete(q.queryShards, channel)
+			q.diskWatcher.UnregisterEvictor(q.localChunkManager.RootPath(), vectorCacheEvictorName(channel))
 		}
 	}
 	q.queryShardsMu.Unlock()
 	log.Info("release collection in query shard service", zap.Int64("collectionId", collectionID))
 }
+
+// vectorCacheEvictorName is the name of the evictor of the vector cache of the query shard on @channel.
+func vectorCacheEvictorName(channel Channel) string {
+	return "vectorCache-" + channel
+}
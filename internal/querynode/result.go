@@ -102,6 +102,10 @@ func reduceSearchResults(ctx context.Context, results []*internalpb.SearchResult
 		log.Warn("shard leader encode search result errors", zap.Error(err))
 		return nil, err
 	}
+	for _, r := range results {
+		searchResults.SealedSegmentIDsSearched = append(searchResults.SealedSegmentIDsSearched, r.GetSealedSegmentIDsSearched()...)
+		searchResults.ChannelIDsSearched = append(searchResults.ChannelIDsSearched, r.GetChannelIDsSearched()...)
+	}
 	//if searchResults.SlicedBlob == nil {
 	//	log.Debug("shard leader send nil results to proxy",
 	//		zap.String("shard", q.channel))
@@ -266,6 +270,11 @@ func mergeInternalRetrieveResult(ctx context.Context, retrieveResults []*interna
 		loopEnd    int
 	)
 
+	for _, r := range retrieveResults {
+		ret.SealedSegmentIDsRetrieved = append(ret.SealedSegmentIDsRetrieved, r.GetSealedSegmentIDsRetrieved()...)
+		ret.ChannelIDsRetrieved = append(ret.ChannelIDsRetrieved, r.GetChannelIDsRetrieved()...)
+	}
+
 	validRetrieveResults := []*internalpb.RetrieveResults{}
 	for _, r := range retrieveResults {
 		size := typeutil.GetSizeOfIDs(r.GetIds())
@@ -250,6 +250,21 @@ func TestResult_mergeInternalRetrieveResults(t *testing.T) {
 		assert.Empty(t, ret.GetFieldsData())
 	})
 
+	t.Run("test merge retrieved scope", func(t *testing.T) {
+		historical := &internalpb.RetrieveResults{
+			Ids:                       &schemapb.IDs{},
+			SealedSegmentIDsRetrieved: []int64{100, 101},
+		}
+		streaming := &internalpb.RetrieveResults{
+			Ids:                 &schemapb.IDs{},
+			ChannelIDsRetrieved: []string{"dml_0_1v0"},
+		}
+		ret, err := mergeInternalRetrieveResult(context.Background(), []*internalpb.RetrieveResults{historical, streaming}, typeutil.Unlimited)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{100, 101}, ret.GetSealedSegmentIDsRetrieved())
+		assert.Equal(t, []string{"dml_0_1v0"}, ret.GetChannelIDsRetrieved())
+	})
+
 	t.Run("test merge", func(t *testing.T) {
 		r1 := &internalpb.RetrieveResults{
 			Ids: &schemapb.IDs{
@@ -20,13 +20,15 @@ import (
 	"context"
 	"errors"
 
+	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/proto/segcorepb"
 	"github.com/milvus-io/milvus/internal/storage"
 )
 
 // retrieveOnSegments performs retrieve on listed segments
 // all segment ids are validated before calling this function
-func retrieveOnSegments(ctx context.Context, replica ReplicaInterface, segType segmentType, collID UniqueID, plan *RetrievePlan, segIDs []UniqueID, vcm storage.ChunkManager) ([]*segcorepb.RetrieveResults, error) {
+// the results spilled by the budget are not returned, they are merged by the budget
+func retrieveOnSegments(ctx context.Context, replica ReplicaInterface, segType segmentType, collID UniqueID, plan *RetrievePlan, segIDs []UniqueID, vcm storage.ChunkManager, budget *retrieveBudget) ([]*segcorepb.RetrieveResults, error) {
 	var retrieveResults []*segcorepb.RetrieveResults
 
 	for _, segID := range segIDs {
@@ -44,13 +46,19 @@ func retrieveOnSegments(ctx context.Context, replica ReplicaInterface, segType s
 		if err := seg.fillIndexedFieldsData(ctx, collID, vcm, result); err != nil {
 			return nil, err
 		}
-		retrieveResults = append(retrieveResults, result)
+		held, err := budget.hold(ctx, result)
+		if err != nil {
+			return nil, err
+		}
+		if held {
+			retrieveResults = append(retrieveResults, result)
+		}
 	}
 	return retrieveResults, nil
 }
 
 // retrieveHistorical will retrieve all the target segments in historical
-func retrieveHistorical(ctx context.Context, replica ReplicaInterface, plan *RetrievePlan, collID UniqueID, partIDs []UniqueID, segIDs []UniqueID, vcm storage.ChunkManager) ([]*segcorepb.RetrieveResults, []UniqueID, []UniqueID, error) {
+func retrieveHistorical(ctx context.Context, replica ReplicaInterface, plan *RetrievePlan, collID UniqueID, partIDs []UniqueID, segIDs []UniqueID, vcm storage.ChunkManager, budget *retrieveBudget) ([]*segcorepb.RetrieveResults, []UniqueID, []UniqueID, error) {
 	var err error
 	var retrieveResults []*segcorepb.RetrieveResults
 	var retrieveSegmentIDs []UniqueID
@@ -59,13 +67,15 @@ func retrieveHistorical(ctx context.Context, replica ReplicaInterface, plan *Ret
 	if err != nil {
 		return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
 	}
+	retrieveSegmentIDs = prunePartitions(replica, segmentTypeSealed, plan.predicates, retrieveSegmentIDs, metrics.QueryLabel)
+	retrieveSegmentIDs = pruneInvisibleSegments(replica, segmentTypeSealed, plan.Timestamp, retrieveSegmentIDs)
 
-	retrieveResults, err = retrieveOnSegments(ctx, replica, segmentTypeSealed, collID, plan, retrieveSegmentIDs, vcm)
+	retrieveResults, err = retrieveOnSegments(ctx, replica, segmentTypeSealed, collID, plan, retrieveSegmentIDs, vcm, budget)
 	return retrieveResults, retrievePartIDs, retrieveSegmentIDs, err
 }
 
 // retrieveStreaming will retrieve all the target segments in streaming
-func retrieveStreaming(ctx context.Context, replica ReplicaInterface, plan *RetrievePlan, collID UniqueID, partIDs []UniqueID, vChannel Channel, vcm storage.ChunkManager) ([]*segcorepb.RetrieveResults, []UniqueID, []UniqueID, error) {
+func retrieveStreaming(ctx context.Context, replica ReplicaInterface, plan *RetrievePlan, collID UniqueID, partIDs []UniqueID, vChannel Channel, vcm storage.ChunkManager, budget *retrieveBudget) ([]*segcorepb.RetrieveResults, []UniqueID, []UniqueID, error) {
 	var err error
 	var retrieveResults []*segcorepb.RetrieveResults
 	var retrievePartIDs []UniqueID
@@ -75,6 +85,7 @@ func retrieveStreaming(ctx context.Context, replica ReplicaInterface, plan *Retr
 	if err != nil {
 		return retrieveResults, retrieveSegmentIDs, retrievePartIDs, err
 	}
-	retrieveResults, err = retrieveOnSegments(ctx, replica, segmentTypeGrowing, collID, plan, retrieveSegmentIDs, vcm)
+	retrieveSegmentIDs = prunePartitions(replica, segmentTypeGrowing, plan.predicates, retrieveSegmentIDs, metrics.QueryLabel)
+	retrieveResults, err = retrieveOnSegments(ctx, replica, segmentTypeGrowing, collID, plan, retrieveSegmentIDs, vcm, budget)
 	return retrieveResults, retrievePartIDs, retrieveSegmentIDs, err
 }
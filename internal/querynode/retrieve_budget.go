@@ -0,0 +1,260 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	// retrieveSpillPrefix is the prefix of the spilled results in the local storage
+	retrieveSpillPrefix = "query_spill"
+	// retrieveSpillChunkRows is the number of rows in a spilled chunk, a chunk per run is held during merging
+	retrieveSpillChunkRows = 4096
+)
+
+// ErrQueryMemoryExceeded means a query retrieves more data than its memory budget.
+var ErrQueryMemoryExceeded = errors.New("QueryMemoryExceeded")
+
+func WrapErrQueryMemoryExceeded(used int64, budget int64) error {
+	return fmt.Errorf("%w(used=%d, budget=%d): the query retrieves more data than the memory budget of a query, "+
+		"narrow the filter expression or set a limit", ErrQueryMemoryExceeded, used, budget)
+}
+
+// retrieveBudget accounts the memory held by the results of a query, a nil budget is unlimited.
+//
+// Without spilling, the query fails fast once the segment results exceed the budget.
+// With spilling, half of the budget is kept for the merged result, the segment results beyond
+// the other half are spilled to the local storage in chunks, and merged chunk by chunk,
+// so the query only fails if the merged result itself exceeds the budget.
+type retrieveBudget struct {
+	limit   int64
+	used    int64
+	spillCM storage.ChunkManager // nil if spilling is disabled
+	prefix  string
+	runs    []*spilledRun
+}
+
+// spilledRun is a segment result spilled to the local storage, its chunks are sorted by primary key as the result.
+type spilledRun struct {
+	chunks []string
+	// rowSize is the average size of the rows, used to account the merged result
+	rowSize int64
+}
+
+func newRetrieveBudget(queryID UniqueID, localCM storage.ChunkManager) *retrieveBudget {
+	limit := Params.QueryNodeCfg.QueryMemoryBudget
+	if limit <= 0 {
+		return nil
+	}
+	budget := &retrieveBudget{limit: limit}
+	if Params.QueryNodeCfg.QuerySpillEnabled && localCM != nil {
+		budget.spillCM = localCM
+		budget.prefix = path.Join(retrieveSpillPrefix, fmt.Sprint(queryID))
+	}
+	return budget
+}
+
+// hold accounts @result, it returns false if @result is spilled and shouldn't be kept in memory.
+func (b *retrieveBudget) hold(ctx context.Context, result *segcorepb.RetrieveResults) (bool, error) {
+	if b == nil {
+		return true, nil
+	}
+	size := int64(proto.Size(result))
+	if b.spillCM == nil {
+		if b.used+size > b.limit {
+			return false, WrapErrQueryMemoryExceeded(b.used+size, b.limit)
+		}
+		b.used += size
+		return true, nil
+	}
+	if b.used+size <= b.limit/2 {
+		b.used += size
+		return true, nil
+	}
+	if err := b.spill(ctx, result, size); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+func (b *retrieveBudget) spill(ctx context.Context, result *segcorepb.RetrieveResults, size int64) error {
+	if result.GetIds() == nil || len(result.GetOffset()) == 0 {
+		return nil
+	}
+	rows := typeutil.GetSizeOfIDs(result.GetIds())
+	if rows == 0 {
+		return nil
+	}
+	run := &spilledRun{rowSize: size / int64(rows)}
+	runDir := path.Join(b.prefix, fmt.Sprint(len(b.runs)))
+	for start := 0; start < rows; start += retrieveSpillChunkRows {
+		end := start + retrieveSpillChunkRows
+		if end > rows {
+			end = rows
+		}
+		chunk := sliceRetrieveResults(result, start, end)
+		blob, err := proto.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+		chunkPath := path.Join(runDir, fmt.Sprint(len(run.chunks)))
+		if err := b.spillCM.Write(ctx, chunkPath, blob); err != nil {
+			return err
+		}
+		run.chunks = append(run.chunks, chunkPath)
+	}
+	b.runs = append(b.runs, run)
+	log.Ctx(ctx).Debug("segment retrieve result spilled", zap.Int("rows", rows), zap.Int64("size", size),
+		zap.Int("chunks", len(run.chunks)))
+	return nil
+}
+
+// sliceRetrieveResults copies the rows in [@start, @end) of @result.
+func sliceRetrieveResults(result *segcorepb.RetrieveResults, start int, end int) *segcorepb.RetrieveResults {
+	ret := &segcorepb.RetrieveResults{
+		Ids:        &schemapb.IDs{},
+		FieldsData: make([]*schemapb.FieldData, len(result.GetFieldsData())),
+	}
+	if len(result.GetOffset()) >= end {
+		ret.Offset = append([]int64{}, result.GetOffset()[start:end]...)
+	}
+	for i := start; i < end; i++ {
+		typeutil.AppendPKs(ret.Ids, typeutil.GetPK(result.GetIds(), int64(i)))
+		typeutil.AppendFieldData(ret.FieldsData, result.GetFieldsData(), int64(i))
+	}
+	return ret
+}
+
+// merge merges @results held in memory and the spilled results.
+func (b *retrieveBudget) merge(ctx context.Context, results []*segcorepb.RetrieveResults, limit int64) (*segcorepb.RetrieveResults, error) {
+	if b == nil || len(b.runs) == 0 {
+		return mergeSegcoreRetrieveResults(ctx, results, limit)
+	}
+
+	sources := make([]*retrieveSource, 0, len(results)+len(b.runs))
+	for _, r := range results {
+		if len(r.GetOffset()) == 0 || typeutil.GetSizeOfIDs(r.GetIds()) == 0 {
+			continue
+		}
+		rows := int64(typeutil.GetSizeOfIDs(r.GetIds()))
+		sources = append(sources, &retrieveSource{current: r, rowSize: int64(proto.Size(r)) / rows})
+	}
+	for _, run := range b.runs {
+		sources = append(sources, &retrieveSource{run: run, rowSize: run.rowSize})
+	}
+
+	ret := &segcorepb.RetrieveResults{Ids: &schemapb.IDs{}}
+	var (
+		used       int64
+		skipDupCnt int64
+		idSet      = make(map[interface{}]struct{})
+		chunks     = make([]*segcorepb.RetrieveResults, len(sources))
+		cursors    = make([]int64, len(sources))
+	)
+	for limit == typeutil.Unlimited || int64(len(idSet)) < limit {
+		for i, source := range sources {
+			if err := source.advance(ctx, b.spillCM); err != nil {
+				return nil, err
+			}
+			chunks[i], cursors[i] = source.current, source.cursor
+		}
+		sel := typeutil.SelectMinPK(chunks, cursors)
+		if sel == -1 {
+			break
+		}
+		source := sources[sel]
+		pk := typeutil.GetPK(source.current.GetIds(), source.cursor)
+		if _, ok := idSet[pk]; !ok {
+			if ret.FieldsData == nil {
+				ret.FieldsData = make([]*schemapb.FieldData, len(source.current.GetFieldsData()))
+			}
+			used += source.rowSize
+			if used > b.limit {
+				return nil, WrapErrQueryMemoryExceeded(used, b.limit)
+			}
+			typeutil.AppendPKs(ret.Ids, pk)
+			typeutil.AppendFieldData(ret.FieldsData, source.current.GetFieldsData(), source.cursor)
+			idSet[pk] = struct{}{}
+		} else {
+			skipDupCnt++
+		}
+		source.cursor++
+	}
+
+	if skipDupCnt > 0 {
+		log.Ctx(ctx).Debug("skip duplicated query result while merging spilled results", zap.Int64("count", skipDupCnt))
+	}
+	return ret, nil
+}
+
+// release removes the spilled results.
+func (b *retrieveBudget) release(ctx context.Context) {
+	if b == nil || len(b.runs) == 0 {
+		return
+	}
+	if err := b.spillCM.RemoveWithPrefix(ctx, b.prefix); err != nil {
+		log.Ctx(ctx).Warn("failed to remove spilled query results", zap.String("prefix", b.prefix), zap.Error(err))
+	}
+}
+
+// retrieveSource is a sorted stream of rows, either a result in memory or a spilled run read chunk by chunk.
+type retrieveSource struct {
+	current *segcorepb.RetrieveResults
+	cursor  int64
+	rowSize int64
+
+	run       *spilledRun
+	nextChunk int
+}
+
+// advance loads the next chunk of the run once the current one is consumed,
+// the current result is left empty after the source is exhausted.
+func (s *retrieveSource) advance(ctx context.Context, cm storage.ChunkManager) error {
+	for s.current == nil || s.cursor >= int64(typeutil.GetSizeOfIDs(s.current.GetIds())) {
+		if s.run == nil || s.nextChunk >= len(s.run.chunks) {
+			s.current, s.cursor = &segcorepb.RetrieveResults{Ids: &schemapb.IDs{}}, 0
+			return nil
+		}
+		blob, err := cm.Read(ctx, s.run.chunks[s.nextChunk])
+		if err != nil {
+			return err
+		}
+		chunk := &segcorepb.RetrieveResults{}
+		if err := proto.Unmarshal(blob, chunk); err != nil {
+			return err
+		}
+		if chunk.Ids == nil {
+			chunk.Ids = &schemapb.IDs{}
+		}
+		s.current, s.cursor = chunk, 0
+		s.nextChunk++
+	}
+	return nil
+}
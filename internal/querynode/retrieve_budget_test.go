@@ -0,0 +1,134 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/segcorepb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+func genBudgetRetrieveResults(start int64, step int64, rows int) *segcorepb.RetrieveResults {
+	pks := make([]int64, 0, rows)
+	offsets := make([]int64, 0, rows)
+	for i := 0; i < rows; i++ {
+		pks = append(pks, start+int64(i)*step)
+		offsets = append(offsets, int64(i))
+	}
+	return &segcorepb.RetrieveResults{
+		Ids: &schemapb.IDs{
+			IdField: &schemapb.IDs_IntId{
+				IntId: &schemapb.LongArray{Data: pks},
+			},
+		},
+		Offset: offsets,
+		FieldsData: []*schemapb.FieldData{
+			genFieldData("Int64Field", common.StartOfUserFieldID+1, schemapb.DataType_Int64, pks, 1),
+		},
+	}
+}
+
+func TestRetrieveBudget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil budget", func(t *testing.T) {
+		var budget *retrieveBudget
+		held, err := budget.hold(ctx, genBudgetRetrieveResults(0, 1, 10))
+		assert.NoError(t, err)
+		assert.True(t, held)
+
+		ret, err := budget.merge(ctx, []*segcorepb.RetrieveResults{genBudgetRetrieveResults(0, 1, 10)}, typeutil.Unlimited)
+		assert.NoError(t, err)
+		assert.Len(t, ret.GetIds().GetIntId().GetData(), 10)
+		budget.release(ctx)
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		budget := &retrieveBudget{limit: 1024}
+		held, err := budget.hold(ctx, genBudgetRetrieveResults(0, 1, 10))
+		assert.NoError(t, err)
+		assert.True(t, held)
+
+		_, err = budget.hold(ctx, genBudgetRetrieveResults(0, 1, 1000))
+		assert.True(t, errors.Is(err, ErrQueryMemoryExceeded))
+	})
+
+	t.Run("spill and merge", func(t *testing.T) {
+		results := []*segcorepb.RetrieveResults{
+			genBudgetRetrieveResults(0, 3, 10000),
+			genBudgetRetrieveResults(1, 3, 10000),
+			genBudgetRetrieveResults(0, 3, 10000),
+			genBudgetRetrieveResults(1, 6, 10000),
+		}
+		// the first two results are held, the others are spilled
+		limit := int64(2 * (proto.Size(results[0]) + proto.Size(results[1])))
+		cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+		budget := &retrieveBudget{limit: limit, spillCM: cm, prefix: "query_spill/1"}
+
+		var held []*segcorepb.RetrieveResults
+		for _, r := range results {
+			ok, err := budget.hold(ctx, r)
+			require.NoError(t, err)
+			if ok {
+				held = append(held, r)
+			}
+		}
+		assert.Len(t, held, 2)
+		require.Len(t, budget.runs, 2)
+		assert.Len(t, budget.runs[0].chunks, 3)
+
+		expected, err := mergeSegcoreRetrieveResults(ctx, results, typeutil.Unlimited)
+		require.NoError(t, err)
+		ret, err := budget.merge(ctx, held, typeutil.Unlimited)
+		require.NoError(t, err)
+		assert.Equal(t, expected.GetIds().GetIntId().GetData(), ret.GetIds().GetIntId().GetData())
+		assert.Equal(t, expected.GetFieldsData()[0].GetScalars().GetLongData().GetData(),
+			ret.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+		ret, err = budget.merge(ctx, held, 100)
+		require.NoError(t, err)
+		assert.Equal(t, expected.GetIds().GetIntId().GetData()[:100], ret.GetIds().GetIntId().GetData())
+
+		budget.release(ctx)
+		paths, _, err := cm.ListWithPrefix(ctx, budget.prefix, true)
+		assert.NoError(t, err)
+		assert.Empty(t, paths)
+	})
+
+	t.Run("merged result exceeds budget", func(t *testing.T) {
+		cm := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+		budget := &retrieveBudget{limit: 64 * 1024, spillCM: cm, prefix: "query_spill/2"}
+		defer budget.release(ctx)
+
+		for i := int64(0); i < 4; i++ {
+			_, err := budget.hold(ctx, genBudgetRetrieveResults(i, 4, 5000))
+			require.NoError(t, err)
+		}
+		_, err := budget.merge(ctx, nil, typeutil.Unlimited)
+		assert.True(t, errors.Is(err, ErrQueryMemoryExceeded))
+	})
+}
@@ -54,6 +54,7 @@ func TestStreaming_retrieve(t *testing.T) {
 			defaultCollectionID,
 			[]UniqueID{defaultPartitionID},
 			defaultDMLChannel,
+			nil,
 			nil)
 		assert.NoError(t, err)
 		assert.Len(t, res, 1)
@@ -66,6 +67,7 @@ func TestStreaming_retrieve(t *testing.T) {
 			defaultCollectionID,
 			nil,
 			defaultDMLChannel,
+			nil,
 			nil)
 		assert.NoError(t, err)
 		assert.Len(t, res, 1)
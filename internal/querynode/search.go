@@ -95,6 +95,8 @@ func searchHistorical(ctx context.Context, replica ReplicaInterface, searchReq *
 	if err != nil {
 		return searchResults, searchSegmentIDs, searchPartIDs, err
 	}
+	searchSegmentIDs = prunePartitions(replica, segmentTypeSealed, searchReq.predicates, searchSegmentIDs, metrics.SearchLabel)
+	searchSegmentIDs = pruneInvisibleSegments(replica, segmentTypeSealed, searchReq.timestamp, searchSegmentIDs)
 	searchResults, err = searchSegments(ctx, replica, segmentTypeSealed, searchReq, searchSegmentIDs)
 	return searchResults, searchPartIDs, searchSegmentIDs, err
 }
@@ -111,6 +113,7 @@ func searchStreaming(ctx context.Context, replica ReplicaInterface, searchReq *s
 	if err != nil {
 		return searchResults, searchSegmentIDs, searchPartIDs, err
 	}
+	searchSegmentIDs = prunePartitions(replica, segmentTypeGrowing, searchReq.predicates, searchSegmentIDs, metrics.SearchLabel)
 	searchResults, err = searchSegments(ctx, replica, segmentTypeGrowing, searchReq, searchSegmentIDs)
 	return searchResults, searchPartIDs, searchSegmentIDs, err
 }
@@ -0,0 +1,185 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+type searchResultCacheKey [sha256.Size]byte
+
+type searchResultCacheEntry struct {
+	key        searchResultCacheKey
+	result     *internalpb.SearchResults
+	segmentIDs []UniqueID
+	size       int64
+	expireAt   time.Time
+}
+
+// searchResultCache keeps the reduced results of sub-searches on sealed segments for a short time,
+// so that retries and duplicate fan-outs of the same request don't search the segments again.
+// Entries are keyed by the request and the versions of the searched segments, any change of
+// the segments leads to a different key.
+type searchResultCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	maxSize int64
+	size    int64
+	entries map[searchResultCacheKey]*list.Element
+	order   *list.List // oldest entry at front
+}
+
+func newSearchResultCache(ttl time.Duration, maxSize int64) *searchResultCache {
+	return &searchResultCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[searchResultCacheKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get returns a copy of the cached result and the searched segments, the sliced blob is shared and must not be modified.
+func (c *searchResultCache) get(key searchResultCacheKey) (*internalpb.SearchResults, []UniqueID, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictExpiredLocked(time.Now())
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, nil, false
+	}
+	entry := elem.Value.(*searchResultCacheEntry)
+	return cloneSearchResults(entry.result), entry.segmentIDs, true
+}
+
+func (c *searchResultCache) put(key searchResultCacheKey, result *internalpb.SearchResults, segmentIDs []UniqueID) {
+	size := int64(len(result.GetSlicedBlob()))
+	if size > c.maxSize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.evictExpiredLocked(now)
+	if elem, ok := c.entries[key]; ok {
+		c.removeLocked(elem)
+	}
+	for c.size+size > c.maxSize && c.order.Len() > 0 {
+		c.removeLocked(c.order.Front())
+	}
+	entry := &searchResultCacheEntry{
+		key:        key,
+		result:     cloneSearchResults(result),
+		segmentIDs: segmentIDs,
+		size:       size,
+		expireAt:   now.Add(c.ttl),
+	}
+	c.entries[key] = c.order.PushBack(entry)
+	c.size += size
+}
+
+func (c *searchResultCache) evictExpiredLocked(now time.Time) {
+	for elem := c.order.Front(); elem != nil; elem = c.order.Front() {
+		if elem.Value.(*searchResultCacheEntry).expireAt.After(now) {
+			return
+		}
+		c.removeLocked(elem)
+	}
+}
+
+func (c *searchResultCache) removeLocked(elem *list.Element) {
+	entry := c.order.Remove(elem).(*searchResultCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+// cloneSearchResults copies the reduced results without the searched scope, the sliced blob is shared.
+func cloneSearchResults(result *internalpb.SearchResults) *internalpb.SearchResults {
+	return &internalpb.SearchResults{
+		Status:         &commonpb.Status{ErrorCode: result.GetStatus().GetErrorCode(), Reason: result.GetStatus().GetReason()},
+		MetricType:     result.GetMetricType(),
+		NumQueries:     result.GetNumQueries(),
+		TopK:           result.GetTopK(),
+		SlicedBlob:     result.GetSlicedBlob(),
+		SlicedOffset:   result.GetSlicedOffset(),
+		SlicedNumCount: result.GetSlicedNumCount(),
+	}
+}
+
+// buildSearchResultCacheKey hashes everything which determines the result of a sub-search on sealed segments,
+// ok is false if the request doesn't specify segments or any of them is not loaded.
+func buildSearchResultCacheKey(replica ReplicaInterface, req *querypb.SearchRequest) (searchResultCacheKey, bool) {
+	var key searchResultCacheKey
+	iReq := req.GetReq()
+	h := sha256.New()
+	buf := make([]byte, 8)
+	writeInt := func(v int64) {
+		binary.LittleEndian.PutUint64(buf, uint64(v))
+		h.Write(buf)
+	}
+	writeBytes := func(b []byte) {
+		writeInt(int64(len(b)))
+		h.Write(b)
+	}
+
+	writeInt(iReq.GetCollectionID())
+	writeInt(int64(iReq.GetTravelTimestamp()))
+	writeInt(iReq.GetNq())
+	writeInt(iReq.GetTopk())
+	writeInt(int64(iReq.GetDslType()))
+	writeBytes([]byte(iReq.GetMetricType()))
+	writeBytes([]byte(iReq.GetDsl()))
+	writeBytes(iReq.GetSerializedExprPlan())
+	writeBytes(iReq.GetPlaceholderGroup())
+	writeInt(int64(len(iReq.GetOutputFieldsId())))
+	for _, fieldID := range iReq.GetOutputFieldsId() {
+		writeInt(fieldID)
+	}
+	writeInt(int64(len(iReq.GetPartitionIDs())))
+	for _, partitionID := range iReq.GetPartitionIDs() {
+		writeInt(partitionID)
+	}
+
+	if len(req.GetSegmentIDs()) == 0 {
+		return key, false
+	}
+	segmentIDs := make([]UniqueID, len(req.GetSegmentIDs()))
+	copy(segmentIDs, req.GetSegmentIDs())
+	sort.Slice(segmentIDs, func(i, j int) bool { return segmentIDs[i] < segmentIDs[j] })
+	writeInt(int64(len(segmentIDs)))
+	for _, segmentID := range segmentIDs {
+		segment, err := replica.getSegmentByID(segmentID, segmentTypeSealed)
+		if err != nil {
+			return key, false
+		}
+		writeInt(segmentID)
+		writeInt(segment.version)
+		writeInt(segment.getDataVersion())
+	}
+
+	copy(key[:], h.Sum(nil))
+	return key, true
+}
@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/internalpb"
+	"github.com/milvus-io/milvus/internal/proto/querypb"
+)
+
+func TestSearchResultCache(t *testing.T) {
+	result := &internalpb.SearchResults{
+		MetricType: "L2",
+		NumQueries: 1,
+		TopK:       10,
+		SlicedBlob: make([]byte, 10),
+	}
+
+	t.Run("get and put", func(t *testing.T) {
+		cache := newSearchResultCache(time.Minute, 100)
+		key := searchResultCacheKey{1}
+		_, _, ok := cache.get(key)
+		assert.False(t, ok)
+
+		cache.put(key, result, []UniqueID{1, 2})
+		ret, segmentIDs, ok := cache.get(key)
+		assert.True(t, ok)
+		assert.Equal(t, []UniqueID{1, 2}, segmentIDs)
+		assert.Equal(t, result.GetSlicedBlob(), ret.GetSlicedBlob())
+		assert.Equal(t, result.GetTopK(), ret.GetTopK())
+		assert.NotSame(t, result, ret)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		cache := newSearchResultCache(time.Millisecond, 100)
+		key := searchResultCacheKey{1}
+		cache.put(key, result, nil)
+		time.Sleep(5 * time.Millisecond)
+		_, _, ok := cache.get(key)
+		assert.False(t, ok)
+		assert.Equal(t, int64(0), cache.size)
+	})
+
+	t.Run("evict oldest", func(t *testing.T) {
+		cache := newSearchResultCache(time.Minute, 25)
+		for i := byte(1); i <= 3; i++ {
+			cache.put(searchResultCacheKey{i}, result, nil)
+		}
+		_, _, ok := cache.get(searchResultCacheKey{1})
+		assert.False(t, ok)
+		_, _, ok = cache.get(searchResultCacheKey{3})
+		assert.True(t, ok)
+		assert.Equal(t, int64(20), cache.size)
+
+		// larger than the cache
+		cache = newSearchResultCache(time.Minute, 5)
+		cache.put(searchResultCacheKey{1}, result, nil)
+		_, _, ok = cache.get(searchResultCacheKey{1})
+		assert.False(t, ok)
+	})
+}
+
+func TestBuildSearchResultCacheKey(t *testing.T) {
+	replica, err := genSimpleReplicaWithSealSegment(context.Background())
+	require.NoError(t, err)
+	collection, err := replica.getCollectionByID(defaultCollectionID)
+	require.NoError(t, err)
+	iReq, err := genSearchRequest(defaultNQ, IndexFaissIDMap, collection.schema)
+	require.NoError(t, err)
+	req := &querypb.SearchRequest{
+		Req:        iReq,
+		SegmentIDs: []UniqueID{defaultSegmentID},
+	}
+
+	key, ok := buildSearchResultCacheKey(replica, req)
+	assert.True(t, ok)
+	key2, ok := buildSearchResultCacheKey(replica, req)
+	assert.True(t, ok)
+	assert.Equal(t, key, key2)
+
+	// segment changed
+	segment, err := replica.getSegmentByID(defaultSegmentID, segmentTypeSealed)
+	require.NoError(t, err)
+	segment.bumpDataVersion()
+	key2, ok = buildSearchResultCacheKey(replica, req)
+	assert.True(t, ok)
+	assert.NotEqual(t, key, key2)
+
+	// request changed
+	iReq.TravelTimestamp++
+	key3, ok := buildSearchResultCacheKey(replica, req)
+	assert.True(t, ok)
+	assert.NotEqual(t, key2, key3)
+
+	// segment not loaded
+	req.SegmentIDs = []UniqueID{defaultSegmentID + 100}
+	_, ok = buildSearchResultCacheKey(replica, req)
+	assert.False(t, ok)
+
+	// all segments
+	req.SegmentIDs = nil
+	_, ok = buildSearchResultCacheKey(replica, req)
+	assert.False(t, ok)
+}
@@ -32,15 +32,16 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/util/concurrency"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/metricsinfo"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 	"github.com/milvus-io/milvus/internal/util/typeutil"
 
-	"github.com/bits-and-blooms/bloom/v3"
 	"github.com/milvus-io/milvus/internal/metrics"
 	"github.com/milvus-io/milvus/internal/util/timerecord"
 
@@ -65,8 +66,14 @@ const (
 	segmentTypeSealed  = commonpb.SegmentState_Sealed
 )
 
+// pkStatsLoadTimeout bounds the lazy loading of the history stats of a sealed segment.
+const pkStatsLoadTimeout = 30 * time.Second
+
 var (
 	ErrSegmentUnhealthy = errors.New("segment unhealthy")
+
+	// allocates data versions of all segments, see Segment.getDataVersion
+	segmentDataVersionAllocator atomic.Int64
 )
 
 // IndexedFieldInfo contains binlog info of vector field
@@ -93,6 +100,8 @@ type Segment struct {
 	recentlyModified *atomic.Bool
 	segmentType      *atomic.Int32
 	destroyed        *atomic.Bool
+	// changed whenever data, deletions or indexes of the segment change
+	dataVersion atomic.Int64
 
 	idBinlogRowSizes []int64
 
@@ -102,6 +111,19 @@ type Segment struct {
 	// only used by sealed segments
 	currentStat  *storage.PkStatistics
 	historyStats []*storage.PkStatistics
+	// set if the history stats are loaded lazily from statsLogPaths by pkStatsCache,
+	// their pk ranges are kept in pkRanges to check the pks before loading them
+	statsLogPaths []string
+	pkStatsCache  *storage.PkStatsCache
+	pkRanges      []*storage.PkStatistics
+
+	fieldStatsLock sync.RWMutex
+	// min/max of numeric scalar fields, used to prune partitions
+	fieldStats map[FieldID]*fieldRange
+	// persisted timestamp index of sealed segments, used to prune the segments invisible to time travel
+	timestampIndex *storage.TimestampIndex
+	// live statistics of scalar fields, merged by fieldStatsCollector
+	fieldStatistics map[FieldID]*metricsinfo.FieldStatistics
 
 	pool *concurrency.Pool
 }
@@ -119,6 +141,74 @@ func (s *Segment) getIDBinlogRowSizes() []int64 {
 	return s.idBinlogRowSizes
 }
 
+// updateFieldStats extends the value ranges of numeric fields with the inserted or loaded field data.
+func (s *Segment) updateFieldStats(fieldsData ...*schemapb.FieldData) {
+	s.fieldStatsLock.Lock()
+	defer s.fieldStatsLock.Unlock()
+	if s.fieldStats == nil {
+		s.fieldStats = make(map[FieldID]*fieldRange)
+	}
+	for _, data := range fieldsData {
+		r := rangeOfFieldData(data)
+		if r == nil {
+			continue
+		}
+		if old, ok := s.fieldStats[data.GetFieldId()]; ok {
+			r = old.merge(r)
+		}
+		s.fieldStats[data.GetFieldId()] = r
+	}
+
+	if !Params.QueryNodeCfg.FieldStatsEnabled {
+		return
+	}
+	if s.fieldStatistics == nil {
+		s.fieldStatistics = make(map[FieldID]*metricsinfo.FieldStatistics)
+	}
+	for _, data := range fieldsData {
+		observeFieldData(s.fieldStatistics, data)
+	}
+}
+
+// getFieldStatistics returns a copy of the live statistics of scalar fields.
+func (s *Segment) getFieldStatistics() map[FieldID]*metricsinfo.FieldStatistics {
+	s.fieldStatsLock.RLock()
+	defer s.fieldStatsLock.RUnlock()
+	stats := make(map[FieldID]*metricsinfo.FieldStatistics, len(s.fieldStatistics))
+	for fieldID, fieldStats := range s.fieldStatistics {
+		stats[fieldID] = fieldStats.Clone()
+	}
+	return stats
+}
+
+// getFieldStats returns the value ranges of numeric fields, fields without statistics are absent.
+func (s *Segment) getFieldStats() map[FieldID]*fieldRange {
+	s.fieldStatsLock.RLock()
+	defer s.fieldStatsLock.RUnlock()
+	stats := make(map[FieldID]*fieldRange, len(s.fieldStats))
+	for fieldID, r := range s.fieldStats {
+		stats[fieldID] = r
+	}
+	return stats
+}
+
+// getTimestampIndex returns the persisted timestamp index, nil if it is not loaded.
+func (s *Segment) getTimestampIndex() *storage.TimestampIndex {
+	s.fieldStatsLock.RLock()
+	defer s.fieldStatsLock.RUnlock()
+	return s.timestampIndex
+}
+
+// getDataVersion returns a version which changes whenever the searchable content of the segment changes,
+// versions are never reused by other segment instances.
+func (s *Segment) getDataVersion() int64 {
+	return s.dataVersion.Load()
+}
+
+func (s *Segment) bumpDataVersion() {
+	s.dataVersion.Store(segmentDataVersionAllocator.Inc())
+}
+
 func (s *Segment) setRecentlyModified(modify bool) {
 	s.recentlyModified.Store(modify)
 }
@@ -225,6 +315,7 @@ func newSegment(collection *Collection,
 		historyStats:      []*storage.PkStatistics{},
 		pool:              pool,
 	}
+	segment.bumpDataVersion()
 
 	return segment, nil
 }
@@ -251,8 +342,16 @@ func deleteSegment(segment *Segment) {
 		return nil, nil
 	}).Await()
 
+	segment.statLock.Lock()
 	segment.currentStat = nil
 	segment.historyStats = nil
+	if segment.pkStatsCache != nil {
+		segment.pkStatsCache.Remove(segment.segmentID)
+		segment.pkStatsCache = nil
+		segment.statsLogPaths = nil
+		segment.pkRanges = nil
+	}
+	segment.statLock.Unlock()
 
 	log.Info("delete segment from memory",
 		zap.Int64("collectionID", segment.collectionID),
@@ -434,33 +533,81 @@ func (s *Segment) getFieldDataPath(indexedFieldInfo *IndexedFieldInfo, offset in
 }
 
 func fillBinVecFieldData(ctx context.Context, vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
-	dim := fieldData.GetVectors().GetDim()
-	rowBytes := dim / 8
+	rowBytes := vecRowBytes(fieldData)
 	content, err := vcm.ReadAt(ctx, dataPath, offset*rowBytes, rowBytes)
 	if err != nil {
 		return err
 	}
-	x := fieldData.GetVectors().GetData().(*schemapb.VectorField_BinaryVector)
-	resultLen := dim / 8
-	copy(x.BinaryVector[i*int(resultLen):(i+1)*int(resultLen)], content)
-	return nil
+	return setVecFieldData(fieldData, i, content, endian)
 }
 
 func fillFloatVecFieldData(ctx context.Context, vcm storage.ChunkManager, dataPath string, fieldData *schemapb.FieldData, i int, offset int64, endian binary.ByteOrder) error {
-	dim := fieldData.GetVectors().GetDim()
-	rowBytes := dim * 4
+	rowBytes := vecRowBytes(fieldData)
 	content, err := vcm.ReadAt(ctx, dataPath, offset*rowBytes, rowBytes)
 	if err != nil {
 		return err
 	}
-	x := fieldData.GetVectors().GetData().(*schemapb.VectorField_FloatVector)
-	floatResult := make([]float32, dim)
-	buf := bytes.NewReader(content)
-	if err = binary.Read(buf, endian, &floatResult); err != nil {
-		return err
+	return setVecFieldData(fieldData, i, content, endian)
+}
+
+// vecRowBytes returns the size of a row of the vector @fieldData in binlog.
+func vecRowBytes(fieldData *schemapb.FieldData) int64 {
+	dim := fieldData.GetVectors().GetDim()
+	if fieldData.Type == schemapb.DataType_BinaryVector {
+		return dim / 8
+	}
+	return dim * 4
+}
+
+// setVecFieldData sets the i-th vector of @fieldData with the binlog @content of a row.
+func setVecFieldData(fieldData *schemapb.FieldData, i int, content []byte, endian binary.ByteOrder) error {
+	dim := fieldData.GetVectors().GetDim()
+	switch x := fieldData.GetVectors().GetData().(type) {
+	case *schemapb.VectorField_BinaryVector:
+		resultLen := dim / 8
+		copy(x.BinaryVector[i*int(resultLen):(i+1)*int(resultLen)], content)
+	case *schemapb.VectorField_FloatVector:
+		floatResult := make([]float32, dim)
+		buf := bytes.NewReader(content)
+		if err := binary.Read(buf, endian, &floatResult); err != nil {
+			return err
+		}
+		resultLen := dim
+		copy(x.FloatVector.Data[i*int(resultLen):(i+1)*int(resultLen)], floatResult)
+	default:
+		return fmt.Errorf("invalid vector data type: %s", fieldData.Type.String())
+	}
+	return nil
+}
+
+// fillVecFieldData fills the vector @fieldData of the rows at @offsets of the segment,
+// the rows in the same binlog are read by one MultiReadAt, so the adjacent rows are fetched together.
+func (s *Segment) fillVecFieldData(ctx context.Context, vcm storage.ChunkManager, indexedFieldInfo *IndexedFieldInfo,
+	fieldData *schemapb.FieldData, offsets []int64, endian binary.ByteOrder) error {
+	rowBytes := vecRowBytes(fieldData)
+	var dataPaths []string
+	rows := make(map[string][]int)
+	ranges := make(map[string][]storage.Range)
+	for i, offset := range offsets {
+		dataPath, offsetInBinlog := s.getFieldDataPath(indexedFieldInfo, offset)
+		if _, ok := rows[dataPath]; !ok {
+			dataPaths = append(dataPaths, dataPath)
+		}
+		rows[dataPath] = append(rows[dataPath], i)
+		ranges[dataPath] = append(ranges[dataPath], storage.Range{Offset: offsetInBinlog * rowBytes, Length: rowBytes})
+	}
+
+	for _, dataPath := range dataPaths {
+		contents, err := vcm.MultiReadAt(ctx, dataPath, ranges[dataPath])
+		if err != nil {
+			return err
+		}
+		for j, i := range rows[dataPath] {
+			if err := setVecFieldData(fieldData, i, contents[j], endian); err != nil {
+				return err
+			}
+		}
 	}
-	resultLen := dim
-	copy(x.FloatVector.Data[i*int(resultLen):(i+1)*int(resultLen)], floatResult)
 	return nil
 }
 
@@ -608,6 +755,13 @@ func (s *Segment) fillIndexedFieldsData(ctx context.Context, collectionID Unique
 			continue
 		}
 
+		if fieldData.Type == schemapb.DataType_FloatVector || fieldData.Type == schemapb.DataType_BinaryVector {
+			if err := s.fillVecFieldData(ctx, vcm, indexedFieldInfo, fieldData, result.Offset, common.Endian); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// TODO: optimize here. Now we'll read a whole file from storage every time we retrieve raw data by offset.
 		for i, offset := range result.Offset {
 			dataPath, offsetInBinlog := s.getFieldDataPath(indexedFieldInfo, offset)
@@ -648,26 +802,79 @@ func (s *Segment) updateBloomFilter(pks []primaryKey) {
 func (s *Segment) InitCurrentStat() {
 	if s.currentStat == nil {
 		s.currentStat = &storage.PkStatistics{
-			PkFilter: bloom.NewWithEstimates(storage.BloomFilterSize, storage.MaxBloomFalsePositive),
+			PkFilter: storage.NewBloomFilterWithRows(storage.BloomFilterSize),
 		}
 	}
 }
 
+// setLazyPkStats makes the history stats loaded from the stats logs on demand, only the pk ranges of @stats are kept.
+func (s *Segment) setLazyPkStats(pkStatsCache *storage.PkStatsCache, statsLogPaths []string, stats []*storage.PkStatistics) {
+	s.statLock.Lock()
+	defer s.statLock.Unlock()
+	s.pkStatsCache = pkStatsCache
+	s.statsLogPaths = statsLogPaths
+	s.pkRanges = storage.PkRanges(stats)
+}
+
 // check if PK exists is current
 func (s *Segment) isPKExist(pk primaryKey) bool {
+	return s.pksExist([]primaryKey{pk})[0]
+}
+
+// pksExist checks if every pk of @pks may exist in the segment. The history stats loaded lazily are loaded
+// outside statLock at most once for all the pks, and only if some pk is in their pk ranges.
+func (s *Segment) pksExist(pks []primaryKey) []bool {
+	exist := make([]bool, len(pks))
+	var inLazyRanges []int
 	s.statLock.Lock()
-	defer s.statLock.Unlock()
-	if s.currentStat != nil && s.currentStat.PkExist(pk) {
-		return true
+	for i, pk := range pks {
+		if s.currentStat != nil && s.currentStat.PkExist(pk) {
+			exist[i] = true
+			continue
+		}
+		// for sealed, if one of the stats shows it exist, then we have to check it
+		for _, historyStat := range s.historyStats {
+			if historyStat.PkExist(pk) {
+				exist[i] = true
+				break
+			}
+		}
+		if exist[i] {
+			continue
+		}
+		for _, pkRange := range s.pkRanges {
+			if pkRange.PkInRange(pk) {
+				inLazyRanges = append(inLazyRanges, i)
+				break
+			}
+		}
+	}
+	pkStatsCache, statsLogPaths := s.pkStatsCache, s.statsLogPaths
+	s.statLock.Unlock()
+	if len(inLazyRanges) == 0 || pkStatsCache == nil {
+		return exist
 	}
 
-	// for sealed, if one of the stats shows it exist, then we have to check it
-	for _, historyStat := range s.historyStats {
-		if historyStat.PkExist(pk) {
-			return true
+	ctx, cancel := context.WithTimeout(context.Background(), pkStatsLoadTimeout)
+	defer cancel()
+	stats, err := pkStatsCache.Get(ctx, s.segmentID, statsLogPaths)
+	if err != nil {
+		// unable to filter by the stats, the pks may exist
+		log.Warn("failed to load pk stats lazily", zap.Int64("segmentID", s.segmentID), zap.Error(err))
+		for _, i := range inLazyRanges {
+			exist[i] = true
+		}
+		return exist
+	}
+	for _, i := range inLazyRanges {
+		for _, stat := range stats {
+			if stat.PkExist(pks[i]) {
+				exist[i] = true
+				break
+			}
 		}
 	}
-	return false
+	return exist
 }
 
 //-------------------------------------------------------------------------------------- interfaces for growing segment
@@ -699,6 +906,37 @@ func (s *Segment) segmentPreInsert(numOfRecords int) (int64, error) {
 	return offset, nil
 }
 
+// spill spills the cold chunks of the vector fields of the growing segment to files under dir,
+// until targetBytes are released, it returns the bytes released.
+func (s *Segment) spill(dir string, targetBytes int64) (int64, error) {
+	/*
+		CStatus
+		SpillGrowingSegment(CSegmentInterface c_segment, const char* dir, int64_t target_bytes, int64_t* spilled_bytes);
+	*/
+	if s.getType() != segmentTypeGrowing {
+		return 0, nil
+	}
+
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	if !s.healthy() {
+		return 0, fmt.Errorf("%w(segmentID=%d)", ErrSegmentUnhealthy, s.segmentID)
+	}
+
+	cDir := C.CString(dir)
+	defer C.free(unsafe.Pointer(cDir))
+	var spilled int64
+	var status C.CStatus
+	s.pool.Submit(func() (interface{}, error) {
+		status = C.SpillGrowingSegment(s.segmentPtr, cDir, C.int64_t(targetBytes), (*C.int64_t)(&spilled))
+		return nil, nil
+	}).Await()
+	if err := HandleCStatus(&status, "Spill failed"); err != nil {
+		return 0, err
+	}
+	return spilled, nil
+}
+
 func (s *Segment) segmentPreDelete(numOfRecords int) int64 {
 	/*
 		long int
@@ -735,6 +973,8 @@ func (s *Segment) segmentInsert(offset int64, entityIDs []UniqueID, timestamps [
 	if err != nil {
 		return fmt.Errorf("failed to marshal insert record: %s", err)
 	}
+	// update statistics before the data become visible, so partition pruning never skips them
+	s.updateFieldStats(record.GetFieldsData()...)
 
 	var numOfRow = len(entityIDs)
 	var cOffset = C.int64_t(offset)
@@ -759,6 +999,7 @@ func (s *Segment) segmentInsert(offset int64, entityIDs []UniqueID, timestamps [
 	if err := HandleCStatus(&status, "Insert failed"); err != nil {
 		return err
 	}
+	s.bumpDataVersion()
 	metrics.QueryNodeNumEntities.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Add(float64(numOfRow))
 	s.setRecentlyModified(true)
 	return nil
@@ -833,6 +1074,7 @@ func (s *Segment) segmentDelete(offset int64, entityIDs []primaryKey, timestamps
 	if err := HandleCStatus(&status, "Delete failed"); err != nil {
 		return err
 	}
+	s.bumpDataVersion()
 
 	return nil
 }
@@ -874,6 +1116,8 @@ func (s *Segment) segmentLoadFieldData(fieldID int64, rowCount int64, data *sche
 	if err := HandleCStatus(&status, "LoadFieldData failed"); err != nil {
 		return err
 	}
+	s.bumpDataVersion()
+	s.updateFieldStats(data)
 
 	log.Info("load field done",
 		zap.Int64("fieldID", fieldID),
@@ -883,6 +1127,101 @@ func (s *Segment) segmentLoadFieldData(fieldID int64, rowCount int64, data *sche
 	return nil
 }
 
+// segmentLoadDictionaryFieldData loads a dictionary-encoded VarChar field, segcore keeps it encoded
+// and evaluates expressions on the codes.
+func (s *Segment) segmentLoadDictionaryFieldData(fieldID int64, data *storage.DictionaryEncodedStrings) error {
+	/*
+		CStatus
+		LoadDictionaryFieldData(CSegmentInterface c_segment, CLoadDictionaryFieldDataInfo load_info);
+	*/
+	if s.getType() != segmentTypeSealed {
+		errMsg := fmt.Sprintln("segmentLoadDictionaryFieldData failed, illegal segment type ", s.segmentType, "segmentID = ", s.ID())
+		return errors.New(errMsg)
+	}
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	if !s.healthy() {
+		return fmt.Errorf("%w(segmentID=%d)", ErrSegmentUnhealthy, s.segmentID)
+	}
+	if data.RowNum() == 0 || len(data.Dictionary) == 0 {
+		return fmt.Errorf("empty dictionary-encoded field, fieldID = %d", fieldID)
+	}
+
+	dictionaryBlob, err := proto.Marshal(&schemapb.StringArray{Data: data.Dictionary})
+	if err != nil {
+		return err
+	}
+
+	loadInfo := C.CLoadDictionaryFieldDataInfo{
+		field_id:        C.int64_t(fieldID),
+		dictionary:      (*C.uint8_t)(unsafe.Pointer(&dictionaryBlob[0])),
+		dictionary_size: C.uint64_t(len(dictionaryBlob)),
+		codes:           (*C.int32_t)(unsafe.Pointer(&data.Codes[0])),
+		row_count:       C.int64_t(data.RowNum()),
+	}
+
+	var status C.CStatus
+	s.pool.Submit(func() (interface{}, error) {
+		status = C.LoadDictionaryFieldData(s.segmentPtr, loadInfo)
+		return nil, nil
+	}).Await()
+
+	if err := HandleCStatus(&status, "LoadDictionaryFieldData failed"); err != nil {
+		return err
+	}
+	s.bumpDataVersion()
+
+	log.Info("load dictionary-encoded field done",
+		zap.Int64("fieldID", fieldID),
+		zap.Int("row count", data.RowNum()),
+		zap.Int("dictionary size", len(data.Dictionary)),
+		zap.Int64("segmentID", s.ID()))
+
+	return nil
+}
+
+// segmentLoadTimestampIndex loads the persisted timestamp index, so that segcore uses its slices
+// instead of rebuilding them when the timestamps are loaded.
+func (s *Segment) segmentLoadTimestampIndex(index *storage.TimestampIndex) error {
+	/*
+		CStatus
+		LoadTimestampIndex(CSegmentInterface c_segment, const int64_t* slice_lengths, int64_t num_slices);
+	*/
+	if s.getType() != segmentTypeSealed {
+		errMsg := fmt.Sprintln("segmentLoadTimestampIndex failed, illegal segment type ", s.segmentType, "segmentID = ", s.ID())
+		return errors.New(errMsg)
+	}
+	if len(index.Lengths) == 0 {
+		return fmt.Errorf("empty timestamp index, segmentID = %d", s.ID())
+	}
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	if !s.healthy() {
+		return fmt.Errorf("%w(segmentID=%d)", ErrSegmentUnhealthy, s.segmentID)
+	}
+
+	var status C.CStatus
+	s.pool.Submit(func() (interface{}, error) {
+		status = C.LoadTimestampIndex(s.segmentPtr, (*C.int64_t)(unsafe.Pointer(&index.Lengths[0])), C.int64_t(len(index.Lengths)))
+		return nil, nil
+	}).Await()
+
+	if err := HandleCStatus(&status, "LoadTimestampIndex failed"); err != nil {
+		return err
+	}
+
+	s.fieldStatsLock.Lock()
+	s.timestampIndex = index
+	s.fieldStatsLock.Unlock()
+
+	log.Info("load timestamp index done",
+		zap.Int64("segmentID", s.ID()),
+		zap.Int("slices", len(index.Lengths)),
+		zap.Uint64("minTimestamp", index.MinTimestamp()),
+		zap.Uint64("maxTimestamp", index.MaxTimestamp()))
+	return nil
+}
+
 func (s *Segment) segmentLoadDeletedRecord(primaryKeys []primaryKey, timestamps []Timestamp, rowCount int64) error {
 	s.mut.RLock()
 	defer s.mut.RUnlock()
@@ -944,6 +1283,7 @@ func (s *Segment) segmentLoadDeletedRecord(primaryKeys []primaryKey, timestamps
 	if err := HandleCStatus(&status, "LoadDeletedRecord failed"); err != nil {
 		return err
 	}
+	s.bumpDataVersion()
 
 	log.Info("load deleted record done",
 		zap.Int64("row count", rowCount),
@@ -952,6 +1292,49 @@ func (s *Segment) segmentLoadDeletedRecord(primaryKeys []primaryKey, timestamps
 	return nil
 }
 
+func (s *Segment) segmentLoadDeletionVector(offsets []int64, timestamps []Timestamp) error {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	if !s.healthy() {
+		return fmt.Errorf("%w(segmentID=%d)", ErrSegmentUnhealthy, s.segmentID)
+	}
+
+	if s.getType() != segmentTypeSealed {
+		return fmt.Errorf("unexpected segmentType when segmentLoadDeletionVector, segmentType = %s", s.getType().String())
+	}
+	if len(offsets) <= 0 {
+		return fmt.Errorf("empty offsets to delete")
+	}
+	if len(offsets) != len(timestamps) {
+		return errors.New("length of offsets not equal to length of timestamps")
+	}
+
+	loadInfo := C.CLoadDeletionVectorInfo{
+		offsets:    (*C.int64_t)(unsafe.Pointer(&offsets[0])),
+		timestamps: (*C.uint64_t)(unsafe.Pointer(&timestamps[0])),
+		row_count:  C.int64_t(len(offsets)),
+	}
+	/*
+		CStatus
+		LoadDeletionVector(CSegmentInterface c_segment, CLoadDeletionVectorInfo deletion_vector_info)
+	*/
+	var status C.CStatus
+	s.pool.Submit(func() (interface{}, error) {
+		status = C.LoadDeletionVector(s.segmentPtr, loadInfo)
+		return nil, nil
+	}).Await()
+
+	if err := HandleCStatus(&status, "LoadDeletionVector failed"); err != nil {
+		return err
+	}
+	s.bumpDataVersion()
+
+	log.Info("load deletion vector done",
+		zap.Int("row count", len(offsets)),
+		zap.Int64("segmentID", s.ID()))
+	return nil
+}
+
 func (s *Segment) segmentLoadIndexData(bytesIndex [][]byte, indexInfo *querypb.FieldIndexInfo, fieldType schemapb.DataType) error {
 	loadIndexInfo, err := newLoadIndexInfo()
 	defer deleteLoadIndexInfo(loadIndexInfo)
@@ -987,6 +1370,7 @@ func (s *Segment) segmentLoadIndexData(bytesIndex [][]byte, indexInfo *querypb.F
 	if err := HandleCStatus(&status, "UpdateSealedSegmentIndex failed"); err != nil {
 		return err
 	}
+	s.bumpDataVersion()
 
 	log.Info("updateSegmentIndex done", zap.Int64("segmentID", s.ID()), zap.Int64("fieldID", indexInfo.FieldID))
 
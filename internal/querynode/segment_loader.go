@@ -70,6 +70,8 @@ type segmentLoader struct {
 
 	cm     storage.ChunkManager // minio cm
 	etcdKV *etcdkv.EtcdKV
+	// loads the pk stats of the sealed segments on demand, nil if they are loaded with the segments
+	pkStatsCache *storage.PkStatsCache
 
 	ioPool  *concurrency.Pool
 	cpuPool *concurrency.Pool
@@ -234,6 +236,11 @@ func (loader *segmentLoader) loadFiles(ctx context.Context, segment *Segment,
 	// for now, there will be multiple copies in the process of data loading into segCore
 	defer debug.FreeOSMemory()
 
+	// the small objects read for the segment, e.g. delta logs, are allocated from the arena,
+	// they are copied into the segment, so the arena lives until the segment is loaded
+	arena := storage.NewArena()
+	defer arena.Release()
+
 	if segment.getType() == segmentTypeSealed {
 		fieldID2IndexInfo := make(map[int64]*querypb.FieldIndexInfo)
 		for _, indexInfo := range loadInfo.IndexInfos {
@@ -260,6 +267,13 @@ func (loader *segmentLoader) loadFiles(ctx context.Context, segment *Segment,
 			}
 		}
 
+		// the persisted timestamp index must be loaded before the timestamps
+		if err := loader.loadTimestampIndex(ctx, segment, loadInfo); err != nil {
+			log.Warn("failed to load timestamp index, rebuild it from the timestamps",
+				zap.Int64("segmentID", segment.segmentID),
+				zap.Error(err))
+		}
+
 		if err := loader.loadIndexedFieldData(ctx, segment, indexedFieldInfos); err != nil {
 			return err
 		}
@@ -284,7 +298,7 @@ func (loader *segmentLoader) loadFiles(ctx context.Context, segment *Segment,
 	}
 
 	log.Info("loading delta...", zap.Int64("segmentID", segmentID))
-	err = loader.loadDeltaLogs(ctx, segment, loadInfo.Deltalogs)
+	err = loader.loadDeltaLogs(ctx, segment, loadInfo.Deltalogs, arena)
 	return err
 }
 
@@ -310,10 +324,22 @@ func (loader *segmentLoader) loadGrowingSegmentFields(ctx context.Context, segme
 
 	// change all field bin log loading into concurrent
 	loadFutures := make([]*concurrency.Future, 0, len(fieldBinlogs))
+	var binlogPaths []string
 	for _, fieldBinlog := range fieldBinlogs {
 		futures := loader.loadFieldBinlogsAsync(ctx, fieldBinlog)
 		loadFutures = append(loadFutures, futures...)
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			binlogPaths = append(binlogPaths, binlog.GetLogPath())
+		}
 	}
+	// the binlogs are read into pooled buffers, which are released after the field data is copied into segcore
+	defer func() {
+		for _, future := range loadFutures {
+			if buffer, ok := future.Value().(*storage.PooledBuffer); ok {
+				buffer.Release()
+			}
+		}
+	}()
 
 	// wait for async load results
 	blobs := make([]*storage.Blob, len(loadFutures))
@@ -322,8 +348,10 @@ func (loader *segmentLoader) loadGrowingSegmentFields(ctx context.Context, segme
 			return future.Err()
 		}
 
-		blob := future.Value().(*storage.Blob)
-		blobs[index] = blob
+		blobs[index] = &storage.Blob{
+			Key:   binlogPaths[index],
+			Value: future.Value().(*storage.PooledBuffer).Bytes(),
+		}
 	}
 	log.Info("log field binlogs done",
 		zap.Int64("collection", segment.collectionID),
@@ -386,21 +414,54 @@ func (loader *segmentLoader) loadSealedSegmentFields(ctx context.Context, segmen
 
 // async load field of sealed segment
 func (loader *segmentLoader) loadSealedField(ctx context.Context, segment *Segment, field *datapb.FieldBinlog, loadInfo *querypb.SegmentLoadInfo) error {
+	loaded, err := loader.loadDictionaryField(ctx, segment, field, loadInfo)
+	if err != nil {
+		log.Warn("failed to load dictionary-encoded field, fallback to binlogs",
+			zap.Int64("segmentID", segment.segmentID),
+			zap.Int64("fieldID", field.GetFieldID()),
+			zap.Error(err))
+	}
+	if loaded {
+		return nil
+	}
+
 	iCodec := storage.InsertCodec{}
 
 	// Avoid consuming too much memory if no CPU worker ready,
 	// acquire a CPU worker before load field binlogs
 	futures := loader.loadFieldBinlogsAsync(ctx, field)
 
-	err := concurrency.AwaitAll(futures...)
+	// the binlogs are read into pooled buffers, which are released after the field data is copied into segcore
+	defer func() {
+		for _, future := range futures {
+			if buffer, ok := future.Value().(*storage.PooledBuffer); ok {
+				buffer.Release()
+			}
+		}
+	}()
+
+	err = concurrency.AwaitAll(futures...)
 	if err != nil {
 		return err
 	}
 
 	blobs := make([]*storage.Blob, len(futures))
 	for index, future := range futures {
-		blob := future.Value().(*storage.Blob)
-		blobs[index] = blob
+		blobs[index] = &storage.Blob{
+			Key:   field.Binlogs[index].GetLogPath(),
+			Value: future.Value().(*storage.PooledBuffer).Bytes(),
+		}
+	}
+
+	loaded, err = loader.loadDictionaryBinlogs(segment, field.GetFieldID(), blobs, loadInfo)
+	if err != nil {
+		log.Warn("failed to load dictionary-encoded binlogs, fallback to decoding them",
+			zap.Int64("segmentID", segment.segmentID),
+			zap.Int64("fieldID", field.GetFieldID()),
+			zap.Error(err))
+	}
+	if loaded {
+		return nil
 	}
 
 	insertData := storage.InsertData{
@@ -416,23 +477,160 @@ func (loader *segmentLoader) loadSealedField(ctx context.Context, segment *Segme
 	return loader.loadSealedSegments(segment, &insertData)
 }
 
-// Load binlogs concurrently into memory from KV storage asyncly
+// loadTimestampIndex loads the timestamp index logs, which datanode saves as stats logs of the timestamp field,
+// one for each insert binlog. Nothing is loaded if some insert binlog has no timestamp index log.
+func (loader *segmentLoader) loadTimestampIndex(ctx context.Context, segment *Segment, loadInfo *querypb.SegmentLoadInfo) error {
+	var binlogNum int
+	for _, fieldBinlog := range loadInfo.GetBinlogPaths() {
+		if fieldBinlog.GetFieldID() == common.TimeStampField {
+			binlogNum = len(fieldBinlog.GetBinlogs())
+		}
+	}
+	var tsIndexLogs []string
+	for _, fieldBinlog := range loadInfo.GetStatslogs() {
+		if fieldBinlog.GetFieldID() != common.TimeStampField {
+			continue
+		}
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			tsIndexLogs = append(tsIndexLogs, binlog.GetLogPath())
+		}
+	}
+	if len(tsIndexLogs) == 0 || len(tsIndexLogs) != binlogNum {
+		return nil
+	}
+
+	values, err := loader.cm.MultiRead(ctx, tsIndexLogs)
+	if err != nil {
+		return err
+	}
+	codec := storage.NewTimestampIndexCodec()
+	parts := make([]*storage.TimestampIndex, 0, len(values))
+	for i, value := range values {
+		part, err := codec.Deserialize(&storage.Blob{Key: tsIndexLogs[i], Value: value})
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+	}
+
+	index := storage.MergeTimestampIndexes(parts)
+	if index.RowNum() != loadInfo.GetNumOfRows() {
+		return fmt.Errorf("timestamp index has %d rows, but segment has %d rows", index.RowNum(), loadInfo.GetNumOfRows())
+	}
+	return segment.segmentLoadTimestampIndex(index)
+}
+
+// isDictionaryField returns whether the field could be kept dictionary-encoded, only the VarChar fields
+// except the primary key could be.
+func (loader *segmentLoader) isDictionaryField(segment *Segment, fieldID int64) bool {
+	fieldType, err := loader.getFieldType(segment, fieldID)
+	if err != nil || fieldType != schemapb.DataType_VarChar {
+		return false
+	}
+	pkFieldID, err := loader.metaReplica.getPKFieldIDByCollectionID(segment.collectionID)
+	return err == nil && pkFieldID != fieldID
+}
+
+// loadDictionaryBinlogs loads a VarChar field from its binlogs if their payloads are all dictionary-encoded, which is
+// decided by the parquet footers before decoding them, e.g. the fields of the segments not compacted yet. The field is kept dictionary-encoded only if the dictionary
+// has at most common.binlog.dictionaryMaxCardinality values and is at most half as long as the field, otherwise
+// the decoded values are loaded, so that the binlogs are never decoded twice.
+// It returns false if the field is not loaded.
+func (loader *segmentLoader) loadDictionaryBinlogs(segment *Segment, fieldID int64, blobs []*storage.Blob, loadInfo *querypb.SegmentLoadInfo) (bool, error) {
+	if !loader.isDictionaryField(segment, fieldID) {
+		return false, nil
+	}
+	iCodec := storage.InsertCodec{}
+	data, err := iCodec.DeserializeDictionary(blobs)
+	if err != nil || data == nil {
+		return false, err
+	}
+	if int64(data.RowNum()) != loadInfo.GetNumOfRows() {
+		return false, fmt.Errorf("dictionary-encoded field has %d rows, but segment has %d rows", data.RowNum(), loadInfo.GetNumOfRows())
+	}
+
+	maxCardinality := storage.PayloadDictionaryMaxCardinality()
+	if half := int(loadInfo.GetNumOfRows() / 2); half < maxCardinality {
+		maxCardinality = half
+	}
+	if len(data.Dictionary) > maxCardinality {
+		insertData := &storage.InsertData{
+			Data: map[int64]storage.FieldData{
+				fieldID: &storage.StringFieldData{
+					NumRows: []int64{int64(data.RowNum())},
+					Data:    data.Decode(),
+				},
+			},
+		}
+		if err := loader.loadSealedSegments(segment, insertData); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if err := segment.segmentLoadDictionaryFieldData(fieldID, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// loadDictionaryField loads a VarChar field from its dictionary logs, which compaction saves as stats logs of the field,
+// one for each insert binlog. It returns false if the field is not completely dictionary-encoded.
+func (loader *segmentLoader) loadDictionaryField(ctx context.Context, segment *Segment, field *datapb.FieldBinlog, loadInfo *querypb.SegmentLoadInfo) (bool, error) {
+	var dictionaryLogs []string
+	for _, fieldBinlog := range loadInfo.GetStatslogs() {
+		if fieldBinlog.GetFieldID() != field.GetFieldID() {
+			continue
+		}
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			dictionaryLogs = append(dictionaryLogs, binlog.GetLogPath())
+		}
+	}
+	if len(dictionaryLogs) == 0 || len(dictionaryLogs) != len(field.GetBinlogs()) {
+		return false, nil
+	}
+	if !loader.isDictionaryField(segment, field.GetFieldID()) {
+		return false, nil
+	}
+
+	values, err := loader.cm.MultiRead(ctx, dictionaryLogs)
+	if err != nil {
+		return false, err
+	}
+	codec := storage.NewDictionaryCodec()
+	parts := make([]*storage.DictionaryEncodedStrings, 0, len(values))
+	for i, value := range values {
+		part, err := codec.Deserialize(&storage.Blob{Key: dictionaryLogs[i], Value: value})
+		if err != nil {
+			return false, err
+		}
+		parts = append(parts, part)
+	}
+
+	data := storage.MergeDictionaries(parts)
+	if int64(data.RowNum()) != loadInfo.GetNumOfRows() {
+		return false, fmt.Errorf("dictionary-encoded field has %d rows, but segment has %d rows", data.RowNum(), loadInfo.GetNumOfRows())
+	}
+
+	if err := segment.segmentLoadDictionaryFieldData(field.GetFieldID(), data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Load binlogs concurrently into pooled buffers from KV storage asyncly,
+// the value of a future is a *storage.PooledBuffer, which must be released by the caller.
 func (loader *segmentLoader) loadFieldBinlogsAsync(ctx context.Context, field *datapb.FieldBinlog) []*concurrency.Future {
 	futures := make([]*concurrency.Future, 0, len(field.Binlogs))
 	for i := range field.Binlogs {
 		path := field.Binlogs[i].GetLogPath()
 		future := loader.ioPool.Submit(func() (interface{}, error) {
-			binLog, err := loader.cm.Read(ctx, path)
+			buffer, err := storage.ReadPooled(ctx, loader.cm, path)
 			if err != nil {
 				log.Warn("failed to load binlog", zap.String("filePath", path), zap.Error(err))
 				return nil, err
 			}
-			blob := &storage.Blob{
-				Key:   path,
-				Value: binLog,
-			}
-
-			return blob, nil
+			return buffer, nil
 		})
 
 		futures = append(futures, future)
@@ -638,49 +836,57 @@ func (loader *segmentLoader) loadSegmentBloomFilter(ctx context.Context, segment
 	}
 
 	startTs := time.Now()
-	values, err := loader.cm.MultiRead(ctx, binlogPaths)
+	stats, err := storage.LoadPkStatistics(ctx, loader.cm, binlogPaths)
 	if err != nil {
+		log.Warn("failed to load pk stats", zap.Error(err))
 		return err
 	}
-	blobs := make([]*storage.Blob, 0)
-	for i := 0; i < len(values); i++ {
-		blobs = append(blobs, &storage.Blob{Value: values[i]})
-	}
-
-	stats, err := storage.DeserializeStats(blobs)
-	if err != nil {
-		log.Warn("failed to deserialize stats", zap.Error(err))
-		return err
+	if loader.pkStatsCache != nil && segment.getType() == segmentTypeSealed {
+		segment.setLazyPkStats(loader.pkStatsCache, binlogPaths, stats)
+		log.Info("pk stats will be loaded lazily", zap.Int64("segmentID", segment.segmentID), zap.Int("stats logs", len(binlogPaths)))
+		return nil
 	}
 	var size uint
 	for _, stat := range stats {
-		pkStat := &storage.PkStatistics{
-			PkFilter: stat.BF,
-			MinPK:    stat.MinPk,
-			MaxPK:    stat.MaxPk,
-		}
-		size += stat.BF.Cap()
-		segment.historyStats = append(segment.historyStats, pkStat)
+		size += stat.PkFilter.Cap()
 	}
+	segment.historyStats = append(segment.historyStats, stats...)
 	log.Info("Successfully load pk stats", zap.Any("time", time.Since(startTs)), zap.Int64("segment", segment.segmentID), zap.Uint("size", size))
 	return nil
 }
 
-func (loader *segmentLoader) loadDeltaLogs(ctx context.Context, segment *Segment, deltaLogs []*datapb.FieldBinlog) error {
-	dCodec := storage.DeleteCodec{}
-	var blobs []*storage.Blob
+func (loader *segmentLoader) loadDeltaLogs(ctx context.Context, segment *Segment, deltaLogs []*datapb.FieldBinlog, arena *storage.Arena) error {
+	var paths []string
+	var fieldIDs []int64
 	for _, deltaLog := range deltaLogs {
 		for _, bLog := range deltaLog.GetBinlogs() {
-			value, err := loader.cm.Read(ctx, bLog.GetLogPath())
-			if err != nil {
+			paths = append(paths, bLog.GetLogPath())
+			fieldIDs = append(fieldIDs, deltaLog.GetFieldID())
+		}
+	}
+	if len(paths) == 0 {
+		log.Info("there are no delta logs saved with segment, skip loading delete record", zap.Any("segmentID", segment.segmentID))
+		return nil
+	}
+	values, err := storage.MultiReadArena(ctx, loader.cm, paths, arena, loader.ioPool.Cap())
+	if err != nil {
+		return err
+	}
+
+	dCodec := storage.DeleteCodec{}
+	var blobs []*storage.Blob
+	for i, logPath := range paths {
+		blob := &storage.Blob{
+			Key:   logPath,
+			Value: values[i],
+		}
+		if fieldIDs[i] == common.DeletionVectorFieldID {
+			if err := loader.loadDeletionVector(segment, blob); err != nil {
 				return err
 			}
-			blob := &storage.Blob{
-				Key:   bLog.GetLogPath(),
-				Value: value,
-			}
-			blobs = append(blobs, blob)
+			continue
 		}
+		blobs = append(blobs, blob)
 	}
 	if len(blobs) == 0 {
 		log.Info("there are no delta logs saved with segment, skip loading delete record", zap.Any("segmentID", segment.segmentID))
@@ -698,6 +904,28 @@ func (loader *segmentLoader) loadDeltaLogs(ctx context.Context, segment *Segment
 	return nil
 }
 
+// loadDeletionVector applies a merged deletion vector to the segment. Sealed segments load it as a bitmap,
+// others fall back to replaying the embedded delete records.
+func (loader *segmentLoader) loadDeletionVector(segment *Segment, blob *storage.Blob) error {
+	dv, err := storage.NewDeletionVectorCodec().Deserialize(blob)
+	if err != nil {
+		return err
+	}
+	if dv.Count() == 0 {
+		return nil
+	}
+
+	deleteData := dv.DeleteData()
+	if segment.getType() != segmentTypeSealed {
+		return segment.segmentLoadDeletedRecord(deleteData.Pks, deleteData.Tss, deleteData.RowCount)
+	}
+	if dv.NumRows() != segment.getRowCount() {
+		return fmt.Errorf("deletion vector row count mismatch, segmentID = %d, expected = %d, actual = %d",
+			segment.segmentID, segment.getRowCount(), dv.NumRows())
+	}
+	return segment.segmentLoadDeletionVector(dv.Offsets(), deleteData.Tss)
+}
+
 func (loader *segmentLoader) FromDmlCPLoadDelete(ctx context.Context, collectionID int64, position *internalpb.MsgPosition,
 	segmentIDs []int64) error {
 	startTs := time.Now()
@@ -994,16 +1222,27 @@ func newSegmentLoader(
 		panic(err)
 	}
 
+	var pkStatsCache *storage.PkStatsCache
+	if Params.CommonCfg.BloomFilterLazyLoad {
+		pkStatsCache, err = storage.NewPkStatsCache(cm, Params.CommonCfg.BloomFilterCacheSize, Params.QueryNodeCfg.CacheValidateInterval)
+		if err != nil {
+			log.Error("failed to create pk stats cache for segment loader", zap.Error(err))
+			panic(err)
+		}
+	}
+
 	log.Info("SegmentLoader created",
 		zap.Int("ioPoolSize", ioPoolSize),
 		zap.Int("cpuPoolSize", cpuNum),
+		zap.Bool("lazyLoadPkStats", pkStatsCache != nil),
 	)
 
 	loader := &segmentLoader{
 		metaReplica: metaReplica,
 
-		cm:     cm,
-		etcdKV: etcdKV,
+		cm:           cm,
+		etcdKV:       etcdKV,
+		pkStatsCache: pkStatsCache,
 
 		// init them later
 		ioPool:  ioPool,
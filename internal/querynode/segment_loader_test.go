@@ -336,7 +336,7 @@ func TestSegmentLoader_invalid(t *testing.T) {
 		require.NoError(t, err)
 
 		cm := &mocks.ChunkManager{}
-		cm.EXPECT().Read(mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("mocked"))
+		cm.EXPECT().Reader(mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("mocked"))
 
 		loader.cm = cm
 		fieldPk := genPKFieldSchema(simpleInt64Field)
@@ -0,0 +1,171 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+// serviceableLagCheckInterval is the interval to refresh the serviceable lag of the channels,
+// the lag keeps growing between two tSafe updates if a channel is stuck.
+const serviceableLagCheckInterval = time.Second
+
+// lagMonitor is global serviceableLagMonitor in QueryNode.
+var lagMonitor = newServiceableLagMonitor()
+
+type channelServiceable struct {
+	collectionID UniqueID
+	tSafe        Timestamp
+}
+
+// serviceableLagMonitor exports the lag between now and the serviceable time of the channels,
+// and flags the collections whose lag exceeds queryNode.serviceableLag.sloThreshold.
+type serviceableLagMonitor struct {
+	mu       sync.Mutex
+	channels map[Channel]*channelServiceable
+	violated map[UniqueID]bool
+	// lags is the max lag among the channels of each collection, refreshed by check
+	lags map[UniqueID]time.Duration
+}
+
+func newServiceableLagMonitor() *serviceableLagMonitor {
+	return &serviceableLagMonitor{
+		channels: make(map[Channel]*channelServiceable),
+		violated: make(map[UniqueID]bool),
+		lags:     make(map[UniqueID]time.Duration),
+	}
+}
+
+// update records the serviceable time of the channel.
+func (m *serviceableLagMonitor) update(collectionID UniqueID, channel Channel, tSafe Timestamp) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.channels[channel] = &channelServiceable{collectionID: collectionID, tSafe: tSafe}
+}
+
+// remove removes the channel and cleans up its metrics.
+func (m *serviceableLagMonitor) remove(channel Channel) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cs, ok := m.channels[channel]
+	if !ok {
+		return
+	}
+	delete(m.channels, channel)
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	metrics.QueryNodeServiceableLag.DeleteLabelValues(nodeID, fmt.Sprint(cs.collectionID), channel)
+	for _, other := range m.channels {
+		if other.collectionID == cs.collectionID {
+			return
+		}
+	}
+	delete(m.violated, cs.collectionID)
+	delete(m.lags, cs.collectionID)
+	metrics.QueryNodeServiceableLagSLOViolated.DeleteLabelValues(nodeID, fmt.Sprint(cs.collectionID))
+}
+
+// check refreshes the lag of all channels and the SLO flags of the collections.
+func (m *serviceableLagMonitor) check(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	threshold := Params.QueryNodeCfg.ServiceableLagSLOThreshold
+
+	maxLags := make(map[UniqueID]time.Duration)
+	maxLagChannels := make(map[UniqueID]Channel)
+	for channel, cs := range m.channels {
+		p, _ := tsoutil.ParseTS(cs.tSafe)
+		lag := now.Sub(p)
+		metrics.QueryNodeServiceableLag.WithLabelValues(nodeID, fmt.Sprint(cs.collectionID), channel).Set(float64(lag.Milliseconds()))
+		if lag > maxLags[cs.collectionID] || maxLagChannels[cs.collectionID] == "" {
+			maxLags[cs.collectionID] = lag
+			maxLagChannels[cs.collectionID] = channel
+		}
+	}
+
+	m.lags = maxLags
+	for collectionID, lag := range maxLags {
+		violated := threshold > 0 && lag > threshold
+		if violated != m.violated[collectionID] {
+			if violated {
+				log.Warn("serviceable lag of collection exceeds the SLO threshold",
+					zap.Int64("collectionID", collectionID),
+					zap.String("channel", maxLagChannels[collectionID]),
+					zap.Duration("lag", lag),
+					zap.Duration("threshold", threshold))
+			} else {
+				log.Info("serviceable lag of collection recovered within the SLO threshold",
+					zap.Int64("collectionID", collectionID),
+					zap.Duration("lag", lag),
+					zap.Duration("threshold", threshold))
+			}
+		}
+		m.violated[collectionID] = violated
+		value := 0.0
+		if violated {
+			value = 1
+		}
+		metrics.QueryNodeServiceableLagSLOViolated.WithLabelValues(nodeID, fmt.Sprint(collectionID)).Set(value)
+	}
+}
+
+// violatedCollections returns the collections violating the SLO.
+func (m *serviceableLagMonitor) violatedCollections() []UniqueID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make([]UniqueID, 0)
+	for collectionID, violated := range m.violated {
+		if violated {
+			ret = append(ret, collectionID)
+		}
+	}
+	return ret
+}
+
+// collectionLags returns the serviceable lag of each collection, which is the max lag of its channels.
+func (m *serviceableLagMonitor) collectionLags() map[UniqueID]time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make(map[UniqueID]time.Duration, len(m.lags))
+	for collectionID, lag := range m.lags {
+		ret[collectionID] = lag
+	}
+	return ret
+}
+
+func (m *serviceableLagMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(serviceableLagCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.check(now)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/util/tsoutil"
+)
+
+func TestServiceableLagMonitor(t *testing.T) {
+	m := newServiceableLagMonitor()
+	threshold := Params.QueryNodeCfg.ServiceableLagSLOThreshold
+	defer func() { Params.QueryNodeCfg.ServiceableLagSLOThreshold = threshold }()
+	Params.QueryNodeCfg.ServiceableLagSLOThreshold = time.Second
+
+	now := time.Now()
+	m.update(1, "dml-0", tsoutil.ComposeTSByTime(now.Add(-100*time.Millisecond), 0))
+	m.update(1, "dml-1", tsoutil.ComposeTSByTime(now.Add(-200*time.Millisecond), 0))
+	m.update(2, "dml-2", tsoutil.ComposeTSByTime(now.Add(-100*time.Millisecond), 0))
+	m.check(now)
+	assert.Empty(t, m.violatedCollections())
+	assert.Equal(t, map[UniqueID]time.Duration{1: 200 * time.Millisecond, 2: 100 * time.Millisecond}, m.collectionLags())
+
+	// a stuck channel violates the SLO without tSafe updates
+	m.check(now.Add(2 * time.Second))
+	assert.ElementsMatch(t, []UniqueID{1, 2}, m.violatedCollections())
+
+	later := now.Add(2 * time.Second)
+	m.update(1, "dml-0", tsoutil.ComposeTSByTime(later, 0))
+	m.update(1, "dml-1", tsoutil.ComposeTSByTime(later, 0))
+	m.check(later)
+	assert.ElementsMatch(t, []UniqueID{2}, m.violatedCollections())
+
+	m.remove("dml-2")
+	assert.Empty(t, m.violatedCollections())
+	assert.NotContains(t, m.collectionLags(), UniqueID(2))
+	m.remove("not-exist")
+
+	// 0 disables the flag
+	Params.QueryNodeCfg.ServiceableLagSLOThreshold = 0
+	m.check(later.Add(time.Hour))
+	assert.Empty(t, m.violatedCollections())
+}
@@ -78,21 +78,25 @@ func (q *queryTask) queryOnStreaming() error {
 	}
 	defer plan.delete()
 
-	sResults, _, _, sErr := retrieveStreaming(ctx, q.QS.metaReplica, plan, q.CollectionID, q.iReq.GetPartitionIDs(), q.QS.channel, q.QS.vectorChunkManager)
+	budget := newRetrieveBudget(q.ID(), q.QS.localChunkManager)
+	defer budget.release(ctx)
+
+	sResults, _, _, sErr := retrieveStreaming(ctx, q.QS.metaReplica, plan, q.CollectionID, q.iReq.GetPartitionIDs(), q.QS.channel, q.QS.vectorChunkManager, budget)
 	if sErr != nil {
 		return sErr
 	}
 
 	q.tr.RecordSpan()
-	mergedResult, err := mergeSegcoreRetrieveResults(ctx, sResults, q.iReq.GetLimit())
+	mergedResult, err := budget.merge(ctx, sResults, q.iReq.GetLimit())
 	if err != nil {
 		return err
 	}
 
 	q.Ret = &internalpb.RetrieveResults{
-		Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
-		Ids:        mergedResult.Ids,
-		FieldsData: mergedResult.FieldsData,
+		Status:              &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Ids:                 mergedResult.Ids,
+		FieldsData:          mergedResult.FieldsData,
+		ChannelIDsRetrieved: []string{q.QS.channel},
 	}
 	q.reduceDur = q.tr.RecordSpan()
 	return nil
@@ -122,19 +126,24 @@ func (q *queryTask) queryOnHistorical() error {
 		return err
 	}
 	defer plan.delete()
-	retrieveResults, _, _, err := retrieveHistorical(ctx, q.QS.metaReplica, plan, q.CollectionID, nil, q.req.SegmentIDs, q.QS.vectorChunkManager)
+
+	budget := newRetrieveBudget(q.ID(), q.QS.localChunkManager)
+	defer budget.release(ctx)
+
+	retrieveResults, _, segmentIDs, err := retrieveHistorical(ctx, q.QS.metaReplica, plan, q.CollectionID, nil, q.req.SegmentIDs, q.QS.vectorChunkManager, budget)
 	if err != nil {
 		return err
 	}
 
-	mergedResult, err := mergeSegcoreRetrieveResults(ctx, retrieveResults, q.req.GetReq().GetLimit())
+	mergedResult, err := budget.merge(ctx, retrieveResults, q.req.GetReq().GetLimit())
 	if err != nil {
 		return err
 	}
 	q.Ret = &internalpb.RetrieveResults{
-		Status:     &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
-		Ids:        mergedResult.Ids,
-		FieldsData: mergedResult.FieldsData,
+		Status:                    &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Ids:                       mergedResult.Ids,
+		FieldsData:                mergedResult.FieldsData,
+		SealedSegmentIDsRetrieved: segmentIDs,
 	}
 
 	return nil
@@ -122,7 +122,11 @@ func (s *searchTask) searchOnStreaming() error {
 		return sErr
 	}
 	defer deleteSearchResults(partResults)
-	return s.reduceResults(ctx, searchReq, partResults)
+	if err := s.reduceResults(ctx, searchReq, partResults); err != nil {
+		return err
+	}
+	s.setSearchedScope(nil, s.req.GetDmlChannels()[:1])
+	return nil
 }
 
 func (s *searchTask) searchOnHistorical() error {
@@ -144,6 +148,23 @@ func (s *searchTask) searchOnHistorical() error {
 		return fmt.Errorf("retrieve failed, collection has been released, collectionID = %d", s.CollectionID)
 	}
 
+	// merged tasks have combined placeholder groups, only cache results of standalone tasks
+	cache := s.QS.searchResultCache
+	var cacheKey searchResultCacheKey
+	cacheable := false
+	if cache != nil && len(s.otherTasks) == 0 {
+		cacheKey, cacheable = buildSearchResultCacheKey(s.QS.metaReplica, s.req)
+	}
+	if cacheable {
+		if ret, searchedSegmentIDs, ok := cache.get(cacheKey); ok {
+			metrics.QueryNodeSearchResultCacheCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.CacheHitLabel).Inc()
+			s.Ret = ret
+			s.setSearchedScope(searchedSegmentIDs, nil)
+			return nil
+		}
+		metrics.QueryNodeSearchResultCacheCount.WithLabelValues(fmt.Sprint(paramtable.GetNodeID()), metrics.CacheMissLabel).Inc()
+	}
+
 	segmentIDs := s.req.GetSegmentIDs()
 	searchReq, err2 := newSearchRequest(s.QS.collection, s.req, s.PlaceholderGroup)
 	if err2 != nil {
@@ -151,12 +172,23 @@ func (s *searchTask) searchOnHistorical() error {
 	}
 	defer searchReq.delete()
 
-	partResults, _, _, err := searchHistorical(ctx, s.QS.metaReplica, searchReq, s.CollectionID, nil, segmentIDs)
+	partResults, _, searchedSegmentIDs, err := searchHistorical(ctx, s.QS.metaReplica, searchReq, s.CollectionID, nil, segmentIDs)
 	if err != nil {
 		return err
 	}
 	defer deleteSearchResults(partResults)
-	return s.reduceResults(ctx, searchReq, partResults)
+	if err := s.reduceResults(ctx, searchReq, partResults); err != nil {
+		return err
+	}
+	s.setSearchedScope(searchedSegmentIDs, nil)
+
+	// segments may change during the search, the result is cached only if they are still the same
+	if cacheable {
+		if key, ok := buildSearchResultCacheKey(s.QS.metaReplica, s.req); ok && key == cacheKey {
+			cache.put(cacheKey, s.Ret, searchedSegmentIDs)
+		}
+	}
+	return nil
 }
 
 func (s *searchTask) Execute(ctx context.Context) error {
@@ -284,6 +316,19 @@ func (s *searchTask) reduceResults(ctx context.Context, searchReq *searchRequest
 	return nil
 }
 
+// setSearchedScope records the sealed segments and the channels searched into the results of the task
+// and its merged tasks, which share the same search scope.
+func (s *searchTask) setSearchedScope(segmentIDs []UniqueID, channels []string) {
+	tasks := append([]*searchTask{s}, s.otherTasks...)
+	for _, t := range tasks {
+		if t.Ret == nil {
+			continue
+		}
+		t.Ret.SealedSegmentIDsSearched = segmentIDs
+		t.Ret.ChannelIDsSearched = channels
+	}
+}
+
 func (s *searchTask) CanMergeWith(t readTask) bool {
 	s2, ok := t.(*searchTask)
 	if !ok {
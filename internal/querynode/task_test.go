@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proto/datapb"
 	"github.com/milvus-io/milvus/internal/proto/internalpb"
 	"github.com/milvus-io/milvus/internal/proto/querypb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 func TestTask_watchDmChannelsTask(t *testing.T) {
@@ -118,6 +119,36 @@ func TestTask_watchDmChannelsTask(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("test execute on read-only node", func(t *testing.T) {
+		node, err := genSimpleQueryNode(ctx)
+		require.NoError(t, err)
+		defer node.Stop()
+
+		Params.QueryNodeCfg.ReadOnly = true
+		defer func() { Params.QueryNodeCfg.ReadOnly = false }()
+
+		task := watchDmChannelsTask{
+			req:  genWatchDMChannelsRequest(),
+			node: node,
+		}
+		task.req.Infos = []*datapb.VchannelInfo{
+			{
+				CollectionID: defaultCollectionID,
+				ChannelName:  defaultDMLChannel,
+			},
+		}
+		task.req.PartitionIDs = []UniqueID{0}
+		err = task.Execute(ctx)
+		assert.NoError(t, err)
+
+		// no flow graph consumes the channel, and requests never wait for the stream
+		_, err = node.dataSyncService.getFlowGraphByDMLChannel(defaultCollectionID, defaultDMLChannel)
+		assert.Error(t, err)
+		ts, err := node.tSafeReplica.getTSafe(defaultDMLChannel)
+		assert.NoError(t, err)
+		assert.Equal(t, typeutil.MaxTimestamp, ts)
+	})
+
 	t.Run("test execute loadPartition", func(t *testing.T) {
 		node, err := genSimpleQueryNode(ctx)
 		require.NoError(t, err)
@@ -0,0 +1,40 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+// pruneInvisibleSegments removes the sealed segments whose rows are all inserted after the travel timestamp,
+// according to the persisted timestamp indexes. Segments without timestamp index are kept.
+func pruneInvisibleSegments(replica ReplicaInterface, segType segmentType, ts Timestamp, segIDs []UniqueID) []UniqueID {
+	if ts == 0 || len(segIDs) == 0 {
+		return segIDs
+	}
+
+	result := make([]UniqueID, 0, len(segIDs))
+	for _, segID := range segIDs {
+		segment, err := replica.getSegmentByID(segID, segType)
+		if err != nil {
+			// leave it to the search / query of segments
+			result = append(result, segID)
+			continue
+		}
+		if index := segment.getTimestampIndex(); index != nil && index.MinTimestamp() > ts {
+			continue
+		}
+		result = append(result, segID)
+	}
+	return result
+}
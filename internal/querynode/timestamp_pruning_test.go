@@ -0,0 +1,47 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querynode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func TestPruneInvisibleSegments(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replica, err := genSimpleReplicaWithSealSegment(ctx)
+	assert.NoError(t, err)
+
+	segIDs := []UniqueID{defaultSegmentID, defaultSegmentID + 1}
+	// no timestamp index loaded
+	assert.Equal(t, segIDs, pruneInvisibleSegments(replica, segmentTypeSealed, 1, segIDs))
+
+	segment, err := replica.getSegmentByID(defaultSegmentID, segmentTypeSealed)
+	assert.NoError(t, err)
+	segment.timestampIndex = storage.BuildTimestampIndex([]int64{100, 101, 102})
+
+	assert.Equal(t, segIDs, pruneInvisibleSegments(replica, segmentTypeSealed, 0, segIDs))
+	assert.Equal(t, segIDs, pruneInvisibleSegments(replica, segmentTypeSealed, 100, segIDs))
+	// the segment not found is left to search
+	assert.Equal(t, []UniqueID{defaultSegmentID + 1}, pruneInvisibleSegments(replica, segmentTypeSealed, 99, segIDs))
+}
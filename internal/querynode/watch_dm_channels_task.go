@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/commonpbutil"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 )
 
 type watchDmChannelsTask struct {
@@ -111,6 +112,10 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) (err error) {
 		}
 	}()
 
+	if Params.QueryNodeCfg.ReadOnly {
+		return w.watchReadOnly(coll, lType, vChannels)
+	}
+
 	unFlushedSegmentIDs, err := w.LoadGrowingSegments(ctx, collectionID)
 
 	// remove growing segment if watch dmChannels failed
@@ -150,6 +155,37 @@ func (w *watchDmChannelsTask) Execute(ctx context.Context) (err error) {
 	return nil
 }
 
+// watchReadOnly sets up the channels on a read-only node, which never consumes the message stream,
+// so neither growing segments nor flow graphs are needed. The tSafe is fixed to the max timestamp,
+// requests are served by the loaded sealed segments without waiting for the stream.
+func (w *watchDmChannelsTask) watchReadOnly(coll *Collection, lType loadType, vChannels []Channel) error {
+	for _, partitionID := range w.req.GetLoadMeta().GetPartitionIDs() {
+		err := w.node.metaReplica.addPartition(coll.ID(), partitionID)
+		if err != nil {
+			return err
+		}
+	}
+	coll.setLoadType(lType)
+
+	for _, channel := range vChannels {
+		w.node.tSafeReplica.addTSafe(channel)
+		err := w.node.tSafeReplica.setTSafe(channel, typeutil.MaxTimestamp)
+		if err != nil {
+			return err
+		}
+	}
+	for _, dmlChannel := range vChannels {
+		w.node.queryShardService.addQueryShard(coll.ID(), dmlChannel, w.req.GetReplicaID())
+	}
+
+	log.Info("WatchDmChannels done on read-only node",
+		zap.Int64("collectionID", coll.ID()),
+		zap.Strings("vChannels", vChannels),
+		zap.Int64("replicaID", w.req.GetReplicaID()),
+	)
+	return nil
+}
+
 // PostExecute setup ShardCluster first version and without do gc if failed.
 func (w *watchDmChannelsTask) PostExecute(ctx context.Context) error {
 	// setup shard cluster version
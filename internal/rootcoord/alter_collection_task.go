@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
 	"go.uber.org/zap"
 
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
 )
 
@@ -37,8 +40,16 @@ func (a *alterCollectionTask) Execute(ctx context.Context) error {
 		return err
 	}
 
+	properties, err := keepShardRouting(oldColl.Properties, a.Req.GetProperties())
+	if err != nil {
+		log.Warn("failed to alter collection properties",
+			zap.String("collectionName", a.Req.GetCollectionName()), zap.Error(err))
+		return err
+	}
+	a.Req.Properties = properties
+
 	newColl := oldColl.Clone()
-	newColl.Properties = a.Req.GetProperties()
+	newColl.Properties = properties
 
 	ts := a.GetTs()
 	redoTask := newBaseRedoTask(a.core.stepExecutor)
@@ -65,3 +76,27 @@ func (a *alterCollectionTask) Execute(ctx context.Context) error {
 
 	return redoTask.Execute(ctx)
 }
+
+// keepShardRouting carries the shard routing of collection over the altered properties,
+// the routing can't be changed once the collection is created, or primary keys would be routed to other shards.
+func keepShardRouting(oldProperties, newProperties []*commonpb.KeyValuePair) ([]*commonpb.KeyValuePair, error) {
+	oldRouting, err := typeutil.GetShardRouting(oldProperties)
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range newProperties {
+		if kv.GetKey() == common.CollectionShardRoutingKey {
+			if kv.GetValue() != oldRouting {
+				return nil, fmt.Errorf("shard routing of collection can't be altered, current routing is %s", oldRouting)
+			}
+			return newProperties, nil
+		}
+	}
+	for _, kv := range oldProperties {
+		if kv.GetKey() == common.CollectionShardRoutingKey {
+			properties := common.CloneKeyValuePairs(newProperties)
+			return append(properties, &commonpb.KeyValuePair{Key: kv.GetKey(), Value: kv.GetValue()}), nil
+		}
+	}
+	return newProperties, nil
+}
@@ -141,3 +141,35 @@ func Test_alterCollectionTask_Execute(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func Test_keepShardRouting(t *testing.T) {
+	jumpHash := []*commonpb.KeyValuePair{
+		{Key: common.CollectionShardRoutingKey, Value: common.ShardRoutingJumpHash},
+	}
+	ttl := []*commonpb.KeyValuePair{
+		{Key: common.CollectionTTLConfigKey, Value: "3600"},
+	}
+
+	properties, err := keepShardRouting(nil, ttl)
+	assert.NoError(t, err)
+	assert.Equal(t, ttl, properties)
+
+	properties, err = keepShardRouting(jumpHash, ttl)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(properties))
+	assert.Equal(t, 1, len(ttl))
+	assert.Equal(t, common.CollectionShardRoutingKey, properties[1].GetKey())
+	assert.Equal(t, common.ShardRoutingJumpHash, properties[1].GetValue())
+
+	properties, err = keepShardRouting(jumpHash, jumpHash)
+	assert.NoError(t, err)
+	assert.Equal(t, jumpHash, properties)
+
+	_, err = keepShardRouting(nil, jumpHash)
+	assert.Error(t, err)
+
+	_, err = keepShardRouting(jumpHash, []*commonpb.KeyValuePair{
+		{Key: common.CollectionShardRoutingKey, Value: common.ShardRoutingModulo},
+	})
+	assert.Error(t, err)
+}
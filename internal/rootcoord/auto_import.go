@@ -0,0 +1,337 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus/internal/kv"
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutil"
+)
+
+const (
+	// autoImportSubPath is the kv path recording the ingested files and their ETags.
+	autoImportSubPath = "auto-import"
+	// autoImportMaxAttempts is the times to try importing a batch before giving it up.
+	autoImportMaxAttempts = 3
+	// autoImportRelistenInterval is the interval to listen the notifications again after a failure.
+	autoImportRelistenInterval = 5 * time.Second
+)
+
+var errAutoImportNotSupported = errors.New("auto import requires a storage supporting bucket notifications, e.g. MinIO")
+
+type autoImportFunc func(ctx context.Context, req *milvuspb.ImportRequest) (*milvuspb.ImportResponse, error)
+
+type autoImportFile struct {
+	storage.ObjectEvent
+	receivedAt time.Time
+}
+
+// autoImportBatch is the files imported by one import request.
+type autoImportBatch struct {
+	key        string
+	collection string
+	partition  string
+	rowBased   bool
+	files      []*autoImportFile
+}
+
+func (b *autoImportBatch) target() string {
+	return b.collection + "/" + b.partition
+}
+
+func (b *autoImportBatch) firstEventTime() time.Time {
+	first := b.files[0].EventTime
+	for _, f := range b.files[1:] {
+		if f.EventTime.Before(first) {
+			first = f.EventTime
+		}
+	}
+	return first
+}
+
+func (b *autoImportBatch) lastReceivedAt() time.Time {
+	last := b.files[0].receivedAt
+	for _, f := range b.files[1:] {
+		if f.receivedAt.After(last) {
+			last = f.receivedAt
+		}
+	}
+	return last
+}
+
+// autoImporter turns a bucket prefix into a drop folder, it listens the objects created under the prefix
+// and imports them into the collections named by their paths:
+//   - <prefix>/<collection>[/<partition>]/<file>.json is imported as a row-based file,
+//   - <prefix>/<collection>[/<partition>]/<batch>/<field>.npy files are imported together as a column-based batch.
+//
+// A file or batch is imported after it stays unchanged for the batch window, and batches of the same
+// collection partition are imported in the order they arrived. Ingested files are recorded with their ETags,
+// so re-delivered notifications and unchanged re-uploads are not imported twice.
+type autoImporter struct {
+	ctx        context.Context
+	cm         storage.ChunkManager
+	notifier   storage.ObjectNotifier
+	kv         kv.TxnKV
+	importFunc autoImportFunc
+	prefix     string
+	window     time.Duration
+
+	pending  map[string]*autoImportFile
+	attempts map[string]int
+}
+
+func newAutoImporter(ctx context.Context, cm storage.ChunkManager, kv kv.TxnKV, importFunc autoImportFunc, prefix string, window time.Duration) (*autoImporter, error) {
+	notifier, ok := storage.AsChunkManager[storage.ObjectNotifier](cm)
+	if !ok {
+		return nil, errAutoImportNotSupported
+	}
+	return &autoImporter{
+		ctx:        ctx,
+		cm:         cm,
+		notifier:   notifier,
+		kv:         kv,
+		importFunc: importFunc,
+		prefix:     strings.Trim(prefix, "/") + "/",
+		window:     window,
+		pending:    make(map[string]*autoImportFile),
+		attempts:   make(map[string]int),
+	}, nil
+}
+
+func (a *autoImporter) start(wg *sync.WaitGroup) {
+	defer wg.Done()
+	log.Info("start auto import", zap.String("prefix", a.prefix), zap.Duration("window", a.window))
+
+	interval := a.window / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	a.catchUp()
+	events := a.notifier.ListenObjectCreated(a.ctx, a.prefix, "")
+	var relisten <-chan time.Time
+	for {
+		select {
+		case <-a.ctx.Done():
+			log.Info("auto import exits")
+			return
+		case event, ok := <-events:
+			if !ok || event.Err != nil {
+				log.Warn("auto import stops listening bucket notifications, retry later", zap.Error(event.Err))
+				events = nil
+				relisten = time.After(autoImportRelistenInterval)
+				continue
+			}
+			a.add(event, time.Now())
+		case <-relisten:
+			relisten = nil
+			// files created while not listening are picked up by catching up
+			a.catchUp()
+			events = a.notifier.ListenObjectCreated(a.ctx, a.prefix, "")
+		case now := <-ticker.C:
+			a.flush(now)
+		}
+	}
+}
+
+// catchUp adds the existing files not ingested yet, which arrived while the notifications were missed.
+func (a *autoImporter) catchUp() {
+	var filePaths []string
+	err := a.cm.WalkWithPrefix(a.ctx, a.prefix, true, func(info storage.ChunkObjectInfo) bool {
+		filePaths = append(filePaths, strings.TrimPrefix(info.FilePath, "/"))
+		return true
+	})
+	if err != nil {
+		log.Warn("auto import failed to list existing files", zap.String("prefix", a.prefix), zap.Error(err))
+		return
+	}
+	now := time.Now()
+	for _, filePath := range filePaths {
+		info, err := a.cm.Stat(a.ctx, filePath)
+		if err != nil {
+			log.Warn("auto import failed to stat existing file", zap.String("file", filePath), zap.Error(err))
+			continue
+		}
+		if a.ingested(filePath, info.ETag) {
+			continue
+		}
+		a.add(storage.ObjectEvent{
+			FilePath:  filePath,
+			Size:      info.Size,
+			ETag:      info.ETag,
+			EventTime: info.ModifyTime,
+		}, now)
+	}
+}
+
+func (a *autoImporter) add(event storage.ObjectEvent, now time.Time) {
+	_, ext := importutil.GetFileNameAndExt(event.FilePath)
+	if ext != importutil.JSONFileExt && ext != importutil.NumpyFileExt {
+		return
+	}
+	a.pending[event.FilePath] = &autoImportFile{ObjectEvent: event, receivedAt: now}
+}
+
+func (a *autoImporter) ingestedKey(filePath string) string {
+	return path.Join(autoImportSubPath, filePath)
+}
+
+func (a *autoImporter) ingested(filePath string, etag string) bool {
+	v, err := a.kv.Load(a.ingestedKey(filePath))
+	return err == nil && v == etag
+}
+
+// batches groups the pending files by the import requests, files with invalid paths are dropped.
+func (a *autoImporter) batches() []*autoImportBatch {
+	batches := make(map[string]*autoImportBatch)
+	for filePath, f := range a.pending {
+		parts := strings.Split(strings.TrimPrefix(filePath, a.prefix), "/")
+		_, ext := importutil.GetFileNameAndExt(filePath)
+		batch := &autoImportBatch{key: filePath, rowBased: ext == importutil.JSONFileExt}
+		depth := len(parts)
+		if !batch.rowBased {
+			// the parent directory of numpy files is the batch
+			batch.key = path.Dir(filePath)
+			depth--
+		}
+		switch depth {
+		case 2:
+			batch.collection = parts[0]
+		case 3:
+			batch.collection, batch.partition = parts[0], parts[1]
+		default:
+			log.Warn("auto import ignores file out of the layout", zap.String("file", filePath))
+			delete(a.pending, filePath)
+			continue
+		}
+		if existing, ok := batches[batch.key]; ok {
+			batch = existing
+		} else {
+			batches[batch.key] = batch
+		}
+		batch.files = append(batch.files, f)
+	}
+
+	ret := make([]*autoImportBatch, 0, len(batches))
+	for _, batch := range batches {
+		sort.Slice(batch.files, func(i, j int) bool { return batch.files[i].FilePath < batch.files[j].FilePath })
+		ret = append(ret, batch)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		ti, tj := ret[i].firstEventTime(), ret[j].firstEventTime()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return ret[i].key < ret[j].key
+	})
+	return ret
+}
+
+// flush imports the batches unchanged for the batch window, in the order of arrival per collection partition.
+func (a *autoImporter) flush(now time.Time) {
+	blocked := make(map[string]bool)
+	for _, batch := range a.batches() {
+		if blocked[batch.target()] {
+			continue
+		}
+		if now.Sub(batch.lastReceivedAt()) < a.window {
+			// later batches of the same target wait for this one to keep the order
+			blocked[batch.target()] = true
+			continue
+		}
+		if !a.importBatch(batch) {
+			blocked[batch.target()] = true
+		}
+	}
+}
+
+// importBatch imports the batch and returns whether it's done with, either imported or given up.
+func (a *autoImporter) importBatch(batch *autoImportBatch) bool {
+	files := make([]string, 0, len(batch.files))
+	ingested := make(map[string]string, len(batch.files))
+	skip := true
+	for _, f := range batch.files {
+		files = append(files, f.FilePath)
+		ingested[a.ingestedKey(f.FilePath)] = f.ETag
+		skip = skip && a.ingested(f.FilePath, f.ETag)
+	}
+	done := func() bool {
+		for _, f := range files {
+			delete(a.pending, f)
+		}
+		delete(a.attempts, batch.key)
+		return true
+	}
+	if skip {
+		log.Info("auto import skips ingested files", zap.Strings("files", files))
+		return done()
+	}
+
+	req := &milvuspb.ImportRequest{
+		CollectionName: batch.collection,
+		PartitionName:  batch.partition,
+		RowBased:       batch.rowBased,
+		Files:          files,
+	}
+	resp, err := a.importFunc(a.ctx, req)
+	if err == nil && resp.GetStatus().GetErrorCode() != commonpb.ErrorCode_Success {
+		err = errors.New(resp.GetStatus().GetReason())
+	}
+	if err != nil {
+		a.attempts[batch.key]++
+		if a.attempts[batch.key] < autoImportMaxAttempts {
+			log.Warn("auto import failed, retry later",
+				zap.String("collection", batch.collection),
+				zap.String("partition", batch.partition),
+				zap.Strings("files", files),
+				zap.Int("attempts", a.attempts[batch.key]),
+				zap.Error(err))
+			return false
+		}
+		log.Error("auto import failed, give up the files",
+			zap.String("collection", batch.collection),
+			zap.String("partition", batch.partition),
+			zap.Strings("files", files),
+			zap.Error(err))
+		return done()
+	}
+
+	if err := a.kv.MultiSave(ingested); err != nil {
+		log.Warn("auto import failed to record ingested files, they may be imported again", zap.Strings("files", files), zap.Error(err))
+	}
+	log.Info("auto import files",
+		zap.String("collection", batch.collection),
+		zap.String("partition", batch.partition),
+		zap.Strings("files", files),
+		zap.Int64s("tasks", resp.GetTasks()))
+	return done()
+}
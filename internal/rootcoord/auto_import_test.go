@@ -0,0 +1,131 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rootcoord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	memkv "github.com/milvus-io/milvus/internal/kv/mem"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+type notifyingChunkManager struct {
+	storage.ChunkManager
+	events chan storage.ObjectEvent
+}
+
+func (cm *notifyingChunkManager) ListenObjectCreated(ctx context.Context, prefix string, suffix string) <-chan storage.ObjectEvent {
+	return cm.events
+}
+
+func TestAutoImporter(t *testing.T) {
+	ctx := context.Background()
+	local := storage.NewLocalChunkManager(storage.RootPath(t.TempDir()))
+	cm := &notifyingChunkManager{ChunkManager: local, events: make(chan storage.ObjectEvent)}
+
+	_, err := newAutoImporter(ctx, local, memkv.NewMemoryKV(), nil, "auto-import", time.Second)
+	assert.ErrorIs(t, err, errAutoImportNotSupported)
+
+	var requests []*milvuspb.ImportRequest
+	importFunc := func(ctx context.Context, req *milvuspb.ImportRequest) (*milvuspb.ImportResponse, error) {
+		requests = append(requests, req)
+		if req.GetCollectionName() == "bad" {
+			return nil, errors.New("collection not found")
+		}
+		return &milvuspb.ImportResponse{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}}, nil
+	}
+	window := 10 * time.Second
+	importer, err := newAutoImporter(ctx, cm, memkv.NewMemoryKV(), importFunc, "/auto-import/", window)
+	require.NoError(t, err)
+
+	t0 := time.Now()
+	event := func(filePath string, offset time.Duration) storage.ObjectEvent {
+		return storage.ObjectEvent{FilePath: filePath, ETag: "etag", EventTime: t0.Add(offset)}
+	}
+	importer.add(event("auto-import/c1/p1/b.json", time.Second), t0)
+	importer.add(event("auto-import/c1/a.json", 0), t0)
+	importer.add(event("auto-import/c2/batch/f2.npy", 0), t0)
+	importer.add(event("auto-import/c2/batch/f1.npy", 0), t0)
+	importer.add(event("auto-import/c1/readme.txt", 0), t0)
+	importer.add(event("auto-import/c3/a/b/c.json", 0), t0)
+	assert.Len(t, importer.pending, 5)
+
+	// not stable for the batch window yet
+	importer.flush(t0.Add(time.Second))
+	assert.Empty(t, requests)
+
+	// a later file of c2 batch delays the batch
+	importer.add(event("auto-import/c2/batch/f3.npy", 2*time.Second), t0.Add(2*time.Second))
+	importer.flush(t0.Add(window))
+	require.Len(t, requests, 2)
+	assert.Equal(t, "c1", requests[0].GetCollectionName())
+	assert.Equal(t, "", requests[0].GetPartitionName())
+	assert.Equal(t, []string{"auto-import/c1/a.json"}, requests[0].GetFiles())
+	assert.True(t, requests[0].GetRowBased())
+	assert.Equal(t, "p1", requests[1].GetPartitionName())
+	assert.Empty(t, importer.pending["auto-import/c3/a/b/c.json"])
+
+	importer.flush(t0.Add(window + 2*time.Second))
+	require.Len(t, requests, 3)
+	assert.Equal(t, "c2", requests[2].GetCollectionName())
+	assert.False(t, requests[2].GetRowBased())
+	assert.Equal(t, []string{"auto-import/c2/batch/f1.npy", "auto-import/c2/batch/f2.npy", "auto-import/c2/batch/f3.npy"}, requests[2].GetFiles())
+	assert.Empty(t, importer.pending)
+
+	// re-delivered notification is deduplicated, a changed file is imported again
+	importer.add(event("auto-import/c1/a.json", 0), t0)
+	importer.flush(t0.Add(window))
+	assert.Len(t, requests, 3)
+	changed := event("auto-import/c1/a.json", 0)
+	changed.ETag = "changed"
+	importer.add(changed, t0)
+	importer.flush(t0.Add(window))
+	assert.Len(t, requests, 4)
+
+	// failed imports are retried, and block the later batches of the same target
+	importer.add(event("auto-import/bad/a.json", 0), t0)
+	importer.add(event("auto-import/bad/b.json", time.Second), t0)
+	for i := 0; i < autoImportMaxAttempts; i++ {
+		importer.flush(t0.Add(window))
+	}
+	assert.Len(t, requests, 4+autoImportMaxAttempts)
+	for _, req := range requests[4:] {
+		assert.Equal(t, []string{"auto-import/bad/a.json"}, req.GetFiles())
+	}
+	assert.NotContains(t, importer.pending, "auto-import/bad/a.json")
+	assert.Contains(t, importer.pending, "auto-import/bad/b.json")
+	delete(importer.pending, "auto-import/bad/b.json")
+
+	// catch up the files arrived while not listening
+	require.NoError(t, local.Write(ctx, "auto-import/c4/a.json", []byte("{}")))
+	require.NoError(t, local.Write(ctx, "other/c4/a.json", []byte("{}")))
+	importer.catchUp()
+	assert.Len(t, importer.pending, 1)
+	assert.Contains(t, importer.pending, "auto-import/c4/a.json")
+	importer.flush(time.Now().Add(window))
+	assert.Equal(t, "c4", requests[len(requests)-1].GetCollectionName())
+	importer.catchUp()
+	assert.Empty(t, importer.pending)
+}
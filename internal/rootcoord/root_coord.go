@@ -129,6 +129,7 @@ type Core struct {
 	factory dependency.Factory
 
 	importManager *importManager
+	autoImporter  *autoImporter
 
 	enableActiveStandBy bool
 	activateFunc        func()
@@ -432,6 +433,20 @@ func (c *Core) initImportManager() error {
 	return nil
 }
 
+func (c *Core) initAutoImporter() error {
+	ingestedKv, err := c.metaKVCreator(Params.EtcdCfg.KvRootPath.GetValue())
+	if err != nil {
+		return err
+	}
+	cm, err := c.factory.NewPersistentStorageChunkManager(c.ctx)
+	if err != nil {
+		return err
+	}
+	c.autoImporter, err = newAutoImporter(c.ctx, cm, ingestedKv, c.Import,
+		Params.RootCoordCfg.AutoImportPrefix, Params.RootCoordCfg.AutoImportBatchWindow)
+	return err
+}
+
 func (c *Core) initInternal() error {
 	if err := c.initSession(); err != nil {
 		return err
@@ -482,6 +497,12 @@ func (c *Core) initInternal() error {
 		return err
 	}
 
+	if Params.RootCoordCfg.AutoImportEnabled {
+		if err := c.initAutoImporter(); err != nil {
+			return err
+		}
+	}
+
 	if err := c.initCredentials(); err != nil {
 		return err
 	}
@@ -631,6 +652,10 @@ func (c *Core) startInternal() error {
 	go c.importManager.cleanupLoop(&c.wg)
 	go c.importManager.sendOutTasksLoop(&c.wg)
 	go c.importManager.flipTaskStateLoop(&c.wg)
+	if c.autoImporter != nil {
+		c.wg.Add(1)
+		go c.autoImporter.start(&c.wg)
+	}
 	Params.RootCoordCfg.CreatedTime = time.Now()
 	Params.RootCoordCfg.UpdatedTime = time.Now()
 
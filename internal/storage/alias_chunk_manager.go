@@ -0,0 +1,377 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// AliasChunkManager makes the keys under the root path and the legacy root paths aliases of each other,
+// so that renaming the root path doesn't require migrating the data up front. The objects written before
+// the rename are read from the legacy root paths, and the keys kept in the meta with the legacy root paths
+// are still readable after the objects are moved to the root path by Rekey.
+//
+// Only the reads fall back to the aliases of the key, the writes and listings are issued as they are,
+// and the removals remove all aliases of the key.
+type AliasChunkManager struct {
+	ChunkManager
+	rootPath        string
+	legacyRootPaths []string
+	concurrency     int
+}
+
+var _ ChunkManager = (*AliasChunkManager)(nil)
+var _ Prefetcher = (*AliasChunkManager)(nil)
+var _ ConditionalReader = (*AliasChunkManager)(nil)
+
+// NewAliasChunkManager returns a ChunkManager reading the objects of @cm not found under the root path
+// from the root paths set by the LegacyRootPaths option.
+func NewAliasChunkManager(cm ChunkManager, opts ...Option) *AliasChunkManager {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	rootPath := strings.Trim(c.rootPath, "/")
+	legacyRootPaths := make([]string, 0, len(c.legacyRootPaths))
+	for _, legacyRootPath := range c.legacyRootPaths {
+		legacyRootPath = strings.Trim(legacyRootPath, "/")
+		if legacyRootPath != "" && legacyRootPath != rootPath {
+			legacyRootPaths = append(legacyRootPaths, legacyRootPath)
+		}
+	}
+	return &AliasChunkManager{
+		ChunkManager:    cm,
+		rootPath:        rootPath,
+		legacyRootPaths: legacyRootPaths,
+		concurrency:     c.concurrency,
+	}
+}
+
+// Unwrap returns the wrapped chunk manager.
+func (a *AliasChunkManager) Unwrap() ChunkManager {
+	return a.ChunkManager
+}
+
+// Aliases returns the aliases of @filePath, the root path first, then the legacy root paths in order.
+// The key itself is not included, and nil is returned if it's out of all the root paths.
+func (a *AliasChunkManager) Aliases(filePath string) []string {
+	// the longest root path matched wins, since a root path may be nested in another one
+	root, matched := "", false
+	for _, rootPath := range append([]string{a.rootPath}, a.legacyRootPaths...) {
+		if (rootPath == "" || filePath == rootPath || strings.HasPrefix(filePath, rootPath+"/")) &&
+			(!matched || len(rootPath) > len(root)) {
+			root, matched = rootPath, true
+		}
+	}
+	if !matched {
+		return nil
+	}
+	rel := strings.TrimPrefix(strings.TrimPrefix(filePath, root), "/")
+	aliases := make([]string, 0, len(a.legacyRootPaths))
+	for _, rootPath := range append([]string{a.rootPath}, a.legacyRootPaths...) {
+		if rootPath != root {
+			aliases = append(aliases, joinRootPath(rootPath, rel))
+		}
+	}
+	return aliases
+}
+
+// joinRootPath joins @rel to @rootPath, keeping the trailing slash of @rel, which matters for prefix matching.
+func joinRootPath(rootPath string, rel string) string {
+	if rootPath == "" {
+		return rel
+	}
+	if rel == "" {
+		return rootPath
+	}
+	return rootPath + "/" + rel
+}
+
+// withAliases calls @fn with @filePath, then its aliases in order until @fn returns an error other than ErrNoSuchKey.
+func (a *AliasChunkManager) withAliases(filePath string, fn func(filePath string) error) error {
+	err := fn(filePath)
+	if !errors.Is(err, ErrNoSuchKey) {
+		return err
+	}
+	for _, alias := range a.Aliases(filePath) {
+		if aliasErr := fn(alias); !errors.Is(aliasErr, ErrNoSuchKey) {
+			return aliasErr
+		}
+	}
+	return err
+}
+
+// Prefetch passes the hint to the wrapped chunk manager, the objects are read from the current root path first.
+func (a *AliasChunkManager) Prefetch(ctx context.Context, filePaths []string) {
+	Prefetch(ctx, a.ChunkManager, filePaths)
+}
+
+func (a *AliasChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	var ret string
+	err := a.withAliases(filePath, func(filePath string) error {
+		var err error
+		ret, err = a.ChunkManager.Path(ctx, filePath)
+		return err
+	})
+	return ret, err
+}
+
+func (a *AliasChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	var ret int64
+	err := a.withAliases(filePath, func(filePath string) error {
+		var err error
+		ret, err = a.ChunkManager.Size(ctx, filePath)
+		return err
+	})
+	return ret, err
+}
+
+// Stat stats @filePath or its first alias existing, the file path of the returned info is the one found.
+func (a *AliasChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	var ret ObjectInfo
+	err := a.withAliases(filePath, func(filePath string) error {
+		var err error
+		ret, err = a.ChunkManager.Stat(ctx, filePath)
+		return err
+	})
+	return ret, err
+}
+
+// MultiStat stats @filePaths by the wrapped chunk manager, then the aliases of the ones not found one by one.
+func (a *AliasChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	infos, err := a.ChunkManager.MultiStat(ctx, filePaths)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	var indexes []int
+	for i, info := range infos {
+		if info == nil && len(a.Aliases(filePaths[i])) > 0 {
+			missing = append(missing, filePaths[i])
+			indexes = append(indexes, i)
+		}
+	}
+	if len(missing) == 0 {
+		return infos, nil
+	}
+	found, err := parallelMultiStat(ctx, missing, a.concurrency, a.Stat)
+	if err != nil {
+		return nil, err
+	}
+	for i, info := range found {
+		infos[indexes[i]] = info
+	}
+	return infos, nil
+}
+
+func (a *AliasChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	exist, err := a.ChunkManager.Exist(ctx, filePath)
+	if err != nil || exist {
+		return exist, err
+	}
+	for _, alias := range a.Aliases(filePath) {
+		exist, err = a.ChunkManager.Exist(ctx, alias)
+		if err != nil || exist {
+			return exist, err
+		}
+	}
+	return false, nil
+}
+
+func (a *AliasChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	var ret []byte
+	err := a.withAliases(filePath, func(filePath string) error {
+		var err error
+		ret, err = a.ChunkManager.Read(ctx, filePath)
+		return err
+	})
+	return ret, err
+}
+
+// ReadIfModified reads @filePath or its first alias existing unless its ETag is still @etag, see ConditionalReader.
+func (a *AliasChunkManager) ReadIfModified(ctx context.Context, filePath string, etag string) ([]byte, string, error) {
+	var ret []byte
+	var retETag string
+	err := a.withAliases(filePath, func(filePath string) error {
+		var err error
+		ret, retETag, err = ReadIfModified(ctx, a.ChunkManager, filePath, etag)
+		return err
+	})
+	return ret, retETag, err
+}
+
+func (a *AliasChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	var ret FileReader
+	err := a.withAliases(filePath, func(filePath string) error {
+		var err error
+		ret, err = a.ChunkManager.Reader(ctx, filePath)
+		return err
+	})
+	return ret, err
+}
+
+// MultiRead reads @filePaths by the wrapped chunk manager, they are read one by one with their aliases if it fails,
+// since the errors of the paths not found are aggregated with the others.
+func (a *AliasChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	contents, err := a.ChunkManager.MultiRead(ctx, filePaths)
+	if err == nil {
+		return contents, nil
+	}
+	for _, filePath := range filePaths {
+		if len(a.Aliases(filePath)) > 0 {
+			return parallelMultiRead(ctx, filePaths, a.concurrency, a.Read)
+		}
+	}
+	return nil, err
+}
+
+func (a *AliasChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	filePaths, _, err := a.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := a.MultiRead(ctx, filePaths)
+	return filePaths, result, err
+}
+
+func (a *AliasChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	var ret []byte
+	err := a.withAliases(filePath, func(filePath string) error {
+		var err error
+		ret, err = a.ChunkManager.ReadAt(ctx, filePath, off, length)
+		return err
+	})
+	return ret, err
+}
+
+func (a *AliasChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	var ret [][]byte
+	err := a.withAliases(filePath, func(filePath string) error {
+		var err error
+		ret, err = a.ChunkManager.MultiReadAt(ctx, filePath, ranges)
+		return err
+	})
+	return ret, err
+}
+
+// PresignURL presigns the first one existing of @filePath and its aliases, or @filePath if none exists.
+func (a *AliasChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	if aliases := a.Aliases(filePath); len(aliases) > 0 {
+		exist, err := a.ChunkManager.Exist(ctx, filePath)
+		for i := 0; err == nil && !exist && i < len(aliases); i++ {
+			if exist, err = a.ChunkManager.Exist(ctx, aliases[i]); exist {
+				filePath = aliases[i]
+			}
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return a.ChunkManager.PresignURL(ctx, filePath, method, expiry)
+}
+
+func (a *AliasChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	return a.withAliases(srcFilePath, func(srcFilePath string) error {
+		return a.ChunkManager.Copy(ctx, srcFilePath, dstFilePath)
+	})
+}
+
+func (a *AliasChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	return a.withAliases(srcFilePath, func(srcFilePath string) error {
+		return a.ChunkManager.Move(ctx, srcFilePath, dstFilePath)
+	})
+}
+
+// Remove removes @filePath and all its aliases.
+func (a *AliasChunkManager) Remove(ctx context.Context, filePath string) error {
+	return a.ChunkManager.MultiRemove(ctx, append([]string{filePath}, a.Aliases(filePath)...))
+}
+
+// MultiRemove removes @filePaths and all their aliases.
+func (a *AliasChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	all := make([]string, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		all = append(all, filePath)
+		all = append(all, a.Aliases(filePath)...)
+	}
+	return a.ChunkManager.MultiRemove(ctx, all)
+}
+
+// RemoveWithPrefix removes the objects under @prefix and the aliases of the prefix.
+func (a *AliasChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	for _, p := range append([]string{prefix}, a.Aliases(prefix)...) {
+		if err := a.ChunkManager.RemoveWithPrefix(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rekey moves the objects under the legacy root paths to the same keys under the root path, and returns
+// the number of the objects moved. The objects already existing under the root path are not overwritten,
+// the legacy ones are removed instead. Every object is kept readable by its aliases during the move,
+// since it's copied before the legacy one is removed.
+func (a *AliasChunkManager) Rekey(ctx context.Context) (int, error) {
+	moved := 0
+	for _, legacyRootPath := range a.legacyRootPaths {
+		var err error
+		walkErr := a.ChunkManager.WalkWithPrefix(ctx, legacyRootPath+"/", true, func(chunkObjectInfo ChunkObjectInfo) bool {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+			rel := strings.TrimPrefix(strings.TrimPrefix(chunkObjectInfo.FilePath, "/"), legacyRootPath+"/")
+			if err = a.moveObject(ctx, chunkObjectInfo.FilePath, path.Join(a.rootPath, rel)); err != nil {
+				return false
+			}
+			moved++
+			return true
+		})
+		if err == nil {
+			err = walkErr
+		}
+		if err != nil {
+			return moved, err
+		}
+	}
+	if moved > 0 {
+		log.Info("rekey objects of legacy root paths", zap.Strings("legacyRootPaths", a.legacyRootPaths),
+			zap.String("rootPath", a.rootPath), zap.Int("objects", moved))
+	}
+	return moved, nil
+}
+
+// moveObject copies @srcFilePath to @dstFilePath if it doesn't exist, then removes @srcFilePath.
+func (a *AliasChunkManager) moveObject(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	exist, err := a.ChunkManager.Exist(ctx, dstFilePath)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		if err := a.ChunkManager.Copy(ctx, srcFilePath, dstFilePath); err != nil {
+			return err
+		}
+	}
+	return a.ChunkManager.Remove(ctx, srcFilePath)
+}
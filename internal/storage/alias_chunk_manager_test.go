@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliasChunkManager_Aliases(t *testing.T) {
+	a := NewAliasChunkManager(nil, RootPath("data"), LegacyRootPaths("/files/", "data", "", "files/old"))
+	assert.Equal(t, []string{"files/a/b", "files/old/a/b"}, a.Aliases("data/a/b"))
+	assert.Equal(t, []string{"data/a/b", "files/old/a/b"}, a.Aliases("files/a/b"))
+	// the longest root path wins
+	assert.Equal(t, []string{"data/a/", "files/a/"}, a.Aliases("files/old/a/"))
+	assert.Nil(t, a.Aliases("other/a/b"))
+	assert.Nil(t, a.Aliases("database/a"))
+}
+
+func TestAliasChunkManager(t *testing.T) {
+	ctx := context.Background()
+	lcm := NewLocalChunkManager(RootPath(t.TempDir()))
+	a := NewAliasChunkManager(lcm, RootPath("data"), LegacyRootPaths("files"))
+
+	require.NoError(t, lcm.Write(ctx, "files/insert_log/1", []byte("legacy")))
+	require.NoError(t, lcm.Write(ctx, "files/insert_log/2", []byte("legacy")))
+	require.NoError(t, lcm.Write(ctx, "data/insert_log/2", []byte("current")))
+
+	// the keys of the root path fall back to the legacy root path
+	content, err := a.Read(ctx, "data/insert_log/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("legacy"), content)
+	content, err = a.Read(ctx, "data/insert_log/2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("current"), content)
+	contents, err := a.MultiRead(ctx, []string{"data/insert_log/1", "data/insert_log/2"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("legacy"), []byte("current")}, contents)
+	exist, err := a.Exist(ctx, "data/insert_log/1")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	infos, err := a.MultiStat(ctx, []string{"data/insert_log/1", "data/insert_log/3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "files/insert_log/1", infos[0].FilePath)
+	assert.Nil(t, infos[1])
+	_, err = a.Read(ctx, "data/insert_log/3")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+
+	moved, err := a.Rekey(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, moved)
+	filePaths, _, err := lcm.ListWithPrefix(ctx, "files/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, filePaths)
+
+	// the existing objects of the root path are not overwritten, and the legacy keys are still readable
+	content, err = a.Read(ctx, "data/insert_log/2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("current"), content)
+	content, err = a.Read(ctx, "files/insert_log/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("legacy"), content)
+
+	// all aliases are removed
+	require.NoError(t, lcm.Write(ctx, "files/insert_log/1", []byte("legacy")))
+	require.NoError(t, a.Remove(ctx, "files/insert_log/1"))
+	for _, filePath := range []string{"files/insert_log/1", "data/insert_log/1"} {
+		exist, err = lcm.Exist(ctx, filePath)
+		assert.NoError(t, err)
+		assert.False(t, exist)
+	}
+}
@@ -0,0 +1,157 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	// arenaSlabSize is the size of the slabs backing the allocations of the arenas.
+	arenaSlabSize = 8 << 20
+	// arenaMaxSlabAlloc is the max size served by the slabs,
+	// the larger allocations are made separately so that they don't waste the rest of the slabs.
+	arenaMaxSlabAlloc = arenaSlabSize / 4
+)
+
+var arenaSlabPool = sync.Pool{
+	New: func() interface{} {
+		slab := make([]byte, arenaSlabSize)
+		return &slab
+	},
+}
+
+// Arena allocates the byte slices of many small objects from a few large slabs,
+// all of them are freed together by Release, e.g. once a segment is loaded.
+// The slices must not be accessed after the arena is released.
+type Arena struct {
+	mu        sync.Mutex
+	slabs     []*[]byte
+	free      []byte // the unallocated part of the last slab
+	allocated int64
+	released  bool
+}
+
+// NewArena creates an empty arena, the slabs are allocated on demand.
+func NewArena() *Arena {
+	return &Arena{}
+}
+
+// Alloc returns a slice of @size bytes, its content is undefined.
+func (a *Arena) Alloc(size int) []byte {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.released {
+		panic("alloc from a released arena")
+	}
+	a.allocated += int64(size)
+	if size > arenaMaxSlabAlloc {
+		return make([]byte, size)
+	}
+	if len(a.free) < size {
+		slab := arenaSlabPool.Get().(*[]byte)
+		a.slabs = append(a.slabs, slab)
+		a.free = *slab
+	}
+	buf := a.free[:size:size]
+	a.free = a.free[size:]
+	return buf
+}
+
+// Allocated returns the bytes allocated from the arena.
+func (a *Arena) Allocated() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.allocated
+}
+
+// Release puts the slabs back to the pool, it's safe to release an arena more than once.
+func (a *Arena) Release() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.released {
+		return
+	}
+	for _, slab := range a.slabs {
+		arenaSlabPool.Put(slab)
+	}
+	a.slabs = nil
+	a.free = nil
+	a.released = true
+}
+
+// MultiReadArena reads @filePaths of @cm into the slices allocated from @arena with at most @concurrency goroutines,
+// the results keep the order of @filePaths and live until the arena is released.
+func MultiReadArena(ctx context.Context, cm ChunkManager, filePaths []string, arena *Arena, concurrency int) ([][]byte, error) {
+	infos, err := cm.MultiStat(ctx, filePaths)
+	if err != nil {
+		return nil, err
+	}
+	bufs := make([][]byte, len(filePaths))
+	for i, info := range infos {
+		if info == nil {
+			return nil, WrapErrNoSuchKey(filePaths[i])
+		}
+		bufs[i] = arena.Alloc(int(info.Size))
+	}
+
+	indexes := make([]int, len(filePaths))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	_, err = parallelMultiDo(ctx, indexes, concurrency, func(ctx context.Context, i int) (struct{}, error) {
+		return struct{}{}, readFullInto(ctx, cm, filePaths[i], bufs[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bufs, nil
+}
+
+// ReadWithPrefixArena is ReadWithPrefix whose contents are allocated from @arena.
+func ReadWithPrefixArena(ctx context.Context, cm ChunkManager, prefix string, arena *Arena, concurrency int) ([]string, [][]byte, error) {
+	filePaths, _, err := cm.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := MultiReadArena(ctx, cm, filePaths, arena, concurrency)
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, values, nil
+}
+
+// readFullInto reads the object @filePath, which must be of len(buf) bytes, into @buf.
+func readFullInto(ctx context.Context, cm ChunkManager, filePath string, buf []byte) error {
+	reader, err := cm.Reader(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if _, err := io.ReadFull(reader, buf); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return WrapErrNoSuchKey(filePath)
+		}
+		return fmt.Errorf("failed to read %s of %d bytes: %w", filePath, len(buf), err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArena(t *testing.T) {
+	arena := NewArena()
+
+	a := arena.Alloc(16)
+	b := arena.Alloc(16)
+	assert.Len(t, a, 16)
+	assert.Equal(t, 16, cap(a))
+	// allocated from the same slab
+	assert.Len(t, arena.slabs, 1)
+	copy(a, "aaaaaaaaaaaaaaaa")
+	copy(b, "bbbbbbbbbbbbbbbb")
+	assert.Equal(t, "aaaaaaaaaaaaaaaa", string(a))
+
+	// a new slab is taken if the rest of the current one isn't enough
+	arena.Alloc(arenaMaxSlabAlloc)
+	arena.Alloc(arenaMaxSlabAlloc)
+	arena.Alloc(arenaMaxSlabAlloc)
+	arena.Alloc(arenaMaxSlabAlloc)
+	assert.Len(t, arena.slabs, 2)
+
+	// large allocations don't use the slabs
+	large := arena.Alloc(arenaSlabSize)
+	assert.Len(t, large, arenaSlabSize)
+	assert.Len(t, arena.slabs, 2)
+
+	assert.Equal(t, int64(32+4*arenaMaxSlabAlloc+arenaSlabSize), arena.Allocated())
+
+	arena.Release()
+	arena.Release()
+	assert.Nil(t, arena.slabs)
+	assert.Panics(t, func() {
+		arena.Alloc(1)
+	})
+}
+
+func TestMultiReadArena(t *testing.T) {
+	ctx := context.Background()
+	testRoot := "arena"
+	cm := NewLocalChunkManager(RootPath(localPath))
+	defer cm.RemoveWithPrefix(ctx, testRoot)
+
+	contents := map[string][]byte{
+		path.Join(testRoot, "prefix", "key1"): []byte("value1"),
+		path.Join(testRoot, "prefix", "key2"): []byte("value22"),
+		path.Join(testRoot, "prefix", "key3"): {},
+	}
+	require.NoError(t, cm.MultiWrite(ctx, contents))
+
+	arena := NewArena()
+	defer arena.Release()
+
+	keys := []string{path.Join(testRoot, "prefix", "key2"), path.Join(testRoot, "prefix", "key1")}
+	values, err := MultiReadArena(ctx, cm, keys, arena, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value22"), []byte("value1")}, values)
+	assert.Equal(t, int64(13), arena.Allocated())
+
+	_, err = MultiReadArena(ctx, cm, []string{path.Join(testRoot, "not_exist")}, arena, 2)
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+
+	filePaths, values, err := ReadWithPrefixArena(ctx, cm, path.Join(testRoot, "prefix"), arena, 2)
+	assert.NoError(t, err)
+	assert.Len(t, filePaths, 3)
+	for i, filePath := range filePaths {
+		assert.Equal(t, string(contents[filePath]), string(values[i]))
+	}
+}
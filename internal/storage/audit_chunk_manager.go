@@ -0,0 +1,265 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	lumberjack "gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/trace"
+)
+
+// AuditOp is a destructive storage operation recorded by AuditChunkManager.
+type AuditOp string
+
+const (
+	AuditOpRemove           AuditOp = "Remove"
+	AuditOpRemoveWithPrefix AuditOp = "RemoveWithPrefix"
+	// AuditOpMove is recorded for the source removed by Move
+	AuditOpMove AuditOp = "Move"
+	// AuditOpOverwrite is recorded for the writes, copies and moves replacing an existing object
+	AuditOpOverwrite AuditOp = "Overwrite"
+)
+
+// AuditRecord records a destructive storage operation.
+type AuditRecord struct {
+	Time      time.Time `json:"time"`
+	Component string    `json:"component"`
+	Op        AuditOp   `json:"op"`
+	// Key is the prefix for RemoveWithPrefix
+	Key     string `json:"key"`
+	TraceID string `json:"traceID,omitempty"`
+	// Error is the error of the operation, the record is written whether the operation succeeds or not
+	Error string `json:"error,omitempty"`
+}
+
+// AuditSink persists the audit records, it must be safe for concurrent use.
+type AuditSink interface {
+	Record(records ...AuditRecord)
+}
+
+// FileAuditSink writes the audit records into a local file as JSON lines, the file is rotated by size.
+type FileAuditSink struct {
+	mu     sync.Mutex
+	logger *lumberjack.Logger
+}
+
+var _ AuditSink = (*FileAuditSink)(nil)
+
+// NewFileAuditSink returns a FileAuditSink writing @filePath, which is rotated once it reaches @maxSizeMB.
+func NewFileAuditSink(filePath string, maxSizeMB int) *FileAuditSink {
+	return &FileAuditSink{
+		logger: &lumberjack.Logger{
+			Filename:  filePath,
+			MaxSize:   maxSizeMB,
+			LocalTime: true,
+		},
+	}
+}
+
+func (s *FileAuditSink) Record(records ...AuditRecord) {
+	var buf []byte
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			log.Warn("failed to marshal storage audit record", zap.String("key", record.Key), zap.Error(err))
+			continue
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.logger.Write(buf); err != nil {
+		log.RatedWarn(10, "failed to write storage audit records", zap.String("path", s.logger.Filename), zap.Error(err))
+	}
+}
+
+// Close closes the file.
+func (s *FileAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logger.Close()
+}
+
+var (
+	fileAuditSinksMu sync.Mutex
+	// fileAuditSinks are shared by the chunk managers of a process by their file paths,
+	// as a file must only be written and rotated by one FileAuditSink
+	fileAuditSinks = make(map[string]*FileAuditSink)
+)
+
+func getFileAuditSink(filePath string, maxSizeMB int) *FileAuditSink {
+	fileAuditSinksMu.Lock()
+	defer fileAuditSinksMu.Unlock()
+	sink, ok := fileAuditSinks[filePath]
+	if !ok {
+		sink = NewFileAuditSink(filePath, maxSizeMB)
+		fileAuditSinks[filePath] = sink
+	}
+	return sink
+}
+
+// AuditChunkManager records the destructive operations on the wrapped ChunkManager into the AuditSink,
+// with the component issuing them and the trace id of the context, so that the removal of the binlogs of
+// a segment could be traced back to the request or the task issuing it.
+//
+// The writes, copies and moves replacing existing objects are recorded only if overwrite auditing is enabled,
+// as it stats the target before every one of them.
+type AuditChunkManager struct {
+	ChunkManager
+	sink      AuditSink
+	component string
+	overwrite bool
+}
+
+var _ ChunkManager = (*AuditChunkManager)(nil)
+var _ Prefetcher = (*AuditChunkManager)(nil)
+var _ ConditionalReader = (*AuditChunkManager)(nil)
+
+// NewAuditChunkManager returns a ChunkManager recording the destructive operations on @cm of @component into @sink.
+func NewAuditChunkManager(cm ChunkManager, sink AuditSink, component string, overwrite bool) *AuditChunkManager {
+	return &AuditChunkManager{
+		ChunkManager: cm,
+		sink:         sink,
+		component:    component,
+		overwrite:    overwrite,
+	}
+}
+
+// Unwrap returns the wrapped chunk manager.
+func (a *AuditChunkManager) Unwrap() ChunkManager {
+	return a.ChunkManager
+}
+
+// Prefetch passes the hint to the wrapped chunk manager.
+func (a *AuditChunkManager) Prefetch(ctx context.Context, filePaths []string) {
+	Prefetch(ctx, a.ChunkManager, filePaths)
+}
+
+// ReadIfModified passes the conditional read to the wrapped chunk manager, the reads are not recorded.
+func (a *AuditChunkManager) ReadIfModified(ctx context.Context, filePath string, etag string) ([]byte, string, error) {
+	return ReadIfModified(ctx, a.ChunkManager, filePath, etag)
+}
+
+func (a *AuditChunkManager) record(ctx context.Context, op AuditOp, err error, keys ...string) {
+	traceID, _, _ := trace.InfoFromContext(ctx)
+	now := time.Now()
+	records := make([]AuditRecord, 0, len(keys))
+	for _, key := range keys {
+		record := AuditRecord{
+			Time:      now,
+			Component: a.component,
+			Op:        op,
+			Key:       key,
+			TraceID:   traceID,
+		}
+		if err != nil {
+			record.Error = err.Error()
+		}
+		records = append(records, record)
+	}
+	a.sink.Record(records...)
+}
+
+// existing returns the ones of @filePaths which exist, if overwrite auditing is enabled.
+// The objects which fail to stat are regarded as existing, so that the overwrites are never missed.
+func (a *AuditChunkManager) existing(ctx context.Context, filePaths ...string) []string {
+	if !a.overwrite {
+		return nil
+	}
+	infos, err := a.ChunkManager.MultiStat(ctx, filePaths)
+	if err != nil {
+		return filePaths
+	}
+	var existing []string
+	for i, info := range infos {
+		if info != nil {
+			existing = append(existing, filePaths[i])
+		}
+	}
+	return existing
+}
+
+func (a *AuditChunkManager) recordOverwrites(ctx context.Context, existing []string, err error) {
+	if len(existing) > 0 {
+		a.record(ctx, AuditOpOverwrite, err, existing...)
+	}
+}
+
+func (a *AuditChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	existing := a.existing(ctx, filePath)
+	err := a.ChunkManager.Write(ctx, filePath, content)
+	a.recordOverwrites(ctx, existing, err)
+	return err
+}
+
+func (a *AuditChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	existing := a.existing(ctx, filePath)
+	err := a.ChunkManager.WriteWithOptions(ctx, filePath, content, opts...)
+	a.recordOverwrites(ctx, existing, err)
+	return err
+}
+
+func (a *AuditChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	filePaths := make([]string, 0, len(contents))
+	for filePath := range contents {
+		filePaths = append(filePaths, filePath)
+	}
+	existing := a.existing(ctx, filePaths...)
+	err := a.ChunkManager.MultiWrite(ctx, contents)
+	a.recordOverwrites(ctx, existing, err)
+	return err
+}
+
+func (a *AuditChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	existing := a.existing(ctx, dstFilePath)
+	err := a.ChunkManager.Copy(ctx, srcFilePath, dstFilePath)
+	a.recordOverwrites(ctx, existing, err)
+	return err
+}
+
+func (a *AuditChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	existing := a.existing(ctx, dstFilePath)
+	err := a.ChunkManager.Move(ctx, srcFilePath, dstFilePath)
+	a.recordOverwrites(ctx, existing, err)
+	a.record(ctx, AuditOpMove, err, srcFilePath)
+	return err
+}
+
+func (a *AuditChunkManager) Remove(ctx context.Context, filePath string) error {
+	err := a.ChunkManager.Remove(ctx, filePath)
+	a.record(ctx, AuditOpRemove, err, filePath)
+	return err
+}
+
+func (a *AuditChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	err := a.ChunkManager.MultiRemove(ctx, filePaths)
+	a.record(ctx, AuditOpRemove, err, filePaths...)
+	return err
+}
+
+func (a *AuditChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	err := a.ChunkManager.RemoveWithPrefix(ctx, prefix)
+	a.record(ctx, AuditOpRemoveWithPrefix, err, prefix)
+	return err
+}
@@ -0,0 +1,101 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryAuditSink struct {
+	mu      sync.Mutex
+	records []AuditRecord
+}
+
+func (s *memoryAuditSink) Record(records ...AuditRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, records...)
+}
+
+func TestAuditChunkManager(t *testing.T) {
+	ctx := context.Background()
+	cm := NewLocalChunkManager(RootPath(t.TempDir()))
+
+	t.Run("removals", func(t *testing.T) {
+		sink := &memoryAuditSink{}
+		acm := NewAuditChunkManager(cm, sink, "datanode", false)
+		require.NoError(t, acm.MultiWrite(ctx, map[string][]byte{"r/1": []byte("1"), "r/2": []byte("2"), "r/3": []byte("3")}))
+		assert.Empty(t, sink.records)
+
+		require.NoError(t, acm.Remove(ctx, "r/1"))
+		require.NoError(t, acm.MultiRemove(ctx, []string{"r/2"}))
+		require.NoError(t, acm.RemoveWithPrefix(ctx, "r/"))
+		require.Len(t, sink.records, 3)
+		assert.Equal(t, AuditOpRemove, sink.records[0].Op)
+		assert.Equal(t, "r/1", sink.records[0].Key)
+		assert.Equal(t, "datanode", sink.records[0].Component)
+		assert.Equal(t, "r/2", sink.records[1].Key)
+		assert.Equal(t, AuditOpRemoveWithPrefix, sink.records[2].Op)
+		assert.Equal(t, "r/", sink.records[2].Key)
+		assert.Empty(t, sink.records[2].Error)
+	})
+
+	t.Run("overwrites", func(t *testing.T) {
+		sink := &memoryAuditSink{}
+		acm := NewAuditChunkManager(cm, sink, "datacoord", true)
+		require.NoError(t, acm.Write(ctx, "w/1", []byte("1")))
+		assert.Empty(t, sink.records)
+		require.NoError(t, acm.Write(ctx, "w/1", []byte("2")))
+		require.NoError(t, acm.Move(ctx, "w/1", "w/2"))
+		require.Len(t, sink.records, 2)
+		assert.Equal(t, AuditRecord{Time: sink.records[0].Time, Component: "datacoord", Op: AuditOpOverwrite, Key: "w/1"}, sink.records[0])
+		assert.Equal(t, AuditOpMove, sink.records[1].Op)
+		assert.Equal(t, "w/1", sink.records[1].Key)
+	})
+
+	t.Run("file sink", func(t *testing.T) {
+		filePath := path.Join(t.TempDir(), "audit.log")
+		sink := NewFileAuditSink(filePath, 1)
+		acm := NewAuditChunkManager(cm, sink, "querynode", false)
+		require.NoError(t, acm.Write(ctx, "f/1", []byte("1")))
+		require.NoError(t, acm.Remove(ctx, "f/1"))
+		require.NoError(t, sink.Close())
+
+		f, err := os.Open(filePath)
+		require.NoError(t, err)
+		defer f.Close()
+		var records []AuditRecord
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var record AuditRecord
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, record)
+		}
+		require.Len(t, records, 1)
+		assert.Equal(t, "f/1", records[0].Key)
+		assert.Equal(t, "querynode", records[0].Component)
+	})
+}
@@ -0,0 +1,285 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// Auth modes of Azure Blob Storage, neither keeps any secret in the config.
+const (
+	// AuthModeManagedIdentity gets the OAuth tokens of the managed identity of the VM, VMSS or AKS node
+	// from the instance metadata service.
+	AuthModeManagedIdentity = "managedIdentity"
+	// AuthModeSASToken authorizes the requests by a SAS token, which is got again by a refresh callback
+	// before it expires, e.g. reading the token file rotated by a secret store.
+	AuthModeSASToken = "sasToken"
+)
+
+const (
+	// storageResource is the resource of the OAuth tokens accessing Azure Blob Storage.
+	storageResource = "https://storage.azure.com/"
+	// imdsEndpoint is the token endpoint of the Azure instance metadata service.
+	imdsEndpoint   = "http://169.254.169.254/metadata/identity/oauth2/token"
+	imdsAPIVersion = "2018-02-01"
+
+	// DefaultRefreshWindow is how long before the expiration the tokens are refreshed by default.
+	DefaultRefreshWindow = 5 * time.Minute
+	// refreshRetryInterval is how often the token is refreshed again after a failure, while the previous one
+	// is still valid.
+	refreshRetryInterval = 10 * time.Second
+)
+
+// Token is an OAuth access token or a SAS token of Azure Blob Storage, zero ExpiresOn means it never expires.
+type Token struct {
+	Value     string
+	ExpiresOn time.Time
+}
+
+// TokenRefresher gets a new token, it's called by TokenSource before the current token expires.
+type TokenRefresher func(ctx context.Context) (*Token, error)
+
+// TokenSource caches the token of a TokenRefresher and refreshes it RefreshWindow before it expires.
+// The previous token keeps serving until it really expires if refreshing fails.
+type TokenSource struct {
+	refresh TokenRefresher
+	window  time.Duration
+	// now is replaced in unit tests
+	now func() time.Time
+
+	mu      sync.Mutex
+	token   *Token
+	retryAt time.Time
+}
+
+// NewTokenSource returns a TokenSource of @refresh, refreshing the tokens @window before they expire,
+// DefaultRefreshWindow if @window isn't positive.
+func NewTokenSource(refresh TokenRefresher, window time.Duration) *TokenSource {
+	if window <= 0 {
+		window = DefaultRefreshWindow
+	}
+	return &TokenSource{
+		refresh: refresh,
+		window:  window,
+		now:     time.Now,
+	}
+}
+
+// Token returns the cached token, it's refreshed first if it's going to expire.
+func (s *TokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != nil && !s.needRefresh(now) {
+		return s.token, nil
+	}
+	token, err := s.refresh(ctx)
+	if err == nil && token.Value == "" {
+		err = errors.New("empty token")
+	}
+	if err != nil {
+		if s.token != nil && (s.token.ExpiresOn.IsZero() || now.Before(s.token.ExpiresOn)) {
+			log.Warn("failed to refresh the azure token, keep using the previous one",
+				zap.Time("expiresOn", s.token.ExpiresOn), zap.Error(err))
+			s.retryAt = now.Add(refreshRetryInterval)
+			return s.token, nil
+		}
+		return nil, errors.Wrap(err, "failed to refresh azure token")
+	}
+	s.token = token
+	s.retryAt = time.Time{}
+	return token, nil
+}
+
+// needRefresh returns whether the cached token expires within the refresh window at @now,
+// the refreshing is retried every refreshRetryInterval after a failure.
+func (s *TokenSource) needRefresh(now time.Time) bool {
+	if s.token.ExpiresOn.IsZero() || now.Before(s.token.ExpiresOn.Add(-s.window)) {
+		return false
+	}
+	return !now.Before(s.retryAt)
+}
+
+// NewTokenRefresher returns the refresher of @mode. With AuthModeManagedIdentity, @clientID is the client ID of
+// the user-assigned managed identity, empty means the system-assigned one or AZURE_CLIENT_ID.
+// With AuthModeSASToken, the token is read from @sasTokenFile at every refresh, unless @sasRefresher is given.
+func NewTokenRefresher(mode string, clientID string, sasTokenFile string, sasRefresher TokenRefresher) (TokenRefresher, error) {
+	switch mode {
+	case "", AuthModeManagedIdentity:
+		if clientID == "" {
+			clientID = os.Getenv("AZURE_CLIENT_ID")
+		}
+		return newManagedIdentityRefresher(http.DefaultClient, imdsEndpoint, clientID), nil
+	case AuthModeSASToken:
+		if sasRefresher != nil {
+			return sasRefresher, nil
+		}
+		if sasTokenFile == "" {
+			return nil, errors.New("neither SAS token file nor SAS token refresher is set")
+		}
+		return SASTokenFileRefresher(sasTokenFile), nil
+	default:
+		return nil, errors.Errorf("unknown azure auth mode %s", mode)
+	}
+}
+
+// newManagedIdentityRefresher returns the refresher getting the tokens of the managed identity from @endpoint.
+func newManagedIdentityRefresher(client *http.Client, endpoint string, clientID string) TokenRefresher {
+	return func(ctx context.Context) (*Token, error) {
+		v := url.Values{}
+		v.Set("api-version", imdsAPIVersion)
+		v.Set("resource", storageResource)
+		if clientID != "" {
+			v.Set("client_id", clientID)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+v.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Metadata", "true")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusOK {
+			msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+			return nil, errors.Errorf("managed identity endpoint responded %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+		}
+		var result struct {
+			AccessToken string `json:"access_token"`
+			// seconds since the epoch, in a string
+			ExpiresOn string `json:"expires_on"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return nil, errors.Wrap(err, "failed to decode managed identity token")
+		}
+		expiresOn, err := strconv.ParseInt(result.ExpiresOn, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid expires_on %s of managed identity token", result.ExpiresOn)
+		}
+		return &Token{Value: result.AccessToken, ExpiresOn: time.Unix(expiresOn, 0)}, nil
+	}
+}
+
+// SASTokenFileRefresher returns the refresher reading the SAS token from @path, which is rotated by others,
+// e.g. a Kubernetes secret or the secrets store CSI driver. The token expires at its signed expiry "se".
+func SASTokenFileRefresher(path string) TokenRefresher {
+	return func(ctx context.Context) (*Token, error) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read SAS token file")
+		}
+		return ParseSASToken(string(data))
+	}
+}
+
+// ParseSASToken returns the token of the SAS query string @sas, which expires at its signed expiry "se".
+func ParseSASToken(sas string) (*Token, error) {
+	sas = strings.TrimPrefix(strings.TrimSpace(sas), "?")
+	v, err := url.ParseQuery(sas)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid SAS token")
+	}
+	if v.Get("sig") == "" {
+		return nil, errors.New("invalid SAS token without signature")
+	}
+	token := &Token{Value: sas}
+	if se := v.Get("se"); se != "" {
+		token.ExpiresOn, err = time.Parse(time.RFC3339, se)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signed expiry %s of SAS token", se)
+		}
+	}
+	return token, nil
+}
+
+// WrapHTTPTransport authorizes the requests by the tokens of tokenSrc, the OAuth tokens of the managed identity are
+// set as the bearer tokens, and the SAS tokens are added to the queries.
+type WrapHTTPTransport struct {
+	mode     string
+	tokenSrc *TokenSource
+	backend  http.RoundTripper
+}
+
+// NewWrapHTTPTransport returns the transport authorizing the requests sent by @backend with the tokens of @tokenSrc
+// in @mode, http.DefaultTransport if @backend is nil.
+func NewWrapHTTPTransport(mode string, tokenSrc *TokenSource, backend http.RoundTripper) *WrapHTTPTransport {
+	if mode == "" {
+		mode = AuthModeManagedIdentity
+	}
+	if backend == nil {
+		backend = http.DefaultTransport
+	}
+	return &WrapHTTPTransport{mode: mode, tokenSrc: tokenSrc, backend: backend}
+}
+
+// RoundTrip authorizes a copy of @req by the current token.
+func (t *WrapHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.tokenSrc.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	if t.mode == AuthModeSASToken {
+		sas, err := url.ParseQuery(token.Value)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid SAS token")
+		}
+		req.URL.RawQuery = withSAS(req.URL.Query(), sas).Encode()
+		// the source blob of a copy in the same account is authorized by the same SAS token
+		if source := req.Header.Get(headerCopySource); source != "" {
+			u, err := url.Parse(source)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid copy source")
+			}
+			u.RawQuery = withSAS(u.Query(), sas).Encode()
+			req.Header.Set(headerCopySource, u.String())
+		}
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token.Value)
+	}
+	return t.backend.RoundTrip(req)
+}
+
+// withSAS sets the parameters of the SAS token @sas to @query and returns it.
+func withSAS(query url.Values, sas url.Values) url.Values {
+	for key, values := range sas {
+		query[key] = values
+	}
+	return query
+}
@@ -0,0 +1,246 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenSource_Refresh(t *testing.T) {
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	var calls int
+	var refreshErr error
+	src := NewTokenSource(func(ctx context.Context) (*Token, error) {
+		calls++
+		if refreshErr != nil {
+			return nil, refreshErr
+		}
+		return &Token{Value: fmt.Sprintf("token-%d", calls), ExpiresOn: now.Add(time.Hour)}, nil
+	}, 10*time.Minute)
+	src.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	token, err := src.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.Value)
+
+	// cached until the refresh window
+	expiresOn := now.Add(time.Hour)
+	now = expiresOn.Add(-11 * time.Minute)
+	token, err = src.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.Value)
+	assert.Equal(t, 1, calls)
+
+	// refreshed within the refresh window
+	now = expiresOn.Add(-9 * time.Minute)
+	token, err = src.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token.Value)
+	assert.Equal(t, 2, calls)
+
+	// the previous token keeps serving if refreshing fails, and it's retried after the retry interval
+	expiresOn = now.Add(time.Hour)
+	now = expiresOn.Add(-time.Minute)
+	refreshErr = errors.New("mock error")
+	token, err = src.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token.Value)
+	assert.Equal(t, 3, calls)
+	_, err = src.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	now = now.Add(refreshRetryInterval)
+	_, err = src.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, calls)
+
+	// fails once the previous token expires
+	now = expiresOn
+	_, err = src.Token(ctx)
+	assert.Error(t, err)
+
+	refreshErr = nil
+	token, err = src.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-6", token.Value)
+
+	// empty tokens are refused
+	src = NewTokenSource(func(ctx context.Context) (*Token, error) {
+		return &Token{}, nil
+	}, 0)
+	assert.Equal(t, DefaultRefreshWindow, src.window)
+	_, err = src.Token(ctx)
+	assert.Error(t, err)
+}
+
+func TestManagedIdentityRefresher(t *testing.T) {
+	expiresOn := time.Now().Add(time.Hour).Truncate(time.Second)
+	var status int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		assert.Equal(t, storageResource, r.URL.Query().Get("resource"))
+		assert.Equal(t, imdsAPIVersion, r.URL.Query().Get("api-version"))
+		assert.Equal(t, "client-id", r.URL.Query().Get("client_id"))
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"access-token","expires_on":"%d","resource":"%s","token_type":"Bearer"}`,
+			expiresOn.Unix(), storageResource)
+	}))
+	defer server.Close()
+
+	refresh := newManagedIdentityRefresher(server.Client(), server.URL, "client-id")
+	token, err := refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token.Value)
+	assert.True(t, expiresOn.Equal(token.ExpiresOn))
+
+	status = http.StatusBadRequest
+	_, err = refresh(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSASTokenFileRefresher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sas")
+	refresh := SASTokenFileRefresher(path)
+	_, err := refresh(context.Background())
+	assert.Error(t, err)
+
+	// the token file rotated by others is read again at every refresh
+	require.NoError(t, ioutil.WriteFile(path, []byte("?sv=2021-08-06&se=2022-10-01T01:00:00Z&sp=rwdl&sig=sig1\n"), 0600))
+	token, err := refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "sv=2021-08-06&se=2022-10-01T01:00:00Z&sp=rwdl&sig=sig1", token.Value)
+	assert.Equal(t, time.Date(2022, 10, 1, 1, 0, 0, 0, time.UTC), token.ExpiresOn)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("sv=2021-08-06&sp=rwdl&sig=sig2"), 0600))
+	token, err = refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "sv=2021-08-06&sp=rwdl&sig=sig2", token.Value)
+	assert.True(t, token.ExpiresOn.IsZero())
+
+	_, err = ParseSASToken("sv=2021-08-06&sp=rwdl")
+	assert.Error(t, err)
+	_, err = ParseSASToken("se=tomorrow&sig=sig")
+	assert.Error(t, err)
+	_, err = ParseSASToken("sig=%zz")
+	assert.Error(t, err)
+}
+
+func TestNewTokenRefresher(t *testing.T) {
+	_, err := NewTokenRefresher(AuthModeManagedIdentity, "", "", nil)
+	assert.NoError(t, err)
+	_, err = NewTokenRefresher(AuthModeSASToken, "", "", nil)
+	assert.Error(t, err)
+	_, err = NewTokenRefresher(AuthModeSASToken, "", "/path/to/sas", nil)
+	assert.NoError(t, err)
+	_, err = NewTokenRefresher("accessKey", "", "", nil)
+	assert.Error(t, err)
+
+	callback := func(ctx context.Context) (*Token, error) {
+		return &Token{Value: "sig=callback"}, nil
+	}
+	refresh, err := NewTokenRefresher(AuthModeSASToken, "", "/path/to/sas", callback)
+	require.NoError(t, err)
+	token, err := refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "sig=callback", token.Value)
+}
+
+type mockTransport struct {
+	req *http.Request
+	err error
+}
+
+func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.req = req
+	return nil, m.err
+}
+
+func TestWrapHTTPTransport_RoundTrip(t *testing.T) {
+	var token string
+	var refreshErr error
+	src := NewTokenSource(func(ctx context.Context) (*Token, error) {
+		return &Token{Value: token, ExpiresOn: time.Now().Add(time.Hour)}, refreshErr
+	}, time.Minute)
+
+	t.Run("managed identity", func(t *testing.T) {
+		backend := &mockTransport{}
+		tr := NewWrapHTTPTransport("", src, backend)
+		token = "access-token"
+		req, err := http.NewRequest(http.MethodGet, "http://example.com/bucket/key", nil)
+		require.NoError(t, err)
+		_, err = tr.RoundTrip(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer access-token", backend.req.Header.Get("Authorization"))
+		// the request of the caller is not modified
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("sas token", func(t *testing.T) {
+		src.token = nil
+		backend := &mockTransport{}
+		tr := &WrapHTTPTransport{mode: AuthModeSASToken, tokenSrc: src, backend: backend}
+		token = "sv=2021-08-06&sp=rwdl&sig=sig"
+		req, err := http.NewRequest(http.MethodPut, "http://example.com/container/dst?comp=list", nil)
+		require.NoError(t, err)
+		req.Header.Set(headerCopySource, "http://example.com/container/src")
+		_, err = tr.RoundTrip(req)
+		assert.NoError(t, err)
+		query := backend.req.URL.Query()
+		assert.Equal(t, "list", query.Get("comp"))
+		assert.Equal(t, "sig", query.Get("sig"))
+		assert.Equal(t, "rwdl", query.Get("sp"))
+		assert.Empty(t, backend.req.Header.Get("Authorization"))
+		assert.Empty(t, req.URL.Query().Get("sig"))
+		// the source blob of the copy is authorized as well
+		assert.Equal(t, "http://example.com/container/src?sig=sig&sp=rwdl&sv=2021-08-06", backend.req.Header.Get(headerCopySource))
+		assert.Equal(t, "http://example.com/container/src", req.Header.Get(headerCopySource))
+	})
+
+	t.Run("refresh failed", func(t *testing.T) {
+		src.token = nil
+		refreshErr = errors.New("mock error")
+		defer func() { refreshErr = nil }()
+		tr := &WrapHTTPTransport{mode: AuthModeManagedIdentity, tokenSrc: src, backend: &mockTransport{}}
+		req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		require.NoError(t, err)
+		_, err = tr.RoundTrip(req)
+		assert.Error(t, err)
+	})
+
+	t.Run("call failed", func(t *testing.T) {
+		tr := &WrapHTTPTransport{mode: AuthModeManagedIdentity, tokenSrc: src, backend: &mockTransport{err: errors.New("mock error")}}
+		req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		require.NoError(t, err)
+		_, err = tr.RoundTrip(req)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,488 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// APIVersion is the version of the Blob Storage REST API called by BlobClient,
+// which supports the OAuth authorization and the blob index tags.
+const APIVersion = "2020-10-02"
+
+const (
+	headerVersion         = "x-ms-version"
+	headerDate            = "x-ms-date"
+	headerErrorCode       = "x-ms-error-code"
+	headerBlobType        = "x-ms-blob-type"
+	headerTags            = "x-ms-tags"
+	headerMetaPrefix      = "x-ms-meta-"
+	headerRange           = "x-ms-range"
+	headerDeleteSnapshots = "x-ms-delete-snapshots"
+	headerCopySource      = "x-ms-copy-source"
+	headerCopyStatus      = "x-ms-copy-status"
+)
+
+// Error codes of Blob Storage returned in ResponseError.
+const (
+	ErrCodeBlobNotFound           = "BlobNotFound"
+	ErrCodeBlobAlreadyExists      = "BlobAlreadyExists"
+	ErrCodeContainerNotFound      = "ContainerNotFound"
+	ErrCodeContainerAlreadyExists = "ContainerAlreadyExists"
+	ErrCodeConditionNotMet        = "ConditionNotMet"
+)
+
+// copyPollInterval is how often the status of a pending copy is checked.
+var copyPollInterval = time.Second
+
+// ResponseError is an error responded by Blob Storage.
+type ResponseError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("azure blob storage responded %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// ErrorCode returns the error code of the ResponseError wrapped by @err, empty if there is none.
+func ErrorCode(err error) string {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.Code
+	}
+	return ""
+}
+
+// BlobProperties are the properties of a blob returned by GetProperties, GetBlob and ListBlobs.
+type BlobProperties struct {
+	Name         string
+	Size         int64
+	LastModified time.Time
+	// ETag is without the quotes
+	ETag     string
+	Metadata map[string]string
+	// Tags are only returned by ListBlobs with IncludeTags
+	Tags map[string]string
+	// CopyStatus is the status of the last copy to the blob, "pending", "success", "aborted" or "failed"
+	CopyStatus string
+}
+
+// PutOptions are the options of PutBlob.
+type PutOptions struct {
+	Metadata map[string]string
+	Tags     map[string]string
+	// IfMatch writes the blob only if its ETag is IfMatch, IfNoneMatch "*" writes it only if it doesn't exist
+	IfMatch     string
+	IfNoneMatch string
+}
+
+// ListOptions are the options of ListBlobs.
+type ListOptions struct {
+	Prefix string
+	// Delimiter lists the blob names up to the next Delimiter after Prefix as BlobPrefixes, empty lists all the blobs
+	Delimiter string
+	// Marker is the NextMarker of the previous page, empty lists the first page
+	Marker          string
+	MaxResults      int
+	IncludeMetadata bool
+	IncludeTags     bool
+}
+
+// ListBlobsPage is a page of the blobs listed by ListBlobs, in lexicographical order.
+type ListBlobsPage struct {
+	Blobs        []BlobProperties
+	BlobPrefixes []string
+	// NextMarker is empty if it's the last page
+	NextMarker string
+}
+
+// BlobClient calls the REST API of Blob Storage to access the blobs of a container,
+// its requests are authorized by the transport, e.g. WrapHTTPTransport.
+type BlobClient struct {
+	endpoint  *url.URL
+	container string
+	client    *http.Client
+}
+
+// NewBlobClient returns the client of @container at the blob service endpoint @address,
+// e.g. "<account>.blob.core.windows.net", with https if @secure. The requests are sent by @transport.
+func NewBlobClient(address string, secure bool, container string, transport http.RoundTripper) (*BlobClient, error) {
+	if address == "" || container == "" {
+		return nil, errors.New("address and container of azure blob storage must be set")
+	}
+	scheme := "http"
+	if secure {
+		scheme = "https"
+	}
+	endpoint, err := url.Parse(scheme + "://" + strings.TrimSuffix(address, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid address of azure blob storage")
+	}
+	return &BlobClient{
+		endpoint:  endpoint,
+		container: container,
+		client:    &http.Client{Transport: transport},
+	}, nil
+}
+
+// Container returns the name of the container.
+func (c *BlobClient) Container() string {
+	return c.container
+}
+
+// BlobURL returns the URL of the blob @name.
+func (c *BlobClient) BlobURL(name string) *url.URL {
+	u := *c.endpoint
+	u.Path = "/" + c.container + "/" + name
+	return &u
+}
+
+func (c *BlobClient) containerURL(query url.Values) *url.URL {
+	u := *c.endpoint
+	u.Path = "/" + c.container
+	query.Set("restype", "container")
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+// do sends the request and returns the response, a ResponseError is returned if the request fails.
+func (c *BlobClient) do(ctx context.Context, method string, u *url.URL, header http.Header, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set(headerVersion, APIVersion)
+	req.Header.Set(headerDate, time.Now().UTC().Format(http.TimeFormat))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		return nil, newResponseError(resp)
+	}
+	return resp, nil
+}
+
+// doAndClose sends the request and drops the response body.
+func (c *BlobClient) doAndClose(ctx context.Context, method string, u *url.URL, header http.Header, body []byte) (*http.Response, error) {
+	resp, err := c.do(ctx, method, u, header, body)
+	if err != nil {
+		return nil, err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	return resp, nil
+}
+
+func newResponseError(resp *http.Response) error {
+	respErr := &ResponseError{StatusCode: resp.StatusCode, Code: resp.Header.Get(headerErrorCode)}
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+	if xml.Unmarshal(trimBOM(data), &body) == nil {
+		if respErr.Code == "" {
+			respErr.Code = body.Code
+		}
+		respErr.Message = strings.TrimSpace(body.Message)
+	}
+	if respErr.Message == "" {
+		respErr.Message = resp.Status
+	}
+	return respErr
+}
+
+// trimBOM trims the UTF-8 byte order mark leading the XML bodies of Blob Storage.
+func trimBOM(data []byte) []byte {
+	return bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+}
+
+// ContainerExists returns whether the container exists.
+func (c *BlobClient) ContainerExists(ctx context.Context) (bool, error) {
+	_, err := c.doAndClose(ctx, http.MethodHead, c.containerURL(url.Values{}), nil, nil)
+	if err != nil {
+		var respErr *ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateContainer creates the container, it succeeds if the container already exists.
+func (c *BlobClient) CreateContainer(ctx context.Context) error {
+	_, err := c.doAndClose(ctx, http.MethodPut, c.containerURL(url.Values{}), nil, nil)
+	if ErrorCode(err) == ErrCodeContainerAlreadyExists {
+		return nil
+	}
+	return err
+}
+
+// PutBlob writes @data to the block blob @name.
+func (c *BlobClient) PutBlob(ctx context.Context, name string, data []byte, opts PutOptions) error {
+	header := http.Header{}
+	header.Set(headerBlobType, "BlockBlob")
+	header.Set("Content-Type", "application/octet-stream")
+	for key, value := range opts.Metadata {
+		header.Set(headerMetaPrefix+key, value)
+	}
+	if len(opts.Tags) > 0 {
+		tags := url.Values{}
+		for key, value := range opts.Tags {
+			tags.Set(key, value)
+		}
+		header.Set(headerTags, tags.Encode())
+	}
+	if opts.IfMatch != "" {
+		header.Set("If-Match", quoteETag(opts.IfMatch))
+	}
+	if opts.IfNoneMatch != "" {
+		header.Set("If-None-Match", opts.IfNoneMatch)
+	}
+	_, err := c.doAndClose(ctx, http.MethodPut, c.BlobURL(name), header, data)
+	return err
+}
+
+// GetBlob returns the content of the blob @name from @offset, @count bytes or to the end if @count is negative.
+// The caller must close the returned reader.
+func (c *BlobClient) GetBlob(ctx context.Context, name string, offset int64, count int64) (io.ReadCloser, *BlobProperties, error) {
+	header := http.Header{}
+	if count >= 0 {
+		header.Set(headerRange, fmt.Sprintf("bytes=%d-%d", offset, offset+count-1))
+	} else if offset > 0 {
+		header.Set(headerRange, fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := c.do(ctx, http.MethodGet, c.BlobURL(name), header, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	props, err := propertiesFromHeader(name, resp.Header)
+	if err != nil {
+		resp.Body.Close()
+		return nil, nil, err
+	}
+	return resp.Body, props, nil
+}
+
+// GetProperties returns the properties and metadata of the blob @name.
+func (c *BlobClient) GetProperties(ctx context.Context, name string) (*BlobProperties, error) {
+	resp, err := c.doAndClose(ctx, http.MethodHead, c.BlobURL(name), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return propertiesFromHeader(name, resp.Header)
+}
+
+// DeleteBlob deletes the blob @name with its snapshots.
+func (c *BlobClient) DeleteBlob(ctx context.Context, name string) error {
+	header := http.Header{}
+	header.Set(headerDeleteSnapshots, "include")
+	_, err := c.doAndClose(ctx, http.MethodDelete, c.BlobURL(name), header, nil)
+	return err
+}
+
+// CopyBlob copies the blob @src to @dst of the container server-side, and waits for the copy to complete.
+func (c *BlobClient) CopyBlob(ctx context.Context, src string, dst string) error {
+	header := http.Header{}
+	header.Set(headerCopySource, c.BlobURL(src).String())
+	resp, err := c.doAndClose(ctx, http.MethodPut, c.BlobURL(dst), header, nil)
+	if err != nil {
+		return err
+	}
+	status := resp.Header.Get(headerCopyStatus)
+	for status == "pending" {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(copyPollInterval):
+		}
+		props, err := c.GetProperties(ctx, dst)
+		if err != nil {
+			return err
+		}
+		status = props.CopyStatus
+	}
+	if status != "" && status != "success" {
+		return errors.Errorf("copy of blob %s to %s is %s", src, dst, status)
+	}
+	return nil
+}
+
+// ListBlobs lists a page of the blobs in @opts.
+func (c *BlobClient) ListBlobs(ctx context.Context, opts ListOptions) (*ListBlobsPage, error) {
+	query := url.Values{}
+	query.Set("comp", "list")
+	if opts.Prefix != "" {
+		query.Set("prefix", opts.Prefix)
+	}
+	if opts.Delimiter != "" {
+		query.Set("delimiter", opts.Delimiter)
+	}
+	if opts.Marker != "" {
+		query.Set("marker", opts.Marker)
+	}
+	if opts.MaxResults > 0 {
+		query.Set("maxresults", strconv.Itoa(opts.MaxResults))
+	}
+	var include []string
+	if opts.IncludeMetadata {
+		include = append(include, "metadata")
+	}
+	if opts.IncludeTags {
+		include = append(include, "tags")
+	}
+	if len(include) > 0 {
+		query.Set("include", strings.Join(include, ","))
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.containerURL(query), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var result listBlobsResult
+	if err := xml.Unmarshal(trimBOM(data), &result); err != nil {
+		return nil, errors.Wrap(err, "failed to decode blob list")
+	}
+
+	page := &ListBlobsPage{NextMarker: result.NextMarker}
+	for _, blob := range result.Blobs.Blob {
+		props := BlobProperties{
+			Name: blob.Name,
+			Size: blob.Properties.ContentLength,
+			ETag: strings.Trim(blob.Properties.Etag, `"`),
+		}
+		if blob.Properties.LastModified != "" {
+			props.LastModified, err = http.ParseTime(blob.Properties.LastModified)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid last modified time of blob %s", blob.Name)
+			}
+		}
+		if opts.IncludeMetadata {
+			props.Metadata = make(map[string]string, len(blob.Metadata.Items))
+			for _, item := range blob.Metadata.Items {
+				props.Metadata[strings.ToLower(item.XMLName.Local)] = item.Value
+			}
+		}
+		if opts.IncludeTags {
+			props.Tags = make(map[string]string, len(blob.Tags.Tag))
+			for _, tag := range blob.Tags.Tag {
+				props.Tags[tag.Key] = tag.Value
+			}
+		}
+		page.Blobs = append(page.Blobs, props)
+	}
+	for _, prefix := range result.Blobs.BlobPrefix {
+		page.BlobPrefixes = append(page.BlobPrefixes, prefix.Name)
+	}
+	return page, nil
+}
+
+// listBlobsResult is the response body of List Blobs.
+type listBlobsResult struct {
+	XMLName xml.Name `xml:"EnumerationResults"`
+	Blobs   struct {
+		Blob []struct {
+			Name       string `xml:"Name"`
+			Properties struct {
+				LastModified  string `xml:"Last-Modified"`
+				ContentLength int64  `xml:"Content-Length"`
+				Etag          string `xml:"Etag"`
+			} `xml:"Properties"`
+			Metadata struct {
+				Items []struct {
+					XMLName xml.Name
+					Value   string `xml:",chardata"`
+				} `xml:",any"`
+			} `xml:"Metadata"`
+			Tags struct {
+				Tag []struct {
+					Key   string `xml:"Key"`
+					Value string `xml:"Value"`
+				} `xml:"TagSet>Tag"`
+			} `xml:"Tags"`
+		} `xml:"Blob"`
+		BlobPrefix []struct {
+			Name string `xml:"Name"`
+		} `xml:"BlobPrefix"`
+	} `xml:"Blobs"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// propertiesFromHeader returns the properties of the blob @name in the response @header.
+func propertiesFromHeader(name string, header http.Header) (*BlobProperties, error) {
+	props := &BlobProperties{
+		Name:       name,
+		ETag:       strings.Trim(header.Get("ETag"), `"`),
+		Metadata:   make(map[string]string),
+		CopyStatus: header.Get(headerCopyStatus),
+	}
+	if length := header.Get("Content-Length"); length != "" {
+		size, err := strconv.ParseInt(length, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid content length of blob %s", name)
+		}
+		props.Size = size
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		t, err := http.ParseTime(lastModified)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid last modified time of blob %s", name)
+		}
+		props.LastModified = t
+	}
+	for key, values := range header {
+		// header keys are canonicalized, e.g. X-Ms-Meta-Collection-Id
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, headerMetaPrefix) && len(values) > 0 {
+			props.Metadata[strings.TrimPrefix(key, headerMetaPrefix)] = values[0]
+		}
+	}
+	return props, nil
+}
+
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
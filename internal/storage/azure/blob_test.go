@@ -0,0 +1,241 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBlobClient(t *testing.T, handler http.HandlerFunc) *BlobClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewBlobClient(strings.TrimPrefix(server.URL, "http://"), false, "container", nil)
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewBlobClient(t *testing.T) {
+	_, err := NewBlobClient("", true, "container", nil)
+	assert.Error(t, err)
+	_, err = NewBlobClient("account.blob.core.windows.net", true, "", nil)
+	assert.Error(t, err)
+
+	client, err := NewBlobClient("account.blob.core.windows.net/", true, "container", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "container", client.Container())
+	assert.Equal(t, "https://account.blob.core.windows.net/container/a%20b/c", client.BlobURL("a b/c").String())
+}
+
+func TestBlobClient_ResponseError(t *testing.T) {
+	var header bool
+	client := newTestBlobClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, APIVersion, r.Header.Get(headerVersion))
+		assert.NotEmpty(t, r.Header.Get(headerDate))
+		if header {
+			// HEAD responses carry the error code in the header only
+			w.Header().Set(headerErrorCode, ErrCodeBlobNotFound)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusPreconditionFailed)
+		fmt.Fprint(w, "\xef\xbb\xbf<?xml version=\"1.0\" encoding=\"utf-8\"?><Error><Code>ConditionNotMet</Code>"+
+			"<Message>The condition specified using HTTP conditional header(s) is not met.</Message></Error>")
+	})
+	ctx := context.Background()
+
+	err := client.PutBlob(ctx, "a", []byte("a"), PutOptions{IfMatch: "etag"})
+	assert.Equal(t, ErrCodeConditionNotMet, ErrorCode(err))
+	assert.Contains(t, err.Error(), "condition specified")
+
+	header = true
+	_, err = client.GetProperties(ctx, "a")
+	assert.Equal(t, ErrCodeBlobNotFound, ErrorCode(err))
+	assert.Equal(t, "", ErrorCode(nil))
+}
+
+func TestBlobClient_PutGet(t *testing.T) {
+	lastModified := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	var putReq *http.Request
+	client := newTestBlobClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/container/dir/blob", r.URL.Path)
+		switch r.Method {
+		case http.MethodPut:
+			putReq = r
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			assert.Equal(t, "bytes=2-4", r.Header.Get(headerRange))
+			w.Header().Set("ETag", `"0x8DA"`)
+			w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+			w.Header().Set("X-Ms-Meta-Collection", "1")
+			w.Header().Set("Content-Length", "3")
+			w.WriteHeader(http.StatusPartialContent)
+			fmt.Fprint(w, "cde")
+		}
+	})
+	ctx := context.Background()
+
+	err := client.PutBlob(ctx, "dir/blob", []byte("abcdef"), PutOptions{
+		Metadata:    map[string]string{"collection": "1"},
+		Tags:        map[string]string{"segment": "2"},
+		IfNoneMatch: "*",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "BlockBlob", putReq.Header.Get(headerBlobType))
+	assert.Equal(t, "1", putReq.Header.Get("x-ms-meta-collection"))
+	assert.Equal(t, "segment=2", putReq.Header.Get(headerTags))
+	assert.Equal(t, "*", putReq.Header.Get("If-None-Match"))
+	assert.Equal(t, int64(6), putReq.ContentLength)
+
+	reader, props, err := client.GetBlob(ctx, "dir/blob", 2, 3)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "cde", string(data))
+	assert.Equal(t, "0x8DA", props.ETag)
+	assert.Equal(t, int64(3), props.Size)
+	assert.True(t, lastModified.Equal(props.LastModified))
+	assert.Equal(t, map[string]string{"collection": "1"}, props.Metadata)
+}
+
+func TestBlobClient_ListBlobs(t *testing.T) {
+	client := newTestBlobClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "container", query.Get("restype"))
+		assert.Equal(t, "list", query.Get("comp"))
+		assert.Equal(t, "dir/", query.Get("prefix"))
+		assert.Equal(t, "/", query.Get("delimiter"))
+		assert.Equal(t, "metadata,tags", query.Get("include"))
+		assert.Equal(t, "10", query.Get("maxresults"))
+		if query.Get("marker") == "" {
+			fmt.Fprint(w, "\xef\xbb\xbf<?xml version=\"1.0\" encoding=\"utf-8\"?>"+
+				`<EnumerationResults ContainerName="container"><Blobs>`+
+				`<Blob><Name>dir/a</Name><Properties><Last-Modified>Sat, 01 Oct 2022 00:00:00 GMT</Last-Modified>`+
+				`<Etag>0x8DA</Etag><Content-Length>5</Content-Length></Properties>`+
+				`<Metadata><Collection>1</Collection></Metadata>`+
+				`<Tags><TagSet><Tag><Key>segment</Key><Value>2</Value></Tag></TagSet></Tags></Blob>`+
+				`<BlobPrefix><Name>dir/sub/</Name></BlobPrefix>`+
+				`</Blobs><NextMarker>next</NextMarker></EnumerationResults>`)
+			return
+		}
+		fmt.Fprint(w, `<EnumerationResults><Blobs><Blob><Name>dir/b</Name><Properties>`+
+			`<Content-Length>0</Content-Length></Properties></Blob></Blobs><NextMarker/></EnumerationResults>`)
+	})
+	ctx := context.Background()
+
+	opts := ListOptions{Prefix: "dir/", Delimiter: "/", MaxResults: 10, IncludeMetadata: true, IncludeTags: true}
+	page, err := client.ListBlobs(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(page.Blobs))
+	blob := page.Blobs[0]
+	assert.Equal(t, "dir/a", blob.Name)
+	assert.Equal(t, int64(5), blob.Size)
+	assert.Equal(t, "0x8DA", blob.ETag)
+	assert.True(t, time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC).Equal(blob.LastModified))
+	assert.Equal(t, map[string]string{"collection": "1"}, blob.Metadata)
+	assert.Equal(t, map[string]string{"segment": "2"}, blob.Tags)
+	assert.Equal(t, []string{"dir/sub/"}, page.BlobPrefixes)
+	assert.Equal(t, "next", page.NextMarker)
+
+	opts.Marker = page.NextMarker
+	page, err = client.ListBlobs(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(page.Blobs))
+	assert.Equal(t, "dir/b", page.Blobs[0].Name)
+	assert.Empty(t, page.NextMarker)
+}
+
+func TestBlobClient_CopyBlob(t *testing.T) {
+	copyPollIntervalBak := copyPollInterval
+	copyPollInterval = time.Millisecond
+	defer func() { copyPollInterval = copyPollIntervalBak }()
+
+	var polls int
+	finalStatus := "success"
+	client := newTestBlobClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			assert.Equal(t, "/container/dst", r.URL.Path)
+			assert.True(t, strings.HasSuffix(r.Header.Get(headerCopySource), "/container/src"))
+			w.Header().Set(headerCopyStatus, "pending")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodHead:
+			polls++
+			if polls < 3 {
+				w.Header().Set(headerCopyStatus, "pending")
+			} else {
+				w.Header().Set(headerCopyStatus, finalStatus)
+			}
+		}
+	})
+	ctx := context.Background()
+
+	require.NoError(t, client.CopyBlob(ctx, "src", "dst"))
+	assert.Equal(t, 3, polls)
+
+	polls = 0
+	finalStatus = "failed"
+	assert.Error(t, client.CopyBlob(ctx, "src", "dst"))
+
+	polls = 0
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(t, client.CopyBlob(ctx, "src", "dst"))
+}
+
+func TestBlobClient_Container(t *testing.T) {
+	var exists bool
+	client := newTestBlobClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/container", r.URL.Path)
+		assert.Equal(t, "container", r.URL.Query().Get("restype"))
+		if exists {
+			if r.Method == http.MethodPut {
+				w.Header().Set(headerErrorCode, ErrCodeContainerAlreadyExists)
+				w.WriteHeader(http.StatusConflict)
+			}
+			return
+		}
+		if r.Method == http.MethodHead {
+			w.Header().Set(headerErrorCode, ErrCodeContainerNotFound)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	ctx := context.Background()
+
+	exist, err := client.ContainerExists(ctx)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	assert.NoError(t, client.CreateContainer(ctx))
+
+	exists = true
+	exist, err = client.ContainerExists(ctx)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.NoError(t, client.CreateContainer(ctx))
+}
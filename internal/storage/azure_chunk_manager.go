@@ -0,0 +1,488 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/storage/azure"
+	"github.com/milvus-io/milvus/internal/util/errorutil"
+	"github.com/milvus-io/milvus/internal/util/retry"
+	"go.uber.org/zap"
+	"golang.org/x/exp/mmap"
+)
+
+// azureListPageSize is the max number of blobs listed by one List Blobs request.
+var azureListPageSize = 5000
+
+// AzureChunkManager is responsible for read and write data stored in a container of Azure Blob Storage.
+// The requests are authorized by the OAuth tokens of the managed identity or by the SAS tokens,
+// which are refreshed before they expire, so that no account key is kept in the configs.
+type AzureChunkManager struct {
+	client      *azure.BlobClient
+	rootPath    string
+	concurrency int
+
+	listObjectMetadata bool
+	prefixSizes        *prefixSizeCache
+}
+
+var _ ChunkManager = (*AzureChunkManager)(nil)
+
+// NewAzureChunkManager creates the chunk manager of the container @BucketName at the blob service endpoint @Address.
+// Deprecated: Do not call this directly! Use factory.NewPersistentStorageChunkManager instead.
+func NewAzureChunkManager(ctx context.Context, opts ...Option) (*AzureChunkManager, error) {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	refresher, err := azure.NewTokenRefresher(c.azureAuthMode, c.azureClientID, c.azureSASTokenFile, c.azureSASRefresher)
+	if err != nil {
+		return nil, err
+	}
+	backend := http.DefaultTransport
+	if governor := getIOGovernor(c); governor != nil {
+		backend = &governedTransport{backend: backend, governor: governor}
+	}
+	tokenSrc := azure.NewTokenSource(refresher, c.azureRefreshWindow)
+	transport := azure.NewWrapHTTPTransport(c.azureAuthMode, tokenSrc, backend)
+	client, err := azure.NewBlobClient(c.address, c.useSSL, c.bucketName, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	checkContainerFn := func() error {
+		exist, err := client.ContainerExists(ctx)
+		if err != nil {
+			log.Warn("failed to check azure container exist", zap.String("container", c.bucketName), zap.Error(err))
+			return err
+		}
+		if exist {
+			return nil
+		}
+		if !c.createBucket {
+			return fmt.Errorf("container %s not Existed", c.bucketName)
+		}
+		log.Info("azure container not exist, create container.", zap.String("container", c.bucketName))
+		if err := client.CreateContainer(ctx); err != nil {
+			log.Warn("failed to create azure container", zap.String("container", c.bucketName), zap.Error(err))
+			return err
+		}
+		return nil
+	}
+	if err := retry.Do(ctx, checkContainerFn, retry.Attempts(CheckBucketRetryAttempts)); err != nil {
+		return nil, err
+	}
+
+	acm := &AzureChunkManager{
+		client:             client,
+		rootPath:           strings.TrimLeft(c.rootPath, "/"),
+		concurrency:        c.concurrency,
+		listObjectMetadata: c.listObjectMetadata,
+		prefixSizes:        newPrefixSizeCache(c.prefixSizeCacheTTL),
+	}
+	log.Info("azure chunk manager init success.", zap.String("container", c.bucketName),
+		zap.String("authMode", c.azureAuthMode), zap.String("root", acm.RootPath()))
+	return acm, nil
+}
+
+// wrapAzureErr converts the not found error of the blob at @filePath to ErrNoSuchKey.
+func wrapAzureErr(filePath string, err error) error {
+	if azure.ErrorCode(err) == azure.ErrCodeBlobNotFound {
+		return WrapErrNoSuchKey(filePath)
+	}
+	return err
+}
+
+// RootPath returns the root path of the chunk manager.
+func (acm *AzureChunkManager) RootPath() string {
+	return acm.rootPath
+}
+
+// Path returns @filePath if the blob exists.
+func (acm *AzureChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	_, err := acm.Stat(ctx, filePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return "", errors.New("azure blob cannot be found with filePath:" + filePath)
+	}
+	if err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// Size returns the size of the blob at @filePath.
+func (acm *AzureChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	info, err := acm.Stat(ctx, filePath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+// Stat returns the size, modify time and ETag of the blob with a single HEAD request.
+func (acm *AzureChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	props, err := acm.client.GetProperties(ctx, filePath)
+	if err != nil {
+		err = wrapAzureErr(filePath, err)
+		if !errors.Is(err, ErrNoSuchKey) {
+			log.Warn("failed to get blob properties", zap.String("path", filePath), zap.Error(err))
+		}
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		FilePath:   filePath,
+		Size:       props.Size,
+		ModifyTime: props.LastModified,
+		ETag:       props.ETag,
+	}, nil
+}
+
+// MultiStat stats the blobs with @filePaths by parallel HEAD requests.
+func (acm *AzureChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	return parallelMultiStat(ctx, filePaths, acm.concurrency, acm.Stat)
+}
+
+// Write writes @content to the block blob at @filePath.
+func (acm *AzureChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	if err := acm.client.PutBlob(ctx, filePath, content, azure.PutOptions{}); err != nil {
+		log.Warn("failed to put blob", zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// WriteWithOptions writes @content with the user metadata and the blob index tags in @opts,
+// the metadata names must be valid C# identifiers. The S3 storage classes are not applied.
+func (acm *AzureChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	c := newWriteConfig(opts...)
+	err := acm.client.PutBlob(ctx, filePath, content, azure.PutOptions{Metadata: c.userMetadata, Tags: c.tags})
+	if err != nil {
+		log.Warn("failed to put blob with options", zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// WriteIfNotExist writes @content with an `If-None-Match: *` precondition,
+// so that the blob is created only if it doesn't exist yet.
+func (acm *AzureChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	err := acm.client.PutBlob(ctx, filePath, content, azure.PutOptions{IfNoneMatch: "*"})
+	if err != nil {
+		code := azure.ErrorCode(err)
+		if code == azure.ErrCodeBlobAlreadyExists || code == azure.ErrCodeConditionNotMet {
+			return WrapErrObjectExists(filePath)
+		}
+		log.Warn("failed to put blob if not exist", zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// MultiWrite saves multiple blobs, the path is the key of @contents.
+func (acm *AzureChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	return parallelMultiWrite(ctx, contents, acm.concurrency, acm.Write)
+}
+
+// Append appends @content to the blob at @filePath. The blob is read, extended and written back
+// with an `If-Match` precondition on the ETag read, and the append is retried if the blob is modified concurrently.
+func (acm *AzureChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	err := retry.Do(ctx, func() error {
+		err := acm.appendOnce(ctx, filePath, content)
+		if err != nil && !errors.Is(err, ErrAppendConflict) {
+			return retry.Unrecoverable(err)
+		}
+		return err
+	}, retry.Attempts(AppendRetryAttempts))
+	if err != nil {
+		log.Warn("failed to append blob", zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (acm *AzureChunkManager) appendOnce(ctx context.Context, filePath string, content []byte) error {
+	opts := azure.PutOptions{IfNoneMatch: "*"}
+	data := content
+	reader, props, err := acm.client.GetBlob(ctx, filePath, 0, -1)
+	if err == nil {
+		data, err = Read(reader, props.Size+int64(len(content)))
+		reader.Close()
+		if err != nil {
+			return err
+		}
+		data = append(data, content...)
+		opts = azure.PutOptions{IfMatch: props.ETag}
+	} else if azure.ErrorCode(err) != azure.ErrCodeBlobNotFound {
+		return err
+	}
+
+	err = acm.client.PutBlob(ctx, filePath, data, opts)
+	code := azure.ErrorCode(err)
+	if code == azure.ErrCodeConditionNotMet || code == azure.ErrCodeBlobAlreadyExists {
+		return fmt.Errorf("%w(key=%s)", ErrAppendConflict, filePath)
+	}
+	return err
+}
+
+// Copy copies the blob at @srcFilePath to @dstFilePath with a server-side copy.
+func (acm *AzureChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	var err error
+	if srcFilePath == dstFilePath {
+		_, err = acm.client.GetProperties(ctx, srcFilePath)
+	} else {
+		err = acm.client.CopyBlob(ctx, srcFilePath, dstFilePath)
+	}
+	if err != nil {
+		err = wrapAzureErr(srcFilePath, err)
+		log.Warn("failed to copy blob", zap.String("src", srcFilePath), zap.String("dst", dstFilePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Move moves the blob at @srcFilePath to @dstFilePath, it's copied and the source blob is removed afterwards.
+func (acm *AzureChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	if err := acm.Copy(ctx, srcFilePath, dstFilePath); err != nil {
+		return err
+	}
+	if srcFilePath == dstFilePath {
+		return nil
+	}
+	return acm.Remove(ctx, srcFilePath)
+}
+
+// PresignURL is not supported, the SAS tokens are only read from the token source and never signed by the chunk manager.
+func (acm *AzureChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	return "", errors.New("presigning url is not supported by azure chunk manager")
+}
+
+// Exist checks whether the blob at @filePath exists.
+func (acm *AzureChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	_, err := acm.Stat(ctx, filePath)
+	if err != nil {
+		if errors.Is(err, ErrNoSuchKey) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Read reads the whole blob at @filePath.
+func (acm *AzureChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	reader, props, err := acm.client.GetBlob(ctx, filePath, 0, -1)
+	if err != nil {
+		err = wrapAzureErr(filePath, err)
+		log.Warn("failed to get blob", zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	defer reader.Close()
+	data, err := Read(reader, props.Size)
+	if err != nil {
+		log.Warn("failed to read blob", zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	return data, nil
+}
+
+// Reader returns the reader of the blob at @filePath.
+func (acm *AzureChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	reader, _, err := acm.client.GetBlob(ctx, filePath, 0, -1)
+	if err != nil {
+		err = wrapAzureErr(filePath, err)
+		log.Warn("failed to get blob", zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	return reader, nil
+}
+
+// MultiRead reads the blobs with @filePaths, the results keep the order of @filePaths.
+func (acm *AzureChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	return parallelMultiRead(ctx, filePaths, acm.concurrency, acm.Read)
+}
+
+// PrefixSize lists the common prefixes under @prefix, and walks them in parallel with at most concurrency goroutines.
+func (acm *AzureChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	return acm.prefixSizes.get(prefix, func() (int64, int64, error) {
+		return parallelPrefixSize(ctx, prefix, acm.concurrency, acm.WalkWithPrefix)
+	})
+}
+
+// ReadWithPrefix reads all the blobs with @prefix.
+func (acm *AzureChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	filePaths, _, err := acm.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	contents, err := acm.MultiRead(ctx, filePaths)
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, contents, nil
+}
+
+func (acm *AzureChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	return nil, errors.New("this method has not been implemented")
+}
+
+// ReadAt reads @length bytes of the blob at @filePath from @off with a ranged GET.
+func (acm *AzureChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	if off < 0 || length < 0 {
+		return nil, io.EOF
+	}
+	reader, _, err := acm.client.GetBlob(ctx, filePath, off, length)
+	if err != nil {
+		err = wrapAzureErr(filePath, err)
+		log.Warn("failed to get blob range", zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	defer reader.Close()
+	data, err := Read(reader, length)
+	if err != nil {
+		log.Warn("failed to read blob range", zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	return data, nil
+}
+
+// MultiReadAt reads the @ranges of @filePath, the adjacent or overlapping ranges are coalesced into one ranged GET,
+// and the ranged GETs are issued in parallel.
+func (acm *AzureChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	return parallelMultiReadAt(ctx, filePath, ranges, acm.concurrency, acm.ReadAt)
+}
+
+// Remove deletes the blob at @filePath, removing a blob which doesn't exist succeeds like S3.
+func (acm *AzureChunkManager) Remove(ctx context.Context, filePath string) error {
+	err := acm.client.DeleteBlob(ctx, filePath)
+	if err != nil && azure.ErrorCode(err) != azure.ErrCodeBlobNotFound {
+		log.Warn("failed to delete blob", zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// MultiRemove deletes the blobs with @filePaths.
+func (acm *AzureChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	var el errorutil.ErrorList
+	for _, filePath := range filePaths {
+		if err := acm.Remove(ctx, filePath); err != nil {
+			el = append(el, err)
+		}
+	}
+	if len(el) == 0 {
+		return nil
+	}
+	return el
+}
+
+// RemoveWithPrefix removes all the blobs with @prefix, the listed blobs are deleted page by page.
+func (acm *AzureChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	var filePaths []string
+	var removeErr error
+	err := acm.WalkWithPrefix(ctx, prefix, true, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePaths = append(filePaths, chunkObjectInfo.FilePath)
+		if len(filePaths) < azureListPageSize {
+			return true
+		}
+		removeErr = acm.MultiRemove(ctx, filePaths)
+		filePaths = filePaths[:0]
+		return removeErr == nil
+	})
+	if err == nil {
+		err = removeErr
+	}
+	if err == nil && len(filePaths) > 0 {
+		err = acm.MultiRemove(ctx, filePaths)
+	}
+	if err != nil {
+		log.Warn("failed to remove blobs", zap.String("prefix", prefix), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListWithPrefix returns the blobs with @prefix, if @recursive is false, only the blobs without "/" after @prefix
+// and the common prefixes up to the next "/" are returned, like ListWithPrefix of MinioChunkManager.
+func (acm *AzureChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	var filePaths []string
+	var modTimes []time.Time
+	err := acm.WalkWithPrefix(ctx, prefix, recursive, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePaths = append(filePaths, chunkObjectInfo.FilePath)
+		modTimes = append(modTimes, chunkObjectInfo.ModifyTime)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, modTimes, nil
+}
+
+// WalkWithPrefix walks the blobs with @prefix page by page. Blob Storage lists the whole container flat, so the
+// recursive walk takes no request per "directory", and the non-recursive walk visits the blobs and the common
+// prefixes in lexicographical order. The user metadata and blob index tags are returned by the listing itself
+// if listObjectMetadata is set.
+func (acm *AzureChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	opts := azure.ListOptions{
+		Prefix:          prefix,
+		MaxResults:      azureListPageSize,
+		IncludeMetadata: acm.listObjectMetadata,
+		IncludeTags:     acm.listObjectMetadata,
+	}
+	if !recursive {
+		opts.Delimiter = "/"
+	}
+	for {
+		page, err := acm.client.ListBlobs(ctx, opts)
+		if err != nil {
+			log.Warn("failed to list blobs with prefix", zap.String("prefix", prefix), zap.Error(err))
+			return err
+		}
+		blobs, prefixes := page.Blobs, page.BlobPrefixes
+		for len(blobs) > 0 || len(prefixes) > 0 {
+			var info ChunkObjectInfo
+			if len(prefixes) > 0 && (len(blobs) == 0 || prefixes[0] < blobs[0].Name) {
+				info = ChunkObjectInfo{FilePath: prefixes[0]}
+				prefixes = prefixes[1:]
+			} else {
+				info = ChunkObjectInfo{
+					FilePath:     blobs[0].Name,
+					ModifyTime:   blobs[0].LastModified,
+					Size:         blobs[0].Size,
+					UserMetadata: blobs[0].Metadata,
+					Tags:         blobs[0].Tags,
+				}
+				blobs = blobs[1:]
+			}
+			if !walkFunc(info) {
+				return nil
+			}
+		}
+		if page.NextMarker == "" {
+			return nil
+		}
+		opts.Marker = page.NextMarker
+	}
+}
@@ -0,0 +1,537 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/storage/azure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBlob struct {
+	data     []byte
+	etag     string
+	modTime  time.Time
+	metadata map[string]string
+	tags     map[string]string
+}
+
+// fakeBlobService serves the subset of the Blob Storage REST API called by azure.BlobClient.
+type fakeBlobService struct {
+	t         *testing.T
+	container string
+
+	mu            sync.Mutex
+	containerMade bool
+	blobs         map[string]*fakeBlob
+	version       int
+	sigs          []string
+}
+
+func newFakeBlobService(t *testing.T, container string) *fakeBlobService {
+	return &fakeBlobService{t: t, container: container, blobs: make(map[string]*fakeBlob)}
+}
+
+func (s *fakeBlobService) fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func (s *fakeBlobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Equal(s.t, azure.APIVersion, r.Header.Get("x-ms-version"))
+	s.sigs = append(s.sigs, r.URL.Query().Get("sig"))
+
+	name := strings.TrimPrefix(r.URL.Path, "/"+s.container)
+	if name == "" {
+		s.serveContainer(w, r)
+		return
+	}
+	name = strings.TrimPrefix(name, "/")
+	if !s.containerMade {
+		s.fail(w, http.StatusNotFound, azure.ErrCodeContainerNotFound)
+		return
+	}
+	blob := s.blobs[name]
+	switch r.Method {
+	case http.MethodPut:
+		if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch == "*" && blob != nil {
+			s.fail(w, http.StatusConflict, azure.ErrCodeBlobAlreadyExists)
+			return
+		}
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && (blob == nil || ifMatch != `"`+blob.etag+`"`) {
+			s.fail(w, http.StatusPreconditionFailed, azure.ErrCodeConditionNotMet)
+			return
+		}
+		var data []byte
+		if source := r.Header.Get("x-ms-copy-source"); source != "" {
+			u, err := url.Parse(source)
+			require.NoError(s.t, err)
+			src := s.blobs[strings.TrimPrefix(u.Path, "/"+s.container+"/")]
+			if src == nil {
+				s.fail(w, http.StatusNotFound, azure.ErrCodeBlobNotFound)
+				return
+			}
+			data = append([]byte{}, src.data...)
+			w.Header().Set("x-ms-copy-status", "success")
+		} else {
+			require.Equal(s.t, "BlockBlob", r.Header.Get("x-ms-blob-type"))
+			var err error
+			data, err = ioutil.ReadAll(r.Body)
+			require.NoError(s.t, err)
+		}
+		s.version++
+		blob = &fakeBlob{data: data, etag: fmt.Sprintf("0x%X", s.version), modTime: time.Now().UTC(),
+			metadata: make(map[string]string), tags: make(map[string]string)}
+		for key, values := range r.Header {
+			if key = strings.ToLower(key); strings.HasPrefix(key, "x-ms-meta-") {
+				blob.metadata[strings.TrimPrefix(key, "x-ms-meta-")] = values[0]
+			}
+		}
+		tags, err := url.ParseQuery(r.Header.Get("x-ms-tags"))
+		require.NoError(s.t, err)
+		for key := range tags {
+			blob.tags[key] = tags.Get(key)
+		}
+		s.blobs[name] = blob
+		w.Header().Set("ETag", `"`+blob.etag+`"`)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet, http.MethodHead:
+		if blob == nil {
+			s.fail(w, http.StatusNotFound, azure.ErrCodeBlobNotFound)
+			return
+		}
+		data := blob.data
+		status := http.StatusOK
+		if rng := r.Header.Get("x-ms-range"); rng != "" {
+			var start, end int
+			_, err := fmt.Sscanf(rng, "bytes=%d-%d", &start, &end)
+			require.NoError(s.t, err)
+			if start >= len(data) {
+				s.fail(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+				return
+			}
+			if end >= len(data) {
+				end = len(data) - 1
+			}
+			data = data[start : end+1]
+			status = http.StatusPartialContent
+		}
+		w.Header().Set("ETag", `"`+blob.etag+`"`)
+		w.Header().Set("Last-Modified", blob.modTime.Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		for key, value := range blob.metadata {
+			w.Header().Set("x-ms-meta-"+key, value)
+		}
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodDelete:
+		if blob == nil {
+			s.fail(w, http.StatusNotFound, azure.ErrCodeBlobNotFound)
+			return
+		}
+		delete(s.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (s *fakeBlobService) serveContainer(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	require.Equal(s.t, "container", query.Get("restype"))
+	switch {
+	case r.Method == http.MethodPut:
+		if s.containerMade {
+			s.fail(w, http.StatusConflict, azure.ErrCodeContainerAlreadyExists)
+			return
+		}
+		s.containerMade = true
+		w.WriteHeader(http.StatusCreated)
+	case !s.containerMade:
+		s.fail(w, http.StatusNotFound, azure.ErrCodeContainerNotFound)
+	case r.Method == http.MethodHead:
+	case query.Get("comp") == "list":
+		s.list(w, query)
+	}
+}
+
+type fakeListResult struct {
+	XMLName xml.Name `xml:"EnumerationResults"`
+	Blobs   struct {
+		Blob       []fakeListBlob `xml:"Blob"`
+		BlobPrefix []struct {
+			Name string `xml:"Name"`
+		} `xml:"BlobPrefix"`
+	} `xml:"Blobs"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+type fakeListBlob struct {
+	Name          string `xml:"Name"`
+	LastModified  string `xml:"Properties>Last-Modified"`
+	ContentLength int    `xml:"Properties>Content-Length"`
+	Etag          string `xml:"Properties>Etag"`
+	Metadata      struct {
+		Items []fakeMetadata
+	} `xml:"Metadata"`
+	Tags []fakeTag `xml:"Tags>TagSet>Tag"`
+}
+
+type fakeMetadata struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type fakeTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+func (s *fakeBlobService) list(w http.ResponseWriter, query url.Values) {
+	prefix, delimiter, marker := query.Get("prefix"), query.Get("delimiter"), query.Get("marker")
+	maxResults, err := strconv.Atoi(query.Get("maxresults"))
+	require.NoError(s.t, err)
+	include := query.Get("include")
+
+	names := make([]string, 0, len(s.blobs))
+	for name := range s.blobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result fakeListResult
+	seen := make(map[string]bool)
+	count := 0
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) || name < marker {
+			continue
+		}
+		if count == maxResults {
+			result.NextMarker = name
+			break
+		}
+		if delimiter != "" {
+			if idx := strings.Index(name[len(prefix):], delimiter); idx >= 0 {
+				dir := name[:len(prefix)+idx+len(delimiter)]
+				if !seen[dir] {
+					seen[dir] = true
+					result.Blobs.BlobPrefix = append(result.Blobs.BlobPrefix, struct {
+						Name string `xml:"Name"`
+					}{Name: dir})
+					count++
+				}
+				continue
+			}
+		}
+		blob := s.blobs[name]
+		item := fakeListBlob{Name: name, LastModified: blob.modTime.Format(http.TimeFormat),
+			ContentLength: len(blob.data), Etag: blob.etag}
+		if strings.Contains(include, "metadata") {
+			for key, value := range blob.metadata {
+				item.Metadata.Items = append(item.Metadata.Items, fakeMetadata{XMLName: xml.Name{Local: key}, Value: value})
+			}
+		}
+		if strings.Contains(include, "tags") {
+			for key, value := range blob.tags {
+				item.Tags = append(item.Tags, fakeTag{Key: key, Value: value})
+			}
+		}
+		result.Blobs.Blob = append(result.Blobs.Blob, item)
+		count++
+	}
+	data, err := xml.Marshal(result)
+	require.NoError(s.t, err)
+	w.Write(append([]byte("\xef\xbb\xbf"), data...))
+}
+
+func newTestAzureChunkManager(t *testing.T, service *fakeBlobService, opts ...Option) *AzureChunkManager {
+	server := httptest.NewServer(service)
+	t.Cleanup(server.Close)
+	opts = append([]Option{
+		Address(strings.TrimPrefix(server.URL, "http://")),
+		BucketName(service.container),
+		RootPath("files"),
+		CreateBucket(true),
+		Concurrency(4),
+		AzureAuth(azure.AuthModeSASToken, "", "", time.Minute),
+		AzureSASTokenRefresher(func(ctx context.Context) (*azure.Token, error) {
+			return &azure.Token{Value: "sv=2021-08-06&sp=rwdlc&sig=sig", ExpiresOn: time.Now().Add(time.Hour)}, nil
+		}),
+	}, opts...)
+	acm, err := NewAzureChunkManager(context.Background(), opts...)
+	require.NoError(t, err)
+	return acm
+}
+
+func TestAzureChunkManager(t *testing.T) {
+	ctx := context.Background()
+	service := newFakeBlobService(t, "container")
+	acm := newTestAzureChunkManager(t, service)
+	assert.Equal(t, "files", acm.RootPath())
+	// the requests are authorized by the SAS token
+	for _, sig := range service.sigs {
+		assert.Equal(t, "sig", sig)
+	}
+
+	t.Run("write and read", func(t *testing.T) {
+		key := path.Join(acm.RootPath(), "rw", "a")
+		require.NoError(t, acm.Write(ctx, key, []byte("0123456789")))
+
+		data, err := acm.Read(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", string(data))
+		data, err = acm.ReadAt(ctx, key, 2, 3)
+		require.NoError(t, err)
+		assert.Equal(t, "234", string(data))
+		_, err = acm.ReadAt(ctx, key, -1, 3)
+		assert.Error(t, err)
+		results, err := acm.MultiReadAt(ctx, key, []Range{{Offset: 0, Length: 2}, {Offset: 1, Length: 2}, {Offset: 8, Length: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("01"), []byte("12"), []byte("89")}, results)
+		reader, err := acm.Reader(ctx, key)
+		require.NoError(t, err)
+		data, err = ioutil.ReadAll(reader)
+		reader.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", string(data))
+
+		info, err := acm.Stat(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), info.Size)
+		assert.NotEmpty(t, info.ETag)
+		size, err := acm.Size(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), size)
+		p, err := acm.Path(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, key, p)
+
+		missing := path.Join(acm.RootPath(), "rw", "missing")
+		_, err = acm.Read(ctx, missing)
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+		_, err = acm.Stat(ctx, missing)
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+		_, err = acm.Path(ctx, missing)
+		assert.Error(t, err)
+		exist, err := acm.Exist(ctx, missing)
+		require.NoError(t, err)
+		assert.False(t, exist)
+		infos, err := acm.MultiStat(ctx, []string{key, missing})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), infos[0].Size)
+		assert.Nil(t, infos[1])
+
+		require.NoError(t, acm.MultiWrite(ctx, map[string][]byte{key + "1": []byte("b"), key + "2": []byte("c")}))
+		contents, err := acm.MultiRead(ctx, []string{key + "1", key + "2"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("b"), []byte("c")}, contents)
+
+		_, err = acm.Mmap(ctx, key)
+		assert.Error(t, err)
+		_, err = acm.PresignURL(ctx, key, http.MethodGet, time.Minute)
+		assert.Error(t, err)
+	})
+
+	t.Run("conditional writes", func(t *testing.T) {
+		key := path.Join(acm.RootPath(), "cond", "a")
+		require.NoError(t, acm.WriteIfNotExist(ctx, key, []byte("a")))
+		assert.ErrorIs(t, acm.WriteIfNotExist(ctx, key, []byte("b")), ErrObjectExists)
+
+		require.NoError(t, acm.Append(ctx, key, []byte("b")))
+		appended := path.Join(acm.RootPath(), "cond", "b")
+		require.NoError(t, acm.Append(ctx, appended, []byte("c")))
+		data, err := acm.Read(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, "ab", string(data))
+		data, err = acm.Read(ctx, appended)
+		require.NoError(t, err)
+		assert.Equal(t, "c", string(data))
+
+		// an append conflicting with a concurrent write is retried
+		info, err := acm.Stat(ctx, key)
+		require.NoError(t, err)
+		require.NoError(t, acm.Write(ctx, key, []byte("x")))
+		err = acm.client.PutBlob(ctx, key, []byte("y"), azure.PutOptions{IfMatch: info.ETag})
+		assert.Equal(t, azure.ErrCodeConditionNotMet, azure.ErrorCode(err))
+		require.NoError(t, acm.Append(ctx, key, []byte("z")))
+		data, err = acm.Read(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, "xz", string(data))
+	})
+
+	t.Run("copy and move", func(t *testing.T) {
+		src := path.Join(acm.RootPath(), "copy", "src")
+		dst := path.Join(acm.RootPath(), "copy", "dst")
+		require.NoError(t, acm.Write(ctx, src, []byte("data")))
+		require.NoError(t, acm.Copy(ctx, src, dst))
+		require.NoError(t, acm.Copy(ctx, src, src))
+		data, err := acm.Read(ctx, dst)
+		require.NoError(t, err)
+		assert.Equal(t, "data", string(data))
+
+		moved := path.Join(acm.RootPath(), "copy", "moved")
+		require.NoError(t, acm.Move(ctx, src, moved))
+		exist, err := acm.Exist(ctx, src)
+		require.NoError(t, err)
+		assert.False(t, exist)
+		assert.ErrorIs(t, acm.Copy(ctx, src, dst), ErrNoSuchKey)
+	})
+
+	t.Run("list and remove", func(t *testing.T) {
+		listPrefix := path.Join(acm.RootPath(), "list")
+		keys := []string{"a", "b/c", "b/d", "e/f/g"}
+		for _, key := range keys {
+			require.NoError(t, acm.WriteWithOptions(ctx, path.Join(listPrefix, key), []byte(key),
+				WithUserMetadata(map[string]string{"collection": "1"}), WithTags(map[string]string{"segment": "2"})))
+		}
+
+		filePaths, modTimes, err := acm.ListWithPrefix(ctx, listPrefix+"/", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{listPrefix + "/a", listPrefix + "/b/c", listPrefix + "/b/d", listPrefix + "/e/f/g"}, filePaths)
+		assert.Equal(t, len(filePaths), len(modTimes))
+		// one level with the common prefixes
+		filePaths, modTimes, err = acm.ListWithPrefix(ctx, listPrefix+"/", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{listPrefix + "/a", listPrefix + "/b/", listPrefix + "/e/"}, filePaths)
+		assert.False(t, modTimes[0].IsZero())
+		assert.True(t, modTimes[1].IsZero())
+		filePaths, _, err = acm.ListWithPrefix(ctx, listPrefix+"/e/", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{listPrefix + "/e/f/"}, filePaths)
+
+		// the walk goes on page by page and stops early
+		var walked []string
+		err = acm.WalkWithPrefix(ctx, listPrefix, true, func(info ChunkObjectInfo) bool {
+			walked = append(walked, info.FilePath)
+			assert.Nil(t, info.UserMetadata)
+			return len(walked) < 3
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, len(walked))
+
+		bytes, objects, err := acm.PrefixSize(ctx, listPrefix+"/")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1+3+3+5), bytes)
+		assert.Equal(t, int64(4), objects)
+
+		keys, contents, err := acm.ReadWithPrefix(ctx, listPrefix+"/b/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{listPrefix + "/b/c", listPrefix + "/b/d"}, keys)
+		assert.Equal(t, [][]byte{[]byte("b/c"), []byte("b/d")}, contents)
+
+		require.NoError(t, acm.MultiRemove(ctx, []string{listPrefix + "/a", listPrefix + "/missing"}))
+		require.NoError(t, acm.RemoveWithPrefix(ctx, listPrefix+"/b/"))
+		filePaths, _, err = acm.ListWithPrefix(ctx, listPrefix, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{listPrefix + "/e/f/g"}, filePaths)
+		require.NoError(t, acm.Remove(ctx, listPrefix+"/e/f/g"))
+	})
+
+	t.Run("list metadata", func(t *testing.T) {
+		acm := newTestAzureChunkManager(t, service, ListObjectMetadata(true))
+		key := path.Join(acm.RootPath(), "meta", "a")
+		require.NoError(t, acm.WriteWithOptions(ctx, key, []byte("a"),
+			WithUserMetadata(map[string]string{"collection": "1"}), WithTags(map[string]string{"segment": "2"})))
+		var infos []ChunkObjectInfo
+		err := acm.WalkWithPrefix(ctx, path.Join(acm.RootPath(), "meta"), true, func(info ChunkObjectInfo) bool {
+			infos = append(infos, info)
+			return true
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(infos))
+		assert.Equal(t, map[string]string{"collection": "1"}, infos[0].UserMetadata)
+		assert.Equal(t, map[string]string{"segment": "2"}, infos[0].Tags)
+	})
+}
+
+func TestAzureChunkManager_RemoveWithPrefixPages(t *testing.T) {
+	pageSizeBak := azureListPageSize
+	azureListPageSize = 2
+	defer func() { azureListPageSize = pageSizeBak }()
+
+	ctx := context.Background()
+	acm := newTestAzureChunkManager(t, newFakeBlobService(t, "container"))
+	prefix := path.Join(acm.RootPath(), "pages")
+	for i := 0; i < 5; i++ {
+		require.NoError(t, acm.Write(ctx, path.Join(prefix, strconv.Itoa(i)), []byte{byte(i)}))
+	}
+	filePaths, _, err := acm.ListWithPrefix(ctx, prefix, true)
+	require.NoError(t, err)
+	assert.Equal(t, 5, len(filePaths))
+	require.NoError(t, acm.RemoveWithPrefix(ctx, prefix))
+	filePaths, _, err = acm.ListWithPrefix(ctx, prefix, true)
+	require.NoError(t, err)
+	assert.Empty(t, filePaths)
+}
+
+func TestNewAzureChunkManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("container not exist", func(t *testing.T) {
+		server := httptest.NewServer(newFakeBlobService(t, "container"))
+		defer server.Close()
+		attemptsBak := CheckBucketRetryAttempts
+		CheckBucketRetryAttempts = 1
+		defer func() { CheckBucketRetryAttempts = attemptsBak }()
+		_, err := NewAzureChunkManager(ctx, Address(strings.TrimPrefix(server.URL, "http://")), BucketName("container"),
+			AzureAuth(azure.AuthModeSASToken, "", "", 0),
+			AzureSASTokenRefresher(func(ctx context.Context) (*azure.Token, error) {
+				return &azure.Token{Value: "sig=sig"}, nil
+			}))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid auth", func(t *testing.T) {
+		_, err := NewAzureChunkManager(ctx, Address("localhost:0"), BucketName("container"), AzureAuth("accessKey", "", "", 0))
+		assert.Error(t, err)
+		_, err = NewAzureChunkManager(ctx, Address("localhost:0"), BucketName("container"), AzureAuth(azure.AuthModeSASToken, "", "", 0))
+		assert.Error(t, err)
+	})
+
+	t.Run("by factory", func(t *testing.T) {
+		server := httptest.NewServer(newFakeBlobService(t, "container"))
+		defer server.Close()
+		factory := NewChunkManagerFactory("azure", Address(strings.TrimPrefix(server.URL, "http://")),
+			BucketName("container"), CreateBucket(true),
+			AzureAuth(azure.AuthModeSASToken, "", "", 0),
+			AzureSASTokenRefresher(func(ctx context.Context) (*azure.Token, error) {
+				return &azure.Token{Value: "sig=sig"}, nil
+			}))
+		cm, err := factory.NewPersistentStorageChunkManager(ctx)
+		require.NoError(t, err)
+		_, ok := cm.(*AzureChunkManager)
+		assert.True(t, ok)
+	})
+}
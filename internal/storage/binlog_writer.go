@@ -146,6 +146,26 @@ func (writer *baseBinlogWriter) Close() {
 // InsertBinlogWriter is an object to write binlog file which saves insert data.
 type InsertBinlogWriter struct {
 	baseBinlogWriter
+	payloadCompression PayloadCompression
+}
+
+// SetPayloadCompression sets the codec of the payloads of the events written after. The lz4 codec is recorded in
+// the fixed part of the insert events, whose length is recorded in the descriptor event. The vector payloads
+// are loaded by segcore and always compressed by zstd, other codecs are refused for them.
+func (writer *InsertBinlogWriter) SetPayloadCompression(compression PayloadCompression) error {
+	compression, err := ParsePayloadCompression(string(compression))
+	if err != nil {
+		return err
+	}
+	if typeutil.IsVectorType(writer.PayloadDataType) {
+		if compression != PayloadCompressionZstd {
+			return fmt.Errorf("payload compression %s is not supported by %s", compression, writer.PayloadDataType)
+		}
+		return nil
+	}
+	writer.payloadCompression = compression
+	writer.PostHeaderLengths[InsertEventType] = uint8(getEventFixPartSize(InsertEventType) + compression.codeSize())
+	return nil
 }
 
 // NextInsertEventWriter returns an event writer to write insert data to an event.
@@ -167,6 +187,13 @@ func (writer *InsertBinlogWriter) NextInsertEventWriter(dim ...int) (*insertEven
 	if err != nil {
 		return nil, err
 	}
+	if payloadWriter, ok := event.PayloadWriterInterface.(*NativePayloadWriter); ok && writer.payloadCompression != "" {
+		if err := payloadWriter.SetCompression(writer.payloadCompression); err != nil {
+			event.Close()
+			return nil, err
+		}
+		event.payloadCompression = writer.payloadCompression
+	}
 
 	writer.eventWriters = append(writer.eventWriters, event)
 	return event, nil
@@ -0,0 +1,237 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/bits-and-blooms/bloom/v3"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/cache"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+// bloomFilterParams returns the target false positive rate and the max size in bits of the pk bloom filters.
+func bloomFilterParams() (float64, uint) {
+	fpRate := MaxBloomFalsePositive
+	var maxBits uint
+	// the params stay zero if not initialized, e.g. in tools and unit tests
+	params := paramtable.Get()
+	if rate := params.CommonCfg.BloomFilterFalsePositiveRate; rate > 0 && rate < 1 {
+		fpRate = rate
+	}
+	if maxSize := params.CommonCfg.BloomFilterMaxSize; maxSize > 0 {
+		maxBits = uint(maxSize) * 8
+	}
+	return fpRate, maxBits
+}
+
+// estimateBloomFilterParameters estimates the number of bits m and hash functions k for rows entries,
+// m is capped by maxBits if it's positive, and k is recomputed for the capped m to keep the lowest fp rate.
+func estimateBloomFilterParameters(rows uint, fpRate float64, maxBits uint) (uint, uint) {
+	if rows == 0 {
+		rows = 1
+	}
+	m, k := bloom.EstimateParameters(rows, fpRate)
+	if maxBits > 0 && m > maxBits {
+		m = maxBits
+		k = uint(math.Max(1, math.Round(float64(m)/float64(rows)*math.Ln2)))
+	}
+	return m, k
+}
+
+// NewBloomFilterWithRows creates a pk bloom filter sized for rows entries,
+// by the false positive rate and memory budget configured by common.bloomFilter.
+func NewBloomFilterWithRows(rows uint) *bloom.BloomFilter {
+	fpRate, maxBits := bloomFilterParams()
+	return bloom.New(estimateBloomFilterParameters(rows, fpRate, maxBits))
+}
+
+// LoadPkStatistics reads and deserializes the pk stats logs.
+func LoadPkStatistics(ctx context.Context, cm ChunkManager, statsLogs []string) ([]*PkStatistics, error) {
+	values, err := cm.MultiRead(ctx, statsLogs)
+	if err != nil {
+		return nil, err
+	}
+	return deserializePkStatistics(values)
+}
+
+func deserializePkStatistics(values [][]byte) ([]*PkStatistics, error) {
+	blobs := make([]*Blob, 0, len(values))
+	for _, value := range values {
+		blobs = append(blobs, &Blob{Value: value})
+	}
+
+	stats, err := DeserializeStats(blobs)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*PkStatistics, 0, len(stats))
+	for _, stat := range stats {
+		results = append(results, &PkStatistics{
+			PkFilter: stat.BF,
+			MinPK:    stat.MinPk,
+			MaxPK:    stat.MaxPk,
+		})
+	}
+	return results, nil
+}
+
+// PkRanges returns the copies of @stats keeping only the min and max pks, which are kept in memory to check
+// the pks by PkInRange before the bloom filters are loaded lazily.
+func PkRanges(stats []*PkStatistics) []*PkStatistics {
+	ranges := make([]*PkStatistics, 0, len(stats))
+	for _, stat := range stats {
+		ranges = append(ranges, &PkStatistics{MinPK: stat.MinPK, MaxPK: stat.MaxPK})
+	}
+	return ranges
+}
+
+// PkStatsCache keeps the pk statistics of the most recently checked segments,
+// the evicted ones are loaded from their stats logs again on demand.
+// The statistics cached for longer than the validate interval are validated by the ETags of their stats logs,
+// and loaded again if any of them is modified.
+type PkStatsCache struct {
+	cm               ChunkManager
+	lru              *cache.LRU
+	group            singleflight.Group
+	validateInterval time.Duration
+}
+
+// pkStatsCacheEntry is the pk statistics of a segment cached.
+type pkStatsCacheEntry struct {
+	stats []*PkStatistics
+	// etags are the ETags of the stats logs, nil if the statistics are never validated
+	etags []string
+	// validatedAt is the unix nanoseconds the statistics were loaded or validated at last
+	validatedAt int64
+}
+
+// NewPkStatsCache creates a PkStatsCache holding the statistics of at most capacity segments,
+// the statistics are validated every @validateInterval, zero never validates them.
+func NewPkStatsCache(cm ChunkManager, capacity int, validateInterval time.Duration) (*PkStatsCache, error) {
+	lru, err := cache.NewLRU(capacity, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PkStatsCache{
+		cm:               cm,
+		lru:              lru,
+		validateInterval: validateInterval,
+	}, nil
+}
+
+// Get returns the pk statistics of the segment, loads them from statsLogs if not cached, or if any of the
+// stats logs is modified since cached. Concurrent loads of the same segment are merged into one.
+func (c *PkStatsCache) Get(ctx context.Context, segmentID int64, statsLogs []string) ([]*PkStatistics, error) {
+	var cached *pkStatsCacheEntry
+	if value, ok := c.lru.Get(segmentID); ok {
+		cached = value.(*pkStatsCacheEntry)
+		if c.validateInterval <= 0 || time.Since(time.Unix(0, atomic.LoadInt64(&cached.validatedAt))) < c.validateInterval {
+			return cached.stats, nil
+		}
+	}
+
+	value, err, _ := c.group.Do(fmt.Sprint(segmentID), func() (interface{}, error) {
+		if cached != nil && !c.modified(ctx, statsLogs, cached.etags) {
+			atomic.StoreInt64(&cached.validatedAt, time.Now().UnixNano())
+			return cached.stats, nil
+		}
+		entry, err := c.load(ctx, statsLogs)
+		if err != nil {
+			return nil, err
+		}
+		c.lru.Add(segmentID, entry)
+		return entry.stats, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*PkStatistics), nil
+}
+
+func (c *PkStatsCache) load(ctx context.Context, statsLogs []string) (*pkStatsCacheEntry, error) {
+	entry := &pkStatsCacheEntry{validatedAt: time.Now().UnixNano()}
+	if c.validateInterval <= 0 {
+		stats, err := LoadPkStatistics(ctx, c.cm, statsLogs)
+		if err != nil {
+			return nil, err
+		}
+		entry.stats = stats
+		return entry, nil
+	}
+
+	values := make([][]byte, 0, len(statsLogs))
+	entry.etags = make([]string, 0, len(statsLogs))
+	for _, statsLog := range statsLogs {
+		value, etag, err := ReadIfModified(ctx, c.cm, statsLog, "")
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		entry.etags = append(entry.etags, etag)
+	}
+	stats, err := deserializePkStatistics(values)
+	if err != nil {
+		return nil, err
+	}
+	entry.stats = stats
+	return entry, nil
+}
+
+// modified checks whether any of @statsLogs is modified since its ETag in @etags,
+// the statistics cached keep serving if the validation fails.
+func (c *PkStatsCache) modified(ctx context.Context, statsLogs []string, etags []string) bool {
+	if len(statsLogs) != len(etags) {
+		return true
+	}
+	for i, statsLog := range statsLogs {
+		_, _, err := ReadIfModified(ctx, c.cm, statsLog, etags[i])
+		if errors.Is(err, ErrNotModified) {
+			continue
+		}
+		if err != nil {
+			log.Warn("failed to validate the cached pk stats log", zap.String("path", statsLog), zap.Error(err))
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// Remove drops the cached statistics of the segment.
+func (c *PkStatsCache) Remove(segmentID int64) {
+	c.lru.Remove(segmentID)
+}
+
+// Len returns the number of the segments cached.
+func (c *PkStatsCache) Len() int {
+	return c.lru.Len()
+}
+
+// Close releases the cache.
+func (c *PkStatsCache) Close() {
+	c.lru.Close()
+}
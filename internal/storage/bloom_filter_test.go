@@ -0,0 +1,154 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateBloomFilterParameters(t *testing.T) {
+	m, k := estimateBloomFilterParameters(100000, MaxBloomFalsePositive, 0)
+	expectedM, expectedK := bloom.EstimateParameters(100000, MaxBloomFalsePositive)
+	assert.Equal(t, expectedM, m)
+	assert.Equal(t, expectedK, k)
+
+	// smaller segments get smaller filters
+	small, _ := estimateBloomFilterParameters(1000, MaxBloomFalsePositive, 0)
+	assert.Less(t, small, m)
+
+	// capped by the budget, with fewer hash functions
+	capped, cappedK := estimateBloomFilterParameters(100000, MaxBloomFalsePositive, m/2)
+	assert.Equal(t, m/2, capped)
+	assert.Less(t, cappedK, k)
+	assert.GreaterOrEqual(t, cappedK, uint(1))
+
+	// empty segments are valid
+	m, k = estimateBloomFilterParameters(0, MaxBloomFalsePositive, 0)
+	assert.Greater(t, m, uint(0))
+	assert.Greater(t, k, uint(0))
+}
+
+func TestPkStatsCache(t *testing.T) {
+	ctx := context.Background()
+	testRoot := "pk_stats_cache"
+	cm := NewLocalChunkManager(RootPath(localPath))
+	defer cm.RemoveWithPrefix(ctx, testRoot)
+
+	statsLogs := make(map[int64][]string)
+	for segmentID := int64(1); segmentID <= 3; segmentID++ {
+		sw := &StatsWriter{}
+		err := sw.GeneratePrimaryKeyStats(100, schemapb.DataType_Int64, &Int64FieldData{Data: []int64{segmentID * 10, segmentID*10 + 1}})
+		require.NoError(t, err)
+		key := path.Join(testRoot, "stats", fmt.Sprint(segmentID))
+		require.NoError(t, cm.Write(ctx, key, sw.GetBuffer()))
+		statsLogs[segmentID] = []string{key}
+	}
+
+	stats, err := LoadPkStatistics(ctx, cm, statsLogs[1])
+	assert.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.True(t, stats[0].PkExist(NewInt64PrimaryKey(10)))
+	assert.False(t, stats[0].PkExist(NewInt64PrimaryKey(20)))
+	// the ranges are checked without the bloom filters
+	ranges := PkRanges(stats)
+	require.Len(t, ranges, 1)
+	assert.Nil(t, ranges[0].PkFilter)
+	assert.True(t, ranges[0].PkInRange(NewInt64PrimaryKey(11)))
+	assert.False(t, ranges[0].PkInRange(NewInt64PrimaryKey(12)))
+	assert.False(t, ranges[0].PkExist(NewInt64PrimaryKey(10)))
+
+	_, err = NewPkStatsCache(cm, 0, 0)
+	assert.Error(t, err)
+
+	c, err := NewPkStatsCache(cm, 2, 0)
+	require.NoError(t, err)
+	defer c.Close()
+
+	for segmentID := int64(1); segmentID <= 3; segmentID++ {
+		stats, err := c.Get(ctx, segmentID, statsLogs[segmentID])
+		assert.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.True(t, stats[0].PkExist(NewInt64PrimaryKey(segmentID*10+1)))
+	}
+	// the least recently used one is evicted
+	assert.Equal(t, 2, c.Len())
+
+	// loaded again on demand
+	stats, err = c.Get(ctx, 1, statsLogs[1])
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+
+	c.Remove(1)
+	assert.Equal(t, 1, c.Len())
+
+	_, err = c.Get(ctx, 4, []string{path.Join(testRoot, "not_exist")})
+	assert.Error(t, err)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestPkStatsCacheValidate(t *testing.T) {
+	ctx := context.Background()
+	cm := NewMemoryChunkManager(RootPath("pk_stats_cache"))
+	writeStats := func(key string, pks ...int64) {
+		sw := &StatsWriter{}
+		err := sw.GeneratePrimaryKeyStats(100, schemapb.DataType_Int64, &Int64FieldData{Data: pks})
+		require.NoError(t, err)
+		require.NoError(t, cm.Write(ctx, key, sw.GetBuffer()))
+	}
+	statsLogs := []string{"pk_stats_cache/stats/1", "pk_stats_cache/stats/2"}
+	writeStats(statsLogs[0], 10, 11)
+	writeStats(statsLogs[1], 12, 13)
+
+	// validated at every get
+	c, err := NewPkStatsCache(cm, 2, time.Nanosecond)
+	require.NoError(t, err)
+	defer c.Close()
+
+	stats, err := c.Get(ctx, 1, statsLogs)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.True(t, stats[0].PkExist(NewInt64PrimaryKey(10)))
+
+	// not modified, the cached statistics are returned
+	cached, err := c.Get(ctx, 1, statsLogs)
+	require.NoError(t, err)
+	assert.Same(t, stats[0], cached[0])
+
+	// loaded again once any stats log is modified
+	writeStats(statsLogs[1], 20, 21)
+	stats, err = c.Get(ctx, 1, statsLogs)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.NotSame(t, cached[0], stats[0])
+	assert.True(t, stats[1].PkExist(NewInt64PrimaryKey(20)))
+	assert.False(t, stats[1].PkInRange(NewInt64PrimaryKey(12)))
+
+	// the cached statistics keep serving if the validation fails
+	require.NoError(t, cm.Remove(ctx, statsLogs[0]))
+	cached, err = c.Get(ctx, 1, statsLogs)
+	require.NoError(t, err)
+	assert.Same(t, stats[0], cached[0])
+}
@@ -0,0 +1,116 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/atomic"
+)
+
+// maxPooledBufferSize is the max capacity of the buffers put back to the pool,
+// the larger ones are left to GC so that the pool doesn't pin the memory of a few huge objects.
+const maxPooledBufferSize = 64 << 20
+
+var readBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// PooledBuffer is the content of an object read into a pooled buffer.
+// Release must be called once the content is no longer used, the content must not be accessed after that.
+type PooledBuffer struct {
+	buf      *bytes.Buffer
+	released atomic.Bool
+}
+
+// Bytes returns the content of the object.
+func (b *PooledBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// Release puts the buffer back to the pool, it's safe to release a buffer more than once.
+func (b *PooledBuffer) Release() {
+	if b == nil || !b.released.CAS(false, true) {
+		return
+	}
+	if b.buf.Cap() <= maxPooledBufferSize {
+		b.buf.Reset()
+		readBufferPool.Put(b.buf)
+	}
+	b.buf = nil
+}
+
+// ReadInto reads the object @filePath of @cm into @buf, which is grown if it's not large enough,
+// and returns the slice of @buf holding the content. It allows the caller to reuse the buffers across reads.
+func ReadInto(ctx context.Context, cm ChunkManager, filePath string, buf []byte) ([]byte, error) {
+	b := bytes.NewBuffer(buf[:0])
+	if err := readInto(ctx, cm, filePath, b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// ReadPooled reads the object @filePath of @cm into a buffer of the pool, instead of allocating a new slice per object.
+func ReadPooled(ctx context.Context, cm ChunkManager, filePath string) (*PooledBuffer, error) {
+	buf := readBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	if err := readInto(ctx, cm, filePath, buf); err != nil {
+		readBufferPool.Put(buf)
+		return nil, err
+	}
+	return &PooledBuffer{buf: buf}, nil
+}
+
+// MultiReadPooled reads @filePaths into the buffers of the pool with at most @concurrency goroutines,
+// the results keep the order of @filePaths. If any read fails, all the buffers read are released.
+func MultiReadPooled(ctx context.Context, cm ChunkManager, filePaths []string, concurrency int) ([]*PooledBuffer, error) {
+	results, err := parallelMultiDo(ctx, filePaths, concurrency, func(ctx context.Context, filePath string) (*PooledBuffer, error) {
+		return ReadPooled(ctx, cm, filePath)
+	})
+	if err != nil {
+		ReleaseBuffers(results)
+		return nil, err
+	}
+	return results, nil
+}
+
+// ReleaseBuffers releases all the non-nil @buffers.
+func ReleaseBuffers(buffers []*PooledBuffer) {
+	for _, buffer := range buffers {
+		buffer.Release()
+	}
+}
+
+func readInto(ctx context.Context, cm ChunkManager, filePath string, buf *bytes.Buffer) error {
+	reader, err := cm.Reader(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if _, err := buf.ReadFrom(reader); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return WrapErrNoSuchKey(filePath)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferPool(t *testing.T) {
+	ctx := context.Background()
+	testRoot := "buffer_pool"
+	cm := NewLocalChunkManager(RootPath(localPath))
+	defer cm.RemoveWithPrefix(ctx, testRoot)
+
+	contents := map[string][]byte{
+		path.Join(testRoot, "key1"): []byte("value1"),
+		path.Join(testRoot, "key2"): []byte("value22"),
+	}
+	require.NoError(t, cm.MultiWrite(ctx, contents))
+
+	t.Run("ReadPooled", func(t *testing.T) {
+		for filePath, content := range contents {
+			buffer, err := ReadPooled(ctx, cm, filePath)
+			assert.NoError(t, err)
+			assert.Equal(t, content, buffer.Bytes())
+			buffer.Release()
+			// release twice is harmless
+			buffer.Release()
+		}
+
+		_, err := ReadPooled(ctx, cm, path.Join(testRoot, "not_exist"))
+		assert.Error(t, err)
+	})
+
+	t.Run("ReadInto", func(t *testing.T) {
+		key := path.Join(testRoot, "key2")
+		buf := make([]byte, 0, 64)
+		content, err := ReadInto(ctx, cm, key, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, contents[key], content)
+		// the content is read into the given buffer
+		assert.Equal(t, &buf[:1][0], &content[0])
+
+		content, err = ReadInto(ctx, cm, key, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, contents[key], content)
+	})
+
+	t.Run("MultiReadPooled", func(t *testing.T) {
+		filePaths := []string{path.Join(testRoot, "key2"), path.Join(testRoot, "key1")}
+		buffers, err := MultiReadPooled(ctx, cm, filePaths, 2)
+		assert.NoError(t, err)
+		for i, filePath := range filePaths {
+			assert.Equal(t, contents[filePath], buffers[i].Bytes())
+		}
+		ReleaseBuffers(buffers)
+
+		_, err = MultiReadPooled(ctx, cm, append(filePaths, path.Join(testRoot, "not_exist")), 2)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,104 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// chaosConfigRefreshInterval bounds how often ChaosChunkManager reads its config.
+const chaosConfigRefreshInterval = time.Second
+
+// ChaosConfig is the faults injected by ChaosChunkManager, the zero value injects no fault.
+type ChaosConfig struct {
+	// Prefix limits the faults to the keys with the prefix, empty for all keys
+	Prefix string
+	// WriteErrorRate is the probability in [0, 1] of failing a write
+	WriteErrorRate float64
+	// ReadLatency is added to every read, plus a random jitter up to ReadLatencyJitter
+	ReadLatency       time.Duration
+	ReadLatencyJitter time.Duration
+}
+
+// ChaosConfigFunc returns the current ChaosConfig, it's backed by the refreshable configs in production.
+type ChaosConfigFunc func() ChaosConfig
+
+// ChaosChunkManager is a FaultInjectionChunkManager driven by a config read again while running,
+// so that storage fault drills could be started and stopped on a running cluster without restarting it.
+type ChaosChunkManager struct {
+	*FaultInjectionChunkManager
+
+	configFunc      ChaosConfigFunc
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	config      ChaosConfig
+	refreshedAt time.Time
+}
+
+// NewChaosChunkManager returns @cm injecting the faults configured by @configFunc.
+func NewChaosChunkManager(cm ChunkManager, configFunc ChaosConfigFunc) *ChaosChunkManager {
+	ccm := &ChaosChunkManager{
+		FaultInjectionChunkManager: NewFaultInjectionChunkManager(cm, time.Now().UnixNano()),
+		configFunc:                 configFunc,
+		refreshInterval:            chaosConfigRefreshInterval,
+	}
+	ccm.FaultInjectionChunkManager.refresh = ccm.refresh
+	return ccm
+}
+
+// refresh applies the config if it changed, the config is read at most once per refreshInterval.
+func (ccm *ChaosChunkManager) refresh() {
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+	now := time.Now()
+	if now.Sub(ccm.refreshedAt) < ccm.refreshInterval {
+		return
+	}
+	ccm.refreshedAt = now
+
+	config := ccm.configFunc()
+	if config == ccm.config {
+		return
+	}
+	ccm.config = config
+
+	if config == (ChaosConfig{}) {
+		ccm.ClearFaults()
+		log.Info("storage chaos disabled")
+		return
+	}
+	ccm.SetFault(FaultOpWrite, &Fault{
+		Prefix:    config.Prefix,
+		ErrorRate: config.WriteErrorRate,
+	})
+	ccm.SetFault(FaultOpRead, &Fault{
+		Prefix:        config.Prefix,
+		Latency:       config.ReadLatency,
+		LatencyJitter: config.ReadLatencyJitter,
+	})
+	log.Warn("storage chaos enabled",
+		zap.String("prefix", config.Prefix),
+		zap.Float64("writeErrorRate", config.WriteErrorRate),
+		zap.Duration("readLatency", config.ReadLatency),
+		zap.Duration("readLatencyJitter", config.ReadLatencyJitter))
+}
@@ -0,0 +1,72 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosChunkManager(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mu     sync.Mutex
+		config ChaosConfig
+	)
+	setConfig := func(c ChaosConfig) {
+		mu.Lock()
+		defer mu.Unlock()
+		config = c
+	}
+	cm := NewChaosChunkManager(NewLocalChunkManager(RootPath(t.TempDir())), func() ChaosConfig {
+		mu.Lock()
+		defer mu.Unlock()
+		return config
+	})
+	cm.refreshInterval = 0
+	content := []byte("0123456789")
+
+	// no fault
+	require.NoError(t, cm.Write(ctx, "a/b", content))
+
+	setConfig(ChaosConfig{WriteErrorRate: 1, ReadLatency: 50 * time.Millisecond})
+	assert.ErrorIs(t, cm.Write(ctx, "a/c", content), ErrInjectedFault)
+	start := time.Now()
+	got, err := cm.Read(ctx, "a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// limited to the prefix
+	setConfig(ChaosConfig{WriteErrorRate: 1, Prefix: "x/"})
+	assert.NoError(t, cm.Write(ctx, "a/c", content))
+	assert.ErrorIs(t, cm.Write(ctx, "x/c", content), ErrInjectedFault)
+
+	setConfig(ChaosConfig{})
+	assert.NoError(t, cm.Write(ctx, "x/c", content))
+	assert.Equal(t, 2, cm.Injected(FaultOpWrite))
+
+	// the config is not read again within the refresh interval
+	cm.refreshInterval = time.Hour
+	setConfig(ChaosConfig{WriteErrorRate: 1})
+	assert.NoError(t, cm.Write(ctx, "x/d", content))
+}
@@ -0,0 +1,142 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chunkfs mounts the objects of a ChunkManager as a read-only file system by FUSE, so that the binlogs
+// could be inspected by the standard tools, and the exported data could be read in place by the external jobs.
+package chunkfs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// Entry is a file or a directory of ChunkFS.
+type Entry struct {
+	Name string
+	// Key is the key of the object of a file, or the prefix of the objects of a directory, which ends with "/"
+	// except the root directory of the whole storage
+	Key        string
+	Dir        bool
+	Size       int64
+	ModifyTime time.Time
+}
+
+// ChunkFS is the read-only file system of the objects of a ChunkManager, the keys are split into the directories
+// by "/". A directory exists as long as there are objects under it, like the common prefixes of S3.
+type ChunkFS struct {
+	cm   storage.ChunkManager
+	root *Entry
+}
+
+// New returns the ChunkFS of the objects of @cm under @prefix, e.g. the root path of an object storage.
+func New(cm storage.ChunkManager, prefix string) *ChunkFS {
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &ChunkFS{
+		cm:   cm,
+		root: &Entry{Key: prefix, Dir: true},
+	}
+}
+
+// Root returns the root directory.
+func (f *ChunkFS) Root() *Entry {
+	return f.root
+}
+
+// Lookup returns the entry @name in the directory @dir, an error wrapping storage.ErrNoSuchKey is returned
+// if it doesn't exist.
+func (f *ChunkFS) Lookup(ctx context.Context, dir *Entry, name string) (*Entry, error) {
+	key := dir.Key + name
+	// check the directory first, since the local storages stat the directories like the files
+	found := false
+	err := f.cm.WalkWithPrefix(ctx, key+"/", false, func(storage.ChunkObjectInfo) bool {
+		found = true
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return &Entry{Name: name, Key: key + "/", Dir: true}, nil
+	}
+	info, err := f.cm.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{Name: name, Key: key, Size: info.Size, ModifyTime: info.ModifyTime}, nil
+}
+
+// ReadDir returns the entries of the directory @dir.
+func (f *ChunkFS) ReadDir(ctx context.Context, dir *Entry) ([]*Entry, error) {
+	var entries []*Entry
+	err := f.cm.WalkWithPrefix(ctx, dir.Key, false, func(info storage.ChunkObjectInfo) bool {
+		name := strings.TrimPrefix(info.FilePath, dir.Key)
+		if storage.IsCommonPrefix(name) {
+			name = strings.TrimSuffix(name, "/")
+			if name != "" {
+				entries = append(entries, &Entry{Name: name, Key: info.FilePath, Dir: true})
+			}
+			return true
+		}
+		// the keys not in the directory, e.g. the root path itself, are not files of it
+		if name != "" && !strings.Contains(name, "/") {
+			entries = append(entries, &Entry{Name: name, Key: info.FilePath, Size: info.Size, ModifyTime: info.ModifyTime})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Read reads at most @size bytes of the file @file at @off, fewer bytes are returned at the end of the file.
+func (f *ChunkFS) Read(ctx context.Context, file *Entry, off int64, size int) ([]byte, error) {
+	if file.Dir {
+		return nil, errors.New("read a directory")
+	}
+	if off >= file.Size || size <= 0 {
+		return nil, nil
+	}
+	length := int64(size)
+	if off+length > file.Size {
+		length = file.Size - off
+	}
+	return f.cm.ReadAt(ctx, file.Key, off, length)
+}
+
+// MountOptions are the options of Mount.
+type MountOptions struct {
+	// AllowOther allows the other users to access the file system, e.g. the external jobs,
+	// which needs user_allow_other in /etc/fuse.conf
+	AllowOther bool
+	// CacheTTL is how long the kernel caches the entries and attributes
+	CacheTTL time.Duration
+	Debug    bool
+}
+
+// Server is a mounted ChunkFS.
+type Server interface {
+	// Wait waits until the file system is unmounted
+	Wait()
+	Unmount() error
+}
@@ -0,0 +1,112 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunkfs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+func TestChunkFS(t *testing.T) {
+	ctx := context.Background()
+	cm := storage.NewMemoryChunkManager(storage.RootPath("files"))
+	require.NoError(t, cm.MultiWrite(ctx, map[string][]byte{
+		"files/insert_log/1/2/100": []byte("0123456789"),
+		"files/insert_log/1/2/101": []byte("abc"),
+		"files/stats_log/1/2/100":  []byte("stats"),
+		"files/README":             []byte("readme"),
+		"others/secret":            []byte("secret"),
+	}))
+	f := New(cm, "/files/")
+
+	names := func(entries []*Entry) []string {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+		return names
+	}
+
+	t.Run("read dir", func(t *testing.T) {
+		entries, err := f.ReadDir(ctx, f.Root())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"insert_log", "stats_log", "README"}, names(entries))
+		for _, entry := range entries {
+			assert.Equal(t, entry.Name != "README", entry.Dir)
+		}
+
+		dir, err := f.Lookup(ctx, f.Root(), "insert_log")
+		require.NoError(t, err)
+		assert.True(t, dir.Dir)
+		assert.Equal(t, "files/insert_log/", dir.Key)
+		dir, err = f.Lookup(ctx, dir, "1")
+		require.NoError(t, err)
+		dir, err = f.Lookup(ctx, dir, "2")
+		require.NoError(t, err)
+		entries, err = f.ReadDir(ctx, dir)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"100", "101"}, names(entries))
+		for _, entry := range entries {
+			assert.False(t, entry.Dir)
+			assert.False(t, entry.ModifyTime.IsZero())
+		}
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		file, err := f.Lookup(ctx, f.Root(), "README")
+		require.NoError(t, err)
+		assert.False(t, file.Dir)
+		assert.EqualValues(t, 6, file.Size)
+
+		_, err = f.Lookup(ctx, f.Root(), "missing")
+		assert.True(t, errors.Is(err, storage.ErrNoSuchKey))
+		// the objects out of the prefix are not visible
+		_, err = f.Lookup(ctx, f.Root(), "secret")
+		assert.True(t, errors.Is(err, storage.ErrNoSuchKey))
+	})
+
+	t.Run("read", func(t *testing.T) {
+		dir, err := f.Lookup(ctx, f.Root(), "insert_log")
+		require.NoError(t, err)
+		dir, err = f.Lookup(ctx, dir, "1")
+		require.NoError(t, err)
+		dir, err = f.Lookup(ctx, dir, "2")
+		require.NoError(t, err)
+		file, err := f.Lookup(ctx, dir, "100")
+		require.NoError(t, err)
+
+		data, err := f.Read(ctx, file, 2, 4)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("2345"), data)
+		// short read at the end of the file
+		data, err = f.Read(ctx, file, 8, 4096)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("89"), data)
+		data, err = f.Read(ctx, file, 10, 4096)
+		require.NoError(t, err)
+		assert.Empty(t, data)
+
+		_, err = f.Read(ctx, dir, 0, 10)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,159 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package chunkfs
+
+import (
+	"context"
+	"errors"
+	"syscall"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// Mount mounts @f on @mountPoint read-only until the returned server is unmounted.
+func Mount(f *ChunkFS, mountPoint string, opts MountOptions) (Server, error) {
+	cacheTTL := opts.CacheTTL
+	server, err := fs.Mount(mountPoint, &dirNode{fs: f, entry: f.Root()}, &fs.Options{
+		MountOptions: fuse.MountOptions{
+			AllowOther: opts.AllowOther,
+			FsName:     "milvus",
+			Name:       "chunkfs",
+			Options:    []string{"ro"},
+			Debug:      opts.Debug,
+			// mount by the syscall if permitted, the containers running as root usually have no fusermount
+			DirectMount: true,
+		},
+		EntryTimeout: &cacheTTL,
+		AttrTimeout:  &cacheTTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// toErrno converts the error of the storage to the errno of the file system.
+func toErrno(err error) syscall.Errno {
+	switch {
+	case errors.Is(err, storage.ErrNoSuchKey):
+		return syscall.ENOENT
+	case errors.Is(err, context.Canceled):
+		return syscall.EINTR
+	default:
+		log.Warn("chunkfs failed to access storage", zap.Error(err))
+		return syscall.EIO
+	}
+}
+
+func setAttr(out *fuse.Attr, entry *Entry) {
+	if entry.Dir {
+		out.Mode = fuse.S_IFDIR | 0555
+		out.Nlink = 2
+		return
+	}
+	out.Mode = fuse.S_IFREG | 0444
+	out.Nlink = 1
+	out.Size = uint64(entry.Size)
+	out.Blocks = (out.Size + 511) / 512
+	out.SetTimes(&entry.ModifyTime, &entry.ModifyTime, &entry.ModifyTime)
+}
+
+type dirNode struct {
+	fs.Inode
+	fs    *ChunkFS
+	entry *Entry
+}
+
+var (
+	_ fs.NodeLookuper  = (*dirNode)(nil)
+	_ fs.NodeReaddirer = (*dirNode)(nil)
+	_ fs.NodeGetattrer = (*dirNode)(nil)
+)
+
+func (n *dirNode) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	entry, err := n.fs.Lookup(ctx, n.entry, name)
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	setAttr(&out.Attr, entry)
+	if entry.Dir {
+		return n.NewInode(ctx, &dirNode{fs: n.fs, entry: entry}, fs.StableAttr{Mode: fuse.S_IFDIR}), 0
+	}
+	return n.NewInode(ctx, &fileNode{fs: n.fs, entry: entry}, fs.StableAttr{Mode: fuse.S_IFREG}), 0
+}
+
+func (n *dirNode) Readdir(ctx context.Context) (fs.DirStream, syscall.Errno) {
+	entries, err := n.fs.ReadDir(ctx, n.entry)
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	list := make([]fuse.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		mode := uint32(fuse.S_IFREG)
+		if entry.Dir {
+			mode = fuse.S_IFDIR
+		}
+		list = append(list, fuse.DirEntry{Name: entry.Name, Mode: mode})
+	}
+	return fs.NewListDirStream(list), 0
+}
+
+func (n *dirNode) Getattr(ctx context.Context, fh fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	setAttr(&out.Attr, n.entry)
+	return 0
+}
+
+type fileNode struct {
+	fs.Inode
+	fs    *ChunkFS
+	entry *Entry
+}
+
+var (
+	_ fs.NodeOpener    = (*fileNode)(nil)
+	_ fs.NodeReader    = (*fileNode)(nil)
+	_ fs.NodeGetattrer = (*fileNode)(nil)
+)
+
+func (n *fileNode) Open(ctx context.Context, flags uint32) (fs.FileHandle, uint32, syscall.Errno) {
+	if flags&(syscall.O_WRONLY|syscall.O_RDWR|syscall.O_APPEND|syscall.O_TRUNC) != 0 {
+		return nil, 0, syscall.EROFS
+	}
+	// the objects are immutable once written, so the kernel keeps the pages read
+	return nil, fuse.FOPEN_KEEP_CACHE, 0
+}
+
+func (n *fileNode) Read(ctx context.Context, fh fs.FileHandle, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	data, err := n.fs.Read(ctx, n.entry, off, len(dest))
+	if err != nil {
+		return nil, toErrno(err)
+	}
+	return fuse.ReadResultData(data), 0
+}
+
+func (n *fileNode) Getattr(ctx context.Context, fh fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	setAttr(&out.Attr, n.entry)
+	return 0
+}
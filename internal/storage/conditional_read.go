@@ -0,0 +1,105 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// ErrNotModified means the object read by ReadIfModified still has the given ETag, the cached content is valid.
+var ErrNotModified = errors.New("NotModified")
+
+func WrapErrNotModified(key string) error {
+	return fmt.Errorf("%w(key=%s)", ErrNotModified, key)
+}
+
+// ConditionalReader is implemented by the chunk managers which could check the ETag and read the object
+// in one request, so that validating a cached object costs no more than a Stat.
+type ConditionalReader interface {
+	// ReadIfModified reads @filePath and returns its content and ETag, unless its ETag is still @etag,
+	// in which case an error wrapping ErrNotModified is returned. Empty @etag always reads the object.
+	ReadIfModified(ctx context.Context, filePath string, etag string) ([]byte, string, error)
+}
+
+var _ ConditionalReader = (*MinioChunkManager)(nil)
+
+// ReadIfModified reads @filePath with `If-None-Match: @etag`, the object isn't transferred if it's not modified.
+func (mcm *MinioChunkManager) ReadIfModified(ctx context.Context, filePath string, etag string) ([]byte, string, error) {
+	opts := mcm.getObjectOptions()
+	if etag != "" {
+		if err := opts.SetMatchETagExcept(etag); err != nil {
+			return nil, "", err
+		}
+	}
+	object, err := mcm.Client.GetObject(ctx, mcm.bucketName, filePath, opts)
+	if err != nil {
+		log.Warn("failed to get object", zap.String("path", filePath), zap.Error(err))
+		return nil, "", err
+	}
+	defer object.Close()
+
+	objectInfo, err := object.Stat()
+	if err != nil {
+		return nil, "", mcm.conditionalReadError(filePath, err)
+	}
+	data, err := Read(object, objectInfo.Size)
+	if err != nil {
+		return nil, "", mcm.conditionalReadError(filePath, err)
+	}
+	return data, objectInfo.ETag, nil
+}
+
+func (mcm *MinioChunkManager) conditionalReadError(filePath string, err error) error {
+	errResponse := minio.ToErrorResponse(err)
+	switch {
+	case errResponse.StatusCode == http.StatusNotModified:
+		return WrapErrNotModified(filePath)
+	case errResponse.Code == "NoSuchKey":
+		return WrapErrNoSuchKey(filePath)
+	}
+	log.Warn("failed to read object if modified", zap.String("path", filePath), zap.Error(err))
+	return err
+}
+
+// ReadIfModified reads @filePath with @cm unless its ETag is still @etag, see ConditionalReader.
+// The chunk managers which are not ConditionalReaders stat the object before reading it.
+func ReadIfModified(ctx context.Context, cm ChunkManager, filePath string, etag string) ([]byte, string, error) {
+	if reader, ok := cm.(ConditionalReader); ok {
+		return reader.ReadIfModified(ctx, filePath, etag)
+	}
+	info, err := cm.Stat(ctx, filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	if etag != "" && info.ETag == etag {
+		return nil, "", WrapErrNotModified(filePath)
+	}
+	// the object may be rewritten after the stat, then the stale ETag only makes the next call read it again
+	content, err := cm.Read(ctx, filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, info.ETag, nil
+}
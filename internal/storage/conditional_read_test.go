@@ -0,0 +1,133 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testReadIfModified writes @filePath by @writer, and reads it by @cm.
+func testReadIfModified(t *testing.T, writer ChunkManager, cm ChunkManager, filePath string) {
+	ctx := context.Background()
+	require.NoError(t, writer.Write(ctx, filePath, []byte("v1")))
+
+	content, etag, err := ReadIfModified(ctx, cm, filePath, "")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1"), content)
+	assert.NotEmpty(t, etag)
+
+	_, _, err = ReadIfModified(ctx, cm, filePath, etag)
+	assert.ErrorIs(t, err, ErrNotModified)
+
+	require.NoError(t, writer.Write(ctx, filePath, []byte("v2")))
+	content, newETag, err := ReadIfModified(ctx, cm, filePath, etag)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), content)
+	assert.NotEqual(t, etag, newETag)
+
+	_, _, err = ReadIfModified(ctx, cm, filePath+"-missing", etag)
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+}
+
+func TestReadIfModified(t *testing.T) {
+	cm := NewMemoryChunkManager(RootPath("conditional"))
+	testReadIfModified(t, cm, cm, "conditional/a")
+}
+
+// countingConditionalReader counts the conditional reads passed to the wrapped chunk manager.
+type countingConditionalReader struct {
+	ChunkManager
+	calls int
+}
+
+func (c *countingConditionalReader) ReadIfModified(ctx context.Context, filePath string, etag string) ([]byte, string, error) {
+	c.calls++
+	return ReadIfModified(ctx, c.ChunkManager, filePath, etag)
+}
+
+func TestChunkManagerWrappersReadIfModified(t *testing.T) {
+	ctx := context.Background()
+	newInner := func() *countingConditionalReader {
+		return &countingConditionalReader{ChunkManager: NewMemoryChunkManager(RootPath("conditional"))}
+	}
+
+	t.Run("alias", func(t *testing.T) {
+		inner := newInner()
+		a := NewAliasChunkManager(inner, RootPath("conditional"), LegacyRootPaths("legacy"))
+		testReadIfModified(t, a, a, "conditional/a")
+		assert.NotZero(t, inner.calls)
+
+		// the legacy root path is read if the key isn't found under the root path
+		require.NoError(t, inner.Write(ctx, "legacy/b", []byte("legacy")))
+		content, etag, err := a.ReadIfModified(ctx, "conditional/b", "")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("legacy"), content)
+		_, _, err = a.ReadIfModified(ctx, "conditional/b", etag)
+		assert.ErrorIs(t, err, ErrNotModified)
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		inner := newInner()
+		testReadIfModified(t, inner, NewReadOnlyChunkManager(inner), "conditional/a")
+		assert.NotZero(t, inner.calls)
+	})
+
+	t.Run("sub", func(t *testing.T) {
+		inner := newInner()
+		sub, err := NewSubChunkManager(inner, "sub")
+		require.NoError(t, err)
+		testReadIfModified(t, sub, sub, "a")
+		assert.NotZero(t, inner.calls)
+		_, _, err = sub.ReadIfModified(ctx, "../a", "")
+		assert.ErrorIs(t, err, ErrInvalidSubPath)
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		inner := newInner()
+		sink := &memoryAuditSink{}
+		acm := NewAuditChunkManager(inner, sink, "querynode", false)
+		testReadIfModified(t, acm, acm, "conditional/a")
+		assert.NotZero(t, inner.calls)
+		assert.Empty(t, sink.records)
+	})
+
+	t.Run("tenant", func(t *testing.T) {
+		inner := newInner()
+		tenant, err := NewTenantChunkManager(inner, "db1", RootPath("conditional"))
+		require.NoError(t, err)
+		testReadIfModified(t, tenant, tenant, "conditional/a")
+		assert.NotZero(t, inner.calls)
+		exist, err := inner.Exist(ctx, "conditional/tenants/db1/a")
+		require.NoError(t, err)
+		assert.True(t, exist)
+	})
+}
+
+func TestMinioChunkManagerReadIfModified(t *testing.T) {
+	ctx := context.Background()
+	testBucket, err := Params.Load("minio.bucketName")
+	require.NoError(t, err)
+	testCM, err := newMinIOChunkManager(ctx, testBucket, "test-conditional-read")
+	require.NoError(t, err)
+	defer testCM.RemoveWithPrefix(ctx, testCM.RootPath())
+	testReadIfModified(t, testCM, testCM, path.Join(testCM.RootPath(), "a"))
+}
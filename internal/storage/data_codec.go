@@ -25,6 +25,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/apache/arrow/go/v8/arrow"
+
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
 	"github.com/milvus-io/milvus/internal/common"
 	"github.com/milvus-io/milvus/internal/proto/etcdpb"
@@ -255,6 +257,8 @@ type InsertData struct {
 // ${tenant}/insert_log/${collection_id}/${partition_id}/${segment_id}/${field_id}/${log_idx}
 type InsertCodec struct {
 	Schema *etcdpb.CollectionMeta
+	// PayloadCompression is the codec of the scalar payloads, common.binlog.payloadCompression if it's empty
+	PayloadCompression PayloadCompression
 }
 
 // NewInsertCodec creates an InsertCodec with provided collection meta
@@ -265,6 +269,8 @@ func NewInsertCodec(schema *etcdpb.CollectionMeta) *InsertCodec {
 // Serialize transfer insert data to blob. It will sort insert data by timestamp.
 // From schema, it gets all fields.
 // For each field, it will create a binlog writer, and write an event to the binlog.
+// The scalar payloads are compressed by PayloadCompression.
+// The fields are written as parquet binlogs instead if common.binlog.formatVersion is 2.
 // It returns binlog buffer in the end.
 func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID UniqueID, data *InsertData) ([]*Blob, []*Blob, error) {
 	blobs := make([]*Blob, 0)
@@ -289,11 +295,50 @@ func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID Unique
 	}
 	sort.Sort(dataSorter)
 
+	compression := insertCodec.PayloadCompression
+	if compression == "" {
+		var err error
+		if compression, err = defaultPayloadCompression(); err != nil {
+			return nil, nil, err
+		}
+	}
+	formatVersion, rowGroupSize := binlogFormatParams()
 	for _, field := range insertCodec.Schema.Schema.Fields {
 		singleData := data.Data[field.FieldID]
+		blobKey := fmt.Sprintf("%d", field.FieldID)
+
+		// stats fields
+		if field.GetIsPrimaryKey() {
+			statsWriter := &StatsWriter{}
+			err := statsWriter.GeneratePrimaryKeyStats(field.FieldID, field.DataType, singleData)
+			if err != nil {
+				return nil, nil, err
+			}
+			statsBuffer := statsWriter.GetBuffer()
+			statsBlobs = append(statsBlobs, &Blob{
+				Key:   blobKey,
+				Value: statsBuffer,
+			})
+		}
+
+		if formatVersion == BinlogFormatV2 {
+			buffer, err := insertCodec.serializeParquetField(partitionID, segmentID, field, singleData, startTs, endTs, rowGroupSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			blobs = append(blobs, &Blob{
+				Key:   blobKey,
+				Value: buffer,
+			})
+			continue
+		}
 
 		// encode fields
 		writer = NewInsertBinlogWriter(field.DataType, insertCodec.Schema.ID, partitionID, segmentID, field.FieldID)
+		if err := writer.SetPayloadCompression(payloadCompressionOf(compression, field.DataType)); err != nil {
+			writer.Close()
+			return nil, nil, err
+		}
 		var eventWriter *insertEventWriter
 		var err error
 		if typeutil.IsVectorType(field.DataType) {
@@ -418,32 +463,51 @@ func (insertCodec *InsertCodec) Serialize(partitionID UniqueID, segmentID Unique
 			writer.Close()
 			return nil, nil, err
 		}
-		blobKey := fmt.Sprintf("%d", field.FieldID)
 		blobs = append(blobs, &Blob{
 			Key:   blobKey,
 			Value: buffer,
 		})
 		eventWriter.Close()
 		writer.Close()
-
-		// stats fields
-		if field.GetIsPrimaryKey() {
-			statsWriter := &StatsWriter{}
-			err = statsWriter.GeneratePrimaryKeyStats(field.FieldID, field.DataType, singleData)
-			if err != nil {
-				return nil, nil, err
-			}
-			statsBuffer := statsWriter.GetBuffer()
-			statsBlobs = append(statsBlobs, &Blob{
-				Key:   blobKey,
-				Value: statsBuffer,
-			})
-		}
 	}
 
 	return blobs, statsBlobs, nil
 }
 
+// SerializeRecord serializes an insert record built by InsertRecordBuilder or InsertDataToRecord, the same as
+// Serialize. The numeric and vector columns are serialized from the arrow buffers without copying, which are
+// sorted by row id in place, so the record must not be shared.
+func (insertCodec *InsertCodec) SerializeRecord(partitionID UniqueID, segmentID UniqueID, record arrow.Record) ([]*Blob, []*Blob, error) {
+	data, err := RecordToInsertData(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	return insertCodec.Serialize(partitionID, segmentID, data)
+}
+
+// serializeParquetField encodes the field data as a parquet binlog.
+func (insertCodec *InsertCodec) serializeParquetField(partitionID UniqueID, segmentID UniqueID, field *schemapb.FieldSchema,
+	data FieldData, startTs int64, endTs int64, rowGroupSize int64) ([]byte, error) {
+	meta := &ParquetBinlogMeta{
+		CollectionID:   insertCodec.Schema.ID,
+		PartitionID:    partitionID,
+		SegmentID:      segmentID,
+		FieldID:        field.FieldID,
+		FieldName:      field.Name,
+		DataType:       field.DataType,
+		StartTimestamp: typeutil.Timestamp(startTs),
+		EndTimestamp:   typeutil.Timestamp(endTs),
+		OriginalSize:   data.GetMemorySize(),
+	}
+	switch field.DataType {
+	case schemapb.DataType_BinaryVector:
+		meta.Dim = data.(*BinaryVectorFieldData).Dim
+	case schemapb.DataType_FloatVector:
+		meta.Dim = data.(*FloatVectorFieldData).Dim
+	}
+	return WriteParquetBinlog(meta, data, rowGroupSize)
+}
+
 func (insertCodec *InsertCodec) DeserializeAll(blobs []*Blob) (
 	collectionID UniqueID,
 	partitionID UniqueID,
@@ -475,6 +539,14 @@ func (insertCodec *InsertCodec) DeserializeInto(fieldBinlogs []*Blob, rowNum int
 	err error,
 ) {
 	for _, blob := range fieldBinlogs {
+		if BinlogFormatVersion(blob.Value) == BinlogFormatV2 {
+			collectionID, partitionID, segmentID, err = deserializeParquetInto(blob, insertData)
+			if err != nil {
+				return InvalidUniqueID, InvalidUniqueID, InvalidUniqueID, err
+			}
+			continue
+		}
+
 		binlogReader, err := NewBinlogReader(blob.Value)
 		if err != nil {
 			return InvalidUniqueID, InvalidUniqueID, InvalidUniqueID, err
@@ -747,6 +819,36 @@ func (insertCodec *InsertCodec) DeserializeInto(fieldBinlogs []*Blob, rowNum int
 	return collectionID, partitionID, segmentID, nil
 }
 
+// deserializeParquetInto reads the parquet binlog into @insertData.
+func deserializeParquetInto(blob *Blob, insertData *InsertData) (
+	collectionID UniqueID,
+	partitionID UniqueID,
+	segmentID UniqueID,
+	err error,
+) {
+	reader, err := NewParquetBinlogReaderFromBuffer(blob.Value)
+	if err != nil {
+		return InvalidUniqueID, InvalidUniqueID, InvalidUniqueID, err
+	}
+	defer reader.Close()
+
+	data, err := reader.ReadAll()
+	if err != nil {
+		return InvalidUniqueID, InvalidUniqueID, InvalidUniqueID, err
+	}
+	if insertData.Data[reader.FieldID] == nil {
+		insertData.Data[reader.FieldID] = data
+	} else {
+		MergeFieldData(insertData, reader.FieldID, data)
+	}
+	if reader.FieldID == common.TimeStampField {
+		insertData.Infos = append(insertData.Infos, BlobInfo{
+			Length: data.RowNum(),
+		})
+	}
+	return reader.CollectionID, reader.PartitionID, reader.SegmentID, nil
+}
+
 // Deserialize transfer blob back to insert data.
 // From schema, it get all fields.
 // For each field, it will create a binlog reader, and read all event to the buffer.
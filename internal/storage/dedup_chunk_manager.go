@@ -0,0 +1,588 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/exp/mmap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/lock"
+)
+
+// DedupPrefix is the prefix under the root path keeping the content-addressed objects and their references.
+const DedupPrefix = "cas"
+
+const (
+	dedupBlobPrefix = "blobs"
+	dedupRefPrefix  = "refs"
+	// dedupPointerMagic starts every pointer object, binlogs and index files never start with it
+	dedupPointerMagic = "\x00milvus-cas-pointer\x00"
+	// maxDedupPointerSize is an upper bound of the pointer object size, larger objects are never pointers
+	maxDedupPointerSize = 512
+)
+
+// dedupPointer is written at the path of a deduplicated object, it points to the blob holding the content.
+type dedupPointer struct {
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+}
+
+// DedupChunkManager stores the objects no smaller than a threshold by the sha256 of their contents,
+// so that the byte-identical files produced by compactions and replications are stored only once.
+//
+// The content is kept in a blob object named by the hash, the path written by the caller only keeps
+// a small pointer object, and every pointer owns a reference object under the refs prefix of the hash.
+// A reference is added before the blob and the pointer are written, and removed after the pointer is removed,
+// so a blob is only collected by CollectGarbage once it is left without references.
+// The references of a blob are listed again right before it's removed, under the lock of its hash,
+// which the writes of the same process take while they reference the blob.
+// Objects smaller than the threshold, and objects written before dedup is enabled, are read as they are.
+type DedupChunkManager struct {
+	ChunkManager
+	root        string
+	minSize     int64
+	concurrency int
+
+	// hashLocks are read-locked by the writes referencing a blob and locked by CollectGarbage removing it
+	hashLocks *lock.KeyLock
+
+	mu sync.Mutex
+	// unreferenced records since when a blob is seen without references by CollectGarbage
+	unreferenced map[string]time.Time
+}
+
+var _ ChunkManager = (*DedupChunkManager)(nil)
+
+// NewDedupChunkManager returns a ChunkManager deduplicating the objects written to @cm,
+// the threshold of the object size is set by the Dedup option.
+func NewDedupChunkManager(cm ChunkManager, opts ...Option) *DedupChunkManager {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return &DedupChunkManager{
+		ChunkManager: cm,
+		root:         path.Join(cm.RootPath(), DedupPrefix),
+		minSize:      c.dedupMinSize,
+		concurrency:  c.concurrency,
+		hashLocks:    lock.NewKeyLock(),
+		unreferenced: make(map[string]time.Time),
+	}
+}
+
+// Unwrap returns the wrapped chunk manager.
+func (d *DedupChunkManager) Unwrap() ChunkManager {
+	return d.ChunkManager
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func (d *DedupChunkManager) blobPath(hash string) string {
+	return path.Join(d.root, dedupBlobPrefix, hash[:2], hash)
+}
+
+func (d *DedupChunkManager) refPrefix(hash string) string {
+	return path.Join(d.root, dedupRefPrefix, hash) + "/"
+}
+
+// refPath returns the reference of @filePath to the blob of @hash, the file path is hashed to keep the key short.
+func (d *DedupChunkManager) refPath(hash string, filePath string) string {
+	return d.refPrefix(hash) + contentHash([]byte(filePath))[:32]
+}
+
+// isInternal returns true if @filePath is a blob or a reference.
+func (d *DedupChunkManager) isInternal(filePath string) bool {
+	root := strings.TrimLeft(d.root, "/") + "/"
+	return strings.HasPrefix(strings.TrimLeft(filePath, "/"), root)
+}
+
+func encodeDedupPointer(p *dedupPointer) []byte {
+	content, _ := json.Marshal(p)
+	return append([]byte(dedupPointerMagic), content...)
+}
+
+// decodeDedupPointer returns nil if @content is not a pointer.
+func decodeDedupPointer(content []byte) *dedupPointer {
+	if len(content) > maxDedupPointerSize || !bytes.HasPrefix(content, []byte(dedupPointerMagic)) {
+		return nil
+	}
+	p := &dedupPointer{}
+	if err := json.Unmarshal(content[len(dedupPointerMagic):], p); err != nil || len(p.Hash) != sha256.Size*2 {
+		return nil
+	}
+	return p
+}
+
+// resolve returns the pointer written at @filePath, nil if @filePath is a plain object.
+func (d *DedupChunkManager) resolve(ctx context.Context, filePath string) (*dedupPointer, ObjectInfo, error) {
+	info, err := d.ChunkManager.Stat(ctx, filePath)
+	if err != nil {
+		return nil, info, err
+	}
+	if info.Size > maxDedupPointerSize {
+		return nil, info, nil
+	}
+	content, err := d.ChunkManager.Read(ctx, filePath)
+	if err != nil {
+		return nil, info, err
+	}
+	return decodeDedupPointer(content), info, nil
+}
+
+// target returns the path holding the content of @filePath.
+func (d *DedupChunkManager) target(ctx context.Context, filePath string) (string, error) {
+	p, _, err := d.resolve(ctx, filePath)
+	if err != nil {
+		return "", err
+	}
+	if p == nil {
+		return filePath, nil
+	}
+	return d.blobPath(p.Hash), nil
+}
+
+// putBlob references the blob of @content by @filePath, and writes the blob if it doesn't exist.
+func (d *DedupChunkManager) putBlob(ctx context.Context, filePath string, content []byte) (*dedupPointer, error) {
+	p := &dedupPointer{Hash: contentHash(content), Size: int64(len(content))}
+	d.hashLocks.RLock(p.Hash)
+	defer d.hashLocks.RUnlock(p.Hash)
+	if err := d.ChunkManager.Write(ctx, d.refPath(p.Hash, filePath), []byte(filePath)); err != nil {
+		return nil, err
+	}
+	err := d.ChunkManager.WriteIfNotExist(ctx, d.blobPath(p.Hash), content)
+	if err != nil && !errors.Is(err, ErrObjectExists) {
+		return nil, err
+	}
+	return p, nil
+}
+
+// unref removes the reference of @filePath, the blob is left to CollectGarbage.
+func (d *DedupChunkManager) unref(ctx context.Context, p *dedupPointer, filePath string) error {
+	if p == nil {
+		return nil
+	}
+	return d.ChunkManager.Remove(ctx, d.refPath(p.Hash, filePath))
+}
+
+func (d *DedupChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	target, err := d.target(ctx, filePath)
+	if err != nil {
+		return "", err
+	}
+	return d.ChunkManager.Path(ctx, target)
+}
+
+func (d *DedupChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	p, info, err := d.resolve(ctx, filePath)
+	if err != nil {
+		return 0, err
+	}
+	if p == nil {
+		return info.Size, nil
+	}
+	return p.Size, nil
+}
+
+// Stat returns the size of the content for a deduplicated object, and its ETag is the content hash.
+func (d *DedupChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	p, info, err := d.resolve(ctx, filePath)
+	if err != nil || p == nil {
+		return info, err
+	}
+	info.Size = p.Size
+	info.ETag = p.Hash
+	return info, nil
+}
+
+// MultiStat stats the objects one by one in parallel, since the pointer objects have to be resolved.
+func (d *DedupChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	return parallelMultiStat(ctx, filePaths, d.concurrency, d.Stat)
+}
+
+func (d *DedupChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	return d.WriteWithOptions(ctx, filePath, content)
+}
+
+// WriteWithOptions applies @opts to the pointer object if the content is deduplicated.
+// The reference to the overwritten content is left to CollectGarbage.
+func (d *DedupChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	if int64(len(content)) < d.minSize {
+		return d.ChunkManager.WriteWithOptions(ctx, filePath, content, opts...)
+	}
+	p, err := d.putBlob(ctx, filePath, content)
+	if err != nil {
+		return err
+	}
+	return d.ChunkManager.WriteWithOptions(ctx, filePath, encodeDedupPointer(p), opts...)
+}
+
+func (d *DedupChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	if int64(len(content)) < d.minSize {
+		return d.ChunkManager.WriteIfNotExist(ctx, filePath, content)
+	}
+	exist, err := d.ChunkManager.Exist(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return WrapErrObjectExists(filePath)
+	}
+	p, err := d.putBlob(ctx, filePath, content)
+	if err != nil {
+		return err
+	}
+	err = d.ChunkManager.WriteIfNotExist(ctx, filePath, encodeDedupPointer(p))
+	if errors.Is(err, ErrObjectExists) {
+		// the reference is owned by the pointer written by others
+		existing, _, resolveErr := d.resolve(ctx, filePath)
+		if resolveErr == nil && (existing == nil || existing.Hash != p.Hash) {
+			_ = d.unref(ctx, p, filePath)
+		}
+	}
+	return err
+}
+
+func (d *DedupChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	for filePath, content := range contents {
+		if err := d.Write(ctx, filePath, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DedupChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	exist, err := d.ChunkManager.Exist(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	var old []byte
+	if exist {
+		old, err = d.Read(ctx, filePath)
+		if err != nil {
+			return err
+		}
+	}
+	return d.Write(ctx, filePath, append(old, content...))
+}
+
+// Copy only copies the pointer if @srcFilePath is deduplicated.
+func (d *DedupChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	p, _, err := d.resolve(ctx, srcFilePath)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return d.ChunkManager.Copy(ctx, srcFilePath, dstFilePath)
+	}
+	if err := d.ChunkManager.Write(ctx, d.refPath(p.Hash, dstFilePath), []byte(dstFilePath)); err != nil {
+		return err
+	}
+	return d.ChunkManager.Write(ctx, dstFilePath, encodeDedupPointer(p))
+}
+
+func (d *DedupChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	p, _, err := d.resolve(ctx, srcFilePath)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return d.ChunkManager.Move(ctx, srcFilePath, dstFilePath)
+	}
+	if err := d.Copy(ctx, srcFilePath, dstFilePath); err != nil {
+		return err
+	}
+	return d.Remove(ctx, srcFilePath)
+}
+
+// PresignURL presigns the URLs reading the blob of a deduplicated object,
+// the URLs writing @filePath write a plain object, which is read as it is.
+func (d *DedupChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	if method == http.MethodGet || method == http.MethodHead {
+		target, err := d.target(ctx, filePath)
+		if err != nil {
+			return "", err
+		}
+		filePath = target
+	}
+	return d.ChunkManager.PresignURL(ctx, filePath, method, expiry)
+}
+
+func (d *DedupChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	content, err := d.ChunkManager.Read(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if p := decodeDedupPointer(content); p != nil {
+		return d.ChunkManager.Read(ctx, d.blobPath(p.Hash))
+	}
+	return content, nil
+}
+
+func (d *DedupChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	target, err := d.target(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return d.ChunkManager.Reader(ctx, target)
+}
+
+func (d *DedupChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	return parallelMultiRead(ctx, filePaths, d.concurrency, d.Read)
+}
+
+// ListWithPrefix lists the paths written by callers, the blobs and references are never listed.
+func (d *DedupChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	paths, modTimes, err := d.ChunkManager.ListWithPrefix(ctx, prefix, recursive)
+	if err != nil {
+		return nil, nil, err
+	}
+	filteredPaths := make([]string, 0, len(paths))
+	filteredModTimes := make([]time.Time, 0, len(modTimes))
+	for i, filePath := range paths {
+		if d.isInternal(filePath) {
+			continue
+		}
+		filteredPaths = append(filteredPaths, filePath)
+		filteredModTimes = append(filteredModTimes, modTimes[i])
+	}
+	return filteredPaths, filteredModTimes, nil
+}
+
+func (d *DedupChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	return d.ChunkManager.WalkWithPrefix(ctx, prefix, recursive, func(chunkObjectInfo ChunkObjectInfo) bool {
+		if d.isInternal(chunkObjectInfo.FilePath) {
+			return true
+		}
+		return walkFunc(chunkObjectInfo)
+	})
+}
+
+// PrefixSize counts the deduplicated objects as their pointers, the shared blobs are not counted.
+func (d *DedupChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	return parallelPrefixSize(ctx, prefix, d.concurrency, d.WalkWithPrefix)
+}
+
+func (d *DedupChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	paths, _, err := d.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	contents, err := d.MultiRead(ctx, paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paths, contents, nil
+}
+
+func (d *DedupChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	target, err := d.target(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return d.ChunkManager.Mmap(ctx, target)
+}
+
+func (d *DedupChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	target, err := d.target(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return d.ChunkManager.ReadAt(ctx, target, off, length)
+}
+
+func (d *DedupChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	target, err := d.target(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return d.ChunkManager.MultiReadAt(ctx, target, ranges)
+}
+
+// Remove removes @filePath and its reference, the blob is removed by CollectGarbage once it has no references.
+func (d *DedupChunkManager) Remove(ctx context.Context, filePath string) error {
+	p, _, err := d.resolve(ctx, filePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := d.ChunkManager.Remove(ctx, filePath); err != nil {
+		return err
+	}
+	return d.unref(ctx, p, filePath)
+}
+
+func (d *DedupChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	for _, filePath := range filePaths {
+		if err := d.Remove(ctx, filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DedupChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	paths, _, err := d.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return err
+	}
+	return d.MultiRemove(ctx, paths)
+}
+
+// DedupGCStats is the result of a round of DedupChunkManager.CollectGarbage.
+type DedupGCStats struct {
+	Blobs        int
+	References   int
+	RemovedRefs  int
+	RemovedBlobs int
+}
+
+// CollectGarbage removes the references whose pointers are gone or overwritten, which are left by crashes,
+// and the blobs without references. Only the references older than @tolerance are checked,
+// so that the writes in progress are not interrupted, and a blob is only removed after it stays
+// without references for @tolerance, since a writer may reference an existing blob at any time.
+func (d *DedupChunkManager) CollectGarbage(ctx context.Context, tolerance time.Duration) (DedupGCStats, error) {
+	var stats DedupGCStats
+	refCounts := make(map[string]int)
+	var walkErr error
+	err := d.ChunkManager.WalkWithPrefix(ctx, path.Join(d.root, dedupRefPrefix)+"/", true, func(info ChunkObjectInfo) bool {
+		hash := path.Base(path.Dir(info.FilePath))
+		stats.References++
+		if time.Since(info.ModifyTime) <= tolerance {
+			refCounts[hash]++
+			return true
+		}
+		valid, err := d.isValidRef(ctx, hash, info.FilePath)
+		if err != nil {
+			walkErr = err
+			return false
+		}
+		if valid {
+			refCounts[hash]++
+			return true
+		}
+		if err := d.ChunkManager.Remove(ctx, info.FilePath); err != nil {
+			log.Warn("failed to remove dangling dedup reference", zap.String("path", info.FilePath), zap.Error(err))
+			refCounts[hash]++
+			return true
+		}
+		stats.RemovedRefs++
+		return true
+	})
+	if err == nil {
+		err = walkErr
+	}
+	if err != nil {
+		return stats, err
+	}
+
+	now := time.Now()
+	seen := make(map[string]struct{})
+	err = d.ChunkManager.WalkWithPrefix(ctx, path.Join(d.root, dedupBlobPrefix)+"/", true, func(info ChunkObjectInfo) bool {
+		hash := path.Base(info.FilePath)
+		stats.Blobs++
+		seen[hash] = struct{}{}
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if refCounts[hash] > 0 {
+			delete(d.unreferenced, hash)
+			return true
+		}
+		since, ok := d.unreferenced[hash]
+		if !ok {
+			d.unreferenced[hash] = now
+			return true
+		}
+		if now.Sub(since) <= tolerance || time.Since(info.ModifyTime) <= tolerance {
+			return true
+		}
+		removed, err := d.removeBlob(ctx, hash, info.FilePath)
+		if err != nil {
+			log.Warn("failed to remove dedup blob", zap.String("path", info.FilePath), zap.Error(err))
+			return true
+		}
+		delete(d.unreferenced, hash)
+		if removed {
+			stats.RemovedBlobs++
+		}
+		return true
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	d.mu.Lock()
+	for hash := range d.unreferenced {
+		if _, ok := seen[hash]; !ok {
+			delete(d.unreferenced, hash)
+		}
+	}
+	d.mu.Unlock()
+	return stats, nil
+}
+
+// removeBlob removes the blob of @hash at @blobPath if it's still without references, the references counted
+// by the walk are stale once a write references the blob again, so they are listed again under the hash lock.
+// A write of another process may still reference the blob between the listing and the removal,
+// which is unlikely as the blob has been without references for the gc tolerance.
+func (d *DedupChunkManager) removeBlob(ctx context.Context, hash string, blobPath string) (bool, error) {
+	d.hashLocks.Lock(hash)
+	defer d.hashLocks.Unlock(hash)
+	refs, _, err := d.ChunkManager.ListWithPrefix(ctx, d.refPrefix(hash), true)
+	if err != nil {
+		return false, err
+	}
+	if len(refs) > 0 {
+		return false, nil
+	}
+	return true, d.ChunkManager.Remove(ctx, blobPath)
+}
+
+// isValidRef returns true if the pointer owning the reference at @refPath still points to the blob of @hash.
+func (d *DedupChunkManager) isValidRef(ctx context.Context, hash string, refPath string) (bool, error) {
+	filePath, err := d.ChunkManager.Read(ctx, refPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read dedup reference %s: %w", refPath, err)
+	}
+	p, _, err := d.resolve(ctx, string(filePath))
+	if errors.Is(err, ErrNoSuchKey) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return p != nil && p.Hash == hash, nil
+}
@@ -0,0 +1,157 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countWithPrefix(t *testing.T, cm ChunkManager, prefix string) int {
+	count := 0
+	err := cm.WalkWithPrefix(context.Background(), prefix, true, func(ChunkObjectInfo) bool {
+		count++
+		return true
+	})
+	require.NoError(t, err)
+	return count
+}
+
+func TestDedupChunkManager(t *testing.T) {
+	ctx := context.Background()
+	cm := NewLocalChunkManager(RootPath(t.TempDir()))
+	dcm := NewDedupChunkManager(cm, Dedup(true, 16))
+	blobs := path.Join(dcm.root, dedupBlobPrefix) + "/"
+	refs := path.Join(dcm.root, dedupRefPrefix) + "/"
+
+	content := bytes.Repeat([]byte("binlog"), 10)
+	require.NoError(t, dcm.Write(ctx, "a/1", content))
+	require.NoError(t, dcm.Write(ctx, "b/1", content))
+	require.NoError(t, dcm.Write(ctx, "a/small", []byte("small")))
+	assert.Equal(t, 1, countWithPrefix(t, cm, blobs))
+	assert.Equal(t, 2, countWithPrefix(t, cm, refs))
+
+	// small objects are stored as they are
+	raw, err := cm.Read(ctx, "a/small")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("small"), raw)
+
+	for _, filePath := range []string{"a/1", "b/1"} {
+		read, err := dcm.Read(ctx, filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, content, read)
+		size, err := dcm.Size(ctx, filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(content)), size)
+		info, err := dcm.Stat(ctx, filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, contentHash(content), info.ETag)
+		part, err := dcm.ReadAt(ctx, filePath, 6, 6)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("binlog"), part)
+	}
+
+	paths, _, err := dcm.ListWithPrefix(ctx, "", true)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 3)
+
+	require.NoError(t, dcm.Copy(ctx, "a/1", "c/1"))
+	assert.Equal(t, 1, countWithPrefix(t, cm, blobs))
+	assert.Equal(t, 3, countWithPrefix(t, cm, refs))
+	require.NoError(t, dcm.Move(ctx, "c/1", "c/2"))
+	assert.Equal(t, 3, countWithPrefix(t, cm, refs))
+	read, err := dcm.Read(ctx, "c/2")
+	assert.NoError(t, err)
+	assert.Equal(t, content, read)
+
+	err = dcm.WriteIfNotExist(ctx, "a/1", content)
+	assert.ErrorIs(t, err, ErrObjectExists)
+	assert.Equal(t, 3, countWithPrefix(t, cm, refs))
+
+	require.NoError(t, dcm.Remove(ctx, "a/1"))
+	require.NoError(t, dcm.MultiRemove(ctx, []string{"b/1", "c/2"}))
+	assert.Equal(t, 0, countWithPrefix(t, cm, refs))
+	assert.Equal(t, 1, countWithPrefix(t, cm, blobs))
+
+	// the blob is removed once it stays unreferenced for the tolerance
+	stats, err := dcm.CollectGarbage(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Blobs)
+	assert.Equal(t, 0, stats.RemovedBlobs)
+	stats, err = dcm.CollectGarbage(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.RemovedBlobs)
+	assert.Equal(t, 0, countWithPrefix(t, cm, blobs))
+}
+
+func TestDedupChunkManager_DanglingReference(t *testing.T) {
+	ctx := context.Background()
+	cm := NewLocalChunkManager(RootPath(t.TempDir()))
+	dcm := NewDedupChunkManager(cm, Dedup(true, 1))
+	refs := path.Join(dcm.root, dedupRefPrefix) + "/"
+
+	require.NoError(t, dcm.Write(ctx, "a/1", []byte("old content")))
+	// overwriting leaves the reference to the old content
+	require.NoError(t, dcm.Write(ctx, "a/1", []byte("new content")))
+	assert.Equal(t, 2, countWithPrefix(t, cm, refs))
+	// removing the pointer directly leaves its reference as well
+	require.NoError(t, dcm.Write(ctx, "a/2", []byte("other content")))
+	require.NoError(t, cm.Remove(ctx, "a/2"))
+
+	stats, err := dcm.CollectGarbage(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.References)
+	assert.Equal(t, 2, stats.RemovedRefs)
+	assert.Equal(t, 1, countWithPrefix(t, cm, refs))
+	stats, err = dcm.CollectGarbage(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.RemovedBlobs)
+
+	read, err := dcm.Read(ctx, "a/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("new content"), read)
+}
+
+func TestDedupChunkManager_ReferencedBeforeRemoval(t *testing.T) {
+	ctx := context.Background()
+	cm := NewLocalChunkManager(RootPath(t.TempDir()))
+	dcm := NewDedupChunkManager(cm, Dedup(true, 1))
+	blobs := path.Join(dcm.root, dedupBlobPrefix) + "/"
+
+	content := []byte("binlog")
+	hash := contentHash(content)
+	require.NoError(t, dcm.Write(ctx, "a/1", content))
+	require.NoError(t, dcm.Remove(ctx, "a/1"))
+	stats, err := dcm.CollectGarbage(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stats.RemovedBlobs)
+
+	// a write references the blob after the walk counted the references
+	require.NoError(t, dcm.Write(ctx, "b/1", content))
+	removed, err := dcm.removeBlob(ctx, hash, dcm.blobPath(hash))
+	assert.NoError(t, err)
+	assert.False(t, removed)
+	assert.Equal(t, 1, countWithPrefix(t, cm, blobs))
+	read, err := dcm.Read(ctx, "b/1")
+	assert.NoError(t, err)
+	assert.Equal(t, content, read)
+}
@@ -0,0 +1,234 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/common"
+)
+
+const (
+	deletionVectorMagic   uint32 = 0x564c4544 // "DELV"
+	deletionVectorVersion uint32 = 1
+)
+
+// deletedRow is the deletion of the row at some offset of a sealed segment.
+type deletedRow struct {
+	pk PrimaryKey
+	ts Timestamp
+}
+
+// DeletionVector marks the deleted rows of a sealed segment by their offsets, so that the deletions
+// can be applied by a bitmap instead of matching the primary keys against the delete records.
+// The primary keys and timestamps of the deletions are kept for time travel and compaction.
+type DeletionVector struct {
+	numRows int64
+	rows    map[int64]deletedRow
+}
+
+// NewDeletionVector returns an empty deletion vector for a segment with @numRows rows.
+func NewDeletionVector(numRows int64) *DeletionVector {
+	return &DeletionVector{
+		numRows: numRows,
+		rows:    make(map[int64]deletedRow),
+	}
+}
+
+// NumRows returns the row number of the segment.
+func (dv *DeletionVector) NumRows() int64 {
+	return dv.numRows
+}
+
+// Count returns the number of deleted rows.
+func (dv *DeletionVector) Count() int64 {
+	return int64(len(dv.rows))
+}
+
+// Contains returns true if the row at @offset is deleted.
+func (dv *DeletionVector) Contains(offset int64) bool {
+	_, ok := dv.rows[offset]
+	return ok
+}
+
+// Offsets returns the offsets of the deleted rows in ascending order.
+func (dv *DeletionVector) Offsets() []int64 {
+	offsets := make([]int64, 0, len(dv.rows))
+	for offset := range dv.rows {
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	return offsets
+}
+
+// DeleteData returns the deletions in the order of the row offsets.
+func (dv *DeletionVector) DeleteData() *DeleteData {
+	data := &DeleteData{}
+	for _, offset := range dv.Offsets() {
+		row := dv.rows[offset]
+		data.Append(row.pk, row.ts)
+	}
+	return data
+}
+
+// MaxTimestamp returns the max timestamp of the deletions.
+func (dv *DeletionVector) MaxTimestamp() Timestamp {
+	var maxTs Timestamp
+	for _, row := range dv.rows {
+		if row.ts > maxTs {
+			maxTs = row.ts
+		}
+	}
+	return maxTs
+}
+
+// Merge marks the rows deleted by @deletes, @pks and @tss are the primary keys and insert timestamps
+// of the segment rows in offset order. A delete record doesn't delete the rows inserted after it,
+// the records not matching any row are ignored. It returns the number of newly deleted rows.
+func (dv *DeletionVector) Merge(pks []PrimaryKey, tss []Timestamp, deletes *DeleteData) (int64, error) {
+	if int64(len(pks)) != dv.numRows || len(pks) != len(tss) {
+		return 0, fmt.Errorf("row number mismatch, deletion vector: %d, pks: %d, timestamps: %d", dv.numRows, len(pks), len(tss))
+	}
+	if deletes == nil || deletes.RowCount == 0 {
+		return 0, nil
+	}
+
+	deleted := make(map[interface{}][]int, deletes.RowCount)
+	for i, pk := range deletes.Pks {
+		deleted[pk.GetValue()] = append(deleted[pk.GetValue()], i)
+	}
+
+	var count int64
+	for offset, pk := range pks {
+		for _, i := range deleted[pk.GetValue()] {
+			ts := deletes.Tss[i]
+			if tss[offset] > ts {
+				continue
+			}
+			// keep the earliest deletion of the row
+			if row, ok := dv.rows[int64(offset)]; ok {
+				if ts < row.ts {
+					dv.rows[int64(offset)] = deletedRow{pk: pk, ts: ts}
+				}
+				continue
+			}
+			dv.rows[int64(offset)] = deletedRow{pk: pk, ts: ts}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeletionVectorCodec serializes and deserializes the deletion vector.
+// The layout is: magic | version | row number | bitmap of deleted rows | length of deltalog | deltalog,
+// where the deltalog keeps the deletions in the order of the set bits.
+type DeletionVectorCodec struct{}
+
+// NewDeletionVectorCodec returns a DeletionVectorCodec.
+func NewDeletionVectorCodec() *DeletionVectorCodec {
+	return &DeletionVectorCodec{}
+}
+
+// Serialize transfers the deletion vector to blob.
+func (codec *DeletionVectorCodec) Serialize(collectionID, partitionID, segmentID UniqueID, dv *DeletionVector) (*Blob, error) {
+	words := make([]uint64, (dv.numRows+63)/64)
+	for offset := range dv.rows {
+		words[offset/64] |= 1 << uint(offset%64)
+	}
+
+	var deltalog []byte
+	if dv.Count() > 0 {
+		blob, err := NewDeleteCodec().Serialize(collectionID, partitionID, segmentID, dv.DeleteData())
+		if err != nil {
+			return nil, err
+		}
+		deltalog = blob.Value
+	}
+
+	buf := new(bytes.Buffer)
+	for _, v := range []interface{}{deletionVectorMagic, deletionVectorVersion, dv.numRows, words, int64(len(deltalog)), deltalog} {
+		if err := binary.Write(buf, common.Endian, v); err != nil {
+			return nil, err
+		}
+	}
+	return &Blob{Value: buf.Bytes()}, nil
+}
+
+// Deserialize transfers the blob back to deletion vector.
+func (codec *DeletionVectorCodec) Deserialize(blob *Blob) (*DeletionVector, error) {
+	reader := bytes.NewReader(blob.Value)
+	var magic, version uint32
+	var numRows int64
+	for _, v := range []interface{}{&magic, &version, &numRows} {
+		if err := binary.Read(reader, common.Endian, v); err != nil {
+			return nil, fmt.Errorf("failed to read deletion vector header, key: %s, err: %w", blob.Key, err)
+		}
+	}
+	if magic != deletionVectorMagic {
+		return nil, fmt.Errorf("invalid deletion vector, key: %s", blob.Key)
+	}
+	if version != deletionVectorVersion {
+		return nil, fmt.Errorf("unsupported deletion vector version %d, key: %s", version, blob.Key)
+	}
+	if numRows < 0 || (numRows+63)/64*8 > int64(reader.Len()) {
+		return nil, fmt.Errorf("invalid row number %d of deletion vector, key: %s", numRows, blob.Key)
+	}
+
+	words := make([]uint64, (numRows+63)/64)
+	if err := binary.Read(reader, common.Endian, words); err != nil {
+		return nil, err
+	}
+	var length int64
+	if err := binary.Read(reader, common.Endian, &length); err != nil {
+		return nil, err
+	}
+	if length != int64(reader.Len()) {
+		return nil, fmt.Errorf("invalid deltalog length %d of deletion vector, key: %s", length, blob.Key)
+	}
+
+	dv := NewDeletionVector(numRows)
+	var offsets []int64
+	for i, word := range words {
+		for word != 0 {
+			offsets = append(offsets, int64(i*64+bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+	if len(offsets) == 0 {
+		return dv, nil
+	}
+
+	deltalog := make([]byte, length)
+	if _, err := reader.Read(deltalog); err != nil {
+		return nil, err
+	}
+	_, _, data, err := NewDeleteCodec().Deserialize([]*Blob{{Key: blob.Key, Value: deltalog}})
+	if err != nil {
+		return nil, err
+	}
+	if data.RowCount != int64(len(offsets)) {
+		return nil, fmt.Errorf("deletion vector corrupted, %d rows deleted but %d delete records, key: %s", len(offsets), data.RowCount, blob.Key)
+	}
+	for i, offset := range offsets {
+		dv.rows[offset] = deletedRow{pk: data.Pks[i], ts: data.Tss[i]}
+	}
+	return dv, nil
+}
@@ -0,0 +1,104 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func genDeletionVectorRows() ([]PrimaryKey, []Timestamp) {
+	pks := []PrimaryKey{
+		NewInt64PrimaryKey(1),
+		NewInt64PrimaryKey(2),
+		NewInt64PrimaryKey(3),
+		NewInt64PrimaryKey(2),
+		NewInt64PrimaryKey(4),
+	}
+	tss := []Timestamp{10, 10, 10, 30, 10}
+	return pks, tss
+}
+
+func TestDeletionVector_Merge(t *testing.T) {
+	pks, tss := genDeletionVectorRows()
+	dv := NewDeletionVector(int64(len(pks)))
+
+	deletes := &DeleteData{}
+	deletes.Append(NewInt64PrimaryKey(2), 20)
+	deletes.Append(NewInt64PrimaryKey(4), 5)
+	deletes.Append(NewInt64PrimaryKey(100), 20)
+	count, err := dv.Merge(pks, tss, deletes)
+	require.NoError(t, err)
+	// pk 2 inserted again after the deletion, pk 4 deleted before its insertion
+	assert.EqualValues(t, 1, count)
+	assert.True(t, dv.Contains(1))
+	assert.False(t, dv.Contains(3))
+	assert.False(t, dv.Contains(4))
+
+	deletes = &DeleteData{}
+	deletes.Append(NewInt64PrimaryKey(2), 40)
+	deletes.Append(NewInt64PrimaryKey(3), 40)
+	count, err = dv.Merge(pks, tss, deletes)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	assert.Equal(t, []int64{1, 2, 3}, dv.Offsets())
+	assert.EqualValues(t, 40, dv.MaxTimestamp())
+
+	data := dv.DeleteData()
+	assert.EqualValues(t, 3, data.RowCount)
+	// the earliest deletion of row 1 is kept
+	assert.Equal(t, []Timestamp{20, 40, 40}, data.Tss)
+
+	_, err = dv.Merge(pks[1:], tss, deletes)
+	assert.Error(t, err)
+}
+
+func TestDeletionVectorCodec(t *testing.T) {
+	pks, tss := genDeletionVectorRows()
+	dv := NewDeletionVector(int64(len(pks)))
+	codec := NewDeletionVectorCodec()
+
+	blob, err := codec.Serialize(1, 2, 3, dv)
+	require.NoError(t, err)
+	empty, err := codec.Deserialize(blob)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(pks), empty.NumRows())
+	assert.EqualValues(t, 0, empty.Count())
+
+	deletes := &DeleteData{}
+	deletes.Append(NewInt64PrimaryKey(1), 20)
+	deletes.Append(NewInt64PrimaryKey(4), 30)
+	_, err = dv.Merge(pks, tss, deletes)
+	require.NoError(t, err)
+
+	blob, err = codec.Serialize(1, 2, 3, dv)
+	require.NoError(t, err)
+	result, err := codec.Deserialize(blob)
+	require.NoError(t, err)
+	assert.Equal(t, dv.NumRows(), result.NumRows())
+	assert.Equal(t, []int64{0, 4}, result.Offsets())
+	assert.Equal(t, dv.DeleteData(), result.DeleteData())
+
+	_, err = codec.Deserialize(&Blob{Value: blob.Value[:10]})
+	assert.Error(t, err)
+	_, err = codec.Deserialize(&Blob{Value: blob.Value[:len(blob.Value)-1]})
+	assert.Error(t, err)
+	_, err = codec.Deserialize(&Blob{Value: []byte("invalid deletion vector")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,218 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/common"
+)
+
+const (
+	dictionaryMagic   uint32 = 0x54434944 // "DICT"
+	dictionaryVersion uint32 = 1
+)
+
+// DictionaryEncodedStrings is a dictionary-encoded string column. The dictionary keeps the distinct values
+// in ascending order, and the code of a row is the position of its value in the dictionary,
+// so that the codes compare the same as the values.
+type DictionaryEncodedStrings struct {
+	Dictionary []string
+	Codes      []int32
+}
+
+// EncodeDictionary dictionary-encodes @data, it returns nil if @data has more than @maxCardinality distinct values.
+func EncodeDictionary(data []string, maxCardinality int) *DictionaryEncodedStrings {
+	distinct := make(map[string]struct{})
+	for _, value := range data {
+		if _, ok := distinct[value]; ok {
+			continue
+		}
+		if len(distinct) >= maxCardinality {
+			return nil
+		}
+		distinct[value] = struct{}{}
+	}
+
+	dictionary := make([]string, 0, len(distinct))
+	for value := range distinct {
+		dictionary = append(dictionary, value)
+	}
+	sort.Strings(dictionary)
+	return &DictionaryEncodedStrings{
+		Dictionary: dictionary,
+		Codes:      encodeByDictionary(dictionary, data),
+	}
+}
+
+func encodeByDictionary(dictionary []string, data []string) []int32 {
+	codes := make([]int32, len(data))
+	for i, value := range data {
+		codes[i] = int32(sort.SearchStrings(dictionary, value))
+	}
+	return codes
+}
+
+// RowNum returns the number of rows.
+func (d *DictionaryEncodedStrings) RowNum() int {
+	return len(d.Codes)
+}
+
+// Decode returns the values of the rows.
+func (d *DictionaryEncodedStrings) Decode() []string {
+	data := make([]string, len(d.Codes))
+	for i, code := range d.Codes {
+		data[i] = d.Dictionary[code]
+	}
+	return data
+}
+
+// MergeDictionaries concatenates the rows of @parts into one column with a merged dictionary.
+func MergeDictionaries(parts []*DictionaryEncodedStrings) *DictionaryEncodedStrings {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	distinct := make(map[string]struct{})
+	rowNum := 0
+	for _, part := range parts {
+		for _, value := range part.Dictionary {
+			distinct[value] = struct{}{}
+		}
+		rowNum += part.RowNum()
+	}
+	dictionary := make([]string, 0, len(distinct))
+	for value := range distinct {
+		dictionary = append(dictionary, value)
+	}
+	sort.Strings(dictionary)
+
+	codes := make([]int32, 0, rowNum)
+	for _, part := range parts {
+		// remap the codes of the part to the merged dictionary
+		remap := encodeByDictionary(dictionary, part.Dictionary)
+		for _, code := range part.Codes {
+			codes = append(codes, remap[code])
+		}
+	}
+	return &DictionaryEncodedStrings{
+		Dictionary: dictionary,
+		Codes:      codes,
+	}
+}
+
+// DictionaryCodec serializes and deserializes the dictionary-encoded string columns.
+// The layout is: magic | version | dictionary size | (value length | value) of every dictionary value
+// | row number | codes.
+type DictionaryCodec struct{}
+
+// NewDictionaryCodec returns a DictionaryCodec.
+func NewDictionaryCodec() *DictionaryCodec {
+	return &DictionaryCodec{}
+}
+
+// Serialize transfers the dictionary-encoded column to blob.
+func (codec *DictionaryCodec) Serialize(d *DictionaryEncodedStrings) (*Blob, error) {
+	buf := new(bytes.Buffer)
+	write := func(values ...interface{}) error {
+		for _, v := range values {
+			if err := binary.Write(buf, common.Endian, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := write(dictionaryMagic, dictionaryVersion, int64(len(d.Dictionary))); err != nil {
+		return nil, err
+	}
+	for _, value := range d.Dictionary {
+		if err := write(uint32(len(value)), []byte(value)); err != nil {
+			return nil, err
+		}
+	}
+	if err := write(int64(len(d.Codes)), d.Codes); err != nil {
+		return nil, err
+	}
+	return &Blob{Value: buf.Bytes()}, nil
+}
+
+// Deserialize transfers the blob back to the dictionary-encoded column.
+func (codec *DictionaryCodec) Deserialize(blob *Blob) (*DictionaryEncodedStrings, error) {
+	reader := bytes.NewReader(blob.Value)
+	var magic, version uint32
+	var size int64
+	for _, v := range []interface{}{&magic, &version, &size} {
+		if err := binary.Read(reader, common.Endian, v); err != nil {
+			return nil, fmt.Errorf("failed to read dictionary header, key: %s, err: %w", blob.Key, err)
+		}
+	}
+	if magic != dictionaryMagic {
+		return nil, fmt.Errorf("invalid dictionary, key: %s", blob.Key)
+	}
+	if version != dictionaryVersion {
+		return nil, fmt.Errorf("unsupported dictionary version %d, key: %s", version, blob.Key)
+	}
+	// every value takes 4 bytes of length at least
+	if size < 0 || size*4 > int64(reader.Len()) {
+		return nil, fmt.Errorf("invalid dictionary size %d, key: %s", size, blob.Key)
+	}
+
+	dictionary := make([]string, size)
+	for i := range dictionary {
+		var length uint32
+		if err := binary.Read(reader, common.Endian, &length); err != nil {
+			return nil, err
+		}
+		if int64(length) > int64(reader.Len()) {
+			return nil, fmt.Errorf("invalid dictionary value length %d, key: %s", length, blob.Key)
+		}
+		value := make([]byte, length)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		dictionary[i] = string(value)
+		if i > 0 && dictionary[i-1] >= dictionary[i] {
+			return nil, fmt.Errorf("dictionary is not sorted, key: %s", blob.Key)
+		}
+	}
+
+	var rowNum int64
+	if err := binary.Read(reader, common.Endian, &rowNum); err != nil {
+		return nil, err
+	}
+	if rowNum < 0 || rowNum*4 != int64(reader.Len()) {
+		return nil, fmt.Errorf("invalid row number %d of dictionary, key: %s", rowNum, blob.Key)
+	}
+	codes := make([]int32, rowNum)
+	if err := binary.Read(reader, common.Endian, codes); err != nil {
+		return nil, err
+	}
+	for _, code := range codes {
+		if code < 0 || int64(code) >= size {
+			return nil, fmt.Errorf("dictionary code %d out of range, key: %s", code, blob.Key)
+		}
+	}
+	return &DictionaryEncodedStrings{
+		Dictionary: dictionary,
+		Codes:      codes,
+	}, nil
+}
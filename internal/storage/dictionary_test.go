@@ -0,0 +1,83 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDictionary(t *testing.T) {
+	data := []string{"b", "a", "c", "a", "", "b"}
+	d := EncodeDictionary(data, 4)
+	require.NotNil(t, d)
+	assert.Equal(t, []string{"", "a", "b", "c"}, d.Dictionary)
+	assert.Equal(t, []int32{2, 1, 3, 1, 0, 2}, d.Codes)
+	assert.Equal(t, len(data), d.RowNum())
+	assert.Equal(t, data, d.Decode())
+
+	// too many distinct values
+	assert.Nil(t, EncodeDictionary(data, 3))
+
+	empty := EncodeDictionary(nil, 1)
+	require.NotNil(t, empty)
+	assert.Equal(t, 0, empty.RowNum())
+}
+
+func TestMergeDictionaries(t *testing.T) {
+	part1 := EncodeDictionary([]string{"b", "d", "b"}, 10)
+	part2 := EncodeDictionary([]string{"a", "d", "c"}, 10)
+	merged := MergeDictionaries([]*DictionaryEncodedStrings{part1, part2})
+	assert.Equal(t, []string{"a", "b", "c", "d"}, merged.Dictionary)
+	assert.Equal(t, []string{"b", "d", "b", "a", "d", "c"}, merged.Decode())
+
+	assert.Same(t, part1, MergeDictionaries([]*DictionaryEncodedStrings{part1}))
+}
+
+func TestDictionaryCodec(t *testing.T) {
+	codec := NewDictionaryCodec()
+	d := EncodeDictionary([]string{"tenant_1", "tenant_2", "", "tenant_1"}, 10)
+	blob, err := codec.Serialize(d)
+	require.NoError(t, err)
+
+	got, err := codec.Deserialize(blob)
+	assert.NoError(t, err)
+	assert.Equal(t, d, got)
+
+	t.Run("corrupted", func(t *testing.T) {
+		_, err := codec.Deserialize(&Blob{Value: []byte{1, 2, 3}})
+		assert.Error(t, err)
+
+		_, err = codec.Deserialize(&Blob{Value: append([]byte{0, 0, 0, 0}, blob.Value[4:]...)})
+		assert.Error(t, err)
+
+		_, err = codec.Deserialize(&Blob{Value: blob.Value[:len(blob.Value)-1]})
+		assert.Error(t, err)
+
+		unsorted, err := codec.Serialize(&DictionaryEncodedStrings{Dictionary: []string{"b", "a"}, Codes: []int32{0, 1}})
+		require.NoError(t, err)
+		_, err = codec.Deserialize(unsorted)
+		assert.Error(t, err)
+
+		outOfRange, err := codec.Serialize(&DictionaryEncodedStrings{Dictionary: []string{"a"}, Codes: []int32{1}})
+		require.NoError(t, err)
+		_, err = codec.Deserialize(outOfRange)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,156 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// ErrDiskQuotaExceeded means the write is rejected since the disk usage of the local root path is above the high watermark.
+var ErrDiskQuotaExceeded = errors.New("DiskQuotaExceeded")
+
+func WrapErrDiskQuotaExceeded(path string, usage float64, highWatermark float64) error {
+	return fmt.Errorf("%w(path=%s, usage=%.4f, highWatermark=%.4f)", ErrDiskQuotaExceeded, path, usage, highWatermark)
+}
+
+// DiskEvictFunc is called when a write would push the disk usage above the high watermark,
+// it should free at least @size bytes under the local root path, which brings the usage back to the low watermark.
+type DiskEvictFunc func(ctx context.Context, size int64) error
+
+// diskUsage is the usage of the file system.
+type diskUsage struct {
+	total uint64
+	used  uint64
+}
+
+func (u diskUsage) ratio(extra int64) float64 {
+	if u.total == 0 {
+		return 0
+	}
+	return float64(int64(u.used)+extra) / float64(u.total)
+}
+
+// freeShortage returns the bytes to free to bring the free space ratio back to @minFreeRatio.
+func (u diskUsage) freeShortage(minFreeRatio float64) int64 {
+	return int64(minFreeRatio*float64(u.total)) - int64(u.total-u.used)
+}
+
+// getDiskUsage returns the usage of the file system which @dir is on,
+// the blocks reserved for root are counted as used since they are not available to us.
+func getDiskUsage(dir string) (diskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return diskUsage{}, err
+	}
+	bsize := uint64(stat.Bsize)
+	total := stat.Blocks * bsize
+	return diskUsage{
+		total: total,
+		used:  total - stat.Bavail*bsize,
+	}, nil
+}
+
+// diskQuota enforces the high and low watermarks of the disk usage on the local root path.
+type diskQuota struct {
+	rootPath      string
+	highWatermark float64
+	lowWatermark  float64
+	evict         DiskEvictFunc
+
+	getDiskUsage func(dir string) (diskUsage, error)
+}
+
+// newDiskQuota returns nil if the high watermark is not set, which means no quota.
+func newDiskQuota(c *config) *diskQuota {
+	if c.diskHighWatermark <= 0 {
+		return nil
+	}
+	lowWatermark := c.diskLowWatermark
+	if lowWatermark <= 0 || lowWatermark > c.diskHighWatermark {
+		lowWatermark = c.diskHighWatermark
+	}
+	return &diskQuota{
+		rootPath:      c.rootPath,
+		highWatermark: c.diskHighWatermark,
+		lowWatermark:  lowWatermark,
+		evict:         c.diskEvictFunc,
+		getDiskUsage:  getDiskUsage,
+	}
+}
+
+// check makes sure writing @size more bytes keeps the disk usage under the high watermark,
+// the evict func is called to make room if set, otherwise an error wrapping ErrDiskQuotaExceeded is returned.
+// A nil diskQuota accepts all writes.
+func (q *diskQuota) check(ctx context.Context, size int64) error {
+	if q == nil {
+		return nil
+	}
+	usage, err := q.getDiskUsage(q.rootPath)
+	if err != nil {
+		// the root path may not be created yet, let the write go and fail by itself if the disk is broken
+		log.RatedWarn(10, "failed to get disk usage of local storage", zap.String("path", q.rootPath), zap.Error(err))
+		return nil
+	}
+	ratio := usage.ratio(size)
+	metrics.LocalStorageDiskUsageRatio.WithLabelValues(q.rootPath).Set(usage.ratio(0))
+	if ratio <= q.highWatermark {
+		if ratio > q.lowWatermark {
+			log.RatedWarn(10, "disk usage of local storage is approaching the high watermark",
+				zap.String("path", q.rootPath),
+				zap.Float64("usage", ratio),
+				zap.Float64("highWatermark", q.highWatermark))
+		}
+		return nil
+	}
+
+	if q.evict != nil {
+		toFree := int64(usage.used) + size - int64(q.lowWatermark*float64(usage.total))
+		start := time.Now()
+		err := q.evict(ctx, toFree)
+		log.Info("evict local storage since disk usage exceeds the high watermark",
+			zap.String("path", q.rootPath),
+			zap.Float64("usage", ratio),
+			zap.Int64("toFree", toFree),
+			zap.Duration("cost", time.Since(start)),
+			zap.Error(err))
+		if err == nil {
+			usage, err = q.getDiskUsage(q.rootPath)
+			if err == nil {
+				ratio = usage.ratio(size)
+				metrics.LocalStorageDiskUsageRatio.WithLabelValues(q.rootPath).Set(usage.ratio(0))
+				if ratio <= q.highWatermark {
+					return nil
+				}
+			}
+		}
+	}
+
+	log.RatedWarn(10, "disk usage of local storage exceeds the high watermark, reject the write",
+		zap.String("path", q.rootPath),
+		zap.Float64("usage", ratio),
+		zap.Float64("highWatermark", q.highWatermark))
+	return WrapErrDiskQuotaExceeded(q.rootPath, ratio, q.highWatermark)
+}
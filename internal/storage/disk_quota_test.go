@@ -0,0 +1,105 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskQuota(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no quota", func(t *testing.T) {
+		assert.Nil(t, newDiskQuota(&config{}))
+		var quota *diskQuota
+		assert.NoError(t, quota.check(ctx, 1<<40))
+	})
+
+	t.Run("real disk usage", func(t *testing.T) {
+		usage, err := getDiskUsage(t.TempDir())
+		require.NoError(t, err)
+		assert.Greater(t, usage.total, uint64(0))
+		assert.LessOrEqual(t, usage.used, usage.total)
+
+		_, err = getDiskUsage(path.Join(t.TempDir(), "not-exist"))
+		assert.Error(t, err)
+	})
+
+	newQuota := func(used uint64, evict DiskEvictFunc) *diskQuota {
+		quota := newDiskQuota(&config{
+			rootPath:          "/tmp/quota",
+			diskHighWatermark: 0.9,
+			diskLowWatermark:  0.8,
+			diskEvictFunc:     evict,
+		})
+		quota.getDiskUsage = func(dir string) (diskUsage, error) {
+			return diskUsage{total: 1000, used: used}, nil
+		}
+		return quota
+	}
+
+	t.Run("below high watermark", func(t *testing.T) {
+		assert.NoError(t, newQuota(500, nil).check(ctx, 100))
+		// above the low watermark only warns
+		assert.NoError(t, newQuota(850, nil).check(ctx, 50))
+	})
+
+	t.Run("above high watermark", func(t *testing.T) {
+		err := newQuota(850, nil).check(ctx, 100)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, ErrDiskQuotaExceeded))
+	})
+
+	t.Run("evict", func(t *testing.T) {
+		var quota *diskQuota
+		var toFree int64
+		quota = newQuota(850, func(ctx context.Context, size int64) error {
+			toFree = size
+			quota.getDiskUsage = func(dir string) (diskUsage, error) {
+				return diskUsage{total: 1000, used: 700}, nil
+			}
+			return nil
+		})
+		assert.NoError(t, quota.check(ctx, 100))
+		// back to the low watermark after writing
+		assert.Equal(t, int64(150), toFree)
+
+		quota = newQuota(850, func(ctx context.Context, size int64) error {
+			return errors.New("mock error")
+		})
+		assert.True(t, errors.Is(quota.check(ctx, 100), ErrDiskQuotaExceeded))
+	})
+
+	t.Run("local chunk manager", func(t *testing.T) {
+		localPath := t.TempDir()
+		// any write exceeds a tiny high watermark unless the disk is empty
+		testCM := NewLocalChunkManager(RootPath(localPath), DiskQuota(1e-9, 0))
+		err := testCM.Write(ctx, "key", []byte("value"))
+		assert.True(t, errors.Is(err, ErrDiskQuotaExceeded))
+		err = testCM.Append(ctx, "key", []byte("value"))
+		assert.True(t, errors.Is(err, ErrDiskQuotaExceeded))
+
+		testCM = NewLocalChunkManager(RootPath(localPath), DiskQuota(1, 1))
+		assert.NoError(t, testCM.Write(ctx, "key", []byte("value")))
+	})
+}
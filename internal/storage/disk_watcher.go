@@ -0,0 +1,211 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// DiskWatcher periodically stats the watched local directories and publishes their free space,
+// the evictors registered on a directory are called in order when its free space drops below the threshold.
+type DiskWatcher struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	dirs map[string]*watchedDir
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+
+	getDiskUsage func(dir string) (diskUsage, error)
+}
+
+type watchedDir struct {
+	minFreeRatio float64
+	evictors     []namedEvictor
+}
+
+type namedEvictor struct {
+	name  string
+	evict DiskEvictFunc
+}
+
+// NewDiskWatcher creates a DiskWatcher checking the watched directories every @interval.
+func NewDiskWatcher(interval time.Duration) *DiskWatcher {
+	return &DiskWatcher{
+		interval:     interval,
+		dirs:         make(map[string]*watchedDir),
+		closeCh:      make(chan struct{}),
+		getDiskUsage: getDiskUsage,
+	}
+}
+
+// Watch starts watching @dir, the evictors of @dir are called when the free space ratio
+// of its file system drops below @minFreeRatio, zero means only the metrics are published.
+func (w *DiskWatcher) Watch(dir string, minFreeRatio float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if d, ok := w.dirs[dir]; ok {
+		d.minFreeRatio = minFreeRatio
+		return
+	}
+	w.dirs[dir] = &watchedDir{minFreeRatio: minFreeRatio}
+}
+
+// Unwatch stops watching @dir, the evictors registered on it are dropped as well.
+func (w *DiskWatcher) Unwatch(dir string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.dirs, dir)
+}
+
+// RegisterEvictor registers @evict with @name on the watched @dir, the evictor with the same name is replaced.
+// Returns false if @dir is not watched.
+func (w *DiskWatcher) RegisterEvictor(dir string, name string, evict DiskEvictFunc) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	d, ok := w.dirs[dir]
+	if !ok {
+		return false
+	}
+	for i := range d.evictors {
+		if d.evictors[i].name == name {
+			d.evictors[i].evict = evict
+			return true
+		}
+	}
+	d.evictors = append(d.evictors, namedEvictor{name: name, evict: evict})
+	return true
+}
+
+// UnregisterEvictor removes the evictor with @name from @dir.
+func (w *DiskWatcher) UnregisterEvictor(dir string, name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	d, ok := w.dirs[dir]
+	if !ok {
+		return
+	}
+	for i := range d.evictors {
+		if d.evictors[i].name == name {
+			d.evictors = append(d.evictors[:i], d.evictors[i+1:]...)
+			return
+		}
+	}
+}
+
+// Start starts the background checking.
+func (w *DiskWatcher) Start() {
+	w.startOnce.Do(func() {
+		w.wg.Add(1)
+		go w.run()
+	})
+}
+
+// Stop stops the background checking and waits for the running check to finish.
+func (w *DiskWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.closeCh)
+	})
+	w.wg.Wait()
+}
+
+func (w *DiskWatcher) run() {
+	defer w.wg.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		// interrupt the running evictors on stop
+		<-w.closeCh
+		cancel()
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.closeCh:
+			return
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// check stats all watched directories, and calls the evictors of the directories short of space.
+func (w *DiskWatcher) check(ctx context.Context) {
+	w.mu.Lock()
+	dirs := make(map[string]watchedDir, len(w.dirs))
+	for dir, d := range w.dirs {
+		dirs[dir] = watchedDir{
+			minFreeRatio: d.minFreeRatio,
+			evictors:     append([]namedEvictor(nil), d.evictors...),
+		}
+	}
+	w.mu.Unlock()
+
+	for dir, d := range dirs {
+		usage, err := w.getDiskUsage(dir)
+		if err != nil {
+			log.RatedWarn(60, "failed to get disk usage", zap.String("dir", dir), zap.Error(err))
+			continue
+		}
+		w.publish(dir, usage)
+		toFree := usage.freeShortage(d.minFreeRatio)
+		if toFree <= 0 {
+			continue
+		}
+
+		log.Warn("free disk space is below the threshold, evict local files",
+			zap.String("dir", dir),
+			zap.Uint64("free", usage.total-usage.used),
+			zap.Float64("minFreeRatio", d.minFreeRatio),
+			zap.Int64("toFree", toFree),
+			zap.Int("evictors", len(d.evictors)))
+		for _, evictor := range d.evictors {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := evictor.evict(ctx, toFree); err != nil {
+				log.Warn("failed to evict local files", zap.String("dir", dir), zap.String("evictor", evictor.name), zap.Error(err))
+				continue
+			}
+			usage, err = w.getDiskUsage(dir)
+			if err != nil {
+				break
+			}
+			w.publish(dir, usage)
+			if toFree = usage.freeShortage(d.minFreeRatio); toFree <= 0 {
+				break
+			}
+		}
+	}
+}
+
+func (w *DiskWatcher) publish(dir string, usage diskUsage) {
+	metrics.LocalStorageDiskFreeBytes.WithLabelValues(dir).Set(float64(usage.total - usage.used))
+	metrics.LocalStorageDiskUsageRatio.WithLabelValues(dir).Set(usage.ratio(0))
+}
@@ -0,0 +1,118 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func TestDiskWatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("register evictor", func(t *testing.T) {
+		w := NewDiskWatcher(time.Minute)
+		noop := func(ctx context.Context, size int64) error { return nil }
+		assert.False(t, w.RegisterEvictor("/dir", "a", noop))
+
+		w.Watch("/dir", 0.1)
+		assert.True(t, w.RegisterEvictor("/dir", "a", noop))
+		assert.True(t, w.RegisterEvictor("/dir", "b", noop))
+		assert.True(t, w.RegisterEvictor("/dir", "a", noop))
+		assert.Equal(t, 2, len(w.dirs["/dir"].evictors))
+
+		w.UnregisterEvictor("/dir", "a")
+		assert.Equal(t, 1, len(w.dirs["/dir"].evictors))
+		assert.Equal(t, "b", w.dirs["/dir"].evictors[0].name)
+		w.UnregisterEvictor("/not-watched", "b")
+
+		w.Unwatch("/dir")
+		assert.Empty(t, w.dirs)
+	})
+
+	t.Run("evict", func(t *testing.T) {
+		w := NewDiskWatcher(time.Minute)
+		used := uint64(950)
+		w.getDiskUsage = func(dir string) (diskUsage, error) {
+			return diskUsage{total: 1000, used: used}, nil
+		}
+		w.Watch("/dir", 0.1)
+
+		var calls []string
+		w.RegisterEvictor("/dir", "fail", func(ctx context.Context, size int64) error {
+			calls = append(calls, "fail")
+			return errors.New("mock error")
+		})
+		w.RegisterEvictor("/dir", "first", func(ctx context.Context, size int64) error {
+			calls = append(calls, "first")
+			assert.Equal(t, int64(50), size)
+			used -= 30
+			return nil
+		})
+		w.RegisterEvictor("/dir", "second", func(ctx context.Context, size int64) error {
+			calls = append(calls, "second")
+			// the shortage left by the former evictor
+			assert.Equal(t, int64(20), size)
+			used -= 20
+			return nil
+		})
+		w.RegisterEvictor("/dir", "third", func(ctx context.Context, size int64) error {
+			calls = append(calls, "third")
+			return nil
+		})
+
+		w.check(ctx)
+		assert.Equal(t, []string{"fail", "first", "second"}, calls)
+
+		// enough free space
+		calls = nil
+		w.check(ctx)
+		assert.Empty(t, calls)
+	})
+
+	t.Run("metrics only", func(t *testing.T) {
+		w := NewDiskWatcher(time.Minute)
+		w.getDiskUsage = func(dir string) (diskUsage, error) {
+			return diskUsage{total: 1000, used: 1000}, nil
+		}
+		w.Watch("/dir", 0)
+		w.RegisterEvictor("/dir", "never", func(ctx context.Context, size int64) error {
+			t.FailNow()
+			return nil
+		})
+		w.check(ctx)
+	})
+
+	t.Run("start and stop", func(t *testing.T) {
+		w := NewDiskWatcher(10 * time.Millisecond)
+		checked := atomic.NewInt32(0)
+		w.getDiskUsage = func(dir string) (diskUsage, error) {
+			checked.Inc()
+			return diskUsage{}, errors.New("mock error")
+		}
+		w.Watch(t.TempDir(), 0.1)
+		w.Start()
+		assert.Eventually(t, func() bool { return checked.Load() > 0 }, time.Second, 10*time.Millisecond)
+		w.Stop()
+		w.Stop()
+	})
+}
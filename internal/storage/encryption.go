@@ -0,0 +1,248 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	// dataKeySize is the size of the data keys, which select AES-256.
+	dataKeySize = 32
+	// maxEnvelopeFieldSize is the max size of the key id and the wrapped data key in the envelope header.
+	maxEnvelopeFieldSize = 1<<16 - 1
+)
+
+// envelopeMagic starts the encrypted objects.
+var envelopeMagic = []byte("MVE1")
+
+var (
+	ErrKeyNotFound      = errors.New("KeyNotFound")
+	ErrInvalidEnvelope  = errors.New("InvalidEnvelope")
+	ErrDecryptionFailed = errors.New("DecryptionFailed")
+)
+
+// KeyProvider wraps and unwraps the data keys with the master keys, e.g. by a KMS.
+type KeyProvider interface {
+	// WrapKey encrypts @dataKey with the master key @keyID.
+	WrapKey(ctx context.Context, keyID string, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts @wrapped with the master key @keyID.
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// StaticKeyProvider wraps the data keys with the AES master keys held in memory.
+type StaticKeyProvider struct {
+	keys map[string][]byte
+}
+
+var _ KeyProvider = (*StaticKeyProvider)(nil)
+
+// NewStaticKeyProvider returns a StaticKeyProvider with the master keys of 16, 24 or 32 bytes indexed by key id.
+func NewStaticKeyProvider(keys map[string][]byte) (*StaticKeyProvider, error) {
+	for keyID, key := range keys {
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, fmt.Errorf("invalid master key %s: %w", keyID, err)
+		}
+	}
+	return &StaticKeyProvider{keys: keys}, nil
+}
+
+// NewStaticKeyProviderFromFile returns a StaticKeyProvider with the master keys in the JSON file @path,
+// which maps the key ids to the base64-encoded keys, e.g. {"key-1": "<base64 of 32 bytes>"}.
+func NewStaticKeyProviderFromFile(path string) (*StaticKeyProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	encoded := make(map[string]string)
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to parse master keys %s: %w", path, err)
+	}
+	keys := make(map[string][]byte, len(encoded))
+	for keyID, value := range encoded {
+		key, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid master key %s: %w", keyID, err)
+		}
+		keys[keyID] = key
+	}
+	return NewStaticKeyProvider(keys)
+}
+
+// HasKey returns whether the master key @keyID is held.
+func (p *StaticKeyProvider) HasKey(keyID string) bool {
+	_, ok := p.keys[keyID]
+	return ok
+}
+
+func (p *StaticKeyProvider) WrapKey(ctx context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w(keyID=%s)", ErrKeyNotFound, keyID)
+	}
+	return sealGCM(key, dataKey)
+}
+
+func (p *StaticKeyProvider) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w(keyID=%s)", ErrKeyNotFound, keyID)
+	}
+	return openGCM(key, wrapped)
+}
+
+// envelope is an object encrypted with a data key, which is wrapped by the master key KeyID and stored with it:
+// magic | len(KeyID) uint16 | KeyID | len(WrappedKey) uint16 | WrappedKey | nonce | sealed content.
+type envelope struct {
+	KeyID      string
+	WrappedKey []byte
+	// Sealed is the nonce followed by the content sealed by AES-GCM
+	Sealed []byte
+}
+
+// IsEncryptedObject returns true if @content is an object encrypted by EncryptObject.
+func IsEncryptedObject(content []byte) bool {
+	return bytes.HasPrefix(content, envelopeMagic)
+}
+
+func (e *envelope) marshal() ([]byte, error) {
+	if len(e.KeyID) > maxEnvelopeFieldSize || len(e.WrappedKey) > maxEnvelopeFieldSize {
+		return nil, fmt.Errorf("%w: key id or wrapped key too large", ErrInvalidEnvelope)
+	}
+	buf := make([]byte, 0, len(envelopeMagic)+4+len(e.KeyID)+len(e.WrappedKey)+len(e.Sealed))
+	buf = append(buf, envelopeMagic...)
+	var size [2]byte
+	binary.LittleEndian.PutUint16(size[:], uint16(len(e.KeyID)))
+	buf = append(append(buf, size[:]...), e.KeyID...)
+	binary.LittleEndian.PutUint16(size[:], uint16(len(e.WrappedKey)))
+	buf = append(append(buf, size[:]...), e.WrappedKey...)
+	return append(buf, e.Sealed...), nil
+}
+
+func unmarshalEnvelope(content []byte) (*envelope, error) {
+	if !IsEncryptedObject(content) {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidEnvelope)
+	}
+	content = content[len(envelopeMagic):]
+	readField := func() ([]byte, error) {
+		if len(content) < 2 {
+			return nil, fmt.Errorf("%w: truncated header", ErrInvalidEnvelope)
+		}
+		size := int(binary.LittleEndian.Uint16(content))
+		if len(content) < 2+size {
+			return nil, fmt.Errorf("%w: truncated header", ErrInvalidEnvelope)
+		}
+		field := content[2 : 2+size]
+		content = content[2+size:]
+		return field, nil
+	}
+	keyID, err := readField()
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := readField()
+	if err != nil {
+		return nil, err
+	}
+	return &envelope{KeyID: string(keyID), WrappedKey: wrappedKey, Sealed: content}, nil
+}
+
+// EncryptObject encrypts @content with a new data key, which is wrapped by the master key @keyID of @keys.
+func EncryptObject(ctx context.Context, keys KeyProvider, keyID string, content []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	wrappedKey, err := keys.WrapKey(ctx, keyID, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := sealGCM(dataKey, content)
+	if err != nil {
+		return nil, err
+	}
+	e := &envelope{KeyID: keyID, WrappedKey: wrappedKey, Sealed: sealed}
+	return e.marshal()
+}
+
+// DecryptObject decrypts the object encrypted by EncryptObject.
+func DecryptObject(ctx context.Context, keys KeyProvider, content []byte) ([]byte, error) {
+	e, err := unmarshalEnvelope(content)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := keys.UnwrapKey(ctx, e.KeyID, e.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	return openGCM(dataKey, e.Sealed)
+}
+
+// EncryptionKeyID returns the id of the master key wrapping the data key of the encrypted object.
+func EncryptionKeyID(content []byte) (string, error) {
+	e, err := unmarshalEnvelope(content)
+	if err != nil {
+		return "", err
+	}
+	return e.KeyID, nil
+}
+
+func sealGCM(key []byte, plaintext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func openGCM(key []byte, sealed []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: truncated content", ErrDecryptionFailed)
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
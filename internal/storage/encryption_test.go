@@ -0,0 +1,106 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyProvider(t *testing.T) *StaticKeyProvider {
+	keys, err := NewStaticKeyProvider(map[string][]byte{
+		"key-1": bytes.Repeat([]byte{1}, 32),
+		"key-2": bytes.Repeat([]byte{2}, 16),
+	})
+	require.NoError(t, err)
+	return keys
+}
+
+func TestEncryptObject(t *testing.T) {
+	ctx := context.Background()
+	keys := newTestKeyProvider(t)
+
+	_, err := NewStaticKeyProvider(map[string][]byte{"bad": []byte("short")})
+	assert.Error(t, err)
+
+	content := []byte("binlog")
+	encrypted, err := EncryptObject(ctx, keys, "key-1", content)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedObject(encrypted))
+	assert.False(t, bytes.Contains(encrypted, content))
+	keyID, err := EncryptionKeyID(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "key-1", keyID)
+
+	decrypted, err := DecryptObject(ctx, keys, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, content, decrypted)
+
+	// the data keys differ between the objects
+	other, err := EncryptObject(ctx, keys, "key-1", content)
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, other)
+
+	_, err = EncryptObject(ctx, keys, "key-3", content)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = DecryptObject(ctx, keys, tampered)
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = DecryptObject(ctx, keys, content)
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+	_, err = DecryptObject(ctx, keys, encrypted[:len(envelopeMagic)+3])
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+}
+
+func TestNewStaticKeyProviderFromFile(t *testing.T) {
+	dir := t.TempDir()
+	writeKeys := func(content string) string {
+		keyFile := path.Join(dir, "keys.json")
+		require.NoError(t, ioutil.WriteFile(keyFile, []byte(content), 0600))
+		return keyFile
+	}
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	keys, err := NewStaticKeyProviderFromFile(writeKeys(`{"key-1": "` + key + `"}`))
+	require.NoError(t, err)
+	assert.True(t, keys.HasKey("key-1"))
+	assert.False(t, keys.HasKey("key-2"))
+	encrypted, err := EncryptObject(context.Background(), keys, "key-1", []byte("binlog"))
+	require.NoError(t, err)
+	decrypted, err := DecryptObject(context.Background(), newTestKeyProvider(t), encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("binlog"), decrypted)
+
+	_, err = NewStaticKeyProviderFromFile(path.Join(dir, "not-exist.json"))
+	assert.Error(t, err)
+	_, err = NewStaticKeyProviderFromFile(writeKeys(`not json`))
+	assert.Error(t, err)
+	_, err = NewStaticKeyProviderFromFile(writeKeys(`{"key-1": "not base64!"}`))
+	assert.Error(t, err)
+	_, err = NewStaticKeyProviderFromFile(writeKeys(`{"key-1": "` + base64.StdEncoding.EncodeToString([]byte("short")) + `"}`))
+	assert.Error(t, err)
+}
@@ -412,6 +412,23 @@ func readInsertEventDataFixPart(buffer io.Reader) (*insertEventData, error) {
 	return data, nil
 }
 
+// readInsertEventPayloadCompression reads the codec following the insert event data in the fixed part of an insert
+// event, @postHeaderLength is the length of the fixed part recorded in the descriptor event.
+func readInsertEventPayloadCompression(buffer io.Reader, postHeaderLength int32) (PayloadCompression, error) {
+	switch postHeaderLength - getEventFixPartSize(InsertEventType) {
+	case 0:
+		return "", nil
+	case PayloadCompressionLz4.codeSize():
+		var code uint8
+		if err := binary.Read(buffer, common.Endian, &code); err != nil {
+			return "", err
+		}
+		return payloadCompressionOfCode(code)
+	default:
+		return "", fmt.Errorf("invalid fixed part length %d of insert event", postHeaderLength)
+	}
+}
+
 func readDeleteEventDataFixPart(buffer io.Reader) (*deleteEventData, error) {
 	data := &deleteEventData{}
 	if err := binary.Read(buffer, common.Endian, data); err != nil {
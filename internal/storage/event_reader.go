@@ -28,8 +28,10 @@ type EventReader struct {
 	eventHeader
 	eventData
 	PayloadReaderInterface
-	buffer   *bytes.Buffer
-	isClosed bool
+	// payloadCompression is the codec recorded in the fixed part of the insert events
+	payloadCompression PayloadCompression
+	buffer             *bytes.Buffer
+	isClosed           bool
 }
 
 func (reader *EventReader) readHeader() error {
@@ -44,7 +46,9 @@ func (reader *EventReader) readHeader() error {
 	return nil
 }
 
-func (reader *EventReader) readData() error {
+// readData reads the fixed part of the event data, whose length is recorded in @postHeaderLengths of the
+// descriptor event. The default length of the event type is used if it's not recorded.
+func (reader *EventReader) readData(postHeaderLengths []uint8) error {
 	if reader.isClosed {
 		return fmt.Errorf("event reader is closed")
 	}
@@ -52,7 +56,13 @@ func (reader *EventReader) readData() error {
 	var err error
 	switch reader.TypeCode {
 	case InsertEventType:
-		data, err = readInsertEventDataFixPart(reader.buffer)
+		postHeaderLength := getEventFixPartSize(InsertEventType)
+		if int(InsertEventType) < len(postHeaderLengths) {
+			postHeaderLength = int32(postHeaderLengths[InsertEventType])
+		}
+		if data, err = readInsertEventDataFixPart(reader.buffer); err == nil {
+			reader.payloadCompression, err = readInsertEventPayloadCompression(reader.buffer, postHeaderLength)
+		}
 	case DeleteEventType:
 		data, err = readDeleteEventDataFixPart(reader.buffer)
 	case CreateCollectionEventType:
@@ -85,7 +95,8 @@ func (reader *EventReader) Close() {
 	}
 }
 
-func newEventReader(datatype schemapb.DataType, buffer *bytes.Buffer) (*EventReader, error) {
+// newEventReader reads the next event in @buffer, @postHeaderLengths are the ones of the descriptor event.
+func newEventReader(datatype schemapb.DataType, postHeaderLengths []uint8, buffer *bytes.Buffer) (*EventReader, error) {
 	reader := &EventReader{
 		eventHeader: eventHeader{
 			baseEventHeader{},
@@ -97,12 +108,16 @@ func newEventReader(datatype schemapb.DataType, buffer *bytes.Buffer) (*EventRea
 	if err := reader.readHeader(); err != nil {
 		return nil, err
 	}
-	if err := reader.readData(); err != nil {
+	if err := reader.readData(postHeaderLengths); err != nil {
 		return nil, err
 	}
 
-	next := int(reader.EventLength - reader.eventHeader.GetMemoryUsageInBytes() - reader.GetEventDataFixPartSize())
-	payloadBuffer := buffer.Next(next)
+	next := int(reader.EventLength - reader.eventHeader.GetMemoryUsageInBytes() - reader.GetEventDataFixPartSize() -
+		reader.payloadCompression.codeSize())
+	payloadBuffer, err := decompressPayload(reader.payloadCompression, buffer.Next(next))
+	if err != nil {
+		return nil, err
+	}
 	payloadReader, err := NewPayloadReader(datatype, payloadBuffer)
 	if err != nil {
 		return nil, err
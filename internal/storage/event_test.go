@@ -167,7 +167,7 @@ func TestInsertEvent(t *testing.T) {
 		assert.Equal(t, values, ev)
 		pR.Close()
 
-		r, err := newEventReader(dt, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(dt, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -358,7 +358,7 @@ func TestInsertEvent(t *testing.T) {
 		assert.Equal(t, s[2], "abcdefg")
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 
 		s, err = pR.GetStringFromPayload()
@@ -413,7 +413,7 @@ func TestDeleteEvent(t *testing.T) {
 
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 
 		s, err = pR.GetStringFromPayload()
@@ -467,7 +467,7 @@ func TestCreateCollectionEvent(t *testing.T) {
 		assert.Equal(t, values, []int64{1, 2, 3, 4, 5, 6})
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_Int64, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -515,7 +515,7 @@ func TestCreateCollectionEvent(t *testing.T) {
 
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 
 		s, err = pR.GetStringFromPayload()
@@ -569,7 +569,7 @@ func TestDropCollectionEvent(t *testing.T) {
 		assert.Equal(t, values, []int64{1, 2, 3, 4, 5, 6})
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_Int64, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -617,7 +617,7 @@ func TestDropCollectionEvent(t *testing.T) {
 
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 
 		s, err = r.GetStringFromPayload()
@@ -671,7 +671,7 @@ func TestCreatePartitionEvent(t *testing.T) {
 		assert.Equal(t, values, []int64{1, 2, 3, 4, 5, 6})
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_Int64, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -719,7 +719,7 @@ func TestCreatePartitionEvent(t *testing.T) {
 
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 
 		s, err = pR.GetStringFromPayload()
@@ -773,7 +773,7 @@ func TestDropPartitionEvent(t *testing.T) {
 		assert.Equal(t, values, []int64{1, 2, 3, 4, 5, 6})
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_Int64, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_Int64, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 		payload, _, err := r.GetDataFromPayload()
 		assert.Nil(t, err)
@@ -821,7 +821,7 @@ func TestDropPartitionEvent(t *testing.T) {
 
 		pR.Close()
 
-		r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+		r, err := newEventReader(schemapb.DataType_String, nil, bytes.NewBuffer(wBuf))
 		assert.Nil(t, err)
 
 		s, err = pR.GetStringFromPayload()
@@ -1044,7 +1044,7 @@ func TestReadFixPartError(t *testing.T) {
 
 func TestEventReaderError(t *testing.T) {
 	buf := new(bytes.Buffer)
-	r, err := newEventReader(schemapb.DataType_Int64, buf)
+	r, err := newEventReader(schemapb.DataType_Int64, nil, buf)
 	assert.Nil(t, r)
 	assert.NotNil(t, err)
 
@@ -1052,7 +1052,7 @@ func TestEventReaderError(t *testing.T) {
 	err = header.Write(buf)
 	assert.Nil(t, err)
 
-	r, err = newEventReader(schemapb.DataType_Int64, buf)
+	r, err = newEventReader(schemapb.DataType_Int64, nil, buf)
 	assert.Nil(t, r)
 	assert.NotNil(t, err)
 
@@ -1061,7 +1061,7 @@ func TestEventReaderError(t *testing.T) {
 	err = header.Write(buf)
 	assert.Nil(t, err)
 
-	r, err = newEventReader(schemapb.DataType_Int64, buf)
+	r, err = newEventReader(schemapb.DataType_Int64, nil, buf)
 	assert.Nil(t, r)
 	assert.NotNil(t, err)
 
@@ -1078,7 +1078,7 @@ func TestEventReaderError(t *testing.T) {
 	err = binary.Write(buf, common.Endian, insertData)
 	assert.Nil(t, err)
 
-	r, err = newEventReader(schemapb.DataType_Int64, buf)
+	r, err = newEventReader(schemapb.DataType_Int64, nil, buf)
 	assert.Nil(t, r)
 	assert.NotNil(t, err)
 
@@ -1099,14 +1099,14 @@ func TestEventClose(t *testing.T) {
 	w.Close()
 
 	wBuf := buf.Bytes()
-	r, err := newEventReader(schemapb.DataType_String, bytes.NewBuffer(wBuf))
+	r, err := newEventReader(schemapb.DataType_String, nil, bytes.NewBuffer(wBuf))
 	assert.Nil(t, err)
 
 	r.Close()
 
 	err = r.readHeader()
 	assert.NotNil(t, err)
-	err = r.readData()
+	err = r.readData(nil)
 	assert.NotNil(t, err)
 }
 
@@ -170,6 +170,22 @@ func (writer *baseEventWriter) SetOffset(offset int32) {
 type insertEventWriter struct {
 	baseEventWriter
 	insertEventData
+	// payloadCompression is the codec of the payload, lz4 is recorded after the insert event data
+	payloadCompression PayloadCompression
+}
+
+func (writer *insertEventWriter) getFixPartSize() int32 {
+	return writer.insertEventData.GetEventDataFixPartSize() + writer.payloadCompression.codeSize()
+}
+
+func (writer *insertEventWriter) writeFixPart(buffer io.Writer) error {
+	if err := writer.insertEventData.WriteEventData(buffer); err != nil {
+		return err
+	}
+	if writer.payloadCompression.codeSize() == 0 {
+		return nil
+	}
+	return binary.Write(buffer, common.Endian, payloadCompressionLz4Code)
 }
 
 type deleteEventWriter struct {
@@ -212,15 +228,15 @@ func newDescriptorEvent() *descriptorEvent {
 }
 
 func newInsertEventWriter(dataType schemapb.DataType, dim ...int) (*insertEventWriter, error) {
-	var payloadWriter *PayloadWriter
+	var payloadWriter PayloadWriterInterface
 	var err error
 	if typeutil.IsVectorType(dataType) {
 		if len(dim) != 1 {
 			return nil, fmt.Errorf("incorrect input numbers")
 		}
-		payloadWriter, err = NewPayloadWriter(dataType, dim[0])
+		payloadWriter, err = newPayloadWriter(dataType, dim[0])
 	} else {
-		payloadWriter, err = NewPayloadWriter(dataType)
+		payloadWriter, err = newPayloadWriter(dataType)
 	}
 	if err != nil {
 		return nil, err
@@ -237,13 +253,13 @@ func newInsertEventWriter(dataType schemapb.DataType, dim ...int) (*insertEventW
 		},
 		insertEventData: *data,
 	}
-	writer.baseEventWriter.getEventDataSize = writer.insertEventData.GetEventDataFixPartSize
-	writer.baseEventWriter.writeEventData = writer.insertEventData.WriteEventData
+	writer.baseEventWriter.getEventDataSize = writer.getFixPartSize
+	writer.baseEventWriter.writeEventData = writer.writeFixPart
 	return writer, nil
 }
 
 func newDeleteEventWriter(dataType schemapb.DataType) (*deleteEventWriter, error) {
-	payloadWriter, err := NewPayloadWriter(dataType)
+	payloadWriter, err := newPayloadWriter(dataType)
 	if err != nil {
 		return nil, err
 	}
@@ -269,7 +285,7 @@ func newCreateCollectionEventWriter(dataType schemapb.DataType) (*createCollecti
 		return nil, errors.New("incorrect data type")
 	}
 
-	payloadWriter, err := NewPayloadWriter(dataType)
+	payloadWriter, err := newPayloadWriter(dataType)
 	if err != nil {
 		return nil, err
 	}
@@ -295,7 +311,7 @@ func newDropCollectionEventWriter(dataType schemapb.DataType) (*dropCollectionEv
 		return nil, errors.New("incorrect data type")
 	}
 
-	payloadWriter, err := NewPayloadWriter(dataType)
+	payloadWriter, err := newPayloadWriter(dataType)
 	if err != nil {
 		return nil, err
 	}
@@ -321,7 +337,7 @@ func newCreatePartitionEventWriter(dataType schemapb.DataType) (*createPartition
 		return nil, errors.New("incorrect data type")
 	}
 
-	payloadWriter, err := NewPayloadWriter(dataType)
+	payloadWriter, err := newPayloadWriter(dataType)
 	if err != nil {
 		return nil, err
 	}
@@ -347,7 +363,7 @@ func newDropPartitionEventWriter(dataType schemapb.DataType) (*dropPartitionEven
 		return nil, errors.New("incorrect data type")
 	}
 
-	payloadWriter, err := NewPayloadWriter(dataType)
+	payloadWriter, err := newPayloadWriter(dataType)
 	if err != nil {
 		return nil, err
 	}
@@ -369,7 +385,7 @@ func newDropPartitionEventWriter(dataType schemapb.DataType) (*dropPartitionEven
 }
 
 func newIndexFileEventWriter(dataType schemapb.DataType) (*indexFileEventWriter, error) {
-	payloadWriter, err := NewPayloadWriter(dataType)
+	payloadWriter, err := newPayloadWriter(dataType)
 	if err != nil {
 		return nil, err
 	}
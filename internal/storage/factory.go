@@ -3,58 +3,461 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"go.uber.org/zap"
 )
 
+// FactoryFunc creates the ChunkManager of a storage backend with the options given to ChunkManagerFactory.
+type FactoryFunc func(ctx context.Context, opts ...Option) (ChunkManager, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]FactoryFunc)
+)
+
+func init() {
+	RegisterFactory("local", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		return newLocalChunkManager(opts...)
+	})
+	RegisterFactory("minio", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		return NewMinioChunkManager(ctx, opts...)
+	})
+	RegisterFactory("azure", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		return NewAzureChunkManager(ctx, opts...)
+	})
+	RegisterFactory("memory", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		return newSharedMemoryChunkManager(opts...), nil
+	})
+	RegisterFactory("rocksdb", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		return newSharedRocksChunkManager(opts...)
+	})
+	RegisterFactory("remote", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		return NewRemoteChunkManager(ctx, opts...)
+	})
+}
+
+// RegisterFactory registers the storage backend @name, which is selected by the `common.storageType` config,
+// so that other backends could be plugged in without patching the factory. The backend with the same name is replaced.
+// The backends other than local are given the options of the `minio` config section.
+func RegisterFactory(name string, f FactoryFunc) {
+	if f == nil {
+		panic("storage: RegisterFactory with nil factory func for " + name)
+	}
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[name] = f
+}
+
+func getFactory(name string) (FactoryFunc, bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	f, ok := factories[name]
+	return f, ok
+}
+
 type ChunkManagerFactory struct {
 	persistentStorage string
-	config            *config
+	opts              []Option
 }
 
 func NewChunkManagerFactoryWithParam(params *paramtable.ComponentParam) *ChunkManagerFactory {
 	if params.CommonCfg.StorageType == "local" {
-		return NewChunkManagerFactory("local", RootPath(params.LocalStorageCfg.Path.GetValue()))
+		return NewChunkManagerFactory("local",
+			RootPath(params.LocalStorageCfg.Path.GetValue()),
+			Concurrency(params.LocalStorageCfg.Concurrency.GetAsInt()),
+			WithFsync(params.LocalStorageCfg.Fsync.GetAsBool()),
+			WithJournal(params.LocalStorageCfg.Journal.GetAsBool()),
+			DiskQuota(params.LocalStorageCfg.DiskHighWatermark.GetAsFloat(),
+				params.LocalStorageCfg.DiskLowWatermark.GetAsFloat()),
+			ReadOnly(params.CommonCfg.StorageReadOnly),
+			TenantQuotas(params.CommonCfg.StorageTenantDefaultQuota, params.CommonCfg.StorageTenantQuotas),
+			StorageAudit(params.CommonCfg.StorageAuditFilePath, params.CommonCfg.StorageAuditMaxSizeMB,
+				params.CommonCfg.StorageAuditOverwrite),
+			Dedup(params.CommonCfg.StorageDedupEnabled, params.CommonCfg.StorageDedupMinSize),
+			Chaos(chaosConfigFromParam(params)))
 	}
-	return NewChunkManagerFactory("minio",
+	if params.CommonCfg.StorageType == "rocksdb" {
+		return NewChunkManagerFactory("rocksdb",
+			RootPath(params.LocalStorageCfg.Path.GetValue()),
+			Concurrency(params.LocalStorageCfg.Concurrency.GetAsInt()),
+			WithFsync(params.LocalStorageCfg.Fsync.GetAsBool()),
+			DiskQuota(params.LocalStorageCfg.DiskHighWatermark.GetAsFloat(),
+				params.LocalStorageCfg.DiskLowWatermark.GetAsFloat()),
+			SmallObjectThreshold(int64(params.LocalStorageCfg.SmallObjectThreshold.GetAsInt())),
+			ReadOnly(params.CommonCfg.StorageReadOnly),
+			TenantQuotas(params.CommonCfg.StorageTenantDefaultQuota, params.CommonCfg.StorageTenantQuotas),
+			StorageAudit(params.CommonCfg.StorageAuditFilePath, params.CommonCfg.StorageAuditMaxSizeMB,
+				params.CommonCfg.StorageAuditOverwrite),
+			Dedup(params.CommonCfg.StorageDedupEnabled, params.CommonCfg.StorageDedupMinSize),
+			Chaos(chaosConfigFromParam(params)))
+	}
+	if params.CommonCfg.StorageType == "memory" {
+		return NewChunkManagerFactory("memory",
+			RootPath(params.MinioCfg.RootPath.GetValue()),
+			BucketName(params.MinioCfg.BucketName.GetValue()),
+			MemoryCapacity(params.CommonCfg.StorageMemoryCapacity),
+			ReadOnly(params.CommonCfg.StorageReadOnly),
+			TenantQuotas(params.CommonCfg.StorageTenantDefaultQuota, params.CommonCfg.StorageTenantQuotas),
+			StorageAudit(params.CommonCfg.StorageAuditFilePath, params.CommonCfg.StorageAuditMaxSizeMB,
+				params.CommonCfg.StorageAuditOverwrite),
+			Dedup(params.CommonCfg.StorageDedupEnabled, params.CommonCfg.StorageDedupMinSize),
+			Chaos(chaosConfigFromParam(params)))
+	}
+	if params.CommonCfg.StorageType == "remote" {
+		// the dedup is left to the storage gateway, which stores the deduplicated objects
+		return NewChunkManagerFactory("remote",
+			RootPath(params.MinioCfg.RootPath.GetValue()),
+			Gateway(params.StorageGatewayCfg.Address.GetValue(), params.StorageGatewayCfg.MaxMessageSize.GetAsInt()*1024*1024),
+			GatewayAuth(params.StorageGatewayCfg.Token.GetValue(), params.StorageGatewayCfg.TLSMode.GetAsInt(),
+				params.StorageGatewayCfg.ServerPemPath.GetValue(), params.StorageGatewayCfg.ServerKeyPath.GetValue(),
+				params.StorageGatewayCfg.CaPemPath.GetValue()),
+			ReadOnly(params.CommonCfg.StorageReadOnly),
+			TenantQuotas(params.CommonCfg.StorageTenantDefaultQuota, params.CommonCfg.StorageTenantQuotas),
+			StorageAudit(params.CommonCfg.StorageAuditFilePath, params.CommonCfg.StorageAuditMaxSizeMB,
+				params.CommonCfg.StorageAuditOverwrite),
+			Chaos(chaosConfigFromParam(params)))
+	}
+
+	address := params.MinioCfg.Address.GetValue()
+	accessKeyID := params.MinioCfg.AccessKeyID.GetValue()
+	secretAccessKey := params.MinioCfg.SecretAccessKey.GetValue()
+	useSSL := params.MinioCfg.UseSSL.GetAsBool()
+	override, err := params.MinioCfg.GetEndpointOverride(paramtable.GetRole())
+	if err != nil {
+		panic(err)
+	}
+	if override != nil {
+		log.Info("object storage endpoint is overridden", zap.String("role", paramtable.GetRole()), zap.String("address", override.Address))
+		address = override.Address
+		if override.AccessKeyID != "" {
+			accessKeyID = override.AccessKeyID
+		}
+		if override.SecretAccessKey != "" {
+			secretAccessKey = override.SecretAccessKey
+		}
+		if override.UseSSL != nil {
+			useSSL = *override.UseSSL
+		}
+	}
+
+	return NewChunkManagerFactory(params.CommonCfg.StorageType,
 		RootPath(params.MinioCfg.RootPath.GetValue()),
-		Address(params.MinioCfg.Address.GetValue()),
-		AccessKeyID(params.MinioCfg.AccessKeyID.GetValue()),
-		SecretAccessKeyID(params.MinioCfg.SecretAccessKey.GetValue()),
-		UseSSL(params.MinioCfg.UseSSL.GetAsBool()),
+		Address(address),
+		AccessKeyID(accessKeyID),
+		SecretAccessKeyID(secretAccessKey),
+		UseSSL(useSSL),
 		BucketName(params.MinioCfg.BucketName.GetValue()),
 		UseIAM(params.MinioCfg.UseIAM.GetAsBool()),
 		CloudProvider(params.MinioCfg.CloudProvider.GetValue()),
 		IAMEndpoint(params.MinioCfg.IAMEndpoint.GetValue()),
-		CreateBucket(true))
+		AssumeRole(assumeRoleFromParam(params)),
+		GCPAuth(params.MinioCfg.GCPAuthMode.GetValue(), params.MinioCfg.GCPCredentialsFile.GetValue()),
+		AzureAuth(params.MinioCfg.AzureAuthMode.GetValue(), params.MinioCfg.AzureClientID.GetValue(),
+			params.MinioCfg.AzureSASTokenFile.GetValue(),
+			time.Duration(params.MinioCfg.AzureRefreshWindow.GetAsInt())*time.Second),
+		Concurrency(params.MinioCfg.Concurrency.GetAsInt()),
+		PrefetchWindow(params.MinioCfg.PrefetchWindow.GetAsInt()),
+		ListObjectMetadata(params.MinioCfg.ListObjectMetadata.GetAsBool()),
+		SelectPushdown(params.MinioCfg.SelectPushdown.GetAsBool()),
+		IOGovernorLimits(params.MinioCfg.GovernorMaxConcurrency.GetAsInt(),
+			int64(params.MinioCfg.GovernorMaxBandwidth.GetAsInt())*1024*1024,
+			params.MinioCfg.GovernorHostSocket.GetValue()),
+		transportFromParam(params),
+		WithUploadPartSize(uint64(params.MinioCfg.UploadPartSize.GetAsInt())*1024*1024),
+		WithUploadConcurrency(params.MinioCfg.UploadConcurrency.GetAsInt()),
+		WithDownloadConcurrency(params.MinioCfg.DownloadConcurrency.GetAsInt()),
+		AdaptiveThrottling(params.MinioCfg.ThrottleEnabled.GetAsBool(),
+			params.MinioCfg.ThrottleMinConcurrency.GetAsInt(),
+			params.MinioCfg.ThrottleMaxConcurrency.GetAsInt()),
+		ObjectLock(params.MinioCfg.ObjectLockMode.GetValue(),
+			time.Duration(params.MinioCfg.ObjectLockRetentionDays.GetAsInt())*24*time.Hour,
+			params.MinioCfg.ObjectLockLegalHold.GetAsBool()),
+		storageClassFromParam(params),
+		// an overridden endpoint must serve the existing bucket instead of creating a new one
+		CreateBucket(!params.CommonCfg.StorageReadOnly && override == nil),
+		ReadOnly(params.CommonCfg.StorageReadOnly),
+		TenantQuotas(params.CommonCfg.StorageTenantDefaultQuota, params.CommonCfg.StorageTenantQuotas),
+		StorageAudit(params.CommonCfg.StorageAuditFilePath, params.CommonCfg.StorageAuditMaxSizeMB,
+			params.CommonCfg.StorageAuditOverwrite),
+		Dedup(params.CommonCfg.StorageDedupEnabled, params.CommonCfg.StorageDedupMinSize),
+		StorageMarker(len(params.MinioCfg.EndpointOverrides.GetValue()) > 0, override != nil),
+		Chaos(chaosConfigFromParam(params)),
+		RequesterPays(params.MinioCfg.RequesterPays.GetAsBool()),
+		WithBuckets(bucketsFromParam(params)...),
+		KeySharding(shardBucketsFromParam(params), params.MinioCfg.ShardingPrefixes.GetAsInt()),
+		replicationFromParam(params),
+		LegacyRootPaths(legacyRootPathsFromParam(params)...))
 }
 
-func NewChunkManagerFactory(persistentStorage string, opts ...Option) *ChunkManagerFactory {
-	c := newDefaultConfig()
-	for _, opt := range opts {
-		opt(c)
+// assumeRoleFromParam returns the IAM role configured by "minio.assumeRole".
+func assumeRoleFromParam(params *paramtable.ComponentParam) AssumeRoleConfig {
+	return AssumeRoleConfig{
+		RoleARN:       params.MinioCfg.AssumeRoleARN.GetValue(),
+		ExternalID:    params.MinioCfg.AssumeRoleExternalID.GetValue(),
+		SessionName:   params.MinioCfg.AssumeRoleSessionName.GetValue(),
+		STSEndpoint:   params.MinioCfg.AssumeRoleSTSEndpoint.GetValue(),
+		Region:        params.MinioCfg.AssumeRoleRegion.GetValue(),
+		Duration:      time.Duration(params.MinioCfg.AssumeRoleDuration.GetAsInt()) * time.Second,
+		RefreshWindow: time.Duration(params.MinioCfg.AssumeRoleRefreshWindow.GetAsInt()) * time.Second,
+	}
+}
+
+// transportFromParam returns the Transport option configured by "minio.transport".
+func transportFromParam(params *paramtable.ComponentParam) Option {
+	transport := TransportConfig{
+		MaxIdleConns:          params.MinioCfg.TransportMaxIdleConns.GetAsInt(),
+		MaxIdleConnsPerHost:   params.MinioCfg.TransportMaxIdleConnsPerHost.GetAsInt(),
+		MaxConnsPerHost:       params.MinioCfg.TransportMaxConnsPerHost.GetAsInt(),
+		DialTimeout:           time.Duration(params.MinioCfg.TransportDialTimeout.GetAsInt()) * time.Millisecond,
+		IdleConnTimeout:       time.Duration(params.MinioCfg.TransportIdleConnTimeout.GetAsInt()) * time.Second,
+		ResponseHeaderTimeout: time.Duration(params.MinioCfg.TransportResponseHeaderTimeout.GetAsInt()) * time.Second,
+		UnixSocket:            params.MinioCfg.TransportUnixSocket.GetValue(),
+	}
+	caCertFile := params.MinioCfg.TransportCACertFile.GetValue()
+	insecureSkipVerify := params.MinioCfg.TransportInsecureSkipVerify.GetAsBool()
+	if caCertFile != "" || insecureSkipVerify {
+		tlsConfig, err := NewTLSConfig(caCertFile, insecureSkipVerify)
+		if err != nil {
+			panic(err)
+		}
+		transport.TLSConfig = tlsConfig
+	}
+	return Transport(transport)
+}
+
+// storageClassFromParam returns the StorageClass option configured by "minio.storageClass".
+func storageClassFromParam(params *paramtable.ComponentParam) Option {
+	policy, err := ParseStorageClassPolicy(params.MinioCfg.StorageClassPolicy.GetValue())
+	if err != nil {
+		panic(err)
+	}
+	return StorageClass(strings.ToUpper(params.MinioCfg.StorageClassDefault.GetValue()), policy)
+}
+
+// replicationFromParam returns the Replication option configured by "minio.replication".
+func replicationFromParam(params *paramtable.ComponentParam) Option {
+	if !params.MinioCfg.ReplicationEnabled.GetAsBool() {
+		return Replication(nil, "", 0)
+	}
+	target, err := params.MinioCfg.GetReplicationTarget()
+	if err != nil {
+		panic(err)
 	}
+	if target == nil {
+		panic("minio.replication.target must be configured when minio.replication.enabled is true")
+	}
+	log.Info("object changes are replicated", zap.String("bucket", target.BucketName), zap.String("address", target.Address))
+	return Replication([]Option{
+		Address(target.Address),
+		BucketName(target.BucketName),
+		AccessKeyID(target.AccessKeyID),
+		SecretAccessKeyID(target.SecretAccessKey),
+		UseSSL(target.UseSSL),
+		UseIAM(target.UseIAM),
+		RequesterPays(target.RequesterPays),
+	}, params.MinioCfg.ReplicationQueueDir.GetValue(),
+		time.Duration(params.MinioCfg.ReplicationInterval.GetAsInt())*time.Second)
+}
+
+// shardBucketsFromParam returns the buckets configured by "minio.sharding.buckets", without the empty names.
+func shardBucketsFromParam(params *paramtable.ComponentParam) []string {
+	var buckets []string
+	for _, bucket := range params.MinioCfg.ShardingBuckets.GetAsStrings() {
+		if bucket = strings.TrimSpace(bucket); bucket != "" {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+// legacyRootPathsFromParam returns the root paths configured by "minio.legacy.rootPaths", without the empty ones.
+func legacyRootPathsFromParam(params *paramtable.ComponentParam) []string {
+	var rootPaths []string
+	for _, rootPath := range params.MinioCfg.LegacyRootPaths.GetAsStrings() {
+		if rootPath = strings.TrimSpace(rootPath); rootPath != "" {
+			rootPaths = append(rootPaths, rootPath)
+		}
+	}
+	return rootPaths
+}
+
+// bucketsFromParam returns the named buckets configured by "minio.buckets", which are never created by the chunk managers.
+func bucketsFromParam(params *paramtable.ComponentParam) []BucketConfig {
+	buckets, err := params.MinioCfg.GetBuckets()
+	if err != nil {
+		panic(err)
+	}
+	configs := make([]BucketConfig, 0, len(buckets))
+	for _, bucket := range buckets {
+		log.Info("object keys are routed to named bucket", zap.String("name", bucket.Name),
+			zap.String("prefix", bucket.Prefix), zap.String("bucket", bucket.BucketName), zap.String("address", bucket.Address))
+		configs = append(configs, BucketConfig{
+			Name:   bucket.Name,
+			Prefix: bucket.Prefix,
+			Opts: []Option{
+				Address(bucket.Address),
+				BucketName(bucket.BucketName),
+				AccessKeyID(bucket.AccessKeyID),
+				SecretAccessKeyID(bucket.SecretAccessKey),
+				UseSSL(bucket.UseSSL),
+				UseIAM(bucket.UseIAM),
+				RequesterPays(bucket.RequesterPays),
+				CreateBucket(false),
+			},
+		})
+	}
+	return configs
+}
+
+// chaosConfigFromParam returns nil if chaos is not enabled, otherwise the returned func reads the chaos config
+// on every call, so that it follows the refreshed configs. Enabling chaos takes a restart, as the chunk managers
+// wrapped by ChaosChunkManager hide the optional interfaces of the storage, e.g. VersionedChunkManager.
+func chaosConfigFromParam(params *paramtable.ComponentParam) ChaosConfigFunc {
+	if !params.MinioCfg.ChaosEnabled.GetAsBool() {
+		return nil
+	}
+	return func() ChaosConfig {
+		return ChaosConfig{
+			Prefix:            params.MinioCfg.ChaosPrefix.GetValue(),
+			WriteErrorRate:    params.MinioCfg.ChaosWriteErrorRate.GetAsFloat(),
+			ReadLatency:       time.Duration(params.MinioCfg.ChaosReadLatency.GetAsInt()) * time.Millisecond,
+			ReadLatencyJitter: time.Duration(params.MinioCfg.ChaosReadLatencyJitter.GetAsInt()) * time.Millisecond,
+		}
+	}
+}
+
+func NewChunkManagerFactory(persistentStorage string, opts ...Option) *ChunkManagerFactory {
 	return &ChunkManagerFactory{
 		persistentStorage: persistentStorage,
-		config:            c,
+		opts:              opts,
 	}
 }
 
 func (f *ChunkManagerFactory) newChunkManager(ctx context.Context, engine string) (ChunkManager, error) {
-	switch engine {
-	case "local":
-		return NewLocalChunkManager(RootPath(f.config.rootPath)), nil
-	case "minio":
-		return newMinioChunkManagerWithConfig(ctx, f.config)
-	default:
+	newFn, ok := getFactory(engine)
+	if !ok {
 		return nil, errors.New("no chunk manager implemented with engine: " + engine)
 	}
+	cm, err := newFn(ctx, f.opts...)
+	if err != nil {
+		return nil, err
+	}
+	c := newDefaultConfig()
+	for _, opt := range f.opts {
+		opt(c)
+	}
+	if c.storageMarker && engine != "local" {
+		if err := checkStorageMarker(ctx, cm, c); err != nil {
+			return nil, err
+		}
+	}
+	if (len(c.shardBuckets) > 0 || c.shardPrefixes > 1) && engine != "local" {
+		shards := []ChunkManager{cm}
+		if len(c.shardBuckets) > 0 {
+			shards = shards[:0]
+			for _, bucketName := range c.shardBuckets {
+				opts := append(append([]Option{}, f.opts...), BucketName(bucketName))
+				shardCM, err := newFn(ctx, opts...)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create chunk manager of shard bucket %s: %w", bucketName, err)
+				}
+				shards = append(shards, shardCM)
+			}
+		}
+		cm = NewShardedChunkManager(shards, c.shardPrefixes)
+	}
+	if len(c.buckets) > 0 && engine != "local" {
+		multi := NewMultiBucketChunkManager(cm)
+		for _, bucket := range c.buckets {
+			opts := append(append([]Option{}, f.opts...), bucket.Opts...)
+			bucketCM, err := newFn(ctx, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create chunk manager of bucket %s: %w", bucket.Name, err)
+			}
+			multi.AddBucket(bucket.Name, bucket.Prefix, bucketCM)
+		}
+		cm = multi
+	}
+	if len(c.replicationTarget) > 0 && engine != "local" {
+		replicator, err := f.getReplicator(ctx, newFn, cm, c)
+		if err != nil {
+			return nil, err
+		}
+		cm = NewReplicatedChunkManager(cm, replicator)
+	}
+	if len(c.legacyRootPaths) > 0 && engine != "local" {
+		cm = NewAliasChunkManager(cm, f.opts...)
+	}
+	if c.chaos != nil {
+		cm = NewChaosChunkManager(cm, c.chaos)
+	}
+	if c.dedup {
+		cm = NewDedupChunkManager(cm, f.opts...)
+	}
+	if c.auditFilePath != "" {
+		sink := getFileAuditSink(c.auditFilePath, c.auditMaxSizeMB)
+		cm = NewAuditChunkManager(cm, sink, paramtable.GetRole(), c.auditOverwrite)
+	}
+	if c.readOnly {
+		return NewReadOnlyChunkManager(cm), nil
+	}
+	return cm, nil
+}
+
+var (
+	replicatorsMu sync.Mutex
+	// replicators are shared by the chunk managers of a process by their queue directories,
+	// as a queue directory must only be consumed by one Replicator
+	replicators = make(map[string]*Replicator)
+)
+
+// getReplicator returns the running Replicator of the queue directory in @c, it is created
+// with @primary and the secondary storage if not yet.
+func (f *ChunkManagerFactory) getReplicator(ctx context.Context, newFn FactoryFunc, primary ChunkManager, c *config) (*Replicator, error) {
+	replicatorsMu.Lock()
+	defer replicatorsMu.Unlock()
+	if replicator, ok := replicators[c.replicationQueueDir]; ok {
+		return replicator, nil
+	}
+	opts := append(append([]Option{}, f.opts...), c.replicationTarget...)
+	secondary, err := newFn(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chunk manager of replication target: %w", err)
+	}
+	replicator, err := NewReplicator(primary, secondary, c.replicationQueueDir, c.replicationInterval)
+	if err != nil {
+		return nil, err
+	}
+	replicator.Start()
+	replicators[c.replicationQueueDir] = replicator
+	return replicator, nil
 }
 
 func (f *ChunkManagerFactory) NewPersistentStorageChunkManager(ctx context.Context) (ChunkManager, error) {
 	return f.newChunkManager(ctx, f.persistentStorage)
 }
 
+// NewTenantChunkManager returns the chunk manager of the persistent storage isolated to the sub-root of @tenant,
+// e.g. a database, see TenantChunkManager. The quotas of the tenants are set by the TenantQuotas option.
+func (f *ChunkManagerFactory) NewTenantChunkManager(ctx context.Context, tenant string) (*TenantChunkManager, error) {
+	cm, err := f.newChunkManager(ctx, f.persistentStorage)
+	if err != nil {
+		return nil, err
+	}
+	opts := f.opts
+	if f.persistentStorage == "local" || f.persistentStorage == "rocksdb" {
+		// the keys of the local storages are relative to the root path
+		opts = append(append([]Option{}, f.opts...), RootPath(""))
+	}
+	return NewTenantChunkManager(cm, tenant, opts...)
+}
+
 type Factory interface {
 	NewPersistentStorageChunkManager(ctx context.Context) (ChunkManager, error)
 }
@@ -0,0 +1,107 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterFactory(t *testing.T) {
+	ctx := context.Background()
+
+	f := NewChunkManagerFactory("local", RootPath(localPath))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	assert.NoError(t, err)
+	assert.IsType(t, &LocalChunkManager{}, cm)
+
+	f = NewChunkManagerFactory("mock-backend", RootPath("root"), BucketName("bucket"), Address("address"))
+	_, err = f.NewPersistentStorageChunkManager(ctx)
+	assert.Error(t, err)
+
+	var config BackendConfig
+	RegisterFactory("mock-backend", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		config = NewBackendConfig(opts...)
+		return nil, errors.New("mock error")
+	})
+	defer func() {
+		factoriesMu.Lock()
+		delete(factories, "mock-backend")
+		factoriesMu.Unlock()
+	}()
+	_, err = f.NewPersistentStorageChunkManager(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, "root", config.RootPath)
+	assert.Equal(t, "bucket", config.BucketName)
+	assert.Equal(t, "address", config.Address)
+
+	localCM := NewLocalChunkManager(RootPath(localPath))
+	RegisterFactory("mock-backend", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		return localCM, nil
+	})
+	cm, err = f.NewPersistentStorageChunkManager(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, localCM, cm)
+
+	assert.Panics(t, func() {
+		RegisterFactory("mock-backend", nil)
+	})
+}
+
+func TestStorageMarker(t *testing.T) {
+	ctx := context.Background()
+	attempts := CheckBucketRetryAttempts
+	CheckBucketRetryAttempts = 1
+	defer func() { CheckBucketRetryAttempts = attempts }()
+
+	rootPath := path.Join(localPath, "storage_marker")
+	localCM := NewLocalChunkManager(RootPath(rootPath))
+	defer localCM.RemoveWithPrefix(ctx, localCM.RootPath())
+	RegisterFactory("marker-backend", func(ctx context.Context, opts ...Option) (ChunkManager, error) {
+		return NewLocalChunkManager(opts...), nil
+	})
+	defer func() {
+		factoriesMu.Lock()
+		delete(factories, "marker-backend")
+		factoriesMu.Unlock()
+	}()
+
+	// the overridden endpoint fails before the marker is written
+	_, err := NewChunkManagerFactory("marker-backend", RootPath(rootPath), StorageMarker(true, true)).
+		NewPersistentStorageChunkManager(ctx)
+	assert.Error(t, err)
+
+	_, err = NewChunkManagerFactory("marker-backend", RootPath(rootPath), StorageMarker(true, false)).
+		NewPersistentStorageChunkManager(ctx)
+	assert.NoError(t, err)
+	exist, err := localCM.Exist(ctx, path.Join(rootPath, StorageMarkerName))
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	_, err = NewChunkManagerFactory("marker-backend", RootPath(rootPath), StorageMarker(true, true)).
+		NewPersistentStorageChunkManager(ctx)
+	assert.NoError(t, err)
+
+	// the overridden endpoint serves another root
+	_, err = NewChunkManagerFactory("marker-backend", RootPath(path.Join(localPath, "another_root")), StorageMarker(true, true)).
+		NewPersistentStorageChunkManager(ctx)
+	assert.Error(t, err)
+}
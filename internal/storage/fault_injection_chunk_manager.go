@@ -0,0 +1,425 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/mmap"
+)
+
+// ErrInjectedFault is returned by the operations failed by FaultInjectionChunkManager.
+var ErrInjectedFault = errors.New("InjectedFault")
+
+func WrapErrInjectedFault(op FaultOp, filePath string) error {
+	return fmt.Errorf("%w(op=%s, key=%s)", ErrInjectedFault, op, filePath)
+}
+
+// FaultOp is the kind of ChunkManager operations the faults are injected into.
+type FaultOp string
+
+const (
+	// FaultOpRead covers Read, MultiRead, ReadAt, MultiReadAt, Reader, ReadWithPrefix and Mmap
+	FaultOpRead FaultOp = "read"
+	// FaultOpWrite covers Write, WriteWithOptions, WriteIfNotExist, MultiWrite, Append, Copy and Move
+	FaultOpWrite FaultOp = "write"
+	// FaultOpStat covers Path, Size, Stat, MultiStat and Exist
+	FaultOpStat FaultOp = "stat"
+	// FaultOpList covers ListWithPrefix, WalkWithPrefix and PrefixSize
+	FaultOpList FaultOp = "list"
+	// FaultOpRemove covers Remove, MultiRemove and RemoveWithPrefix
+	FaultOpRemove FaultOp = "remove"
+)
+
+// Fault describes the faults injected into an operation, the rates are probabilities in [0, 1].
+type Fault struct {
+	// Prefix limits the fault to the keys with the prefix, empty for all keys
+	Prefix string
+	// Latency is added before the operation, plus a random jitter up to LatencyJitter
+	Latency       time.Duration
+	LatencyJitter time.Duration
+	// ErrorRate fails the operation with ErrInjectedFault without touching the storage
+	ErrorRate float64
+	// PartialWriteRate writes a random prefix of the content, then fails the write with ErrInjectedFault
+	PartialWriteRate float64
+	// TornReadRate returns a random prefix of the content read without error
+	TornReadRate float64
+}
+
+// FaultInjectionChunkManager injects latency, errors, partial writes and torn reads into the wrapped ChunkManager,
+// so that the recovery logic can be tested without a flaky object storage.
+// The faults are drawn from a seeded source, so a test reproduces the same faults for the same sequence of operations.
+type FaultInjectionChunkManager struct {
+	ChunkManager
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	faults   map[FaultOp]*Fault
+	injected map[FaultOp]int
+
+	// refresh is called before looking up the faults, so that they could be updated lazily, e.g. by ChaosChunkManager
+	refresh func()
+}
+
+var _ ChunkManager = (*FaultInjectionChunkManager)(nil)
+
+// NewFaultInjectionChunkManager returns @cm injecting no fault until SetFault is called.
+func NewFaultInjectionChunkManager(cm ChunkManager, seed int64) *FaultInjectionChunkManager {
+	return &FaultInjectionChunkManager{
+		ChunkManager: cm,
+		rand:         rand.New(rand.NewSource(seed)),
+		faults:       make(map[FaultOp]*Fault),
+		injected:     make(map[FaultOp]int),
+	}
+}
+
+// Unwrap returns the wrapped chunk manager.
+func (f *FaultInjectionChunkManager) Unwrap() ChunkManager {
+	return f.ChunkManager
+}
+
+// SetFault sets the fault of @op, nil clears it.
+func (fm *FaultInjectionChunkManager) SetFault(op FaultOp, fault *Fault) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if fault == nil {
+		delete(fm.faults, op)
+		return
+	}
+	fm.faults[op] = fault
+}
+
+// ClearFaults stops injecting faults.
+func (fm *FaultInjectionChunkManager) ClearFaults() {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	fm.faults = make(map[FaultOp]*Fault)
+}
+
+// Injected returns the number of errors, partial writes and torn reads injected into @op.
+func (fm *FaultInjectionChunkManager) Injected(op FaultOp) int {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	return fm.injected[op]
+}
+
+func (fm *FaultInjectionChunkManager) fault(op FaultOp, filePath string) *Fault {
+	if fm.refresh != nil {
+		fm.refresh()
+	}
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	fault, ok := fm.faults[op]
+	if !ok || !strings.HasPrefix(filePath, fault.Prefix) {
+		return nil
+	}
+	return fault
+}
+
+// hit draws whether the fault of @rate happens, and counts it.
+func (fm *FaultInjectionChunkManager) hit(op FaultOp, rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if fm.rand.Float64() >= rate {
+		return false
+	}
+	fm.injected[op]++
+	return true
+}
+
+// truncate returns a random strict prefix of @content.
+func (fm *FaultInjectionChunkManager) truncate(content []byte) []byte {
+	if len(content) == 0 {
+		return content
+	}
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	return content[:fm.rand.Intn(len(content))]
+}
+
+// before delays and fails the operation on @filePath per the fault of @op.
+func (fm *FaultInjectionChunkManager) before(ctx context.Context, op FaultOp, filePath string) error {
+	fault := fm.fault(op, filePath)
+	if fault == nil {
+		return nil
+	}
+	latency := fault.Latency
+	if fault.LatencyJitter > 0 {
+		fm.mu.Lock()
+		latency += time.Duration(fm.rand.Int63n(int64(fault.LatencyJitter)))
+		fm.mu.Unlock()
+	}
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if fm.hit(op, fault.ErrorRate) {
+		return WrapErrInjectedFault(op, filePath)
+	}
+	return nil
+}
+
+// tear returns a torn copy of @content read from @filePath per the fault of read.
+func (fm *FaultInjectionChunkManager) tear(filePath string, content []byte) []byte {
+	fault := fm.fault(FaultOpRead, filePath)
+	if fault == nil || !fm.hit(FaultOpRead, fault.TornReadRate) {
+		return content
+	}
+	return fm.truncate(content)
+}
+
+// partialWrite writes a prefix of @content and returns true if a partial write is injected.
+func (fm *FaultInjectionChunkManager) partialWrite(ctx context.Context, filePath string, content []byte) (bool, error) {
+	fault := fm.fault(FaultOpWrite, filePath)
+	if fault == nil || !fm.hit(FaultOpWrite, fault.PartialWriteRate) {
+		return false, nil
+	}
+	if err := fm.ChunkManager.Write(ctx, filePath, fm.truncate(content)); err != nil {
+		return true, err
+	}
+	return true, WrapErrInjectedFault(FaultOpWrite, filePath)
+}
+
+func (fm *FaultInjectionChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	if err := fm.before(ctx, FaultOpStat, filePath); err != nil {
+		return "", err
+	}
+	return fm.ChunkManager.Path(ctx, filePath)
+}
+
+func (fm *FaultInjectionChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	if err := fm.before(ctx, FaultOpStat, filePath); err != nil {
+		return 0, err
+	}
+	return fm.ChunkManager.Size(ctx, filePath)
+}
+
+func (fm *FaultInjectionChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	if err := fm.before(ctx, FaultOpStat, filePath); err != nil {
+		return ObjectInfo{}, err
+	}
+	return fm.ChunkManager.Stat(ctx, filePath)
+}
+
+func (fm *FaultInjectionChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	for _, filePath := range filePaths {
+		if err := fm.before(ctx, FaultOpStat, filePath); err != nil {
+			return nil, err
+		}
+	}
+	return fm.ChunkManager.MultiStat(ctx, filePaths)
+}
+
+func (fm *FaultInjectionChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	if err := fm.before(ctx, FaultOpStat, filePath); err != nil {
+		return false, err
+	}
+	return fm.ChunkManager.Exist(ctx, filePath)
+}
+
+func (fm *FaultInjectionChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	if err := fm.before(ctx, FaultOpWrite, filePath); err != nil {
+		return err
+	}
+	if partial, err := fm.partialWrite(ctx, filePath, content); partial {
+		return err
+	}
+	return fm.ChunkManager.Write(ctx, filePath, content)
+}
+
+func (fm *FaultInjectionChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	if err := fm.before(ctx, FaultOpWrite, filePath); err != nil {
+		return err
+	}
+	if partial, err := fm.partialWrite(ctx, filePath, content); partial {
+		return err
+	}
+	return fm.ChunkManager.WriteWithOptions(ctx, filePath, content, opts...)
+}
+
+func (fm *FaultInjectionChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	if err := fm.before(ctx, FaultOpWrite, filePath); err != nil {
+		return err
+	}
+	return fm.ChunkManager.WriteIfNotExist(ctx, filePath, content)
+}
+
+// MultiWrite writes the contents one by one, so that a fault leaves the contents before it written.
+func (fm *FaultInjectionChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	for filePath, content := range contents {
+		if err := fm.Write(ctx, filePath, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fm *FaultInjectionChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	if err := fm.before(ctx, FaultOpWrite, filePath); err != nil {
+		return err
+	}
+	return fm.ChunkManager.Append(ctx, filePath, content)
+}
+
+func (fm *FaultInjectionChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	if err := fm.before(ctx, FaultOpWrite, dstFilePath); err != nil {
+		return err
+	}
+	return fm.ChunkManager.Copy(ctx, srcFilePath, dstFilePath)
+}
+
+func (fm *FaultInjectionChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	if err := fm.before(ctx, FaultOpWrite, dstFilePath); err != nil {
+		return err
+	}
+	return fm.ChunkManager.Move(ctx, srcFilePath, dstFilePath)
+}
+
+func (fm *FaultInjectionChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	if err := fm.before(ctx, FaultOpRead, filePath); err != nil {
+		return nil, err
+	}
+	content, err := fm.ChunkManager.Read(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return fm.tear(filePath, content), nil
+}
+
+func (fm *FaultInjectionChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	if err := fm.before(ctx, FaultOpRead, filePath); err != nil {
+		return nil, err
+	}
+	return fm.ChunkManager.Reader(ctx, filePath)
+}
+
+func (fm *FaultInjectionChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	contents := make([][]byte, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		content, err := fm.Read(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+func (fm *FaultInjectionChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	if err := fm.before(ctx, FaultOpList, prefix); err != nil {
+		return nil, nil, err
+	}
+	return fm.ChunkManager.ListWithPrefix(ctx, prefix, recursive)
+}
+
+func (fm *FaultInjectionChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	if err := fm.before(ctx, FaultOpList, prefix); err != nil {
+		return err
+	}
+	return fm.ChunkManager.WalkWithPrefix(ctx, prefix, recursive, walkFunc)
+}
+
+func (fm *FaultInjectionChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	if err := fm.before(ctx, FaultOpList, prefix); err != nil {
+		return 0, 0, err
+	}
+	return fm.ChunkManager.PrefixSize(ctx, prefix)
+}
+
+func (fm *FaultInjectionChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	if err := fm.before(ctx, FaultOpRead, prefix); err != nil {
+		return nil, nil, err
+	}
+	filePaths, contents, err := fm.ChunkManager.ReadWithPrefix(ctx, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range contents {
+		contents[i] = fm.tear(filePaths[i], contents[i])
+	}
+	return filePaths, contents, nil
+}
+
+func (fm *FaultInjectionChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	if err := fm.before(ctx, FaultOpRead, filePath); err != nil {
+		return nil, err
+	}
+	return fm.ChunkManager.Mmap(ctx, filePath)
+}
+
+func (fm *FaultInjectionChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	if err := fm.before(ctx, FaultOpRead, filePath); err != nil {
+		return nil, err
+	}
+	content, err := fm.ChunkManager.ReadAt(ctx, filePath, off, length)
+	if err != nil {
+		return nil, err
+	}
+	return fm.tear(filePath, content), nil
+}
+
+func (fm *FaultInjectionChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	if err := fm.before(ctx, FaultOpRead, filePath); err != nil {
+		return nil, err
+	}
+	contents, err := fm.ChunkManager.MultiReadAt(ctx, filePath, ranges)
+	if err != nil {
+		return nil, err
+	}
+	for i := range contents {
+		contents[i] = fm.tear(filePath, contents[i])
+	}
+	return contents, nil
+}
+
+func (fm *FaultInjectionChunkManager) Remove(ctx context.Context, filePath string) error {
+	if err := fm.before(ctx, FaultOpRemove, filePath); err != nil {
+		return err
+	}
+	return fm.ChunkManager.Remove(ctx, filePath)
+}
+
+// MultiRemove removes the files one by one, so that a fault leaves the files before it removed.
+func (fm *FaultInjectionChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	for _, filePath := range filePaths {
+		if err := fm.Remove(ctx, filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fm *FaultInjectionChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	if err := fm.before(ctx, FaultOpRemove, prefix); err != nil {
+		return err
+	}
+	return fm.ChunkManager.RemoveWithPrefix(ctx, prefix)
+}
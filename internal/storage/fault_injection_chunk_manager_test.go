@@ -0,0 +1,121 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultInjectionChunkManager(t *testing.T) {
+	ctx := context.Background()
+	cm := NewLocalChunkManager(RootPath(t.TempDir()))
+	fm := NewFaultInjectionChunkManager(cm, 1)
+	content := []byte("0123456789")
+
+	// no fault
+	require.NoError(t, fm.Write(ctx, "a/b", content))
+	got, err := fm.Read(ctx, "a/b")
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	t.Run("error", func(t *testing.T) {
+		defer fm.ClearFaults()
+		fm.SetFault(FaultOpRead, &Fault{ErrorRate: 1})
+		_, err := fm.Read(ctx, "a/b")
+		assert.ErrorIs(t, err, ErrInjectedFault)
+		_, err = fm.MultiRead(ctx, []string{"a/b"})
+		assert.ErrorIs(t, err, ErrInjectedFault)
+		_, err = fm.ReadAt(ctx, "a/b", 0, 1)
+		assert.ErrorIs(t, err, ErrInjectedFault)
+		assert.Equal(t, 3, fm.Injected(FaultOpRead))
+
+		// other ops are not affected
+		exist, err := fm.Exist(ctx, "a/b")
+		assert.NoError(t, err)
+		assert.True(t, exist)
+
+		fm.SetFault(FaultOpRemove, &Fault{ErrorRate: 1, Prefix: "a/"})
+		assert.ErrorIs(t, fm.Remove(ctx, "a/b"), ErrInjectedFault)
+		assert.ErrorIs(t, fm.RemoveWithPrefix(ctx, "a/"), ErrInjectedFault)
+		assert.NoError(t, fm.Remove(ctx, "c/d"))
+
+		fm.SetFault(FaultOpRemove, nil)
+		fm.SetFault(FaultOpRead, &Fault{ErrorRate: 1, Prefix: "c/"})
+		_, err = fm.Read(ctx, "a/b")
+		assert.NoError(t, err)
+	})
+
+	t.Run("partial write", func(t *testing.T) {
+		defer fm.ClearFaults()
+		fm.SetFault(FaultOpWrite, &Fault{PartialWriteRate: 1})
+		assert.ErrorIs(t, fm.Write(ctx, "a/partial", content), ErrInjectedFault)
+		written, err := cm.Read(ctx, "a/partial")
+		require.NoError(t, err)
+		assert.Less(t, len(written), len(content))
+		assert.Equal(t, content[:len(written)], written)
+
+		assert.ErrorIs(t, fm.MultiWrite(ctx, map[string][]byte{"a/m1": content}), ErrInjectedFault)
+	})
+
+	t.Run("torn read", func(t *testing.T) {
+		defer fm.ClearFaults()
+		fm.SetFault(FaultOpRead, &Fault{TornReadRate: 1})
+		got, err := fm.Read(ctx, "a/b")
+		require.NoError(t, err)
+		assert.Less(t, len(got), len(content))
+		assert.Equal(t, content[:len(got)], got)
+
+		parts, err := fm.MultiReadAt(ctx, "a/b", []Range{{Offset: 0, Length: 4}, {Offset: 4, Length: 4}})
+		require.NoError(t, err)
+		for _, part := range parts {
+			assert.Less(t, len(part), 4)
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		defer fm.ClearFaults()
+		fm.SetFault(FaultOpStat, &Fault{Latency: 20 * time.Millisecond, LatencyJitter: time.Millisecond})
+		start := time.Now()
+		_, err := fm.Size(ctx, "a/b")
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+		fm.SetFault(FaultOpStat, &Fault{Latency: time.Minute})
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err = fm.Stat(ctx, "a/b")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		defer fm.ClearFaults()
+		fm.SetFault(FaultOpStat, &Fault{ErrorRate: 0.5})
+		failed := 0
+		for i := 0; i < 1000; i++ {
+			if _, err := fm.Exist(ctx, "a/b"); err != nil {
+				failed++
+			}
+		}
+		assert.InDelta(t, 500, failed, 100)
+		assert.Equal(t, failed, fm.Injected(FaultOpStat))
+	})
+}
@@ -0,0 +1,126 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus/internal/proto/storagepb"
+)
+
+// GatewayTokenKey is the metadata key of the shared token authenticating the requests to the storage gateway.
+const GatewayTokenKey = "storage-gateway-token"
+
+// GatewayTLSConfig returns the TLS config of the storage gateway by the tls mode of common.security.tlsMode,
+// nil if the mode is 0. The gateway presents the cert of @certPath and @keyPath, which the clients verify
+// by the CA of @caPath. In mode 2 the clients present the same cert, which the gateway verifies by the CA.
+func GatewayTLSConfig(mode int, certPath, keyPath, caPath string, server bool) (*tls.Config, error) {
+	if mode == 0 {
+		return nil, nil
+	}
+	if mode != 1 && mode != 2 {
+		return nil, fmt.Errorf("invalid tls mode %d", mode)
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if server || mode == 2 {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load storage gateway cert: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if !server || mode == 2 {
+		ca, err := ioutil.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read storage gateway ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to append storage gateway ca %s", caPath)
+		}
+		if server {
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			tlsConfig.ClientCAs = pool
+		} else {
+			tlsConfig.RootCAs = pool
+		}
+	}
+	return tlsConfig, nil
+}
+
+// NewGatewayGrpcServer returns the grpc server serving @cm as the storage gateway, it's served over TLS
+// if @tlsConfig is not nil, and only the requests with @token are served if it's not empty.
+func NewGatewayGrpcServer(cm ChunkManager, token string, tlsConfig *tls.Config, opts ...grpc.ServerOption) *grpc.Server {
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	if token != "" {
+		opts = append(opts,
+			grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := checkGatewayToken(ctx, token); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}),
+			grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := checkGatewayToken(ss.Context(), token); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}))
+	}
+	server := grpc.NewServer(opts...)
+	storagepb.RegisterChunkManagerServer(server, NewGatewayServer(cm))
+	return server
+}
+
+func checkGatewayToken(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(GatewayTokenKey) {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid storage gateway token")
+}
+
+// gatewayToken attaches the shared token to the requests to the storage gateway.
+type gatewayToken struct {
+	token  string
+	secure bool
+}
+
+var _ credentials.PerRPCCredentials = gatewayToken{}
+
+func (t gatewayToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{GatewayTokenKey: t.token}, nil
+}
+
+func (t gatewayToken) RequireTransportSecurity() bool {
+	return t.secure
+}
@@ -0,0 +1,199 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/storagepb"
+)
+
+// gatewayWalkBatchSize is the max number of objects sent in one message of WalkWithPrefix
+const gatewayWalkBatchSize = 1000
+
+// GatewayServer serves a ChunkManager to the RemoteChunkManagers of the nodes which can't access the storage
+// directly, by the ChunkManager service of gRPC.
+type GatewayServer struct {
+	cm ChunkManager
+}
+
+var _ storagepb.ChunkManagerServer = (*GatewayServer)(nil)
+
+// NewGatewayServer returns a GatewayServer serving @cm.
+func NewGatewayServer(cm ChunkManager) *GatewayServer {
+	return &GatewayServer{cm: cm}
+}
+
+func (s *GatewayServer) GetRootPath(ctx context.Context, req *storagepb.GetRootPathRequest) (*storagepb.GetRootPathResponse, error) {
+	return &storagepb.GetRootPathResponse{Status: gatewayStatus(nil), RootPath: s.cm.RootPath()}, nil
+}
+
+func (s *GatewayServer) Path(ctx context.Context, req *storagepb.PathRequest) (*storagepb.PathResponse, error) {
+	filePath, err := s.cm.Path(ctx, req.GetPath())
+	return &storagepb.PathResponse{Status: gatewayStatus(err), Path: filePath}, nil
+}
+
+func (s *GatewayServer) Size(ctx context.Context, req *storagepb.PathRequest) (*storagepb.SizeResponse, error) {
+	size, err := s.cm.Size(ctx, req.GetPath())
+	return &storagepb.SizeResponse{Status: gatewayStatus(err), Size: size}, nil
+}
+
+func (s *GatewayServer) MultiStat(ctx context.Context, req *storagepb.MultiPathRequest) (*storagepb.MultiStatResponse, error) {
+	infos, err := s.cm.MultiStat(ctx, req.GetPaths())
+	if err != nil {
+		return &storagepb.MultiStatResponse{Status: gatewayStatus(err)}, nil
+	}
+	resp := &storagepb.MultiStatResponse{
+		Status: gatewayStatus(nil),
+		Infos:  make([]*storagepb.ObjectInfo, 0, len(infos)),
+		Exists: make([]bool, 0, len(infos)),
+	}
+	for _, info := range infos {
+		if info == nil {
+			resp.Infos = append(resp.Infos, &storagepb.ObjectInfo{})
+			resp.Exists = append(resp.Exists, false)
+			continue
+		}
+		resp.Infos = append(resp.Infos, &storagepb.ObjectInfo{
+			Path:       info.FilePath,
+			Size:       info.Size,
+			ModifyTime: gatewayTime(info.ModifyTime),
+			Etag:       info.ETag,
+		})
+		resp.Exists = append(resp.Exists, true)
+	}
+	return resp, nil
+}
+
+func (s *GatewayServer) Exist(ctx context.Context, req *storagepb.PathRequest) (*storagepb.ExistResponse, error) {
+	exist, err := s.cm.Exist(ctx, req.GetPath())
+	return &storagepb.ExistResponse{Status: gatewayStatus(err), Exist: exist}, nil
+}
+
+func (s *GatewayServer) Write(ctx context.Context, req *storagepb.WriteRequest) (*commonpb.Status, error) {
+	if req.GetIfNotExist() {
+		return gatewayStatus(s.cm.WriteIfNotExist(ctx, req.GetPath(), req.GetContent())), nil
+	}
+	if len(req.GetUserMetadata()) == 0 && len(req.GetTags()) == 0 && req.GetStorageClass() == "" {
+		return gatewayStatus(s.cm.Write(ctx, req.GetPath(), req.GetContent())), nil
+	}
+	opts := []WriteOption{WithUserMetadata(req.GetUserMetadata()), WithTags(req.GetTags())}
+	if req.GetStorageClass() != "" {
+		opts = append(opts, WithStorageClass(req.GetStorageClass()))
+	}
+	return gatewayStatus(s.cm.WriteWithOptions(ctx, req.GetPath(), req.GetContent(), opts...)), nil
+}
+
+func (s *GatewayServer) MultiWrite(ctx context.Context, req *storagepb.MultiWriteRequest) (*commonpb.Status, error) {
+	return gatewayStatus(s.cm.MultiWrite(ctx, req.GetContents())), nil
+}
+
+func (s *GatewayServer) Append(ctx context.Context, req *storagepb.WriteRequest) (*commonpb.Status, error) {
+	return gatewayStatus(s.cm.Append(ctx, req.GetPath(), req.GetContent())), nil
+}
+
+func (s *GatewayServer) Copy(ctx context.Context, req *storagepb.CopyRequest) (*commonpb.Status, error) {
+	return gatewayStatus(s.cm.Copy(ctx, req.GetSrcPath(), req.GetDstPath())), nil
+}
+
+func (s *GatewayServer) Move(ctx context.Context, req *storagepb.CopyRequest) (*commonpb.Status, error) {
+	return gatewayStatus(s.cm.Move(ctx, req.GetSrcPath(), req.GetDstPath())), nil
+}
+
+func (s *GatewayServer) PresignURL(ctx context.Context, req *storagepb.PresignURLRequest) (*storagepb.PresignURLResponse, error) {
+	url, err := s.cm.PresignURL(ctx, req.GetPath(), req.GetMethod(), time.Duration(req.GetExpiryMs())*time.Millisecond)
+	return &storagepb.PresignURLResponse{Status: gatewayStatus(err), Url: url}, nil
+}
+
+func (s *GatewayServer) Read(ctx context.Context, req *storagepb.PathRequest) (*storagepb.ReadResponse, error) {
+	content, err := s.cm.Read(ctx, req.GetPath())
+	return &storagepb.ReadResponse{Status: gatewayStatus(err), Content: content}, nil
+}
+
+func (s *GatewayServer) MultiRead(ctx context.Context, req *storagepb.MultiPathRequest) (*storagepb.MultiReadResponse, error) {
+	contents, err := s.cm.MultiRead(ctx, req.GetPaths())
+	return &storagepb.MultiReadResponse{Status: gatewayStatus(err), Contents: contents}, nil
+}
+
+func (s *GatewayServer) ReadAt(ctx context.Context, req *storagepb.ReadAtRequest) (*storagepb.ReadResponse, error) {
+	content, err := s.cm.ReadAt(ctx, req.GetPath(), req.GetOffset(), req.GetLength())
+	return &storagepb.ReadResponse{Status: gatewayStatus(err), Content: content}, nil
+}
+
+func (s *GatewayServer) MultiReadAt(ctx context.Context, req *storagepb.MultiReadAtRequest) (*storagepb.MultiReadResponse, error) {
+	ranges := make([]Range, 0, len(req.GetRanges()))
+	for _, r := range req.GetRanges() {
+		ranges = append(ranges, Range{Offset: r.GetOffset(), Length: r.GetLength()})
+	}
+	contents, err := s.cm.MultiReadAt(ctx, req.GetPath(), ranges)
+	return &storagepb.MultiReadResponse{Status: gatewayStatus(err), Contents: contents}, nil
+}
+
+func (s *GatewayServer) ReadWithPrefix(ctx context.Context, req *storagepb.PathRequest) (*storagepb.ReadWithPrefixResponse, error) {
+	filePaths, contents, err := s.cm.ReadWithPrefix(ctx, req.GetPath())
+	return &storagepb.ReadWithPrefixResponse{Status: gatewayStatus(err), Paths: filePaths, Contents: contents}, nil
+}
+
+func (s *GatewayServer) WalkWithPrefix(req *storagepb.WalkWithPrefixRequest, stream storagepb.ChunkManager_WalkWithPrefixServer) error {
+	var batch []*storagepb.ObjectInfo
+	var sendErr error
+	err := s.cm.WalkWithPrefix(stream.Context(), req.GetPrefix(), req.GetRecursive(), func(chunkObjectInfo ChunkObjectInfo) bool {
+		batch = append(batch, &storagepb.ObjectInfo{
+			Path:         chunkObjectInfo.FilePath,
+			Size:         chunkObjectInfo.Size,
+			ModifyTime:   gatewayTime(chunkObjectInfo.ModifyTime),
+			UserMetadata: chunkObjectInfo.UserMetadata,
+			Tags:         chunkObjectInfo.Tags,
+		})
+		if len(batch) < gatewayWalkBatchSize {
+			return true
+		}
+		sendErr = stream.Send(&storagepb.WalkWithPrefixResponse{Status: gatewayStatus(nil), Infos: batch})
+		batch = nil
+		// the client stops the walk by canceling the stream
+		return sendErr == nil
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return stream.Send(&storagepb.WalkWithPrefixResponse{Status: gatewayStatus(err)})
+	}
+	if len(batch) > 0 {
+		return stream.Send(&storagepb.WalkWithPrefixResponse{Status: gatewayStatus(nil), Infos: batch})
+	}
+	return nil
+}
+
+func (s *GatewayServer) PrefixSize(ctx context.Context, req *storagepb.PathRequest) (*storagepb.PrefixSizeResponse, error) {
+	bytes, objects, err := s.cm.PrefixSize(ctx, req.GetPath())
+	return &storagepb.PrefixSizeResponse{Status: gatewayStatus(err), Bytes: bytes, Objects: objects}, nil
+}
+
+func (s *GatewayServer) Remove(ctx context.Context, req *storagepb.PathRequest) (*commonpb.Status, error) {
+	return gatewayStatus(s.cm.Remove(ctx, req.GetPath())), nil
+}
+
+func (s *GatewayServer) MultiRemove(ctx context.Context, req *storagepb.MultiPathRequest) (*commonpb.Status, error) {
+	return gatewayStatus(s.cm.MultiRemove(ctx, req.GetPaths())), nil
+}
+
+func (s *GatewayServer) RemoveWithPrefix(ctx context.Context, req *storagepb.PathRequest) (*commonpb.Status, error) {
+	return gatewayStatus(s.cm.RemoveWithPrefix(ctx, req.GetPath())), nil
+}
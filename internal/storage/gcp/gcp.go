@@ -1,8 +1,12 @@
 package gcp
 
 import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -11,10 +15,74 @@ import (
 	"golang.org/x/oauth2/google"
 )
 
+// Auth modes of GCS when IAM is used.
+const (
+	// AuthModeMetadata gets the tokens of the service account attached to the GCE instance or GKE node
+	// from the metadata server.
+	AuthModeMetadata = "metadata"
+	// AuthModeWorkloadIdentity gets the tokens by workload identity, that is the Kubernetes service account bound to
+	// a Google service account on GKE, or the external account credentials configuration of Workload Identity
+	// Federation elsewhere. The service account keys are refused.
+	AuthModeWorkloadIdentity = "workloadIdentity"
+)
+
+// gcsScope is the OAuth scope of the tokens accessing GCS.
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// serviceAccountKeyType is the type of the service account key JSON file.
+const serviceAccountKeyType = "service_account"
+
+// NewTokenSource returns the token source of @mode, which refreshes the tokens once they expire.
+// With AuthModeWorkloadIdentity, @credentialsFile is the external account credentials configuration of Workload
+// Identity Federation, empty means the application default credentials, which are the GKE metadata server if
+// GOOGLE_APPLICATION_CREDENTIALS is not set.
+func NewTokenSource(mode string, credentialsFile string) (oauth2.TokenSource, error) {
+	switch mode {
+	case "", AuthModeMetadata:
+		return google.ComputeTokenSource(""), nil
+	case AuthModeWorkloadIdentity:
+		// the tokens are refreshed after the creation, so the context must never be canceled
+		ctx := context.Background()
+		var creds *google.Credentials
+		var err error
+		if credentialsFile != "" {
+			var data []byte
+			data, err = ioutil.ReadFile(credentialsFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read credentials file")
+			}
+			creds, err = google.CredentialsFromJSON(ctx, data, gcsScope)
+		} else {
+			creds, err = google.FindDefaultCredentials(ctx, gcsScope)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find workload identity credentials")
+		}
+		if isServiceAccountKey(creds.JSON) {
+			return nil, errors.New("service account key is not workload identity, use the external account credentials configuration or the GKE metadata server instead")
+		}
+		return creds.TokenSource, nil
+	default:
+		return nil, errors.Errorf("unknown gcp auth mode %s", mode)
+	}
+}
+
+func isServiceAccountKey(credsJSON []byte) bool {
+	if len(credsJSON) == 0 {
+		return false
+	}
+	var f struct {
+		Type string `json:"type"`
+	}
+	return json.Unmarshal(credsJSON, &f) == nil && f.Type == serviceAccountKeyType
+}
+
 // WrapHTTPTransport wraps http.Transport, add an auth header to support GCP native auth
 type WrapHTTPTransport struct {
-	tokenSrc     oauth2.TokenSource
-	backend      transport
+	tokenSrc oauth2.TokenSource
+	backend  transport
+
+	mu           sync.Mutex
 	currentToken *oauth2.Token
 }
 
@@ -41,14 +109,18 @@ func NewWrapHTTPTransport(secure bool) (*WrapHTTPTransport, error) {
 func (t *WrapHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// here Valid() means the token won't be expired in 10 sec
 	// so the http client timeout shouldn't be longer, or we need to change the default `expiryDelta` time
+	t.mu.Lock()
 	if !t.currentToken.Valid() {
-		var err error
-		t.currentToken, err = t.tokenSrc.Token()
+		token, err := t.tokenSrc.Token()
 		if err != nil {
+			t.mu.Unlock()
 			return nil, errors.Wrap(err, "failed to acquire token")
 		}
+		t.currentToken = token
 	}
-	req.Header.Set("Authorization", "Bearer "+t.currentToken.AccessToken)
+	accessToken := t.currentToken.AccessToken
+	t.mu.Unlock()
+	req.Header.Set("Authorization", "Bearer "+accessToken)
 	return t.backend.RoundTrip(req)
 }
 
@@ -56,6 +128,12 @@ const GcsDefaultAddress = "storage.googleapis.com"
 
 // NewMinioClient returns a minio.Client which is compatible for GCS
 func NewMinioClient(address string, opts *minio.Options) (*minio.Client, error) {
+	return NewMinioClientWithTokenSource(address, opts, nil)
+}
+
+// NewMinioClientWithTokenSource returns a minio.Client which is compatible for GCS, the requests are authorized by the
+// tokens of @tokenSrc if opts.Creds is nil, nil @tokenSrc means the tokens of the metadata server.
+func NewMinioClientWithTokenSource(address string, opts *minio.Options, tokenSrc oauth2.TokenSource) (*minio.Client, error) {
 	if opts == nil {
 		opts = &minio.Options{}
 	}
@@ -78,6 +156,13 @@ func NewMinioClient(address string, opts *minio.Options) (*minio.Client, error)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create default transport")
 	}
+	if tokenSrc != nil {
+		transport.tokenSrc = tokenSrc
+	}
+	// keep the transport given by caller as the backend
+	if opts.Transport != nil {
+		transport.backend = opts.Transport
+	}
 	opts.Transport = transport
 	opts.Creds = credentials.NewStaticV2("", "", "")
 	return minio.New(address, opts)
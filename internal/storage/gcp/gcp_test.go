@@ -2,7 +2,9 @@ package gcp
 
 import (
 	"errors"
+	"io/ioutil"
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/minio/minio-go/v7"
@@ -101,3 +103,53 @@ func TestGCPWrappedHTTPTransport_RoundTrip(t *testing.T) {
 	})
 
 }
+
+func TestNewTokenSource(t *testing.T) {
+	t.Run("metadata ok", func(t *testing.T) {
+		tokenSrc, err := NewTokenSource(AuthModeMetadata, "")
+		assert.NoError(t, err)
+		assert.NotNil(t, tokenSrc)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := NewTokenSource("unknown", "")
+		assert.Error(t, err)
+	})
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	t.Run("workload identity federation ok", func(t *testing.T) {
+		file := writeFile("external_account.json", `{
+			"type": "external_account",
+			"audience": "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/provider",
+			"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+			"token_url": "https://sts.googleapis.com/v1/token",
+			"credential_source": {"file": "/var/run/secrets/token"}
+		}`)
+		tokenSrc, err := NewTokenSource(AuthModeWorkloadIdentity, file)
+		assert.NoError(t, err)
+		assert.NotNil(t, tokenSrc)
+	})
+
+	t.Run("service account key refused", func(t *testing.T) {
+		file := writeFile("service_account.json", `{"type": "service_account", "client_email": "a@b.com", "private_key": ""}`)
+		_, err := NewTokenSource(AuthModeWorkloadIdentity, file)
+		assert.Error(t, err)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		_, err := NewTokenSource(AuthModeWorkloadIdentity, filepath.Join(dir, "not_exist.json"))
+		assert.Error(t, err)
+	})
+
+	t.Run("client with token source", func(t *testing.T) {
+		minioCli, err := NewMinioClientWithTokenSource("", nil, &mockTokenSource{token: "mocktoken"})
+		assert.NoError(t, err)
+		assert.Equal(t, GcsDefaultAddress, minioCli.EndpointURL().Host)
+	})
+}
@@ -0,0 +1,145 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// globMetaChars are the chars with special meanings in the segments of glob patterns, see path.Match.
+const globMetaChars = `*?[\`
+
+// globAnyDepth is the pattern segment which matches any number of segments.
+const globAnyDepth = "**"
+
+// ListWithPattern lists the objects matching the glob @pattern, see WalkWithPattern.
+func ListWithPattern(ctx context.Context, cm ChunkManager, pattern string) ([]string, []time.Time, error) {
+	var filePaths []string
+	var modTimes []time.Time
+	err := WalkWithPattern(ctx, cm, pattern, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePaths = append(filePaths, chunkObjectInfo.FilePath)
+		modTimes = append(modTimes, chunkObjectInfo.ModifyTime)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, modTimes, nil
+}
+
+// WalkWithPattern calls @walkFunc for every object matching the glob @pattern, it stops walking if @walkFunc returns false.
+// The segments of @pattern separated by "/" are matched by path.Match, and the segment "**" matches any number of segments,
+// e.g. "files/insert_log/*/*/*/101/*" matches the insert binlogs of field 101.
+//
+// The pattern is evaluated level by level with the non-recursive WalkWithPrefix, so the storage lists only the keys
+// starting with the literal prefix of each level, and the levels below the common prefixes not matching are never listed.
+// Only the names are matched client-side, except the levels after "**", which are listed recursively.
+func WalkWithPattern(ctx context.Context, cm ChunkManager, pattern string, walkFunc ChunkObjectWalkFunc) error {
+	segments := strings.Split(pattern, "/")
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid glob pattern %s: %w", pattern, err)
+		}
+	}
+	_, err := walkPattern(ctx, cm, "", segments, walkFunc)
+	return err
+}
+
+// walkPattern walks the objects under @prefix matching @segments, it returns false if @walkFunc stopped the walk.
+func walkPattern(ctx context.Context, cm ChunkManager, prefix string, segments []string, walkFunc ChunkObjectWalkFunc) (bool, error) {
+	// the literal levels are not listed
+	for len(segments) > 1 && !strings.ContainsAny(segments[0], globMetaChars) {
+		prefix += segments[0] + "/"
+		segments = segments[1:]
+	}
+	if segments[0] == globAnyDepth {
+		return walkAnyDepthPattern(ctx, cm, prefix, segments, walkFunc)
+	}
+
+	segment, last := segments[0], len(segments) == 1
+	literal := segment
+	if i := strings.IndexAny(segment, globMetaChars); i >= 0 {
+		literal = segment[:i]
+	}
+	continued := true
+	var subPrefixes []string
+	err := cm.WalkWithPrefix(ctx, prefix+literal, false, func(chunkObjectInfo ChunkObjectInfo) bool {
+		name := path.Base(strings.TrimSuffix(chunkObjectInfo.FilePath, "/"))
+		if matched, _ := path.Match(segment, name); !matched {
+			return true
+		}
+		if IsCommonPrefix(chunkObjectInfo.FilePath) {
+			if !last {
+				subPrefixes = append(subPrefixes, prefix+name+"/")
+			}
+			return true
+		}
+		if last {
+			continued = walkFunc(chunkObjectInfo)
+		}
+		return continued
+	})
+	if err != nil || !continued {
+		return continued, err
+	}
+	for _, subPrefix := range subPrefixes {
+		continued, err := walkPattern(ctx, cm, subPrefix, segments[1:], walkFunc)
+		if err != nil || !continued {
+			return continued, err
+		}
+	}
+	return true, nil
+}
+
+// walkAnyDepthPattern walks the objects under @prefix recursively and matches them with @segments client-side.
+func walkAnyDepthPattern(ctx context.Context, cm ChunkManager, prefix string, segments []string, walkFunc ChunkObjectWalkFunc) (bool, error) {
+	continued := true
+	err := cm.WalkWithPrefix(ctx, prefix, true, func(chunkObjectInfo ChunkObjectInfo) bool {
+		if !matchSegments(segments, strings.Split(strings.TrimPrefix(chunkObjectInfo.FilePath, prefix), "/")) {
+			return true
+		}
+		continued = walkFunc(chunkObjectInfo)
+		return continued
+	})
+	return continued, err
+}
+
+// matchSegments returns true if the segments of a path @names match the pattern @segments.
+func matchSegments(segments []string, names []string) bool {
+	for len(segments) > 0 {
+		if segments[0] == globAnyDepth {
+			for i := 0; i <= len(names); i++ {
+				if matchSegments(segments[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(segments[0], names[0]); !matched {
+			return false
+		}
+		segments, names = segments[1:], names[1:]
+	}
+	return len(names) == 0
+}
@@ -0,0 +1,88 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWithPattern(t *testing.T) {
+	ctx := context.Background()
+	testRoot := "test_glob"
+	testCM := NewLocalChunkManager(RootPath(localPath))
+	defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+	keys := []string{
+		"insert_log/1/2/3/100/1",
+		"insert_log/1/2/3/101/2",
+		"insert_log/1/2/4/101/3",
+		"insert_log/1/2/4/1010/4",
+		"stats_log/1/2/3/101/5",
+		"delta_log/1/2/3/6",
+	}
+	for _, key := range keys {
+		err := testCM.Write(ctx, path.Join(testRoot, key), []byte("value"))
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"insert_log/*/*/*/101/*", []string{"insert_log/1/2/3/101/2", "insert_log/1/2/4/101/3"}},
+		{"*_log/**/101/*", []string{"insert_log/1/2/3/101/2", "insert_log/1/2/4/101/3", "stats_log/1/2/3/101/5"}},
+		{"insert_log/1/2/[34]/10?/*", []string{"insert_log/1/2/3/100/1", "insert_log/1/2/3/101/2", "insert_log/1/2/4/101/3"}},
+		{"delta_log/1/2/3/6", []string{"delta_log/1/2/3/6"}},
+		{"**/4", []string{"insert_log/1/2/4/1010/4"}},
+		{"insert_log/*/*/5/*/*", nil},
+	}
+	for _, test := range tests {
+		filePaths, modTimes, err := ListWithPattern(ctx, testCM, path.Join(testRoot, test.pattern))
+		assert.NoError(t, err)
+		var expected []string
+		for _, key := range test.expected {
+			expected = append(expected, path.Join(testRoot, key))
+		}
+		assert.ElementsMatch(t, expected, filePaths, test.pattern)
+		assert.Equal(t, len(filePaths), len(modTimes))
+	}
+
+	// stop walking
+	count := 0
+	err := WalkWithPattern(ctx, testCM, path.Join(testRoot, "**"), func(chunkObjectInfo ChunkObjectInfo) bool {
+		count++
+		return count < 2
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	_, _, err = ListWithPattern(ctx, testCM, path.Join(testRoot, "[1-"))
+	assert.Error(t, err)
+}
+
+func TestMatchSegments(t *testing.T) {
+	assert.True(t, matchSegments([]string{"**"}, nil))
+	assert.True(t, matchSegments([]string{"a", "**", "c"}, []string{"a", "c"}))
+	assert.True(t, matchSegments([]string{"a", "**", "c"}, []string{"a", "b", "b", "c"}))
+	assert.False(t, matchSegments([]string{"a", "**", "c"}, []string{"a", "b"}))
+	assert.False(t, matchSegments([]string{"a", "*"}, []string{"a", "b", "c"}))
+}
@@ -112,6 +112,23 @@ func (codec *IndexFileBinlogCodec) SerializeIndexParams(
 	return indexParamBlob, nil
 }
 
+// SerializeIndexFile serializes a single index file as blob, so that the index files could be encoded and
+// uploaded one by one. The index params blob is serialized by SerializeIndexParams.
+func (codec *IndexFileBinlogCodec) SerializeIndexFile(
+	indexBuildID UniqueID,
+	version int64,
+	collectionID UniqueID,
+	partitionID UniqueID,
+	segmentID UniqueID,
+	fieldID UniqueID,
+	indexName string,
+	indexID UniqueID,
+	data *Blob,
+	ts Timestamp,
+) (*Blob, error) {
+	return codec.serializeImpl(indexBuildID, version, collectionID, partitionID, segmentID, fieldID, indexName, indexID, data.Key, data.Value, ts)
+}
+
 // Serialize serilizes data as blobs.
 func (codec *IndexFileBinlogCodec) Serialize(
 	indexBuildID UniqueID,
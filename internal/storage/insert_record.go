@@ -0,0 +1,544 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+)
+
+// the keys of the arrow field metadata of the insert records
+const (
+	insertRecordFieldIDKey  = parquetFieldIDKey
+	insertRecordDataTypeKey = "milvus.data_type"
+)
+
+// NewInsertRecordSchema returns the arrow schema of the insert records of @schema, each field is a column named
+// after the field, with the field id and the data type in the field metadata.
+func NewInsertRecordSchema(schema *schemapb.CollectionSchema) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, 0, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		dataType, err := newArrowDataType(field)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, arrow.Field{
+			Name: field.GetName(),
+			Type: dataType,
+			Metadata: arrow.NewMetadata(
+				[]string{insertRecordFieldIDKey, insertRecordDataTypeKey},
+				[]string{strconv.FormatInt(field.GetFieldID(), 10), field.GetDataType().String()}),
+		})
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// newArrowDataType returns the arrow type of the field, the vectors are fixed size binaries of the dim.
+func newArrowDataType(field *schemapb.FieldSchema) (arrow.DataType, error) {
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case schemapb.DataType_Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case schemapb.DataType_Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case schemapb.DataType_Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case schemapb.DataType_Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case schemapb.DataType_Float:
+		return arrow.PrimitiveTypes.Float32, nil
+	case schemapb.DataType_Double:
+		return arrow.PrimitiveTypes.Float64, nil
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		return arrow.BinaryTypes.String, nil
+	case schemapb.DataType_BinaryVector:
+		dim, err := GetDimFromParams(field.GetTypeParams())
+		if err != nil {
+			return nil, err
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: dim / 8}, nil
+	case schemapb.DataType_FloatVector:
+		dim, err := GetDimFromParams(field.GetTypeParams())
+		if err != nil {
+			return nil, err
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: dim * 4}, nil
+	default:
+		return nil, fmt.Errorf("arrow does not support datatype %v", field.GetDataType().String())
+	}
+}
+
+// parseInsertRecordField returns the field id and the data type in the metadata of the column.
+func parseInsertRecordField(field arrow.Field) (FieldID, schemapb.DataType, error) {
+	value := func(key string) string {
+		if i := field.Metadata.FindKey(key); i >= 0 {
+			return field.Metadata.Values()[i]
+		}
+		return ""
+	}
+	fieldID, err := strconv.ParseInt(value(insertRecordFieldIDKey), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid field id of column %s, %w", field.Name, err)
+	}
+	dataType, ok := schemapb.DataType_value[value(insertRecordDataTypeKey)]
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid data type of column %s", field.Name)
+	}
+	return fieldID, schemapb.DataType(dataType), nil
+}
+
+// InsertDataToRecord converts the fields of @schema in @data to an arrow record, the field data is copied into
+// the arrow buffers.
+func InsertDataToRecord(schema *schemapb.CollectionSchema, data *InsertData) (arrow.Record, error) {
+	arrowSchema, err := NewInsertRecordSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]arrow.Array, 0, len(schema.GetFields()))
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+	rows := -1
+	for _, field := range schema.GetFields() {
+		fieldData, ok := data.Data[field.GetFieldID()]
+		if !ok {
+			return nil, fmt.Errorf("field %d is not in the insert data", field.GetFieldID())
+		}
+		if rows >= 0 && fieldData.RowNum() != rows {
+			return nil, fmt.Errorf("field %d has %d rows, but the other fields have %d rows",
+				field.GetFieldID(), fieldData.RowNum(), rows)
+		}
+		rows = fieldData.RowNum()
+		column, err := newArrowArray(field.GetDataType(), fieldData)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	if rows < 0 {
+		rows = 0
+	}
+	// the record retains the columns
+	return array.NewRecord(arrowSchema, columns, int64(rows)), nil
+}
+
+// RecordToInsertData converts an insert record to InsertData. The numeric and vector field data share the
+// memory of the arrow buffers instead of copying, so the record must be allocated by the go allocator,
+// and any change to the field data changes the record as well.
+func RecordToInsertData(record arrow.Record) (*InsertData, error) {
+	data := &InsertData{Data: make(map[FieldID]FieldData, record.NumCols())}
+	for i, field := range record.Schema().Fields() {
+		fieldID, dataType, err := parseInsertRecordField(field)
+		if err != nil {
+			return nil, err
+		}
+		fieldData, err := newFieldDataFromArrow(dataType, record.Column(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert column %s, %w", field.Name, err)
+		}
+		data.Data[fieldID] = fieldData
+	}
+	return data, nil
+}
+
+// newArrowArray copies @data of @dataType into a new arrow array.
+func newArrowArray(dataType schemapb.DataType, data FieldData) (arrow.Array, error) {
+	mem := memory.DefaultAllocator
+	switch dataType {
+	case schemapb.DataType_Bool:
+		builder := array.NewBooleanBuilder(mem)
+		defer builder.Release()
+		builder.AppendValues(data.(*BoolFieldData).Data, nil)
+		return builder.NewArray(), nil
+	case schemapb.DataType_Int8:
+		builder := array.NewInt8Builder(mem)
+		defer builder.Release()
+		builder.AppendValues(data.(*Int8FieldData).Data, nil)
+		return builder.NewArray(), nil
+	case schemapb.DataType_Int16:
+		builder := array.NewInt16Builder(mem)
+		defer builder.Release()
+		builder.AppendValues(data.(*Int16FieldData).Data, nil)
+		return builder.NewArray(), nil
+	case schemapb.DataType_Int32:
+		builder := array.NewInt32Builder(mem)
+		defer builder.Release()
+		builder.AppendValues(data.(*Int32FieldData).Data, nil)
+		return builder.NewArray(), nil
+	case schemapb.DataType_Int64:
+		builder := array.NewInt64Builder(mem)
+		defer builder.Release()
+		builder.AppendValues(data.(*Int64FieldData).Data, nil)
+		return builder.NewArray(), nil
+	case schemapb.DataType_Float:
+		builder := array.NewFloat32Builder(mem)
+		defer builder.Release()
+		builder.AppendValues(data.(*FloatFieldData).Data, nil)
+		return builder.NewArray(), nil
+	case schemapb.DataType_Double:
+		builder := array.NewFloat64Builder(mem)
+		defer builder.Release()
+		builder.AppendValues(data.(*DoubleFieldData).Data, nil)
+		return builder.NewArray(), nil
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		builder := array.NewStringBuilder(mem)
+		defer builder.Release()
+		builder.AppendValues(data.(*StringFieldData).Data, nil)
+		return builder.NewArray(), nil
+	case schemapb.DataType_BinaryVector:
+		// the vectors are fixed size binaries, the same as the payloads of the event binlogs
+		vectors := data.(*BinaryVectorFieldData)
+		width := vectors.Dim / 8
+		builder := array.NewFixedSizeBinaryBuilder(mem, &arrow.FixedSizeBinaryType{ByteWidth: width})
+		defer builder.Release()
+		for i := 0; i < vectors.RowNum(); i++ {
+			builder.Append(vectors.Data[i*width : (i+1)*width])
+		}
+		return builder.NewArray(), nil
+	case schemapb.DataType_FloatVector:
+		vectors := data.(*FloatVectorFieldData)
+		builder := array.NewFixedSizeBinaryBuilder(mem, &arrow.FixedSizeBinaryType{ByteWidth: vectors.Dim * 4})
+		defer builder.Release()
+		for i := 0; i < vectors.RowNum(); i++ {
+			builder.Append(arrow.Float32Traits.CastToBytes(vectors.Data[i*vectors.Dim : (i+1)*vectors.Dim]))
+		}
+		return builder.NewArray(), nil
+	default:
+		return nil, fmt.Errorf("arrow does not support datatype %v", dataType.String())
+	}
+}
+
+// newFieldDataFromArrow converts the arrow array to the field data of @dataType, only the booleans and
+// the strings are copied.
+func newFieldDataFromArrow(dataType schemapb.DataType, arr arrow.Array) (FieldData, error) {
+	if arr.NullN() > 0 {
+		return nil, fmt.Errorf("field data could not be null")
+	}
+	numRows := []int64{int64(arr.Len())}
+	switch dataType {
+	case schemapb.DataType_Bool:
+		values, ok := arr.(*array.Boolean)
+		if !ok {
+			return nil, fmt.Errorf("expect boolean array, but got %s", arr.DataType())
+		}
+		data := make([]bool, values.Len())
+		for i := range data {
+			data[i] = values.Value(i)
+		}
+		return &BoolFieldData{NumRows: numRows, Data: data}, nil
+	case schemapb.DataType_Int8:
+		values, ok := arr.(*array.Int8)
+		if !ok {
+			return nil, fmt.Errorf("expect int8 array, but got %s", arr.DataType())
+		}
+		return &Int8FieldData{NumRows: numRows, Data: values.Int8Values()}, nil
+	case schemapb.DataType_Int16:
+		values, ok := arr.(*array.Int16)
+		if !ok {
+			return nil, fmt.Errorf("expect int16 array, but got %s", arr.DataType())
+		}
+		return &Int16FieldData{NumRows: numRows, Data: values.Int16Values()}, nil
+	case schemapb.DataType_Int32:
+		values, ok := arr.(*array.Int32)
+		if !ok {
+			return nil, fmt.Errorf("expect int32 array, but got %s", arr.DataType())
+		}
+		return &Int32FieldData{NumRows: numRows, Data: values.Int32Values()}, nil
+	case schemapb.DataType_Int64:
+		values, ok := arr.(*array.Int64)
+		if !ok {
+			return nil, fmt.Errorf("expect int64 array, but got %s", arr.DataType())
+		}
+		return &Int64FieldData{NumRows: numRows, Data: values.Int64Values()}, nil
+	case schemapb.DataType_Float:
+		values, ok := arr.(*array.Float32)
+		if !ok {
+			return nil, fmt.Errorf("expect float32 array, but got %s", arr.DataType())
+		}
+		return &FloatFieldData{NumRows: numRows, Data: values.Float32Values()}, nil
+	case schemapb.DataType_Double:
+		values, ok := arr.(*array.Float64)
+		if !ok {
+			return nil, fmt.Errorf("expect float64 array, but got %s", arr.DataType())
+		}
+		return &DoubleFieldData{NumRows: numRows, Data: values.Float64Values()}, nil
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		values, ok := arr.(*array.String)
+		if !ok {
+			return nil, fmt.Errorf("expect string array, but got %s", arr.DataType())
+		}
+		data := make([]string, values.Len())
+		for i := range data {
+			data[i] = values.Value(i)
+		}
+		return &StringFieldData{NumRows: numRows, Data: data}, nil
+	case schemapb.DataType_BinaryVector:
+		values, ok := arr.(*array.FixedSizeBinary)
+		if !ok {
+			return nil, fmt.Errorf("expect fixed size binary array, but got %s", arr.DataType())
+		}
+		width := values.DataType().(*arrow.FixedSizeBinaryType).ByteWidth
+		return &BinaryVectorFieldData{NumRows: numRows, Data: fixedSizeBinaryBytes(values), Dim: width * 8}, nil
+	case schemapb.DataType_FloatVector:
+		values, ok := arr.(*array.FixedSizeBinary)
+		if !ok {
+			return nil, fmt.Errorf("expect fixed size binary array, but got %s", arr.DataType())
+		}
+		width := values.DataType().(*arrow.FixedSizeBinaryType).ByteWidth
+		if width%4 != 0 {
+			return nil, fmt.Errorf("invalid byte width %d of float vectors", width)
+		}
+		data := arrow.Float32Traits.CastFromBytes(fixedSizeBinaryBytes(values))
+		return &FloatVectorFieldData{NumRows: numRows, Data: data, Dim: width / 4}, nil
+	default:
+		return nil, fmt.Errorf("arrow does not support datatype %v", dataType.String())
+	}
+}
+
+// fixedSizeBinaryBytes returns the bytes of all values of the array, without copying.
+func fixedSizeBinaryBytes(arr *array.FixedSizeBinary) []byte {
+	data := arr.Data()
+	if data.Len() == 0 || data.Buffers()[1] == nil {
+		return []byte{}
+	}
+	width := arr.DataType().(*arrow.FixedSizeBinaryType).ByteWidth
+	return data.Buffers()[1].Bytes()[data.Offset()*width : (data.Offset()+data.Len())*width]
+}
+
+// InsertRecordBuilder builds the insert records row by row, which keeps the values in the column buffers
+// instead of a slice of interfaces per field.
+type InsertRecordBuilder struct {
+	schema   *arrow.Schema
+	fieldIDs []FieldID
+	builder  *array.RecordBuilder
+}
+
+// NewInsertRecordBuilder returns a builder of the insert records of @schema.
+func NewInsertRecordBuilder(schema *schemapb.CollectionSchema) (*InsertRecordBuilder, error) {
+	arrowSchema, err := NewInsertRecordSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	fieldIDs := make([]FieldID, 0, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		fieldIDs = append(fieldIDs, field.GetFieldID())
+	}
+	return &InsertRecordBuilder{
+		schema:   arrowSchema,
+		fieldIDs: fieldIDs,
+		builder:  array.NewRecordBuilder(memory.DefaultAllocator, arrowSchema),
+	}, nil
+}
+
+// Schema returns the arrow schema of the records.
+func (b *InsertRecordBuilder) Schema() *arrow.Schema {
+	return b.schema
+}
+
+// Len returns the number of rows appended since the last record is built.
+func (b *InsertRecordBuilder) Len() int {
+	if len(b.fieldIDs) == 0 {
+		return 0
+	}
+	return b.builder.Field(0).Len()
+}
+
+// AppendRow appends a row of the field values, e.g. the rows of InsertBinlogIterator. The row must hold the
+// values of all fields, the builder should be discarded if any error is returned, because the row
+// might be partially appended.
+func (b *InsertRecordBuilder) AppendRow(row map[FieldID]interface{}) error {
+	if len(row) != len(b.fieldIDs) {
+		return fmt.Errorf("expect %d fields, but got %d", len(b.fieldIDs), len(row))
+	}
+	for i, fieldID := range b.fieldIDs {
+		value, ok := row[fieldID]
+		if !ok {
+			return fmt.Errorf("field %d is not in the row", fieldID)
+		}
+		if err := appendArrowValue(b.builder.Field(i), b.schema.Field(i).Type, value); err != nil {
+			return fmt.Errorf("failed to append field %d, %w", fieldID, err)
+		}
+	}
+	return nil
+}
+
+// NewRecord builds a record of the rows appended, and resets the builder for the next record.
+// The record must be released after used.
+func (b *InsertRecordBuilder) NewRecord() arrow.Record {
+	return b.builder.NewRecord()
+}
+
+// Release releases the builder.
+func (b *InsertRecordBuilder) Release() {
+	b.builder.Release()
+}
+
+func appendArrowValue(builder array.Builder, dataType arrow.DataType, value interface{}) error {
+	var ok bool
+	switch builder := builder.(type) {
+	case *array.BooleanBuilder:
+		var v bool
+		if v, ok = value.(bool); ok {
+			builder.Append(v)
+		}
+	case *array.Int8Builder:
+		var v int8
+		if v, ok = value.(int8); ok {
+			builder.Append(v)
+		}
+	case *array.Int16Builder:
+		var v int16
+		if v, ok = value.(int16); ok {
+			builder.Append(v)
+		}
+	case *array.Int32Builder:
+		var v int32
+		if v, ok = value.(int32); ok {
+			builder.Append(v)
+		}
+	case *array.Int64Builder:
+		var v int64
+		if v, ok = value.(int64); ok {
+			builder.Append(v)
+		}
+	case *array.Float32Builder:
+		var v float32
+		if v, ok = value.(float32); ok {
+			builder.Append(v)
+		}
+	case *array.Float64Builder:
+		var v float64
+		if v, ok = value.(float64); ok {
+			builder.Append(v)
+		}
+	case *array.StringBuilder:
+		var v string
+		if v, ok = value.(string); ok {
+			builder.Append(v)
+		}
+	case *array.FixedSizeBinaryBuilder:
+		width := dataType.(*arrow.FixedSizeBinaryType).ByteWidth
+		switch v := value.(type) {
+		case []byte:
+			if ok = len(v) == width; ok {
+				builder.Append(v)
+			}
+		case []float32:
+			if ok = len(v)*4 == width; ok {
+				builder.Append(arrow.Float32Traits.CastToBytes(v))
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported arrow type %s", dataType)
+	}
+	if !ok {
+		return fmt.Errorf("unexpected value %T of arrow type %s", value, dataType)
+	}
+	return nil
+}
+
+// AppendRecordRow appends the @i-th row of @record to the field data of @data, the fields not in
+// the record are left unchanged.
+func AppendRecordRow(data *InsertData, record arrow.Record, i int) error {
+	for col, field := range record.Schema().Fields() {
+		fieldID, _, err := parseInsertRecordField(field)
+		if err != nil {
+			return err
+		}
+		fieldData, ok := data.Data[fieldID]
+		if !ok {
+			return fmt.Errorf("field %d is not in the insert data", fieldID)
+		}
+		if err := appendFieldDataValue(fieldData, record.Column(col), i); err != nil {
+			return fmt.Errorf("failed to append column %s, %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// appendFieldDataValue appends the @i-th value of @arr to @data, without boxing the value as GetRow does.
+func appendFieldDataValue(data FieldData, arr arrow.Array, i int) error {
+	var ok bool
+	switch data := data.(type) {
+	case *BoolFieldData:
+		var values *array.Boolean
+		if values, ok = arr.(*array.Boolean); ok {
+			data.Data = append(data.Data, values.Value(i))
+		}
+	case *Int8FieldData:
+		var values *array.Int8
+		if values, ok = arr.(*array.Int8); ok {
+			data.Data = append(data.Data, values.Value(i))
+		}
+	case *Int16FieldData:
+		var values *array.Int16
+		if values, ok = arr.(*array.Int16); ok {
+			data.Data = append(data.Data, values.Value(i))
+		}
+	case *Int32FieldData:
+		var values *array.Int32
+		if values, ok = arr.(*array.Int32); ok {
+			data.Data = append(data.Data, values.Value(i))
+		}
+	case *Int64FieldData:
+		var values *array.Int64
+		if values, ok = arr.(*array.Int64); ok {
+			data.Data = append(data.Data, values.Value(i))
+		}
+	case *FloatFieldData:
+		var values *array.Float32
+		if values, ok = arr.(*array.Float32); ok {
+			data.Data = append(data.Data, values.Value(i))
+		}
+	case *DoubleFieldData:
+		var values *array.Float64
+		if values, ok = arr.(*array.Float64); ok {
+			data.Data = append(data.Data, values.Value(i))
+		}
+	case *StringFieldData:
+		var values *array.String
+		if values, ok = arr.(*array.String); ok {
+			data.Data = append(data.Data, values.Value(i))
+		}
+	case *BinaryVectorFieldData:
+		var values *array.FixedSizeBinary
+		if values, ok = arr.(*array.FixedSizeBinary); ok {
+			data.Data = append(data.Data, values.Value(i)...)
+		}
+	case *FloatVectorFieldData:
+		var values *array.FixedSizeBinary
+		if values, ok = arr.(*array.FixedSizeBinary); ok {
+			data.Data = append(data.Data, arrow.Float32Traits.CastFromBytes(values.Value(i))...)
+		}
+	default:
+		return fmt.Errorf("unsupported field data %T", data)
+	}
+	if !ok {
+		return fmt.Errorf("unexpected arrow type %s of field data %T", arr.DataType(), data)
+	}
+	return nil
+}
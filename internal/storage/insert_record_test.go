@@ -0,0 +1,196 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+)
+
+func genInsertRecordTestData(rows int) (*etcdpb.CollectionMeta, *InsertData) {
+	meta, data := genParquetBinlogTestData(rows)
+	for _, field := range meta.Schema.Fields {
+		switch field.FieldID {
+		case 108:
+			field.TypeParams = []*commonpb.KeyValuePair{{Key: "dim", Value: "16"}}
+		case 109:
+			field.TypeParams = []*commonpb.KeyValuePair{{Key: "dim", Value: "4"}}
+		}
+	}
+	return meta, data
+}
+
+func TestInsertRecord(t *testing.T) {
+	meta, data := genInsertRecordTestData(100)
+
+	record, err := InsertDataToRecord(meta.Schema, data)
+	require.NoError(t, err)
+	defer record.Release()
+	assert.EqualValues(t, 100, record.NumRows())
+	assert.EqualValues(t, len(meta.Schema.Fields), record.NumCols())
+	assert.Equal(t, "float_vector", record.ColumnName(11))
+
+	converted, err := RecordToInsertData(record)
+	require.NoError(t, err)
+	require.Len(t, converted.Data, len(data.Data))
+	for fieldID, fieldData := range data.Data {
+		assert.Equal(t, fieldData.RowNum(), converted.Data[fieldID].RowNum(), fieldID)
+		for i := 0; i < fieldData.RowNum(); i++ {
+			assert.Equal(t, fieldData.GetRow(i), converted.Data[fieldID].GetRow(i), fieldID)
+		}
+	}
+	assert.Equal(t, 16, converted.Data[108].(*BinaryVectorFieldData).Dim)
+	assert.Equal(t, 4, converted.Data[109].(*FloatVectorFieldData).Dim)
+
+	t.Run("append record rows", func(t *testing.T) {
+		target := &InsertData{Data: map[FieldID]FieldData{
+			100: &Int64FieldData{NumRows: []int64{0}},
+			109: &FloatVectorFieldData{NumRows: []int64{0}, Dim: 4},
+		}}
+		schema := &schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{meta.Schema.Fields[2], meta.Schema.Fields[11]}}
+		sub, err := InsertDataToRecord(schema, data)
+		require.NoError(t, err)
+		defer sub.Release()
+		require.NoError(t, AppendRecordRow(target, sub, 3))
+		require.NoError(t, AppendRecordRow(target, sub, 1))
+		assert.Equal(t, []int64{4, 2}, target.Data[100].(*Int64FieldData).Data)
+		assert.Equal(t, []float32{3, 4, 5, 6, 1, 2, 3, 4}, target.Data[109].(*FloatVectorFieldData).Data)
+
+		// the field is missing or of another type
+		assert.Error(t, AppendRecordRow(&InsertData{Data: map[FieldID]FieldData{100: target.Data[100]}}, sub, 0))
+		assert.Error(t, AppendRecordRow(&InsertData{Data: map[FieldID]FieldData{
+			100: &Int32FieldData{NumRows: []int64{0}},
+			109: target.Data[109],
+		}}, sub, 0))
+	})
+
+	t.Run("invalid insert data", func(t *testing.T) {
+		_, err := InsertDataToRecord(meta.Schema, &InsertData{Data: map[FieldID]FieldData{}})
+		assert.Error(t, err)
+
+		delete(data.Data, 109)
+		schema := &schemapb.CollectionSchema{Fields: meta.Schema.Fields[:11]}
+		data.Data[100].(*Int64FieldData).Data = data.Data[100].(*Int64FieldData).Data[:10]
+		_, err = InsertDataToRecord(schema, data)
+		assert.Error(t, err)
+
+		// the dim of vectors is required
+		_, err = NewInsertRecordSchema(&schemapb.CollectionSchema{Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, DataType: schemapb.DataType_FloatVector},
+		}})
+		assert.Error(t, err)
+	})
+}
+
+func TestInsertRecordBuilder(t *testing.T) {
+	meta, data := genInsertRecordTestData(10)
+	builder, err := NewInsertRecordBuilder(meta.Schema)
+	require.NoError(t, err)
+	defer builder.Release()
+
+	for i := 0; i < 10; i++ {
+		row := make(map[FieldID]interface{})
+		for fieldID, fieldData := range data.Data {
+			row[fieldID] = fieldData.GetRow(i)
+		}
+		require.NoError(t, builder.AppendRow(row))
+	}
+	assert.Equal(t, 10, builder.Len())
+	record := builder.NewRecord()
+	defer record.Release()
+	assert.Equal(t, 0, builder.Len())
+	assert.True(t, builder.Schema().Equal(record.Schema()))
+
+	converted, err := RecordToInsertData(record)
+	require.NoError(t, err)
+	for fieldID, fieldData := range data.Data {
+		for i := 0; i < fieldData.RowNum(); i++ {
+			assert.Equal(t, fieldData.GetRow(i), converted.Data[fieldID].GetRow(i), fieldID)
+		}
+	}
+
+	validRow := func() map[FieldID]interface{} {
+		row := make(map[FieldID]interface{})
+		for fieldID, fieldData := range data.Data {
+			row[fieldID] = fieldData.GetRow(0)
+		}
+		return row
+	}
+	tests := []struct {
+		description string
+		fieldID     FieldID
+		value       interface{}
+	}{
+		{"invalid bool", 101, 1},
+		{"invalid int8", 102, nil},
+		{"invalid int16", 103, int32(1)},
+		{"invalid int32", 104, int64(1)},
+		{"invalid int64", 100, "1"},
+		{"invalid float", 105, float64(1)},
+		{"invalid double", 106, float32(1)},
+		{"invalid varchar", 107, []byte("1")},
+		{"invalid binary vector", 108, []byte{1}},
+		{"invalid float vector", 109, []float32{1, 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			builder, err := NewInsertRecordBuilder(meta.Schema)
+			require.NoError(t, err)
+			defer builder.Release()
+			row := validRow()
+			row[test.fieldID] = test.value
+			assert.Error(t, builder.AppendRow(row))
+		})
+	}
+
+	t.Run("invalid fields", func(t *testing.T) {
+		row := validRow()
+		delete(row, 109)
+		assert.Error(t, builder.AppendRow(row))
+		row[110] = int64(1)
+		assert.Error(t, builder.AppendRow(row))
+	})
+}
+
+func TestInsertCodec_SerializeRecord(t *testing.T) {
+	meta, data := genInsertRecordTestData(100)
+	codec := NewInsertCodec(meta)
+	record, err := InsertDataToRecord(meta.Schema, data)
+	require.NoError(t, err)
+	defer record.Release()
+
+	blobs, statsBlobs, err := codec.SerializeRecord(2, 3, record)
+	require.NoError(t, err)
+	expectedBlobs, expectedStatsBlobs, err := codec.Serialize(2, 3, data)
+	require.NoError(t, err)
+	assert.Equal(t, expectedStatsBlobs, statsBlobs)
+	require.Len(t, blobs, len(expectedBlobs))
+
+	_, _, _, deserialized, err := codec.DeserializeAll(blobs)
+	require.NoError(t, err)
+	for fieldID, fieldData := range data.Data {
+		for i := 0; i < fieldData.RowNum(); i++ {
+			assert.Equal(t, fieldData.GetRow(i), deserialized.Data[fieldID].GetRow(i), fieldID)
+		}
+	}
+}
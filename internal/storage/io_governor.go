@@ -0,0 +1,436 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/ratelimitutil"
+)
+
+// IOGovernor bounds the concurrency and the bandwidth of object storage requests.
+type IOGovernor interface {
+	// Acquire blocks until a new request is allowed to start.
+	Acquire(ctx context.Context) (IOPermit, error)
+}
+
+// IOPermit is held by an in-flight request.
+type IOPermit interface {
+	// WaitN blocks until @n more bytes are allowed to be transferred, it still throttles after Release.
+	WaitN(ctx context.Context, n int) error
+	// Release gives the concurrency slot back, it's safe to call it more than once.
+	Release()
+	// Close ends the request, the slot is released if it's not yet.
+	Close()
+}
+
+const (
+	// ioGovernorWaitInterval is the interval to recheck the bandwidth limiter.
+	ioGovernorWaitInterval = 10 * time.Millisecond
+	// ioGovernorWaitBatch is the number of bytes transferred before the bandwidth is waited for,
+	// so that the host io governor isn't asked on every read.
+	ioGovernorWaitBatch = 256 * 1024
+	// ioGovernorReleaseTimeout bounds the release request to the host io governor.
+	ioGovernorReleaseTimeout = time.Second
+
+	// the line protocol between the host io governor and its clients
+	ioGovernorAcquire = "acquire"
+	ioGovernorWait    = "wait"
+	ioGovernorRelease = "release"
+	ioGovernorReplyOK = "ok"
+)
+
+var (
+	ioGovernorOnce sync.Once
+	ioGovernor     IOGovernor
+)
+
+// getIOGovernor returns the governor shared by all chunk managers of the process, the limits of the first
+// chunk manager configured with limits win. It returns nil if @c sets no limit.
+func getIOGovernor(c *config) IOGovernor {
+	if c.governorMaxConcurrency <= 0 && c.governorMaxBandwidth <= 0 {
+		return nil
+	}
+	ioGovernorOnce.Do(func() {
+		if c.governorHostSocket != "" {
+			ioGovernor = newHostIOGovernor(c.governorHostSocket, c.governorMaxConcurrency, c.governorMaxBandwidth)
+		} else {
+			ioGovernor = newLocalIOGovernor(c.governorMaxConcurrency, c.governorMaxBandwidth)
+		}
+		log.Info("object storage io governor enabled", zap.Int("maxConcurrency", c.governorMaxConcurrency),
+			zap.Int64("maxBandwidth", c.governorMaxBandwidth), zap.String("hostSocket", c.governorHostSocket))
+	})
+	return ioGovernor
+}
+
+// localIOGovernor enforces the limits within the process.
+type localIOGovernor struct {
+	slots   chan struct{}          // nil if the concurrency is unlimited
+	limiter *ratelimitutil.Limiter // nil if the bandwidth is unlimited
+	burst   int                    // the most bytes allowed at once by limiter
+}
+
+func newLocalIOGovernor(maxConcurrency int, maxBandwidth int64) *localIOGovernor {
+	g := &localIOGovernor{}
+	if maxConcurrency > 0 {
+		g.slots = make(chan struct{}, maxConcurrency)
+	}
+	if maxBandwidth > 0 {
+		g.limiter = ratelimitutil.NewLimiter(ratelimitutil.Limit(maxBandwidth), float64(maxBandwidth))
+		g.burst = int(maxBandwidth)
+	}
+	return g
+}
+
+func (g *localIOGovernor) Acquire(ctx context.Context) (IOPermit, error) {
+	if g.slots != nil {
+		select {
+		case g.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return &localIOPermit{governor: g}, nil
+}
+
+type localIOPermit struct {
+	governor *localIOGovernor
+	once     sync.Once
+}
+
+// WaitN waits for at most a burst at a time, the limiter never allows more than a burst at once.
+func (p *localIOPermit) WaitN(ctx context.Context, n int) error {
+	limiter := p.governor.limiter
+	if limiter == nil {
+		return nil
+	}
+	for n > 0 {
+		batch := n
+		if batch > p.governor.burst {
+			batch = p.governor.burst
+		}
+		for !limiter.AllowN(time.Now(), batch) {
+			select {
+			case <-time.After(ioGovernorWaitInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		n -= batch
+	}
+	return nil
+}
+
+func (p *localIOPermit) Release() {
+	p.once.Do(func() {
+		if p.governor.slots != nil {
+			<-p.governor.slots
+		}
+	})
+}
+
+func (p *localIOPermit) Close() {
+	p.Release()
+}
+
+// hostIOGovernor shares the limits with the other processes on the host through a unix socket,
+// the first process binding the socket serves its localIOGovernor to all of them, and a permit is
+// held until it's released or its connection is closed. It falls back to the limits of the process if the socket is unavailable.
+type hostIOGovernor struct {
+	socketPath string
+	local      *localIOGovernor
+
+	mu       sync.Mutex
+	listener net.Listener
+}
+
+func newHostIOGovernor(socketPath string, maxConcurrency int, maxBandwidth int64) *hostIOGovernor {
+	return &hostIOGovernor{
+		socketPath: socketPath,
+		local:      newLocalIOGovernor(maxConcurrency, maxBandwidth),
+	}
+}
+
+func (g *hostIOGovernor) Acquire(ctx context.Context) (IOPermit, error) {
+	conn, err := g.dial(ctx)
+	if err != nil {
+		log.RatedWarn(60, "failed to connect io governor of the host, use the limits of the process",
+			zap.String("socket", g.socketPath), zap.Error(err))
+		return g.local.Acquire(ctx)
+	}
+	permit := &hostIOPermit{conn: conn, reader: bufio.NewReader(conn)}
+	err = permit.request(ctx, ioGovernorAcquire)
+	if err != nil {
+		permit.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		log.RatedWarn(60, "failed to acquire from io governor of the host, use the limits of the process",
+			zap.String("socket", g.socketPath), zap.Error(err))
+		return g.local.Acquire(ctx)
+	}
+	return permit, nil
+}
+
+// dial connects the coordinator, this process becomes the coordinator if there is none.
+func (g *hostIOGovernor) dial(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", g.socketPath)
+	if err == nil {
+		return conn, nil
+	}
+	if err := g.serve(); err != nil {
+		return nil, err
+	}
+	return dialer.DialContext(ctx, "unix", g.socketPath)
+}
+
+func (g *hostIOGovernor) serve() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.listener != nil {
+		return nil
+	}
+
+	listener, err := net.Listen("unix", g.socketPath)
+	if err != nil {
+		// another process may have become the coordinator
+		if conn, dialErr := net.DialTimeout("unix", g.socketPath, time.Second); dialErr == nil {
+			conn.Close()
+			return nil
+		}
+		// the socket file is left by a dead coordinator
+		if removeErr := os.Remove(g.socketPath); removeErr != nil && !os.IsNotExist(removeErr) {
+			return err
+		}
+		listener, err = net.Listen("unix", g.socketPath)
+		if err != nil {
+			return err
+		}
+	}
+	g.listener = listener
+	log.Info("serve io governor of the host", zap.String("socket", g.socketPath))
+	go g.accept(listener)
+	return nil
+}
+
+func (g *hostIOGovernor) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Warn("io governor of the host stops serving", zap.String("socket", g.socketPath), zap.Error(err))
+			g.mu.Lock()
+			if g.listener == listener {
+				g.listener = nil
+			}
+			g.mu.Unlock()
+			return
+		}
+		go g.handle(conn)
+	}
+}
+
+// handle serves the requests of one permit, the permit is released on request or once the connection is closed,
+// the bandwidth is still served after the release.
+func (g *hostIOGovernor) handle(conn net.Conn) {
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var permit IOPermit
+	defer func() {
+		if permit != nil {
+			permit.Release()
+		}
+	}()
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1 && fields[0] == ioGovernorAcquire && permit == nil:
+			permit, err = g.local.Acquire(ctx)
+		case len(fields) == 2 && fields[0] == ioGovernorWait && permit != nil:
+			var n int
+			n, err = strconv.Atoi(fields[1])
+			if err == nil {
+				err = permit.WaitN(ctx, n)
+			}
+		case len(fields) == 1 && fields[0] == ioGovernorRelease && permit != nil:
+			permit.Release()
+		default:
+			err = fmt.Errorf("unexpected io governor request %q", strings.TrimSpace(line))
+		}
+		if err != nil {
+			log.Warn("io governor of the host failed to serve request", zap.Error(err))
+			return
+		}
+		if _, err := io.WriteString(conn, ioGovernorReplyOK+"\n"); err != nil {
+			return
+		}
+	}
+}
+
+// close stops serving the socket if this process is the coordinator.
+func (g *hostIOGovernor) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.listener != nil {
+		g.listener.Close()
+		g.listener = nil
+	}
+}
+
+type hostIOPermit struct {
+	conn     net.Conn
+	reader   *bufio.Reader
+	released sync.Once
+	closed   sync.Once
+}
+
+func (p *hostIOPermit) WaitN(ctx context.Context, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	err := p.request(ctx, fmt.Sprintf("%s %d", ioGovernorWait, n))
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	// the governor is best effort, the transfer goes on if the coordinator is gone
+	return nil
+}
+
+// Release asks the coordinator to release the slot, the connection is closed if it fails
+// so that the coordinator releases it anyway.
+func (p *hostIOPermit) Release() {
+	p.released.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), ioGovernorReleaseTimeout)
+		defer cancel()
+		if err := p.request(ctx, ioGovernorRelease); err != nil {
+			p.Close()
+		}
+	})
+}
+
+func (p *hostIOPermit) Close() {
+	p.closed.Do(func() {
+		p.conn.Close()
+	})
+}
+
+// request sends a line to the coordinator and waits for the reply, the connection is interrupted if ctx is done.
+func (p *hostIOPermit) request(ctx context.Context, line string) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	if _, err := io.WriteString(p.conn, line+"\n"); err != nil {
+		return err
+	}
+	reply, err := p.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(reply) != ioGovernorReplyOK {
+		return fmt.Errorf("unexpected io governor reply %q", strings.TrimSpace(reply))
+	}
+	return nil
+}
+
+// governedTransport acquires a permit from the governor for every request, the concurrency slot is released
+// once the response headers arrive, so that a request issued while reading a body never waits for the slot
+// held by the body itself. The bytes sent and received are throttled by the permit until the body is closed.
+type governedTransport struct {
+	backend  http.RoundTripper
+	governor IOGovernor
+}
+
+func (t *governedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	permit, err := t.governor.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(ctx)
+		req.Body = &governedBody{ReadCloser: req.Body, ctx: ctx, permit: permit}
+	}
+	resp, err := t.backend.RoundTrip(req)
+	permit.Release()
+	if err != nil {
+		permit.Close()
+		return nil, err
+	}
+	resp.Body = &governedBody{ReadCloser: resp.Body, ctx: ctx, permit: permit, close: true}
+	return resp, nil
+}
+
+// governedBody throttles the reads by the permit, the bytes read are waited for by batches of
+// ioGovernorWaitBatch. The permit is closed at the end if close is set.
+type governedBody struct {
+	io.ReadCloser
+	ctx     context.Context
+	permit  IOPermit
+	close   bool
+	pending int
+}
+
+func (b *governedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.pending += n
+	if b.pending >= ioGovernorWaitBatch || (err == io.EOF && b.pending > 0) {
+		if waitErr := b.permit.WaitN(b.ctx, b.pending); waitErr != nil && err == nil {
+			err = waitErr
+		}
+		b.pending = 0
+	}
+	if err == io.EOF && b.close {
+		b.permit.Close()
+	}
+	return n, err
+}
+
+func (b *governedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.close {
+		b.permit.Close()
+	}
+	return err
+}
@@ -0,0 +1,143 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalIOGovernor(t *testing.T) {
+	t.Run("concurrency", func(t *testing.T) {
+		g := newLocalIOGovernor(2, 0)
+		p1, err := g.Acquire(context.Background())
+		require.NoError(t, err)
+		_, err = g.Acquire(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = g.Acquire(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		p1.Release()
+		// release is idempotent
+		p1.Release()
+		p3, err := g.Acquire(context.Background())
+		assert.NoError(t, err)
+		p3.Release()
+	})
+
+	t.Run("bandwidth", func(t *testing.T) {
+		g := newLocalIOGovernor(0, 100000)
+		p, err := g.Acquire(context.Background())
+		require.NoError(t, err)
+		defer p.Release()
+
+		// the burst is used up, and the limiter allows a debt of the next second
+		assert.NoError(t, p.WaitN(context.Background(), 100000))
+		assert.NoError(t, p.WaitN(context.Background(), 100000))
+		// the tokens of the next second have been used up
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, p.WaitN(ctx, 100000), context.DeadlineExceeded)
+	})
+
+	t.Run("more than burst", func(t *testing.T) {
+		g := newLocalIOGovernor(0, 100000)
+		p, err := g.Acquire(context.Background())
+		require.NoError(t, err)
+		defer p.Close()
+
+		// the wait is split by the burst instead of waiting forever
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, p.WaitN(ctx, 150000))
+	})
+}
+
+func TestHostIOGovernor(t *testing.T) {
+	socket := path.Join(t.TempDir(), "io_governor.sock")
+	g1 := newHostIOGovernor(socket, 1, 0)
+	defer g1.close()
+	g2 := newHostIOGovernor(socket, 1, 0)
+	defer g2.close()
+
+	// g1 becomes the coordinator
+	p1, err := g1.Acquire(context.Background())
+	require.NoError(t, err)
+	_, ok := p1.(*hostIOPermit)
+	assert.True(t, ok)
+	assert.NoError(t, p1.WaitN(context.Background(), 1024))
+
+	// the limit is shared with g2
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = g2.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the slot is released while the bandwidth is still served
+	p1.Release()
+	p2, err := g2.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.NoError(t, p1.WaitN(context.Background(), 1024))
+	p1.Close()
+	p2.Close()
+
+	// g2 takes over once the coordinator is gone
+	g1.close()
+	p2, err = g2.Acquire(context.Background())
+	require.NoError(t, err)
+	_, ok = p2.(*hostIOPermit)
+	assert.True(t, ok)
+	p2.Close()
+	g2.mu.Lock()
+	assert.NotNil(t, g2.listener)
+	g2.mu.Unlock()
+}
+
+func TestGovernedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("value"))
+	}))
+	defer server.Close()
+
+	governor := newLocalIOGovernor(1, 0)
+	client := &http.Client{Transport: &governedTransport{backend: http.DefaultTransport, governor: governor}}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	// the slot is released once the headers arrive, a nested request doesn't wait for the body
+	assert.Equal(t, 0, len(governor.slots))
+	nested, err := client.Get(server.URL)
+	require.NoError(t, err)
+	nested.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), content)
+	resp.Body.Close()
+	assert.Equal(t, 0, len(governor.slots))
+}
@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// KeyRotateTransform returns the MigrateTransform moving the encrypted objects to the master key @newKeyID.
+// The data keys are only unwrapped and wrapped again by the new master key, the contents are left as they are,
+// unless @reencrypt is true, with which the contents are decrypted and encrypted again with new data keys.
+// The objects already under the new master key and the objects not encrypted are skipped,
+// so a rotation interrupted without the state file could be rerun from the beginning.
+func KeyRotateTransform(keys KeyProvider, newKeyID string, reencrypt bool) MigrateTransform {
+	return func(ctx context.Context, key string, content []byte) ([]byte, error) {
+		if !IsEncryptedObject(content) {
+			log.Debug("skip rotating the object not encrypted", zap.String("key", key))
+			return nil, ErrSkipObject
+		}
+		e, err := unmarshalEnvelope(content)
+		if err != nil {
+			return nil, err
+		}
+		if e.KeyID == newKeyID {
+			return nil, ErrSkipObject
+		}
+		if reencrypt {
+			plaintext, err := DecryptObject(ctx, keys, content)
+			if err != nil {
+				return nil, err
+			}
+			return EncryptObject(ctx, keys, newKeyID, plaintext)
+		}
+		dataKey, err := keys.UnwrapKey(ctx, e.KeyID, e.WrappedKey)
+		if err != nil {
+			return nil, err
+		}
+		wrappedKey, err := keys.WrapKey(ctx, newKeyID, dataKey)
+		if err != nil {
+			return nil, err
+		}
+		rotated := &envelope{KeyID: newKeyID, WrappedKey: wrappedKey, Sealed: e.Sealed}
+		return rotated.marshal()
+	}
+}
+
+// RotateKeys moves the encrypted objects under the directory @prefix of @cm to the master key @newKeyID in place,
+// see KeyRotateTransform. The objects are rotated by a Migrator, so @opts bound the workers and the rate,
+// and a rotation with the state file is resumable. It's run by the command milvus rotate-keys.
+// The chunk managers don't encrypt the objects they write, only the objects encrypted by EncryptObject are rotated.
+func RotateKeys(ctx context.Context, cm ChunkManager, prefix string, keys KeyProvider, newKeyID string, reencrypt bool,
+	opts ...MigratorOption) (*MigrateState, error) {
+	opts = append(opts, WithMigrateTransform(KeyRotateTransform(keys, newKeyID, reencrypt)))
+	return NewMigrator(cm, cm, opts...).Migrate(ctx, prefix, prefix)
+}
@@ -0,0 +1,87 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateKeys(t *testing.T) {
+	ctx := context.Background()
+	keys := newTestKeyProvider(t)
+	cm := NewLocalChunkManager(RootPath(t.TempDir() + "/"))
+
+	for i := 0; i < 10; i++ {
+		encrypted, err := EncryptObject(ctx, keys, "key-1", []byte(fmt.Sprint(i)))
+		require.NoError(t, err)
+		require.NoError(t, cm.Write(ctx, path.Join("files", fmt.Sprint(i)), encrypted))
+	}
+	require.NoError(t, cm.Write(ctx, path.Join("files", "plain"), []byte("plain")))
+
+	verify := func(keyID string) {
+		for i := 0; i < 10; i++ {
+			content, err := cm.Read(ctx, path.Join("files", fmt.Sprint(i)))
+			require.NoError(t, err)
+			rotated, err := EncryptionKeyID(content)
+			assert.NoError(t, err)
+			assert.Equal(t, keyID, rotated)
+			plaintext, err := DecryptObject(ctx, keys, content)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte(fmt.Sprint(i)), plaintext)
+		}
+		content, err := cm.Read(ctx, path.Join("files", "plain"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("plain"), content)
+	}
+
+	statePath := filepath.Join(t.TempDir(), "state")
+	state, err := RotateKeys(ctx, cm, "files", keys, "key-2", false,
+		WithMigrateWorkers(4), WithMigrateRate(1000), WithMigrateBandwidth(1<<20), WithMigrateStateFile(statePath))
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, state.Objects)
+	assert.EqualValues(t, 1, state.Skipped)
+	verify("key-2")
+
+	data, err := ioutil.ReadFile(statePath)
+	require.NoError(t, err)
+	saved := &MigrateState{}
+	require.NoError(t, json.Unmarshal(data, saved))
+	assert.Equal(t, state, saved)
+
+	// rerun without the state, the rotated objects are skipped
+	state, err = RotateKeys(ctx, cm, "files", keys, "key-2", false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, state.Skipped)
+
+	state, err = RotateKeys(ctx, cm, "files", keys, "key-1", true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, state.Skipped)
+	verify("key-1")
+
+	_, err = RotateKeys(ctx, cm, "files", keys, "key-3", false)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	verify("key-1")
+}
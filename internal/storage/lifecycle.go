@@ -0,0 +1,156 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// lifecycleRuleIDPrefix is the prefix of the IDs of the lifecycle rules managed by Milvus,
+// the rules of other IDs are configured by the operators and kept as they are.
+const lifecycleRuleIDPrefix = "milvus-"
+
+// LifecycleManager installs the bucket lifecycle rules derived from the Milvus configs.
+type LifecycleManager interface {
+	// ApplyLifecycle installs or updates the managed lifecycle rules of the bucket, objects orphaned by the
+	// incomplete multipart uploads are aborted after @abortMultipartAfter, and the aged objects are transitioned
+	// by the storage class policy. Returns true if the lifecycle of the bucket is changed.
+	ApplyLifecycle(ctx context.Context, abortMultipartAfter time.Duration) (bool, error)
+}
+
+var _ LifecycleManager = (*MinioChunkManager)(nil)
+
+// lifecycleDays rounds @d up to days, the granularity of the lifecycle rules, which is at least one day.
+func lifecycleDays(d time.Duration) lifecycle.ExpirationDays {
+	days := (d + 24*time.Hour - 1) / (24 * time.Hour)
+	if days < 1 {
+		days = 1
+	}
+	return lifecycle.ExpirationDays(days)
+}
+
+// buildLifecycleRules returns the lifecycle rules managed by Milvus of the objects under @rootPath. Only the aged
+// storage class rules of patterns in the form of "prefix*" are converted into transitions, as the lifecycle rules
+// filter the objects by prefix, the others are left to TransitionStorageClass.
+func buildLifecycleRules(rootPath string, abortMultipartAfter time.Duration, policy StorageClassPolicy) []lifecycle.Rule {
+	idPrefix := lifecycleRuleIDPrefix + rootPath + "-"
+	rootPrefix := rootPath
+	if rootPrefix != "" && !strings.HasSuffix(rootPrefix, "/") {
+		rootPrefix += "/"
+	}
+	var rules []lifecycle.Rule
+	if abortMultipartAfter > 0 {
+		rules = append(rules, lifecycle.Rule{
+			ID:         idPrefix + "abort-multipart-upload",
+			Status:     "Enabled",
+			RuleFilter: lifecycle.Filter{Prefix: rootPrefix},
+			AbortIncompleteMultipartUpload: lifecycle.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: lifecycleDays(abortMultipartAfter),
+			},
+		})
+	}
+	for i, rule := range policy {
+		prefix := strings.TrimSuffix(rule.Pattern, "*")
+		if rule.MinAge <= 0 || strings.Contains(prefix, "*") || !strings.HasPrefix(prefix, rootPrefix) {
+			continue
+		}
+		rules = append(rules, lifecycle.Rule{
+			ID:         fmt.Sprintf("%stransition-%d", idPrefix, i),
+			Status:     "Enabled",
+			RuleFilter: lifecycle.Filter{Prefix: prefix},
+			Transition: lifecycle.Transition{
+				Days:         lifecycleDays(rule.MinAge),
+				StorageClass: rule.StorageClass,
+			},
+		})
+	}
+	return rules
+}
+
+// mergeLifecycleRules replaces the rules of @current managed by Milvus under @rootPath with @managed.
+// The rules of other root paths sharing the bucket are kept.
+func mergeLifecycleRules(current []lifecycle.Rule, rootPath string, managed []lifecycle.Rule) []lifecycle.Rule {
+	idPrefix := lifecycleRuleIDPrefix + rootPath + "-"
+	merged := make([]lifecycle.Rule, 0, len(current)+len(managed))
+	for _, rule := range current {
+		if !strings.HasPrefix(rule.ID, idPrefix) {
+			merged = append(merged, rule)
+		}
+	}
+	return append(merged, managed...)
+}
+
+// ApplyLifecycle installs or updates the lifecycle rules managed by Milvus, the bucket is not touched if they
+// are installed already. The lifecycle rules of the bucket not managed by Milvus are kept.
+func (mcm *MinioChunkManager) ApplyLifecycle(ctx context.Context, abortMultipartAfter time.Duration) (bool, error) {
+	current, err := mcm.Client.GetBucketLifecycle(ctx, mcm.bucketName)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchLifecycleConfiguration" {
+			log.Warn("failed to get bucket lifecycle", zap.String("bucket", mcm.bucketName), zap.Error(err))
+			return false, err
+		}
+		current = lifecycle.NewConfiguration()
+	}
+
+	managed := buildLifecycleRules(mcm.rootPath, abortMultipartAfter, mcm.storageClassPolicy)
+	if sameLifecycleRules(current.Rules, mcm.rootPath, managed) {
+		return false, nil
+	}
+	config := lifecycle.NewConfiguration()
+	config.Rules = mergeLifecycleRules(current.Rules, mcm.rootPath, managed)
+	if err := mcm.Client.SetBucketLifecycle(ctx, mcm.bucketName, config); err != nil {
+		log.Warn("failed to set bucket lifecycle", zap.String("bucket", mcm.bucketName), zap.Error(err))
+		return false, err
+	}
+	log.Info("bucket lifecycle updated", zap.String("bucket", mcm.bucketName), zap.Int("managedRules", len(managed)))
+	return true, nil
+}
+
+// sameLifecycleRules returns true if @current has exactly the rules in @managed among the ones managed by Milvus
+// under @rootPath, only the fields set by buildLifecycleRules are compared.
+func sameLifecycleRules(current []lifecycle.Rule, rootPath string, managed []lifecycle.Rule) bool {
+	idPrefix := lifecycleRuleIDPrefix + rootPath + "-"
+	installed := make(map[string]string)
+	for _, rule := range current {
+		if strings.HasPrefix(rule.ID, idPrefix) {
+			installed[rule.ID] = lifecycleRuleDigest(rule)
+		}
+	}
+	if len(installed) != len(managed) {
+		return false
+	}
+	for _, rule := range managed {
+		if installed[rule.ID] != lifecycleRuleDigest(rule) {
+			return false
+		}
+	}
+	return true
+}
+
+func lifecycleRuleDigest(rule lifecycle.Rule) string {
+	return fmt.Sprintf("%s|%s|%d|%d|%s", rule.Status, rule.RuleFilter.Prefix,
+		rule.AbortIncompleteMultipartUpload.DaysAfterInitiation, rule.Transition.Days, rule.Transition.StorageClass)
+}
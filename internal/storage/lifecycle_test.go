@@ -0,0 +1,62 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleRules(t *testing.T) {
+	assert.Equal(t, lifecycle.ExpirationDays(1), lifecycleDays(time.Hour))
+	assert.Equal(t, lifecycle.ExpirationDays(1), lifecycleDays(24*time.Hour))
+	assert.Equal(t, lifecycle.ExpirationDays(2), lifecycleDays(25*time.Hour))
+
+	policy, err := ParseStorageClassPolicy("files/delta_log/*:STANDARD_IA:720h,*/insert_log/*:GLACIER_IR:720h," +
+		"files/index_files/*:STANDARD,other/delta_log/*:STANDARD_IA:720h")
+	require.NoError(t, err)
+	rules := buildLifecycleRules("files", 36*time.Hour, policy)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "milvus-files-abort-multipart-upload", rules[0].ID)
+	assert.Equal(t, "files/", rules[0].RuleFilter.Prefix)
+	assert.Equal(t, lifecycle.ExpirationDays(2), rules[0].AbortIncompleteMultipartUpload.DaysAfterInitiation)
+	assert.Equal(t, "milvus-files-transition-0", rules[1].ID)
+	assert.Equal(t, "files/delta_log/", rules[1].RuleFilter.Prefix)
+	assert.Equal(t, lifecycle.ExpirationDays(30), rules[1].Transition.Days)
+	assert.Equal(t, StorageClassStandardIA, rules[1].Transition.StorageClass)
+
+	assert.Empty(t, buildLifecycleRules("files", 0, nil))
+
+	operatorRule := lifecycle.Rule{ID: "expire-logs", Status: "Enabled", RuleFilter: lifecycle.Filter{Prefix: "logs/"}}
+	otherRootRule := lifecycle.Rule{ID: "milvus-other-abort-multipart-upload", Status: "Enabled"}
+	staleRule := lifecycle.Rule{ID: "milvus-files-transition-3", Status: "Enabled"}
+	current := []lifecycle.Rule{operatorRule, otherRootRule, staleRule}
+	assert.False(t, sameLifecycleRules(current, "files", rules))
+
+	merged := mergeLifecycleRules(current, "files", rules)
+	assert.Equal(t, []lifecycle.Rule{operatorRule, otherRootRule, rules[0], rules[1]}, merged)
+	assert.True(t, sameLifecycleRules(merged, "files", rules))
+
+	changed := append([]lifecycle.Rule{}, rules...)
+	changed[1].Transition.Days = 60
+	assert.False(t, sameLifecycleRules(merged, "files", changed))
+	assert.False(t, sameLifecycleRules(merged, "files", rules[:1]))
+}
@@ -26,31 +26,62 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
-	"go.uber.org/zap"
 	"golang.org/x/exp/mmap"
 
-	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/util/errorutil"
 )
 
 // LocalChunkManager is responsible for read and write local file.
 type LocalChunkManager struct {
-	localPath string
+	localPath   string
+	concurrency int
+	// fsync makes writes flushed to disk before returning
+	fsync bool
+	// quota rejects writes above the high watermark of the disk usage, nil means no quota
+	quota       *diskQuota
+	prefixSizes *prefixSizeCache
+	// journal makes the mutations crash consistent, nil means no journal
+	journal *localJournal
 }
 
 var _ ChunkManager = (*LocalChunkManager)(nil)
 
-// NewLocalChunkManager create a new local manager object.
+// errStopWalk is used to stop filepath.Walk when the walk func of WalkWithPrefix returns false
+var errStopWalk = errors.New("stop walk")
+
+// NewLocalChunkManager create a new local manager object, it panics if the journal enabled by the WithJournal option
+// fails to replay.
 func NewLocalChunkManager(opts ...Option) *LocalChunkManager {
+	lcm, err := newLocalChunkManager(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return lcm
+}
+
+func newLocalChunkManager(opts ...Option) (*LocalChunkManager, error) {
 	c := newDefaultConfig()
 	for _, opt := range opts {
 		opt(c)
 	}
-	return &LocalChunkManager{
-		localPath: c.rootPath,
+	lcm := &LocalChunkManager{
+		localPath:   c.rootPath,
+		concurrency: c.concurrency,
+		fsync:       c.fsync,
+		quota:       newDiskQuota(c),
+		prefixSizes: newPrefixSizeCache(c.prefixSizeCacheTTL),
+	}
+	if c.journal {
+		journal, err := openLocalJournal(c.rootPath)
+		if err != nil {
+			return nil, err
+		}
+		lcm.journal = journal
 	}
+	return lcm, nil
 }
 
 // RootPath returns lcm root path.
@@ -60,32 +91,36 @@ func (lcm *LocalChunkManager) RootPath() string {
 
 // Path returns the path of local data if exists.
 func (lcm *LocalChunkManager) Path(ctx context.Context, filePath string) (string, error) {
-	exist, err := lcm.Exist(ctx, filePath)
+	_, err := lcm.Stat(ctx, filePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return "", fmt.Errorf("local file cannot be found with filePath: %s", filePath)
+	}
 	if err != nil {
 		return "", err
 	}
-
-	if !exist {
-		return "", fmt.Errorf("local file cannot be found with filePath: %s", filePath)
-	}
 	absPath := path.Join(lcm.localPath, filePath)
 	return absPath, nil
 }
 
 func (lcm *LocalChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
-	exist, err := lcm.Exist(ctx, filePath)
-	if err != nil {
+	if _, err := lcm.Stat(ctx, filePath); err != nil {
 		return nil, err
 	}
-	if !exist {
-		return nil, errors.New("local file cannot be found with filePath:" + filePath)
-	}
 	absPath := path.Join(lcm.localPath, filePath)
 	return os.Open(absPath)
 }
 
 // Write writes the data to local storage.
 func (lcm *LocalChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	if err := lcm.quota.check(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	if lcm.journal != nil {
+		return lcm.journal.write(filePath, func(w io.Writer) error {
+			_, err := w.Write(content)
+			return err
+		})
+	}
 	absPath := path.Join(lcm.localPath, filePath)
 	dir := path.Dir(absPath)
 	exist, err := lcm.Exist(ctx, dir)
@@ -98,22 +133,195 @@ func (lcm *LocalChunkManager) Write(ctx context.Context, filePath string, conten
 			return err
 		}
 	}
+	if lcm.fsync {
+		return writeFile(absPath, os.O_TRUNC, content, true)
+	}
 	return ioutil.WriteFile(absPath, content, os.ModePerm)
 }
 
-// MultiWrite writes the data to local storage.
-func (lcm *LocalChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
-	var el errorutil.ErrorList
-	for filePath, content := range contents {
-		err := lcm.Write(ctx, filePath, content)
+// WriteWithOptions writes the data to local storage, the user metadata and tags are ignored
+// since local file system has no place to keep them.
+func (lcm *LocalChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	return lcm.Write(ctx, filePath, content)
+}
+
+// WriteIfNotExist writes the data to local storage, the file is created exclusively
+// and an error wrapping ErrObjectExists is returned if it already exists.
+func (lcm *LocalChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	if err := lcm.quota.check(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	if lcm.journal != nil {
+		return lcm.journal.writeIfNotExist(filePath, content)
+	}
+	absPath := path.Join(lcm.localPath, filePath)
+	if err := os.MkdirAll(path.Dir(absPath), os.ModePerm); err != nil {
+		return err
+	}
+	err := writeFile(absPath, os.O_EXCL, content, lcm.fsync)
+	if os.IsExist(err) {
+		return WrapErrObjectExists(filePath)
+	}
+	return err
+}
+
+// Append appends the data to the end of local file, the file is created if it doesn't exist.
+func (lcm *LocalChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	if err := lcm.quota.check(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	if lcm.journal != nil {
+		return lcm.journal.appendFile(filePath, content)
+	}
+	absPath := path.Join(lcm.localPath, filePath)
+	if err := os.MkdirAll(path.Dir(absPath), os.ModePerm); err != nil {
+		return err
+	}
+	return appendFile(absPath, content, lcm.fsync)
+}
+
+// appendFile appends the content to the end of the file, and flushes it to disk if @fsync is set.
+func appendFile(absPath string, content []byte, fsync bool) error {
+	f, err := os.OpenFile(absPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if fsync {
+		if err = f.Sync(); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// writeFile opens the file with @flag, writes the content and flushes it to disk if @fsync is set,
+// the parent directory is flushed too so that the new file entry survives a crash.
+func writeFile(absPath string, flag int, content []byte, fsync bool) error {
+	f, err := os.OpenFile(absPath, os.O_WRONLY|os.O_CREATE|flag, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	fail := func(err error) error {
+		f.Close()
+		if flag&os.O_EXCL != 0 {
+			// the file is created exclusively by us, don't leave a partial one behind
+			os.Remove(absPath)
+		}
+		return err
+	}
+	if _, err = f.Write(content); err != nil {
+		return fail(err)
+	}
+	if !fsync {
+		return f.Close()
+	}
+	if err = f.Sync(); err != nil {
+		return fail(err)
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return syncDir(path.Dir(absPath))
+}
+
+// syncDir flushes the entries of directory @dir to disk.
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
+
+// Copy copies the local file at @srcFilePath to @dstFilePath, the parent directory of @dstFilePath is created if needed.
+func (lcm *LocalChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	srcPath := path.Join(lcm.localPath, srcFilePath)
+	dstPath := path.Join(lcm.localPath, dstFilePath)
+	if srcPath == dstPath {
+		_, err := os.Stat(srcPath)
+		return err
+	}
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if lcm.quota != nil {
+		info, err := src.Stat()
 		if err != nil {
-			el = append(el, err)
+			return err
+		}
+		if err := lcm.quota.check(ctx, info.Size()); err != nil {
+			return err
 		}
 	}
-	if len(el) == 0 {
-		return nil
+	if lcm.journal != nil {
+		return lcm.journal.write(dstFilePath, func(w io.Writer) error {
+			_, err := io.Copy(w, src)
+			return err
+		})
 	}
-	return el
+	if err := os.MkdirAll(path.Dir(dstPath), os.ModePerm); err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if !lcm.fsync {
+		return dst.Close()
+	}
+	if err = dst.Sync(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	return syncDir(path.Dir(dstPath))
+}
+
+// Move renames the local file at @srcFilePath to @dstFilePath, the file is copied and removed instead
+// if the two paths are on different devices.
+func (lcm *LocalChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	srcPath := path.Join(lcm.localPath, srcFilePath)
+	dstPath := path.Join(lcm.localPath, dstFilePath)
+	if srcPath == dstPath {
+		_, err := os.Stat(srcPath)
+		return err
+	}
+	if lcm.journal != nil {
+		return lcm.journal.move(srcFilePath, dstFilePath)
+	}
+	if err := os.MkdirAll(path.Dir(dstPath), os.ModePerm); err != nil {
+		return err
+	}
+	err := os.Rename(srcPath, dstPath)
+	if errors.Is(err, syscall.EXDEV) {
+		if err = lcm.Copy(ctx, srcFilePath, dstFilePath); err != nil {
+			return err
+		}
+		return os.Remove(srcPath)
+	}
+	if err != nil || !lcm.fsync {
+		return err
+	}
+	return syncDir(path.Dir(dstPath))
+}
+
+// MultiWrite writes the data to local storage.
+func (lcm *LocalChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	return parallelMultiWrite(ctx, contents, lcm.concurrency, lcm.Write)
 }
 
 // Exist checks whether chunk is saved to local storage.
@@ -129,76 +337,108 @@ func (lcm *LocalChunkManager) Exist(ctx context.Context, filePath string) (bool,
 	return true, nil
 }
 
-// Read reads the local storage data if exists.
+// Read reads the local storage data, an error wrapping ErrNoSuchKey is returned if it doesn't exist.
 func (lcm *LocalChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
-	exist, err := lcm.Exist(ctx, filePath)
-	if err != nil {
+	if _, err := lcm.Stat(ctx, filePath); err != nil {
 		return nil, err
 	}
-	if !exist {
-		return nil, fmt.Errorf("file not exist: %s", filePath)
-	}
 	absPath := path.Join(lcm.localPath, filePath)
 	return ioutil.ReadFile(absPath)
 }
 
 // MultiRead reads the local storage data if exists.
 func (lcm *LocalChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
-	results := make([][]byte, len(filePaths))
-	var el errorutil.ErrorList
-	for i, filePath := range filePaths {
-		content, err := lcm.Read(ctx, filePath)
-		if err != nil {
-			el = append(el, err)
-		}
-		results[i] = content
-	}
-	if len(el) == 0 {
-		return results, nil
-	}
-	return results, el
+	return parallelMultiRead(ctx, filePaths, lcm.concurrency, lcm.Read)
 }
 
 func (lcm *LocalChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
 	var filePaths []string
 	var modTimes []time.Time
+	err := lcm.WalkWithPrefix(ctx, prefix, recursive, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePaths = append(filePaths, chunkObjectInfo.FilePath)
+		modTimes = append(modTimes, chunkObjectInfo.ModifyTime)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, modTimes, nil
+}
+
+func (lcm *LocalChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
 	if recursive {
 		absPrefix := path.Join(lcm.localPath, prefix)
+		// keep the tailing "/" dropped by path.Join, so that only the files in the dir of the prefix are walked
+		if strings.HasSuffix(prefix, "/") {
+			absPrefix += "/"
+		}
 		dir := filepath.Dir(absPrefix)
 		err := filepath.Walk(dir, func(filePath string, f os.FileInfo, err error) error {
-			if strings.HasPrefix(filePath, absPrefix) && !f.IsDir() {
-				filePaths = append(filePaths, strings.TrimPrefix(filePath, lcm.localPath))
+			if !strings.HasPrefix(filePath, absPrefix) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			relPath := strings.TrimPrefix(filePath, lcm.localPath)
+			// the journal and the staged files are never walked, nor removed by RemoveWithPrefix
+			if isLocalJournalPath(relPath) {
+				if f.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if f.IsDir() {
+				return nil
+			}
+			if !walkFunc(ChunkObjectInfo{FilePath: relPath, ModifyTime: f.ModTime(), Size: f.Size()}) {
+				return errStopWalk
 			}
 			return nil
 		})
-		if err != nil {
-			return nil, nil, err
-		}
-		for _, filePath := range filePaths {
-			modTime, err2 := lcm.getModTime(filePath)
-			if err2 != nil {
-				return filePaths, nil, err2
-			}
-			modTimes = append(modTimes, modTime)
+		if err != nil && err != errStopWalk {
+			return err
 		}
-		return filePaths, modTimes, nil
+		return nil
 	}
-	absPrefix := path.Join(lcm.localPath, prefix+"*")
-	absPaths, err := filepath.Glob(absPrefix)
-	if err != nil {
-		return nil, nil, err
+
+	// list the dir of the prefix like the listing of S3 with the "/" delimiter, the sub dirs are the common prefixes
+	dir, namePrefix := path.Join(lcm.localPath, prefix), ""
+	if !strings.HasSuffix(prefix, "/") {
+		dir, namePrefix = path.Split(dir)
 	}
-	for _, absPath := range absPaths {
-		filePaths = append(filePaths, strings.TrimPrefix(absPath, lcm.localPath))
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
 	}
-	for _, filePath := range filePaths {
-		modTime, err2 := lcm.getModTime(filePath)
-		if err2 != nil {
-			return filePaths, nil, err2
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), namePrefix) {
+			continue
+		}
+		info := ChunkObjectInfo{FilePath: strings.TrimPrefix(path.Join(dir, entry.Name()), lcm.localPath), ModifyTime: entry.ModTime()}
+		if isLocalJournalPath(info.FilePath) {
+			continue
+		}
+		if entry.IsDir() {
+			info.FilePath += "/"
+		} else {
+			info.Size = entry.Size()
+		}
+		if !walkFunc(info) {
+			return nil
 		}
-		modTimes = append(modTimes, modTime)
 	}
-	return filePaths, modTimes, nil
+	return nil
+}
+
+// PrefixSize lists the dir of @prefix, and walks the sub dirs in parallel with at most concurrency goroutines.
+func (lcm *LocalChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	return lcm.prefixSizes.get(prefix, func() (int64, int64, error) {
+		return parallelPrefixSize(ctx, prefix, lcm.concurrency, lcm.WalkWithPrefix)
+	})
 }
 
 func (lcm *LocalChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
@@ -228,6 +468,16 @@ func (lcm *LocalChunkManager) ReadAt(ctx context.Context, filePath string, off i
 	return res, nil
 }
 
+// MultiReadAt reads the @ranges of @filePath, the adjacent or overlapping ranges are coalesced into one read.
+func (lcm *LocalChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	return parallelMultiReadAt(ctx, filePath, ranges, lcm.concurrency, lcm.ReadAt)
+}
+
+// PresignURL is not supported by local storage.
+func (lcm *LocalChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	return "", errors.New("local storage doesn't support presigned url")
+}
+
 func (lcm *LocalChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
 	absPath := path.Join(lcm.localPath, filePath)
 	return mmap.Open(path.Clean(absPath))
@@ -244,11 +494,38 @@ func (lcm *LocalChunkManager) Size(ctx context.Context, filePath string) (int64,
 	return size, nil
 }
 
+// Stat returns the size, modify time and ETag of the local file, the ETag is derived from
+// the modify time and size since local file system keeps no content hash.
+func (lcm *LocalChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	absPath := path.Join(lcm.localPath, filePath)
+	fi, err := os.Stat(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ObjectInfo{}, WrapErrNoSuchKey(filePath)
+		}
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		FilePath:   filePath,
+		Size:       fi.Size(),
+		ModifyTime: fi.ModTime(),
+		ETag:       fmt.Sprintf("%x-%x", fi.ModTime().UnixNano(), fi.Size()),
+	}, nil
+}
+
+// MultiStat stats the local files with at most concurrency goroutines.
+func (lcm *LocalChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	return parallelMultiStat(ctx, filePaths, lcm.concurrency, lcm.Stat)
+}
+
 func (lcm *LocalChunkManager) Remove(ctx context.Context, filePath string) error {
 	exist, err := lcm.Exist(ctx, filePath)
 	if err != nil {
 		return err
 	}
+	if exist && lcm.journal != nil {
+		return lcm.journal.remove(filePath)
+	}
 	if exist {
 		absPath := path.Join(lcm.localPath, filePath)
 		err := os.RemoveAll(absPath)
@@ -280,14 +557,3 @@ func (lcm *LocalChunkManager) RemoveWithPrefix(ctx context.Context, prefix strin
 	}
 	return lcm.MultiRemove(ctx, filePaths)
 }
-
-func (lcm *LocalChunkManager) getModTime(filepath string) (time.Time, error) {
-	absPath := path.Join(lcm.localPath, filepath)
-	fi, err := os.Stat(absPath)
-	if err != nil {
-		log.Error("stat fileinfo error", zap.String("relative filepath", filepath))
-		return time.Time{}, err
-	}
-
-	return fi.ModTime(), nil
-}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -166,6 +167,108 @@ func TestLocalCM(t *testing.T) {
 
 	})
 
+	t.Run("test write with fsync", func(t *testing.T) {
+		testWriteRoot := "test_write_fsync"
+
+		testCM := NewLocalChunkManager(RootPath(localPath), WithFsync(true))
+		defer testCM.RemoveWithPrefix(ctx, testWriteRoot)
+
+		err := testCM.Write(ctx, path.Join(testWriteRoot, "key_1"), []byte("111"))
+		assert.NoError(t, err)
+		// overwrite with shorter content
+		err = testCM.Write(ctx, path.Join(testWriteRoot, "key_1"), []byte("1"))
+		assert.NoError(t, err)
+		err = testCM.MultiWrite(ctx, map[string][]byte{path.Join(testWriteRoot, "key_2"): []byte("222")})
+		assert.NoError(t, err)
+
+		val, err := testCM.Read(ctx, path.Join(testWriteRoot, "key_1"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("1"), val)
+
+		val, err = testCM.Read(ctx, path.Join(testWriteRoot, "key_2"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("222"), val)
+
+		err = testCM.Write(ctx, path.Join(testWriteRoot, "key_1/key_1"), []byte("111"))
+		assert.Error(t, err)
+	})
+
+	t.Run("test Append", func(t *testing.T) {
+		testAppendRoot := "test_append"
+
+		testCM := NewLocalChunkManager(RootPath(localPath), WithFsync(true))
+		defer testCM.RemoveWithPrefix(ctx, testAppendRoot)
+
+		key := path.Join(testAppendRoot, "sub", "key")
+		err := testCM.Append(ctx, key, []byte("111"))
+		assert.NoError(t, err)
+		err = testCM.Append(ctx, key, []byte("222"))
+		assert.NoError(t, err)
+
+		val, err := testCM.Read(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111222"), val)
+
+		err = testCM.Append(ctx, path.Join(testAppendRoot, "sub"), []byte("111"))
+		assert.Error(t, err)
+	})
+
+	t.Run("test WriteIfNotExist", func(t *testing.T) {
+		testRoot := "test_write_if_not_exist"
+
+		testCM := NewLocalChunkManager(RootPath(localPath), WithFsync(true))
+		defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+		key := path.Join(testRoot, "sub", "key")
+		err := testCM.WriteIfNotExist(ctx, key, []byte("111"))
+		assert.NoError(t, err)
+		err = testCM.WriteIfNotExist(ctx, key, []byte("222"))
+		assert.ErrorIs(t, err, ErrObjectExists)
+
+		val, err := testCM.Read(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+	})
+
+	t.Run("test Copy and Move", func(t *testing.T) {
+		testRoot := "test_copy_move"
+
+		testCM := NewLocalChunkManager(RootPath(localPath), WithFsync(true))
+		defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+		src := path.Join(testRoot, "src")
+		err := testCM.Write(ctx, src, []byte("111"))
+		require.NoError(t, err)
+
+		copied := path.Join(testRoot, "sub", "copied")
+		err = testCM.Copy(ctx, src, copied)
+		assert.NoError(t, err)
+		val, err := testCM.Read(ctx, copied)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+
+		err = testCM.Copy(ctx, src, src)
+		assert.NoError(t, err)
+		val, err = testCM.Read(ctx, src)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+
+		moved := path.Join(testRoot, "sub2", "moved")
+		err = testCM.Move(ctx, src, moved)
+		assert.NoError(t, err)
+		val, err = testCM.Read(ctx, moved)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+		exist, err := testCM.Exist(ctx, src)
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		err = testCM.Copy(ctx, src, copied)
+		assert.Error(t, err)
+		err = testCM.Move(ctx, src, moved)
+		assert.Error(t, err)
+	})
+
 	t.Run("test MultiSave", func(t *testing.T) {
 		testMultiSaveRoot := "test_multisave"
 
@@ -356,6 +459,49 @@ func TestLocalCM(t *testing.T) {
 		assert.Equal(t, int64(0), size)
 	})
 
+	t.Run("test Stat", func(t *testing.T) {
+		testStatRoot := "stat"
+
+		testCM := NewLocalChunkManager(RootPath(localPath))
+		defer testCM.RemoveWithPrefix(ctx, testStatRoot)
+
+		key := path.Join(testStatRoot, "key")
+		value := []byte("value")
+		err := testCM.Write(ctx, key, value)
+		assert.NoError(t, err)
+
+		info, err := testCM.Stat(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, key, info.FilePath)
+		assert.Equal(t, int64(len(value)), info.Size)
+		assert.False(t, info.ModifyTime.IsZero())
+		assert.NotEmpty(t, info.ETag)
+
+		_, err = testCM.Stat(ctx, path.Join(testStatRoot, "not_exist"))
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+	})
+
+	t.Run("test MultiStat", func(t *testing.T) {
+		testMultiStatRoot := "multi_stat"
+
+		testCM := NewLocalChunkManager(RootPath(localPath))
+		defer testCM.RemoveWithPrefix(ctx, testMultiStatRoot)
+
+		key1 := path.Join(testMultiStatRoot, "key1")
+		key2 := path.Join(testMultiStatRoot, "key2")
+		err := testCM.MultiWrite(ctx, map[string][]byte{key1: []byte("a"), key2: []byte("bb")})
+		assert.NoError(t, err)
+
+		infos, err := testCM.MultiStat(ctx, []string{key2, path.Join(testMultiStatRoot, "not_exist"), key1})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(infos))
+		assert.Equal(t, key2, infos[0].FilePath)
+		assert.Equal(t, int64(2), infos[0].Size)
+		assert.Nil(t, infos[1])
+		assert.Equal(t, key1, infos[2].FilePath)
+		assert.Equal(t, int64(1), infos[2].Size)
+	})
+
 	t.Run("test Path", func(t *testing.T) {
 		testGetSizeRoot := "get_path"
 
@@ -439,6 +585,11 @@ func TestLocalCM(t *testing.T) {
 		fmt.Println(dirs)
 		assert.Equal(t, 3, len(dirs))
 		assert.Equal(t, 3, len(mods))
+		// the sub dirs are the common prefixes like the object storages
+		assert.True(t, IsCommonPrefix(dirs[0]))
+		assert.True(t, strings.HasSuffix(dirs[0], path.Join(testPrefix, "abc")+"/"))
+		assert.False(t, IsCommonPrefix(dirs[1]))
+		assert.False(t, IsCommonPrefix(dirs[2]))
 
 		testPrefix2 := path.Join(testPrefix, "a")
 		dirs, mods, err = testCM.ListWithPrefix(ctx, testPrefix2, false)
@@ -461,4 +612,57 @@ func TestLocalCM(t *testing.T) {
 		assert.Equal(t, 1, len(dirs))
 		assert.Equal(t, 1, len(mods))
 	})
+
+	t.Run("test WalkWithPrefix", func(t *testing.T) {
+		testPrefix := "prefix-WalkWithPrefix"
+
+		testCM := NewLocalChunkManager(RootPath(localPath))
+		defer testCM.RemoveWithPrefix(ctx, testPrefix)
+
+		for _, key := range []string{"abc/def", "abc/deg", "abd", "bcd"} {
+			err := testCM.Write(ctx, path.Join(testPrefix, key), []byte("a"))
+			assert.NoError(t, err)
+		}
+
+		var keys []string
+		err := testCM.WalkWithPrefix(ctx, testPrefix, true, func(chunkInfo ChunkObjectInfo) bool {
+			assert.False(t, chunkInfo.ModifyTime.IsZero())
+			keys = append(keys, chunkInfo.FilePath)
+			return true
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			path.Join(testPrefix, "abc/def"),
+			path.Join(testPrefix, "abc/deg"),
+			path.Join(testPrefix, "abd"),
+			path.Join(testPrefix, "bcd"),
+		}, keys)
+
+		keys = nil
+		err = testCM.WalkWithPrefix(ctx, path.Join(testPrefix, "a"), false, func(chunkInfo ChunkObjectInfo) bool {
+			keys = append(keys, chunkInfo.FilePath)
+			return true
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(keys))
+
+		// stop walking when walk func returns false
+		for _, recursive := range []bool{true, false} {
+			count := 0
+			err = testCM.WalkWithPrefix(ctx, testPrefix+"/", recursive, func(chunkInfo ChunkObjectInfo) bool {
+				count++
+				return false
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
+		}
+
+		count := 0
+		err = testCM.WalkWithPrefix(ctx, path.Join(testPrefix, "not_exist"), true, func(chunkInfo ChunkObjectInfo) bool {
+			count++
+			return true
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
 }
@@ -0,0 +1,445 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+const (
+	// localJournalDir is the dir under the root path of LocalChunkManager keeping the journal and the staged files.
+	localJournalDir = ".journal"
+	// localJournalFile is the name of the journal in localJournalDir.
+	localJournalFile = "journal.log"
+	// localJournalCheckpointSize is the size above which the journal is truncated once no operation is in flight.
+	localJournalCheckpointSize = 4 << 20
+)
+
+type journalOp string
+
+const (
+	// journalOpWrite renames the staged file to the path, it's rolled forward if the staged file is left.
+	journalOpWrite journalOp = "write"
+	// journalOpWriteIfNotExist links the staged file to the path, it's rolled forward if the path doesn't exist.
+	journalOpWriteIfNotExist journalOp = "writeIfNotExist"
+	// journalOpAppend appends to the path, it's rolled back by truncating the file to its original size.
+	journalOpAppend journalOp = "append"
+	// journalOpMove renames the path to the destination, it's rolled forward if the path is left.
+	journalOpMove journalOp = "move"
+	// journalOpRemove removes the path, it's rolled forward.
+	journalOpRemove journalOp = "remove"
+	// journalOpCommit and journalOpAbort end the operation of the same seq.
+	journalOpCommit journalOp = "commit"
+	journalOpAbort  journalOp = "abort"
+)
+
+// journalRecord is a line of the journal, the paths are relative to the root path.
+type journalRecord struct {
+	Seq    uint64    `json:"seq"`
+	Op     journalOp `json:"op"`
+	Path   string    `json:"path,omitempty"`
+	Dst    string    `json:"dst,omitempty"`
+	Staged string    `json:"staged,omitempty"`
+	// Size is the original size of the appended file, -1 means it didn't exist
+	Size int64 `json:"size,omitempty"`
+}
+
+// localJournal is the write-ahead journal of LocalChunkManager. Every mutation records its intent and syncs it
+// before touching the files, and records its end once the files are synced, so the operations in flight on a crash
+// are replayed when the journal is opened again: the new contents are staged and synced before they are recorded,
+// so the writes, moves and removes are rolled forward, and the appends, which modify the files in place,
+// are rolled back. The callers are told the success only after the files and the end records are synced,
+// so the store never loses or tears what the callers have been told.
+type localJournal struct {
+	rootPath string
+	dir      string
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	seq      uint64
+	inflight int
+}
+
+// openLocalJournal replays the operations left in the journal under @rootPath, and opens it for the new operations.
+func openLocalJournal(rootPath string) (*localJournal, error) {
+	j := &localJournal{
+		rootPath: rootPath,
+		dir:      path.Join(rootPath, localJournalDir),
+	}
+	if err := os.MkdirAll(j.dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	if err := j.replay(); err != nil {
+		return nil, fmt.Errorf("failed to replay the journal of local storage %s: %w", rootPath, err)
+	}
+	// the staged files of the operations replayed or never recorded are useless now
+	entries, err := ioutil.ReadDir(j.dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Name() != localJournalFile {
+			if err := os.RemoveAll(path.Join(j.dir, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	j.file, err = os.OpenFile(path.Join(j.dir, localJournalFile), os.O_WRONLY|os.O_CREATE|os.O_TRUNC|os.O_APPEND, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+	if err := j.file.Sync(); err != nil {
+		j.file.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// replay replays the operations without end records in the order they began.
+func (j *localJournal) replay() error {
+	f, err := os.Open(path.Join(j.dir, localJournalFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var pending []journalRecord
+	ended := make(map[uint64]bool)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var record journalRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// only the last record could be torn by a crash, and its operation never began
+			log.Warn("ignore the torn record of local storage journal", zap.String("path", j.rootPath), zap.Error(err))
+			break
+		}
+		if record.Op == journalOpCommit || record.Op == journalOpAbort {
+			ended[record.Seq] = true
+			continue
+		}
+		pending = append(pending, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for _, record := range pending {
+		if ended[record.Seq] {
+			continue
+		}
+		log.Info("replay the operation of local storage journal", zap.String("path", j.rootPath),
+			zap.Uint64("seq", record.Seq), zap.String("op", string(record.Op)), zap.String("filePath", record.Path))
+		if err := j.replayRecord(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *localJournal) replayRecord(record journalRecord) error {
+	absPath := path.Join(j.rootPath, record.Path)
+	switch record.Op {
+	case journalOpWrite, journalOpWriteIfNotExist:
+		staged := path.Join(j.dir, record.Staged)
+		if _, err := os.Stat(staged); os.IsNotExist(err) {
+			return nil
+		}
+		if record.Op == journalOpWriteIfNotExist {
+			if _, err := os.Stat(absPath); err == nil {
+				return nil
+			}
+		}
+		if err := os.MkdirAll(path.Dir(absPath), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.Rename(staged, absPath); err != nil {
+			return err
+		}
+		return syncDir(path.Dir(absPath))
+	case journalOpAppend:
+		if record.Size < 0 {
+			err := os.Remove(absPath)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		}
+		err := os.Truncate(absPath, record.Size)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	case journalOpMove:
+		if _, err := os.Stat(absPath); os.IsNotExist(err) {
+			return nil
+		}
+		dstPath := path.Join(j.rootPath, record.Dst)
+		if err := os.MkdirAll(path.Dir(dstPath), os.ModePerm); err != nil {
+			return err
+		}
+		return renameFile(absPath, dstPath)
+	case journalOpRemove:
+		if err := os.RemoveAll(absPath); err != nil {
+			return err
+		}
+		return syncDir(path.Dir(absPath))
+	default:
+		return fmt.Errorf("unknown operation %s of seq %d", record.Op, record.Seq)
+	}
+}
+
+// isLocalJournalPath returns whether @filePath relative to the root path is localJournalDir or under it.
+func isLocalJournalPath(filePath string) bool {
+	filePath = strings.Trim(filePath, "/")
+	return filePath == localJournalDir || strings.HasPrefix(filePath, localJournalDir+"/")
+}
+
+// renameFile renames @srcPath to @dstPath and syncs their dirs, the file is copied and removed instead
+// if the two paths are on different devices.
+func renameFile(srcPath string, dstPath string) error {
+	err := os.Rename(srcPath, dstPath)
+	if errors.Is(err, syscall.EXDEV) {
+		content, err := ioutil.ReadFile(srcPath)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(dstPath, os.O_TRUNC, content, true); err != nil {
+			return err
+		}
+		if err := os.Remove(srcPath); err != nil {
+			return err
+		}
+		return syncDir(path.Dir(srcPath))
+	}
+	if err != nil {
+		return err
+	}
+	if err := syncDir(path.Dir(dstPath)); err != nil {
+		return err
+	}
+	return syncDir(path.Dir(srcPath))
+}
+
+// stage writes a new staged file with @write and syncs it, it returns the seq of the operation and the staged file name.
+func (j *localJournal) stage(write func(w io.Writer) error) (uint64, string, error) {
+	seq := j.nextSeq()
+	staged := fmt.Sprintf("%d.staged", seq)
+	stagedPath := path.Join(j.dir, staged)
+	f, err := os.OpenFile(stagedPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, os.ModePerm)
+	if err != nil {
+		return 0, "", err
+	}
+	err = write(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = syncDir(j.dir)
+	}
+	if err != nil {
+		os.Remove(stagedPath)
+		return 0, "", err
+	}
+	return seq, staged, nil
+}
+
+func (j *localJournal) nextSeq() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.seq++
+	return j.seq
+}
+
+// append writes @record to the journal and syncs it, the caller must hold the lock.
+func (j *localJournal) append(record journalRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	n, err := j.file.Write(append(line, '\n'))
+	j.size += int64(n)
+	if err != nil {
+		return err
+	}
+	return j.file.Sync()
+}
+
+// begin records the intent of an operation, @record.Seq is assigned if it's not staged.
+func (j *localJournal) begin(record *journalRecord) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if record.Seq == 0 {
+		j.seq++
+		record.Seq = j.seq
+	}
+	if err := j.append(*record); err != nil {
+		return err
+	}
+	j.inflight++
+	return nil
+}
+
+// end records the end of the operation @seq, @rollback is called to undo what's done if @opErr is not nil.
+// The journal is truncated once it's large and no operation is in flight.
+func (j *localJournal) end(seq uint64, opErr error, rollback func()) error {
+	op := journalOpCommit
+	if opErr != nil {
+		op = journalOpAbort
+		if rollback != nil {
+			rollback()
+		}
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.inflight--
+	if err := j.append(journalRecord{Seq: seq, Op: op}); err != nil {
+		if opErr != nil {
+			return opErr
+		}
+		return err
+	}
+	if j.inflight == 0 && j.size > localJournalCheckpointSize {
+		if err := j.file.Truncate(0); err != nil {
+			log.Warn("failed to truncate local storage journal", zap.String("path", j.rootPath), zap.Error(err))
+		} else {
+			j.size = 0
+		}
+	}
+	return opErr
+}
+
+// write stages @content and renames it to @filePath.
+func (j *localJournal) write(filePath string, write func(w io.Writer) error) error {
+	seq, staged, err := j.stage(write)
+	if err != nil {
+		return err
+	}
+	stagedPath := path.Join(j.dir, staged)
+	if err := j.begin(&journalRecord{Seq: seq, Op: journalOpWrite, Path: filePath, Staged: staged}); err != nil {
+		os.Remove(stagedPath)
+		return err
+	}
+	absPath := path.Join(j.rootPath, filePath)
+	err = os.MkdirAll(path.Dir(absPath), os.ModePerm)
+	if err == nil {
+		err = renameFile(stagedPath, absPath)
+	}
+	return j.end(seq, err, func() { os.Remove(stagedPath) })
+}
+
+// writeIfNotExist stages @content and links it to @filePath, an error wrapping ErrObjectExists is returned if it exists.
+func (j *localJournal) writeIfNotExist(filePath string, content []byte) error {
+	seq, staged, err := j.stage(func(w io.Writer) error {
+		_, err := w.Write(content)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	stagedPath := path.Join(j.dir, staged)
+	if err := j.begin(&journalRecord{Seq: seq, Op: journalOpWriteIfNotExist, Path: filePath, Staged: staged}); err != nil {
+		os.Remove(stagedPath)
+		return err
+	}
+	absPath := path.Join(j.rootPath, filePath)
+	err = os.MkdirAll(path.Dir(absPath), os.ModePerm)
+	if err == nil {
+		err = os.Link(stagedPath, absPath)
+		if os.IsExist(err) {
+			err = WrapErrObjectExists(filePath)
+		}
+	}
+	if err == nil {
+		err = syncDir(path.Dir(absPath))
+	}
+	// the linked file is kept by the path
+	os.Remove(stagedPath)
+	return j.end(seq, err, nil)
+}
+
+// appendFile appends @content to @filePath, which is truncated to its original size if the append fails.
+func (j *localJournal) appendFile(filePath string, content []byte) error {
+	absPath := path.Join(j.rootPath, filePath)
+	size := int64(-1)
+	if info, err := os.Stat(absPath); err == nil {
+		size = info.Size()
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	record := &journalRecord{Op: journalOpAppend, Path: filePath, Size: size}
+	if err := j.begin(record); err != nil {
+		return err
+	}
+	err := os.MkdirAll(path.Dir(absPath), os.ModePerm)
+	if err == nil {
+		err = appendFile(absPath, content, true)
+	}
+	return j.end(record.Seq, err, func() {
+		if rollbackErr := j.replayRecord(*record); rollbackErr != nil {
+			log.Warn("failed to roll back the append of local storage", zap.String("filePath", filePath), zap.Error(rollbackErr))
+		}
+	})
+}
+
+// move renames @srcFilePath to @dstFilePath.
+func (j *localJournal) move(srcFilePath string, dstFilePath string) error {
+	record := &journalRecord{Op: journalOpMove, Path: srcFilePath, Dst: dstFilePath}
+	if err := j.begin(record); err != nil {
+		return err
+	}
+	dstPath := path.Join(j.rootPath, dstFilePath)
+	err := os.MkdirAll(path.Dir(dstPath), os.ModePerm)
+	if err == nil {
+		err = renameFile(path.Join(j.rootPath, srcFilePath), dstPath)
+	}
+	return j.end(record.Seq, err, nil)
+}
+
+// remove removes @filePath.
+func (j *localJournal) remove(filePath string) error {
+	record := &journalRecord{Op: journalOpRemove, Path: filePath}
+	if err := j.begin(record); err != nil {
+		return err
+	}
+	absPath := path.Join(j.rootPath, filePath)
+	err := os.RemoveAll(absPath)
+	if err == nil {
+		err = syncDir(path.Dir(absPath))
+	}
+	return j.end(record.Seq, err, nil)
+}
@@ -0,0 +1,131 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalJournal(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir() + "/"
+	lcm := NewLocalChunkManager(RootPath(rootPath), WithJournal(true))
+
+	require.NoError(t, lcm.Write(ctx, "a/1", []byte("value")))
+	require.NoError(t, lcm.Append(ctx, "a/1", []byte("1")))
+	require.NoError(t, lcm.Append(ctx, "a/2", []byte("2")))
+	assert.ErrorIs(t, lcm.WriteIfNotExist(ctx, "a/1", []byte("other")), ErrObjectExists)
+	require.NoError(t, lcm.WriteIfNotExist(ctx, "a/3", []byte("3")))
+	require.NoError(t, lcm.Copy(ctx, "a/1", "b/1"))
+	require.NoError(t, lcm.Move(ctx, "b/1", "b/2"))
+	require.NoError(t, lcm.Remove(ctx, "a/2"))
+
+	filePaths, _, err := lcm.ListWithPrefix(ctx, "a/", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/1", "a/3"}, filePaths)
+	values, err := lcm.MultiRead(ctx, []string{"a/1", "a/3", "b/2"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value1"), []byte("3"), []byte("value1")}, values)
+	// the staged files are renamed
+	entries, err := ioutil.ReadDir(path.Join(rootPath, localJournalDir))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// all operations are ended, nothing is replayed
+	lcm = NewLocalChunkManager(RootPath(rootPath), WithJournal(true))
+	values, err = lcm.MultiRead(ctx, []string{"a/1", "a/3", "b/2"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value1"), []byte("3"), []byte("value1")}, values)
+
+	// the journal is never listed nor removed with the prefix
+	filePaths, _, err = lcm.ListWithPrefix(ctx, "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/1", "a/3", "b/2"}, filePaths)
+	filePaths, _, err = lcm.ListWithPrefix(ctx, ".j", false)
+	assert.NoError(t, err)
+	assert.Empty(t, filePaths)
+	filePaths, _, err = lcm.ListWithPrefix(ctx, localJournalDir+"/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, filePaths)
+	require.NoError(t, lcm.RemoveWithPrefix(ctx, ""))
+	_, err = os.Stat(path.Join(rootPath, localJournalDir, localJournalFile))
+	assert.NoError(t, err)
+	require.NoError(t, lcm.Write(ctx, "c/1", []byte("1")))
+}
+
+func TestLocalJournal_Replay(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir()
+	lcm := NewLocalChunkManager(RootPath(rootPath), WithJournal(true))
+	require.NoError(t, lcm.Write(ctx, "append", []byte("value")))
+	require.NoError(t, lcm.Write(ctx, "move", []byte("move")))
+	require.NoError(t, lcm.Write(ctx, "remove", []byte("remove")))
+	require.NoError(t, lcm.Write(ctx, "exist", []byte("exist")))
+
+	// the operations crashed after their intents are recorded
+	j := lcm.journal
+	write := func(content string) func(w io.Writer) error {
+		return func(w io.Writer) error {
+			_, err := w.Write([]byte(content))
+			return err
+		}
+	}
+	seq, staged, err := j.stage(write("new"))
+	require.NoError(t, err)
+	require.NoError(t, j.begin(&journalRecord{Seq: seq, Op: journalOpWrite, Path: "dir/write", Staged: staged}))
+	seq, staged, err = j.stage(write("new"))
+	require.NoError(t, err)
+	require.NoError(t, j.begin(&journalRecord{Seq: seq, Op: journalOpWriteIfNotExist, Path: "exist", Staged: staged}))
+	require.NoError(t, j.begin(&journalRecord{Op: journalOpAppend, Path: "append", Size: 5}))
+	require.NoError(t, appendFile(path.Join(rootPath, "append"), []byte("torn"), false))
+	require.NoError(t, j.begin(&journalRecord{Op: journalOpAppend, Path: "created", Size: -1}))
+	require.NoError(t, appendFile(path.Join(rootPath, "created"), []byte("torn"), false))
+	require.NoError(t, j.begin(&journalRecord{Op: journalOpMove, Path: "move", Dst: "moved"}))
+	require.NoError(t, j.begin(&journalRecord{Op: journalOpRemove, Path: "remove"}))
+	// the staged file which is never recorded
+	_, _, err = j.stage(write("lost"))
+	require.NoError(t, err)
+	// the torn record
+	_, err = j.file.Write([]byte("{\"seq\":"))
+	require.NoError(t, err)
+
+	lcm = NewLocalChunkManager(RootPath(rootPath), WithJournal(true))
+	for filePath, expected := range map[string]string{"dir/write": "new", "exist": "exist", "append": "value", "moved": "move"} {
+		value, err := lcm.Read(ctx, filePath)
+		assert.NoError(t, err, filePath)
+		assert.Equal(t, []byte(expected), value, filePath)
+	}
+	for _, filePath := range []string{"created", "move", "remove"} {
+		exist, err := lcm.Exist(ctx, filePath)
+		assert.NoError(t, err)
+		assert.False(t, exist, filePath)
+	}
+	entries, err := ioutil.ReadDir(path.Join(rootPath, localJournalDir))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	info, err := os.Stat(path.Join(rootPath, localJournalDir, localJournalFile))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), info.Size())
+}
@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// localSnapshotDir is the dir under the root path of LocalChunkManager keeping the snapshots.
+const localSnapshotDir = ".snapshots"
+
+// errReflinkNotSupported is returned by reflink if the file system or the platform can't clone files.
+var errReflinkNotSupported = errors.New("reflink is not supported")
+
+// SnapshotPath returns the path of the snapshot @snapshotName relative to the root path,
+// the file @filePath snapshotted is at path.Join(SnapshotPath(snapshotName), filePath).
+func (lcm *LocalChunkManager) SnapshotPath(snapshotName string) string {
+	return path.Join(localSnapshotDir, snapshotName)
+}
+
+// Snapshot makes the snapshot @snapshotName of all files under @prefix with negligible space cost, the files are
+// reflinked on the file systems supporting it (e.g. xfs and btrfs on linux), otherwise hardlinked.
+// The snapshot is built in a temporary dir and renamed, so it either contains all files or doesn't exist.
+//
+// The reflinked files are copy-on-write, but the hardlinked ones share the content with the originals,
+// so the files must not be modified in place (Write to an existing file or Append) while the snapshot is kept,
+// which is the case of the binlogs and the index files.
+func (lcm *LocalChunkManager) Snapshot(ctx context.Context, prefix string, snapshotName string) error {
+	if snapshotName == "" || strings.Contains(snapshotName, "/") || strings.HasPrefix(snapshotName, ".") {
+		return fmt.Errorf("invalid snapshot name %q", snapshotName)
+	}
+	snapshotsPath := path.Join(lcm.localPath, localSnapshotDir)
+	snapshotPath := path.Join(snapshotsPath, snapshotName)
+	if _, err := os.Stat(snapshotPath); err == nil {
+		return fmt.Errorf("snapshot %s already exists", snapshotName)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(snapshotsPath, os.ModePerm); err != nil {
+		return err
+	}
+	tmpPath, err := os.MkdirTemp(snapshotsPath, "."+snapshotName+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpPath)
+
+	useReflink := true
+	err = lcm.WalkWithPrefix(ctx, prefix, true, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePath := strings.TrimPrefix(chunkObjectInfo.FilePath, "/")
+		// the snapshots are never snapshotted, and the journal is never walked
+		if strings.HasPrefix(filePath, localSnapshotDir+"/") {
+			return true
+		}
+		if err = ctx.Err(); err != nil {
+			return false
+		}
+		src, dst := path.Join(lcm.localPath, filePath), path.Join(tmpPath, filePath)
+		if err = os.MkdirAll(path.Dir(dst), os.ModePerm); err != nil {
+			return false
+		}
+		if useReflink {
+			err = reflink(src, dst)
+			if err == nil {
+				return true
+			}
+			if !errors.Is(err, errReflinkNotSupported) {
+				return false
+			}
+			// don't try the other files
+			useReflink = false
+		}
+		err = os.Link(src, dst)
+		return err == nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s: %w", prefix, err)
+	}
+	if lcm.fsync {
+		if err := syncTree(tmpPath); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmpPath, snapshotPath); err != nil {
+		return err
+	}
+	if lcm.fsync {
+		return syncDir(snapshotsPath)
+	}
+	return nil
+}
+
+// RemoveSnapshot removes the snapshot @snapshotName, the snapshotted files are kept.
+func (lcm *LocalChunkManager) RemoveSnapshot(ctx context.Context, snapshotName string) error {
+	if snapshotName == "" || strings.Contains(snapshotName, "/") || strings.HasPrefix(snapshotName, ".") {
+		return fmt.Errorf("invalid snapshot name %q", snapshotName)
+	}
+	return os.RemoveAll(path.Join(lcm.localPath, localSnapshotDir, snapshotName))
+}
+
+// syncTree flushes the entries of all dirs under @root to disk, the content of the linked files is already on disk.
+func syncTree(root string) error {
+	return filepath.Walk(root, func(filePath string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !f.IsDir() {
+			return nil
+		}
+		return syncDir(filePath)
+	})
+}
@@ -0,0 +1,64 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalCM_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	testCM := NewLocalChunkManager(RootPath(t.TempDir()), WithFsync(true))
+
+	contents := map[string][]byte{
+		"insert_log/1/2/3/100/1": []byte("a"),
+		"insert_log/1/2/3/101/2": []byte("bb"),
+		"insert_log/2/2/3/101/3": []byte("ccc"),
+	}
+	require.NoError(t, testCM.MultiWrite(ctx, contents))
+
+	require.NoError(t, testCM.Snapshot(ctx, "insert_log/1/", "backup"))
+	assert.Error(t, testCM.Snapshot(ctx, "insert_log/1/", "backup"))
+	assert.Error(t, testCM.Snapshot(ctx, "insert_log/1/", "../backup"))
+
+	// the snapshot is kept after the files are removed
+	require.NoError(t, testCM.RemoveWithPrefix(ctx, "insert_log/"))
+	filePaths, _, err := testCM.ListWithPrefix(ctx, testCM.SnapshotPath("backup")+"/", true)
+	assert.NoError(t, err)
+	assert.Len(t, filePaths, 2)
+	for _, key := range []string{"insert_log/1/2/3/100/1", "insert_log/1/2/3/101/2"} {
+		content, err := testCM.Read(ctx, path.Join(testCM.SnapshotPath("backup"), key))
+		assert.NoError(t, err)
+		assert.Equal(t, contents[key], content)
+	}
+
+	// the snapshots are not snapshotted
+	require.NoError(t, testCM.Snapshot(ctx, "", "all"))
+	filePaths, _, err = testCM.ListWithPrefix(ctx, testCM.SnapshotPath("all")+"/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, filePaths)
+
+	require.NoError(t, testCM.RemoveSnapshot(ctx, "backup"))
+	exist, err := testCM.Exist(ctx, testCM.SnapshotPath("backup"))
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
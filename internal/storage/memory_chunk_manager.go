@@ -0,0 +1,441 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/mmap"
+)
+
+// ErrMemoryCapacityExceeded means a write to a MemoryChunkManager is rejected since its capacity is exhausted.
+var ErrMemoryCapacityExceeded = errors.New("MemoryCapacityExceeded")
+
+func WrapErrMemoryCapacityExceeded(filePath string) error {
+	return fmt.Errorf("%w(key=%s)", ErrMemoryCapacityExceeded, filePath)
+}
+
+var (
+	memoryStoresMu sync.Mutex
+	// memoryStores are the stores shared by the MemoryChunkManagers created by ChunkManagerFactory, by bucket name
+	memoryStores = make(map[string]*memoryStore)
+)
+
+type memoryObject struct {
+	content    []byte
+	modifyTime time.Time
+	etag       string
+}
+
+// memoryStore keeps the objects of MemoryChunkManagers, the contents are never modified once stored,
+// the writes replace them, so they could be shared by the readers without copying.
+type memoryStore struct {
+	capacity int64
+
+	mu      sync.RWMutex
+	objects map[string]*memoryObject
+	size    int64
+}
+
+func newMemoryStore(capacity int64) *memoryStore {
+	return &memoryStore{
+		capacity: capacity,
+		objects:  make(map[string]*memoryObject),
+	}
+}
+
+// MemoryChunkManager keeps the objects in memory like an object storage, which is for the unit tests and the embedded
+// mode, with no temporary dir to manage. The keys are kept as they are, and listed in lexicographical order like S3.
+// The chunk managers created by ChunkManagerFactory with the storage type "memory" share the objects of the same bucket
+// in the process, the ones created by NewMemoryChunkManager have their own objects.
+type MemoryChunkManager struct {
+	rootPath    string
+	concurrency int
+	store       *memoryStore
+}
+
+var _ ChunkManager = (*MemoryChunkManager)(nil)
+
+// NewMemoryChunkManager creates a MemoryChunkManager with its own objects, the capacity is set by the MemoryCapacity option.
+func NewMemoryChunkManager(opts ...Option) *MemoryChunkManager {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return &MemoryChunkManager{
+		rootPath:    c.rootPath,
+		concurrency: c.concurrency,
+		store:       newMemoryStore(c.memoryCapacity),
+	}
+}
+
+// newSharedMemoryChunkManager creates a MemoryChunkManager sharing the objects of the bucket set by the BucketName option.
+func newSharedMemoryChunkManager(opts ...Option) *MemoryChunkManager {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	memoryStoresMu.Lock()
+	defer memoryStoresMu.Unlock()
+	store, ok := memoryStores[c.bucketName]
+	if !ok {
+		store = newMemoryStore(c.memoryCapacity)
+		memoryStores[c.bucketName] = store
+	}
+	return &MemoryChunkManager{
+		rootPath:    c.rootPath,
+		concurrency: c.concurrency,
+		store:       store,
+	}
+}
+
+func (mcm *MemoryChunkManager) get(filePath string) (*memoryObject, error) {
+	mcm.store.mu.RLock()
+	defer mcm.store.mu.RUnlock()
+	object, ok := mcm.store.objects[filePath]
+	if !ok {
+		return nil, WrapErrNoSuchKey(filePath)
+	}
+	return object, nil
+}
+
+// put stores @content at @filePath, the caller must hold the write lock and must not modify @content any more.
+func (mcm *MemoryChunkManager) put(filePath string, content []byte) error {
+	size := mcm.store.size + int64(len(content))
+	if old, ok := mcm.store.objects[filePath]; ok {
+		size -= int64(len(old.content))
+	}
+	if mcm.store.capacity > 0 && size > mcm.store.capacity {
+		return WrapErrMemoryCapacityExceeded(filePath)
+	}
+	sum := md5.Sum(content)
+	mcm.store.objects[filePath] = &memoryObject{
+		content:    content,
+		modifyTime: time.Now(),
+		etag:       hex.EncodeToString(sum[:]),
+	}
+	mcm.store.size = size
+	return nil
+}
+
+// RootPath returns the root path, which is only reported since the keys are kept as they are.
+func (mcm *MemoryChunkManager) RootPath() string {
+	return mcm.rootPath
+}
+
+// Path returns @filePath if it exists.
+func (mcm *MemoryChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	if _, err := mcm.get(filePath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+func (mcm *MemoryChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	object, err := mcm.get(filePath)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(object.content)), nil
+}
+
+// Stat returns the size, modify time and ETag of the object, the ETag is the md5 of the content like S3.
+func (mcm *MemoryChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	object, err := mcm.get(filePath)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		FilePath:   filePath,
+		Size:       int64(len(object.content)),
+		ModifyTime: object.modifyTime,
+		ETag:       object.etag,
+	}, nil
+}
+
+func (mcm *MemoryChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	infos := make([]*ObjectInfo, len(filePaths))
+	for i, filePath := range filePaths {
+		info, err := mcm.Stat(ctx, filePath)
+		if errors.Is(err, ErrNoSuchKey) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		infos[i] = &info
+	}
+	return infos, nil
+}
+
+// Write copies @content to the object @filePath.
+func (mcm *MemoryChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	content = append([]byte{}, content...)
+	mcm.store.mu.Lock()
+	defer mcm.store.mu.Unlock()
+	return mcm.put(filePath, content)
+}
+
+// WriteWithOptions writes the data to memory, the user metadata and tags are ignored.
+func (mcm *MemoryChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	return mcm.Write(ctx, filePath, content)
+}
+
+func (mcm *MemoryChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	content = append([]byte{}, content...)
+	mcm.store.mu.Lock()
+	defer mcm.store.mu.Unlock()
+	if _, ok := mcm.store.objects[filePath]; ok {
+		return WrapErrObjectExists(filePath)
+	}
+	return mcm.put(filePath, content)
+}
+
+func (mcm *MemoryChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	for filePath, content := range contents {
+		if err := mcm.Write(ctx, filePath, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mcm *MemoryChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	mcm.store.mu.Lock()
+	defer mcm.store.mu.Unlock()
+	var appended []byte
+	if old, ok := mcm.store.objects[filePath]; ok {
+		appended = append(appended, old.content...)
+	}
+	return mcm.put(filePath, append(appended, content...))
+}
+
+func (mcm *MemoryChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	mcm.store.mu.Lock()
+	defer mcm.store.mu.Unlock()
+	src, ok := mcm.store.objects[srcFilePath]
+	if !ok {
+		return WrapErrNoSuchKey(srcFilePath)
+	}
+	return mcm.put(dstFilePath, src.content)
+}
+
+func (mcm *MemoryChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	mcm.store.mu.Lock()
+	defer mcm.store.mu.Unlock()
+	src, ok := mcm.store.objects[srcFilePath]
+	if !ok {
+		return WrapErrNoSuchKey(srcFilePath)
+	}
+	if srcFilePath == dstFilePath {
+		return nil
+	}
+	// the source is removed first, so that a move never exceeds the capacity
+	mcm.remove(srcFilePath)
+	if err := mcm.put(dstFilePath, src.content); err != nil {
+		mcm.store.objects[srcFilePath] = src
+		mcm.store.size += int64(len(src.content))
+		return err
+	}
+	return nil
+}
+
+// PresignURL is not supported by memory storage.
+func (mcm *MemoryChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	return "", errors.New("memory storage doesn't support presigned url")
+}
+
+func (mcm *MemoryChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	_, err := mcm.get(filePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Read returns a copy of the content of @filePath.
+func (mcm *MemoryChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	object, err := mcm.get(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, object.content...), nil
+}
+
+func (mcm *MemoryChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	object, err := mcm.get(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(object.content)), nil
+}
+
+func (mcm *MemoryChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	contents := make([][]byte, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		content, err := mcm.Read(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+func (mcm *MemoryChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	var filePaths []string
+	var modTimes []time.Time
+	err := mcm.WalkWithPrefix(ctx, prefix, recursive, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePaths = append(filePaths, chunkObjectInfo.FilePath)
+		modTimes = append(modTimes, chunkObjectInfo.ModifyTime)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, modTimes, nil
+}
+
+// WalkWithPrefix visits the objects with @prefix in lexicographical order, the objects are collected before
+// @walkFunc is called, so @walkFunc could modify the objects.
+func (mcm *MemoryChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	var infos []ChunkObjectInfo
+	commonPrefixes := make(map[string]struct{})
+	mcm.store.mu.RLock()
+	for filePath, object := range mcm.store.objects {
+		if !strings.HasPrefix(filePath, prefix) {
+			continue
+		}
+		if !recursive {
+			if i := strings.Index(filePath[len(prefix):], "/"); i >= 0 {
+				commonPrefixes[filePath[:len(prefix)+i+1]] = struct{}{}
+				continue
+			}
+		}
+		infos = append(infos, ChunkObjectInfo{FilePath: filePath, ModifyTime: object.modifyTime, Size: int64(len(object.content))})
+	}
+	mcm.store.mu.RUnlock()
+	for commonPrefix := range commonPrefixes {
+		infos = append(infos, ChunkObjectInfo{FilePath: commonPrefix})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].FilePath < infos[j].FilePath
+	})
+
+	for _, info := range infos {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !walkFunc(info) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (mcm *MemoryChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	var size, objects int64
+	mcm.store.mu.RLock()
+	defer mcm.store.mu.RUnlock()
+	for filePath, object := range mcm.store.objects {
+		if strings.HasPrefix(filePath, prefix) {
+			size += int64(len(object.content))
+			objects++
+		}
+	}
+	return size, objects, nil
+}
+
+func (mcm *MemoryChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	filePaths, _, err := mcm.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := mcm.MultiRead(ctx, filePaths)
+	return filePaths, result, err
+}
+
+// Mmap is not supported by memory storage.
+func (mcm *MemoryChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	return nil, errors.New("memory storage doesn't support mmap")
+}
+
+// ReadAt returns a copy of the @length bytes of @filePath from @off, io.EOF is returned if they are out of the object.
+func (mcm *MemoryChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	if off < 0 || length < 0 {
+		return nil, io.EOF
+	}
+	object, err := mcm.get(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if off+length > int64(len(object.content)) {
+		return nil, io.EOF
+	}
+	return append([]byte{}, object.content[off:off+length]...), nil
+}
+
+func (mcm *MemoryChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	return parallelMultiReadAt(ctx, filePath, ranges, mcm.concurrency, mcm.ReadAt)
+}
+
+// remove removes @filePath if it exists, the caller must hold the write lock.
+func (mcm *MemoryChunkManager) remove(filePath string) {
+	if object, ok := mcm.store.objects[filePath]; ok {
+		mcm.store.size -= int64(len(object.content))
+		delete(mcm.store.objects, filePath)
+	}
+}
+
+// Remove removes @filePath, removing an object which doesn't exist is not an error like S3.
+func (mcm *MemoryChunkManager) Remove(ctx context.Context, filePath string) error {
+	mcm.store.mu.Lock()
+	defer mcm.store.mu.Unlock()
+	mcm.remove(filePath)
+	return nil
+}
+
+func (mcm *MemoryChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	mcm.store.mu.Lock()
+	defer mcm.store.mu.Unlock()
+	for _, filePath := range filePaths {
+		mcm.remove(filePath)
+	}
+	return nil
+}
+
+func (mcm *MemoryChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	mcm.store.mu.Lock()
+	defer mcm.store.mu.Unlock()
+	for filePath := range mcm.store.objects {
+		if strings.HasPrefix(filePath, prefix) {
+			mcm.remove(filePath)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryChunkManager(t *testing.T) {
+	ctx := context.Background()
+	mcm := NewMemoryChunkManager(RootPath("files"))
+	assert.Equal(t, "files", mcm.RootPath())
+
+	content := []byte("value")
+	require.NoError(t, mcm.Write(ctx, "files/a/1", content))
+	// the written content is copied
+	content[0] = 'V'
+	require.NoError(t, mcm.MultiWrite(ctx, map[string][]byte{"files/a/2": []byte("22"), "files/b/3": []byte("333")}))
+	require.NoError(t, mcm.Append(ctx, "files/a/2", []byte("22")))
+
+	value, err := mcm.Read(ctx, "files/a/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	values, err := mcm.MultiRead(ctx, []string{"files/a/1", "files/a/2"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value"), []byte("2222")}, values)
+	_, err = mcm.Read(ctx, "files/a/3")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+
+	reader, err := mcm.Reader(ctx, "files/a/1")
+	assert.NoError(t, err)
+	value, err = ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	assert.NoError(t, reader.Close())
+
+	value, err = mcm.ReadAt(ctx, "files/a/1", 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("alu"), value)
+	_, err = mcm.ReadAt(ctx, "files/a/1", 3, 3)
+	assert.ErrorIs(t, err, io.EOF)
+	values, err = mcm.MultiReadAt(ctx, "files/a/1", []Range{{Offset: 0, Length: 1}, {Offset: 4, Length: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("v"), []byte("e")}, values)
+
+	info, err := mcm.Stat(ctx, "files/a/1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), info.Size)
+	assert.NotEmpty(t, info.ETag)
+	infos, err := mcm.MultiStat(ctx, []string{"files/a/2", "files/a/3"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), infos[0].Size)
+	assert.Nil(t, infos[1])
+
+	assert.ErrorIs(t, mcm.WriteIfNotExist(ctx, "files/a/1", []byte("other")), ErrObjectExists)
+	assert.NoError(t, mcm.Copy(ctx, "files/a/1", "files/c/1"))
+	assert.NoError(t, mcm.Move(ctx, "files/c/1", "files/c/2"))
+	assert.ErrorIs(t, mcm.Move(ctx, "files/c/1", "files/c/2"), ErrNoSuchKey)
+
+	filePaths, _, err := mcm.ListWithPrefix(ctx, "files/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"files/a/", "files/b/", "files/c/"}, filePaths)
+	filePaths, _, err = mcm.ListWithPrefix(ctx, "files/", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"files/a/1", "files/a/2", "files/b/3", "files/c/2"}, filePaths)
+	size, objects, err := mcm.PrefixSize(ctx, "files/a/")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), size)
+	assert.Equal(t, int64(2), objects)
+
+	assert.NoError(t, mcm.Remove(ctx, "files/c/2"))
+	assert.NoError(t, mcm.MultiRemove(ctx, []string{"files/b/3", "files/b/4"}))
+	assert.NoError(t, mcm.RemoveWithPrefix(ctx, "files/a/"))
+	filePaths, _, err = mcm.ListWithPrefix(ctx, "files/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, filePaths)
+	assert.Equal(t, int64(0), mcm.store.size)
+}
+
+func TestMemoryChunkManager_Capacity(t *testing.T) {
+	ctx := context.Background()
+	mcm := NewMemoryChunkManager(MemoryCapacity(10))
+
+	require.NoError(t, mcm.Write(ctx, "a", make([]byte, 6)))
+	assert.ErrorIs(t, mcm.Write(ctx, "b", make([]byte, 5)), ErrMemoryCapacityExceeded)
+	assert.ErrorIs(t, mcm.Append(ctx, "a", make([]byte, 5)), ErrMemoryCapacityExceeded)
+	assert.ErrorIs(t, mcm.Copy(ctx, "a", "b"), ErrMemoryCapacityExceeded)
+	// overwriting frees the old content
+	require.NoError(t, mcm.Write(ctx, "a", make([]byte, 10)))
+	require.NoError(t, mcm.Move(ctx, "a", "b"))
+	require.NoError(t, mcm.Remove(ctx, "b"))
+	require.NoError(t, mcm.Write(ctx, "c", make([]byte, 10)))
+}
+
+func TestMemoryChunkManager_Shared(t *testing.T) {
+	ctx := context.Background()
+	f := NewChunkManagerFactory("memory", BucketName("test-memory-shared"), RootPath("files"))
+	cm1, err := f.NewPersistentStorageChunkManager(ctx)
+	require.NoError(t, err)
+	cm2, err := f.NewPersistentStorageChunkManager(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, cm1.Write(ctx, "files/a", []byte("value")))
+	value, err := cm2.Read(ctx, "files/a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// the other buckets and the ones created by NewMemoryChunkManager are not shared
+	exist, err := NewMemoryChunkManager().Exist(ctx, "files/a")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	other, err := NewChunkManagerFactory("memory", BucketName("test-memory-other")).NewPersistentStorageChunkManager(ctx)
+	require.NoError(t, err)
+	exist, err = other.Exist(ctx, "files/a")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
@@ -0,0 +1,379 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/ratelimitutil"
+)
+
+const (
+	// DefaultMigrateWorkers is the default number of objects migrated concurrently.
+	DefaultMigrateWorkers = 8
+	// migrateStateSaveInterval is the number of migrated objects between two saves of the progress state.
+	migrateStateSaveInterval = 100
+)
+
+var (
+	ErrChecksumMismatch = errors.New("ChecksumMismatch")
+	// ErrSkipObject is returned by MigrateTransform to leave the object unmigrated.
+	ErrSkipObject = errors.New("SkipObject")
+
+	castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// MigrateState is the progress of a migration, it's persisted so that an interrupted migration can be resumed.
+type MigrateState struct {
+	SrcPrefix string `json:"srcPrefix"`
+	DstPrefix string `json:"dstPrefix"`
+	// Checkpoint is the last key, in lexicographic order, up to which all objects are migrated.
+	Checkpoint string `json:"checkpoint"`
+	// Objects and Bytes count the objects up to the checkpoint, the objects migrated after it are counted
+	// once the checkpoint advances, so that they're not counted again when migrated again on resume.
+	Objects int64 `json:"objects"`
+	Bytes   int64 `json:"bytes"`
+	// Skipped is the number of objects left unmigrated by the transform, they are included in Objects.
+	Skipped int64 `json:"skipped,omitempty"`
+}
+
+// MigrateTransform converts the content of the object @key before it's written to the target,
+// it returns ErrSkipObject if the object needn't be migrated.
+type MigrateTransform func(ctx context.Context, key string, content []byte) ([]byte, error)
+
+// Migrator copies all objects under a prefix from one ChunkManager to another, e.g. to relocate a cluster
+// to another storage backend.
+type Migrator struct {
+	src       ChunkManager
+	dst       ChunkManager
+	workers   int
+	verify    bool
+	dryRun    bool
+	statePath string
+	transform MigrateTransform
+
+	objectLimiter *ratelimitutil.Limiter
+	byteLimiter   *ratelimitutil.Limiter
+}
+
+// MigratorOption is used to config the Migrator.
+type MigratorOption func(*Migrator)
+
+// WithMigrateWorkers sets the number of objects migrated concurrently.
+func WithMigrateWorkers(workers int) MigratorOption {
+	return func(m *Migrator) {
+		m.workers = workers
+	}
+}
+
+// WithMigrateVerify makes the migrated objects read back and compared with the source by checksum.
+func WithMigrateVerify(verify bool) MigratorOption {
+	return func(m *Migrator) {
+		m.verify = verify
+	}
+}
+
+// WithMigrateDryRun makes the Migrator only count the objects to migrate without writing anything.
+func WithMigrateDryRun(dryRun bool) MigratorOption {
+	return func(m *Migrator) {
+		m.dryRun = dryRun
+	}
+}
+
+// WithMigrateStateFile persists the progress in the local file @statePath, a migration with the same
+// state file continues from where the previous one stopped.
+func WithMigrateStateFile(statePath string) MigratorOption {
+	return func(m *Migrator) {
+		m.statePath = statePath
+	}
+}
+
+// WithMigrateTransform converts the objects by @transform while migrating them, with the same source and target
+// the objects are rewritten in place.
+func WithMigrateTransform(transform MigrateTransform) MigratorOption {
+	return func(m *Migrator) {
+		m.transform = transform
+	}
+}
+
+// WithMigrateRate bounds the number of objects migrated per second, non-positive means unlimited.
+func WithMigrateRate(objectsPerSecond float64) MigratorOption {
+	return func(m *Migrator) {
+		if objectsPerSecond > 0 {
+			m.objectLimiter = ratelimitutil.NewLimiter(ratelimitutil.Limit(objectsPerSecond), 1)
+		}
+	}
+}
+
+// WithMigrateBandwidth bounds the bytes read per second from the source, non-positive means unlimited.
+func WithMigrateBandwidth(bytesPerSecond int64) MigratorOption {
+	return func(m *Migrator) {
+		if bytesPerSecond > 0 {
+			m.byteLimiter = ratelimitutil.NewLimiter(ratelimitutil.Limit(bytesPerSecond), float64(bytesPerSecond))
+		}
+	}
+}
+
+// NewMigrator creates a Migrator copying objects from @src to @dst.
+func NewMigrator(src ChunkManager, dst ChunkManager, opts ...MigratorOption) *Migrator {
+	m := &Migrator{
+		src:     src,
+		dst:     dst,
+		workers: DefaultMigrateWorkers,
+		verify:  true,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.workers <= 0 {
+		m.workers = 1
+	}
+	return m
+}
+
+// Migrate copies all objects under the directory @srcPrefix of the source to the directory @dstPrefix of the target.
+// The keys are sorted before they're migrated, the walk order of a ChunkManager isn't necessarily lexicographic,
+// and the keys up to the checkpoint of the persisted state are skipped.
+func (m *Migrator) Migrate(ctx context.Context, srcPrefix string, dstPrefix string) (*MigrateState, error) {
+	state, err := m.loadState(srcPrefix, dstPrefix)
+	if err != nil {
+		return nil, err
+	}
+	progress := newMigrateProgress(state)
+	checkpoint := state.Checkpoint
+
+	walkPrefix := srcPrefix
+	if walkPrefix != "" && !strings.HasSuffix(walkPrefix, "/") {
+		walkPrefix += "/"
+	}
+	relPrefix := strings.TrimSuffix(strings.TrimPrefix(srcPrefix, "/"), "/")
+
+	type migrateTask struct {
+		seq int64
+		key string
+	}
+	tasks := make(chan migrateTask, m.workers)
+	g, gctx := errgroup.WithContext(ctx)
+	for i := 0; i < m.workers; i++ {
+		g.Go(func() error {
+			for task := range tasks {
+				dstKey := path.Join(dstPrefix, strings.TrimPrefix(strings.TrimPrefix(task.key, "/"), relPrefix))
+				size, err := m.migrateObject(gctx, task.key, dstKey)
+				skipped := errors.Is(err, ErrSkipObject)
+				if err != nil && !skipped {
+					return err
+				}
+				if progress.finish(task.seq, size, skipped)%migrateStateSaveInterval == 0 {
+					if err := m.saveState(progress.snapshot()); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	}
+	g.Go(func() error {
+		defer close(tasks)
+		var keys []string
+		err := m.src.WalkWithPrefix(gctx, walkPrefix, true, func(info ChunkObjectInfo) bool {
+			if checkpoint == "" || info.FilePath > checkpoint {
+				keys = append(keys, info.FilePath)
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		sort.Strings(keys)
+		for seq, key := range keys {
+			progress.dispatch(int64(seq), key)
+			select {
+			case tasks <- migrateTask{seq: int64(seq), key: key}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+
+	err = g.Wait()
+	state = progress.snapshot()
+	if saveErr := m.saveState(state); err == nil {
+		err = saveErr
+	}
+	if err != nil {
+		log.Warn("failed to migrate objects", zap.String("srcPrefix", srcPrefix), zap.String("dstPrefix", dstPrefix),
+			zap.String("checkpoint", state.Checkpoint), zap.Error(err))
+		return state, err
+	}
+	log.Info("migrate objects done", zap.String("srcPrefix", srcPrefix), zap.String("dstPrefix", dstPrefix),
+		zap.Int64("objects", state.Objects), zap.Int64("bytes", state.Bytes), zap.Int64("skipped", state.Skipped),
+		zap.Bool("dryRun", m.dryRun))
+	return state, nil
+}
+
+// migrateObject copies @srcKey to @dstKey and returns the size of the object,
+// ErrSkipObject is returned with the size if the transform skipped it.
+func (m *Migrator) migrateObject(ctx context.Context, srcKey string, dstKey string) (int64, error) {
+	if err := waitLimiter(ctx, m.objectLimiter, 1); err != nil {
+		return 0, err
+	}
+	if m.dryRun {
+		return m.src.Size(ctx, srcKey)
+	}
+	content, err := m.src.Read(ctx, srcKey)
+	if err != nil {
+		return 0, err
+	}
+	size := int64(len(content))
+	if err := waitLimiter(ctx, m.byteLimiter, len(content)); err != nil {
+		return 0, err
+	}
+	if m.transform != nil {
+		content, err = m.transform(ctx, srcKey, content)
+		if err != nil {
+			return size, err
+		}
+	}
+	if err := m.dst.Write(ctx, dstKey, content); err != nil {
+		return 0, err
+	}
+	if m.verify {
+		written, err := m.dst.Read(ctx, dstKey)
+		if err != nil {
+			return 0, err
+		}
+		if crc32.Checksum(written, castagnoliTable) != crc32.Checksum(content, castagnoliTable) {
+			return 0, fmt.Errorf("%w(src=%s, dst=%s)", ErrChecksumMismatch, srcKey, dstKey)
+		}
+	}
+	return size, nil
+}
+
+func (m *Migrator) loadState(srcPrefix string, dstPrefix string) (*MigrateState, error) {
+	state := &MigrateState{SrcPrefix: srcPrefix, DstPrefix: dstPrefix}
+	if m.statePath == "" || m.dryRun {
+		return state, nil
+	}
+	data, err := ioutil.ReadFile(m.statePath)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse migrate state %s: %w", m.statePath, err)
+	}
+	if state.SrcPrefix != srcPrefix || state.DstPrefix != dstPrefix {
+		return nil, fmt.Errorf("migrate state %s belongs to another migration, srcPrefix = %s, dstPrefix = %s",
+			m.statePath, state.SrcPrefix, state.DstPrefix)
+	}
+	return state, nil
+}
+
+// saveState persists the state by writing a temporary file and renaming it, so that the state file is never partial.
+func (m *Migrator) saveState(state *MigrateState) error {
+	if m.statePath == "" || m.dryRun {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmpPath := m.statePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, m.statePath)
+}
+
+// migrateProgress tracks the objects migrated out of order by the workers,
+// the checkpoint only advances past a key once all keys dispatched before it are migrated.
+type migrateProgress struct {
+	mu       sync.Mutex
+	state    *MigrateState
+	keys     map[int64]string
+	done     map[int64]migratedObject
+	next     int64
+	finished int64
+}
+
+// migratedObject is an object migrated but not yet counted in the state.
+type migratedObject struct {
+	size    int64
+	skipped bool
+}
+
+func newMigrateProgress(state *MigrateState) *migrateProgress {
+	return &migrateProgress{
+		state: state,
+		keys:  make(map[int64]string),
+		done:  make(map[int64]migratedObject),
+	}
+}
+
+func (p *migrateProgress) dispatch(seq int64, key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[seq] = key
+}
+
+// finish marks the object @seq migrated and returns the number of objects migrated by this run so far.
+func (p *migrateProgress) finish(seq int64, size int64, skipped bool) int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done[seq] = migratedObject{size: size, skipped: skipped}
+	p.finished++
+	for {
+		object, ok := p.done[p.next]
+		if !ok {
+			break
+		}
+		p.state.Checkpoint = p.keys[p.next]
+		p.state.Objects++
+		p.state.Bytes += object.size
+		if object.skipped {
+			p.state.Skipped++
+		}
+		delete(p.done, p.next)
+		delete(p.keys, p.next)
+		p.next++
+	}
+	return p.finished
+}
+
+func (p *migrateProgress) snapshot() *MigrateState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state := *p.state
+	return &state
+}
@@ -0,0 +1,126 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	src := NewLocalChunkManager(RootPath(t.TempDir()))
+	for i := 0; i < 10; i++ {
+		err := src.Write(ctx, path.Join("files", "insert_log", fmt.Sprint(i)), []byte(fmt.Sprint(i)))
+		require.NoError(t, err)
+	}
+	err := src.Write(ctx, path.Join("other", "0"), []byte("0"))
+	require.NoError(t, err)
+
+	t.Run("dry run", func(t *testing.T) {
+		dst := NewLocalChunkManager(RootPath(t.TempDir()))
+		statePath := filepath.Join(t.TempDir(), "state")
+		state, err := NewMigrator(src, dst, WithMigrateDryRun(true), WithMigrateStateFile(statePath)).Migrate(ctx, "files", "files")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 10, state.Objects)
+		assert.EqualValues(t, 10, state.Bytes)
+
+		keys, _, err := dst.ListWithPrefix(ctx, "", true)
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+		_, err = ioutil.ReadFile(statePath)
+		assert.Error(t, err)
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		dst := NewLocalChunkManager(RootPath(t.TempDir()))
+		statePath := filepath.Join(t.TempDir(), "state")
+		state, err := NewMigrator(src, dst, WithMigrateWorkers(4), WithMigrateStateFile(statePath)).Migrate(ctx, "files", "moved")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 10, state.Objects)
+
+		for i := 0; i < 10; i++ {
+			val, err := dst.Read(ctx, path.Join("moved", "insert_log", fmt.Sprint(i)))
+			assert.NoError(t, err)
+			assert.Equal(t, []byte(fmt.Sprint(i)), val)
+		}
+		exist, err := dst.Exist(ctx, path.Join("moved", "0"))
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		data, err := ioutil.ReadFile(statePath)
+		require.NoError(t, err)
+		saved := &MigrateState{}
+		require.NoError(t, json.Unmarshal(data, saved))
+		assert.Equal(t, state, saved)
+
+		_, err = NewMigrator(src, dst, WithMigrateStateFile(statePath)).Migrate(ctx, "other", "moved")
+		assert.Error(t, err)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		dst := NewLocalChunkManager(RootPath(t.TempDir()))
+		statePath := filepath.Join(t.TempDir(), "state")
+		keys, _, err := src.ListWithPrefix(ctx, "files/", true)
+		require.NoError(t, err)
+		require.Equal(t, 10, len(keys))
+		sort.Strings(keys)
+		data, err := json.Marshal(&MigrateState{SrcPrefix: "files", DstPrefix: "files", Checkpoint: keys[4], Objects: 5, Bytes: 5})
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(statePath, data, 0644))
+
+		state, err := NewMigrator(src, dst, WithMigrateStateFile(statePath)).Migrate(ctx, "files", "files")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 10, state.Objects)
+		assert.Equal(t, keys[9], state.Checkpoint)
+
+		migrated, _, err := dst.ListWithPrefix(ctx, "files/", true)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, keys[5:], migrated)
+	})
+}
+
+func TestMigrateProgress(t *testing.T) {
+	progress := newMigrateProgress(&MigrateState{})
+	for seq, key := range []string{"a", "b", "c"} {
+		progress.dispatch(int64(seq), key)
+	}
+
+	// the objects migrated after an unfinished one aren't counted, they're migrated again on resume
+	assert.EqualValues(t, 1, progress.finish(1, 10, false))
+	assert.EqualValues(t, 2, progress.finish(2, 20, true))
+	state := progress.snapshot()
+	assert.Equal(t, "", state.Checkpoint)
+	assert.EqualValues(t, 0, state.Objects)
+	assert.EqualValues(t, 0, state.Bytes)
+
+	assert.EqualValues(t, 3, progress.finish(0, 1, false))
+	state = progress.snapshot()
+	assert.Equal(t, "c", state.Checkpoint)
+	assert.EqualValues(t, 3, state.Objects)
+	assert.EqualValues(t, 31, state.Bytes)
+	assert.EqualValues(t, 1, state.Skipped)
+}
@@ -23,6 +23,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -31,36 +33,98 @@ import (
 	"github.com/milvus-io/milvus/internal/util/errorutil"
 	"github.com/milvus-io/milvus/internal/util/retry"
 	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 	"go.uber.org/zap"
 	"golang.org/x/exp/mmap"
 )
 
 var (
 	ErrNoSuchKey = errors.New("NoSuchKey")
+	// ErrAppendConflict means the object is modified by others during Append.
+	ErrAppendConflict = errors.New("AppendConflict")
+	// ErrObjectExists means the object written by WriteIfNotExist already exists.
+	ErrObjectExists = errors.New("ObjectExists")
+	// ErrObjectLocked means the object is protected by object lock and can't be removed yet.
+	ErrObjectLocked = errors.New("ObjectLocked")
 )
 
 const (
 	CloudProviderGCP = "gcp"
 	CloudProviderAWS = "aws"
+	// CloudProviderAzure is refused by MinioChunkManager, since Azure Blob Storage has no S3 API,
+	// it's accessed by AzureChunkManager of the storage type "azure" instead.
+	CloudProviderAzure = "azure"
+)
+
+const (
+	// multiStatListThreshold is the min number of paths of MultiStat to stat them by listing
+	multiStatListThreshold = 16
+	// multiStatListFactor bounds the number of objects listed by MultiStat relative to the number of paths
+	multiStatListFactor = 4
 )
 
 func WrapErrNoSuchKey(key string) error {
 	return fmt.Errorf("%w(key=%s)", ErrNoSuchKey, key)
 }
 
+func WrapErrObjectExists(key string) error {
+	return fmt.Errorf("%w(key=%s)", ErrObjectExists, key)
+}
+
+func WrapErrObjectLocked(key string) error {
+	return fmt.Errorf("%w(key=%s)", ErrObjectLocked, key)
+}
+
 var CheckBucketRetryAttempts uint = 20
 
+var (
+	// RemoveBatchSize is the max number of objects deleted by one DeleteObjects request,
+	// S3 accepts at most 1000 keys per request.
+	RemoveBatchSize = 1000
+	// RemoveRetryAttempts is the max attempts to delete the objects failed in a batch.
+	RemoveRetryAttempts uint = 3
+	// AppendRetryAttempts is the max attempts of Append when the object is modified concurrently.
+	AppendRetryAttempts uint = 5
+)
+
 // MinioChunkManager is responsible for read and write data stored in minio.
 type MinioChunkManager struct {
 	*minio.Client
 
 	//	ctx        context.Context
-	bucketName string
-	rootPath   string
+	bucketName  string
+	rootPath    string
+	concurrency int
+
+	listObjectMetadata bool
+	// requesterPays makes the reads pay the requests to a requester pays bucket of another account
+	requesterPays bool
+
+	// object lock applied to the written objects
+	objectLockMode      minio.RetentionMode
+	objectLockRetention time.Duration
+	objectLockLegalHold bool
+
+	// storage classes of the written objects
+	storageClass       string
+	storageClassPolicy StorageClassPolicy
+
+	prefixSizes *prefixSizeCache
+	// readahead downloads the objects hinted by Prefetch ahead of the reads
+	readahead *readahead
+	// selectPushdown makes SelectObject evaluate the expressions with S3 Select
+	selectPushdown bool
+
+	// uploadPartSize and uploadConcurrency are the part size and the parallel parts of the multipart uploads,
+	// zero means the defaults of minio-go
+	uploadPartSize    uint64
+	uploadConcurrency int
+	// downloadConcurrency is the max ranged GETs in parallel to read a large object, values less than
+	// or equal to 1 read the objects sequentially
+	downloadConcurrency int
 }
 
 var _ ChunkManager = (*MinioChunkManager)(nil)
+var _ Prefetcher = (*MinioChunkManager)(nil)
 
 // NewMinioChunkManager create a new local manager object.
 // Deprecated: Do not call this directly! Use factory.NewPersistentStorageChunkManager instead.
@@ -74,25 +138,48 @@ func NewMinioChunkManager(ctx context.Context, opts ...Option) (*MinioChunkManag
 }
 
 func newMinioChunkManagerWithConfig(ctx context.Context, c *config) (*MinioChunkManager, error) {
-	var creds *credentials.Credentials
 	var newMinioFn = minio.New
 
-	switch c.cloudProvider {
-	case CloudProviderGCP:
+	objectLockMode := minio.RetentionMode(strings.ToUpper(c.objectLockMode))
+	if objectLockMode != "" && !objectLockMode.IsValid() {
+		return nil, fmt.Errorf("invalid object lock mode %s", c.objectLockMode)
+	}
+
+	if err := validateTransferConfig(c); err != nil {
+		return nil, err
+	}
+
+	if c.cloudProvider == CloudProviderGCP {
 		newMinioFn = gcp.NewMinioClient
-		if !c.useIAM {
-			creds = credentials.NewStaticV2(c.accessKeyID, c.secretAccessKeyID, "")
-		}
-	default: // aws, minio
 		if c.useIAM {
-			creds = credentials.NewIAM("")
-		} else {
-			creds = credentials.NewStaticV4(c.accessKeyID, c.secretAccessKeyID, "")
+			tokenSrc, err := gcp.NewTokenSource(c.gcpAuthMode, c.gcpCredentialsFile)
+			if err != nil {
+				return nil, err
+			}
+			newMinioFn = func(address string, opts *minio.Options) (*minio.Client, error) {
+				return gcp.NewMinioClientWithTokenSource(address, opts, tokenSrc)
+			}
 		}
 	}
+	creds, err := newMinioCredentials(c)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := newMinioTransport(c)
+	if err != nil {
+		return nil, err
+	}
+	var transport http.RoundTripper = backend
+	if governor := getIOGovernor(c); governor != nil {
+		transport = &governedTransport{backend: backend, governor: governor}
+	}
+	if throttler := getAdaptiveThrottler(c); throttler != nil {
+		transport = &throttledTransport{backend: transport, throttler: throttler}
+	}
 	minioOpts := &minio.Options{
-		Creds:  creds,
-		Secure: c.useSSL,
+		Creds:     creds,
+		Secure:    c.useSSL,
+		Transport: &conditionalTransport{backend: transport},
 	}
 	minIOClient, err := newMinioFn(c.address, minioOpts)
 	// options nil or invalid formatted endpoint, don't need to retry
@@ -110,7 +197,10 @@ func newMinioChunkManagerWithConfig(ctx context.Context, c *config) (*MinioChunk
 		if !bucketExists {
 			if c.createBucket {
 				log.Info("blob bucket not exist, create bucket.", zap.Any("bucket name", c.bucketName))
-				err := minIOClient.MakeBucket(ctx, c.bucketName, minio.MakeBucketOptions{})
+				// object lock could only be enabled when the bucket is created
+				err := minIOClient.MakeBucket(ctx, c.bucketName, minio.MakeBucketOptions{
+					ObjectLocking: objectLockMode != "" || c.objectLockLegalHold,
+				})
 				if err != nil {
 					log.Warn("failed to create blob bucket", zap.String("bucket", c.bucketName), zap.Error(err))
 					return err
@@ -121,16 +211,35 @@ func newMinioChunkManagerWithConfig(ctx context.Context, c *config) (*MinioChunk
 		}
 		return nil
 	}
-	err = retry.Do(ctx, checkBucketFn, retry.Attempts(CheckBucketRetryAttempts))
-	if err != nil {
-		return nil, err
+	// HeadBucket couldn't carry the request payer header, which is required by the requester pays buckets of other accounts
+	if c.requesterPays {
+		log.Info("skip checking the requester pays bucket", zap.String("bucket", c.bucketName))
+	} else {
+		err = retry.Do(ctx, checkBucketFn, retry.Attempts(CheckBucketRetryAttempts))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	mcm := &MinioChunkManager{
-		Client:     minIOClient,
-		bucketName: c.bucketName,
+		Client:              minIOClient,
+		bucketName:          c.bucketName,
+		concurrency:         c.concurrency,
+		listObjectMetadata:  c.listObjectMetadata,
+		requesterPays:       c.requesterPays,
+		objectLockMode:      objectLockMode,
+		objectLockRetention: c.objectLockRetention,
+		objectLockLegalHold: c.objectLockLegalHold,
+		storageClass:        c.storageClass,
+		storageClassPolicy:  c.storageClassPolicy,
+		prefixSizes:         newPrefixSizeCache(c.prefixSizeCacheTTL),
+		selectPushdown:      c.selectPushdown,
+		uploadPartSize:      c.uploadPartSize,
+		uploadConcurrency:   c.uploadConcurrency,
+		downloadConcurrency: c.downloadConcurrency,
 	}
 	mcm.rootPath = mcm.normalizeRootPath(c.rootPath)
+	mcm.readahead = newReadahead(c.prefetchWindow, mcm.read)
 	log.Info("minio chunk manager init success.", zap.String("bucketname", c.bucketName), zap.String("root", mcm.RootPath()))
 	return mcm, nil
 }
@@ -141,6 +250,27 @@ func (mcm *MinioChunkManager) normalizeRootPath(rootPath string) string {
 	return strings.TrimLeft(rootPath, "/")
 }
 
+// requestPayerHeader makes the requester pay the requests to a requester pays bucket.
+const requestPayerHeader = "x-amz-request-payer"
+
+// getObjectOptions returns the options of GetObject and StatObject, with the request payer header if requester pays.
+func (mcm *MinioChunkManager) getObjectOptions() minio.GetObjectOptions {
+	opts := minio.GetObjectOptions{}
+	if mcm.requesterPays {
+		opts.Set(requestPayerHeader, "requester")
+	}
+	return opts
+}
+
+// listObjectsOptions returns the options of ListObjects, with the request payer header if requester pays.
+func (mcm *MinioChunkManager) listObjectsOptions(prefix string, recursive bool) minio.ListObjectsOptions {
+	opts := minio.ListObjectsOptions{Prefix: prefix, Recursive: recursive}
+	if mcm.requesterPays {
+		opts.Set(requestPayerHeader, "requester")
+	}
+	return opts
+}
+
 // SetVar set the variable value of mcm
 func (mcm *MinioChunkManager) SetVar(bucketName string, rootPath string) {
 	mcm.bucketName = bucketName
@@ -154,19 +284,19 @@ func (mcm *MinioChunkManager) RootPath() string {
 
 // Path returns the path of minio data if exists.
 func (mcm *MinioChunkManager) Path(ctx context.Context, filePath string) (string, error) {
-	exist, err := mcm.Exist(ctx, filePath)
+	_, err := mcm.Stat(ctx, filePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return "", errors.New("minio file manage cannot be found with filePath:" + filePath)
+	}
 	if err != nil {
 		return "", err
 	}
-	if !exist {
-		return "", errors.New("minio file manage cannot be found with filePath:" + filePath)
-	}
 	return filePath, nil
 }
 
 // Reader returns the path of minio data if exists.
 func (mcm *MinioChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
-	reader, err := mcm.Client.GetObject(ctx, mcm.bucketName, filePath, minio.GetObjectOptions{})
+	reader, err := mcm.Client.GetObject(ctx, mcm.bucketName, filePath, mcm.getObjectOptions())
 	if err != nil {
 		log.Warn("failed to get object", zap.String("path", filePath), zap.Error(err))
 		return nil, err
@@ -175,18 +305,141 @@ func (mcm *MinioChunkManager) Reader(ctx context.Context, filePath string) (File
 }
 
 func (mcm *MinioChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
-	objectInfo, err := mcm.Client.StatObject(ctx, mcm.bucketName, filePath, minio.StatObjectOptions{})
+	objectInfo, err := mcm.Stat(ctx, filePath)
 	if err != nil {
-		log.Warn("failed to stat object", zap.String("path", filePath), zap.Error(err))
 		return 0, err
 	}
-
 	return objectInfo.Size, nil
 }
 
+// Stat returns the size, modify time and ETag of the object with a single HEAD request.
+func (mcm *MinioChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	objectInfo, err := mcm.Client.StatObject(ctx, mcm.bucketName, filePath, mcm.getObjectOptions())
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return ObjectInfo{}, WrapErrNoSuchKey(filePath)
+		}
+		log.Warn("failed to stat object", zap.String("path", filePath), zap.Error(err))
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		FilePath:   filePath,
+		Size:       objectInfo.Size,
+		ModifyTime: objectInfo.LastModified,
+		ETag:       objectInfo.ETag,
+	}, nil
+}
+
+// MultiStat stats the objects with @filePaths. If there are many paths sharing a common directory,
+// they are stat by listing the directory instead of a HEAD request per path. The listing is bounded by
+// multiStatListFactor times the number of paths, the paths not reached by the listing are stat
+// with parallel HEAD requests.
+func (mcm *MinioChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	results := make([]*ObjectInfo, len(filePaths))
+	pending := make(map[string][]int, len(filePaths))
+	for i, filePath := range filePaths {
+		pending[filePath] = append(pending[filePath], i)
+	}
+
+	if len(pending) >= multiStatListThreshold {
+		if dir := commonDir(filePaths); dir != "" {
+			mcm.listStat(ctx, dir, len(pending)*multiStatListFactor, pending, results)
+		}
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	rest := make([]string, 0, len(pending))
+	for filePath := range pending {
+		rest = append(rest, filePath)
+	}
+	infos, err := parallelMultiStat(ctx, rest, mcm.concurrency, mcm.Stat)
+	if err != nil {
+		return nil, err
+	}
+	for i, filePath := range rest {
+		for _, idx := range pending[filePath] {
+			results[idx] = infos[i]
+		}
+	}
+	return results, nil
+}
+
+// listStat fills @results of the @pending paths found by listing @dir, and removes them from @pending.
+// If the listing completes within @limit objects, the paths left are known missing and removed as well.
+func (mcm *MinioChunkManager) listStat(ctx context.Context, dir string, limit int, pending map[string][]int, results []*ObjectInfo) {
+	// cancel the listing goroutines of minio client when the listing stops early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	last := ""
+	for filePath := range pending {
+		if filePath > last {
+			last = filePath
+		}
+	}
+
+	listed := 0
+	complete := true
+	for object := range mcm.Client.ListObjects(ctx, mcm.bucketName, mcm.listObjectsOptions(dir, true)) {
+		if object.Err != nil {
+			log.Warn("failed to list objects to stat, fall back to stat them one by one", zap.String("prefix", dir), zap.Error(object.Err))
+			complete = false
+			break
+		}
+		// objects are listed in lexicographical order
+		if object.Key > last {
+			break
+		}
+		if indexes, ok := pending[object.Key]; ok {
+			info := &ObjectInfo{
+				FilePath:   object.Key,
+				Size:       object.Size,
+				ModifyTime: object.LastModified,
+				ETag:       object.ETag,
+			}
+			for _, idx := range indexes {
+				results[idx] = info
+			}
+			delete(pending, object.Key)
+			if len(pending) == 0 {
+				break
+			}
+		}
+		listed++
+		if listed >= limit {
+			complete = false
+			break
+		}
+	}
+	if complete {
+		for filePath := range pending {
+			delete(pending, filePath)
+		}
+	}
+}
+
+// commonDir returns the longest directory prefix, with the trailing "/", shared by @filePaths.
+func commonDir(filePaths []string) string {
+	if len(filePaths) == 0 {
+		return ""
+	}
+	prefix := filePaths[0]
+	for _, filePath := range filePaths[1:] {
+		for !strings.HasPrefix(filePath, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix[:strings.LastIndex(prefix, "/")+1]
+}
+
 // Write writes the data to minio storage.
 func (mcm *MinioChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
-	_, err := mcm.Client.PutObject(ctx, mcm.bucketName, filePath, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+	defer mcm.readahead.invalidate(filePath)
+	putOpts := mcm.putObjectOptions()
+	putOpts.StorageClass = mcm.storageClassFor(filePath, nil)
+	_, err := mcm.Client.PutObject(ctx, mcm.bucketName, filePath, bytes.NewReader(content), int64(len(content)), putOpts)
 
 	if err != nil {
 		log.Warn("failed to put object", zap.String("path", filePath), zap.Error(err))
@@ -196,39 +449,226 @@ func (mcm *MinioChunkManager) Write(ctx context.Context, filePath string, conten
 	return nil
 }
 
+// WriteWithOptions writes the data to minio storage with the user metadata and tags in @opts.
+func (mcm *MinioChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	defer mcm.readahead.invalidate(filePath)
+	c := newWriteConfig(opts...)
+	putOpts := mcm.putObjectOptions()
+	putOpts.UserMetadata = c.userMetadata
+	putOpts.UserTags = c.tags
+	putOpts.StorageClass = mcm.storageClassFor(filePath, c)
+	_, err := mcm.Client.PutObject(ctx, mcm.bucketName, filePath, bytes.NewReader(content), int64(len(content)), putOpts)
+	if err != nil {
+		log.Warn("failed to put object with options", zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// WriteIfNotExist writes the data to minio storage with an `If-None-Match: *` precondition,
+// so that the object is created only if it doesn't exist yet.
+func (mcm *MinioChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	defer mcm.readahead.invalidate(filePath)
+	putOpts := mcm.putObjectOptions()
+	putOpts.StorageClass = mcm.storageClassFor(filePath, nil)
+	_, err := mcm.Client.PutObject(withIfNoneMatch(ctx), mcm.bucketName, filePath, bytes.NewReader(content), int64(len(content)), putOpts)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "PreconditionFailed" {
+			return WrapErrObjectExists(filePath)
+		}
+		log.Warn("failed to put object if not exist", zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 // MultiWrite saves multiple objects, the path is the key of @kvs.
 // The object value is the value of @kvs.
 func (mcm *MinioChunkManager) MultiWrite(ctx context.Context, kvs map[string][]byte) error {
-	var el errorutil.ErrorList
-	for key, value := range kvs {
-		err := mcm.Write(ctx, key, value)
+	return parallelMultiWrite(ctx, kvs, mcm.concurrency, mcm.Write)
+}
+
+// Append appends @content to the object at @filePath. Object storage can't append natively,
+// so the object is read, extended and written back. The write is conditional on the ETag of the object read,
+// `If-Match` if it exists and `If-None-Match: *` otherwise, and the append is retried if the object is modified
+// concurrently.
+func (mcm *MinioChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	defer mcm.readahead.invalidate(filePath)
+	err := retry.Do(ctx, func() error {
+		err := mcm.appendOnce(ctx, filePath, content)
+		if err != nil && !errors.Is(err, ErrAppendConflict) {
+			return retry.Unrecoverable(err)
+		}
+		return err
+	}, retry.Attempts(AppendRetryAttempts))
+	if err != nil {
+		log.Warn("failed to append object", zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (mcm *MinioChunkManager) appendOnce(ctx context.Context, filePath string, content []byte) error {
+	etag, size, err := mcm.statETag(ctx, filePath)
+	if err != nil {
+		return err
+	}
+
+	data := content
+	if etag != "" {
+		opts := mcm.getObjectOptions()
+		if err := opts.SetMatchETag(etag); err != nil {
+			return err
+		}
+		object, err := mcm.Client.GetObject(ctx, mcm.bucketName, filePath, opts)
 		if err != nil {
-			el = append(el, err)
+			return err
+		}
+		data, err = Read(object, size+int64(len(content)))
+		object.Close()
+		if err != nil {
+			code := minio.ToErrorResponse(err).Code
+			if code == "PreconditionFailed" || code == "NoSuchKey" {
+				return fmt.Errorf("%w(key=%s)", ErrAppendConflict, filePath)
+			}
+			return err
 		}
+		data = append(data, content...)
 	}
-	if len(el) == 0 {
+
+	conditionalCtx := withIfNoneMatch(ctx)
+	if etag != "" {
+		conditionalCtx = withIfMatch(ctx, etag)
+	}
+	putOpts := mcm.putObjectOptions()
+	putOpts.StorageClass = mcm.storageClassFor(filePath, nil)
+	_, err = mcm.Client.PutObject(conditionalCtx, mcm.bucketName, filePath, bytes.NewReader(data), int64(len(data)), putOpts)
+	if err != nil {
+		code := minio.ToErrorResponse(err).Code
+		if code == "PreconditionFailed" || code == "ConditionalRequestConflict" {
+			return fmt.Errorf("%w(key=%s)", ErrAppendConflict, filePath)
+		}
+		return err
+	}
+	return nil
+}
+
+// statETag returns the ETag and size of the object, the ETag is empty if the object doesn't exist.
+func (mcm *MinioChunkManager) statETag(ctx context.Context, filePath string) (string, int64, error) {
+	info, err := mcm.Stat(ctx, filePath)
+	if err != nil {
+		if errors.Is(err, ErrNoSuchKey) {
+			return "", 0, nil
+		}
+		return "", 0, err
+	}
+	return info.ETag, info.Size, nil
+}
+
+// Copy copies the object at @srcFilePath to @dstFilePath with a server-side copy, objects larger than
+// the single copy limit are copied part by part. If the storage doesn't support server-side copy,
+// the object is streamed through the node instead.
+func (mcm *MinioChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	defer mcm.readahead.invalidate(dstFilePath)
+	var err error
+	if srcFilePath == dstFilePath {
+		_, err = mcm.Client.StatObject(ctx, mcm.bucketName, srcFilePath, mcm.getObjectOptions())
+	} else {
+		_, err = mcm.Client.ComposeObject(ctx,
+			mcm.copyDestOptions(dstFilePath),
+			minio.CopySrcOptions{Bucket: mcm.bucketName, Object: srcFilePath})
+		if err != nil && minio.ToErrorResponse(err).Code == "NotImplemented" {
+			err = mcm.streamCopy(ctx, srcFilePath, dstFilePath)
+		}
+	}
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return WrapErrNoSuchKey(srcFilePath)
+		}
+		log.Warn("failed to copy object", zap.String("src", srcFilePath), zap.String("dst", dstFilePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// streamCopy copies the object by reading it from @srcFilePath and writing it to @dstFilePath.
+func (mcm *MinioChunkManager) streamCopy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	object, err := mcm.Client.GetObject(ctx, mcm.bucketName, srcFilePath, mcm.getObjectOptions())
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+	info, err := object.Stat()
+	if err != nil {
+		return err
+	}
+	putOpts := mcm.putObjectOptions()
+	putOpts.StorageClass = mcm.storageClassFor(dstFilePath, nil)
+	_, err = mcm.Client.PutObject(ctx, mcm.bucketName, dstFilePath, object, info.Size, putOpts)
+	return err
+}
+
+// Move moves the object at @srcFilePath to @dstFilePath. Object storage can't rename objects,
+// so the object is copied and the source object is removed afterwards.
+func (mcm *MinioChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	if err := mcm.Copy(ctx, srcFilePath, dstFilePath); err != nil {
+		return err
+	}
+	if srcFilePath == dstFilePath {
 		return nil
 	}
-	return el
+	return mcm.Remove(ctx, srcFilePath)
+}
+
+// PresignURL returns a presigned URL to access the object at @filePath directly, GET, HEAD and PUT are supported.
+func (mcm *MinioChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	var u *url.URL
+	var err error
+	switch method {
+	case http.MethodGet:
+		u, err = mcm.Client.PresignedGetObject(ctx, mcm.bucketName, filePath, expiry, nil)
+	case http.MethodHead:
+		u, err = mcm.Client.PresignedHeadObject(ctx, mcm.bucketName, filePath, expiry, nil)
+	case http.MethodPut:
+		u, err = mcm.Client.PresignedPutObject(ctx, mcm.bucketName, filePath, expiry)
+	default:
+		return "", fmt.Errorf("unsupported method %s to presign url", method)
+	}
+	if err != nil {
+		log.Warn("failed to presign url", zap.String("path", filePath), zap.String("method", method), zap.Error(err))
+		return "", err
+	}
+	return u.String(), nil
 }
 
 // Exist checks whether chunk is saved to minio storage.
 func (mcm *MinioChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
-	_, err := mcm.Client.StatObject(ctx, mcm.bucketName, filePath, minio.StatObjectOptions{})
+	_, err := mcm.Stat(ctx, filePath)
 	if err != nil {
-		errResponse := minio.ToErrorResponse(err)
-		if errResponse.Code == "NoSuchKey" {
+		if errors.Is(err, ErrNoSuchKey) {
 			return false, nil
 		}
-		log.Warn("failed to stat object", zap.String("path", filePath), zap.Error(err))
 		return false, err
 	}
 	return true, nil
 }
 
-// Read reads the minio storage data if exists.
+// Read reads the minio storage data if exists, the object prefetched is returned without reading it again.
 func (mcm *MinioChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
-	object, err := mcm.Client.GetObject(ctx, mcm.bucketName, filePath, minio.GetObjectOptions{})
+	if content, ok := mcm.readahead.take(ctx, filePath); ok {
+		return content, nil
+	}
+	return mcm.read(ctx, filePath)
+}
+
+// Prefetch downloads at most the prefetch window of @filePaths ahead of the reads, nothing is prefetched
+// if the prefetch window is 0.
+func (mcm *MinioChunkManager) Prefetch(ctx context.Context, filePaths []string) {
+	mcm.readahead.prefetch(ctx, filePaths)
+}
+
+func (mcm *MinioChunkManager) read(ctx context.Context, filePath string) ([]byte, error) {
+	object, err := mcm.Client.GetObject(ctx, mcm.bucketName, filePath, mcm.getObjectOptions())
 	if err != nil {
 		log.Warn("failed to get object", zap.String("path", filePath), zap.Error(err))
 		return nil, err
@@ -245,7 +685,12 @@ func (mcm *MinioChunkManager) Read(ctx context.Context, filePath string) ([]byte
 		return nil, err
 	}
 
-	data, err := Read(object, objectInfo.Size)
+	var data []byte
+	if mcm.downloadConcurrency > 1 && objectInfo.Size > mcm.downloadPartSize() {
+		data, err = mcm.parallelRead(ctx, filePath, object, objectInfo.Size)
+	} else {
+		data, err = Read(object, objectInfo.Size)
+	}
 	if err != nil {
 		errResponse := minio.ToErrorResponse(err)
 		if errResponse.Code == "NoSuchKey" {
@@ -257,21 +702,16 @@ func (mcm *MinioChunkManager) Read(ctx context.Context, filePath string) ([]byte
 	return data, nil
 }
 
+// MultiRead reads the objects with @keys, the results keep the order of @keys.
 func (mcm *MinioChunkManager) MultiRead(ctx context.Context, keys []string) ([][]byte, error) {
-	var el errorutil.ErrorList
-	var objectsValues [][]byte
-	for _, key := range keys {
-		objectValue, err := mcm.Read(ctx, key)
-		if err != nil {
-			el = append(el, err)
-		}
-		objectsValues = append(objectsValues, objectValue)
-	}
+	return parallelMultiRead(ctx, keys, mcm.concurrency, mcm.Read)
+}
 
-	if len(el) == 0 {
-		return objectsValues, nil
-	}
-	return objectsValues, el
+// PrefixSize lists the common prefixes under @prefix, and walks them in parallel with at most concurrency goroutines.
+func (mcm *MinioChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	return mcm.prefixSizes.get(prefix, func() (int64, int64, error) {
+		return parallelPrefixSize(ctx, prefix, mcm.concurrency, mcm.WalkWithPrefix)
+	})
 }
 
 func (mcm *MinioChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
@@ -297,7 +737,7 @@ func (mcm *MinioChunkManager) ReadAt(ctx context.Context, filePath string, off i
 		return nil, io.EOF
 	}
 
-	opts := minio.GetObjectOptions{}
+	opts := mcm.getObjectOptions()
 	err := opts.SetRange(off, off+length-1)
 	if err != nil {
 		log.Warn("failed to set range", zap.String("path", filePath), zap.Error(err))
@@ -323,10 +763,21 @@ func (mcm *MinioChunkManager) ReadAt(ctx context.Context, filePath string, off i
 	return data, nil
 }
 
+// MultiReadAt reads the @ranges of @filePath, the adjacent or overlapping ranges are coalesced into one ranged GET,
+// and the ranged GETs are issued in parallel.
+func (mcm *MinioChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	return parallelMultiReadAt(ctx, filePath, ranges, mcm.concurrency, mcm.ReadAt)
+}
+
 // Remove deletes an object with @key.
 func (mcm *MinioChunkManager) Remove(ctx context.Context, filePath string) error {
+	defer mcm.readahead.invalidate(filePath)
 	err := mcm.Client.RemoveObject(ctx, mcm.bucketName, filePath, minio.RemoveObjectOptions{})
 	if err != nil {
+		if isObjectLockedErr(err) {
+			log.Warn("object is locked, skip removing it", zap.String("path", filePath), zap.Error(err))
+			return WrapErrObjectLocked(filePath)
+		}
 		log.Warn("failed to remove object", zap.String("path", filePath), zap.Error(err))
 		return err
 	}
@@ -349,31 +800,131 @@ func (mcm *MinioChunkManager) MultiRemove(ctx context.Context, keys []string) er
 }
 
 // RemoveWithPrefix removes all objects with the same prefix @prefix from minio.
+// Objects are deleted in batches of at most `RemoveBatchSize` keys while walking the prefix,
+// the keys failed to delete in a batch are retried before moving on.
+// The objects protected by object lock are skipped, and reported by a *LockedObjectsError at the end.
 func (mcm *MinioChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
-	objects := mcm.Client.ListObjects(ctx, mcm.bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true})
+	defer mcm.readahead.invalidate(prefix)
+	batch := make([]string, 0, RemoveBatchSize)
+	var lockedKeys []string
+	removeBatch := func() error {
+		err := mcm.removeObjects(ctx, batch)
+		batch = batch[:0]
+		var lockedErr *LockedObjectsError
+		if errors.As(err, &lockedErr) {
+			lockedKeys = append(lockedKeys, lockedErr.Keys...)
+			return nil
+		}
+		return err
+	}
+
+	var removeErr error
+	err := mcm.WalkWithPrefix(ctx, prefix, true, func(chunkObjectInfo ChunkObjectInfo) bool {
+		batch = append(batch, chunkObjectInfo.FilePath)
+		if len(batch) < RemoveBatchSize {
+			return true
+		}
+		removeErr = removeBatch()
+		return removeErr == nil
+	})
+	if err == nil {
+		err = removeErr
+	}
+	if err == nil && len(batch) > 0 {
+		err = removeBatch()
+	}
+	if err != nil {
+		log.Warn("failed to remove objects", zap.String("prefix", prefix), zap.Error(err))
+		return err
+	}
+	if len(lockedKeys) > 0 {
+		log.Warn("some objects are locked, skip removing them", zap.String("prefix", prefix), zap.Int("locked", len(lockedKeys)))
+		return &LockedObjectsError{Keys: lockedKeys}
+	}
+	return nil
+}
+
+// removeObjects deletes @keys with one DeleteObjects request, and retries the keys failed to delete.
+// The locked keys are not retried, they are returned by a *LockedObjectsError.
+func (mcm *MinioChunkManager) removeObjects(ctx context.Context, keys []string) error {
+	var lockedKeys []string
+	err := retry.Do(ctx, func() error {
+		failedKeys, locked, err := mcm.removeObjectsOnce(ctx, keys)
+		if err != nil {
+			log.Debug("failed to remove some objects in batch", zap.Int("batchSize", len(keys)),
+				zap.Int("failed", len(failedKeys)), zap.Error(err))
+		}
+		lockedKeys = append(lockedKeys, locked...)
+		keys = failedKeys
+		return err
+	}, retry.Attempts(RemoveRetryAttempts))
+	if err != nil {
+		return err
+	}
+	if len(lockedKeys) > 0 {
+		return &LockedObjectsError{Keys: lockedKeys}
+	}
+	return nil
+}
+
+// removeObjectsOnce returns the keys failed to delete, the locked keys and the last error of the failed keys.
+func (mcm *MinioChunkManager) removeObjectsOnce(ctx context.Context, keys []string) ([]string, []string, error) {
+	objects := make(chan minio.ObjectInfo, len(keys))
+	for _, key := range keys {
+		objects <- minio.ObjectInfo{Key: key}
+	}
+	close(objects)
+
+	var failedKeys, lockedKeys []string
+	var lastErr error
 	for rErr := range mcm.Client.RemoveObjects(ctx, mcm.bucketName, objects, minio.RemoveObjectsOptions{GovernanceBypass: false}) {
 		if rErr.Err != nil {
-			log.Warn("failed to remove objects", zap.String("prefix", prefix), zap.Error(rErr.Err))
-			return rErr.Err
+			if isObjectLockedErr(rErr.Err) {
+				lockedKeys = append(lockedKeys, rErr.ObjectName)
+				continue
+			}
+			failedKeys = append(failedKeys, rErr.ObjectName)
+			lastErr = rErr.Err
 		}
 	}
-	return nil
+	return failedKeys, lockedKeys, lastErr
 }
 
 // ListWithPrefix returns objects with provided prefix.
-// by default, if `recursive`=false, list object with return object with path under save level
+// by default, if `recursive`=false, list object with return object with path under save level,
+// and the common prefixes of the objects under the deeper levels
 // say minio has followinng objects: [a, ab, a/b, ab/c]
-// calling `ListWithPrefix` with `prefix` = a && `recursive` = false will only returns [a, ab]
+// calling `ListWithPrefix` with `prefix` = a && `recursive` = false will only returns [a, a/, ab, ab/]
 // If caller needs all objects without level limitation, `recursive` shall be true.
 func (mcm *MinioChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	var objectsKeys []string
+	var modTimes []time.Time
+	// the paths and modify times need no metadata
+	err := mcm.walkWithPrefix(ctx, prefix, recursive, false, func(chunkObjectInfo ChunkObjectInfo) bool {
+		objectsKeys = append(objectsKeys, chunkObjectInfo.FilePath)
+		modTimes = append(modTimes, chunkObjectInfo.ModifyTime)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return objectsKeys, modTimes, nil
+}
+
+// WalkWithPrefix walks the objects of @prefix, their user metadata and tags are filled if listObjectMetadata is set.
+func (mcm *MinioChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	return mcm.walkWithPrefix(ctx, prefix, recursive, mcm.listObjectMetadata, walkFunc)
+}
 
+func (mcm *MinioChunkManager) walkWithPrefix(ctx context.Context, prefix string, recursive bool, withMetadata bool, walkFunc ChunkObjectWalkFunc) error {
 	// cannot use ListObjects(ctx, bucketName, Opt{Prefix:prefix, Recursive:true})
 	// if minio has lots of objects under the provided path
 	// recursive = true may timeout during the recursive browsing the objects.
 	// See also: https://github.com/milvus-io/milvus/issues/19095
 
-	var objectsKeys []string
-	var modTimes []time.Time
+	// cancel the listing goroutines of minio client when the walk stops early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	tasks := list.New()
 	tasks.PushBack(prefix)
@@ -384,28 +935,61 @@ func (mcm *MinioChunkManager) ListWithPrefix(ctx context.Context, prefix string,
 
 		// TODO add concurrent call if performance matters
 		// only return current level per call
-		objects := mcm.Client.ListObjects(ctx, mcm.bucketName, minio.ListObjectsOptions{Prefix: pre, Recursive: false})
+		objects := mcm.Client.ListObjects(ctx, mcm.bucketName, mcm.listObjectsOptions(pre, false))
 
 		for object := range objects {
 			if object.Err != nil {
 				log.Warn("failed to list with prefix", zap.String("prefix", prefix), zap.Error(object.Err))
-				return nil, nil, object.Err
+				return object.Err
 			}
 
-			// with tailing "/", object is a "directory"
+			// with tailing "/", object is a "directory", which is a common prefix, or the folder object of the prefix
 			if strings.HasSuffix(object.Key, "/") {
-				// enqueue when recursive is true
-				if recursive && object.Key != pre {
+				if object.Key == pre {
+					continue
+				}
+				// enqueue when recursive is true, otherwise the common prefix is walked like the local dirs
+				if recursive {
 					tasks.PushBack(object.Key)
+					continue
+				}
+				if !walkFunc(ChunkObjectInfo{FilePath: object.Key, ModifyTime: object.LastModified}) {
+					return nil
 				}
 				continue
 			}
-			objectsKeys = append(objectsKeys, object.Key)
-			modTimes = append(modTimes, object.LastModified)
+			info := ChunkObjectInfo{FilePath: object.Key, ModifyTime: object.LastModified, Size: object.Size}
+			if withMetadata {
+				if err := mcm.fillObjectMetadata(ctx, &info); err != nil {
+					log.Warn("failed to get object metadata", zap.String("path", object.Key), zap.Error(err))
+					return err
+				}
+			}
+			if !walkFunc(info) {
+				return nil
+			}
 		}
 	}
+	return nil
+}
 
-	return objectsKeys, modTimes, nil
+// fillObjectMetadata fills the user metadata and tags of the object, ListObjects of S3 returns neither of them.
+func (mcm *MinioChunkManager) fillObjectMetadata(ctx context.Context, info *ChunkObjectInfo) error {
+	objectInfo, err := mcm.Client.StatObject(ctx, mcm.bucketName, info.FilePath, mcm.getObjectOptions())
+	if err != nil {
+		return err
+	}
+	info.UserMetadata = make(map[string]string, len(objectInfo.UserMetadata))
+	for k, v := range objectInfo.UserMetadata {
+		// header keys are canonicalized, e.g. Collection-Id
+		info.UserMetadata[strings.ToLower(k)] = v
+	}
+	objectTags, err := mcm.Client.GetObjectTagging(ctx, mcm.bucketName, info.FilePath, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return err
+	}
+	info.Tags = objectTags.ToMap()
+	return nil
 }
 
 // Learn from file.ReadFile
@@ -426,3 +1010,40 @@ func Read(r io.Reader, size int64) ([]byte, error) {
 		}
 	}
 }
+
+type preconditionKey struct{}
+
+// precondition is the conditional header added to the requests creating an object.
+type precondition struct {
+	header string
+	value  string
+}
+
+// withIfNoneMatch makes the object written with @ctx be created only if it doesn't exist.
+func withIfNoneMatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, preconditionKey{}, precondition{header: "If-None-Match", value: "*"})
+}
+
+// withIfMatch makes the object written with @ctx be replaced only if its ETag is still @etag.
+func withIfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, preconditionKey{}, precondition{header: "If-Match", value: "\"" + strings.Trim(etag, "\"") + "\""})
+}
+
+// conditionalTransport adds the precondition headers carried by the request context,
+// minio-go doesn't support conditional PutObject.
+type conditionalTransport struct {
+	backend http.RoundTripper
+}
+
+// RoundTrip adds the precondition to the requests creating an object, that is a single part upload
+// or the completion of a multipart upload. The requests uploading parts are left untouched.
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if p, ok := req.Context().Value(preconditionKey{}).(precondition); ok {
+		multipart := req.URL.Query().Has("uploadId")
+		if (req.Method == http.MethodPut && !multipart) || (req.Method == http.MethodPost && multipart) {
+			req = req.Clone(req.Context())
+			req.Header.Set(p.header, p.value)
+		}
+	}
+	return t.backend.RoundTrip(req)
+}
@@ -19,10 +19,15 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"path"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/milvus-io/milvus/internal/util/paramtable"
 
@@ -326,6 +331,209 @@ func TestMinIOCM(t *testing.T) {
 		}
 	})
 
+	t.Run("test RemoveWithPrefix in batches", func(t *testing.T) {
+		testRemoveRoot := path.Join(testMinIOKVRoot, "remove_batch")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testRemoveRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testRemoveRoot)
+
+		originBatchSize := RemoveBatchSize
+		RemoveBatchSize = 3
+		defer func() { RemoveBatchSize = originBatchSize }()
+
+		var keys []string
+		for i := 0; i < 10; i++ {
+			keys = append(keys, path.Join(testRemoveRoot, "batch", "key_"+strconv.Itoa(i)))
+		}
+		keys = append(keys, path.Join(testRemoveRoot, "batch", "sub", "key"))
+		for _, key := range keys {
+			err = testCM.Write(ctx, key, []byte("a"))
+			require.NoError(t, err)
+		}
+		err = testCM.Write(ctx, path.Join(testRemoveRoot, "other"), []byte("a"))
+		require.NoError(t, err)
+
+		err = testCM.RemoveWithPrefix(ctx, path.Join(testRemoveRoot, "batch"))
+		assert.NoError(t, err)
+
+		for _, key := range keys {
+			exist, err := testCM.Exist(ctx, key)
+			assert.NoError(t, err)
+			assert.False(t, exist)
+		}
+		exist, err := testCM.Exist(ctx, path.Join(testRemoveRoot, "other"))
+		assert.NoError(t, err)
+		assert.True(t, exist)
+	})
+
+	t.Run("test Append", func(t *testing.T) {
+		testAppendRoot := path.Join(testMinIOKVRoot, "append")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testAppendRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testAppendRoot)
+
+		key := path.Join(testAppendRoot, "key")
+		err = testCM.Append(ctx, key, []byte("111"))
+		assert.NoError(t, err)
+		err = testCM.Append(ctx, key, []byte("222"))
+		assert.NoError(t, err)
+
+		val, err := testCM.Read(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111222"), val)
+	})
+
+	t.Run("test WriteIfNotExist", func(t *testing.T) {
+		testRoot := path.Join(testMinIOKVRoot, "write_if_not_exist")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+		key := path.Join(testRoot, "key")
+		err = testCM.WriteIfNotExist(ctx, key, []byte("111"))
+		assert.NoError(t, err)
+		err = testCM.WriteIfNotExist(ctx, key, []byte("222"))
+		assert.ErrorIs(t, err, ErrObjectExists)
+
+		val, err := testCM.Read(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+	})
+
+	t.Run("test Copy and Move", func(t *testing.T) {
+		testRoot := path.Join(testMinIOKVRoot, "copy_move")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+		src := path.Join(testRoot, "src")
+		err = testCM.Write(ctx, src, []byte("111"))
+		require.NoError(t, err)
+
+		copied := path.Join(testRoot, "copied")
+		err = testCM.Copy(ctx, src, copied)
+		assert.NoError(t, err)
+		val, err := testCM.Read(ctx, copied)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+
+		moved := path.Join(testRoot, "moved")
+		err = testCM.Move(ctx, src, moved)
+		assert.NoError(t, err)
+		val, err = testCM.Read(ctx, moved)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+		exist, err := testCM.Exist(ctx, src)
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		err = testCM.Move(ctx, moved, moved)
+		assert.NoError(t, err)
+		exist, err = testCM.Exist(ctx, moved)
+		assert.NoError(t, err)
+		assert.True(t, exist)
+
+		err = testCM.Copy(ctx, src, copied)
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+	})
+
+	t.Run("test WriteWithOptions", func(t *testing.T) {
+		testRoot := path.Join(testMinIOKVRoot, "write_with_options")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+		key := path.Join(testRoot, "insert_log/1/2/3")
+		err = testCM.WriteWithOptions(ctx, key, []byte("111"),
+			WithUserMetadata(map[string]string{"owner": "milvus"}),
+			WithBinlogTags(1, 2, "insert_log"))
+		require.NoError(t, err)
+
+		val, err := testCM.Read(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+
+		// metadata is not listed by default
+		var infos []ChunkObjectInfo
+		err = testCM.WalkWithPrefix(ctx, testRoot, true, func(info ChunkObjectInfo) bool {
+			infos = append(infos, info)
+			return true
+		})
+		assert.NoError(t, err)
+		require.Equal(t, 1, len(infos))
+		assert.Nil(t, infos[0].Tags)
+
+		testCM.listObjectMetadata = true
+		infos = nil
+		err = testCM.WalkWithPrefix(ctx, testRoot, true, func(info ChunkObjectInfo) bool {
+			infos = append(infos, info)
+			return true
+		})
+		assert.NoError(t, err)
+		require.Equal(t, 1, len(infos))
+		assert.Equal(t, key, infos[0].FilePath)
+		assert.Equal(t, "milvus", infos[0].UserMetadata["owner"])
+		assert.Equal(t, map[string]string{
+			ObjectTagCollectionID: "1",
+			ObjectTagSegmentID:    "2",
+			ObjectTagLogType:      "insert_log",
+		}, infos[0].Tags)
+	})
+
+	t.Run("test PresignURL", func(t *testing.T) {
+		testRoot := path.Join(testMinIOKVRoot, "presign")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+		key := path.Join(testRoot, "key")
+		putURL, err := testCM.PresignURL(ctx, key, http.MethodPut, time.Minute)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, putURL, strings.NewReader("111"))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		getURL, err := testCM.PresignURL(ctx, key, http.MethodGet, time.Minute)
+		require.NoError(t, err)
+		resp, err = http.Get(getURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		val, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("111"), val)
+
+		_, err = testCM.PresignURL(ctx, key, http.MethodDelete, time.Minute)
+		assert.Error(t, err)
+	})
+
 	t.Run("test ReadAt", func(t *testing.T) {
 		testLoadPartialRoot := path.Join(testMinIOKVRoot, "load_partial")
 
@@ -375,6 +583,35 @@ func TestMinIOCM(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("test MultiReadAt", func(t *testing.T) {
+		testMultiReadAtRoot := path.Join(testMinIOKVRoot, "multi_read_at")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testMultiReadAtRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testMultiReadAtRoot)
+
+		key := path.Join(testMultiReadAtRoot, "key")
+		value := []byte("TestMinIOKV_MultiReadAt_value")
+		err = testCM.Write(ctx, key, value)
+		assert.NoError(t, err)
+
+		ranges := []Range{{Offset: 10, Length: 5}, {Offset: 0, Length: 3}, {Offset: 3, Length: 4}, {Offset: 12, Length: 6}}
+		results, err := testCM.MultiReadAt(ctx, key, ranges)
+		assert.NoError(t, err)
+		for i, r := range ranges {
+			assert.Equal(t, value[r.Offset:r.Offset+r.Length], results[i])
+		}
+
+		_, err = testCM.MultiReadAt(ctx, key, []Range{{Offset: -1, Length: 2}})
+		assert.Error(t, err)
+
+		_, err = testCM.MultiReadAt(ctx, path.Join(testMultiReadAtRoot, "not_exist"), ranges)
+		assert.Error(t, err)
+	})
+
 	t.Run("test Size", func(t *testing.T) {
 		testGetSizeRoot := path.Join(testMinIOKVRoot, "get_size")
 		ctx, cancel := context.WithCancel(context.Background())
@@ -401,6 +638,141 @@ func TestMinIOCM(t *testing.T) {
 		assert.Equal(t, int64(0), size)
 	})
 
+	t.Run("test Stat", func(t *testing.T) {
+		testStatRoot := path.Join(testMinIOKVRoot, "stat")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testStatRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testStatRoot)
+
+		key := path.Join(testStatRoot, "key")
+		value := []byte("value")
+		err = testCM.Write(ctx, key, value)
+		assert.NoError(t, err)
+
+		info, err := testCM.Stat(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, key, info.FilePath)
+		assert.Equal(t, int64(len(value)), info.Size)
+		assert.False(t, info.ModifyTime.IsZero())
+		assert.NotEmpty(t, info.ETag)
+
+		// ETag changes after the object is rewritten
+		err = testCM.Write(ctx, key, []byte("value2"))
+		assert.NoError(t, err)
+		info2, err := testCM.Stat(ctx, key)
+		assert.NoError(t, err)
+		assert.NotEqual(t, info.ETag, info2.ETag)
+
+		_, err = testCM.Stat(ctx, path.Join(testStatRoot, "not_exist"))
+		assert.ErrorIs(t, err, ErrNoSuchKey)
+	})
+
+	t.Run("test MultiStat", func(t *testing.T) {
+		testMultiStatRoot := path.Join(testMinIOKVRoot, "multi_stat")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testMultiStatRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testMultiStatRoot)
+
+		contents := make(map[string][]byte)
+		for i := 0; i < multiStatListThreshold; i++ {
+			contents[path.Join(testMultiStatRoot, fmt.Sprintf("key%02d", i))] = make([]byte, i+1)
+		}
+		err = testCM.MultiWrite(ctx, contents)
+		require.NoError(t, err)
+
+		tests := []struct {
+			description string
+			count       int
+		}{
+			{"stat by HEAD", 2},
+			{"stat by listing", multiStatListThreshold},
+		}
+		for _, test := range tests {
+			t.Run(test.description, func(t *testing.T) {
+				filePaths := []string{path.Join(testMultiStatRoot, "not_exist")}
+				for i := test.count - 1; i >= 0; i-- {
+					filePaths = append(filePaths, path.Join(testMultiStatRoot, fmt.Sprintf("key%02d", i)))
+				}
+				infos, err := testCM.MultiStat(ctx, filePaths)
+				assert.NoError(t, err)
+				assert.Equal(t, len(filePaths), len(infos))
+				assert.Nil(t, infos[0])
+				for i, info := range infos[1:] {
+					require.NotNil(t, info)
+					assert.Equal(t, filePaths[i+1], info.FilePath)
+					assert.Equal(t, int64(len(contents[info.FilePath])), info.Size)
+					assert.NotEmpty(t, info.ETag)
+				}
+			})
+		}
+	})
+
+	t.Run("test versioning", func(t *testing.T) {
+		testVersionRoot := path.Join(testMinIOKVRoot, "version")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// use a separate bucket since versioning could not be disabled once enabled
+		versionBucket := testBucket + "-version"
+		testCM, err := newMinIOChunkManager(ctx, versionBucket, testVersionRoot)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testVersionRoot)
+
+		if err := testCM.Client.EnableVersioning(ctx, versionBucket); err != nil {
+			t.Skipf("versioning is not supported by the minio deployment: %v", err)
+		}
+		enabled, err := testCM.IsVersioningEnabled(ctx)
+		require.NoError(t, err)
+		require.True(t, enabled)
+
+		key := path.Join(testVersionRoot, "key")
+		err = testCM.Write(ctx, key, []byte("value1"))
+		require.NoError(t, err)
+		err = testCM.Write(ctx, key, []byte("value2"))
+		require.NoError(t, err)
+
+		versions, err := testCM.ListVersionsWithPrefix(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(versions))
+		oldest := versions[len(versions)-1]
+		assert.False(t, oldest.IsLatest)
+
+		content, err := testCM.ReadVersion(ctx, key, oldest.VersionID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value1"), content)
+
+		err = testCM.RestoreVersion(ctx, key, oldest.VersionID)
+		assert.NoError(t, err)
+		content, err = testCM.Read(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value1"), content)
+
+		// remove leaves a delete marker which could be undeleted
+		err = testCM.RemoveWithPrefix(ctx, testVersionRoot)
+		assert.NoError(t, err)
+		exist, err := testCM.Exist(ctx, key)
+		assert.NoError(t, err)
+		assert.False(t, exist)
+		restored, err := testCM.UndeleteWithPrefix(ctx, testVersionRoot)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{key}, restored)
+		content, err = testCM.Read(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value1"), content)
+
+		err = testCM.RemoveAllVersions(ctx, key)
+		assert.NoError(t, err)
+		versions, err = testCM.ListVersionsWithPrefix(ctx, key)
+		assert.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+
 	t.Run("test Path", func(t *testing.T) {
 		testGetPathRoot := path.Join(testMinIOKVRoot, "get_path")
 		ctx, cancel := context.WithCancel(context.Background())
@@ -497,6 +869,18 @@ func TestMinIOCM(t *testing.T) {
 		assert.Equal(t, 3, len(dirs))
 		assert.Equal(t, 3, len(mods))
 
+		// one level with the common prefixes
+		dirs, mods, err = testCM.ListWithPrefix(ctx, testPrefix+"/", false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testPrefix + "/a/", testPrefix + "/b/", testPrefix + "/bc/"}, dirs)
+		assert.Equal(t, 3, len(mods))
+		dirs, _, err = testCM.ListWithPrefix(ctx, path.Join(testPrefix, "b"), false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testPrefix + "/b/", testPrefix + "/bc/"}, dirs)
+		dirs, _, err = testCM.ListWithPrefix(ctx, path.Join(testPrefix, "a")+"/", false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{path.Join(testPrefix, pathB), path.Join(testPrefix, pathC)}, dirs)
+
 		testCM.RemoveWithPrefix(ctx, testPrefix)
 		r, m, err = testCM.ListWithPrefix(ctx, pathPrefix, true)
 		assert.NoError(t, err)
@@ -510,6 +894,43 @@ func TestMinIOCM(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("test WalkWithPrefix", func(t *testing.T) {
+		testPrefix := path.Join(testMinIOKVRoot, "walk")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		testCM, err := newMinIOChunkManager(ctx, testBucket, testPrefix)
+		require.NoError(t, err)
+		defer testCM.RemoveWithPrefix(ctx, testPrefix)
+
+		for _, key := range []string{"a/a", "a/b", "b/b/b", "bc"} {
+			err = testCM.Write(ctx, path.Join(testPrefix, key), []byte("a"))
+			require.NoError(t, err)
+		}
+
+		var keys []string
+		err = testCM.WalkWithPrefix(ctx, testPrefix+"/", true, func(chunkInfo ChunkObjectInfo) bool {
+			assert.False(t, chunkInfo.ModifyTime.IsZero())
+			keys = append(keys, chunkInfo.FilePath)
+			return true
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			path.Join(testPrefix, "a/a"),
+			path.Join(testPrefix, "a/b"),
+			path.Join(testPrefix, "b/b/b"),
+			path.Join(testPrefix, "bc"),
+		}, keys)
+
+		count := 0
+		err = testCM.WalkWithPrefix(ctx, testPrefix+"/", true, func(chunkInfo ChunkObjectInfo) bool {
+			count++
+			return count < 2
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
 	t.Run("test NoSuchKey", func(t *testing.T) {
 		testPrefix := path.Join(testMinIOKVRoot, "nokey")
 		ctx, cancel := context.WithCancel(context.Background())
@@ -531,6 +952,13 @@ func TestMinIOCM(t *testing.T) {
 	})
 }
 
+func TestCommonDir(t *testing.T) {
+	assert.Equal(t, "", commonDir(nil))
+	assert.Equal(t, "a/b/", commonDir([]string{"a/b/c1", "a/b/c2", "a/b/d/e"}))
+	assert.Equal(t, "a/", commonDir([]string{"a/b1/c", "a/b2/c"}))
+	assert.Equal(t, "", commonDir([]string{"a/b", "c/d"}))
+}
+
 func TestMinioChunkManager_normalizeRootPath(t *testing.T) {
 	type testCase struct {
 		input    string
@@ -571,3 +999,63 @@ func TestMinioChunkManager_normalizeRootPath(t *testing.T) {
 		})
 	}
 }
+
+type recordTransport struct {
+	headers []string
+	matches []string
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.headers = append(t.headers, req.Header.Get("If-None-Match"))
+	t.matches = append(t.matches, req.Header.Get("If-Match"))
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestConditionalTransport(t *testing.T) {
+	backend := &recordTransport{}
+	transport := &conditionalTransport{backend: backend}
+	ctx := withIfNoneMatch(context.Background())
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPut, "http://minio/bucket/key", nil),
+		httptest.NewRequest(http.MethodPost, "http://minio/bucket/key?uploads", nil),
+		httptest.NewRequest(http.MethodPut, "http://minio/bucket/key?uploadId=1&partNumber=1", nil),
+		httptest.NewRequest(http.MethodPost, "http://minio/bucket/key?uploadId=1", nil),
+		httptest.NewRequest(http.MethodGet, "http://minio/bucket/key", nil),
+	}
+	for _, req := range requests {
+		_, err := transport.RoundTrip(req.WithContext(ctx))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"*", "", "", "*", ""}, backend.headers)
+	assert.Equal(t, []string{"", "", "", "", ""}, backend.matches)
+
+	// the appends replace the object only if it's not modified
+	backend = &recordTransport{}
+	transport = &conditionalTransport{backend: backend}
+	ctx = withIfMatch(context.Background(), "etag")
+	for _, req := range requests {
+		_, err := transport.RoundTrip(req.WithContext(ctx))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{`"etag"`, "", "", `"etag"`, ""}, backend.matches)
+	assert.Equal(t, []string{"", "", "", "", ""}, backend.headers)
+
+	// requests without precondition are left untouched
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodPut, "http://minio/bucket/key", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "", backend.headers[len(backend.headers)-1])
+}
+
+func TestMinioChunkManager_requesterPays(t *testing.T) {
+	mcm := &MinioChunkManager{}
+	getOpts := mcm.getObjectOptions()
+	assert.Empty(t, getOpts.Header().Get(requestPayerHeader))
+
+	mcm.requesterPays = true
+	getOpts = mcm.getObjectOptions()
+	assert.Equal(t, "requester", getOpts.Header().Get(requestPayerHeader))
+	listOpts := mcm.listObjectsOptions("prefix", true)
+	assert.Equal(t, "prefix", listOpts.Prefix)
+	assert.True(t, listOpts.Recursive)
+}
@@ -0,0 +1,264 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/signer"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSTSEndpoint        = "https://sts.amazonaws.com"
+	defaultSTSRegion          = "us-east-1"
+	defaultAssumeRoleDuration = time.Hour
+	// defaultAssumeRoleSessionName is the session name shown in the CloudTrail logs of the assumed role
+	defaultAssumeRoleSessionName = "milvus"
+	// assumeRoleRetryInterval is how often the credentials are assumed again after a failure,
+	// while the previous credentials are still valid
+	assumeRoleRetryInterval = 10 * time.Second
+)
+
+// AssumeRoleConfig is the IAM role assumed through STS by MinioChunkManager, the source credentials calling STS
+// are the access keys, or the IAM credentials (instance profile, ECS task role or EKS IRSA) if UseIAM.
+type AssumeRoleConfig struct {
+	RoleARN    string
+	ExternalID string
+	// SessionName defaults to "milvus"
+	SessionName string
+	// STSEndpoint defaults to the regional endpoint of Region, or https://sts.amazonaws.com if Region is empty
+	STSEndpoint string
+	Region      string
+	// Duration of the assumed credentials, defaults to one hour
+	Duration time.Duration
+	// RefreshWindow is how long before the expiration the credentials are refreshed,
+	// zero refreshes them after 80% of their lifetime passes
+	RefreshWindow time.Duration
+}
+
+// newMinioCredentials returns the credentials of the minio client configured by @c, the temporary ones are
+// refreshed by the client before they expire.
+func newMinioCredentials(c *config) (*credentials.Credentials, error) {
+	var creds *credentials.Credentials
+	switch c.cloudProvider {
+	case CloudProviderGCP:
+		if !c.useIAM {
+			creds = credentials.NewStaticV2(c.accessKeyID, c.secretAccessKeyID, "")
+		}
+		if c.assumeRole.RoleARN != "" {
+			return nil, errors.New("assuming IAM role is not supported by cloud provider gcp")
+		}
+		return creds, nil
+	case CloudProviderAzure:
+		return nil, errors.New("cloud provider azure has no S3 API, use storage type azure instead")
+	default: // aws, minio
+		if c.useIAM {
+			creds = credentials.NewIAM("")
+		} else {
+			creds = credentials.NewStaticV4(c.accessKeyID, c.secretAccessKeyID, "")
+		}
+	}
+	if c.assumeRole.RoleARN == "" {
+		return creds, nil
+	}
+	provider, err := newAssumeRoleProvider(creds, c.assumeRole)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("object storage credentials are assumed from IAM role",
+		zap.String("roleARN", c.assumeRole.RoleARN), zap.String("stsEndpoint", provider.endpoint))
+	return credentials.New(provider), nil
+}
+
+// assumeRoleProvider retrieves the temporary credentials of an IAM role by STS AssumeRole, the STS request is signed
+// by the source credentials, which are refreshed on their own. minio-go's STSAssumeRole supports neither the
+// external ID nor temporary source credentials, so it's not used.
+type assumeRoleProvider struct {
+	credentials.Expiry
+
+	client   *http.Client
+	source   *credentials.Credentials
+	endpoint string
+	region   string
+	cfg      AssumeRoleConfig
+
+	mu sync.Mutex
+	// the last assumed credentials, which are kept serving until they really expire if refreshing fails
+	value      credentials.Value
+	expiration time.Time
+}
+
+var _ credentials.Provider = (*assumeRoleProvider)(nil)
+
+func newAssumeRoleProvider(source *credentials.Credentials, cfg AssumeRoleConfig) (*assumeRoleProvider, error) {
+	region := cfg.Region
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	endpoint := cfg.STSEndpoint
+	if endpoint == "" {
+		switch {
+		case region == "":
+			endpoint = defaultSTSEndpoint
+		case strings.HasPrefix(region, "cn-"):
+			endpoint = "https://sts." + region + ".amazonaws.com.cn"
+		default:
+			endpoint = "https://sts." + region + ".amazonaws.com"
+		}
+	}
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid STS endpoint %s: %w", endpoint, err)
+	}
+	if region == "" {
+		region = defaultSTSRegion
+	}
+	if cfg.SessionName == "" {
+		cfg.SessionName = defaultAssumeRoleSessionName
+	}
+	if cfg.Duration <= 0 {
+		cfg.Duration = defaultAssumeRoleDuration
+	}
+	if cfg.RefreshWindow >= cfg.Duration {
+		return nil, fmt.Errorf("refresh window %s of the assumed role must be shorter than its duration %s", cfg.RefreshWindow, cfg.Duration)
+	}
+	return &assumeRoleProvider{
+		client:   &http.Client{Transport: http.DefaultTransport},
+		source:   source,
+		endpoint: endpoint,
+		region:   region,
+		cfg:      cfg,
+	}, nil
+}
+
+// Retrieve assumes the role again, the previous credentials are returned if it fails before they expire,
+// and it's retried after assumeRoleRetryInterval.
+func (p *assumeRoleProvider) Retrieve() (credentials.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	resp, err := p.assumeRole()
+	if err != nil {
+		now := time.Now()
+		if p.CurrentTime != nil {
+			now = p.CurrentTime()
+		}
+		if now.Before(p.expiration) {
+			log.Warn("failed to refresh the credentials of the assumed role, keep using the previous ones",
+				zap.String("roleARN", p.cfg.RoleARN), zap.Time("expiration", p.expiration), zap.Error(err))
+			retryAt := now.Add(assumeRoleRetryInterval)
+			if retryAt.After(p.expiration) {
+				retryAt = p.expiration
+			}
+			p.SetExpiration(retryAt, 0)
+			return p.value, nil
+		}
+		return credentials.Value{}, fmt.Errorf("failed to assume role %s: %w", p.cfg.RoleARN, err)
+	}
+
+	creds := resp.Result.Credentials
+	p.value = credentials.Value{
+		AccessKeyID:     creds.AccessKey,
+		SecretAccessKey: creds.SecretKey,
+		SessionToken:    creds.SessionToken,
+		SignerType:      credentials.SignatureV4,
+	}
+	p.expiration = creds.Expiration
+	if p.cfg.RefreshWindow > 0 {
+		p.SetExpiration(creds.Expiration, p.cfg.RefreshWindow)
+	} else {
+		p.SetExpiration(creds.Expiration, credentials.DefaultExpiryWindow)
+	}
+	log.Info("assumed IAM role for object storage", zap.String("roleARN", p.cfg.RoleARN), zap.Time("expiration", p.expiration))
+	return p.value, nil
+}
+
+// assumeRole calls STS AssumeRole signed by the source credentials.
+func (p *assumeRoleProvider) assumeRole() (*credentials.AssumeRoleResponse, error) {
+	source, err := p.source.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source credentials: %w", err)
+	}
+	if source.AccessKeyID == "" || source.SecretAccessKey == "" {
+		return nil, errors.New("no source credentials to assume role")
+	}
+
+	v := url.Values{}
+	v.Set("Action", "AssumeRole")
+	v.Set("Version", credentials.STSVersion)
+	v.Set("RoleArn", p.cfg.RoleARN)
+	v.Set("RoleSessionName", p.cfg.SessionName)
+	v.Set("DurationSeconds", strconv.Itoa(int(p.cfg.Duration/time.Second)))
+	if p.cfg.ExternalID != "" {
+		v.Set("ExternalId", p.cfg.ExternalID)
+	}
+	body := v.Encode()
+	hash := sha256.Sum256([]byte(body))
+
+	u, err := url.Parse(p.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = "/"
+	req, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(hash[:]))
+	// the source credentials are temporary ones if they come from IAM
+	if source.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", source.SessionToken)
+	}
+	req = signer.SignV4STS(*req, source.AccessKeyID, source.SecretAccessKey, p.region)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("STS responded %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	result := &credentials.AssumeRoleResponse{}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	if result.Result.Credentials.AccessKey == "" {
+		return nil, errors.New("STS responded no credentials")
+	}
+	return result, nil
+}
@@ -0,0 +1,118 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssumeRoleProvider(t *testing.T) {
+	var calls int32
+	var failing atomic.Value
+	failing.Store(false)
+	expiration := time.Now().Add(time.Hour).UTC()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if failing.Load().(bool) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "AssumeRole", r.Form.Get("Action"))
+		assert.Equal(t, "arn:aws:iam::123456789012:role/milvus", r.Form.Get("RoleArn"))
+		assert.Equal(t, "ext-id", r.Form.Get("ExternalId"))
+		assert.Equal(t, "milvus", r.Form.Get("RoleSessionName"))
+		assert.Equal(t, "1800", r.Form.Get("DurationSeconds"))
+		assert.Equal(t, "source-token", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.Contains(r.Header.Get("Authorization"), "Credential=source-ak/"))
+		fmt.Fprintf(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult><Credentials>`+
+			`<AccessKeyId>ak-%d</AccessKeyId><SecretAccessKey>sk</SecretAccessKey><SessionToken>token</SessionToken>`+
+			`<Expiration>%s</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`, n, expiration.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	source := credentials.NewStaticV4("source-ak", "source-sk", "source-token")
+	_, err := newAssumeRoleProvider(source, AssumeRoleConfig{RoleARN: "arn", Duration: time.Minute, RefreshWindow: time.Minute})
+	assert.Error(t, err)
+
+	provider, err := newAssumeRoleProvider(source, AssumeRoleConfig{
+		RoleARN:       "arn:aws:iam::123456789012:role/milvus",
+		ExternalID:    "ext-id",
+		STSEndpoint:   server.URL,
+		Duration:      30 * time.Minute,
+		RefreshWindow: 5 * time.Minute,
+	})
+	require.NoError(t, err)
+	creds := credentials.New(provider)
+
+	value, err := creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ak-1", value.AccessKeyID)
+	assert.Equal(t, "token", value.SessionToken)
+	value, err = creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ak-1", value.AccessKeyID)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// refreshed within the refresh window before the expiration
+	provider.CurrentTime = func() time.Time { return expiration.Add(-4 * time.Minute) }
+	value, err = creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ak-2", value.AccessKeyID)
+
+	// the previous credentials keep serving if refreshing fails before they expire
+	failing.Store(true)
+	provider.CurrentTime = func() time.Time { return expiration.Add(-4 * time.Minute) }
+	value, err = creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ak-2", value.AccessKeyID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	provider.expiration = time.Now().Add(-time.Second)
+	creds.Expire()
+	_, err = creds.Get()
+	assert.Error(t, err)
+}
+
+func TestNewMinioCredentials(t *testing.T) {
+	creds, err := newMinioCredentials(&config{accessKeyID: "ak", secretAccessKeyID: "sk"})
+	require.NoError(t, err)
+	value, err := creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ak", value.AccessKeyID)
+
+	_, err = newMinioCredentials(&config{cloudProvider: CloudProviderGCP, assumeRole: AssumeRoleConfig{RoleARN: "arn"}})
+	assert.Error(t, err)
+
+	_, err = newMinioCredentials(&config{cloudProvider: CloudProviderAzure, useIAM: true})
+	assert.Error(t, err)
+
+	creds, err = newMinioCredentials(&config{accessKeyID: "ak", secretAccessKeyID: "sk",
+		assumeRole: AssumeRoleConfig{RoleARN: "arn", Region: "cn-north-1"}})
+	require.NoError(t, err)
+	assert.NotNil(t, creds)
+}
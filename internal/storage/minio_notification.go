@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+// ObjectEvent is an object creation pushed by the storage, Err is set if listening failed,
+// after which no more events are sent.
+type ObjectEvent struct {
+	FilePath  string
+	Size      int64
+	ETag      string
+	EventTime time.Time
+	Err       error
+}
+
+// ObjectNotifier is implemented by chunk managers able to push object creation events.
+type ObjectNotifier interface {
+	// ListenObjectCreated sends the objects created with @prefix and @suffix to the returned channel,
+	// the channel is closed once @ctx is done or listening failed.
+	ListenObjectCreated(ctx context.Context, prefix string, suffix string) <-chan ObjectEvent
+}
+
+var _ ObjectNotifier = (*MinioChunkManager)(nil)
+
+// ListenObjectCreated listens the bucket notifications, which is a MinIO extension not supported by S3.
+func (mcm *MinioChunkManager) ListenObjectCreated(ctx context.Context, prefix string, suffix string) <-chan ObjectEvent {
+	infos := mcm.ListenBucketNotification(ctx, mcm.bucketName, prefix, suffix, []string{string(notification.ObjectCreatedAll)})
+	events := make(chan ObjectEvent)
+	go func() {
+		defer close(events)
+		for info := range infos {
+			if info.Err != nil {
+				select {
+				case events <- ObjectEvent{Err: info.Err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			for _, record := range info.Records {
+				event, err := toObjectEvent(record)
+				if err != nil {
+					event = ObjectEvent{Err: err}
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}
+	}()
+	return events
+}
+
+func toObjectEvent(record notification.Event) (ObjectEvent, error) {
+	// keys in notifications are url encoded
+	key, err := url.QueryUnescape(record.S3.Object.Key)
+	if err != nil {
+		return ObjectEvent{}, err
+	}
+	eventTime, err := time.Parse(time.RFC3339Nano, record.EventTime)
+	if err != nil {
+		eventTime = time.Now()
+	}
+	return ObjectEvent{
+		FilePath:  key,
+		Size:      record.S3.Object.Size,
+		ETag:      record.S3.Object.ETag,
+		EventTime: eventTime,
+	}, nil
+}
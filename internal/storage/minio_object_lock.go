@@ -0,0 +1,103 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// LockedObjectsError reports the objects skipped by a batch removal because they are protected by object lock.
+type LockedObjectsError struct {
+	Keys []string
+}
+
+func (e *LockedObjectsError) Error() string {
+	return fmt.Sprintf("%s(count=%d)", ErrObjectLocked, len(e.Keys))
+}
+
+// Is makes errors.Is(err, ErrObjectLocked) true for *LockedObjectsError.
+func (e *LockedObjectsError) Is(target error) bool {
+	return target == ErrObjectLocked
+}
+
+// LockedObjects returns the keys reported locked by @err, and whether @err is caused only by locked objects.
+func LockedObjects(err error) ([]string, bool) {
+	var lockedErr *LockedObjectsError
+	if errors.As(err, &lockedErr) {
+		return lockedErr.Keys, true
+	}
+	return nil, errors.Is(err, ErrObjectLocked)
+}
+
+// isObjectLockedErr tells whether @err is returned by the storage because the object is under retention or legal hold.
+// S3 returns AccessDenied for the locked objects, so the message is checked as well.
+func isObjectLockedErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrObjectLocked) {
+		return true
+	}
+	resp := minio.ToErrorResponse(err)
+	switch resp.Code {
+	case "ObjectLocked":
+		return true
+	case "AccessDenied", "InvalidRequest":
+		msg := strings.ToLower(resp.Message)
+		for _, keyword := range []string{"worm", "retention", "legal hold", "object lock", "object is locked"} {
+			if strings.Contains(msg, keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// putObjectOptions returns the options of PutObject with the object lock and the multipart upload configured.
+func (mcm *MinioChunkManager) putObjectOptions() minio.PutObjectOptions {
+	opts := minio.PutObjectOptions{
+		PartSize: mcm.uploadPartSize,
+	}
+	if mcm.uploadConcurrency > 0 {
+		opts.NumThreads = uint(mcm.uploadConcurrency)
+	}
+	if mcm.objectLockMode != "" && mcm.objectLockRetention > 0 {
+		opts.Mode = mcm.objectLockMode
+		opts.RetainUntilDate = time.Now().Add(mcm.objectLockRetention)
+	}
+	if mcm.objectLockLegalHold {
+		opts.LegalHold = minio.LegalHoldEnabled
+	}
+	return opts
+}
+
+// copyDestOptions returns the destination of server-side copy with the object lock configured.
+func (mcm *MinioChunkManager) copyDestOptions(filePath string) minio.CopyDestOptions {
+	putOpts := mcm.putObjectOptions()
+	return minio.CopyDestOptions{
+		Bucket:          mcm.bucketName,
+		Object:          filePath,
+		Mode:            putOpts.Mode,
+		RetainUntilDate: putOpts.RetainUntilDate,
+		LegalHold:       putOpts.LegalHold,
+	}
+}
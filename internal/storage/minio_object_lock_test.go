@@ -0,0 +1,108 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsObjectLockedErr(t *testing.T) {
+	assert.False(t, isObjectLockedErr(nil))
+	assert.False(t, isObjectLockedErr(errors.New("mock error")))
+	assert.False(t, isObjectLockedErr(minio.ErrorResponse{Code: "NoSuchKey"}))
+	assert.False(t, isObjectLockedErr(minio.ErrorResponse{Code: "AccessDenied", Message: "Access Denied."}))
+
+	assert.True(t, isObjectLockedErr(minio.ErrorResponse{Code: "ObjectLocked"}))
+	assert.True(t, isObjectLockedErr(minio.ErrorResponse{Code: "AccessDenied", Message: "Object is WORM protected and cannot be overwritten"}))
+	assert.True(t, isObjectLockedErr(minio.ErrorResponse{Code: "InvalidRequest", Message: "Object is under Legal Hold"}))
+	assert.True(t, isObjectLockedErr(WrapErrObjectLocked("key")))
+
+	lockedErr := &LockedObjectsError{Keys: []string{"a", "b"}}
+	assert.True(t, errors.Is(lockedErr, ErrObjectLocked))
+	keys, ok := LockedObjects(lockedErr)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	keys, ok = LockedObjects(WrapErrObjectLocked("a"))
+	assert.True(t, ok)
+	assert.Empty(t, keys)
+	_, ok = LockedObjects(errors.New("mock error"))
+	assert.False(t, ok)
+}
+
+func TestMinIOCMObjectLock(t *testing.T) {
+	Params.Init()
+	testBucket, err := Params.Load("minio.bucketName")
+	require.NoError(t, err)
+	configRoot, err := Params.Load("minio.rootPath")
+	require.NoError(t, err)
+	testLockRoot := path.Join(configRoot, "milvus-minio-ut-root", "lock")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	accessKeyID, _ := Params.Load("minio.accessKeyID")
+	secretAccessKey, _ := Params.Load("minio.secretAccessKey")
+	useSSLStr, _ := Params.Load("minio.useSSL")
+	useSSL, _ := strconv.ParseBool(useSSLStr)
+
+	_, err = NewMinioChunkManager(ctx, Address(getMinioAddress()), BucketName(testBucket),
+		ObjectLock("mock", time.Minute, false))
+	assert.Error(t, err)
+
+	// object lock could only be enabled on a new bucket
+	lockBucket := testBucket + "-lock"
+	testCM, err := NewMinioChunkManager(ctx,
+		RootPath(testLockRoot),
+		Address(getMinioAddress()),
+		AccessKeyID(accessKeyID),
+		SecretAccessKeyID(secretAccessKey),
+		UseSSL(useSSL),
+		BucketName(lockBucket),
+		CreateBucket(true),
+		ObjectLock("governance", time.Minute, false),
+	)
+	if err != nil {
+		t.Skipf("object lock is not supported by the minio deployment: %v", err)
+	}
+	if _, _, _, _, err := testCM.Client.GetObjectLockConfig(ctx, lockBucket); err != nil {
+		t.Skipf("object lock is not enabled on bucket %s: %v", lockBucket, err)
+	}
+
+	keys := []string{path.Join(testLockRoot, "a"), path.Join(testLockRoot, "b")}
+	for _, key := range keys {
+		require.NoError(t, testCM.Write(ctx, key, []byte("value")))
+	}
+
+	err = testCM.Remove(ctx, keys[0])
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrObjectLocked))
+
+	err = testCM.RemoveWithPrefix(ctx, testLockRoot)
+	assert.Error(t, err)
+	locked, ok := LockedObjects(err)
+	assert.True(t, ok)
+	assert.ElementsMatch(t, keys, locked)
+}
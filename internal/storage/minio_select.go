@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// ErrSelectNotSupported means the storage can't evaluate the select expression, the object should be read entirely
+// and filtered locally instead.
+var ErrSelectNotSupported = errors.New("SelectNotSupported")
+
+func WrapErrSelectNotSupported(key string) error {
+	return fmt.Errorf("%w(key=%s)", ErrSelectNotSupported, key)
+}
+
+// maxSelectRecordSize is the max size of a record returned by S3 Select.
+const maxSelectRecordSize = 16 * 1024 * 1024
+
+// ObjectSelector is implemented by the chunk managers which could evaluate SQL expressions on the Parquet objects
+// server-side, so that only the needed columns and rows are transferred.
+type ObjectSelector interface {
+	// SelectObject evaluates the S3 Select @expression, e.g. "SELECT s.pk FROM S3Object s WHERE s.ts > 100",
+	// on the Parquet object @filePath, and returns the selected records encoded in JSON. An error wrapping
+	// ErrSelectNotSupported is returned if the storage doesn't support it.
+	SelectObject(ctx context.Context, filePath string, expression string) ([][]byte, error)
+}
+
+var _ ObjectSelector = (*MinioChunkManager)(nil)
+
+// SelectObject evaluates @expression on the Parquet object @filePath with S3 Select if it's enabled,
+// the records are returned line by line in JSON.
+func (mcm *MinioChunkManager) SelectObject(ctx context.Context, filePath string, expression string) ([][]byte, error) {
+	if !mcm.selectPushdown {
+		return nil, WrapErrSelectNotSupported(filePath)
+	}
+	results, err := mcm.Client.SelectObjectContent(ctx, mcm.bucketName, filePath, minio.SelectObjectOptions{
+		Expression:     expression,
+		ExpressionType: minio.QueryExpressionTypeSQL,
+		InputSerialization: minio.SelectObjectInputSerialization{
+			Parquet: &minio.ParquetInputOptions{},
+		},
+		OutputSerialization: minio.SelectObjectOutputSerialization{
+			JSON: &minio.JSONOutputOptions{RecordDelimiter: "\n"},
+		},
+	})
+	if err != nil {
+		return nil, mcm.selectError(filePath, err)
+	}
+	defer results.Close()
+
+	var records [][]byte
+	scanner := bufio.NewScanner(results)
+	scanner.Buffer(nil, maxSelectRecordSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		records = append(records, append([]byte{}, scanner.Bytes()...))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, mcm.selectError(filePath, err)
+	}
+	return records, nil
+}
+
+// selectError converts the errors of the storages not supporting S3 Select or Parquet input to ErrSelectNotSupported.
+func (mcm *MinioChunkManager) selectError(filePath string, err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey":
+		return WrapErrNoSuchKey(filePath)
+	case "NotImplemented", "MethodNotAllowed", "UnsupportedSqlOperation", "ParquetParsingError",
+		"InvalidDataSource", "UnsupportedFormat":
+		log.Info("object storage doesn't support select", zap.String("path", filePath), zap.Error(err))
+		return WrapErrSelectNotSupported(filePath)
+	}
+	log.Warn("failed to select object", zap.String("path", filePath), zap.Error(err))
+	return err
+}
+
+// SelectObject evaluates @expression on @filePath with the ObjectSelector found in @cm by AsChunkManager, otherwise
+// or if the storage doesn't support it, the object is read entirely and the records are selected by @fallback locally.
+// The object not found by the selector is read through @cm as well, since the wrappers may store it by another key.
+func SelectObject(ctx context.Context, cm ChunkManager, filePath string, expression string,
+	fallback func(content []byte) ([][]byte, error)) ([][]byte, error) {
+	if selector, ok := AsChunkManager[ObjectSelector](cm); ok {
+		records, err := selector.SelectObject(ctx, filePath, expression)
+		if !errors.Is(err, ErrSelectNotSupported) && !errors.Is(err, ErrNoSuchKey) {
+			return records, err
+		}
+	}
+	content, err := cm.Read(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return fallback(content)
+}
@@ -0,0 +1,96 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSelector selects the records with the selected result, or fails with err.
+type fakeSelector struct {
+	*MemoryChunkManager
+	records [][]byte
+	err     error
+	selects int
+}
+
+func (s *fakeSelector) SelectObject(ctx context.Context, filePath string, expression string) ([][]byte, error) {
+	s.selects++
+	return s.records, s.err
+}
+
+func TestSelectObject(t *testing.T) {
+	ctx := context.Background()
+	cm := NewMemoryChunkManager(RootPath("select"))
+	require.NoError(t, cm.Write(ctx, "a", []byte("1\n2\n3")))
+
+	fallbacks := 0
+	fallback := func(content []byte) ([][]byte, error) {
+		fallbacks++
+		return bytes.Split(content, []byte("\n"))[1:], nil
+	}
+	expression := "SELECT * FROM S3Object s WHERE s.pk > 1"
+
+	// not a selector
+	records, err := SelectObject(ctx, cm, "a", expression, fallback)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("3")}, records)
+	assert.Equal(t, 1, fallbacks)
+
+	selector := &fakeSelector{MemoryChunkManager: cm, records: [][]byte{[]byte("3")}}
+	records, err = SelectObject(ctx, selector, "a", expression, fallback)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("3")}, records)
+	assert.Equal(t, 1, fallbacks)
+
+	// unsupported
+	selector.err = WrapErrSelectNotSupported("a")
+	records, err = SelectObject(ctx, selector, "a", expression, fallback)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("3")}, records)
+	assert.Equal(t, 2, fallbacks)
+	assert.Equal(t, 2, selector.selects)
+
+	// found through the wrappers, and the objects stored by other keys are read through them
+	selector.err = WrapErrNoSuchKey("a")
+	records, err = SelectObject(ctx, NewFaultInjectionChunkManager(selector, 0), "a", expression, fallback)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("3")}, records)
+	assert.Equal(t, 3, fallbacks)
+	assert.Equal(t, 3, selector.selects)
+
+	// the other errors are not fallen back
+	selector.err = errors.New("mock")
+	_, err = SelectObject(ctx, selector, "a", expression, fallback)
+	assert.Error(t, err)
+	assert.Equal(t, 3, fallbacks)
+
+	_, err = SelectObject(ctx, cm, "b", expression, fallback)
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+}
+
+func TestMinioChunkManager_SelectObjectDisabled(t *testing.T) {
+	mcm := &MinioChunkManager{}
+	_, err := mcm.SelectObject(context.Background(), "a", "SELECT * FROM S3Object")
+	assert.ErrorIs(t, err, ErrSelectNotSupported)
+}
@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+const (
+	// throttleDecreaseInterval is the min interval between two decreases of the concurrency limit,
+	// the throttled responses of the requests sent before a decrease shouldn't decrease it again
+	throttleDecreaseInterval = time.Second
+	// maxRetryAfter bounds the pause requested by the Retry-After header
+	maxRetryAfter = time.Minute
+)
+
+var (
+	adaptiveThrottlerOnce sync.Once
+	adaptiveThrottler     *AdaptiveThrottler
+)
+
+// getAdaptiveThrottler returns the throttler shared by all chunk managers of the process, the limits of the first
+// chunk manager enabling it win. It returns nil if @c doesn't enable it.
+func getAdaptiveThrottler(c *config) *AdaptiveThrottler {
+	if !c.throttleEnabled {
+		return nil
+	}
+	adaptiveThrottlerOnce.Do(func() {
+		adaptiveThrottler = NewAdaptiveThrottler(c.throttleMinConcurrency, c.throttleMaxConcurrency)
+		log.Info("object storage adaptive throttling enabled", zap.Int("minConcurrency", c.throttleMinConcurrency),
+			zap.Int("maxConcurrency", c.throttleMaxConcurrency))
+	})
+	return adaptiveThrottler
+}
+
+// AdaptiveThrottler limits the in-flight object storage requests by AIMD: the limit halves when the storage
+// throttles the requests, i.e. 429 or 503 SlowDown, and grows by one every limit successful requests.
+// All requests pause until the time asked by the Retry-After header of a throttled response, so that
+// the retries of the requests don't make the throttling worse.
+type AdaptiveThrottler struct {
+	mu       sync.Mutex
+	min      float64
+	max      float64
+	limit    float64
+	inflight int
+	// pausedUntil is when the requests are allowed to start again after a Retry-After
+	pausedUntil  time.Time
+	lastDecrease time.Time
+	// changed is closed and replaced whenever a request may start, i.e. a request ends or the limit grows
+	changed chan struct{}
+}
+
+// NewAdaptiveThrottler returns a throttler whose limit starts at @maxConcurrency and never drops below @minConcurrency.
+func NewAdaptiveThrottler(minConcurrency int, maxConcurrency int) *AdaptiveThrottler {
+	if minConcurrency < 1 {
+		minConcurrency = 1
+	}
+	if maxConcurrency < minConcurrency {
+		maxConcurrency = minConcurrency
+	}
+	metrics.StorageThrottleLimit.Set(float64(maxConcurrency))
+	return &AdaptiveThrottler{
+		min:     float64(minConcurrency),
+		max:     float64(maxConcurrency),
+		limit:   float64(maxConcurrency),
+		changed: make(chan struct{}),
+	}
+}
+
+// Limit returns the current concurrency limit.
+func (t *AdaptiveThrottler) Limit() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return int(t.limit)
+}
+
+// Acquire blocks until a new request is allowed to start.
+func (t *AdaptiveThrottler) Acquire(ctx context.Context) error {
+	for {
+		t.mu.Lock()
+		now := time.Now()
+		if now.Before(t.pausedUntil) {
+			pause := t.pausedUntil.Sub(now)
+			t.mu.Unlock()
+			timer := time.NewTimer(pause)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+			continue
+		}
+		if t.inflight < int(t.limit) {
+			t.inflight++
+			t.mu.Unlock()
+			return nil
+		}
+		changed := t.changed
+		t.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release ends a request, @throttled tells whether the storage throttled it, and @retryAfter is the pause it asked for.
+func (t *AdaptiveThrottler) Release(throttled bool, retryAfter time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inflight--
+	now := time.Now()
+	if throttled {
+		if now.Sub(t.lastDecrease) >= throttleDecreaseInterval {
+			t.limit /= 2
+			if t.limit < t.min {
+				t.limit = t.min
+			}
+			t.lastDecrease = now
+			log.RatedInfo(10, "object storage throttled the requests, decrease the concurrency",
+				zap.Int("limit", int(t.limit)), zap.Duration("retryAfter", retryAfter))
+		}
+		if until := now.Add(retryAfter); until.After(t.pausedUntil) {
+			t.pausedUntil = until
+		}
+		metrics.StorageThrottledCount.Inc()
+	} else if t.limit < t.max {
+		t.limit += 1 / t.limit
+		if t.limit > t.max {
+			t.limit = t.max
+		}
+	}
+	metrics.StorageThrottleLimit.Set(float64(int(t.limit)))
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// abort ends a request without adjusting the limit.
+func (t *AdaptiveThrottler) abort() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inflight--
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// isThrottled returns whether @resp throttles the request, and the pause asked by its Retry-After header.
+func isThrottled(resp *http.Response, now time.Time) (bool, time.Duration) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return false, 0
+	}
+	return true, parseRetryAfter(resp.Header.Get("Retry-After"), now)
+}
+
+// parseRetryAfter parses the Retry-After header in seconds or HTTP date, zero is returned if it's absent or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	var retryAfter time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		retryAfter = date.Sub(now)
+	}
+	if retryAfter < 0 {
+		return 0
+	}
+	if retryAfter > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return retryAfter
+}
+
+// throttledTransport limits the requests by the throttler, a request counts as in flight until its response
+// headers arrive, which is when the storage admits or throttles it.
+type throttledTransport struct {
+	backend   http.RoundTripper
+	throttler *AdaptiveThrottler
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.throttler.Acquire(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := t.backend.RoundTrip(req)
+	if err != nil {
+		// the storage didn't answer, which tells nothing about its load
+		t.throttler.abort()
+		return nil, err
+	}
+	throttled, retryAfter := isThrottled(resp, time.Now())
+	t.throttler.Release(throttled, retryAfter)
+	return resp, nil
+}
@@ -0,0 +1,101 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	assert.Equal(t, maxRetryAfter, parseRetryAfter("3600", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	date := now.Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	assert.InDelta(t, float64(10*time.Second), float64(parseRetryAfter(date, now)), float64(time.Second))
+}
+
+func TestAdaptiveThrottler(t *testing.T) {
+	ctx := context.Background()
+	throttler := NewAdaptiveThrottler(2, 8)
+	assert.Equal(t, 8, throttler.Limit())
+
+	for i := 0; i < 8; i++ {
+		require.NoError(t, throttler.Acquire(ctx))
+	}
+	// all slots are taken
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.Error(t, throttler.Acquire(timeoutCtx))
+
+	// the throttled responses in a burst halve the limit once
+	throttler.Release(true, 0)
+	throttler.Release(true, 0)
+	assert.Equal(t, 4, throttler.Limit())
+	// the successful requests grow it back slowly
+	for i := 0; i < 6; i++ {
+		throttler.Release(false, 0)
+	}
+	assert.Equal(t, 5, throttler.Limit())
+
+	// the requests pause for the Retry-After
+	throttler.lastDecrease = time.Time{}
+	require.NoError(t, throttler.Acquire(ctx))
+	throttler.Release(true, 100*time.Millisecond)
+	assert.Equal(t, 2, throttler.Limit())
+	start := time.Now()
+	require.NoError(t, throttler.Acquire(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	throttler.abort()
+}
+
+func TestThrottledTransport(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	throttler := NewAdaptiveThrottler(1, 4)
+	client := &http.Client{Transport: &throttledTransport{backend: http.DefaultTransport, throttler: throttler}}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 2, throttler.Limit())
+
+	start := time.Now()
+	resp, err = client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
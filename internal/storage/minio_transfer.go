@@ -0,0 +1,87 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	// MinUploadPartSize is the min part size of the multipart uploads accepted by S3
+	MinUploadPartSize = 5 << 20
+	// DefaultDownloadPartSize is the size of the ranged GETs of a parallel download if the upload part size is not set
+	DefaultDownloadPartSize = 16 << 20
+)
+
+func validateTransferConfig(c *config) error {
+	if c.uploadPartSize > 0 && c.uploadPartSize < MinUploadPartSize {
+		return fmt.Errorf("upload part size %d is less than the min part size %d", c.uploadPartSize, MinUploadPartSize)
+	}
+	return nil
+}
+
+// downloadPartSize returns the size of the ranged GETs of a parallel download, which follows the upload part size,
+// so that every GET is served by one part.
+func (mcm *MinioChunkManager) downloadPartSize() int64 {
+	if mcm.uploadPartSize > 0 {
+		return int64(mcm.uploadPartSize)
+	}
+	return DefaultDownloadPartSize
+}
+
+// splitParts splits [0, @size) into the ranges of @partSize, the last one may be shorter.
+func splitParts(size int64, partSize int64) []Range {
+	parts := make([]Range, 0, (size+partSize-1)/partSize)
+	for off := int64(0); off < size; off += partSize {
+		length := partSize
+		if off+length > size {
+			length = size - off
+		}
+		parts = append(parts, Range{Offset: off, Length: length})
+	}
+	return parts
+}
+
+// parallelRead reads the @size bytes of @filePath with at most downloadConcurrency ranged GETs in parallel,
+// the first part is read from @object, which is the opened GET of the whole object.
+func (mcm *MinioChunkManager) parallelRead(ctx context.Context, filePath string, object io.Reader, size int64) ([]byte, error) {
+	data := make([]byte, size)
+	parts := splitParts(size, mcm.downloadPartSize())
+	_, err := parallelMultiDo(ctx, parts, mcm.downloadConcurrency, func(ctx context.Context, part Range) (struct{}, error) {
+		buf := data[part.Offset : part.Offset+part.Length]
+		if part.Offset == 0 {
+			_, err := io.ReadFull(object, buf)
+			return struct{}{}, err
+		}
+		content, err := mcm.ReadAt(ctx, filePath, part.Offset, part.Length)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if int64(len(content)) < part.Length {
+			return struct{}{}, fmt.Errorf("short read of %s at offset %d, expected %d bytes, got %d bytes",
+				filePath, part.Offset, part.Length, len(content))
+		}
+		copy(buf, content)
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
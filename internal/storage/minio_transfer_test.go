@@ -0,0 +1,67 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitParts(t *testing.T) {
+	assert.Equal(t, []Range{{0, 4}, {4, 4}, {8, 2}}, splitParts(10, 4))
+	assert.Equal(t, []Range{{0, 8}}, splitParts(8, 8))
+	assert.Empty(t, splitParts(0, 8))
+}
+
+func TestValidateTransferConfig(t *testing.T) {
+	c := newDefaultConfig()
+	assert.NoError(t, validateTransferConfig(c))
+	WithUploadPartSize(MinUploadPartSize)(c)
+	assert.NoError(t, validateTransferConfig(c))
+	WithUploadPartSize(1 << 20)(c)
+	assert.Error(t, validateTransferConfig(c))
+}
+
+func TestMinioChunkManagerTransfer(t *testing.T) {
+	ctx := context.Background()
+	testBucket, err := Params.Load("minio.bucketName")
+	require.NoError(t, err)
+	testCM, err := newMinIOChunkManager(ctx, testBucket, "test-transfer")
+	require.NoError(t, err)
+	defer testCM.RemoveWithPrefix(ctx, testCM.RootPath())
+	testCM.uploadPartSize = MinUploadPartSize
+	testCM.uploadConcurrency = 4
+	testCM.downloadConcurrency = 4
+
+	content := make([]byte, 3*MinUploadPartSize+123)
+	rand.Read(content)
+	filePath := path.Join(testCM.RootPath(), "large")
+	require.NoError(t, testCM.Write(ctx, filePath, content))
+
+	read, err := testCM.Read(ctx, filePath)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(content, read))
+
+	_, err = testCM.Read(ctx, path.Join(testCM.RootPath(), "missing"))
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+}
@@ -0,0 +1,116 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// TransportConfig tunes the HTTP transport of MinioChunkManager, the zero values keep the defaults of minio-go,
+// whose idle connections per host are too few for the parallel loads of a node.
+type TransportConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost bounds the connections including the active ones, zero means no limit
+	MaxConnsPerHost       int
+	DialTimeout           time.Duration
+	IdleConnTimeout       time.Duration
+	ResponseHeaderTimeout time.Duration
+	// TLSConfig replaces the TLS config of the SSL connections
+	TLSConfig *tls.Config
+	// UnixSocket makes the requests go through the unix socket instead of the address of the endpoint,
+	// e.g. a sidecar proxy of the object storage
+	UnixSocket string
+}
+
+// NewTLSConfig returns the TLS config trusting the CA certificates in @caCertFile besides the system ones,
+// empty @caCertFile means only the system ones.
+func NewTLSConfig(caCertFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec
+	}
+	if caCertFile == "" {
+		return tlsConfig, nil
+	}
+	pem, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no CA certificate found in %s", caCertFile)
+	}
+	tlsConfig.RootCAs = rootCAs
+	return tlsConfig, nil
+}
+
+// newMinioTransport returns the default transport of minio-go tuned by the transport config in @c.
+func newMinioTransport(c *config) (*http.Transport, error) {
+	transport, err := minio.DefaultTransport(c.useSSL)
+	if err != nil {
+		return nil, err
+	}
+	tc := c.transport
+	if tc.MaxIdleConns > 0 {
+		transport.MaxIdleConns = tc.MaxIdleConns
+	}
+	if tc.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = tc.MaxIdleConnsPerHost
+	}
+	if tc.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = tc.MaxConnsPerHost
+	}
+	if tc.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = tc.IdleConnTimeout
+	}
+	if tc.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = tc.ResponseHeaderTimeout
+	}
+	if tc.TLSConfig != nil && c.useSSL {
+		transport.TLSClientConfig = tc.TLSConfig.Clone()
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if tc.DialTimeout > 0 {
+		dialer.Timeout = tc.DialTimeout
+	}
+	transport.DialContext = dialer.DialContext
+	if tc.UnixSocket != "" {
+		socket := tc.UnixSocket
+		// the requests keep the host of the endpoint, only the connections go to the socket
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+	}
+	return transport, nil
+}
@@ -0,0 +1,92 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMinioTransport(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		transport, err := newMinioTransport(newDefaultConfig())
+		require.NoError(t, err)
+		assert.Equal(t, 256, transport.MaxIdleConns)
+		assert.Equal(t, 16, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	})
+
+	t.Run("tuned", func(t *testing.T) {
+		tlsConfig, err := NewTLSConfig("", true)
+		require.NoError(t, err)
+		c := newDefaultConfig()
+		UseSSL(true)(c)
+		Transport(TransportConfig{
+			MaxIdleConns:        1024,
+			MaxIdleConnsPerHost: 512,
+			MaxConnsPerHost:     600,
+			IdleConnTimeout:     5 * time.Minute,
+			TLSConfig:           tlsConfig,
+		})(c)
+		transport, err := newMinioTransport(c)
+		require.NoError(t, err)
+		assert.Equal(t, 1024, transport.MaxIdleConns)
+		assert.Equal(t, 512, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, 600, transport.MaxConnsPerHost)
+		assert.Equal(t, 5*time.Minute, transport.IdleConnTimeout)
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+	})
+
+	t.Run("unix socket", func(t *testing.T) {
+		socket := path.Join(t.TempDir(), "s3.sock")
+		listener, err := net.Listen("unix", socket)
+		require.NoError(t, err)
+		server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, r.Host)
+		})}
+		go server.Serve(listener)
+		defer server.Close()
+
+		c := newDefaultConfig()
+		Transport(TransportConfig{UnixSocket: socket})(c)
+		transport, err := newMinioTransport(c)
+		require.NoError(t, err)
+		resp, err := (&http.Client{Transport: transport}).Get("http://s3.example.com:9000/bucket")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "s3.example.com:9000", string(body))
+	})
+
+	t.Run("invalid ca", func(t *testing.T) {
+		caCertFile := path.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(caCertFile, []byte("not a certificate"), 0600))
+		_, err := NewTLSConfig(caCertFile, false)
+		assert.Error(t, err)
+		_, err = NewTLSConfig(path.Join(t.TempDir(), "missing.pem"), false)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,315 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/mmap"
+)
+
+// bucketRoute is a named bucket serving the object keys with prefix.
+type bucketRoute struct {
+	name   string
+	prefix string
+	cm     ChunkManager
+}
+
+// MultiBucketChunkManager routes the object keys to the chunk managers of the named buckets by their prefixes,
+// the keys matching no prefix go to the default chunk manager. The keys are not rewritten, so the objects of a bucket
+// are stored under its prefix, and the listings covering several buckets are merged.
+type MultiBucketChunkManager struct {
+	ChunkManager
+	// routes are sorted by prefix length descending, so that the longest prefix matches first
+	routes []bucketRoute
+}
+
+var _ ChunkManager = (*MultiBucketChunkManager)(nil)
+
+// NewMultiBucketChunkManager returns a MultiBucketChunkManager sending all the keys to @defaultCM until buckets are added.
+func NewMultiBucketChunkManager(defaultCM ChunkManager) *MultiBucketChunkManager {
+	return &MultiBucketChunkManager{ChunkManager: defaultCM}
+}
+
+// AddBucket routes the keys with @prefix to @cm, it is not safe to call it while the chunk manager is in use.
+func (m *MultiBucketChunkManager) AddBucket(name string, prefix string, cm ChunkManager) {
+	m.routes = append(m.routes, bucketRoute{name: name, prefix: prefix, cm: cm})
+	sort.SliceStable(m.routes, func(i, j int) bool {
+		return len(m.routes[i].prefix) > len(m.routes[j].prefix)
+	})
+}
+
+// Buckets returns the names of the added buckets.
+func (m *MultiBucketChunkManager) Buckets() []string {
+	names := make([]string, 0, len(m.routes))
+	for _, route := range m.routes {
+		names = append(names, route.name)
+	}
+	return names
+}
+
+// route returns the chunk manager serving @filePath.
+func (m *MultiBucketChunkManager) route(filePath string) ChunkManager {
+	for _, route := range m.routes {
+		if strings.HasPrefix(filePath, route.prefix) {
+			return route.cm
+		}
+	}
+	return m.ChunkManager
+}
+
+// prefixTargets returns the chunk managers which may have the objects with @prefix,
+// that is the one serving @prefix and the buckets whose prefixes are under @prefix.
+func (m *MultiBucketChunkManager) prefixTargets(prefix string) []ChunkManager {
+	targets := []ChunkManager{m.route(prefix)}
+	for _, route := range m.routes {
+		if strings.HasPrefix(route.prefix, prefix) && route.cm != targets[0] {
+			targets = append(targets, route.cm)
+		}
+	}
+	return targets
+}
+
+// groupPaths groups the indexes of @filePaths by the chunk managers serving them.
+func (m *MultiBucketChunkManager) groupPaths(filePaths []string) map[ChunkManager][]int {
+	groups := make(map[ChunkManager][]int)
+	for i, filePath := range filePaths {
+		cm := m.route(filePath)
+		groups[cm] = append(groups[cm], i)
+	}
+	return groups
+}
+
+func pickPaths(filePaths []string, indexes []int) []string {
+	picked := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		picked = append(picked, filePaths[i])
+	}
+	return picked
+}
+
+func (m *MultiBucketChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	return m.route(filePath).Path(ctx, filePath)
+}
+
+func (m *MultiBucketChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	return m.route(filePath).Size(ctx, filePath)
+}
+
+func (m *MultiBucketChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	return m.route(filePath).Stat(ctx, filePath)
+}
+
+func (m *MultiBucketChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	results := make([]*ObjectInfo, len(filePaths))
+	for cm, indexes := range m.groupPaths(filePaths) {
+		infos, err := cm.MultiStat(ctx, pickPaths(filePaths, indexes))
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range indexes {
+			results[i] = infos[j]
+		}
+	}
+	return results, nil
+}
+
+func (m *MultiBucketChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	return m.route(filePath).Write(ctx, filePath, content)
+}
+
+func (m *MultiBucketChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	return m.route(filePath).WriteWithOptions(ctx, filePath, content, opts...)
+}
+
+func (m *MultiBucketChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	return m.route(filePath).WriteIfNotExist(ctx, filePath, content)
+}
+
+func (m *MultiBucketChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	groups := make(map[ChunkManager]map[string][]byte)
+	for filePath, content := range contents {
+		cm := m.route(filePath)
+		if groups[cm] == nil {
+			groups[cm] = make(map[string][]byte)
+		}
+		groups[cm][filePath] = content
+	}
+	for cm, group := range groups {
+		if err := cm.MultiWrite(ctx, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MultiBucketChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	return m.route(filePath).Append(ctx, filePath, content)
+}
+
+// Copy copies within the bucket if both paths are served by it, otherwise the object is read and written again.
+func (m *MultiBucketChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	src, dst := m.route(srcFilePath), m.route(dstFilePath)
+	if src == dst {
+		return src.Copy(ctx, srcFilePath, dstFilePath)
+	}
+	content, err := src.Read(ctx, srcFilePath)
+	if err != nil {
+		return err
+	}
+	return dst.Write(ctx, dstFilePath, content)
+}
+
+// Move moves within the bucket if both paths are served by it, otherwise the object is copied and then removed.
+func (m *MultiBucketChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	src, dst := m.route(srcFilePath), m.route(dstFilePath)
+	if src == dst {
+		return src.Move(ctx, srcFilePath, dstFilePath)
+	}
+	if err := m.Copy(ctx, srcFilePath, dstFilePath); err != nil {
+		return err
+	}
+	return src.Remove(ctx, srcFilePath)
+}
+
+func (m *MultiBucketChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	return m.route(filePath).PresignURL(ctx, filePath, method, expiry)
+}
+
+func (m *MultiBucketChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	return m.route(filePath).Exist(ctx, filePath)
+}
+
+func (m *MultiBucketChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	return m.route(filePath).Read(ctx, filePath)
+}
+
+func (m *MultiBucketChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	return m.route(filePath).Reader(ctx, filePath)
+}
+
+func (m *MultiBucketChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	results := make([][]byte, len(filePaths))
+	for cm, indexes := range m.groupPaths(filePaths) {
+		contents, err := cm.MultiRead(ctx, pickPaths(filePaths, indexes))
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range indexes {
+			results[i] = contents[j]
+		}
+	}
+	return results, nil
+}
+
+// ListWithPrefix lists all the buckets which may have the objects with @prefix, the keys are kept only if listed from
+// the bucket serving them, so the objects of the default bucket shadowed by a named bucket are hidden.
+func (m *MultiBucketChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	var filePaths []string
+	var modTimes []time.Time
+	for _, cm := range m.prefixTargets(prefix) {
+		paths, times, err := cm.ListWithPrefix(ctx, prefix, recursive)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, filePath := range paths {
+			if m.route(filePath) == cm {
+				filePaths = append(filePaths, filePath)
+				modTimes = append(modTimes, times[i])
+			}
+		}
+	}
+	return filePaths, modTimes, nil
+}
+
+func (m *MultiBucketChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	stopped := false
+	for _, cm := range m.prefixTargets(prefix) {
+		cm := cm
+		err := cm.WalkWithPrefix(ctx, prefix, recursive, func(info ChunkObjectInfo) bool {
+			if m.route(info.FilePath) != cm {
+				return true
+			}
+			stopped = !walkFunc(info)
+			return !stopped
+		})
+		if err != nil || stopped {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrefixSize walks the buckets one by one like WalkWithPrefix, so the objects shadowed by a named bucket are not counted.
+func (m *MultiBucketChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	return parallelPrefixSize(ctx, prefix, 1, m.WalkWithPrefix)
+}
+
+func (m *MultiBucketChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	var filePaths []string
+	var contents [][]byte
+	for _, cm := range m.prefixTargets(prefix) {
+		paths, datas, err := cm.ReadWithPrefix(ctx, prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, filePath := range paths {
+			if m.route(filePath) == cm {
+				filePaths = append(filePaths, filePath)
+				contents = append(contents, datas[i])
+			}
+		}
+	}
+	return filePaths, contents, nil
+}
+
+func (m *MultiBucketChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	return m.route(filePath).Mmap(ctx, filePath)
+}
+
+func (m *MultiBucketChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	return m.route(filePath).ReadAt(ctx, filePath, off, length)
+}
+
+func (m *MultiBucketChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	return m.route(filePath).MultiReadAt(ctx, filePath, ranges)
+}
+
+func (m *MultiBucketChunkManager) Remove(ctx context.Context, filePath string) error {
+	return m.route(filePath).Remove(ctx, filePath)
+}
+
+func (m *MultiBucketChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	for cm, indexes := range m.groupPaths(filePaths) {
+		if err := cm.MultiRemove(ctx, pickPaths(filePaths, indexes)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MultiBucketChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	for _, cm := range m.prefixTargets(prefix) {
+		if err := cm.RemoveWithPrefix(ctx, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiBucketChunkManager(t *testing.T) {
+	ctx := context.Background()
+	defaultCM := NewLocalChunkManager(RootPath(t.TempDir() + "/"))
+	datasets := NewLocalChunkManager(RootPath(t.TempDir() + "/"))
+	public := NewLocalChunkManager(RootPath(t.TempDir() + "/"))
+	cm := NewMultiBucketChunkManager(defaultCM)
+	cm.AddBucket("datasets", "datasets/", datasets)
+	cm.AddBucket("public", "datasets/public/", public)
+	assert.Equal(t, []string{"public", "datasets"}, cm.Buckets())
+
+	require.NoError(t, cm.MultiWrite(ctx, map[string][]byte{
+		"files/a":               []byte("a"),
+		"datasets/b":            []byte("b"),
+		"datasets/public/c":     []byte("c"),
+		"datasets-not-shadowed": []byte("d"),
+	}))
+	// the longest prefix wins
+	exist, err := public.Exist(ctx, "datasets/public/c")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	exist, err = datasets.Exist(ctx, "datasets/public/c")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	exist, err = defaultCM.Exist(ctx, "datasets-not-shadowed")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	contents, err := cm.MultiRead(ctx, []string{"datasets/public/c", "files/a", "datasets/b"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("c"), []byte("a"), []byte("b")}, contents)
+
+	infos, err := cm.MultiStat(ctx, []string{"datasets/b", "datasets/not-exist"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), infos[0].Size)
+	assert.Nil(t, infos[1])
+
+	// the objects of the default bucket shadowed by a named bucket are hidden
+	require.NoError(t, defaultCM.Write(ctx, "datasets/shadowed", []byte("x")))
+	filePaths, _, err := cm.ListWithPrefix(ctx, "datasets", true)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"datasets/b", "datasets/public/c", "datasets-not-shadowed"}, filePaths)
+	filePaths, contents, err = cm.ReadWithPrefix(ctx, "datasets/")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"datasets/b", "datasets/public/c"}, filePaths)
+	assert.Len(t, contents, 2)
+
+	walked := 0
+	err = cm.WalkWithPrefix(ctx, "", true, func(info ChunkObjectInfo) bool {
+		walked++
+		return walked < 2
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, walked)
+
+	// copy and move across buckets
+	require.NoError(t, cm.Copy(ctx, "datasets/public/c", "files/c"))
+	content, err := defaultCM.Read(ctx, "files/c")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("c"), content)
+	require.NoError(t, cm.Move(ctx, "files/a", "datasets/a"))
+	content, err = datasets.Read(ctx, "datasets/a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a"), content)
+	exist, err = cm.Exist(ctx, "files/a")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	require.NoError(t, cm.MultiRemove(ctx, []string{"datasets/a", "files/c"}))
+	require.NoError(t, cm.RemoveWithPrefix(ctx, "datasets/"))
+	filePaths, _, err = cm.ListWithPrefix(ctx, "", true)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"datasets-not-shadowed"}, filePaths)
+}
@@ -1,5 +1,12 @@
 package storage
 
+import (
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/storage/azure"
+)
+
 // Option for setting params used by chunk manager client.
 type config struct {
 	address           string
@@ -12,12 +19,143 @@ type config struct {
 	useIAM            bool
 	cloudProvider     string
 	iamEndpoint       string
+	// assumeRole makes MinioChunkManager access the storage with the credentials of the assumed IAM role
+	assumeRole AssumeRoleConfig
+	// gcpAuthMode and gcpCredentialsFile are how MinioChunkManager gets the tokens of GCS when useIAM
+	gcpAuthMode        string
+	gcpCredentialsFile string
+	// azure* are how AzureChunkManager authorizes the requests to Azure Blob Storage, see azure.NewTokenRefresher
+	azureAuthMode      string
+	azureClientID      string
+	azureSASTokenFile  string
+	azureSASRefresher  azure.TokenRefresher
+	azureRefreshWindow time.Duration
+	concurrency        int
+	fsync              bool
+	// journal makes LocalChunkManager record the mutations in a write-ahead journal
+	journal bool
+	// selectPushdown makes MinioChunkManager evaluate the select expressions with S3 Select
+	selectPushdown bool
+	// prefetchWindow is the max number of objects downloaded ahead of the reads by the Prefetch hints
+	prefetchWindow int
+	// smallObjectThreshold is the size below which RocksChunkManager packs the objects into RocksDB
+	smallObjectThreshold int64
+	// listObjectMetadata fetches user metadata and tags of every listed object
+	listObjectMetadata bool
+	// governor bounds the object storage requests of the process
+	governorMaxConcurrency int
+	governorMaxBandwidth   int64
+	governorHostSocket     string
+	// part size and parallelism of the transfers of MinioChunkManager
+	uploadPartSize      uint64
+	uploadConcurrency   int
+	downloadConcurrency int
+	// throttle makes MinioChunkManager adapt the concurrency of the requests to the throttling of the storage
+	throttleEnabled        bool
+	throttleMinConcurrency int
+	throttleMaxConcurrency int
+	// transport tunes the HTTP transport of MinioChunkManager
+	transport TransportConfig
+	// object lock applied to the written objects
+	objectLockMode      string
+	objectLockRetention time.Duration
+	objectLockLegalHold bool
+	// disk quota of the local root path, the watermarks are usage ratios of the file system
+	diskHighWatermark float64
+	diskLowWatermark  float64
+	diskEvictFunc     DiskEvictFunc
+	// readOnly wraps the chunk manager created by ChunkManagerFactory with ReadOnlyChunkManager
+	readOnly bool
+	// dedup wraps the chunk manager created by ChunkManagerFactory with DedupChunkManager,
+	// which deduplicates the objects no smaller than dedupMinSize
+	dedup        bool
+	dedupMinSize int64
+	// storageMarker makes ChunkManagerFactory write the storage marker, or verify it if the endpoint is overridden
+	storageMarker      bool
+	endpointOverridden bool
+	// chaos wraps the chunk manager created by ChunkManagerFactory with ChaosChunkManager
+	chaos ChaosConfigFunc
+	// requesterPays makes MinioChunkManager pay the reads of a requester pays bucket
+	requesterPays bool
+	// buckets are the named buckets routed by MultiBucketChunkManager
+	buckets []BucketConfig
+	// shardBuckets and shardPrefixes make ChunkManagerFactory distribute the object keys by ShardedChunkManager
+	shardBuckets  []string
+	shardPrefixes int
+	// replicationTarget makes ChunkManagerFactory replicate the changes to the storage created with these options
+	replicationTarget   []Option
+	replicationQueueDir string
+	replicationInterval time.Duration
+	// storage classes of the written objects, and of the aged objects transitioned by TransitionStorageClass
+	storageClass       string
+	storageClassPolicy StorageClassPolicy
+	// prefixSizeCacheTTL caches the results of PrefixSize, zero disables the cache
+	prefixSizeCacheTTL time.Duration
+	// cacheValidateInterval makes VectorChunkManager validate the cached vector files by their ETags, zero never validates
+	cacheValidateInterval time.Duration
+	// auditFilePath makes ChunkManagerFactory record the destructive operations into the file, empty disables it
+	auditFilePath  string
+	auditMaxSizeMB int
+	auditOverwrite bool
+	// tenantDefaultQuota bounds the bytes of every tenant of TenantChunkManager, zero means no limit
+	tenantDefaultQuota int64
+	// tenantQuotas overrides tenantDefaultQuota of the tenants
+	tenantQuotas map[string]int64
+	// legacyRootPaths make ChunkManagerFactory read the objects not found under rootPath from these root paths
+	legacyRootPaths []string
+	// memoryCapacity bounds the total bytes of the objects kept by MemoryChunkManager, zero means no limit
+	memoryCapacity int64
+	// gatewayAddress is the storage gateway served to RemoteChunkManager, whose messages are bounded by gatewayMaxMessageSize
+	gatewayAddress        string
+	gatewayMaxMessageSize int
+	// gatewayToken and the TLS config of gatewayTLSMode authenticate the gateway and RemoteChunkManager
+	gatewayToken    string
+	gatewayTLSMode  int
+	gatewayCertPath string
+	gatewayKeyPath  string
+	gatewayCAPath   string
 }
 
 func newDefaultConfig() *config {
 	return &config{}
 }
 
+// BackendConfig is the generic part of the options, which is read by the backends registered by RegisterFactory.
+type BackendConfig struct {
+	Address           string
+	BucketName        string
+	AccessKeyID       string
+	SecretAccessKeyID string
+	UseSSL            bool
+	CreateBucket      bool
+	RootPath          string
+	UseIAM            bool
+	CloudProvider     string
+	IAMEndpoint       string
+	Concurrency       int
+}
+
+// NewBackendConfig applies @opts and returns the generic part of them.
+func NewBackendConfig(opts ...Option) BackendConfig {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return BackendConfig{
+		Address:           c.address,
+		BucketName:        c.bucketName,
+		AccessKeyID:       c.accessKeyID,
+		SecretAccessKeyID: c.secretAccessKeyID,
+		UseSSL:            c.useSSL,
+		CreateBucket:      c.createBucket,
+		RootPath:          c.rootPath,
+		UseIAM:            c.useIAM,
+		CloudProvider:     c.cloudProvider,
+		IAMEndpoint:       c.iamEndpoint,
+		Concurrency:       c.concurrency,
+	}
+}
+
 // Option is used to config the retry function.
 type Option func(*config)
 
@@ -79,3 +217,449 @@ func IAMEndpoint(iamEndpoint string) Option {
 		c.iamEndpoint = iamEndpoint
 	}
 }
+
+// AssumeRole makes MinioChunkManager access the storage with the temporary credentials of the IAM role of @cfg,
+// which are assumed through STS by the access keys or the IAM credentials, and refreshed before they expire.
+// An empty role ARN disables it.
+func AssumeRole(cfg AssumeRoleConfig) Option {
+	return func(c *config) {
+		c.assumeRole = cfg
+	}
+}
+
+// GCPAuth sets how MinioChunkManager gets the tokens of GCS when IAM is used, see gcp.NewTokenSource.
+func GCPAuth(mode string, credentialsFile string) Option {
+	return func(c *config) {
+		c.gcpAuthMode = mode
+		c.gcpCredentialsFile = credentialsFile
+	}
+}
+
+// AzureAuth sets how AzureChunkManager authorizes the requests to Azure Blob Storage, see azure.NewTokenRefresher,
+// the tokens are refreshed @refreshWindow before they expire.
+func AzureAuth(mode string, clientID string, sasTokenFile string, refreshWindow time.Duration) Option {
+	return func(c *config) {
+		c.azureAuthMode = mode
+		c.azureClientID = clientID
+		c.azureSASTokenFile = sasTokenFile
+		c.azureRefreshWindow = refreshWindow
+	}
+}
+
+// AzureSASTokenRefresher sets the callback getting the SAS tokens of Azure Blob Storage instead of the SAS token file,
+// it's called again before the current token expires.
+func AzureSASTokenRefresher(refresher azure.TokenRefresher) Option {
+	return func(c *config) {
+		c.azureSASRefresher = refresher
+	}
+}
+
+// Concurrency sets the max number of goroutines used by MultiRead and MultiWrite,
+// values less than or equal to 1 keep them sequential.
+func Concurrency(concurrency int) Option {
+	return func(c *config) {
+		c.concurrency = concurrency
+	}
+}
+
+// PrefixSizeCacheTTL caches the results of PrefixSize for @ttl, so that the usage reported periodically
+// doesn't list the whole prefixes every time. Zero disables the cache.
+func PrefixSizeCacheTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.prefixSizeCacheTTL = ttl
+	}
+}
+
+// CacheValidateInterval makes VectorChunkManager validate the vector files cached for longer than @interval
+// by ReadIfModified before reading them, so that the vector files rewritten in the storage aren't served stale.
+// Zero trusts the cached vector files until they're evicted.
+func CacheValidateInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.cacheValidateInterval = interval
+	}
+}
+
+// PrefetchWindow makes the remote chunk managers download at most @window objects hinted by Prefetch ahead of the reads,
+// zero disables the readahead.
+func PrefetchWindow(window int) Option {
+	return func(c *config) {
+		c.prefetchWindow = window
+	}
+}
+
+// SelectPushdown makes MinioChunkManager evaluate the expressions of SelectObject with S3 Select on the Parquet objects,
+// otherwise the objects are read entirely and filtered locally.
+func SelectPushdown(enabled bool) Option {
+	return func(c *config) {
+		c.selectPushdown = enabled
+	}
+}
+
+// LegacyRootPaths makes ChunkManagerFactory wrap the chunk managers with AliasChunkManager, so that the objects
+// written under the root paths used before the current one are still readable after the root path is renamed.
+func LegacyRootPaths(rootPaths ...string) Option {
+	return func(c *config) {
+		c.legacyRootPaths = rootPaths
+	}
+}
+
+// MemoryCapacity makes MemoryChunkManager reject the writes which push the total bytes of its objects above @capacity,
+// zero means no limit.
+func MemoryCapacity(capacity int64) Option {
+	return func(c *config) {
+		c.memoryCapacity = capacity
+	}
+}
+
+// SmallObjectThreshold makes RocksChunkManager pack the objects smaller than @threshold bytes into RocksDB,
+// the others are kept as files.
+func SmallObjectThreshold(threshold int64) Option {
+	return func(c *config) {
+		c.smallObjectThreshold = threshold
+	}
+}
+
+// WithFsync makes LocalChunkManager flush written files to disk before returning,
+// which trades write throughput for crash safety.
+func WithFsync(fsync bool) Option {
+	return func(c *config) {
+		c.fsync = fsync
+	}
+}
+
+// WithJournal makes LocalChunkManager record the mutations in a write-ahead journal under the root path,
+// the operations interrupted by a crash are replayed or rolled back when it's created again.
+func WithJournal(journal bool) Option {
+	return func(c *config) {
+		c.journal = journal
+	}
+}
+
+// ListObjectMetadata makes WalkWithPrefix of MinioChunkManager fetch the user metadata and tags of the walked objects,
+// which costs two more requests per object. ListWithPrefix returns no metadata, so it never fetches them.
+func ListObjectMetadata(listObjectMetadata bool) Option {
+	return func(c *config) {
+		c.listObjectMetadata = listObjectMetadata
+	}
+}
+
+// IOGovernorLimits bounds the in-flight object storage requests and their bandwidth in bytes per second
+// of all MinioChunkManagers in the process, zero means unlimited.
+// If @hostSocket is not empty, the limits are shared by all processes on the host using the same socket.
+func IOGovernorLimits(maxConcurrency int, maxBandwidth int64, hostSocket string) Option {
+	return func(c *config) {
+		c.governorMaxConcurrency = maxConcurrency
+		c.governorMaxBandwidth = maxBandwidth
+		c.governorHostSocket = hostSocket
+	}
+}
+
+// WithUploadPartSize sets the part size in bytes of the multipart uploads of MinioChunkManager, which must be
+// at least MinUploadPartSize. Zero means the part size chosen by minio-go.
+func WithUploadPartSize(partSize uint64) Option {
+	return func(c *config) {
+		c.uploadPartSize = partSize
+	}
+}
+
+// WithUploadConcurrency sets the max parts uploaded in parallel by a multipart upload of MinioChunkManager,
+// zero means the default of minio-go.
+func WithUploadConcurrency(concurrency int) Option {
+	return func(c *config) {
+		c.uploadConcurrency = concurrency
+	}
+}
+
+// WithDownloadConcurrency makes MinioChunkManager read the objects larger than the upload part size, or
+// DefaultDownloadPartSize if it's not set, with at most @concurrency ranged GETs of the part size in parallel.
+// Values less than or equal to 1 read the objects sequentially.
+func WithDownloadConcurrency(concurrency int) Option {
+	return func(c *config) {
+		c.downloadConcurrency = concurrency
+	}
+}
+
+// AdaptiveThrottling makes all MinioChunkManagers in the process share an AdaptiveThrottler, whose limit of
+// the in-flight requests adapts between @minConcurrency and @maxConcurrency to the throttling of the storage.
+func AdaptiveThrottling(enabled bool, minConcurrency int, maxConcurrency int) Option {
+	return func(c *config) {
+		c.throttleEnabled = enabled
+		c.throttleMinConcurrency = minConcurrency
+		c.throttleMaxConcurrency = maxConcurrency
+	}
+}
+
+// Transport tunes the connection pool, timeouts and TLS of the HTTP transport of MinioChunkManager,
+// the zero fields of @transport keep the defaults.
+func Transport(transport TransportConfig) Option {
+	return func(c *config) {
+		c.transport = transport
+	}
+}
+
+// ObjectLock makes MinioChunkManager write the objects with S3 object lock, the objects can't be removed
+// until @retention passes with @mode (GOVERNANCE or COMPLIANCE), or while the legal hold is on.
+// The bucket must have object lock enabled, which happens when it is created by the chunk manager.
+func ObjectLock(mode string, retention time.Duration, legalHold bool) Option {
+	return func(c *config) {
+		c.objectLockMode = mode
+		c.objectLockRetention = retention
+		c.objectLockLegalHold = legalHold
+	}
+}
+
+// DiskQuota makes LocalChunkManager reject the writes which push the disk usage of the root path above @highWatermark,
+// a warning is logged once the usage exceeds @lowWatermark. The watermarks are ratios in (0, 1], zero disables the quota.
+func DiskQuota(highWatermark float64, lowWatermark float64) Option {
+	return func(c *config) {
+		c.diskHighWatermark = highWatermark
+		c.diskLowWatermark = lowWatermark
+	}
+}
+
+// WithDiskEvictFunc makes LocalChunkManager call @evict to free disk space instead of rejecting the write
+// when the disk usage exceeds the high watermark of DiskQuota.
+func WithDiskEvictFunc(evict DiskEvictFunc) Option {
+	return func(c *config) {
+		c.diskEvictFunc = evict
+	}
+}
+
+// StorageAudit makes ChunkManagerFactory create chunk managers recording the destructive operations into the local file
+// @filePath, which is rotated once it reaches @maxSizeMB, see AuditChunkManager. An empty @filePath disables it.
+func StorageAudit(filePath string, maxSizeMB int, overwrite bool) Option {
+	return func(c *config) {
+		c.auditFilePath = filePath
+		c.auditMaxSizeMB = maxSizeMB
+		c.auditOverwrite = overwrite
+	}
+}
+
+// TenantQuotas makes TenantChunkManager reject the writes which push the bytes of a tenant above its quota in @quotas,
+// or @defaultQuota for the tenants not in @quotas. Non-positive quotas mean no limit.
+func TenantQuotas(defaultQuota int64, quotas map[string]int64) Option {
+	return func(c *config) {
+		c.tenantDefaultQuota = defaultQuota
+		c.tenantQuotas = quotas
+	}
+}
+
+// ReadOnly makes ChunkManagerFactory create read-only chunk managers, whose mutations fail with ErrReadOnly.
+func ReadOnly(readOnly bool) Option {
+	return func(c *config) {
+		c.readOnly = readOnly
+	}
+}
+
+// Dedup makes ChunkManagerFactory create chunk managers storing the objects no smaller than @minSize bytes by their content hashes.
+func Dedup(enabled bool, minSize int64) Option {
+	return func(c *config) {
+		c.dedup = enabled
+		c.dedupMinSize = minSize
+	}
+}
+
+// StorageMarker makes ChunkManagerFactory check the object keys resolve identically through the overridden endpoints,
+// the chunk managers with @overridden endpoint verify the storage marker written by the others exists.
+func StorageMarker(enabled bool, overridden bool) Option {
+	return func(c *config) {
+		c.storageMarker = enabled
+		c.endpointOverridden = overridden
+	}
+}
+
+// Chaos makes ChunkManagerFactory create chunk managers injecting the faults configured by @configFunc,
+// which is read again while running, nil disables it.
+func Chaos(configFunc ChaosConfigFunc) Option {
+	return func(c *config) {
+		c.chaos = configFunc
+	}
+}
+
+// RequesterPays makes MinioChunkManager pay the reads of a requester pays bucket owned by another account,
+// the bucket is not checked or created at start as HeadBucket couldn't be paid by the requester.
+func RequesterPays(requesterPays bool) Option {
+	return func(c *config) {
+		c.requesterPays = requesterPays
+	}
+}
+
+// BucketConfig is a named bucket serving the object keys with Prefix, its chunk manager is created
+// with Opts applied after the options of the default bucket.
+type BucketConfig struct {
+	Name   string
+	Prefix string
+	Opts   []Option
+}
+
+// WithBuckets makes ChunkManagerFactory route the object keys with the prefixes of @buckets to their own buckets,
+// the keys matching no prefix go to the default bucket.
+func WithBuckets(buckets ...BucketConfig) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// KeySharding makes ChunkManagerFactory distribute the object keys by hash over @prefixes top-level prefixes
+// of every bucket in @buckets, @buckets could be empty for the default bucket only.
+func KeySharding(buckets []string, prefixes int) Option {
+	return func(c *config) {
+		c.shardBuckets = buckets
+		c.shardPrefixes = prefixes
+	}
+}
+
+// Replication makes ChunkManagerFactory replicate the object changes asynchronously to the secondary storage,
+// which is created with @target applied after the options of the primary storage. The changes not replicated yet
+// are queued in the local directory @queueDir, and retried every @interval.
+func Replication(target []Option, queueDir string, interval time.Duration) Option {
+	return func(c *config) {
+		c.replicationTarget = target
+		c.replicationQueueDir = queueDir
+		c.replicationInterval = interval
+	}
+}
+
+// StorageClass makes MinioChunkManager write the objects with the S3 storage class chosen by @policy for the new
+// objects, or @defaultClass if no rule matches. The rules with min age are applied by TransitionStorageClass.
+func StorageClass(defaultClass string, policy StorageClassPolicy) Option {
+	return func(c *config) {
+		c.storageClass = defaultClass
+		c.storageClassPolicy = policy
+	}
+}
+
+// Gateway makes RemoteChunkManager access the storage through the storage gateway at @address,
+// @maxMessageSize bounds the bytes of the requests and responses, which bounds the objects to read or write.
+func Gateway(address string, maxMessageSize int) Option {
+	return func(c *config) {
+		c.gatewayAddress = address
+		c.gatewayMaxMessageSize = maxMessageSize
+	}
+}
+
+// GatewayAuth makes RemoteChunkManager send @token to the storage gateway, and connect it over TLS in the tls mode
+// of common.security.tlsMode, see GatewayTLSConfig.
+func GatewayAuth(token string, tlsMode int, certPath, keyPath, caPath string) Option {
+	return func(c *config) {
+		c.gatewayToken = token
+		c.gatewayTLSMode = tlsMode
+		c.gatewayCertPath = certPath
+		c.gatewayKeyPath = keyPath
+		c.gatewayCAPath = caPath
+	}
+}
+
+// Keys of the tags attached by WithBinlogTags.
+const (
+	ObjectTagCollectionID = "collection-id"
+	ObjectTagSegmentID    = "segment-id"
+	ObjectTagLogType      = "log-type"
+)
+
+// writeConfig holds the extra information attached to the object written by WriteWithOptions.
+type writeConfig struct {
+	userMetadata map[string]string
+	tags         map[string]string
+	storageClass string
+}
+
+func newWriteConfig(opts ...WriteOption) *writeConfig {
+	c := &writeConfig{
+		userMetadata: make(map[string]string),
+		tags:         make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WriteOption is used to config WriteWithOptions.
+type WriteOption func(*writeConfig)
+
+// WithUserMetadata attaches @metadata to the written object as user metadata.
+func WithUserMetadata(metadata map[string]string) WriteOption {
+	return func(c *writeConfig) {
+		for k, v := range metadata {
+			c.userMetadata[k] = v
+		}
+	}
+}
+
+// WithTags attaches @tags to the written object, which can be used by lifecycle rules of the storage.
+func WithTags(tags map[string]string) WriteOption {
+	return func(c *writeConfig) {
+		for k, v := range tags {
+			c.tags[k] = v
+		}
+	}
+}
+
+// WithStorageClass writes the object with the S3 storage class @class, which wins over the storage class policy.
+func WithStorageClass(class string) WriteOption {
+	return func(c *writeConfig) {
+		c.storageClass = class
+	}
+}
+
+// WithBinlogTags tags the written object with the collection, segment and log type of the binlog.
+func WithBinlogTags(collectionID UniqueID, segmentID UniqueID, logType string) WriteOption {
+	return WithTags(map[string]string{
+		ObjectTagCollectionID: strconv.FormatInt(collectionID, 10),
+		ObjectTagSegmentID:    strconv.FormatInt(segmentID, 10),
+		ObjectTagLogType:      logType,
+	})
+}
+
+// Defaults of the RemoteReaderAt created by NewRemoteReaderAt.
+const (
+	DefaultReaderAtBlockSize   = 4 * 1024 * 1024
+	DefaultReaderAtCacheBlocks = 16
+	DefaultReaderAtConcurrency = 4
+)
+
+// readerAtConfig holds the config of RemoteReaderAt.
+type readerAtConfig struct {
+	blockSize   int64
+	cacheBlocks int
+	concurrency int
+}
+
+func newReaderAtConfig(opts ...ReaderAtOption) *readerAtConfig {
+	c := &readerAtConfig{
+		blockSize:   DefaultReaderAtBlockSize,
+		cacheBlocks: DefaultReaderAtCacheBlocks,
+		concurrency: DefaultReaderAtConcurrency,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ReaderAtOption is used to config NewRemoteReaderAt.
+type ReaderAtOption func(*readerAtConfig)
+
+// ReaderAtBlockSize makes RemoteReaderAt fetch and cache the object in blocks of @size bytes.
+func ReaderAtBlockSize(size int64) ReaderAtOption {
+	return func(c *readerAtConfig) {
+		if size > 0 {
+			c.blockSize = size
+		}
+	}
+}
+
+// ReaderAtCacheBlocks makes RemoteReaderAt keep at most @blocks recently read blocks, zero disables the cache.
+func ReaderAtCacheBlocks(blocks int) ReaderAtOption {
+	return func(c *readerAtConfig) {
+		c.cacheBlocks = blocks
+	}
+}
+
+// ReaderAtConcurrency makes RemoteReaderAt fetch at most @concurrency blocks in parallel for one read.
+func ReaderAtConcurrency(concurrency int) ReaderAtOption {
+	return func(c *readerAtConfig) {
+		c.concurrency = concurrency
+	}
+}
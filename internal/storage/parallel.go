@@ -0,0 +1,212 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/milvus-io/milvus/internal/util/errorutil"
+)
+
+// parallelMultiRead calls @read for every path of @filePaths with at most @concurrency goroutines.
+// The results keep the order of @filePaths, the content of a failed path is nil,
+// and all errors are aggregated into an errorutil.ErrorList.
+func parallelMultiRead(ctx context.Context, filePaths []string, concurrency int,
+	read func(ctx context.Context, filePath string) ([]byte, error)) ([][]byte, error) {
+	return parallelMultiDo(ctx, filePaths, concurrency, read)
+}
+
+// parallelMultiStat calls @stat for every path of @filePaths with at most @concurrency goroutines.
+// The results keep the order of @filePaths, the info of a path which doesn't exist is nil,
+// and all other errors are aggregated into an errorutil.ErrorList.
+func parallelMultiStat(ctx context.Context, filePaths []string, concurrency int,
+	stat func(ctx context.Context, filePath string) (ObjectInfo, error)) ([]*ObjectInfo, error) {
+	return parallelMultiDo(ctx, filePaths, concurrency, func(ctx context.Context, filePath string) (*ObjectInfo, error) {
+		info, err := stat(ctx, filePath)
+		if errors.Is(err, ErrNoSuchKey) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &info, nil
+	})
+}
+
+// parallelMultiReadAt reads the @ranges of @filePath with @readAt, the adjacent or overlapping ranges are
+// coalesced into one read, and the coalesced reads are issued with at most @concurrency goroutines.
+// The results keep the order of @ranges, and the results of the coalesced ranges share memory.
+func parallelMultiReadAt(ctx context.Context, filePath string, ranges []Range, concurrency int,
+	readAt func(ctx context.Context, filePath string, off int64, length int64) ([]byte, error)) ([][]byte, error) {
+	for _, r := range ranges {
+		if r.Offset < 0 || r.Length < 0 {
+			return nil, fmt.Errorf("invalid range of %s, offset: %d, length: %d", filePath, r.Offset, r.Length)
+		}
+	}
+
+	merged := coalesceRanges(ranges)
+	contents, err := parallelMultiDo(ctx, merged, concurrency, func(ctx context.Context, m coalescedRange) ([]byte, error) {
+		if m.Length == 0 {
+			return []byte{}, nil
+		}
+		return readAt(ctx, filePath, m.Offset, m.Length)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(ranges))
+	for i, m := range merged {
+		content := contents[i]
+		if int64(len(content)) < m.Length {
+			return nil, fmt.Errorf("short read of %s at offset %d, expected %d bytes, got %d bytes",
+				filePath, m.Offset, m.Length, len(content))
+		}
+		for _, idx := range m.members {
+			start := ranges[idx].Offset - m.Offset
+			results[idx] = content[start : start+ranges[idx].Length : start+ranges[idx].Length]
+		}
+	}
+	return results, nil
+}
+
+// coalescedRange is a range covering the adjacent or overlapping @members, which are indexes of the ranges.
+type coalescedRange struct {
+	Range
+	members []int
+}
+
+// coalesceRanges sorts @ranges by offset and merges the adjacent or overlapping ones.
+func coalesceRanges(ranges []Range) []coalescedRange {
+	indexes := make([]int, len(ranges))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return ranges[indexes[i]].Offset < ranges[indexes[j]].Offset
+	})
+
+	var merged []coalescedRange
+	for _, idx := range indexes {
+		r := ranges[idx]
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if end := last.Offset + last.Length; r.Offset <= end {
+				if r.Offset+r.Length > end {
+					last.Length = r.Offset + r.Length - last.Offset
+				}
+				last.members = append(last.members, idx)
+				continue
+			}
+		}
+		merged = append(merged, coalescedRange{Range: r, members: []int{idx}})
+	}
+	return merged
+}
+
+// parallelMultiDo calls @do for every input with at most @concurrency goroutines,
+// the results keep the order of @inputs, and all errors are aggregated into an errorutil.ErrorList.
+func parallelMultiDo[I any, T any](ctx context.Context, inputs []I, concurrency int,
+	do func(ctx context.Context, input I) (T, error)) ([]T, error) {
+	results := make([]T, len(inputs))
+	errs := make([]error, len(inputs))
+
+	if concurrency <= 1 || len(inputs) <= 1 {
+		for i, input := range inputs {
+			results[i], errs[i] = do(ctx, input)
+		}
+		return results, collectErrors(errs)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, input := range inputs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, input I) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i], errs[i] = do(ctx, input)
+		}(i, input)
+	}
+	wg.Wait()
+	return results, collectErrors(errs)
+}
+
+// parallelMultiWrite calls @write for every entry of @contents with at most @concurrency goroutines,
+// all errors are aggregated into an errorutil.ErrorList.
+func parallelMultiWrite(ctx context.Context, contents map[string][]byte, concurrency int,
+	write func(ctx context.Context, filePath string, content []byte) error) error {
+	errs := make([]error, len(contents))
+	if concurrency <= 1 || len(contents) <= 1 {
+		i := 0
+		for filePath, content := range contents {
+			errs[i] = write(ctx, filePath, content)
+			i++
+		}
+		return collectErrors(errs)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	i := 0
+	for filePath, content := range contents {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, filePath string, content []byte) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = write(ctx, filePath, content)
+		}(i, filePath, content)
+		i++
+	}
+	wg.Wait()
+	return collectErrors(errs)
+}
+
+// MultiWriteBinlogs writes the binlogs @contents of the segment to @cm with at most @concurrency goroutines,
+// every binlog is tagged by WithBinlogTags with its log type, which is the first dir of its path under the root path.
+func MultiWriteBinlogs(ctx context.Context, cm ChunkManager, collectionID, segmentID UniqueID, contents map[string][]byte,
+	concurrency int) error {
+	return parallelMultiWrite(ctx, contents, concurrency, func(ctx context.Context, filePath string, content []byte) error {
+		logType, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(filePath, cm.RootPath()), "/"), "/")
+		return cm.WriteWithOptions(ctx, filePath, content, WithBinlogTags(collectionID, segmentID, logType))
+	})
+}
+
+// collectErrors returns an errorutil.ErrorList holding the non-nil errors, or nil if there is none.
+func collectErrors(errs []error) error {
+	var el errorutil.ErrorList
+	for _, err := range errs {
+		if err != nil {
+			el = append(el, err)
+		}
+	}
+	if len(el) == 0 {
+		return nil
+	}
+	return el
+}
@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/util/errorutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelMultiRead(t *testing.T) {
+	ctx := context.Background()
+	var filePaths []string
+	for i := 0; i < 20; i++ {
+		filePaths = append(filePaths, fmt.Sprintf("key_%d", i))
+	}
+
+	for _, concurrency := range []int{0, 1, 4} {
+		var running, maxRunning int32
+		results, err := parallelMultiRead(ctx, filePaths, concurrency, func(ctx context.Context, filePath string) ([]byte, error) {
+			cur := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				old := atomic.LoadInt32(&maxRunning)
+				if cur <= old || atomic.CompareAndSwapInt32(&maxRunning, old, cur) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			if filePath == "key_3" || filePath == "key_7" {
+				return nil, errors.New("mock read error")
+			}
+			return []byte(filePath), nil
+		})
+		assert.Error(t, err)
+		el, ok := err.(errorutil.ErrorList)
+		assert.True(t, ok)
+		assert.Equal(t, 2, len(el))
+		assert.Equal(t, len(filePaths), len(results))
+		for i, filePath := range filePaths {
+			if i == 3 || i == 7 {
+				assert.Nil(t, results[i])
+				continue
+			}
+			assert.Equal(t, []byte(filePath), results[i])
+		}
+		if concurrency <= 1 {
+			assert.Equal(t, int32(1), maxRunning)
+		} else {
+			assert.LessOrEqual(t, maxRunning, int32(concurrency))
+		}
+	}
+}
+
+func TestCoalesceRanges(t *testing.T) {
+	ranges := []Range{
+		{Offset: 20, Length: 5},
+		{Offset: 0, Length: 10},
+		{Offset: 10, Length: 5},
+		{Offset: 12, Length: 2},
+		{Offset: 30, Length: 0},
+	}
+	merged := coalesceRanges(ranges)
+	assert.Equal(t, 3, len(merged))
+	assert.Equal(t, Range{Offset: 0, Length: 15}, merged[0].Range)
+	assert.ElementsMatch(t, []int{1, 2, 3}, merged[0].members)
+	assert.Equal(t, Range{Offset: 20, Length: 5}, merged[1].Range)
+	assert.Equal(t, []int{0}, merged[1].members)
+	assert.Equal(t, Range{Offset: 30, Length: 0}, merged[2].Range)
+}
+
+func TestParallelMultiReadAt(t *testing.T) {
+	ctx := context.Background()
+	content := make([]byte, 100)
+	for i := range content {
+		content[i] = byte(i)
+	}
+	var reads int32
+	readAt := func(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+		atomic.AddInt32(&reads, 1)
+		if off+length > int64(len(content)) {
+			return nil, errors.New("out of range")
+		}
+		return content[off : off+length], nil
+	}
+
+	ranges := []Range{{Offset: 50, Length: 10}, {Offset: 0, Length: 4}, {Offset: 4, Length: 4}, {Offset: 55, Length: 10}, {Offset: 90, Length: 0}}
+	for _, concurrency := range []int{1, 4} {
+		atomic.StoreInt32(&reads, 0)
+		results, err := parallelMultiReadAt(ctx, "key", ranges, concurrency, readAt)
+		assert.NoError(t, err)
+		// [0, 8), [50, 65) and the empty range are read without request
+		assert.EqualValues(t, 2, atomic.LoadInt32(&reads))
+		for i, r := range ranges {
+			assert.Equal(t, content[r.Offset:r.Offset+r.Length], results[i])
+		}
+	}
+
+	_, err := parallelMultiReadAt(ctx, "key", []Range{{Offset: 95, Length: 10}}, 1, readAt)
+	assert.Error(t, err)
+	_, err = parallelMultiReadAt(ctx, "key", []Range{{Offset: -1, Length: 10}}, 1, readAt)
+	assert.Error(t, err)
+	_, err = parallelMultiReadAt(ctx, "key", []Range{{Offset: 0, Length: 10}}, 1,
+		func(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+			return content[:5], nil
+		})
+	assert.Error(t, err)
+}
+
+func TestParallelMultiWrite(t *testing.T) {
+	ctx := context.Background()
+	contents := make(map[string][]byte)
+	for i := 0; i < 20; i++ {
+		contents[fmt.Sprintf("key_%d", i)] = []byte{byte(i)}
+	}
+
+	for _, concurrency := range []int{1, 4} {
+		var written sync.Map
+		err := parallelMultiWrite(ctx, contents, concurrency, func(ctx context.Context, filePath string, content []byte) error {
+			written.Store(filePath, content)
+			return nil
+		})
+		assert.NoError(t, err)
+		for filePath, content := range contents {
+			v, ok := written.Load(filePath)
+			assert.True(t, ok)
+			assert.Equal(t, content, v)
+		}
+
+		err = parallelMultiWrite(ctx, contents, concurrency, func(ctx context.Context, filePath string, content []byte) error {
+			return errors.New("mock write error")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, len(contents), len(err.(errorutil.ErrorList)))
+	}
+}
+
+// taggedChunkManager records the tags of the objects written by WriteWithOptions.
+type taggedChunkManager struct {
+	ChunkManager
+	tags sync.Map
+}
+
+func (cm *taggedChunkManager) RootPath() string {
+	return "files"
+}
+
+func (cm *taggedChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	cm.tags.Store(filePath, newWriteConfig(opts...).tags)
+	return nil
+}
+
+func TestMultiWriteBinlogs(t *testing.T) {
+	cm := &taggedChunkManager{}
+	err := MultiWriteBinlogs(context.Background(), cm, 1, 2, map[string][]byte{
+		"files/insert_log/1/10/2/100/1000": {1},
+		"files/delta_log/1/10/2/1001":      {2},
+	}, 2)
+	assert.NoError(t, err)
+	for filePath, logType := range map[string]string{
+		"files/insert_log/1/10/2/100/1000": "insert_log",
+		"files/delta_log/1/10/2/1001":      "delta_log",
+	} {
+		tags, ok := cm.tags.Load(filePath)
+		assert.True(t, ok)
+		assert.Equal(t, map[string]string{
+			ObjectTagCollectionID: "1",
+			ObjectTagSegmentID:    "2",
+			ObjectTagLogType:      logType,
+		}, tags)
+	}
+}
+
+func TestLocalCM_ConcurrentMultiReadWrite(t *testing.T) {
+	ctx := context.Background()
+	testRoot := "test_concurrent_multi"
+	testCM := NewLocalChunkManager(RootPath(localPath), Concurrency(4))
+	defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+	contents := make(map[string][]byte)
+	var keys []string
+	for i := 0; i < 16; i++ {
+		key := path.Join(testRoot, fmt.Sprintf("key_%d", i))
+		keys = append(keys, key)
+		contents[key] = []byte(key)
+	}
+	err := testCM.MultiWrite(ctx, contents)
+	assert.NoError(t, err)
+
+	results, err := testCM.MultiRead(ctx, keys)
+	assert.NoError(t, err)
+	for i, key := range keys {
+		assert.Equal(t, []byte(key), results[i])
+	}
+
+	_, err = testCM.MultiRead(ctx, append(keys, path.Join(testRoot, "not_exist")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,474 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/parquet"
+	"github.com/apache/arrow/go/v8/parquet/compress"
+	"github.com/apache/arrow/go/v8/parquet/file"
+	"github.com/apache/arrow/go/v8/parquet/pqarrow"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+const (
+	// BinlogFormatV1 is the binlog of the descriptor event and the insert events with parquet payloads
+	BinlogFormatV1 = 1
+	// BinlogFormatV2 is a plain parquet file with the field as its only column, which could be read by
+	// any parquet tool, and read by row groups with ReadAt
+	BinlogFormatV2 = 2
+
+	// DefaultBinlogRowGroupSize is the default size in bytes of the data of a row group of the parquet binlogs
+	DefaultBinlogRowGroupSize = 8 * 1024 * 1024
+
+	parquetMagic = "PAR1"
+	// parquetFieldIDKey is the arrow field metadata written as the field id of the parquet column
+	parquetFieldIDKey = "PARQUET:field_id"
+
+	// the keys of the key value metadata of the parquet binlogs
+	parquetBinlogVersionKey      = "milvus.binlog_version"
+	parquetBinlogCollectionIDKey = "milvus.collection_id"
+	parquetBinlogPartitionIDKey  = "milvus.partition_id"
+	parquetBinlogSegmentIDKey    = "milvus.segment_id"
+	parquetBinlogFieldIDKey      = "milvus.field_id"
+	parquetBinlogDataTypeKey     = "milvus.data_type"
+	parquetBinlogDimKey          = "milvus.dim"
+	parquetBinlogStartTsKey      = "milvus.start_timestamp"
+	parquetBinlogEndTsKey        = "milvus.end_timestamp"
+	parquetBinlogOriginalSizeKey = "milvus.original_size"
+)
+
+// BinlogFormatVersion returns the format version of the binlog @content, the parquet binlogs start with
+// the parquet magic, while the event binlogs start with the binlog magic number.
+func BinlogFormatVersion(content []byte) int {
+	if bytes.HasPrefix(content, []byte(parquetMagic)) {
+		return BinlogFormatV2
+	}
+	return BinlogFormatV1
+}
+
+// binlogFormatParams returns the format version and the row group size of the insert binlogs to write,
+// the parquet binlogs are only written if common.binlog.formatVersion is 2.
+func binlogFormatParams() (int, int64) {
+	// the params stay zero if not initialized, e.g. in tools and unit tests
+	params := paramtable.Get()
+	if params.CommonCfg.BinlogFormatVersion != BinlogFormatV2 {
+		return BinlogFormatV1, 0
+	}
+	rowGroupSize := params.CommonCfg.BinlogRowGroupSize
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultBinlogRowGroupSize
+	}
+	return BinlogFormatV2, rowGroupSize
+}
+
+// ParquetBinlogMeta is the metadata of a parquet binlog, kept in the key value metadata of the file.
+type ParquetBinlogMeta struct {
+	CollectionID UniqueID
+	PartitionID  UniqueID
+	SegmentID    UniqueID
+	FieldID      FieldID
+	// FieldName is the name of the column, the field id is the name if it's empty
+	FieldName string
+	DataType  schemapb.DataType
+	// Dim is the dimension of the vector fields
+	Dim            int
+	StartTimestamp Timestamp
+	EndTimestamp   Timestamp
+	// OriginalSize is the memory size of the field data
+	OriginalSize int
+}
+
+func (meta *ParquetBinlogMeta) keyValues() *arrow.Metadata {
+	keyValues := arrow.NewMetadata([]string{
+		parquetBinlogVersionKey,
+		parquetBinlogCollectionIDKey,
+		parquetBinlogPartitionIDKey,
+		parquetBinlogSegmentIDKey,
+		parquetBinlogFieldIDKey,
+		parquetBinlogDataTypeKey,
+		parquetBinlogDimKey,
+		parquetBinlogStartTsKey,
+		parquetBinlogEndTsKey,
+		parquetBinlogOriginalSizeKey,
+	}, []string{
+		strconv.Itoa(BinlogFormatV2),
+		strconv.FormatInt(meta.CollectionID, 10),
+		strconv.FormatInt(meta.PartitionID, 10),
+		strconv.FormatInt(meta.SegmentID, 10),
+		strconv.FormatInt(meta.FieldID, 10),
+		meta.DataType.String(),
+		strconv.Itoa(meta.Dim),
+		strconv.FormatUint(meta.StartTimestamp, 10),
+		strconv.FormatUint(meta.EndTimestamp, 10),
+		strconv.Itoa(meta.OriginalSize),
+	})
+	return &keyValues
+}
+
+// WriteParquetBinlog encodes @data as a parquet binlog described by @meta, each row group holds about
+// @rowGroupSize bytes of the field data, so that a row group could be fetched by a ranged read.
+func WriteParquetBinlog(meta *ParquetBinlogMeta, data FieldData, rowGroupSize int64) ([]byte, error) {
+	arr, err := newArrowArray(meta.DataType, data)
+	if err != nil {
+		return nil, err
+	}
+	defer arr.Release()
+
+	name := meta.FieldName
+	if name == "" {
+		name = strconv.FormatInt(meta.FieldID, 10)
+	}
+	schema := arrow.NewSchema([]arrow.Field{{
+		Name:     name,
+		Type:     arr.DataType(),
+		Metadata: arrow.NewMetadata([]string{parquetFieldIDKey}, []string{strconv.FormatInt(meta.FieldID, 10)}),
+	}}, meta.keyValues())
+	record := array.NewRecord(schema, []arrow.Array{arr}, int64(arr.Len()))
+	defer record.Release()
+	table := array.NewTableFromRecords(schema, []arrow.Record{record})
+	defer table.Release()
+
+	output := &bytes.Buffer{}
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Zstd),
+		parquet.WithCompressionLevel(payloadZstdLevel),
+	)
+	// the arrow schema is stored as well, so that the arrow readers restore the narrow integer types
+	arrowProps := pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema())
+	if err := pqarrow.WriteTable(table, output, parquetRowGroupRows(data, rowGroupSize), props, arrowProps); err != nil {
+		return nil, err
+	}
+	return output.Bytes(), nil
+}
+
+// parquetRowGroupRows returns the number of rows of a row group holding about @rowGroupSize bytes of @data.
+func parquetRowGroupRows(data FieldData, rowGroupSize int64) int64 {
+	rows := int64(data.RowNum())
+	size := int64(data.GetMemorySize())
+	if rows == 0 || rowGroupSize <= 0 || size <= rowGroupSize {
+		return payloadRowGroupSize
+	}
+	// round up, the memory size includes a few bytes of the field data besides the rows
+	return (rows*rowGroupSize + size - 1) / size
+}
+
+// ParquetBinlogReader reads a parquet binlog by row groups, only the footer is read when it's opened,
+// and the column chunk of a row group is read when the row group is read.
+type ParquetBinlogReader struct {
+	ParquetBinlogMeta
+	reader *file.Reader
+}
+
+// NewParquetBinlogReader opens the parquet binlog in @r.
+func NewParquetBinlogReader(r parquet.ReaderAtSeeker) (*ParquetBinlogReader, error) {
+	reader, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := parseParquetBinlogMeta(reader)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	return &ParquetBinlogReader{ParquetBinlogMeta: *meta, reader: reader}, nil
+}
+
+// NewParquetBinlogReaderFromBuffer opens the parquet binlog read in memory.
+func NewParquetBinlogReaderFromBuffer(content []byte) (*ParquetBinlogReader, error) {
+	return NewParquetBinlogReader(bytes.NewReader(content))
+}
+
+// OpenParquetBinlog opens the parquet binlog @filePath in @cm, the row groups are fetched on demand by ranged reads.
+func OpenParquetBinlog(ctx context.Context, cm ChunkManager, filePath string, opts ...ReaderAtOption) (*ParquetBinlogReader, error) {
+	readerAt, err := NewRemoteReaderAt(ctx, cm, filePath, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewParquetBinlogReader(io.NewSectionReader(readerAt, 0, readerAt.Size()))
+}
+
+func parseParquetBinlogMeta(reader *file.Reader) (*ParquetBinlogMeta, error) {
+	keyValues := reader.MetaData().KeyValueMetadata()
+	get := func(key string) (string, error) {
+		value := keyValues.FindValue(key)
+		if value == nil {
+			return "", fmt.Errorf("%s not in parquet binlog metadata", key)
+		}
+		return *value, nil
+	}
+	getInt := func(key string) (int64, error) {
+		value, err := get(key)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseInt(value, 10, 64)
+	}
+
+	version, err := getInt(parquetBinlogVersionKey)
+	if err != nil {
+		return nil, err
+	}
+	if version != BinlogFormatV2 {
+		return nil, fmt.Errorf("unsupported parquet binlog version %d", version)
+	}
+	if reader.MetaData().Schema.NumColumns() != 1 {
+		return nil, fmt.Errorf("parquet binlog has %d columns", reader.MetaData().Schema.NumColumns())
+	}
+
+	meta := &ParquetBinlogMeta{FieldName: reader.MetaData().Schema.Column(0).Name()}
+	for key, value := range map[string]*int64{
+		parquetBinlogCollectionIDKey: &meta.CollectionID,
+		parquetBinlogPartitionIDKey:  &meta.PartitionID,
+		parquetBinlogSegmentIDKey:    &meta.SegmentID,
+		parquetBinlogFieldIDKey:      &meta.FieldID,
+	} {
+		if *value, err = getInt(key); err != nil {
+			return nil, err
+		}
+	}
+	dataType, err := get(parquetBinlogDataTypeKey)
+	if err != nil {
+		return nil, err
+	}
+	typeValue, ok := schemapb.DataType_value[dataType]
+	if !ok {
+		return nil, fmt.Errorf("unknown datatype %s of parquet binlog", dataType)
+	}
+	meta.DataType = schemapb.DataType(typeValue)
+	for key, value := range map[string]*int{
+		parquetBinlogDimKey:          &meta.Dim,
+		parquetBinlogOriginalSizeKey: &meta.OriginalSize,
+	} {
+		v, err := getInt(key)
+		if err != nil {
+			return nil, err
+		}
+		*value = int(v)
+	}
+	for key, value := range map[string]*Timestamp{
+		parquetBinlogStartTsKey: &meta.StartTimestamp,
+		parquetBinlogEndTsKey:   &meta.EndTimestamp,
+	} {
+		v, err := get(key)
+		if err != nil {
+			return nil, err
+		}
+		if *value, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	return meta, nil
+}
+
+// NumRows returns the number of rows of all row groups.
+func (r *ParquetBinlogReader) NumRows() int64 {
+	return r.reader.NumRows()
+}
+
+// NumRowGroups returns the number of row groups.
+func (r *ParquetBinlogReader) NumRowGroups() int {
+	return r.reader.NumRowGroups()
+}
+
+// RowGroupRows returns the number of rows of the @i-th row group.
+func (r *ParquetBinlogReader) RowGroupRows(i int) int64 {
+	return r.reader.RowGroup(i).NumRows()
+}
+
+// ReadRowGroup reads the field data of the @i-th row group.
+func (r *ParquetBinlogReader) ReadRowGroup(i int) (FieldData, error) {
+	if i < 0 || i >= r.reader.NumRowGroups() {
+		return nil, fmt.Errorf("row group %d out of range [0, %d)", i, r.reader.NumRowGroups())
+	}
+	rowGroup := r.reader.RowGroup(i)
+	rows := rowGroup.NumRows()
+	column := rowGroup.Column(0)
+	switch r.DataType {
+	case schemapb.DataType_Bool:
+		values, err := readParquetColumn[bool, *file.BooleanColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		return &BoolFieldData{NumRows: []int64{rows}, Data: values}, nil
+	case schemapb.DataType_Int8:
+		values, err := readParquetColumn[int32, *file.Int32ColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]int8, len(values))
+		for i, value := range values {
+			data[i] = int8(value)
+		}
+		return &Int8FieldData{NumRows: []int64{rows}, Data: data}, nil
+	case schemapb.DataType_Int16:
+		values, err := readParquetColumn[int32, *file.Int32ColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]int16, len(values))
+		for i, value := range values {
+			data[i] = int16(value)
+		}
+		return &Int16FieldData{NumRows: []int64{rows}, Data: data}, nil
+	case schemapb.DataType_Int32:
+		values, err := readParquetColumn[int32, *file.Int32ColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		return &Int32FieldData{NumRows: []int64{rows}, Data: values}, nil
+	case schemapb.DataType_Int64:
+		values, err := readParquetColumn[int64, *file.Int64ColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		return &Int64FieldData{NumRows: []int64{rows}, Data: values}, nil
+	case schemapb.DataType_Float:
+		values, err := readParquetColumn[float32, *file.Float32ColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		return &FloatFieldData{NumRows: []int64{rows}, Data: values}, nil
+	case schemapb.DataType_Double:
+		values, err := readParquetColumn[float64, *file.Float64ColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		return &DoubleFieldData{NumRows: []int64{rows}, Data: values}, nil
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		values, err := readParquetColumn[parquet.ByteArray, *file.ByteArrayColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]string, len(values))
+		for i, value := range values {
+			data[i] = string(value)
+		}
+		return &StringFieldData{NumRows: []int64{rows}, Data: data}, nil
+	case schemapb.DataType_BinaryVector:
+		values, err := readParquetColumn[parquet.FixedLenByteArray, *file.FixedLenByteArrayColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		width := r.Dim / 8
+		data := make([]byte, len(values)*width)
+		for i, value := range values {
+			copy(data[i*width:(i+1)*width], value)
+		}
+		return &BinaryVectorFieldData{NumRows: []int64{rows}, Data: data, Dim: r.Dim}, nil
+	case schemapb.DataType_FloatVector:
+		values, err := readParquetColumn[parquet.FixedLenByteArray, *file.FixedLenByteArrayColumnChunkReader](column, rows)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]float32, len(values)*r.Dim)
+		for i, value := range values {
+			copy(arrow.Float32Traits.CastToBytes(data[i*r.Dim:(i+1)*r.Dim]), value)
+		}
+		return &FloatVectorFieldData{NumRows: []int64{rows}, Data: data, Dim: r.Dim}, nil
+	default:
+		return nil, fmt.Errorf("parquet binlog does not support datatype %v", r.DataType.String())
+	}
+}
+
+// ReadAll reads the field data of all row groups.
+func (r *ParquetBinlogReader) ReadAll() (FieldData, error) {
+	merged := &InsertData{Data: make(map[FieldID]FieldData)}
+	for i := 0; i < r.reader.NumRowGroups(); i++ {
+		data, err := r.ReadRowGroup(i)
+		if err != nil {
+			return nil, err
+		}
+		if merged.Data[r.FieldID] == nil {
+			merged.Data[r.FieldID] = data
+			continue
+		}
+		MergeFieldData(merged, r.FieldID, data)
+	}
+	if merged.Data[r.FieldID] == nil {
+		// no row group is written for an empty field
+		return r.emptyFieldData()
+	}
+	return merged.Data[r.FieldID], nil
+}
+
+func (r *ParquetBinlogReader) emptyFieldData() (FieldData, error) {
+	switch r.DataType {
+	case schemapb.DataType_Bool:
+		return &BoolFieldData{NumRows: []int64{0}}, nil
+	case schemapb.DataType_Int8:
+		return &Int8FieldData{NumRows: []int64{0}}, nil
+	case schemapb.DataType_Int16:
+		return &Int16FieldData{NumRows: []int64{0}}, nil
+	case schemapb.DataType_Int32:
+		return &Int32FieldData{NumRows: []int64{0}}, nil
+	case schemapb.DataType_Int64:
+		return &Int64FieldData{NumRows: []int64{0}}, nil
+	case schemapb.DataType_Float:
+		return &FloatFieldData{NumRows: []int64{0}}, nil
+	case schemapb.DataType_Double:
+		return &DoubleFieldData{NumRows: []int64{0}}, nil
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		return &StringFieldData{NumRows: []int64{0}}, nil
+	case schemapb.DataType_BinaryVector:
+		return &BinaryVectorFieldData{NumRows: []int64{0}, Dim: r.Dim}, nil
+	case schemapb.DataType_FloatVector:
+		return &FloatVectorFieldData{NumRows: []int64{0}, Dim: r.Dim}, nil
+	default:
+		return nil, fmt.Errorf("parquet binlog does not support datatype %v", r.DataType.String())
+	}
+}
+
+// Close closes the reader.
+func (r *ParquetBinlogReader) Close() error {
+	return r.reader.Close()
+}
+
+// readParquetColumn reads all @rows values of the column chunk, which are read by batches of pages.
+func readParquetColumn[T any, E interface {
+	ReadBatch(int64, []T, []int16, []int16) (int64, int, error)
+}](column file.ColumnChunkReader, rows int64) ([]T, error) {
+	reader, ok := column.(E)
+	if !ok {
+		return nil, fmt.Errorf("expect type %T, but got %T", *new(E), column)
+	}
+	values := make([]T, rows)
+	var offset int64
+	for offset < rows {
+		_, read, err := reader.ReadBatch(rows-offset, values[offset:], nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if read == 0 {
+			break
+		}
+		offset += int64(read)
+	}
+	if offset != rows {
+		return nil, fmt.Errorf("expect %d rows, but got %d", rows, offset)
+	}
+	return values, nil
+}
@@ -0,0 +1,204 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+func genParquetBinlogTestData(rows int) (*etcdpb.CollectionMeta, *InsertData) {
+	meta := &etcdpb.CollectionMeta{
+		ID: 1,
+		Schema: &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: common.RowIDField, Name: "row_id", DataType: schemapb.DataType_Int64},
+				{FieldID: common.TimeStampField, Name: "Ts", DataType: schemapb.DataType_Int64},
+				{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+				{FieldID: 101, Name: "bool", DataType: schemapb.DataType_Bool},
+				{FieldID: 102, Name: "int8", DataType: schemapb.DataType_Int8},
+				{FieldID: 103, Name: "int16", DataType: schemapb.DataType_Int16},
+				{FieldID: 104, Name: "int32", DataType: schemapb.DataType_Int32},
+				{FieldID: 105, Name: "float", DataType: schemapb.DataType_Float},
+				{FieldID: 106, Name: "double", DataType: schemapb.DataType_Double},
+				{FieldID: 107, Name: "varchar", DataType: schemapb.DataType_VarChar},
+				{FieldID: 108, Name: "binary_vector", DataType: schemapb.DataType_BinaryVector},
+				{FieldID: 109, Name: "float_vector", DataType: schemapb.DataType_FloatVector},
+			},
+		},
+	}
+	data := &InsertData{Data: map[FieldID]FieldData{
+		common.RowIDField:     &Int64FieldData{NumRows: []int64{int64(rows)}},
+		common.TimeStampField: &Int64FieldData{NumRows: []int64{int64(rows)}},
+		100:                   &Int64FieldData{NumRows: []int64{int64(rows)}},
+		101:                   &BoolFieldData{NumRows: []int64{int64(rows)}},
+		102:                   &Int8FieldData{NumRows: []int64{int64(rows)}},
+		103:                   &Int16FieldData{NumRows: []int64{int64(rows)}},
+		104:                   &Int32FieldData{NumRows: []int64{int64(rows)}},
+		105:                   &FloatFieldData{NumRows: []int64{int64(rows)}},
+		106:                   &DoubleFieldData{NumRows: []int64{int64(rows)}},
+		107:                   &StringFieldData{NumRows: []int64{int64(rows)}},
+		108:                   &BinaryVectorFieldData{NumRows: []int64{int64(rows)}, Dim: 16},
+		109:                   &FloatVectorFieldData{NumRows: []int64{int64(rows)}, Dim: 4},
+	}}
+	for i := 0; i < rows; i++ {
+		for _, fieldID := range []FieldID{common.RowIDField, common.TimeStampField, 100} {
+			fieldData := data.Data[fieldID].(*Int64FieldData)
+			fieldData.Data = append(fieldData.Data, int64(i+1))
+		}
+		data.Data[101].(*BoolFieldData).Data = append(data.Data[101].(*BoolFieldData).Data, i%2 == 0)
+		data.Data[102].(*Int8FieldData).Data = append(data.Data[102].(*Int8FieldData).Data, int8(i))
+		data.Data[103].(*Int16FieldData).Data = append(data.Data[103].(*Int16FieldData).Data, int16(-i))
+		data.Data[104].(*Int32FieldData).Data = append(data.Data[104].(*Int32FieldData).Data, int32(i*3))
+		data.Data[105].(*FloatFieldData).Data = append(data.Data[105].(*FloatFieldData).Data, float32(i)/2)
+		data.Data[106].(*DoubleFieldData).Data = append(data.Data[106].(*DoubleFieldData).Data, math.Sqrt(float64(i)))
+		data.Data[107].(*StringFieldData).Data = append(data.Data[107].(*StringFieldData).Data, fmt.Sprintf("str-%d", i))
+		data.Data[108].(*BinaryVectorFieldData).Data = append(data.Data[108].(*BinaryVectorFieldData).Data, byte(i), byte(i>>8))
+		data.Data[109].(*FloatVectorFieldData).Data = append(data.Data[109].(*FloatVectorFieldData).Data,
+			float32(i), float32(i+1), float32(i+2), float32(i+3))
+	}
+	return meta, data
+}
+
+func TestParquetBinlog(t *testing.T) {
+	const rows = 1000
+	_, data := genParquetBinlogTestData(rows)
+
+	meta := &ParquetBinlogMeta{
+		CollectionID:   1,
+		PartitionID:    2,
+		SegmentID:      3,
+		FieldID:        109,
+		FieldName:      "float_vector",
+		DataType:       schemapb.DataType_FloatVector,
+		Dim:            4,
+		StartTimestamp: 10,
+		EndTimestamp:   20,
+		OriginalSize:   data.Data[109].GetMemorySize(),
+	}
+	// about 10 rows of 16 bytes in a row group
+	content, err := WriteParquetBinlog(meta, data.Data[109], 160)
+	require.NoError(t, err)
+	assert.Equal(t, BinlogFormatV2, BinlogFormatVersion(content))
+
+	reader, err := NewParquetBinlogReaderFromBuffer(content)
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, *meta, reader.ParquetBinlogMeta)
+	assert.EqualValues(t, rows, reader.NumRows())
+	assert.Equal(t, 100, reader.NumRowGroups())
+	assert.EqualValues(t, 10, reader.RowGroupRows(1))
+
+	group, err := reader.ReadRowGroup(1)
+	require.NoError(t, err)
+	assert.Equal(t, data.Data[109].(*FloatVectorFieldData).Data[40:80], group.(*FloatVectorFieldData).Data)
+	_, err = reader.ReadRowGroup(100)
+	assert.Error(t, err)
+
+	all, err := reader.ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, data.Data[109].(*FloatVectorFieldData).Data, all.(*FloatVectorFieldData).Data)
+	assert.Equal(t, 4, all.(*FloatVectorFieldData).Dim)
+
+	rowNum, err := countBinlogRows(content)
+	require.NoError(t, err)
+	assert.EqualValues(t, rows, rowNum)
+
+	t.Run("read by ranges", func(t *testing.T) {
+		ctx := context.Background()
+		cm := NewMemoryChunkManager()
+		require.NoError(t, cm.Write(ctx, "binlog", content))
+		reader, err := OpenParquetBinlog(ctx, cm, "binlog", ReaderAtBlockSize(64))
+		require.NoError(t, err)
+		defer reader.Close()
+		group, err := reader.ReadRowGroup(99)
+		require.NoError(t, err)
+		assert.Equal(t, data.Data[109].(*FloatVectorFieldData).Data[3960:], group.(*FloatVectorFieldData).Data)
+	})
+
+	t.Run("not parquet binlog", func(t *testing.T) {
+		_, err := NewParquetBinlogReaderFromBuffer([]byte("not a parquet file"))
+		assert.Error(t, err)
+		// a parquet file without the binlog metadata
+		w, err := NewNativePayloadWriter(schemapb.DataType_Int64)
+		require.NoError(t, err)
+		defer w.Close()
+		require.NoError(t, w.AddInt64ToPayload([]int64{1, 2}))
+		require.NoError(t, w.FinishPayloadWriter())
+		payload, err := w.GetPayloadBufferFromWriter()
+		require.NoError(t, err)
+		_, err = NewParquetBinlogReaderFromBuffer(payload)
+		assert.Error(t, err)
+	})
+}
+
+func TestInsertCodec_ParquetBinlog(t *testing.T) {
+	collMeta, data := genParquetBinlogTestData(100)
+	codec := NewInsertCodec(collMeta)
+
+	v1Blobs, v1Stats, err := codec.Serialize(2, 3, data)
+	require.NoError(t, err)
+	for _, blob := range v1Blobs {
+		assert.Equal(t, BinlogFormatV1, BinlogFormatVersion(blob.Value))
+	}
+
+	params := paramtable.Get()
+	params.CommonCfg.BinlogFormatVersion = BinlogFormatV2
+	params.CommonCfg.BinlogRowGroupSize = 128
+	defer func() {
+		params.CommonCfg.BinlogFormatVersion = 0
+		params.CommonCfg.BinlogRowGroupSize = 0
+	}()
+	blobs, stats, err := codec.Serialize(2, 3, data)
+	require.NoError(t, err)
+	require.Len(t, blobs, len(collMeta.Schema.Fields))
+	assert.Equal(t, v1Stats, stats)
+	for _, blob := range blobs {
+		assert.Equal(t, BinlogFormatV2, BinlogFormatVersion(blob.Value))
+	}
+
+	collectionID, partitionID, segmentID, deserialized, err := codec.DeserializeAll(blobs)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, collectionID)
+	assert.EqualValues(t, 2, partitionID)
+	assert.EqualValues(t, 3, segmentID)
+	require.Len(t, deserialized.Data, len(data.Data))
+	for fieldID, fieldData := range data.Data {
+		assert.Equal(t, fieldData.RowNum(), deserialized.Data[fieldID].RowNum(), fieldID)
+		for i := 0; i < fieldData.RowNum(); i++ {
+			assert.Equal(t, fieldData.GetRow(i), deserialized.Data[fieldID].GetRow(i), fieldID)
+		}
+	}
+	assert.Equal(t, []BlobInfo{{Length: 100}}, deserialized.Infos)
+
+	// the binlogs of both formats are readable together, e.g. the binlogs of a segment written before and after
+	// the format is switched
+	mixed := &InsertData{Data: make(map[FieldID]FieldData)}
+	_, _, _, err = codec.DeserializeInto([]*Blob{v1Blobs[3], blobs[3]}, 0, mixed)
+	require.NoError(t, err)
+	assert.Equal(t, 200, mixed.Data[101].RowNum())
+}
@@ -0,0 +1,162 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+)
+
+// ApplyPartialUpdates overlays the scalar columns of @updates on the rows of @base with the same primary key,
+// which merges the delta columns of partial updates into the full rows at compaction or read time.
+// @updates carries the primary key, the timestamp and only the updated scalar fields of each row, the fields absent
+// from it keep the base values, so the vectors are never resent nor reindexed for metadata-only changes.
+// A row updated several times takes the latest update by timestamp, the updates older than the base row or
+// targeting the rows not in @base are skipped. The updated rows take the timestamp of the update like an upsert.
+// The base data is modified in place, and the number of updated rows is returned.
+func ApplyPartialUpdates(schema *schemapb.CollectionSchema, base *InsertData, updates *InsertData) (int, error) {
+	if base == nil || updates == nil {
+		return 0, nil
+	}
+	for fieldID := range updates.Data {
+		field := getField(schema, fieldID)
+		if field == nil {
+			return 0, fmt.Errorf("field %d of partial update not found in schema", fieldID)
+		}
+		if field.GetIsPrimaryKey() || fieldID == common.RowIDField {
+			continue
+		}
+		if _, ok := base.Data[fieldID]; !ok {
+			return 0, fmt.Errorf("field %d of partial update not found in base data", fieldID)
+		}
+		if field.GetDataType() == schemapb.DataType_FloatVector || field.GetDataType() == schemapb.DataType_BinaryVector {
+			return 0, fmt.Errorf("vector field %d could not be partially updated", fieldID)
+		}
+	}
+
+	basePks, err := GetPkFromInsertData(schema, base)
+	if err != nil {
+		return 0, err
+	}
+	baseTss, err := GetTimestampFromInsertData(base)
+	if err != nil {
+		return 0, err
+	}
+	updatePks, err := GetPkFromInsertData(schema, updates)
+	if err != nil {
+		return 0, err
+	}
+	updateTss, err := GetTimestampFromInsertData(updates)
+	if err != nil {
+		return 0, err
+	}
+
+	// the latest update of each primary key
+	latest := make(map[interface{}]int, updatePks.RowNum())
+	for j := 0; j < updatePks.RowNum(); j++ {
+		pk := updatePks.GetRow(j)
+		if prev, ok := latest[pk]; !ok || updateTss.Data[j] > updateTss.Data[prev] {
+			latest[pk] = j
+		}
+	}
+
+	updated := 0
+	for i := 0; i < basePks.RowNum(); i++ {
+		j, ok := latest[basePks.GetRow(i)]
+		if !ok || updateTss.Data[j] < baseTss.Data[i] {
+			continue
+		}
+		for fieldID, fieldData := range updates.Data {
+			if fieldID == common.RowIDField || fieldID == common.TimeStampField {
+				continue
+			}
+			if err := setFieldDataRow(base.Data[fieldID], i, fieldData, j); err != nil {
+				return updated, fmt.Errorf("failed to update field %d: %w", fieldID, err)
+			}
+		}
+		baseTss.Data[i] = updateTss.Data[j]
+		updated++
+	}
+	return updated, nil
+}
+
+func getField(schema *schemapb.CollectionSchema, fieldID FieldID) *schemapb.FieldSchema {
+	if fieldID == common.RowIDField || fieldID == common.TimeStampField {
+		return &schemapb.FieldSchema{FieldID: fieldID, DataType: schemapb.DataType_Int64}
+	}
+	for _, field := range schema.GetFields() {
+		if field.GetFieldID() == fieldID {
+			return field
+		}
+	}
+	return nil
+}
+
+// setFieldDataRow sets the @i-th row of scalar field data @dst to the @j-th row of @src.
+func setFieldDataRow(dst FieldData, i int, src FieldData, j int) error {
+	if j >= src.RowNum() {
+		return fmt.Errorf("row %d out of range of partial update with %d rows", j, src.RowNum())
+	}
+	switch dst := dst.(type) {
+	case *BoolFieldData:
+		if src, ok := src.(*BoolFieldData); ok {
+			dst.Data[i] = src.Data[j]
+			return nil
+		}
+	case *Int8FieldData:
+		if src, ok := src.(*Int8FieldData); ok {
+			dst.Data[i] = src.Data[j]
+			return nil
+		}
+	case *Int16FieldData:
+		if src, ok := src.(*Int16FieldData); ok {
+			dst.Data[i] = src.Data[j]
+			return nil
+		}
+	case *Int32FieldData:
+		if src, ok := src.(*Int32FieldData); ok {
+			dst.Data[i] = src.Data[j]
+			return nil
+		}
+	case *Int64FieldData:
+		if src, ok := src.(*Int64FieldData); ok {
+			dst.Data[i] = src.Data[j]
+			return nil
+		}
+	case *FloatFieldData:
+		if src, ok := src.(*FloatFieldData); ok {
+			dst.Data[i] = src.Data[j]
+			return nil
+		}
+	case *DoubleFieldData:
+		if src, ok := src.(*DoubleFieldData); ok {
+			dst.Data[i] = src.Data[j]
+			return nil
+		}
+	case *StringFieldData:
+		if src, ok := src.(*StringFieldData); ok {
+			dst.Data[i] = src.Data[j]
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported field data type %T", dst)
+	}
+	return errors.New("data type of partial update mismatches the base data")
+}
@@ -0,0 +1,101 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPartialUpdates(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "tag", DataType: schemapb.DataType_VarChar},
+			{FieldID: 102, Name: "score", DataType: schemapb.DataType_Float},
+			{FieldID: 103, Name: "vec", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "2"}}},
+		},
+	}
+	newBase := func() *InsertData {
+		return &InsertData{Data: map[FieldID]FieldData{
+			common.RowIDField:     &Int64FieldData{Data: []int64{1, 2, 3}},
+			common.TimeStampField: &Int64FieldData{Data: []int64{10, 10, 30}},
+			100:                   &Int64FieldData{Data: []int64{1, 2, 3}},
+			101:                   &StringFieldData{Data: []string{"a", "b", "c"}},
+			102:                   &FloatFieldData{Data: []float32{1, 2, 3}},
+			103:                   &FloatVectorFieldData{Dim: 2, Data: []float32{1, 1, 2, 2, 3, 3}},
+		}}
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		base := newBase()
+		updates := &InsertData{Data: map[FieldID]FieldData{
+			common.TimeStampField: &Int64FieldData{Data: []int64{20, 25, 20, 20}},
+			100:                   &Int64FieldData{Data: []int64{2, 2, 3, 4}},
+			101:                   &StringFieldData{Data: []string{"b1", "b2", "c1", "d1"}},
+		}}
+		n, err := ApplyPartialUpdates(schema, base, updates)
+		assert.NoError(t, err)
+		// pk 3 is newer than the update, and pk 4 doesn't exist
+		assert.Equal(t, 1, n)
+		assert.Equal(t, []string{"a", "b2", "c"}, base.Data[101].(*StringFieldData).Data)
+		assert.Equal(t, []int64{10, 25, 30}, base.Data[common.TimeStampField].(*Int64FieldData).Data)
+		// the fields absent from the update are kept
+		assert.Equal(t, []float32{1, 2, 3}, base.Data[102].(*FloatFieldData).Data)
+		assert.Equal(t, []float32{1, 1, 2, 2, 3, 3}, base.Data[103].(*FloatVectorFieldData).Data)
+	})
+
+	t.Run("invalid updates", func(t *testing.T) {
+		n, err := ApplyPartialUpdates(schema, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+
+		_, err = ApplyPartialUpdates(schema, newBase(), &InsertData{Data: map[FieldID]FieldData{
+			common.TimeStampField: &Int64FieldData{Data: []int64{20}},
+			100:                   &Int64FieldData{Data: []int64{1}},
+			103:                   &FloatVectorFieldData{Dim: 2, Data: []float32{5, 5}},
+		}})
+		assert.Error(t, err)
+
+		_, err = ApplyPartialUpdates(schema, newBase(), &InsertData{Data: map[FieldID]FieldData{
+			common.TimeStampField: &Int64FieldData{Data: []int64{20}},
+			100:                   &Int64FieldData{Data: []int64{1}},
+			999:                   &Int64FieldData{Data: []int64{1}},
+		}})
+		assert.Error(t, err)
+
+		// missing timestamp
+		_, err = ApplyPartialUpdates(schema, newBase(), &InsertData{Data: map[FieldID]FieldData{
+			100: &Int64FieldData{Data: []int64{1}},
+			101: &StringFieldData{Data: []string{"a1"}},
+		}})
+		assert.Error(t, err)
+
+		// mismatched type
+		_, err = ApplyPartialUpdates(schema, newBase(), &InsertData{Data: map[FieldID]FieldData{
+			common.TimeStampField: &Int64FieldData{Data: []int64{20}},
+			100:                   &Int64FieldData{Data: []int64{1}},
+			102:                   &DoubleFieldData{Data: []float64{1}},
+		}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,330 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/metautil"
+)
+
+// BuildLogPath builds the path of a binlog on the storage by the path template of @binlogType.
+func BuildLogPath(rootPath string, binlogType BinlogType, collectionID, partitionID, segmentID, fieldID, logID UniqueID) (string, error) {
+	switch binlogType {
+	case InsertBinlog:
+		return metautil.BuildInsertLogPath(rootPath, collectionID, partitionID, segmentID, fieldID, logID), nil
+	case DeleteBinlog:
+		return metautil.BuildDeltaLogPath(rootPath, collectionID, partitionID, segmentID, logID), nil
+	case StatsBinlog:
+		return metautil.BuildStatsLogPath(rootPath, collectionID, partitionID, segmentID, fieldID, logID), nil
+	default:
+		return "", fmt.Errorf("invalid binlog type: %d", binlogType)
+	}
+}
+
+// logPathPrefixRefMark marks the log paths kept in meta as a reference to the LogPathPrefixes dictionary.
+const logPathPrefixRefMark = "$"
+
+// ErrUnknownLogPathPrefix means a log path refers to a prefix absent from the LogPathPrefixes dictionary.
+var ErrUnknownLogPathPrefix = errors.New("UnknownLogPathPrefix")
+
+func WrapErrUnknownLogPathPrefix(id UniqueID) error {
+	return fmt.Errorf("%w(id=%d)", ErrUnknownLogPathPrefix, id)
+}
+
+// LogPathPrefixAllocator allocates the ID of @prefix absent from the LogPathPrefixes dictionary. The ID must be
+// persisted with @prefix before it's returned, so that it's never allocated to another prefix, e.g. by the other
+// datacoords sharing the meta.
+type LogPathPrefixAllocator func(prefix string) (UniqueID, error)
+
+// LogPathPrefixes is the dictionary of the root paths, other than the chunk manager root path, the binlogs are
+// written under, e.g. by a chunk manager of another root path before a migration. The log paths following the
+// path template under such a prefix are kept in meta as the prefix ID plus the log ID. It's not thread safe.
+type LogPathPrefixes struct {
+	ids      map[string]UniqueID
+	prefixes map[UniqueID]string
+	alloc    LogPathPrefixAllocator
+}
+
+// NewLogPathPrefixes returns an empty LogPathPrefixes, the prefixes absent are added with the IDs allocated by @alloc.
+// The dictionary never grows if @alloc is nil, the log paths under the prefixes absent are kept as they are.
+func NewLogPathPrefixes(alloc LogPathPrefixAllocator) *LogPathPrefixes {
+	return &LogPathPrefixes{
+		ids:      make(map[string]UniqueID),
+		prefixes: make(map[UniqueID]string),
+		alloc:    alloc,
+	}
+}
+
+// Set adds @prefix with @id, it's used to restore the dictionary persisted in meta.
+func (p *LogPathPrefixes) Set(id UniqueID, prefix string) {
+	p.ids[prefix] = id
+	p.prefixes[id] = prefix
+}
+
+// Prefix returns the prefix of @id.
+func (p *LogPathPrefixes) Prefix(id UniqueID) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	prefix, ok := p.prefixes[id]
+	return prefix, ok
+}
+
+// ref returns the ID of @prefix, @prefix is added with the ID allocated if absent.
+// False is returned if @prefix is absent and no allocator is set.
+func (p *LogPathPrefixes) ref(prefix string) (UniqueID, bool, error) {
+	if id, ok := p.ids[prefix]; ok {
+		return id, true, nil
+	}
+	if p.alloc == nil {
+		return 0, false, nil
+	}
+	id, err := p.alloc(prefix)
+	if err != nil {
+		return 0, false, err
+	}
+	p.Set(id, prefix)
+	return id, true, nil
+}
+
+// LogPathPrefixesGetter returns the LogPathPrefixes, it's called only if a log path is compressed against them,
+// so that the dictionary can be loaded lazily.
+type LogPathPrefixesGetter func() (*LogPathPrefixes, error)
+
+// LogPathPrefixRef returns the prefix ID @logPath refers to if it's compressed against a LogPathPrefixes.
+func LogPathPrefixRef(logPath string) (UniqueID, bool) {
+	if !strings.HasPrefix(logPath, logPathPrefixRefMark) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(logPath[len(logPathPrefixRefMark):], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// CompressLogPaths dictionary-encodes the log paths of @fieldBinlogs so that only the log IDs are kept in meta:
+//   - the paths following the template of @binlogType under @rootPath are replaced with an empty path,
+//   - the paths following the template under another root path are replaced with a reference to the root path
+//     in the dictionary returned by @getPrefixes, the root path is added to it if absent and the dictionary has
+//     an allocator. @getPrefixes can be nil to keep such paths,
+//   - the paths not following the template are kept as they are.
+func CompressLogPaths(rootPath string, getPrefixes LogPathPrefixesGetter, binlogType BinlogType, collectionID, partitionID, segmentID UniqueID, fieldBinlogs ...*datapb.FieldBinlog) error {
+	for _, fieldBinlog := range fieldBinlogs {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if binlog.GetLogPath() == "" {
+				continue
+			}
+			if _, ok := LogPathPrefixRef(binlog.GetLogPath()); ok {
+				continue
+			}
+			logID, err := parseLogID(binlog.GetLogPath())
+			if err != nil {
+				// not following the template
+				continue
+			}
+			logPath, err := BuildLogPath(rootPath, binlogType, collectionID, partitionID, segmentID, fieldBinlog.GetFieldID(), logID)
+			if err != nil {
+				return err
+			}
+			binlog.LogID = logID
+			if logPath == binlog.GetLogPath() {
+				binlog.LogPath = ""
+				continue
+			}
+			if getPrefixes == nil {
+				continue
+			}
+			prefix, ok, err := logPathPrefix(binlog.GetLogPath(), binlogType, collectionID, partitionID, segmentID, fieldBinlog.GetFieldID(), logID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			prefixes, err := getPrefixes()
+			if err != nil {
+				return err
+			}
+			id, ok, err := prefixes.ref(prefix)
+			if err != nil {
+				return err
+			}
+			if ok {
+				binlog.LogPath = logPathPrefixRefMark + strconv.FormatInt(id, 10)
+			}
+		}
+	}
+	return nil
+}
+
+// DecompressLogPaths rebuilds the log paths encoded by CompressLogPaths. Entries keeping a full path,
+// e.g. the ones persisted by older versions, are left untouched.
+func DecompressLogPaths(rootPath string, getPrefixes LogPathPrefixesGetter, binlogType BinlogType, collectionID, partitionID, segmentID UniqueID, fieldBinlogs ...*datapb.FieldBinlog) error {
+	for _, fieldBinlog := range fieldBinlogs {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			prefix := rootPath
+			if binlog.GetLogPath() != "" {
+				id, ok := LogPathPrefixRef(binlog.GetLogPath())
+				if !ok {
+					continue
+				}
+				var err error
+				if prefix, err = lookupLogPathPrefix(getPrefixes, id); err != nil {
+					return fmt.Errorf("decompress log paths of segment %d failed: %w", segmentID, err)
+				}
+			}
+			logPath, err := BuildLogPath(prefix, binlogType, collectionID, partitionID, segmentID, fieldBinlog.GetFieldID(), binlog.GetLogID())
+			if err != nil {
+				return err
+			}
+			binlog.LogPath = logPath
+		}
+	}
+	return nil
+}
+
+func lookupLogPathPrefix(getPrefixes LogPathPrefixesGetter, id UniqueID) (string, error) {
+	if getPrefixes == nil {
+		return "", WrapErrUnknownLogPathPrefix(id)
+	}
+	prefixes, err := getPrefixes()
+	if err != nil {
+		return "", err
+	}
+	prefix, ok := prefixes.Prefix(id)
+	if !ok {
+		return "", WrapErrUnknownLogPathPrefix(id)
+	}
+	return prefix, nil
+}
+
+// logPathPrefix returns the root path @logPath is built under by the path template of @binlogType,
+// false if @logPath doesn't follow the template.
+func logPathPrefix(logPath string, binlogType BinlogType, collectionID, partitionID, segmentID, fieldID, logID UniqueID) (string, bool, error) {
+	suffix, err := BuildLogPath("", binlogType, collectionID, partitionID, segmentID, fieldID, logID)
+	if err != nil {
+		return "", false, err
+	}
+	if !strings.HasSuffix(logPath, "/"+suffix) {
+		return "", false, nil
+	}
+	prefix := strings.TrimSuffix(logPath, "/"+suffix)
+	rebuilt, err := BuildLogPath(prefix, binlogType, collectionID, partitionID, segmentID, fieldID, logID)
+	if err != nil || rebuilt != logPath {
+		return "", false, err
+	}
+	return prefix, true, nil
+}
+
+// BuildIndexFilePaths rebuilds the index file paths of @fileKeys. Keys holding a full path,
+// e.g. the ones persisted by older versions, are returned as they are.
+func BuildIndexFilePaths(rootPath string, buildID, indexVersion, partitionID, segmentID UniqueID, fileKeys []string) []string {
+	paths := make([]string, 0, len(fileKeys))
+	for _, fileKey := range fileKeys {
+		if strings.Contains(fileKey, "/") {
+			paths = append(paths, fileKey)
+			continue
+		}
+		paths = append(paths, metautil.BuildSegmentIndexFilePath(rootPath, buildID, indexVersion, partitionID, segmentID, fileKey))
+	}
+	return paths
+}
+
+// CompressIndexFileKeys collapses each run of @fileKeys sharing a key template and numbered by consecutive IDs,
+// e.g. the slices "IVF_0", "IVF_1", ..., "IVF_127" of an index, into the template plus the ID range "IVF_{0..127}".
+// The order of the keys is kept.
+func CompressIndexFileKeys(fileKeys []string) []string {
+	compressed := make([]string, 0, len(fileKeys))
+	for i := 0; i < len(fileKeys); {
+		template, first, ok := splitIndexFileKey(fileKeys[i])
+		j := i + 1
+		for ok && j < len(fileKeys) {
+			nextTemplate, id, nextOK := splitIndexFileKey(fileKeys[j])
+			if !nextOK || nextTemplate != template || id != first+int64(j-i) {
+				break
+			}
+			j++
+		}
+		if j-i < 2 {
+			compressed = append(compressed, fileKeys[i])
+		} else {
+			compressed = append(compressed, fmt.Sprintf("%s{%d..%d}", template, first, first+int64(j-i-1)))
+		}
+		i = j
+	}
+	return compressed
+}
+
+// DecompressIndexFileKeys expands the key ranges encoded by CompressIndexFileKeys. Other keys, e.g. the
+// full index file paths persisted by older versions, are returned as they are.
+func DecompressIndexFileKeys(fileKeys []string) []string {
+	decompressed := make([]string, 0, len(fileKeys))
+	for _, fileKey := range fileKeys {
+		matches := indexFileKeyRangePattern.FindStringSubmatch(fileKey)
+		if matches == nil {
+			decompressed = append(decompressed, fileKey)
+			continue
+		}
+		first, err1 := strconv.ParseInt(matches[2], 10, 64)
+		last, err2 := strconv.ParseInt(matches[3], 10, 64)
+		if err1 != nil || err2 != nil || first > last {
+			decompressed = append(decompressed, fileKey)
+			continue
+		}
+		for id := first; id <= last; id++ {
+			decompressed = append(decompressed, matches[1]+strconv.FormatInt(id, 10))
+		}
+	}
+	return decompressed
+}
+
+var indexFileKeyRangePattern = regexp.MustCompile(`^([^/{}]*)\{(\d+)\.\.(\d+)\}$`)
+
+// splitIndexFileKey splits @fileKey into the key template and the trailing ID.
+func splitIndexFileKey(fileKey string) (string, int64, bool) {
+	if strings.ContainsAny(fileKey, "/{}") {
+		return "", 0, false
+	}
+	idx := len(fileKey)
+	for idx > 0 && fileKey[idx-1] >= '0' && fileKey[idx-1] <= '9' {
+		idx--
+	}
+	// leading zeros can't be rebuilt from the ID
+	if idx == len(fileKey) || (fileKey[idx] == '0' && idx < len(fileKey)-1) {
+		return "", 0, false
+	}
+	id, err := strconv.ParseInt(fileKey[idx:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return fileKey[:idx], id, true
+}
+
+func parseLogID(logPath string) (UniqueID, error) {
+	idx := strings.LastIndex(logPath, "/")
+	if idx == -1 {
+		return 0, fmt.Errorf("invalid binlog path: %s", logPath)
+	}
+	return strconv.ParseInt(logPath[idx+1:], 10, 64)
+}
@@ -0,0 +1,186 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/proto/datapb"
+	"github.com/milvus-io/milvus/internal/util/metautil"
+)
+
+func TestCompressLogPaths(t *testing.T) {
+	rootPath := "files"
+	insertLogPath := metautil.BuildInsertLogPath(rootPath, 1, 10, 100, 101, 1000)
+	foreignLogPath := metautil.BuildInsertLogPath("other", 1, 10, 100, 101, 1001)
+	fieldBinlog := &datapb.FieldBinlog{
+		FieldID: 101,
+		Binlogs: []*datapb.Binlog{
+			{LogPath: insertLogPath},
+			{LogPath: foreignLogPath},
+			{LogID: 1002},
+		},
+	}
+
+	err := CompressLogPaths(rootPath, nil, InsertBinlog, 1, 10, 100, fieldBinlog)
+	require.NoError(t, err)
+	assert.Equal(t, "", fieldBinlog.Binlogs[0].GetLogPath())
+	assert.EqualValues(t, 1000, fieldBinlog.Binlogs[0].GetLogID())
+	assert.Equal(t, foreignLogPath, fieldBinlog.Binlogs[1].GetLogPath())
+	assert.EqualValues(t, 1001, fieldBinlog.Binlogs[1].GetLogID())
+	assert.Equal(t, "", fieldBinlog.Binlogs[2].GetLogPath())
+
+	err = DecompressLogPaths(rootPath, nil, InsertBinlog, 1, 10, 100, fieldBinlog)
+	require.NoError(t, err)
+	assert.Equal(t, insertLogPath, fieldBinlog.Binlogs[0].GetLogPath())
+	assert.Equal(t, foreignLogPath, fieldBinlog.Binlogs[1].GetLogPath())
+	assert.Equal(t, metautil.BuildInsertLogPath(rootPath, 1, 10, 100, 101, 1002), fieldBinlog.Binlogs[2].GetLogPath())
+
+	deltalog := &datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogPath: metautil.BuildDeltaLogPath(rootPath, 1, 10, 100, 2000)}}}
+	err = CompressLogPaths(rootPath, nil, DeleteBinlog, 1, 10, 100, deltalog)
+	require.NoError(t, err)
+	assert.Equal(t, "", deltalog.Binlogs[0].GetLogPath())
+
+	untemplated := &datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogPath: "badpath"}, {LogPath: "bad/path"}}}
+	err = CompressLogPaths(rootPath, nil, InsertBinlog, 1, 10, 100, untemplated)
+	require.NoError(t, err)
+	assert.Equal(t, "badpath", untemplated.Binlogs[0].GetLogPath())
+	assert.Equal(t, "bad/path", untemplated.Binlogs[1].GetLogPath())
+	err = CompressLogPaths(rootPath, nil, BinlogType(100), 1, 10, 100, &datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogPath: "a/1"}}})
+	assert.Error(t, err)
+	err = DecompressLogPaths(rootPath, nil, BinlogType(100), 1, 10, 100, &datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogID: 1}}})
+	assert.Error(t, err)
+}
+
+func TestCompressLogPathsWithPrefixes(t *testing.T) {
+	rootPath := "files"
+	var allocated []string
+	prefixes := NewLogPathPrefixes(func(prefix string) (UniqueID, error) {
+		allocated = append(allocated, prefix)
+		return UniqueID(len(allocated) + 1), nil
+	})
+	prefixes.Set(1, "old")
+
+	statslog := func(prefix string, logID UniqueID) string {
+		return metautil.BuildStatsLogPath(prefix, 1, 10, 100, 101, logID)
+	}
+	untemplated := "somewhere/else/3000"
+	fieldBinlog := &datapb.FieldBinlog{
+		FieldID: 101,
+		Binlogs: []*datapb.Binlog{
+			{LogPath: statslog(rootPath, 1000)},
+			{LogPath: statslog("old", 1001)},
+			{LogPath: statslog("/mnt/other", 1002)},
+			{LogPath: statslog("/mnt/other", 1003)},
+			{LogPath: untemplated},
+		},
+	}
+
+	getPrefixes := func() (*LogPathPrefixes, error) { return prefixes, nil }
+	err := CompressLogPaths(rootPath, getPrefixes, StatsBinlog, 1, 10, 100, fieldBinlog)
+	require.NoError(t, err)
+	assert.Equal(t, "", fieldBinlog.Binlogs[0].GetLogPath())
+	assert.Equal(t, "$1", fieldBinlog.Binlogs[1].GetLogPath())
+	assert.EqualValues(t, 1001, fieldBinlog.Binlogs[1].GetLogID())
+	assert.Equal(t, "$2", fieldBinlog.Binlogs[2].GetLogPath())
+	assert.Equal(t, "$2", fieldBinlog.Binlogs[3].GetLogPath())
+	assert.Equal(t, untemplated, fieldBinlog.Binlogs[4].GetLogPath())
+	prefix, ok := prefixes.Prefix(2)
+	assert.True(t, ok)
+	assert.Equal(t, "/mnt/other", prefix)
+	// allocated once for the new prefix only
+	assert.Equal(t, []string{"/mnt/other"}, allocated)
+
+	id, ok := LogPathPrefixRef(fieldBinlog.Binlogs[2].GetLogPath())
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, id)
+	_, ok = LogPathPrefixRef(untemplated)
+	assert.False(t, ok)
+
+	// compressing twice changes nothing
+	err = CompressLogPaths(rootPath, getPrefixes, StatsBinlog, 1, 10, 100, fieldBinlog)
+	require.NoError(t, err)
+	assert.Equal(t, "$1", fieldBinlog.Binlogs[1].GetLogPath())
+
+	err = DecompressLogPaths(rootPath, getPrefixes, StatsBinlog, 1, 10, 100, fieldBinlog)
+	require.NoError(t, err)
+	assert.Equal(t, statslog(rootPath, 1000), fieldBinlog.Binlogs[0].GetLogPath())
+	assert.Equal(t, statslog("old", 1001), fieldBinlog.Binlogs[1].GetLogPath())
+	assert.Equal(t, statslog("/mnt/other", 1002), fieldBinlog.Binlogs[2].GetLogPath())
+	assert.Equal(t, statslog("/mnt/other", 1003), fieldBinlog.Binlogs[3].GetLogPath())
+	assert.Equal(t, untemplated, fieldBinlog.Binlogs[4].GetLogPath())
+
+	// the prefix referred is unknown
+	err = DecompressLogPaths(rootPath, func() (*LogPathPrefixes, error) { return NewLogPathPrefixes(nil), nil }, StatsBinlog, 1, 10, 100,
+		&datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogID: 1, LogPath: "$3"}}})
+	assert.ErrorIs(t, err, ErrUnknownLogPathPrefix)
+	err = DecompressLogPaths(rootPath, nil, StatsBinlog, 1, 10, 100,
+		&datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogID: 1, LogPath: "$1"}}})
+	assert.ErrorIs(t, err, ErrUnknownLogPathPrefix)
+	err = DecompressLogPaths(rootPath, func() (*LogPathPrefixes, error) { return nil, errors.New("mock error") }, StatsBinlog, 1, 10, 100,
+		&datapb.FieldBinlog{Binlogs: []*datapb.Binlog{{LogID: 1, LogPath: "$1"}}})
+	assert.Error(t, err)
+
+	// the dictionary is only got for the paths under another root path
+	getPrefixes = func() (*LogPathPrefixes, error) { return nil, errors.New("mock error") }
+	err = CompressLogPaths(rootPath, getPrefixes, StatsBinlog, 1, 10, 100,
+		&datapb.FieldBinlog{FieldID: 101, Binlogs: []*datapb.Binlog{{LogPath: statslog(rootPath, 1000)}, {LogPath: untemplated}}})
+	assert.NoError(t, err)
+	err = CompressLogPaths(rootPath, getPrefixes, StatsBinlog, 1, 10, 100,
+		&datapb.FieldBinlog{FieldID: 101, Binlogs: []*datapb.Binlog{{LogPath: statslog("/mnt/other", 1000)}}})
+	assert.Error(t, err)
+
+	// the paths under the new prefixes are kept without an allocator
+	fieldBinlog = &datapb.FieldBinlog{FieldID: 101, Binlogs: []*datapb.Binlog{{LogPath: statslog("/mnt/new", 1000)}}}
+	getPrefixes = func() (*LogPathPrefixes, error) { return NewLogPathPrefixes(nil), nil }
+	err = CompressLogPaths(rootPath, getPrefixes, StatsBinlog, 1, 10, 100, fieldBinlog)
+	assert.NoError(t, err)
+	assert.Equal(t, statslog("/mnt/new", 1000), fieldBinlog.Binlogs[0].GetLogPath())
+
+	// the allocation failed
+	getPrefixes = func() (*LogPathPrefixes, error) {
+		return NewLogPathPrefixes(func(prefix string) (UniqueID, error) { return 0, errors.New("mock error") }), nil
+	}
+	err = CompressLogPaths(rootPath, getPrefixes, StatsBinlog, 1, 10, 100, fieldBinlog)
+	assert.Error(t, err)
+}
+
+func TestBuildIndexFilePaths(t *testing.T) {
+	rootPath := "files"
+	legacyPath := "legacy/index_files/1/2/3/4/IVF"
+	paths := BuildIndexFilePaths(rootPath, 1, 2, 3, 4, []string{"IVF", legacyPath})
+	assert.Equal(t, []string{metautil.BuildSegmentIndexFilePath(rootPath, 1, 2, 3, 4, "IVF"), legacyPath}, paths)
+}
+
+func TestCompressIndexFileKeys(t *testing.T) {
+	fileKeys := []string{IndexParamsKey, "SLICE_META", "IVF_0", "IVF_1", "IVF_2", "IVF_3", "RAW_7", "RAW_8", "IVF_5", "HNSW", "V_01", "V_02"}
+	compressed := CompressIndexFileKeys(fileKeys)
+	assert.Equal(t, []string{IndexParamsKey, "SLICE_META", "IVF_{0..3}", "RAW_{7..8}", "IVF_5", "HNSW", "V_01", "V_02"}, compressed)
+	assert.Equal(t, fileKeys, DecompressIndexFileKeys(compressed))
+
+	// the keys persisted by older versions
+	legacy := []string{"IVF_0", "IVF_1", "files/index_files/1/2/3/4/IVF_2"}
+	assert.Equal(t, legacy, DecompressIndexFileKeys(legacy))
+	assert.Equal(t, []string{"IVF_{0..1}", "files/index_files/1/2/3/4/IVF_2"}, CompressIndexFileKeys(legacy))
+
+	assert.Equal(t, []string{"IVF_{3..1}"}, DecompressIndexFileKeys([]string{"IVF_{3..1}"}))
+	assert.Empty(t, CompressIndexFileKeys(nil))
+}
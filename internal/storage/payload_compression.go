@@ -0,0 +1,147 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/parquet/compress"
+	"github.com/pierrec/lz4/v4"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// PayloadCompression is the codec compressing the scalar payloads of the insert binlogs.
+type PayloadCompression string
+
+const (
+	// PayloadCompressionNone leaves the payloads uncompressed
+	PayloadCompressionNone PayloadCompression = "none"
+	// PayloadCompressionZstd compresses the column chunks of the payloads by zstd, it's the codec of the binlogs
+	// written before the codec is configurable, and the one of the vector payloads
+	PayloadCompressionZstd PayloadCompression = "zstd"
+	// PayloadCompressionLz4 compresses the whole uncompressed payloads by the lz4 frame format, it's faster
+	// than zstd but the payloads are only readable by the go readers
+	PayloadCompressionLz4 PayloadCompression = "lz4"
+)
+
+// payloadCompressionLz4Code is the code of lz4 recorded in the fixed part of the insert events.
+// The none and zstd payloads are bare parquet files whose codec is recorded by parquet in the column
+// chunks, the fixed part of their events is left as it is so that they stay readable by segcore.
+const payloadCompressionLz4Code uint8 = 1
+
+// ParsePayloadCompression parses the codec @s, zstd is returned if it's empty.
+func ParsePayloadCompression(s string) (PayloadCompression, error) {
+	switch compression := PayloadCompression(strings.ToLower(strings.TrimSpace(s))); compression {
+	case "":
+		return PayloadCompressionZstd, nil
+	case PayloadCompressionNone, PayloadCompressionZstd, PayloadCompressionLz4:
+		return compression, nil
+	default:
+		return "", fmt.Errorf("invalid payload compression %s, should be %s, %s or %s",
+			s, PayloadCompressionNone, PayloadCompressionZstd, PayloadCompressionLz4)
+	}
+}
+
+// GetPayloadCompression returns the codec of the collection with @properties, it's the collection property
+// collection.payload.compression if specified, otherwise common.binlog.payloadCompression.
+func GetPayloadCompression(properties []*commonpb.KeyValuePair) (PayloadCompression, error) {
+	for _, kv := range properties {
+		if kv.GetKey() == common.CollectionPayloadCompressionKey {
+			return ParsePayloadCompression(kv.GetValue())
+		}
+	}
+	return defaultPayloadCompression()
+}
+
+// defaultPayloadCompression returns common.binlog.payloadCompression, the param stays empty if not initialized,
+// e.g. in tools and unit tests.
+func defaultPayloadCompression() (PayloadCompression, error) {
+	return ParsePayloadCompression(paramtable.Get().CommonCfg.BinlogPayloadCompression)
+}
+
+// parquetCodec returns the codec of the column chunks of the payloads.
+func (c PayloadCompression) parquetCodec() compress.Compression {
+	if c == PayloadCompressionZstd {
+		return compress.Codecs.Zstd
+	}
+	// the lz4 payloads are compressed as a whole
+	return compress.Codecs.Uncompressed
+}
+
+// compressPayload compresses the parquet payload @buf written with the column chunks compressed by parquetCodec.
+func compressPayload(compression PayloadCompression, buf []byte) ([]byte, error) {
+	if compression != PayloadCompressionLz4 {
+		return buf, nil
+	}
+	output := &bytes.Buffer{}
+	writer := lz4.NewWriter(output)
+	if _, err := writer.Write(buf); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return output.Bytes(), nil
+}
+
+// decompressPayload restores the parquet payload compressed by compressPayload.
+func decompressPayload(compression PayloadCompression, buf []byte) ([]byte, error) {
+	if compression != PayloadCompressionLz4 {
+		return buf, nil
+	}
+	payload, err := io.ReadAll(lz4.NewReader(bytes.NewReader(buf)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress lz4 payload: %w", err)
+	}
+	return payload, nil
+}
+
+// payloadCompressionOf returns the codec of the payloads of @dataType in a collection compressed by @compression.
+// The vector payloads are written by the cgo writer and loaded by segcore, which only reads bare parquet
+// files, so they are always compressed by zstd.
+func payloadCompressionOf(compression PayloadCompression, dataType schemapb.DataType) PayloadCompression {
+	if typeutil.IsVectorType(dataType) {
+		return PayloadCompressionZstd
+	}
+	return compression
+}
+
+// codeSize returns the size of the code of the codec recorded in the fixed part of the insert events,
+// it's 0 if the codec is not recorded.
+func (c PayloadCompression) codeSize() int32 {
+	if c != PayloadCompressionLz4 {
+		return 0
+	}
+	return int32(binary.Size(payloadCompressionLz4Code))
+}
+
+// payloadCompressionOfCode returns the codec of @code recorded in the fixed part of an insert event.
+func payloadCompressionOfCode(code uint8) (PayloadCompression, error) {
+	if code != payloadCompressionLz4Code {
+		return "", fmt.Errorf("unknown payload compression code %d", code)
+	}
+	return PayloadCompressionLz4, nil
+}
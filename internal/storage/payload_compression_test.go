@@ -0,0 +1,195 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+)
+
+func TestParsePayloadCompression(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected PayloadCompression
+	}{
+		{"", PayloadCompressionZstd},
+		{"none", PayloadCompressionNone},
+		{"zstd", PayloadCompressionZstd},
+		{" LZ4 ", PayloadCompressionLz4},
+	}
+	for _, test := range tests {
+		compression, err := ParsePayloadCompression(test.value)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, compression)
+	}
+	_, err := ParsePayloadCompression("snappy")
+	assert.Error(t, err)
+}
+
+func TestGetPayloadCompression(t *testing.T) {
+	compression, err := GetPayloadCompression(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, PayloadCompressionZstd, compression)
+
+	params := paramtable.Get()
+	defaultCompression := params.CommonCfg.BinlogPayloadCompression
+	defer func() { params.CommonCfg.BinlogPayloadCompression = defaultCompression }()
+	params.CommonCfg.BinlogPayloadCompression = "none"
+	compression, err = GetPayloadCompression([]*commonpb.KeyValuePair{{Key: common.CollectionTTLConfigKey, Value: "10"}})
+	assert.NoError(t, err)
+	assert.Equal(t, PayloadCompressionNone, compression)
+
+	compression, err = GetPayloadCompression([]*commonpb.KeyValuePair{{Key: common.CollectionPayloadCompressionKey, Value: "lz4"}})
+	assert.NoError(t, err)
+	assert.Equal(t, PayloadCompressionLz4, compression)
+
+	_, err = GetPayloadCompression([]*commonpb.KeyValuePair{{Key: common.CollectionPayloadCompressionKey, Value: "gzip"}})
+	assert.Error(t, err)
+}
+
+func TestPayloadCompression(t *testing.T) {
+	values := make([]int64, 10000)
+	for i := range values {
+		values[i] = int64(i % 7)
+	}
+	sizes := make(map[PayloadCompression]int)
+	for _, compression := range []PayloadCompression{PayloadCompressionNone, PayloadCompressionZstd, PayloadCompressionLz4} {
+		t.Run(string(compression), func(t *testing.T) {
+			w, err := NewNativePayloadWriter(schemapb.DataType_Int64)
+			require.NoError(t, err)
+			defer w.Close()
+			require.NoError(t, w.SetCompression(compression))
+			require.NoError(t, w.AddInt64ToPayload(values))
+			require.NoError(t, w.FinishPayloadWriter())
+			assert.Error(t, w.SetCompression(compression))
+			buf, err := w.GetPayloadBufferFromWriter()
+			require.NoError(t, err)
+			sizes[compression] = len(buf)
+
+			payload, err := decompressPayload(compression, buf)
+			require.NoError(t, err)
+			r, err := NewPayloadReader(schemapb.DataType_Int64, payload)
+			require.NoError(t, err)
+			defer r.Close()
+			data, err := r.GetInt64FromPayload()
+			require.NoError(t, err)
+			assert.Equal(t, values, data)
+		})
+	}
+	assert.Less(t, sizes[PayloadCompressionZstd], sizes[PayloadCompressionNone])
+	assert.Less(t, sizes[PayloadCompressionLz4], sizes[PayloadCompressionNone])
+
+	w, err := NewNativePayloadWriter(schemapb.DataType_Int64)
+	require.NoError(t, err)
+	defer w.Close()
+	assert.Error(t, w.SetCompression("snappy"))
+
+	_, err = decompressPayload(PayloadCompressionLz4, []byte("not lz4"))
+	assert.Error(t, err)
+}
+
+func TestInsertCodec_PayloadCompression(t *testing.T) {
+	meta, data := genParquetBinlogTestData(100)
+	// the vector payloads are written by the cgo writer
+	meta.Schema.Fields = meta.Schema.Fields[:10]
+	delete(data.Data, 108)
+	delete(data.Data, 109)
+
+	for _, compression := range []PayloadCompression{"", PayloadCompressionNone, PayloadCompressionZstd, PayloadCompressionLz4} {
+		t.Run(string(compression), func(t *testing.T) {
+			codec := NewInsertCodec(meta)
+			codec.PayloadCompression = compression
+			blobs, _, err := codec.Serialize(2, 3, data)
+			require.NoError(t, err)
+
+			// only lz4 is recorded in the insert events, the others are bare parquet payloads
+			var expected PayloadCompression
+			postHeaderLength := getEventFixPartSize(InsertEventType)
+			if compression == PayloadCompressionLz4 {
+				expected = PayloadCompressionLz4
+				postHeaderLength++
+			}
+			for _, blob := range blobs {
+				reader, err := NewBinlogReader(blob.Value)
+				require.NoError(t, err)
+				assert.Equal(t, uint8(postHeaderLength), reader.PostHeaderLengths[InsertEventType])
+				eventReader, err := reader.NextEventReader()
+				require.NoError(t, err)
+				assert.Equal(t, expected, eventReader.payloadCompression)
+				reader.Close()
+			}
+
+			_, _, deserialized, err := codec.Deserialize(blobs)
+			require.NoError(t, err)
+			for fieldID, fieldData := range data.Data {
+				for i := 0; i < fieldData.RowNum(); i++ {
+					assert.Equal(t, fieldData.GetRow(i), deserialized.Data[fieldID].GetRow(i), fieldID)
+				}
+			}
+		})
+	}
+
+	codec := NewInsertCodec(meta)
+	codec.PayloadCompression = "snappy"
+	_, _, err := codec.Serialize(2, 3, data)
+	assert.Error(t, err)
+}
+
+func TestInsertBinlogWriter_PayloadCompression(t *testing.T) {
+	// the vector payloads are loaded by segcore, which only reads bare parquet payloads
+	w := NewInsertBinlogWriter(schemapb.DataType_FloatVector, 1, 2, 3, 100)
+	defer w.Close()
+	assert.NoError(t, w.SetPayloadCompression(PayloadCompressionZstd))
+	assert.Error(t, w.SetPayloadCompression(PayloadCompressionLz4))
+	assert.Error(t, w.SetPayloadCompression(PayloadCompressionNone))
+	assert.Equal(t, uint8(getEventFixPartSize(InsertEventType)), w.PostHeaderLengths[InsertEventType])
+
+	assert.Equal(t, PayloadCompressionZstd, payloadCompressionOf(PayloadCompressionLz4, schemapb.DataType_BinaryVector))
+	assert.Equal(t, PayloadCompressionLz4, payloadCompressionOf(PayloadCompressionLz4, schemapb.DataType_Int64))
+
+	w = NewInsertBinlogWriter(schemapb.DataType_Int64, 1, 2, 3, 100)
+	defer w.Close()
+	assert.Error(t, w.SetPayloadCompression("gzip"))
+	assert.NoError(t, w.SetPayloadCompression(PayloadCompressionLz4))
+	assert.Equal(t, uint8(getEventFixPartSize(InsertEventType)+1), w.PostHeaderLengths[InsertEventType])
+}
+
+func TestReadInsertEventPayloadCompression(t *testing.T) {
+	// the binlogs written before the codec is configurable have no codec recorded
+	compression, err := readInsertEventPayloadCompression(&bytes.Buffer{}, 16)
+	assert.NoError(t, err)
+	assert.Equal(t, PayloadCompression(""), compression)
+
+	compression, err = readInsertEventPayloadCompression(bytes.NewBuffer([]byte{payloadCompressionLz4Code}), 17)
+	assert.NoError(t, err)
+	assert.Equal(t, PayloadCompressionLz4, compression)
+
+	_, err = readInsertEventPayloadCompression(bytes.NewBuffer([]byte{2}), 17)
+	assert.Error(t, err)
+	_, err = readInsertEventPayloadCompression(&bytes.Buffer{}, 17)
+	assert.Error(t, err)
+	_, err = readInsertEventPayloadCompression(bytes.NewBuffer([]byte{1, 1}), 18)
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/parquet"
+	"github.com/apache/arrow/go/v8/parquet/metadata"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+)
+
+// int64sSorted returns whether @values are in non-decreasing order.
+func int64sSorted(values []int64) bool {
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// int64DeltaProperties returns the writer properties of the int64 values in @arr, they are delta-encoded and
+// bit-packed if they are sorted, e.g. the auto id primary keys, the row ids and the timestamps of a segment,
+// whose deltas take a few bits only.
+func int64DeltaProperties(arr *array.Int64) []parquet.WriterProperty {
+	if !int64sSorted(arr.Int64Values()) {
+		return nil
+	}
+	// the encoding applies only if dictionary encoding is disabled
+	return []parquet.WriterProperty{
+		parquet.WithDictionaryDefault(false),
+		parquet.WithEncoding(parquet.Encodings.DeltaBinaryPacked),
+	}
+}
+
+// GetInt64RangeFromPayload returns the min and max values of the payload from the statistics in its footer
+// without reading the data, false is returned if the payload has no statistics.
+func (r *PayloadReader) GetInt64RangeFromPayload() (min int64, max int64, ok bool, err error) {
+	if r.colType != schemapb.DataType_Int64 {
+		return 0, 0, false, fmt.Errorf("failed to get int64 range from datatype %v", r.colType.String())
+	}
+	for i := 0; i < r.reader.NumRowGroups(); i++ {
+		column, err := r.reader.MetaData().RowGroup(i).ColumnChunk(0)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		if set, err := column.StatsSet(); err != nil || !set {
+			return 0, 0, false, err
+		}
+		stats, err := column.Statistics()
+		if err != nil {
+			return 0, 0, false, err
+		}
+		int64Stats, isInt64 := stats.(*metadata.Int64Statistics)
+		if !isInt64 || !int64Stats.HasMinMax() {
+			return 0, 0, false, nil
+		}
+		if !ok || int64Stats.Min() < min {
+			min = int64Stats.Min()
+		}
+		if !ok || int64Stats.Max() > max {
+			max = int64Stats.Max()
+		}
+		ok = true
+	}
+	return min, max, ok, nil
+}
+
+// Int64BinlogRange returns the min and max values of the Int64 insert binlog @content, only the footers of the
+// payloads are read, so that the binlogs out of a range, e.g. of the primary keys deleted, are skipped cheaply.
+// False is returned if some payload has no statistics.
+func Int64BinlogRange(content []byte) (min int64, max int64, ok bool, err error) {
+	if BinlogFormatVersion(content) == BinlogFormatV2 {
+		return 0, 0, false, nil
+	}
+	reader, err := NewBinlogReader(content)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer reader.Close()
+	if reader.PayloadDataType != schemapb.DataType_Int64 {
+		return 0, 0, false, fmt.Errorf("failed to get int64 range from datatype %v", reader.PayloadDataType.String())
+	}
+
+	for {
+		eventReader, err := reader.NextEventReader()
+		if err != nil {
+			return 0, 0, false, err
+		}
+		if eventReader == nil {
+			break
+		}
+		payloadReader, isNative := eventReader.PayloadReaderInterface.(*PayloadReader)
+		if !isNative {
+			return 0, 0, false, nil
+		}
+		eventMin, eventMax, eventOk, err := payloadReader.GetInt64RangeFromPayload()
+		if err != nil || !eventOk {
+			return 0, 0, false, err
+		}
+		if !ok || eventMin < min {
+			min = eventMin
+		}
+		if !ok || eventMax > max {
+			max = eventMax
+		}
+		ok = true
+	}
+	return min, max, ok, nil
+}
@@ -0,0 +1,125 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v8/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+)
+
+func writeInt64Payload(t *testing.T, values []int64) []byte {
+	w, err := NewNativePayloadWriter(schemapb.DataType_Int64)
+	require.NoError(t, err)
+	defer w.Close()
+	require.NoError(t, w.SetCompression(PayloadCompressionNone))
+	require.NoError(t, w.AddInt64ToPayload(values))
+	require.NoError(t, w.FinishPayloadWriter())
+	buf, err := w.GetPayloadBufferFromWriter()
+	require.NoError(t, err)
+	return buf
+}
+
+func payloadEncodings(t *testing.T, r *PayloadReader) []parquet.Encoding {
+	column, err := r.reader.MetaData().RowGroup(0).ColumnChunk(0)
+	require.NoError(t, err)
+	return column.Encodings()
+}
+
+func TestPayloadDelta(t *testing.T) {
+	const base = int64(443348749428113409)
+	rows := 100000
+
+	t.Run("sorted", func(t *testing.T) {
+		values := make([]int64, rows)
+		for i := range values {
+			// auto id with a few gaps
+			values[i] = base + int64(i) + int64(i/1000)
+		}
+		buf := writeInt64Payload(t, values)
+		// the plain encoding takes 8 bytes per row
+		assert.Less(t, len(buf), rows)
+
+		r, err := NewPayloadReader(schemapb.DataType_Int64, buf)
+		require.NoError(t, err)
+		defer r.Close()
+		assert.Contains(t, payloadEncodings(t, r), parquet.Encodings.DeltaBinaryPacked)
+		data, err := r.GetInt64FromPayload()
+		require.NoError(t, err)
+		assert.Equal(t, values, data)
+
+		min, max, ok, err := r.GetInt64RangeFromPayload()
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, values[0], min)
+		assert.Equal(t, values[rows-1], max)
+	})
+
+	t.Run("unsorted", func(t *testing.T) {
+		values := []int64{5, 3, 9, -1, 3}
+		r, err := NewPayloadReader(schemapb.DataType_Int64, writeInt64Payload(t, values))
+		require.NoError(t, err)
+		defer r.Close()
+		assert.NotContains(t, payloadEncodings(t, r), parquet.Encodings.DeltaBinaryPacked)
+		data, err := r.GetInt64FromPayload()
+		require.NoError(t, err)
+		assert.Equal(t, values, data)
+
+		min, max, ok, err := r.GetInt64RangeFromPayload()
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, int64(-1), min)
+		assert.Equal(t, int64(9), max)
+	})
+
+	t.Run("not int64", func(t *testing.T) {
+		r, err := NewPayloadReader(schemapb.DataType_VarChar, writeStringPayload(t, []string{"a"}))
+		require.NoError(t, err)
+		defer r.Close()
+		_, _, _, err = r.GetInt64RangeFromPayload()
+		assert.Error(t, err)
+	})
+}
+
+func TestInt64BinlogRange(t *testing.T) {
+	w := NewInsertBinlogWriter(schemapb.DataType_Int64, 10, 20, 30, 40)
+	defer w.Close()
+	for _, values := range [][]int64{{100, 101, 103}, {7, 150, 99}} {
+		e, err := w.NextInsertEventWriter()
+		require.NoError(t, err)
+		require.NoError(t, e.AddDataToPayload(values))
+		e.SetEventTimestamp(100, 200)
+	}
+	w.SetEventTimeStamp(1000, 2000)
+	w.baseBinlogWriter.descriptorEventData.AddExtra(originalSizeKey, "48")
+	require.NoError(t, w.Finish())
+	buf, err := w.GetBuffer()
+	require.NoError(t, err)
+
+	min, max, ok, err := Int64BinlogRange(buf)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), min)
+	assert.Equal(t, int64(150), max)
+
+	_, _, _, err = Int64BinlogRange([]byte("invalid"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,239 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/parquet"
+	"github.com/apache/arrow/go/v8/parquet/file"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/util/paramtable"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// DefaultPayloadDictionaryMaxCardinality is the default of common.binlog.dictionaryMaxCardinality.
+const DefaultPayloadDictionaryMaxCardinality = 4096
+
+// PayloadDictionaryMaxCardinality returns common.binlog.dictionaryMaxCardinality, the max number of distinct
+// values of the VarChar payloads always dictionary-encoded.
+func PayloadDictionaryMaxCardinality() int {
+	// the param stays zero if not initialized, e.g. in tools and unit tests
+	if maxCardinality := paramtable.Get().CommonCfg.BinlogDictionaryMaxCardinality; maxCardinality > 0 {
+		return maxCardinality
+	}
+	return DefaultPayloadDictionaryMaxCardinality
+}
+
+// stringDictionaryPageSize returns the size of the dictionary page of the strings in @arr,
+// false is returned if there are more than @maxCardinality distinct strings.
+func stringDictionaryPageSize(arr *array.String, maxCardinality int) (int64, bool) {
+	distinct := make(map[string]struct{})
+	var size int64
+	for i := 0; i < arr.Len(); i++ {
+		value := arr.Value(i)
+		if _, ok := distinct[value]; ok {
+			continue
+		}
+		if len(distinct) >= maxCardinality {
+			return 0, false
+		}
+		distinct[value] = struct{}{}
+		// a dictionary value is encoded as its length followed by its bytes
+		size += int64(len(value)) + 4
+	}
+	return size, true
+}
+
+// stringDictionaryProperties returns the writer properties of the strings in @arr, they are dictionary-encoded
+// without falling back to plain if there are at most @maxCardinality distinct strings.
+func stringDictionaryProperties(arr *array.String, maxCardinality int) []parquet.WriterProperty {
+	size, ok := stringDictionaryPageSize(arr, maxCardinality)
+	if !ok {
+		return nil
+	}
+	opts := []parquet.WriterProperty{parquet.WithDictionaryDefault(true)}
+	// the writer falls back to plain once the dictionary reaches the limit
+	if size >= parquet.DefaultDictionaryPageSizeLimit {
+		opts = append(opts, parquet.WithDictionaryPageSizeLimit(size+1))
+	}
+	return opts
+}
+
+// dictionaryPageType is the name of the dictionary page type, whose enum is internal to the parquet package.
+const dictionaryPageType = "DICTIONARY_PAGE"
+
+// dictionaryEncoded returns whether all the data pages of the payload are dictionary-encoded, which is decided by
+// the page encoding stats in the parquet footer without decoding any page. The column chunks falling back to plain
+// have a dictionary page as well, and the chunks without the encoding stats are taken as not dictionary-encoded.
+func (r *PayloadReader) dictionaryEncoded() bool {
+	if r.reader.NumRowGroups() == 0 {
+		return false
+	}
+	for i := 0; i < r.reader.NumRowGroups(); i++ {
+		column, err := r.reader.MetaData().RowGroup(i).ColumnChunk(0)
+		if err != nil || !column.HasDictionaryPage() || len(column.EncodingStats()) == 0 {
+			return false
+		}
+		for _, stats := range column.EncodingStats() {
+			if stats.PageType.String() == dictionaryPageType {
+				continue
+			}
+			if stats.Encoding != parquet.Encodings.PlainDict && stats.Encoding != parquet.Encodings.RLEDict {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// internByteArrays returns the distinct values of @values in order of appearance and the codes of the rows,
+// so that the rows of the same value share the memory. Nil is returned if there are more than @maxCardinality
+// distinct values.
+func internByteArrays(values []parquet.ByteArray, maxCardinality int) ([]string, []int32) {
+	index := make(map[string]int32)
+	dictionary := make([]string, 0)
+	codes := make([]int32, len(values))
+	for i, value := range values {
+		// the lookup by the converted bytes doesn't allocate
+		code, ok := index[string(value)]
+		if !ok {
+			if len(dictionary) >= maxCardinality {
+				return nil, nil
+			}
+			code = int32(len(dictionary))
+			dictionary = append(dictionary, string(value))
+			index[dictionary[code]] = code
+		}
+		codes[i] = code
+	}
+	return dictionary, codes
+}
+
+func (r *PayloadReader) readByteArrays() ([]parquet.ByteArray, error) {
+	if r.colType != schemapb.DataType_String && r.colType != schemapb.DataType_VarChar {
+		return nil, fmt.Errorf("failed to get string from datatype %v", r.colType.String())
+	}
+
+	values := make([]parquet.ByteArray, r.numRows)
+	valuesRead, err := ReadDataFromAllRowGroups[parquet.ByteArray, *file.ByteArrayColumnChunkReader](r.reader, values, 0, r.numRows)
+	if err != nil {
+		return nil, err
+	}
+
+	if valuesRead != r.numRows {
+		return nil, fmt.Errorf("expect %d rows, but got valuesRead = %d", r.numRows, valuesRead)
+	}
+	return values, nil
+}
+
+// GetStringDictionaryFromPayload returns the strings of the payload dictionary-encoded, nil is returned without
+// decoding the payload if it's not dictionary-encoded.
+func (r *PayloadReader) GetStringDictionaryFromPayload() (*DictionaryEncodedStrings, error) {
+	if r.colType != schemapb.DataType_String && r.colType != schemapb.DataType_VarChar {
+		return nil, fmt.Errorf("failed to get string from datatype %v", r.colType.String())
+	}
+	if !r.dictionaryEncoded() {
+		return nil, nil
+	}
+	values, err := r.readByteArrays()
+	if err != nil {
+		return nil, err
+	}
+	dictionary, codes := internByteArrays(values, len(values))
+
+	// the codes of DictionaryEncodedStrings are the positions in the sorted dictionary
+	sorted := make([]string, len(dictionary))
+	copy(sorted, dictionary)
+	sort.Strings(sorted)
+	remap := encodeByDictionary(sorted, dictionary)
+	for i, code := range codes {
+		codes[i] = remap[code]
+	}
+	return &DictionaryEncodedStrings{
+		Dictionary: sorted,
+		Codes:      codes,
+	}, nil
+}
+
+// walkStringPayloads calls @fn on the payloads of the VarChar field in @blobs in order until it returns false,
+// false is returned if some binlog is not a VarChar event binlog.
+func walkStringPayloads(blobs []*Blob, fn func(r *PayloadReader) (bool, error)) (bool, error) {
+	for _, blob := range blobs {
+		if BinlogFormatVersion(blob.Value) == BinlogFormatV2 {
+			return false, nil
+		}
+		ok, err := func() (bool, error) {
+			binlogReader, err := NewBinlogReader(blob.Value)
+			if err != nil {
+				return false, err
+			}
+			defer binlogReader.Close()
+			if !typeutil.IsStringType(binlogReader.PayloadDataType) {
+				return false, nil
+			}
+			for {
+				eventReader, err := binlogReader.NextEventReader()
+				if err != nil {
+					return false, err
+				}
+				if eventReader == nil {
+					return true, nil
+				}
+				payloadReader, ok := eventReader.PayloadReaderInterface.(*PayloadReader)
+				if !ok {
+					return false, nil
+				}
+				if next, err := fn(payloadReader); !next || err != nil {
+					return false, err
+				}
+			}
+		}()
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// DeserializeDictionary returns the VarChar field in @blobs dictionary-encoded. It's decided by the parquet footers
+// whether the payloads of all the binlogs are dictionary-encoded before decoding any of them, nil is returned if not.
+func (insertCodec *InsertCodec) DeserializeDictionary(blobs []*Blob) (*DictionaryEncodedStrings, error) {
+	encoded, err := walkStringPayloads(blobs, func(r *PayloadReader) (bool, error) {
+		return r.dictionaryEncoded(), nil
+	})
+	if err != nil || !encoded {
+		return nil, err
+	}
+
+	parts := make([]*DictionaryEncodedStrings, 0, len(blobs))
+	encoded, err = walkStringPayloads(blobs, func(r *PayloadReader) (bool, error) {
+		part, err := r.GetStringDictionaryFromPayload()
+		if err != nil || part == nil {
+			return false, err
+		}
+		parts = append(parts, part)
+		return true, nil
+	})
+	if err != nil || !encoded || len(parts) == 0 {
+		return nil, err
+	}
+	return MergeDictionaries(parts), nil
+}
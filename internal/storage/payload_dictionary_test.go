@@ -0,0 +1,177 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
+	"github.com/milvus-io/milvus/internal/proto/etcdpb"
+)
+
+func writeStringPayload(t *testing.T, values []string) []byte {
+	w, err := NewNativePayloadWriter(schemapb.DataType_VarChar)
+	require.NoError(t, err)
+	defer w.Close()
+	require.NoError(t, w.SetCompression(PayloadCompressionNone))
+	for _, value := range values {
+		require.NoError(t, w.AddOneStringToPayload(value))
+	}
+	require.NoError(t, w.FinishPayloadWriter())
+	buf, err := w.GetPayloadBufferFromWriter()
+	require.NoError(t, err)
+	return buf
+}
+
+func TestPayloadDictionary(t *testing.T) {
+	assert.Equal(t, DefaultPayloadDictionaryMaxCardinality, PayloadDictionaryMaxCardinality())
+
+	t.Run("low cardinality", func(t *testing.T) {
+		// the dictionary is larger than the default dictionary page size limit
+		categories := make([]string, 100)
+		for i := range categories {
+			categories[i] = fmt.Sprintf("%03d", 99-i) + strings.Repeat("c", 20*1024)
+		}
+		values := make([]string, 10000)
+		for i := range values {
+			values[i] = categories[i*7%len(categories)]
+		}
+		buf := writeStringPayload(t, values)
+		// the rows are not expanded, which take about 200MB
+		assert.Less(t, len(buf), 4*1024*1024)
+
+		r, err := NewPayloadReader(schemapb.DataType_VarChar, buf)
+		require.NoError(t, err)
+		defer r.Close()
+		data, err := r.GetStringFromPayload()
+		require.NoError(t, err)
+		assert.Equal(t, values, data)
+
+		assert.True(t, r.dictionaryEncoded())
+		encoded, err := r.GetStringDictionaryFromPayload()
+		require.NoError(t, err)
+		require.NotNil(t, encoded)
+		assert.Len(t, encoded.Dictionary, 100)
+		assert.True(t, sort.StringsAreSorted(encoded.Dictionary))
+		assert.Equal(t, values, encoded.Decode())
+	})
+
+	t.Run("high cardinality", func(t *testing.T) {
+		values := make([]string, 10000)
+		for i := range values {
+			values[i] = fmt.Sprintf("value-%d", i) + strings.Repeat("v", 200)
+		}
+		r, err := NewPayloadReader(schemapb.DataType_VarChar, writeStringPayload(t, values))
+		require.NoError(t, err)
+		defer r.Close()
+		data, err := r.GetStringFromPayload()
+		require.NoError(t, err)
+		assert.Equal(t, values, data)
+
+		// the dictionary page is written before falling back to plain
+		column, err := r.reader.MetaData().RowGroup(0).ColumnChunk(0)
+		require.NoError(t, err)
+		assert.True(t, column.HasDictionaryPage())
+		assert.False(t, r.dictionaryEncoded())
+		encoded, err := r.GetStringDictionaryFromPayload()
+		assert.NoError(t, err)
+		assert.Nil(t, encoded)
+	})
+
+	t.Run("not string", func(t *testing.T) {
+		w, err := NewNativePayloadWriter(schemapb.DataType_Int64)
+		require.NoError(t, err)
+		defer w.Close()
+		require.NoError(t, w.AddInt64ToPayload([]int64{1, 1, 2}))
+		require.NoError(t, w.FinishPayloadWriter())
+		buf, err := w.GetPayloadBufferFromWriter()
+		require.NoError(t, err)
+		r, err := NewPayloadReader(schemapb.DataType_Int64, buf)
+		require.NoError(t, err)
+		defer r.Close()
+		_, err = r.GetStringDictionaryFromPayload()
+		assert.Error(t, err)
+	})
+}
+
+func TestInsertCodec_DeserializeDictionary(t *testing.T) {
+	meta := &etcdpb.CollectionMeta{
+		ID: 1,
+		Schema: &schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{
+				{FieldID: common.RowIDField, Name: "row_id", DataType: schemapb.DataType_Int64},
+				{FieldID: common.TimeStampField, Name: "Ts", DataType: schemapb.DataType_Int64},
+				{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+				{FieldID: 101, Name: "category", DataType: schemapb.DataType_VarChar},
+			},
+		},
+	}
+	genBlobs := func(categories []string, rows int) []*Blob {
+		data := &InsertData{Data: map[FieldID]FieldData{
+			common.RowIDField:     &Int64FieldData{NumRows: []int64{int64(rows)}},
+			common.TimeStampField: &Int64FieldData{NumRows: []int64{int64(rows)}},
+			100:                   &Int64FieldData{NumRows: []int64{int64(rows)}},
+			101:                   &StringFieldData{NumRows: []int64{int64(rows)}},
+		}}
+		for i := 0; i < rows; i++ {
+			for _, fieldID := range []FieldID{common.RowIDField, common.TimeStampField, 100} {
+				fieldData := data.Data[fieldID].(*Int64FieldData)
+				fieldData.Data = append(fieldData.Data, int64(i+1))
+			}
+			fieldData := data.Data[101].(*StringFieldData)
+			fieldData.Data = append(fieldData.Data, categories[i%len(categories)])
+		}
+		blobs, _, err := NewInsertCodec(meta).Serialize(2, 3, data)
+		require.NoError(t, err)
+		return blobs
+	}
+	first := genBlobs([]string{"b", "a"}, 10)
+	second := genBlobs([]string{"c", "b"}, 20)
+	codec := NewInsertCodec(meta)
+
+	encoded, err := codec.DeserializeDictionary([]*Blob{first[3], second[3]})
+	require.NoError(t, err)
+	require.NotNil(t, encoded)
+	assert.Equal(t, []string{"a", "b", "c"}, encoded.Dictionary)
+	assert.Equal(t, 30, encoded.RowNum())
+	_, _, expected, err := codec.Deserialize(first)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Data[101].(*StringFieldData).Data, encoded.Decode()[:10])
+
+	// the payload of the last binlog falls back to plain
+	plain := make([]string, 10000)
+	for i := range plain {
+		plain[i] = fmt.Sprintf("value-%d", i) + strings.Repeat("v", 200)
+	}
+	encoded, err = codec.DeserializeDictionary([]*Blob{first[3], genBlobs(plain, len(plain))[3]})
+	assert.NoError(t, err)
+	assert.Nil(t, encoded)
+
+	encoded, err = codec.DeserializeDictionary([]*Blob{first[2]})
+	assert.NoError(t, err)
+	assert.Nil(t, encoded)
+
+	_, err = codec.DeserializeDictionary([]*Blob{{Key: "invalid", Value: []byte("invalid")}})
+	assert.Error(t, err)
+}
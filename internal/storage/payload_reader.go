@@ -233,18 +233,20 @@ func (r *PayloadReader) GetDoubleFromPayload() ([]float64, error) {
 }
 
 func (r *PayloadReader) GetStringFromPayload() ([]string, error) {
-	if r.colType != schemapb.DataType_String && r.colType != schemapb.DataType_VarChar {
-		return nil, fmt.Errorf("failed to get string from datatype %v", r.colType.String())
-	}
-
-	values := make([]parquet.ByteArray, r.numRows)
-	valuesRead, err := ReadDataFromAllRowGroups[parquet.ByteArray, *file.ByteArrayColumnChunkReader](r.reader, values, 0, r.numRows)
+	values, err := r.readByteArrays()
 	if err != nil {
 		return nil, err
 	}
 
-	if valuesRead != r.numRows {
-		return nil, fmt.Errorf("expect %d rows, but got valuesRead = %d", r.numRows, valuesRead)
+	// the rows of the same dictionary value share the memory
+	if r.dictionaryEncoded() {
+		if dictionary, codes := internByteArrays(values, PayloadDictionaryMaxCardinality()); dictionary != nil {
+			ret := make([]string, r.numRows)
+			for i, code := range codes {
+				ret[i] = dictionary[code]
+			}
+			return ret, nil
+		}
 	}
 
 	ret := make([]string, r.numRows)
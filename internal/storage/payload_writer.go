@@ -0,0 +1,299 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/parquet"
+	"github.com/apache/arrow/go/v8/parquet/pqarrow"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+const (
+	// payloadColumnName is the name of the only column of a payload, the same as the one written by the cgo writer
+	payloadColumnName = "val"
+	// payloadRowGroupSize keeps all rows in one row group, the same as the cgo writer
+	payloadRowGroupSize = 1024 * 1024 * 1024
+	payloadZstdLevel    = 3
+)
+
+// NativePayloadWriter writes scalar data into payload in pure Go, without the cgo calls and the copies
+// of the arrow bridge. Its payloads are the same parquet files as the ones written by PayloadWriter,
+// so that they are read by both PayloadReader and the segcore.
+type NativePayloadWriter struct {
+	colType     schemapb.DataType
+	dataType    arrow.DataType
+	compression PayloadCompression
+	builder     array.Builder
+	rows        int
+	output      *bytes.Buffer
+}
+
+var _ PayloadWriterInterface = (*NativePayloadWriter)(nil)
+
+// NewNativePayloadWriter is constructor of NativePayloadWriter, vector types are not supported.
+func NewNativePayloadWriter(colType schemapb.DataType) (*NativePayloadWriter, error) {
+	var dataType arrow.DataType
+	switch colType {
+	case schemapb.DataType_Bool:
+		dataType = arrow.FixedWidthTypes.Boolean
+	case schemapb.DataType_Int8:
+		dataType = arrow.PrimitiveTypes.Int8
+	case schemapb.DataType_Int16:
+		dataType = arrow.PrimitiveTypes.Int16
+	case schemapb.DataType_Int32:
+		dataType = arrow.PrimitiveTypes.Int32
+	case schemapb.DataType_Int64:
+		dataType = arrow.PrimitiveTypes.Int64
+	case schemapb.DataType_Float:
+		dataType = arrow.PrimitiveTypes.Float32
+	case schemapb.DataType_Double:
+		dataType = arrow.PrimitiveTypes.Float64
+	case schemapb.DataType_String, schemapb.DataType_VarChar:
+		dataType = arrow.BinaryTypes.String
+	default:
+		return nil, fmt.Errorf("native payload writer does not support datatype %v", colType.String())
+	}
+	return &NativePayloadWriter{
+		colType:     colType,
+		dataType:    dataType,
+		compression: PayloadCompressionZstd,
+		builder:     array.NewBuilder(memory.DefaultAllocator, dataType),
+	}, nil
+}
+
+// newPayloadWriter returns NativePayloadWriter for scalar types, and the cgo PayloadWriter for vector types.
+func newPayloadWriter(colType schemapb.DataType, dim ...int) (PayloadWriterInterface, error) {
+	if typeutil.IsVectorType(colType) {
+		w, err := NewPayloadWriter(colType, dim...)
+		if err != nil {
+			return nil, err
+		}
+		return w, nil
+	}
+	w, err := NewNativePayloadWriter(colType)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// SetCompression sets the codec of the payload, the payload is compressed by zstd if it's not set.
+func (w *NativePayloadWriter) SetCompression(compression PayloadCompression) error {
+	if w.output != nil {
+		return errors.New("payload writer has been finished")
+	}
+	if _, err := ParsePayloadCompression(string(compression)); err != nil {
+		return err
+	}
+	w.compression = compression
+	return nil
+}
+
+func (w *NativePayloadWriter) checkAdd(colType schemapb.DataType, length int) error {
+	if w.output != nil {
+		return errors.New("payload writer has been finished")
+	}
+	if length <= 0 {
+		return errors.New("can't add empty msgs into payload")
+	}
+	if w.colType != colType && !(typeutil.IsStringType(w.colType) && typeutil.IsStringType(colType)) {
+		return fmt.Errorf("can't add %v into payload of datatype %v", colType.String(), w.colType.String())
+	}
+	return nil
+}
+
+// AddDataToPayload adds @msgs into payload, vector types are not supported
+func (w *NativePayloadWriter) AddDataToPayload(msgs interface{}, dim ...int) error {
+	if len(dim) != 0 {
+		return errors.New("incorrect input numbers")
+	}
+	switch val := msgs.(type) {
+	case []bool:
+		return w.AddBoolToPayload(val)
+	case []int8:
+		return w.AddInt8ToPayload(val)
+	case []int16:
+		return w.AddInt16ToPayload(val)
+	case []int32:
+		return w.AddInt32ToPayload(val)
+	case []int64:
+		return w.AddInt64ToPayload(val)
+	case []float32:
+		return w.AddFloatToPayload(val)
+	case []float64:
+		return w.AddDoubleToPayload(val)
+	case string:
+		return w.AddOneStringToPayload(val)
+	default:
+		return errors.New("incorrect datatype")
+	}
+}
+
+func (w *NativePayloadWriter) AddBoolToPayload(msgs []bool) error {
+	if err := w.checkAdd(schemapb.DataType_Bool, len(msgs)); err != nil {
+		return err
+	}
+	w.builder.(*array.BooleanBuilder).AppendValues(msgs, nil)
+	return nil
+}
+
+func (w *NativePayloadWriter) AddByteToPayload(msgs []byte) error {
+	if err := w.checkAdd(schemapb.DataType_Int8, len(msgs)); err != nil {
+		return err
+	}
+	builder := w.builder.(*array.Int8Builder)
+	builder.Reserve(len(msgs))
+	for _, msg := range msgs {
+		builder.UnsafeAppend(int8(msg))
+	}
+	return nil
+}
+
+func (w *NativePayloadWriter) AddInt8ToPayload(msgs []int8) error {
+	if err := w.checkAdd(schemapb.DataType_Int8, len(msgs)); err != nil {
+		return err
+	}
+	w.builder.(*array.Int8Builder).AppendValues(msgs, nil)
+	return nil
+}
+
+func (w *NativePayloadWriter) AddInt16ToPayload(msgs []int16) error {
+	if err := w.checkAdd(schemapb.DataType_Int16, len(msgs)); err != nil {
+		return err
+	}
+	w.builder.(*array.Int16Builder).AppendValues(msgs, nil)
+	return nil
+}
+
+func (w *NativePayloadWriter) AddInt32ToPayload(msgs []int32) error {
+	if err := w.checkAdd(schemapb.DataType_Int32, len(msgs)); err != nil {
+		return err
+	}
+	w.builder.(*array.Int32Builder).AppendValues(msgs, nil)
+	return nil
+}
+
+func (w *NativePayloadWriter) AddInt64ToPayload(msgs []int64) error {
+	if err := w.checkAdd(schemapb.DataType_Int64, len(msgs)); err != nil {
+		return err
+	}
+	w.builder.(*array.Int64Builder).AppendValues(msgs, nil)
+	return nil
+}
+
+func (w *NativePayloadWriter) AddFloatToPayload(msgs []float32) error {
+	if err := w.checkAdd(schemapb.DataType_Float, len(msgs)); err != nil {
+		return err
+	}
+	w.builder.(*array.Float32Builder).AppendValues(msgs, nil)
+	return nil
+}
+
+func (w *NativePayloadWriter) AddDoubleToPayload(msgs []float64) error {
+	if err := w.checkAdd(schemapb.DataType_Double, len(msgs)); err != nil {
+		return err
+	}
+	w.builder.(*array.Float64Builder).AppendValues(msgs, nil)
+	return nil
+}
+
+// AddOneStringToPayload adds @msg into payload, empty string is allowed
+func (w *NativePayloadWriter) AddOneStringToPayload(msg string) error {
+	if err := w.checkAdd(schemapb.DataType_VarChar, 1); err != nil {
+		return err
+	}
+	w.builder.(*array.StringBuilder).Append(msg)
+	return nil
+}
+
+func (w *NativePayloadWriter) AddBinaryVectorToPayload(binVec []byte, dim int) error {
+	return errors.New("native payload writer does not support binary vector")
+}
+
+func (w *NativePayloadWriter) AddFloatVectorToPayload(floatVec []float32, dim int) error {
+	return errors.New("native payload writer does not support float vector")
+}
+
+// FinishPayloadWriter encodes the added data as a parquet file with the same schema and properties as the cgo writer,
+// except the codec, the low cardinality strings are always dictionary-encoded and the sorted int64s are delta-encoded.
+func (w *NativePayloadWriter) FinishPayloadWriter() error {
+	if w.output != nil {
+		return errors.New("payload writer has been finished")
+	}
+	// the builder is reset by NewArray
+	w.rows = w.builder.Len()
+	arr := w.builder.NewArray()
+	defer arr.Release()
+	schema := arrow.NewSchema([]arrow.Field{{Name: payloadColumnName, Type: w.dataType, Nullable: true}}, nil)
+	record := array.NewRecord(schema, []arrow.Array{arr}, int64(arr.Len()))
+	defer record.Release()
+	table := array.NewTableFromRecords(schema, []arrow.Record{record})
+	defer table.Release()
+
+	output := &bytes.Buffer{}
+	opts := []parquet.WriterProperty{parquet.WithCompression(w.compression.parquetCodec())}
+	if w.compression == PayloadCompressionZstd {
+		opts = append(opts, parquet.WithCompressionLevel(payloadZstdLevel))
+	}
+	if stringArr, ok := arr.(*array.String); ok {
+		opts = append(opts, stringDictionaryProperties(stringArr, PayloadDictionaryMaxCardinality())...)
+	}
+	if int64Arr, ok := arr.(*array.Int64); ok {
+		opts = append(opts, int64DeltaProperties(int64Arr)...)
+	}
+	props := parquet.NewWriterProperties(opts...)
+	if err := pqarrow.WriteTable(table, output, payloadRowGroupSize, props, pqarrow.DefaultWriterProps()); err != nil {
+		return err
+	}
+	payload, err := compressPayload(w.compression, output.Bytes())
+	if err != nil {
+		return err
+	}
+	w.output = bytes.NewBuffer(payload)
+	return nil
+}
+
+func (w *NativePayloadWriter) GetPayloadBufferFromWriter() ([]byte, error) {
+	if w.output == nil || w.output.Len() == 0 {
+		return nil, errors.New("empty buffer")
+	}
+	return w.output.Bytes(), nil
+}
+
+func (w *NativePayloadWriter) GetPayloadLengthFromWriter() (int, error) {
+	if w.output != nil {
+		return w.rows, nil
+	}
+	return w.builder.Len(), nil
+}
+
+func (w *NativePayloadWriter) ReleasePayloadWriter() {
+	w.builder.Release()
+}
+
+func (w *NativePayloadWriter) Close() {
+	w.ReleasePayloadWriter()
+}
@@ -0,0 +1,118 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+)
+
+func TestNativePayloadWriter_Compatibility(t *testing.T) {
+	cases := []struct {
+		colType schemapb.DataType
+		data    interface{}
+	}{
+		{colType: schemapb.DataType_Bool, data: []bool{true, false, true}},
+		{colType: schemapb.DataType_Int8, data: []int8{1, -2, 3}},
+		{colType: schemapb.DataType_Int16, data: []int16{1, -2, 3}},
+		{colType: schemapb.DataType_Int32, data: []int32{1, -2, 3}},
+		{colType: schemapb.DataType_Int64, data: []int64{1, -2, 3}},
+		{colType: schemapb.DataType_Float, data: []float32{1.5, -2.5, 3}},
+		{colType: schemapb.DataType_Double, data: []float64{1.5, -2.5, 3}},
+		{colType: schemapb.DataType_VarChar, data: []string{"a", "", "ccc"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.colType.String(), func(t *testing.T) {
+			native, err := NewNativePayloadWriter(c.colType)
+			require.NoError(t, err)
+			defer native.Close()
+			cgo, err := NewPayloadWriter(c.colType)
+			require.NoError(t, err)
+			defer cgo.Close()
+
+			for _, w := range []PayloadWriterInterface{native, cgo} {
+				if strs, ok := c.data.([]string); ok {
+					for _, str := range strs {
+						require.NoError(t, w.AddOneStringToPayload(str))
+					}
+				} else {
+					require.NoError(t, w.AddDataToPayload(c.data))
+				}
+				require.NoError(t, w.FinishPayloadWriter())
+				length, err := w.GetPayloadLengthFromWriter()
+				assert.NoError(t, err)
+				assert.Equal(t, 3, length)
+			}
+
+			nativeBuf, err := native.GetPayloadBufferFromWriter()
+			require.NoError(t, err)
+			cgoBuf, err := cgo.GetPayloadBufferFromWriter()
+			require.NoError(t, err)
+
+			// the payloads written by both writers are read by both readers
+			for _, buf := range [][]byte{nativeBuf, cgoBuf} {
+				r, err := NewPayloadReader(c.colType, buf)
+				require.NoError(t, err)
+				data, _, err := r.GetDataFromPayload()
+				assert.NoError(t, err)
+				assert.Equal(t, c.data, data)
+				r.Close()
+
+				rc, err := NewPayloadReaderCgo(c.colType, buf)
+				require.NoError(t, err)
+				data, _, err = rc.GetDataFromPayload()
+				assert.NoError(t, err)
+				assert.Equal(t, c.data, data)
+				rc.Close()
+			}
+		})
+	}
+}
+
+func TestNativePayloadWriter_Error(t *testing.T) {
+	_, err := NewNativePayloadWriter(schemapb.DataType_FloatVector)
+	assert.Error(t, err)
+
+	w, err := NewNativePayloadWriter(schemapb.DataType_Int64)
+	require.NoError(t, err)
+	defer w.Close()
+	assert.Error(t, w.AddInt64ToPayload(nil))
+	assert.Error(t, w.AddInt32ToPayload([]int32{1}))
+	assert.Error(t, w.AddDataToPayload([]int64{1}, 8))
+	assert.Error(t, w.AddFloatVectorToPayload([]float32{1}, 1))
+	_, err = w.GetPayloadBufferFromWriter()
+	assert.Error(t, err)
+
+	assert.NoError(t, w.AddInt64ToPayload([]int64{1}))
+	assert.NoError(t, w.FinishPayloadWriter())
+	assert.Error(t, w.FinishPayloadWriter())
+	assert.Error(t, w.AddInt64ToPayload([]int64{2}))
+
+	vw, err := newPayloadWriter(schemapb.DataType_FloatVector, 8)
+	require.NoError(t, err)
+	defer vw.Close()
+	assert.IsType(t, &PayloadWriter{}, vw)
+	sw, err := newPayloadWriter(schemapb.DataType_Int64)
+	require.NoError(t, err)
+	defer sw.Close()
+	assert.IsType(t, &NativePayloadWriter{}, sw)
+}
@@ -80,13 +80,21 @@ func (st *PkStatistics) UpdatePKRange(ids FieldData) error {
 	return nil
 }
 
+// PkInRange returns whether @pk is between the min and max pk, which needs no bloom filter.
+func (st *PkStatistics) PkInRange(pk PrimaryKey) bool {
+	if st.MinPK == nil || st.MaxPK == nil {
+		return false
+	}
+	return !st.MinPK.GT(pk) && !st.MaxPK.LT(pk)
+}
+
 func (st *PkStatistics) PkExist(pk PrimaryKey) bool {
 	// empty pkStatics
-	if st.MinPK == nil || st.MaxPK == nil || st.PkFilter == nil {
+	if st.PkFilter == nil {
 		return false
 	}
 	// check pk range first, ugly but key it for now
-	if st.MinPK.GT(pk) || st.MaxPK.LT(pk) {
+	if !st.PkInRange(pk) {
 		return false
 	}
 
@@ -0,0 +1,182 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// readaheadTTL is how long a prefetched object is kept if it's never read.
+const readaheadTTL = time.Minute
+
+// Prefetcher is implemented by the chunk managers which could read ahead the objects about to be read.
+type Prefetcher interface {
+	// Prefetch hints that @filePaths are going to be read in order, the chunk manager may download them in the
+	// background, so that the next objects are ready while the current one is decoded. It's only a hint,
+	// the downloads are canceled with @ctx, and the objects are read as usual if they're not prefetched.
+	Prefetch(ctx context.Context, filePaths []string)
+}
+
+// Prefetch hints @cm that @filePaths are going to be read in order if it's a Prefetcher, otherwise it does nothing.
+func Prefetch(ctx context.Context, cm ChunkManager, filePaths []string) {
+	if prefetcher, ok := cm.(Prefetcher); ok {
+		prefetcher.Prefetch(ctx, filePaths)
+	}
+}
+
+type readaheadItem struct {
+	ctx      context.Context
+	filePath string
+}
+
+type readaheadEntry struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	// content, err and doneTime are set before done is closed
+	content  []byte
+	err      error
+	doneTime time.Time
+}
+
+// readahead is the readahead pipeline of the chunk managers reading remote objects. The hinted paths are queued,
+// and at most window of them are downloaded ahead of the reads, once an object is read the next one is started.
+// A prefetched object is read only once, and it's dropped if it's modified or not read in readaheadTTL.
+// A nil readahead prefetches nothing.
+type readahead struct {
+	read   func(ctx context.Context, filePath string) ([]byte, error)
+	window int
+
+	mu      sync.Mutex
+	queue   []readaheadItem
+	entries map[string]*readaheadEntry
+}
+
+// newReadahead returns a readahead downloading at most @window objects ahead with @read, it returns nil if @window is 0.
+func newReadahead(window int, read func(ctx context.Context, filePath string) ([]byte, error)) *readahead {
+	if window <= 0 {
+		return nil
+	}
+	return &readahead{
+		read:    read,
+		window:  window,
+		entries: make(map[string]*readaheadEntry),
+	}
+}
+
+// prefetch queues @filePaths, the ones prefetched or queued are skipped.
+func (r *readahead) prefetch(ctx context.Context, filePaths []string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queued := make(map[string]struct{}, len(r.queue))
+	for _, item := range r.queue {
+		queued[item.filePath] = struct{}{}
+	}
+	for _, filePath := range filePaths {
+		if _, ok := r.entries[filePath]; ok {
+			continue
+		}
+		if _, ok := queued[filePath]; ok {
+			continue
+		}
+		queued[filePath] = struct{}{}
+		r.queue = append(r.queue, readaheadItem{ctx: ctx, filePath: filePath})
+	}
+	r.fill()
+}
+
+// fill drops the expired objects and starts the queued downloads until there are window objects ahead,
+// the caller must hold the lock.
+func (r *readahead) fill() {
+	for filePath, entry := range r.entries {
+		select {
+		case <-entry.done:
+			if time.Since(entry.doneTime) > readaheadTTL {
+				delete(r.entries, filePath)
+			}
+		default:
+		}
+	}
+	for len(r.entries) < r.window && len(r.queue) > 0 {
+		item := r.queue[0]
+		r.queue = r.queue[1:]
+		if item.ctx.Err() != nil {
+			continue
+		}
+		if _, ok := r.entries[item.filePath]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(item.ctx)
+		entry := &readaheadEntry{cancel: cancel, done: make(chan struct{})}
+		r.entries[item.filePath] = entry
+		go func(filePath string) {
+			defer cancel()
+			entry.content, entry.err = r.read(ctx, filePath)
+			entry.doneTime = time.Now()
+			close(entry.done)
+		}(item.filePath)
+	}
+}
+
+// take returns the prefetched content of @filePath, it waits if the object is being downloaded.
+// It returns false if the object isn't prefetched or failed to be downloaded, then it should be read as usual.
+func (r *readahead) take(ctx context.Context, filePath string) ([]byte, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.Lock()
+	entry, ok := r.entries[filePath]
+	if ok {
+		delete(r.entries, filePath)
+		r.fill()
+	}
+	r.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	select {
+	case <-entry.done:
+	case <-ctx.Done():
+		entry.cancel()
+		return nil, false
+	}
+	if entry.err != nil {
+		return nil, false
+	}
+	return entry.content, true
+}
+
+// invalidate drops the prefetched objects with @prefix, it's called once they're modified.
+func (r *readahead) invalidate(prefix string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for filePath, entry := range r.entries {
+		if strings.HasPrefix(filePath, prefix) {
+			entry.cancel()
+			delete(r.entries, filePath)
+		}
+	}
+	r.fill()
+}
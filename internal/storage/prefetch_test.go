@@ -0,0 +1,111 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingReader counts the reads of every path, the reads of the paths in blocked wait until unblocked.
+type countingReader struct {
+	mu      sync.Mutex
+	reads   map[string]int
+	blocked chan struct{}
+}
+
+func (r *countingReader) read(ctx context.Context, filePath string) ([]byte, error) {
+	r.mu.Lock()
+	r.reads[filePath]++
+	r.mu.Unlock()
+	if r.blocked != nil {
+		select {
+		case <-r.blocked:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return []byte(filePath), nil
+}
+
+func (r *countingReader) count(filePath string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reads[filePath]
+}
+
+func TestReadahead(t *testing.T) {
+	ctx := context.Background()
+	reader := &countingReader{reads: make(map[string]int), blocked: make(chan struct{})}
+	r := newReadahead(2, reader.read)
+
+	r.prefetch(ctx, []string{"a", "b", "c", "a"})
+	// only the window is downloaded ahead
+	assert.Eventually(t, func() bool { return reader.count("a") == 1 && reader.count("b") == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, 0, reader.count("c"))
+
+	close(reader.blocked)
+	content, ok := r.take(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), content)
+	// the next one is started once one is read
+	assert.Eventually(t, func() bool { return reader.count("c") == 1 }, time.Second, time.Millisecond)
+	// a prefetched object is read only once
+	_, ok = r.take(ctx, "a")
+	assert.False(t, ok)
+
+	// the modified objects are dropped
+	r.invalidate("b")
+	_, ok = r.take(ctx, "b")
+	assert.False(t, ok)
+	content, ok = r.take(ctx, "c")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("c"), content)
+
+	// the hints are canceled with their context
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	r.prefetch(ctx, []string{"d"})
+	_, ok = r.take(context.Background(), "d")
+	assert.False(t, ok)
+	assert.Equal(t, 0, reader.count("d"))
+
+	// a nil readahead prefetches nothing
+	r = newReadahead(0, reader.read)
+	assert.Nil(t, r)
+	r.prefetch(context.Background(), []string{"e"})
+	_, ok = r.take(context.Background(), "e")
+	assert.False(t, ok)
+	r.invalidate("e")
+}
+
+func TestPrefetch(t *testing.T) {
+	// the chunk managers not prefetching ignore the hints
+	Prefetch(context.Background(), NewMemoryChunkManager(), []string{"a"})
+
+	reader := &countingReader{reads: make(map[string]int)}
+	mcm := &MinioChunkManager{readahead: newReadahead(1, reader.read)}
+	Prefetch(context.Background(), NewReadOnlyChunkManager(mcm), []string{"a"})
+	assert.Eventually(t, func() bool { return reader.count("a") == 1 }, time.Second, time.Millisecond)
+	content, err := mcm.Read(context.Background(), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a"), content)
+}
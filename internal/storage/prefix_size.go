@@ -0,0 +1,111 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type prefixSize struct {
+	bytes    int64
+	objects  int64
+	expireAt time.Time
+}
+
+// prefixSizeCache caches the results of PrefixSize for ttl, the results are not invalidated by writes,
+// so they could be stale for at most ttl. A zero ttl disables the cache.
+type prefixSizeCache struct {
+	ttl time.Duration
+
+	mu    sync.Mutex
+	sizes map[string]prefixSize
+}
+
+func newPrefixSizeCache(ttl time.Duration) *prefixSizeCache {
+	return &prefixSizeCache{
+		ttl:   ttl,
+		sizes: make(map[string]prefixSize),
+	}
+}
+
+// get returns the cached size of @prefix if it's not expired, or computes it by @compute and caches it.
+func (c *prefixSizeCache) get(prefix string, compute func() (int64, int64, error)) (int64, int64, error) {
+	if c == nil || c.ttl <= 0 {
+		return compute()
+	}
+	c.mu.Lock()
+	size, ok := c.sizes[prefix]
+	c.mu.Unlock()
+	if ok && time.Now().Before(size.expireAt) {
+		return size.bytes, size.objects, nil
+	}
+
+	bytes, objects, err := compute()
+	if err != nil {
+		return 0, 0, err
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for p, size := range c.sizes {
+		if !now.Before(size.expireAt) {
+			delete(c.sizes, p)
+		}
+	}
+	c.sizes[prefix] = prefixSize{bytes: bytes, objects: objects, expireAt: now.Add(c.ttl)}
+	return bytes, objects, nil
+}
+
+// parallelPrefixSize sums the sizes of the objects with @prefix visited by @walk. The first level is listed
+// non-recursively, then the common prefixes found are walked recursively with at most @concurrency goroutines.
+func parallelPrefixSize(ctx context.Context, prefix string, concurrency int,
+	walk func(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error) (int64, int64, error) {
+	var bytes, objects int64
+	var subPrefixes []string
+	err := walk(ctx, prefix, false, func(chunkObjectInfo ChunkObjectInfo) bool {
+		if IsCommonPrefix(chunkObjectInfo.FilePath) {
+			subPrefixes = append(subPrefixes, chunkObjectInfo.FilePath)
+			return true
+		}
+		bytes += chunkObjectInfo.Size
+		objects++
+		return true
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	sizes, err := parallelMultiDo(ctx, subPrefixes, concurrency, func(ctx context.Context, subPrefix string) (prefixSize, error) {
+		var size prefixSize
+		err := walk(ctx, subPrefix, true, func(chunkObjectInfo ChunkObjectInfo) bool {
+			size.bytes += chunkObjectInfo.Size
+			size.objects++
+			return true
+		})
+		return size, err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, size := range sizes {
+		bytes += size.bytes
+		objects += size.objects
+	}
+	return bytes, objects, nil
+}
@@ -0,0 +1,109 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixSize(t *testing.T) {
+	ctx := context.Background()
+	testRoot := "test_prefix_size"
+	testCM := NewLocalChunkManager(RootPath(localPath), PrefixSizeCacheTTL(time.Hour))
+	defer testCM.RemoveWithPrefix(ctx, testRoot)
+
+	contents := map[string]string{
+		"insert_log/1/2/3/100/1":  "a",
+		"insert_log/1/2/3/101/2":  "bb",
+		"insert_log/1/2/4/101/3":  "ccc",
+		"insert_log/10/2/4/101/4": "dddd",
+		"delta_log/1/2/3/5":       "eeeee",
+	}
+	for key, value := range contents {
+		err := testCM.Write(ctx, path.Join(testRoot, key), []byte(value))
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		prefix  string
+		bytes   int64
+		objects int64
+	}{
+		{"insert_log/1/", 6, 3},
+		{"insert_log/1/2/4/", 3, 1},
+		{"insert_log/", 10, 4},
+		{"", 15, 5},
+		{"stats_log/", 0, 0},
+	}
+	for _, test := range tests {
+		bytes, objects, err := testCM.PrefixSize(ctx, path.Join(testRoot, test.prefix)+"/")
+		assert.NoError(t, err)
+		assert.Equal(t, test.bytes, bytes, test.prefix)
+		assert.Equal(t, test.objects, objects, test.prefix)
+	}
+
+	// the cached results are returned until expired
+	err := testCM.Write(ctx, path.Join(testRoot, "insert_log/1/2/5/101/6"), []byte("ffffff"))
+	require.NoError(t, err)
+	bytes, objects, err := testCM.PrefixSize(ctx, path.Join(testRoot, "insert_log/1")+"/")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), bytes)
+	assert.Equal(t, int64(3), objects)
+}
+
+func TestPrefixSizeCache(t *testing.T) {
+	calls := 0
+	compute := func() (int64, int64, error) {
+		calls++
+		return 10, 1, nil
+	}
+
+	var disabled *prefixSizeCache
+	disabled.get("a", compute)
+	disabled.get("a", compute)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	cache := newPrefixSizeCache(time.Hour)
+	for i := 0; i < 3; i++ {
+		bytes, objects, err := cache.get("a", compute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), bytes)
+		assert.Equal(t, int64(1), objects)
+	}
+	assert.Equal(t, 1, calls)
+
+	// the errors are not cached
+	_, _, err := cache.get("b", func() (int64, int64, error) { return 0, 0, errors.New("mock") })
+	assert.Error(t, err)
+	_, _, err = cache.get("b", compute)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	cache = newPrefixSizeCache(time.Millisecond)
+	cache.get("a", compute)
+	time.Sleep(2 * time.Millisecond)
+	cache.get("a", compute)
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,114 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrReadOnly means a mutation is issued to a read-only ChunkManager.
+var ErrReadOnly = errors.New("ReadOnly")
+
+func WrapErrReadOnly(filePath string) error {
+	return fmt.Errorf("%w(key=%s)", ErrReadOnly, filePath)
+}
+
+// ReadOnlyChunkManager rejects all the mutations to the wrapped ChunkManager with ErrReadOnly,
+// so that an analytics replica or a debugging cluster sharing the bucket never mutates the data of the primary.
+type ReadOnlyChunkManager struct {
+	ChunkManager
+}
+
+var _ ChunkManager = (*ReadOnlyChunkManager)(nil)
+var _ Prefetcher = (*ReadOnlyChunkManager)(nil)
+var _ ConditionalReader = (*ReadOnlyChunkManager)(nil)
+
+// NewReadOnlyChunkManager returns a read-only view of @cm.
+func NewReadOnlyChunkManager(cm ChunkManager) *ReadOnlyChunkManager {
+	if ro, ok := cm.(*ReadOnlyChunkManager); ok {
+		return ro
+	}
+	return &ReadOnlyChunkManager{ChunkManager: cm}
+}
+
+func (ro *ReadOnlyChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	return WrapErrReadOnly(filePath)
+}
+
+func (ro *ReadOnlyChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	return WrapErrReadOnly(filePath)
+}
+
+func (ro *ReadOnlyChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	return WrapErrReadOnly(filePath)
+}
+
+func (ro *ReadOnlyChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	for filePath := range contents {
+		return WrapErrReadOnly(filePath)
+	}
+	return nil
+}
+
+func (ro *ReadOnlyChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	return WrapErrReadOnly(filePath)
+}
+
+func (ro *ReadOnlyChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	return WrapErrReadOnly(dstFilePath)
+}
+
+func (ro *ReadOnlyChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	return WrapErrReadOnly(srcFilePath)
+}
+
+// PresignURL only presigns the URLs reading @filePath.
+func (ro *ReadOnlyChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	if method != http.MethodGet && method != http.MethodHead {
+		return "", WrapErrReadOnly(filePath)
+	}
+	return ro.ChunkManager.PresignURL(ctx, filePath, method, expiry)
+}
+
+func (ro *ReadOnlyChunkManager) Remove(ctx context.Context, filePath string) error {
+	return WrapErrReadOnly(filePath)
+}
+
+func (ro *ReadOnlyChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	if len(filePaths) == 0 {
+		return nil
+	}
+	return WrapErrReadOnly(filePaths[0])
+}
+
+func (ro *ReadOnlyChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	return WrapErrReadOnly(prefix)
+}
+
+// Prefetch passes the hint to the wrapped chunk manager.
+func (ro *ReadOnlyChunkManager) Prefetch(ctx context.Context, filePaths []string) {
+	Prefetch(ctx, ro.ChunkManager, filePaths)
+}
+
+// ReadIfModified passes the conditional read to the wrapped chunk manager, see ConditionalReader.
+func (ro *ReadOnlyChunkManager) ReadIfModified(ctx context.Context, filePath string, etag string) ([]byte, string, error) {
+	return ReadIfModified(ctx, ro.ChunkManager, filePath, etag)
+}
@@ -0,0 +1,75 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyChunkManager(t *testing.T) {
+	ctx := context.Background()
+	cm := NewLocalChunkManager(RootPath(t.TempDir()))
+	require.NoError(t, cm.Write(ctx, "a/b", []byte("content")))
+
+	ro := NewReadOnlyChunkManager(cm)
+	assert.Same(t, ro, NewReadOnlyChunkManager(ro))
+
+	content, err := ro.Read(ctx, "a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("content"), content)
+	exist, err := ro.Exist(ctx, "a/b")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	paths, _, err := ro.ListWithPrefix(ctx, "a/", true)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 1)
+
+	assert.ErrorIs(t, ro.Write(ctx, "a/c", []byte("c")), ErrReadOnly)
+	assert.ErrorIs(t, ro.WriteWithOptions(ctx, "a/c", []byte("c")), ErrReadOnly)
+	assert.ErrorIs(t, ro.WriteIfNotExist(ctx, "a/c", []byte("c")), ErrReadOnly)
+	assert.ErrorIs(t, ro.MultiWrite(ctx, map[string][]byte{"a/c": []byte("c")}), ErrReadOnly)
+	assert.ErrorIs(t, ro.Append(ctx, "a/b", []byte("c")), ErrReadOnly)
+	assert.ErrorIs(t, ro.Copy(ctx, "a/b", "a/c"), ErrReadOnly)
+	assert.ErrorIs(t, ro.Move(ctx, "a/b", "a/c"), ErrReadOnly)
+	assert.ErrorIs(t, ro.Remove(ctx, "a/b"), ErrReadOnly)
+	assert.ErrorIs(t, ro.MultiRemove(ctx, []string{"a/b"}), ErrReadOnly)
+	assert.ErrorIs(t, ro.RemoveWithPrefix(ctx, "a/"), ErrReadOnly)
+	_, err = ro.PresignURL(ctx, "a/b", http.MethodPut, time.Minute)
+	assert.ErrorIs(t, err, ErrReadOnly)
+
+	content, err = cm.Read(ctx, "a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("content"), content)
+	exist, err = cm.Exist(ctx, "a/c")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func TestChunkManagerFactoryReadOnly(t *testing.T) {
+	ctx := context.Background()
+	f := NewChunkManagerFactory("local", RootPath(t.TempDir()), ReadOnly(true))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	assert.NoError(t, err)
+	assert.IsType(t, &ReadOnlyChunkManager{}, cm)
+	assert.ErrorIs(t, cm.Write(ctx, "a", []byte("a")), ErrReadOnly)
+}
@@ -0,0 +1,493 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/mmap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/proto/storagepb"
+)
+
+const (
+	gatewayDialTimeout = 10 * time.Second
+	// gatewayDefaultMaxMessageSize is the max message size of the gateway if it's not configured
+	gatewayDefaultMaxMessageSize = 512 * 1024 * 1024
+)
+
+// gatewayErrors are the errors recovered by RemoteChunkManager from the status of the gateway,
+// by the prefix of the reason, so that the callers could check them by errors.Is like the other chunk managers.
+var gatewayErrors = []error{
+	io.EOF,
+	io.ErrUnexpectedEOF,
+	ErrNoSuchKey,
+	ErrAppendConflict,
+	ErrObjectExists,
+	ErrObjectLocked,
+	ErrReadOnly,
+	ErrDiskQuotaExceeded,
+	ErrMemoryCapacityExceeded,
+	ErrTenantQuotaExceeded,
+	ErrInvalidSubPath,
+	ErrInjectedFault,
+}
+
+// gatewayError is an error returned by the gateway, which wraps the error of the same kind.
+type gatewayError struct {
+	kind   error
+	reason string
+}
+
+func (e *gatewayError) Error() string {
+	return e.reason
+}
+
+func (e *gatewayError) Unwrap() error {
+	return e.kind
+}
+
+// gatewayStatus returns the status of @err returned by the gateway, the reason starts with the message of the
+// error of gatewayErrors wrapped by @err, if any.
+func gatewayStatus(err error) *commonpb.Status {
+	if err == nil {
+		return &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}
+	}
+	reason := err.Error()
+	for _, kind := range gatewayErrors {
+		if errors.Is(err, kind) {
+			if !strings.HasPrefix(reason, kind.Error()) {
+				reason = kind.Error() + ": " + reason
+			}
+			break
+		}
+	}
+	return &commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: reason}
+}
+
+// gatewayStatusError returns the error of the status returned by the gateway, see gatewayStatus.
+func gatewayStatusError(status *commonpb.Status) error {
+	if status == nil {
+		return errors.New("storage gateway returns no status")
+	}
+	if status.GetErrorCode() == commonpb.ErrorCode_Success {
+		return nil
+	}
+	for _, kind := range gatewayErrors {
+		if status.GetReason() == kind.Error() {
+			// io.EOF is compared by the callers of ReadAt
+			return kind
+		}
+		if strings.HasPrefix(status.GetReason(), kind.Error()) {
+			return &gatewayError{kind: kind, reason: status.GetReason()}
+		}
+	}
+	return errors.New(status.GetReason())
+}
+
+// gatewayTime converts @t to unix nanoseconds, zero time is converted to zero.
+func gatewayTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromGatewayTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// RemoteChunkManager accesses the storage of a storage gateway by gRPC, for the nodes which can't access the
+// object storage directly. The paths are the keys of the storage of the gateway, and RootPath is its root path.
+type RemoteChunkManager struct {
+	conn     *grpc.ClientConn
+	client   storagepb.ChunkManagerClient
+	rootPath string
+}
+
+var _ ChunkManager = (*RemoteChunkManager)(nil)
+
+// NewRemoteChunkManager connects the storage gateway configured by Gateway.
+func NewRemoteChunkManager(ctx context.Context, opts ...Option) (*RemoteChunkManager, error) {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return newRemoteChunkManagerWithConfig(ctx, c)
+}
+
+func newRemoteChunkManagerWithConfig(ctx context.Context, c *config) (*RemoteChunkManager, error) {
+	if c.gatewayAddress == "" {
+		return nil, errors.New("storage gateway address is not configured")
+	}
+	maxMessageSize := c.gatewayMaxMessageSize
+	if maxMessageSize <= 0 {
+		maxMessageSize = gatewayDefaultMaxMessageSize
+	}
+	tlsConfig, err := GatewayTLSConfig(c.gatewayTLSMode, c.gatewayCertPath, c.gatewayKeyPath, c.gatewayCAPath, false)
+	if err != nil {
+		return nil, err
+	}
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if tlsConfig != nil {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	}
+	if c.gatewayToken != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(gatewayToken{token: c.gatewayToken, secure: tlsConfig != nil}))
+	}
+	dialCtx, cancel := context.WithTimeout(ctx, gatewayDialTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(dialCtx, c.gatewayAddress, append(dialOpts,
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxMessageSize),
+			grpc.MaxCallSendMsgSize(maxMessageSize)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                10 * time.Second,
+			Timeout:             20 * time.Second,
+			PermitWithoutStream: true,
+		}))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect storage gateway %s: %w", c.gatewayAddress, err)
+	}
+	client := storagepb.NewChunkManagerClient(conn)
+	resp, err := client.GetRootPath(ctx, &storagepb.GetRootPathRequest{})
+	if err == nil {
+		err = gatewayStatusError(resp.GetStatus())
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to get root path of storage gateway %s: %w", c.gatewayAddress, err)
+	}
+	return &RemoteChunkManager{
+		conn:     conn,
+		client:   client,
+		rootPath: resp.GetRootPath(),
+	}, nil
+}
+
+// Close closes the connection to the gateway.
+func (rcm *RemoteChunkManager) Close() error {
+	return rcm.conn.Close()
+}
+
+// RootPath returns the root path of the storage of the gateway.
+func (rcm *RemoteChunkManager) RootPath() string {
+	return rcm.rootPath
+}
+
+func (rcm *RemoteChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	resp, err := rcm.client.Path(ctx, &storagepb.PathRequest{Path: filePath})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetPath(), gatewayStatusError(resp.GetStatus())
+}
+
+func (rcm *RemoteChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	resp, err := rcm.client.Size(ctx, &storagepb.PathRequest{Path: filePath})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetSize(), gatewayStatusError(resp.GetStatus())
+}
+
+func (rcm *RemoteChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	infos, err := rcm.MultiStat(ctx, []string{filePath})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if infos[0] == nil {
+		return ObjectInfo{}, WrapErrNoSuchKey(filePath)
+	}
+	return *infos[0], nil
+}
+
+func (rcm *RemoteChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	resp, err := rcm.client.MultiStat(ctx, &storagepb.MultiPathRequest{Paths: filePaths})
+	if err != nil {
+		return nil, err
+	}
+	if err := gatewayStatusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	if len(resp.GetInfos()) != len(filePaths) || len(resp.GetExists()) != len(filePaths) {
+		return nil, fmt.Errorf("storage gateway returns %d infos of %d paths", len(resp.GetInfos()), len(filePaths))
+	}
+	infos := make([]*ObjectInfo, len(filePaths))
+	for i, info := range resp.GetInfos() {
+		if !resp.GetExists()[i] {
+			continue
+		}
+		infos[i] = &ObjectInfo{
+			FilePath:   info.GetPath(),
+			Size:       info.GetSize(),
+			ModifyTime: fromGatewayTime(info.GetModifyTime()),
+			ETag:       info.GetEtag(),
+		}
+	}
+	return infos, nil
+}
+
+func (rcm *RemoteChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	return rcm.write(ctx, &storagepb.WriteRequest{Path: filePath, Content: content})
+}
+
+func (rcm *RemoteChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	c := newWriteConfig(opts...)
+	return rcm.write(ctx, &storagepb.WriteRequest{
+		Path:         filePath,
+		Content:      content,
+		UserMetadata: c.userMetadata,
+		Tags:         c.tags,
+		StorageClass: c.storageClass,
+	})
+}
+
+func (rcm *RemoteChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	return rcm.write(ctx, &storagepb.WriteRequest{Path: filePath, Content: content, IfNotExist: true})
+}
+
+func (rcm *RemoteChunkManager) write(ctx context.Context, req *storagepb.WriteRequest) error {
+	status, err := rcm.client.Write(ctx, req)
+	if err != nil {
+		return err
+	}
+	return gatewayStatusError(status)
+}
+
+func (rcm *RemoteChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	status, err := rcm.client.MultiWrite(ctx, &storagepb.MultiWriteRequest{Contents: contents})
+	if err != nil {
+		return err
+	}
+	return gatewayStatusError(status)
+}
+
+func (rcm *RemoteChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	status, err := rcm.client.Append(ctx, &storagepb.WriteRequest{Path: filePath, Content: content})
+	if err != nil {
+		return err
+	}
+	return gatewayStatusError(status)
+}
+
+func (rcm *RemoteChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	status, err := rcm.client.Copy(ctx, &storagepb.CopyRequest{SrcPath: srcFilePath, DstPath: dstFilePath})
+	if err != nil {
+		return err
+	}
+	return gatewayStatusError(status)
+}
+
+func (rcm *RemoteChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	status, err := rcm.client.Move(ctx, &storagepb.CopyRequest{SrcPath: srcFilePath, DstPath: dstFilePath})
+	if err != nil {
+		return err
+	}
+	return gatewayStatusError(status)
+}
+
+// PresignURL returns the URL presigned by the storage of the gateway, which may not be accessible by the node.
+func (rcm *RemoteChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	resp, err := rcm.client.PresignURL(ctx, &storagepb.PresignURLRequest{
+		Path:     filePath,
+		Method:   method,
+		ExpiryMs: expiry.Milliseconds(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetUrl(), gatewayStatusError(resp.GetStatus())
+}
+
+func (rcm *RemoteChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	resp, err := rcm.client.Exist(ctx, &storagepb.PathRequest{Path: filePath})
+	if err != nil {
+		return false, err
+	}
+	return resp.GetExist(), gatewayStatusError(resp.GetStatus())
+}
+
+func (rcm *RemoteChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	resp, err := rcm.client.Read(ctx, &storagepb.PathRequest{Path: filePath})
+	if err != nil {
+		return nil, err
+	}
+	if err := gatewayStatusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp.GetContent(), nil
+}
+
+// Reader reads the whole object at once, the objects are bounded by the max message size of the gateway.
+func (rcm *RemoteChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	content, err := rcm.Read(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (rcm *RemoteChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	resp, err := rcm.client.MultiRead(ctx, &storagepb.MultiPathRequest{Paths: filePaths})
+	if err != nil {
+		return nil, err
+	}
+	if err := gatewayStatusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp.GetContents(), nil
+}
+
+func (rcm *RemoteChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	var filePaths []string
+	var modTimes []time.Time
+	err := rcm.WalkWithPrefix(ctx, prefix, recursive, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePaths = append(filePaths, chunkObjectInfo.FilePath)
+		modTimes = append(modTimes, chunkObjectInfo.ModifyTime)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, modTimes, nil
+}
+
+func (rcm *RemoteChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
+	// canceling the stream stops the walk of the gateway if @walkFunc stops it
+	defer cancel()
+	stream, err := rcm.client.WalkWithPrefix(ctx, &storagepb.WalkWithPrefixRequest{Prefix: prefix, Recursive: recursive})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := gatewayStatusError(resp.GetStatus()); err != nil {
+			return err
+		}
+		for _, info := range resp.GetInfos() {
+			if !walkFunc(ChunkObjectInfo{
+				FilePath:     info.GetPath(),
+				ModifyTime:   fromGatewayTime(info.GetModifyTime()),
+				Size:         info.GetSize(),
+				UserMetadata: info.GetUserMetadata(),
+				Tags:         info.GetTags(),
+			}) {
+				return nil
+			}
+		}
+	}
+}
+
+func (rcm *RemoteChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	resp, err := rcm.client.PrefixSize(ctx, &storagepb.PathRequest{Path: prefix})
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := gatewayStatusError(resp.GetStatus()); err != nil {
+		return 0, 0, err
+	}
+	return resp.GetBytes(), resp.GetObjects(), nil
+}
+
+func (rcm *RemoteChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	resp, err := rcm.client.ReadWithPrefix(ctx, &storagepb.PathRequest{Path: prefix})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := gatewayStatusError(resp.GetStatus()); err != nil {
+		return nil, nil, err
+	}
+	return resp.GetPaths(), resp.GetContents(), nil
+}
+
+// Mmap is not supported by the remote storage.
+func (rcm *RemoteChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	return nil, errors.New("remote storage doesn't support mmap")
+}
+
+func (rcm *RemoteChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	resp, err := rcm.client.ReadAt(ctx, &storagepb.ReadAtRequest{Path: filePath, Offset: off, Length: length})
+	if err != nil {
+		return nil, err
+	}
+	if err := gatewayStatusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp.GetContent(), nil
+}
+
+func (rcm *RemoteChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	req := &storagepb.MultiReadAtRequest{Path: filePath, Ranges: make([]*storagepb.Range, 0, len(ranges))}
+	for _, r := range ranges {
+		req.Ranges = append(req.Ranges, &storagepb.Range{Offset: r.Offset, Length: r.Length})
+	}
+	resp, err := rcm.client.MultiReadAt(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := gatewayStatusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp.GetContents(), nil
+}
+
+func (rcm *RemoteChunkManager) Remove(ctx context.Context, filePath string) error {
+	status, err := rcm.client.Remove(ctx, &storagepb.PathRequest{Path: filePath})
+	if err != nil {
+		return err
+	}
+	return gatewayStatusError(status)
+}
+
+func (rcm *RemoteChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	status, err := rcm.client.MultiRemove(ctx, &storagepb.MultiPathRequest{Paths: filePaths})
+	if err != nil {
+		return err
+	}
+	return gatewayStatusError(status)
+}
+
+func (rcm *RemoteChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	status, err := rcm.client.RemoveWithPrefix(ctx, &storagepb.PathRequest{Path: prefix})
+	if err != nil {
+		return err
+	}
+	return gatewayStatusError(status)
+}
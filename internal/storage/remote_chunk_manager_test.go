@@ -0,0 +1,200 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/internal/proto/storagepb"
+)
+
+func newTestRemoteChunkManager(t *testing.T, cm ChunkManager) *RemoteChunkManager {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	storagepb.RegisterChunkManagerServer(server, NewGatewayServer(cm))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	rcm, err := NewRemoteChunkManager(context.Background(), Gateway(lis.Addr().String(), 0))
+	require.NoError(t, err)
+	t.Cleanup(func() { rcm.Close() })
+	return rcm
+}
+
+func TestRemoteChunkManager(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryChunkManager(RootPath("files"))
+	rcm := newTestRemoteChunkManager(t, backend)
+	assert.Equal(t, "files", rcm.RootPath())
+
+	t.Run("write and read", func(t *testing.T) {
+		require.NoError(t, rcm.Write(ctx, "files/a", []byte("hello")))
+		require.NoError(t, rcm.MultiWrite(ctx, map[string][]byte{"files/b": []byte("b"), "files/c": []byte("c")}))
+		require.NoError(t, rcm.Append(ctx, "files/a", []byte(" world")))
+
+		content, err := rcm.Read(ctx, "files/a")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello world"), content)
+		contents, err := rcm.MultiRead(ctx, []string{"files/b", "files/c"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("b"), []byte("c")}, contents)
+		reader, err := rcm.Reader(ctx, "files/a")
+		require.NoError(t, err)
+		content, err = io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello world"), content)
+
+		content, err = rcm.ReadAt(ctx, "files/a", 6, 5)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("world"), content)
+		_, err = rcm.ReadAt(ctx, "files/a", 6, 100)
+		assert.Equal(t, io.EOF, err)
+		parts, err := rcm.MultiReadAt(ctx, "files/a", []Range{{Offset: 0, Length: 5}, {Offset: 6, Length: 5}})
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("hello"), []byte("world")}, parts)
+
+		size, err := rcm.Size(ctx, "files/a")
+		require.NoError(t, err)
+		assert.EqualValues(t, 11, size)
+		bytes, objects, err := rcm.PrefixSize(ctx, "files/")
+		require.NoError(t, err)
+		assert.EqualValues(t, 13, bytes)
+		assert.EqualValues(t, 3, objects)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := rcm.Read(ctx, "files/missing")
+		assert.True(t, errors.Is(err, ErrNoSuchKey))
+		_, err = rcm.Stat(ctx, "files/missing")
+		assert.True(t, errors.Is(err, ErrNoSuchKey))
+		err = rcm.WriteIfNotExist(ctx, "files/a", []byte("a"))
+		assert.True(t, errors.Is(err, ErrObjectExists))
+		assert.Contains(t, err.Error(), "files/a")
+	})
+
+	t.Run("stat and list", func(t *testing.T) {
+		exist, err := rcm.Exist(ctx, "files/b")
+		require.NoError(t, err)
+		assert.True(t, exist)
+		infos, err := rcm.MultiStat(ctx, []string{"files/b", "files/missing"})
+		require.NoError(t, err)
+		require.Len(t, infos, 2)
+		assert.EqualValues(t, 1, infos[0].Size)
+		assert.False(t, infos[0].ModifyTime.IsZero())
+		assert.Nil(t, infos[1])
+
+		filePaths, modTimes, err := rcm.ListWithPrefix(ctx, "files/", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"files/a", "files/b", "files/c"}, filePaths)
+		assert.Len(t, modTimes, 3)
+		filePaths, contents, err := rcm.ReadWithPrefix(ctx, "files/b")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"files/b"}, filePaths)
+		assert.Equal(t, [][]byte{[]byte("b")}, contents)
+	})
+
+	t.Run("walk in batches", func(t *testing.T) {
+		contents := make(map[string][]byte)
+		for i := 0; i < gatewayWalkBatchSize+10; i++ {
+			contents[fmt.Sprintf("walk/%05d", i)] = []byte{1}
+		}
+		require.NoError(t, rcm.MultiWrite(ctx, contents))
+		count := 0
+		require.NoError(t, rcm.WalkWithPrefix(ctx, "walk/", true, func(info ChunkObjectInfo) bool {
+			count++
+			return true
+		}))
+		assert.Equal(t, gatewayWalkBatchSize+10, count)
+
+		count = 0
+		require.NoError(t, rcm.WalkWithPrefix(ctx, "walk/", true, func(info ChunkObjectInfo) bool {
+			count++
+			return count < 5
+		}))
+		assert.Equal(t, 5, count)
+		require.NoError(t, rcm.RemoveWithPrefix(ctx, "walk/"))
+	})
+
+	t.Run("copy, move and remove", func(t *testing.T) {
+		require.NoError(t, rcm.Copy(ctx, "files/b", "files/d"))
+		require.NoError(t, rcm.Move(ctx, "files/d", "files/e"))
+		content, err := backend.Read(ctx, "files/e")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("b"), content)
+		require.NoError(t, rcm.Remove(ctx, "files/e"))
+		require.NoError(t, rcm.MultiRemove(ctx, []string{"files/b", "files/c"}))
+		filePaths, _, err := rcm.ListWithPrefix(ctx, "files/", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"files/a"}, filePaths)
+	})
+}
+
+func TestRemoteChunkManagerNotConfigured(t *testing.T) {
+	_, err := NewRemoteChunkManager(context.Background())
+	assert.Error(t, err)
+}
+
+func TestRemoteChunkManagerToken(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := NewGatewayGrpcServer(NewMemoryChunkManager(RootPath("files")), "secret", nil)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	rcm, err := NewRemoteChunkManager(context.Background(), Gateway(lis.Addr().String(), 0),
+		GatewayAuth("secret", 0, "", "", ""))
+	require.NoError(t, err)
+	defer rcm.Close()
+	assert.Equal(t, "files", rcm.RootPath())
+	require.NoError(t, rcm.Write(context.Background(), "files/a", []byte("a")))
+	// the streams are authenticated as well
+	err = rcm.WalkWithPrefix(context.Background(), "files/", true, func(info ChunkObjectInfo) bool {
+		assert.Equal(t, "files/a", info.FilePath)
+		return true
+	})
+	assert.NoError(t, err)
+
+	// the requests without the token are rejected
+	_, err = NewRemoteChunkManager(context.Background(), Gateway(lis.Addr().String(), 0))
+	assert.Error(t, err)
+	_, err = NewRemoteChunkManager(context.Background(), Gateway(lis.Addr().String(), 0),
+		GatewayAuth("wrong", 0, "", "", ""))
+	assert.Error(t, err)
+}
+
+func TestGatewayTLSConfig(t *testing.T) {
+	tlsConfig, err := GatewayTLSConfig(0, "", "", "", true)
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	_, err = GatewayTLSConfig(3, "", "", "", true)
+	assert.Error(t, err)
+	_, err = GatewayTLSConfig(1, "not-exist.pem", "not-exist.key", "", true)
+	assert.Error(t, err)
+	_, err = GatewayTLSConfig(1, "", "", "not-exist.pem", false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// remoteBlock is a block of the object read by RemoteReaderAt.
+type remoteBlock struct {
+	index int64
+	done  chan struct{}
+	// data and err are set before done is closed
+	data []byte
+	err  error
+}
+
+// RemoteReaderAt is an io.ReaderAt of an object, which fetches the blocks covering the ranges read on demand,
+// so that a large object, e.g. an index file, could be accessed randomly without downloading it entirely.
+// The missing blocks of a read are fetched by concurrent ranged reads, the blocks being fetched are shared
+// by the concurrent reads, and the recently read blocks are cached.
+type RemoteReaderAt struct {
+	ctx         context.Context
+	cm          ChunkManager
+	filePath    string
+	size        int64
+	blockSize   int64
+	cacheBlocks int
+	concurrency int
+
+	mu sync.Mutex
+	// cached are the blocks fetched, in the order of the recent reads, the front is the most recent one
+	cached   *list.List
+	blocks   map[int64]*list.Element
+	fetching map[int64]*remoteBlock
+}
+
+var _ io.ReaderAt = (*RemoteReaderAt)(nil)
+
+// NewRemoteReaderAt returns a RemoteReaderAt of @filePath in @cm, the blocks are fetched with the ReadAt of @cm,
+// and the fetches are canceled with @ctx.
+func NewRemoteReaderAt(ctx context.Context, cm ChunkManager, filePath string, opts ...ReaderAtOption) (*RemoteReaderAt, error) {
+	c := newReaderAtConfig(opts...)
+	size, err := cm.Size(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteReaderAt{
+		ctx:         ctx,
+		cm:          cm,
+		filePath:    filePath,
+		size:        size,
+		blockSize:   c.blockSize,
+		cacheBlocks: c.cacheBlocks,
+		concurrency: c.concurrency,
+		cached:      list.New(),
+		blocks:      make(map[int64]*list.Element),
+		fetching:    make(map[int64]*remoteBlock),
+	}, nil
+}
+
+// Size returns the size of the object.
+func (r *RemoteReaderAt) Size() int64 {
+	return r.size
+}
+
+// ReadAt reads len(@p) bytes of the object from @off, io.EOF is returned if it reaches the end of the object.
+func (r *RemoteReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d to read %s", off, r.filePath)
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	end := off + int64(len(p))
+	if end > r.size {
+		end = r.size
+	}
+	if end == off {
+		return 0, nil
+	}
+
+	blocks, err := r.getBlocks(off/r.blockSize, (end-1)/r.blockSize)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, block := range blocks {
+		blockOff := block.index * r.blockSize
+		start := off + int64(n) - blockOff
+		n += copy(p[n:end-off], block.data[start:])
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// getBlocks returns the blocks from @first to @last, the missing ones are fetched concurrently.
+func (r *RemoteReaderAt) getBlocks(first int64, last int64) ([]*remoteBlock, error) {
+	blocks := make([]*remoteBlock, 0, last-first+1)
+	var missing []*remoteBlock
+	r.mu.Lock()
+	for index := first; index <= last; index++ {
+		if elem, ok := r.blocks[index]; ok {
+			r.cached.MoveToFront(elem)
+			blocks = append(blocks, elem.Value.(*remoteBlock))
+			continue
+		}
+		block, ok := r.fetching[index]
+		if !ok {
+			block = &remoteBlock{index: index, done: make(chan struct{})}
+			r.fetching[index] = block
+			missing = append(missing, block)
+		}
+		blocks = append(blocks, block)
+	}
+	r.mu.Unlock()
+
+	if len(missing) > 0 {
+		r.fetch(missing)
+	}
+	for _, block := range blocks {
+		select {
+		case <-block.done:
+		case <-r.ctx.Done():
+			return nil, r.ctx.Err()
+		}
+		if block.err != nil {
+			return nil, block.err
+		}
+	}
+	return blocks, nil
+}
+
+// fetch reads @blocks with at most concurrency goroutines, and caches the ones fetched.
+func (r *RemoteReaderAt) fetch(blocks []*remoteBlock) {
+	parallelMultiDo(r.ctx, blocks, r.concurrency, func(ctx context.Context, block *remoteBlock) (struct{}, error) {
+		off := block.index * r.blockSize
+		length := r.blockSize
+		if off+length > r.size {
+			length = r.size - off
+		}
+		block.data, block.err = r.cm.ReadAt(ctx, r.filePath, off, length)
+		return struct{}{}, block.err
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, block := range blocks {
+		delete(r.fetching, block.index)
+		if block.err == nil && r.cacheBlocks > 0 {
+			r.blocks[block.index] = r.cached.PushFront(block)
+		}
+		close(block.done)
+	}
+	for r.cached.Len() > r.cacheBlocks {
+		oldest := r.cached.Back()
+		r.cached.Remove(oldest)
+		delete(r.blocks, oldest.Value.(*remoteBlock).index)
+	}
+}
+
+// Close drops the cached blocks.
+func (r *RemoteReaderAt) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cached.Init()
+	r.blocks = make(map[int64]*list.Element)
+	return nil
+}
@@ -0,0 +1,113 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingReadAtChunkManager counts the ReadAt calls.
+type countingReadAtChunkManager struct {
+	ChunkManager
+	reads int64
+}
+
+func (cm *countingReadAtChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	atomic.AddInt64(&cm.reads, 1)
+	return cm.ChunkManager.ReadAt(ctx, filePath, off, length)
+}
+
+func TestRemoteReaderAt(t *testing.T) {
+	ctx := context.Background()
+	content := make([]byte, 1000)
+	for i := range content {
+		content[i] = byte(i)
+	}
+	cm := &countingReadAtChunkManager{ChunkManager: NewMemoryChunkManager()}
+	require.NoError(t, cm.Write(ctx, "index", content))
+
+	r, err := NewRemoteReaderAt(ctx, cm, "index", ReaderAtBlockSize(100), ReaderAtCacheBlocks(3), ReaderAtConcurrency(2))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), r.Size())
+
+	// only the blocks covering the range are fetched
+	p := make([]byte, 150)
+	n, err := r.ReadAt(p, 250)
+	assert.NoError(t, err)
+	assert.Equal(t, 150, n)
+	assert.Equal(t, content[250:400], p)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&cm.reads))
+
+	// the cached blocks are not fetched again
+	n, err = r.ReadAt(p[:100], 300)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, n)
+	assert.Equal(t, content[300:400], p[:100])
+	assert.Equal(t, int64(2), atomic.LoadInt64(&cm.reads))
+
+	// the last block is shorter, and the read beyond the end returns io.EOF
+	n, err = r.ReadAt(p, 900)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 100, n)
+	assert.Equal(t, content[900:], p[:100])
+	_, err = r.ReadAt(p, 1000)
+	assert.ErrorIs(t, err, io.EOF)
+	_, err = r.ReadAt(p, -1)
+	assert.Error(t, err)
+
+	// the least recently read blocks are evicted
+	_, err = r.ReadAt(p[:1], 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, r.cached.Len())
+	reads := atomic.LoadInt64(&cm.reads)
+	_, err = r.ReadAt(p[:1], 350)
+	assert.NoError(t, err)
+	assert.Equal(t, reads, atomic.LoadInt64(&cm.reads))
+	_, err = r.ReadAt(p[:1], 250)
+	assert.NoError(t, err)
+	assert.Equal(t, reads+1, atomic.LoadInt64(&cm.reads))
+
+	// the concurrent reads share the blocks being fetched
+	assert.NoError(t, r.Close())
+	r, err = NewRemoteReaderAt(ctx, cm, "index", ReaderAtBlockSize(100), ReaderAtCacheBlocks(10))
+	require.NoError(t, err)
+	reads = atomic.LoadInt64(&cm.reads)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := make([]byte, 1000)
+			n, err := r.ReadAt(p, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, 1000, n)
+			assert.Equal(t, content, p)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, reads+10, atomic.LoadInt64(&cm.reads))
+
+	_, err = NewRemoteReaderAt(ctx, cm, "not-exist")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+}
@@ -0,0 +1,406 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// Operations of the object changes replicated by Replicator.
+const (
+	replicateWrite        = "write"
+	replicateRemove       = "remove"
+	replicateRemovePrefix = "removePrefix"
+)
+
+// replicationEntry is an object change waiting to be replicated, the content is read from the primary storage
+// when it is replicated, so that the queue stays small and the latest content is replicated.
+type replicationEntry struct {
+	seq int64
+	Op  string `json:"op"`
+	Key string `json:"key"`
+}
+
+// replicationQueue persists the entries as files named by their sequence numbers in a local directory,
+// so that the changes not replicated yet survive restarts.
+type replicationQueue struct {
+	dir string
+
+	mu      sync.Mutex
+	nextSeq int64
+	pending int
+}
+
+func newReplicationQueue(dir string) (*replicationQueue, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	q := &replicationQueue{dir: dir}
+	seqs, err := q.seqs()
+	if err != nil {
+		return nil, err
+	}
+	if len(seqs) > 0 {
+		q.nextSeq = seqs[len(seqs)-1] + 1
+	}
+	q.pending = len(seqs)
+	metrics.StorageReplicationPendingCount.Set(float64(q.pending))
+	return q, nil
+}
+
+// seqs returns the sequence numbers of the persisted entries in order, the temporary files are skipped.
+func (q *replicationQueue) seqs() ([]int64, error) {
+	files, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+	seqs := make([]int64, 0, len(files))
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		seq, err := strconv.ParseInt(strings.TrimSuffix(file.Name(), ".json"), 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+func (q *replicationQueue) entryPath(seq int64) string {
+	return path.Join(q.dir, fmt.Sprintf("%020d.json", seq))
+}
+
+// push persists the entries by writing temporary files and renaming them, so that an entry file is never partial.
+func (q *replicationQueue) push(op string, keys ...string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, key := range keys {
+		data, err := json.Marshal(&replicationEntry{Op: op, Key: key})
+		if err != nil {
+			return err
+		}
+		entryPath := q.entryPath(q.nextSeq)
+		if err := ioutil.WriteFile(entryPath+".tmp", data, 0644); err != nil {
+			return err
+		}
+		if err := os.Rename(entryPath+".tmp", entryPath); err != nil {
+			return err
+		}
+		q.nextSeq++
+		q.pending++
+	}
+	metrics.StorageReplicationPendingCount.Set(float64(q.pending))
+	return nil
+}
+
+// peek returns at most @limit entries in the order they are pushed.
+func (q *replicationQueue) peek(limit int) ([]*replicationEntry, error) {
+	seqs, err := q.seqs()
+	if err != nil {
+		return nil, err
+	}
+	if len(seqs) > limit {
+		seqs = seqs[:limit]
+	}
+	entries := make([]*replicationEntry, 0, len(seqs))
+	for _, seq := range seqs {
+		data, err := ioutil.ReadFile(q.entryPath(seq))
+		if err != nil {
+			return nil, err
+		}
+		entry := &replicationEntry{seq: seq}
+		if err := json.Unmarshal(data, entry); err != nil {
+			return nil, fmt.Errorf("failed to parse replication entry %s: %w", q.entryPath(seq), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// done removes the replicated entry.
+func (q *replicationQueue) done(entry *replicationEntry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := os.Remove(q.entryPath(entry.seq)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	q.pending--
+	metrics.StorageReplicationPendingCount.Set(float64(q.pending))
+	return nil
+}
+
+func (q *replicationQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pending
+}
+
+// replicateBatchSize is the max number of entries read from the queue at a time.
+const replicateBatchSize = 1024
+
+// Replicator replays the object changes of the primary storage on the secondary storage in the background,
+// in the order they are made. A failed change is retried in the next round, and blocks the changes after it,
+// so that a removal is never replicated before the write of the same key.
+type Replicator struct {
+	primary   ChunkManager
+	secondary ChunkManager
+	queue     *replicationQueue
+	interval  time.Duration
+
+	notifyCh  chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewReplicator returns a Replicator from @primary to @secondary, the changes not replicated yet are queued
+// in the local directory @queueDir. The failed changes are retried every @interval.
+func NewReplicator(primary ChunkManager, secondary ChunkManager, queueDir string, interval time.Duration) (*Replicator, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid replication interval %v", interval)
+	}
+	queue, err := newReplicationQueue(queueDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replication queue %s: %w", queueDir, err)
+	}
+	return &Replicator{
+		primary:   primary,
+		secondary: secondary,
+		queue:     queue,
+		interval:  interval,
+		notifyCh:  make(chan struct{}, 1),
+		closeCh:   make(chan struct{}),
+	}, nil
+}
+
+// Start starts replicating in the background.
+func (r *Replicator) Start() {
+	r.startOnce.Do(func() {
+		r.wg.Add(1)
+		go r.run()
+	})
+}
+
+// Stop stops replicating and waits for the running round to finish, the queued changes are kept.
+func (r *Replicator) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.closeCh)
+	})
+	r.wg.Wait()
+}
+
+// Pending returns the number of the changes not replicated yet.
+func (r *Replicator) Pending() int {
+	return r.queue.len()
+}
+
+// enqueue queues the changes of @keys and wakes up the background replication.
+func (r *Replicator) enqueue(op string, keys ...string) error {
+	if err := r.queue.push(op, keys...); err != nil {
+		return fmt.Errorf("failed to queue the replication of %d objects: %w", len(keys), err)
+	}
+	select {
+	case r.notifyCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (r *Replicator) run() {
+	defer r.wg.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		// interrupt the running round on stop
+		<-r.closeCh
+		cancel()
+	}()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.closeCh:
+			return
+		case <-ticker.C:
+		case <-r.notifyCh:
+		}
+		if err := r.replicate(ctx); err != nil && ctx.Err() == nil {
+			metrics.StorageReplicationFailCount.Inc()
+			log.RatedWarn(60, "failed to replicate object changes, retry later",
+				zap.Int("pending", r.Pending()), zap.Error(err))
+		}
+	}
+}
+
+// replicate replays the queued changes in order until the queue is drained or a change fails.
+func (r *Replicator) replicate(ctx context.Context) error {
+	for {
+		entries, err := r.queue.peek(replicateBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		for _, entry := range entries {
+			if err := r.apply(ctx, entry); err != nil {
+				return fmt.Errorf("failed to replicate %s of %s: %w", entry.Op, entry.Key, err)
+			}
+			if err := r.queue.done(entry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *Replicator) apply(ctx context.Context, entry *replicationEntry) error {
+	switch entry.Op {
+	case replicateWrite:
+		content, err := r.primary.Read(ctx, entry.Key)
+		if err != nil {
+			// removed or moved after written, the removal is queued after this entry,
+			// not every backend returns ErrNoSuchKey for the missing objects
+			if exist, existErr := r.primary.Exist(ctx, entry.Key); existErr == nil && !exist {
+				return nil
+			}
+			return err
+		}
+		return r.secondary.Write(ctx, entry.Key, content)
+	case replicateRemove:
+		return r.secondary.Remove(ctx, entry.Key)
+	case replicateRemovePrefix:
+		return r.secondary.RemoveWithPrefix(ctx, entry.Key)
+	default:
+		log.Warn("skip unknown replication entry", zap.String("op", entry.Op), zap.String("key", entry.Key))
+		return nil
+	}
+}
+
+// ReplicatedChunkManager writes to the primary storage synchronously, and queues the changes to be replicated
+// to the secondary storage of another region by a Replicator, so that a copy of the data survives the loss of
+// the primary region without the bucket replication of the storage service. A change is acknowledged once it is
+// made on the primary storage and persisted in the queue, the reads are always served by the primary storage.
+// The object metadata and tags are not replicated.
+type ReplicatedChunkManager struct {
+	ChunkManager
+	replicator *Replicator
+}
+
+var _ ChunkManager = (*ReplicatedChunkManager)(nil)
+
+// NewReplicatedChunkManager returns a ReplicatedChunkManager writing to @primary, the chunk managers of
+// the same storage could share a Replicator.
+func NewReplicatedChunkManager(primary ChunkManager, replicator *Replicator) *ReplicatedChunkManager {
+	return &ReplicatedChunkManager{ChunkManager: primary, replicator: replicator}
+}
+
+func (r *ReplicatedChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	if err := r.ChunkManager.Write(ctx, filePath, content); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateWrite, filePath)
+}
+
+func (r *ReplicatedChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	if err := r.ChunkManager.WriteWithOptions(ctx, filePath, content, opts...); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateWrite, filePath)
+}
+
+func (r *ReplicatedChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	if err := r.ChunkManager.WriteIfNotExist(ctx, filePath, content); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateWrite, filePath)
+}
+
+func (r *ReplicatedChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	if err := r.ChunkManager.MultiWrite(ctx, contents); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(contents))
+	for filePath := range contents {
+		keys = append(keys, filePath)
+	}
+	return r.replicator.enqueue(replicateWrite, keys...)
+}
+
+func (r *ReplicatedChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	if err := r.ChunkManager.Append(ctx, filePath, content); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateWrite, filePath)
+}
+
+func (r *ReplicatedChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	if err := r.ChunkManager.Copy(ctx, srcFilePath, dstFilePath); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateWrite, dstFilePath)
+}
+
+func (r *ReplicatedChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	if err := r.ChunkManager.Move(ctx, srcFilePath, dstFilePath); err != nil {
+		return err
+	}
+	if err := r.replicator.enqueue(replicateWrite, dstFilePath); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateRemove, srcFilePath)
+}
+
+func (r *ReplicatedChunkManager) Remove(ctx context.Context, filePath string) error {
+	if err := r.ChunkManager.Remove(ctx, filePath); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateRemove, filePath)
+}
+
+func (r *ReplicatedChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	if err := r.ChunkManager.MultiRemove(ctx, filePaths); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateRemove, filePaths...)
+}
+
+func (r *ReplicatedChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	if err := r.ChunkManager.RemoveWithPrefix(ctx, prefix); err != nil {
+		return err
+	}
+	return r.replicator.enqueue(replicateRemovePrefix, prefix)
+}
@@ -0,0 +1,80 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicatedChunkManager(t *testing.T) {
+	ctx := context.Background()
+	primary := NewLocalChunkManager(RootPath(t.TempDir() + "/"))
+	secondary := NewFaultInjectionChunkManager(NewLocalChunkManager(RootPath(t.TempDir()+"/")), 0)
+	queueDir := t.TempDir()
+
+	_, err := NewReplicator(primary, secondary, queueDir, 0)
+	assert.Error(t, err)
+	replicator, err := NewReplicator(primary, secondary, queueDir, time.Hour)
+	require.NoError(t, err)
+	cm := NewReplicatedChunkManager(primary, replicator)
+
+	require.NoError(t, cm.Write(ctx, "files/a", []byte("a")))
+	require.NoError(t, cm.MultiWrite(ctx, map[string][]byte{"files/b": []byte("b"), "files/c": []byte("c")}))
+	require.NoError(t, cm.Remove(ctx, "files/c"))
+	require.NoError(t, cm.Move(ctx, "files/a", "files/d"))
+	assert.Equal(t, 6, replicator.Pending())
+
+	// the queue survives restarts
+	replicator, err = NewReplicator(primary, secondary, queueDir, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 6, replicator.Pending())
+
+	// a failed change blocks the changes after it
+	secondary.SetFault(FaultOpWrite, &Fault{ErrorRate: 1})
+	// files/a is skipped as it is moved, and the write of files/b fails
+	assert.Error(t, replicator.replicate(ctx))
+	assert.Equal(t, 5, replicator.Pending())
+	secondary.ClearFaults()
+
+	require.NoError(t, replicator.replicate(ctx))
+	assert.Equal(t, 0, replicator.Pending())
+	filePaths, _, err := secondary.ListWithPrefix(ctx, "files/", true)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"files/b", "files/d"}, filePaths)
+	content, err := secondary.Read(ctx, "files/d")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a"), content)
+
+	// the reads are served by the primary storage
+	content, err = cm.Read(ctx, "files/b")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("b"), content)
+
+	cm = NewReplicatedChunkManager(primary, replicator)
+	replicator.Start()
+	defer replicator.Stop()
+	require.NoError(t, cm.RemoveWithPrefix(ctx, "files/"))
+	assert.Eventually(t, func() bool {
+		filePaths, _, err := secondary.ListWithPrefix(ctx, "files/", true)
+		return err == nil && len(filePaths) == 0 && replicator.Pending() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
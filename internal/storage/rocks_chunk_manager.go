@@ -0,0 +1,546 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tecbot/gorocksdb"
+	"golang.org/x/exp/mmap"
+
+	rocksdbkv "github.com/milvus-io/milvus/internal/kv/rocksdb"
+	"github.com/milvus-io/milvus/internal/util/errorutil"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// DefaultSmallObjectThreshold is the size below which RocksChunkManager packs the objects into RocksDB by default.
+const DefaultSmallObjectThreshold = 64 * 1024
+
+const (
+	// rocksObjectPacked means the content is packed in the index entry
+	rocksObjectPacked byte = 0
+	// rocksObjectFile means the content is kept in a file
+	rocksObjectFile byte = 1
+	// rocksObjectHeaderSize is the size of the kind, modify time and size of an index entry
+	rocksObjectHeaderSize = 17
+)
+
+var (
+	rocksChunkManagersMu sync.Mutex
+	// rocksChunkManagers are shared by the chunk managers created by ChunkManagerFactory by their root paths,
+	// as a RocksDB could only be opened once by a process
+	rocksChunkManagers = make(map[string]*RocksChunkManager)
+)
+
+// rocksObject is an index entry of RocksChunkManager.
+type rocksObject struct {
+	kind       byte
+	modifyTime time.Time
+	size       int64
+	// content is the content of a packed object
+	content []byte
+}
+
+func encodeRocksObject(object *rocksObject) []byte {
+	value := make([]byte, rocksObjectHeaderSize, rocksObjectHeaderSize+len(object.content))
+	value[0] = object.kind
+	binary.BigEndian.PutUint64(value[1:9], uint64(object.modifyTime.UnixNano()))
+	binary.BigEndian.PutUint64(value[9:17], uint64(object.size))
+	return append(value, object.content...)
+}
+
+func decodeRocksObject(filePath string, value []byte) (*rocksObject, error) {
+	if len(value) < rocksObjectHeaderSize {
+		return nil, fmt.Errorf("corrupted rocksdb index entry of %s, size %d", filePath, len(value))
+	}
+	object := &rocksObject{
+		kind:       value[0],
+		modifyTime: time.Unix(0, int64(binary.BigEndian.Uint64(value[1:9]))),
+		size:       int64(binary.BigEndian.Uint64(value[9:17])),
+	}
+	if object.kind == rocksObjectPacked {
+		object.content = value[rocksObjectHeaderSize:]
+	}
+	return object, nil
+}
+
+// RocksChunkManager keeps the objects smaller than the small object threshold in RocksDB, and the others as files,
+// so that the millions of tiny delta logs and stats logs don't take a file and an inode each.
+// RocksDB indexes all the objects by their keys as they are, the entries of the large objects record their sizes
+// and modify times, so the listings and stats never touch the file system. Both RocksDB and the files are under
+// the root path, in the "rocksdb" and "objects" dirs.
+type RocksChunkManager struct {
+	rootPath    string
+	concurrency int
+	threshold   int64
+
+	kv *rocksdbkv.RocksdbKV
+	// files keeps the large objects
+	files *LocalChunkManager
+	// mu serializes the mutations, so that the index entries and the files are consistent
+	mu sync.Mutex
+}
+
+var _ ChunkManager = (*RocksChunkManager)(nil)
+
+// NewRocksChunkManager opens the RocksDB under the root path, and creates a RocksChunkManager with it,
+// the caller must close it when done.
+func NewRocksChunkManager(opts ...Option) (*RocksChunkManager, error) {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.rootPath == "" {
+		return nil, errors.New("rocksdb storage needs a root path")
+	}
+	if err := os.MkdirAll(c.rootPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+	kv, err := rocksdbkv.NewRocksdbKV(path.Join(c.rootPath, "rocksdb"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rocksdb of %s: %w", c.rootPath, err)
+	}
+	kv.WriteOptions.SetSync(c.fsync)
+	threshold := c.smallObjectThreshold
+	if threshold <= 0 {
+		threshold = DefaultSmallObjectThreshold
+	}
+	return &RocksChunkManager{
+		rootPath:    c.rootPath,
+		concurrency: c.concurrency,
+		threshold:   threshold,
+		kv:          kv,
+		files:       NewLocalChunkManager(append(opts, RootPath(path.Join(c.rootPath, "objects")))...),
+	}, nil
+}
+
+// newSharedRocksChunkManager returns the RocksChunkManager of the root path set by the RootPath option, it's opened if not yet.
+func newSharedRocksChunkManager(opts ...Option) (*RocksChunkManager, error) {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	rocksChunkManagersMu.Lock()
+	defer rocksChunkManagersMu.Unlock()
+	if rcm, ok := rocksChunkManagers[c.rootPath]; ok {
+		return rcm, nil
+	}
+	rcm, err := NewRocksChunkManager(opts...)
+	if err != nil {
+		return nil, err
+	}
+	rocksChunkManagers[c.rootPath] = rcm
+	return rcm, nil
+}
+
+// Close closes the RocksDB.
+func (rcm *RocksChunkManager) Close() {
+	rcm.kv.Close()
+}
+
+func (rcm *RocksChunkManager) get(filePath string) (*rocksObject, error) {
+	value, err := rcm.kv.DB.Get(rcm.kv.ReadOptions, []byte(filePath))
+	if err != nil {
+		return nil, err
+	}
+	defer value.Free()
+	if !value.Exists() {
+		return nil, WrapErrNoSuchKey(filePath)
+	}
+	return decodeRocksObject(filePath, append([]byte{}, value.Data()...))
+}
+
+// read returns the content of @object at @filePath.
+func (rcm *RocksChunkManager) read(ctx context.Context, filePath string, object *rocksObject) ([]byte, error) {
+	if object.kind == rocksObjectPacked {
+		return object.content, nil
+	}
+	return rcm.files.Read(ctx, filePath)
+}
+
+// put writes @content to @filePath, the caller must hold the lock. A small object is packed into its index entry,
+// and a large one is written to its file before its index entry, so an index entry never refers to a partial file.
+func (rcm *RocksChunkManager) put(ctx context.Context, filePath string, content []byte) error {
+	old, err := rcm.get(filePath)
+	if err != nil && !errors.Is(err, ErrNoSuchKey) {
+		return err
+	}
+	object := &rocksObject{kind: rocksObjectPacked, modifyTime: time.Now(), size: int64(len(content))}
+	if object.size < rcm.threshold {
+		object.content = content
+	} else {
+		object.kind = rocksObjectFile
+		if err := rcm.files.Write(ctx, filePath, content); err != nil {
+			return err
+		}
+	}
+	if err := rcm.kv.DB.Put(rcm.kv.WriteOptions, []byte(filePath), encodeRocksObject(object)); err != nil {
+		return err
+	}
+	// the file of a large object replaced by a small one is left
+	if old != nil && old.kind == rocksObjectFile && object.kind == rocksObjectPacked {
+		return rcm.files.Remove(ctx, filePath)
+	}
+	return nil
+}
+
+// remove removes @filePath if it exists, the caller must hold the lock.
+func (rcm *RocksChunkManager) remove(ctx context.Context, filePath string) error {
+	object, err := rcm.get(filePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := rcm.kv.DB.Delete(rcm.kv.WriteOptions, []byte(filePath)); err != nil {
+		return err
+	}
+	if object.kind == rocksObjectFile {
+		return rcm.files.Remove(ctx, filePath)
+	}
+	return nil
+}
+
+// RootPath returns the root path of RocksDB and the files, the keys are kept as they are.
+func (rcm *RocksChunkManager) RootPath() string {
+	return rcm.rootPath
+}
+
+// Path returns the path of the file of a large object, the small objects packed in RocksDB have no path.
+func (rcm *RocksChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	object, err := rcm.get(filePath)
+	if err != nil {
+		return "", err
+	}
+	if object.kind == rocksObjectPacked {
+		return "", fmt.Errorf("object %s is packed in rocksdb, which has no file path", filePath)
+	}
+	return rcm.files.Path(ctx, filePath)
+}
+
+func (rcm *RocksChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	object, err := rcm.get(filePath)
+	if err != nil {
+		return 0, err
+	}
+	return object.size, nil
+}
+
+// Stat returns the size, modify time and ETag of the object, the ETag is derived from the modify time and size
+// like LocalChunkManager.
+func (rcm *RocksChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	object, err := rcm.get(filePath)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		FilePath:   filePath,
+		Size:       object.size,
+		ModifyTime: object.modifyTime,
+		ETag:       fmt.Sprintf("%x-%x", object.modifyTime.UnixNano(), object.size),
+	}, nil
+}
+
+func (rcm *RocksChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	return parallelMultiStat(ctx, filePaths, rcm.concurrency, rcm.Stat)
+}
+
+func (rcm *RocksChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	return rcm.put(ctx, filePath, content)
+}
+
+// WriteWithOptions writes the data, the user metadata and tags are ignored.
+func (rcm *RocksChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	return rcm.Write(ctx, filePath, content)
+}
+
+func (rcm *RocksChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	_, err := rcm.get(filePath)
+	if err == nil {
+		return WrapErrObjectExists(filePath)
+	}
+	if !errors.Is(err, ErrNoSuchKey) {
+		return err
+	}
+	return rcm.put(ctx, filePath, content)
+}
+
+func (rcm *RocksChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	for filePath, content := range contents {
+		if err := rcm.Write(ctx, filePath, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Append rewrites the object with @content appended, a small object becomes a file once it reaches the threshold.
+func (rcm *RocksChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	object, err := rcm.get(filePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return rcm.put(ctx, filePath, content)
+	}
+	if err != nil {
+		return err
+	}
+	old, err := rcm.read(ctx, filePath, object)
+	if err != nil {
+		return err
+	}
+	return rcm.put(ctx, filePath, append(old, content...))
+}
+
+func (rcm *RocksChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	object, err := rcm.get(srcFilePath)
+	if err != nil {
+		return err
+	}
+	content, err := rcm.read(ctx, srcFilePath, object)
+	if err != nil {
+		return err
+	}
+	return rcm.put(ctx, dstFilePath, content)
+}
+
+func (rcm *RocksChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	object, err := rcm.get(srcFilePath)
+	if err != nil {
+		return err
+	}
+	if srcFilePath == dstFilePath {
+		return nil
+	}
+	content, err := rcm.read(ctx, srcFilePath, object)
+	if err != nil {
+		return err
+	}
+	if err := rcm.put(ctx, dstFilePath, content); err != nil {
+		return err
+	}
+	return rcm.remove(ctx, srcFilePath)
+}
+
+// PresignURL is not supported by rocksdb storage.
+func (rcm *RocksChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	return "", errors.New("rocksdb storage doesn't support presigned url")
+}
+
+func (rcm *RocksChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	_, err := rcm.get(filePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (rcm *RocksChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	object, err := rcm.get(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return rcm.read(ctx, filePath, object)
+}
+
+func (rcm *RocksChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	object, err := rcm.get(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if object.kind == rocksObjectPacked {
+		return ioutil.NopCloser(bytes.NewReader(object.content)), nil
+	}
+	return rcm.files.Reader(ctx, filePath)
+}
+
+// MultiRead reads the objects with at most concurrency goroutines.
+func (rcm *RocksChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	return parallelMultiRead(ctx, filePaths, rcm.concurrency, rcm.Read)
+}
+
+func (rcm *RocksChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	var filePaths []string
+	var modTimes []time.Time
+	err := rcm.WalkWithPrefix(ctx, prefix, recursive, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePaths = append(filePaths, chunkObjectInfo.FilePath)
+		modTimes = append(modTimes, chunkObjectInfo.ModifyTime)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, modTimes, nil
+}
+
+// iterate calls @fn for the index entries with @prefix in lexicographical order until it returns false,
+// @fn could return a key to seek to instead of the next entry.
+func (rcm *RocksChunkManager) iterate(ctx context.Context, prefix string, fn func(filePath string, object *rocksObject) (bool, string)) error {
+	option := gorocksdb.NewDefaultReadOptions()
+	defer option.Destroy()
+	var iter *rocksdbkv.RocksIterator
+	if prefix == "" {
+		iter = rocksdbkv.NewRocksIterator(rcm.kv.DB, option)
+	} else {
+		iter = rocksdbkv.NewRocksIteratorWithUpperBound(rcm.kv.DB, typeutil.AddOne(prefix), option)
+	}
+	defer iter.Close()
+
+	for iter.Seek([]byte(prefix)); iter.Valid(); {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		key, value := iter.Key(), iter.Value()
+		filePath := string(key.Data())
+		object, err := decodeRocksObject(filePath, append([]byte{}, value.Data()...))
+		key.Free()
+		value.Free()
+		if err != nil {
+			return err
+		}
+		continued, seek := fn(filePath, object)
+		if !continued {
+			return nil
+		}
+		if seek != "" {
+			iter.Seek([]byte(seek))
+		} else {
+			iter.Next()
+		}
+	}
+	return iter.Err()
+}
+
+// WalkWithPrefix visits the objects with @prefix in lexicographical order like S3, the listing is served by
+// RocksDB only. The objects under a common prefix are skipped by seeking past it in the non-recursive walks.
+func (rcm *RocksChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	return rcm.iterate(ctx, prefix, func(filePath string, object *rocksObject) (bool, string) {
+		if !recursive {
+			if i := strings.Index(filePath[len(prefix):], "/"); i >= 0 {
+				commonPrefix := filePath[:len(prefix)+i+1]
+				return walkFunc(ChunkObjectInfo{FilePath: commonPrefix}), typeutil.AddOne(commonPrefix)
+			}
+		}
+		return walkFunc(ChunkObjectInfo{FilePath: filePath, ModifyTime: object.modifyTime, Size: object.size}), ""
+	})
+}
+
+// PrefixSize sums the sizes recorded by the index entries with @prefix.
+func (rcm *RocksChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	var size, objects int64
+	err := rcm.iterate(ctx, prefix, func(filePath string, object *rocksObject) (bool, string) {
+		size += object.size
+		objects++
+		return true, ""
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return size, objects, nil
+}
+
+func (rcm *RocksChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	filePaths, _, err := rcm.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := rcm.MultiRead(ctx, filePaths)
+	return filePaths, result, err
+}
+
+// Mmap maps the file of a large object, the small objects packed in RocksDB couldn't be mapped.
+func (rcm *RocksChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	object, err := rcm.get(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if object.kind == rocksObjectPacked {
+		return nil, fmt.Errorf("object %s is packed in rocksdb, which couldn't be mapped", filePath)
+	}
+	return rcm.files.Mmap(ctx, filePath)
+}
+
+func (rcm *RocksChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	if off < 0 || length < 0 {
+		return nil, io.EOF
+	}
+	object, err := rcm.get(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if object.kind == rocksObjectFile {
+		return rcm.files.ReadAt(ctx, filePath, off, length)
+	}
+	if off+length > object.size {
+		return nil, io.EOF
+	}
+	return object.content[off : off+length], nil
+}
+
+func (rcm *RocksChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	return parallelMultiReadAt(ctx, filePath, ranges, rcm.concurrency, rcm.ReadAt)
+}
+
+// Remove removes @filePath, removing an object which doesn't exist is not an error like S3.
+func (rcm *RocksChunkManager) Remove(ctx context.Context, filePath string) error {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	return rcm.remove(ctx, filePath)
+}
+
+func (rcm *RocksChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	var el errorutil.ErrorList
+	for _, filePath := range filePaths {
+		if err := rcm.remove(ctx, filePath); err != nil {
+			el = append(el, err)
+		}
+	}
+	if len(el) == 0 {
+		return nil
+	}
+	return el
+}
+
+func (rcm *RocksChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	filePaths, _, err := rcm.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return err
+	}
+	return rcm.MultiRemove(ctx, filePaths)
+}
@@ -0,0 +1,138 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRocksChunkManager(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir()
+	rcm, err := NewRocksChunkManager(RootPath(rootPath), SmallObjectThreshold(8))
+	require.NoError(t, err)
+	defer rcm.Close()
+	assert.Equal(t, rootPath, rcm.RootPath())
+
+	large := []byte("large value")
+	require.NoError(t, rcm.Write(ctx, "files/a/1", []byte("small")))
+	require.NoError(t, rcm.Write(ctx, "files/a/2", large))
+	require.NoError(t, rcm.MultiWrite(ctx, map[string][]byte{"files/b/3": []byte("333"), "files/c": []byte("4")}))
+
+	// only the large object is kept as a file
+	_, err = rcm.Path(ctx, "files/a/1")
+	assert.Error(t, err)
+	filePath, err := rcm.Path(ctx, "files/a/2")
+	assert.NoError(t, err)
+	assert.Equal(t, path.Join(rootPath, "objects", "files/a/2"), filePath)
+	_, err = rcm.Mmap(ctx, "files/a/1")
+	assert.Error(t, err)
+
+	values, err := rcm.MultiRead(ctx, []string{"files/a/1", "files/a/2"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("small"), large}, values)
+	_, err = rcm.Read(ctx, "files/a/3")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+	for _, filePath := range []string{"files/a/1", "files/a/2"} {
+		reader, err := rcm.Reader(ctx, filePath)
+		require.NoError(t, err)
+		value, err := ioutil.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.NoError(t, reader.Close())
+		expected, err := rcm.Read(ctx, filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+
+	value, err := rcm.ReadAt(ctx, "files/a/1", 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("mal"), value)
+	_, err = rcm.ReadAt(ctx, "files/a/1", 3, 3)
+	assert.ErrorIs(t, err, io.EOF)
+	values, err = rcm.MultiReadAt(ctx, "files/a/2", []Range{{Offset: 0, Length: 5}, {Offset: 6, Length: 5}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("large"), []byte("value")}, values)
+
+	info, err := rcm.Stat(ctx, "files/a/2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(large)), info.Size)
+	assert.NotEmpty(t, info.ETag)
+	infos, err := rcm.MultiStat(ctx, []string{"files/a/1", "files/a/3"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), infos[0].Size)
+	assert.Nil(t, infos[1])
+
+	filePaths, _, err := rcm.ListWithPrefix(ctx, "files/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"files/a/", "files/b/", "files/c"}, filePaths)
+	filePaths, _, err = rcm.ListWithPrefix(ctx, "files/a", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"files/a/1", "files/a/2"}, filePaths)
+	size, objects, err := rcm.PrefixSize(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5+len(large)+3+1), size)
+	assert.Equal(t, int64(4), objects)
+
+	// the small object becomes a file once it reaches the threshold, and the file is removed once it shrinks
+	require.NoError(t, rcm.Append(ctx, "files/a/1", []byte("er!")))
+	_, err = rcm.Path(ctx, "files/a/1")
+	assert.NoError(t, err)
+	require.NoError(t, rcm.Write(ctx, "files/a/2", []byte("2")))
+	assert.NoFileExists(t, filePath)
+
+	assert.ErrorIs(t, rcm.WriteIfNotExist(ctx, "files/a/1", []byte("other")), ErrObjectExists)
+	assert.NoError(t, rcm.Copy(ctx, "files/a/1", "files/d/1"))
+	assert.NoError(t, rcm.Move(ctx, "files/d/1", "files/d/2"))
+	assert.ErrorIs(t, rcm.Move(ctx, "files/d/1", "files/d/2"), ErrNoSuchKey)
+	value, err = rcm.Read(ctx, "files/d/2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("smaller!"), value)
+
+	assert.NoError(t, rcm.Remove(ctx, "files/c"))
+	assert.NoError(t, rcm.Remove(ctx, "files/c"))
+	assert.NoError(t, rcm.RemoveWithPrefix(ctx, "files/a/"))
+	exist, err := rcm.Exist(ctx, "files/a/1")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	filePaths, _, err = rcm.ListWithPrefix(ctx, "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"files/b/3", "files/d/2"}, filePaths)
+}
+
+func TestRocksChunkManager_Reopen(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir()
+	rcm, err := NewRocksChunkManager(RootPath(rootPath), SmallObjectThreshold(4))
+	require.NoError(t, err)
+	require.NoError(t, rcm.Write(ctx, "a", []byte("1")))
+	require.NoError(t, rcm.Write(ctx, "b", []byte("22222")))
+	rcm.Close()
+
+	rcm, err = NewRocksChunkManager(RootPath(rootPath), SmallObjectThreshold(4))
+	require.NoError(t, err)
+	defer rcm.Close()
+	values, err := rcm.MultiRead(ctx, []string{"a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("22222")}, values)
+}
@@ -0,0 +1,378 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/ratelimitutil"
+)
+
+// ScrubTarget is an object recorded in meta, with the stats it's verified against.
+type ScrubTarget struct {
+	FilePath string
+	// Size is the exact size of the object, 0 means unknown
+	Size int64
+	// IsBinlog is true if the object is a binlog, whose events are parsed to verify the content
+	IsBinlog bool
+	// Rows is the number of rows in the binlog, 0 means unknown
+	Rows int64
+}
+
+// ScrubIssueType is the kind of problem found by Scrubber.
+type ScrubIssueType string
+
+const (
+	// ScrubIssueMissing means the object recorded in meta doesn't exist
+	ScrubIssueMissing ScrubIssueType = "missing"
+	// ScrubIssueSizeMismatch means the size of the object differs from meta
+	ScrubIssueSizeMismatch ScrubIssueType = "sizeMismatch"
+	// ScrubIssueCorrupted means the content of the object is not a valid binlog, or its rows differ from meta
+	ScrubIssueCorrupted ScrubIssueType = "corrupted"
+)
+
+// ScrubIssue is a problem of an object found by Scrubber.
+type ScrubIssue struct {
+	Target ScrubTarget
+	Type   ScrubIssueType
+	Detail string
+}
+
+// ScrubReport is the result of a round of Scrubber.
+type ScrubReport struct {
+	Start    time.Time
+	End      time.Time
+	Targets  int
+	Verified int
+	Issues   []ScrubIssue
+}
+
+// ScrubTargetLister lists the objects recorded in meta, it's called at the beginning of every round.
+type ScrubTargetLister func(ctx context.Context) ([]ScrubTarget, error)
+
+// ScrubberOption configures Scrubber.
+type ScrubberOption func(s *Scrubber)
+
+// WithScrubRate bounds the number of objects verified per second, non-positive means unlimited.
+func WithScrubRate(objectsPerSecond float64) ScrubberOption {
+	return func(s *Scrubber) {
+		if objectsPerSecond > 0 {
+			s.objectLimiter = ratelimitutil.NewLimiter(ratelimitutil.Limit(objectsPerSecond), 1)
+		}
+	}
+}
+
+// WithScrubBandwidth bounds the bytes read per second to verify the contents, non-positive means unlimited.
+func WithScrubBandwidth(bytesPerSecond int64) ScrubberOption {
+	return func(s *Scrubber) {
+		if bytesPerSecond > 0 {
+			s.byteLimiter = ratelimitutil.NewLimiter(ratelimitutil.Limit(bytesPerSecond), float64(bytesPerSecond))
+		}
+	}
+}
+
+// WithScrubContent makes Scrubber read the objects and verify them as binlogs, besides their sizes.
+func WithScrubContent(verify bool) ScrubberOption {
+	return func(s *Scrubber) {
+		s.verifyContent = verify
+	}
+}
+
+// WithScrubIssueHandler sets the callback called for every issue once it's found.
+func WithScrubIssueHandler(handler func(ScrubIssue)) ScrubberOption {
+	return func(s *Scrubber) {
+		s.onIssue = handler
+	}
+}
+
+// Scrubber walks the prefixes of the storage continuously and verifies the objects recorded in meta,
+// so that corrupted or missing binlogs are reported before loading them fails.
+// Objects not recorded in meta are left to the garbage collector.
+type Scrubber struct {
+	cm       ChunkManager
+	prefixes []string
+	lister   ScrubTargetLister
+	interval time.Duration
+
+	objectLimiter *ratelimitutil.Limiter
+	byteLimiter   *ratelimitutil.Limiter
+	verifyContent bool
+	onIssue       func(ScrubIssue)
+
+	mu         sync.RWMutex
+	lastReport *ScrubReport
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+	closeCh   chan struct{}
+}
+
+// NewScrubber returns a Scrubber verifying the objects listed by @lister under @prefixes of @cm every @interval.
+func NewScrubber(cm ChunkManager, prefixes []string, lister ScrubTargetLister, interval time.Duration, opts ...ScrubberOption) *Scrubber {
+	s := &Scrubber{
+		cm:       cm,
+		prefixes: prefixes,
+		lister:   lister,
+		interval: interval,
+		closeCh:  make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start starts scrubbing in background.
+func (s *Scrubber) Start() {
+	s.startOnce.Do(func() {
+		s.wg.Add(1)
+		go s.work()
+	})
+}
+
+// Close stops scrubbing and waits for the round in progress to quit.
+func (s *Scrubber) Close() {
+	s.stopOnce.Do(func() {
+		close(s.closeCh)
+		s.wg.Wait()
+	})
+}
+
+// LastReport returns the report of the last finished round, nil if no round has finished.
+func (s *Scrubber) LastReport() *ScrubReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastReport
+}
+
+func (s *Scrubber) work() {
+	defer s.wg.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		report, err := s.Scrub(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Warn("failed to scrub storage", zap.Error(err))
+		}
+		if err == nil {
+			log.Info("storage scrubbed", zap.Int("targets", report.Targets), zap.Int("verified", report.Verified),
+				zap.Int("issues", len(report.Issues)), zap.Duration("duration", report.End.Sub(report.Start)))
+		}
+		select {
+		case <-ticker.C:
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// Scrub runs a round, it walks the prefixes and verifies every listed object found,
+// then the listed objects not found by the walk are checked to be missing.
+func (s *Scrubber) Scrub(ctx context.Context) (*ScrubReport, error) {
+	report := &ScrubReport{Start: time.Now()}
+	targetList, err := s.lister(ctx)
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]ScrubTarget, len(targetList))
+	for _, target := range targetList {
+		targets[normalizeScrubPath(target.FilePath)] = target
+	}
+	report.Targets = len(targets)
+
+	var verifyErr error
+	for _, prefix := range s.prefixes {
+		err := s.cm.WalkWithPrefix(ctx, prefix, true, func(info ChunkObjectInfo) bool {
+			key := normalizeScrubPath(info.FilePath)
+			target, ok := targets[key]
+			if !ok {
+				return true
+			}
+			delete(targets, key)
+			if verifyErr = s.verify(ctx, target, report); verifyErr != nil {
+				return false
+			}
+			return true
+		})
+		if err == nil {
+			err = verifyErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the objects not walked are either missing or out of the prefixes
+	for _, target := range targets {
+		if err := s.verify(ctx, target, report); err != nil {
+			return nil, err
+		}
+	}
+
+	report.End = time.Now()
+	s.mu.Lock()
+	s.lastReport = report
+	s.mu.Unlock()
+	return report, nil
+}
+
+func normalizeScrubPath(filePath string) string {
+	return strings.TrimLeft(filePath, "/")
+}
+
+// verify checks @target and records the issue found into @report, it only returns the errors aborting the round.
+func (s *Scrubber) verify(ctx context.Context, target ScrubTarget, report *ScrubReport) error {
+	if err := waitLimiter(ctx, s.objectLimiter, 1); err != nil {
+		return err
+	}
+	report.Verified++
+	issue, err := s.check(ctx, target)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		// the transient errors are not issues, the object is verified again in the next round
+		log.Warn("failed to verify object", zap.String("path", target.FilePath), zap.Error(err))
+		return nil
+	}
+	if issue != nil {
+		report.Issues = append(report.Issues, *issue)
+		log.Warn("object integrity issue found", zap.String("path", target.FilePath),
+			zap.String("type", string(issue.Type)), zap.String("detail", issue.Detail))
+		if s.onIssue != nil {
+			s.onIssue(*issue)
+		}
+	}
+	return nil
+}
+
+func (s *Scrubber) check(ctx context.Context, target ScrubTarget) (*ScrubIssue, error) {
+	info, err := s.cm.Stat(ctx, target.FilePath)
+	if errors.Is(err, ErrNoSuchKey) {
+		return &ScrubIssue{Target: target, Type: ScrubIssueMissing, Detail: "object not found"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if target.Size > 0 && info.Size != target.Size {
+		return &ScrubIssue{Target: target, Type: ScrubIssueSizeMismatch,
+			Detail: fmt.Sprintf("expected size %d, actual size %d", target.Size, info.Size)}, nil
+	}
+	if !s.verifyContent || !target.IsBinlog {
+		return nil, nil
+	}
+
+	if err := waitLimiter(ctx, s.byteLimiter, int(info.Size)); err != nil {
+		return nil, err
+	}
+	content, err := s.cm.Read(ctx, target.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := countBinlogRows(content)
+	if err != nil {
+		return &ScrubIssue{Target: target, Type: ScrubIssueCorrupted, Detail: err.Error()}, nil
+	}
+	if target.Rows > 0 && rows != target.Rows {
+		return &ScrubIssue{Target: target, Type: ScrubIssueCorrupted,
+			Detail: fmt.Sprintf("expected %d rows, actual %d rows", target.Rows, rows)}, nil
+	}
+	return nil, nil
+}
+
+// countBinlogRows parses all events of the binlog in @content and returns the number of rows in them.
+func countBinlogRows(content []byte) (rows int64, err error) {
+	// the payload readers may panic on the malformed data
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed binlog: %v", r)
+		}
+	}()
+	if BinlogFormatVersion(content) == BinlogFormatV2 {
+		return countParquetBinlogRows(content)
+	}
+	reader, err := NewBinlogReader(content)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	for {
+		event, err := reader.NextEventReader()
+		if err != nil {
+			return 0, err
+		}
+		if event == nil {
+			return rows, nil
+		}
+		length, err := event.GetPayloadLengthFromReader()
+		if err != nil {
+			return 0, err
+		}
+		rows += int64(length)
+	}
+}
+
+// countParquetBinlogRows reads all row groups of the parquet binlog in @content and returns the number of rows in them.
+func countParquetBinlogRows(content []byte) (int64, error) {
+	reader, err := NewParquetBinlogReaderFromBuffer(content)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	var rows int64
+	for i := 0; i < reader.NumRowGroups(); i++ {
+		data, err := reader.ReadRowGroup(i)
+		if err != nil {
+			return 0, err
+		}
+		rows += int64(data.RowNum())
+	}
+	return rows, nil
+}
+
+func waitLimiter(ctx context.Context, limiter *ratelimitutil.Limiter, n int) error {
+	if limiter == nil || n <= 0 {
+		return nil
+	}
+	for !limiter.AllowN(time.Now(), n) {
+		select {
+		case <-time.After(ioGovernorWaitInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
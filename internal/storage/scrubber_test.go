@@ -0,0 +1,110 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+)
+
+func newScrubTestBinlog(t *testing.T, rows []int64) []byte {
+	w := NewInsertBinlogWriter(schemapb.DataType_Int64, 1, 2, 3, 4)
+	defer w.Close()
+	e, err := w.NextInsertEventWriter()
+	require.NoError(t, err)
+	require.NoError(t, e.AddDataToPayload(rows))
+	e.SetEventTimestamp(100, 200)
+	w.SetEventTimeStamp(100, 200)
+	w.baseBinlogWriter.descriptorEventData.AddExtra(originalSizeKey, "24")
+	require.NoError(t, w.Finish())
+	buf, err := w.GetBuffer()
+	require.NoError(t, err)
+	return buf
+}
+
+func TestScrubber(t *testing.T) {
+	ctx := context.Background()
+	cm := NewLocalChunkManager(RootPath(t.TempDir()))
+	binlog := newScrubTestBinlog(t, []int64{1, 2, 3})
+	require.NoError(t, cm.Write(ctx, "insert_log/good", binlog))
+	require.NoError(t, cm.Write(ctx, "insert_log/rows", binlog))
+	require.NoError(t, cm.Write(ctx, "insert_log/corrupted", binlog[:len(binlog)/2]))
+	require.NoError(t, cm.Write(ctx, "stats_log/good", []byte("stats")))
+	require.NoError(t, cm.Write(ctx, "stats_log/size", []byte("stats")))
+	require.NoError(t, cm.Write(ctx, "insert_log/unknown", []byte("not in meta")))
+
+	targets := []ScrubTarget{
+		{FilePath: "insert_log/good", IsBinlog: true, Rows: 3},
+		{FilePath: "insert_log/rows", IsBinlog: true, Rows: 4},
+		{FilePath: "insert_log/corrupted", IsBinlog: true, Rows: 3},
+		{FilePath: "insert_log/missing", IsBinlog: true, Rows: 3},
+		{FilePath: "stats_log/good", Size: 5},
+		{FilePath: "stats_log/size", Size: 6},
+	}
+	var handled []ScrubIssue
+	s := NewScrubber(cm, []string{"insert_log/", "stats_log/"}, func(ctx context.Context) ([]ScrubTarget, error) {
+		return targets, nil
+	}, time.Hour, WithScrubContent(true), WithScrubRate(1000), WithScrubBandwidth(1024*1024),
+		WithScrubIssueHandler(func(issue ScrubIssue) {
+			handled = append(handled, issue)
+		}))
+	assert.Nil(t, s.LastReport())
+
+	report, err := s.Scrub(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(targets), report.Targets)
+	assert.Equal(t, len(targets), report.Verified)
+	assert.Equal(t, report, s.LastReport())
+	assert.Equal(t, report.Issues, handled)
+
+	issues := make(map[string]ScrubIssueType)
+	for _, issue := range report.Issues {
+		issues[issue.Target.FilePath] = issue.Type
+	}
+	assert.Equal(t, map[string]ScrubIssueType{
+		"insert_log/rows":      ScrubIssueCorrupted,
+		"insert_log/corrupted": ScrubIssueCorrupted,
+		"insert_log/missing":   ScrubIssueMissing,
+		"stats_log/size":       ScrubIssueSizeMismatch,
+	}, issues)
+
+	// without content verification, only the existence and the sizes are checked
+	s = NewScrubber(cm, []string{"insert_log/", "stats_log/"}, func(ctx context.Context) ([]ScrubTarget, error) {
+		return targets, nil
+	}, time.Hour)
+	report, err = s.Scrub(ctx)
+	require.NoError(t, err)
+	assert.Len(t, report.Issues, 2)
+
+	s.Start()
+	s.Close()
+}
+
+func TestCountBinlogRows(t *testing.T) {
+	rows, err := countBinlogRows(newScrubTestBinlog(t, []int64{1, 2, 3, 4}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), rows)
+
+	_, err = countBinlogRows([]byte("not a binlog"))
+	assert.Error(t, err)
+}
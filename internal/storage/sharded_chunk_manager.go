@@ -0,0 +1,347 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/mmap"
+)
+
+// keyShard is a top-level prefix of a bucket holding a part of the object keys.
+type keyShard struct {
+	cm     ChunkManager
+	prefix string
+}
+
+// ShardedChunkManager distributes the object keys by hash over the top-level prefixes of several buckets,
+// so that the requests of massive parallel loads are spread over the per-bucket and per-prefix rate limits.
+// The callers see the keys as they are, the shard prefixes are added and removed inside. The listings merge
+// all the shards, so they are more expensive than the ones of a single bucket, and are not sorted across shards
+// when walking.
+type ShardedChunkManager struct {
+	ChunkManager
+	shards []keyShard
+}
+
+var _ ChunkManager = (*ShardedChunkManager)(nil)
+
+// NewShardedChunkManager returns a ShardedChunkManager over @prefixes top-level prefixes of every chunk manager
+// in @buckets, the first one serves the calls not about any key, like RootPath. No prefix is added if @prefixes
+// is less than 2. The shard of a key depends on the number of buckets and prefixes, which must never be changed
+// on existing data.
+func NewShardedChunkManager(buckets []ChunkManager, prefixes int) *ShardedChunkManager {
+	if prefixes < 1 {
+		prefixes = 1
+	}
+	shards := make([]keyShard, 0, len(buckets)*prefixes)
+	for _, cm := range buckets {
+		for i := 0; i < prefixes; i++ {
+			shard := keyShard{cm: cm}
+			if prefixes > 1 {
+				shard.prefix = fmt.Sprintf("%02x/", i)
+			}
+			shards = append(shards, shard)
+		}
+	}
+	return &ShardedChunkManager{ChunkManager: buckets[0], shards: shards}
+}
+
+// locate returns the shard of @filePath and the key stored in it.
+func (s *ShardedChunkManager) locate(filePath string) (keyShard, string) {
+	h := fnv.New32a()
+	h.Write([]byte(filePath))
+	shard := s.shards[h.Sum32()%uint32(len(s.shards))]
+	return shard, shard.prefix + filePath
+}
+
+// groupPaths groups the indexes of @filePaths by the chunk managers storing them, and returns the stored keys.
+func (s *ShardedChunkManager) groupPaths(filePaths []string) (map[ChunkManager][]int, []string) {
+	groups := make(map[ChunkManager][]int)
+	keys := make([]string, len(filePaths))
+	for i, filePath := range filePaths {
+		var shard keyShard
+		shard, keys[i] = s.locate(filePath)
+		groups[shard.cm] = append(groups[shard.cm], i)
+	}
+	return groups, keys
+}
+
+// Path returns @filePath if it exists, instead of the key stored in the shard, which is of no use to the callers.
+func (s *ShardedChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	shard, key := s.locate(filePath)
+	if _, err := shard.cm.Path(ctx, key); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+func (s *ShardedChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	shard, key := s.locate(filePath)
+	return shard.cm.Size(ctx, key)
+}
+
+func (s *ShardedChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	shard, key := s.locate(filePath)
+	info, err := shard.cm.Stat(ctx, key)
+	if err != nil {
+		return info, err
+	}
+	info.FilePath = filePath
+	return info, nil
+}
+
+func (s *ShardedChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	results := make([]*ObjectInfo, len(filePaths))
+	groups, keys := s.groupPaths(filePaths)
+	for cm, indexes := range groups {
+		infos, err := cm.MultiStat(ctx, pickPaths(keys, indexes))
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range indexes {
+			if infos[j] != nil {
+				infos[j].FilePath = filePaths[i]
+			}
+			results[i] = infos[j]
+		}
+	}
+	return results, nil
+}
+
+func (s *ShardedChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	shard, key := s.locate(filePath)
+	return shard.cm.Write(ctx, key, content)
+}
+
+func (s *ShardedChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	shard, key := s.locate(filePath)
+	return shard.cm.WriteWithOptions(ctx, key, content, opts...)
+}
+
+func (s *ShardedChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	shard, key := s.locate(filePath)
+	return shard.cm.WriteIfNotExist(ctx, key, content)
+}
+
+func (s *ShardedChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	groups := make(map[ChunkManager]map[string][]byte)
+	for filePath, content := range contents {
+		shard, key := s.locate(filePath)
+		if groups[shard.cm] == nil {
+			groups[shard.cm] = make(map[string][]byte)
+		}
+		groups[shard.cm][key] = content
+	}
+	for cm, group := range groups {
+		if err := cm.MultiWrite(ctx, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ShardedChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	shard, key := s.locate(filePath)
+	return shard.cm.Append(ctx, key, content)
+}
+
+// Copy copies within the bucket if both keys are stored in it, otherwise the object is read and written again.
+func (s *ShardedChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	src, srcKey := s.locate(srcFilePath)
+	dst, dstKey := s.locate(dstFilePath)
+	if src.cm == dst.cm {
+		return src.cm.Copy(ctx, srcKey, dstKey)
+	}
+	content, err := src.cm.Read(ctx, srcKey)
+	if err != nil {
+		return err
+	}
+	return dst.cm.Write(ctx, dstKey, content)
+}
+
+// Move moves within the bucket if both keys are stored in it, otherwise the object is copied and then removed.
+func (s *ShardedChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	src, srcKey := s.locate(srcFilePath)
+	dst, dstKey := s.locate(dstFilePath)
+	if src.cm == dst.cm {
+		return src.cm.Move(ctx, srcKey, dstKey)
+	}
+	if err := s.Copy(ctx, srcFilePath, dstFilePath); err != nil {
+		return err
+	}
+	return src.cm.Remove(ctx, srcKey)
+}
+
+func (s *ShardedChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	shard, key := s.locate(filePath)
+	return shard.cm.PresignURL(ctx, key, method, expiry)
+}
+
+func (s *ShardedChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	shard, key := s.locate(filePath)
+	return shard.cm.Exist(ctx, key)
+}
+
+func (s *ShardedChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	shard, key := s.locate(filePath)
+	return shard.cm.Read(ctx, key)
+}
+
+func (s *ShardedChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	shard, key := s.locate(filePath)
+	return shard.cm.Reader(ctx, key)
+}
+
+func (s *ShardedChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	results := make([][]byte, len(filePaths))
+	groups, keys := s.groupPaths(filePaths)
+	for cm, indexes := range groups {
+		contents, err := cm.MultiRead(ctx, pickPaths(keys, indexes))
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range indexes {
+			results[i] = contents[j]
+		}
+	}
+	return results, nil
+}
+
+// ListWithPrefix lists all the shards, the directories found in several shards are returned once,
+// and the results are sorted by path like the listing of a single bucket.
+func (s *ShardedChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	listed := make(map[string]time.Time)
+	for _, shard := range s.shards {
+		paths, times, err := shard.cm.ListWithPrefix(ctx, shard.prefix+prefix, recursive)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, filePath := range paths {
+			filePath = strings.TrimPrefix(filePath, shard.prefix)
+			if modTime, ok := listed[filePath]; !ok || times[i].After(modTime) {
+				listed[filePath] = times[i]
+			}
+		}
+	}
+	filePaths := make([]string, 0, len(listed))
+	for filePath := range listed {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+	modTimes := make([]time.Time, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		modTimes = append(modTimes, listed[filePath])
+	}
+	return filePaths, modTimes, nil
+}
+
+// WalkWithPrefix walks the shards one by one, the directories found in several shards are walked once.
+func (s *ShardedChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	walked := make(map[string]struct{})
+	stopped := false
+	for _, shard := range s.shards {
+		shard := shard
+		err := shard.cm.WalkWithPrefix(ctx, shard.prefix+prefix, recursive, func(info ChunkObjectInfo) bool {
+			info.FilePath = strings.TrimPrefix(info.FilePath, shard.prefix)
+			if _, ok := walked[info.FilePath]; ok {
+				return true
+			}
+			walked[info.FilePath] = struct{}{}
+			stopped = !walkFunc(info)
+			return !stopped
+		})
+		if err != nil || stopped {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrefixSize sums the usages of all the shards, the objects found in several shards are counted several times.
+func (s *ShardedChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	var bytes, objects int64
+	for _, shard := range s.shards {
+		shardBytes, shardObjects, err := shard.cm.PrefixSize(ctx, shard.prefix+prefix)
+		if err != nil {
+			return 0, 0, err
+		}
+		bytes += shardBytes
+		objects += shardObjects
+	}
+	return bytes, objects, nil
+}
+
+func (s *ShardedChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	var filePaths []string
+	var contents [][]byte
+	for _, shard := range s.shards {
+		paths, datas, err := shard.cm.ReadWithPrefix(ctx, shard.prefix+prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, filePath := range paths {
+			filePaths = append(filePaths, strings.TrimPrefix(filePath, shard.prefix))
+			contents = append(contents, datas[i])
+		}
+	}
+	return filePaths, contents, nil
+}
+
+func (s *ShardedChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	shard, key := s.locate(filePath)
+	return shard.cm.Mmap(ctx, key)
+}
+
+func (s *ShardedChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	shard, key := s.locate(filePath)
+	return shard.cm.ReadAt(ctx, key, off, length)
+}
+
+func (s *ShardedChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	shard, key := s.locate(filePath)
+	return shard.cm.MultiReadAt(ctx, key, ranges)
+}
+
+func (s *ShardedChunkManager) Remove(ctx context.Context, filePath string) error {
+	shard, key := s.locate(filePath)
+	return shard.cm.Remove(ctx, key)
+}
+
+func (s *ShardedChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	groups, keys := s.groupPaths(filePaths)
+	for cm, indexes := range groups {
+		if err := cm.MultiRemove(ctx, pickPaths(keys, indexes)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ShardedChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	for _, shard := range s.shards {
+		if err := shard.cm.RemoveWithPrefix(ctx, shard.prefix+prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedChunkManager(t *testing.T) {
+	ctx := context.Background()
+	bucket0 := NewLocalChunkManager(RootPath(t.TempDir() + "/"))
+	bucket1 := NewLocalChunkManager(RootPath(t.TempDir() + "/"))
+	cm := NewShardedChunkManager([]ChunkManager{bucket0, bucket1}, 2)
+	assert.Len(t, cm.shards, 4)
+	assert.Equal(t, bucket0.RootPath(), cm.RootPath())
+
+	contents := make(map[string][]byte)
+	var filePaths []string
+	for i := 0; i < 32; i++ {
+		filePath := fmt.Sprintf("files/insert_log/%d", i)
+		contents[filePath] = []byte(filePath)
+		filePaths = append(filePaths, filePath)
+	}
+	require.NoError(t, cm.MultiWrite(ctx, contents))
+
+	// the keys are spread over the shards, with the shard prefixes added
+	used := make(map[int]struct{})
+	for i, shard := range cm.shards {
+		paths, _, err := shard.cm.ListWithPrefix(ctx, shard.prefix+"files/", true)
+		assert.NoError(t, err)
+		if len(paths) > 0 {
+			used[i] = struct{}{}
+		}
+	}
+	assert.Greater(t, len(used), 1)
+	shard, key := cm.locate(filePaths[0])
+	assert.Equal(t, shard.prefix+filePaths[0], key)
+	exist, err := shard.cm.Exist(ctx, key)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	values, err := cm.MultiRead(ctx, filePaths)
+	assert.NoError(t, err)
+	for i, filePath := range filePaths {
+		assert.Equal(t, []byte(filePath), values[i])
+	}
+	info, err := cm.Stat(ctx, filePaths[1])
+	assert.NoError(t, err)
+	assert.Equal(t, filePaths[1], info.FilePath)
+	p, err := cm.Path(ctx, filePaths[1])
+	assert.NoError(t, err)
+	assert.Equal(t, filePaths[1], p)
+
+	listed, modTimes, err := cm.ListWithPrefix(ctx, "files/", true)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, filePaths, listed)
+	assert.Len(t, modTimes, len(listed))
+	assert.True(t, sort.StringsAreSorted(listed))
+	// the directory in every shard is listed once
+	listed, _, err = cm.ListWithPrefix(ctx, "files/", false)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+	listed, values, err = cm.ReadWithPrefix(ctx, "files/insert_log/1")
+	assert.NoError(t, err)
+	assert.Len(t, listed, 11)
+	assert.Len(t, values, 11)
+
+	require.NoError(t, cm.Move(ctx, filePaths[0], "files/moved"))
+	content, err := cm.Read(ctx, "files/moved")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(filePaths[0]), content)
+	exist, err = cm.Exist(ctx, filePaths[0])
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	require.NoError(t, cm.RemoveWithPrefix(ctx, "files/"))
+	listed, _, err = cm.ListWithPrefix(ctx, "", true)
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+}
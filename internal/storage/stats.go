@@ -25,8 +25,10 @@ import (
 )
 
 const (
-	// TODO silverxia maybe need set from config
-	BloomFilterSize       uint    = 100000
+	// BloomFilterSize is the expected number of rows of the growing segments,
+	// the bloom filters of the flushed ones are sized by their actual row counts
+	BloomFilterSize uint = 100000
+	// MaxBloomFalsePositive is the default false positive rate, overridden by common.bloomFilter.falsePositiveRate
 	MaxBloomFalsePositive float64 = 0.005
 )
 
@@ -152,7 +154,7 @@ func (sw *StatsWriter) GeneratePrimaryKeyStats(fieldID int64, pkType schemapb.Da
 		PkType:  int64(pkType),
 	}
 
-	stats.BF = bloom.NewWithEstimates(uint(msgs.RowNum()), MaxBloomFalsePositive)
+	stats.BF = NewBloomFilterWithRows(uint(msgs.RowNum()))
 	switch pkType {
 	case schemapb.DataType_Int64:
 		data := msgs.(*Int64FieldData).Data
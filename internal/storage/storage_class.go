@@ -0,0 +1,176 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/log"
+)
+
+// The S3 storage classes commonly used for binlogs and index files.
+const (
+	StorageClassStandard   = "STANDARD"
+	StorageClassStandardIA = "STANDARD_IA"
+	StorageClassGlacierIR  = "GLACIER_IR"
+)
+
+// StorageClassRule sets the storage class of the objects whose keys match Pattern and are at least MinAge old,
+// "*" in Pattern matches any characters including "/".
+type StorageClassRule struct {
+	Pattern      string
+	StorageClass string
+	MinAge       time.Duration
+
+	re *regexp.Regexp
+}
+
+func (r *StorageClassRule) match(key string) bool {
+	if r.re == nil {
+		r.re = regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(r.Pattern), `\*`, ".*") + "$")
+	}
+	return r.re.MatchString(key)
+}
+
+// StorageClassPolicy chooses the storage classes of the objects by their keys and ages.
+type StorageClassPolicy []*StorageClassRule
+
+// ParseStorageClassPolicy parses the comma separated rules in the form of "pattern:storageClass[:minAge]",
+// e.g. "*/index_files/*:STANDARD,*/delta_log/*:STANDARD_IA:720h".
+func ParseStorageClassPolicy(value string) (StorageClassPolicy, error) {
+	var policy StorageClassPolicy
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid storage class rule %q, pattern:storageClass[:minAge] is expected", item)
+		}
+		rule := &StorageClassRule{Pattern: parts[0], StorageClass: strings.ToUpper(parts[1])}
+		if len(parts) == 3 {
+			minAge, err := time.ParseDuration(parts[2])
+			if err != nil || minAge < 0 {
+				return nil, fmt.Errorf("invalid min age of storage class rule %q", item)
+			}
+			rule.MinAge = minAge
+		}
+		policy = append(policy, rule)
+	}
+	return policy, nil
+}
+
+// StorageClass returns the storage class of the object @key of @age, that is the one of the oldest rule matching it,
+// the first one among the rules of the same age. Returns empty if no rule matches.
+func (p StorageClassPolicy) StorageClass(key string, age time.Duration) string {
+	var chosen *StorageClassRule
+	for _, rule := range p {
+		if rule.MinAge > age || !rule.match(key) {
+			continue
+		}
+		if chosen == nil || rule.MinAge > chosen.MinAge {
+			chosen = rule
+		}
+	}
+	if chosen == nil {
+		return ""
+	}
+	return chosen.StorageClass
+}
+
+// hasAgedRules returns true if some rule takes effect only on the aged objects.
+func (p StorageClassPolicy) hasAgedRules() bool {
+	for _, rule := range p {
+		if rule.MinAge > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// StorageClassTransitioner moves the aged objects to the storage classes chosen by the storage class policy.
+type StorageClassTransitioner interface {
+	TransitionStorageClass(ctx context.Context, prefix string) (int, error)
+}
+
+var _ StorageClassTransitioner = (*MinioChunkManager)(nil)
+
+// storageClassFor returns the storage class of a new object, the one given by WithStorageClass wins over the policy,
+// which wins over the default storage class.
+func (mcm *MinioChunkManager) storageClassFor(filePath string, c *writeConfig) string {
+	if c != nil && c.storageClass != "" {
+		return c.storageClass
+	}
+	if class := mcm.storageClassPolicy.StorageClass(filePath, 0); class != "" {
+		return class
+	}
+	return mcm.storageClass
+}
+
+// TransitionStorageClass copies the objects with @prefix in place to the storage classes chosen by the policy
+// for their ages, and returns the number of objects transitioned. The user metadata is kept, the tags are kept
+// by the copy. Nothing is done if the policy has no rule for the aged objects.
+func (mcm *MinioChunkManager) TransitionStorageClass(ctx context.Context, prefix string) (int, error) {
+	if !mcm.storageClassPolicy.hasAgedRules() {
+		return 0, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	transitioned := 0
+	now := time.Now()
+	for object := range mcm.Client.ListObjects(ctx, mcm.bucketName, mcm.listObjectsOptions(prefix, true)) {
+		if object.Err != nil {
+			return transitioned, object.Err
+		}
+		target := mcm.storageClassPolicy.StorageClass(object.Key, now.Sub(object.LastModified))
+		current := object.StorageClass
+		if current == "" {
+			current = StorageClassStandard
+		}
+		if target == "" || target == current {
+			continue
+		}
+		info, err := mcm.Client.StatObject(ctx, mcm.bucketName, object.Key, mcm.getObjectOptions())
+		if err != nil {
+			return transitioned, err
+		}
+		metadata := make(map[string]string, len(info.UserMetadata)+1)
+		for k, v := range info.UserMetadata {
+			metadata[k] = v
+		}
+		metadata["X-Amz-Storage-Class"] = target
+		dst := mcm.copyDestOptions(object.Key)
+		dst.ReplaceMetadata = true
+		dst.UserMetadata = metadata
+		if _, err := mcm.Client.CopyObject(ctx, dst, minio.CopySrcOptions{Bucket: mcm.bucketName, Object: object.Key}); err != nil {
+			log.Warn("failed to transition storage class", zap.String("path", object.Key),
+				zap.String("from", current), zap.String("to", target), zap.Error(err))
+			return transitioned, err
+		}
+		transitioned++
+	}
+	return transitioned, nil
+}
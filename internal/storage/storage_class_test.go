@@ -0,0 +1,67 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStorageClassPolicy(t *testing.T) {
+	policy, err := ParseStorageClassPolicy("")
+	require.NoError(t, err)
+	assert.Empty(t, policy)
+
+	policy, err = ParseStorageClassPolicy("*/index_files/*:STANDARD, */delta_log/*:standard_ia:720h,*/delta_log/*:GLACIER_IR:2160h")
+	require.NoError(t, err)
+	require.Len(t, policy, 3)
+	assert.Equal(t, "*/index_files/*", policy[0].Pattern)
+	assert.Equal(t, StorageClassStandard, policy[0].StorageClass)
+	assert.Equal(t, time.Duration(0), policy[0].MinAge)
+	assert.Equal(t, StorageClassStandardIA, policy[1].StorageClass)
+	assert.Equal(t, 720*time.Hour, policy[1].MinAge)
+	assert.True(t, policy.hasAgedRules())
+
+	for _, value := range []string{"*/delta_log/*", ":STANDARD", "*/delta_log/*:", "*/delta_log/*:STANDARD_IA:30d", "a:b:1h:c", "a:b:-1h"} {
+		_, err = ParseStorageClassPolicy(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestStorageClassPolicy(t *testing.T) {
+	policy, err := ParseStorageClassPolicy("*/index_files/*:STANDARD,*/delta_log/*:STANDARD_IA:720h,*/delta_log/*:GLACIER_IR:2160h,*/delta_log/*:GLACIER_IR:720h")
+	require.NoError(t, err)
+
+	assert.Equal(t, StorageClassStandard, policy.StorageClass("files/index_files/1/2/3/4/5/index", 0))
+	assert.Equal(t, StorageClassStandard, policy.StorageClass("files/index_files/1/2/3/4/5/index", 5000*time.Hour))
+	assert.Equal(t, "", policy.StorageClass("files/delta_log/1/2/3/4", 0))
+	// the first one wins among the rules of the same age
+	assert.Equal(t, StorageClassStandardIA, policy.StorageClass("files/delta_log/1/2/3/4", 720*time.Hour))
+	assert.Equal(t, StorageClassGlacierIR, policy.StorageClass("files/delta_log/1/2/3/4", 3000*time.Hour))
+	assert.Equal(t, "", policy.StorageClass("files/insert_log/1/2/3/4/5", 3000*time.Hour))
+	// the pattern is anchored and the other regexp characters are literal
+	assert.Equal(t, "", policy.StorageClass("files/delta_log.bak", 3000*time.Hour))
+	assert.False(t, StorageClassPolicy(nil).hasAgedRules())
+
+	mcm := &MinioChunkManager{storageClass: StorageClassStandardIA, storageClassPolicy: policy}
+	assert.Equal(t, StorageClassStandard, mcm.storageClassFor("files/index_files/1", nil))
+	assert.Equal(t, StorageClassStandardIA, mcm.storageClassFor("files/insert_log/1", nil))
+	assert.Equal(t, StorageClassGlacierIR, mcm.storageClassFor("files/index_files/1", newWriteConfig(WithStorageClass(StorageClassGlacierIR))))
+}
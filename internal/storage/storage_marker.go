@@ -0,0 +1,68 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util/retry"
+	"go.uber.org/zap"
+)
+
+// StorageMarkerName is the name of the object under the root path, which is written through the default endpoint
+// and verified through the overridden endpoints, so a gateway serving another bucket is detected on start.
+const StorageMarkerName = "storage_marker"
+
+// checkStorageMarker writes the storage marker through the default endpoint if it doesn't exist,
+// or verifies it exists through an overridden endpoint.
+func checkStorageMarker(ctx context.Context, cm ChunkManager, c *config) error {
+	marker := path.Join(cm.RootPath(), StorageMarkerName)
+	if c.endpointOverridden {
+		// the marker may not be written yet if the node starts before the coordinators of a new cluster
+		err := retry.Do(ctx, func() error {
+			exist, err := cm.Exist(ctx, marker)
+			if err != nil {
+				return err
+			}
+			if !exist {
+				return fmt.Errorf("storage marker %s doesn't exist through the overridden endpoint %s, "+
+					"the endpoint must serve the bucket %s of the default endpoint", marker, c.address, c.bucketName)
+			}
+			return nil
+		}, retry.Attempts(CheckBucketRetryAttempts))
+		if err != nil {
+			return err
+		}
+		log.Info("storage marker is verified through the overridden endpoint", zap.String("address", c.address))
+		return nil
+	}
+
+	if c.readOnly {
+		return nil
+	}
+	exist, err := cm.Exist(ctx, marker)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return nil
+	}
+	return cm.Write(ctx, marker, []byte(path.Join(c.bucketName, cm.RootPath())))
+}
@@ -0,0 +1,442 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/mmap"
+)
+
+// ErrInvalidSubPath means a path given to a SubChunkManager is invalid or escapes its prefix.
+var ErrInvalidSubPath = errors.New("InvalidSubPath")
+
+func WrapErrInvalidSubPath(filePath string) error {
+	return fmt.Errorf("%w(path=%s)", ErrInvalidSubPath, filePath)
+}
+
+// SubChunkManager confines the operations of a ChunkManager to a prefix, like chroot.
+// Paths given to it are relative to the prefix, and paths returned by it are relative as well.
+type SubChunkManager struct {
+	cm     ChunkManager
+	prefix string
+	// root is kept at the head of the paths given and returned, see newRootedSubChunkManager
+	root string
+}
+
+var _ ChunkManager = (*SubChunkManager)(nil)
+var _ ConditionalReader = (*SubChunkManager)(nil)
+
+// NewSubChunkManager returns a ChunkManager whose operations are confined to @prefix of @cm.
+func NewSubChunkManager(cm ChunkManager, prefix string) (*SubChunkManager, error) {
+	cleaned, err := cleanSubPath(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if sub, ok := cm.(*SubChunkManager); ok && sub.root == "" {
+		return &SubChunkManager{cm: sub.cm, prefix: joinSubPath(sub.prefix, cleaned)}, nil
+	}
+	return &SubChunkManager{cm: cm, prefix: cleaned}, nil
+}
+
+// newRootedSubChunkManager returns a SubChunkManager confined to @prefix of @cm, whose paths are under @root
+// instead of relative to the prefix, e.g. "{root}/a" is mapped to "{prefix}/a" and back.
+// Paths out of @root are rejected with ErrInvalidSubPath.
+func newRootedSubChunkManager(cm ChunkManager, prefix string, root string) (*SubChunkManager, error) {
+	sub, err := NewSubChunkManager(cm, prefix)
+	if err != nil {
+		return nil, err
+	}
+	sub.root, err = cleanSubPath(root)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// cleanSubPath cleans @filePath as a path relative to the sub root,
+// paths climbing above the root with ".." are rejected.
+func cleanSubPath(filePath string) (string, error) {
+	if strings.ContainsRune(filePath, 0) || strings.Contains(filePath, "\\") {
+		return "", WrapErrInvalidSubPath(filePath)
+	}
+	cleaned := path.Clean(strings.TrimLeft(filePath, "/"))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", WrapErrInvalidSubPath(filePath)
+	}
+	if cleaned == "." {
+		return "", nil
+	}
+	return cleaned, nil
+}
+
+func joinSubPath(prefix, filePath string) string {
+	if prefix == "" {
+		return filePath
+	}
+	if filePath == "" {
+		return prefix
+	}
+	return prefix + "/" + filePath
+}
+
+// subPath cleans @filePath and strips the root from it.
+func (sm *SubChunkManager) subPath(filePath string) (string, error) {
+	cleaned, err := cleanSubPath(filePath)
+	if err != nil || sm.root == "" {
+		return cleaned, err
+	}
+	if cleaned == sm.root {
+		return "", nil
+	}
+	if !strings.HasPrefix(cleaned, sm.root+"/") {
+		return "", WrapErrInvalidSubPath(filePath)
+	}
+	return strings.TrimPrefix(cleaned, sm.root+"/"), nil
+}
+
+// fullPath converts @filePath relative to the prefix to the path of the underlying chunk manager.
+func (sm *SubChunkManager) fullPath(filePath string) (string, error) {
+	cleaned, err := sm.subPath(filePath)
+	if err != nil {
+		return "", err
+	}
+	if cleaned == "" {
+		return "", WrapErrInvalidSubPath(filePath)
+	}
+	return joinSubPath(sm.prefix, cleaned), nil
+}
+
+// fullPrefix converts a listing prefix, keeping the trailing slash which matters for prefix matching.
+func (sm *SubChunkManager) fullPrefix(prefix string) (string, error) {
+	cleaned, err := sm.subPath(prefix)
+	if err != nil {
+		return "", err
+	}
+	full := joinSubPath(sm.prefix, cleaned)
+	if full != "" && (cleaned == "" || strings.HasSuffix(prefix, "/")) {
+		full += "/"
+	}
+	return full, nil
+}
+
+// relPath converts a path returned by the underlying chunk manager to the path relative to the prefix,
+// false is returned if it's out of the prefix.
+func (sm *SubChunkManager) relPath(filePath string) (string, bool) {
+	filePath = strings.TrimLeft(filePath, "/")
+	if sm.prefix != "" {
+		if !strings.HasPrefix(filePath, sm.prefix+"/") {
+			return "", false
+		}
+		filePath = strings.TrimPrefix(filePath, sm.prefix+"/")
+	}
+	return joinSubPath(sm.root, filePath), true
+}
+
+func (sm *SubChunkManager) fullPaths(filePaths []string) ([]string, error) {
+	ret := make([]string, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		full, err := sm.fullPath(filePath)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, full)
+	}
+	return ret, nil
+}
+
+// Prefix returns the prefix of the underlying chunk manager the operations are confined to.
+func (sm *SubChunkManager) Prefix() string {
+	return sm.prefix
+}
+
+// RootPath returns the root of the paths, it's empty unless created by newRootedSubChunkManager,
+// as paths of SubChunkManager are relative to its prefix.
+func (sm *SubChunkManager) RootPath() string {
+	return sm.root
+}
+
+func (sm *SubChunkManager) Path(ctx context.Context, filePath string) (string, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return "", err
+	}
+	return sm.cm.Path(ctx, full)
+}
+
+func (sm *SubChunkManager) Size(ctx context.Context, filePath string) (int64, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return 0, err
+	}
+	return sm.cm.Size(ctx, full)
+}
+
+func (sm *SubChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := sm.cm.Stat(ctx, full)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if rel, ok := sm.relPath(info.FilePath); ok {
+		info.FilePath = rel
+	}
+	return info, nil
+}
+
+func (sm *SubChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	fulls, err := sm.fullPaths(filePaths)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := sm.cm.MultiStat(ctx, fulls)
+	if err != nil {
+		return nil, err
+	}
+	for i, info := range infos {
+		if info == nil {
+			continue
+		}
+		rel := *info
+		if p, ok := sm.relPath(rel.FilePath); ok {
+			rel.FilePath = p
+		}
+		infos[i] = &rel
+	}
+	return infos, nil
+}
+
+func (sm *SubChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return err
+	}
+	return sm.cm.Write(ctx, full, content)
+}
+
+func (sm *SubChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return err
+	}
+	return sm.cm.WriteWithOptions(ctx, full, content, opts...)
+}
+
+func (sm *SubChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return err
+	}
+	return sm.cm.WriteIfNotExist(ctx, full, content)
+}
+
+func (sm *SubChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	fullContents := make(map[string][]byte, len(contents))
+	for filePath, content := range contents {
+		full, err := sm.fullPath(filePath)
+		if err != nil {
+			return err
+		}
+		fullContents[full] = content
+	}
+	return sm.cm.MultiWrite(ctx, fullContents)
+}
+
+func (sm *SubChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return err
+	}
+	return sm.cm.Append(ctx, full, content)
+}
+
+func (sm *SubChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	src, err := sm.fullPath(srcFilePath)
+	if err != nil {
+		return err
+	}
+	dst, err := sm.fullPath(dstFilePath)
+	if err != nil {
+		return err
+	}
+	return sm.cm.Copy(ctx, src, dst)
+}
+
+func (sm *SubChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	src, err := sm.fullPath(srcFilePath)
+	if err != nil {
+		return err
+	}
+	dst, err := sm.fullPath(dstFilePath)
+	if err != nil {
+		return err
+	}
+	return sm.cm.Move(ctx, src, dst)
+}
+
+func (sm *SubChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return "", err
+	}
+	return sm.cm.PresignURL(ctx, full, method, expiry)
+}
+
+func (sm *SubChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return false, err
+	}
+	return sm.cm.Exist(ctx, full)
+}
+
+func (sm *SubChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return sm.cm.Read(ctx, full)
+}
+
+// ReadIfModified reads @filePath under the prefix unless its ETag is still @etag, see ConditionalReader.
+func (sm *SubChunkManager) ReadIfModified(ctx context.Context, filePath string, etag string) ([]byte, string, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	return ReadIfModified(ctx, sm.cm, full, etag)
+}
+
+func (sm *SubChunkManager) Reader(ctx context.Context, filePath string) (FileReader, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return sm.cm.Reader(ctx, full)
+}
+
+func (sm *SubChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
+	fulls, err := sm.fullPaths(filePaths)
+	if err != nil {
+		return nil, err
+	}
+	return sm.cm.MultiRead(ctx, fulls)
+}
+
+func (sm *SubChunkManager) ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error) {
+	var filePaths []string
+	var modTimes []time.Time
+	err := sm.WalkWithPrefix(ctx, prefix, recursive, func(chunkObjectInfo ChunkObjectInfo) bool {
+		filePaths = append(filePaths, chunkObjectInfo.FilePath)
+		modTimes = append(modTimes, chunkObjectInfo.ModifyTime)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, modTimes, nil
+}
+
+func (sm *SubChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	full, err := sm.fullPrefix(prefix)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sm.cm.PrefixSize(ctx, full)
+}
+
+func (sm *SubChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	full, err := sm.fullPrefix(prefix)
+	if err != nil {
+		return err
+	}
+	return sm.cm.WalkWithPrefix(ctx, full, recursive, func(chunkObjectInfo ChunkObjectInfo) bool {
+		rel, ok := sm.relPath(chunkObjectInfo.FilePath)
+		if !ok {
+			return true
+		}
+		chunkObjectInfo.FilePath = rel
+		return walkFunc(chunkObjectInfo)
+	})
+}
+
+func (sm *SubChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
+	filePaths, _, err := sm.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := sm.MultiRead(ctx, filePaths)
+	return filePaths, result, err
+}
+
+func (sm *SubChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return sm.cm.Mmap(ctx, full)
+}
+
+func (sm *SubChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return sm.cm.ReadAt(ctx, full, off, length)
+}
+
+func (sm *SubChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return sm.cm.MultiReadAt(ctx, full, ranges)
+}
+
+func (sm *SubChunkManager) Remove(ctx context.Context, filePath string) error {
+	full, err := sm.fullPath(filePath)
+	if err != nil {
+		return err
+	}
+	return sm.cm.Remove(ctx, full)
+}
+
+func (sm *SubChunkManager) MultiRemove(ctx context.Context, filePaths []string) error {
+	fulls, err := sm.fullPaths(filePaths)
+	if err != nil {
+		return err
+	}
+	return sm.cm.MultiRemove(ctx, fulls)
+}
+
+// RemoveWithPrefix removes the objects under @prefix, only the ones listed within the sub root are removed,
+// as the underlying chunk manager may match objects sharing the same string prefix out of it.
+func (sm *SubChunkManager) RemoveWithPrefix(ctx context.Context, prefix string) error {
+	filePaths, _, err := sm.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return err
+	}
+	return sm.MultiRemove(ctx, filePaths)
+}
@@ -0,0 +1,123 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanSubPath(t *testing.T) {
+	cases := []struct {
+		in   string
+		out  string
+		fail bool
+	}{
+		{in: "", out: ""},
+		{in: "/", out: ""},
+		{in: "a/b", out: "a/b"},
+		{in: "/a//b/", out: "a/b"},
+		{in: "a/./b/../c", out: "a/c"},
+		{in: "a/..", out: ""},
+		{in: "..", fail: true},
+		{in: "../a", fail: true},
+		{in: "a/../../b", fail: true},
+		{in: "/../a", fail: true},
+		{in: "a\\..\\b", fail: true},
+		{in: "a\x00b", fail: true},
+	}
+	for _, c := range cases {
+		out, err := cleanSubPath(c.in)
+		if c.fail {
+			assert.ErrorIs(t, err, ErrInvalidSubPath, c.in)
+			continue
+		}
+		assert.NoError(t, err, c.in)
+		assert.Equal(t, c.out, out, c.in)
+	}
+}
+
+func TestSubChunkManager(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	cm := NewLocalChunkManager(RootPath(root))
+
+	_, err := NewSubChunkManager(cm, "../escape")
+	assert.ErrorIs(t, err, ErrInvalidSubPath)
+
+	sub, err := NewSubChunkManager(cm, "collection/1")
+	require.NoError(t, err)
+	assert.Equal(t, "collection/1", sub.Prefix())
+	assert.Equal(t, "", sub.RootPath())
+
+	require.NoError(t, sub.Write(ctx, "segment/1/a", []byte("a")))
+	require.NoError(t, sub.MultiWrite(ctx, map[string][]byte{"segment/1/b": []byte("b"), "segment/2/c": []byte("c")}))
+	// a sibling sharing the same string prefix must be invisible to the sub chunk manager
+	require.NoError(t, cm.Write(ctx, "collection/10/segment/1/x", []byte("x")))
+
+	content, err := cm.Read(ctx, "collection/1/segment/1/a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a"), content)
+
+	content, err = sub.Read(ctx, "/segment/1/../1/b")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("b"), content)
+
+	_, err = sub.Read(ctx, "../10/segment/1/x")
+	assert.ErrorIs(t, err, ErrInvalidSubPath)
+	assert.ErrorIs(t, sub.Write(ctx, "segment/../../../x", []byte("x")), ErrInvalidSubPath)
+	assert.ErrorIs(t, sub.Write(ctx, "", []byte("x")), ErrInvalidSubPath)
+	assert.ErrorIs(t, sub.Copy(ctx, "segment/1/a", "../../x"), ErrInvalidSubPath)
+
+	info, err := sub.Stat(ctx, "segment/1/a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), info.Size)
+
+	filePaths, _, err := sub.ListWithPrefix(ctx, "", true)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"segment/1/a", "segment/1/b", "segment/2/c"}, filePaths)
+
+	filePaths, contents, err := sub.ReadWithPrefix(ctx, "segment/1/")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"segment/1/a", "segment/1/b"}, filePaths)
+	assert.Len(t, contents, 2)
+
+	// nested sub chunk manager stacks the prefixes
+	nested, err := NewSubChunkManager(sub, "segment/2")
+	require.NoError(t, err)
+	assert.Equal(t, "collection/1/segment/2", nested.Prefix())
+	exist, err := nested.Exist(ctx, "c")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	require.NoError(t, sub.Copy(ctx, "segment/1/a", "segment/3/a"))
+	require.NoError(t, sub.Move(ctx, "segment/3/a", "segment/3/b"))
+	exist, err = sub.Exist(ctx, "segment/3/b")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	require.NoError(t, sub.RemoveWithPrefix(ctx, ""))
+	filePaths, _, err = sub.ListWithPrefix(ctx, "", true)
+	assert.NoError(t, err)
+	assert.Empty(t, filePaths)
+	exist, err = cm.Exist(ctx, "collection/10/segment/1/x")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+}
@@ -0,0 +1,229 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// tenantsDir is the directory under the root path holding the sub-roots of the tenants.
+	tenantsDir = "tenants"
+	// tenantUsageRefreshInterval is the interval to recount the usage of a tenant by PrefixSize,
+	// the bytes written in between are added to the last count.
+	tenantUsageRefreshInterval = 30 * time.Second
+)
+
+// ErrTenantQuotaExceeded means the write is rejected since the tenant uses up its quota.
+var ErrTenantQuotaExceeded = errors.New("TenantQuotaExceeded")
+
+func WrapErrTenantQuotaExceeded(tenant string, used int64, quota int64) error {
+	return fmt.Errorf("%w(tenant=%s, used=%d, quota=%d)", ErrTenantQuotaExceeded, tenant, used, quota)
+}
+
+// TenantChunkManager isolates the objects of a tenant, e.g. a database, under the sub-root "tenants/{tenant}"
+// of the root path. The keys are given and returned as if the tenant owns the whole root path, and they are
+// rewritten into the sub-root by SubChunkManager, so a tenant could never address the objects of the others.
+// The keys out of the root path are rejected with ErrInvalidSubPath.
+//
+// The writes are rejected with ErrTenantQuotaExceeded once the bytes of the sub-root reach the quota of the tenant.
+// The usage is counted by PrefixSize at most every tenantUsageRefreshInterval, and the bytes reserved since the
+// count started are added to it, the removals are only reflected by the next count. The count runs outside the lock,
+// so the writes only wait for it before the first count of the tenant.
+type TenantChunkManager struct {
+	*SubChunkManager
+	tenant string
+	quota  int64
+
+	mu sync.Mutex
+	// counted is the bytes of the last count, started at countedAt when reserved was countedReserved
+	counted         int64
+	countedReserved int64
+	countedAt       time.Time
+	// reserved is the total bytes reserved by the writes
+	reserved int64
+	// counting is closed when the running count finishes, nil if no count is running
+	counting chan struct{}
+}
+
+var _ ChunkManager = (*TenantChunkManager)(nil)
+var _ ConditionalReader = (*TenantChunkManager)(nil)
+
+// NewTenantChunkManager returns the view of @tenant on @cm, the root path of the keys and the quotas
+// are set by the RootPath and TenantQuotas options.
+func NewTenantChunkManager(cm ChunkManager, tenant string, opts ...Option) (*TenantChunkManager, error) {
+	if tenant == "" || tenant == "." || tenant == ".." || strings.Contains(tenant, "/") {
+		return nil, fmt.Errorf("invalid tenant name %q", tenant)
+	}
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	quota, ok := c.tenantQuotas[tenant]
+	if !ok {
+		quota = c.tenantDefaultQuota
+	}
+	rootPath := strings.Trim(c.rootPath, "/")
+	sub, err := newRootedSubChunkManager(cm, joinRootPath(rootPath, tenantsDir+"/"+tenant), rootPath)
+	if err != nil {
+		return nil, err
+	}
+	return &TenantChunkManager{
+		SubChunkManager: sub,
+		tenant:          tenant,
+		quota:           quota,
+	}, nil
+}
+
+// Tenant returns the name of the tenant.
+func (t *TenantChunkManager) Tenant() string {
+	return t.tenant
+}
+
+// Usage returns the bytes and the number of the objects of the tenant.
+func (t *TenantChunkManager) Usage(ctx context.Context) (int64, int64, error) {
+	return t.count(ctx)
+}
+
+// count counts the usage of the tenant by PrefixSize without holding the lock,
+// the result is kept unless a count started later has been kept already.
+func (t *TenantChunkManager) count(ctx context.Context) (int64, int64, error) {
+	t.mu.Lock()
+	startAt, startReserved := time.Now(), t.reserved
+	t.mu.Unlock()
+
+	bytes, objects, err := t.SubChunkManager.PrefixSize(ctx, t.RootPath())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if startAt.After(t.countedAt) {
+		t.counted, t.countedReserved, t.countedAt = bytes, startReserved, startAt
+	}
+	return bytes, objects, nil
+}
+
+// used returns the bytes of the last count plus the bytes reserved since it started, the caller must hold the lock.
+func (t *TenantChunkManager) used() int64 {
+	return t.counted + t.reserved - t.countedReserved
+}
+
+// checkQuota reserves @size bytes of the quota for a write, or returns an error wrapping ErrTenantQuotaExceeded.
+// The bytes of failed writes stay reserved until the next count.
+func (t *TenantChunkManager) checkQuota(ctx context.Context, size int64) error {
+	if t.quota <= 0 {
+		return nil
+	}
+	for {
+		t.mu.Lock()
+		if t.counting == nil && time.Since(t.countedAt) > tenantUsageRefreshInterval {
+			counting := make(chan struct{})
+			t.counting = counting
+			t.mu.Unlock()
+
+			_, _, err := t.count(ctx)
+
+			t.mu.Lock()
+			t.counting = nil
+			t.mu.Unlock()
+			close(counting)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if t.countedAt.IsZero() {
+			// wait for the first count, nothing is known about the usage before it
+			counting := t.counting
+			t.mu.Unlock()
+			select {
+			case <-counting:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		used := t.used()
+		if used+size > t.quota {
+			t.mu.Unlock()
+			return WrapErrTenantQuotaExceeded(t.tenant, used, t.quota)
+		}
+		t.reserved += size
+		t.mu.Unlock()
+		return nil
+	}
+}
+
+func (t *TenantChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
+	if err := t.checkQuota(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	return t.SubChunkManager.Write(ctx, filePath, content)
+}
+
+func (t *TenantChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	if err := t.checkQuota(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	return t.SubChunkManager.WriteWithOptions(ctx, filePath, content, opts...)
+}
+
+func (t *TenantChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	if err := t.checkQuota(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	return t.SubChunkManager.WriteIfNotExist(ctx, filePath, content)
+}
+
+func (t *TenantChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
+	var size int64
+	for _, content := range contents {
+		size += int64(len(content))
+	}
+	if err := t.checkQuota(ctx, size); err != nil {
+		return err
+	}
+	return t.SubChunkManager.MultiWrite(ctx, contents)
+}
+
+func (t *TenantChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	if err := t.checkQuota(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	return t.SubChunkManager.Append(ctx, filePath, content)
+}
+
+func (t *TenantChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	if t.quota > 0 {
+		size, err := t.SubChunkManager.Size(ctx, srcFilePath)
+		if err != nil {
+			return err
+		}
+		if err := t.checkQuota(ctx, size); err != nil {
+			return err
+		}
+	}
+	return t.SubChunkManager.Copy(ctx, srcFilePath, dstFilePath)
+}
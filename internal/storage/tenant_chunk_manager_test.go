@@ -0,0 +1,153 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantChunkManager(t *testing.T) {
+	ctx := context.Background()
+	cm := NewMemoryChunkManager(RootPath("files"))
+
+	_, err := NewTenantChunkManager(cm, "a/b", RootPath("files"))
+	assert.Error(t, err)
+
+	db1, err := NewTenantChunkManager(cm, "db1", RootPath("files"), TenantQuotas(0, map[string]int64{"db10": 4}))
+	require.NoError(t, err)
+	db10, err := NewTenantChunkManager(cm, "db10", RootPath("files"), TenantQuotas(0, map[string]int64{"db10": 4}))
+	require.NoError(t, err)
+
+	require.NoError(t, db1.Write(ctx, "files/insert_log/1", []byte("1")))
+	require.NoError(t, db10.Write(ctx, "files/insert_log/1", []byte("10")))
+
+	// the keys are rewritten into the sub-roots
+	content, err := cm.Read(ctx, "files/tenants/db1/insert_log/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), content)
+	content, err = db10.Read(ctx, "files/insert_log/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("10"), content)
+
+	keys, _, err := db1.ListWithPrefix(ctx, "files", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"files/insert_log/1"}, keys)
+	keys, _, err = db1.ListWithPrefix(ctx, "files/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"files/insert_log/"}, keys)
+	info, err := db1.Stat(ctx, "files/insert_log/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "files/insert_log/1", info.FilePath)
+	bytes, objects, err := db1.Usage(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, bytes)
+	assert.EqualValues(t, 1, objects)
+
+	// no cross-tenant access
+	for _, key := range []string{"other/1", "files/../other/1", "../files/insert_log/1", "files/../../files/insert_log/1"} {
+		_, err = db1.Read(ctx, key)
+		assert.ErrorIs(t, err, ErrInvalidSubPath, key)
+	}
+	content, err = db1.Read(ctx, "files/./insert_log/../insert_log/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), content)
+	_, err = db1.Read(ctx, "files/tenants/db10/insert_log/1")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+
+	require.NoError(t, db1.RemoveWithPrefix(ctx, "files"))
+	exist, err := db10.Exist(ctx, "files/insert_log/1")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	// quota
+	require.NoError(t, db10.Append(ctx, "files/insert_log/1", []byte("0")))
+	err = db10.Write(ctx, "files/insert_log/2", []byte("22"))
+	assert.ErrorIs(t, err, ErrTenantQuotaExceeded)
+	err = db10.Copy(ctx, "files/insert_log/1", "files/insert_log/2")
+	assert.ErrorIs(t, err, ErrTenantQuotaExceeded)
+	require.NoError(t, db10.Write(ctx, "files/insert_log/2", []byte("2")))
+	// the removal is reflected by the next count
+	require.NoError(t, db10.Remove(ctx, "files/insert_log/1"))
+	_, _, err = db10.Usage(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, db10.MultiWrite(ctx, map[string][]byte{"files/insert_log/3": []byte("333")}))
+}
+
+func TestTenantChunkManagerConcurrentWriters(t *testing.T) {
+	ctx := context.Background()
+	fm := NewFaultInjectionChunkManager(NewMemoryChunkManager(RootPath("files")), 0)
+	fm.SetFault(FaultOpList, &Fault{Latency: 200 * time.Millisecond})
+	tenant, err := NewTenantChunkManager(fm, "db1", RootPath("files"), TenantQuotas(100, nil))
+	require.NoError(t, err)
+
+	write := func(writers int) int {
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			written int
+		)
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				err := tenant.Write(ctx, fmt.Sprintf("files/insert_log/%d-%d", writers, i), make([]byte, 10))
+				if err == nil {
+					mu.Lock()
+					written++
+					mu.Unlock()
+				} else {
+					assert.ErrorIs(t, err, ErrTenantQuotaExceeded)
+				}
+			}(i)
+		}
+		wg.Wait()
+		return written
+	}
+
+	// the writers wait for the first count, then share the quota
+	assert.Equal(t, 5, write(5))
+	assert.Equal(t, 5, write(20))
+	bytes, _, err := tenant.Usage(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, bytes)
+	require.NoError(t, tenant.RemoveWithPrefix(ctx, "files"))
+
+	// the writers are not blocked by a stale count running in the background
+	tenant.mu.Lock()
+	tenant.countedAt = time.Now().Add(-time.Hour)
+	tenant.mu.Unlock()
+	go tenant.Write(ctx, "files/insert_log/trigger", nil)
+	assert.Eventually(t, func() bool {
+		tenant.mu.Lock()
+		defer tenant.mu.Unlock()
+		return tenant.counting != nil
+	}, time.Second, time.Millisecond)
+	start := time.Now()
+	// the removal is not reflected before the count finishes
+	assert.Equal(t, 0, write(4))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return write(4) == 4
+	}, time.Second, 10*time.Millisecond)
+}
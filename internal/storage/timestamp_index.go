@@ -0,0 +1,229 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/milvus-io/milvus/internal/common"
+)
+
+const (
+	timestampIndexMagic   uint32 = 0x58445354 // "TSDX"
+	timestampIndexVersion uint32 = 1
+
+	// TimestampIndexMinSliceLength is the min rows of a slice in the timestamp index of a large column,
+	// which is the same as the one used by segcore.
+	TimestampIndexMinSliceLength = 4096
+)
+
+// TimestampIndex is the compact index of the timestamp column of a segment. The rows are split into slices in row order,
+// and the timestamps of a slice are no less than the ones of the slices before it, that is a sorted run of slices.
+// So the rows visible at a timestamp or expired before a timestamp are found by a binary search on the slices,
+// only the rows of the slice containing the timestamp need to be scanned.
+type TimestampIndex struct {
+	// Lengths, MinTimestamps and MaxTimestamps of the slices in row order
+	Lengths       []int64
+	MinTimestamps []Timestamp
+	MaxTimestamps []Timestamp
+}
+
+// BuildTimestampIndex builds the timestamp index of @timestamps, a slice is cut wherever the timestamps before it
+// are no greater than the ones after it, and it has TimestampIndexMinSliceLength rows at least if the column is large.
+func BuildTimestampIndex(timestamps []int64) *TimestampIndex {
+	index := &TimestampIndex{}
+	size := len(timestamps)
+	if size == 0 {
+		return index
+	}
+	minSliceLength := TimestampIndexMinSliceLength
+	if size < TimestampIndexMinSliceLength {
+		minSliceLength = 1
+	}
+
+	// suffixMins[i] is the min timestamp of the rows from i
+	suffixMins := make([]Timestamp, size)
+	suffixMins[size-1] = Timestamp(timestamps[size-1])
+	for i := size - 2; i >= 0; i-- {
+		suffixMins[i] = suffixMins[i+1]
+		if ts := Timestamp(timestamps[i]); ts < suffixMins[i] {
+			suffixMins[i] = ts
+		}
+	}
+
+	var prefixMax Timestamp
+	var sliceLength int64
+	var sliceMin, sliceMax Timestamp
+	for i, v := range timestamps {
+		ts := Timestamp(v)
+		if i > 0 && prefixMax <= suffixMins[i] && sliceLength >= int64(minSliceLength) {
+			index.append(sliceLength, sliceMin, sliceMax)
+			sliceLength = 0
+		}
+		if sliceLength == 0 || ts < sliceMin {
+			sliceMin = ts
+		}
+		if sliceLength == 0 || ts > sliceMax {
+			sliceMax = ts
+		}
+		if ts > prefixMax {
+			prefixMax = ts
+		}
+		sliceLength++
+	}
+	index.append(sliceLength, sliceMin, sliceMax)
+	return index
+}
+
+func (index *TimestampIndex) append(length int64, min Timestamp, max Timestamp) {
+	index.Lengths = append(index.Lengths, length)
+	index.MinTimestamps = append(index.MinTimestamps, min)
+	index.MaxTimestamps = append(index.MaxTimestamps, max)
+}
+
+// MergeTimestampIndexes concatenates the timestamp indexes of the binlogs of a segment in row order,
+// the adjacent slices overlapping in time are merged into one to keep the slices sorted.
+func MergeTimestampIndexes(parts []*TimestampIndex) *TimestampIndex {
+	merged := &TimestampIndex{}
+	for _, part := range parts {
+		for i := range part.Lengths {
+			length, min, max := part.Lengths[i], part.MinTimestamps[i], part.MaxTimestamps[i]
+			for n := len(merged.Lengths); n > 0 && merged.MaxTimestamps[n-1] > min; n-- {
+				length += merged.Lengths[n-1]
+				if merged.MinTimestamps[n-1] < min {
+					min = merged.MinTimestamps[n-1]
+				}
+				if merged.MaxTimestamps[n-1] > max {
+					max = merged.MaxTimestamps[n-1]
+				}
+				merged.Lengths = merged.Lengths[:n-1]
+				merged.MinTimestamps = merged.MinTimestamps[:n-1]
+				merged.MaxTimestamps = merged.MaxTimestamps[:n-1]
+			}
+			merged.append(length, min, max)
+		}
+	}
+	return merged
+}
+
+// RowNum returns the number of rows indexed.
+func (index *TimestampIndex) RowNum() int64 {
+	var rowNum int64
+	for _, length := range index.Lengths {
+		rowNum += length
+	}
+	return rowNum
+}
+
+// MinTimestamp returns the min timestamp of the rows, 0 if there is no row.
+func (index *TimestampIndex) MinTimestamp() Timestamp {
+	if len(index.MinTimestamps) == 0 {
+		return 0
+	}
+	return index.MinTimestamps[0]
+}
+
+// MaxTimestamp returns the max timestamp of the rows, 0 if there is no row.
+func (index *TimestampIndex) MaxTimestamp() Timestamp {
+	if len(index.MaxTimestamps) == 0 {
+		return 0
+	}
+	return index.MaxTimestamps[len(index.MaxTimestamps)-1]
+}
+
+// ActiveRange returns the range [beg, end) of rows to scan for the rows visible at @ts,
+// the rows before beg are all visible, and the rows from end are all invisible.
+func (index *TimestampIndex) ActiveRange(ts Timestamp) (int64, int64) {
+	rowNum := index.RowNum()
+	if rowNum == 0 || ts >= index.MaxTimestamp() {
+		return rowNum, rowNum
+	}
+	if ts < index.MinTimestamp() {
+		return 0, 0
+	}
+	slice := sort.Search(len(index.MinTimestamps), func(i int) bool {
+		return index.MinTimestamps[i] > ts
+	}) - 1
+	var beg int64
+	for _, length := range index.Lengths[:slice] {
+		beg += length
+	}
+	return beg, beg + index.Lengths[slice]
+}
+
+// TimestampIndexCodec serializes and deserializes the timestamp index.
+// The layout is: magic | version | slice number | lengths | min timestamps | max timestamps.
+type TimestampIndexCodec struct{}
+
+// NewTimestampIndexCodec returns a TimestampIndexCodec.
+func NewTimestampIndexCodec() *TimestampIndexCodec {
+	return &TimestampIndexCodec{}
+}
+
+// Serialize transfers the timestamp index to blob.
+func (codec *TimestampIndexCodec) Serialize(index *TimestampIndex) (*Blob, error) {
+	buf := new(bytes.Buffer)
+	for _, v := range []interface{}{timestampIndexMagic, timestampIndexVersion, int64(len(index.Lengths)),
+		index.Lengths, index.MinTimestamps, index.MaxTimestamps} {
+		if err := binary.Write(buf, common.Endian, v); err != nil {
+			return nil, err
+		}
+	}
+	return &Blob{Value: buf.Bytes()}, nil
+}
+
+// Deserialize transfers the blob back to the timestamp index, the slices must be sorted.
+func (codec *TimestampIndexCodec) Deserialize(blob *Blob) (*TimestampIndex, error) {
+	reader := bytes.NewReader(blob.Value)
+	var magic, version uint32
+	var num int64
+	for _, v := range []interface{}{&magic, &version, &num} {
+		if err := binary.Read(reader, common.Endian, v); err != nil {
+			return nil, fmt.Errorf("failed to read timestamp index header, key: %s, err: %w", blob.Key, err)
+		}
+	}
+	if magic != timestampIndexMagic {
+		return nil, fmt.Errorf("invalid timestamp index, key: %s", blob.Key)
+	}
+	if version != timestampIndexVersion {
+		return nil, fmt.Errorf("unsupported timestamp index version %d, key: %s", version, blob.Key)
+	}
+	if num < 0 || num*24 != int64(reader.Len()) {
+		return nil, fmt.Errorf("invalid slice number %d of timestamp index, key: %s", num, blob.Key)
+	}
+
+	index := &TimestampIndex{
+		Lengths:       make([]int64, num),
+		MinTimestamps: make([]Timestamp, num),
+		MaxTimestamps: make([]Timestamp, num),
+	}
+	for _, v := range []interface{}{index.Lengths, index.MinTimestamps, index.MaxTimestamps} {
+		if err := binary.Read(reader, common.Endian, v); err != nil {
+			return nil, err
+		}
+	}
+	for i := range index.Lengths {
+		if index.Lengths[i] <= 0 || index.MinTimestamps[i] > index.MaxTimestamps[i] ||
+			(i > 0 && index.MaxTimestamps[i-1] > index.MinTimestamps[i]) {
+			return nil, fmt.Errorf("timestamp index slice %d is not sorted, key: %s", i, blob.Key)
+		}
+	}
+	return index, nil
+}
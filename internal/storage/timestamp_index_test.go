@@ -0,0 +1,100 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestampIndex(t *testing.T) {
+	index := BuildTimestampIndex([]int64{1, 2, 4, 3, 5, 7, 6, 8})
+	assert.Equal(t, []int64{1, 1, 2, 1, 2, 1}, index.Lengths)
+	assert.Equal(t, []Timestamp{1, 2, 3, 5, 6, 8}, index.MinTimestamps)
+	assert.Equal(t, []Timestamp{1, 2, 4, 5, 7, 8}, index.MaxTimestamps)
+	assert.Equal(t, int64(8), index.RowNum())
+	assert.Equal(t, Timestamp(1), index.MinTimestamp())
+	assert.Equal(t, Timestamp(8), index.MaxTimestamp())
+
+	cases := []struct {
+		ts       Timestamp
+		beg, end int64
+	}{
+		{ts: 0, beg: 0, end: 0},
+		{ts: 3, beg: 2, end: 4},
+		{ts: 5, beg: 4, end: 5},
+		{ts: 6, beg: 5, end: 7},
+		{ts: 8, beg: 8, end: 8},
+		{ts: 10, beg: 8, end: 8},
+	}
+	for _, c := range cases {
+		beg, end := index.ActiveRange(c.ts)
+		assert.Equal(t, c.beg, beg, c.ts)
+		assert.Equal(t, c.end, end, c.ts)
+	}
+
+	empty := BuildTimestampIndex(nil)
+	assert.Equal(t, int64(0), empty.RowNum())
+	beg, end := empty.ActiveRange(1)
+	assert.Equal(t, int64(0), beg)
+	assert.Equal(t, int64(0), end)
+
+	// large columns are cut into slices of TimestampIndexMinSliceLength rows at least
+	timestamps := make([]int64, TimestampIndexMinSliceLength*3)
+	for i := range timestamps {
+		timestamps[i] = int64(i)
+	}
+	index = BuildTimestampIndex(timestamps)
+	assert.Equal(t, []int64{TimestampIndexMinSliceLength, TimestampIndexMinSliceLength, TimestampIndexMinSliceLength}, index.Lengths)
+}
+
+func TestMergeTimestampIndexes(t *testing.T) {
+	merged := MergeTimestampIndexes([]*TimestampIndex{
+		BuildTimestampIndex([]int64{1, 2, 3}),
+		BuildTimestampIndex([]int64{5, 6}),
+		// overlaps with the slices after 2
+		BuildTimestampIndex([]int64{2, 7}),
+	})
+	assert.Equal(t, []int64{1, 1, 4, 1}, merged.Lengths)
+	assert.Equal(t, []Timestamp{1, 2, 2, 7}, merged.MinTimestamps)
+	assert.Equal(t, []Timestamp{1, 2, 6, 7}, merged.MaxTimestamps)
+	assert.Equal(t, int64(7), merged.RowNum())
+}
+
+func TestTimestampIndexCodec(t *testing.T) {
+	codec := NewTimestampIndexCodec()
+	index := BuildTimestampIndex([]int64{3, 1, 2, 5, 4})
+	blob, err := codec.Serialize(index)
+	require.NoError(t, err)
+
+	decoded, err := codec.Deserialize(blob)
+	assert.NoError(t, err)
+	assert.Equal(t, index, decoded)
+
+	_, err = codec.Deserialize(&Blob{Value: blob.Value[:len(blob.Value)-1]})
+	assert.Error(t, err)
+	_, err = codec.Deserialize(&Blob{Value: []byte("invalid timestamp index")})
+	assert.Error(t, err)
+
+	unsorted := &TimestampIndex{Lengths: []int64{1, 1}, MinTimestamps: []Timestamp{2, 1}, MaxTimestamps: []Timestamp{2, 1}}
+	blob, err = codec.Serialize(unsorted)
+	require.NoError(t, err)
+	_, err = codec.Deserialize(blob)
+	assert.Error(t, err)
+}
@@ -14,6 +14,7 @@ package storage
 import (
 	"context"
 	"io"
+	"strings"
 	"time"
 
 	"golang.org/x/exp/mmap"
@@ -24,6 +25,43 @@ type FileReader interface {
 	io.Closer
 }
 
+// ChunkObjectInfo is the info of an object visited by WalkWithPrefix.
+type ChunkObjectInfo struct {
+	// FilePath ends with "/" if it's a common prefix listed by the non-recursive walks, see IsCommonPrefix
+	FilePath string
+	// ModifyTime is zero for the common prefixes of the object storages, which have no modify time
+	ModifyTime time.Time
+	// Size is zero for the common prefixes
+	Size int64
+	// UserMetadata and Tags are only filled if the chunk manager supports them and is configured to list them.
+	UserMetadata map[string]string
+	Tags         map[string]string
+}
+
+// IsCommonPrefix returns true if @filePath listed by the non-recursive ListWithPrefix or WalkWithPrefix
+// is a common prefix, rather than an object.
+func IsCommonPrefix(filePath string) bool {
+	return strings.HasSuffix(filePath, "/")
+}
+
+// Range is a byte range of an object, which starts at @Offset and has @Length bytes.
+type Range struct {
+	Offset int64
+	Length int64
+}
+
+// ObjectInfo is the info of an object returned by Stat.
+type ObjectInfo struct {
+	FilePath   string
+	Size       int64
+	ModifyTime time.Time
+	// ETag changes whenever the object is rewritten
+	ETag string
+}
+
+// ChunkObjectWalkFunc is called for every object visited by WalkWithPrefix, the walk stops if it returns false.
+type ChunkObjectWalkFunc func(chunkObjectInfo ChunkObjectInfo) bool
+
 // ChunkManager is to manager chunks.
 // Include Read, Write, Remove chunks.
 type ChunkManager interface {
@@ -33,10 +71,29 @@ type ChunkManager interface {
 	Path(ctx context.Context, filePath string) (string, error)
 	// Size returns path of @filePath.
 	Size(ctx context.Context, filePath string) (int64, error)
+	// Stat returns the size, modify time and ETag of @filePath in one call,
+	// an error wrapping ErrNoSuchKey is returned if it doesn't exist.
+	Stat(ctx context.Context, filePath string) (ObjectInfo, error)
+	// MultiStat stats @filePaths in one call, the results keep the order of @filePaths,
+	// and the info of a path which doesn't exist is nil.
+	MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error)
 	// Write writes @content to @filePath.
 	Write(ctx context.Context, filePath string, content []byte) error
+	// WriteWithOptions writes @content to @filePath with the user metadata and tags in @opts.
+	WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error
+	// WriteIfNotExist writes @content to @filePath only if @filePath doesn't exist,
+	// an error wrapping ErrObjectExists is returned otherwise.
+	WriteIfNotExist(ctx context.Context, filePath string, content []byte) error
 	// MultiWrite writes multi @content to @filePath.
 	MultiWrite(ctx context.Context, contents map[string][]byte) error
+	// Append appends @content to the end of @filePath, @filePath is created if it doesn't exist.
+	Append(ctx context.Context, filePath string, content []byte) error
+	// Copy copies @srcFilePath to @dstFilePath, with a server-side copy if the storage supports it.
+	Copy(ctx context.Context, srcFilePath string, dstFilePath string) error
+	// Move moves @srcFilePath to @dstFilePath, with a rename if the storage supports it.
+	Move(ctx context.Context, srcFilePath string, dstFilePath string) error
+	// PresignURL returns a URL to access @filePath directly with http @method, which expires after @expiry.
+	PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error)
 	// Exist returns true if @filePath exists.
 	Exist(ctx context.Context, filePath string) (bool, error)
 	// Read reads @filePath and returns content.
@@ -45,7 +102,17 @@ type ChunkManager interface {
 	Reader(ctx context.Context, filePath string) (FileReader, error)
 	// MultiRead reads @filePath and returns content.
 	MultiRead(ctx context.Context, filePaths []string) ([][]byte, error)
+	// ListWithPrefix lists the objects with @prefix. If @recursive is false, only one level is listed like the
+	// listing with the "/" delimiter of S3: the objects without "/" after @prefix, and the common prefixes up to
+	// the next "/" including it, which are the "directories" to be listed level by level.
+	// See ListWithPattern to list the objects matching a glob pattern.
 	ListWithPrefix(ctx context.Context, prefix string, recursive bool) ([]string, []time.Time, error)
+	// WalkWithPrefix calls @walkFunc for every object with @prefix without holding all of them in memory,
+	// it stops walking if @walkFunc returns false. @recursive is the same as ListWithPrefix.
+	WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error
+	// PrefixSize returns the total bytes and the number of the objects with @prefix, listed recursively.
+	// The result could be cached for a while if the chunk manager is configured with PrefixSizeCacheTTL.
+	PrefixSize(ctx context.Context, prefix string) (bytes int64, objects int64, err error)
 	// ReadWithPrefix reads files with same @prefix and returns contents.
 	ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error)
 	Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error)
@@ -53,6 +120,9 @@ type ChunkManager interface {
 	// if all bytes are read, @err is io.EOF.
 	// return other error if read failed.
 	ReadAt(ctx context.Context, filePath string, off int64, length int64) (p []byte, err error)
+	// MultiReadAt reads the byte @ranges of @filePath, the results keep the order of @ranges.
+	// The adjacent or overlapping ranges are coalesced into one read, so their results may share memory.
+	MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error)
 	// Remove delete @filePath.
 	Remove(ctx context.Context, filePath string) error
 	// MultiRemove delete @filePaths.
@@ -60,3 +130,32 @@ type ChunkManager interface {
 	// RemoveWithPrefix remove files with same @prefix.
 	RemoveWithPrefix(ctx context.Context, prefix string) error
 }
+
+// ObjectVersion is a version of an object kept by a storage with versioning enabled.
+type ObjectVersion struct {
+	FilePath   string
+	VersionID  string
+	Size       int64
+	ModifyTime time.Time
+	IsLatest   bool
+	// IsDeleteMarker is true if the version is the marker left by a delete without version id
+	IsDeleteMarker bool
+}
+
+// VersionedChunkManager is implemented by the chunk managers whose storage can keep the old versions of objects,
+// so that objects removed or overwritten by accident can be recovered.
+type VersionedChunkManager interface {
+	ChunkManager
+	// IsVersioningEnabled returns true if the storage keeps the old versions of objects.
+	IsVersioningEnabled(ctx context.Context) (bool, error)
+	// ListVersionsWithPrefix lists all versions and delete markers of the objects with @prefix, newest first for each object.
+	ListVersionsWithPrefix(ctx context.Context, prefix string) ([]ObjectVersion, error)
+	// ReadVersion reads the content of @filePath at @versionID.
+	ReadVersion(ctx context.Context, filePath string, versionID string) ([]byte, error)
+	// RestoreVersion makes @versionID the latest version of @filePath.
+	RestoreVersion(ctx context.Context, filePath string, versionID string) error
+	// UndeleteWithPrefix removes the delete markers on top of the objects with @prefix, it returns the restored paths.
+	UndeleteWithPrefix(ctx context.Context, prefix string) ([]string, error)
+	// RemoveAllVersions removes all versions of @filePath permanently.
+	RemoveAllVersions(ctx context.Context, filePath string) error
+}
@@ -0,0 +1,43 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+// Unwrapper is implemented by the chunk managers wrapping another chunk manager with the same keys,
+// so that the capabilities of the wrapped one, e.g. VersionedChunkManager, are found by AsChunkManager.
+//
+// The wrappers changing the keys or spreading them over several chunk managers, e.g. ShardedChunkManager,
+// and ReadOnlyChunkManager, whose capabilities must not be reached around it, don't implement it.
+type Unwrapper interface {
+	Unwrap() ChunkManager
+}
+
+// AsChunkManager returns the first chunk manager of type T in the chain of @cm and the chunk managers it wraps,
+// from the outermost one, like errors.As.
+func AsChunkManager[T any](cm ChunkManager) (T, bool) {
+	for cm != nil {
+		if target, ok := cm.(T); ok {
+			return target, true
+		}
+		unwrapper, ok := cm.(Unwrapper)
+		if !ok {
+			break
+		}
+		cm = unwrapper.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
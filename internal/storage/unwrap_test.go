@@ -0,0 +1,56 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsChunkManager(t *testing.T) {
+	local := NewLocalChunkManager(RootPath(t.TempDir()))
+	dcm := NewDedupChunkManager(NewFaultInjectionChunkManager(local, 0))
+
+	found, ok := AsChunkManager[*LocalChunkManager](dcm)
+	assert.True(t, ok)
+	assert.Same(t, local, found)
+	outermost, ok := AsChunkManager[*DedupChunkManager](dcm)
+	assert.True(t, ok)
+	assert.Same(t, dcm, outermost)
+
+	_, ok = AsChunkManager[VersionedChunkManager](dcm)
+	assert.False(t, ok)
+	// the capabilities are not reached around the read-only chunk manager
+	_, ok = AsChunkManager[*LocalChunkManager](NewReadOnlyChunkManager(local))
+	assert.False(t, ok)
+}
+
+func TestAsChunkManager_FactoryChain(t *testing.T) {
+	// the chain built by ChunkManagerFactory with legacy root paths, dedup and audit enabled
+	local := NewLocalChunkManager(RootPath(t.TempDir()))
+	alias := NewAliasChunkManager(local, RootPath("files"), LegacyRootPaths("legacy"))
+	dcm := NewDedupChunkManager(alias)
+	cm := NewAuditChunkManager(dcm, &memoryAuditSink{}, "datacoord", false)
+
+	foundDedup, ok := AsChunkManager[*DedupChunkManager](cm)
+	assert.True(t, ok)
+	assert.Same(t, dcm, foundDedup)
+	foundAlias, ok := AsChunkManager[*AliasChunkManager](cm)
+	assert.True(t, ok)
+	assert.Same(t, alias, foundAlias)
+}
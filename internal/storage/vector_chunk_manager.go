@@ -21,6 +21,7 @@ import (
 	"errors"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -49,27 +50,57 @@ type VectorChunkManager struct {
 	cacheSize      int64
 	cacheSizeMutex sync.Mutex
 	fixSize        bool // Prevent cache capactiy from changing too frequently
+	// the cached vector files older than validateInterval are validated by their ETags, zero never validates
+	validateInterval time.Duration
+}
+
+// vectorCacheEntry is a vector file cached in the cache storage.
+type vectorCacheEntry struct {
+	reader *mmap.ReaderAt
+	// etag is the ETag of the vector file deserialized, empty if the vector files are never validated
+	etag string
+	// validatedAt is the unix nanoseconds the vector file was read or validated at last
+	validatedAt int64
+}
+
+func newVectorCacheEntry(reader *mmap.ReaderAt, etag string) *vectorCacheEntry {
+	return &vectorCacheEntry{reader: reader, etag: etag, validatedAt: time.Now().UnixNano()}
+}
+
+func (e *vectorCacheEntry) validated() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&e.validatedAt))
+}
+
+func (e *vectorCacheEntry) setValidated(t time.Time) {
+	atomic.StoreInt64(&e.validatedAt, t.UnixNano())
 }
 
 var _ ChunkManager = (*VectorChunkManager)(nil)
+var _ Prefetcher = (*VectorChunkManager)(nil)
 
 // NewVectorChunkManager create a new vector manager object.
-func NewVectorChunkManager(ctx context.Context, cacheStorage ChunkManager, vectorStorage ChunkManager, schema *etcdpb.CollectionMeta, cacheLimit int64, cacheEnable bool) (*VectorChunkManager, error) {
+// The validation of the cached vector files is set by the CacheValidateInterval option.
+func NewVectorChunkManager(ctx context.Context, cacheStorage ChunkManager, vectorStorage ChunkManager, schema *etcdpb.CollectionMeta, cacheLimit int64, cacheEnable bool, opts ...Option) (*VectorChunkManager, error) {
+	c := newDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
 	insertCodec := NewInsertCodec(schema)
 	vcm := &VectorChunkManager{
 		cacheStorage:  cacheStorage,
 		vectorStorage: vectorStorage,
 
-		insertCodec: insertCodec,
-		cacheEnable: cacheEnable,
-		cacheLimit:  cacheLimit,
+		insertCodec:      insertCodec,
+		cacheEnable:      cacheEnable,
+		cacheLimit:       cacheLimit,
+		validateInterval: c.cacheValidateInterval,
 	}
 	if cacheEnable {
 		if cacheLimit <= 0 {
 			return nil, errors.New("cache limit must be positive if cacheEnable")
 		}
 		c, err := cache.NewLRU(defaultLocalCacheSize, func(k cache.Key, v cache.Value) {
-			r := v.(*mmap.ReaderAt)
+			r := v.(*vectorCacheEntry).reader
 			size := r.Len()
 			err := r.Close()
 			if err != nil {
@@ -132,41 +163,117 @@ func (vcm *VectorChunkManager) Size(ctx context.Context, filePath string) (int64
 	return vcm.vectorStorage.Size(ctx, filePath)
 }
 
+// Stat returns the size, modify time and ETag of the vector data in vector storage.
+func (vcm *VectorChunkManager) Stat(ctx context.Context, filePath string) (ObjectInfo, error) {
+	return vcm.vectorStorage.Stat(ctx, filePath)
+}
+
+// MultiStat stats the vector data in vector storage.
+func (vcm *VectorChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*ObjectInfo, error) {
+	return vcm.vectorStorage.MultiStat(ctx, filePaths)
+}
+
 // Write writes the vector data to local cache if cache enabled.
 func (vcm *VectorChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
 	return vcm.vectorStorage.Write(ctx, filePath, content)
 }
 
+// WriteWithOptions writes the vector data to vector storage with the user metadata and tags in @opts.
+func (vcm *VectorChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...WriteOption) error {
+	return vcm.vectorStorage.WriteWithOptions(ctx, filePath, content, opts...)
+}
+
+// WriteIfNotExist writes the vector data to vector storage if @filePath doesn't exist.
+func (vcm *VectorChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	return vcm.vectorStorage.WriteIfNotExist(ctx, filePath, content)
+}
+
 // MultiWrite writes the vector data to local cache if cache enabled.
 func (vcm *VectorChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
 	return vcm.vectorStorage.MultiWrite(ctx, contents)
 }
 
+// Append appends the data to vector storage, the cached data of @filePath is invalidated.
+func (vcm *VectorChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	err := vcm.vectorStorage.Append(ctx, filePath, content)
+	if err != nil {
+		return err
+	}
+	if vcm.cacheEnable {
+		vcm.cache.Remove(filePath)
+	}
+	return nil
+}
+
+// Copy copies the data in vector storage, the cached data of @dstFilePath is invalidated.
+func (vcm *VectorChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	err := vcm.vectorStorage.Copy(ctx, srcFilePath, dstFilePath)
+	if err != nil {
+		return err
+	}
+	if vcm.cacheEnable {
+		vcm.cache.Remove(dstFilePath)
+	}
+	return nil
+}
+
+// Move moves the data in vector storage, the cached data of both paths is invalidated.
+func (vcm *VectorChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	err := vcm.vectorStorage.Move(ctx, srcFilePath, dstFilePath)
+	if err != nil {
+		return err
+	}
+	if vcm.cacheEnable {
+		vcm.cache.Remove(srcFilePath)
+		vcm.cache.Remove(dstFilePath)
+	}
+	return nil
+}
+
+// PresignURL presigns the url of the data in vector storage.
+func (vcm *VectorChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	return vcm.vectorStorage.PresignURL(ctx, filePath, method, expiry)
+}
+
 // Exist checks whether vector data is saved to local cache.
 func (vcm *VectorChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
 	return vcm.vectorStorage.Exist(ctx, filePath)
 }
 
 func (vcm *VectorChunkManager) readWithCache(ctx context.Context, filePath string) ([]byte, error) {
-	contents, err := vcm.vectorStorage.Read(ctx, filePath)
+	var contents []byte
+	var etag string
+	var err error
+	if vcm.validateInterval > 0 {
+		contents, etag, err = ReadIfModified(ctx, vcm.vectorStorage, filePath, "")
+	} else {
+		contents, err = vcm.vectorStorage.Read(ctx, filePath)
+	}
 	if err != nil {
 		return nil, err
 	}
+	results, _, err := vcm.cacheVectorFile(ctx, filePath, contents, etag)
+	return results, err
+}
+
+// cacheVectorFile deserializes the vector file @contents with @etag into the cache storage,
+// and returns the deserialized vector data and its mmap.
+func (vcm *VectorChunkManager) cacheVectorFile(ctx context.Context, filePath string, contents []byte, etag string) ([]byte, *mmap.ReaderAt, error) {
 	results, err := vcm.deserializeVectorFile(filePath, contents)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	err = vcm.cacheStorage.Write(ctx, filePath, results)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	r, err := vcm.cacheStorage.Mmap(ctx, filePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	size, err := vcm.cacheStorage.Size(ctx, filePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	vcm.cacheSizeMutex.Lock()
 	vcm.cacheSize += size
@@ -183,15 +290,51 @@ func (vcm *VectorChunkManager) readWithCache(ctx context.Context, filePath strin
 			vcm.fixSize = true
 		}
 	}
-	vcm.cache.Add(filePath, r)
-	return results, nil
+	vcm.cache.Add(filePath, newVectorCacheEntry(r, etag))
+	return results, r, nil
+}
+
+// cached returns the mmap of the cached vector file @filePath. The vector file cached for longer than
+// the validate interval is validated by ReadIfModified first, and it's cached again if it's modified.
+// The cached vector file keeps serving if the validation fails, unless the vector file is removed.
+func (vcm *VectorChunkManager) cached(ctx context.Context, filePath string) (*mmap.ReaderAt, bool, error) {
+	value, ok := vcm.cache.Get(filePath)
+	if !ok {
+		return nil, false, nil
+	}
+	entry := value.(*vectorCacheEntry)
+	if vcm.validateInterval <= 0 || time.Since(entry.validated()) < vcm.validateInterval {
+		return entry.reader, true, nil
+	}
+	contents, etag, err := ReadIfModified(ctx, vcm.vectorStorage, filePath, entry.etag)
+	switch {
+	case errors.Is(err, ErrNotModified):
+		entry.setValidated(time.Now())
+		return entry.reader, true, nil
+	case errors.Is(err, ErrNoSuchKey):
+		vcm.cache.Remove(filePath)
+		return nil, false, err
+	case err != nil:
+		log.Warn("failed to validate the cached vector file", zap.String("path", filePath), zap.Error(err))
+		return entry.reader, true, nil
+	}
+	log.Info("cached vector file modified", zap.String("path", filePath), zap.String("etag", etag))
+	vcm.cache.Remove(filePath)
+	_, r, err := vcm.cacheVectorFile(ctx, filePath, contents, etag)
+	if err != nil {
+		return nil, false, err
+	}
+	return r, true, nil
 }
 
 // Read reads the pure vector data. If cached, it reads from local.
 func (vcm *VectorChunkManager) Read(ctx context.Context, filePath string) ([]byte, error) {
 	if vcm.cacheEnable {
-		if r, ok := vcm.cache.Get(filePath); ok {
-			at := r.(*mmap.ReaderAt)
+		at, ok, err := vcm.cached(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
 			p := make([]byte, at.Len())
 			_, err := at.ReadAt(p, 0)
 			if err != nil {
@@ -208,6 +351,20 @@ func (vcm *VectorChunkManager) Read(ctx context.Context, filePath string) ([]byt
 	return vcm.deserializeVectorFile(filePath, contents)
 }
 
+// Prefetch hints the vector storage to download the vector files not cached yet ahead of the reads.
+func (vcm *VectorChunkManager) Prefetch(ctx context.Context, filePaths []string) {
+	if vcm.cacheEnable {
+		uncached := make([]string, 0, len(filePaths))
+		for _, filePath := range filePaths {
+			if !vcm.cache.Contains(filePath) {
+				uncached = append(uncached, filePath)
+			}
+		}
+		filePaths = uncached
+	}
+	Prefetch(ctx, vcm.vectorStorage, filePaths)
+}
+
 // MultiRead reads the pure vector data. If cached, it reads from local.
 func (vcm *VectorChunkManager) MultiRead(ctx context.Context, filePaths []string) ([][]byte, error) {
 	results := make([][]byte, len(filePaths))
@@ -238,10 +395,18 @@ func (vcm *VectorChunkManager) ListWithPrefix(ctx context.Context, prefix string
 	return vcm.vectorStorage.ListWithPrefix(ctx, prefix, recursive)
 }
 
+func (vcm *VectorChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc ChunkObjectWalkFunc) error {
+	return vcm.vectorStorage.WalkWithPrefix(ctx, prefix, recursive, walkFunc)
+}
+
+func (vcm *VectorChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	return vcm.vectorStorage.PrefixSize(ctx, prefix)
+}
+
 func (vcm *VectorChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
 	if vcm.cacheEnable && vcm.cache != nil {
 		if r, ok := vcm.cache.Get(filePath); ok {
-			return r.(*mmap.ReaderAt), nil
+			return r.(*vectorCacheEntry).reader, nil
 		}
 	}
 	return nil, errors.New("the file mmap has not been cached")
@@ -254,8 +419,11 @@ func (vcm *VectorChunkManager) Reader(ctx context.Context, filePath string) (Fil
 // ReadAt reads specific position data of vector. If cached, it reads from local.
 func (vcm *VectorChunkManager) ReadAt(ctx context.Context, filePath string, off int64, length int64) ([]byte, error) {
 	if vcm.cacheEnable {
-		if r, ok := vcm.cache.Get(filePath); ok {
-			at := r.(*mmap.ReaderAt)
+		at, ok, err := vcm.cached(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
 			p := make([]byte, length)
 			_, err := at.ReadAt(p, off)
 			if err != nil {
@@ -289,6 +457,12 @@ func (vcm *VectorChunkManager) ReadAt(ctx context.Context, filePath string, off
 	}
 	return p, nil
 }
+
+// MultiReadAt reads the @ranges of the deserialized vector data, the adjacent or overlapping ranges are coalesced.
+func (vcm *VectorChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []Range) ([][]byte, error) {
+	return parallelMultiReadAt(ctx, filePath, ranges, 1, vcm.ReadAt)
+}
+
 func (vcm *VectorChunkManager) Remove(ctx context.Context, filePath string) error {
 	err := vcm.vectorStorage.Remove(ctx, filePath)
 	if err != nil {
@@ -330,6 +504,31 @@ func (vcm *VectorChunkManager) RemoveWithPrefix(ctx context.Context, prefix stri
 	return nil
 }
 
+// EvictCache removes the least recently used vector files from the local cache until at least @size bytes are freed,
+// it could be registered to DiskWatcher as an evictor.
+func (vcm *VectorChunkManager) EvictCache(ctx context.Context, size int64) error {
+	if !vcm.cacheEnable {
+		return nil
+	}
+	var freed int64
+	var count int
+	for freed < size {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		key, value, ok := vcm.cache.GetOldest()
+		if !ok {
+			break
+		}
+		// the file is unmapped and removed by the evict callback of the cache
+		freed += int64(value.(*vectorCacheEntry).reader.Len())
+		count++
+		vcm.cache.Remove(key)
+	}
+	log.Info("vector cache evicted", zap.Int64("toFree", size), zap.Int64("freed", freed), zap.Int("files", count))
+	return nil
+}
+
 func (vcm *VectorChunkManager) Close() {
 	if vcm.cache != nil && vcm.cacheEnable {
 		vcm.cache.Close()
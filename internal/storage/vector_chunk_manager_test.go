@@ -19,8 +19,10 @@ package storage
 import (
 	"context"
 	"errors"
+	"math"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -460,3 +462,95 @@ func TestVectorChunkManager_Read(t *testing.T) {
 		vcm.Close()
 	}
 }
+
+func TestVectorChunkManager_ValidateCache(t *testing.T) {
+	ctx := context.Background()
+	rcm := NewMemoryChunkManager(RootPath("vector-chunk-manager"))
+	lcm := NewLocalChunkManager(RootPath(t.TempDir()))
+	meta := initMeta()
+	// validated at every read
+	vcm, err := NewVectorChunkManager(ctx, lcm, rcm, meta, 1024, true, CacheValidateInterval(time.Nanosecond))
+	assert.NoError(t, err)
+	defer vcm.Close()
+
+	// the parquet binlogs are written in pure go
+	params := paramtable.Get()
+	params.CommonCfg.BinlogFormatVersion = BinlogFormatV2
+	defer func() {
+		params.CommonCfg.BinlogFormatVersion = 0
+	}()
+	binlogs := initBinlogFile(meta)
+	values := make(map[string][]byte)
+	for _, binlog := range binlogs {
+		assert.NoError(t, rcm.Write(ctx, binlog.Key, binlog.Value))
+		values[binlog.Key] = binlog.Value
+	}
+
+	content, err := vcm.Read(ctx, "108")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 255}, content)
+	cached, err := vcm.Mmap(ctx, "108")
+	assert.NoError(t, err)
+
+	// not modified, the cached file is read
+	content, err = vcm.ReadAt(ctx, "108", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{255}, content)
+	r, err := vcm.Mmap(ctx, "108")
+	assert.NoError(t, err)
+	assert.Same(t, cached, r)
+
+	// cached again once the file is modified
+	expected, err := vcm.Read(ctx, "109")
+	assert.NoError(t, err)
+	assert.NoError(t, rcm.Write(ctx, "108", values["109"]))
+	content, err = vcm.Read(ctx, "108")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, content)
+	r, err = vcm.Mmap(ctx, "108")
+	assert.NoError(t, err)
+	assert.NotSame(t, cached, r)
+
+	// evicted once the file is removed
+	assert.NoError(t, rcm.Remove(ctx, "108"))
+	_, err = vcm.Read(ctx, "108")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+	assert.False(t, vcm.cache.Contains("108"))
+}
+
+func TestVectorChunkManager_EvictCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vcm, cancel, err := buildVectorChunkManager(localPath, true)
+	assert.NoError(t, err)
+	defer cancel()
+
+	meta := initMeta()
+	binlogs := initBinlogFile(meta)
+	for _, binlog := range binlogs {
+		err := vcm.vectorStorage.Write(ctx, binlog.Key, binlog.Value)
+		assert.NoError(t, err)
+	}
+	_, err = vcm.Read(ctx, "108")
+	assert.NoError(t, err)
+	_, err = vcm.Read(ctx, "109")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, vcm.cache.Len())
+
+	// the least recently used file is evicted first
+	err = vcm.EvictCache(ctx, 1)
+	assert.NoError(t, err)
+	assert.False(t, vcm.cache.Contains("108"))
+	assert.True(t, vcm.cache.Contains("109"))
+
+	err = vcm.EvictCache(ctx, math.MaxInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, vcm.cache.Len())
+	vcm.Close()
+
+	vcm, cancel, err = buildVectorChunkManager(localPath, false)
+	assert.NoError(t, err)
+	defer cancel()
+	assert.NoError(t, vcm.EvictCache(ctx, 1))
+}
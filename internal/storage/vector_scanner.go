@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+)
+
+// InvalidVectorReport records the invalid float vectors found in one insert binlog.
+type InvalidVectorReport struct {
+	Key          string
+	CollectionID UniqueID
+	PartitionID  UniqueID
+	SegmentID    UniqueID
+	FieldID      FieldID
+	// Offsets are the row offsets inside the binlog, Reasons are aligned with Offsets.
+	Offsets []int
+	Reasons []typeutil.VectorValidity
+}
+
+// ScanInvalidVectors scans a float vector insert binlog and reports the vectors containing NaN/Inf,
+// all-zero vectors are reported as well when checkZero is true.
+// It returns nil report if the binlog does not hold float vectors.
+func ScanInvalidVectors(key string, content []byte, checkZero bool) (*InvalidVectorReport, error) {
+	if BinlogFormatVersion(content) == BinlogFormatV2 {
+		return scanParquetInvalidVectors(key, content, checkZero)
+	}
+	reader, err := NewBinlogReader(content)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	if reader.PayloadDataType != schemapb.DataType_FloatVector {
+		return nil, nil
+	}
+	report := &InvalidVectorReport{
+		Key:          key,
+		CollectionID: reader.CollectionID,
+		PartitionID:  reader.PartitionID,
+		SegmentID:    reader.SegmentID,
+		FieldID:      reader.FieldID,
+	}
+
+	rowOffset := 0
+	for {
+		event, err := reader.NextEventReader()
+		if err != nil {
+			return nil, err
+		}
+		if event == nil {
+			break
+		}
+		if event.eventHeader.TypeCode != InsertEventType {
+			continue
+		}
+		data, dim, err := event.GetFloatVectorFromPayload()
+		if err != nil {
+			return nil, err
+		}
+		offsets, reasons := typeutil.FindInvalidFloatVectors(data, dim, checkZero)
+		for i, offset := range offsets {
+			report.Offsets = append(report.Offsets, rowOffset+offset)
+			report.Reasons = append(report.Reasons, reasons[i])
+		}
+		if dim > 0 {
+			rowOffset += len(data) / dim
+		}
+	}
+	return report, nil
+}
+
+// scanParquetInvalidVectors is ScanInvalidVectors of the parquet binlogs, which are scanned by row groups.
+func scanParquetInvalidVectors(key string, content []byte, checkZero bool) (*InvalidVectorReport, error) {
+	reader, err := NewParquetBinlogReaderFromBuffer(content)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	if reader.DataType != schemapb.DataType_FloatVector {
+		return nil, nil
+	}
+	report := &InvalidVectorReport{
+		Key:          key,
+		CollectionID: reader.CollectionID,
+		PartitionID:  reader.PartitionID,
+		SegmentID:    reader.SegmentID,
+		FieldID:      reader.FieldID,
+	}
+
+	rowOffset := 0
+	for i := 0; i < reader.NumRowGroups(); i++ {
+		data, err := reader.ReadRowGroup(i)
+		if err != nil {
+			return nil, err
+		}
+		vectors := data.(*FloatVectorFieldData)
+		offsets, reasons := typeutil.FindInvalidFloatVectors(vectors.Data, vectors.Dim, checkZero)
+		for i, offset := range offsets {
+			report.Offsets = append(report.Offsets, rowOffset+offset)
+			report.Reasons = append(report.Reasons, reasons[i])
+		}
+		rowOffset += vectors.RowNum()
+	}
+	return report, nil
+}
+
+// ScanInvalidVectorsWithPrefix scans all float vector binlogs under @prefix, such as the insert log
+// path of a segment, and returns the reports of the binlogs holding invalid vectors.
+func ScanInvalidVectorsWithPrefix(ctx context.Context, cm ChunkManager, prefix string, checkZero bool) ([]*InvalidVectorReport, error) {
+	keys, _, err := cm.ListWithPrefix(ctx, prefix, true)
+	if err != nil {
+		return nil, err
+	}
+	var reports []*InvalidVectorReport
+	for _, key := range keys {
+		content, err := cm.Read(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		report, err := ScanInvalidVectors(key, content, checkZero)
+		if err != nil {
+			return nil, err
+		}
+		if report != nil && len(report.Offsets) > 0 {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
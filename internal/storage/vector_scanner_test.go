@@ -0,0 +1,88 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"testing"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/util/typeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFloatVectorBinlog(t *testing.T, segmentID int64, batches ...[]float32) []byte {
+	w := NewInsertBinlogWriter(schemapb.DataType_FloatVector, 10, 20, segmentID, 101)
+	defer w.Close()
+	for _, batch := range batches {
+		e, err := w.NextInsertEventWriter(2)
+		require.NoError(t, err)
+		require.NoError(t, e.AddDataToPayload(batch, 2))
+		e.SetEventTimestamp(100, 200)
+	}
+	w.SetEventTimeStamp(1000, 2000)
+	w.AddExtra(originalSizeKey, fmt.Sprintf("%v", 1024))
+	require.NoError(t, w.Finish())
+	buf, err := w.GetBuffer()
+	require.NoError(t, err)
+	return buf
+}
+
+func TestScanInvalidVectors(t *testing.T) {
+	nan := float32(math.NaN())
+	inf := float32(math.Inf(1))
+	buf := writeFloatVectorBinlog(t, 30,
+		[]float32{1, 2, nan, 4},
+		[]float32{0, 0, 5, inf})
+
+	report, err := ScanInvalidVectors("key", buf, false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), report.SegmentID)
+	assert.Equal(t, int64(101), report.FieldID)
+	assert.Equal(t, []int{1, 3}, report.Offsets)
+	assert.Equal(t, []typeutil.VectorValidity{typeutil.NaNVector, typeutil.InfVector}, report.Reasons)
+
+	report, err = ScanInvalidVectors("key", buf, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, report.Offsets)
+
+	_, err = ScanInvalidVectors("key", []byte("invalid"), false)
+	assert.Error(t, err)
+}
+
+func TestScanInvalidVectorsWithPrefix(t *testing.T) {
+	ctx := context.Background()
+	testRoot := "test_scan_invalid_vectors"
+	cm := NewLocalChunkManager(RootPath(localPath))
+	defer cm.RemoveWithPrefix(ctx, testRoot)
+
+	nan := float32(math.NaN())
+	err := cm.Write(ctx, path.Join(testRoot, "1", "101", "1"), writeFloatVectorBinlog(t, 1, []float32{1, 2, 3, 4}))
+	assert.NoError(t, err)
+	err = cm.Write(ctx, path.Join(testRoot, "2", "101", "1"), writeFloatVectorBinlog(t, 2, []float32{1, 2, nan, 4}))
+	assert.NoError(t, err)
+
+	reports, err := ScanInvalidVectorsWithPrefix(ctx, cm, testRoot, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(reports))
+	assert.Equal(t, int64(2), reports[0].SegmentID)
+	assert.Equal(t, []int{1}, reports[0].Offsets)
+}
@@ -97,6 +97,9 @@ type DataNode interface {
 
 	// AddImportSegment puts the given import segment to current DataNode's flow graph.
 	AddImportSegment(ctx context.Context, req *datapb.AddImportSegmentRequest) (*datapb.AddImportSegmentResponse, error)
+
+	// FreshSearch searches the rows in the insert buffers of the collection by brute force.
+	FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error)
 }
 
 // DataNodeComponent is used by grpc server of DataNode
@@ -332,6 +335,9 @@ type DataCoord interface {
 	BroadcastAlteredCollection(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error)
 
 	CheckHealth(ctx context.Context, req *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error)
+
+	// FreshSearch searches the insert buffers of the DataNodes watching the channels of the collection by brute force.
+	FreshSearch(ctx context.Context, req *datapb.FreshSearchRequest) (*datapb.FreshSearchResponse, error)
 }
 
 // DataCoordComponent defines the interface of DataCoord component.
@@ -0,0 +1,143 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+)
+
+const (
+	// TypeParamKey is the type param key of a VarChar field to specify the name of its analyzer.
+	TypeParamKey = "analyzer"
+	// ParamsTypeParamKey is the type param key of a VarChar field to specify the params of its analyzer in JSON.
+	ParamsTypeParamKey = "analyzer_params"
+)
+
+// Analyzer splits a text into the terms used to build and query the sparse representation of a VarChar field.
+// The same analyzer must be applied to the field data on insertion and to the query text, or the terms won't match.
+type Analyzer interface {
+	// Analyze returns the terms of @text in order, a term repeats as many times as it occurs.
+	Analyze(text string) []string
+}
+
+// Factory creates an analyzer with @params, which is the raw JSON of the analyzer params and may be empty.
+type Factory func(params []byte) (Analyzer, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+func init() {
+	mustRegister(Standard, newStandardAnalyzer)
+	mustRegister(Whitespace, newWhitespaceAnalyzer)
+	mustRegister(CJK, newCJKAnalyzer)
+}
+
+// Register makes an analyzer available with @name, the name can't be registered twice.
+func Register(name string, factory Factory) error {
+	if name == "" || factory == nil {
+		return fmt.Errorf("invalid analyzer registration, name: %q", name)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("analyzer %s is already registered", name)
+	}
+	registry[name] = factory
+	return nil
+}
+
+func mustRegister(name string, factory Factory) {
+	if err := Register(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// Names returns the sorted names of the registered analyzers.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the analyzer registered with @name.
+func New(name string, params string) (Analyzer, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown analyzer %s, supported analyzers: %v", name, Names())
+	}
+	analyzer, err := factory([]byte(params))
+	if err != nil {
+		return nil, fmt.Errorf("invalid params of analyzer %s: %w", name, err)
+	}
+	return analyzer, nil
+}
+
+// FromField creates the analyzer configured by the type params of @field,
+// nil is returned if no analyzer is configured.
+func FromField(field *schemapb.FieldSchema) (Analyzer, error) {
+	var name, params string
+	hasName, hasParams := false, false
+	for _, kv := range field.GetTypeParams() {
+		switch kv.GetKey() {
+		case TypeParamKey:
+			name, hasName = kv.GetValue(), true
+		case ParamsTypeParamKey:
+			params, hasParams = kv.GetValue(), true
+		}
+	}
+	if !hasName && !hasParams {
+		return nil, nil
+	}
+	if field.GetDataType() != schemapb.DataType_VarChar {
+		return nil, fmt.Errorf("analyzer can only be specified for VarChar field, field: %s, type: %s",
+			field.GetName(), field.GetDataType().String())
+	}
+	if !hasName {
+		return nil, fmt.Errorf("type param(%s) should be specified with %s, field: %s", TypeParamKey, ParamsTypeParamKey, field.GetName())
+	}
+	return New(name, params)
+}
+
+// IsAnalyzerTypeParam returns whether @key is a type param key of the analyzer.
+func IsAnalyzerTypeParam(key string) bool {
+	return key == TypeParamKey || key == ParamsTypeParamKey
+}
+
+// decodeParams decodes the JSON @params into @v, unknown params are rejected.
+func decodeParams(params []byte, v interface{}) error {
+	if len(bytes.TrimSpace(params)) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(params))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,113 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinAnalyzers(t *testing.T) {
+	tests := []struct {
+		description string
+		name        string
+		params      string
+		text        string
+		expected    []string
+	}{
+		{"standard", Standard, "", "Hello, World! hello-milvus 2.2", []string{"hello", "world", "hello", "milvus", "2", "2"}},
+		{"standard without lowercase", Standard, `{"lowercase": false}`, "Hello World", []string{"Hello", "World"}},
+		{"standard with stop words", Standard, `{"stop_words": ["_english_", "Milvus"]}`, "The Milvus is a database", []string{"database"}},
+		{"whitespace", Whitespace, "", "Hello, World!\thello", []string{"Hello,", "World!", "hello"}},
+		{"whitespace with lowercase", Whitespace, `{"lowercase": true, "stop_words": ["hello"]}`, "Hello World", []string{"world"}},
+		{"cjk", CJK, "", "向量数据库 Milvus", []string{"向量", "量数", "数据", "据库", "milvus"}},
+		{"cjk mixed", CJK, "", "使用Milvus和 Zilliz云", []string{"使用", "milvus", "和", "zilliz", "云"}},
+		{"empty", Standard, "", " ,. ", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			a, err := New(test.name, test.params)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, a.Analyze(test.text))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("unknown", "")
+	assert.Error(t, err)
+
+	_, err = New(Standard, `{"unknown": 1}`)
+	assert.Error(t, err)
+
+	_, err = New(Standard, `not json`)
+	assert.Error(t, err)
+}
+
+type upperAnalyzer struct{}
+
+func (a upperAnalyzer) Analyze(text string) []string {
+	return []string{text + "!"}
+}
+
+func TestRegister(t *testing.T) {
+	err := Register("test_custom", func(params []byte) (Analyzer, error) {
+		return upperAnalyzer{}, nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, Names(), "test_custom")
+
+	a, err := New("test_custom", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a!"}, a.Analyze("a"))
+
+	err = Register("test_custom", func(params []byte) (Analyzer, error) {
+		return upperAnalyzer{}, nil
+	})
+	assert.Error(t, err)
+	assert.Error(t, Register("", nil))
+}
+
+func TestFromField(t *testing.T) {
+	field := &schemapb.FieldSchema{
+		Name:     "text",
+		DataType: schemapb.DataType_VarChar,
+		TypeParams: []*commonpb.KeyValuePair{
+			{Key: "max_length", Value: "256"},
+		},
+	}
+	a, err := FromField(field)
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+
+	field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{Key: ParamsTypeParamKey, Value: `{"stop_words": ["a"]}`})
+	_, err = FromField(field)
+	assert.Error(t, err)
+
+	field.TypeParams = append(field.TypeParams, &commonpb.KeyValuePair{Key: TypeParamKey, Value: Whitespace})
+	a, err = FromField(field)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, a.Analyze("a b"))
+
+	field.DataType = schemapb.DataType_Int64
+	_, err = FromField(field)
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Names of the builtin analyzers.
+const (
+	// Standard splits the text at the characters other than letters and digits, and lowercases the terms.
+	Standard = "standard"
+	// Whitespace splits the text at the white spaces only, the terms are kept as they are by default.
+	Whitespace = "whitespace"
+	// CJK works as Standard, except that the runs of CJK characters are split into overlapping bigrams.
+	CJK = "cjk"
+)
+
+// EnglishStopWords is the name of the builtin English stop word list, which could be put in the stop words params.
+const EnglishStopWords = "_english_"
+
+var englishStopWords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "if", "in", "into", "is", "it",
+	"no", "not", "of", "on", "or", "such", "that", "the", "their", "then", "there", "these",
+	"they", "this", "to", "was", "will", "with",
+}
+
+// builtinParams are the params shared by the builtin analyzers.
+type builtinParams struct {
+	// StopWords are the terms removed from the output, EnglishStopWords expands to the builtin English list.
+	StopWords []string `json:"stop_words"`
+	// Lowercase indicates whether to lowercase the terms, the default value depends on the analyzer.
+	Lowercase *bool `json:"lowercase"`
+}
+
+// termFilter lowercases the terms and removes the stop words.
+type termFilter struct {
+	lowercase bool
+	stopWords map[string]struct{}
+}
+
+func newTermFilter(params []byte, lowercase bool) (*termFilter, error) {
+	p := builtinParams{}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Lowercase != nil {
+		lowercase = *p.Lowercase
+	}
+	f := &termFilter{lowercase: lowercase}
+	if len(p.StopWords) > 0 {
+		f.stopWords = make(map[string]struct{})
+	}
+	for _, word := range p.StopWords {
+		words := []string{word}
+		if word == EnglishStopWords {
+			words = englishStopWords
+		}
+		for _, w := range words {
+			f.stopWords[f.normalize(w)] = struct{}{}
+		}
+	}
+	return f, nil
+}
+
+func (f *termFilter) normalize(term string) string {
+	if f.lowercase {
+		return strings.ToLower(term)
+	}
+	return term
+}
+
+// appendTerm appends @term to @terms unless it's a stop word.
+func (f *termFilter) appendTerm(terms []string, term string) []string {
+	term = f.normalize(term)
+	if _, ok := f.stopWords[term]; ok {
+		return terms
+	}
+	return append(terms, term)
+}
+
+type standardAnalyzer struct {
+	filter *termFilter
+}
+
+func newStandardAnalyzer(params []byte) (Analyzer, error) {
+	filter, err := newTermFilter(params, true)
+	if err != nil {
+		return nil, err
+	}
+	return &standardAnalyzer{filter: filter}, nil
+}
+
+func (a *standardAnalyzer) Analyze(text string) []string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(text, isWordSeparator) {
+		terms = a.filter.appendTerm(terms, word)
+	}
+	return terms
+}
+
+type whitespaceAnalyzer struct {
+	filter *termFilter
+}
+
+func newWhitespaceAnalyzer(params []byte) (Analyzer, error) {
+	filter, err := newTermFilter(params, false)
+	if err != nil {
+		return nil, err
+	}
+	return &whitespaceAnalyzer{filter: filter}, nil
+}
+
+func (a *whitespaceAnalyzer) Analyze(text string) []string {
+	var terms []string
+	for _, word := range strings.Fields(text) {
+		terms = a.filter.appendTerm(terms, word)
+	}
+	return terms
+}
+
+type cjkAnalyzer struct {
+	filter *termFilter
+}
+
+func newCJKAnalyzer(params []byte) (Analyzer, error) {
+	filter, err := newTermFilter(params, true)
+	if err != nil {
+		return nil, err
+	}
+	return &cjkAnalyzer{filter: filter}, nil
+}
+
+// Analyze splits every word into the runs of CJK and non-CJK characters,
+// a non-CJK run is a term, a CJK run is split into overlapping bigrams, or kept if it's a single character.
+func (a *cjkAnalyzer) Analyze(text string) []string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(text, isWordSeparator) {
+		runes := []rune(word)
+		start := 0
+		for start < len(runes) {
+			cjk := isCJK(runes[start])
+			end := start + 1
+			for end < len(runes) && isCJK(runes[end]) == cjk {
+				end++
+			}
+			switch {
+			case !cjk:
+				terms = a.filter.appendTerm(terms, string(runes[start:end]))
+			case end-start == 1:
+				terms = a.filter.appendTerm(terms, string(runes[start]))
+			default:
+				for i := start; i+1 < end; i++ {
+					terms = a.filter.appendTerm(terms, string(runes[i:i+2]))
+				}
+			}
+			start = end
+		}
+	}
+	return terms
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
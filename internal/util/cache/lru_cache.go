@@ -126,8 +126,9 @@ func (c *LRU) Add(key, value Value) {
 }
 
 func (c *LRU) Get(key Key) (value Value, ok bool) {
-	c.m.RLock()
-	defer c.m.RUnlock()
+	// the entry is moved to the front, so the write lock is required
+	c.m.Lock()
+	defer c.m.Unlock()
 	c.stats.readCount++
 	if e, ok := c.items[key]; ok {
 		c.stats.hitCount++
@@ -0,0 +1,209 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressor
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+
+	"github.com/milvus-io/milvus/internal/metrics"
+)
+
+// Names of the compressors registered to grpc, which could be used by grpc.UseCompressor.
+const (
+	GrpcCompressorGzip = "gzip"
+	GrpcCompressorZstd = "zstd"
+)
+
+func init() {
+	encoding.RegisterCompressor(&grpcGzipCompressor{})
+	encoding.RegisterCompressor(&grpcZstdCompressor{})
+}
+
+// grpcGzipCompressor implements encoding.Compressor with gzip, the compressed and raw bytes are recorded by metrics.
+type grpcGzipCompressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+func (c *grpcGzipCompressor) Name() string {
+	return GrpcCompressorGzip
+}
+
+func (c *grpcGzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	cw := newCountingWriter(w, c.Name())
+	gw, ok := c.writers.Get().(*gzip.Writer)
+	if ok {
+		gw.Reset(cw)
+	} else {
+		gw = gzip.NewWriter(cw)
+	}
+	return &grpcCompressWriter{
+		WriteCloser: gw,
+		name:        c.Name(),
+		release:     func() { c.writers.Put(gw) },
+	}, nil
+}
+
+func (c *grpcGzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	cr := newCountingReader(r, c.Name())
+	gr, ok := c.readers.Get().(*gzip.Reader)
+	if ok {
+		if err := gr.Reset(cr); err != nil {
+			c.readers.Put(gr)
+			return nil, err
+		}
+	} else {
+		var err error
+		if gr, err = gzip.NewReader(cr); err != nil {
+			return nil, err
+		}
+	}
+	return &grpcDecompressReader{
+		Reader:  gr,
+		name:    c.Name(),
+		release: func() { c.readers.Put(gr) },
+	}, nil
+}
+
+// grpcZstdCompressor implements encoding.Compressor with zstd, the compressed and raw bytes are recorded by metrics.
+type grpcZstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func (c *grpcZstdCompressor) Name() string {
+	return GrpcCompressorZstd
+}
+
+func (c *grpcZstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	cw := newCountingWriter(w, c.Name())
+	enc, ok := c.encoders.Get().(*zstd.Encoder)
+	if ok {
+		enc.Reset(cw)
+	} else {
+		var err error
+		if enc, err = zstd.NewWriter(cw, zstd.WithEncoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	}
+	return &grpcCompressWriter{
+		WriteCloser: enc,
+		name:        c.Name(),
+		release:     func() { c.encoders.Put(enc) },
+	}, nil
+}
+
+func (c *grpcZstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	cr := newCountingReader(r, c.Name())
+	dec, ok := c.decoders.Get().(*zstd.Decoder)
+	if ok {
+		if err := dec.Reset(cr); err != nil {
+			c.decoders.Put(dec)
+			return nil, err
+		}
+	} else {
+		var err error
+		if dec, err = zstd.NewReader(cr, zstd.WithDecoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	}
+	return &grpcDecompressReader{
+		Reader:  dec,
+		name:    c.Name(),
+		release: func() { c.decoders.Put(dec) },
+	}, nil
+}
+
+// grpcCompressWriter records the raw bytes written, and returns the compressor to the pool when closed.
+type grpcCompressWriter struct {
+	io.WriteCloser
+	name    string
+	release func()
+}
+
+func (w *grpcCompressWriter) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+	metrics.GrpcCompressionBytes.WithLabelValues(w.name, metrics.CompressLabel, metrics.RawLabel).Add(float64(n))
+	return n, err
+}
+
+func (w *grpcCompressWriter) Close() error {
+	err := w.WriteCloser.Close()
+	if err == nil {
+		w.release()
+	}
+	return err
+}
+
+// grpcDecompressReader records the raw bytes read, and returns the decompressor to the pool at EOF.
+// The decompressor is left to GC if the message is not read to the end.
+type grpcDecompressReader struct {
+	io.Reader
+	name     string
+	release  func()
+	released bool
+}
+
+func (r *grpcDecompressReader) Read(p []byte) (int, error) {
+	if r.released {
+		return 0, io.EOF
+	}
+	n, err := r.Reader.Read(p)
+	metrics.GrpcCompressionBytes.WithLabelValues(r.name, metrics.DecompressLabel, metrics.RawLabel).Add(float64(n))
+	if err == io.EOF {
+		r.released = true
+		r.release()
+	}
+	return n, err
+}
+
+// countingWriter records the compressed bytes written to the grpc stream.
+type countingWriter struct {
+	w    io.Writer
+	name string
+}
+
+func newCountingWriter(w io.Writer, name string) *countingWriter {
+	return &countingWriter{w: w, name: name}
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	metrics.GrpcCompressionBytes.WithLabelValues(w.name, metrics.CompressLabel, metrics.CompressedLabel).Add(float64(n))
+	return n, err
+}
+
+// countingReader records the compressed bytes read from the grpc stream.
+type countingReader struct {
+	r    io.Reader
+	name string
+}
+
+func newCountingReader(r io.Reader, name string) *countingReader {
+	return &countingReader{r: r, name: name}
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	metrics.GrpcCompressionBytes.WithLabelValues(r.name, metrics.DecompressLabel, metrics.CompressedLabel).Add(float64(n))
+	return n, err
+}
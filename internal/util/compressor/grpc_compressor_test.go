@@ -0,0 +1,60 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compressor
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestGrpcCompressor(t *testing.T) {
+	data := []byte(strings.Repeat("hello grpc compressor!", 1024))
+	for _, name := range []string{GrpcCompressorGzip, GrpcCompressorZstd} {
+		c := encoding.GetCompressor(name)
+		assert.NotNil(t, c)
+		assert.Equal(t, name, c.Name())
+
+		// compressors and decompressors are reused from the pool in the second round
+		for i := 0; i < 2; i++ {
+			compressed := new(bytes.Buffer)
+			w, err := c.Compress(compressed)
+			assert.NoError(t, err)
+			_, err = w.Write(data)
+			assert.NoError(t, err)
+			assert.NoError(t, w.Close())
+			assert.Less(t, compressed.Len(), len(data))
+
+			r, err := c.Decompress(compressed)
+			assert.NoError(t, err)
+			decompressed, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+		}
+
+		_, err := c.Decompress(bytes.NewReader([]byte("invalid")))
+		if err == nil {
+			r, _ := c.Decompress(bytes.NewReader([]byte("invalid")))
+			_, err = io.ReadAll(r)
+		}
+		assert.Error(t, err)
+	}
+}
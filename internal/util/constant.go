@@ -57,6 +57,16 @@ const (
 	ParamsKeyToParse = "params"
 )
 
+// Invalid vector policies applied by proxy on insert
+const (
+	// InvalidVectorPolicyReject rejects the whole insert request.
+	InvalidVectorPolicyReject = "reject"
+	// InvalidVectorPolicyZeroFill replaces the invalid vectors with zero vectors.
+	InvalidVectorPolicyZeroFill = "zerofill"
+	// InvalidVectorPolicySkip drops the rows holding invalid vectors.
+	InvalidVectorPolicySkip = "skip"
+)
+
 var (
 	DefaultRoles = []string{RoleAdmin, RolePublic}
 
@@ -68,7 +68,11 @@ func (f *DefaultFactory) initMQLocalService(params *paramtable.ComponentParam) m
 // initRemoteService Pulsar has higher priority than Kafka.
 func (f *DefaultFactory) initMQRemoteService(params *paramtable.ComponentParam) msgstream.Factory {
 	if params.PulsarEnable() {
-		return msgstream.NewPmsFactory(&params.PulsarCfg)
+		factory, err := msgstream.NewPmsFactory(&params.PulsarCfg)
+		if err != nil {
+			panic(err)
+		}
+		return factory
 	}
 
 	if params.KafkaEnable() {
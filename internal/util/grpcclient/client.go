@@ -32,6 +32,8 @@ import (
 	grpcopentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	"github.com/milvus-io/milvus/internal/log"
 	"github.com/milvus-io/milvus/internal/util"
+	// register the grpc compressors
+	_ "github.com/milvus-io/milvus/internal/util/compressor"
 	"github.com/milvus-io/milvus/internal/util/crypto"
 	"github.com/milvus-io/milvus/internal/util/funcutil"
 	"github.com/milvus-io/milvus/internal/util/generic"
@@ -76,6 +78,8 @@ type ClientBase[T any] struct {
 	MaxBackoff        float32
 	BackoffMultiplier float32
 	NodeID            int64
+	// CompressionType is the grpc compressor of the requests, empty means no compression
+	CompressionType string
 }
 
 // SetRole sets role of client
@@ -143,6 +147,18 @@ func (c *ClientBase[T]) resetConnection(client T) {
 	c.grpcClient = generic.Zero[T]()
 }
 
+// callOptions returns the default call options of the connection.
+func (c *ClientBase[T]) callOptions() []grpc.CallOption {
+	opts := []grpc.CallOption{
+		grpc.MaxCallRecvMsgSize(c.ClientMaxRecvSize),
+		grpc.MaxCallSendMsgSize(c.ClientMaxSendSize),
+	}
+	if c.CompressionType != "" {
+		opts = append(opts, grpc.UseCompressor(c.CompressionType))
+	}
+	return opts
+}
+
 func (c *ClientBase[T]) connect(ctx context.Context) error {
 	addr, err := c.getAddrFunc()
 	if err != nil {
@@ -175,10 +191,7 @@ func (c *ClientBase[T]) connect(ctx context.Context) error {
 			// #nosec G402
 			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})),
 			grpc.WithBlock(),
-			grpc.WithDefaultCallOptions(
-				grpc.MaxCallRecvMsgSize(c.ClientMaxRecvSize),
-				grpc.MaxCallSendMsgSize(c.ClientMaxSendSize),
-			),
+			grpc.WithDefaultCallOptions(c.callOptions()...),
 			grpc.WithUnaryInterceptor(grpcopentracing.UnaryClientInterceptor(opts...)),
 			grpc.WithStreamInterceptor(grpcopentracing.StreamClientInterceptor(opts...)),
 			grpc.WithDefaultServiceConfig(retryPolicy),
@@ -205,10 +218,7 @@ func (c *ClientBase[T]) connect(ctx context.Context) error {
 			grpc.WithInsecure(),
 			//grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})),
 			grpc.WithBlock(),
-			grpc.WithDefaultCallOptions(
-				grpc.MaxCallRecvMsgSize(c.ClientMaxRecvSize),
-				grpc.MaxCallSendMsgSize(c.ClientMaxSendSize),
-			),
+			grpc.WithDefaultCallOptions(c.callOptions()...),
 			grpc.WithUnaryInterceptor(grpcopentracing.UnaryClientInterceptor(opts...)),
 			grpc.WithStreamInterceptor(grpcopentracing.StreamClientInterceptor(opts...)),
 			grpc.WithDefaultServiceConfig(retryPolicy),
@@ -51,6 +51,7 @@ type BinlogAdapter struct {
 	chunkManager     storage.ChunkManager       // storage interfaces to read binlog files
 	callFlushFunc    ImportFlushFunc            // call back function to flush segment
 	shardNum         int32                      // sharding number of the collection
+	shardRouting     string                     // how primary keys are routed to shards, see common.ShardRouting*
 	blockSize        int64                      // maximum size of a read block(unit:byte)
 	maxTotalSize     int64                      // maximum size of in-memory segments(unit:byte)
 	primaryKey       storage.FieldID            // id of primary key
@@ -551,7 +552,7 @@ func (p *BinlogAdapter) getShardingListByPrimaryInt64(primaryKeys []int64,
 			actualDeleted++
 		} else {
 			hash, _ := typeutil.Hash32Int64(key)
-			shardID := hash % uint32(p.shardNum)
+			shardID := typeutil.HashKey2Shard(hash, uint32(p.shardNum), p.shardRouting)
 			fields := memoryData[shardID] // initSegmentData() can ensure the existence, no need to check bound here
 			field := fields[p.primaryKey] // initSegmentData() can ensure the existence, no need to check here
 
@@ -603,7 +604,7 @@ func (p *BinlogAdapter) getShardingListByPrimaryVarchar(primaryKeys []string,
 			actualDeleted++
 		} else {
 			hash := typeutil.HashString2Uint32(key)
-			shardID := hash % uint32(p.shardNum)
+			shardID := typeutil.HashKey2Shard(hash, uint32(p.shardNum), p.shardRouting)
 			fields := memoryData[shardID] // initSegmentData() can ensure the existence, no need to check bound here
 			field := fields[p.primaryKey] // initSegmentData() can ensure the existence, no need to check existence here
 
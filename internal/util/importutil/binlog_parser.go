@@ -34,6 +34,7 @@ type BinlogParser struct {
 	ctx              context.Context            // for canceling parse process
 	collectionSchema *schemapb.CollectionSchema // collection schema
 	shardNum         int32                      // sharding number of the collection
+	shardRouting     string                     // how primary keys are routed to shards, see common.ShardRouting*
 	blockSize        int64                      // maximum size of a read block(unit:byte)
 	chunkManager     storage.ChunkManager       // storage interfaces to browse/read the files
 	callFlushFunc    ImportFlushFunc            // call back function to flush segment
@@ -98,7 +99,7 @@ func NewBinlogParser(ctx context.Context,
 // For instance, the insertlogRoot is "backup/bak1/data/insert_log/435978159196147009/435978159196147010".
 // 435978159196147009 is a collection id, 435978159196147010 is a partition id,
 // there is a segment(id is 435978159261483009) under this partition.
-// ListWithPrefix() will return all the insert logs under this partition:
+// WalkWithPrefix() will visit all the insert logs under this partition:
 //
 //	"backup/bak1/data/insert_log/435978159196147009/435978159196147010/435978159261483009/0/435978159903735811"
 //	"backup/bak1/data/insert_log/435978159196147009/435978159196147010/435978159261483009/1/435978159903735812"
@@ -115,22 +116,20 @@ func NewBinlogParser(ctx context.Context,
 func (p *BinlogParser) constructSegmentHolders(insertlogRoot string, deltalogRoot string) ([]*SegmentFilesHolder, error) {
 	holders := make(map[int64]*SegmentFilesHolder)
 	// TODO add context
-	insertlogs, _, err := p.chunkManager.ListWithPrefix(context.TODO(), insertlogRoot, true)
-	if err != nil {
-		log.Error("Binlog parser: list insert logs error", zap.Error(err))
-		return nil, fmt.Errorf("failed to list insert logs with root path %s, error: %w", insertlogRoot, err)
-	}
-
-	// collect insert log paths
-	log.Info("Binlog parser: list insert logs", zap.Int("logsCount", len(insertlogs)))
-	for _, insertlog := range insertlogs {
+	insertlogCount := 0
+	var parseErr error
+	err := p.chunkManager.WalkWithPrefix(context.TODO(), insertlogRoot, true, func(chunkInfo storage.ChunkObjectInfo) bool {
+		// collect insert log paths
+		insertlog := chunkInfo.FilePath
+		insertlogCount++
 		log.Info("Binlog parser: mapping insert log to segment", zap.String("insertlog", insertlog))
 		fieldPath := path.Dir(insertlog)
 		fieldStrID := path.Base(fieldPath)
 		fieldID, err := strconv.ParseInt(fieldStrID, 10, 64)
 		if err != nil {
 			log.Error("Binlog parser: failed to parse field id", zap.String("fieldPath", fieldPath), zap.Error(err))
-			return nil, fmt.Errorf("failed to parse field id from insert log path %s, error: %w", insertlog, err)
+			parseErr = fmt.Errorf("failed to parse field id from insert log path %s, error: %w", insertlog, err)
+			return false
 		}
 
 		segmentPath := path.Dir(fieldPath)
@@ -138,7 +137,8 @@ func (p *BinlogParser) constructSegmentHolders(insertlogRoot string, deltalogRoo
 		segmentID, err := strconv.ParseInt(segmentStrID, 10, 64)
 		if err != nil {
 			log.Error("Binlog parser: failed to parse segment id", zap.String("segmentPath", segmentPath), zap.Error(err))
-			return nil, fmt.Errorf("failed to parse segment id from insert log path %s, error: %w", insertlog, err)
+			parseErr = fmt.Errorf("failed to parse segment id from insert log path %s, error: %w", insertlog, err)
+			return false
 		}
 
 		holder, ok := holders[segmentID]
@@ -154,7 +154,16 @@ func (p *BinlogParser) constructSegmentHolders(insertlogRoot string, deltalogRoo
 			holder.fieldFiles[fieldID] = append(holder.fieldFiles[fieldID], insertlog)
 			holders[segmentID] = holder
 		}
+		return true
+	})
+	if err != nil {
+		log.Error("Binlog parser: list insert logs error", zap.Error(err))
+		return nil, fmt.Errorf("failed to list insert logs with root path %s, error: %w", insertlogRoot, err)
+	}
+	if parseErr != nil {
+		return nil, parseErr
 	}
+	log.Info("Binlog parser: list insert logs", zap.Int("logsCount", insertlogCount))
 
 	// sort the insert log paths of each field by ascendent sequence
 	// there might be several insert logs under a field, for example:
@@ -174,21 +183,18 @@ func (p *BinlogParser) constructSegmentHolders(insertlogRoot string, deltalogRoo
 	// collect delta log paths
 	if len(deltalogRoot) > 0 {
 		// TODO add context
-		deltalogs, _, err := p.chunkManager.ListWithPrefix(context.TODO(), deltalogRoot, true)
-		if err != nil {
-			log.Error("Binlog parser: failed to list delta logs", zap.Error(err))
-			return nil, fmt.Errorf("failed to list delta logs, error: %w", err)
-		}
-
-		log.Info("Binlog parser: list delta logs", zap.Int("logsCount", len(deltalogs)))
-		for _, deltalog := range deltalogs {
+		deltalogCount := 0
+		err := p.chunkManager.WalkWithPrefix(context.TODO(), deltalogRoot, true, func(chunkInfo storage.ChunkObjectInfo) bool {
+			deltalog := chunkInfo.FilePath
+			deltalogCount++
 			log.Info("Binlog parser: mapping delta log to segment", zap.String("deltalog", deltalog))
 			segmentPath := path.Dir(deltalog)
 			segmentStrID := path.Base(segmentPath)
 			segmentID, err := strconv.ParseInt(segmentStrID, 10, 64)
 			if err != nil {
 				log.Error("Binlog parser: failed to parse segment id", zap.String("segmentPath", segmentPath), zap.Error(err))
-				return nil, fmt.Errorf("failed to parse segment id from delta log path %s, error: %w", deltalog, err)
+				parseErr = fmt.Errorf("failed to parse segment id from delta log path %s, error: %w", deltalog, err)
+				return false
 			}
 
 			// if the segment id doesn't exist, no need to process this deltalog
@@ -196,7 +202,16 @@ func (p *BinlogParser) constructSegmentHolders(insertlogRoot string, deltalogRoo
 			if ok {
 				holder.deltaFiles = append(holder.deltaFiles, deltalog)
 			}
+			return true
+		})
+		if err != nil {
+			log.Error("Binlog parser: failed to list delta logs", zap.Error(err))
+			return nil, fmt.Errorf("failed to list delta logs, error: %w", err)
+		}
+		if parseErr != nil {
+			return nil, parseErr
 		}
+		log.Info("Binlog parser: list delta logs", zap.Int("logsCount", deltalogCount))
 	}
 
 	// since the map in golang is not sorted, we sort the segment id array to return holder list with ascending sequence
@@ -221,6 +236,7 @@ func (p *BinlogParser) parseSegmentFiles(segmentHolder *SegmentFilesHolder) erro
 		log.Error("Binlog parser: failed to create binlog adapter", zap.Error(err))
 		return fmt.Errorf("failed to create binlog adapter, error: %w", err)
 	}
+	adapter.shardRouting = p.shardRouting
 
 	return adapter.Read(segmentHolder)
 }
@@ -82,6 +82,7 @@ type ImportWrapper struct {
 	cancel           context.CancelFunc         // for canceling parse process
 	collectionSchema *schemapb.CollectionSchema // collection schema
 	shardNum         int32                      // sharding number of the collection
+	shardRouting     string                     // how primary keys are routed to shards, see common.ShardRouting*
 	segmentSize      int64                      // maximum size of a segment(unit:byte) defined by dataCoord.segment.maxSize (milvus.yml)
 	rowIDAllocator   *allocator.IDAllocator     // autoid allocator
 	chunkManager     storage.ChunkManager
@@ -139,6 +140,11 @@ func NewImportWrapper(ctx context.Context, collectionSchema *schemapb.Collection
 	return wrapper
 }
 
+// SetShardRouting sets how primary keys are routed to shards, it must be the same as the collection's routing
+func (p *ImportWrapper) SetShardRouting(routing string) {
+	p.shardRouting = routing
+}
+
 func (p *ImportWrapper) SetCallbackFunctions(assignSegmentFunc AssignSegmentFunc, createBinlogsFunc CreateBinlogsFunc, saveSegmentFunc SaveSegmentFunc) error {
 	if assignSegmentFunc == nil {
 		log.Error("import wrapper: callback function AssignSegmentFunc is nil")
@@ -248,13 +254,22 @@ func (p *ImportWrapper) fileValidation(filePaths []string) (bool, error) {
 			return rowBased, fmt.Errorf("duplicate file: '%s'", filePath)
 		}
 		fileNames[name] = struct{}{}
+	}
 
-		// check file size, single file size cannot exceed MaxFileSize
-		size, err := p.chunkManager.Size(p.ctx, filePath)
-		if err != nil {
-			log.Error("import wrapper: failed to get file size", zap.String("filePath", filePath), zap.Error(err))
-			return rowBased, fmt.Errorf("failed to get file size of '%s', error:%w", filePath, err)
+	// check file size, single file size cannot exceed MaxFileSize
+	// fetch all the object infos in one batch to avoid a round trip per file
+	infos, err := p.chunkManager.MultiStat(p.ctx, filePaths)
+	if err != nil {
+		log.Error("import wrapper: failed to get file sizes", zap.Strings("filePaths", filePaths), zap.Error(err))
+		return rowBased, fmt.Errorf("failed to get file sizes, error:%w", err)
+	}
+
+	for i, filePath := range filePaths {
+		if i >= len(infos) || infos[i] == nil {
+			log.Error("import wrapper: file doesn't exist", zap.String("filePath", filePath))
+			return rowBased, fmt.Errorf("failed to get file size of '%s', error:file doesn't exist", filePath)
 		}
+		size := infos[i].Size
 
 		// empty file
 		if size == 0 {
@@ -489,6 +504,7 @@ func (p *ImportWrapper) doBinlogImport(filePaths []string, tsStartPoint uint64,
 	if err != nil {
 		return err
 	}
+	parser.shardRouting = p.shardRouting
 
 	err = parser.Parse(filePaths)
 	if err != nil {
@@ -532,6 +548,7 @@ func (p *ImportWrapper) parseRowBasedJSON(filePath string, onlyValidate bool) er
 	if err != nil {
 		return err
 	}
+	consumer.shardRouting = p.shardRouting
 
 	err = parser.ParseRows(reader, consumer)
 	if err != nil {
@@ -595,83 +612,6 @@ func (p *ImportWrapper) parseColumnBasedNumpy(filePath string, onlyValidate bool
 	return nil
 }
 
-// appendFunc defines the methods to append data to storage.FieldData
-func (p *ImportWrapper) appendFunc(schema *schemapb.FieldSchema) func(src storage.FieldData, n int, target storage.FieldData) error {
-	switch schema.DataType {
-	case schemapb.DataType_Bool:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.BoolFieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).(bool))
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_Float:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.FloatFieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).(float32))
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_Double:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.DoubleFieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).(float64))
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_Int8:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.Int8FieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).(int8))
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_Int16:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.Int16FieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).(int16))
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_Int32:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.Int32FieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).(int32))
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_Int64:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.Int64FieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).(int64))
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_BinaryVector:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.BinaryVectorFieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).([]byte)...)
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_FloatVector:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.FloatVectorFieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).([]float32)...)
-			arr.NumRows[0]++
-			return nil
-		}
-	case schemapb.DataType_String, schemapb.DataType_VarChar:
-		return func(src storage.FieldData, n int, target storage.FieldData) error {
-			arr := target.(*storage.StringFieldData)
-			arr.Data = append(arr.Data, src.GetRow(n).(string))
-			return nil
-		}
-	default:
-		return nil
-	}
-}
-
 // splitFieldsData is to split the in-memory data(parsed from column-based files) into blocks, each block save to a binlog file
 func (p *ImportWrapper) splitFieldsData(fieldsData map[storage.FieldID]storage.FieldData, blockSize int64) error {
 	if len(fieldsData) == 0 {
@@ -772,17 +712,14 @@ func (p *ImportWrapper) splitFieldsData(fieldsData map[storage.FieldID]storage.F
 		segmentsData = append(segmentsData, segmentData)
 	}
 
-	// prepare append functions
-	appendFunctions := make(map[string]func(src storage.FieldData, n int, target storage.FieldData) error)
-	for i := 0; i < len(p.collectionSchema.Fields); i++ {
-		schema := p.collectionSchema.Fields[i]
-		appendFuncErr := p.appendFunc(schema)
-		if appendFuncErr == nil {
-			log.Error("import wrapper: unsupported field data type")
-			return fmt.Errorf("unsupported field data type: %d", schema.GetDataType())
-		}
-		appendFunctions[schema.GetName()] = appendFuncErr
+	// the fields data is converted to a record, so that the rows are appended to the shards by the typed values
+	// of the columns instead of the values boxed by GetRow
+	record, err := storage.InsertDataToRecord(p.collectionSchema, &storage.InsertData{Data: fieldsData})
+	if err != nil {
+		log.Error("import wrapper: failed to convert fields data to record", zap.Error(err))
+		return fmt.Errorf("failed to convert fields data to record, error: %w", err)
 	}
+	defer record.Release()
 
 	// split data into shards
 	for i := 0; i < rowCount; i++ {
@@ -792,7 +729,7 @@ func (p *ImportWrapper) splitFieldsData(fieldsData map[storage.FieldID]storage.F
 		strPK, ok := interface{}(pk).(string)
 		if ok {
 			hash := typeutil.HashString2Uint32(strPK)
-			shard = hash % uint32(p.shardNum)
+			shard = typeutil.HashKey2Shard(hash, uint32(p.shardNum), p.shardRouting)
 		} else {
 			intPK, ok := interface{}(pk).(int64)
 			if !ok {
@@ -800,7 +737,7 @@ func (p *ImportWrapper) splitFieldsData(fieldsData map[storage.FieldID]storage.F
 				return fmt.Errorf("primary key field must be int64 or varchar")
 			}
 			hash, _ := typeutil.Hash32Int64(intPK)
-			shard = hash % uint32(p.shardNum)
+			shard = typeutil.HashKey2Shard(hash, uint32(p.shardNum), p.shardRouting)
 		}
 
 		// set rowID field
@@ -808,15 +745,10 @@ func (p *ImportWrapper) splitFieldsData(fieldsData map[storage.FieldID]storage.F
 		rowIDField.Data = append(rowIDField.Data, rowIDFieldArr.GetRow(i).(int64))
 
 		// append row to shard
-		for k := 0; k < len(p.collectionSchema.Fields); k++ {
-			schema := p.collectionSchema.Fields[k]
-			srcData := fieldsData[schema.GetFieldID()]
-			targetData := segmentsData[shard][schema.GetFieldID()]
-			appendFunc := appendFunctions[schema.GetName()]
-			err := appendFunc(srcData, i, targetData)
-			if err != nil {
-				return err
-			}
+		err = storage.AppendRecordRow(&storage.InsertData{Data: segmentsData[shard]}, record, i)
+		if err != nil {
+			log.Error("import wrapper: failed to append row to shard", zap.Int("row", i), zap.Error(err))
+			return fmt.Errorf("failed to append row %d to shard %d, error: %w", i, shard, err)
 		}
 
 		// when the estimated size is close to blockSize, force flush
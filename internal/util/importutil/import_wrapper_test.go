@@ -65,14 +65,53 @@ func (mc *MockChunkManager) Reader(ctx context.Context, filePath string) (storag
 	return nil, nil
 }
 
+func (mc *MockChunkManager) Stat(ctx context.Context, filePath string) (storage.ObjectInfo, error) {
+	return storage.ObjectInfo{}, nil
+}
+
+func (mc *MockChunkManager) MultiStat(ctx context.Context, filePaths []string) ([]*storage.ObjectInfo, error) {
+	if mc.sizeErr != nil {
+		return nil, mc.sizeErr
+	}
+	infos := make([]*storage.ObjectInfo, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		infos = append(infos, &storage.ObjectInfo{FilePath: filePath, Size: mc.size})
+	}
+	return infos, nil
+}
+
 func (mc *MockChunkManager) Write(ctx context.Context, filePath string, content []byte) error {
 	return nil
 }
 
+func (mc *MockChunkManager) WriteWithOptions(ctx context.Context, filePath string, content []byte, opts ...storage.WriteOption) error {
+	return nil
+}
+
+func (mc *MockChunkManager) WriteIfNotExist(ctx context.Context, filePath string, content []byte) error {
+	return nil
+}
+
 func (mc *MockChunkManager) MultiWrite(ctx context.Context, contents map[string][]byte) error {
 	return nil
 }
 
+func (mc *MockChunkManager) Append(ctx context.Context, filePath string, content []byte) error {
+	return nil
+}
+
+func (mc *MockChunkManager) Copy(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	return nil
+}
+
+func (mc *MockChunkManager) Move(ctx context.Context, srcFilePath string, dstFilePath string) error {
+	return nil
+}
+
+func (mc *MockChunkManager) PresignURL(ctx context.Context, filePath string, method string, expiry time.Duration) (string, error) {
+	return "", nil
+}
+
 func (mc *MockChunkManager) Exist(ctx context.Context, filePath string) (bool, error) {
 	return true, nil
 }
@@ -107,6 +146,19 @@ func (mc *MockChunkManager) ListWithPrefix(ctx context.Context, prefix string, r
 	return nil, nil, nil
 }
 
+func (mc *MockChunkManager) WalkWithPrefix(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) error {
+	if mc.listErr != nil {
+		return mc.listErr
+	}
+
+	for _, filePath := range mc.listResult[prefix] {
+		if !walkFunc(storage.ChunkObjectInfo{FilePath: filePath}) {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (mc *MockChunkManager) ReadWithPrefix(ctx context.Context, prefix string) ([]string, [][]byte, error) {
 	return nil, nil, nil
 }
@@ -115,6 +167,10 @@ func (mc *MockChunkManager) ReadAt(ctx context.Context, filePath string, off int
 	return nil, nil
 }
 
+func (mc *MockChunkManager) MultiReadAt(ctx context.Context, filePath string, ranges []storage.Range) ([][]byte, error) {
+	return nil, nil
+}
+
 func (mc *MockChunkManager) Mmap(ctx context.Context, filePath string) (*mmap.ReaderAt, error) {
 	return nil, nil
 }
@@ -139,6 +195,10 @@ func (mc *MockChunkManager) RemoveWithPrefix(ctx context.Context, prefix string)
 	return nil
 }
 
+func (mc *MockChunkManager) PrefixSize(ctx context.Context, prefix string) (int64, int64, error) {
+	return 0, 0, nil
+}
+
 type rowCounterTest struct {
 	rowCount int
 	callTime int
@@ -1116,6 +1176,15 @@ func Test_ImportWrapperSplitFieldsData(t *testing.T) {
 	assert.Equal(t, 0, len(importResult.AutoIds))
 	assert.Equal(t, 2, rowCounter.callTime)
 	assert.Equal(t, rowCount, rowCounter.rowCount)
+
+	// jump hash routing, success
+	wrapper.SetShardRouting(common.ShardRoutingJumpHash)
+	rowCounter.callTime = 0
+	rowCounter.rowCount = 0
+	err = wrapper.splitFieldsData(input, 1024)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rowCounter.callTime)
+	assert.Equal(t, rowCount, rowCounter.rowCount)
 }
 
 func Test_ImportWrapperReportPersisted(t *testing.T) {
@@ -54,6 +54,7 @@ type JSONRowConsumer struct {
 	validators       map[storage.FieldID]*Validator          // validators for each field
 	rowCounter       int64                                   // how many rows have been consumed
 	shardNum         int32                                   // sharding number of the collection
+	shardRouting     string                                  // how primary keys are routed to shards, see common.ShardRouting*
 	segmentsData     []map[storage.FieldID]storage.FieldData // in-memory segments data
 	blockSize        int64                                   // maximum size of a read block(unit:byte)
 	primaryKey       storage.FieldID                         // name of primary key
@@ -237,7 +238,7 @@ func (v *JSONRowConsumer) Handle(rows []map[storage.FieldID]interface{}) error {
 			value := row[v.primaryKey]
 			pk := string(value.(string))
 			hash := typeutil.HashString2Uint32(pk)
-			shard = hash % uint32(v.shardNum)
+			shard = typeutil.HashKey2Shard(hash, uint32(v.shardNum), v.shardRouting)
 			pkArray := v.segmentsData[shard][v.primaryKey].(*storage.StringFieldData)
 			pkArray.Data = append(pkArray.Data, pk)
 			pkArray.NumRows[0]++
@@ -266,7 +267,7 @@ func (v *JSONRowConsumer) Handle(rows []map[storage.FieldID]interface{}) error {
 				return fmt.Errorf("failed to hash primary key %d at the row %d, error: %w", pk, v.rowCounter+int64(i), err)
 			}
 
-			shard = hash % uint32(v.shardNum)
+			shard = typeutil.HashKey2Shard(hash, uint32(v.shardNum), v.shardRouting)
 			pkArray := v.segmentsData[shard][v.primaryKey].(*storage.Int64FieldData)
 			pkArray.Data = append(pkArray.Data, pk)
 			pkArray.NumRows[0]++
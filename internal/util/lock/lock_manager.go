@@ -0,0 +1,296 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// ErrDeadlock is returned when waiting for a key would never end,
+// because the holders of the key are waiting for the keys held by the waiter, directly or not.
+var ErrDeadlock = errors.New("deadlock detected")
+
+// Mode is the mode a key is locked in.
+type Mode int
+
+const (
+	// Shared keys are held by many guards at the same time.
+	Shared Mode = iota
+	// Exclusive keys are held by one guard at a time.
+	Exclusive
+)
+
+func (m Mode) String() string {
+	if m == Exclusive {
+		return "exclusive"
+	}
+	return "shared"
+}
+
+// Request requests to lock a key in a mode.
+type Request struct {
+	Key  string
+	Mode Mode
+}
+
+// SharedKey requests to lock @key in the shared mode.
+func SharedKey(key string) Request {
+	return Request{Key: key, Mode: Shared}
+}
+
+// ExclusiveKey requests to lock @key in the exclusive mode.
+func ExclusiveKey(key string) Request {
+	return Request{Key: key, Mode: Exclusive}
+}
+
+type keyState struct {
+	exclusive *Guard
+	shared    map[*Guard]struct{}
+	// released is closed and renewed whenever the key is released, to wake up the waiters
+	released chan struct{}
+	waiters  int
+	// exclusiveWaiters blocks the new shared requests, so that the exclusive ones won't starve
+	exclusiveWaiters int
+}
+
+func newKeyState() *keyState {
+	return &keyState{
+		shared:   make(map[*Guard]struct{}),
+		released: make(chan struct{}),
+	}
+}
+
+func (s *keyState) grantable(g *Guard, mode Mode) bool {
+	if s.exclusive != nil && s.exclusive != g {
+		return false
+	}
+	if mode == Shared {
+		_, held := s.shared[g]
+		return held || s.exclusive == g || s.exclusiveWaiters == 0
+	}
+	_, held := s.shared[g]
+	return len(s.shared) == 0 || (len(s.shared) == 1 && held)
+}
+
+func (s *keyState) idle() bool {
+	return s.exclusive == nil && len(s.shared) == 0 && s.waiters == 0
+}
+
+// LockManager locks string keys, such as the ids of the collections and segments, in shared or exclusive mode,
+// so that the operations on different keys don't serialize each other like they do with a global mutex.
+//
+// The keys are held by guards, a guard locks keys in the order requested and releases them all at once.
+// Acquiring keys in a consistent order, such as collection before segment, avoids deadlocks,
+// the manager detects the ones caused by inconsistent orders and fails the acquisition closing the cycle with ErrDeadlock.
+type LockManager struct {
+	name string
+
+	mu   sync.Mutex
+	keys map[string]*keyState
+	// waiting maps the guards to the requests they're waiting for
+	waiting map[*Guard]Request
+}
+
+// NewLockManager creates a LockManager, @name labels the lock metrics.
+func NewLockManager(name string) *LockManager {
+	return &LockManager{
+		name:    name,
+		keys:    make(map[string]*keyState),
+		waiting: make(map[*Guard]Request),
+	}
+}
+
+// Guard holds the keys locked by a LockManager, a guard is not safe for concurrent use.
+type Guard struct {
+	manager *LockManager
+	held    map[string]Mode
+}
+
+// Acquire locks the keys of @reqs in order for a new guard, the guard must be released after use.
+// It's failed if @ctx is done or a deadlock is detected while waiting, no key remains locked then.
+func (m *LockManager) Acquire(ctx context.Context, reqs ...Request) (*Guard, error) {
+	g := &Guard{manager: m, held: make(map[string]Mode)}
+	if err := g.Acquire(ctx, reqs...); err != nil {
+		g.Release()
+		return nil, err
+	}
+	return g, nil
+}
+
+// Acquire locks more keys of @reqs in order for the guard, a shared key held by the guard is upgraded to exclusive if requested.
+// The keys already locked remain held if it's failed.
+func (g *Guard) Acquire(ctx context.Context, reqs ...Request) error {
+	for _, req := range reqs {
+		if err := g.manager.lock(ctx, g, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Release releases all keys held by the guard.
+func (g *Guard) Release() {
+	m := g.manager
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range g.held {
+		s := m.keys[key]
+		if s.exclusive == g {
+			s.exclusive = nil
+		}
+		delete(s.shared, g)
+		close(s.released)
+		s.released = make(chan struct{})
+		if s.idle() {
+			delete(m.keys, key)
+		}
+	}
+	g.held = make(map[string]Mode)
+}
+
+func (m *LockManager) lock(ctx context.Context, g *Guard, req Request) error {
+	if mode, ok := g.held[req.Key]; ok && mode >= req.Mode {
+		return nil
+	}
+	start := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.keys[req.Key]
+	if !ok {
+		s = newKeyState()
+		m.keys[req.Key] = s
+	}
+	for !s.grantable(g, req.Mode) {
+		m.waiting[g] = req
+		if m.deadlocked(g) {
+			delete(m.waiting, g)
+			if s.idle() {
+				delete(m.keys, req.Key)
+			}
+			metrics.LockDeadlockCount.WithLabelValues(m.name).Inc()
+			log.Warn("deadlock detected, give up acquiring the key",
+				zap.String("lock", m.name),
+				zap.String("key", req.Key),
+				zap.Stringer("mode", req.Mode),
+				zap.Int("heldKeys", len(g.held)))
+			return fmt.Errorf("%w: failed to lock key %s in %s mode of %s", ErrDeadlock, req.Key, req.Mode, m.name)
+		}
+		err := m.wait(ctx, s, req.Mode)
+		delete(m.waiting, g)
+		if err != nil {
+			if s.idle() {
+				delete(m.keys, req.Key)
+			}
+			return err
+		}
+	}
+
+	if req.Mode == Exclusive {
+		delete(s.shared, g)
+		s.exclusive = g
+	} else if s.exclusive != g {
+		s.shared[g] = struct{}{}
+	}
+	g.held[req.Key] = req.Mode
+	metrics.LockWaitLatency.WithLabelValues(m.name, req.Mode.String()).Observe(float64(time.Since(start).Milliseconds()))
+	return nil
+}
+
+// wait waits until @s is released or @ctx is done, m.mu must be held and is held again on return.
+func (m *LockManager) wait(ctx context.Context, s *keyState, mode Mode) error {
+	s.waiters++
+	if mode == Exclusive {
+		s.exclusiveWaiters++
+	}
+	released := s.released
+	m.mu.Unlock()
+
+	var err error
+	select {
+	case <-released:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	m.mu.Lock()
+	s.waiters--
+	if mode == Exclusive {
+		s.exclusiveWaiters--
+		// the shared waiters blocked by this one may be granted now
+		close(s.released)
+		s.released = make(chan struct{})
+	}
+	return err
+}
+
+// blockers returns the guards which @g waits for to be granted @req.
+func (m *LockManager) blockers(g *Guard, req Request) []*Guard {
+	s := m.keys[req.Key]
+	var blockers []*Guard
+	if s.exclusive != nil && s.exclusive != g {
+		blockers = append(blockers, s.exclusive)
+	}
+	if req.Mode == Exclusive {
+		for holder := range s.shared {
+			if holder != g {
+				blockers = append(blockers, holder)
+			}
+		}
+	} else if _, held := s.shared[g]; !held && s.exclusiveWaiters > 0 {
+		for waiter, waiting := range m.waiting {
+			if waiter != g && waiting.Key == req.Key && waiting.Mode == Exclusive {
+				blockers = append(blockers, waiter)
+			}
+		}
+	}
+	return blockers
+}
+
+// deadlocked checks whether the wait-for graph has a cycle through @g, m.mu must be held.
+func (m *LockManager) deadlocked(g *Guard) bool {
+	visited := make(map[*Guard]struct{})
+	stack := m.blockers(g, m.waiting[g])
+	for len(stack) > 0 {
+		blocker := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if blocker == g {
+			return true
+		}
+		if _, ok := visited[blocker]; ok {
+			continue
+		}
+		visited[blocker] = struct{}{}
+		if req, ok := m.waiting[blocker]; ok {
+			stack = append(stack, m.blockers(blocker, req)...)
+		}
+	}
+	return false
+}
+
+func (m *LockManager) size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.keys)
+}
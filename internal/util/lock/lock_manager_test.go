@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acquireAsync acquires @reqs in another goroutine, the result is sent to the returned channel.
+func acquireAsync(m *LockManager, reqs ...Request) <-chan *Guard {
+	ch := make(chan *Guard, 1)
+	go func() {
+		g, err := m.Acquire(context.Background(), reqs...)
+		if err != nil {
+			g = nil
+		}
+		ch <- g
+	}()
+	return ch
+}
+
+// waitForWaiters waits until @n guards are waiting for the keys of @m.
+func waitForWaiters(t *testing.T, m *LockManager, n int) {
+	assert.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.waiting) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestLockManager(t *testing.T) {
+	ctx := context.Background()
+	m := NewLockManager("test")
+
+	shared, err := m.Acquire(ctx, SharedKey("collection-1"), ExclusiveKey("segment-1"))
+	require.NoError(t, err)
+
+	// the other segments of the collection aren't blocked
+	g, err := m.Acquire(ctx, SharedKey("collection-1"), ExclusiveKey("segment-2"))
+	require.NoError(t, err)
+	g.Release()
+
+	segment := acquireAsync(m, SharedKey("collection-1"), ExclusiveKey("segment-1"))
+	waitForWaiters(t, m, 1)
+	shared.Release()
+	shared = <-segment
+	require.NotNil(t, shared)
+
+	collection := acquireAsync(m, ExclusiveKey("collection-1"))
+	waitForWaiters(t, m, 1)
+	// the exclusive waiter blocks the new shared requests
+	other := acquireAsync(m, SharedKey("collection-1"))
+	waitForWaiters(t, m, 2)
+
+	shared.Release()
+	g = <-collection
+	require.NotNil(t, g)
+	waitForWaiters(t, m, 1)
+	g.Release()
+	g = <-other
+	require.NotNil(t, g)
+	g.Release()
+
+	assert.Equal(t, 0, m.size())
+}
+
+func TestLockManager_Reentrant(t *testing.T) {
+	ctx := context.Background()
+	m := NewLockManager("test")
+
+	g, err := m.Acquire(ctx, SharedKey("a"), SharedKey("a"))
+	require.NoError(t, err)
+	// upgrade the only shared holder
+	require.NoError(t, g.Acquire(ctx, ExclusiveKey("a"), SharedKey("a")))
+
+	waiter := acquireAsync(m, SharedKey("a"))
+	waitForWaiters(t, m, 1)
+	g.Release()
+	g = <-waiter
+	require.NotNil(t, g)
+	g.Release()
+	assert.Equal(t, 0, m.size())
+}
+
+func TestLockManager_Deadlock(t *testing.T) {
+	ctx := context.Background()
+	m := NewLockManager("test")
+
+	g1, err := m.Acquire(ctx, ExclusiveKey("a"))
+	require.NoError(t, err)
+	g2, err := m.Acquire(ctx, ExclusiveKey("b"))
+	require.NoError(t, err)
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- g1.Acquire(ctx, ExclusiveKey("b"))
+	}()
+	waitForWaiters(t, m, 1)
+
+	// g2 closes the cycle
+	err = g2.Acquire(ctx, ExclusiveKey("a"))
+	assert.ErrorIs(t, err, ErrDeadlock)
+	g2.Release()
+	assert.NoError(t, <-acquired)
+	g1.Release()
+
+	// both shared holders upgrading
+	g1, err = m.Acquire(ctx, SharedKey("a"))
+	require.NoError(t, err)
+	g2, err = m.Acquire(ctx, SharedKey("a"))
+	require.NoError(t, err)
+	go func() {
+		acquired <- g1.Acquire(ctx, ExclusiveKey("a"))
+	}()
+	waitForWaiters(t, m, 1)
+	err = g2.Acquire(ctx, ExclusiveKey("a"))
+	assert.ErrorIs(t, err, ErrDeadlock)
+	g2.Release()
+	assert.NoError(t, <-acquired)
+	g1.Release()
+
+	assert.Equal(t, 0, m.size())
+}
+
+func TestLockManager_Canceled(t *testing.T) {
+	m := NewLockManager("test")
+	g, err := m.Acquire(context.Background(), SharedKey("a"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = m.Acquire(ctx, SharedKey("b"), ExclusiveKey("a"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the canceled exclusive waiter doesn't block the shared requests any more
+	other, err := m.Acquire(context.Background(), SharedKey("a"))
+	require.NoError(t, err)
+	other.Release()
+	g.Release()
+	assert.Equal(t, 0, m.size())
+}
@@ -0,0 +1,154 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsinfo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// FieldStatsSketchSize is the number of the smallest value hashes kept to estimate the number of distinct values
+	FieldStatsSketchSize = 256
+	// FieldStatsSampleSize is the number of the numeric values sampled to build histograms
+	FieldStatsSampleSize = 256
+)
+
+// FieldStatistics are the live statistics of a scalar field, which are built from the loaded and inserted data
+// of a collection without waiting for the statistics of compactions, and could be merged across segments and nodes.
+type FieldStatistics struct {
+	RowCount int64 `json:"row_count"`
+	// Sketch is a KMV sketch, the smallest distinct hashes of the values in ascending order
+	Sketch []uint64 `json:"sketch,omitempty"`
+	// Sample is a uniform sample of the values of numeric fields, in no particular order
+	Sample []float64 `json:"sample,omitempty"`
+}
+
+// Observe adds a value by its hash, the hashes must be uniformly distributed.
+func (s *FieldStatistics) Observe(hash uint64) {
+	s.RowCount++
+	s.addHash(hash)
+}
+
+// ObserveNumber adds a numeric value by its hash, the value is sampled by reservoir sampling.
+func (s *FieldStatistics) ObserveNumber(hash uint64, value float64) {
+	s.Observe(hash)
+	if len(s.Sample) < FieldStatsSampleSize {
+		s.Sample = append(s.Sample, value)
+		return
+	}
+	if i := rand.Int63n(s.RowCount); i < FieldStatsSampleSize {
+		s.Sample[i] = value
+	}
+}
+
+func (s *FieldStatistics) addHash(hash uint64) {
+	full := len(s.Sketch) >= FieldStatsSketchSize
+	if full && hash >= s.Sketch[len(s.Sketch)-1] {
+		return
+	}
+	i := sort.Search(len(s.Sketch), func(i int) bool { return s.Sketch[i] >= hash })
+	if i < len(s.Sketch) && s.Sketch[i] == hash {
+		return
+	}
+	if full {
+		s.Sketch = s.Sketch[:len(s.Sketch)-1]
+	}
+	s.Sketch = append(s.Sketch, 0)
+	copy(s.Sketch[i+1:], s.Sketch[i:])
+	s.Sketch[i] = hash
+}
+
+// NDV returns the estimated number of distinct values.
+func (s *FieldStatistics) NDV() int64 {
+	if len(s.Sketch) < FieldStatsSketchSize {
+		return int64(len(s.Sketch))
+	}
+	// the k-th smallest of n uniform hashes is about k/n of the hash space
+	kth := float64(s.Sketch[FieldStatsSketchSize-1]) / math.MaxUint64
+	ndv := int64(float64(FieldStatsSketchSize-1) / kth)
+	if ndv > s.RowCount {
+		return s.RowCount
+	}
+	return ndv
+}
+
+// Histogram returns the bounds of an equi-depth histogram with @buckets buckets built from the sample,
+// the i-th bucket is between the i-th and the (i+1)-th bounds. Returns nil if the field is not numeric.
+func (s *FieldStatistics) Histogram(buckets int) []float64 {
+	if len(s.Sample) == 0 || buckets <= 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), s.Sample...)
+	sort.Float64s(sorted)
+	bounds := make([]float64, 0, buckets+1)
+	for i := 0; i <= buckets; i++ {
+		bounds = append(bounds, sorted[(len(sorted)-1)*i/buckets])
+	}
+	return bounds
+}
+
+// Clone returns a deep copy.
+func (s *FieldStatistics) Clone() *FieldStatistics {
+	return &FieldStatistics{
+		RowCount: s.RowCount,
+		Sketch:   append([]uint64(nil), s.Sketch...),
+		Sample:   append([]float64(nil), s.Sample...),
+	}
+}
+
+// MergeFieldStatistics merges the statistics of disjoint data, like the segments of a collection,
+// the samples are taken in proportion to the row counts.
+func MergeFieldStatistics(a, b *FieldStatistics) *FieldStatistics {
+	if a == nil {
+		return b.Clone()
+	}
+	if b == nil {
+		return a.Clone()
+	}
+	merged := &FieldStatistics{RowCount: a.RowCount + b.RowCount}
+	merged.Sketch = append(merged.Sketch, a.Sketch...)
+	for _, hash := range b.Sketch {
+		merged.addHash(hash)
+	}
+
+	if len(a.Sample)+len(b.Sample) <= FieldStatsSampleSize {
+		merged.Sample = append(append(merged.Sample, a.Sample...), b.Sample...)
+		return merged
+	}
+	fromA := int(math.Round(float64(FieldStatsSampleSize) * float64(a.RowCount) / float64(merged.RowCount)))
+	if fromA > len(a.Sample) {
+		fromA = len(a.Sample)
+	}
+	fromB := FieldStatsSampleSize - fromA
+	if fromB > len(b.Sample) {
+		fromB = len(b.Sample)
+		fromA = FieldStatsSampleSize - fromB
+	}
+	merged.Sample = append(append(merged.Sample, strideSample(a.Sample, fromA)...), strideSample(b.Sample, fromB)...)
+	return merged
+}
+
+// strideSample picks @n values evenly spaced in @sample, as a sample not yet full keeps the values in insertion order.
+func strideSample(sample []float64, n int) []float64 {
+	picked := make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		picked = append(picked, sample[i*len(sample)/n])
+	}
+	return picked
+}
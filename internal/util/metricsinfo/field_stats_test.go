@@ -0,0 +1,74 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsinfo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/spaolacci/murmur3"
+	"github.com/stretchr/testify/assert"
+)
+
+func hashInt(v int64) uint64 {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(v))
+	return murmur3.Sum64(buf)
+}
+
+func TestFieldStatistics(t *testing.T) {
+	stats := &FieldStatistics{}
+	assert.Equal(t, int64(0), stats.NDV())
+	assert.Nil(t, stats.Histogram(4))
+
+	for i := 0; i < 100; i++ {
+		stats.ObserveNumber(hashInt(int64(i%10)), float64(i%10))
+	}
+	assert.Equal(t, int64(100), stats.RowCount)
+	assert.Equal(t, int64(10), stats.NDV())
+	assert.Len(t, stats.Sample, 100)
+	assert.Equal(t, []float64{0, 2, 4, 7, 9}, stats.Histogram(4))
+
+	large := &FieldStatistics{}
+	for i := 0; i < 100000; i++ {
+		large.ObserveNumber(hashInt(int64(i)), float64(i))
+	}
+	assert.Len(t, large.Sketch, FieldStatsSketchSize)
+	assert.Len(t, large.Sample, FieldStatsSampleSize)
+	assert.InDelta(t, 100000, large.NDV(), 20000)
+	bounds := large.Histogram(2)
+	assert.InDelta(t, 50000, bounds[1], 15000)
+
+	merged := MergeFieldStatistics(stats, large)
+	assert.Equal(t, int64(100100), merged.RowCount)
+	assert.Len(t, merged.Sample, FieldStatsSampleSize)
+	assert.InDelta(t, 100010, merged.NDV(), 20000)
+	// inputs are not modified
+	assert.Len(t, stats.Sample, 100)
+
+	// the same values in two segments are counted once
+	merged = MergeFieldStatistics(stats, stats)
+	assert.Equal(t, int64(10), merged.NDV())
+	assert.Len(t, merged.Sample, 200)
+
+	assert.Equal(t, stats, MergeFieldStatistics(nil, stats))
+	varchar := &FieldStatistics{}
+	varchar.Observe(1)
+	varchar.Observe(1)
+	assert.Equal(t, int64(1), varchar.NDV())
+	assert.Nil(t, varchar.Histogram(4))
+}
@@ -100,6 +100,8 @@ type QueryNodeInfos struct {
 	BaseComponentInfos
 	SystemConfigurations QueryNodeConfiguration `json:"system_configurations"`
 	QuotaMetrics         *QueryNodeQuotaMetrics `json:"quota_metrics"`
+	// FieldStats are the live statistics of the scalar fields of the loaded collections, collectionID -> fieldID -> stats
+	FieldStats map[int64]map[int64]*FieldStatistics `json:"field_stats,omitempty"`
 }
 
 // QueryCoordConfiguration records the configuration of QueryCoord.
@@ -62,6 +62,10 @@ type QueryNodeQuotaMetrics struct {
 	Fgm         FlowGraphMetric
 	SearchQueue ReadInfoInQueue
 	QueryQueue  ReadInfoInQueue
+	// LagSLOViolatedCollections are the collections whose serviceable lag exceeds the SLO threshold
+	LagSLOViolatedCollections []int64
+	// ServiceableLags are the serviceable lags of the collections, collectionID -> lag
+	ServiceableLags map[int64]time.Duration
 }
 
 type DataCoordQuotaMetrics struct {
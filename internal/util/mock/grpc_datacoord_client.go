@@ -173,3 +173,7 @@ func (m *GrpcDataCoordClient) BroadcastAlteredCollection(ctx context.Context, in
 	return &commonpb.Status{}, m.Err
 
 }
+
+func (m *GrpcDataCoordClient) FreshSearch(ctx context.Context, in *datapb.FreshSearchRequest, opts ...grpc.CallOption) (*datapb.FreshSearchResponse, error) {
+	return &datapb.FreshSearchResponse{}, m.Err
+}
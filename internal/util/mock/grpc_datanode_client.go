@@ -80,3 +80,7 @@ func (m *GrpcDataNodeClient) AddImportSegment(ctx context.Context, in *datapb.Ad
 func (m *GrpcDataNodeClient) SyncSegments(ctx context.Context, in *datapb.SyncSegmentsRequest, opts ...grpc.CallOption) (*commonpb.Status, error) {
 	return &commonpb.Status{}, m.Err
 }
+
+func (m *GrpcDataNodeClient) FreshSearch(ctx context.Context, in *datapb.FreshSearchRequest, opts ...grpc.CallOption) (*datapb.FreshSearchResponse, error) {
+	return &datapb.FreshSearchResponse{}, m.Err
+}
@@ -12,6 +12,7 @@
 package paramtable
 
 import (
+	"fmt"
 	"math"
 	"os"
 	"runtime"
@@ -25,6 +26,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/log"
+	"github.com/milvus-io/milvus/internal/util"
 )
 
 const (
@@ -150,6 +152,49 @@ type commonConfig struct {
 
 	StorageType string
 	SimdType    string
+	// StorageReadOnly forbids mutating the object storage, e.g. for the secondary clusters sharing the bucket.
+	StorageReadOnly bool
+	// StorageDedupEnabled stores the objects no smaller than StorageDedupMinSize bytes by their content hashes.
+	StorageDedupEnabled bool
+	StorageDedupMinSize int64
+	// StorageMemoryCapacity bounds the total bytes of the objects kept by the memory storage, 0 means no limit.
+	StorageMemoryCapacity int64
+	// StorageTenantDefaultQuota bounds the bytes of every storage tenant, 0 means no limit.
+	StorageTenantDefaultQuota int64
+	// StorageTenantQuotas overrides StorageTenantDefaultQuota of the tenants.
+	StorageTenantQuotas map[string]int64
+	// StorageAuditFilePath is the file recording the destructive storage operations, empty disables the audit.
+	StorageAuditFilePath  string
+	StorageAuditMaxSizeMB int
+	// StorageAuditOverwrite records the writes replacing existing objects as well, which stats every written object.
+	StorageAuditOverwrite bool
+
+	// BloomFilterFalsePositiveRate is the target false positive rate of the pk bloom filters,
+	// whose sizes are estimated from the row counts of the segments.
+	BloomFilterFalsePositiveRate float64
+	// BloomFilterMaxSize caps the bytes of a single pk bloom filter, 0 means no limit.
+	BloomFilterMaxSize int64
+	// BloomFilterLazyLoad loads the pk bloom filters of the sealed segments on demand,
+	// keeping at most BloomFilterCacheSize segments' of them in memory.
+	BloomFilterLazyLoad  bool
+	BloomFilterCacheSize int
+
+	// BinlogFormatVersion is the format of the insert binlogs written, 1 for the event binlogs,
+	// 2 for the parquet binlogs. Both formats are always readable.
+	BinlogFormatVersion int
+	// BinlogRowGroupSize is the size in bytes of the data of a row group of the parquet binlogs.
+	BinlogRowGroupSize int64
+	// BinlogPayloadCompression is the codec of the scalar payloads of the insert binlogs, none, zstd or lz4,
+	// it's overridden by the collection property collection.payload.compression.
+	BinlogPayloadCompression string
+	// BinlogDictionaryMaxCardinality is the max number of distinct values of the VarChar payloads dictionary-encoded
+	// without falling back to plain, queryNode keeps the fields of such binlogs dictionary-encoded in memory.
+	BinlogDictionaryMaxCardinality int
+
+	// MetaCompactPaths makes datacoord keep the binlog paths under other root paths as references to the
+	// log path prefixes in meta, and indexcoord keep the numbered index file keys as ID ranges.
+	// The versions before can't read such meta, so it's off until downgrades are ruled out.
+	MetaCompactPaths bool
 
 	AuthorizationEnabled bool
 
@@ -157,6 +202,16 @@ type commonConfig struct {
 
 	SessionTTL        int64
 	SessionRetryTimes int64
+
+	// RoleRestartPolicy is the restart policy of the roles running in one process, "never" or "onFailure".
+	RoleRestartPolicy string
+	// RoleMaxRestarts is the max consecutive restarts of a role before the process exits.
+	RoleMaxRestarts    int
+	RoleRestartBackoff time.Duration
+	// RoleUnhealthyThreshold is the number of abnormal health checks in a row to restart a role,
+	// which are done every RoleHealthCheckInterval.
+	RoleHealthCheckInterval time.Duration
+	RoleUnhealthyThreshold  int
 }
 
 func (p *commonConfig) init(base *BaseTable) {
@@ -199,6 +254,14 @@ func (p *commonConfig) init(base *BaseTable) {
 	p.initBeamWidthRatio()
 	p.initGracefulTime()
 	p.initStorageType()
+	p.initStorageReadOnly()
+	p.initStorageDedup()
+	p.initStorageMemoryCapacity()
+	p.initStorageTenancy()
+	p.initStorageAudit()
+	p.initBloomFilter()
+	p.initBinlogFormat()
+	p.initMetaCompactPaths()
 	p.initThreadCoreCoefficient()
 
 	p.initEnableAuthorization()
@@ -207,6 +270,8 @@ func (p *commonConfig) init(base *BaseTable) {
 
 	p.initSessionTTL()
 	p.initSessionRetryTimes()
+
+	p.initRoleSupervision()
 }
 
 func (p *commonConfig) initClusterPrefix() {
@@ -438,6 +503,63 @@ func (p *commonConfig) initStorageType() {
 	p.StorageType = p.Base.LoadWithDefault("common.storageType", "minio")
 }
 
+func (p *commonConfig) initStorageReadOnly() {
+	p.StorageReadOnly = p.Base.ParseBool("common.storageReadOnly", false)
+}
+
+func (p *commonConfig) initStorageDedup() {
+	p.StorageDedupEnabled = p.Base.ParseBool("common.storageDedup.enabled", false)
+	p.StorageDedupMinSize = p.Base.ParseInt64WithDefault("common.storageDedup.minSize", 1024*1024)
+}
+
+func (p *commonConfig) initStorageMemoryCapacity() {
+	p.StorageMemoryCapacity = p.Base.ParseInt64WithDefault("common.storageMemoryCapacity", 0)
+}
+
+func (p *commonConfig) initStorageTenancy() {
+	p.StorageTenantDefaultQuota = p.Base.ParseInt64WithDefault("common.storageTenancy.defaultQuota", 0)
+	// comma separated "tenant:bytes"
+	p.StorageTenantQuotas = make(map[string]int64)
+	for _, rule := range strings.Split(p.Base.LoadWithDefault("common.storageTenancy.quotas", ""), ",") {
+		if rule = strings.TrimSpace(rule); rule == "" {
+			continue
+		}
+		i := strings.LastIndex(rule, ":")
+		if i < 0 {
+			panic(fmt.Errorf("invalid storage tenant quota %q, should be tenant:bytes", rule))
+		}
+		quota, err := strconv.ParseInt(strings.TrimSpace(rule[i+1:]), 10, 64)
+		if err != nil {
+			panic(fmt.Errorf("invalid storage tenant quota %q: %w", rule, err))
+		}
+		p.StorageTenantQuotas[strings.TrimSpace(rule[:i])] = quota
+	}
+}
+
+func (p *commonConfig) initStorageAudit() {
+	p.StorageAuditFilePath = p.Base.LoadWithDefault("common.storageAudit.filePath", "")
+	p.StorageAuditMaxSizeMB = p.Base.ParseIntWithDefault("common.storageAudit.maxSize", 300)
+	p.StorageAuditOverwrite = p.Base.ParseBool("common.storageAudit.overwrite", false)
+}
+
+func (p *commonConfig) initBloomFilter() {
+	p.BloomFilterFalsePositiveRate = p.Base.ParseFloatWithDefault("common.bloomFilter.falsePositiveRate", 0.005)
+	p.BloomFilterMaxSize = p.Base.ParseInt64WithDefault("common.bloomFilter.maxSize", 0)
+	p.BloomFilterLazyLoad = p.Base.ParseBool("common.bloomFilter.lazyLoad", false)
+	p.BloomFilterCacheSize = p.Base.ParseIntWithDefault("common.bloomFilter.cacheSize", 1024)
+}
+
+func (p *commonConfig) initBinlogFormat() {
+	p.BinlogFormatVersion = p.Base.ParseIntWithDefault("common.binlog.formatVersion", 1)
+	p.BinlogRowGroupSize = p.Base.ParseInt64WithDefault("common.binlog.rowGroupSize", 8*1024*1024)
+	p.BinlogPayloadCompression = p.Base.LoadWithDefault("common.binlog.payloadCompression", "zstd")
+	p.BinlogDictionaryMaxCardinality = p.Base.ParseIntWithDefault("common.binlog.dictionaryMaxCardinality", 4096)
+}
+
+func (p *commonConfig) initMetaCompactPaths() {
+	p.MetaCompactPaths = p.Base.ParseBool("common.meta.compactPaths", false)
+}
+
 func (p *commonConfig) initEnableAuthorization() {
 	p.AuthorizationEnabled = p.Base.ParseBool("common.security.authorizationEnabled", false)
 }
@@ -454,6 +576,16 @@ func (p *commonConfig) initSessionRetryTimes() {
 	p.SessionRetryTimes = p.Base.ParseInt64WithDefault("common.session.retryTimes", 30)
 }
 
+func (p *commonConfig) initRoleSupervision() {
+	p.RoleRestartPolicy = p.Base.LoadWithDefault("common.roleSupervision.restartPolicy", "never")
+	p.RoleMaxRestarts = p.Base.ParseIntWithDefault("common.roleSupervision.maxRestarts", 5)
+	backoff := p.Base.ParseInt64WithDefault("common.roleSupervision.restartBackoff", 1000)
+	p.RoleRestartBackoff = time.Duration(backoff) * time.Millisecond
+	interval := p.Base.ParseInt64WithDefault("common.roleSupervision.healthCheckInterval", 10)
+	p.RoleHealthCheckInterval = time.Duration(interval) * time.Second
+	p.RoleUnhealthyThreshold = p.Base.ParseIntWithDefault("common.roleSupervision.unhealthyThreshold", 3)
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // --- rootcoord ---
 type rootCoordConfig struct {
@@ -468,6 +600,11 @@ type rootCoordConfig struct {
 	// --- ETCD Path ---
 	ImportTaskSubPath string
 
+	// AutoImportEnabled watches the bucket notifications of AutoImportPrefix and imports new files automatically.
+	AutoImportEnabled     bool
+	AutoImportPrefix      string
+	AutoImportBatchWindow time.Duration
+
 	CreatedTime time.Time
 	UpdatedTime time.Time
 
@@ -483,6 +620,14 @@ func (p *rootCoordConfig) init(base *BaseTable) {
 	p.ImportTaskRetention = p.Base.ParseFloatWithDefault("rootCoord.importTaskRetention", 24*60*60)
 	p.ImportTaskSubPath = "importtask"
 	p.EnableActiveStandby = p.Base.ParseBool("rootCoord.enableActiveStandby", false)
+	p.initAutoImport()
+}
+
+func (p *rootCoordConfig) initAutoImport() {
+	p.AutoImportEnabled = p.Base.ParseBool("rootCoord.autoImport.enabled", false)
+	p.AutoImportPrefix = strings.Trim(p.Base.LoadWithDefault("rootCoord.autoImport.prefix", "auto-import"), "/")
+	window := p.Base.ParseInt64WithDefault("rootCoord.autoImport.batchWindow", 10)
+	p.AutoImportBatchWindow = time.Duration(window) * time.Second
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -504,6 +649,10 @@ type AccessLogConfig struct {
 	MaxBackups int
 	//File path in minIO
 	RemotePath string
+	// if record the segments read by search & query requests
+	SegmentAuditEnable bool
+	// the fraction of search & query requests whose segment access are recorded
+	SegmentAuditSampleRate float64
 }
 
 type proxyConfig struct {
@@ -526,12 +675,47 @@ type proxyConfig struct {
 	MaxRoleNum               int
 	AccessLog                AccessLogConfig
 
+	// InvalidVectorPolicy decides how insert handles vectors containing NaN/Inf.
+	InvalidVectorPolicy string
+	// RejectZeroVector treats all-zero vectors as invalid vectors.
+	RejectZeroVector bool
+
 	// required from QueryCoord
 	SearchResultChannelNames   []string
 	RetrieveResultChannelNames []string
 
 	MaxTaskNum int64
 
+	// ShardLeaderCacheExpiration is how long the shard leaders cached by proxy are used
+	// before fetching them from QueryCoord again, 0 means never expire.
+	ShardLeaderCacheExpiration time.Duration
+
+	// PresignURLEnabled allows privileged users to get presigned object storage urls through proxy.
+	PresignURLEnabled bool
+	// PresignURLMaxExpiry caps how long a presigned url stays valid.
+	PresignURLMaxExpiry time.Duration
+
+	// AdaptiveConsistencyEnabled relaxes the guarantee timestamp of strong and bounded reads
+	// by the serviceable lag of the collection, up to AdaptiveConsistencyMaxStaleness.
+	AdaptiveConsistencyEnabled      bool
+	AdaptiveConsistencyMaxStaleness time.Duration
+	// AdaptiveConsistencyLagRefreshInterval is how often proxy syncs serviceable lags from QueryCoord.
+	AdaptiveConsistencyLagRefreshInterval time.Duration
+	// FreshSearchEnabled merges the searches over the insert buffers of the DataNodes into the searches by default,
+	// and relaxes their guarantee timestamps up to FreshSearchMaxStaleness like adaptive consistency.
+	FreshSearchEnabled      bool
+	FreshSearchMaxStaleness time.Duration
+
+	// QueryResultSpillEnabled writes the query results larger than QueryResultSpillThreshold to the object storage,
+	// and responds their presigned urls valid for QueryResultSpillExpiry instead, if the queries accept it.
+	QueryResultSpillEnabled   bool
+	QueryResultSpillThreshold int64
+	QueryResultSpillExpiry    time.Duration
+
+	// AnalyzerMaxTermsPerRow caps the number of terms analyzed from a row of a VarChar field with analyzer,
+	// 0 means unlimited.
+	AnalyzerMaxTermsPerRow int64
+
 	CreatedTime time.Time
 	UpdatedTime time.Time
 }
@@ -556,6 +740,13 @@ func (p *proxyConfig) init(base *BaseTable) {
 
 	p.initSoPath()
 	p.initAccessLogConfig()
+	p.initVectorValidation()
+	p.initShardLeaderCacheExpiration()
+	p.initPresignURL()
+	p.initAdaptiveConsistency()
+	p.initFreshSearch()
+	p.initQueryResultSpill()
+	p.initAnalyzer()
 }
 
 // InitAlias initialize Alias member.
@@ -639,6 +830,58 @@ func (p *proxyConfig) initMaxDimension() {
 	p.MaxDimension = maxDimension
 }
 
+func (p *proxyConfig) initVectorValidation() {
+	policy := strings.ToLower(p.Base.LoadWithDefault("proxy.vectorValidation.invalidPolicy", util.InvalidVectorPolicyReject))
+	switch policy {
+	case util.InvalidVectorPolicyReject, util.InvalidVectorPolicyZeroFill, util.InvalidVectorPolicySkip:
+	default:
+		panic(fmt.Sprintf("unknown proxy.vectorValidation.invalidPolicy: %s", policy))
+	}
+	p.InvalidVectorPolicy = policy
+	p.RejectZeroVector = p.Base.ParseBool("proxy.vectorValidation.rejectZeroVector", false)
+}
+
+func (p *proxyConfig) initShardLeaderCacheExpiration() {
+	expiration := p.Base.ParseInt64WithDefault("proxy.shardLeaderCacheExpiration", 30)
+	p.ShardLeaderCacheExpiration = time.Duration(expiration) * time.Second
+}
+
+func (p *proxyConfig) initPresignURL() {
+	p.PresignURLEnabled = p.Base.ParseBool("proxy.presignURL.enabled", false)
+	maxExpiry := p.Base.ParseInt64WithDefault("proxy.presignURL.maxExpiry", 3600)
+	p.PresignURLMaxExpiry = time.Duration(maxExpiry) * time.Second
+}
+
+func (p *proxyConfig) initAdaptiveConsistency() {
+	p.AdaptiveConsistencyEnabled = p.Base.ParseBool("proxy.adaptiveConsistency.enabled", false)
+	maxStaleness := p.Base.ParseInt64WithDefault("proxy.adaptiveConsistency.maxStaleness", 5000)
+	p.AdaptiveConsistencyMaxStaleness = time.Duration(maxStaleness) * time.Millisecond
+	interval := p.Base.ParseInt64WithDefault("proxy.adaptiveConsistency.lagRefreshInterval", 3000)
+	p.AdaptiveConsistencyLagRefreshInterval = time.Duration(interval) * time.Millisecond
+}
+
+func (p *proxyConfig) initFreshSearch() {
+	p.FreshSearchEnabled = p.Base.ParseBool("proxy.freshSearch.enabled", false)
+	maxStaleness := p.Base.ParseInt64WithDefault("proxy.freshSearch.maxStaleness", 1000)
+	p.FreshSearchMaxStaleness = time.Duration(maxStaleness) * time.Millisecond
+}
+
+func (p *proxyConfig) initQueryResultSpill() {
+	p.QueryResultSpillEnabled = p.Base.ParseBool("proxy.queryResultSpill.enabled", false)
+	p.QueryResultSpillThreshold = p.Base.ParseInt64WithDefault("proxy.queryResultSpill.threshold", 0)
+	if p.QueryResultSpillThreshold <= 0 {
+		// the results which can't be sent by the grpc server of proxy, whose config is loaded the same way
+		p.QueryResultSpillThreshold = p.Base.ParseInt64WithDefault("grpc.serverMaxSendSize",
+			p.Base.ParseInt64WithDefault("proxy.grpc.serverMaxSendSize", math.MaxInt32))
+	}
+	expiry := p.Base.ParseInt64WithDefault("proxy.queryResultSpill.expiry", 3600)
+	p.QueryResultSpillExpiry = time.Duration(expiry) * time.Second
+}
+
+func (p *proxyConfig) initAnalyzer() {
+	p.AnalyzerMaxTermsPerRow = p.Base.ParseInt64WithDefault("proxy.analyzer.maxTermsPerRow", 8192)
+}
+
 func (p *proxyConfig) initMaxTaskNum() {
 	p.MaxTaskNum = p.Base.ParseInt64WithDefault("proxy.maxTaskNum", 1024)
 }
@@ -682,6 +925,10 @@ func (p *proxyConfig) initAccessLogConfig() {
 	if minioEnable {
 		p.initAccessLogMinioConfig()
 	}
+
+	if enable {
+		p.initAccessLogSegmentAuditConfig()
+	}
 }
 
 func (p *proxyConfig) initAccessLogFileConfig() {
@@ -697,6 +944,15 @@ func (p *proxyConfig) initAccessLogMinioConfig() {
 	p.AccessLog.RemotePath = p.Base.LoadWithDefault("proxy.accessLog.remotePath", "access_log/")
 }
 
+func (p *proxyConfig) initAccessLogSegmentAuditConfig() {
+	p.AccessLog.SegmentAuditEnable = p.Base.ParseBool("proxy.accessLog.segmentAudit.enable", false)
+	sampleRate := p.Base.ParseFloatWithDefault("proxy.accessLog.segmentAudit.sampleRate", 0.01)
+	if sampleRate < 0 || sampleRate > 1 {
+		panic(fmt.Sprintf("proxy.accessLog.segmentAudit.sampleRate must be in [0, 1], got %v", sampleRate))
+	}
+	p.AccessLog.SegmentAuditSampleRate = sampleRate
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // --- querycoord ---
 type queryCoordConfig struct {
@@ -728,6 +984,12 @@ type queryCoordConfig struct {
 
 	NextTargetSurviveTime    time.Duration
 	UpdateNextTargetInterval time.Duration
+
+	// the segments of read-only replicas follow the target only once per interval
+	ReadOnlyReplicaSnapshotInterval time.Duration
+
+	// loading tasks of collections with higher load priority preempt the ones with lower priority
+	LoadPriorityPreemptionEnabled bool
 }
 
 func (p *queryCoordConfig) init(base *BaseTable) {
@@ -753,6 +1015,8 @@ func (p *queryCoordConfig) init(base *BaseTable) {
 	p.initEnableActiveStandby()
 	p.initNextTargetSurviveTime()
 	p.initUpdateNextTargetInterval()
+	p.initReadOnlyReplicaSnapshotInterval()
+	p.initLoadPriorityPreemptionEnabled()
 }
 
 func (p *queryCoordConfig) initTaskRetryNum() {
@@ -881,6 +1145,15 @@ func (p *queryCoordConfig) initUpdateNextTargetInterval() {
 	p.UpdateNextTargetInterval = time.Duration(updateNextTargetInterval) * time.Second
 }
 
+func (p *queryCoordConfig) initReadOnlyReplicaSnapshotInterval() {
+	interval := p.Base.ParseInt64WithDefault("queryCoord.readOnlyReplica.snapshotInterval", 300)
+	p.ReadOnlyReplicaSnapshotInterval = time.Duration(interval) * time.Second
+}
+
+func (p *queryCoordConfig) initLoadPriorityPreemptionEnabled() {
+	p.LoadPriorityPreemptionEnabled = p.Base.ParseBool("queryCoord.loadPriority.preemptionEnabled", true)
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // --- querynode ---
 type queryNodeConfig struct {
@@ -916,6 +1189,8 @@ type queryNodeConfig struct {
 	// cache limit
 	CacheEnabled     bool
 	CacheMemoryLimit int64
+	// the cached vector files and pk statistics are validated by their ETags every interval, 0 never validates them
+	CacheValidateInterval time.Duration
 
 	GroupEnabled         bool
 	MaxReceiveChanSize   int32
@@ -928,6 +1203,37 @@ type queryNodeConfig struct {
 	GCHelperEnabled   bool
 	MinimumGOGCConfig int
 	MaximumGOGCConfig int
+
+	// skip partitions which can't match the filter by partition statistics
+	PartitionPruningEnabled bool
+
+	// cache of per-segment sub-search results
+	SearchResultCacheEnabled bool
+	SearchResultCacheTTL     time.Duration
+	SearchResultCacheMaxSize int64
+
+	// collections whose serviceable lag exceeds the threshold are flagged as violating the SLO, 0 means never
+	ServiceableLagSLOThreshold time.Duration
+
+	// read-only nodes never consume the streaming data, they only serve sealed segments
+	ReadOnly bool
+
+	// the local storage is evicted when its free space ratio drops below the threshold
+	DiskWatcherInterval     time.Duration
+	DiskWatcherMinFreeRatio float64
+
+	// memory budget of the results retrieved by a query, 0 means unlimited,
+	// the results beyond the budget are spilled to the local storage if spilling is enabled
+	QueryMemoryBudget int64
+	QuerySpillEnabled bool
+
+	// the cold chunks of growing segments are spilled to the local storage once the memory usage ratio
+	// exceeds the watermark, 0 means never
+	GrowingSpillMemoryWatermark float64
+	GrowingSpillCheckInterval   time.Duration
+
+	FieldStatsEnabled         bool
+	FieldStatsRefreshInterval time.Duration
 }
 
 func (p *queryNodeConfig) init(base *BaseTable) {
@@ -945,6 +1251,7 @@ func (p *queryNodeConfig) init(base *BaseTable) {
 
 	p.initCacheMemoryLimit()
 	p.initCacheEnabled()
+	p.initCacheValidateInterval()
 
 	p.initGroupEnabled()
 	p.initMaxReceiveChanSize()
@@ -960,6 +1267,27 @@ func (p *queryNodeConfig) init(base *BaseTable) {
 	p.initGCTunerEnbaled()
 	p.initMaximumGOGC()
 	p.initMinimumGOGC()
+
+	p.initPartitionPruningEnabled()
+
+	p.initSearchResultCacheEnabled()
+	p.initSearchResultCacheTTL()
+	p.initSearchResultCacheMaxSize()
+
+	p.initServiceableLagSLOThreshold()
+	p.initReadOnly()
+
+	p.initDiskWatcherInterval()
+	p.initDiskWatcherMinFreeRatio()
+
+	p.initQueryMemoryBudget()
+	p.initQuerySpillEnabled()
+
+	p.initGrowingSpillMemoryWatermark()
+	p.initGrowingSpillCheckInterval()
+
+	p.initFieldStatsEnabled()
+	p.initFieldStatsRefreshInterval()
 }
 
 // InitAlias initializes an alias for the QueryNode role.
@@ -1048,6 +1376,11 @@ func (p *queryNodeConfig) initCacheEnabled() {
 	}
 }
 
+func (p *queryNodeConfig) initCacheValidateInterval() {
+	interval := p.Base.ParseInt64WithDefault("queryNode.cache.validateInterval", 0)
+	p.CacheValidateInterval = time.Duration(interval) * time.Second
+}
+
 func (p *queryNodeConfig) initGroupEnabled() {
 	p.GroupEnabled = p.Base.ParseBool("queryNode.grouping.enabled", true)
 }
@@ -1132,6 +1465,67 @@ func (p *queryNodeConfig) initMaximumGOGC() {
 	p.MaximumGOGCConfig = p.Base.ParseIntWithDefault("queryNode.gchelper.maximumGoGC", 200)
 }
 
+func (p *queryNodeConfig) initPartitionPruningEnabled() {
+	p.PartitionPruningEnabled = p.Base.ParseBool("queryNode.partitionPruning.enabled", true)
+}
+
+func (p *queryNodeConfig) initSearchResultCacheEnabled() {
+	p.SearchResultCacheEnabled = p.Base.ParseBool("queryNode.searchResultCache.enabled", false)
+}
+
+func (p *queryNodeConfig) initSearchResultCacheTTL() {
+	ttl := p.Base.ParseInt64WithDefault("queryNode.searchResultCache.ttl", 1000)
+	p.SearchResultCacheTTL = time.Duration(ttl) * time.Millisecond
+}
+
+func (p *queryNodeConfig) initSearchResultCacheMaxSize() {
+	p.SearchResultCacheMaxSize = p.Base.ParseInt64WithDefault("queryNode.searchResultCache.maxSize", 64) * 1024 * 1024
+}
+
+func (p *queryNodeConfig) initServiceableLagSLOThreshold() {
+	threshold := p.Base.ParseInt64WithDefault("queryNode.serviceableLag.sloThreshold", 10000)
+	p.ServiceableLagSLOThreshold = time.Duration(threshold) * time.Millisecond
+}
+
+func (p *queryNodeConfig) initReadOnly() {
+	p.ReadOnly = p.Base.ParseBool("queryNode.readOnly", false)
+}
+
+func (p *queryNodeConfig) initDiskWatcherInterval() {
+	interval := p.Base.ParseInt64WithDefault("queryNode.diskWatcher.interval", 60)
+	p.DiskWatcherInterval = time.Duration(interval) * time.Second
+}
+
+func (p *queryNodeConfig) initDiskWatcherMinFreeRatio() {
+	p.DiskWatcherMinFreeRatio = p.Base.ParseFloatWithDefault("queryNode.diskWatcher.minFreeRatio", 0.1)
+}
+
+func (p *queryNodeConfig) initQueryMemoryBudget() {
+	p.QueryMemoryBudget = p.Base.ParseInt64WithDefault("queryNode.queryMemory.budget", 0) * 1024 * 1024
+}
+
+func (p *queryNodeConfig) initQuerySpillEnabled() {
+	p.QuerySpillEnabled = p.Base.ParseBool("queryNode.queryMemory.spillEnabled", false)
+}
+
+func (p *queryNodeConfig) initGrowingSpillMemoryWatermark() {
+	p.GrowingSpillMemoryWatermark = p.Base.ParseFloatWithDefault("queryNode.growingSpill.memoryWatermark", 0)
+}
+
+func (p *queryNodeConfig) initGrowingSpillCheckInterval() {
+	interval := p.Base.ParseInt64WithDefault("queryNode.growingSpill.checkInterval", 10)
+	p.GrowingSpillCheckInterval = time.Duration(interval) * time.Second
+}
+
+func (p *queryNodeConfig) initFieldStatsEnabled() {
+	p.FieldStatsEnabled = p.Base.ParseBool("queryNode.fieldStats.enabled", true)
+}
+
+func (p *queryNodeConfig) initFieldStatsRefreshInterval() {
+	interval := p.Base.ParseInt64WithDefault("queryNode.fieldStats.refreshInterval", 60)
+	p.FieldStatsRefreshInterval = time.Duration(interval) * time.Second
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // --- datacoord ---
 type dataCoordConfig struct {
@@ -1172,7 +1566,26 @@ type dataCoordConfig struct {
 	GCInterval              time.Duration
 	GCMissingTolerance      time.Duration
 	GCDropTolerance         time.Duration
+	GCRemoveMode            string
+	GCSnapshotPinTTL        time.Duration
+	GCReportStorageUsage    bool
 	EnableActiveStandby     bool
+
+	// Deletion Vector
+	EnableDeletionVector         bool
+	DeletionVectorMergeInterval  time.Duration
+	DeletionVectorMinDeltalogNum int
+
+	// Scrubber
+	EnableScrubber     bool
+	ScrubInterval      time.Duration
+	ScrubRate          float64
+	ScrubBandwidth     int64
+	ScrubVerifyContent bool
+
+	// Statistics
+	StatisticsFreshness   time.Duration
+	StatisticsSyncTimeout time.Duration
 }
 
 func (p *dataCoordConfig) init(base *BaseTable) {
@@ -1205,7 +1618,19 @@ func (p *dataCoordConfig) init(base *BaseTable) {
 	p.initGCInterval()
 	p.initGCMissingTolerance()
 	p.initGCDropTolerance()
+	p.initGCRemoveMode()
+	p.initGCSnapshotPinTTL()
+	p.initGCReportStorageUsage()
 	p.initEnableActiveStandby()
+
+	p.initEnableDeletionVector()
+	p.initDeletionVectorMergeInterval()
+	p.initDeletionVectorMinDeltalogNum()
+
+	p.initScrubber()
+
+	p.initStatisticsFreshness()
+	p.initStatisticsSyncTimeout()
 }
 
 func (p *dataCoordConfig) initSegmentMaxSize() {
@@ -1315,6 +1740,18 @@ func (p *dataCoordConfig) initGCDropTolerance() {
 	p.GCDropTolerance = time.Duration(p.Base.ParseInt64WithDefault("dataCoord.gc.dropTolerance", 24*60*60)) * time.Second
 }
 
+func (p *dataCoordConfig) initGCRemoveMode() {
+	p.GCRemoveMode = p.Base.LoadWithDefault("dataCoord.gc.removeMode", "default")
+}
+
+func (p *dataCoordConfig) initGCSnapshotPinTTL() {
+	p.GCSnapshotPinTTL = time.Duration(p.Base.ParseInt64WithDefault("dataCoord.gc.snapshotPinTTL", 24*60*60)) * time.Second
+}
+
+func (p *dataCoordConfig) initGCReportStorageUsage() {
+	p.GCReportStorageUsage = p.Base.ParseBool("dataCoord.gc.reportStorageUsage", false)
+}
+
 func (p *dataCoordConfig) SetEnableAutoCompaction(enable bool) {
 	p.EnableAutoCompaction.Store(enable)
 }
@@ -1331,6 +1768,36 @@ func (p *dataCoordConfig) initEnableActiveStandby() {
 	p.EnableActiveStandby = p.Base.ParseBool("dataCoord.enableActiveStandby", false)
 }
 
+// -- Deletion Vector --
+func (p *dataCoordConfig) initEnableDeletionVector() {
+	p.EnableDeletionVector = p.Base.ParseBool("dataCoord.deletionVector.enabled", false)
+}
+
+func (p *dataCoordConfig) initDeletionVectorMergeInterval() {
+	p.DeletionVectorMergeInterval = time.Duration(p.Base.ParseInt64WithDefault("dataCoord.deletionVector.mergeInterval", 10*60)) * time.Second
+}
+
+func (p *dataCoordConfig) initDeletionVectorMinDeltalogNum() {
+	p.DeletionVectorMinDeltalogNum = p.Base.ParseIntWithDefault("dataCoord.deletionVector.minDeltalogNum", 4)
+}
+
+// -- Scrubber --
+func (p *dataCoordConfig) initScrubber() {
+	p.EnableScrubber = p.Base.ParseBool("dataCoord.scrubber.enabled", false)
+	p.ScrubInterval = time.Duration(p.Base.ParseInt64WithDefault("dataCoord.scrubber.interval", 24*60*60)) * time.Second
+	p.ScrubRate = p.Base.ParseFloatWithDefault("dataCoord.scrubber.rate", 10)
+	p.ScrubBandwidth = p.Base.ParseInt64WithDefault("dataCoord.scrubber.bandwidth", 16) * 1024 * 1024
+	p.ScrubVerifyContent = p.Base.ParseBool("dataCoord.scrubber.verifyContent", true)
+}
+
+func (p *dataCoordConfig) initStatisticsFreshness() {
+	p.StatisticsFreshness = time.Duration(p.Base.ParseInt64WithDefault("dataCoord.statistics.freshness", 0)) * time.Millisecond
+}
+
+func (p *dataCoordConfig) initStatisticsSyncTimeout() {
+	p.StatisticsSyncTimeout = time.Duration(p.Base.ParseInt64WithDefault("dataCoord.statistics.syncTimeout", 3000)) * time.Millisecond
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // --- datanode ---
 type dataNodeConfig struct {
@@ -1352,6 +1819,21 @@ type dataNodeConfig struct {
 	// io concurrency to fetch stats logs
 	IOConcurrency int
 
+	// VarChar fields with no more distinct values in a compaction batch are dictionary-encoded, 0 disables it
+	CompactionDictionaryMaxCardinality int
+
+	// save the timestamp index of every insert binlog as a stats log of the timestamp field
+	TimestampIndexEnabled bool
+
+	// answer the brute-force searches over the insert buffers requested by the fresh searches of proxies
+	FreshSearchEnabled bool
+
+	// watchdog of stalled time ticks
+	TimeTickWatchdogEnabled        bool
+	TimeTickWatchdogCheckInterval  time.Duration
+	TimeTickStallThreshold         time.Duration
+	TimeTickStallMaxReconnectTimes int
+
 	CreatedTime time.Time
 	UpdatedTime time.Time
 }
@@ -1364,8 +1846,16 @@ func (p *dataNodeConfig) init(base *BaseTable) {
 	p.initFlushDeleteBufferSize()
 	p.initSyncPeriod()
 	p.initIOConcurrency()
+	p.initCompactionDictionaryMaxCardinality()
+	p.initTimestampIndexEnabled()
+	p.initFreshSearchEnabled()
 
 	p.initChannelWatchPath()
+
+	p.initTimeTickWatchdogEnabled()
+	p.initTimeTickWatchdogCheckInterval()
+	p.initTimeTickStallThreshold()
+	p.initTimeTickStallMaxReconnectTimes()
 }
 
 // InitAlias init this DataNode alias
@@ -1373,6 +1863,18 @@ func (p *dataNodeConfig) InitAlias(alias string) {
 	p.Alias = alias
 }
 
+func (p *dataNodeConfig) initCompactionDictionaryMaxCardinality() {
+	p.CompactionDictionaryMaxCardinality = p.Base.ParseIntWithDefault("dataNode.compaction.dictionaryMaxCardinality", 0)
+}
+
+func (p *dataNodeConfig) initTimestampIndexEnabled() {
+	p.TimestampIndexEnabled = p.Base.ParseBool("dataNode.timestampIndex.enabled", true)
+}
+
+func (p *dataNodeConfig) initFreshSearchEnabled() {
+	p.FreshSearchEnabled = p.Base.ParseBool("dataNode.freshSearch.enabled", false)
+}
+
 func (p *dataNodeConfig) initFlowGraphMaxQueueLength() {
 	p.FlowGraphMaxQueueLength = p.Base.ParseInt32WithDefault("dataNode.dataSync.flowGraph.maxQueueLength", 1024)
 }
@@ -1409,6 +1911,24 @@ func (p *dataNodeConfig) initIOConcurrency() {
 	p.IOConcurrency = p.Base.ParseIntWithDefault("dataNode.dataSync.ioConcurrency", 10)
 }
 
+func (p *dataNodeConfig) initTimeTickWatchdogEnabled() {
+	p.TimeTickWatchdogEnabled = p.Base.ParseBool("dataNode.timeTickWatchdog.enabled", true)
+}
+
+func (p *dataNodeConfig) initTimeTickWatchdogCheckInterval() {
+	interval := p.Base.ParseInt64WithDefault("dataNode.timeTickWatchdog.checkInterval", 30)
+	p.TimeTickWatchdogCheckInterval = time.Duration(interval) * time.Second
+}
+
+func (p *dataNodeConfig) initTimeTickStallThreshold() {
+	threshold := p.Base.ParseInt64WithDefault("dataNode.timeTickWatchdog.stallThreshold", 300)
+	p.TimeTickStallThreshold = time.Duration(threshold) * time.Second
+}
+
+func (p *dataNodeConfig) initTimeTickStallMaxReconnectTimes() {
+	p.TimeTickStallMaxReconnectTimes = p.Base.ParseIntWithDefault("dataNode.timeTickWatchdog.maxReconnectTimes", 3)
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // --- indexcoord ---
 type indexCoordConfig struct {
@@ -1485,6 +2005,10 @@ type indexNodeConfig struct {
 	EnableDisk             bool
 	DiskCapacityLimit      int64
 	MaxDiskUsagePercentage float64
+
+	// stream the index files to the storage one by one instead of encoding all of them before uploading
+	StreamUploadEnabled       bool
+	StreamUploadMaxBufferSize int64
 }
 
 func (p *indexNodeConfig) init(base *BaseTable) {
@@ -1493,6 +2017,7 @@ func (p *indexNodeConfig) init(base *BaseTable) {
 	p.initEnableDisk()
 	p.initDiskCapacity()
 	p.initMaxDiskUsagePercentage()
+	p.initStreamUpload()
 }
 
 // InitAlias initializes an alias for the IndexNode role.
@@ -1532,6 +2057,11 @@ func (p *indexNodeConfig) initDiskCapacity() {
 	p.DiskCapacityLimit = diskSize * 1024 * 1024 * 1024
 }
 
+func (p *indexNodeConfig) initStreamUpload() {
+	p.StreamUploadEnabled = p.Base.ParseBool("indexNode.streamUpload.enabled", false)
+	p.StreamUploadMaxBufferSize = p.Base.ParseInt64WithDefault("indexNode.streamUpload.maxBufferSize", 256) * 1024 * 1024
+}
+
 func (p *indexNodeConfig) initMaxDiskUsagePercentage() {
 	maxDiskUsagePercentageStr := p.Base.LoadWithDefault("indexNode.maxDiskUsagePercentage", "95")
 	maxDiskUsagePercentage, err := strconv.ParseInt(maxDiskUsagePercentageStr, 10, 64)
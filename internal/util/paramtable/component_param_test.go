@@ -12,6 +12,7 @@
 package paramtable
 
 import (
+	"math"
 	"runtime"
 	"testing"
 	"time"
@@ -51,6 +52,26 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, Params.RetentionDuration, int64(DefaultRetentionDuration))
 		t.Logf("default retention duration = %d", Params.RetentionDuration)
 
+		assert.False(t, Params.StorageReadOnly)
+		assert.False(t, Params.StorageDedupEnabled)
+		assert.Equal(t, int64(1024*1024), Params.StorageDedupMinSize)
+		assert.Equal(t, int64(0), Params.StorageMemoryCapacity)
+		assert.Equal(t, int64(0), Params.StorageTenantDefaultQuota)
+		assert.Empty(t, Params.StorageTenantQuotas)
+		assert.Equal(t, "", Params.StorageAuditFilePath)
+		assert.Equal(t, 300, Params.StorageAuditMaxSizeMB)
+		assert.False(t, Params.StorageAuditOverwrite)
+
+		assert.Equal(t, 0.005, Params.BloomFilterFalsePositiveRate)
+		assert.Equal(t, int64(0), Params.BloomFilterMaxSize)
+		assert.False(t, Params.BloomFilterLazyLoad)
+		assert.Equal(t, 1024, Params.BloomFilterCacheSize)
+		assert.Equal(t, 1, Params.BinlogFormatVersion)
+		assert.Equal(t, int64(8*1024*1024), Params.BinlogRowGroupSize)
+		assert.Equal(t, "zstd", Params.BinlogPayloadCompression)
+		assert.Equal(t, 4096, Params.BinlogDictionaryMaxCardinality)
+		assert.False(t, Params.MetaCompactPaths)
+
 		assert.Equal(t, int64(Params.EntityExpirationTTL), int64(-1))
 		t.Logf("default entity expiration = %d", Params.EntityExpirationTTL)
 
@@ -119,6 +140,12 @@ func TestComponentParam(t *testing.T) {
 		t.Logf("default session TTL time = %d", Params.SessionTTL)
 		assert.Equal(t, Params.SessionRetryTimes, int64(DefaultSessionRetryTimes))
 		t.Logf("default session retry times = %d", Params.SessionRetryTimes)
+
+		assert.Equal(t, "never", Params.RoleRestartPolicy)
+		assert.Equal(t, 5, Params.RoleMaxRestarts)
+		assert.Equal(t, time.Second, Params.RoleRestartBackoff)
+		assert.Equal(t, 10*time.Second, Params.RoleHealthCheckInterval)
+		assert.Equal(t, 3, Params.RoleUnhealthyThreshold)
 	})
 
 	t.Run("test rootCoordConfig", func(t *testing.T) {
@@ -132,6 +159,9 @@ func TestComponentParam(t *testing.T) {
 		t.Logf("master ImportTaskRetention = %f", Params.ImportTaskRetention)
 		assert.Equal(t, Params.EnableActiveStandby, false)
 		t.Logf("rootCoord EnableActiveStandby = %t", Params.EnableActiveStandby)
+		assert.False(t, Params.AutoImportEnabled)
+		assert.Equal(t, "auto-import", Params.AutoImportPrefix)
+		assert.Equal(t, 10*time.Second, Params.AutoImportBatchWindow)
 
 		Params.CreatedTime = time.Now()
 		Params.UpdatedTime = time.Now()
@@ -163,6 +193,18 @@ func TestComponentParam(t *testing.T) {
 		t.Logf("AccessLog.MaxBackups: %d", Params.AccessLog.MaxBackups)
 
 		t.Logf("AccessLog.MaxDays: %d", Params.AccessLog.RotatedTime)
+
+		assert.False(t, Params.PresignURLEnabled)
+		assert.Equal(t, time.Hour, Params.PresignURLMaxExpiry)
+		assert.False(t, Params.AdaptiveConsistencyEnabled)
+		assert.Equal(t, 5*time.Second, Params.AdaptiveConsistencyMaxStaleness)
+		assert.Equal(t, 3*time.Second, Params.AdaptiveConsistencyLagRefreshInterval)
+		assert.False(t, Params.FreshSearchEnabled)
+		assert.Equal(t, time.Second, Params.FreshSearchMaxStaleness)
+		assert.False(t, Params.QueryResultSpillEnabled)
+		assert.Equal(t, int64(math.MaxInt32), Params.QueryResultSpillThreshold)
+		assert.Equal(t, time.Hour, Params.QueryResultSpillExpiry)
+		assert.Equal(t, int64(8192), Params.AnalyzerMaxTermsPerRow)
 	})
 
 	t.Run("test proxyConfig panic", func(t *testing.T) {
@@ -233,6 +275,8 @@ func TestComponentParam(t *testing.T) {
 		Params := params.QueryCoordCfg
 		assert.Equal(t, Params.EnableActiveStandby, false)
 		t.Logf("queryCoord EnableActiveStandby = %t", Params.EnableActiveStandby)
+		assert.Equal(t, 5*time.Minute, Params.ReadOnlyReplicaSnapshotInterval)
+		assert.True(t, Params.LoadPriorityPreemptionEnabled)
 	})
 
 	t.Run("test queryNodeConfig", func(t *testing.T) {
@@ -264,6 +308,24 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, int64(1000), Params.MaxGroupNQ)
 		assert.Equal(t, 10.0, Params.TopKMergeRatio)
 		assert.Equal(t, 10.0, Params.CPURatio)
+		assert.Equal(t, true, Params.PartitionPruningEnabled)
+		assert.Equal(t, false, Params.SearchResultCacheEnabled)
+		assert.Equal(t, time.Second, Params.SearchResultCacheTTL)
+		assert.Equal(t, int64(64*1024*1024), Params.SearchResultCacheMaxSize)
+		assert.Equal(t, time.Duration(0), Params.CacheValidateInterval)
+		assert.Equal(t, 10*time.Second, Params.ServiceableLagSLOThreshold)
+		assert.False(t, Params.ReadOnly)
+
+		assert.Equal(t, time.Minute, Params.DiskWatcherInterval)
+		assert.Equal(t, 0.1, Params.DiskWatcherMinFreeRatio)
+
+		assert.Equal(t, int64(0), Params.QueryMemoryBudget)
+		assert.False(t, Params.QuerySpillEnabled)
+
+		assert.Equal(t, 0.0, Params.GrowingSpillMemoryWatermark)
+		assert.Equal(t, 10*time.Second, Params.GrowingSpillCheckInterval)
+		assert.True(t, Params.FieldStatsEnabled)
+		assert.Equal(t, 60*time.Second, Params.FieldStatsRefreshInterval)
 
 		// test small indexNlist/NProbe default
 		Params.Base.Remove("queryNode.segcore.smallIndex.nlist")
@@ -297,8 +359,20 @@ func TestComponentParam(t *testing.T) {
 		Params := params.DataCoordCfg
 		assert.Equal(t, 24*60*60*time.Second, Params.SegmentMaxLifetime)
 		assert.True(t, Params.EnableGarbageCollection)
+		assert.Equal(t, "default", Params.GCRemoveMode)
+		assert.Equal(t, 24*time.Hour, Params.GCSnapshotPinTTL)
+		assert.False(t, Params.GCReportStorageUsage)
 		assert.Equal(t, Params.EnableActiveStandby, false)
 		t.Logf("dataCoord EnableActiveStandby = %t", Params.EnableActiveStandby)
+		assert.False(t, Params.EnableDeletionVector)
+		assert.Equal(t, 10*time.Minute, Params.DeletionVectorMergeInterval)
+		assert.Equal(t, 4, Params.DeletionVectorMinDeltalogNum)
+
+		assert.False(t, Params.EnableScrubber)
+		assert.Equal(t, 24*time.Hour, Params.ScrubInterval)
+		assert.Equal(t, float64(10), Params.ScrubRate)
+		assert.Equal(t, int64(16*1024*1024), Params.ScrubBandwidth)
+		assert.True(t, Params.ScrubVerifyContent)
 	})
 
 	t.Run("test dataNodeConfig", func(t *testing.T) {
@@ -325,6 +399,14 @@ func TestComponentParam(t *testing.T) {
 		t.Logf("SyncPeriod: %v", period)
 		assert.Equal(t, 10*time.Minute, Params.SyncPeriod)
 
+		assert.True(t, Params.TimeTickWatchdogEnabled)
+		assert.Equal(t, 30*time.Second, Params.TimeTickWatchdogCheckInterval)
+		assert.Equal(t, 5*time.Minute, Params.TimeTickStallThreshold)
+		assert.Equal(t, 3, Params.TimeTickStallMaxReconnectTimes)
+		assert.Equal(t, 0, Params.CompactionDictionaryMaxCardinality)
+		assert.True(t, Params.TimestampIndexEnabled)
+		assert.False(t, Params.FreshSearchEnabled)
+
 		Params.CreatedTime = time.Now()
 		t.Logf("CreatedTime: %v", Params.CreatedTime)
 
@@ -360,5 +442,8 @@ func TestComponentParam(t *testing.T) {
 
 		Params.UpdatedTime = time.Now()
 		t.Logf("UpdatedTime: %v", Params.UpdatedTime)
+
+		assert.False(t, Params.StreamUploadEnabled)
+		assert.Equal(t, int64(256*1024*1024), Params.StreamUploadMaxBufferSize)
 	})
 }
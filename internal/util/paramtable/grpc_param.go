@@ -50,6 +50,9 @@ const (
 	DefaultMaxBackoff        float32 = 60.0
 	DefaultBackoffMultiplier float32 = 2.0
 
+	// Grpc compression, empty means no compression
+	DefaultCompression = ""
+
 	ProxyInternalPort = 19529
 	ProxyExternalPort = 19530
 )
@@ -197,6 +200,9 @@ type GrpcClientConfig struct {
 	InitialBackoff    float32
 	MaxBackoff        float32
 	BackoffMultiplier float32
+
+	// Compression is the compressor used by the requests, the server responds with the same compressor
+	Compression string
 }
 
 // InitOnce initialize grpc client config once
@@ -211,6 +217,7 @@ func (p *GrpcClientConfig) init(domain string) {
 
 	p.initClientMaxSendSize()
 	p.initClientMaxRecvSize()
+	p.negotiateClientMaxSendSize()
 	p.initDialTimeout()
 	p.initKeepAliveTimeout()
 	p.initKeepAliveTime()
@@ -218,6 +225,7 @@ func (p *GrpcClientConfig) init(domain string) {
 	p.initInitialBackoff()
 	p.initMaxBackoff()
 	p.initBackoffMultiplier()
+	p.initCompression()
 }
 
 func (p *GrpcClientConfig) ParseConfig(funcDesc string, key string, backKey string, parseValue func(string) (interface{}, error), applyValue func(interface{}, error)) {
@@ -290,6 +298,28 @@ func (p *GrpcClientConfig) initClientMaxRecvSize() {
 		})
 }
 
+// negotiateClientMaxSendSize caps the max send size by the max recv size of the server,
+// so that the oversized requests fail fast on the client side instead of being transferred and rejected by the server.
+func (p *GrpcClientConfig) negotiateClientMaxSendSize() {
+	serverMaxRecvSize := DefaultServerMaxRecvSize
+	valueStr, err := p.Load("grpc.serverMaxRecvSize")
+	if err != nil {
+		valueStr, err = p.Load(p.Domain + ".grpc.serverMaxRecvSize")
+	}
+	if err == nil {
+		if value, err := strconv.Atoi(valueStr); err == nil && value > 0 {
+			serverMaxRecvSize = value
+		}
+	}
+	if p.ClientMaxSendSize > serverMaxRecvSize {
+		log.Info("client max send size exceeds the server max recv size, use the server one",
+			zap.String("role", p.Domain),
+			zap.Int("grpc.clientMaxSendSize", p.ClientMaxSendSize),
+			zap.Int("grpc.serverMaxRecvSize", serverMaxRecvSize))
+		p.ClientMaxSendSize = serverMaxRecvSize
+	}
+}
+
 func (p *GrpcClientConfig) initDialTimeout() {
 	funcDesc := "Init dial timeout"
 	key := "grpc.client.dialTimeout"
@@ -459,3 +489,25 @@ func (p *GrpcClientConfig) initBackoffMultiplier() {
 			p.BackoffMultiplier = float32(v)
 		})
 }
+
+func (p *GrpcClientConfig) initCompression() {
+	funcDesc := "Init compression"
+	key := "grpc.client.compression"
+	// the config of the server role takes precedence, so that compression could be enabled for some roles only
+	p.ParseConfig(funcDesc, fmt.Sprintf("%s.%s", p.Domain, key), key,
+		func(s string) (interface{}, error) {
+			switch s {
+			case "", "gzip", "zstd":
+				return s, nil
+			default:
+				return nil, fmt.Errorf("unsupported grpc compression %s", s)
+			}
+		},
+		func(i interface{}, err error) {
+			if err != nil {
+				p.Compression = DefaultCompression
+				return
+			}
+			p.Compression = i.(string)
+		})
+}
@@ -90,6 +90,31 @@ func TestGrpcClientParams(t *testing.T) {
 	Params.initClientMaxSendSize()
 	assert.Equal(t, Params.ClientMaxSendSize, DefaultClientMaxSendSize)
 
+	Params.Save("grpc.serverMaxRecvSize", "1500")
+	Params.Save(role+".grpc.clientMaxSendSize", "2000")
+	Params.initClientMaxSendSize()
+	Params.negotiateClientMaxSendSize()
+	assert.Equal(t, Params.ClientMaxSendSize, 1500)
+	Params.Remove(role + ".grpc.clientMaxSendSize")
+	Params.Remove("grpc.serverMaxRecvSize")
+	Params.initClientMaxSendSize()
+	Params.negotiateClientMaxSendSize()
+	assert.Equal(t, Params.ClientMaxSendSize, DefaultClientMaxSendSize)
+
+	Params.initCompression()
+	assert.Equal(t, Params.Compression, DefaultCompression)
+	Params.Save("grpc.client.compression", "zstd")
+	Params.initCompression()
+	assert.Equal(t, Params.Compression, "zstd")
+	Params.Save(role+".grpc.client.compression", "gzip")
+	Params.initCompression()
+	assert.Equal(t, Params.Compression, "gzip")
+	Params.Save(role+".grpc.client.compression", "lz4")
+	Params.initCompression()
+	assert.Equal(t, Params.Compression, DefaultCompression)
+	Params.Remove(role + ".grpc.client.compression")
+	Params.Remove("grpc.client.compression")
+
 	Params.initDialTimeout()
 	assert.Equal(t, Params.DialTimeout, DefaultDialTimeout)
 	Params.Save("grpc.client.dialTimeout", "aaa")
@@ -64,6 +64,12 @@ func (pi *ParamItem) GetAsInt() int {
 	return getAndConvert(pi, strconv.Atoi, 0)
 }
 
+func (pi *ParamItem) GetAsFloat() float64 {
+	return getAndConvert(pi, func(value string) (float64, error) {
+		return strconv.ParseFloat(value, 64)
+	}, 0.0)
+}
+
 type CompositeParamItem struct {
 	Items  []*ParamItem
 	Format func(map[string]string) string
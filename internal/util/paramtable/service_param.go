@@ -18,9 +18,12 @@ package paramtable
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/url"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -51,6 +54,8 @@ type ServiceParam struct {
 	KafkaCfg        KafkaConfig
 	RocksmqCfg      RocksmqConfig
 	MinioCfg        MinioConfig
+
+	StorageGatewayCfg StorageGatewayConfig
 }
 
 func (p *ServiceParam) Init() {
@@ -67,6 +72,7 @@ func (p *ServiceParam) Init() {
 	p.KafkaCfg.Init(&p.BaseTable)
 	p.RocksmqCfg.Init(&p.BaseTable)
 	p.MinioCfg.Init(&p.BaseTable)
+	p.StorageGatewayCfg.Init(&p.BaseTable)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -218,7 +224,15 @@ func (p *EtcdConfig) Init(base *BaseTable) {
 }
 
 type LocalStorageConfig struct {
-	Path ParamItem
+	Path        ParamItem
+	Concurrency ParamItem
+	Fsync       ParamItem
+	Journal     ParamItem
+
+	DiskHighWatermark ParamItem
+	DiskLowWatermark  ParamItem
+
+	SmallObjectThreshold ParamItem
 }
 
 func (p *LocalStorageConfig) Init(base *BaseTable) {
@@ -228,6 +242,48 @@ func (p *LocalStorageConfig) Init(base *BaseTable) {
 		DefaultValue: "/var/lib/milvus/data",
 	}
 	p.Path.Init(base.mgr)
+
+	p.Concurrency = ParamItem{
+		Key:          "localStorage.concurrency",
+		Version:      "2.2.0",
+		DefaultValue: "1",
+	}
+	p.Concurrency.Init(base.mgr)
+
+	p.Fsync = ParamItem{
+		Key:          "localStorage.fsync",
+		Version:      "2.2.0",
+		DefaultValue: "false",
+	}
+	p.Fsync.Init(base.mgr)
+
+	p.Journal = ParamItem{
+		Key:          "localStorage.journal",
+		Version:      "2.2.0",
+		DefaultValue: "false",
+	}
+	p.Journal.Init(base.mgr)
+
+	p.DiskHighWatermark = ParamItem{
+		Key:          "localStorage.diskQuota.highWatermark",
+		Version:      "2.2.0",
+		DefaultValue: "0",
+	}
+	p.DiskHighWatermark.Init(base.mgr)
+
+	p.DiskLowWatermark = ParamItem{
+		Key:          "localStorage.diskQuota.lowWatermark",
+		Version:      "2.2.0",
+		DefaultValue: "0",
+	}
+	p.DiskLowWatermark.Init(base.mgr)
+
+	p.SmallObjectThreshold = ParamItem{
+		Key:          "localStorage.smallObjectThreshold",
+		Version:      "2.2.0",
+		DefaultValue: "65536",
+	}
+	p.SmallObjectThreshold.Init(base.mgr)
 }
 
 type MetaStoreConfig struct {
@@ -341,6 +397,9 @@ type PulsarConfig struct {
 	// support tenant
 	Tenant    ParamItem
 	Namespace ParamItem
+
+	// ChannelGroups puts channels into their own tenant/namespace with namespace policies
+	ChannelGroups ParamGroup
 }
 
 func (p *PulsarConfig) Init(base *BaseTable) {
@@ -439,6 +498,11 @@ func (p *PulsarConfig) Init(base *BaseTable) {
 	}
 	p.AuthParams.Init(base.mgr)
 
+	p.ChannelGroups = ParamGroup{
+		KeyPrefix: "pulsar.channelGroups.",
+		Version:   "2.2.0",
+	}
+	p.ChannelGroups.Init(base.mgr)
 }
 
 // --- kafka ---
@@ -516,6 +580,84 @@ func (r *RocksmqConfig) Init(base *BaseTable) {
 	r.Path.Init(base.mgr)
 }
 
+// /////////////////////////////////////////////////////////////////////////////
+// --- storage gateway ---
+type StorageGatewayConfig struct {
+	Address        ParamItem
+	ListenAddress  ParamItem
+	Port           ParamItem
+	MaxMessageSize ParamItem
+	Token          ParamItem
+
+	// the gateway and its clients reuse the tls config of the proxy
+	TLSMode       ParamItem
+	ServerPemPath ParamItem
+	ServerKeyPath ParamItem
+	CaPemPath     ParamItem
+}
+
+func (p *StorageGatewayConfig) Init(base *BaseTable) {
+	p.Address = ParamItem{
+		Key:          "storageGateway.address",
+		DefaultValue: "localhost:19540",
+		Version:      "2.2.0",
+	}
+	p.Address.Init(base.mgr)
+
+	p.ListenAddress = ParamItem{
+		Key:          "storageGateway.listenAddress",
+		DefaultValue: "localhost",
+		Version:      "2.2.0",
+	}
+	p.ListenAddress.Init(base.mgr)
+
+	p.Port = ParamItem{
+		Key:          "storageGateway.port",
+		DefaultValue: "19540",
+		Version:      "2.2.0",
+	}
+	p.Port.Init(base.mgr)
+
+	p.MaxMessageSize = ParamItem{
+		Key:          "storageGateway.maxMessageSize",
+		DefaultValue: "512",
+		Version:      "2.2.0",
+	}
+	p.MaxMessageSize.Init(base.mgr)
+
+	p.Token = ParamItem{
+		Key:          "storageGateway.token",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.Token.Init(base.mgr)
+
+	p.TLSMode = ParamItem{
+		Key:          "common.security.tlsMode",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.TLSMode.Init(base.mgr)
+
+	p.ServerPemPath = ParamItem{
+		Key:     "tls.serverPemPath",
+		Version: "2.2.0",
+	}
+	p.ServerPemPath.Init(base.mgr)
+
+	p.ServerKeyPath = ParamItem{
+		Key:     "tls.serverKeyPath",
+		Version: "2.2.0",
+	}
+	p.ServerKeyPath.Init(base.mgr)
+
+	p.CaPemPath = ParamItem{
+		Key:     "tls.caPemPath",
+		Version: "2.2.0",
+	}
+	p.CaPemPath.Init(base.mgr)
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // --- minio ---
 type MinioConfig struct {
@@ -529,6 +671,79 @@ type MinioConfig struct {
 	UseIAM          ParamItem
 	CloudProvider   ParamItem
 	IAMEndpoint     ParamItem
+	Concurrency     ParamItem
+	PrefetchWindow  ParamItem
+
+	AssumeRoleARN           ParamItem
+	AssumeRoleExternalID    ParamItem
+	AssumeRoleSessionName   ParamItem
+	AssumeRoleSTSEndpoint   ParamItem
+	AssumeRoleRegion        ParamItem
+	AssumeRoleDuration      ParamItem
+	AssumeRoleRefreshWindow ParamItem
+
+	GCPAuthMode        ParamItem
+	GCPCredentialsFile ParamItem
+
+	AzureAuthMode      ParamItem
+	AzureClientID      ParamItem
+	AzureSASTokenFile  ParamItem
+	AzureRefreshWindow ParamItem
+
+	ListObjectMetadata ParamItem
+	SelectPushdown     ParamItem
+
+	GovernorMaxConcurrency ParamItem
+	GovernorMaxBandwidth   ParamItem
+	GovernorHostSocket     ParamItem
+
+	TransportMaxIdleConns          ParamItem
+	TransportMaxIdleConnsPerHost   ParamItem
+	TransportMaxConnsPerHost       ParamItem
+	TransportDialTimeout           ParamItem
+	TransportIdleConnTimeout       ParamItem
+	TransportResponseHeaderTimeout ParamItem
+	TransportCACertFile            ParamItem
+	TransportInsecureSkipVerify    ParamItem
+	TransportUnixSocket            ParamItem
+
+	UploadPartSize      ParamItem
+	UploadConcurrency   ParamItem
+	DownloadConcurrency ParamItem
+
+	ThrottleEnabled        ParamItem
+	ThrottleMinConcurrency ParamItem
+	ThrottleMaxConcurrency ParamItem
+
+	ObjectLockMode          ParamItem
+	ObjectLockRetentionDays ParamItem
+	ObjectLockLegalHold     ParamItem
+
+	StorageClassDefault ParamItem
+	StorageClassPolicy  ParamItem
+
+	LifecycleEnabled ParamItem
+
+	EndpointOverrides ParamGroup
+	RequesterPays     ParamItem
+	Buckets           ParamGroup
+
+	ShardingBuckets  ParamItem
+	ShardingPrefixes ParamItem
+
+	LegacyRootPaths ParamItem
+	LegacyRekey     ParamItem
+
+	ReplicationEnabled  ParamItem
+	ReplicationTarget   ParamGroup
+	ReplicationQueueDir ParamItem
+	ReplicationInterval ParamItem
+
+	ChaosEnabled           ParamItem
+	ChaosPrefix            ParamItem
+	ChaosWriteErrorRate    ParamItem
+	ChaosReadLatency       ParamItem
+	ChaosReadLatencyJitter ParamItem
 }
 
 func (p *MinioConfig) Init(base *BaseTable) {
@@ -611,4 +826,594 @@ func (p *MinioConfig) Init(base *BaseTable) {
 		Version:      "2.0.0",
 	}
 	p.IAMEndpoint.Init(base.mgr)
+
+	p.AssumeRoleARN = ParamItem{
+		Key:          "minio.assumeRole.roleARN",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.AssumeRoleARN.Init(base.mgr)
+
+	p.AssumeRoleExternalID = ParamItem{
+		Key:          "minio.assumeRole.externalID",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.AssumeRoleExternalID.Init(base.mgr)
+
+	p.AssumeRoleSessionName = ParamItem{
+		Key:          "minio.assumeRole.sessionName",
+		DefaultValue: "milvus",
+		Version:      "2.2.0",
+	}
+	p.AssumeRoleSessionName.Init(base.mgr)
+
+	p.AssumeRoleSTSEndpoint = ParamItem{
+		Key:          "minio.assumeRole.stsEndpoint",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.AssumeRoleSTSEndpoint.Init(base.mgr)
+
+	p.AssumeRoleRegion = ParamItem{
+		Key:          "minio.assumeRole.region",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.AssumeRoleRegion.Init(base.mgr)
+
+	p.AssumeRoleDuration = ParamItem{
+		Key:          "minio.assumeRole.duration",
+		DefaultValue: "3600",
+		Version:      "2.2.0",
+	}
+	p.AssumeRoleDuration.Init(base.mgr)
+
+	p.AssumeRoleRefreshWindow = ParamItem{
+		Key:          "minio.assumeRole.refreshWindow",
+		DefaultValue: "300",
+		Version:      "2.2.0",
+	}
+	p.AssumeRoleRefreshWindow.Init(base.mgr)
+
+	p.GCPAuthMode = ParamItem{
+		Key:          "minio.gcp.authMode",
+		DefaultValue: "metadata",
+		Version:      "2.2.0",
+	}
+	p.GCPAuthMode.Init(base.mgr)
+
+	p.GCPCredentialsFile = ParamItem{
+		Key:          "minio.gcp.credentialsFile",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.GCPCredentialsFile.Init(base.mgr)
+
+	p.AzureAuthMode = ParamItem{
+		Key:          "minio.azure.authMode",
+		DefaultValue: "managedIdentity",
+		Version:      "2.2.0",
+	}
+	p.AzureAuthMode.Init(base.mgr)
+
+	p.AzureClientID = ParamItem{
+		Key:          "minio.azure.clientID",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.AzureClientID.Init(base.mgr)
+
+	p.AzureSASTokenFile = ParamItem{
+		Key:          "minio.azure.sasTokenFile",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.AzureSASTokenFile.Init(base.mgr)
+
+	p.AzureRefreshWindow = ParamItem{
+		Key:          "minio.azure.refreshWindow",
+		DefaultValue: "300",
+		Version:      "2.2.0",
+	}
+	p.AzureRefreshWindow.Init(base.mgr)
+
+	p.Concurrency = ParamItem{
+		Key:          "minio.concurrency",
+		DefaultValue: "1",
+		Version:      "2.2.0",
+	}
+	p.Concurrency.Init(base.mgr)
+
+	p.PrefetchWindow = ParamItem{
+		Key:          "minio.prefetchWindow",
+		DefaultValue: "4",
+		Version:      "2.2.0",
+	}
+	p.PrefetchWindow.Init(base.mgr)
+
+	p.ListObjectMetadata = ParamItem{
+		Key:          "minio.listObjectMetadata",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.ListObjectMetadata.Init(base.mgr)
+
+	p.SelectPushdown = ParamItem{
+		Key:          "minio.selectPushdown",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.SelectPushdown.Init(base.mgr)
+
+	p.GovernorMaxConcurrency = ParamItem{
+		Key:          "minio.governor.maxConcurrency",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.GovernorMaxConcurrency.Init(base.mgr)
+
+	p.GovernorMaxBandwidth = ParamItem{
+		Key:          "minio.governor.maxBandwidth",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.GovernorMaxBandwidth.Init(base.mgr)
+
+	p.GovernorHostSocket = ParamItem{
+		Key:          "minio.governor.hostSocket",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.GovernorHostSocket.Init(base.mgr)
+
+	p.TransportMaxIdleConns = ParamItem{
+		Key:          "minio.transport.maxIdleConns",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.TransportMaxIdleConns.Init(base.mgr)
+
+	p.TransportMaxIdleConnsPerHost = ParamItem{
+		Key:          "minio.transport.maxIdleConnsPerHost",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.TransportMaxIdleConnsPerHost.Init(base.mgr)
+
+	p.TransportMaxConnsPerHost = ParamItem{
+		Key:          "minio.transport.maxConnsPerHost",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.TransportMaxConnsPerHost.Init(base.mgr)
+
+	p.TransportDialTimeout = ParamItem{
+		Key:          "minio.transport.dialTimeout",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.TransportDialTimeout.Init(base.mgr)
+
+	p.TransportIdleConnTimeout = ParamItem{
+		Key:          "minio.transport.idleConnTimeout",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.TransportIdleConnTimeout.Init(base.mgr)
+
+	p.TransportResponseHeaderTimeout = ParamItem{
+		Key:          "minio.transport.responseHeaderTimeout",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.TransportResponseHeaderTimeout.Init(base.mgr)
+
+	p.TransportCACertFile = ParamItem{
+		Key:          "minio.transport.caCertFile",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.TransportCACertFile.Init(base.mgr)
+
+	p.TransportInsecureSkipVerify = ParamItem{
+		Key:          "minio.transport.insecureSkipVerify",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.TransportInsecureSkipVerify.Init(base.mgr)
+
+	p.TransportUnixSocket = ParamItem{
+		Key:          "minio.transport.unixSocket",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.TransportUnixSocket.Init(base.mgr)
+
+	p.UploadPartSize = ParamItem{
+		Key:          "minio.upload.partSize",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.UploadPartSize.Init(base.mgr)
+
+	p.UploadConcurrency = ParamItem{
+		Key:          "minio.upload.concurrency",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.UploadConcurrency.Init(base.mgr)
+
+	p.DownloadConcurrency = ParamItem{
+		Key:          "minio.download.concurrency",
+		DefaultValue: "1",
+		Version:      "2.2.0",
+	}
+	p.DownloadConcurrency.Init(base.mgr)
+
+	p.ThrottleEnabled = ParamItem{
+		Key:          "minio.throttle.enabled",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.ThrottleEnabled.Init(base.mgr)
+
+	p.ThrottleMinConcurrency = ParamItem{
+		Key:          "minio.throttle.minConcurrency",
+		DefaultValue: "4",
+		Version:      "2.2.0",
+	}
+	p.ThrottleMinConcurrency.Init(base.mgr)
+
+	p.ThrottleMaxConcurrency = ParamItem{
+		Key:          "minio.throttle.maxConcurrency",
+		DefaultValue: "256",
+		Version:      "2.2.0",
+	}
+	p.ThrottleMaxConcurrency.Init(base.mgr)
+
+	p.ObjectLockMode = ParamItem{
+		Key:          "minio.objectLock.mode",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.ObjectLockMode.Init(base.mgr)
+
+	p.ObjectLockRetentionDays = ParamItem{
+		Key:          "minio.objectLock.retentionDays",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.ObjectLockRetentionDays.Init(base.mgr)
+
+	p.ObjectLockLegalHold = ParamItem{
+		Key:          "minio.objectLock.legalHold",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.ObjectLockLegalHold.Init(base.mgr)
+
+	p.StorageClassDefault = ParamItem{
+		Key:          "minio.storageClass.default",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.StorageClassDefault.Init(base.mgr)
+
+	p.StorageClassPolicy = ParamItem{
+		Key:          "minio.storageClass.policy",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.StorageClassPolicy.Init(base.mgr)
+
+	p.LifecycleEnabled = ParamItem{
+		Key:          "minio.lifecycle.enabled",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.LifecycleEnabled.Init(base.mgr)
+
+	p.EndpointOverrides = ParamGroup{
+		KeyPrefix: "minio.endpointOverrides.",
+		Version:   "2.2.0",
+	}
+	p.EndpointOverrides.Init(base.mgr)
+
+	p.RequesterPays = ParamItem{
+		Key:          "minio.requesterPays",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.RequesterPays.Init(base.mgr)
+
+	p.Buckets = ParamGroup{
+		KeyPrefix: "minio.buckets.",
+		Version:   "2.2.0",
+	}
+	p.Buckets.Init(base.mgr)
+
+	p.ShardingBuckets = ParamItem{
+		Key:          "minio.sharding.buckets",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.ShardingBuckets.Init(base.mgr)
+
+	p.ShardingPrefixes = ParamItem{
+		Key:          "minio.sharding.prefixes",
+		DefaultValue: "1",
+		Version:      "2.2.0",
+	}
+	p.ShardingPrefixes.Init(base.mgr)
+
+	p.LegacyRootPaths = ParamItem{
+		Key:          "minio.legacy.rootPaths",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.LegacyRootPaths.Init(base.mgr)
+
+	p.LegacyRekey = ParamItem{
+		Key:          "minio.legacy.rekey",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.LegacyRekey.Init(base.mgr)
+
+	p.ReplicationEnabled = ParamItem{
+		Key:          "minio.replication.enabled",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.ReplicationEnabled.Init(base.mgr)
+
+	p.ReplicationTarget = ParamGroup{
+		KeyPrefix: "minio.replication.target.",
+		Version:   "2.2.0",
+	}
+	p.ReplicationTarget.Init(base.mgr)
+
+	p.ReplicationQueueDir = ParamItem{
+		Key:          "minio.replication.queueDir",
+		DefaultValue: "/var/lib/milvus/data/replication",
+		Version:      "2.2.0",
+	}
+	p.ReplicationQueueDir.Init(base.mgr)
+
+	p.ReplicationInterval = ParamItem{
+		Key:          "minio.replication.interval",
+		DefaultValue: "10",
+		Version:      "2.2.0",
+	}
+	p.ReplicationInterval.Init(base.mgr)
+
+	p.ChaosEnabled = ParamItem{
+		Key:          "minio.chaos.enabled",
+		DefaultValue: "false",
+		Version:      "2.2.0",
+	}
+	p.ChaosEnabled.Init(base.mgr)
+
+	p.ChaosPrefix = ParamItem{
+		Key:          "minio.chaos.prefix",
+		DefaultValue: "",
+		Version:      "2.2.0",
+	}
+	p.ChaosPrefix.Init(base.mgr)
+
+	p.ChaosWriteErrorRate = ParamItem{
+		Key:          "minio.chaos.writeErrorRate",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.ChaosWriteErrorRate.Init(base.mgr)
+
+	p.ChaosReadLatency = ParamItem{
+		Key:          "minio.chaos.readLatency",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.ChaosReadLatency.Init(base.mgr)
+
+	p.ChaosReadLatencyJitter = ParamItem{
+		Key:          "minio.chaos.readLatencyJitter",
+		DefaultValue: "0",
+		Version:      "2.2.0",
+	}
+	p.ChaosReadLatencyJitter.Init(base.mgr)
+}
+
+// MinioEndpointOverride is the endpoint and credentials used by a role instead of the minio config,
+// the empty fields are not overridden.
+type MinioEndpointOverride struct {
+	Address         string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          *bool
+}
+
+// GetEndpointOverride returns the endpoint override of @role configured by "minio.endpointOverrides.<role>.*",
+// nil is returned if there is no override. Only the endpoint and credentials could be overridden,
+// the bucket and root path are shared, so that the object keys resolve identically on every node.
+func (p *MinioConfig) GetEndpointOverride(role string) (*MinioEndpointOverride, error) {
+	prefix := strings.ToLower(role) + "."
+	values := p.EndpointOverrides.GetValue()
+	var override *MinioEndpointOverride
+	for key, value := range values {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if override == nil {
+			override = &MinioEndpointOverride{}
+		}
+		switch strings.TrimPrefix(key, prefix) {
+		case "address":
+			override.Address = value
+		case "port":
+			// merged into the address below
+		case "accesskeyid":
+			override.AccessKeyID = value
+		case "secretaccesskey":
+			override.SecretAccessKey = value
+		case "usessl":
+			useSSL, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid minio.endpointOverrides.%s: %w", key, err)
+			}
+			override.UseSSL = &useSSL
+		default:
+			return nil, fmt.Errorf("minio.endpointOverrides.%s could not be overridden, only address, port, accessKeyID, secretAccessKey and useSSL are allowed", key)
+		}
+	}
+	if override == nil {
+		return nil, nil
+	}
+
+	port, ok := values[prefix+"port"]
+	if !ok {
+		port = p.Port.GetValue()
+	}
+	if override.Address == "" {
+		override.Address = strings.Split(p.Address.GetValue(), ":")[0]
+	}
+	if !strings.Contains(override.Address, ":") {
+		override.Address = override.Address + ":" + port
+	}
+	return override, nil
+}
+
+// MinioBucket is a named bucket configured by "minio.buckets.<name>.*", which serves the object keys with Prefix
+// instead of the default bucket. The endpoint and credentials not configured are the ones of the minio config.
+type MinioBucket struct {
+	Name            string
+	Prefix          string
+	BucketName      string
+	Address         string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+	UseIAM          bool
+	RequesterPays   bool
+}
+
+// GetBuckets returns the named buckets sorted by name, every bucket must have a non-empty prefix and bucket name.
+func (p *MinioConfig) GetBuckets() ([]*MinioBucket, error) {
+	values := p.Buckets.GetValue()
+	buckets := make(map[string]*MinioBucket)
+	ports := make(map[string]string)
+	for key, value := range values {
+		idx := strings.Index(key, ".")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid minio.buckets.%s, the bucket name is missing", key)
+		}
+		name := key[:idx]
+		bucket, ok := buckets[name]
+		if !ok {
+			bucket = p.newBucket(name)
+			buckets[name] = bucket
+		}
+
+		switch field := key[idx+1:]; field {
+		case "prefix":
+			bucket.Prefix = value
+		case "port":
+			ports[name] = value
+		default:
+			known, err := bucket.set(field, value)
+			if !known {
+				return nil, fmt.Errorf("unknown minio.buckets.%s, only prefix, bucketName, address, port, accessKeyID, secretAccessKey, useSSL, useIAM and requesterPays are allowed", key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid minio.buckets.%s: %w", key, err)
+			}
+		}
+	}
+
+	result := make([]*MinioBucket, 0, len(buckets))
+	for name, bucket := range buckets {
+		if bucket.Prefix == "" || bucket.BucketName == "" {
+			return nil, fmt.Errorf("minio.buckets.%s must have both prefix and bucketName", name)
+		}
+		if !strings.Contains(bucket.Address, ":") {
+			port, ok := ports[name]
+			if !ok {
+				port = p.Port.GetValue()
+			}
+			bucket.Address = bucket.Address + ":" + port
+		}
+		result = append(result, bucket)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// newBucket returns a bucket with the endpoint and credentials of the minio config.
+func (p *MinioConfig) newBucket(name string) *MinioBucket {
+	return &MinioBucket{
+		Name:            name,
+		Address:         strings.Split(p.Address.GetValue(), ":")[0],
+		AccessKeyID:     p.AccessKeyID.GetValue(),
+		SecretAccessKey: p.SecretAccessKey.GetValue(),
+		UseSSL:          p.UseSSL.GetAsBool(),
+		UseIAM:          p.UseIAM.GetAsBool(),
+	}
+}
+
+// set sets the field of the lowercased config @key, returns false if @key is not a field of the endpoint and credentials.
+func (b *MinioBucket) set(key string, value string) (bool, error) {
+	var err error
+	switch key {
+	case "bucketname":
+		b.BucketName = value
+	case "address":
+		b.Address = value
+	case "accesskeyid":
+		b.AccessKeyID = value
+	case "secretaccesskey":
+		b.SecretAccessKey = value
+	case "usessl":
+		b.UseSSL, err = strconv.ParseBool(value)
+	case "useiam":
+		b.UseIAM, err = strconv.ParseBool(value)
+	case "requesterpays":
+		b.RequesterPays, err = strconv.ParseBool(value)
+	default:
+		return false, nil
+	}
+	return true, err
+}
+
+// GetReplicationTarget returns the bucket configured by "minio.replication.target.*", which the objects are
+// replicated to, nil if not configured. The endpoint and credentials not configured are the ones of the minio config,
+// so a bucket of another region in the same account only needs bucketName and address.
+func (p *MinioConfig) GetReplicationTarget() (*MinioBucket, error) {
+	values := p.ReplicationTarget.GetValue()
+	if len(values) == 0 {
+		return nil, nil
+	}
+	target := p.newBucket("replication")
+	port := p.Port.GetValue()
+	for key, value := range values {
+		if key == "port" {
+			port = value
+			continue
+		}
+		known, err := target.set(key, value)
+		if !known {
+			return nil, fmt.Errorf("unknown minio.replication.target.%s, only bucketName, address, port, accessKeyID, secretAccessKey, useSSL, useIAM and requesterPays are allowed", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid minio.replication.target.%s: %w", key, err)
+		}
+	}
+	if target.BucketName == "" {
+		return nil, errors.New("minio.replication.target.bucketName is required")
+	}
+	if !strings.Contains(target.Address, ":") {
+		target.Address = target.Address + ":" + port
+	}
+	return target, nil
 }
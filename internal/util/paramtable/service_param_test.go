@@ -12,6 +12,7 @@
 package paramtable
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/milvus-io/milvus/internal/util/metricsinfo"
@@ -109,6 +110,7 @@ func TestServiceParam(t *testing.T) {
 
 		assert.Equal(t, "public", Params.Tenant.GetValue())
 		assert.Equal(t, "default", Params.Namespace.GetValue())
+		assert.Equal(t, 0, len(Params.ChannelGroups.GetValue()))
 	})
 
 	t.Run("test rocksmqConfig", func(t *testing.T) {
@@ -118,6 +120,29 @@ func TestServiceParam(t *testing.T) {
 		t.Logf("rocksmq path = %s", Params.Path.GetValue())
 	})
 
+	t.Run("test localStorageConfig", func(t *testing.T) {
+		Params := &SParams.LocalStorageCfg
+
+		assert.NotEqual(t, Params.Path.GetValue(), "")
+		assert.False(t, Params.Fsync.GetAsBool())
+		assert.False(t, Params.Journal.GetAsBool())
+		assert.Equal(t, 0.0, Params.DiskHighWatermark.GetAsFloat())
+		assert.Equal(t, 0.0, Params.DiskLowWatermark.GetAsFloat())
+		assert.Equal(t, 65536, Params.SmallObjectThreshold.GetAsInt())
+	})
+
+	t.Run("test storageGatewayConfig", func(t *testing.T) {
+		Params := &SParams.StorageGatewayCfg
+
+		assert.Equal(t, "localhost:19540", Params.Address.GetValue())
+		assert.Equal(t, "localhost", Params.ListenAddress.GetValue())
+		assert.Equal(t, 19540, Params.Port.GetAsInt())
+		assert.Equal(t, 512, Params.MaxMessageSize.GetAsInt())
+		assert.Equal(t, "", Params.Token.GetValue())
+		assert.Equal(t, 0, Params.TLSMode.GetAsInt())
+		assert.Equal(t, "configs/cert/server.pem", Params.ServerPemPath.GetValue())
+	})
+
 	t.Run("test minioConfig", func(t *testing.T) {
 		Params := &SParams.MinioCfg
 
@@ -138,8 +163,166 @@ func TestServiceParam(t *testing.T) {
 
 		assert.Equal(t, Params.IAMEndpoint.GetValue(), "")
 
+		assert.Equal(t, "", Params.AssumeRoleARN.GetValue())
+		assert.Equal(t, "", Params.AssumeRoleExternalID.GetValue())
+		assert.Equal(t, "milvus", Params.AssumeRoleSessionName.GetValue())
+		assert.Equal(t, "", Params.AssumeRoleSTSEndpoint.GetValue())
+		assert.Equal(t, "", Params.AssumeRoleRegion.GetValue())
+		assert.Equal(t, 3600, Params.AssumeRoleDuration.GetAsInt())
+		assert.Equal(t, 300, Params.AssumeRoleRefreshWindow.GetAsInt())
+		assert.Equal(t, "metadata", Params.GCPAuthMode.GetValue())
+		assert.Equal(t, "", Params.GCPCredentialsFile.GetValue())
+		assert.Equal(t, "managedIdentity", Params.AzureAuthMode.GetValue())
+		assert.Equal(t, "", Params.AzureClientID.GetValue())
+		assert.Equal(t, "", Params.AzureSASTokenFile.GetValue())
+		assert.Equal(t, 300, Params.AzureRefreshWindow.GetAsInt())
+
+		assert.Equal(t, 4, Params.PrefetchWindow.GetAsInt())
+		assert.False(t, Params.ListObjectMetadata.GetAsBool())
+		assert.False(t, Params.SelectPushdown.GetAsBool())
+
+		assert.Equal(t, 0, Params.GovernorMaxConcurrency.GetAsInt())
+		assert.Equal(t, 0, Params.GovernorMaxBandwidth.GetAsInt())
+		assert.Equal(t, "", Params.GovernorHostSocket.GetValue())
+
+		assert.Equal(t, 0, Params.TransportMaxIdleConnsPerHost.GetAsInt())
+		assert.Equal(t, 0, Params.TransportDialTimeout.GetAsInt())
+		assert.False(t, Params.TransportInsecureSkipVerify.GetAsBool())
+		assert.Equal(t, "", Params.TransportUnixSocket.GetValue())
+
+		assert.Equal(t, 0, Params.UploadPartSize.GetAsInt())
+		assert.Equal(t, 0, Params.UploadConcurrency.GetAsInt())
+		assert.Equal(t, 1, Params.DownloadConcurrency.GetAsInt())
+
+		assert.False(t, Params.ThrottleEnabled.GetAsBool())
+		assert.Equal(t, 4, Params.ThrottleMinConcurrency.GetAsInt())
+		assert.Equal(t, 256, Params.ThrottleMaxConcurrency.GetAsInt())
+
+		assert.Equal(t, "", Params.ObjectLockMode.GetValue())
+		assert.Equal(t, 0, Params.ObjectLockRetentionDays.GetAsInt())
+		assert.False(t, Params.ObjectLockLegalHold.GetAsBool())
+
+		assert.Equal(t, "", Params.StorageClassDefault.GetValue())
+		assert.Equal(t, "", Params.StorageClassPolicy.GetValue())
+		assert.False(t, Params.LifecycleEnabled.GetAsBool())
+
+		assert.False(t, Params.ChaosEnabled.GetAsBool())
+		assert.Equal(t, "", Params.ChaosPrefix.GetValue())
+		assert.Equal(t, 0.0, Params.ChaosWriteErrorRate.GetAsFloat())
+		assert.Equal(t, 0, Params.ChaosReadLatency.GetAsInt())
+		assert.Equal(t, 0, Params.ChaosReadLatencyJitter.GetAsInt())
+
+		assert.Equal(t, "", Params.ShardingBuckets.GetValue())
+		assert.Equal(t, 1, Params.ShardingPrefixes.GetAsInt())
+		assert.Empty(t, Params.LegacyRootPaths.GetValue())
+		assert.False(t, Params.LegacyRekey.GetAsBool())
+
 		t.Logf("Minio BucketName = %s", Params.BucketName.GetValue())
 
 		t.Logf("Minio rootpath = %s", Params.RootPath.GetValue())
+
+		override, err := Params.GetEndpointOverride("querynode")
+		assert.NoError(t, err)
+		assert.Nil(t, override)
+
+		cfg := *Params
+		overrides := map[string]string{
+			"querynode.address":         "gateway",
+			"querynode.accesskeyid":     "ak",
+			"querynode.secretaccesskey": "sk",
+			"querynode.usessl":          "true",
+			"datanode.port":             "9001",
+		}
+		cfg.EndpointOverrides = ParamGroup{GetFunc: func() map[string]string { return overrides }}
+		override, err = cfg.GetEndpointOverride("querynode")
+		assert.NoError(t, err)
+		assert.Equal(t, "gateway:"+Params.Port.GetValue(), override.Address)
+		assert.Equal(t, "ak", override.AccessKeyID)
+		assert.Equal(t, "sk", override.SecretAccessKey)
+		assert.True(t, *override.UseSSL)
+
+		override, err = cfg.GetEndpointOverride("datanode")
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Split(Params.Address.GetValue(), ":")[0]+":9001", override.Address)
+		assert.Empty(t, override.AccessKeyID)
+		assert.Nil(t, override.UseSSL)
+
+		overrides["indexnode.bucketname"] = "another-bucket"
+		_, err = cfg.GetEndpointOverride("indexnode")
+		assert.Error(t, err)
+		overrides["querynode.usessl"] = "not bool"
+		_, err = cfg.GetEndpointOverride("querynode")
+		assert.Error(t, err)
+
+		assert.False(t, Params.RequesterPays.GetAsBool())
+		buckets, err := Params.GetBuckets()
+		assert.NoError(t, err)
+		assert.Empty(t, buckets)
+
+		bucketValues := map[string]string{
+			"public.prefix":           "datasets/",
+			"public.bucketname":       "public-datasets",
+			"public.address":          "s3.amazonaws.com",
+			"public.port":             "443",
+			"public.usessl":           "true",
+			"public.requesterpays":    "true",
+			"archive.prefix":          "archive/",
+			"archive.bucketname":      "archive",
+			"archive.accesskeyid":     "ak",
+			"archive.secretaccesskey": "sk",
+		}
+		cfg.Buckets = ParamGroup{GetFunc: func() map[string]string { return bucketValues }}
+		buckets, err = cfg.GetBuckets()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(buckets))
+		assert.Equal(t, "archive", buckets[0].Name)
+		assert.Equal(t, "archive/", buckets[0].Prefix)
+		assert.Equal(t, strings.Split(Params.Address.GetValue(), ":")[0]+":"+Params.Port.GetValue(), buckets[0].Address)
+		assert.Equal(t, "ak", buckets[0].AccessKeyID)
+		assert.False(t, buckets[0].RequesterPays)
+		assert.Equal(t, "public", buckets[1].Name)
+		assert.Equal(t, "public-datasets", buckets[1].BucketName)
+		assert.Equal(t, "s3.amazonaws.com:443", buckets[1].Address)
+		assert.Equal(t, Params.AccessKeyID.GetValue(), buckets[1].AccessKeyID)
+		assert.True(t, buckets[1].UseSSL)
+		assert.True(t, buckets[1].RequesterPays)
+
+		bucketValues["public.requesterpays"] = "not bool"
+		_, err = cfg.GetBuckets()
+		assert.Error(t, err)
+		bucketValues["public.requesterpays"] = "true"
+		bucketValues["public.rootpath"] = "files"
+		_, err = cfg.GetBuckets()
+		assert.Error(t, err)
+		delete(bucketValues, "public.rootpath")
+		delete(bucketValues, "archive.prefix")
+		_, err = cfg.GetBuckets()
+		assert.Error(t, err)
+
+		assert.False(t, Params.ReplicationEnabled.GetAsBool())
+		assert.Equal(t, 10, Params.ReplicationInterval.GetAsInt())
+		target, err := Params.GetReplicationTarget()
+		assert.NoError(t, err)
+		assert.Nil(t, target)
+
+		targetValues := map[string]string{
+			"bucketname": "dr-bucket",
+			"address":    "s3.us-west-2.amazonaws.com",
+			"port":       "443",
+		}
+		cfg.ReplicationTarget = ParamGroup{GetFunc: func() map[string]string { return targetValues }}
+		target, err = cfg.GetReplicationTarget()
+		assert.NoError(t, err)
+		assert.Equal(t, "dr-bucket", target.BucketName)
+		assert.Equal(t, "s3.us-west-2.amazonaws.com:443", target.Address)
+		assert.Equal(t, Params.AccessKeyID.GetValue(), target.AccessKeyID)
+
+		targetValues["prefix"] = "files/"
+		_, err = cfg.GetReplicationTarget()
+		assert.Error(t, err)
+		delete(targetValues, "prefix")
+		delete(targetValues, "bucketname")
+		_, err = cfg.GetReplicationTarget()
+		assert.Error(t, err)
 	})
 }
@@ -88,6 +88,8 @@ type Session struct {
 	Exclusive   bool   `json:"Exclusive,omitempty"`
 	TriggerKill bool
 	Version     semver.Version `json:"Version,omitempty"`
+	// ReadOnly is set by the query nodes which never consume the message stream
+	ReadOnly bool `json:"ReadOnly,omitempty"`
 
 	liveCh  <-chan bool
 	etcdCli *clientv3.Client
@@ -17,11 +17,13 @@
 package typeutil
 
 import (
+	"fmt"
 	"hash/crc32"
 	"unsafe"
 
 	"github.com/spaolacci/murmur3"
 
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
 	"github.com/milvus-io/milvus/internal/common"
 )
@@ -71,8 +73,52 @@ func HashString2Uint32(v string) uint32 {
 	return crc32.ChecksumIEEE([]byte(subString))
 }
 
+// JumpHash maps a key to a bucket in [0, numBuckets) with the jump consistent hash,
+// when the number of buckets grows from n to n+1, only 1/(n+1) of the keys are remapped.
+// See "A Fast, Minimal Memory, Consistent Hash Algorithm" by Lamping and Veach.
+func JumpHash(key uint64, numBuckets int32) int32 {
+	var b, j int64 = -1, 0
+	for j < int64(numBuckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int32(b)
+}
+
+// GetShardRouting returns the shard routing in collection properties,
+// common.ShardRoutingModulo is returned if it's not specified.
+func GetShardRouting(properties []*commonpb.KeyValuePair) (string, error) {
+	for _, kv := range properties {
+		if kv.GetKey() != common.CollectionShardRoutingKey {
+			continue
+		}
+		switch kv.GetValue() {
+		case common.ShardRoutingModulo, common.ShardRoutingJumpHash:
+			return kv.GetValue(), nil
+		default:
+			return "", fmt.Errorf("invalid shard routing %s, should be %s or %s",
+				kv.GetValue(), common.ShardRoutingModulo, common.ShardRoutingJumpHash)
+		}
+	}
+	return common.ShardRoutingModulo, nil
+}
+
+// HashKey2Shard maps the hash value of a primary key to a shard with the given routing
+func HashKey2Shard(hash uint32, numShard uint32, routing string) uint32 {
+	if routing == common.ShardRoutingJumpHash {
+		return uint32(JumpHash(uint64(hash), int32(numShard)))
+	}
+	return hash % numShard
+}
+
 // HashPK2Channels hash primary keys to channels
 func HashPK2Channels(primaryKeys *schemapb.IDs, shardNames []string) []uint32 {
+	return HashPK2ChannelsWithRouting(primaryKeys, shardNames, common.ShardRoutingModulo)
+}
+
+// HashPK2ChannelsWithRouting hash primary keys to channels with the given shard routing
+func HashPK2ChannelsWithRouting(primaryKeys *schemapb.IDs, shardNames []string, routing string) []uint32 {
 	numShard := uint32(len(shardNames))
 	var hashValues []uint32
 	switch primaryKeys.IdField.(type) {
@@ -80,13 +126,13 @@ func HashPK2Channels(primaryKeys *schemapb.IDs, shardNames []string) []uint32 {
 		pks := primaryKeys.GetIntId().Data
 		for _, pk := range pks {
 			value, _ := Hash32Int64(pk)
-			hashValues = append(hashValues, value%numShard)
+			hashValues = append(hashValues, HashKey2Shard(value, numShard, routing))
 		}
 	case *schemapb.IDs_StrId:
 		pks := primaryKeys.GetStrId().Data
 		for _, pk := range pks {
 			hash := HashString2Uint32(pk)
-			hashValues = append(hashValues, hash%numShard)
+			hashValues = append(hashValues, HashKey2Shard(hash, numShard, routing))
 		}
 	default:
 		//TODO::
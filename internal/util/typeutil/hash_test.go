@@ -21,7 +21,9 @@ import (
 	"testing"
 	"unsafe"
 
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/milvus-io/milvus/internal/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,3 +96,74 @@ func TestHashPK2Channels(t *testing.T) {
 	assert.Equal(t, 5, len(ret))
 	assert.Equal(t, ret[1], ret[2])
 }
+
+func TestJumpHash(t *testing.T) {
+	for key := uint64(0); key < 1000; key++ {
+		assert.Equal(t, int32(0), JumpHash(key, 1))
+	}
+
+	// growing the buckets from n to n+1 only moves keys into the new bucket
+	moved := 0
+	for key := uint64(0); key < 10000; key++ {
+		hash, _ := Hash32Uint64(key)
+		before := JumpHash(uint64(hash), 4)
+		after := JumpHash(uint64(hash), 5)
+		assert.True(t, before >= 0 && before < 4)
+		if before != after {
+			assert.Equal(t, int32(4), after)
+			moved++
+		}
+	}
+	assert.Greater(t, moved, 1000)
+	assert.Less(t, moved, 3000)
+}
+
+func TestGetShardRouting(t *testing.T) {
+	routing, err := GetShardRouting(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, common.ShardRoutingModulo, routing)
+
+	routing, err = GetShardRouting([]*commonpb.KeyValuePair{
+		{Key: common.CollectionTTLConfigKey, Value: "10"},
+		{Key: common.CollectionShardRoutingKey, Value: common.ShardRoutingJumpHash},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, common.ShardRoutingJumpHash, routing)
+
+	_, err = GetShardRouting([]*commonpb.KeyValuePair{
+		{Key: common.CollectionShardRoutingKey, Value: "ring"},
+	})
+	assert.Error(t, err)
+}
+
+func TestHashPK2ChannelsWithRouting(t *testing.T) {
+	channels := []string{"test1", "test2", "test3"}
+	int64IDs := &schemapb.IDs{
+		IdField: &schemapb.IDs_IntId{
+			IntId: &schemapb.LongArray{
+				Data: []int64{100, 102, 102, 103, 104},
+			},
+		},
+	}
+	ret := HashPK2ChannelsWithRouting(int64IDs, channels, common.ShardRoutingJumpHash)
+	assert.Equal(t, 5, len(ret))
+	assert.Equal(t, ret[1], ret[2])
+	for _, shard := range ret {
+		assert.Less(t, shard, uint32(len(channels)))
+	}
+
+	stringIDs := &schemapb.IDs{
+		IdField: &schemapb.IDs_StrId{
+			StrId: &schemapb.StringArray{
+				Data: []string{"ab", "bc", "bc", "abd", "milvus"},
+			},
+		},
+	}
+	ret = HashPK2ChannelsWithRouting(stringIDs, channels, common.ShardRoutingJumpHash)
+	assert.Equal(t, 5, len(ret))
+	assert.Equal(t, ret[1], ret[2])
+
+	// modulo routing is the same as HashPK2Channels
+	assert.Equal(t, HashPK2Channels(stringIDs, channels),
+		HashPK2ChannelsWithRouting(stringIDs, channels, common.ShardRoutingModulo))
+}
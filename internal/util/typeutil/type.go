@@ -46,6 +46,12 @@ const (
 	DataCoordRole = "datacoord"
 	// DataNodeRole is a constant represent DataNode
 	DataNodeRole = "datanode"
+	// BenchRole is a constant represent the workload generator, it's not a server type
+	BenchRole = "bench"
+	// StorageBenchRole is a constant represent the benchmark of the storage, it's not a server type
+	StorageBenchRole = "storage-bench"
+	// StorageGatewayRole is a constant represent the storage gateway, which runs alone rather than with the other servers
+	StorageGatewayRole = "storage-gateway"
 )
 
 const Unlimited int64 = -1
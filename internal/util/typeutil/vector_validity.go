@@ -0,0 +1,91 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"math"
+)
+
+// VectorValidity describes whether a float vector is usable by the indexes.
+type VectorValidity int
+
+const (
+	// ValidVector means all elements are finite and the vector is accepted.
+	ValidVector VectorValidity = iota
+	// NaNVector means at least one element is NaN.
+	NaNVector
+	// InfVector means at least one element is +Inf or -Inf.
+	InfVector
+	// ZeroVector means all elements are zero, which can not be normalized.
+	ZeroVector
+)
+
+// String returns the readable name of the validity.
+func (v VectorValidity) String() string {
+	switch v {
+	case ValidVector:
+		return "valid"
+	case NaNVector:
+		return "nan"
+	case InfVector:
+		return "inf"
+	case ZeroVector:
+		return "zero"
+	default:
+		return "unknown"
+	}
+}
+
+// CheckFloatVector checks a single float vector, zero vectors are only reported when checkZero is true.
+func CheckFloatVector(vector []float32, checkZero bool) VectorValidity {
+	allZero := true
+	for _, v := range vector {
+		f := float64(v)
+		if math.IsNaN(f) {
+			return NaNVector
+		}
+		if math.IsInf(f, 0) {
+			return InfVector
+		}
+		if v != 0 {
+			allZero = false
+		}
+	}
+	if checkZero && allZero {
+		return ZeroVector
+	}
+	return ValidVector
+}
+
+// FindInvalidFloatVectors walks the flattened float vectors with dimension dim,
+// and returns the row offsets of invalid vectors and the reason of each one.
+func FindInvalidFloatVectors(data []float32, dim int, checkZero bool) ([]int, []VectorValidity) {
+	if dim <= 0 {
+		return nil, nil
+	}
+	var offsets []int
+	var reasons []VectorValidity
+	rows := len(data) / dim
+	for i := 0; i < rows; i++ {
+		validity := CheckFloatVector(data[i*dim:(i+1)*dim], checkZero)
+		if validity != ValidVector {
+			offsets = append(offsets, i)
+			reasons = append(reasons, validity)
+		}
+	}
+	return offsets, reasons
+}
@@ -0,0 +1,52 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFloatVector(t *testing.T) {
+	assert.Equal(t, ValidVector, CheckFloatVector([]float32{1, 2, 3}, true))
+	assert.Equal(t, NaNVector, CheckFloatVector([]float32{1, float32(math.NaN()), 3}, true))
+	assert.Equal(t, InfVector, CheckFloatVector([]float32{float32(math.Inf(-1)), 2, 3}, true))
+	assert.Equal(t, ZeroVector, CheckFloatVector([]float32{0, 0, 0}, true))
+	assert.Equal(t, ValidVector, CheckFloatVector([]float32{0, 0, 0}, false))
+	assert.Equal(t, "nan", NaNVector.String())
+}
+
+func TestFindInvalidFloatVectors(t *testing.T) {
+	data := []float32{
+		1, 2,
+		float32(math.NaN()), 1,
+		0, 0,
+		3, float32(math.Inf(1)),
+	}
+	offsets, reasons := FindInvalidFloatVectors(data, 2, true)
+	assert.Equal(t, []int{1, 2, 3}, offsets)
+	assert.Equal(t, []VectorValidity{NaNVector, ZeroVector, InfVector}, reasons)
+
+	offsets, reasons = FindInvalidFloatVectors(data, 2, false)
+	assert.Equal(t, []int{1, 3}, offsets)
+	assert.Equal(t, []VectorValidity{NaNVector, InfVector}, reasons)
+
+	offsets, _ = FindInvalidFloatVectors(data, 0, true)
+	assert.Nil(t, offsets)
+}
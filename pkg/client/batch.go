@@ -0,0 +1,261 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+)
+
+// defaultBatchBytes is the default max size of a batch, which is far below the max message size of the proxy,
+// to keep the inserted messages small.
+const defaultBatchBytes = 64 * 1024 * 1024
+
+// BatchOptions limits the batches of InsertInBatches, a batch carries one row at least.
+type BatchOptions struct {
+	// MaxRows is the max rows of a batch, zero means no limit
+	MaxRows int
+	// MaxBytes is the max estimated size of a batch, defaults to 64MB
+	MaxBytes int
+}
+
+// BatchError is the error of InsertInBatches failed in the middle, the rows before Offset have been inserted.
+type BatchError struct {
+	Offset int
+	Err    error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("failed to insert batch from row %d: %s", e.Offset, e.Err.Error())
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// InsertInBatches inserts the rows of @req by batches limited by @opts, the batches are inserted in order.
+// It returns the merged result of the inserted batches, and a BatchError if a batch fails,
+// then the caller could resume from its offset.
+func (c *Client) InsertInBatches(ctx context.Context, req *milvuspb.InsertRequest, opts BatchOptions) (*milvuspb.MutationResult, error) {
+	rows, err := insertRows(req.GetFieldsData())
+	if err != nil {
+		return nil, err
+	}
+	batchRows := batchRowsOf(req, rows, opts)
+
+	merged := &milvuspb.MutationResult{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}}
+	for start := 0; start < rows; start += batchRows {
+		end := start + batchRows
+		if end > rows {
+			end = rows
+		}
+		batch, err := sliceInsertRequest(req, start, end)
+		if err != nil {
+			return merged, err
+		}
+		result, err := c.Insert(ctx, batch)
+		if err != nil {
+			return merged, &BatchError{Offset: start, Err: err}
+		}
+		mergeMutationResult(merged, result, start)
+	}
+	return merged, nil
+}
+
+// batchRowsOf returns the rows per batch of @req with @rows in total, by the average size of the rows.
+func batchRowsOf(req *milvuspb.InsertRequest, rows int, opts BatchOptions) int {
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultBatchBytes
+	}
+	batchRows := rows
+	if rows > 0 {
+		rowBytes := 0
+		for _, field := range req.GetFieldsData() {
+			rowBytes += proto.Size(field)
+		}
+		if rowBytes = rowBytes / rows; rowBytes > 0 && maxBytes/rowBytes < batchRows {
+			batchRows = maxBytes / rowBytes
+		}
+	}
+	if opts.MaxRows > 0 && opts.MaxRows < batchRows {
+		batchRows = opts.MaxRows
+	}
+	if batchRows < 1 {
+		batchRows = 1
+	}
+	return batchRows
+}
+
+// insertRows returns the rows of @fields, which must be the same for all the fields.
+func insertRows(fields []*schemapb.FieldData) (int, error) {
+	if len(fields) == 0 {
+		return 0, errors.New("no field data to insert")
+	}
+	rows := -1
+	for _, field := range fields {
+		n, err := fieldRows(field)
+		if err != nil {
+			return 0, err
+		}
+		if rows >= 0 && n != rows {
+			return 0, fmt.Errorf("field %s has %d rows, but the others have %d rows", field.GetFieldName(), n, rows)
+		}
+		rows = n
+	}
+	return rows, nil
+}
+
+func fieldRows(field *schemapb.FieldData) (int, error) {
+	switch data := field.GetField().(type) {
+	case *schemapb.FieldData_Scalars:
+		switch scalars := data.Scalars.GetData().(type) {
+		case *schemapb.ScalarField_BoolData:
+			return len(scalars.BoolData.GetData()), nil
+		case *schemapb.ScalarField_IntData:
+			return len(scalars.IntData.GetData()), nil
+		case *schemapb.ScalarField_LongData:
+			return len(scalars.LongData.GetData()), nil
+		case *schemapb.ScalarField_FloatData:
+			return len(scalars.FloatData.GetData()), nil
+		case *schemapb.ScalarField_DoubleData:
+			return len(scalars.DoubleData.GetData()), nil
+		case *schemapb.ScalarField_StringData:
+			return len(scalars.StringData.GetData()), nil
+		case *schemapb.ScalarField_BytesData:
+			return len(scalars.BytesData.GetData()), nil
+		}
+	case *schemapb.FieldData_Vectors:
+		dim := int(data.Vectors.GetDim())
+		if dim <= 0 {
+			return 0, fmt.Errorf("invalid dim %d of field %s", dim, field.GetFieldName())
+		}
+		switch vectors := data.Vectors.GetData().(type) {
+		case *schemapb.VectorField_FloatVector:
+			return len(vectors.FloatVector.GetData()) / dim, nil
+		case *schemapb.VectorField_BinaryVector:
+			return len(vectors.BinaryVector) * 8 / dim, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported data of field %s", field.GetFieldName())
+}
+
+// sliceInsertRequest returns the request inserting the rows [start, end) of @req,
+// the hash keys are left to the proxy to compute.
+func sliceInsertRequest(req *milvuspb.InsertRequest, start, end int) (*milvuspb.InsertRequest, error) {
+	fields := make([]*schemapb.FieldData, 0, len(req.GetFieldsData()))
+	for _, field := range req.GetFieldsData() {
+		sliced, err := sliceFieldData(field, start, end)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, sliced)
+	}
+	return &milvuspb.InsertRequest{
+		Base:           req.GetBase(),
+		DbName:         req.GetDbName(),
+		CollectionName: req.GetCollectionName(),
+		PartitionName:  req.GetPartitionName(),
+		FieldsData:     fields,
+		NumRows:        uint32(end - start),
+	}, nil
+}
+
+// sliceFieldData returns the rows [start, end) of @field, sharing the underlying arrays of it.
+func sliceFieldData(field *schemapb.FieldData, start, end int) (*schemapb.FieldData, error) {
+	sliced := &schemapb.FieldData{
+		Type:      field.GetType(),
+		FieldName: field.GetFieldName(),
+		FieldId:   field.GetFieldId(),
+	}
+	switch data := field.GetField().(type) {
+	case *schemapb.FieldData_Scalars:
+		scalars := &schemapb.ScalarField{}
+		switch src := data.Scalars.GetData().(type) {
+		case *schemapb.ScalarField_BoolData:
+			scalars.Data = &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: src.BoolData.GetData()[start:end]}}
+		case *schemapb.ScalarField_IntData:
+			scalars.Data = &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: src.IntData.GetData()[start:end]}}
+		case *schemapb.ScalarField_LongData:
+			scalars.Data = &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: src.LongData.GetData()[start:end]}}
+		case *schemapb.ScalarField_FloatData:
+			scalars.Data = &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: src.FloatData.GetData()[start:end]}}
+		case *schemapb.ScalarField_DoubleData:
+			scalars.Data = &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: src.DoubleData.GetData()[start:end]}}
+		case *schemapb.ScalarField_StringData:
+			scalars.Data = &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: src.StringData.GetData()[start:end]}}
+		case *schemapb.ScalarField_BytesData:
+			scalars.Data = &schemapb.ScalarField_BytesData{BytesData: &schemapb.BytesArray{Data: src.BytesData.GetData()[start:end]}}
+		default:
+			return nil, fmt.Errorf("unsupported scalar data of field %s", field.GetFieldName())
+		}
+		sliced.Field = &schemapb.FieldData_Scalars{Scalars: scalars}
+	case *schemapb.FieldData_Vectors:
+		dim := int(data.Vectors.GetDim())
+		vectors := &schemapb.VectorField{Dim: data.Vectors.GetDim()}
+		switch src := data.Vectors.GetData().(type) {
+		case *schemapb.VectorField_FloatVector:
+			vectors.Data = &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{
+				Data: src.FloatVector.GetData()[start*dim : end*dim],
+			}}
+		case *schemapb.VectorField_BinaryVector:
+			bytesPerRow := dim / 8
+			vectors.Data = &schemapb.VectorField_BinaryVector{BinaryVector: src.BinaryVector[start*bytesPerRow : end*bytesPerRow]}
+		default:
+			return nil, fmt.Errorf("unsupported vector data of field %s", field.GetFieldName())
+		}
+		sliced.Field = &schemapb.FieldData_Vectors{Vectors: vectors}
+	default:
+		return nil, fmt.Errorf("unsupported data of field %s", field.GetFieldName())
+	}
+	return sliced, nil
+}
+
+// mergeMutationResult appends @result of the batch starting from row @offset to @merged.
+func mergeMutationResult(merged, result *milvuspb.MutationResult, offset int) {
+	merged.InsertCnt += result.GetInsertCnt()
+	merged.Timestamp = result.GetTimestamp()
+	for _, idx := range result.GetSuccIndex() {
+		merged.SuccIndex = append(merged.SuccIndex, idx+uint32(offset))
+	}
+	for _, idx := range result.GetErrIndex() {
+		merged.ErrIndex = append(merged.ErrIndex, idx+uint32(offset))
+	}
+
+	switch ids := result.GetIDs().GetIdField().(type) {
+	case *schemapb.IDs_IntId:
+		if merged.IDs == nil {
+			merged.IDs = &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{}}}
+		}
+		if dst := merged.IDs.GetIntId(); dst != nil {
+			dst.Data = append(dst.Data, ids.IntId.GetData()...)
+		}
+	case *schemapb.IDs_StrId:
+		if merged.IDs == nil {
+			merged.IDs = &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{}}}
+		}
+		if dst := merged.IDs.GetStrId(); dst != nil {
+			dst.Data = append(dst.Data, ids.StrId.GetData()...)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInsertRequest(rows int) *milvuspb.InsertRequest {
+	pks := make([]int64, rows)
+	names := make([]string, rows)
+	floatVectors := make([]float32, rows*4)
+	binaryVectors := make([]byte, rows*2)
+	for i := 0; i < rows; i++ {
+		pks[i] = int64(i)
+		names[i] = string(rune('a' + i))
+		for j := 0; j < 4; j++ {
+			floatVectors[i*4+j] = float32(i)
+		}
+		binaryVectors[i*2], binaryVectors[i*2+1] = byte(i), byte(i)
+	}
+	return &milvuspb.InsertRequest{
+		CollectionName: "test",
+		NumRows:        uint32(rows),
+		FieldsData: []*schemapb.FieldData{
+			{
+				Type:      schemapb.DataType_Int64,
+				FieldName: "pk",
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+				}},
+			},
+			{
+				Type:      schemapb.DataType_VarChar,
+				FieldName: "name",
+				Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+					Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: names}},
+				}},
+			},
+			{
+				Type:      schemapb.DataType_FloatVector,
+				FieldName: "float_vector",
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+					Dim:  4,
+					Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: floatVectors}},
+				}},
+			},
+			{
+				Type:      schemapb.DataType_BinaryVector,
+				FieldName: "binary_vector",
+				Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+					Dim:  16,
+					Data: &schemapb.VectorField_BinaryVector{BinaryVector: binaryVectors},
+				}},
+			},
+		},
+	}
+}
+
+func TestSliceInsertRequest(t *testing.T) {
+	req := newTestInsertRequest(5)
+	rows, err := insertRows(req.GetFieldsData())
+	require.NoError(t, err)
+	assert.Equal(t, 5, rows)
+
+	batch, err := sliceInsertRequest(req, 2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), batch.GetNumRows())
+	assert.Equal(t, []int64{2, 3}, batch.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	assert.Equal(t, []string{"c", "d"}, batch.GetFieldsData()[1].GetScalars().GetStringData().GetData())
+	assert.Equal(t, []float32{2, 2, 2, 2, 3, 3, 3, 3}, batch.GetFieldsData()[2].GetVectors().GetFloatVector().GetData())
+	assert.Equal(t, []byte{2, 2, 3, 3}, batch.GetFieldsData()[3].GetVectors().GetBinaryVector())
+
+	req.GetFieldsData()[1].GetScalars().GetStringData().Data = []string{"a"}
+	_, err = insertRows(req.GetFieldsData())
+	assert.Error(t, err)
+	_, err = insertRows(nil)
+	assert.Error(t, err)
+}
+
+func TestBatchRowsOf(t *testing.T) {
+	req := newTestInsertRequest(10)
+	assert.Equal(t, 10, batchRowsOf(req, 10, BatchOptions{}))
+	assert.Equal(t, 3, batchRowsOf(req, 10, BatchOptions{MaxRows: 3}))
+	assert.Equal(t, 1, batchRowsOf(req, 10, BatchOptions{MaxBytes: 1}))
+}
+
+func TestClient_InsertInBatches(t *testing.T) {
+	proxy := &mockProxy{}
+	c := startMockProxy(t, Config{}, proxy)
+	ctx := context.Background()
+
+	result, err := c.InsertInBatches(ctx, newTestInsertRequest(10), BatchOptions{MaxRows: 4})
+	require.NoError(t, err)
+	assert.Len(t, proxy.inserted, 3)
+	assert.Equal(t, int64(10), result.GetInsertCnt())
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, result.GetIDs().GetIntId().GetData())
+	assert.Equal(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, result.GetSuccIndex())
+
+	// the rate limited batch is retried, and the failed one reports its offset
+	proxy.inserted = nil
+	proxy.failures = []error{
+		nil,
+		&StatusError{Code: commonpb.ErrorCode_RateLimit},
+		&StatusError{Code: commonpb.ErrorCode_IllegalArgument},
+	}
+	result, err = c.InsertInBatches(ctx, newTestInsertRequest(10), BatchOptions{MaxRows: 4})
+	var batchErr *BatchError
+	require.True(t, errors.As(err, &batchErr))
+	assert.Equal(t, 4, batchErr.Offset)
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, ErrorCode(err))
+	assert.Equal(t, int64(4), result.GetInsertCnt())
+}
@@ -0,0 +1,339 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client is the Go client of Milvus speaking the proto of the proxy, the requests are retried on the
+// transient failures, see IsRetriable.
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	defaultDialTimeout      = 5 * time.Second
+	defaultKeepaliveTime    = 10 * time.Second
+	defaultKeepaliveTimeout = 20 * time.Second
+	defaultMaxRetries       = 3
+	defaultRetryBackoff     = 100 * time.Millisecond
+	defaultMaxRetryBackoff  = 3 * time.Second
+	// defaultMaxMsgSize is the max request and response size, which is the same with the proxy's default
+	defaultMaxMsgSize = 512 * 1024 * 1024
+)
+
+// authorizationHeader is the metadata key of the credentials verified by the proxy.
+const authorizationHeader = "authorization"
+
+// Config is the config of the client, the zero values are replaced by the defaults.
+type Config struct {
+	// Address is the host:port of the proxy
+	Address string
+	// Username and Password are required if the authorization of Milvus is enabled
+	Username string
+	Password string
+
+	// DialTimeout is how long NewClient waits for the connection, defaults to 5s
+	DialTimeout time.Duration
+	// KeepaliveTime is the interval of the keepalive pings, defaults to 10s
+	KeepaliveTime time.Duration
+
+	// MaxRetries is the max retries of a request failed retriably, defaults to 3, negative disables the retries
+	MaxRetries int
+	// RetryBackoff is the backoff before the first retry, doubled per retry, defaults to 100ms
+	RetryBackoff time.Duration
+	// MaxRetryBackoff caps the backoff, defaults to 3s
+	MaxRetryBackoff time.Duration
+
+	// AcceptSpilledResults makes Query accept the results too large to respond, which are spilled to the object
+	// storage by the proxy, and downloads them from the presigned urls, which must be reachable from the client
+	AcceptSpilledResults bool
+}
+
+func (c *Config) fillDefaults() {
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = defaultDialTimeout
+	}
+	if c.KeepaliveTime <= 0 {
+		c.KeepaliveTime = defaultKeepaliveTime
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = defaultMaxRetries
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = defaultRetryBackoff
+	}
+	if c.MaxRetryBackoff <= 0 {
+		c.MaxRetryBackoff = defaultMaxRetryBackoff
+	}
+}
+
+// Client is the connection to a Milvus proxy, it's safe for concurrent use.
+type Client struct {
+	cfg        Config
+	conn       *grpc.ClientConn
+	service    milvuspb.MilvusServiceClient
+	httpClient *http.Client
+}
+
+// NewClient connects to the proxy of @cfg, it fails if the proxy couldn't be connected within the dial timeout.
+// @opts are appended to the dial options of the client, e.g. grpc.WithTransportCredentials for TLS,
+// otherwise the connection is insecure.
+func NewClient(ctx context.Context, cfg Config, opts ...grpc.DialOption) (*Client, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("address of milvus is empty")
+	}
+	cfg.fillDefaults()
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(defaultMaxMsgSize),
+			grpc.MaxCallSendMsgSize(defaultMaxMsgSize)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.KeepaliveTime,
+			Timeout:             defaultKeepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  cfg.RetryBackoff,
+				Multiplier: 1.6,
+				Jitter:     0.2,
+				MaxDelay:   cfg.MaxRetryBackoff,
+			},
+			MinConnectTimeout: cfg.DialTimeout,
+		}),
+	}
+	if cfg.Username != "" {
+		dialOpts = append(dialOpts, grpc.WithUnaryInterceptor(authInterceptor(cfg.Username, cfg.Password)))
+	}
+	dialOpts = append(dialOpts, opts...)
+
+	dialCtx, cancel := context.WithTimeout(ctx, cfg.DialTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(dialCtx, cfg.Address, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect milvus %s: %w", cfg.Address, err)
+	}
+	return &Client{
+		cfg:        cfg,
+		conn:       conn,
+		service:    milvuspb.NewMilvusServiceClient(conn),
+		httpClient: &http.Client{},
+	}, nil
+}
+
+// authInterceptor attaches the credentials to the requests, which are verified by the proxy.
+func authInterceptor(username, password string) grpc.UnaryClientInterceptor {
+	token := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, authorizationHeader, token)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// Service returns the raw service client, for the requests not wrapped by Client,
+// which are neither retried nor have their statuses converted to errors.
+func (c *Client) Service() milvuspb.MilvusServiceClient {
+	return c.service
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// retry calls @fn until it succeeds or fails not retriably, the backoff is doubled with jitter per retry.
+// The mutations are retried as well, as the retriable statuses are responded before the data is written.
+func (c *Client) retry(ctx context.Context, fn func() error) error {
+	backoff := c.cfg.RetryBackoff
+	for retries := 0; ; retries++ {
+		err := fn()
+		if err == nil || !IsRetriable(err) || retries >= c.cfg.MaxRetries {
+			return err
+		}
+		// sleep for [backoff/2, backoff) to spread the retries of the concurrent requests
+		sleep := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(sleep):
+		}
+		if backoff *= 2; backoff > c.cfg.MaxRetryBackoff {
+			backoff = c.cfg.MaxRetryBackoff
+		}
+	}
+}
+
+// CreateCollection creates a collection.
+func (c *Client) CreateCollection(ctx context.Context, req *milvuspb.CreateCollectionRequest) error {
+	return c.retry(ctx, func() error {
+		s, err := c.service.CreateCollection(ctx, req)
+		if err != nil {
+			return err
+		}
+		return statusErr(s)
+	})
+}
+
+// DropCollection drops a collection.
+func (c *Client) DropCollection(ctx context.Context, collection string) error {
+	return c.retry(ctx, func() error {
+		s, err := c.service.DropCollection(ctx, &milvuspb.DropCollectionRequest{CollectionName: collection})
+		if err != nil {
+			return err
+		}
+		return statusErr(s)
+	})
+}
+
+// HasCollection returns whether the collection exists.
+func (c *Client) HasCollection(ctx context.Context, collection string) (bool, error) {
+	var has bool
+	err := c.retry(ctx, func() error {
+		resp, err := c.service.HasCollection(ctx, &milvuspb.HasCollectionRequest{CollectionName: collection})
+		if err != nil {
+			return err
+		}
+		has = resp.GetValue()
+		return statusErr(resp.GetStatus())
+	})
+	return has, err
+}
+
+// DescribeCollection returns the schema and properties of a collection.
+func (c *Client) DescribeCollection(ctx context.Context, collection string) (*milvuspb.DescribeCollectionResponse, error) {
+	var resp *milvuspb.DescribeCollectionResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.service.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{CollectionName: collection})
+		if err != nil {
+			return err
+		}
+		return statusErr(resp.GetStatus())
+	})
+	return resp, err
+}
+
+// LoadCollection loads a collection, it returns once the loading starts.
+func (c *Client) LoadCollection(ctx context.Context, collection string, replicas int32) error {
+	return c.retry(ctx, func() error {
+		s, err := c.service.LoadCollection(ctx, &milvuspb.LoadCollectionRequest{
+			CollectionName: collection,
+			ReplicaNumber:  replicas,
+		})
+		if err != nil {
+			return err
+		}
+		return statusErr(s)
+	})
+}
+
+// Insert inserts the rows of @req, see InsertInBatches to insert the rows more than a request could carry.
+func (c *Client) Insert(ctx context.Context, req *milvuspb.InsertRequest) (*milvuspb.MutationResult, error) {
+	return c.mutate(ctx, func() (*milvuspb.MutationResult, error) {
+		return c.service.Insert(ctx, req)
+	})
+}
+
+// Delete deletes the entities matching the expression of @req.
+func (c *Client) Delete(ctx context.Context, req *milvuspb.DeleteRequest) (*milvuspb.MutationResult, error) {
+	return c.mutate(ctx, func() (*milvuspb.MutationResult, error) {
+		return c.service.Delete(ctx, req)
+	})
+}
+
+func (c *Client) mutate(ctx context.Context, fn func() (*milvuspb.MutationResult, error)) (*milvuspb.MutationResult, error) {
+	var result *milvuspb.MutationResult
+	err := c.retry(ctx, func() (err error) {
+		result, err = fn()
+		if err != nil {
+			return err
+		}
+		return statusErr(result.GetStatus())
+	})
+	return result, err
+}
+
+// Search searches the vectors of @req.
+func (c *Client) Search(ctx context.Context, req *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
+	var results *milvuspb.SearchResults
+	err := c.retry(ctx, func() (err error) {
+		results, err = c.service.Search(ctx, req)
+		if err != nil {
+			return err
+		}
+		return statusErr(results.GetStatus())
+	})
+	return results, err
+}
+
+// Query returns the entities matching the expression of @req.
+func (c *Client) Query(ctx context.Context, req *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+	if c.cfg.AcceptSpilledResults {
+		req = withSpillResult(req)
+	}
+	var results *milvuspb.QueryResults
+	var header metadata.MD
+	err := c.retry(ctx, func() (err error) {
+		results, err = c.service.Query(ctx, req, grpc.Header(&header))
+		if err != nil {
+			return err
+		}
+		return statusErr(results.GetStatus())
+	})
+	if err != nil {
+		return results, err
+	}
+	return c.downloadSpilledResult(ctx, header, results)
+}
+
+// Flush seals the growing segments of the collections, and returns the sealed segments of each collection.
+func (c *Client) Flush(ctx context.Context, collections ...string) (*milvuspb.FlushResponse, error) {
+	var resp *milvuspb.FlushResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.service.Flush(ctx, &milvuspb.FlushRequest{CollectionNames: collections})
+		if err != nil {
+			return err
+		}
+		return statusErr(resp.GetStatus())
+	})
+	return resp, err
+}
+
+// GetComponentStates returns the state of the proxy, it's not retried to report the failures at once.
+func (c *Client) GetComponentStates(ctx context.Context) (commonpb.StateCode, error) {
+	resp, err := c.service.GetComponentStates(ctx, &milvuspb.GetComponentStatesRequest{})
+	if err != nil {
+		return commonpb.StateCode_Abnormal, err
+	}
+	if err := statusErr(resp.GetStatus()); err != nil {
+		return commonpb.StateCode_Abnormal, err
+	}
+	return resp.GetState().GetStateCode(), nil
+}
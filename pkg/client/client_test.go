@@ -0,0 +1,223 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/schemapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type mockProxy struct {
+	milvuspb.UnimplementedMilvusServiceServer
+
+	mu sync.Mutex
+	// failures are the errors returned by the next requests
+	failures []error
+	tokens   []string
+	inserted []*milvuspb.InsertRequest
+	// spilledURL and spilledChecksum are responded to the queries accepting spilled results
+	spilledURL      string
+	spilledChecksum string
+}
+
+func (m *mockProxy) nextFailure(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	md, _ := metadata.FromIncomingContext(ctx)
+	m.tokens = append(m.tokens, md.Get(authorizationHeader)...)
+	if len(m.failures) == 0 {
+		return nil
+	}
+	err := m.failures[0]
+	m.failures = m.failures[1:]
+	return err
+}
+
+func failureStatus(err error) (*commonpb.Status, error) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return &commonpb.Status{ErrorCode: statusErr.Code, Reason: statusErr.Reason}, nil
+	}
+	return nil, err
+}
+
+func (m *mockProxy) HasCollection(ctx context.Context, req *milvuspb.HasCollectionRequest) (*milvuspb.BoolResponse, error) {
+	if err := m.nextFailure(ctx); err != nil {
+		s, err := failureStatus(err)
+		return &milvuspb.BoolResponse{Status: s}, err
+	}
+	return &milvuspb.BoolResponse{
+		Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		Value:  req.GetCollectionName() == "exist",
+	}, nil
+}
+
+func (m *mockProxy) Insert(ctx context.Context, req *milvuspb.InsertRequest) (*milvuspb.MutationResult, error) {
+	if err := m.nextFailure(ctx); err != nil {
+		s, err := failureStatus(err)
+		return &milvuspb.MutationResult{Status: s}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inserted = append(m.inserted, req)
+	pks := req.GetFieldsData()[0].GetScalars().GetLongData().GetData()
+	succ := make([]uint32, len(pks))
+	for i := range succ {
+		succ[i] = uint32(i)
+	}
+	return &milvuspb.MutationResult{
+		Status:    &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		IDs:       &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+		SuccIndex: succ,
+		InsertCnt: int64(len(pks)),
+	}, nil
+}
+
+func (m *mockProxy) Query(ctx context.Context, req *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+	if err := m.nextFailure(ctx); err != nil {
+		s, err := failureStatus(err)
+		return &milvuspb.QueryResults{Status: s}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	accepted := false
+	for _, param := range req.GetQueryParams() {
+		accepted = accepted || (param.GetKey() == spillResultKey && param.GetValue() == "true")
+	}
+	if accepted && m.spilledURL != "" {
+		grpc.SetHeader(ctx, metadata.Pairs(queryResultURLHeader, m.spilledURL, queryResultSHA256Header, m.spilledChecksum))
+		return &milvuspb.QueryResults{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}}, nil
+	}
+	return &milvuspb.QueryResults{Status: &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success}, CollectionName: "inline"}, nil
+}
+
+func startMockProxy(t *testing.T, cfg Config, proxy *mockProxy) *Client {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	milvuspb.RegisterMilvusServiceServer(server, proxy)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	cfg.Address = "bufnet"
+	cfg.RetryBackoff = time.Millisecond
+	c, err := NewClient(context.Background(), cfg, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestIsRetriable(t *testing.T) {
+	assert.False(t, IsRetriable(nil))
+	assert.True(t, IsRetriable(&StatusError{Code: commonpb.ErrorCode_RateLimit}))
+	assert.True(t, IsRetriable(&StatusError{Code: commonpb.ErrorCode_NotShardLeader}))
+	assert.False(t, IsRetriable(&StatusError{Code: commonpb.ErrorCode_IllegalArgument}))
+	assert.True(t, IsRetriable(status.Error(codes.Unavailable, "unavailable")))
+	assert.False(t, IsRetriable(status.Error(codes.InvalidArgument, "invalid")))
+	assert.False(t, IsRetriable(context.DeadlineExceeded))
+	assert.False(t, IsRetriable(errors.New("unknown")))
+
+	assert.Equal(t, commonpb.ErrorCode_Success, ErrorCode(nil))
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, ErrorCode(&BatchError{Err: &StatusError{Code: commonpb.ErrorCode_RateLimit}}))
+	assert.Equal(t, commonpb.ErrorCode_UnexpectedError, ErrorCode(errors.New("unknown")))
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(context.Background(), Config{})
+	assert.Error(t, err)
+}
+
+func TestClient_Retry(t *testing.T) {
+	proxy := &mockProxy{failures: []error{
+		&StatusError{Code: commonpb.ErrorCode_RateLimit},
+		status.Error(codes.Unavailable, "unavailable"),
+	}}
+	c := startMockProxy(t, Config{Username: "root", Password: "Milvus"}, proxy)
+	ctx := context.Background()
+
+	has, err := c.HasCollection(ctx, "exist")
+	require.NoError(t, err)
+	assert.True(t, has)
+	token := base64.StdEncoding.EncodeToString([]byte("root:Milvus"))
+	assert.Equal(t, []string{token, token, token}, proxy.tokens)
+
+	proxy.failures = []error{&StatusError{Code: commonpb.ErrorCode_IllegalArgument, Reason: "invalid"}}
+	_, err = c.HasCollection(ctx, "exist")
+	assert.Equal(t, commonpb.ErrorCode_IllegalArgument, ErrorCode(err))
+
+	proxy.failures = make([]error, defaultMaxRetries+1)
+	for i := range proxy.failures {
+		proxy.failures[i] = &StatusError{Code: commonpb.ErrorCode_RateLimit}
+	}
+	_, err = c.HasCollection(ctx, "exist")
+	assert.Equal(t, commonpb.ErrorCode_RateLimit, ErrorCode(err))
+	assert.Empty(t, proxy.failures)
+}
+
+func TestClient_QuerySpilledResult(t *testing.T) {
+	spilled, err := proto.Marshal(&milvuspb.QueryResults{
+		Status:         &commonpb.Status{ErrorCode: commonpb.ErrorCode_Success},
+		CollectionName: "spilled",
+	})
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(spilled)
+	}))
+	defer server.Close()
+	sum := sha256.Sum256(spilled)
+
+	proxy := &mockProxy{spilledURL: server.URL, spilledChecksum: hex.EncodeToString(sum[:])}
+	ctx := context.Background()
+
+	c := startMockProxy(t, Config{}, proxy)
+	results, err := c.Query(ctx, &milvuspb.QueryRequest{CollectionName: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "inline", results.GetCollectionName())
+
+	c = startMockProxy(t, Config{AcceptSpilledResults: true}, proxy)
+	req := &milvuspb.QueryRequest{CollectionName: "test"}
+	results, err = c.Query(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "spilled", results.GetCollectionName())
+	assert.Empty(t, req.GetQueryParams())
+
+	proxy.spilledChecksum = "mismatch"
+	_, err = c.Query(ctx, req)
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StatusError is the error of a request failed with a non-success status returned by Milvus.
+type StatusError struct {
+	Code   commonpb.ErrorCode
+	Reason string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("milvus error %s: %s", e.Code.String(), e.Reason)
+}
+
+// statusErr returns nil if @s is success, otherwise the StatusError of it.
+func statusErr(s *commonpb.Status) error {
+	if s.GetErrorCode() == commonpb.ErrorCode_Success {
+		return nil
+	}
+	return &StatusError{Code: s.GetErrorCode(), Reason: s.GetReason()}
+}
+
+// ErrorCode returns the error code of the StatusError wrapped by @err,
+// or ErrorCode_Success if @err is nil, or ErrorCode_UnexpectedError if it's not a StatusError.
+func ErrorCode(err error) commonpb.ErrorCode {
+	if err == nil {
+		return commonpb.ErrorCode_Success
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code
+	}
+	return commonpb.ErrorCode_UnexpectedError
+}
+
+// retriableCodes are the error codes of the transient failures, which could succeed if the request is retried,
+// e.g. the rate limited requests and the ones arrived during shard leader changes.
+var retriableCodes = map[commonpb.ErrorCode]struct{}{
+	commonpb.ErrorCode_RateLimit:          {},
+	commonpb.ErrorCode_NotShardLeader:     {},
+	commonpb.ErrorCode_NoReplicaAvailable: {},
+	commonpb.ErrorCode_DataCoordNA:        {},
+	commonpb.ErrorCode_DDRequestRace:      {},
+	commonpb.ErrorCode_NodeIDNotMatch:     {},
+}
+
+// retriableGrpcCodes are the gRPC codes of the connection and overload failures.
+var retriableGrpcCodes = map[codes.Code]struct{}{
+	codes.Unavailable:       {},
+	codes.ResourceExhausted: {},
+	codes.Aborted:           {},
+}
+
+// IsRetriable returns whether the request failed with @err could succeed if it's retried,
+// the requests failed by the caller's context are never retriable.
+func IsRetriable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		_, ok := retriableCodes[statusErr.Code]
+		return ok
+	}
+	if s, ok := status.FromError(err); ok {
+		_, ok := retriableGrpcCodes[s.Code()]
+		return ok
+	}
+	return false
+}
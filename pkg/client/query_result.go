@@ -0,0 +1,88 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/milvuspb"
+	"google.golang.org/grpc/metadata"
+)
+
+// The query param and response headers of the query results spilled to the object storage by the proxy,
+// see proxy.queryResultSpill in milvus.yaml.
+const (
+	spillResultKey          = "spill_result"
+	queryResultURLHeader    = "query-result-url"
+	queryResultSHA256Header = "query-result-sha256"
+)
+
+// withSpillResult returns @req accepting the spilled result, @req itself is not modified.
+func withSpillResult(req *milvuspb.QueryRequest) *milvuspb.QueryRequest {
+	for _, param := range req.GetQueryParams() {
+		if param.GetKey() == spillResultKey {
+			return req
+		}
+	}
+	accepted := *req
+	accepted.QueryParams = append(append([]*commonpb.KeyValuePair{}, req.GetQueryParams()...),
+		&commonpb.KeyValuePair{Key: spillResultKey, Value: "true"})
+	return &accepted
+}
+
+// downloadSpilledResult returns the result spilled to the object storage if @header refers to it,
+// or @results itself otherwise.
+func (c *Client) downloadSpilledResult(ctx context.Context, header metadata.MD, results *milvuspb.QueryResults) (*milvuspb.QueryResults, error) {
+	urls := header.Get(queryResultURLHeader)
+	if len(urls) == 0 {
+		return results, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urls[0], nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download spilled query result: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download spilled query result: %s", resp.Status)
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download spilled query result: %w", err)
+	}
+	if checksums := header.Get(queryResultSHA256Header); len(checksums) > 0 {
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != checksums[0] {
+			return nil, fmt.Errorf("checksum mismatch of spilled query result %s", urls[0])
+		}
+	}
+	spilled := &milvuspb.QueryResults{}
+	if err := proto.Unmarshal(content, spilled); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spilled query result: %w", err)
+	}
+	return spilled, nil
+}
@@ -49,6 +49,7 @@ mkdir -p indexpb
 mkdir -p datapb
 mkdir -p querypb
 mkdir -p planpb
+mkdir -p storagepb
 
 mkdir -p ../../cmd/tools/migration/legacy/legacypb
 
@@ -87,6 +88,7 @@ ${protoc_opt} --go_out=plugins=grpc,paths=source_relative:./datapb data_coord.pr
 ${protoc_opt} --go_out=plugins=grpc,paths=source_relative:./querypb query_coord.proto
 ${protoc_opt} --go_out=plugins=grpc,paths=source_relative:./planpb plan.proto
 ${protoc_opt} --go_out=plugins=grpc,paths=source_relative:./segcorepb segcore.proto
+${protoc_opt} --go_out=plugins=grpc,paths=source_relative:./storagepb storage.proto
 
 ${protoc_opt} --proto_path=../../cmd/tools/migration/legacy/ \
   --go_out=plugins=grpc,paths=source_relative:../../cmd/tools/migration/legacy/legacypb legacy.proto